@@ -0,0 +1,105 @@
+package checkpoint
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/objstore"
+)
+
+var errWriteAborted = errors.New("snapshot write aborted")
+
+// S3Sink stores snapshots as objects in an S3-compatible bucket, under a key prefix.
+type S3Sink struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+var _ Sink = (*S3Sink)(nil)
+
+func NewS3Sink(endpoint string, bucket string, prefix string) (*S3Sink, error) {
+	client, err := objstore.NewClient(endpoint, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	return &S3Sink{client: client, bucket: bucket, prefix: prefix}, nil
+}
+
+func (s *S3Sink) key(name string) string {
+	return path.Join(s.prefix, name)
+}
+
+func (s *S3Sink) Target(ctx context.Context, name string) ioutil.OutputTarget {
+	return func() (io.Writer, io.Closer, ioutil.Aborter, error) {
+		pr, pw := io.Pipe()
+		w := &s3Writer{pw: pw, out: ioutil.CompressByFileType(name, pw), done: make(chan error, 1)}
+		go func() {
+			// an unknown size streams the object as a multipart upload
+			_, err := s.client.PutObject(ctx, s.bucket, s.key(name), pr, -1, minio.PutObjectOptions{})
+			_ = pr.CloseWithError(err)
+			w.done <- err
+		}()
+		return w, w, w.abort, nil
+	}
+}
+
+func (s *S3Sink) List(ctx context.Context) ([]string, error) {
+	prefix := s.prefix
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	var out []string
+	for obj := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if obj.Err != nil {
+			return nil, obj.Err
+		}
+		out = append(out, strings.TrimPrefix(obj.Key, prefix))
+	}
+	return out, nil
+}
+
+func (s *S3Sink) Delete(ctx context.Context, name string) error {
+	return s.client.RemoveObject(ctx, s.bucket, s.key(name), minio.RemoveObjectOptions{})
+}
+
+// s3Writer streams a snapshot into an upload. The upload only completes when the writer is closed.
+type s3Writer struct {
+	pw     *io.PipeWriter
+	out    io.WriteCloser
+	done   chan error
+	closed bool
+}
+
+func (w *s3Writer) Write(p []byte) (int, error) {
+	return w.out.Write(p)
+}
+
+func (w *s3Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if err := w.out.Close(); err != nil {
+		_ = w.pw.CloseWithError(err)
+		<-w.done
+		return err
+	}
+	return <-w.done
+}
+
+// abort cancels the upload, if the writer has not been closed yet.
+func (w *s3Writer) abort() {
+	if w.closed {
+		return
+	}
+	w.closed = true
+	_ = w.pw.CloseWithError(errWriteAborted)
+	<-w.done
+}
@@ -0,0 +1,156 @@
+package checkpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/cannon/serialize"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+)
+
+var ErrUnknownSinkScheme = errors.New("unknown checkpoint sink scheme")
+
+// Sink stores state snapshots under a name. Names may contain a path,
+// and their extension determines the encoding and compression of the snapshot, like serialize.Write.
+type Sink interface {
+	// Target returns the output target to write the named snapshot to.
+	// The snapshot is only stored once the writer is successfully closed.
+	Target(ctx context.Context, name string) ioutil.OutputTarget
+	// List returns the names of the stored snapshots.
+	List(ctx context.Context) ([]string, error)
+	// Delete removes the named snapshot.
+	Delete(ctx context.Context, name string) error
+}
+
+// Write serializes the snapshot x into the sink under the given name.
+func Write[X serialize.Serializable](ctx context.Context, sink Sink, name string, x X) error {
+	return serialize.WriteTo(name, x, sink.Target(ctx, name))
+}
+
+// NewSink creates a sink for the given location:
+// a local directory, s3://bucket/prefix for S3, or gs://bucket/prefix for GCS.
+// Remote sinks use the S3 API, with credentials from the AWS environment variables. GCS requires HMAC keys.
+// The endpoint overrides the default S3 or GCS endpoint, e.g. for S3-compatible object stores.
+// Returns the sink and the format of the snapshot names in the sink, from the snapshot name format nameFmt.
+func NewSink(location string, endpoint string, nameFmt string) (Sink, string, error) {
+	if !strings.Contains(location, "://") {
+		return NewFileSink(location, nameFmt, 0o755)
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid checkpoint sink %q: %w", location, err)
+	}
+	prefix := strings.TrimPrefix(u.Path, "/")
+	var sink Sink
+	switch u.Scheme {
+	case "file":
+		return NewFileSink(u.Path, nameFmt, 0o755)
+	case "s3":
+		if endpoint == "" {
+			endpoint = "s3.amazonaws.com"
+		}
+		sink, err = NewS3Sink(endpoint, u.Host, prefix)
+	case "gs":
+		if endpoint == "" {
+			endpoint = "storage.googleapis.com"
+		}
+		sink, err = NewS3Sink(endpoint, u.Host, prefix)
+	default:
+		return nil, "", fmt.Errorf("%w: %q", ErrUnknownSinkScheme, u.Scheme)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return sink, nameFmt, nil
+}
+
+// NewFileSink creates a sink for the snapshots named with nameFmt, a file path format relative to dir.
+// The directory of the sink is the directory of the snapshots, so that only it is listed to retain snapshots.
+// Returns the sink and the format of the snapshot names in it, the base name of nameFmt.
+func NewFileSink(dir string, nameFmt string, perm os.FileMode) (*FileSink, string, error) {
+	snapshotDir, err := filepath.Abs(filepath.Join(dir, filepath.Dir(nameFmt)))
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid snapshot dir: %w", err)
+	}
+	return &FileSink{Dir: snapshotDir, Perm: perm}, filepath.Base(nameFmt), nil
+}
+
+// FileSink stores snapshots as files in a local directory.
+type FileSink struct {
+	Dir  string
+	Perm os.FileMode
+}
+
+var _ Sink = (*FileSink)(nil)
+
+func (s *FileSink) Target(_ context.Context, name string) ioutil.OutputTarget {
+	path := filepath.Join(s.Dir, name)
+	return func() (io.Writer, io.Closer, ioutil.Aborter, error) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to create snapshot dir: %w", err)
+		}
+		return ioutil.ToAtomicFile(path, s.Perm)()
+	}
+}
+
+// List returns the names of the files in the directory of the sink. Subdirectories are not listed.
+func (s *FileSink) List(_ context.Context) ([]string, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			out = append(out, entry.Name())
+		}
+	}
+	return out, nil
+}
+
+func (s *FileSink) Delete(_ context.Context, name string) error {
+	return os.Remove(filepath.Join(s.Dir, name))
+}
+
+// Retain garbage-collects the snapshots in the sink that match nameFmt, the snapshot name format with a single step
+// number verb, keeping only the snapshots of the latest keep steps. Other snapshots in the sink are left untouched.
+func Retain(ctx context.Context, sink Sink, nameFmt string, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+	names, err := sink.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	type snapshot struct {
+		name string
+		step uint64
+	}
+	var snapshots []snapshot
+	for _, name := range names {
+		var step uint64
+		if n, err := fmt.Sscanf(name, nameFmt, &step); err != nil || n != 1 || fmt.Sprintf(nameFmt, step) != name {
+			continue
+		}
+		snapshots = append(snapshots, snapshot{name: name, step: step})
+	}
+	if len(snapshots) <= keep {
+		return nil
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].step < snapshots[j].step })
+	for _, s := range snapshots[:len(snapshots)-keep] {
+		if err := sink.Delete(ctx, s.name); err != nil {
+			return fmt.Errorf("failed to delete snapshot %q: %w", s.name, err)
+		}
+	}
+	return nil
+}
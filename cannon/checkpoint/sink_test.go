@@ -0,0 +1,98 @@
+package checkpoint
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSink(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	sink, nameFmt, err := NewSink("snaps", "", "state-%d.json")
+	require.NoError(t, err)
+	require.Equal(t, &FileSink{Dir: filepath.Join(wd, "snaps"), Perm: 0o755}, sink)
+	require.Equal(t, "state-%d.json", nameFmt)
+
+	sink, nameFmt, err = NewSink("file:///tmp/snaps", "", "run/state-%d.json")
+	require.NoError(t, err)
+	require.Equal(t, &FileSink{Dir: "/tmp/snaps/run", Perm: 0o755}, sink, "must store snapshots in their own directory")
+	require.Equal(t, "state-%d.json", nameFmt)
+
+	_, _, err = NewSink("ftp://example.com/snaps", "", "state-%d.json")
+	require.ErrorIs(t, err, ErrUnknownSinkScheme)
+}
+
+func TestNewFileSink(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	sink, nameFmt, err := NewFileSink("", "state-%d.json", 0o644)
+	require.NoError(t, err)
+	require.Equal(t, &FileSink{Dir: wd, Perm: 0o644}, sink)
+	require.Equal(t, "state-%d.json", nameFmt)
+
+	sink, nameFmt, err = NewFileSink("", "/tmp/snaps/state-%d.bin.gz", 0o644)
+	require.NoError(t, err)
+	require.Equal(t, &FileSink{Dir: "/tmp/snaps", Perm: 0o644}, sink)
+	require.Equal(t, "state-%d.bin.gz", nameFmt)
+}
+
+func TestFileSink(t *testing.T) {
+	ctx := context.Background()
+	sink := &FileSink{Dir: filepath.Join(t.TempDir(), "snaps"), Perm: 0o755}
+
+	names, err := sink.List(ctx)
+	require.NoError(t, err)
+	require.Empty(t, names)
+
+	writeSnapshot(t, sink, "a.bin")
+	writeSnapshot(t, sink, "b.bin")
+	writeSnapshot(t, sink, "sub/c.bin")
+	names, err = sink.List(ctx)
+	require.NoError(t, err)
+	sort.Strings(names)
+	require.Equal(t, []string{"a.bin", "b.bin"}, names, "must only list the sink directory")
+
+	require.NoError(t, sink.Delete(ctx, "b.bin"))
+	names, err = sink.List(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a.bin"}, names)
+}
+
+func TestRetain(t *testing.T) {
+	ctx := context.Background()
+	sink := &FileSink{Dir: t.TempDir(), Perm: 0o755}
+	for _, name := range []string{"state-100.bin", "state-2000.bin", "state-300.bin", "state-400.bin", "state-5.bin.gz", "other.bin"} {
+		writeSnapshot(t, sink, name)
+	}
+	// snapshots in subdirectories are not part of the sink
+	writeSnapshot(t, sink, "sub/state-1.bin")
+
+	require.NoError(t, Retain(ctx, sink, "state-%d.bin", 2))
+	names, err := sink.List(ctx)
+	require.NoError(t, err)
+	sort.Strings(names)
+	require.Equal(t, []string{"other.bin", "state-2000.bin", "state-400.bin", "state-5.bin.gz"}, names)
+
+	// a non-positive keep disables retention
+	require.NoError(t, Retain(ctx, sink, "state-%d.bin", 0))
+	names, err = sink.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, names, 4)
+	_, err = os.Stat(filepath.Join(sink.Dir, "sub/state-1.bin"))
+	require.NoError(t, err)
+}
+
+func writeSnapshot(t *testing.T, sink Sink, name string) {
+	w, c, _, err := sink.Target(context.Background(), name)()
+	require.NoError(t, err)
+	_, err = w.Write([]byte(name))
+	require.NoError(t, err)
+	require.NoError(t, c.Close())
+	_, err = os.Stat(filepath.Join(sink.(*FileSink).Dir, name))
+	require.NoError(t, err)
+}
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+)
+
+const maxBacktraceFrames = 64
+
+// logBacktrace logs a best-effort symbolized backtrace of the guest, for runs that failed without debug mode.
+func logBacktrace(l log.Logger, state mipsevm.FPVMState, meta mipsevm.Metadata) {
+	l.Error("Guest backtrace", "step", state.GetStep(), "code", state.GetExitCode())
+	for i, frame := range exec.ScanBacktrace(state.GetPC(), state.GetRegistersRef(), state.GetMemory(), meta, maxBacktraceFrames) {
+		l.Error("Guest frame", "frame", i, "pc", frame.PC, "name", frame.Name)
+	}
+}
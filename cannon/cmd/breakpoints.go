@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+)
+
+// pcBreakpoint breaks at the given hit of a PC. A zero hit count breaks on every hit.
+type pcBreakpoint struct {
+	onHit uint64
+	hits  uint64
+}
+
+// watchpoint breaks when the aligned memory word at addr changes, optionally only when it changes to value.
+type watchpoint struct {
+	addr     uint32
+	value    uint32
+	hasValue bool
+	last     uint32
+}
+
+// Breakpoints checks the VM state against the breakpoints and watchpoints configured for cannon run.
+type Breakpoints struct {
+	pcs        map[uint32]*pcBreakpoint
+	watches    []*watchpoint
+	syscalls   map[uint32]struct{}
+	anySyscall bool
+
+	// pc of the last instruction checked by BeforeStep
+	lastPC uint32
+}
+
+// NewBreakpoints parses the breakpoint specs.
+// PCs are given as "pc" or "pc@n" to break at the n-th time the PC is reached.
+// Watched addresses are given as "addr" or "addr=value" to only break when the word is set to value.
+// Syscalls are given as syscall numbers, or "any" to break at every syscall.
+func NewBreakpoints(pcs []string, watches []string, syscalls []string) (*Breakpoints, error) {
+	b := &Breakpoints{
+		pcs:      make(map[uint32]*pcBreakpoint),
+		syscalls: make(map[uint32]struct{}),
+	}
+	for _, spec := range pcs {
+		pcStr, hitStr, hasHit := strings.Cut(spec, "@")
+		pc, err := strconv.ParseUint(pcStr, 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid break pc %q: %w", spec, err)
+		}
+		bp := &pcBreakpoint{}
+		if hasHit {
+			bp.onHit, err = strconv.ParseUint(hitStr, 10, 64)
+			if err != nil || bp.onHit == 0 {
+				return nil, fmt.Errorf("invalid break pc hit count %q", spec)
+			}
+		}
+		b.pcs[uint32(pc)] = bp
+	}
+	for _, spec := range watches {
+		addrStr, valStr, hasValue := strings.Cut(spec, "=")
+		addr, err := strconv.ParseUint(addrStr, 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid watch addr %q: %w", spec, err)
+		}
+		wp := &watchpoint{addr: uint32(addr) & 0xFFFFFFFC, hasValue: hasValue}
+		if hasValue {
+			val, err := strconv.ParseUint(valStr, 0, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid watch value %q: %w", spec, err)
+			}
+			wp.value = uint32(val)
+		}
+		b.watches = append(b.watches, wp)
+	}
+	for _, spec := range syscalls {
+		if spec == "any" {
+			b.anySyscall = true
+			continue
+		}
+		num, err := strconv.ParseUint(spec, 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid break syscall %q: %w", spec, err)
+		}
+		b.syscalls[uint32(num)] = struct{}{}
+	}
+	return b, nil
+}
+
+// Init records the current values of the watched memory words.
+func (b *Breakpoints) Init(state mipsevm.FPVMState) {
+	for _, wp := range b.watches {
+		wp.last = state.GetMemory().GetMemory(wp.addr)
+	}
+}
+
+// BeforeStep checks the PC and syscall breakpoints against the instruction that is about to be executed.
+// It returns a description of the breakpoint that was hit, if any.
+func (b *Breakpoints) BeforeStep(state mipsevm.FPVMState) (string, bool) {
+	pc := state.GetPC()
+	b.lastPC = pc
+	if bp, ok := b.pcs[pc]; ok {
+		bp.hits++
+		if bp.onHit == 0 || bp.onHit == bp.hits {
+			return fmt.Sprintf("pc %08x reached (hit %d)", pc, bp.hits), true
+		}
+	}
+	if b.anySyscall || len(b.syscalls) > 0 {
+		_, opcode, fun := exec.GetInstructionDetails(pc, state.GetMemory())
+		if opcode == 0 && fun == 0xC {
+			num := state.GetRegistersRef()[2]
+			if _, ok := b.syscalls[num]; ok || b.anySyscall {
+				return fmt.Sprintf("syscall %d at pc %08x", num, pc), true
+			}
+		}
+	}
+	return "", false
+}
+
+// AfterStep checks the watchpoints against the memory after an instruction was executed.
+// It returns a description of the watchpoint that was hit, if any.
+func (b *Breakpoints) AfterStep(state mipsevm.FPVMState) (string, bool) {
+	for _, wp := range b.watches {
+		v := state.GetMemory().GetMemory(wp.addr)
+		if v == wp.last {
+			continue
+		}
+		prev := wp.last
+		wp.last = v
+		if !wp.hasValue || v == wp.value {
+			return fmt.Sprintf("memory %08x changed from %08x to %08x by pc %08x", wp.addr, prev, v, b.lastPC), true
+		}
+	}
+	return "", false
+}
+
+// LastPC returns the PC of the last instruction checked by BeforeStep.
+func (b *Breakpoints) LastPC() uint32 {
+	return b.lastPC
+}
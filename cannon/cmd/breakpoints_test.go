@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+)
+
+func TestNewBreakpointsInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		pcs      []string
+		watches  []string
+		syscalls []string
+		err      string
+	}{
+		{name: "PC", pcs: []string{"foo"}, err: "invalid break pc"},
+		{name: "ZeroHit", pcs: []string{"0x100@0"}, err: "invalid break pc hit count"},
+		{name: "Hit", pcs: []string{"0x100@x"}, err: "invalid break pc hit count"},
+		{name: "WatchAddr", watches: []string{"x"}, err: "invalid watch addr"},
+		{name: "WatchValue", watches: []string{"0x100=x"}, err: "invalid watch value"},
+		{name: "Syscall", syscalls: []string{"write"}, err: "invalid break syscall"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewBreakpoints(test.pcs, test.watches, test.syscalls)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestBreakpoints(t *testing.T) {
+	t.Run("PC", func(t *testing.T) {
+		b, err := NewBreakpoints([]string{"0x100"}, nil, nil)
+		require.NoError(t, err)
+		state := singlethreaded.CreateEmptyState()
+		_, hit := b.BeforeStep(state)
+		require.False(t, hit)
+		state.Cpu.PC = 0x100
+		desc, hit := b.BeforeStep(state)
+		require.True(t, hit)
+		require.Equal(t, "pc 00000100 reached (hit 1)", desc)
+		_, hit = b.BeforeStep(state)
+		require.True(t, hit, "must break on every hit")
+		require.Equal(t, uint32(0x100), b.LastPC())
+	})
+
+	t.Run("PCHitCount", func(t *testing.T) {
+		b, err := NewBreakpoints([]string{"0x100@3"}, nil, nil)
+		require.NoError(t, err)
+		state := singlethreaded.CreateEmptyState()
+		state.Cpu.PC = 0x100
+		for i := 0; i < 2; i++ {
+			_, hit := b.BeforeStep(state)
+			require.False(t, hit)
+		}
+		desc, hit := b.BeforeStep(state)
+		require.True(t, hit)
+		require.Equal(t, "pc 00000100 reached (hit 3)", desc)
+		_, hit = b.BeforeStep(state)
+		require.False(t, hit, "must only break at the given hit")
+	})
+
+	t.Run("Syscall", func(t *testing.T) {
+		b, err := NewBreakpoints(nil, nil, []string{"4004"})
+		require.NoError(t, err)
+		state := singlethreaded.CreateEmptyState()
+		state.Memory.SetMemory(0, 0x0000000C)
+		state.Registers[2] = exec.SysRead
+		_, hit := b.BeforeStep(state)
+		require.False(t, hit)
+		state.Registers[2] = exec.SysWrite
+		desc, hit := b.BeforeStep(state)
+		require.True(t, hit)
+		require.Equal(t, "syscall 4004 at pc 00000000", desc)
+		// Not a syscall instruction
+		state.Memory.SetMemory(0, 0x24080005)
+		_, hit = b.BeforeStep(state)
+		require.False(t, hit)
+	})
+
+	t.Run("AnySyscall", func(t *testing.T) {
+		b, err := NewBreakpoints(nil, nil, []string{"any"})
+		require.NoError(t, err)
+		state := singlethreaded.CreateEmptyState()
+		state.Memory.SetMemory(0, 0x0000000C)
+		state.Registers[2] = exec.SysRead
+		_, hit := b.BeforeStep(state)
+		require.True(t, hit)
+	})
+
+	t.Run("Watch", func(t *testing.T) {
+		b, err := NewBreakpoints(nil, []string{"0x102"}, nil)
+		require.NoError(t, err)
+		state := singlethreaded.CreateEmptyState()
+		state.Memory.SetMemory(0x100, 1)
+		b.Init(state)
+		_, hit := b.AfterStep(state)
+		require.False(t, hit)
+
+		state.Cpu.PC = 0x40
+		b.BeforeStep(state)
+		state.Memory.SetMemory(0x100, 2)
+		desc, hit := b.AfterStep(state)
+		require.True(t, hit, "must watch the aligned word")
+		require.Equal(t, "memory 00000100 changed from 00000001 to 00000002 by pc 00000040", desc)
+		_, hit = b.AfterStep(state)
+		require.False(t, hit)
+	})
+
+	t.Run("WatchValue", func(t *testing.T) {
+		b, err := NewBreakpoints(nil, []string{"0x100=0x3"}, nil)
+		require.NoError(t, err)
+		state := singlethreaded.CreateEmptyState()
+		b.Init(state)
+		state.Memory.SetMemory(0x100, 2)
+		_, hit := b.AfterStep(state)
+		require.False(t, hit, "must only break on the watched value")
+		state.Memory.SetMemory(0x100, 3)
+		desc, hit := b.AfterStep(state)
+		require.True(t, hit)
+		require.Equal(t, "memory 00000100 changed from 00000002 to 00000003 by pc 00000000", desc)
+	})
+}
@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+var (
+	GenVectorsOutFlag = &cli.PathFlag{
+		Name:     "out",
+		Usage:    "Output path to write the JSON test vectors to. Vectors are dumped to stdout if set to -.",
+		Value:    "-",
+		Required: false,
+	}
+	GenVectorsVMTypeFlag = &cli.StringSliceFlag{
+		Name:     "type",
+		Usage:    "VM types to generate test vectors for. Options are 'cannon', 'cannon-mt'",
+		Value:    cli.NewStringSlice(string(cannonVMType), string(mtVMType)),
+		Required: false,
+	}
+	GenVectorsScenarioFlag = &cli.StringSliceFlag{
+		Name:     "scenario",
+		Usage:    "names of the scenarios to generate test vectors for. All scenarios if empty.",
+		Required: false,
+	}
+)
+
+// TestVector is the proof of a single step of a scenario, with the state witness after the step.
+// The onchain VM must compute the post-state hash from the state data, proof data and pre-image oracle data.
+type TestVector struct {
+	Name   string `json:"name"`
+	VMType VMType `json:"vm"`
+	*Proof
+	PostStateData hexutil.Bytes `json:"post-state-data"`
+}
+
+const (
+	regV0 = 2
+	regA0 = 4
+	regT0 = 8
+	regT1 = 9
+	regT2 = 10
+)
+
+// encodeR encodes an R-type instruction
+func encodeR(fun, rs, rt, rd uint32) uint32 {
+	return rs<<21 | rt<<16 | rd<<11 | fun
+}
+
+// encodeI encodes an I-type instruction
+func encodeI(opcode, rs, rt uint32, imm uint16) uint32 {
+	return opcode<<26 | rs<<21 | rt<<16 | uint32(imm)
+}
+
+const syscallInsn = 0x0000000c
+
+// vectorPreimage is served by the pre-image oracle of the test vectors
+var vectorPreimage = []byte("cannon test vector pre-image")
+
+func vectorPreimageKey() [32]byte {
+	return preimage.Keccak256Key(crypto.Keccak256Hash(vectorPreimage)).PreimageKey()
+}
+
+type vectorOracle struct{}
+
+func (vectorOracle) Hint(v []byte) {}
+
+func (vectorOracle) GetPreimage(k [32]byte) []byte {
+	if k != vectorPreimageKey() {
+		panic(fmt.Errorf("unknown pre-image %x", k))
+	}
+	return vectorPreimage
+}
+
+// vectorScenario sets up the state for the instruction at the PC of an empty state.
+type vectorScenario struct {
+	name string
+	// vmTypes restricts the scenario to the given VM types, it applies to all VM types if empty
+	vmTypes []VMType
+	setup   func(state mipsevm.FPVMState)
+}
+
+func setInsn(state mipsevm.FPVMState, insn uint32) {
+	state.GetMemory().SetMemory(state.GetPC(), insn)
+}
+
+func setSyscall(state mipsevm.FPVMState, num uint32, args ...uint32) {
+	setInsn(state, syscallInsn)
+	regs := state.GetRegistersRef()
+	regs[regV0] = num
+	for i, arg := range args {
+		regs[regA0+i] = arg
+	}
+}
+
+func setPreimageKey(state mipsevm.FPVMState, key [32]byte, offset uint32) {
+	switch state := state.(type) {
+	case *singlethreaded.State:
+		state.PreimageKey, state.PreimageOffset = key, offset
+	case *multithreaded.State:
+		state.PreimageKey, state.PreimageOffset = key, offset
+	}
+}
+
+var vectorScenarios = []vectorScenario{
+	{name: "addu", setup: func(state mipsevm.FPVMState) {
+		setInsn(state, encodeR(0x21, regT0, regT1, regT2))
+		state.GetRegistersRef()[regT0] = 0xFFFF_FFFF
+		state.GetRegistersRef()[regT1] = 2
+	}},
+	{name: "lw", setup: func(state mipsevm.FPVMState) {
+		setInsn(state, encodeI(0x23, regT0, regT1, 4))
+		state.GetRegistersRef()[regT0] = 0x1000
+		state.GetMemory().SetMemory(0x1004, 0xDEAD_BEEF)
+	}},
+	{name: "sw", setup: func(state mipsevm.FPVMState) {
+		setInsn(state, encodeI(0x2b, regT0, regT1, 8))
+		state.GetRegistersRef()[regT0] = 0x2000
+		state.GetRegistersRef()[regT1] = 0xCAFE_BABE
+	}},
+	{name: "beq-taken", setup: func(state mipsevm.FPVMState) {
+		setInsn(state, encodeI(0x04, regT0, regT1, 0x10))
+		state.GetRegistersRef()[regT0] = 7
+		state.GetRegistersRef()[regT1] = 7
+	}},
+	{name: "mmap-anonymous", setup: func(state mipsevm.FPVMState) {
+		setSyscall(state, exec.SysMmap, 0, 0x1001)
+	}},
+	{name: "mmap-heap-limit", setup: func(state mipsevm.FPVMState) {
+		setSyscall(state, exec.SysMmap, 0, program.HEAP_END)
+	}},
+	{name: "mmap-stack-overlap", setup: func(state mipsevm.FPVMState) {
+		setSyscall(state, exec.SysMmap, program.STACK_LIMIT-0x1000, 0x2000)
+	}},
+	{name: "brk-query", setup: func(state mipsevm.FPVMState) {
+		setSyscall(state, exec.SysBrk, 0)
+	}},
+	{name: "brk-move", setup: func(state mipsevm.FPVMState) {
+		setSyscall(state, exec.SysBrk, program.PROGRAM_BREAK+0x1000)
+	}},
+	{name: "fcntl-getfl", setup: func(state mipsevm.FPVMState) {
+		setSyscall(state, exec.SysFcntl, exec.FdStdout, exec.FcntlGetFl)
+	}},
+	{name: "fstat-stdout", setup: func(state mipsevm.FPVMState) {
+		setSyscall(state, exec.SysFstat64, exec.FdStdout, 0x3000)
+	}},
+	{name: "write-stdout", setup: func(state mipsevm.FPVMState) {
+		setSyscall(state, exec.SysWrite, exec.FdStdout, 0x3000, 5)
+	}},
+	{name: "read-preimage", setup: func(state mipsevm.FPVMState) {
+		setSyscall(state, exec.SysRead, exec.FdPreimageRead, 0x4000, 4)
+		setPreimageKey(state, vectorPreimageKey(), 8)
+	}},
+	{name: "exit-group", setup: func(state mipsevm.FPVMState) {
+		setSyscall(state, exec.SysExitGroup, 3)
+	}},
+	{name: "clone", vmTypes: []VMType{mtVMType}, setup: func(state mipsevm.FPVMState) {
+		setSyscall(state, exec.SysClone, exec.ValidCloneFlags, 0x7000_0000)
+	}},
+	{name: "futex-wake", vmTypes: []VMType{mtVMType}, setup: func(state mipsevm.FPVMState) {
+		setSyscall(state, exec.SysFutex, 0x5000, exec.FutexWakePrivate, 1)
+	}},
+	{name: "timer-create", vmTypes: []VMType{mtVMType}, setup: func(state mipsevm.FPVMState) {
+		setSyscall(state, exec.SysTimerCreate, exec.ClockGettimeThreadCPUTimeFlag, 0x6000, 0x6100)
+	}},
+	{name: "sched-yield", vmTypes: []VMType{mtVMType}, setup: func(state mipsevm.FPVMState) {
+		setSyscall(state, exec.SysSchedYield)
+	}},
+}
+
+// genVector executes the first step of the scenario on a fresh state of the VM type.
+func genVector(scenario vectorScenario, vmType VMType) (*TestVector, error) {
+	var state mipsevm.FPVMState
+	switch vmType {
+	case cannonVMType:
+		state = singlethreaded.CreateEmptyState()
+	case mtVMType:
+		state = multithreaded.CreateEmptyState()
+	default:
+		return nil, fmt.Errorf("invalid VM type: %q", vmType)
+	}
+	scenario.setup(state)
+	step := state.GetStep()
+	vm := state.CreateVM(log.NewLogger(log.DiscardHandler()), vectorOracle{}, io.Discard, io.Discard, nil)
+	witness, err := vm.Step(true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to step scenario %q on %v: %w", scenario.name, vmType, err)
+	}
+	postState, postHash := state.EncodeWitness()
+	return &TestVector{
+		Name:          scenario.name,
+		VMType:        vmType,
+		Proof:         NewProof(step, witness, postHash),
+		PostStateData: postState,
+	}, nil
+}
+
+func GenVectors(ctx *cli.Context) error {
+	var vmTypes []VMType
+	for _, typ := range ctx.StringSlice(GenVectorsVMTypeFlag.Name) {
+		if typ != string(cannonVMType) && typ != string(mtVMType) {
+			return fmt.Errorf("unknown VM type %q", typ)
+		}
+		vmTypes = append(vmTypes, VMType(typ))
+	}
+	names := ctx.StringSlice(GenVectorsScenarioFlag.Name)
+	for _, name := range names {
+		if !slices.ContainsFunc(vectorScenarios, func(s vectorScenario) bool { return s.name == name }) {
+			return fmt.Errorf("unknown scenario %q", name)
+		}
+	}
+
+	vectors := []*TestVector{}
+	for _, scenario := range vectorScenarios {
+		if len(names) > 0 && !slices.Contains(names, scenario.name) {
+			continue
+		}
+		for _, vmType := range vmTypes {
+			if len(scenario.vmTypes) > 0 && !slices.Contains(scenario.vmTypes, vmType) {
+				continue
+			}
+			vector, err := genVector(scenario, vmType)
+			if err != nil {
+				return err
+			}
+			vectors = append(vectors, vector)
+		}
+	}
+	return jsonutil.WriteJSON(vectors, ioutil.ToStdOutOrFileOrNoop(ctx.Path(GenVectorsOutFlag.Name), OutFilePerm))
+}
+
+var GenVectorsCommand = &cli.Command{
+	Name:  "gen-vectors",
+	Usage: "Generate JSON test vectors for the onchain MIPS VMs",
+	Description: "Execute a curated set of instruction and syscall scenarios, and write the pre-state, step proof " +
+		"and expected post-state witness of each scenario, for the MIPS.sol and MIPS2.sol tests.",
+	Action: GenVectors,
+	Flags: []cli.Flag{
+		GenVectorsOutFlag,
+		GenVectorsVMTypeFlag,
+		GenVectorsScenarioFlag,
+	},
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+func vectorStateHash(t *testing.T, vmType VMType, witness []byte) common.Hash {
+	var hash common.Hash
+	var err error
+	switch vmType {
+	case cannonVMType:
+		hash, err = singlethreaded.StateWitness(witness).StateHash()
+	case mtVMType:
+		hash, err = multithreaded.StateWitness(witness).StateHash()
+	default:
+		t.Fatalf("unknown VM type %v", vmType)
+	}
+	require.NoError(t, err)
+	return hash
+}
+
+func TestGenVector(t *testing.T) {
+	names := make(map[string]struct{})
+	for _, scenario := range vectorScenarios {
+		scenario := scenario
+		require.NotContains(t, names, scenario.name, "scenario names must be unique")
+		names[scenario.name] = struct{}{}
+		for _, vmType := range []VMType{cannonVMType, mtVMType} {
+			vmType := vmType
+			if len(scenario.vmTypes) > 0 && !slices.Contains(scenario.vmTypes, vmType) {
+				continue
+			}
+			t.Run(string(vmType)+"/"+scenario.name, func(t *testing.T) {
+				vector, err := genVector(scenario, vmType)
+				require.NoError(t, err)
+				require.Equal(t, scenario.name, vector.Name)
+				require.Equal(t, vmType, vector.VMType)
+				require.Zero(t, vector.Step, "must prove the first step")
+				require.NotEmpty(t, vector.ProofData)
+				require.Equal(t, vectorStateHash(t, vmType, vector.StateData), vector.Pre, "pre-state hash must match the state data")
+				require.Equal(t, vectorStateHash(t, vmType, vector.PostStateData), vector.Post, "post-state hash must match the post-state data")
+				require.NotEqual(t, vector.Pre, vector.Post)
+				if scenario.name == "read-preimage" {
+					key := vectorPreimageKey()
+					require.Equal(t, key[:], []byte(vector.OracleKey))
+					require.NotEmpty(t, vector.OracleValue)
+				} else {
+					require.Empty(t, vector.OracleKey, "only pre-image reads need oracle data")
+				}
+			})
+		}
+	}
+
+	t.Run("UnknownVMType", func(t *testing.T) {
+		_, err := genVector(vectorScenarios[0], VMType("asterisc"))
+		require.ErrorContains(t, err, "invalid VM type")
+	})
+}
+
+func TestGenVectors(t *testing.T) {
+	app := cli.NewApp()
+	app.Commands = []*cli.Command{GenVectorsCommand}
+	genVectors := func(t *testing.T, args ...string) []*TestVector {
+		out := filepath.Join(t.TempDir(), "vectors.json")
+		require.NoError(t, app.Run(append([]string{"cannon", "gen-vectors", "--out", out}, args...)))
+		vectors, err := jsonutil.LoadJSON[[]*TestVector](out)
+		require.NoError(t, err)
+		return *vectors
+	}
+
+	t.Run("All", func(t *testing.T) {
+		vectors := genVectors(t)
+		mtOnly := 0
+		for _, scenario := range vectorScenarios {
+			if len(scenario.vmTypes) > 0 {
+				mtOnly++
+			}
+		}
+		require.Len(t, vectors, 2*len(vectorScenarios)-mtOnly)
+	})
+
+	t.Run("Filtered", func(t *testing.T) {
+		vectors := genVectors(t, "--type", "cannon", "--scenario", "addu", "--scenario", "clone")
+		require.Len(t, vectors, 1, "must skip scenarios of other VM types")
+		require.Equal(t, "addu", vectors[0].Name)
+		require.Equal(t, cannonVMType, vectors[0].VMType)
+	})
+
+	t.Run("UnknownVMType", func(t *testing.T) {
+		err := app.Run([]string{"cannon", "gen-vectors", "--type", "asterisc"})
+		require.ErrorContains(t, err, `unknown VM type "asterisc"`)
+	})
+
+	t.Run("UnknownScenario", func(t *testing.T) {
+		err := app.Run([]string{"cannon", "gen-vectors", "--scenario", "foo"})
+		require.ErrorContains(t, err, `unknown scenario "foo"`)
+	})
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+)
+
+// introspectionTimeout bounds how long a request waits for the VM to reach the next step.
+// The VM does not step while it is blocked on the pre-image server.
+const introspectionTimeout = 5 * time.Second
+
+// IntrospectionServer serves the live thread state of a running multithreaded VM as JSON.
+// The VM is only inspected in between steps: requests are handed to the run loop, which answers them with Poll.
+type IntrospectionServer struct {
+	log      log.Logger
+	vm       *multithreaded.InstrumentedState
+	requests chan chan *multithreaded.ThreadsInfo
+	srv      *http.Server
+	listener net.Listener
+}
+
+func StartIntrospectionServer(l log.Logger, addr string, vm *multithreaded.InstrumentedState) (*IntrospectionServer, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %v: %w", addr, err)
+	}
+	s := &IntrospectionServer{
+		log:      l,
+		vm:       vm,
+		requests: make(chan chan *multithreaded.ThreadsInfo),
+		listener: listener,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/threads", s.handleThreads)
+	s.srv = &http.Server{Handler: mux, ReadHeaderTimeout: introspectionTimeout}
+	go func() {
+		if err := s.srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			l.Error("Introspection server failed", "err", err)
+		}
+	}()
+	l.Info("Started thread introspection server", "addr", listener.Addr().String())
+	return s, nil
+}
+
+// Addr returns the address the server is listening on.
+func (s *IntrospectionServer) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+func (s *IntrospectionServer) handleThreads(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), introspectionTimeout)
+	defer cancel()
+	reply := make(chan *multithreaded.ThreadsInfo, 1)
+	select {
+	case s.requests <- reply:
+	case <-ctx.Done():
+		http.Error(w, "VM did not reach the next step in time", http.StatusServiceUnavailable)
+		return
+	}
+	info := <-reply
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(info); err != nil {
+		s.log.Warn("Failed to write thread state", "err", err)
+	}
+}
+
+// Poll answers pending requests with the current thread state. It must be called by the run loop, in between steps.
+func (s *IntrospectionServer) Poll() {
+	select {
+	case reply := <-s.requests:
+		reply <- s.vm.ThreadsInfo()
+	default:
+	}
+}
+
+func (s *IntrospectionServer) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return s.srv.Shutdown(ctx)
+}
@@ -3,8 +3,14 @@ package cmd
 import (
 	"debug/elf"
 	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
 	"github.com/ethereum-optimism/optimism/cannon/serialize"
@@ -31,16 +37,62 @@ var (
 	}
 	LoadELFPatchFlag = &cli.StringSliceFlag{
 		Name:     "patch",
-		Usage:    "Type of patching to do",
-		Value:    cli.NewStringSlice("go", "stack"),
+		Usage:    "Patch sets to apply, in order. Options are 'go-runtime', 'stack-init', or 'none' to load the ELF unpatched",
+		Value:    cli.NewStringSlice(string(program.PatchSetGoRuntime), string(program.PatchSetStackInit)),
 		Required: false,
 	}
+	LoadELFPatchReportFlag = &cli.PathFlag{
+		Name:      "patch-report",
+		Usage:     "Output path to write the JSON list of the applied patch sets and the symbols they patched to. Use - to write to Stdout. Not written if empty.",
+		TakesFile: true,
+		Required:  false,
+	}
 	LoadELFOutFlag = &cli.PathFlag{
 		Name:     "out",
 		Usage:    "Output path to write JSON state to. State is dumped to stdout if set to -. Not written if empty.",
 		Value:    "state.json",
 		Required: false,
 	}
+	LoadELFFPUFlag = &cli.BoolFlag{
+		Name:     "fpu",
+		Usage:    "Enable FPU emulation. Only supported by the 'cannon' VM type, and FPU states can't be proven onchain.",
+		Required: false,
+	}
+	LoadELFHeapStartFlag = &cli.Uint64Flag{
+		Name:     "heap-start",
+		Usage:    "address of the first anonymous mapping. The program must be loaded below it.",
+		Value:    program.HEAP_START,
+		Required: false,
+	}
+	LoadELFHeapEndFlag = &cli.Uint64Flag{
+		Name:     "heap-end",
+		Usage:    "end of the anonymous mappings",
+		Value:    program.HEAP_END,
+		Required: false,
+	}
+	LoadELFProgramBreakFlag = &cli.Uint64Flag{
+		Name:     "program-break",
+		Usage:    "fixed program break, reported by brk",
+		Value:    program.PROGRAM_BREAK,
+		Required: false,
+	}
+	LoadELFStackPointerFlag = &cli.Uint64Flag{
+		Name:     "stack-pointer",
+		Usage:    "initial stack pointer, used by the stack patch",
+		Value:    program.STACK_POINTER,
+		Required: false,
+	}
+	LoadELFStackLimitFlag = &cli.Uint64Flag{
+		Name:     "stack-limit",
+		Usage:    "lowest address of the stack region. Mappings at fixed addresses must end below it.",
+		Value:    program.STACK_LIMIT,
+		Required: false,
+	}
+	LoadELFMaxMemoryFlag = &cli.Uint64Flag{
+		Name:     "max-memory",
+		Usage:    "maximum amount of memory the program may allocate, in bytes. 0 is unlimited.",
+		Required: false,
+	}
 	LoadELFMetaFlag = &cli.PathFlag{
 		Name:     "meta",
 		Usage:    "Write metadata file, for symbol lookup during program execution. None if empty.",
@@ -66,22 +118,93 @@ func vmTypeFromString(ctx *cli.Context) (VMType, error) {
 	}
 }
 
+// layoutFromFlags returns the memory layout configured by the flags, or nil if it's the default layout.
+func layoutFromFlags(ctx *cli.Context) (*mipsevm.Layout, error) {
+	addr := func(flag *cli.Uint64Flag) (uint32, error) {
+		v := ctx.Uint64(flag.Name)
+		if v > math.MaxUint32 {
+			return 0, fmt.Errorf("--%s 0x%x is out of the 32-bit address space", flag.Name, v)
+		}
+		return uint32(v), nil
+	}
+	var layout mipsevm.Layout
+	var err error
+	if layout.HeapStart, err = addr(LoadELFHeapStartFlag); err != nil {
+		return nil, err
+	}
+	if layout.HeapEnd, err = addr(LoadELFHeapEndFlag); err != nil {
+		return nil, err
+	}
+	if layout.ProgramBreak, err = addr(LoadELFProgramBreakFlag); err != nil {
+		return nil, err
+	}
+	if layout.StackPointer, err = addr(LoadELFStackPointerFlag); err != nil {
+		return nil, err
+	}
+	if layout.StackLimit, err = addr(LoadELFStackLimitFlag); err != nil {
+		return nil, err
+	}
+	layout.MaxMemory = ctx.Uint64(LoadELFMaxMemoryFlag.Name)
+	if err := layout.Check(); err != nil {
+		return nil, fmt.Errorf("invalid memory layout: %w", err)
+	}
+	if program.IsDefaultLayout(&layout) {
+		return nil, nil
+	}
+	return &layout, nil
+}
+
+// checkELFClass verifies the ELF is a 32-bit program: all VM types are 32-bit MIPS.
+// A 64-bit MIPS ELF has the same machine type, and would otherwise be loaded incorrectly.
+func checkELFClass(f *elf.File) error {
+	if f.Class != elf.ELFCLASS32 {
+		return fmt.Errorf("ELF class %s is not supported, only %s programs can be loaded", f.Class, elf.ELFCLASS32)
+	}
+	return nil
+}
+
 func LoadELF(ctx *cli.Context) error {
+	layout, err := layoutFromFlags(ctx)
+	if err != nil {
+		return err
+	}
+	loadLayout := layout
+	if loadLayout == nil {
+		loadLayout = program.DefaultLayout()
+	}
 	var createInitialState func(f *elf.File) (mipsevm.FPVMState, error)
 
 	if vmType, err := vmTypeFromString(ctx); err != nil {
 		return err
 	} else if vmType == cannonVMType {
 		createInitialState = func(f *elf.File) (mipsevm.FPVMState, error) {
-			return program.LoadELF(f, singlethreaded.CreateInitialState)
+			state, err := program.LoadELFWithLayout(f, singlethreaded.CreateInitialState, loadLayout)
+			if err == nil {
+				state.Layout = layout
+				if ctx.Bool(LoadELFFPUFlag.Name) {
+					state.FPU = exec.NewFPUState()
+				}
+			}
+			return state, err
 		}
 	} else if vmType == mtVMType {
+		if ctx.Bool(LoadELFFPUFlag.Name) {
+			return fmt.Errorf("FPU emulation is not supported by VM type %q", vmType)
+		}
 		createInitialState = func(f *elf.File) (mipsevm.FPVMState, error) {
-			return program.LoadELF(f, multithreaded.CreateInitialState)
+			state, err := program.LoadELFWithLayout(f, multithreaded.CreateInitialState, loadLayout)
+			if err == nil {
+				state.Layout = layout
+			}
+			return state, err
 		}
 	} else {
 		return fmt.Errorf("invalid VM type: %q", vmType)
 	}
+	patchSets, err := program.ParsePatchSets(ctx.StringSlice(LoadELFPatchFlag.Name))
+	if err != nil {
+		return err
+	}
 	elfPath := ctx.Path(LoadELFPathFlag.Name)
 	elfProgram, err := elf.Open(elfPath)
 	if err != nil {
@@ -90,22 +213,31 @@ func LoadELF(ctx *cli.Context) error {
 	if elfProgram.Machine != elf.EM_MIPS {
 		return fmt.Errorf("ELF is not big-endian MIPS R3000, but got %q", elfProgram.Machine.String())
 	}
+	if err := checkELFClass(elfProgram); err != nil {
+		return err
+	}
 	state, err := createInitialState(elfProgram)
 	if err != nil {
 		return fmt.Errorf("failed to load ELF data into VM state: %w", err)
 	}
-	for _, typ := range ctx.StringSlice(LoadELFPatchFlag.Name) {
-		switch typ {
-		case "stack":
-			err = program.PatchStack(state)
-		case "go":
-			err = program.PatchGo(elfProgram, state)
-		default:
-			return fmt.Errorf("unrecognized form of patching: %q", typ)
+	results, err := program.ApplyPatches(elfProgram, state, patchSets)
+	if err != nil {
+		return err
+	}
+	l := Logger(os.Stderr, log.LevelInfo)
+	for _, result := range results {
+		if len(result.Symbols) == 0 {
+			l.Info("Applied patch set", "set", result.Set)
+			continue
 		}
-		if err != nil {
-			return fmt.Errorf("failed to apply patch %s: %w", typ, err)
+		names := make([]string, len(result.Symbols))
+		for i, sym := range result.Symbols {
+			names[i] = sym.Name
 		}
+		l.Info("Applied patch set", "set", result.Set, "symbols", len(names), "patched", strings.Join(names, ","))
+	}
+	if err := jsonutil.WriteJSON(results, ioutil.ToStdOutOrFileOrNoop(ctx.Path(LoadELFPatchReportFlag.Name), OutFilePerm)); err != nil {
+		return fmt.Errorf("failed to output patch report: %w", err)
 	}
 	meta, err := program.MakeMetadata(elfProgram)
 	if err != nil {
@@ -132,6 +264,14 @@ var LoadELFCommand = &cli.Command{
 		LoadELFVMTypeFlag,
 		LoadELFPathFlag,
 		LoadELFPatchFlag,
+		LoadELFPatchReportFlag,
+		LoadELFFPUFlag,
+		LoadELFHeapStartFlag,
+		LoadELFHeapEndFlag,
+		LoadELFProgramBreakFlag,
+		LoadELFStackPointerFlag,
+		LoadELFStackLimitFlag,
+		LoadELFMaxMemoryFlag,
 		LoadELFOutFlag,
 		LoadELFMetaFlag,
 	},
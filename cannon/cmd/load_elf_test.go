@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"debug/elf"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckELFClass(t *testing.T) {
+	require.NoError(t, checkELFClass(&elf.File{FileHeader: elf.FileHeader{Class: elf.ELFCLASS32, Machine: elf.EM_MIPS}}))
+	// 64-bit MIPS programs have the same machine type, but can't run on any of the VM types
+	require.ErrorContains(t, checkELFClass(&elf.File{FileHeader: elf.FileHeader{Class: elf.ELFCLASS64, Machine: elf.EM_MIPS}}), "not supported")
+}
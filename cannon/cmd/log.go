@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
 	"log/slog"
 	"os"
@@ -8,6 +9,8 @@ import (
 	"golang.org/x/term"
 
 	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 )
 
 func Logger(w io.Writer, lvl slog.Level) log.Logger {
@@ -38,3 +41,25 @@ func replaceAttr(_ []string, attr slog.Attr) slog.Attr {
 	}
 	return attr
 }
+
+// guestWriters creates the writers for the stdout and stderr of the program running within the VM.
+// The returned function flushes any buffered output, and must be called once the VM stopped.
+func guestWriters(mode string, guestLogger log.Logger) (stdOut io.Writer, stdErr io.Writer, flush func(), err error) {
+	outLogger := guestLogger.With("module", "guest", "stream", "stdout")
+	errLogger := guestLogger.With("module", "guest", "stream", "stderr")
+	switch mode {
+	case "log":
+		return &mipsevm.LoggingWriter{Log: outLogger}, &mipsevm.LoggingWriter{Log: errLogger}, func() {}, nil
+	case "lines", "json":
+		outLines := &mipsevm.LineLoggingWriter{Log: outLogger, JSONPassthrough: mode == "json"}
+		errLines := &mipsevm.LineLoggingWriter{Log: errLogger, JSONPassthrough: mode == "json"}
+		return outLines, errLines, func() {
+			outLines.Flush()
+			errLines.Flush()
+		}, nil
+	case "raw":
+		return os.Stdout, os.Stderr, func() {}, nil
+	default:
+		return nil, nil, nil, fmt.Errorf("unknown guest output mode %q", mode)
+	}
+}
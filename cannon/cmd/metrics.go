@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+const EnvVarPrefix = "CANNON"
+
+// metricsInterval is the number of steps between updates of the step metrics,
+// to keep the overhead of the metrics off the hot step loop.
+const metricsInterval = 10_000
+
+// RunMetrics exposes the execution throughput of a cannon run, while it is running.
+type RunMetrics struct {
+	registry *prometheus.Registry
+
+	steps       prometheus.Counter
+	stepRate    prometheus.Gauge
+	syscalls    *prometheus.CounterVec
+	preimage    prometheus.Counter
+	merkleize   prometheus.Histogram
+	pendingStep uint64
+	lastUpdate  time.Time
+
+	// preimageRead is set if the observed step is a read from the pre-image channel
+	preimageRead bool
+}
+
+var _ opmetrics.RegistryMetricer = (*RunMetrics)(nil)
+
+func NewRunMetrics() *RunMetrics {
+	registry := opmetrics.NewRegistry()
+	factory := opmetrics.With(registry)
+	return &RunMetrics{
+		registry: registry,
+		steps: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "steps_total",
+			Help:      "Number of steps executed",
+		}),
+		stepRate: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "steps_per_second",
+			Help:      "Number of steps executed per second, over the last metrics interval",
+		}),
+		syscalls: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "syscalls_total",
+			Help:      "Number of syscalls executed, by syscall number",
+		}, []string{"syscall"}),
+		preimage: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "preimage_read_bytes_total",
+			Help:      "Number of bytes read from the pre-image channel by the program",
+		}),
+		merkleize: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "merkleization_seconds",
+			Help:      "Time spent computing the state witness and memory proofs, for snapshots and proofs",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 8),
+		}),
+		lastUpdate: time.Now(),
+	}
+}
+
+func (m *RunMetrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// BeforeStep records the state before the next instruction is executed.
+func (m *RunMetrics) BeforeStep(state mipsevm.FPVMState) {
+	m.pendingStep++
+	if m.pendingStep >= metricsInterval {
+		m.flushSteps()
+	}
+	_, opcode, fun := exec.GetInstructionDetails(state.GetPC(), state.GetMemory())
+	if opcode == 0 && fun == 0xC {
+		syscallNum, a0, _, _, _ := exec.GetSyscallArgs(state.GetRegistersRef())
+		m.syscalls.WithLabelValues(strconv.FormatUint(uint64(syscallNum), 10)).Inc()
+		m.preimageRead = syscallNum == exec.SysRead && a0 == exec.FdPreimageRead
+	}
+}
+
+// AfterStep records the result of the executed instruction.
+func (m *RunMetrics) AfterStep(state mipsevm.FPVMState) {
+	if !m.preimageRead {
+		return
+	}
+	m.preimageRead = false
+	if n := state.GetRegistersRef()[2]; n != exec.SysErrorSignal {
+		m.preimage.Add(float64(n))
+	}
+}
+
+// RecordMerkleization records the time spent computing a state witness or step proof.
+func (m *RunMetrics) RecordMerkleization(d time.Duration) {
+	m.merkleize.Observe(d.Seconds())
+}
+
+// Flush updates the step metrics with the steps that were not recorded yet.
+func (m *RunMetrics) Flush() {
+	m.flushSteps()
+}
+
+func (m *RunMetrics) flushSteps() {
+	now := time.Now()
+	if elapsed := now.Sub(m.lastUpdate); elapsed > 0 {
+		m.stepRate.Set(float64(m.pendingStep) / elapsed.Seconds())
+	}
+	m.steps.Add(float64(m.pendingStep))
+	m.pendingStep = 0
+	m.lastUpdate = now
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+)
+
+func TestRunMetricsSteps(t *testing.T) {
+	m := NewRunMetrics()
+	state := singlethreaded.CreateEmptyState()
+	for i := 0; i < metricsInterval-1; i++ {
+		m.BeforeStep(state)
+	}
+	require.Zero(t, testutil.ToFloat64(m.steps), "must only update the step metrics every interval")
+	m.BeforeStep(state)
+	require.Equal(t, float64(metricsInterval), testutil.ToFloat64(m.steps))
+	require.Positive(t, testutil.ToFloat64(m.stepRate))
+
+	m.BeforeStep(state)
+	m.BeforeStep(state)
+	m.Flush()
+	require.Equal(t, float64(metricsInterval+2), testutil.ToFloat64(m.steps))
+	m.Flush()
+	require.Equal(t, float64(metricsInterval+2), testutil.ToFloat64(m.steps), "must only record the steps once")
+}
+
+func TestRunMetricsSyscalls(t *testing.T) {
+	m := NewRunMetrics()
+	state := singlethreaded.CreateEmptyState()
+	m.BeforeStep(state)
+	require.Zero(t, testutil.CollectAndCount(m.syscalls), "must not count other instructions")
+
+	state.Memory.SetMemory(0, 0x0000000C)
+	state.Registers[2] = exec.SysWrite
+	state.Registers[4] = exec.FdStdout
+	m.BeforeStep(state)
+	m.AfterStep(state)
+	m.BeforeStep(state)
+	m.AfterStep(state)
+	state.Registers[2] = exec.SysBrk
+	m.BeforeStep(state)
+	m.AfterStep(state)
+	require.Equal(t, float64(2), testutil.ToFloat64(m.syscalls.WithLabelValues("4004")))
+	require.Equal(t, float64(1), testutil.ToFloat64(m.syscalls.WithLabelValues("4045")))
+	require.Zero(t, testutil.ToFloat64(m.preimage), "must only count pre-image reads")
+}
+
+func TestRunMetricsPreimageRead(t *testing.T) {
+	m := NewRunMetrics()
+	state := singlethreaded.CreateEmptyState()
+	state.Memory.SetMemory(0, 0x0000000C)
+	read := func(result uint32) {
+		state.Registers[2] = exec.SysRead
+		state.Registers[4] = exec.FdPreimageRead
+		m.BeforeStep(state)
+		state.Registers[2] = result
+		m.AfterStep(state)
+	}
+	read(8)
+	read(4)
+	require.Equal(t, float64(12), testutil.ToFloat64(m.preimage))
+	read(exec.SysErrorSignal)
+	require.Equal(t, float64(12), testutil.ToFloat64(m.preimage), "must not count failed reads")
+
+	// a following step must not be counted as a read
+	state.Memory.SetMemory(0, 0)
+	m.BeforeStep(state)
+	state.Registers[2] = 16
+	m.AfterStep(state)
+	require.Equal(t, float64(12), testutil.ToFloat64(m.preimage))
+}
+
+func TestRunMetricsMerkleization(t *testing.T) {
+	m := NewRunMetrics()
+	m.RecordMerkleization(5 * time.Millisecond)
+	m.RecordMerkleization(time.Second)
+	require.Equal(t, 1, testutil.CollectAndCount(m.merkleize))
+	families, err := m.Registry().Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() == metricsNamespace+"_merkleization_seconds" {
+			require.Equal(t, uint64(2), family.GetMetric()[0].GetHistogram().GetSampleCount())
+			return
+		}
+	}
+	t.Fatal("merkleization histogram not registered")
+}
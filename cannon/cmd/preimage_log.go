@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+)
+
+// PreimageLogEntry is a single pre-image access of the program.
+// Hints and requests are logged when the VM sends them to the pre-image oracle, before the oracle responds,
+// so that the last entry of the log identifies the pre-image the oracle failed to serve.
+type PreimageLogEntry struct {
+	Step  uint64 `json:"step"`
+	Event string `json:"event"` // one of "hint", "request" or "read"
+
+	Hint string        `json:"hint,omitempty"`
+	Key  hexutil.Bytes `json:"key,omitempty"`
+
+	Offset *uint32 `json:"offset,omitempty"`
+	Length *uint32 `json:"length,omitempty"`
+	// Size is the size of the pre-image, excluding the 8-byte length prefix
+	Size *uint64 `json:"size,omitempty"`
+}
+
+// PreimageLog wraps the pre-image oracle of a VM, to write all hints, pre-image requests and pre-image reads
+// of the program to a JSONL audit log.
+type PreimageLog struct {
+	oracle mipsevm.PreimageOracle
+	w      *bufio.Writer
+	enc    *json.Encoder
+	err    error
+
+	step uint64
+}
+
+var _ mipsevm.PreimageOracle = (*PreimageLog)(nil)
+
+func NewPreimageLog(oracle mipsevm.PreimageOracle, w io.Writer) *PreimageLog {
+	bw := bufio.NewWriter(w)
+	return &PreimageLog{oracle: oracle, w: bw, enc: json.NewEncoder(bw)}
+}
+
+func (p *PreimageLog) Hint(v []byte) {
+	p.write(&PreimageLogEntry{Step: p.step, Event: "hint", Hint: string(v)})
+	p.oracle.Hint(v)
+}
+
+func (p *PreimageLog) GetPreimage(k [32]byte) []byte {
+	p.write(&PreimageLogEntry{Step: p.step, Event: "request", Key: k[:]})
+	return p.oracle.GetPreimage(k)
+}
+
+// BeforeStep records the step that is about to be executed, to attribute the oracle calls of the step to it.
+func (p *PreimageLog) BeforeStep(state mipsevm.FPVMState) {
+	p.step = state.GetStep()
+}
+
+// AfterStep logs the pre-image read of the executed step, if any.
+func (p *PreimageLog) AfterStep(state mipsevm.FPVMState, vm mipsevm.FPVM) {
+	key, value, offset := vm.LastPreimage()
+	if offset == ^uint32(0) {
+		return
+	}
+	length := state.GetRegistersRef()[2]
+	if length == exec.SysErrorSignal {
+		length = 0
+	}
+	size := uint64(len(value))
+	if size >= 8 {
+		size -= 8
+	}
+	p.write(&PreimageLogEntry{Step: p.step, Event: "read", Key: key[:], Offset: &offset, Length: &length, Size: &size})
+}
+
+func (p *PreimageLog) write(entry *PreimageLogEntry) {
+	if p.err != nil {
+		return
+	}
+	if err := p.enc.Encode(entry); err != nil {
+		p.err = err
+	}
+}
+
+// Flush writes the buffered entries, and returns the first error that occurred while writing the log.
+func (p *PreimageLog) Flush() error {
+	if p.err != nil {
+		return fmt.Errorf("failed to write pre-image log: %w", p.err)
+	}
+	return p.w.Flush()
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+)
+
+func decodePreimageLog(t *testing.T, data []byte) []PreimageLogEntry {
+	var entries []PreimageLogEntry
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var entry PreimageLogEntry
+		if err := dec.Decode(&entry); errors.Is(err, io.EOF) {
+			return entries
+		} else {
+			require.NoError(t, err)
+		}
+		entries = append(entries, entry)
+	}
+}
+
+func TestPreimageLog(t *testing.T) {
+	key := [32]byte{0x02, 0x01}
+	value := []byte("hello world")
+	po := &stubOracle{preimages: map[[32]byte][]byte{key: value}}
+	var out bytes.Buffer
+	preimageLog := NewPreimageLog(po, &out)
+
+	// read the first 4 bytes of the length prefix of the pre-image, at step 5
+	state := singlethreaded.CreateEmptyState()
+	state.Step = 5
+	state.PreimageKey = key
+	state.Memory.SetMemory(0, 0x0000000C)
+	state.Registers[2] = exec.SysRead
+	state.Registers[4] = exec.FdPreimageRead
+	state.Registers[5] = 0x4000
+	state.Registers[6] = 4
+	vm := singlethreaded.NewInstrumentedState(state, preimageLog, io.Discard, io.Discard, nil)
+
+	preimageLog.BeforeStep(state)
+	preimageLog.Hint([]byte("l2-block 0x01"))
+	_, err := vm.Step(false)
+	require.NoError(t, err)
+	preimageLog.AfterStep(state, vm)
+
+	// a step without pre-image access is not logged
+	preimageLog.BeforeStep(state)
+	_, err = vm.Step(false)
+	require.NoError(t, err)
+	preimageLog.AfterStep(state, vm)
+	require.NoError(t, preimageLog.Flush())
+
+	require.Equal(t, []string{"l2-block 0x01"}, po.Hints(), "must forward hints to the oracle")
+	offset, length, size := uint32(0), uint32(4), uint64(len(value))
+	require.Equal(t, []PreimageLogEntry{
+		{Step: 5, Event: "hint", Hint: "l2-block 0x01"},
+		{Step: 5, Event: "request", Key: key[:]},
+		{Step: 5, Event: "read", Key: key[:], Offset: &offset, Length: &length, Size: &size},
+	}, decodePreimageLog(t, out.Bytes()))
+}
+
+func TestPreimageLogFailedRequest(t *testing.T) {
+	var out bytes.Buffer
+	preimageLog := NewPreimageLog(&stubOracle{}, &out)
+	key := [32]byte{0x02, 0xaa}
+	require.Panics(t, func() {
+		preimageLog.GetPreimage(key)
+	})
+	require.NoError(t, preimageLog.Flush())
+	require.Equal(t, []PreimageLogEntry{
+		{Step: 0, Event: "request", Key: key[:]},
+	}, decodePreimageLog(t, out.Bytes()), "must log the request before the oracle fails to serve it")
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestPreimageLogWriteError(t *testing.T) {
+	// the buffered writer only fails once its buffer is full
+	preimageLog := NewPreimageLog(&stubOracle{}, failingWriter{})
+	for i := 0; i < 1000; i++ {
+		preimageLog.Hint(bytes.Repeat([]byte{'a'}, 100))
+	}
+	require.ErrorContains(t, preimageLog.Flush(), "failed to write pre-image log: disk full")
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+// A remote pre-image oracle uses two connections, one for hints and one for pre-image requests,
+// each speaking the same protocol as the file descriptors of a local pre-image server.
+// The first byte written on a connection selects the channel.
+const (
+	remoteHintChannel     = byte('h')
+	remotePreimageChannel = byte('p')
+)
+
+var (
+	ServePreimagesAddrFlag = &cli.StringFlag{
+		Name:     "addr",
+		Usage:    "address to serve pre-images on: unix:///path/to/socket, or tcp://host:port",
+		Required: true,
+	}
+)
+
+// parseOracleAddr splits a pre-image server address into the network and address to dial or listen on.
+// Addresses without a scheme are TCP addresses.
+func parseOracleAddr(addr string) (network string, address string, err error) {
+	switch {
+	case strings.HasPrefix(addr, "unix://"):
+		network, address = "unix", strings.TrimPrefix(addr, "unix://")
+	case strings.HasPrefix(addr, "tcp://"):
+		network, address = "tcp", strings.TrimPrefix(addr, "tcp://")
+	case strings.Contains(addr, "://"):
+		return "", "", fmt.Errorf("unsupported pre-image server address %q", addr)
+	default:
+		network, address = "tcp", addr
+	}
+	if address == "" {
+		return "", "", fmt.Errorf("missing pre-image server address in %q", addr)
+	}
+	return network, address, nil
+}
+
+// RemotePreimageOracle is a pre-image oracle backed by a pre-image server on another process or machine,
+// e.g. served by 'cannon serve-preimages'.
+type RemotePreimageOracle struct {
+	hintConn     net.Conn
+	preimageConn net.Conn
+	hCl          *preimage.HintWriter
+	pCl          *preimage.OracleClient
+}
+
+var _ mipsevm.PreimageOracle = (*RemotePreimageOracle)(nil)
+
+func DialPreimageOracle(ctx context.Context, addr string) (*RemotePreimageOracle, error) {
+	network, address, err := parseOracleAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+	var d net.Dialer
+	dial := func(channel byte) (net.Conn, error) {
+		conn, err := d.DialContext(ctx, network, address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to pre-image server %v: %w", addr, err)
+		}
+		if _, err := conn.Write([]byte{channel}); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("failed to open pre-image server channel: %w", err)
+		}
+		return conn, nil
+	}
+	hintConn, err := dial(remoteHintChannel)
+	if err != nil {
+		return nil, err
+	}
+	preimageConn, err := dial(remotePreimageChannel)
+	if err != nil {
+		_ = hintConn.Close()
+		return nil, err
+	}
+	return &RemotePreimageOracle{
+		hintConn:     hintConn,
+		preimageConn: preimageConn,
+		hCl:          preimage.NewHintWriter(hintConn),
+		pCl:          preimage.NewOracleClient(preimageConn),
+	}, nil
+}
+
+func (o *RemotePreimageOracle) Hint(v []byte) {
+	o.hCl.Hint(rawHint(v))
+}
+
+func (o *RemotePreimageOracle) GetPreimage(k [32]byte) []byte {
+	return o.pCl.Get(rawKey(k))
+}
+
+func (o *RemotePreimageOracle) Close() error {
+	return errors.Join(o.hintConn.Close(), o.preimageConn.Close())
+}
+
+// preimageServer serves the hints and pre-image requests of remote clients from a local oracle.
+type preimageServer struct {
+	log log.Logger
+	// mu serializes the access of all connections to the oracle
+	mu sync.Mutex
+	po mipsevm.PreimageOracle
+}
+
+func (s *preimageServer) hint(hint string) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("hint failed: %v", r)
+		}
+	}()
+	s.po.Hint([]byte(hint))
+	return nil
+}
+
+func (s *preimageServer) getPreimage(key [32]byte) (value []byte, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("pre-image request failed: %v", r)
+		}
+	}()
+	return s.po.GetPreimage(key), nil
+}
+
+func (s *preimageServer) serveConn(conn net.Conn) {
+	defer conn.Close()
+	var channel [1]byte
+	if _, err := io.ReadFull(conn, channel[:]); err != nil {
+		s.log.Warn("Failed to read pre-image server channel", "remote", conn.RemoteAddr(), "err", err)
+		return
+	}
+	var next func() error
+	switch channel[0] {
+	case remoteHintChannel:
+		r := preimage.NewHintReader(conn)
+		next = func() error { return r.NextHint(s.hint) }
+	case remotePreimageChannel:
+		r := preimage.NewOracleServer(conn)
+		next = func() error { return r.NextPreimageRequest(s.getPreimage) }
+	default:
+		s.log.Warn("Unknown pre-image server channel", "remote", conn.RemoteAddr(), "channel", channel[0])
+		return
+	}
+	s.log.Info("Serving pre-images", "remote", conn.RemoteAddr(), "channel", string(channel[:]))
+	for {
+		if err := next(); err != nil {
+			if !errors.Is(err, io.EOF) {
+				s.log.Error("Failed to serve pre-images", "remote", conn.RemoteAddr(), "err", err)
+			}
+			return
+		}
+	}
+}
+
+// Serve accepts connections until the listener is closed.
+func (s *preimageServer) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go s.serveConn(conn)
+	}
+}
+
+func ServePreimages(ctx *cli.Context) error {
+	l := Logger(os.Stderr, log.LevelInfo).With("module", "preimage-server")
+	network, address, err := parseOracleAddr(ctx.String(ServePreimagesAddrFlag.Name))
+	if err != nil {
+		return err
+	}
+	args := preimageServerArgs(ctx)
+	if args[0] == "" {
+		return errors.New("the pre-image server command must be specified after '--'")
+	}
+	po, err := NewProcessPreimageOracle(args[0], args[1:], l.With("module", "host"), l.With("module", "host"))
+	if err != nil {
+		return fmt.Errorf("failed to create pre-image oracle process: %w", err)
+	}
+	if err := po.Start(); err != nil {
+		return fmt.Errorf("failed to start pre-image oracle server: %w", err)
+	}
+	defer func() {
+		if err := po.Close(); err != nil {
+			l.Error("failed to close pre-image server", "err", err)
+		}
+	}()
+
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %v: %w", address, err)
+	}
+	go func() {
+		<-ctx.Context.Done()
+		_ = listener.Close()
+	}()
+	l.Info("Serving pre-images", "addr", listener.Addr().String())
+	srv := &preimageServer{log: l, po: po}
+	if err := srv.Serve(listener); err != nil {
+		return err
+	}
+	return ctx.Context.Err()
+}
+
+var ServePreimagesCommand = &cli.Command{
+	Name:  "serve-preimages",
+	Usage: "Serve pre-images to cannon runs on other processes or machines",
+	Description: "Start the pre-image server from the args after '--', and serve its hints and pre-images on the given address. " +
+		"Cannon runs use the server with --preimage-server-addr.",
+	Action: ServePreimages,
+	Flags: []cli.Flag{
+		ServePreimagesAddrFlag,
+	},
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestParseOracleAddr(t *testing.T) {
+	tests := []struct {
+		addr    string
+		network string
+		address string
+		err     string
+	}{
+		{addr: "unix:///tmp/preimages.sock", network: "unix", address: "/tmp/preimages.sock"},
+		{addr: "tcp://localhost:8000", network: "tcp", address: "localhost:8000"},
+		{addr: "localhost:8000", network: "tcp", address: "localhost:8000"},
+		{addr: "http://localhost:8000", err: "unsupported pre-image server address"},
+		{addr: "unix://", err: "missing pre-image server address"},
+		{addr: "", err: "missing pre-image server address"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.addr, func(t *testing.T) {
+			network, address, err := parseOracleAddr(test.addr)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.network, network)
+			require.Equal(t, test.address, address)
+		})
+	}
+}
+
+type stubOracle struct {
+	mu        sync.Mutex
+	hints     []string
+	preimages map[[32]byte][]byte
+}
+
+func (o *stubOracle) Hint(v []byte) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.hints = append(o.hints, string(v))
+}
+
+func (o *stubOracle) GetPreimage(k [32]byte) []byte {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	v, ok := o.preimages[k]
+	if !ok {
+		panic("unknown pre-image")
+	}
+	return v
+}
+
+func (o *stubOracle) Hints() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]string(nil), o.hints...)
+}
+
+func TestRemotePreimageOracle(t *testing.T) {
+	key := [32]byte{0x02, 0x01}
+	value := []byte("hello world")
+
+	serve := func(t *testing.T, network, address string) (*stubOracle, string) {
+		listener, err := net.Listen(network, address)
+		require.NoError(t, err)
+		po := &stubOracle{preimages: map[[32]byte][]byte{key: value}}
+		srv := &preimageServer{log: testlog.Logger(t, log.LevelInfo), po: po}
+		done := make(chan error, 1)
+		go func() {
+			done <- srv.Serve(listener)
+		}()
+		t.Cleanup(func() {
+			require.NoError(t, listener.Close())
+			require.NoError(t, <-done, "must stop serving once the listener is closed")
+		})
+		return po, network + "://" + listener.Addr().String()
+	}
+
+	check := func(t *testing.T, po *stubOracle, addr string) {
+		remote, err := DialPreimageOracle(context.Background(), addr)
+		require.NoError(t, err)
+		remote.Hint([]byte("l2-block 0x01"))
+		remote.Hint([]byte("l2-state 0x02"))
+		require.Equal(t, []string{"l2-block 0x01", "l2-state 0x02"}, po.Hints())
+		require.Equal(t, value, remote.GetPreimage(key))
+		require.Equal(t, value, remote.GetPreimage(key), "must serve repeated requests on the same connection")
+		require.NoError(t, remote.Close())
+	}
+
+	t.Run("TCP", func(t *testing.T) {
+		po, addr := serve(t, "tcp", "127.0.0.1:0")
+		check(t, po, addr)
+	})
+
+	t.Run("Unix", func(t *testing.T) {
+		po, addr := serve(t, "unix", filepath.Join(t.TempDir(), "preimages.sock"))
+		check(t, po, addr)
+	})
+
+	t.Run("ConcurrentClients", func(t *testing.T) {
+		po, addr := serve(t, "tcp", "127.0.0.1:0")
+		results := make(chan []byte, 4)
+		for i := 0; i < 4; i++ {
+			remote, err := DialPreimageOracle(context.Background(), addr)
+			require.NoError(t, err)
+			defer remote.Close()
+			go func() {
+				results <- remote.GetPreimage(key)
+			}()
+		}
+		for i := 0; i < 4; i++ {
+			require.Equal(t, value, <-results)
+		}
+		require.Empty(t, po.Hints())
+	})
+
+	t.Run("ServerFailure", func(t *testing.T) {
+		po := &stubOracle{}
+		srv := &preimageServer{log: testlog.Logger(t, log.LevelInfo), po: po}
+		_, err := srv.getPreimage(key)
+		require.ErrorContains(t, err, "pre-image request failed: unknown pre-image", "must not crash the server")
+	})
+
+	t.Run("Unreachable", func(t *testing.T) {
+		_, err := DialPreimageOracle(context.Background(), "unix://"+filepath.Join(t.TempDir(), "missing.sock"))
+		require.ErrorContains(t, err, "failed to connect to pre-image server")
+	})
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+)
+
+var ErrSnapshotMismatch = errors.New("snapshot does not match the expected state hash")
+
+// loadResumeState loads a snapshot to resume from, and verifies that its state witness hashes to the expected hash.
+// This refuses corrupted or mismatched snapshots, rather than silently continuing from whatever state is on disk.
+func loadResumeState(path string, expected string) (*versions.VersionedState, error) {
+	if expected == "" {
+		return nil, fmt.Errorf("resuming from %v requires the expected state hash", path)
+	}
+	b, err := hexutil.Decode(expected)
+	if err != nil || len(b) != common.HashLength {
+		return nil, fmt.Errorf("invalid expected state hash %q", expected)
+	}
+	expectedHash := common.BytesToHash(b)
+	state, err := versions.LoadStateFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load snapshot: %w", err)
+	}
+	if _, hash := state.EncodeWitness(); hash != expectedHash {
+		return nil, fmt.Errorf("%w: snapshot %v at step %d has state hash %s, expected %s",
+			ErrSnapshotMismatch, path, state.GetStep(), hash, expectedHash)
+	}
+	return state, nil
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/cannon/serialize"
+)
+
+func TestLoadResumeState(t *testing.T) {
+	state := singlethreaded.CreateInitialState(0x100, 0x1000)
+	state.Step = 42
+	state.Memory.SetMemory(0x2000, 0xaabbccdd)
+	versioned, err := versions.NewFromState(state)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "snapshot.bin.gz")
+	require.NoError(t, serialize.Write(path, versioned, 0o644))
+	_, stateHash := versioned.EncodeWitness()
+
+	t.Run("Valid", func(t *testing.T) {
+		loaded, err := loadResumeState(path, stateHash.Hex())
+		require.NoError(t, err)
+		require.Equal(t, uint64(42), loaded.GetStep())
+		_, loadedHash := loaded.EncodeWitness()
+		require.Equal(t, stateHash, loadedHash)
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		wrong := stateHash
+		wrong[31] ^= 0x01
+		_, err := loadResumeState(path, wrong.Hex())
+		require.ErrorIs(t, err, ErrSnapshotMismatch)
+		require.ErrorContains(t, err, "at step 42")
+	})
+
+	t.Run("MissingHash", func(t *testing.T) {
+		_, err := loadResumeState(path, "")
+		require.ErrorContains(t, err, "requires the expected state hash")
+	})
+
+	t.Run("InvalidHash", func(t *testing.T) {
+		for _, hash := range []string{"foo", "0x1234", stateHash.Hex()[2:]} {
+			_, err := loadResumeState(path, hash)
+			require.ErrorContains(t, err, "invalid expected state hash", hash)
+		}
+	})
+
+	t.Run("MissingSnapshot", func(t *testing.T) {
+		_, err := loadResumeState(filepath.Join(t.TempDir(), "missing.bin.gz"), stateHash.Hex())
+		require.ErrorContains(t, err, "failed to load snapshot")
+		require.NotErrorIs(t, err, ErrSnapshotMismatch)
+	})
+}
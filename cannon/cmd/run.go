@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/cannon/checkpoint"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
 	"github.com/ethereum-optimism/optimism/cannon/serialize"
 	"github.com/ethereum-optimism/optimism/op-service/ioutil"
@@ -21,9 +22,12 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	mipsexec "github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 )
 
 var (
@@ -32,7 +36,18 @@ var (
 		Usage:     "path of input JSON state. Stdin if left empty.",
 		TakesFile: true,
 		Value:     "state.json",
-		Required:  true,
+		Required:  false,
+	}
+	RunResumeFlag = &cli.PathFlag{
+		Name:      "resume",
+		Usage:     "path of a state snapshot to resume from, instead of --input. The snapshot is verified against --resume-state-hash before running.",
+		TakesFile: true,
+		Required:  false,
+	}
+	RunResumeStateHashFlag = &cli.StringFlag{
+		Name:     "resume-state-hash",
+		Usage:    "expected state witness hash of the --resume snapshot, as logged when the snapshot was written or reported by the witness command",
+		Required: false,
 	}
 	RunOutputFlag = &cli.PathFlag{
 		Name:      "output",
@@ -62,10 +77,31 @@ var (
 	}
 	RunSnapshotFmtFlag = &cli.StringFlag{
 		Name:     "snapshot-fmt",
-		Usage:    "format for snapshot output file names.",
+		Usage:    "format for snapshot output file names. Snapshots are compressed if the name ends in .gz (gzip) or .zst (zstd).",
 		Value:    "state-%d.json",
 		Required: false,
 	}
+	RunSnapshotSinkFlag = &cli.StringFlag{
+		Name: "snapshot-sink",
+		Usage: "where to store snapshots: a local directory, s3://bucket/prefix or gs://bucket/prefix. " +
+			"Snapshot names are formatted with --snapshot-fmt. Credentials are read from the AWS environment variables, GCS requires HMAC keys.",
+		Required: false,
+	}
+	RunSnapshotSinkEndpointFlag = &cli.StringFlag{
+		Name:     "snapshot-sink-endpoint",
+		Usage:    "endpoint of the snapshot sink object store, to override the default S3 or GCS endpoint",
+		Required: false,
+	}
+	RunSnapshotRetainFlag = &cli.IntFlag{
+		Name:     "snapshot-retain",
+		Usage:    "number of most recent snapshots to retain in the snapshot sink. Older snapshots matching --snapshot-fmt are deleted. 0 retains all.",
+		Required: false,
+	}
+	RunSnapshotDedupFlag = &cli.BoolFlag{
+		Name:     "snapshot-dedup",
+		Usage:    "store memory pages with identical contents only once in snapshots and the output state",
+		Required: false,
+	}
 	RunStopAtFlag = &cli.GenericFlag{
 		Name:     "stop-at",
 		Usage:    "step pattern to stop at: " + patternHelp,
@@ -87,6 +123,12 @@ var (
 		Usage:    "stop at the first step that requests a preimage larger than the specified size (in bytes)",
 		Required: false,
 	}
+	RunStopOnFlag = &cli.StringSliceFlag{
+		Name: "stop-on",
+		Usage: "stop right before an event, may be repeated: 'pc=ADDR[@n]' when the PC is reached, 'syscall=N|any' when a syscall is invoked, " +
+			"'preimage=KEY' when a preimage with the key or key prefix is first read, 'exit[=N]' when the exit code is set",
+		Required: false,
+	}
 	RunMetaFlag = &cli.PathFlag{
 		Name:     "meta",
 		Usage:    "path to metadata file for symbol lookup for enhanced debugging info during execution.",
@@ -113,6 +155,115 @@ var (
 		TakesFile: true,
 		Required:  false,
 	}
+	RunTraceOutFlag = &cli.PathFlag{
+		Name:      "trace-out",
+		Usage:     "path to write a JSONL per-instruction trace to. Use - to write to Stdout. Not written if empty.",
+		TakesFile: true,
+		Required:  false,
+	}
+	RunTracePCRangeFlag = &cli.GenericFlag{
+		Name:     "trace-pc-range",
+		Usage:    "only trace instructions with a PC in the inclusive range 'start-end', e.g. '0x1000-0x2000'",
+		Value:    new(PCRangeFlag),
+		Required: false,
+	}
+	RunTraceSyscallsOnlyFlag = &cli.BoolFlag{
+		Name:     "trace-syscalls-only",
+		Usage:    "only trace syscall instructions",
+		Required: false,
+	}
+	RunProfileOutFlag = &cli.PathFlag{
+		Name:      "profile-out",
+		Usage:     "path to write a pprof profile of the guest program counters to. Enables the guest profiler. Not written if empty.",
+		TakesFile: true,
+		Required:  false,
+	}
+	RunProfileSampleRateFlag = &cli.Uint64Flag{
+		Name:     "profile-sample-rate",
+		Usage:    "sample the guest program counter every N steps. 1 records every step.",
+		Value:    1,
+		Required: false,
+	}
+	RunProfileTopFlag = &cli.IntFlag{
+		Name:     "profile-top",
+		Usage:    "number of hot guest functions to report after the run, when profiling is enabled",
+		Value:    20,
+		Required: false,
+	}
+
+	RunBreakPCFlag = &cli.StringSliceFlag{
+		Name:     "break-pc",
+		Usage:    "stop before executing the instruction at this PC. Use 'pc@n' to stop at the n-th time the PC is reached. May be repeated.",
+		Required: false,
+	}
+	RunWatchAddrFlag = &cli.StringSliceFlag{
+		Name:     "watch-addr",
+		Usage:    "stop after the step that changes the memory word at this address. Use 'addr=value' to stop only when the word is set to value. May be repeated.",
+		Required: false,
+	}
+	RunBreakOnSyscallFlag = &cli.StringSliceFlag{
+		Name:     "break-on-syscall",
+		Usage:    "stop before executing a syscall with this number, or 'any' for every syscall. May be repeated.",
+		Required: false,
+	}
+
+	RunMetricsOutFlag = &cli.PathFlag{
+		Name:      "metrics-out",
+		Usage:     "path to write the run statistics to, in the Prometheus text format. Not written if empty.",
+		TakesFile: true,
+		Required:  false,
+	}
+
+	RunSchedQuantumFlag = &cli.Uint64Flag{
+		Name:     "sched-quantum",
+		Usage:    "number of steps a thread may run before it's preempted, for multithreaded states. Recorded in the output states. Non-default values can't be proven onchain.",
+		Value:    mipsexec.SchedQuantum,
+		Required: false,
+	}
+	RunPreimageServerAddrFlag = &cli.StringFlag{
+		Name:     "preimage-server-addr",
+		Usage:    "address of a remote pre-image server, e.g. started with 'cannon serve-preimages': unix:///path/to/socket, or tcp://host:port",
+		Required: false,
+	}
+	RunAsyncHintsFlag = &cli.IntFlag{
+		Name: "async-hints",
+		Usage: "number of hints to queue for dispatch to the pre-image server in the background, while the program keeps running. " +
+			"Pre-image requests wait for queued hints to complete. Hints are dispatched synchronously if 0.",
+		Value:    0,
+		Required: false,
+	}
+	RunPreimageLogFlag = &cli.PathFlag{
+		Name: "preimage-log",
+		Usage: "path to write a JSONL audit log of the hints, pre-image requests and pre-image reads of the program to, with their step numbers. " +
+			"Use - to write to Stdout. Not written if empty.",
+		TakesFile: true,
+		Required:  false,
+	}
+	RunGuestOutputFlag = &cli.StringFlag{
+		Name: "guest-output",
+		Usage: "how to capture the stdout and stderr of the program: 'log' logs every write, 'lines' logs every line with a guest= attribute, " +
+			"'json' additionally logs JSON lines with their fields as attributes, 'raw' writes directly to the host stdout and stderr.",
+		Value:    "log",
+		Required: false,
+	}
+	RunIntrospectAddrFlag = &cli.StringFlag{
+		Name:     "introspect-addr",
+		Usage:    "address to serve the live thread state of a multithreaded VM on, as JSON at /threads, e.g. localhost:7310. Disabled if empty.",
+		Required: false,
+	}
+	RunSchedPolicyFlag = &cli.StringFlag{
+		Name:     "sched-policy",
+		Usage:    "thread scheduling policy for multithreaded states: 'wakeup-priority' (default) or 'round-robin'. Recorded in the output states. Non-default values can't be proven onchain.",
+		Value:    multithreaded.SchedPolicyWakeupPriority.String(),
+		Required: false,
+	}
+	RunDeadlockThresholdFlag = &cli.Uint64Flag{
+		Name: "deadlock-threshold",
+		Usage: "number of steps all threads of a multithreaded state may be blocked on a futex before the run aborts, " +
+			"with a list of the blocked threads. Disabled if 0.",
+		Value:    0,
+		Required: false,
+	}
 
 	OutFilePerm = os.FileMode(0o755)
 )
@@ -131,6 +282,38 @@ type Proof struct {
 	OracleOffset uint32        `json:"oracle-offset,omitempty"`
 }
 
+// NewProof creates the proof output of a step, from the witness of the step and the resulting state hash.
+func NewProof(step uint64, witness *mipsevm.StepWitness, postStateHash common.Hash) *Proof {
+	proof := &Proof{
+		Step:      step,
+		Pre:       witness.StateHash,
+		Post:      postStateHash,
+		StateData: witness.State,
+		ProofData: witness.ProofData,
+	}
+	if witness.HasPreimage() {
+		proof.OracleKey = witness.PreimageKey[:]
+		proof.OracleValue = witness.PreimageValue
+		proof.OracleOffset = witness.PreimageOffset
+	}
+	return proof
+}
+
+// preimageServerArgs returns the CLI args after the first '--', to start the pre-image server with.
+func preimageServerArgs(ctx *cli.Context) []string {
+	args := ctx.Args().Slice()
+	for i, arg := range args {
+		if arg == "--" {
+			args = args[i+1:]
+			break
+		}
+	}
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	return args
+}
+
 type rawHint string
 
 func (rh rawHint) Hint() string {
@@ -280,8 +463,11 @@ func Run(ctx *cli.Context) error {
 	}
 
 	guestLogger := Logger(os.Stderr, log.LevelInfo)
-	outLog := &mipsevm.LoggingWriter{Log: guestLogger.With("module", "guest", "stream", "stdout")}
-	errLog := &mipsevm.LoggingWriter{Log: guestLogger.With("module", "guest", "stream", "stderr")}
+	outLog, errLog, flushGuestOutput, err := guestWriters(ctx.String(RunGuestOutputFlag.Name), guestLogger)
+	if err != nil {
+		return err
+	}
+	defer flushGuestOutput()
 
 	l := Logger(os.Stderr, log.LevelInfo).With("module", "vm")
 
@@ -324,18 +510,7 @@ func Run(ctx *cli.Context) error {
 	}
 	stopAtPreimageLargerThan := ctx.Int(RunStopAtPreimageLargerThanFlag.Name)
 
-	// split CLI args after first '--'
-	args := ctx.Args().Slice()
-	for i, arg := range args {
-		if arg == "--" {
-			args = args[i+1:]
-			break
-		}
-	}
-	if len(args) == 0 {
-		args = []string{""}
-	}
-
+	args := preimageServerArgs(ctx)
 	poOut := Logger(os.Stdout, log.LevelInfo).With("module", "host")
 	poErr := Logger(os.Stderr, log.LevelInfo).With("module", "host")
 	po, err := NewProcessPreimageOracle(args[0], args[1:], poOut, poErr)
@@ -368,11 +543,96 @@ func Run(ctx *cli.Context) error {
 		}
 	}
 
-	state, err := versions.LoadStateFromFile(ctx.Path(RunInputFlag.Name))
-	if err != nil {
-		return fmt.Errorf("failed to load state: %w", err)
+	var state *versions.VersionedState
+	if resumePath := ctx.Path(RunResumeFlag.Name); resumePath != "" {
+		if ctx.IsSet(RunInputFlag.Name) {
+			return errors.New("cannot specify both --input and --resume")
+		}
+		state, err = loadResumeState(resumePath, ctx.String(RunResumeStateHashFlag.Name))
+		if err != nil {
+			return err
+		}
+		l.Info("Resuming from verified snapshot", "path", resumePath, "step", state.GetStep())
+	} else {
+		if !ctx.IsSet(RunInputFlag.Name) {
+			return errors.New("either --input or --resume is required")
+		}
+		state, err = versions.LoadStateFromFile(ctx.Path(RunInputFlag.Name))
+		if err != nil {
+			return fmt.Errorf("failed to load state: %w", err)
+		}
+	}
+	if ctx.Bool(RunSnapshotDedupFlag.Name) {
+		state.GetMemory().SetDeduplicatedSerialization(true)
+	}
+	var vmOracle mipsevm.PreimageOracle = po
+	if addr := ctx.String(RunPreimageServerAddrFlag.Name); addr != "" {
+		if po.cmd != nil {
+			return errors.New("cannot specify both a remote pre-image server and a pre-image server command")
+		}
+		remote, err := DialPreimageOracle(ctx.Context, addr)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := remote.Close(); err != nil {
+				l.Error("failed to close remote pre-image server connection", "err", err)
+			}
+		}()
+		l.Info("Connected to remote pre-image server", "addr", addr)
+		vmOracle = remote
+	}
+	if queueSize := ctx.Int(RunAsyncHintsFlag.Name); queueSize > 0 {
+		asyncHints := mipsevm.NewAsyncHintOracle(vmOracle, queueSize)
+		defer asyncHints.Close()
+		vmOracle = asyncHints
+	}
+	var preimageLog *PreimageLog
+	if logPath := ctx.Path(RunPreimageLogFlag.Name); logPath != "" {
+		w, closer, _, err := ioutil.ToStdOutOrFileOrNoop(logPath, OutFilePerm)()
+		if err != nil {
+			return fmt.Errorf("failed to open pre-image log: %w", err)
+		}
+		preimageLog = NewPreimageLog(vmOracle, w)
+		defer func() {
+			if err := preimageLog.Flush(); err != nil {
+				l.Error("failed to flush pre-image log", "err", err)
+			}
+			if err := closer.Close(); err != nil {
+				l.Error("failed to close pre-image log", "err", err)
+			}
+		}()
+		vmOracle = preimageLog
+	}
+	vm := state.CreateVM(l, vmOracle, outLog, errLog, meta)
+	if ctx.IsSet(RunSchedQuantumFlag.Name) || ctx.IsSet(RunSchedPolicyFlag.Name) {
+		if err := configureScheduler(ctx, l, vm); err != nil {
+			return err
+		}
+	}
+	if threshold := ctx.Uint64(RunDeadlockThresholdFlag.Name); threshold != 0 {
+		mtVM, ok := vm.(*multithreaded.InstrumentedState)
+		if !ok {
+			return fmt.Errorf("deadlock detection requires a multithreaded state, got %T", vm.GetState())
+		}
+		mtVM.SetDeadlockThreshold(threshold)
+	}
+	var introspection *IntrospectionServer
+	if addr := ctx.String(RunIntrospectAddrFlag.Name); addr != "" {
+		mtVM, ok := vm.(*multithreaded.InstrumentedState)
+		if !ok {
+			return fmt.Errorf("thread introspection requires a multithreaded state, got %T", vm.GetState())
+		}
+		introspection, err = StartIntrospectionServer(l, addr, mtVM)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := introspection.Close(); err != nil {
+				l.Error("failed to close introspection server", "err", err)
+			}
+		}()
 	}
-	vm := state.CreateVM(l, po, outLog, errLog, meta)
 	debugProgram := ctx.Bool(RunDebugFlag.Name)
 	if debugProgram {
 		if metaPath := ctx.Path(RunMetaFlag.Name); metaPath == "" {
@@ -385,12 +645,78 @@ func Run(ctx *cli.Context) error {
 
 	proofFmt := ctx.String(RunProofFmtFlag.Name)
 	snapshotFmt := ctx.String(RunSnapshotFmtFlag.Name)
+	var snapshotSink checkpoint.Sink
+	if ctx.IsSet(RunSnapshotSinkFlag.Name) {
+		snapshotSink, snapshotFmt, err = checkpoint.NewSink(ctx.String(RunSnapshotSinkFlag.Name), ctx.String(RunSnapshotSinkEndpointFlag.Name), snapshotFmt)
+		if err != nil {
+			return err
+		}
+	} else if ctx.IsSet(RunSnapshotRetainFlag.Name) {
+		// retain snapshots in the local snapshot directory
+		snapshotSink, snapshotFmt, err = checkpoint.NewFileSink("", snapshotFmt, OutFilePerm)
+		if err != nil {
+			return err
+		}
+	}
+	snapshotRetain := ctx.Int(RunSnapshotRetainFlag.Name)
 
 	stepFn := vm.Step
 	if po.cmd != nil {
 		stepFn = Guard(po.cmd.ProcessState, stepFn)
 	}
 
+	var tracer *InstructionTracer
+	if traceOut := ctx.Path(RunTraceOutFlag.Name); traceOut != "" {
+		w, closer, _, err := ioutil.ToStdOutOrFileOrNoop(traceOut, OutFilePerm)()
+		if err != nil {
+			return fmt.Errorf("failed to open trace output: %w", err)
+		}
+		tracer = NewInstructionTracer(w, ctx.Generic(RunTracePCRangeFlag.Name).(*PCRangeFlag), ctx.Bool(RunTraceSyscallsOnlyFlag.Name))
+		defer func() {
+			if err := tracer.Flush(); err != nil {
+				l.Error("failed to flush instruction trace", "err", err)
+			}
+			if err := closer.Close(); err != nil {
+				l.Error("failed to close instruction trace", "err", err)
+			}
+		}()
+	}
+
+	breakpoints, err := NewBreakpoints(ctx.StringSlice(RunBreakPCFlag.Name), ctx.StringSlice(RunWatchAddrFlag.Name), ctx.StringSlice(RunBreakOnSyscallFlag.Name))
+	if err != nil {
+		return err
+	}
+	breakpoints.Init(state)
+	stopTriggers, err := NewStopTriggers(ctx.StringSlice(RunStopOnFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	var profiler *mipsevm.PCProfiler
+	profileOut := ctx.Path(RunProfileOutFlag.Name)
+	if profileOut != "" {
+		profiler = mipsevm.NewPCProfiler(ctx.Uint64(RunProfileSampleRateFlag.Name))
+	}
+
+	stats := NewRunStats()
+	var runMetrics *RunMetrics
+	if metricsCfg := opmetrics.ReadCLIConfig(ctx); metricsCfg.Enabled {
+		if err := metricsCfg.Check(); err != nil {
+			return fmt.Errorf("invalid metrics config: %w", err)
+		}
+		runMetrics = NewRunMetrics()
+		metricsSrv, err := opmetrics.StartServer(runMetrics.Registry(), metricsCfg.ListenAddr, metricsCfg.ListenPort)
+		if err != nil {
+			return fmt.Errorf("failed to start metrics server: %w", err)
+		}
+		l.Info("Started metrics server", "addr", metricsSrv.Addr())
+		defer func() {
+			if err := metricsSrv.Stop(context.Background()); err != nil {
+				l.Error("failed to stop metrics server", "err", err)
+			}
+		}()
+	}
+
 	start := time.Now()
 
 	startStep := state.GetStep()
@@ -416,6 +742,10 @@ func Run(ctx *cli.Context) error {
 			)
 		}
 
+		if introspection != nil {
+			introspection.Poll()
+		}
+
 		if vm.CheckInfiniteLoop() {
 			// don't loop forever when we get stuck because of an unexpected bad program
 			return fmt.Errorf("detected an infinite loop at step %d", step)
@@ -426,40 +756,88 @@ func Run(ctx *cli.Context) error {
 			break
 		}
 
+		if reason, hit := stopTriggers.Check(state); hit {
+			l.Info("Reached stop trigger", "step", step, "reason", reason, "name", meta.LookupSymbol(state.GetPC()))
+			break
+		}
+
+		if reason, hit := breakpoints.BeforeStep(state); hit {
+			l.Info("Hit breakpoint", "step", step, "reason", reason, "name", meta.LookupSymbol(state.GetPC()))
+			break
+		}
+
 		if snapshotAt(state) {
-			if err := serialize.Write(fmt.Sprintf(snapshotFmt, step), state, OutFilePerm); err != nil {
+			merkleStart := time.Now()
+			_, snapshotHash := state.EncodeWitness()
+			if runMetrics != nil {
+				runMetrics.RecordMerkleization(time.Since(merkleStart))
+			}
+			l.Info("Writing state snapshot", "step", step, "stateHash", snapshotHash)
+			if snapshotSink != nil {
+				if err := checkpoint.Write(ctx.Context, snapshotSink, fmt.Sprintf(snapshotFmt, step), state); err != nil {
+					return fmt.Errorf("failed to write state snapshot: %w", err)
+				}
+				if err := checkpoint.Retain(ctx.Context, snapshotSink, snapshotFmt, snapshotRetain); err != nil {
+					return fmt.Errorf("failed to apply snapshot retention: %w", err)
+				}
+			} else if err := serialize.Write(fmt.Sprintf(snapshotFmt, step), state, OutFilePerm); err != nil {
 				return fmt.Errorf("failed to write state snapshot: %w", err)
 			}
 		}
 
+		stats.Observe(state)
+		if runMetrics != nil {
+			runMetrics.BeforeStep(state)
+		}
+		if preimageLog != nil {
+			preimageLog.BeforeStep(state)
+		}
+		if tracer != nil {
+			tracer.Before(state)
+		}
+		if profiler != nil {
+			profiler.Sample(state)
+		}
+
 		if proofAt(state) {
+			merkleStart := time.Now()
 			witness, err := stepFn(true)
 			if err != nil {
 				return fmt.Errorf("failed at proof-gen step %d (PC: %08x): %w", step, state.GetPC(), err)
 			}
 			_, postStateHash := state.EncodeWitness()
-			proof := &Proof{
-				Step:      step,
-				Pre:       witness.StateHash,
-				Post:      postStateHash,
-				StateData: witness.State,
-				ProofData: witness.ProofData,
-			}
-			if witness.HasPreimage() {
-				proof.OracleKey = witness.PreimageKey[:]
-				proof.OracleValue = witness.PreimageValue
-				proof.OracleOffset = witness.PreimageOffset
+			if runMetrics != nil {
+				runMetrics.RecordMerkleization(time.Since(merkleStart))
 			}
+			proof := NewProof(step, witness, postStateHash)
 			if err := jsonutil.WriteJSON(proof, ioutil.ToStdOutOrFileOrNoop(fmt.Sprintf(proofFmt, step), OutFilePerm)); err != nil {
 				return fmt.Errorf("failed to write proof data: %w", err)
 			}
 		} else {
 			_, err = stepFn(false)
 			if err != nil {
+				logBacktrace(l, state, meta)
 				return fmt.Errorf("failed at step %d (PC: %08x): %w", step, state.GetPC(), err)
 			}
 		}
 
+		if runMetrics != nil {
+			runMetrics.AfterStep(state)
+		}
+		if preimageLog != nil {
+			preimageLog.AfterStep(state, vm)
+		}
+		if tracer != nil {
+			if err := tracer.After(state); err != nil {
+				return err
+			}
+		}
+
+		if reason, hit := breakpoints.AfterStep(state); hit {
+			l.Info("Hit watchpoint", "step", step, "reason", reason, "name", meta.LookupSymbol(breakpoints.LastPC()))
+			break
+		}
+
 		lastPreimageKey, lastPreimageValue, lastPreimageOffset := vm.LastPreimage()
 		if lastPreimageOffset != ^uint32(0) {
 			if stopAtAnyPreimage {
@@ -480,41 +858,125 @@ func Run(ctx *cli.Context) error {
 		}
 	}
 	l.Info("Execution stopped", "exited", state.GetExited(), "code", state.GetExitCode())
+	if runMetrics != nil {
+		runMetrics.Flush()
+	}
 	if debugProgram {
 		vm.Traceback()
+	} else if state.GetExited() && state.GetExitCode() != 0 {
+		logBacktrace(l, state, meta)
+	}
+
+	debugInfo := vm.GetDebugInfo()
+	stats.Finish(state)
+	stats.Log(l, debugInfo)
+	if metricsOut := ctx.Path(RunMetricsOutFlag.Name); metricsOut != "" {
+		if err := stats.WriteMetrics(metricsOut, debugInfo); err != nil {
+			return fmt.Errorf("failed to write run metrics: %w", err)
+		}
+	}
+
+	if profiler != nil {
+		total := profiler.TotalSamples()
+		for i, fn := range profiler.HotFunctions(meta, ctx.Int(RunProfileTopFlag.Name)) {
+			l.Info("Hot function", "rank", i+1, "name", fn.Name, "samples", fn.Samples, "pct", fmt.Sprintf("%.2f%%", float64(fn.Samples)*100/float64(total)))
+		}
+		if err := writeProfile(profileOut, profiler, meta); err != nil {
+			return fmt.Errorf("failed to write guest profile: %w", err)
+		}
 	}
 
 	if err := serialize.Write(ctx.Path(RunOutputFlag.Name), state, OutFilePerm); err != nil {
 		return fmt.Errorf("failed to write state output: %w", err)
 	}
 	if debugInfoFile := ctx.Path(RunDebugInfoFlag.Name); debugInfoFile != "" {
-		if err := jsonutil.WriteJSON(vm.GetDebugInfo(), ioutil.ToStdOutOrFileOrNoop(debugInfoFile, OutFilePerm)); err != nil {
+		if err := jsonutil.WriteJSON(debugInfo, ioutil.ToStdOutOrFileOrNoop(debugInfoFile, OutFilePerm)); err != nil {
 			return fmt.Errorf("failed to write benchmark data: %w", err)
 		}
 	}
 	return nil
 }
 
+func configureScheduler(ctx *cli.Context, l log.Logger, vm mipsevm.FPVM) error {
+	mtVM, ok := vm.(*multithreaded.InstrumentedState)
+	if !ok {
+		return fmt.Errorf("scheduler options require a multithreaded state, got %T", vm.GetState())
+	}
+	// Only override the options that are set, the state records the scheduler it was run with
+	cfg := mtVM.GetState().(*multithreaded.State).GetSchedulerConfig()
+	if ctx.IsSet(RunSchedQuantumFlag.Name) {
+		cfg.Quantum = ctx.Uint64(RunSchedQuantumFlag.Name)
+	}
+	if ctx.IsSet(RunSchedPolicyFlag.Name) {
+		policy, err := multithreaded.ParseSchedPolicy(ctx.String(RunSchedPolicyFlag.Name))
+		if err != nil {
+			return err
+		}
+		cfg.Policy = policy
+	}
+	if err := mtVM.SetSchedulerConfig(cfg); err != nil {
+		return fmt.Errorf("invalid scheduler config: %w", err)
+	}
+	if !cfg.IsDefault() {
+		l.Warn("Using a non-default thread scheduler, steps can't be proven onchain", "quantum", cfg.Quantum, "policy", cfg.Policy)
+	}
+	return nil
+}
+
+func writeProfile(path string, profiler *mipsevm.PCProfiler, meta mipsevm.Metadata) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, OutFilePerm)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return profiler.WritePprof(meta, f)
+}
+
 var RunCommand = &cli.Command{
 	Name:        "run",
 	Usage:       "Run VM step(s) and generate proof data to replicate onchain.",
 	Description: "Run VM step(s) and generate proof data to replicate onchain. See flags to match when to output a proof, a snapshot, or to stop early.",
 	Action:      Run,
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		RunInputFlag,
+		RunResumeFlag,
+		RunResumeStateHashFlag,
 		RunOutputFlag,
 		RunProofAtFlag,
 		RunProofFmtFlag,
 		RunSnapshotAtFlag,
 		RunSnapshotFmtFlag,
+		RunSnapshotSinkFlag,
+		RunSnapshotSinkEndpointFlag,
+		RunSnapshotRetainFlag,
+		RunSnapshotDedupFlag,
 		RunStopAtFlag,
 		RunStopAtPreimageFlag,
 		RunStopAtPreimageTypeFlag,
 		RunStopAtPreimageLargerThanFlag,
+		RunStopOnFlag,
 		RunMetaFlag,
 		RunInfoAtFlag,
 		RunPProfCPU,
 		RunDebugFlag,
 		RunDebugInfoFlag,
-	},
+		RunTraceOutFlag,
+		RunTracePCRangeFlag,
+		RunTraceSyscallsOnlyFlag,
+		RunProfileOutFlag,
+		RunProfileSampleRateFlag,
+		RunProfileTopFlag,
+		RunBreakPCFlag,
+		RunWatchAddrFlag,
+		RunBreakOnSyscallFlag,
+		RunMetricsOutFlag,
+		RunSchedQuantumFlag,
+		RunSchedPolicyFlag,
+		RunIntrospectAddrFlag,
+		RunDeadlockThresholdFlag,
+		RunGuestOutputFlag,
+		RunAsyncHintsFlag,
+		RunPreimageServerAddrFlag,
+		RunPreimageLogFlag,
+	}, opmetrics.CLIFlags(EnvVarPrefix)...),
 }
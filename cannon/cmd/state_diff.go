@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+)
+
+var (
+	StateDiffMaxWordsFlag = &cli.IntFlag{
+		Name:  "max-words",
+		Usage: "maximum number of differing memory words to report per page. 0 reports all of them.",
+		Value: 16,
+	}
+)
+
+func StateDiff(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return fmt.Errorf("expected 2 state files, got %d", ctx.NArg())
+	}
+	pathA, pathB := ctx.Args().Get(0), ctx.Args().Get(1)
+	a, err := versions.LoadStateFromFile(pathA)
+	if err != nil {
+		return fmt.Errorf("invalid state (%v): %w", pathA, err)
+	}
+	b, err := versions.LoadStateFromFile(pathB)
+	if err != nil {
+		return fmt.Errorf("invalid state (%v): %w", pathB, err)
+	}
+	d := &stateDiff{out: os.Stdout, maxWords: ctx.Int(StateDiffMaxWordsFlag.Name)}
+	d.diffStates(a, b)
+	if d.count == 0 {
+		fmt.Println("states are identical")
+		return nil
+	}
+	return fmt.Errorf("found %d differences", d.count)
+}
+
+// stateDiff writes the differences between two states, one per line.
+type stateDiff struct {
+	out      io.Writer
+	maxWords int
+	count    int
+}
+
+func (d *stateDiff) report(format string, args ...any) {
+	d.count++
+	_, _ = fmt.Fprintf(d.out, format+"\n", args...)
+}
+
+func diffField[T comparable](d *stateDiff, name string, a, b T) {
+	if a != b {
+		d.report("%s: %v != %v", name, a, b)
+	}
+}
+
+func (d *stateDiff) diffStates(a, b *versions.VersionedState) {
+	diffField(d, "version", a.Version, b.Version)
+	diffField(d, "step", a.GetStep(), b.GetStep())
+	diffField(d, "exited", a.GetExited(), b.GetExited())
+	diffField(d, "exitCode", a.GetExitCode(), b.GetExitCode())
+	diffField(d, "heap", mipsevm.HexU32(a.GetHeap()), mipsevm.HexU32(b.GetHeap()))
+	diffField(d, "layout", *a.GetLayout(), *b.GetLayout())
+	diffField(d, "preimageKey", a.GetPreimageKey(), b.GetPreimageKey())
+	diffField(d, "preimageOffset", a.GetPreimageOffset(), b.GetPreimageOffset())
+	if !bytes.Equal(a.GetLastHint(), b.GetLastHint()) {
+		d.report("lastHint: %v != %v", a.GetLastHint(), b.GetLastHint())
+	}
+
+	mtA, okA := a.FPVMState.(*multithreaded.State)
+	mtB, okB := b.FPVMState.(*multithreaded.State)
+	if okA && okB {
+		d.diffThreads(mtA, mtB)
+	} else {
+		d.diffCpu("", a.GetCpu(), b.GetCpu())
+		d.diffRegisters("", a.GetRegistersRef(), b.GetRegistersRef())
+	}
+	stA, okA := a.FPVMState.(*singlethreaded.State)
+	stB, okB := b.FPVMState.(*singlethreaded.State)
+	if okA && okB && stA.FPU != nil && stB.FPU != nil {
+		for i := range stA.FPU.FPR {
+			diffField(d, fmt.Sprintf("fpr[%d]", i), mipsevm.HexU32(stA.FPU.FPR[i]), mipsevm.HexU32(stB.FPU.FPR[i]))
+		}
+		diffField(d, "fcsr", mipsevm.HexU32(stA.FPU.FCSR), mipsevm.HexU32(stB.FPU.FCSR))
+	}
+	d.diffMemory(a.GetMemory(), b.GetMemory())
+}
+
+func (d *stateDiff) diffCpu(prefix string, a, b mipsevm.CpuScalars) {
+	diffField(d, prefix+"pc", mipsevm.HexU32(a.PC), mipsevm.HexU32(b.PC))
+	diffField(d, prefix+"nextPC", mipsevm.HexU32(a.NextPC), mipsevm.HexU32(b.NextPC))
+	diffField(d, prefix+"lo", mipsevm.HexU32(a.LO), mipsevm.HexU32(b.LO))
+	diffField(d, prefix+"hi", mipsevm.HexU32(a.HI), mipsevm.HexU32(b.HI))
+}
+
+func (d *stateDiff) diffRegisters(prefix string, a, b *[32]uint32) {
+	for i := range a {
+		diffField(d, fmt.Sprintf("%sreg[%d]", prefix, i), mipsevm.HexU32(a[i]), mipsevm.HexU32(b[i]))
+	}
+}
+
+func (d *stateDiff) diffThreads(a, b *multithreaded.State) {
+	diffField(d, "stepsSinceLastContextSwitch", a.StepsSinceLastContextSwitch, b.StepsSinceLastContextSwitch)
+	diffField(d, "wakeup", mipsevm.HexU32(a.Wakeup), mipsevm.HexU32(b.Wakeup))
+	diffField(d, "traverseRight", a.TraverseRight, b.TraverseRight)
+	diffField(d, "nextThreadId", a.NextThreadId, b.NextThreadId)
+	diffField(d, "activeThread", a.GetCurrentThread().ThreadId, b.GetCurrentThread().ThreadId)
+	if ids := threadIDs(a.LeftThreadStack); fmt.Sprint(ids) != fmt.Sprint(threadIDs(b.LeftThreadStack)) {
+		d.report("leftThreadStack: %v != %v", ids, threadIDs(b.LeftThreadStack))
+	}
+	if ids := threadIDs(a.RightThreadStack); fmt.Sprint(ids) != fmt.Sprint(threadIDs(b.RightThreadStack)) {
+		d.report("rightThreadStack: %v != %v", ids, threadIDs(b.RightThreadStack))
+	}
+
+	threadsA, threadsB := threadsByID(a), threadsByID(b)
+	for _, id := range sortedKeys(threadsA, threadsB) {
+		ta, okA := threadsA[id]
+		tb, okB := threadsB[id]
+		prefix := fmt.Sprintf("thread[%d].", id)
+		if !okA || !okB {
+			d.report("thread[%d]: present in a=%v, b=%v", id, okA, okB)
+			continue
+		}
+		diffField(d, prefix+"exited", ta.Exited, tb.Exited)
+		diffField(d, prefix+"exitCode", ta.ExitCode, tb.ExitCode)
+		diffField(d, prefix+"futexAddr", mipsevm.HexU32(ta.FutexAddr), mipsevm.HexU32(tb.FutexAddr))
+		diffField(d, prefix+"futexVal", mipsevm.HexU32(ta.FutexVal), mipsevm.HexU32(tb.FutexVal))
+		diffField(d, prefix+"futexTimeoutStep", ta.FutexTimeoutStep, tb.FutexTimeoutStep)
+		d.diffCpu(prefix, ta.Cpu, tb.Cpu)
+		d.diffRegisters(prefix, &ta.Registers, &tb.Registers)
+	}
+}
+
+func threadIDs(stack []*multithreaded.ThreadState) []uint32 {
+	ids := make([]uint32, len(stack))
+	for i, t := range stack {
+		ids[i] = t.ThreadId
+	}
+	return ids
+}
+
+func threadsByID(s *multithreaded.State) map[uint32]*multithreaded.ThreadState {
+	out := make(map[uint32]*multithreaded.ThreadState)
+	for _, t := range s.LeftThreadStack {
+		out[t.ThreadId] = t
+	}
+	for _, t := range s.RightThreadStack {
+		out[t.ThreadId] = t
+	}
+	return out
+}
+
+func (d *stateDiff) diffMemory(a, b *memory.Memory) {
+	pagesA, pagesB := pagesByIndex(a), pagesByIndex(b)
+	for _, index := range sortedKeys(pagesA, pagesB) {
+		pa, okA := pagesA[index]
+		pb, okB := pagesB[index]
+		pageAddr := index << memory.PageAddrSize
+		if !okA || !okB {
+			// a missing page reads as zeroes, so only the presence is reported
+			d.report("page %08x: present in a=%v, b=%v", pageAddr, okA, okB)
+			if !okA {
+				pa = new(memory.Page)
+			}
+			if !okB {
+				pb = new(memory.Page)
+			}
+		}
+		if *pa == *pb {
+			continue
+		}
+		words := 0
+		for offset := 0; offset < memory.PageSize; offset += 4 {
+			wa := binary.BigEndian.Uint32(pa[offset : offset+4])
+			wb := binary.BigEndian.Uint32(pb[offset : offset+4])
+			if wa == wb {
+				continue
+			}
+			words++
+			if d.maxWords == 0 || words <= d.maxWords {
+				d.report("mem[%08x]: %08x != %08x", pageAddr+uint32(offset), wa, wb)
+			}
+		}
+		if d.maxWords != 0 && words > d.maxWords {
+			d.report("page %08x: %d more differing words", pageAddr, words-d.maxWords)
+		}
+	}
+}
+
+func pagesByIndex(m *memory.Memory) map[uint32]*memory.Page {
+	out := make(map[uint32]*memory.Page)
+	_ = m.ForEachPage(func(pageIndex uint32, page *memory.Page) error {
+		out[pageIndex] = page
+		return nil
+	})
+	return out
+}
+
+// sortedKeys returns the union of the keys of a and b in ascending order.
+func sortedKeys[V any](a, b map[uint32]V) []uint32 {
+	seen := make(map[uint32]struct{}, len(a))
+	var out []uint32
+	for _, m := range []map[uint32]V{a, b} {
+		for k := range m {
+			if _, ok := seen[k]; !ok {
+				seen[k] = struct{}{}
+				out = append(out, k)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+var StateDiffCommand = &cli.Command{
+	Name:        "diff",
+	Usage:       "Compare two Cannon states",
+	Description: "Compare two Cannon states and report differing fields, threads and memory words. Exits with an error if the states differ.",
+	ArgsUsage:   "<a> <b>",
+	Action:      StateDiff,
+	Flags: []cli.Flag{
+		StateDiffMaxWordsFlag,
+	},
+}
+
+var StateCommand = &cli.Command{
+	Name:        "state",
+	Usage:       "Inspect Cannon states",
+	Subcommands: []*cli.Command{StateDiffCommand},
+}
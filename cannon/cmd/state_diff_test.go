@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+)
+
+func diffStateLines(t *testing.T, maxWords int, a, b mipsevm.FPVMState) []string {
+	va, err := versions.NewFromState(a)
+	require.NoError(t, err)
+	vb, err := versions.NewFromState(b)
+	require.NoError(t, err)
+	var out bytes.Buffer
+	d := &stateDiff{out: &out, maxWords: maxWords}
+	d.diffStates(va, vb)
+	if out.Len() == 0 {
+		require.Zero(t, d.count)
+		return nil
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, d.count, "must count every reported difference")
+	return lines
+}
+
+func TestStateDiffSingleThreaded(t *testing.T) {
+	t.Run("Identical", func(t *testing.T) {
+		a := singlethreaded.CreateInitialState(0x100, 0x1000)
+		a.Memory.SetMemory(0x2000, 0xaabbccdd)
+		b := singlethreaded.CreateInitialState(0x100, 0x1000)
+		b.Memory.SetMemory(0x2000, 0xaabbccdd)
+		require.Empty(t, diffStateLines(t, 0, a, b))
+	})
+
+	t.Run("Fields", func(t *testing.T) {
+		a := singlethreaded.CreateInitialState(0x100, 0x1000)
+		b := singlethreaded.CreateInitialState(0x100, 0x1000)
+		b.Step = 5
+		b.Exited = true
+		b.ExitCode = 1
+		b.Heap = 0x2000
+		b.PreimageOffset = 8
+		b.LastHint = []byte{0x01}
+		b.Cpu.PC = 0x104
+		b.Registers[2] = 0x10
+		require.Equal(t, []string{
+			"step: 0 != 5",
+			"exited: false != true",
+			"exitCode: 0 != 1",
+			"heap: 00001000 != 00002000",
+			"preimageOffset: 0 != 8",
+			"lastHint: 0x != 0x01",
+			"pc: 00000100 != 00000104",
+			"reg[2]: 00000000 != 00000010",
+		}, diffStateLines(t, 0, a, b))
+	})
+
+	t.Run("FPU", func(t *testing.T) {
+		a := singlethreaded.CreateEmptyState()
+		a.FPU = new(exec.FPUState)
+		b := singlethreaded.CreateEmptyState()
+		b.FPU = new(exec.FPUState)
+		b.FPU.FPR[3] = 0x3f800000
+		b.FPU.FCSR = 0x1
+		require.Equal(t, []string{
+			"fpr[3]: 00000000 != 3f800000",
+			"fcsr: 00000000 != 00000001",
+		}, diffStateLines(t, 0, a, b))
+	})
+
+	t.Run("Memory", func(t *testing.T) {
+		a := singlethreaded.CreateEmptyState()
+		a.Memory.SetMemory(0x2000, 0x01)
+		a.Memory.SetMemory(0x2008, 0x02)
+		b := singlethreaded.CreateEmptyState()
+		b.Memory.SetMemory(0x2000, 0x01)
+		b.Memory.SetMemory(0x2008, 0x03)
+		require.Equal(t, []string{
+			"mem[00002008]: 00000002 != 00000003",
+		}, diffStateLines(t, 0, a, b))
+	})
+
+	t.Run("MissingPage", func(t *testing.T) {
+		a := singlethreaded.CreateEmptyState()
+		b := singlethreaded.CreateEmptyState()
+		b.Memory.SetMemory(0x3004, 0x07)
+		require.Equal(t, []string{
+			"page 00003000: present in a=false, b=true",
+			"mem[00003004]: 00000000 != 00000007",
+		}, diffStateLines(t, 0, a, b))
+	})
+
+	t.Run("MissingZeroPage", func(t *testing.T) {
+		a := singlethreaded.CreateEmptyState()
+		b := singlethreaded.CreateEmptyState()
+		b.Memory.AllocPage(3)
+		require.Equal(t, []string{
+			"page 00003000: present in a=false, b=true",
+		}, diffStateLines(t, 0, a, b), "a missing page reads as zeroes")
+	})
+
+	t.Run("MaxWords", func(t *testing.T) {
+		a := singlethreaded.CreateEmptyState()
+		b := singlethreaded.CreateEmptyState()
+		a.Memory.AllocPage(2)
+		for i := uint32(0); i < 5; i++ {
+			b.Memory.SetMemory(0x2000+4*i, i+1)
+		}
+		require.Equal(t, []string{
+			"mem[00002000]: 00000000 != 00000001",
+			"mem[00002004]: 00000000 != 00000002",
+			"page 00002000: 3 more differing words",
+		}, diffStateLines(t, 2, a, b))
+	})
+}
+
+func TestStateDiffMultiThreaded(t *testing.T) {
+	newThread := func(s *multithreaded.State) *multithreaded.ThreadState {
+		thread := multithreaded.CreateEmptyThread()
+		thread.ThreadId = s.NextThreadId
+		s.NextThreadId++
+		return thread
+	}
+
+	t.Run("Identical", func(t *testing.T) {
+		a := multithreaded.CreateInitialState(0x100, 0x1000)
+		b := multithreaded.CreateInitialState(0x100, 0x1000)
+		require.Empty(t, diffStateLines(t, 0, a, b))
+	})
+
+	t.Run("Threads", func(t *testing.T) {
+		a := multithreaded.CreateInitialState(0x100, 0x1000)
+		b := multithreaded.CreateInitialState(0x100, 0x1000)
+		b.StepsSinceLastContextSwitch = 3
+		b.GetCurrentThread().Registers[4] = 0x20
+		b.GetCurrentThread().FutexAddr = 0x40
+		b.LeftThreadStack = append(b.LeftThreadStack, newThread(b))
+		require.Equal(t, []string{
+			"stepsSinceLastContextSwitch: 0 != 3",
+			"nextThreadId: 1 != 2",
+			"activeThread: 0 != 1",
+			"leftThreadStack: [0] != [0 1]",
+			"thread[0].futexAddr: ffffffff != 00000040",
+			"thread[0].reg[4]: 00000000 != 00000020",
+			"thread[1]: present in a=false, b=true",
+		}, diffStateLines(t, 0, a, b))
+	})
+
+	t.Run("RightStack", func(t *testing.T) {
+		a := multithreaded.CreateInitialState(0x100, 0x1000)
+		a.RightThreadStack = append(a.RightThreadStack, newThread(a))
+		b := multithreaded.CreateInitialState(0x100, 0x1000)
+		b.RightThreadStack = append(b.RightThreadStack, newThread(b))
+		b.RightThreadStack[0].Exited = true
+		b.RightThreadStack[0].ExitCode = 2
+		require.Equal(t, []string{
+			"thread[1].exited: false != true",
+			"thread[1].exitCode: 0 != 2",
+		}, diffStateLines(t, 0, a, b))
+	})
+
+	t.Run("VersionMismatch", func(t *testing.T) {
+		a := multithreaded.CreateEmptyState()
+		b := singlethreaded.CreateEmptyState()
+		lines := diffStateLines(t, 0, a, b)
+		require.NotEmpty(t, lines)
+		require.True(t, strings.HasPrefix(lines[0], "version: "), "must report the version first")
+	})
+}
+
+func TestSortedKeys(t *testing.T) {
+	a := map[uint32]memory.Page{3: {}, 1: {}}
+	b := map[uint32]memory.Page{2: {}, 3: {}}
+	require.Equal(t, []uint32{1, 2, 3}, sortedKeys(a, b))
+	require.Empty(t, sortedKeys(map[uint32]memory.Page{}, nil))
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+)
+
+const metricsNamespace = "cannon"
+
+// syscallCategories groups syscalls for the run statistics. Unlisted syscalls are counted as "other".
+var syscallCategories = map[uint32]string{
+	exec.SysMmap:          "memory",
+	exec.SysBrk:           "memory",
+	exec.SysMunmap:        "memory",
+	exec.SysMadvise:       "memory",
+	exec.SysMinCore:       "memory",
+	exec.SysRead:          "io",
+	exec.SysWrite:         "io",
+	exec.SysFcntl:         "io",
+	exec.SysOpen:          "io",
+	exec.SysClose:         "io",
+	exec.SysPread64:       "io",
+	exec.SysFstat64:       "io",
+	exec.SysOpenAt:        "io",
+	exec.SysReadlink:      "io",
+	exec.SysReadlinkAt:    "io",
+	exec.SysIoctl:         "io",
+	exec.SysPipe2:         "io",
+	exec.SysStat64:        "io",
+	exec.SysLlseek:        "io",
+	exec.SysEpollCreate1:  "io",
+	exec.SysEpollCtl:      "io",
+	exec.SysEpollPwait:    "io",
+	exec.SysEventFd2:      "io",
+	exec.SysClone:         "thread",
+	exec.SysExit:          "thread",
+	exec.SysExitGroup:     "thread",
+	exec.SysSchedYield:    "thread",
+	exec.SysGetTID:        "thread",
+	exec.SysGetpid:        "thread",
+	exec.SysFutex:         "thread",
+	exec.SysGetAffinity:   "thread",
+	exec.SysTgkill:        "thread",
+	exec.SysClockGetTime:  "time",
+	exec.SysNanosleep:     "time",
+	exec.SysSetITimer:     "time",
+	exec.SysTimerCreate:   "time",
+	exec.SysTimerSetTime:  "time",
+	exec.SysTimerDelete:   "time",
+	exec.SysRtSigprocmask: "signal",
+	exec.SysRtSigaction:   "signal",
+	exec.SysSigaltstack:   "signal",
+}
+
+// RunStats collects resource usage statistics of a cannon run, to help with sizing the hosts that run the FPVM.
+type RunStats struct {
+	Steps        uint64
+	PeakPages    int
+	PeakHeap     uint32
+	SyscallSteps map[string]uint64
+}
+
+func NewRunStats() *RunStats {
+	return &RunStats{SyscallSteps: make(map[string]uint64)}
+}
+
+// Observe records the state before the next instruction is executed.
+func (s *RunStats) Observe(state mipsevm.FPVMState) {
+	s.Steps++
+	s.observeMemory(state)
+	_, opcode, fun := exec.GetInstructionDetails(state.GetPC(), state.GetMemory())
+	if opcode == 0 && fun == 0xC {
+		category, ok := syscallCategories[state.GetRegistersRef()[2]]
+		if !ok {
+			category = "other"
+		}
+		s.SyscallSteps[category]++
+	}
+}
+
+// Finish records the final state, once the last instruction was executed.
+func (s *RunStats) Finish(state mipsevm.FPVMState) {
+	s.observeMemory(state)
+}
+
+func (s *RunStats) observeMemory(state mipsevm.FPVMState) {
+	s.PeakPages = max(s.PeakPages, state.GetMemory().PageCount())
+	s.PeakHeap = max(s.PeakHeap, state.GetHeap())
+}
+
+func (s *RunStats) categories() []string {
+	out := make([]string, 0, len(s.SyscallSteps))
+	for category := range s.SyscallSteps {
+		out = append(out, category)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Log logs a summary of the run statistics, together with the VM debug info.
+func (s *RunStats) Log(l log.Logger, info *mipsevm.DebugInfo) {
+	l.Info("Run statistics",
+		"steps", s.Steps,
+		"pages", info.Pages,
+		"peakPages", s.PeakPages,
+		"peakHeap", mipsevm.HexU32(s.PeakHeap),
+		"merkleNodes", info.MerkleNodes,
+		"preimageRequests", info.NumPreimageRequests,
+		"preimageBytes", info.TotalPreimageSize,
+		"hints", info.NumHints,
+		"hintBytes", info.TotalHintSize,
+	)
+	for _, category := range s.categories() {
+		l.Info("Syscall statistics", "category", category, "steps", s.SyscallSteps[category])
+	}
+}
+
+// WriteMetrics writes the run statistics to path in the Prometheus text format,
+// e.g. for the node exporter textfile collector.
+func (s *RunStats) WriteMetrics(path string, info *mipsevm.DebugInfo) error {
+	registry := prometheus.NewRegistry()
+	gauge := func(name, help string, value float64) {
+		g := prometheus.NewGauge(prometheus.GaugeOpts{Namespace: metricsNamespace, Name: name, Help: help})
+		g.Set(value)
+		registry.MustRegister(g)
+	}
+	gauge("steps", "Number of steps executed in the run", float64(s.Steps))
+	gauge("pages", "Number of memory pages allocated at the end of the run", float64(info.Pages))
+	gauge("peak_pages", "Peak number of memory pages allocated during the run", float64(s.PeakPages))
+	gauge("peak_heap", "Peak top of the guest heap during the run", float64(s.PeakHeap))
+	gauge("merkle_nodes", "Number of cached merkle nodes at the end of the run", float64(info.MerkleNodes))
+	gauge("preimage_requests", "Number of preimages requested in the run", float64(info.NumPreimageRequests))
+	gauge("preimage_bytes", "Total size of the preimages requested in the run", float64(info.TotalPreimageSize))
+	gauge("hints", "Number of hints written in the run", float64(info.NumHints))
+	gauge("hint_bytes", "Total size of the hints written in the run", float64(info.TotalHintSize))
+
+	syscalls := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "syscall_steps",
+		Help:      "Number of syscall steps executed in the run, by syscall category",
+	}, []string{"category"})
+	for category, steps := range s.SyscallSteps {
+		syscalls.WithLabelValues(category).Set(float64(steps))
+	}
+	registry.MustRegister(syscalls)
+	return prometheus.WriteToTextfile(path, registry)
+}
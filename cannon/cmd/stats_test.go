@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+)
+
+func TestRunStats(t *testing.T) {
+	state := singlethreaded.CreateEmptyState()
+	state.Memory.SetMemory(0, 0x0000000C) // syscall
+	state.Registers[2] = exec.SysWrite
+	state.Heap = 0x2000_0000
+
+	stats := NewRunStats()
+	stats.Observe(state)
+	// The heap and memory grow during the run
+	state.Heap = 0x3000_0000
+	state.Memory.SetMemory(0x1000_0000, 1)
+	state.Memory.SetMemory(0x2000_0000, 1)
+	stats.Observe(state)
+	// The peak is kept when the state shrinks
+	state.Heap = 0x2800_0000
+	state.Memory = singlethreaded.CreateEmptyState().Memory
+	state.Memory.SetMemory(0, 0x0000000C)
+	stats.Observe(state)
+
+	require.Equal(t, uint64(3), stats.Steps)
+	require.Equal(t, uint32(0x3000_0000), stats.PeakHeap, "must track the peak heap during the run")
+	require.Equal(t, 3, stats.PeakPages)
+	require.Equal(t, map[string]uint64{"io": 3}, stats.SyscallSteps)
+
+	// The final state is recorded without counting a step
+	state.Heap = 0x4000_0000
+	stats.Finish(state)
+	require.Equal(t, uint64(3), stats.Steps)
+	require.Equal(t, uint32(0x4000_0000), stats.PeakHeap)
+
+	path := filepath.Join(t.TempDir(), "cannon.prom")
+	require.NoError(t, stats.WriteMetrics(path, &mipsevm.DebugInfo{}))
+	metrics, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(metrics), "cannon_peak_heap 1.073741824e+09\n")
+	require.Contains(t, string(metrics), "cannon_peak_pages 3\n")
+	require.Contains(t, string(metrics), "cannon_syscall_steps{category=\"io\"} 3\n")
+}
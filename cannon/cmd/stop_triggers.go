@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+)
+
+// StopTriggers checks the VM state against the event based stop conditions of cannon run.
+// All triggers are checked before an instruction is executed, so the run stops with the state
+// right before the event, ready to prove the step that causes it.
+type StopTriggers struct {
+	// pc and syscall triggers share their matching with the run breakpoints
+	breakpoints *Breakpoints
+
+	preimageKeys [][]byte
+
+	onExit      bool
+	exitCodes   map[uint8]struct{}
+	anyExitCode bool
+}
+
+// NewStopTriggers parses the stop trigger specs:
+//   - "pc=ADDR" or "pc=ADDR@n" stops when the PC is reached, or at the n-th time it is reached.
+//   - "syscall=N" stops when syscall number N is invoked, "syscall=any" at every syscall.
+//   - "preimage=KEY" stops when the preimage with the given key, or key prefix, is first read.
+//   - "exit" stops when the exit code is about to be set, "exit=N" only when it's set to N.
+func NewStopTriggers(specs []string) (*StopTriggers, error) {
+	t := &StopTriggers{exitCodes: make(map[uint8]struct{})}
+	var pcs, syscalls []string
+	for _, spec := range specs {
+		kind, value, hasValue := strings.Cut(spec, "=")
+		switch kind {
+		case "pc":
+			pcs = append(pcs, value)
+		case "syscall":
+			syscalls = append(syscalls, value)
+		case "preimage":
+			key, err := hexutil.Decode(value)
+			if err != nil || len(key) == 0 || len(key) > 32 {
+				return nil, fmt.Errorf("invalid stop preimage key %q", spec)
+			}
+			t.preimageKeys = append(t.preimageKeys, key)
+		case "exit":
+			t.onExit = true
+			if !hasValue {
+				t.anyExitCode = true
+				continue
+			}
+			code, err := strconv.ParseUint(value, 0, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid stop exit code %q: %w", spec, err)
+			}
+			t.exitCodes[uint8(code)] = struct{}{}
+		default:
+			return nil, fmt.Errorf("unknown stop trigger %q", spec)
+		}
+	}
+	b, err := NewBreakpoints(pcs, nil, syscalls)
+	if err != nil {
+		return nil, err
+	}
+	t.breakpoints = b
+	return t, nil
+}
+
+// Check checks the triggers against the instruction that is about to be executed.
+// It returns a description of the trigger that was hit, if any.
+func (t *StopTriggers) Check(state mipsevm.FPVMState) (string, bool) {
+	if reason, hit := t.breakpoints.BeforeStep(state); hit {
+		return reason, true
+	}
+	if len(t.preimageKeys) == 0 && !t.onExit {
+		return "", false
+	}
+	pc := state.GetPC()
+	if _, opcode, fun := exec.GetInstructionDetails(pc, state.GetMemory()); opcode != 0 || fun != 0xC {
+		return "", false
+	}
+	regs := state.GetRegistersRef()
+	switch regs[2] {
+	case exec.SysRead:
+		if regs[4] != exec.FdPreimageRead || state.GetPreimageOffset() != 0 {
+			return "", false
+		}
+		key := state.GetPreimageKey()
+		for _, prefix := range t.preimageKeys {
+			if bytes.HasPrefix(key[:], prefix) {
+				return fmt.Sprintf("preimage %s requested at pc %08x", key, pc), true
+			}
+		}
+	case exec.SysExit:
+		// exiting a thread only sets the exit code if it's the last thread
+		if vs, ok := state.(*versions.VersionedState); ok {
+			state = vs.FPVMState
+		}
+		if mt, ok := state.(*multithreaded.State); !ok || mt.ThreadCount() != 1 {
+			return "", false
+		}
+		return t.checkExit(uint8(regs[4]), pc)
+	case exec.SysExitGroup:
+		return t.checkExit(uint8(regs[4]), pc)
+	}
+	return "", false
+}
+
+func (t *StopTriggers) checkExit(code uint8, pc uint32) (string, bool) {
+	if !t.onExit {
+		return "", false
+	}
+	if _, ok := t.exitCodes[code]; ok || t.anyExitCode {
+		return fmt.Sprintf("exit with code %d at pc %08x", code, pc), true
+	}
+	return "", false
+}
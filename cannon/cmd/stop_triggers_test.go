@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+)
+
+func TestNewStopTriggersInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		err  string
+	}{
+		{name: "Unknown", spec: "step=10", err: "unknown stop trigger"},
+		{name: "PC", spec: "pc=foo", err: "invalid break pc"},
+		{name: "Syscall", spec: "syscall=write", err: "invalid break syscall"},
+		{name: "PreimageKey", spec: "preimage=foo", err: "invalid stop preimage key"},
+		{name: "EmptyPreimageKey", spec: "preimage=0x", err: "invalid stop preimage key"},
+		{name: "LongPreimageKey", spec: "preimage=0x" + common.Bytes2Hex(make([]byte, 33)), err: "invalid stop preimage key"},
+		{name: "ExitCode", spec: "exit=256", err: "invalid stop exit code"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewStopTriggers([]string{test.spec})
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestStopTriggers(t *testing.T) {
+	syscallState := func(num uint32, a0 uint32) *singlethreaded.State {
+		state := singlethreaded.CreateEmptyState()
+		state.Memory.SetMemory(0, 0x0000000C)
+		state.Registers[2] = num
+		state.Registers[4] = a0
+		return state
+	}
+
+	t.Run("PC", func(t *testing.T) {
+		triggers, err := NewStopTriggers([]string{"pc=0x100@2"})
+		require.NoError(t, err)
+		state := singlethreaded.CreateEmptyState()
+		state.Cpu.PC = 0x100
+		_, hit := triggers.Check(state)
+		require.False(t, hit)
+		desc, hit := triggers.Check(state)
+		require.True(t, hit)
+		require.Equal(t, "pc 00000100 reached (hit 2)", desc)
+	})
+
+	t.Run("Syscall", func(t *testing.T) {
+		triggers, err := NewStopTriggers([]string{"syscall=4004"})
+		require.NoError(t, err)
+		_, hit := triggers.Check(syscallState(exec.SysRead, 0))
+		require.False(t, hit)
+		desc, hit := triggers.Check(syscallState(exec.SysWrite, 0))
+		require.True(t, hit)
+		require.Equal(t, "syscall 4004 at pc 00000000", desc)
+	})
+
+	t.Run("Preimage", func(t *testing.T) {
+		key := common.Hash{0x02, 0xaa, 0xbb}
+		triggers, err := NewStopTriggers([]string{"preimage=0x02aa"})
+		require.NoError(t, err)
+		state := syscallState(exec.SysRead, exec.FdPreimageRead)
+		_, hit := triggers.Check(state)
+		require.False(t, hit, "must not stop on other preimages")
+
+		state.PreimageKey = key
+		desc, hit := triggers.Check(state)
+		require.True(t, hit, "must match key prefixes")
+		require.Equal(t, "preimage "+key.Hex()+" requested at pc 00000000", desc)
+
+		state.PreimageOffset = 8
+		_, hit = triggers.Check(state)
+		require.False(t, hit, "must only stop on the first read")
+
+		state.PreimageOffset = 0
+		state.Registers[4] = exec.FdStdin
+		_, hit = triggers.Check(state)
+		require.False(t, hit, "must only stop on preimage reads")
+
+		state.Registers[4] = exec.FdPreimageRead
+		state.Memory.SetMemory(0, 0x24080005)
+		_, hit = triggers.Check(state)
+		require.False(t, hit, "must only stop on syscall instructions")
+	})
+
+	t.Run("AnyExit", func(t *testing.T) {
+		triggers, err := NewStopTriggers([]string{"exit"})
+		require.NoError(t, err)
+		desc, hit := triggers.Check(syscallState(exec.SysExitGroup, 3))
+		require.True(t, hit)
+		require.Equal(t, "exit with code 3 at pc 00000000", desc)
+	})
+
+	t.Run("ExitCode", func(t *testing.T) {
+		triggers, err := NewStopTriggers([]string{"exit=1"})
+		require.NoError(t, err)
+		_, hit := triggers.Check(syscallState(exec.SysExitGroup, 0))
+		require.False(t, hit, "must only stop on the given exit code")
+		_, hit = triggers.Check(syscallState(exec.SysExitGroup, 1))
+		require.True(t, hit)
+	})
+
+	t.Run("NoExitTrigger", func(t *testing.T) {
+		triggers, err := NewStopTriggers([]string{"preimage=0x02"})
+		require.NoError(t, err)
+		_, hit := triggers.Check(syscallState(exec.SysExitGroup, 0))
+		require.False(t, hit)
+	})
+
+	t.Run("ThreadExit", func(t *testing.T) {
+		triggers, err := NewStopTriggers([]string{"exit"})
+		require.NoError(t, err)
+		state := multithreaded.CreateEmptyState()
+		state.Memory.SetMemory(0, 0x0000000C)
+		state.GetCurrentThread().Registers[2] = exec.SysExit
+		state.GetCurrentThread().Registers[4] = 2
+		other := multithreaded.CreateEmptyThread()
+		other.ThreadId = state.NextThreadId
+		state.NextThreadId++
+		state.RightThreadStack = append(state.RightThreadStack, other)
+		_, hit := triggers.Check(state)
+		require.False(t, hit, "exiting a thread while others remain must not stop")
+
+		state.RightThreadStack = nil
+		versioned, err := versions.NewFromState(state)
+		require.NoError(t, err)
+		desc, hit := triggers.Check(versioned)
+		require.True(t, hit, "exiting the last thread sets the exit code")
+		require.Equal(t, "exit with code 2 at pc 00000000", desc)
+	})
+
+	t.Run("SingleThreadedExit", func(t *testing.T) {
+		triggers, err := NewStopTriggers([]string{"exit"})
+		require.NoError(t, err)
+		_, hit := triggers.Check(syscallState(exec.SysExit, 0))
+		require.False(t, hit, "only exit_group exits the single-threaded VM")
+	})
+}
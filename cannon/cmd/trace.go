@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+)
+
+// PCRangeFlag is a cli flag value that parses an inclusive "start-end" range of program counters.
+type PCRangeFlag struct {
+	repr  string
+	start uint32
+	end   uint32
+	set   bool
+}
+
+func (r *PCRangeFlag) Set(value string) error {
+	parts := strings.Split(value, "-")
+	if len(parts) != 2 {
+		return fmt.Errorf("expected pc range in the form start-end, but got %q", value)
+	}
+	start, err := strconv.ParseUint(parts[0], 0, 32)
+	if err != nil {
+		return fmt.Errorf("failed to parse range start: %w", err)
+	}
+	end, err := strconv.ParseUint(parts[1], 0, 32)
+	if err != nil {
+		return fmt.Errorf("failed to parse range end: %w", err)
+	}
+	if start > end {
+		return fmt.Errorf("range start %#x is after range end %#x", start, end)
+	}
+	r.repr = value
+	r.start = uint32(start)
+	r.end = uint32(end)
+	r.set = true
+	return nil
+}
+
+func (r *PCRangeFlag) String() string {
+	return r.repr
+}
+
+// Contains returns true if the pc is within the range, or if no range was set.
+func (r *PCRangeFlag) Contains(pc uint32) bool {
+	return !r.set || (pc >= r.start && pc <= r.end)
+}
+
+func (r *PCRangeFlag) Clone() any {
+	out := *r
+	return &out
+}
+
+type RegDelta struct {
+	Reg  uint32         `json:"reg"`
+	From mipsevm.HexU32 `json:"from"`
+	To   mipsevm.HexU32 `json:"to"`
+}
+
+type MemAccess struct {
+	Addr  mipsevm.HexU32  `json:"addr"`
+	Value mipsevm.HexU32  `json:"value"`
+	Write *mipsevm.HexU32 `json:"write,omitempty"`
+}
+
+type SyscallTrace struct {
+	Num  uint32            `json:"num"`
+	Args [4]mipsevm.HexU32 `json:"args"`
+	V0   mipsevm.HexU32    `json:"v0"`
+	V1   mipsevm.HexU32    `json:"v1"`
+}
+
+// TraceEntry is a single line of the JSONL instruction trace.
+type TraceEntry struct {
+	Step    uint64         `json:"step"`
+	PC      mipsevm.HexU32 `json:"pc"`
+	Insn    mipsevm.HexU32 `json:"insn"`
+	Opcode  uint32         `json:"opcode"`
+	Fun     uint32         `json:"fun"`
+	Regs    []RegDelta     `json:"regs,omitempty"`
+	HI      *RegDelta      `json:"hi,omitempty"`
+	LO      *RegDelta      `json:"lo,omitempty"`
+	Mem     *MemAccess     `json:"mem,omitempty"`
+	Syscall *SyscallTrace  `json:"syscall,omitempty"`
+}
+
+// InstructionTracer records the effects of each executed instruction as a JSON line.
+// Call Before prior to executing a step, and After once the step completed.
+type InstructionTracer struct {
+	out          *bufio.Writer
+	enc          *json.Encoder
+	pcRange      *PCRangeFlag
+	syscallsOnly bool
+
+	// pre-step capture
+	active    bool
+	entry     TraceEntry
+	regsRef   *[32]uint32
+	preRegs   [32]uint32
+	preCpu    mipsevm.CpuScalars
+	isSyscall bool
+	memAddr   uint32
+	isMemOp   bool
+}
+
+func NewInstructionTracer(w io.Writer, pcRange *PCRangeFlag, syscallsOnly bool) *InstructionTracer {
+	out := bufio.NewWriter(w)
+	return &InstructionTracer{
+		out:          out,
+		enc:          json.NewEncoder(out),
+		pcRange:      pcRange,
+		syscallsOnly: syscallsOnly,
+	}
+}
+
+func (t *InstructionTracer) Before(state mipsevm.FPVMState) {
+	pc := state.GetPC()
+	insn, opcode, fun := exec.GetInstructionDetails(pc, state.GetMemory())
+	t.isSyscall = opcode == 0 && fun == 0xC
+	t.active = t.pcRange.Contains(pc) && (!t.syscallsOnly || t.isSyscall)
+	if !t.active {
+		return
+	}
+	t.regsRef = state.GetRegistersRef()
+	t.preRegs = *t.regsRef
+	t.preCpu = state.GetCpu()
+	t.entry = TraceEntry{
+		Step:   state.GetStep(),
+		PC:     mipsevm.HexU32(pc),
+		Insn:   mipsevm.HexU32(insn),
+		Opcode: opcode,
+		Fun:    fun,
+	}
+	// loads and stores, see exec.ExecMipsCoreStepLogic
+	t.isMemOp = opcode >= 0x20
+	if t.isMemOp {
+		rs := t.preRegs[(insn>>21)&0x1F]
+		t.memAddr = (rs + exec.SignExtend(insn&0xFFFF, 16)) & 0xFFFFFFFC
+		t.entry.Mem = &MemAccess{
+			Addr:  mipsevm.HexU32(t.memAddr),
+			Value: mipsevm.HexU32(state.GetMemory().GetMemory(t.memAddr)),
+		}
+	}
+	if t.isSyscall {
+		t.entry.Syscall = &SyscallTrace{
+			Num: t.preRegs[2],
+			Args: [4]mipsevm.HexU32{
+				mipsevm.HexU32(t.preRegs[4]),
+				mipsevm.HexU32(t.preRegs[5]),
+				mipsevm.HexU32(t.preRegs[6]),
+				mipsevm.HexU32(t.preRegs[7]),
+			},
+		}
+	}
+}
+
+func (t *InstructionTracer) After(state mipsevm.FPVMState) error {
+	if !t.active {
+		return nil
+	}
+	t.active = false
+	postRegs := *t.regsRef
+	for i := range postRegs {
+		if postRegs[i] != t.preRegs[i] {
+			t.entry.Regs = append(t.entry.Regs, RegDelta{Reg: uint32(i), From: mipsevm.HexU32(t.preRegs[i]), To: mipsevm.HexU32(postRegs[i])})
+		}
+	}
+	// HI/LO are only comparable if the same thread is still active
+	if t.regsRef == state.GetRegistersRef() {
+		postCpu := state.GetCpu()
+		if postCpu.HI != t.preCpu.HI {
+			t.entry.HI = &RegDelta{Reg: 0, From: mipsevm.HexU32(t.preCpu.HI), To: mipsevm.HexU32(postCpu.HI)}
+		}
+		if postCpu.LO != t.preCpu.LO {
+			t.entry.LO = &RegDelta{Reg: 0, From: mipsevm.HexU32(t.preCpu.LO), To: mipsevm.HexU32(postCpu.LO)}
+		}
+	}
+	if t.isMemOp {
+		if v := mipsevm.HexU32(state.GetMemory().GetMemory(t.memAddr)); v != t.entry.Mem.Value {
+			t.entry.Mem.Write = &v
+		}
+	}
+	if t.isSyscall {
+		t.entry.Syscall.V0 = mipsevm.HexU32(postRegs[2])
+		t.entry.Syscall.V1 = mipsevm.HexU32(postRegs[7])
+	}
+	if err := t.enc.Encode(&t.entry); err != nil {
+		return fmt.Errorf("failed to write trace entry: %w", err)
+	}
+	return nil
+}
+
+// Flush writes any buffered trace entries to the underlying writer.
+func (t *InstructionTracer) Flush() error {
+	return t.out.Flush()
+}
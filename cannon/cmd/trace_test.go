@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+)
+
+func TestPCRangeFlag(t *testing.T) {
+	var r PCRangeFlag
+	require.True(t, r.Contains(0x1234), "unset range contains all pcs")
+	require.NoError(t, r.Set("0x1000-0x2000"))
+	require.Equal(t, "0x1000-0x2000", r.String())
+	require.True(t, r.Contains(0x1000))
+	require.True(t, r.Contains(0x2000))
+	require.False(t, r.Contains(0xffc))
+	require.False(t, r.Contains(0x2004))
+
+	require.ErrorContains(t, new(PCRangeFlag).Set("0x1000"), "start-end")
+	require.ErrorContains(t, new(PCRangeFlag).Set("0x2000-0x1000"), "is after range end")
+	require.ErrorContains(t, new(PCRangeFlag).Set("0x1000-foo"), "range end")
+}
+
+// traceProgram runs a small program: addiu $t0, $zero, 5; sw $t0, 0x100($zero); syscall (brk),
+// and returns the lines of the trace and the final state.
+func traceProgram(t *testing.T, pcRange *PCRangeFlag, syscallsOnly bool) ([]string, *singlethreaded.State) {
+	state := singlethreaded.CreateEmptyState()
+	state.Memory.SetMemory(0, 0x24080005)
+	state.Memory.SetMemory(4, 0xAC080100)
+	state.Memory.SetMemory(8, 0x0000000C)
+	state.Memory.SetMemory(0x100, 0x1)
+	state.Cpu.NextPC = 4
+	us := singlethreaded.NewInstrumentedState(state, nil, nil, nil, nil)
+
+	var out bytes.Buffer
+	tracer := NewInstructionTracer(&out, pcRange, syscallsOnly)
+	for i := 0; i < 3; i++ {
+		if i == 2 {
+			state.Registers[2] = exec.SysBrk
+		}
+		tracer.Before(state)
+		_, err := us.Step(false)
+		require.NoError(t, err)
+		require.NoError(t, tracer.After(state))
+	}
+	require.NoError(t, tracer.Flush())
+	return strings.Split(strings.TrimSpace(out.String()), "\n"), state
+}
+
+func TestInstructionTracer(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		lines, state := traceProgram(t, new(PCRangeFlag), false)
+		require.Len(t, lines, 3)
+		require.JSONEq(t, `{"step":0,"pc":"00000000","insn":"24080005","opcode":9,"fun":5,"regs":[{"reg":8,"from":"00000000","to":"00000005"}]}`, lines[0])
+		require.JSONEq(t, `{"step":1,"pc":"00000004","insn":"ac080100","opcode":43,"fun":0,"mem":{"addr":"00000100","value":"00000001","write":"00000005"}}`, lines[1])
+		v0 := mipsevm.HexU32(state.Registers[2]).String()
+		require.JSONEq(t, `{"step":2,"pc":"00000008","insn":"0000000c","opcode":0,"fun":12,`+
+			`"regs":[{"reg":2,"from":"00000fcd","to":"`+v0+`"}],`+
+			`"syscall":{"num":4045,"args":["00000000","00000000","00000000","00000000"],"v0":"`+v0+`","v1":"00000000"}}`, lines[2])
+	})
+	t.Run("PCRange", func(t *testing.T) {
+		var r PCRangeFlag
+		require.NoError(t, r.Set("4-8"))
+		lines, _ := traceProgram(t, &r, false)
+		require.Len(t, lines, 2)
+		require.Contains(t, lines[0], `"pc":"00000004"`)
+		require.Contains(t, lines[1], `"pc":"00000008"`)
+	})
+	t.Run("SyscallsOnly", func(t *testing.T) {
+		lines, _ := traceProgram(t, new(PCRangeFlag), true)
+		require.Len(t, lines, 1)
+		require.Contains(t, lines[0], `"syscall":{"num":4045`)
+	})
+}
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+)
+
+var ErrNonDeterministic = errors.New("runs diverged")
+
+var (
+	VerifyDeterminismInputFlag = &cli.PathFlag{
+		Name:      "input",
+		Usage:     "path of input JSON state.",
+		TakesFile: true,
+		Required:  true,
+	}
+	VerifyDeterminismIntervalFlag = &cli.Uint64Flag{
+		Name:  "interval",
+		Usage: "number of steps between state witness comparisons.",
+		Value: 1_000_000,
+	}
+	VerifyDeterminismMaxStepsFlag = &cli.Uint64Flag{
+		Name:  "max-steps",
+		Usage: "step to stop comparing at. The runs are compared until the VM exits if 0.",
+	}
+	VerifyDeterminismOtherCannonFlag = &cli.PathFlag{
+		Name: "other-cannon",
+		Usage: "path of another cannon binary to compare against, e.g. a previous release. " +
+			"It must support 'witness --stream'. The second run uses this binary rather than running in-process.",
+		TakesFile: true,
+	}
+)
+
+// hashSource executes a VM and reports its state hash at requested steps, in ascending order.
+type hashSource interface {
+	// StateHashAt runs the VM up to the given step, and returns the reached step and the state hash.
+	// The reached step is before the requested step if the VM exited.
+	StateHashAt(ctx context.Context, step uint64) (uint64, common.Hash, error)
+	Close() error
+}
+
+// localHashSource runs the VM in-process.
+type localHashSource struct {
+	vm    mipsevm.FPVM
+	po    *ProcessPreimageOracle
+	state mipsevm.FPVMState
+}
+
+func newLocalHashSource(input string, args []string, name string) (*localHashSource, error) {
+	state, err := versions.LoadStateFromFile(input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input state (%v): %w", input, err)
+	}
+	l := Logger(os.Stderr, log.LevelInfo).With("module", "vm", "run", name)
+	guestLogger := Logger(os.Stderr, log.LevelInfo).With("run", name)
+	outLog := &mipsevm.LoggingWriter{Log: guestLogger.With("module", "guest", "stream", "stdout")}
+	errLog := &mipsevm.LoggingWriter{Log: guestLogger.With("module", "guest", "stream", "stderr")}
+	hostLogger := Logger(os.Stderr, log.LevelInfo).With("module", "host", "run", name)
+	po, err := NewProcessPreimageOracle(args[0], args[1:], hostLogger, hostLogger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pre-image oracle process: %w", err)
+	}
+	if err := po.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start pre-image oracle server: %w", err)
+	}
+	vm := state.CreateVM(l, po, outLog, errLog, &program.Metadata{})
+	return &localHashSource{vm: vm, po: po, state: vm.GetState()}, nil
+}
+
+func (s *localHashSource) StateHashAt(ctx context.Context, step uint64) (uint64, common.Hash, error) {
+	for s.state.GetStep() < step && !s.state.GetExited() {
+		if s.state.GetStep()%100 == 0 { // don't check the context too often
+			if err := ctx.Err(); err != nil {
+				return 0, common.Hash{}, err
+			}
+		}
+		if _, err := s.vm.Step(false); err != nil {
+			return 0, common.Hash{}, fmt.Errorf("failed at step %d: %w", s.state.GetStep(), err)
+		}
+	}
+	_, hash := s.state.EncodeWitness()
+	return s.state.GetStep(), hash, nil
+}
+
+func (s *localHashSource) Close() error {
+	return s.po.Close()
+}
+
+// processHashSource runs the VM with another cannon binary, proving the requested steps with 'witness --stream'.
+type processHashSource struct {
+	cmd *exec.Cmd
+	in  io.WriteCloser
+	dec *json.Decoder
+}
+
+func newProcessHashSource(ctx context.Context, binary string, input string, args []string) (*processHashSource, error) {
+	cmdArgs := append([]string{"witness", "--input", input, "--stream", "--"}, args...)
+	cmd := exec.CommandContext(ctx, binary, cmdArgs...)
+	cmd.Stderr = os.Stderr
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start %v: %w", binary, err)
+	}
+	return &processHashSource{cmd: cmd, in: in, dec: json.NewDecoder(out)}, nil
+}
+
+func (s *processHashSource) StateHashAt(ctx context.Context, step uint64) (uint64, common.Hash, error) {
+	if _, err := fmt.Fprintf(s.in, "%d\n", step); err != nil {
+		return 0, common.Hash{}, fmt.Errorf("failed to request step %d: %w", step, err)
+	}
+	var proof Proof
+	if err := s.dec.Decode(&proof); err != nil {
+		// the stream ends early if the VM exited, or failed, before the requested step
+		return 0, common.Hash{}, fmt.Errorf("no proof for step %d: %w", step, err)
+	}
+	return proof.Step, proof.Pre, nil
+}
+
+func (s *processHashSource) Close() error {
+	_ = s.in.Close()
+	return s.cmd.Wait()
+}
+
+type hashSources struct {
+	a, b hashSource
+}
+
+func (s *hashSources) Close() {
+	_ = s.a.Close()
+	_ = s.b.Close()
+}
+
+func VerifyDeterminism(ctx *cli.Context) error {
+	input := ctx.Path(VerifyDeterminismInputFlag.Name)
+	interval := ctx.Uint64(VerifyDeterminismIntervalFlag.Name)
+	if interval == 0 {
+		return fmt.Errorf("interval must be at least 1")
+	}
+	maxSteps := ctx.Uint64(VerifyDeterminismMaxStepsFlag.Name)
+	other := ctx.Path(VerifyDeterminismOtherCannonFlag.Name)
+	l := Logger(os.Stderr, log.LevelInfo)
+
+	start := func() (*hashSources, error) {
+		args := preimageServerArgs(ctx)
+		a, err := newLocalHashSource(input, args, "a")
+		if err != nil {
+			return nil, err
+		}
+		var b hashSource
+		if other != "" {
+			b, err = newProcessHashSource(ctx.Context, other, input, args)
+		} else {
+			b, err = newLocalHashSource(input, args, "b")
+		}
+		if err != nil {
+			_ = a.Close()
+			return nil, err
+		}
+		return &hashSources{a: a, b: b}, nil
+	}
+	return findDivergence(ctx.Context, l, os.Stdout, start, interval, maxSteps)
+}
+
+// findDivergence compares the runs at every interval, until the VM exits or up to maxSteps if not 0.
+// If the runs diverge, both runs are started again and compared at every step after the last match,
+// and an error wrapping ErrNonDeterministic reports the first divergent step.
+func findDivergence(ctx context.Context, l log.Logger, out io.Writer, start func() (*hashSources, error), interval, maxSteps uint64) error {
+	// compare returns false if the runs diverge at the given step
+	compare := func(sources *hashSources, step uint64) (uint64, bool, error) {
+		reached, hashA, err := sources.a.StateHashAt(ctx, step)
+		if err != nil {
+			return 0, false, err
+		}
+		_, hashB, err := sources.b.StateHashAt(ctx, reached)
+		if err != nil {
+			l.Warn("Second run failed", "step", reached, "err", err)
+			return reached, false, nil
+		}
+		return reached, hashA == hashB, nil
+	}
+
+	sources, err := start()
+	if err != nil {
+		return err
+	}
+	// find the first interval in which the runs diverge
+	var lastMatch, divergedAt uint64
+	diverged := false
+	for step := interval; ; step += interval {
+		if maxSteps != 0 && step > maxSteps {
+			step = maxSteps
+		}
+		reached, ok, err := compare(sources, step)
+		if err != nil {
+			sources.Close()
+			return err
+		}
+		if !ok {
+			diverged, divergedAt = true, reached
+			break
+		}
+		lastMatch = reached
+		l.Info("Runs match", "step", reached)
+		if reached < step || (maxSteps != 0 && reached >= maxSteps) {
+			break
+		}
+	}
+	sources.Close()
+	if !diverged {
+		_, _ = fmt.Fprintf(out, "runs match up to step %d\n", lastMatch)
+		return nil
+	}
+
+	// replay both runs, and compare every step after the last match to find the first divergent step
+	sources, err = start()
+	if err != nil {
+		return err
+	}
+	defer sources.Close()
+	for step := lastMatch; ; step++ {
+		reached, ok, err := compare(sources, step)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			_, _ = fmt.Fprintf(out, "first divergent step: %d\n", reached)
+			return fmt.Errorf("%w at step %d, after matching up to step %d", ErrNonDeterministic, reached, lastMatch)
+		}
+		if reached < step || reached >= divergedAt {
+			return fmt.Errorf("runs did not diverge on replay, matching up to step %d", reached)
+		}
+	}
+}
+
+var VerifyDeterminismCommand = &cli.Command{
+	Name:  "verify-determinism",
+	Usage: "Run the same input twice and compare the state witnesses",
+	Description: "Run the same input twice, optionally with another cannon binary, and compare the state witnesses at a step interval. " +
+		"The first divergent step is reported. The pre-image server is started from the args after '--', once for each run.",
+	Action: VerifyDeterminism,
+	Flags: []cli.Flag{
+		VerifyDeterminismInputFlag,
+		VerifyDeterminismIntervalFlag,
+		VerifyDeterminismMaxStepsFlag,
+		VerifyDeterminismOtherCannonFlag,
+	},
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/cannon/serialize"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// stubHashSource reports the step as state hash, or a different hash from the divergeAt step onwards.
+type stubHashSource struct {
+	exitAt    uint64
+	divergeAt uint64
+	step      uint64
+}
+
+func (s *stubHashSource) StateHashAt(_ context.Context, step uint64) (uint64, common.Hash, error) {
+	s.step = min(step, s.exitAt)
+	hash := common.BigToHash(new(big.Int).SetUint64(s.step))
+	if s.divergeAt != 0 && s.step >= s.divergeAt {
+		hash[0] = 0xff
+	}
+	return s.step, hash, nil
+}
+
+func (s *stubHashSource) Close() error {
+	return nil
+}
+
+func TestFindDivergence(t *testing.T) {
+	const noExit = ^uint64(0)
+	run := func(t *testing.T, exitAt, divergeAt, interval, maxSteps uint64) (string, int, error) {
+		starts := 0
+		start := func() (*hashSources, error) {
+			starts++
+			return &hashSources{
+				a: &stubHashSource{exitAt: exitAt},
+				b: &stubHashSource{exitAt: exitAt, divergeAt: divergeAt},
+			}, nil
+		}
+		var out bytes.Buffer
+		err := findDivergence(context.Background(), testlog.Logger(t, log.LevelInfo), &out, start, interval, maxSteps)
+		return out.String(), starts, err
+	}
+
+	t.Run("MatchUpToMaxSteps", func(t *testing.T) {
+		out, starts, err := run(t, noExit, 0, 10, 25)
+		require.NoError(t, err)
+		require.Equal(t, "runs match up to step 25\n", out)
+		require.Equal(t, 1, starts)
+	})
+
+	t.Run("MatchUntilExit", func(t *testing.T) {
+		out, _, err := run(t, 17, 0, 10, 0)
+		require.NoError(t, err)
+		require.Equal(t, "runs match up to step 17\n", out)
+	})
+
+	t.Run("Diverge", func(t *testing.T) {
+		out, starts, err := run(t, noExit, 23, 10, 0)
+		require.ErrorIs(t, err, ErrNonDeterministic)
+		require.ErrorContains(t, err, "at step 23, after matching up to step 20")
+		require.Equal(t, "first divergent step: 23\n", out)
+		require.Equal(t, 2, starts, "must replay the runs to find the divergent step")
+	})
+
+	t.Run("DivergeInFirstInterval", func(t *testing.T) {
+		out, _, err := run(t, noExit, 1, 10, 0)
+		require.ErrorIs(t, err, ErrNonDeterministic)
+		require.Equal(t, "first divergent step: 1\n", out)
+	})
+}
+
+func TestVerifyDeterminism(t *testing.T) {
+	// the empty state executes no-ops from pc 0, and doesn't need a pre-image server
+	state, err := versions.NewFromState(singlethreaded.CreateEmptyState())
+	require.NoError(t, err)
+	input := filepath.Join(t.TempDir(), "state.bin.gz")
+	require.NoError(t, serialize.Write(input, state, 0o644))
+
+	app := cli.NewApp()
+	app.Commands = []*cli.Command{VerifyDeterminismCommand}
+	require.NoError(t, app.Run([]string{"cannon", "verify-determinism", "--input", input, "--interval", "3", "--max-steps", "10"}))
+	require.ErrorContains(t, app.Run([]string{"cannon", "verify-determinism", "--input", input, "--interval", "0"}), "interval must be at least 1")
+}
@@ -1,11 +1,23 @@
 package cmd
 
 import (
+	"bufio"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 
-	factory "github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	factory "github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
 )
 
 var (
@@ -20,6 +32,31 @@ var (
 		Usage:     "path to write binary witness.",
 		TakesFile: true,
 	}
+	WitnessStreamFlag = &cli.BoolFlag{
+		Name: "stream",
+		Usage: "keep the VM running and prove steps on demand: step numbers are read from stdin, one per line in ascending order, " +
+			"and a JSON proof is written to stdout for each of them. The pre-image server is started from the args after '--'.",
+	}
+	WitnessAtStepsFlag = &cli.StringFlag{
+		Name: "at-steps",
+		Usage: "comma separated list of steps to prove, in a single execution pass. " +
+			"The pre-image server is started from the args after '--'.",
+	}
+	WitnessStepsFileFlag = &cli.PathFlag{
+		Name:      "steps-file",
+		Usage:     "path of a proof-request file, with the steps to prove in a single execution pass, one per line or comma separated.",
+		TakesFile: true,
+	}
+	WitnessProofFmtFlag = &cli.StringFlag{
+		Name:  "proof-fmt",
+		Usage: "format for proof data output file names, for --at-steps and --steps-file. Proof data is written to stdout if -.",
+		Value: "proof-%d.json",
+	}
+	WitnessSSZFlag = &cli.BoolFlag{
+		Name: "ssz",
+		Usage: "write the binary witness as a StateWitness SSZ container, and proofs as StepProof SSZ containers instead of JSON. " +
+			"Not supported with --stream.",
+	}
 )
 
 func Witness(ctx *cli.Context) error {
@@ -29,7 +66,28 @@ func Witness(ctx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("invalid input state (%v): %w", input, err)
 	}
+	steps, err := requestedSteps(ctx)
+	if err != nil {
+		return err
+	}
+	if ctx.Bool(WitnessStreamFlag.Name) {
+		if len(steps) != 0 {
+			return fmt.Errorf("cannot specify both --stream and steps to prove")
+		}
+		if ctx.Bool(WitnessSSZFlag.Name) {
+			return fmt.Errorf("cannot specify both --stream and --ssz")
+		}
+		return streamWitnesses(ctx, state, os.Stdin, os.Stdout)
+	}
+	if len(steps) != 0 {
+		return batchWitnesses(ctx, state, steps, ctx.String(WitnessProofFmtFlag.Name))
+	}
 	witness, h := state.EncodeWitness()
+	if ctx.Bool(WitnessSSZFlag.Name) {
+		if witness, err = mipsevm.EncodeStateWitnessSSZ(witness, h); err != nil {
+			return fmt.Errorf("failed to encode witness: %w", err)
+		}
+	}
 	if output != "" {
 		if err := os.WriteFile(output, witness, 0755); err != nil {
 			return fmt.Errorf("writing output to %v: %w", output, err)
@@ -39,6 +97,137 @@ func Witness(ctx *cli.Context) error {
 	return nil
 }
 
+// requestedSteps returns the steps of --at-steps and --steps-file, sorted and without duplicates.
+func requestedSteps(ctx *cli.Context) ([]uint64, error) {
+	var specs []string
+	if v := ctx.String(WitnessAtStepsFlag.Name); v != "" {
+		specs = append(specs, strings.Split(v, ",")...)
+	}
+	if path := ctx.Path(WitnessStepsFileFlag.Name); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read steps file: %w", err)
+		}
+		specs = append(specs, strings.FieldsFunc(string(data), func(r rune) bool {
+			return r == ',' || r == '\n' || r == '\r'
+		})...)
+	}
+	steps := make([]uint64, 0, len(specs))
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		step, err := strconv.ParseUint(spec, 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid step %q: %w", spec, err)
+		}
+		steps = append(steps, step)
+	}
+	slices.Sort(steps)
+	return slices.Compact(steps), nil
+}
+
+// newWitnessStream starts the pre-image server, and creates a witness stream running the VM from the given state.
+// The returned function stops the pre-image server.
+func newWitnessStream(ctx *cli.Context, state *factory.VersionedState) (*mipsevm.WitnessStream, func(), error) {
+	l := Logger(os.Stderr, log.LevelInfo).With("module", "vm")
+	guestLogger := Logger(os.Stderr, log.LevelInfo)
+	outLog := &mipsevm.LoggingWriter{Log: guestLogger.With("module", "guest", "stream", "stdout")}
+	errLog := &mipsevm.LoggingWriter{Log: guestLogger.With("module", "guest", "stream", "stderr")}
+
+	args := preimageServerArgs(ctx)
+	po, err := NewProcessPreimageOracle(args[0], args[1:], Logger(os.Stderr, log.LevelInfo).With("module", "host"), Logger(os.Stderr, log.LevelInfo).With("module", "host"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create pre-image oracle process: %w", err)
+	}
+	if err := po.Start(); err != nil {
+		return nil, nil, fmt.Errorf("failed to start pre-image oracle server: %w", err)
+	}
+	closeOracle := func() {
+		if err := po.Close(); err != nil {
+			l.Error("failed to close pre-image server", "err", err)
+		}
+	}
+	return mipsevm.NewWitnessStream(state.CreateVM(l, po, outLog, errLog, &program.Metadata{})), closeOracle, nil
+}
+
+// batchWitnesses proves all the given steps, in ascending order, during a single execution of the VM.
+func batchWitnesses(ctx *cli.Context, state *factory.VersionedState, steps []uint64, proofFmt string) error {
+	stream, closeOracle, err := newWitnessStream(ctx, state)
+	if err != nil {
+		return err
+	}
+	defer closeOracle()
+	for _, step := range steps {
+		proof, err := stream.ProveStep(ctx.Context, step)
+		if err != nil {
+			return err
+		}
+		out := ioutil.ToStdOutOrFileOrNoop(fmt.Sprintf(proofFmt, step), OutFilePerm)
+		if ctx.Bool(WitnessSSZFlag.Name) {
+			err = writeSSZ(proof, out)
+		} else {
+			err = jsonutil.WriteJSON(NewProof(proof.Step, proof.Witness, proof.PostHash), out)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write proof data: %w", err)
+		}
+	}
+	return nil
+}
+
+// writeSSZ writes the proof as a StepProof SSZ container.
+func writeSSZ(proof *mipsevm.StepProof, target ioutil.OutputTarget) error {
+	data, err := proof.MarshalSSZ()
+	if err != nil {
+		return fmt.Errorf("failed to encode: %w", err)
+	}
+	out, closer, abort, err := target()
+	if err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	defer abort()
+	if _, err := out.Write(data); err != nil {
+		return err
+	}
+	if err := closer.Close(); err != nil {
+		return fmt.Errorf("failed to finish write: %w", err)
+	}
+	return nil
+}
+
+func streamWitnesses(ctx *cli.Context, state *factory.VersionedState, in io.Reader, out io.Writer) error {
+	stream, closeOracle, err := newWitnessStream(ctx, state)
+	if err != nil {
+		return err
+	}
+	defer closeOracle()
+	enc := json.NewEncoder(out)
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		step, err := strconv.ParseUint(line, 0, 64)
+		if err != nil {
+			return fmt.Errorf("invalid step %q: %w", line, err)
+		}
+		proof, err := stream.ProveStep(ctx.Context, step)
+		if err != nil {
+			return err
+		}
+		if err := enc.Encode(NewProof(proof.Step, proof.Witness, proof.PostHash)); err != nil {
+			return fmt.Errorf("failed to write proof: %w", err)
+		}
+	}
+	return scanner.Err()
+}
+
 var WitnessCommand = &cli.Command{
 	Name:        "witness",
 	Usage:       "Convert a Cannon JSON state into a binary witness",
@@ -47,5 +236,10 @@ var WitnessCommand = &cli.Command{
 	Flags: []cli.Flag{
 		WitnessInputFlag,
 		WitnessOutputFlag,
+		WitnessStreamFlag,
+		WitnessAtStepsFlag,
+		WitnessStepsFileFlag,
+		WitnessProofFmtFlag,
+		WitnessSSZFlag,
 	},
 }
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/cannon/serialize"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+func runWitness(args ...string) error {
+	app := cli.NewApp()
+	app.Commands = []*cli.Command{WitnessCommand}
+	return app.Run(append([]string{"cannon", "witness"}, args...))
+}
+
+func TestRequestedSteps(t *testing.T) {
+	dir := t.TempDir()
+	stepsFile := filepath.Join(dir, "steps.txt")
+	require.NoError(t, os.WriteFile(stepsFile, []byte("7\r\n 0x3 ,2\n\n12\n"), 0o644))
+
+	var steps []uint64
+	app := cli.NewApp()
+	app.Flags = []cli.Flag{WitnessAtStepsFlag, WitnessStepsFileFlag}
+	app.Action = func(ctx *cli.Context) error {
+		var err error
+		steps, err = requestedSteps(ctx)
+		return err
+	}
+	require.NoError(t, app.Run([]string{"cannon", "--at-steps", "12, 5,,2", "--steps-file", stepsFile}))
+	require.Equal(t, []uint64{2, 3, 5, 7, 12}, steps, "must sort and deduplicate the steps")
+
+	require.NoError(t, app.Run([]string{"cannon"}))
+	require.Empty(t, steps)
+
+	require.ErrorContains(t, app.Run([]string{"cannon", "--at-steps", "1,foo"}), `invalid step "foo"`)
+	require.ErrorContains(t, app.Run([]string{"cannon", "--steps-file", filepath.Join(dir, "missing.txt")}), "failed to read steps file")
+}
+
+func TestBatchWitnesses(t *testing.T) {
+	dir := t.TempDir()
+	// the empty state executes no-ops from pc 0, and doesn't need a pre-image server
+	state, err := versions.NewFromState(singlethreaded.CreateEmptyState())
+	require.NoError(t, err)
+	input := filepath.Join(dir, "state.bin.gz")
+	require.NoError(t, serialize.Write(input, state, 0o644))
+	_, initialHash := state.EncodeWitness()
+
+	proofFmt := filepath.Join(dir, "proof-%d.json")
+	require.NoError(t, runWitness("--input", input, "--at-steps", "3,0,1", "--proof-fmt", proofFmt))
+
+	var prev *Proof
+	for _, step := range []uint64{0, 1, 3} {
+		proof, err := jsonutil.LoadJSON[Proof](fmt.Sprintf(proofFmt, step))
+		require.NoError(t, err)
+		require.Equal(t, step, proof.Step)
+		require.NotEmpty(t, proof.ProofData)
+		switch step {
+		case 0:
+			require.Equal(t, initialHash, proof.Pre, "must prove the step from the input state")
+		case 1:
+			require.Equal(t, prev.Post, proof.Pre, "must prove consecutive steps from the same execution")
+		}
+		prev = proof
+	}
+	require.NoFileExists(t, fmt.Sprintf(proofFmt, 2), "must only prove the requested steps")
+
+	t.Run("StreamConflict", func(t *testing.T) {
+		err := runWitness("--input", input, "--at-steps", "1", "--stream")
+		require.ErrorContains(t, err, "cannot specify both --stream and steps to prove")
+	})
+}
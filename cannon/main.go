@@ -21,6 +21,10 @@ func main() {
 		cmd.LoadELFCommand,
 		cmd.WitnessCommand,
 		cmd.RunCommand,
+		cmd.StateCommand,
+		cmd.VerifyDeterminismCommand,
+		cmd.ServePreimagesCommand,
+		cmd.GenVectorsCommand,
 	}
 	ctx := ctxinterrupt.WithSignalWaiterMain(context.Background())
 	err := app.RunContext(ctx, os.Args)
@@ -0,0 +1,100 @@
+package mipsevm
+
+import (
+	"fmt"
+	"sync"
+)
+
+// AsyncHintOracle dispatches hints to the wrapped oracle in the background,
+// so that the host can process hints, e.g. to prefetch pre-images, while the guest keeps executing.
+// Hints are queued, and Hint blocks when the queue is full.
+// Pre-image requests wait for all queued hints to complete first, so the host processes hints and requests in order.
+type AsyncHintOracle struct {
+	po    PreimageOracle
+	hints chan []byte
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	pending int
+	// failure is the panic of the wrapped oracle while processing a hint, it is raised again in the calling goroutine
+	failure any
+	done    chan struct{}
+}
+
+var _ PreimageOracle = (*AsyncHintOracle)(nil)
+
+func NewAsyncHintOracle(po PreimageOracle, queueSize int) *AsyncHintOracle {
+	o := &AsyncHintOracle{
+		po:    po,
+		hints: make(chan []byte, queueSize),
+		done:  make(chan struct{}),
+	}
+	o.cond = sync.NewCond(&o.mu)
+	go o.dispatch()
+	return o
+}
+
+func (o *AsyncHintOracle) dispatch() {
+	defer close(o.done)
+	for hint := range o.hints {
+		o.hint(hint)
+	}
+}
+
+func (o *AsyncHintOracle) hint(v []byte) {
+	defer func() {
+		r := recover()
+		o.mu.Lock()
+		if r != nil && o.failure == nil {
+			o.failure = r
+		}
+		o.pending--
+		o.cond.Broadcast()
+		o.mu.Unlock()
+	}()
+	o.po.Hint(v)
+}
+
+func (o *AsyncHintOracle) checkFailure() {
+	if o.failure != nil {
+		panic(fmt.Errorf("async hint failed: %v", o.failure))
+	}
+}
+
+// Hint queues the hint to be sent to the wrapped oracle.
+func (o *AsyncHintOracle) Hint(v []byte) {
+	o.mu.Lock()
+	o.checkFailure()
+	o.pending++
+	o.mu.Unlock()
+	// the hint is sent in the background, after the VM may have reused the buffer
+	o.hints <- append([]byte(nil), v...)
+}
+
+// Wait blocks until all queued hints have been processed.
+func (o *AsyncHintOracle) Wait() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for o.pending > 0 {
+		o.cond.Wait()
+	}
+	o.checkFailure()
+}
+
+// Pending returns the number of hints that were queued, but not processed yet.
+func (o *AsyncHintOracle) Pending() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.pending
+}
+
+func (o *AsyncHintOracle) GetPreimage(k [32]byte) []byte {
+	o.Wait()
+	return o.po.GetPreimage(k)
+}
+
+// Close waits for the queued hints to be processed, and stops the dispatcher.
+func (o *AsyncHintOracle) Close() {
+	close(o.hints)
+	<-o.done
+}
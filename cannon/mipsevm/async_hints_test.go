@@ -0,0 +1,66 @@
+package mipsevm_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+type blockingOracle struct {
+	mu      sync.Mutex
+	release chan struct{}
+	events  []string
+}
+
+func (o *blockingOracle) Hint(v []byte) {
+	<-o.release
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if string(v) == "fail" {
+		panic(errors.New("hint rejected"))
+	}
+	o.events = append(o.events, "hint "+string(v))
+}
+
+func (o *blockingOracle) GetPreimage(k [32]byte) []byte {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, "get")
+	return []byte{k[0]}
+}
+
+func TestAsyncHintOracle(t *testing.T) {
+	t.Run("overlaps hints", func(t *testing.T) {
+		po := &blockingOracle{release: make(chan struct{})}
+		async := mipsevm.NewAsyncHintOracle(po, 2)
+		buf := []byte("a")
+		async.Hint(buf)
+		buf[0] = 'b' // the VM may reuse the hint buffer
+		async.Hint(buf)
+		require.Equal(t, 2, async.Pending(), "hints do not block the caller")
+
+		got := make(chan []byte)
+		go func() {
+			got <- async.GetPreimage([32]byte{7})
+		}()
+		close(po.release)
+		require.Equal(t, []byte{7}, <-got)
+		require.Equal(t, []string{"hint a", "hint b", "get"}, po.events, "hints complete before the pre-image request")
+		require.Zero(t, async.Pending())
+		async.Close()
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		po := &blockingOracle{release: make(chan struct{})}
+		close(po.release)
+		async := mipsevm.NewAsyncHintOracle(po, 1)
+		async.Hint([]byte("fail"))
+		require.Panics(t, func() { async.Wait() })
+		require.Panics(t, func() { async.Hint([]byte("next")) })
+		async.Close()
+	})
+}
@@ -7,4 +7,7 @@ type DebugInfo struct {
 	MemoryUsed          hexutil.Uint64 `json:"memory_used"`
 	NumPreimageRequests int            `json:"num_preimage_requests"`
 	TotalPreimageSize   int            `json:"total_preimage_size"`
+	NumHints            int            `json:"num_hints"`
+	TotalHintSize       int            `json:"total_hint_size"`
+	MerkleNodes         int            `json:"merkle_nodes"`
 }
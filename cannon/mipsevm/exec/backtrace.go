@@ -0,0 +1,67 @@
+package exec
+
+import (
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+)
+
+// maxBacktraceScanWords bounds how far up the guest stack ScanBacktrace looks for return addresses.
+const maxBacktraceScanWords = 1 << 14
+
+type BacktraceFrame struct {
+	PC   mipsevm.HexU32
+	Name string
+}
+
+// ScanBacktrace reconstructs a best-effort guest backtrace, without requiring the VM to track calls.
+// The first frame is the current PC. Further frames are return addresses found in the return address register
+// and on the stack: a word is considered a return address if it points into a known symbol,
+// directly after the delay slot of a call instruction.
+func ScanBacktrace(pc uint32, regs *[32]uint32, mem *memory.Memory, meta mipsevm.Metadata, maxFrames int) []BacktraceFrame {
+	frames := []BacktraceFrame{{PC: mipsevm.HexU32(pc), Name: meta.LookupSymbol(pc)}}
+	add := func(addr uint32) {
+		if uint32(frames[len(frames)-1].PC) == addr {
+			return
+		}
+		frames = append(frames, BacktraceFrame{PC: mipsevm.HexU32(addr), Name: meta.LookupSymbol(addr)})
+	}
+
+	if ra := regs[31]; isReturnAddress(mem, meta, ra) {
+		add(ra)
+	}
+	sp := regs[29] & 0xFFFFFFFC
+	for i := uint32(0); i < maxBacktraceScanWords && len(frames) < maxFrames; i++ {
+		addr := sp + i*4
+		if addr < sp { // stack scan wrapped around the address space
+			break
+		}
+		if v := mem.GetMemory(addr); isReturnAddress(mem, meta, v) {
+			add(v)
+		}
+	}
+	if len(frames) > maxFrames {
+		frames = frames[:maxFrames]
+	}
+	return frames
+}
+
+func isReturnAddress(mem *memory.Memory, meta mipsevm.Metadata, addr uint32) bool {
+	if addr < 8 || addr&3 != 0 || strings.HasPrefix(meta.LookupSymbol(addr), "!") {
+		return false
+	}
+	insn := mem.GetMemory(addr - 8)
+	opcode := insn >> 26
+	switch opcode {
+	case 0x03: // jal
+		return true
+	case 0x00: // jalr
+		return insn&0x3F == 0x09
+	case 0x01: // bltzal, bgezal
+		rt := (insn >> 16) & 0x1F
+		return rt == 0x10 || rt == 0x11
+	default:
+		return false
+	}
+}
@@ -0,0 +1,54 @@
+package exec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+)
+
+type testMetadata map[string][2]uint32
+
+func (m testMetadata) LookupSymbol(addr uint32) string {
+	for name, r := range m {
+		if addr >= r[0] && addr < r[1] {
+			return name
+		}
+	}
+	return "!unknown"
+}
+
+func (m testMetadata) CreateSymbolMatcher(name string) mipsevm.SymbolMatcher {
+	return func(addr uint32) bool { return false }
+}
+
+func TestScanBacktrace(t *testing.T) {
+	meta := testMetadata{
+		"main.main":  {0x1000, 0x1100},
+		"main.outer": {0x2000, 0x2100},
+		"main.inner": {0x3000, 0x3100},
+	}
+	mem := memory.NewMemory()
+	mem.SetMemory(0x1010, 0x0C000800) // jal main.outer
+	mem.SetMemory(0x2020, 0x0060F809) // jalr $3
+	mem.SetMemory(0x2040, 0x00000000) // not a call
+
+	var regs [32]uint32
+	regs[29] = 0x7000
+	regs[31] = 0x2028                 // return into main.outer, after the jalr and its delay slot
+	mem.SetMemory(0x7000, 0x2028)     // saved return address, duplicate of $ra
+	mem.SetMemory(0x7004, 0x2048)     // code pointer not preceded by a call
+	mem.SetMemory(0x7008, 0xdeadbeef) // not in any symbol
+	mem.SetMemory(0x7010, 0x1018)     // return into main.main
+
+	frames := ScanBacktrace(0x3004, &regs, mem, meta, 10)
+	require.Equal(t, []BacktraceFrame{
+		{PC: 0x3004, Name: "main.inner"},
+		{PC: 0x2028, Name: "main.outer"},
+		{PC: 0x1018, Name: "main.main"},
+	}, frames)
+
+	require.Len(t, ScanBacktrace(0x3004, &regs, mem, meta, 2), 2)
+}
@@ -0,0 +1,683 @@
+package exec
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+)
+
+// FPU_WITNESS_SIZE is the size of the FPU state witness encoding in bytes.
+const FPU_WITNESS_SIZE = 33 * 4
+
+// FPU control register numbers, as used by cfc1 and ctc1
+const (
+	FcrFIR  = 0
+	FcrFCSR = 31
+)
+
+// FIR is the value of the FPU implementation register: single, double and word formats are implemented.
+const FIR = 1<<16 | 1<<17 | 1<<20
+
+// FCSR fields. The exception bits in the flags, enables and cause fields are in the order of the fpeX bits.
+const (
+	fcsrRoundingMask = 0x3
+	fcsrFlagsShift   = 2
+	fcsrEnablesShift = 7
+	fcsrCauseShift   = 12
+	fcsrExcMask      = 0x1F
+	fcsrCauseMask    = 0x3F << fcsrCauseShift
+	// fcsrWritable masks the rounding mode, flags, enables, cause, flush-to-zero and condition bits
+	fcsrWritable = 0xFF83_FFFF
+)
+
+// floating-point exceptions
+const (
+	fpeInexact = 1 << iota
+	fpeUnderflow
+	fpeOverflow
+	fpeDivByZero
+	fpeInvalid
+)
+
+// rounding modes of the FCSR
+const (
+	roundNearest = iota
+	roundZero
+	roundPlus
+	roundMinus
+)
+
+// COP1 formats
+const (
+	fmtS = 0x10
+	fmtD = 0x11
+	fmtW = 0x14
+)
+
+// Default NaNs, encoded with the legacy MIPS NaN encoding, where the quiet bit set marks a signaling NaN.
+const (
+	defaultNaN32 = 0x7FBF_FFFF
+	defaultNaN64 = 0x7FF7_FFFF_FFFF_FFFF
+)
+
+// FPUState holds the floating-point registers of the VM. The FPU runs with FR=0:
+// doubles are held in even-odd register pairs, with the low word in the even register.
+type FPUState struct {
+	FPR  [32]uint32 `json:"fpr"`
+	FCSR uint32     `json:"fcsr"`
+}
+
+func NewFPUState() *FPUState {
+	return &FPUState{}
+}
+
+// EncodeWitness returns the FPU registers, followed by the FCSR.
+func (f *FPUState) EncodeWitness() []byte {
+	out := make([]byte, 0, FPU_WITNESS_SIZE)
+	for _, r := range f.FPR {
+		out = binary.BigEndian.AppendUint32(out, r)
+	}
+	return binary.BigEndian.AppendUint32(out, f.FCSR)
+}
+
+// Serialize writes the FPU state in the same encoding as the witness.
+func (f *FPUState) Serialize(out io.Writer) error {
+	_, err := out.Write(f.EncodeWitness())
+	return err
+}
+
+func (f *FPUState) Deserialize(in io.Reader) error {
+	if err := binary.Read(in, binary.BigEndian, &f.FPR); err != nil {
+		return err
+	}
+	return binary.Read(in, binary.BigEndian, &f.FCSR)
+}
+
+func (f *FPUState) Copy() *FPUState {
+	c := *f
+	return &c
+}
+
+func (f *FPUState) fcc(cc uint32) bool {
+	if cc == 0 {
+		return f.FCSR&(1<<23) != 0
+	}
+	return f.FCSR&(1<<(24+cc)) != 0
+}
+
+func (f *FPUState) setFcc(cc uint32, v bool) {
+	bit := uint32(1 << 23)
+	if cc != 0 {
+		bit = 1 << (24 + cc)
+	}
+	if v {
+		f.FCSR |= bit
+	} else {
+		f.FCSR &^= bit
+	}
+}
+
+func (f *FPUState) single(r uint32) float32 {
+	return math.Float32frombits(f.FPR[r])
+}
+
+func (f *FPUState) setSingle(r uint32, v float32) {
+	f.FPR[r] = math.Float32bits(v)
+}
+
+func (f *FPUState) double(r uint32) (float64, error) {
+	if r&1 != 0 {
+		return 0, fmt.Errorf("odd FPU register %d used for double", r)
+	}
+	return math.Float64frombits(uint64(f.FPR[r+1])<<32 | uint64(f.FPR[r])), nil
+}
+
+func (f *FPUState) setDouble(r uint32, v float64) error {
+	if r&1 != 0 {
+		return fmt.Errorf("odd FPU register %d used for double", r)
+	}
+	bits := math.Float64bits(v)
+	f.FPR[r] = uint32(bits)
+	f.FPR[r+1] = uint32(bits >> 32)
+	return nil
+}
+
+// raise records the exceptions of an arithmetic instruction in the cause and flags fields,
+// and returns an error if an enabled exception traps.
+func (f *FPUState) raise(exc uint32) error {
+	f.FCSR = f.FCSR&^fcsrCauseMask | exc<<fcsrCauseShift
+	f.FCSR |= exc << fcsrFlagsShift
+	if trap := exc & (f.FCSR >> fcsrEnablesShift) & fcsrExcMask; trap != 0 {
+		return fmt.Errorf("unsupported floating-point exception trap: cause %02x", trap)
+	}
+	return nil
+}
+
+// IsFPUInstruction returns true if the instruction is executed by the FPU, or depends on the FPU condition codes.
+func IsFPUInstruction(opcode, fun uint32) bool {
+	switch opcode {
+	case 0x11, // cop1
+		0x31, // lwc1
+		0x35, // ldc1
+		0x39, // swc1
+		0x3D: // sdc1
+		return true
+	case 0:
+		return fun == 1 // movf/movt
+	default:
+		return false
+	}
+}
+
+// ExecFPUStepLogic executes an FPU instruction. Arithmetic follows IEEE 754 with the rounding mode of the FCSR,
+// and produces the same results on any host: NaN results are always the default NaN, and there are no fused operations.
+func ExecFPUStepLogic(cpu *mipsevm.CpuScalars, registers *[32]uint32, fpu *FPUState, memory *memory.Memory, insn, opcode, fun uint32, memTracker MemTracker) error {
+	rs := (insn >> 21) & 0x1F
+	rt := (insn >> 16) & 0x1F
+	switch opcode {
+	case 0: // movf/movt
+		rd := (insn >> 11) & 0x1F
+		cc := (insn >> 18) & 0x7
+		tf := (insn>>16)&1 != 0
+		return HandleRd(cpu, registers, rd, registers[rs], fpu.fcc(cc) == tf)
+	case 0x31, 0x35, 0x39, 0x3D:
+		return execFPUMemory(cpu, registers, fpu, memory, insn, opcode, memTracker)
+	}
+
+	fs := (insn >> 11) & 0x1F
+	switch rs {
+	case 0x00: // mfc1
+		return HandleRd(cpu, registers, rt, fpu.FPR[fs], true)
+	case 0x02: // cfc1
+		switch fs {
+		case FcrFIR:
+			return HandleRd(cpu, registers, rt, FIR, true)
+		case FcrFCSR:
+			return HandleRd(cpu, registers, rt, fpu.FCSR, true)
+		default:
+			return fmt.Errorf("unsupported FPU control register %d", fs)
+		}
+	case 0x03: // mfhc1
+		if fs&1 != 0 {
+			return fmt.Errorf("odd FPU register %d used for double", fs)
+		}
+		return HandleRd(cpu, registers, rt, fpu.FPR[fs+1], true)
+	case 0x04: // mtc1
+		fpu.FPR[fs] = registers[rt]
+	case 0x06: // ctc1
+		switch fs {
+		case FcrFIR:
+			// read-only
+		case FcrFCSR:
+			fpu.FCSR = registers[rt] & fcsrWritable
+		default:
+			return fmt.Errorf("unsupported FPU control register %d", fs)
+		}
+	case 0x07: // mthc1
+		if fs&1 != 0 {
+			return fmt.Errorf("odd FPU register %d used for double", fs)
+		}
+		fpu.FPR[fs+1] = registers[rt]
+	case 0x08: // bc1f/bc1t/bc1fl/bc1tl
+		return handleFPUBranch(cpu, fpu, insn)
+	case fmtS, fmtD, fmtW:
+		if err := execFPUArith(fpu, registers, insn, rs, fun); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported FPU instruction: %08x", insn)
+	}
+	cpu.PC = cpu.NextPC
+	cpu.NextPC = cpu.NextPC + 4
+	return nil
+}
+
+func execFPUMemory(cpu *mipsevm.CpuScalars, registers *[32]uint32, fpu *FPUState, memory *memory.Memory, insn, opcode uint32, memTracker MemTracker) error {
+	ft := (insn >> 16) & 0x1F
+	addr := registers[(insn>>21)&0x1F] + SignExtend(insn&0xFFFF, 16)
+	switch opcode {
+	case 0x31, 0x39: // lwc1, swc1
+		if addr&3 != 0 {
+			return fmt.Errorf("unaligned FPU memory access at %08x", addr)
+		}
+		memTracker.TrackMemAccess(addr)
+		if opcode == 0x31 {
+			fpu.FPR[ft] = memory.GetMemory(addr)
+		} else {
+			memory.SetMemory(addr, fpu.FPR[ft])
+		}
+	case 0x35, 0x3D: // ldc1, sdc1
+		if addr&7 != 0 {
+			return fmt.Errorf("unaligned FPU memory access at %08x", addr)
+		}
+		if ft&1 != 0 {
+			return fmt.Errorf("odd FPU register %d used for double", ft)
+		}
+		memTracker.TrackMemAccess(addr)
+		if t, ok := memTracker.(interface{ TrackMemAccess2(addr uint32) }); ok {
+			t.TrackMemAccess2(addr + 4)
+		}
+		// the high word is stored first in big-endian memory
+		if opcode == 0x35 {
+			fpu.FPR[ft+1] = memory.GetMemory(addr)
+			fpu.FPR[ft] = memory.GetMemory(addr + 4)
+		} else {
+			memory.SetMemory(addr, fpu.FPR[ft+1])
+			memory.SetMemory(addr+4, fpu.FPR[ft])
+		}
+	}
+	cpu.PC = cpu.NextPC
+	cpu.NextPC = cpu.NextPC + 4
+	return nil
+}
+
+func handleFPUBranch(cpu *mipsevm.CpuScalars, fpu *FPUState, insn uint32) error {
+	if cpu.NextPC != cpu.PC+4 {
+		panic("branch in delay slot")
+	}
+	cc := (insn >> 18) & 0x7
+	likely := (insn>>17)&1 != 0
+	tf := (insn>>16)&1 != 0
+	prevPC := cpu.PC
+	if fpu.fcc(cc) == tf {
+		cpu.PC = cpu.NextPC // execute the delay slot first
+		cpu.NextPC = prevPC + 4 + (SignExtend(insn&0xFFFF, 16) << 2)
+	} else if likely {
+		// the delay slot of a branch likely is only executed if the branch is taken
+		cpu.PC = prevPC + 8
+		cpu.NextPC = prevPC + 12
+	} else {
+		cpu.PC = cpu.NextPC
+		cpu.NextPC = cpu.NextPC + 4
+	}
+	return nil
+}
+
+func execFPUArith(fpu *FPUState, registers *[32]uint32, insn, format, fun uint32) error {
+	ft := (insn >> 16) & 0x1F
+	fs := (insn >> 11) & 0x1F
+	fd := (insn >> 6) & 0x1F
+	mode := fpu.FCSR & fcsrRoundingMask
+
+	if format == fmtW {
+		switch fun {
+		case 0x20: // cvt.s.w
+			v := int32(fpu.FPR[fs])
+			r := float32(v)
+			cmp := compareInt(int64(v), int64(r))
+			res, exc := roundResult(r, cmp, mode)
+			fpu.setSingle(fd, res)
+			return fpu.raise(exc)
+		case 0x21: // cvt.d.w
+			if err := fpu.setDouble(fd, float64(int32(fpu.FPR[fs]))); err != nil {
+				return err
+			}
+			return fpu.raise(0)
+		default:
+			return fmt.Errorf("unsupported FPU instruction: %08x", insn)
+		}
+	}
+
+	// moves copy the raw register contents, and don't signal exceptions
+	switch fun {
+	case 0x06, 0x11, 0x12, 0x13: // mov, movf/movt, movz, movn
+		move := true
+		switch fun {
+		case 0x11:
+			move = fpu.fcc((insn>>18)&0x7) == ((insn>>16)&1 != 0)
+		case 0x12:
+			move = registers[ft] == 0
+		case 0x13:
+			move = registers[ft] != 0
+		}
+		if !move {
+			return nil
+		}
+		if format == fmtS {
+			fpu.FPR[fd] = fpu.FPR[fs]
+			return nil
+		}
+		v, err := fpu.double(fs)
+		if err != nil {
+			return err
+		}
+		return fpu.setDouble(fd, v)
+	}
+
+	if format == fmtS {
+		a, b := fpu.single(fs), fpu.single(ft)
+		switch {
+		case fun <= 0x07:
+			r, exc := fpArith(fun, a, b, mode)
+			fpu.setSingle(fd, r)
+			return fpu.raise(exc)
+		case fun >= 0x0C && fun <= 0x0F, fun == 0x24:
+			r, exc := fpToWord(float64(a), fun, mode)
+			fpu.FPR[fd] = r
+			return fpu.raise(exc)
+		case fun == 0x21: // cvt.d.s
+			if isNaN(a) {
+				exc := uint32(0)
+				if isSignalingNaN(a) {
+					exc = fpeInvalid
+				}
+				if err := fpu.setDouble(fd, math.Float64frombits(defaultNaN64)); err != nil {
+					return err
+				}
+				return fpu.raise(exc)
+			}
+			if err := fpu.setDouble(fd, float64(a)); err != nil {
+				return err
+			}
+			return fpu.raise(0)
+		case fun >= 0x30:
+			return fpu.raise(fpCompare(fpu, insn, fun, a, b))
+		}
+	} else {
+		a, err := fpu.double(fs)
+		if err != nil {
+			return err
+		}
+		b := math.Float64frombits(uint64(fpu.FPR[ft|1])<<32 | uint64(fpu.FPR[ft&^1]))
+		switch {
+		case fun <= 0x07:
+			if fun < 0x04 && ft&1 != 0 {
+				return fmt.Errorf("odd FPU register %d used for double", ft)
+			}
+			r, exc := fpArith(fun, a, b, mode)
+			if err := fpu.setDouble(fd, r); err != nil {
+				return err
+			}
+			return fpu.raise(exc)
+		case fun >= 0x0C && fun <= 0x0F, fun == 0x24:
+			r, exc := fpToWord(a, fun, mode)
+			fpu.FPR[fd] = r
+			return fpu.raise(exc)
+		case fun == 0x20: // cvt.s.d
+			if isNaN(a) {
+				exc := uint32(0)
+				if isSignalingNaN(a) {
+					exc = fpeInvalid
+				}
+				fpu.FPR[fd] = defaultNaN32
+				return fpu.raise(exc)
+			}
+			r := float32(a)
+			var cmp int
+			if !math.IsInf(a, 0) && !math.IsInf(float64(r), 0) {
+				cmp = bigFloat(a).Cmp(bigFloat(float64(r)))
+			}
+			res, exc := roundResult(r, cmp, mode)
+			if math.IsInf(a, 0) {
+				res, exc = r, 0
+			}
+			fpu.setSingle(fd, res)
+			return fpu.raise(exc)
+		case fun >= 0x30:
+			if ft&1 != 0 {
+				return fmt.Errorf("odd FPU register %d used for double", ft)
+			}
+			return fpu.raise(fpCompare(fpu, insn, fun, a, b))
+		}
+	}
+	return fmt.Errorf("unsupported FPU instruction: %08x", insn)
+}
+
+type float interface {
+	float32 | float64
+}
+
+func isNaN[F float](x F) bool {
+	return x != x
+}
+
+// isSignalingNaN checks the quiet bit of a NaN, which marks a signaling NaN in the legacy MIPS NaN encoding.
+func isSignalingNaN[F float](x F) bool {
+	switch v := any(x).(type) {
+	case float32:
+		return isNaN(v) && math.Float32bits(v)&(1<<22) != 0
+	default:
+		return isNaN(x) && math.Float64bits(float64(x))&(1<<51) != 0
+	}
+}
+
+func defaultNaN[F float]() F {
+	var x F
+	switch any(x).(type) {
+	case float32:
+		return F(math.Float32frombits(defaultNaN32))
+	default:
+		return F(math.Float64frombits(defaultNaN64))
+	}
+}
+
+func nextAfter[F float](x F, up bool) F {
+	dir := math.Inf(-1)
+	if up {
+		dir = math.Inf(1)
+	}
+	switch v := any(x).(type) {
+	case float32:
+		return F(math.Nextafter32(v, float32(dir)))
+	default:
+		return F(math.Nextafter(float64(x), dir))
+	}
+}
+
+func maxFloat[F float]() F {
+	var x F
+	switch any(x).(type) {
+	case float32:
+		return F(math.Float32frombits(0x7F7F_FFFF))
+	default:
+		return F(math.Float64frombits(0x7FEF_FFFF_FFFF_FFFF))
+	}
+}
+
+func isTiny[F float](x F) bool {
+	var zero F
+	switch any(zero).(type) {
+	case float32:
+		return math.Abs(float64(x)) < 0x1p-126
+	default:
+		return math.Abs(float64(x)) < 0x1p-1022
+	}
+}
+
+// bigFloat returns x with enough precision to represent the exact result of adding or multiplying two floats.
+func bigFloat(x float64) *big.Float {
+	return new(big.Float).SetPrec(2200).SetFloat64(x)
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// fpArith executes add, sub, mul, div, sqrt, abs, mov and neg.
+func fpArith[F float](fun uint32, a, b F, mode uint32) (F, uint32) {
+	switch fun {
+	case 0x05: // abs
+		if isNaN(a) {
+			return defaultNaN[F](), 0
+		}
+		return F(math.Abs(float64(a))), 0
+	case 0x07: // neg
+		if isNaN(a) {
+			return defaultNaN[F](), 0
+		}
+		return -a, 0
+	case 0x04: // sqrt
+		b = 0
+	}
+
+	if isNaN(a) || isNaN(b) {
+		if isSignalingNaN(a) || isSignalingNaN(b) {
+			return defaultNaN[F](), fpeInvalid
+		}
+		return defaultNaN[F](), 0
+	}
+
+	// The round-to-nearest result: the explicit conversions prevent fused operations
+	var r F
+	switch fun {
+	case 0x00:
+		r = F(a + b)
+	case 0x01:
+		r = F(a - b)
+	case 0x02:
+		r = F(a * b)
+	case 0x03:
+		r = F(a / b)
+	case 0x04:
+		r = F(math.Sqrt(float64(a)))
+	}
+	if isNaN(r) {
+		return defaultNaN[F](), fpeInvalid
+	}
+	if fun == 0x03 && b == 0 {
+		// division of a finite non-zero number by zero, the signed infinity is exact
+		if math.IsInf(float64(a), 0) {
+			return r, 0
+		}
+		return r, fpeDivByZero
+	}
+	if math.IsInf(float64(a), 0) || math.IsInf(float64(b), 0) {
+		// results with infinite operands are exact
+		return r, 0
+	}
+
+	// find the direction of the exact result from the round-to-nearest result
+	cmp := 0
+	if !math.IsInf(float64(r), 0) {
+		x, y, z := float64(a), float64(b), float64(r)
+		switch fun {
+		case 0x00:
+			cmp = new(big.Float).SetPrec(2200).Add(bigFloat(x), bigFloat(y)).Cmp(bigFloat(z))
+		case 0x01:
+			cmp = new(big.Float).SetPrec(2200).Sub(bigFloat(x), bigFloat(y)).Cmp(bigFloat(z))
+		case 0x02:
+			cmp = new(big.Float).SetPrec(2200).Mul(bigFloat(x), bigFloat(y)).Cmp(bigFloat(z))
+		case 0x03:
+			// the sign of x/y - z is the sign of (x - z*y) * y
+			rem := new(big.Float).SetPrec(2200).Sub(bigFloat(x), new(big.Float).SetPrec(2200).Mul(bigFloat(z), bigFloat(y)))
+			cmp = rem.Sign() * bigFloat(y).Sign()
+		case 0x04:
+			rem := new(big.Float).SetPrec(2200).Sub(bigFloat(x), new(big.Float).SetPrec(2200).Mul(bigFloat(z), bigFloat(z)))
+			cmp = rem.Sign()
+		}
+	}
+	res, exc := roundResult(r, cmp, mode)
+	if res == 0 && cmp == 0 && mode == roundMinus && (fun == 0x00 || fun == 0x01) {
+		// an exact zero sum of operands with opposite signs is negative when rounding down
+		opposite := math.Signbit(float64(a)) != math.Signbit(float64(b))
+		if fun == 0x01 {
+			opposite = !opposite
+		}
+		if opposite {
+			res = F(math.Copysign(0, -1))
+		}
+	}
+	return res, exc
+}
+
+// roundResult applies the rounding mode to the round-to-nearest result r of an operation with finite operands.
+// cmp is the sign of the exact result minus r, which is unused if r overflowed.
+// It returns the rounded result and the raised exceptions.
+func roundResult[F float](r F, cmp int, mode uint32) (F, uint32) {
+	if math.IsInf(float64(r), 0) {
+		exc := uint32(fpeOverflow | fpeInexact)
+		switch {
+		case mode == roundZero,
+			mode == roundPlus && r < 0,
+			mode == roundMinus && r > 0:
+			if r < 0 {
+				return -maxFloat[F](), exc
+			}
+			return maxFloat[F](), exc
+		}
+		return r, exc
+	}
+	if cmp == 0 {
+		return r, 0
+	}
+	switch mode {
+	case roundZero:
+		if (cmp < 0 && r > 0) || (cmp > 0 && r < 0) {
+			r = nextAfter(r, r < 0)
+		}
+	case roundPlus:
+		if cmp > 0 {
+			r = nextAfter(r, true)
+		}
+	case roundMinus:
+		if cmp < 0 {
+			r = nextAfter(r, false)
+		}
+	}
+	exc := uint32(fpeInexact)
+	if math.IsInf(float64(r), 0) {
+		exc |= fpeOverflow
+	} else if isTiny(r) {
+		exc |= fpeUnderflow
+	}
+	return r, exc
+}
+
+// fpToWord executes the round, trunc, ceil, floor and cvt conversions to a word.
+func fpToWord(x float64, fun uint32, mode uint32) (uint32, uint32) {
+	switch fun {
+	case 0x0C:
+		mode = roundNearest
+	case 0x0D:
+		mode = roundZero
+	case 0x0E:
+		mode = roundPlus
+	case 0x0F:
+		mode = roundMinus
+	}
+	var v float64
+	switch mode {
+	case roundNearest:
+		v = math.RoundToEven(x)
+	case roundZero:
+		v = math.Trunc(x)
+	case roundPlus:
+		v = math.Ceil(x)
+	case roundMinus:
+		v = math.Floor(x)
+	}
+	if isNaN(x) || v < math.MinInt32 || v > math.MaxInt32 {
+		// invalid conversions produce the largest positive word
+		return math.MaxInt32, fpeInvalid
+	}
+	if v != x {
+		return uint32(int32(v)), fpeInexact
+	}
+	return uint32(int32(v)), 0
+}
+
+// fpCompare executes c.cond, and sets the condition code to the result.
+func fpCompare[F float](fpu *FPUState, insn uint32, fun uint32, a, b F) uint32 {
+	cond := fun & 0xF
+	cc := (insn >> 8) & 0x7
+	unordered := isNaN(a) || isNaN(b)
+	var exc uint32
+	if unordered && (cond&0x8 != 0 || isSignalingNaN(a) || isSignalingNaN(b)) {
+		exc = fpeInvalid
+	}
+	result := (cond&0x4 != 0 && a < b) || (cond&0x2 != 0 && a == b) || (cond&0x1 != 0 && unordered)
+	fpu.setFcc(cc, result)
+	return exc
+}
@@ -0,0 +1,265 @@
+package exec
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+)
+
+func cop1(format, ft, fs, fd, fun uint32) uint32 {
+	return 0x11<<26 | format<<21 | ft<<16 | fs<<11 | fd<<6 | fun
+}
+
+type fpuTestVM struct {
+	cpu  mipsevm.CpuScalars
+	regs [32]uint32
+	fpu  *FPUState
+	mem  *memory.Memory
+}
+
+func newFPUTestVM() *fpuTestVM {
+	return &fpuTestVM{cpu: mipsevm.CpuScalars{PC: 0x100, NextPC: 0x104}, fpu: NewFPUState(), mem: memory.NewMemory()}
+}
+
+func (vm *fpuTestVM) exec(t *testing.T, insn uint32) error {
+	tracker := NewMemoryTracker(vm.mem)
+	tracker.Reset(true)
+	opcode, fun := insn>>26, insn&0x3F
+	require.True(t, IsFPUInstruction(opcode, fun))
+	return ExecFPUStepLogic(&vm.cpu, &vm.regs, vm.fpu, vm.mem, insn, opcode, fun, tracker)
+}
+
+func (vm *fpuTestVM) setDouble(t *testing.T, r uint32, v float64) {
+	require.NoError(t, vm.fpu.setDouble(r, v))
+}
+
+func (vm *fpuTestVM) double(t *testing.T, r uint32) float64 {
+	v, err := vm.fpu.double(r)
+	require.NoError(t, err)
+	return v
+}
+
+func TestFPUArith(t *testing.T) {
+	vm := newFPUTestVM()
+	vm.fpu.setSingle(1, 1.5)
+	vm.fpu.setSingle(2, 2.25)
+	for _, tc := range []struct {
+		fun      uint32
+		expected float32
+	}{
+		{0x00, 3.75},
+		{0x01, -0.75},
+		{0x02, 3.375},
+		{0x03, float32(1.5) / float32(2.25)},
+		{0x04, float32(math.Sqrt(1.5))},
+		{0x05, 1.5},
+		{0x06, 1.5},
+		{0x07, -1.5},
+	} {
+		require.NoError(t, vm.exec(t, cop1(fmtS, 2, 1, 3, tc.fun)))
+		require.Equal(t, tc.expected, vm.fpu.single(3), "fun %x", tc.fun)
+	}
+	require.Equal(t, uint32(0x100+8*4), vm.cpu.PC)
+
+	vm.setDouble(t, 2, 1)
+	vm.setDouble(t, 4, 3)
+	require.NoError(t, vm.exec(t, cop1(fmtD, 4, 2, 6, 0x03)))
+	require.Equal(t, 1.0/3.0, vm.double(t, 6))
+	require.NotZero(t, vm.fpu.FCSR&(fpeInexact<<fcsrCauseShift))
+	require.NotZero(t, vm.fpu.FCSR&(fpeInexact<<fcsrFlagsShift))
+
+	require.ErrorContains(t, vm.exec(t, cop1(fmtD, 4, 3, 6, 0x00)), "odd FPU register")
+}
+
+func TestFPURoundingModes(t *testing.T) {
+	// the nearest double to 1/3 is below the exact result
+	third := 1.0 / 3.0
+	up := math.Nextafter(third, 1)
+	for _, tc := range []struct {
+		name     string
+		mode     uint32
+		a, b     float64
+		expected float64
+	}{
+		{"nearest", roundNearest, 1, 3, third},
+		{"zero", roundZero, 1, 3, third},
+		{"plus", roundPlus, 1, 3, up},
+		{"minus", roundMinus, 1, 3, third},
+		{"zeroNegative", roundZero, -1, 3, -third},
+		{"plusNegative", roundPlus, -1, 3, -third},
+		{"minusNegative", roundMinus, -1, 3, -up},
+		{"plusTwoThirds", roundPlus, 2, 3, 2 * up},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := newFPUTestVM()
+			vm.fpu.FCSR = tc.mode
+			vm.setDouble(t, 0, tc.a)
+			vm.setDouble(t, 2, tc.b)
+			require.NoError(t, vm.exec(t, cop1(fmtD, 2, 0, 4, 0x03)))
+			require.Equal(t, tc.expected, vm.double(t, 4))
+		})
+	}
+
+	t.Run("overflow", func(t *testing.T) {
+		vm := newFPUTestVM()
+		vm.fpu.FCSR = roundZero
+		vm.setDouble(t, 0, math.MaxFloat64)
+		require.NoError(t, vm.exec(t, cop1(fmtD, 0, 0, 4, 0x00)))
+		require.Equal(t, math.MaxFloat64, vm.double(t, 4))
+		require.Equal(t, uint32(fpeOverflow|fpeInexact), (vm.fpu.FCSR>>fcsrCauseShift)&fcsrExcMask)
+
+		vm.fpu.FCSR = roundNearest
+		require.NoError(t, vm.exec(t, cop1(fmtD, 0, 0, 4, 0x00)))
+		require.True(t, math.IsInf(vm.double(t, 4), 1))
+	})
+
+	t.Run("exactZeroSum", func(t *testing.T) {
+		vm := newFPUTestVM()
+		vm.fpu.FCSR = roundMinus
+		vm.fpu.setSingle(0, 1)
+		vm.fpu.setSingle(1, -1)
+		require.NoError(t, vm.exec(t, cop1(fmtS, 1, 0, 2, 0x00)))
+		require.Equal(t, uint32(0x8000_0000), vm.fpu.FPR[2])
+		require.Zero(t, vm.fpu.FCSR&fcsrCauseMask)
+	})
+}
+
+func TestFPUExceptions(t *testing.T) {
+	vm := newFPUTestVM()
+	vm.fpu.setSingle(0, 1)
+	vm.fpu.setSingle(1, 0)
+	require.NoError(t, vm.exec(t, cop1(fmtS, 1, 0, 2, 0x03)))
+	require.True(t, math.IsInf(float64(vm.fpu.single(2)), 1))
+	require.Equal(t, uint32(fpeDivByZero), (vm.fpu.FCSR>>fcsrCauseShift)&fcsrExcMask)
+
+	vm.fpu.setSingle(0, -1)
+	require.NoError(t, vm.exec(t, cop1(fmtS, 0, 0, 2, 0x04)))
+	require.Equal(t, uint32(defaultNaN32), vm.fpu.FPR[2], "sqrt(-1) is the default NaN")
+	require.Equal(t, uint32(fpeInvalid), (vm.fpu.FCSR>>fcsrCauseShift)&fcsrExcMask)
+	require.Equal(t, uint32(fpeDivByZero|fpeInvalid), (vm.fpu.FCSR>>fcsrFlagsShift)&fcsrExcMask, "flags are sticky")
+
+	// enabled exceptions trap
+	vm.fpu.FCSR = fpeInvalid << fcsrEnablesShift
+	require.ErrorContains(t, vm.exec(t, cop1(fmtS, 0, 0, 2, 0x04)), "floating-point exception")
+}
+
+func TestFPUConversions(t *testing.T) {
+	vm := newFPUTestVM()
+	vm.setDouble(t, 0, -2.5)
+	for _, tc := range []struct {
+		fun      uint32
+		expected int32
+	}{
+		{0x0C, -2}, // round to even
+		{0x0D, -2},
+		{0x0E, -2},
+		{0x0F, -3},
+		{0x24, -2}, // cvt.w with the default round to nearest
+	} {
+		require.NoError(t, vm.exec(t, cop1(fmtD, 0, 0, 2, tc.fun)))
+		require.Equal(t, tc.expected, int32(vm.fpu.FPR[2]), "fun %x", tc.fun)
+		require.Equal(t, uint32(fpeInexact), (vm.fpu.FCSR>>fcsrCauseShift)&fcsrExcMask)
+	}
+
+	vm.setDouble(t, 0, 1e10)
+	require.NoError(t, vm.exec(t, cop1(fmtD, 0, 0, 2, 0x0D)))
+	require.Equal(t, uint32(math.MaxInt32), vm.fpu.FPR[2])
+	require.Equal(t, uint32(fpeInvalid), (vm.fpu.FCSR>>fcsrCauseShift)&fcsrExcMask)
+
+	vm.fpu.FPR[4] = uint32(0xFFFF_FFF9) // -7
+	require.NoError(t, vm.exec(t, cop1(fmtW, 0, 4, 6, 0x21)))
+	require.Equal(t, -7.0, vm.double(t, 6))
+	require.NoError(t, vm.exec(t, cop1(fmtD, 0, 6, 8, 0x20)))
+	require.Equal(t, float32(-7), vm.fpu.single(8))
+	require.NoError(t, vm.exec(t, cop1(fmtS, 0, 8, 10, 0x21)))
+	require.Equal(t, -7.0, vm.double(t, 10))
+
+	vm.fpu.FPR[4] = 1<<24 + 1
+	vm.fpu.FCSR = roundPlus
+	require.NoError(t, vm.exec(t, cop1(fmtW, 0, 4, 6, 0x20)))
+	require.Equal(t, float32(1<<24+2), vm.fpu.single(6))
+}
+
+func TestFPUCompareAndBranch(t *testing.T) {
+	vm := newFPUTestVM()
+	vm.fpu.setSingle(0, 1)
+	vm.fpu.setSingle(1, 2)
+	require.NoError(t, vm.exec(t, cop1(fmtS, 1, 0, 0, 0x3C))) // c.lt.s $f0, $f1
+	require.True(t, vm.fpu.fcc(0))
+	require.NoError(t, vm.exec(t, cop1(fmtS, 1, 0, 3<<2, 0x32))) // c.eq.s $fcc3, $f0, $f1
+	require.False(t, vm.fpu.fcc(3))
+
+	// bc1t to +16 bytes: the delay slot executes first
+	pc := vm.cpu.PC
+	require.NoError(t, vm.exec(t, 0x11<<26|0x08<<21|1<<16|4))
+	require.Equal(t, pc+4, vm.cpu.PC)
+	require.Equal(t, pc+4+16, vm.cpu.NextPC)
+
+	// bc1fl on $fcc0 is not taken, and skips the delay slot
+	vm.cpu = mipsevm.CpuScalars{PC: 0x100, NextPC: 0x104}
+	require.NoError(t, vm.exec(t, 0x11<<26|0x08<<21|1<<17|4))
+	require.Equal(t, uint32(0x108), vm.cpu.PC)
+	require.Equal(t, uint32(0x10C), vm.cpu.NextPC)
+
+	// movt $t0, $t1, $fcc0
+	vm.regs[9] = 42
+	require.NoError(t, vm.exec(t, 9<<21|1<<16|8<<11|1))
+	require.Equal(t, uint32(42), vm.regs[8])
+
+	// unordered comparisons with a NaN
+	vm.fpu.FPR[1] = defaultNaN32 & 0xFFBF_FFFF                // quiet NaN
+	require.NoError(t, vm.exec(t, cop1(fmtS, 1, 0, 0, 0x31))) // c.un.s
+	require.True(t, vm.fpu.fcc(0))
+	require.Zero(t, vm.fpu.FCSR&fcsrCauseMask)
+	require.NoError(t, vm.exec(t, cop1(fmtS, 1, 0, 0, 0x3C))) // c.lt.s signals on unordered
+	require.False(t, vm.fpu.fcc(0))
+	require.Equal(t, uint32(fpeInvalid), (vm.fpu.FCSR>>fcsrCauseShift)&fcsrExcMask)
+}
+
+func TestFPUMoves(t *testing.T) {
+	vm := newFPUTestVM()
+	vm.regs[8] = 0x3F80_0000
+	vm.regs[9] = 0x4000_0000
+	require.NoError(t, vm.exec(t, cop1(0x04, 8, 2, 0, 0))) // mtc1 $t0, $f2
+	require.NoError(t, vm.exec(t, cop1(0x07, 9, 2, 0, 0))) // mthc1 $t1, $f2
+	require.Equal(t, [2]uint32{0x3F80_0000, 0x4000_0000}, [2]uint32{vm.fpu.FPR[2], vm.fpu.FPR[3]})
+	require.NoError(t, vm.exec(t, cop1(0x00, 10, 3, 0, 0))) // mfc1 $t2, $f3
+	require.Equal(t, uint32(0x4000_0000), vm.regs[10])
+
+	vm.regs[8] = 0xFFFF_FFFF
+	require.NoError(t, vm.exec(t, cop1(0x06, 8, FcrFCSR, 0, 0))) // ctc1 $t0, $31
+	require.Equal(t, uint32(fcsrWritable), vm.fpu.FCSR)
+	require.NoError(t, vm.exec(t, cop1(0x02, 10, FcrFIR, 0, 0))) // cfc1 $t2, $0
+	require.Equal(t, uint32(FIR), vm.regs[10])
+
+	vm.regs[8] = 1
+	vm.fpu.FCSR = 0
+	require.NoError(t, vm.exec(t, cop1(fmtS, 8, 2, 4, 0x12))) // movz.s is not moved for a non-zero rt
+	require.Zero(t, vm.fpu.FPR[4])
+	require.NoError(t, vm.exec(t, cop1(fmtS, 8, 2, 4, 0x13))) // movn.s
+	require.Equal(t, vm.fpu.FPR[2], vm.fpu.FPR[4])
+}
+
+func TestFPUMemory(t *testing.T) {
+	vm := newFPUTestVM()
+	vm.regs[4] = 0x1000
+	vm.mem.SetMemory(0x1008, 0x4000_0000)
+	vm.mem.SetMemory(0x100C, 0x0000_0001)
+	require.NoError(t, vm.exec(t, 0x35<<26|4<<21|2<<16|8)) // ldc1 $f2, 8($a0)
+	require.Equal(t, math.Float64frombits(0x4000_0000_0000_0001), vm.double(t, 2))
+
+	require.NoError(t, vm.exec(t, 0x3D<<26|4<<21|2<<16|0x10)) // sdc1 $f2, 16($a0)
+	require.Equal(t, uint32(0x4000_0000), vm.mem.GetMemory(0x1010))
+	require.Equal(t, uint32(0x0000_0001), vm.mem.GetMemory(0x1014))
+
+	require.NoError(t, vm.exec(t, 0x31<<26|4<<21|5<<16|0x10)) // lwc1 $f5, 16($a0)
+	require.Equal(t, uint32(0x4000_0000), vm.fpu.FPR[5])
+	require.NoError(t, vm.exec(t, 0x39<<26|4<<21|5<<16|0x20)) // swc1 $f5, 32($a0)
+	require.Equal(t, uint32(0x4000_0000), vm.mem.GetMemory(0x1020))
+
+	require.ErrorContains(t, vm.exec(t, 0x35<<26|4<<21|2<<16|4), "unaligned")
+}
@@ -9,7 +9,6 @@ import (
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
-	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
 )
 
 // Syscall codes
@@ -29,11 +28,16 @@ const (
 	SysNanosleep    = 4166
 	SysClockGetTime = 4263
 	SysGetpid       = 4020
+	SysMunmap       = 4091
+	SysGetRandom    = 4353
+	SysEpollCreate1 = 4326
+	SysEpollCtl     = 4249
+	SysEpollPwait   = 4313
+	SysEventFd2     = 4325
 )
 
 // Noop Syscall codes
 const (
-	SysMunmap        = 4091
 	SysGetAffinity   = 4240
 	SysMadvise       = 4218
 	SysRtSigprocmask = 4195
@@ -47,11 +51,7 @@ const (
 	SysReadlink      = 4085
 	SysReadlinkAt    = 4298
 	SysIoctl         = 4054
-	SysEpollCreate1  = 4326
 	SysPipe2         = 4328
-	SysEpollCtl      = 4249
-	SysEpollPwait    = 4313
-	SysGetRandom     = 4353
 	SysUname         = 4122
 	SysStat64        = 4213
 	SysGetuid        = 4024
@@ -61,9 +61,7 @@ const (
 	SysTgkill        = 4266
 )
 
-// Profiling-related syscalls
-// Should be able to ignore if we patch out prometheus calls and disable memprofiling
-// TODO(cp-903) - Update patching for mt-cannon so that these can be ignored
+// Timer syscalls, used by the Go runtime for CPU profiling
 const (
 	SysSetITimer    = 4104
 	SysTimerCreate  = 4257
@@ -80,17 +78,58 @@ const (
 	FdHintWrite     = 4
 	FdPreimageRead  = 5
 	FdPreimageWrite = 6
+	// FdEventFd is the fixed descriptor returned by eventfd2. Writes are accepted, but the counter is never readable.
+	FdEventFd = 7
+	// FdEpoll is the fixed descriptor returned by epoll_create1. Its ready set is always empty.
+	FdEpoll = 8
 )
 
 // Errors
 const (
 	SysErrorSignal = ^uint32(0)
 	MipsEBADF      = 0x9
+	MipsENOMEM     = 0xc
 	MipsEINVAL     = 0x16
 	MipsEAGAIN     = 0xb
+	MipsEMFILE     = 0x18
 	MipsETIMEDOUT  = 0x91
 )
 
+// SysFcntl commands
+const (
+	FcntlDupFd        = 0
+	FcntlGetFd        = 1
+	FcntlSetFd        = 2
+	FcntlGetFl        = 3
+	FcntlSetFl        = 4
+	FcntlDupFdCloexec = 1030
+)
+
+// File access modes, as returned by F_GETFL
+const (
+	ORdOnly = 0
+	OWrOnly = 1
+	ORdWr   = 2
+)
+
+// File types and permissions, as reported in st_mode by fstat64
+const (
+	SIfIfo = 0o010000
+	SIfChr = 0o020000
+	// Stat64ModeOffset is the offset of st_mode in the MIPS o32 struct stat64
+	Stat64ModeOffset = 24
+)
+
+// Interval timers and POSIX timers
+const (
+	ITimerReal    = 0
+	ITimerVirtual = 1
+	ITimerProf    = 2
+	// TimerID is the id of the single timer handed out by timer_create.
+	// Signals are never delivered, so all timers can share it.
+	TimerID = 0
+)
+
 // SysFutex-related constants
 const (
 	FutexWaitPrivate  = 128
@@ -148,6 +187,15 @@ const (
 	ClockGettimeRealtimeFlag = 0
 	// ClockGettimeMonotonicFlag is the clock_gettime clock id for Linux's monotonic clock: https://github.com/torvalds/linux/blob/ad618736883b8970f66af799e34007475fe33a68/include/uapi/linux/time.h#L50
 	ClockGettimeMonotonicFlag = 1
+	// The remaining clock_gettime clock ids: https://github.com/torvalds/linux/blob/ad618736883b8970f66af799e34007475fe33a68/include/uapi/linux/time.h#L51-L59
+	ClockGettimeProcessCPUTimeFlag  = 2
+	ClockGettimeThreadCPUTimeFlag   = 3
+	ClockGettimeMonotonicRawFlag    = 4
+	ClockGettimeRealtimeCoarseFlag  = 5
+	ClockGettimeMonotonicCoarseFlag = 6
+	ClockGettimeBoottimeFlag        = 7
+	ClockGettimeRealtimeAlarmFlag   = 8
+	ClockGettimeBoottimeAlarmFlag   = 9
 )
 
 func GetSyscallArgs(registers *[32]uint32) (syscallNum, a0, a1, a2, a3 uint32) {
@@ -161,7 +209,9 @@ func GetSyscallArgs(registers *[32]uint32) (syscallNum, a0, a1, a2, a3 uint32) {
 	return syscallNum, a0, a1, a2, a3
 }
 
-func HandleSysMmap(a0, a1, heap uint32) (v0, v1, newHeap uint32) {
+// HandleSysMmap allocates anonymous mappings from the heap, up to the heap end of the layout,
+// and accepts mappings at fixed addresses below the stack region.
+func HandleSysMmap(a0, a1, heap uint32, layout *mipsevm.Layout) (v0, v1, newHeap uint32) {
 	v1 = uint32(0)
 	newHeap = heap
 
@@ -174,7 +224,7 @@ func HandleSysMmap(a0, a1, heap uint32) (v0, v1, newHeap uint32) {
 		//fmt.Printf("mmap heap 0x%x size 0x%x\n", v0, sz)
 		newHeap += sz
 		// Fail if new heap exceeds memory limit, newHeap overflows around to low memory, or sz overflows
-		if newHeap > program.HEAP_END || newHeap < heap || sz < a1 {
+		if newHeap > layout.HeapEnd || newHeap < heap || sz < a1 {
 			v0 = SysErrorSignal
 			v1 = MipsEINVAL
 			return v0, v1, heap
@@ -182,11 +232,155 @@ func HandleSysMmap(a0, a1, heap uint32) (v0, v1, newHeap uint32) {
 	} else {
 		v0 = a0
 		//fmt.Printf("mmap hint 0x%x size 0x%x\n", v0, sz)
+		// Fail if the mapping wraps around to low memory, reaches into the stack region, or sz overflows
+		if end := a0 + sz; end < a0 || end > layout.StackLimit || sz < a1 {
+			v0 = SysErrorSignal
+			v1 = MipsEINVAL
+			return v0, v1, heap
+		}
 	}
 
 	return v0, v1, newHeap
 }
 
+// HandleSysBrk reports the program break, which is fixed at the program break of the layout.
+// Requests to move the break fail with ENOMEM, rather than reporting an unchanged break,
+// so a guest that allocates with brk fails deterministically instead of overlapping other mappings.
+func HandleSysBrk(a0 uint32, layout *mipsevm.Layout) (v0, v1 uint32) {
+	if a0 == 0 || a0 == layout.ProgramBreak {
+		return layout.ProgramBreak, 0
+	}
+	return SysErrorSignal, MipsENOMEM
+}
+
+// HandleSysMunmap reclaims the pages fully covered by the unmapped range.
+// The onchain VMs treat munmap as a noop and only commit to the memory root,
+// so only pages that hold no data are released: dropping those leaves the memory root unchanged.
+func HandleSysMunmap(a0, a1 uint32, mem *memory.Memory) (v0, v1 uint32) {
+	// args: a0 = addr, a1 = length
+	// returns: v0 = 0, v1 = 0, like the onchain implementation.
+	mem.FreeZeroPages(a0, a1)
+	return 0, 0
+}
+
+// ClockGettimeValue returns the deterministic value of the clock with the given id at the given step.
+// Realtime clocks are fixed at the Unix Epoch. All other clocks advance with the step count at the emulated HZ rate,
+// including the CPU-time clocks, which are not tracked per thread or process.
+// Returns ok as false if the clock id is not recognized.
+func ClockGettimeValue(clockID uint32, step uint64) (secs, nsecs uint32, ok bool) {
+	switch clockID {
+	case ClockGettimeRealtimeFlag, ClockGettimeRealtimeCoarseFlag, ClockGettimeRealtimeAlarmFlag:
+		return 0, 0, true
+	case ClockGettimeMonotonicFlag, ClockGettimeProcessCPUTimeFlag, ClockGettimeThreadCPUTimeFlag,
+		ClockGettimeMonotonicRawFlag, ClockGettimeMonotonicCoarseFlag, ClockGettimeBoottimeFlag, ClockGettimeBoottimeAlarmFlag:
+		// monotonic clocks are used by Go guest programs for goroutine scheduling and to implement
+		// `time.Sleep` (and other sleep related operations).
+		secs = uint32(step / HZ)
+		nsecs = uint32((step % HZ) * (1_000_000_000 / HZ))
+		return secs, nsecs, true
+	default:
+		return 0, 0, false
+	}
+}
+
+// HandleSysSetITimer arms or disarms an interval timer. The timers never expire, since signals are never delivered.
+// The previous value of the timer can't be written in a single step, so it can't be requested.
+func HandleSysSetITimer(a0, a2 uint32) (v0, v1 uint32) {
+	// args: a0 = which, a1 = new_value, a2 = old_value
+	switch a0 {
+	case ITimerReal, ITimerVirtual, ITimerProf:
+	default:
+		return SysErrorSignal, MipsEINVAL
+	}
+	if a2 != 0 {
+		return SysErrorSignal, MipsEINVAL
+	}
+	return 0, 0
+}
+
+// HandleSysTimerCreate creates a timer on one of the clocks of the virtual clock, and writes TimerID to the target address.
+// The notification settings are ignored, since the timer never expires.
+func HandleSysTimerCreate(a0, a2 uint32, memory *memory.Memory, memTracker MemTracker) (v0, v1 uint32) {
+	// args: a0 = clockid, a1 = sevp, a2 = timerid
+	if _, _, ok := ClockGettimeValue(a0, 0); !ok {
+		return SysErrorSignal, MipsEINVAL
+	}
+	effAddr := a2 & 0xFFffFFfc
+	memTracker.TrackMemAccess(effAddr)
+	memory.SetMemory(effAddr, TimerID)
+	return 0, 0
+}
+
+// HandleSysTimerSetTime arms or disarms the timer created by timer_create.
+// Like setitimer, the previous value of the timer can't be requested.
+func HandleSysTimerSetTime(a0, a3 uint32) (v0, v1 uint32) {
+	// args: a0 = timerid, a1 = flags, a2 = new_value, a3 = old_value
+	if a0 != TimerID || a3 != 0 {
+		return SysErrorSignal, MipsEINVAL
+	}
+	return 0, 0
+}
+
+// HandleSysTimerDelete deletes the timer created by timer_create.
+func HandleSysTimerDelete(a0 uint32) (v0, v1 uint32) {
+	// args: a0 = timerid
+	if a0 != TimerID {
+		return SysErrorSignal, MipsEINVAL
+	}
+	return 0, 0
+}
+
+// HandleSysGetRandom fills at most one aligned memory word at the target address with pseudo-random bytes.
+// The bytes are derived from the step count, to keep execution deterministic and provable onchain.
+// Like Linux, getrandom may return fewer bytes than requested, so guests retry for the remainder.
+func HandleSysGetRandom(a0, a1 uint32, step uint64, memory *memory.Memory, memTracker MemTracker) (v0, v1 uint32) {
+	// args: a0 = buf, a1 = count, a2 = flags (ignored)
+	// returns: v0 = written, v1 = err code
+	effAddr := a0 & 0xFFffFFfc
+	memTracker.TrackMemAccess(effAddr)
+	mem := memory.GetMemory(effAddr)
+
+	alignment := a0 & 3
+	n := 4 - alignment
+	if a1 < n {
+		n = a1
+	}
+	var random, outMem [4]byte
+	binary.BigEndian.PutUint32(random[:], uint32(splitmix64(step)))
+	binary.BigEndian.PutUint32(outMem[:], mem)
+	copy(outMem[alignment:alignment+n], random[alignment:alignment+n])
+	memory.SetMemory(effAddr, binary.BigEndian.Uint32(outMem[:]))
+	return n, 0
+}
+
+// splitmix64 is a fast, well-distributed 64-bit mixing function: https://prng.di.unimi.it/splitmix64.c
+func splitmix64(seed uint64) uint64 {
+	z := seed + 0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// HandleSysEpollCtl emulates epoll_ctl on the fixed epoll descriptor. Registrations are accepted, but never tracked,
+// since no descriptor ever becomes ready.
+func HandleSysEpollCtl(a0 uint32) (v0, v1 uint32) {
+	// args: a0 = epfd, a1 = op, a2 = fd, a3 = event
+	if a0 != FdEpoll {
+		return 0xFFffFFff, MipsEBADF
+	}
+	return 0, 0
+}
+
+// HandleSysEpollPwait emulates epoll_pwait on the fixed epoll descriptor. The ready set is always empty,
+// so it returns immediately with zero events, regardless of the timeout.
+func HandleSysEpollPwait(a0 uint32) (v0, v1 uint32) {
+	// args: a0 = epfd, a1 = events, a2 = maxevents, a3 = timeout
+	if a0 != FdEpoll {
+		return 0xFFffFFff, MipsEBADF
+	}
+	return 0, 0
+}
+
 func HandleSysRead(a0, a1, a2 uint32, preimageKey [32]byte, preimageOffset uint32, preimageReader PreimageReader, memory *memory.Memory, memTracker MemTracker) (v0, v1, newPreimageOffset uint32) {
 	// args: a0 = fd, a1 = addr, a2 = count
 	// returns: v0 = read, v1 = err code
@@ -221,6 +415,10 @@ func HandleSysRead(a0, a1, a2 uint32, preimageKey [32]byte, preimageOffset uint3
 	case FdHintRead: // hint response
 		// don't actually read into memory, just say we read it all, we ignore the result anyway
 		v0 = a2
+	case FdEventFd:
+		// the eventfd counter is never readable, like a non-blocking eventfd with a zero counter
+		v0 = 0xFFffFFff
+		v1 = MipsEAGAIN
 	default:
 		v0 = 0xFFffFFff
 		v1 = MipsEBADF
@@ -277,6 +475,14 @@ func HandleSysWrite(a0, a1, a2 uint32, lastHint hexutil.Bytes, preimageKey [32]b
 		newPreimageOffset = 0
 		//fmt.Printf("updating pre-image key: %s\n", m.state.PreimageKey)
 		v0 = a2
+	case FdEventFd:
+		// eventfd writes add an 8-byte value to the counter, which is discarded
+		if a2 < 8 {
+			v0 = 0xFFffFFff
+			v1 = MipsEINVAL
+		} else {
+			v0 = 8
+		}
 	default:
 		v0 = 0xFFffFFff
 		v1 = MipsEBADF
@@ -285,23 +491,76 @@ func HandleSysWrite(a0, a1, a2 uint32, lastHint hexutil.Bytes, preimageKey [32]b
 	return v0, v1, newLastHint, newPreimageKey, newPreimageOffset
 }
 
+// fdAccessMode returns the access mode of one of the fixed file descriptors available to the guest.
+// The fd table is static: descriptors can't be opened, duplicated or closed.
+func fdAccessMode(fd uint32) (mode uint32, ok bool) {
+	switch fd {
+	case FdStdin, FdPreimageRead, FdHintRead:
+		return ORdOnly, true
+	case FdStdout, FdStderr, FdPreimageWrite, FdHintWrite:
+		return OWrOnly, true
+	case FdEventFd, FdEpoll:
+		return ORdWr, true
+	default:
+		return 0, false
+	}
+}
+
+// fdFileMode returns the st_mode of one of the fixed file descriptors available to the guest.
+// Stdio are character devices like /dev/null, the hint and pre-image channels are pipes,
+// and the eventfd and epoll descriptors are anonymous inodes without a file type.
+func fdFileMode(fd uint32) (mode uint32, ok bool) {
+	switch fd {
+	case FdStdin, FdStdout, FdStderr:
+		return SIfChr | 0o666, true
+	case FdHintRead, FdHintWrite, FdPreimageRead, FdPreimageWrite:
+		return SIfIfo | 0o600, true
+	case FdEventFd, FdEpoll:
+		return 0o600, true
+	default:
+		return 0, false
+	}
+}
+
+// HandleSysFstat64 reports the file type of the fixed file descriptors.
+// Only st_mode is written, since a single memory word can be proven onchain.
+// The rest of the stat64 struct at the target address is left untouched.
+func HandleSysFstat64(a0, a1 uint32, memory *memory.Memory, memTracker MemTracker) (v0, v1 uint32) {
+	// args: a0 = fd, a1 = statbuf
+	mode, ok := fdFileMode(a0)
+	if !ok {
+		return 0xFFffFFff, MipsEBADF
+	}
+	effAddr := (a1 + Stat64ModeOffset) & 0xFFffFFfc
+	memTracker.TrackMemAccess(effAddr)
+	memory.SetMemory(effAddr, mode)
+	return 0, 0
+}
+
 func HandleSysFcntl(a0, a1 uint32) (v0, v1 uint32) {
 	// args: a0 = fd, a1 = cmd
 	v1 = uint32(0)
 
-	if a1 == 3 { // F_GETFL: get file descriptor flags
-		switch a0 {
-		case FdStdin, FdPreimageRead, FdHintRead:
-			v0 = 0 // O_RDONLY
-		case FdStdout, FdStderr, FdPreimageWrite, FdHintWrite:
-			v0 = 1 // O_WRONLY
-		default:
-			v0 = 0xFFffFFff
-			v1 = MipsEBADF
+	mode, ok := fdAccessMode(a0)
+	switch a1 {
+	case FcntlGetFl, FcntlGetFd, FcntlSetFd, FcntlSetFl, FcntlDupFd, FcntlDupFdCloexec:
+		if !ok {
+			return 0xFFffFFff, MipsEBADF
 		}
-	} else {
+	default:
+		return 0xFFffFFff, MipsEINVAL // cmd not recognized by this kernel
+	}
+
+	switch a1 {
+	case FcntlGetFl: // get file status flags
+		v0 = mode
+	case FcntlGetFd: // get file descriptor flags: FD_CLOEXEC is never set
+		v0 = 0
+	case FcntlSetFd, FcntlSetFl: // flags are accepted, but ignored
+		v0 = 0
+	case FcntlDupFd, FcntlDupFdCloexec: // the fd table is fixed, no new descriptors can be allocated
 		v0 = 0xFFffFFff
-		v1 = MipsEINVAL // cmd not recognized by this kernel
+		v1 = MipsEMFILE
 	}
 
 	return v0, v1
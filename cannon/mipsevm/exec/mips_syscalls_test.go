@@ -0,0 +1,196 @@
+package exec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+)
+
+func TestClockGettimeValue(t *testing.T) {
+	step := uint64(3*HZ + 7)
+	for _, clkid := range []uint32{ClockGettimeRealtimeFlag, ClockGettimeRealtimeCoarseFlag, ClockGettimeRealtimeAlarmFlag} {
+		secs, nsecs, ok := ClockGettimeValue(clkid, step)
+		require.True(t, ok)
+		require.Zero(t, secs, "realtime clock %d is fixed at the epoch", clkid)
+		require.Zero(t, nsecs)
+	}
+	for _, clkid := range []uint32{ClockGettimeMonotonicFlag, ClockGettimeProcessCPUTimeFlag, ClockGettimeThreadCPUTimeFlag,
+		ClockGettimeMonotonicRawFlag, ClockGettimeMonotonicCoarseFlag, ClockGettimeBoottimeFlag, ClockGettimeBoottimeAlarmFlag} {
+		secs, nsecs, ok := ClockGettimeValue(clkid, step)
+		require.True(t, ok)
+		require.Equal(t, uint32(3), secs, "clock %d", clkid)
+		require.Equal(t, uint32(7*(1_000_000_000/HZ)), nsecs, "clock %d", clkid)
+	}
+	_, _, ok := ClockGettimeValue(10, step)
+	require.False(t, ok)
+}
+
+func TestHandleSysBrk(t *testing.T) {
+	for _, brk := range []uint32{0, program.PROGRAM_BREAK} {
+		v0, v1 := HandleSysBrk(brk, program.DefaultLayout())
+		require.Equal(t, uint32(program.PROGRAM_BREAK), v0)
+		require.Zero(t, v1)
+	}
+	for _, brk := range []uint32{program.PROGRAM_BREAK - memory.PageSize, program.PROGRAM_BREAK + memory.PageSize, ^uint32(0)} {
+		v0, v1 := HandleSysBrk(brk, program.DefaultLayout())
+		require.Equal(t, SysErrorSignal, v0, "shrinking or growing the break at 0x%x", brk)
+		require.Equal(t, uint32(MipsENOMEM), v1)
+	}
+}
+
+func TestHandleSysMmapBounds(t *testing.T) {
+	// fixed mappings must end below the stack region
+	v0, v1, heap := HandleSysMmap(program.STACK_LIMIT-memory.PageSize, 1, program.HEAP_START, program.DefaultLayout())
+	require.Equal(t, uint32(program.STACK_LIMIT-memory.PageSize), v0)
+	require.Zero(t, v1)
+	require.Equal(t, uint32(program.HEAP_START), heap)
+	for _, c := range [][2]uint32{{program.STACK_LIMIT - memory.PageSize, memory.PageSize + 1}, {program.STACK_LIMIT, memory.PageSize}, {0xFF_FF_F0_00, 2 * memory.PageSize}} {
+		v0, v1, heap = HandleSysMmap(c[0], c[1], program.HEAP_START, program.DefaultLayout())
+		require.Equal(t, SysErrorSignal, v0, "mapping 0x%x bytes at 0x%x", c[1], c[0])
+		require.Equal(t, uint32(MipsEINVAL), v1)
+		require.Equal(t, uint32(program.HEAP_START), heap)
+	}
+}
+
+func TestHandleSysGetRandom(t *testing.T) {
+	mem := memory.NewMemory()
+	mem.SetMemory(0x1000, 0xAABBCCDD)
+	tracker := NewMemoryTracker(mem)
+	tracker.Reset(false)
+
+	v0, v1 := HandleSysGetRandom(0x1002, 100, 42, mem, tracker)
+	require.Equal(t, uint32(2), v0, "only up to the end of the word")
+	require.Equal(t, uint32(0), v1)
+	got := mem.GetMemory(0x1000)
+	require.Equal(t, uint32(0xAABB0000), got&0xFFFF0000, "bytes before the buffer are untouched")
+	require.Equal(t, uint32(splitmix64(42))&0xFFFF, got&0xFFFF)
+
+	// deterministic for the same step
+	mem2 := memory.NewMemory()
+	mem2.SetMemory(0x1000, 0xAABBCCDD)
+	_, _ = HandleSysGetRandom(0x1002, 100, 42, mem2, NewMemoryTracker(mem2))
+	require.Equal(t, got, mem2.GetMemory(0x1000))
+
+	v0, _ = HandleSysGetRandom(0x1000, 0, 43, mem, tracker)
+	require.Equal(t, uint32(0), v0)
+	require.Equal(t, got, mem.GetMemory(0x1000))
+}
+
+func TestHandleSysFcntl(t *testing.T) {
+	cases := []struct {
+		name string
+		fd   uint32
+		cmd  uint32
+		v0   uint32
+		v1   uint32
+	}{
+		{"getfl stdin", FdStdin, FcntlGetFl, ORdOnly, 0},
+		{"getfl preimage write", FdPreimageWrite, FcntlGetFl, OWrOnly, 0},
+		{"getfl eventfd", FdEventFd, FcntlGetFl, ORdWr, 0},
+		{"getfl epoll", FdEpoll, FcntlGetFl, ORdWr, 0},
+		{"getfd", FdHintRead, FcntlGetFd, 0, 0},
+		{"setfd", FdStdout, FcntlSetFd, 0, 0},
+		{"setfl", FdStderr, FcntlSetFl, 0, 0},
+		{"dupfd", FdStdout, FcntlDupFd, SysErrorSignal, MipsEMFILE},
+		{"dupfd cloexec", FdStdout, FcntlDupFdCloexec, SysErrorSignal, MipsEMFILE},
+		{"unknown fd", 100, FcntlGetFd, SysErrorSignal, MipsEBADF},
+		{"unknown cmd", FdStdin, 5, SysErrorSignal, MipsEINVAL},
+		{"unknown cmd and fd", 100, 5, SysErrorSignal, MipsEINVAL},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v0, v1 := HandleSysFcntl(c.fd, c.cmd)
+			require.Equal(t, c.v0, v0)
+			require.Equal(t, c.v1, v1)
+		})
+	}
+}
+
+func TestHandleSysFstat64(t *testing.T) {
+	cases := []struct {
+		name string
+		fd   uint32
+		mode uint32
+	}{
+		{"stdin", FdStdin, SIfChr | 0o666},
+		{"stderr", FdStderr, SIfChr | 0o666},
+		{"hint read", FdHintRead, SIfIfo | 0o600},
+		{"preimage write", FdPreimageWrite, SIfIfo | 0o600},
+		{"eventfd", FdEventFd, 0o600},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mem := memory.NewMemory()
+			mem.SetMemory(0x1000+Stat64ModeOffset+4, 0xAABBCCDD)
+			v0, v1 := HandleSysFstat64(c.fd, 0x1000, mem, NewMemoryTracker(mem))
+			require.Equal(t, uint32(0), v0)
+			require.Equal(t, uint32(0), v1)
+			require.Equal(t, c.mode, mem.GetMemory(0x1000+Stat64ModeOffset))
+			require.Equal(t, uint32(0xAABBCCDD), mem.GetMemory(0x1000+Stat64ModeOffset+4), "rest of the struct is untouched")
+		})
+	}
+
+	mem := memory.NewMemory()
+	v0, v1 := HandleSysFstat64(100, 0x1000, mem, NewMemoryTracker(mem))
+	require.Equal(t, SysErrorSignal, v0)
+	require.Equal(t, uint32(MipsEBADF), v1)
+	require.Equal(t, uint32(0), mem.GetMemory(0x1000+Stat64ModeOffset))
+}
+
+func TestHandleSysTimers(t *testing.T) {
+	v0, v1 := HandleSysSetITimer(ITimerProf, 0)
+	require.Equal(t, uint32(0), v0)
+	require.Equal(t, uint32(0), v1)
+	for _, c := range [][2]uint32{{3, 0}, {ITimerReal, 0x1000}} {
+		v0, v1 = HandleSysSetITimer(c[0], c[1])
+		require.Equal(t, SysErrorSignal, v0, "setitimer %d with old value at 0x%x", c[0], c[1])
+		require.Equal(t, uint32(MipsEINVAL), v1)
+	}
+
+	mem := memory.NewMemory()
+	mem.SetMemory(0x1000, 0xAABBCCDD)
+	v0, v1 = HandleSysTimerCreate(ClockGettimeThreadCPUTimeFlag, 0x1000, mem, NewMemoryTracker(mem))
+	require.Equal(t, uint32(0), v0)
+	require.Equal(t, uint32(0), v1)
+	require.Equal(t, uint32(TimerID), mem.GetMemory(0x1000))
+	mem.SetMemory(0x1000, 0xAABBCCDD)
+	v0, v1 = HandleSysTimerCreate(10, 0x1000, mem, NewMemoryTracker(mem))
+	require.Equal(t, SysErrorSignal, v0)
+	require.Equal(t, uint32(MipsEINVAL), v1)
+	require.Equal(t, uint32(0xAABBCCDD), mem.GetMemory(0x1000), "timer id is not written for unknown clocks")
+
+	v0, v1 = HandleSysTimerSetTime(TimerID, 0)
+	require.Equal(t, uint32(0), v0)
+	require.Equal(t, uint32(0), v1)
+	for _, c := range [][2]uint32{{TimerID + 1, 0}, {TimerID, 0x1000}} {
+		v0, v1 = HandleSysTimerSetTime(c[0], c[1])
+		require.Equal(t, SysErrorSignal, v0, "timer_settime %d with old value at 0x%x", c[0], c[1])
+		require.Equal(t, uint32(MipsEINVAL), v1)
+	}
+
+	v0, v1 = HandleSysTimerDelete(TimerID)
+	require.Equal(t, uint32(0), v0)
+	require.Equal(t, uint32(0), v1)
+	v0, v1 = HandleSysTimerDelete(TimerID + 1)
+	require.Equal(t, SysErrorSignal, v0)
+	require.Equal(t, uint32(MipsEINVAL), v1)
+}
+
+func TestHandleSysEpoll(t *testing.T) {
+	v0, v1 := HandleSysEpollCtl(FdEpoll)
+	require.Equal(t, uint32(0), v0)
+	require.Equal(t, uint32(0), v1)
+	v0, v1 = HandleSysEpollPwait(FdEpoll)
+	require.Equal(t, uint32(0), v0)
+	require.Equal(t, uint32(0), v1)
+
+	v0, v1 = HandleSysEpollCtl(FdEventFd)
+	require.Equal(t, uint32(SysErrorSignal), v0)
+	require.Equal(t, uint32(MipsEBADF), v1)
+	v0, v1 = HandleSysEpollPwait(FdStdin)
+	require.Equal(t, uint32(SysErrorSignal), v0)
+	require.Equal(t, uint32(MipsEBADF), v1)
+}
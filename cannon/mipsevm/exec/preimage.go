@@ -17,6 +17,8 @@ type TrackingPreimageOracleReader struct {
 
 	totalPreimageSize   int
 	numPreimageRequests int
+	totalHintSize       int
+	numHints            int
 
 	// cached pre-image data, including 8 byte length prefix
 	lastPreimage []byte
@@ -35,6 +37,8 @@ func (p *TrackingPreimageOracleReader) Reset() {
 }
 
 func (p *TrackingPreimageOracleReader) Hint(v []byte) {
+	p.numHints++
+	p.totalHintSize += len(v)
 	p.po.Hint(v)
 }
 
@@ -75,3 +79,11 @@ func (p *TrackingPreimageOracleReader) TotalPreimageSize() int {
 func (p *TrackingPreimageOracleReader) NumPreimageRequests() int {
 	return p.numPreimageRequests
 }
+
+func (p *TrackingPreimageOracleReader) TotalHintSize() int {
+	return p.totalHintSize
+}
+
+func (p *TrackingPreimageOracleReader) NumHints() int {
+	return p.numHints
+}
@@ -0,0 +1,80 @@
+package exec
+
+import (
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+)
+
+// SyscallContext describes a syscall that is about to be handled by the VM.
+type SyscallContext struct {
+	SyscallNum uint32
+	A0         uint32
+	A1         uint32
+	A2         uint32
+	A3         uint32
+	// Step is the current VM step
+	Step uint64
+	// Memory is the guest memory. Hooks that modify it make the VM diverge from the onchain implementation.
+	Memory *memory.Memory
+}
+
+// SyscallHookFn is invoked before the VM handles a syscall.
+// If handled is true, the VM skips its own handling of the syscall and returns v0 and v1 to the guest.
+// Hooks that only observe syscalls must return handled as false.
+//
+// Hooks are meant for testing and instrumentation:
+// overriding a syscall makes the Go VM diverge from the onchain implementation.
+type SyscallHookFn func(ctx *SyscallContext) (v0, v1 uint32, handled bool)
+
+type syscallHook struct {
+	id uint64
+	fn SyscallHookFn
+}
+
+var (
+	syscallHooksLock sync.RWMutex
+	syscallHooks     = make(map[uint32][]syscallHook)
+	nextSyscallHook  uint64
+)
+
+// RegisterSyscallHook registers a hook for the given syscall number, for all VM instances.
+// Hooks run in registration order, until one of them handles the syscall.
+// The returned function removes the hook again.
+func RegisterSyscallHook(syscallNum uint32, fn SyscallHookFn) (unregister func()) {
+	syscallHooksLock.Lock()
+	defer syscallHooksLock.Unlock()
+	id := nextSyscallHook
+	nextSyscallHook++
+	syscallHooks[syscallNum] = append(syscallHooks[syscallNum], syscallHook{id: id, fn: fn})
+	return func() {
+		syscallHooksLock.Lock()
+		defer syscallHooksLock.Unlock()
+		hooks := syscallHooks[syscallNum]
+		for i, h := range hooks {
+			if h.id == id {
+				hooks = append(hooks[:i:i], hooks[i+1:]...)
+				break
+			}
+		}
+		if len(hooks) == 0 {
+			delete(syscallHooks, syscallNum)
+		} else {
+			syscallHooks[syscallNum] = hooks
+		}
+	}
+}
+
+// RunSyscallHooks runs the hooks registered for the syscall.
+// Returns handled as true if a hook overrides the syscall handling, with the v0 and v1 to return to the guest.
+func RunSyscallHooks(ctx *SyscallContext) (v0, v1 uint32, handled bool) {
+	syscallHooksLock.RLock()
+	hooks := syscallHooks[ctx.SyscallNum]
+	syscallHooksLock.RUnlock()
+	for _, h := range hooks {
+		if v0, v1, handled = h.fn(ctx); handled {
+			return v0, v1, true
+		}
+	}
+	return 0, 0, false
+}
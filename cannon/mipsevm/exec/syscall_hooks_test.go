@@ -0,0 +1,39 @@
+package exec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyscallHooks(t *testing.T) {
+	var observed []uint32
+	unregisterObserver := RegisterSyscallHook(SysRead, func(ctx *SyscallContext) (uint32, uint32, bool) {
+		observed = append(observed, ctx.A0)
+		return 0, 0, false
+	})
+	defer unregisterObserver()
+
+	ctx := &SyscallContext{SyscallNum: SysRead, A0: FdPreimageRead}
+	_, _, handled := RunSyscallHooks(ctx)
+	require.False(t, handled, "observers do not handle syscalls")
+	require.Equal(t, []uint32{FdPreimageRead}, observed)
+
+	unregisterOverride := RegisterSyscallHook(SysRead, func(ctx *SyscallContext) (uint32, uint32, bool) {
+		return SysErrorSignal, MipsEAGAIN, true
+	})
+	v0, v1, handled := RunSyscallHooks(ctx)
+	require.True(t, handled)
+	require.Equal(t, SysErrorSignal, v0)
+	require.Equal(t, uint32(MipsEAGAIN), v1)
+	require.Len(t, observed, 2, "hooks run in registration order")
+
+	_, _, handled = RunSyscallHooks(&SyscallContext{SyscallNum: SysWrite})
+	require.False(t, handled, "hooks are per syscall number")
+
+	unregisterOverride()
+	unregisterOverride() // removing twice is harmless
+	_, _, handled = RunSyscallHooks(ctx)
+	require.False(t, handled)
+	require.Len(t, observed, 3)
+}
@@ -19,6 +19,9 @@ type FPVMState interface {
 	// GetHeap returns the current memory address at the top of the heap
 	GetHeap() uint32
 
+	// GetLayout returns the memory layout of the guest
+	GetLayout() *Layout
+
 	// GetPreimageKey returns the most recently accessed preimage key
 	GetPreimageKey() common.Hash
 
@@ -0,0 +1,103 @@
+package mipsevm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/serialize"
+)
+
+// LAYOUT_WITNESS_SIZE is the size of the layout witness encoding in bytes.
+const LAYOUT_WITNESS_SIZE = 5*4 + 8
+
+var ErrMemoryLimit = errors.New("memory limit exceeded")
+
+// Layout describes the memory layout of the guest program.
+// The onchain VMs only support the default layout (see program.DefaultLayout),
+// states with any other layout can only be executed offchain.
+type Layout struct {
+	// HeapStart is the address of the first anonymous mapping
+	HeapStart uint32 `json:"heapStart"`
+	// HeapEnd is the end of the anonymous mappings
+	HeapEnd uint32 `json:"heapEnd"`
+	// ProgramBreak is the fixed program break, reported by brk
+	ProgramBreak uint32 `json:"programBreak"`
+	// StackPointer is the initial stack pointer
+	StackPointer uint32 `json:"stackPointer"`
+	// StackLimit is the lowest address of the stack region. Mappings at fixed addresses must end below it.
+	StackLimit uint32 `json:"stackLimit"`
+	// MaxMemory is the maximum amount of memory the guest may allocate, in bytes. 0 is unlimited.
+	MaxMemory uint64 `json:"maxMemory"`
+}
+
+// Check returns an error if the regions of the layout are not page aligned, or overlap.
+func (l *Layout) Check() error {
+	if (l.HeapStart|l.HeapEnd|l.ProgramBreak|l.StackLimit|l.StackPointer)&memory.PageAddrMask != 0 {
+		return fmt.Errorf("layout regions must be aligned to %d byte pages", memory.PageSize)
+	}
+	if l.HeapStart > l.HeapEnd {
+		return fmt.Errorf("heap start 0x%08x is above heap end 0x%08x", l.HeapStart, l.HeapEnd)
+	}
+	if l.HeapEnd > l.StackLimit {
+		return fmt.Errorf("heap end 0x%08x is above stack limit 0x%08x", l.HeapEnd, l.StackLimit)
+	}
+	if l.HeapStart > l.ProgramBreak {
+		return fmt.Errorf("heap start 0x%08x is above program break 0x%08x", l.HeapStart, l.ProgramBreak)
+	}
+	if l.ProgramBreak > l.StackLimit {
+		return fmt.Errorf("program break 0x%08x is above stack limit 0x%08x", l.ProgramBreak, l.StackLimit)
+	}
+	// the initial stack takes up 4 pages below the stack pointer
+	if uint64(l.StackPointer) < uint64(l.StackLimit)+4*memory.PageSize {
+		return fmt.Errorf("stack pointer 0x%08x leaves no room for the initial stack above stack limit 0x%08x", l.StackPointer, l.StackLimit)
+	}
+	return nil
+}
+
+// CheckMemoryLimit returns ErrMemoryLimit if the allocated memory exceeds MaxMemory.
+func (l *Layout) CheckMemoryLimit(allocated uint64) error {
+	if l.MaxMemory != 0 && allocated > l.MaxMemory {
+		return fmt.Errorf("%w: %d bytes allocated, limit is %d bytes", ErrMemoryLimit, allocated, l.MaxMemory)
+	}
+	return nil
+}
+
+// EncodeWitness encodes the layout fields in order. States with a custom layout extend their witness with it.
+func (l *Layout) EncodeWitness() []byte {
+	out := make([]byte, 0, LAYOUT_WITNESS_SIZE)
+	out = binary.BigEndian.AppendUint32(out, l.HeapStart)
+	out = binary.BigEndian.AppendUint32(out, l.HeapEnd)
+	out = binary.BigEndian.AppendUint32(out, l.ProgramBreak)
+	out = binary.BigEndian.AppendUint32(out, l.StackPointer)
+	out = binary.BigEndian.AppendUint32(out, l.StackLimit)
+	return binary.BigEndian.AppendUint64(out, l.MaxMemory)
+}
+
+// Serialize writes the layout in the same encoding as the witness.
+func (l *Layout) Serialize(w io.Writer) error {
+	bout := serialize.NewBinaryWriter(w)
+	for _, v := range []any{l.HeapStart, l.HeapEnd, l.ProgramBreak, l.StackPointer, l.StackLimit, l.MaxMemory} {
+		if err := bout.WriteUInt(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *Layout) Deserialize(in io.Reader) error {
+	bin := serialize.NewBinaryReader(in)
+	for _, v := range []any{&l.HeapStart, &l.HeapEnd, &l.ProgramBreak, &l.StackPointer, &l.StackLimit, &l.MaxMemory} {
+		if err := bin.ReadUInt(v); err != nil {
+			return err
+		}
+	}
+	return l.Check()
+}
+
+func (l Layout) String() string {
+	return fmt.Sprintf("heapStart=0x%08x heapEnd=0x%08x programBreak=0x%08x stackPointer=0x%08x stackLimit=0x%08x maxMemory=%d",
+		l.HeapStart, l.HeapEnd, l.ProgramBreak, l.StackPointer, l.StackLimit, l.MaxMemory)
+}
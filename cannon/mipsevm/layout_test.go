@@ -0,0 +1,49 @@
+package mipsevm_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+)
+
+func TestLayoutCheck(t *testing.T) {
+	require.NoError(t, program.DefaultLayout().Check())
+
+	cases := map[string]func(l *mipsevm.Layout){
+		"unaligned heap":        func(l *mipsevm.Layout) { l.HeapStart += 4 },
+		"heap above break":      func(l *mipsevm.Layout) { l.HeapStart = l.ProgramBreak + 0x1000 },
+		"heap start above end":  func(l *mipsevm.Layout) { l.HeapStart = l.HeapEnd + 0x1000 },
+		"heap end above stack":  func(l *mipsevm.Layout) { l.HeapEnd = l.StackLimit + 0x1000 },
+		"break above stack":     func(l *mipsevm.Layout) { l.ProgramBreak = l.StackLimit + 0x1000 },
+		"stack pointer too low": func(l *mipsevm.Layout) { l.StackPointer = l.StackLimit + 0x3000 },
+	}
+	for name, mutate := range cases {
+		l := program.DefaultLayout()
+		mutate(l)
+		require.Error(t, l.Check(), name)
+	}
+}
+
+func TestLayoutMemoryLimit(t *testing.T) {
+	l := program.DefaultLayout()
+	require.NoError(t, l.CheckMemoryLimit(1<<32), "unlimited by default")
+	l.MaxMemory = 1 << 20
+	require.NoError(t, l.CheckMemoryLimit(1<<20))
+	require.ErrorIs(t, l.CheckMemoryLimit(1<<20+4096), mipsevm.ErrMemoryLimit)
+}
+
+func TestLayoutSerialize(t *testing.T) {
+	l := &mipsevm.Layout{HeapStart: 0x1000_0000, HeapEnd: 0x2400_0000, ProgramBreak: 0x2000_0000, StackPointer: 0x3000_0000, StackLimit: 0x2800_0000, MaxMemory: 1 << 30}
+	var buf bytes.Buffer
+	require.NoError(t, l.Serialize(&buf))
+	require.Equal(t, l.EncodeWitness(), buf.Bytes())
+	require.Len(t, buf.Bytes(), mipsevm.LAYOUT_WITNESS_SIZE)
+
+	var res mipsevm.Layout
+	require.NoError(t, res.Deserialize(&buf))
+	require.Equal(t, *l, res)
+}
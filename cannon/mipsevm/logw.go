@@ -1,6 +1,12 @@
 package mipsevm
 
 import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"sort"
+	"strings"
+
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -30,3 +36,106 @@ func (lw *LoggingWriter) Write(b []byte) (int, error) {
 	}
 	return len(b), nil
 }
+
+// maxGuestLineSize is the size at which a guest line is logged, even if it is not terminated yet.
+const maxGuestLineSize = 64 * 1024
+
+// LineLoggingWriter buffers the output of the program running within the VM,
+// and logs every line as a separate record, with the line in the "guest" attribute.
+// With JSON passthrough, lines that are JSON objects are logged with their fields as attributes instead,
+// using the "msg" and "level" fields of the object for the message and level of the record.
+type LineLoggingWriter struct {
+	Log             log.Logger
+	JSONPassthrough bool
+
+	buf []byte
+}
+
+func (lw *LineLoggingWriter) Write(b []byte) (int, error) {
+	n := len(b)
+	for len(b) > 0 {
+		i := bytes.IndexByte(b, '\n')
+		if i < 0 {
+			lw.buf = append(lw.buf, b...)
+			if len(lw.buf) >= maxGuestLineSize {
+				lw.Flush()
+			}
+			break
+		}
+		lw.buf = append(lw.buf, b[:i]...)
+		b = b[i+1:]
+		lw.Flush()
+	}
+	return n, nil
+}
+
+// Flush logs the buffered output, if any, even if the line is not terminated yet.
+func (lw *LineLoggingWriter) Flush() {
+	if len(lw.buf) == 0 {
+		return
+	}
+	line := string(bytes.TrimSuffix(lw.buf, []byte("\r")))
+	lw.buf = lw.buf[:0]
+	if lw.JSONPassthrough && lw.logJSON(line) {
+		return
+	}
+	if logAsText(line) {
+		lw.Log.Info("", "guest", line)
+	} else {
+		lw.Log.Info("", "data", hexutil.Bytes(line))
+	}
+}
+
+// logJSON logs the line as a record with the fields of the JSON object. Returns false if the line is not a JSON object.
+func (lw *LineLoggingWriter) logJSON(line string) bool {
+	if !strings.HasPrefix(strings.TrimSpace(line), "{") {
+		return false
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(line), &fields); err != nil {
+		return false
+	}
+	msg, level := "", log.LevelInfo
+	for _, key := range []string{"msg", "message"} {
+		if v, ok := fields[key].(string); ok {
+			msg = v
+			delete(fields, key)
+			break
+		}
+	}
+	for _, key := range []string{"level", "lvl"} {
+		if v, ok := fields[key].(string); ok {
+			level = guestLogLevel(v)
+			delete(fields, key)
+			break
+		}
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	attrs := make([]any, 0, 2*len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, "guest."+k, fields[k])
+	}
+	lw.Log.Log(level, msg, attrs...)
+	return true
+}
+
+func guestLogLevel(v string) slog.Level {
+	switch strings.ToLower(v) {
+	case "trace", "trce":
+		return log.LevelTrace
+	case "debug", "dbug":
+		return log.LevelDebug
+	case "warn", "warning":
+		return log.LevelWarn
+	case "error", "eror":
+		return log.LevelError
+	case "crit", "fatal", "panic":
+		return log.LevelCrit
+	default:
+		return log.LevelInfo
+	}
+}
@@ -0,0 +1,38 @@
+package mipsevm
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestLineLoggingWriter(t *testing.T) {
+	t.Run("lines", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+		w := &LineLoggingWriter{Log: logger}
+		_, _ = w.Write([]byte("hello "))
+		require.Empty(t, logs.FindLogs(), "line is buffered")
+		_, _ = w.Write([]byte("world\nsecond\r\nthi"))
+		w.Flush()
+		recs := logs.FindLogs()
+		require.Len(t, recs, 3)
+		require.Equal(t, "hello world", recs[0].AttrValue("guest"))
+		require.Equal(t, "second", recs[1].AttrValue("guest"))
+		require.Equal(t, "thi", recs[2].AttrValue("guest"))
+	})
+
+	t.Run("json passthrough", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelDebug)
+		w := &LineLoggingWriter{Log: logger, JSONPassthrough: true}
+		_, _ = w.Write([]byte(`{"level":"warn","msg":"derived block","number":12}` + "\n" + `{not json}` + "\n"))
+		recs := logs.FindLogs()
+		require.Len(t, recs, 2)
+		require.Equal(t, log.LevelWarn, recs[0].Level)
+		require.Equal(t, "derived block", recs[0].Message)
+		require.Equal(t, float64(12), recs[0].AttrValue("guest.number"))
+		require.Equal(t, "{not json}", recs[1].AttrValue("guest"))
+	})
+}
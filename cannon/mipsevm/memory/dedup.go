@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// dedupMarker is set in the page count of deduplicated serialized memory.
+// The page count never exceeds MaxPageCount, so the marker does not conflict with the plain format.
+const dedupMarker = uint32(1 << 31)
+
+// SetDeduplicatedSerialization configures Serialize to write pages with identical contents only once.
+// Deserialize reads both the plain and the deduplicated format.
+func (m *Memory) SetDeduplicatedSerialization(dedup bool) {
+	m.dedup = dedup
+}
+
+// serializeDeduplicated writes the memory with content-addressed pages:
+// every distinct page is written once, and the page indices refer to their contents.
+//
+// dedupMarker | PageCount    uint32
+// len(Contents)              uint32
+// For each distinct page content, in order of first use:
+//
+//	page Data           [PageSize]byte
+//
+// For each page, in order of page index:
+//
+//	page index          uint32
+//	content index       uint32
+func (m *Memory) serializeDeduplicated(out io.Writer) error {
+	indices := make([]uint32, 0, len(m.pages))
+	for pageIndex := range m.pages {
+		indices = append(indices, pageIndex)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+
+	contentIndices := make(map[Page]uint32)
+	var contents []*Page
+	refs := make([]uint32, len(indices))
+	for i, pageIndex := range indices {
+		data := m.pages[pageIndex].Data
+		ci, ok := contentIndices[*data]
+		if !ok {
+			ci = uint32(len(contents))
+			contentIndices[*data] = ci
+			contents = append(contents, data)
+		}
+		refs[i] = ci
+	}
+
+	if err := binary.Write(out, binary.BigEndian, dedupMarker|uint32(len(indices))); err != nil {
+		return err
+	}
+	if err := binary.Write(out, binary.BigEndian, uint32(len(contents))); err != nil {
+		return err
+	}
+	for _, data := range contents {
+		if _, err := out.Write(data[:]); err != nil {
+			return err
+		}
+	}
+	for i, pageIndex := range indices {
+		if err := binary.Write(out, binary.BigEndian, [2]uint32{pageIndex, refs[i]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deserializeDeduplicated reads memory written by serializeDeduplicated, after the page count.
+// Pages with identical contents share their data copy-on-write.
+func (m *Memory) deserializeDeduplicated(in io.Reader, pageCount uint32) error {
+	var contentCount uint32
+	if err := binary.Read(in, binary.BigEndian, &contentCount); err != nil {
+		return err
+	}
+	if contentCount > pageCount {
+		return fmt.Errorf("%d page contents exceed page count %d", contentCount, pageCount)
+	}
+	contents := make([]*Page, contentCount)
+	for i := range contents {
+		contents[i] = new(Page)
+		if _, err := io.ReadFull(in, contents[i][:]); err != nil {
+			return err
+		}
+	}
+	refs := make([][2]uint32, pageCount)
+	uses := make([]int, contentCount)
+	for i := range refs {
+		if err := binary.Read(in, binary.BigEndian, &refs[i]); err != nil {
+			return err
+		}
+		if refs[i][1] >= contentCount {
+			return fmt.Errorf("page %d refers to unknown content %d", refs[i][0], refs[i][1])
+		}
+		uses[refs[i][1]]++
+	}
+	for _, ref := range refs {
+		pageIndex, contentIndex := ref[0], ref[1]
+		m.allocPage(pageIndex, contents[contentIndex], uses[contentIndex] > 1)
+	}
+	return nil
+}
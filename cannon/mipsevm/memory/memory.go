@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"math/bits"
+	"runtime"
 	"sort"
 
 	"github.com/ethereum/go-ethereum/crypto"
@@ -45,13 +46,18 @@ type Memory struct {
 	// pageIndex -> cached page
 	pages map[uint32]*CachedPage
 
-	// Note: since we don't de-alloc pages, we don't do ref-counting.
-	// Once a page exists, it doesn't leave memory
+	// Note: pages are not ref-counted. A page only leaves memory when explicitly freed with FreePage.
 
 	// two caches: we often read instructions from one page, and do memory things with another page.
 	// this prevents map lookups each instruction
 	lastPageKeys [2]uint32
 	lastPage     [2]*CachedPage
+
+	// recent proofs, likewise for instruction fetches and memory accesses, to reuse siblings across steps
+	proofs [2]cachedProof
+
+	// serialize pages with identical contents only once
+	dedup bool
 }
 
 func NewMemory() *Memory {
@@ -66,6 +72,11 @@ func (m *Memory) PageCount() int {
 	return len(m.pages)
 }
 
+// NodeCount returns the number of merkle nodes currently cached, including invalidated ones.
+func (m *Memory) NodeCount() int {
+	return len(m.nodes)
+}
+
 func (m *Memory) ForEachPage(fn func(pageIndex uint32, page *Page) error) error {
 	for pageIndex, cachedPage := range m.pages {
 		if err := fn(pageIndex, cachedPage.Data); err != nil {
@@ -80,6 +91,7 @@ func (m *Memory) Invalidate(addr uint32) {
 	if addr&0x3 != 0 {
 		panic(fmt.Errorf("unaligned memory access: %x", addr))
 	}
+	m.invalidateProofs(addr)
 
 	// find page, and invalidate addr within it
 	if p, ok := m.pageLookup(addr >> PageAddrSize); ok {
@@ -102,6 +114,12 @@ func (m *Memory) Invalidate(addr uint32) {
 }
 
 func (m *Memory) MerkleizeSubtree(gindex uint64) [32]byte {
+	return m.merkleizeSubtree(gindex, m.nodes)
+}
+
+// merkleizeSubtree computes the root of the subtree at gindex, reading cached nodes from m.nodes,
+// and writing any newly computed intermediate nodes to updates.
+func (m *Memory) merkleizeSubtree(gindex uint64, updates map[uint64]*[32]byte) [32]byte {
 	l := uint64(bits.Len64(gindex))
 	if l > 28 {
 		panic("gindex too deep")
@@ -124,16 +142,25 @@ func (m *Memory) MerkleizeSubtree(gindex uint64) [32]byte {
 	if n != nil {
 		return *n
 	}
-	left := m.MerkleizeSubtree(gindex << 1)
-	right := m.MerkleizeSubtree((gindex << 1) | 1)
+	left := m.merkleizeSubtree(gindex<<1, updates)
+	right := m.merkleizeSubtree((gindex<<1)|1, updates)
 	r := HashPair(left, right)
-	m.nodes[gindex] = &r
+	updates[gindex] = &r
 	return r
 }
 
 func (m *Memory) MerkleProof(addr uint32) (out [MEM_PROOF_SIZE]byte) {
-	proof := m.traverseBranch(1, addr, 0)
+	proof := m.cachedMerkleProof(addr)
 	// encode the proof
+	for i := 0; i < 28; i++ {
+		copy(out[i*32:(i+1)*32], proof.nodes[i][:])
+	}
+	return out
+}
+
+// merkleProofUncached computes the proof by traversing the full branch, without using or updating the proof cache.
+func (m *Memory) merkleProofUncached(addr uint32) (out [MEM_PROOF_SIZE]byte) {
+	proof := m.traverseBranch(1, addr, 0)
 	for i := 0; i < 28; i++ {
 		copy(out[i*32:(i+1)*32], proof[i][:])
 	}
@@ -161,7 +188,7 @@ func (m *Memory) traverseBranch(parent uint64, addr uint32, depth uint8) (proof
 }
 
 func (m *Memory) MerkleRoot() [32]byte {
-	return m.MerkleizeSubtree(1)
+	return m.MerkleRootParallel(runtime.GOMAXPROCS(0))
 }
 
 func (m *Memory) pageLookup(pageIndex uint32) (*CachedPage, bool) {
@@ -199,6 +226,7 @@ func (m *Memory) SetMemory(addr uint32, v uint32) {
 		// Go may mmap relatively large ranges, but we only allocate the pages just in time.
 		p = m.AllocPage(pageIndex)
 	} else {
+		p.own()
 		m.Invalidate(addr) // invalidate this branch of memory, now that the value changed
 	}
 	binary.BigEndian.PutUint32(p.Data[pageAddr:pageAddr+4], v)
@@ -218,8 +246,13 @@ func (m *Memory) GetMemory(addr uint32) uint32 {
 }
 
 func (m *Memory) AllocPage(pageIndex uint32) *CachedPage {
-	p := &CachedPage{Data: new(Page)}
+	return m.allocPage(pageIndex, new(Page), false)
+}
+
+func (m *Memory) allocPage(pageIndex uint32, data *Page, shared bool) *CachedPage {
+	p := &CachedPage{Data: data, shared: shared}
 	m.pages[pageIndex] = p
+	m.resetProofs()
 	// make nodes to root
 	k := (1 << PageKeySize) | uint64(pageIndex)
 	for k > 0 {
@@ -229,6 +262,74 @@ func (m *Memory) AllocPage(pageIndex uint32) *CachedPage {
 	return p
 }
 
+// FreePage drops the page at the given index from memory, and prunes the merkle nodes that no longer cover any page.
+// The page reads as zeroes afterwards. Returns false if the page was not allocated.
+func (m *Memory) FreePage(pageIndex uint32) bool {
+	if _, ok := m.pages[pageIndex]; !ok {
+		return false
+	}
+	delete(m.pages, pageIndex)
+	m.resetProofs()
+	for i := range m.lastPageKeys {
+		if m.lastPageKeys[i] == pageIndex {
+			m.lastPageKeys[i] = ^uint32(0)
+			m.lastPage[i] = nil
+		}
+	}
+
+	k := (1 << PageKeySize) | uint64(pageIndex)
+	delete(m.nodes, k)
+	for k > 1 {
+		_, siblingExists := m.nodes[k^1]
+		k >>= 1
+		if siblingExists {
+			// the remaining ancestors still cover other pages, and have to be recomputed
+			for ; k > 0; k >>= 1 {
+				m.nodes[k] = nil
+			}
+			break
+		}
+		// no pages left in this subtree, it's all zero
+		delete(m.nodes, k)
+	}
+	return true
+}
+
+// FreeZeroPages frees all allocated pages that are fully covered by the given address range and only contain zeroes.
+// Freeing zeroed pages does not change the memory merkle root. Returns the number of freed pages.
+func (m *Memory) FreeZeroPages(addr uint32, size uint32) int {
+	// only pages fully within [addr, addr+size) are covered
+	start := (uint64(addr) + PageAddrMask) >> PageAddrSize
+	end := (uint64(addr) + uint64(size)) >> PageAddrSize
+	if end > MaxPageCount {
+		end = MaxPageCount
+	}
+	if start >= end {
+		return 0
+	}
+	var candidates []uint32
+	if end-start < uint64(len(m.pages)) {
+		for i := start; i < end; i++ {
+			if _, ok := m.pages[uint32(i)]; ok {
+				candidates = append(candidates, uint32(i))
+			}
+		}
+	} else {
+		for pageIndex := range m.pages {
+			if uint64(pageIndex) >= start && uint64(pageIndex) < end {
+				candidates = append(candidates, pageIndex)
+			}
+		}
+	}
+	freed := 0
+	for _, pageIndex := range candidates {
+		if m.pages[pageIndex].Data.IsZero() && m.FreePage(pageIndex) {
+			freed++
+		}
+	}
+	return freed
+}
+
 type pageEntry struct {
 	Index uint32 `json:"index"`
 	Data  *Page  `json:"data"`
@@ -257,6 +358,7 @@ func (m *Memory) UnmarshalJSON(data []byte) error {
 	m.pages = make(map[uint32]*CachedPage)
 	m.lastPageKeys = [2]uint32{^uint32(0), ^uint32(0)}
 	m.lastPage = [2]*CachedPage{nil, nil}
+	m.resetProofs()
 	for i, p := range pages {
 		if _, ok := m.pages[p.Index]; ok {
 			return fmt.Errorf("cannot load duplicate page, entry %d, page index %d", i, p.Index)
@@ -274,7 +376,9 @@ func (m *Memory) SetMemoryRange(addr uint32, r io.Reader) error {
 		if !ok {
 			p = m.AllocPage(pageIndex)
 		}
+		p.own()
 		p.InvalidateFull()
+		m.resetProofs()
 		n, err := r.Read(p.Data[pageAddr:])
 		if err != nil {
 			if err == io.EOF {
@@ -296,6 +400,9 @@ func (m *Memory) SetMemoryRange(addr uint32, r io.Reader) error {
 //	page index          uint32
 //	page Data           [PageSize]byte
 func (m *Memory) Serialize(out io.Writer) error {
+	if m.dedup {
+		return m.serializeDeduplicated(out)
+	}
 	if err := binary.Write(out, binary.BigEndian, uint32(m.PageCount())); err != nil {
 		return err
 	}
@@ -315,6 +422,9 @@ func (m *Memory) Deserialize(in io.Reader) error {
 	if err := binary.Read(in, binary.BigEndian, &pageCount); err != nil {
 		return err
 	}
+	if pageCount&dedupMarker != 0 {
+		return m.deserializeDeduplicated(in, pageCount&^dedupMarker)
+	}
 	for i := uint32(0); i < pageCount; i++ {
 		var pageIndex uint32
 		if err := binary.Read(in, binary.BigEndian, &pageIndex); err != nil {
@@ -334,10 +444,11 @@ func (m *Memory) Copy() *Memory {
 	out.pages = make(map[uint32]*CachedPage)
 	out.lastPageKeys = [2]uint32{^uint32(0), ^uint32(0)}
 	out.lastPage = [2]*CachedPage{nil, nil}
+	out.dedup = m.dedup
+	// pages are shared copy-on-write, until either memory modifies them
 	for k, page := range m.pages {
-		data := new(Page)
-		*data = *page.Data
-		out.AllocPage(k).Data = data
+		page.shared = true
+		out.allocPage(k, page.Data, true)
 	}
 	return out
 }
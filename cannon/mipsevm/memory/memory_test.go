@@ -194,4 +194,116 @@ func TestMemoryCopy(t *testing.T) {
 	mcpy := m.Copy()
 	require.Equal(t, uint32(123), mcpy.GetMemory(0x8000))
 	require.Equal(t, m.MerkleRoot(), mcpy.MerkleRoot())
+
+	t.Run("copy-on-write", func(t *testing.T) {
+		m := NewMemory()
+		m.SetMemory(0x8000, 123)
+		m.SetMemory(0x9000, 456)
+		mcpy := m.Copy()
+		require.Same(t, m.pages[8].Data, mcpy.pages[8].Data, "unchanged pages are shared")
+
+		mcpy.SetMemory(0x8004, 789)
+		require.NoError(t, mcpy.SetMemoryRange(0x9000, bytes.NewReader([]byte{1, 2, 3, 4})))
+		m.SetMemory(0x8008, 42)
+		require.Zero(t, m.GetMemory(0x8004))
+		require.Equal(t, uint32(456), m.GetMemory(0x9000))
+		require.Zero(t, mcpy.GetMemory(0x8008))
+		require.Equal(t, uint32(0x01020304), mcpy.GetMemory(0x9000))
+
+		expected := NewMemory()
+		expected.SetMemory(0x8000, 123)
+		expected.SetMemory(0x8008, 42)
+		expected.SetMemory(0x9000, 456)
+		require.Equal(t, expected.MerkleRoot(), m.MerkleRoot())
+		expected.SetMemory(0x8008, 0)
+		expected.SetMemory(0x8004, 789)
+		expected.SetMemory(0x9000, 0x01020304)
+		require.Equal(t, expected.MerkleRoot(), mcpy.MerkleRoot())
+	})
+}
+
+func TestMemoryDeduplicatedSerialization(t *testing.T) {
+	m := NewMemory()
+	for i := uint32(0); i < 8; i++ {
+		m.SetMemory(i*PageSize, 0xdeadbeef) // identical pages
+	}
+	m.SetMemory(0x10_0000, 1)
+	var plain bytes.Buffer
+	require.NoError(t, m.Serialize(&plain))
+
+	m.SetDeduplicatedSerialization(true)
+	var dedup bytes.Buffer
+	require.NoError(t, m.Serialize(&dedup))
+	require.Less(t, dedup.Len()*3, plain.Len(), "identical pages are stored once")
+
+	res := NewMemory()
+	require.NoError(t, res.Deserialize(bytes.NewReader(dedup.Bytes())))
+	require.Equal(t, m.PageCount(), res.PageCount())
+	require.Equal(t, m.MerkleRoot(), res.MerkleRoot())
+	require.Same(t, res.pages[0].Data, res.pages[7].Data, "identical pages share data")
+
+	res.SetMemory(4, 5)
+	require.Equal(t, uint32(5), res.GetMemory(4))
+	require.Zero(t, res.GetMemory(PageSize+4))
+
+	var again bytes.Buffer
+	require.NoError(t, m.Serialize(&again))
+	require.Equal(t, dedup.Bytes(), again.Bytes(), "deduplicated format is deterministic")
+}
+
+func TestMemoryFreePage(t *testing.T) {
+	t.Run("free only page", func(t *testing.T) {
+		m := NewMemory()
+		m.SetMemory(0x8000, 123)
+		require.NotEqual(t, zeroHashes[32-5], m.MerkleRoot())
+		require.True(t, m.FreePage(0x8000>>PageAddrSize))
+		require.Equal(t, 0, m.PageCount())
+		require.Empty(t, m.nodes, "all nodes pruned")
+		require.Equal(t, uint32(0), m.GetMemory(0x8000))
+		require.Equal(t, zeroHashes[32-5], m.MerkleRoot())
+		require.False(t, m.FreePage(0x8000>>PageAddrSize), "already freed")
+	})
+	t.Run("free among other pages", func(t *testing.T) {
+		m := NewMemory()
+		m.SetMemory(0x8000, 123)
+		m.SetMemory(0x13370000, 42)
+		expected := NewMemory()
+		expected.SetMemory(0x13370000, 42)
+
+		_ = m.GetMemory(0x8000) // ensure the page is in the lookup cache
+		require.True(t, m.FreePage(0x8000>>PageAddrSize))
+		require.Equal(t, 1, m.PageCount())
+		require.Equal(t, uint32(0), m.GetMemory(0x8000))
+		require.Equal(t, expected.MerkleRoot(), m.MerkleRoot())
+		require.Equal(t, expected.MerkleProof(0x13370000), m.MerkleProof(0x13370000))
+		require.Equal(t, len(expected.nodes), len(m.nodes), "no dangling nodes")
+
+		// re-allocating the page afterwards must work as usual
+		m.SetMemory(0x8000, 7)
+		expected.SetMemory(0x8000, 7)
+		require.Equal(t, expected.MerkleRoot(), m.MerkleRoot())
+	})
+}
+
+func TestMemoryFreeZeroPages(t *testing.T) {
+	m := NewMemory()
+	m.SetMemory(PageSize*1, 0)
+	m.SetMemory(PageSize*2, 5)
+	m.SetMemory(PageSize*3, 0)
+	m.SetMemory(PageSize*4, 0)
+	m.SetMemory(PageSize*6, 0)
+	root := m.MerkleRoot()
+
+	// page 4 is only partially covered, page 2 holds data
+	require.Equal(t, 2, m.FreeZeroPages(PageSize*1, PageSize*3+4))
+	require.Equal(t, 3, m.PageCount())
+	require.Equal(t, root, m.MerkleRoot(), "freeing zero pages must not change the root")
+	require.Equal(t, uint32(5), m.GetMemory(PageSize*2))
+
+	// the full address range, wrapping past the end of memory
+	require.Equal(t, 2, m.FreeZeroPages(0, ^uint32(0)))
+	require.Equal(t, 1, m.PageCount())
+	require.Equal(t, root, m.MerkleRoot())
+
+	require.Equal(t, 0, m.FreeZeroPages(PageSize*2, 0))
 }
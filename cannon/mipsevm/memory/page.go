@@ -62,12 +62,30 @@ func (p *Page) UnmarshalText(dat []byte) error {
 	return err
 }
 
+// IsZero returns true if the page only contains zero bytes.
+func (p *Page) IsZero() bool {
+	return *p == Page{}
+}
+
 type CachedPage struct {
 	Data *Page
 	// intermediate nodes only
 	Cache [PageSize / 32][32]byte
 	// true if the intermediate node is valid
 	Ok [PageSize / 32]bool
+	// true if Data may be shared with other pages, and has to be copied before it is modified
+	shared bool
+}
+
+// own copies the page data if it is shared, so it can be modified.
+func (p *CachedPage) own() {
+	if !p.shared {
+		return
+	}
+	data := new(Page)
+	*data = *p.Data
+	p.Data = data
+	p.shared = false
 }
 
 func (p *CachedPage) Invalidate(pageAddr uint32) {
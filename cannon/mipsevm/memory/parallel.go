@@ -0,0 +1,77 @@
+package memory
+
+import "sync"
+
+const (
+	// parallelSplitDepth is the tree depth at which invalidated subtrees are split into independent work items.
+	// Each work item covers 2**(PageKeySize-parallelSplitDepth) pages.
+	parallelSplitDepth = 8
+	// parallelMinSubtrees is the minimum number of invalidated subtrees before the work is spread over workers.
+	// Below this the goroutine overhead outweighs the hashing work.
+	parallelMinSubtrees = 4
+)
+
+// MerkleRootParallel computes the same root as MerkleRoot,
+// but merkleizes the invalidated subtrees with a pool of up to the given number of workers.
+// Only invalidated parts of the tree are re-hashed; subtrees with valid cached nodes are skipped entirely.
+func (m *Memory) MerkleRootParallel(workers int) [32]byte {
+	if workers > 1 {
+		var dirty []uint64
+		m.collectInvalidated(1, parallelSplitDepth, &dirty)
+		if len(dirty) >= parallelMinSubtrees {
+			m.merkleizeConcurrently(dirty, workers)
+		}
+	}
+	return m.MerkleizeSubtree(1)
+}
+
+// collectInvalidated appends the gindex of every invalidated node, depth levels below the given gindex, to out.
+func (m *Memory) collectInvalidated(gindex uint64, depth uint, out *[]uint64) {
+	n, ok := m.nodes[gindex]
+	if !ok || n != nil {
+		// zeroed or still valid: nothing to recompute
+		return
+	}
+	if depth == 0 {
+		*out = append(*out, gindex)
+		return
+	}
+	m.collectInvalidated(gindex<<1, depth-1, out)
+	m.collectInvalidated((gindex<<1)|1, depth-1, out)
+}
+
+// merkleizeConcurrently merkleizes the given disjoint subtrees with a pool of workers.
+// Workers only read from m.nodes and each writes to a private node map,
+// which are merged back into m.nodes once all workers are done.
+// The pages of each subtree are only ever touched by the single worker that owns the subtree.
+func (m *Memory) merkleizeConcurrently(subtrees []uint64, workers int) {
+	if workers > len(subtrees) {
+		workers = len(subtrees)
+	}
+	work := make(chan uint64, len(subtrees))
+	for _, gindex := range subtrees {
+		work <- gindex
+	}
+	close(work)
+
+	results := make([]map[uint64]*[32]byte, workers)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		updates := make(map[uint64]*[32]byte)
+		results[i] = updates
+		go func() {
+			defer wg.Done()
+			for gindex := range work {
+				m.merkleizeSubtree(gindex, updates)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, updates := range results {
+		for gindex, node := range updates {
+			m.nodes[gindex] = node
+		}
+	}
+}
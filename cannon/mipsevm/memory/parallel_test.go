@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryMerkleRootParallel(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	seq := NewMemory()
+	par := NewMemory()
+	write := func(count int) {
+		for i := 0; i < count; i++ {
+			addr := rng.Uint32() &^ 3
+			v := rng.Uint32()
+			seq.SetMemory(addr, v)
+			par.SetMemory(addr, v)
+		}
+	}
+
+	write(2000)
+	require.Equal(t, seq.MerkleizeSubtree(1), par.MerkleRootParallel(8), "initial root")
+
+	// only a few subtrees invalidated, takes the sequential path
+	write(2)
+	require.Equal(t, seq.MerkleizeSubtree(1), par.MerkleRootParallel(8), "few changes")
+
+	write(500)
+	require.Equal(t, seq.MerkleizeSubtree(1), par.MerkleRootParallel(3), "many changes")
+
+	// proofs must be served from the merged node cache
+	addr := rng.Uint32() &^ 3
+	require.Equal(t, seq.MerkleProof(addr), par.MerkleProof(addr))
+
+	// no further changes, cached root
+	require.Equal(t, seq.MerkleizeSubtree(1), par.MerkleRootParallel(8), "cached")
+}
@@ -0,0 +1,82 @@
+package memory
+
+import "math/bits"
+
+// proofDepth is the number of nodes in a proof: the leaf, followed by the sibling at each level up to the root.
+const proofDepth = MEM_PROOF_SIZE / 32
+
+// cachedProof is a memory proof that is kept between steps.
+// A write to memory only changes one node of the proof of any other leaf: the sibling at the height where
+// the branches of the two leaves diverge. Only invalidated nodes are merkleized again.
+type cachedProof struct {
+	// leaf is the index of the 32-byte leaf the proof is for
+	leaf uint32
+	used bool
+	// nodes[0] is the leaf, nodes[h] is the sibling at height h (the root is at height proofDepth)
+	nodes [proofDepth][32]byte
+	// ok[h] is false if nodes[h] has to be recomputed
+	ok [proofDepth]bool
+}
+
+// invalidateProofs invalidates the node of each cached proof that covers the given address.
+func (m *Memory) invalidateProofs(addr uint32) {
+	leaf := addr >> 5
+	for i := range m.proofs {
+		if p := &m.proofs[i]; p.used {
+			p.ok[bits.Len32(p.leaf^leaf)] = false
+		}
+	}
+}
+
+// resetProofs drops all cached proofs, for changes to the tree that do not go through Invalidate.
+func (m *Memory) resetProofs() {
+	m.proofs = [len(m.proofs)]cachedProof{}
+}
+
+// cachedMerkleProof returns the proof of the given address, deriving it from the cached proof
+// that shares the most siblings with it. The least recently used proof is replaced.
+func (m *Memory) cachedMerkleProof(addr uint32) *cachedProof {
+	leaf := addr >> 5
+	best, diverge := -1, proofDepth
+	for i := range m.proofs {
+		if p := &m.proofs[i]; p.used {
+			if h := bits.Len32(p.leaf ^ leaf); h < diverge {
+				best, diverge = i, h
+			}
+		}
+	}
+
+	var p cachedProof
+	if best >= 0 {
+		p = m.proofs[best]
+		// the siblings at and below the height where the branches diverge are not shared
+		for h := 0; h <= diverge && h < proofDepth; h++ {
+			p.ok[h] = false
+		}
+	} else {
+		best = len(m.proofs) - 1
+	}
+	p.leaf = leaf
+	p.used = true
+
+	for h := range p.nodes {
+		if p.ok[h] {
+			continue
+		}
+		if h == 0 {
+			p.nodes[h] = m.MerkleizeSubtree((1 << (proofDepth - 1)) | uint64(leaf))
+		} else {
+			p.nodes[h] = m.MerkleizeSubtree((1 << (proofDepth - h)) | uint64((leaf>>(h-1))^1))
+		}
+		p.ok[h] = true
+	}
+
+	// a proof for a different leaf replaces the least recently used one
+	if diverge != 0 {
+		best = len(m.proofs) - 1
+	}
+	// keep the most recently used proof first
+	copy(m.proofs[1:best+1], m.proofs[:best])
+	m.proofs[0] = p
+	return &m.proofs[0]
+}
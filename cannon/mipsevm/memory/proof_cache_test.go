@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryMerkleProofCache(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	m := NewMemory()
+	// keep addresses within a few pages, so that proofs share most of their siblings
+	randAddr := func() uint32 {
+		return (rng.Uint32() & 0x3_ff_fc) | 0x1000_0000
+	}
+	check := func(addr uint32) {
+		require.Equal(t, m.merkleProofUncached(addr), m.MerkleProof(addr), "proof of %08x", addr)
+	}
+
+	for i := 0; i < 2000; i++ {
+		addr := randAddr()
+		switch rng.Intn(10) {
+		case 0:
+			m.FreePage(addr >> PageAddrSize)
+		case 1, 2, 3:
+			// unaligned neighbouring proofs
+			check(addr)
+			check(addr + 4)
+			check(addr ^ 0x1000)
+		default:
+			m.SetMemory(addr, rng.Uint32())
+			check(addr)
+		}
+		check(randAddr())
+	}
+
+	// a far away write, with a cached proof for the same leaf
+	pc := randAddr()
+	check(pc)
+	m.SetMemory(0x7000_0000, 1)
+	check(pc)
+	m.SetMemory(pc, 2)
+	check(pc)
+	check(0x7000_0000)
+
+	// memory changes that bypass Invalidate
+	require.NoError(t, m.SetMemoryRange(pc&^PageAddrMask, io.LimitReader(rng, PageSize)))
+	check(pc)
+}
+
+// benchmarkMerkleProofs proves an instruction fetch and a memory write on each step, like the VM does.
+func benchmarkMerkleProofs(b *testing.B, proof func(m *Memory, addr uint32) [MEM_PROOF_SIZE]byte) {
+	rng := rand.New(rand.NewSource(1234))
+	m := NewMemory()
+	for i := 0; i < 10_000; i++ {
+		m.SetMemory(rng.Uint32()&^3, rng.Uint32())
+	}
+	pc := uint32(0x0001_0000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = proof(m, pc)
+		addr := 0x3000_0000 + (rng.Uint32() & 0xff_fc)
+		_ = proof(m, addr)
+		m.SetMemory(addr, uint32(i))
+		pc += 4
+		if pc >= 0x0002_0000 {
+			pc = 0x0001_0000
+		}
+	}
+}
+
+func BenchmarkMerkleProof(b *testing.B) {
+	b.Run("cached", func(b *testing.B) {
+		benchmarkMerkleProofs(b, (*Memory).MerkleProof)
+	})
+	b.Run("uncached", func(b *testing.B) {
+		benchmarkMerkleProofs(b, (*Memory).merkleProofUncached)
+	})
+}
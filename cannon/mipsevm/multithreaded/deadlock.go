@@ -0,0 +1,99 @@
+package multithreaded
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+)
+
+var ErrDeadlock = errors.New("guest deadlock")
+
+// futexTidMask masks the thread id of the owner in the value of a futex, like FUTEX_TID_MASK of linux
+const futexTidMask = 0x3fff_ffff
+
+// BlockedThread describes a thread of a deadlocked VM.
+type BlockedThread struct {
+	ThreadId    uint32
+	PC          uint32
+	Symbol      string
+	FutexAddr   uint32
+	FutexVal    uint32
+	WaitedSteps uint64
+	// Holder is the thread id stored in the futex value, if it's non-zero and matches a live thread.
+	// Go runtime locks don't record their owner, so this is only a hint for programs that do.
+	Holder *uint32
+}
+
+// DeadlockError is returned by Step when all live threads have been blocked on a futex for the deadlock threshold.
+type DeadlockError struct {
+	Step    uint64
+	Threads []BlockedThread
+}
+
+func (e *DeadlockError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v at step %d, %d blocked threads:", ErrDeadlock, e.Step, len(e.Threads))
+	for _, t := range e.Threads {
+		fmt.Fprintf(&b, "\n  thread %d at pc 0x%08x", t.ThreadId, t.PC)
+		if t.Symbol != "" {
+			fmt.Fprintf(&b, " (%s)", t.Symbol)
+		}
+		fmt.Fprintf(&b, " waiting on 0x%08x (val 0x%08x) for %d steps", t.FutexAddr, t.FutexVal, t.WaitedSteps)
+		if t.Holder != nil {
+			fmt.Fprintf(&b, ", held by thread %d", *t.Holder)
+		}
+	}
+	return b.String()
+}
+
+func (e *DeadlockError) Unwrap() error {
+	return ErrDeadlock
+}
+
+// SetDeadlockThreshold sets the number of steps all live threads may be blocked on a futex,
+// before Step aborts with a DeadlockError. Deadlock detection is disabled if 0, the default.
+func (m *InstrumentedState) SetDeadlockThreshold(steps uint64) {
+	m.deadlockThreshold = steps
+}
+
+// checkDeadlock is called when the blocked thread is still waiting on its futex.
+func (m *InstrumentedState) checkDeadlock(thread *ThreadState) error {
+	if m.deadlockThreshold == 0 || m.waitedSteps(thread) < m.deadlockThreshold {
+		return nil
+	}
+	var live []*ThreadState
+	for _, stack := range [][]*ThreadState{m.state.LeftThreadStack, m.state.RightThreadStack} {
+		for _, t := range stack {
+			if t.Exited {
+				continue
+			}
+			if t.FutexAddr == exec.FutexEmptyAddr || m.waitedSteps(t) < m.deadlockThreshold {
+				return nil
+			}
+			live = append(live, t)
+		}
+	}
+	out := &DeadlockError{Step: m.state.Step}
+	for _, t := range live {
+		blocked := BlockedThread{
+			ThreadId:    t.ThreadId,
+			PC:          t.Cpu.PC,
+			Symbol:      m.LookupSymbol(t.Cpu.PC),
+			FutexAddr:   t.FutexAddr,
+			FutexVal:    t.FutexVal,
+			WaitedSteps: m.waitedSteps(t),
+		}
+		for _, holder := range live {
+			// a zero futex value is the unlocked state of owner-tracking locks
+			if tid := t.FutexVal & futexTidMask; tid != 0 && holder.ThreadId == tid {
+				id := holder.ThreadId
+				blocked.Holder = &id
+				break
+			}
+		}
+		out.Threads = append(out.Threads, blocked)
+	}
+	return out
+}
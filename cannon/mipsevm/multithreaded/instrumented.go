@@ -22,12 +22,21 @@ type InstrumentedState struct {
 
 	preimageOracle *exec.TrackingPreimageOracleReader
 	meta           mipsevm.Metadata
+
+	sched SchedulerConfig
+
+	layout *mipsevm.Layout
+
+	// futex wait statistics per thread id, for introspection
+	waitStats map[uint32]*threadWaitStats
+	// steps all live threads may be blocked on a futex before Step fails, disabled if 0
+	deadlockThreshold uint64
 }
 
 var _ mipsevm.FPVM = (*InstrumentedState)(nil)
 
 func NewInstrumentedState(state *State, po mipsevm.PreimageOracle, stdOut, stdErr io.Writer, log log.Logger, meta mipsevm.Metadata) *InstrumentedState {
-	return &InstrumentedState{
+	m := &InstrumentedState{
 		state:          state,
 		log:            log,
 		stdOut:         stdOut,
@@ -36,7 +45,25 @@ func NewInstrumentedState(state *State, po mipsevm.PreimageOracle, stdOut, stdEr
 		stackTracker:   &NoopThreadedStackTracker{},
 		preimageOracle: exec.NewTrackingPreimageOracleReader(po),
 		meta:           meta,
+		sched:          state.GetSchedulerConfig(),
+		layout:         state.GetLayout(),
 	}
+	m.trackRestoredWaits()
+	return m
+}
+
+// SetSchedulerConfig replaces the thread scheduler configuration, and records it in the state.
+// Steps executed with a non-default configuration can't be proven onchain.
+func (m *InstrumentedState) SetSchedulerConfig(cfg SchedulerConfig) error {
+	if err := cfg.Check(); err != nil {
+		return err
+	}
+	m.sched = cfg
+	m.state.Scheduler = nil
+	if !cfg.IsDefault() {
+		m.state.Scheduler = &cfg
+	}
+	return nil
 }
 
 func (m *InstrumentedState) InitDebug() error {
@@ -70,6 +97,9 @@ func (m *InstrumentedState) Step(proof bool) (wit *mipsevm.StepWitness, err erro
 	if err != nil {
 		return nil, err
 	}
+	if err := m.layout.CheckMemoryLimit(m.state.Memory.UsageRaw()); err != nil {
+		return nil, err
+	}
 
 	if proof {
 		memProof := m.memoryTracker.MemProof()
@@ -104,6 +134,9 @@ func (m *InstrumentedState) GetDebugInfo() *mipsevm.DebugInfo {
 		MemoryUsed:          hexutil.Uint64(m.state.Memory.UsageRaw()),
 		NumPreimageRequests: m.preimageOracle.NumPreimageRequests(),
 		TotalPreimageSize:   m.preimageOracle.TotalPreimageSize(),
+		NumHints:            m.preimageOracle.NumHints(),
+		TotalHintSize:       m.preimageOracle.TotalHintSize(),
+		MerkleNodes:         m.state.Memory.NodeCount(),
 	}
 }
 
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
 )
@@ -98,3 +99,205 @@ func TestInstrumentedState_Alloc(t *testing.T) {
 		})
 	}
 }
+
+func TestInstrumentedState_SchedulerConfig(t *testing.T) {
+	newVM := func(cfg SchedulerConfig) (*State, *InstrumentedState) {
+		state := CreateInitialState(0x1000, 0x10000)
+		state.Memory.SetMemory(0x1000, 0x0000000c) // syscall
+		us := NewInstrumentedState(state, nil, os.Stdout, os.Stderr, testutil.CreateLogger(), nil)
+		require.NoError(t, us.SetSchedulerConfig(cfg))
+		return state, us
+	}
+
+	t.Run("quantum", func(t *testing.T) {
+		state, us := newVM(SchedulerConfig{Quantum: 10, Policy: SchedPolicyWakeupPriority})
+		state.GetRegistersRef()[2] = exec.SysGetTID
+		state.StepsSinceLastContextSwitch = 9
+		_, err := us.Step(false)
+		require.NoError(t, err)
+		require.Equal(t, uint32(0x1004), state.GetPC(), "must run below the quantum")
+
+		state.Memory.SetMemory(0x1004, 0x0000000c)
+		_, err = us.Step(false)
+		require.NoError(t, err)
+		require.Equal(t, uint32(0x1004), state.GetPC(), "must preempt at the quantum")
+		require.Equal(t, uint64(0), state.StepsSinceLastContextSwitch)
+	})
+
+	t.Run("wakeup priority", func(t *testing.T) {
+		state, us := newVM(DefaultSchedulerConfig())
+		state.GetRegistersRef()[2] = exec.SysFutex
+		state.GetRegistersRef()[4] = 0x2000
+		state.GetRegistersRef()[5] = exec.FutexWakePrivate
+		_, err := us.Step(false)
+		require.NoError(t, err)
+		require.Equal(t, uint32(0x2000), state.Wakeup)
+		require.Equal(t, uint64(0), state.StepsSinceLastContextSwitch)
+	})
+
+	t.Run("round robin", func(t *testing.T) {
+		state, us := newVM(SchedulerConfig{Quantum: exec.SchedQuantum, Policy: SchedPolicyRoundRobin})
+		state.GetRegistersRef()[2] = exec.SysFutex
+		state.GetRegistersRef()[4] = 0x2000
+		state.GetRegistersRef()[5] = exec.FutexWakePrivate
+		_, err := us.Step(false)
+		require.NoError(t, err)
+		require.Equal(t, exec.FutexEmptyAddr, state.Wakeup)
+		require.Equal(t, uint32(0x1004), state.GetPC())
+		require.Equal(t, uint64(1), state.StepsSinceLastContextSwitch)
+	})
+
+	t.Run("recorded in state", func(t *testing.T) {
+		state, us := newVM(SchedulerConfig{Quantum: 10, Policy: SchedPolicyRoundRobin})
+		require.Equal(t, &SchedulerConfig{Quantum: 10, Policy: SchedPolicyRoundRobin}, state.Scheduler)
+		witness, _ := state.EncodeWitness()
+		require.Len(t, witness, STATE_WITNESS_SIZE+SCHEDULER_WITNESS_SIZE, "custom scheduler extends the witness")
+		_, err := StateWitness(witness).StateHash()
+		require.NoError(t, err)
+
+		// A VM resumed from the state runs with the recorded scheduler
+		resumed := NewInstrumentedState(state, nil, os.Stdout, os.Stderr, testutil.CreateLogger(), nil)
+		require.Equal(t, *state.Scheduler, resumed.sched)
+
+		require.NoError(t, us.SetSchedulerConfig(DefaultSchedulerConfig()))
+		require.Nil(t, state.Scheduler, "default scheduler is not recorded")
+		witness, _ = state.EncodeWitness()
+		require.Len(t, witness, STATE_WITNESS_SIZE)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		us := NewInstrumentedState(CreateEmptyState(), nil, os.Stdout, os.Stderr, testutil.CreateLogger(), nil)
+		require.Error(t, us.SetSchedulerConfig(SchedulerConfig{Quantum: 0}))
+		require.Error(t, us.SetSchedulerConfig(SchedulerConfig{Quantum: 1, Policy: SchedPolicyRoundRobin + 1}))
+	})
+}
+
+func TestInstrumentedState_ThreadsInfo(t *testing.T) {
+	state := CreateInitialState(0x1000, 0x10000)
+	us := NewInstrumentedState(state, nil, os.Stdout, os.Stderr, testutil.CreateLogger(), nil)
+	thread := state.GetCurrentThread()
+	thread.FutexAddr = 0x2000
+	thread.FutexVal = 1
+	thread.FutexTimeoutStep = 100
+	other := CreateEmptyThread()
+	other.ThreadId = 1
+	state.RightThreadStack = append(state.RightThreadStack, other)
+
+	info := us.ThreadsInfo()
+	require.Equal(t, uint32(0), info.ActiveThreadId)
+	require.Nil(t, info.Wakeup)
+	require.Len(t, info.Threads, 2)
+	require.Equal(t, "left", info.Threads[0].Stack)
+	require.Equal(t, uint32(0x1000), uint32(info.Threads[0].PC))
+	require.NotNil(t, info.Threads[0].Futex)
+	require.Equal(t, uint32(0x2000), uint32(info.Threads[0].Futex.Addr))
+	require.Equal(t, "right", info.Threads[1].Stack)
+	require.Nil(t, info.Threads[1].Futex)
+
+	// the value at the futex address differs, the thread is woken up
+	_, err := us.Step(false)
+	require.NoError(t, err)
+	info = us.ThreadsInfo()
+	require.Nil(t, info.Threads[0].Futex)
+	require.Equal(t, uint64(1), info.Threads[0].Wakeups)
+	require.Equal(t, uint64(0), info.Threads[0].Timeouts)
+	require.Equal(t, uint64(0), info.Threads[1].Wakeups)
+}
+
+func TestInstrumentedState_Deadlock(t *testing.T) {
+	newVM := func() (*State, *InstrumentedState) {
+		state := CreateInitialState(0x1000, 0x10000)
+		us := NewInstrumentedState(state, nil, os.Stdout, os.Stderr, testutil.CreateLogger(), nil)
+		// thread 0 waits on a lock held by thread 1, which waits on a futex nobody wakes
+		state.Memory.SetMemory(0x2000, 1)
+		thread := state.GetCurrentThread()
+		thread.FutexAddr = 0x2000
+		thread.FutexVal = 1
+		thread.FutexTimeoutStep = exec.FutexNoTimeout
+		other := CreateEmptyThread()
+		other.ThreadId = 1
+		other.FutexAddr = 0x3000
+		other.FutexVal = 0
+		other.FutexTimeoutStep = exec.FutexNoTimeout
+		state.RightThreadStack = append(state.RightThreadStack, other)
+		return state, us
+	}
+
+	t.Run("detected", func(t *testing.T) {
+		state, us := newVM()
+		us.SetDeadlockThreshold(10)
+		var err error
+		for i := 0; i < 100 && err == nil; i++ {
+			_, err = us.Step(false)
+		}
+		require.ErrorIs(t, err, ErrDeadlock)
+		var deadlock *DeadlockError
+		require.ErrorAs(t, err, &deadlock)
+		require.Less(t, deadlock.Step, uint64(100))
+		require.Equal(t, state.Step, deadlock.Step)
+		require.Len(t, deadlock.Threads, 2)
+		for _, blocked := range deadlock.Threads {
+			require.GreaterOrEqual(t, blocked.WaitedSteps, uint64(10))
+			switch blocked.ThreadId {
+			case 0:
+				require.Equal(t, uint32(0x2000), blocked.FutexAddr)
+				require.NotNil(t, blocked.Holder)
+				require.Equal(t, uint32(1), *blocked.Holder)
+			case 1:
+				require.Equal(t, uint32(0x3000), blocked.FutexAddr)
+				require.Nil(t, blocked.Holder)
+			}
+		}
+		require.Contains(t, err.Error(), "held by thread 1")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		_, us := newVM()
+		for i := 0; i < 100; i++ {
+			_, err := us.Step(false)
+			require.NoError(t, err)
+		}
+		info := us.ThreadsInfo()
+		require.NotNil(t, info.Threads[0].Futex)
+		require.GreaterOrEqual(t, info.Threads[0].Futex.WaitedSteps, uint64(99))
+	})
+
+	t.Run("running thread", func(t *testing.T) {
+		state, us := newVM()
+		us.SetDeadlockThreshold(10)
+		state.RightThreadStack[0].FutexAddr = exec.FutexEmptyAddr
+		state.Memory.SetMemory(0x1000, 0x1000ffff) // branch to self
+		for i := 0; i < 100; i++ {
+			_, err := us.Step(false)
+			require.NoError(t, err)
+		}
+	})
+}
+
+func TestInstrumentedState_WaitAccounting(t *testing.T) {
+	state := CreateInitialState(0x1000, 0x10000)
+	us := NewInstrumentedState(state, nil, os.Stdout, os.Stderr, testutil.CreateLogger(), nil)
+	state.Memory.SetMemory(0x1000, 0x0000000c) // syscall
+	state.GetRegistersRef()[2] = exec.SysFutex
+	state.GetRegistersRef()[4] = 0x2000
+	state.GetRegistersRef()[5] = exec.FutexWaitPrivate
+	state.GetRegistersRef()[6] = 0
+	_, err := us.Step(false)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0x2000), state.GetCurrentThread().FutexAddr)
+
+	for i := 0; i < 5; i++ {
+		_, err = us.Step(false)
+		require.NoError(t, err)
+	}
+	info := us.ThreadsInfo()
+	require.Equal(t, uint64(5), info.Threads[0].Futex.WaitedSteps)
+
+	state.Memory.SetMemory(0x2000, 1)
+	_, err = us.Step(false)
+	require.NoError(t, err)
+	info = us.ThreadsInfo()
+	require.Nil(t, info.Threads[0].Futex)
+	require.Equal(t, uint64(6), info.Threads[0].WaitSteps)
+	require.Equal(t, uint64(1), info.Threads[0].Wakeups)
+}
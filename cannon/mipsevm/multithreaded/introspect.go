@@ -0,0 +1,140 @@
+package multithreaded
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+)
+
+// ThreadInfo describes a single thread, for live inspection of a running VM.
+type ThreadInfo struct {
+	ThreadId uint32         `json:"threadId"`
+	PC       hexutil.Uint   `json:"pc"`
+	NextPC   hexutil.Uint   `json:"nextPC"`
+	Exited   bool           `json:"exited"`
+	ExitCode uint8          `json:"exitCode"`
+	Stack    string         `json:"stack"`
+	Symbol   string         `json:"symbol,omitempty"`
+	Futex    *FutexWaitInfo `json:"futex,omitempty"`
+	// Wakeups is the number of futex waits of the thread that completed, since the VM was created
+	Wakeups uint64 `json:"wakeups"`
+	// Timeouts is the number of futex waits of the thread that timed out, since the VM was created
+	Timeouts uint64 `json:"timeouts"`
+	// WaitSteps is the number of steps the thread spent in completed futex waits, since the VM was created
+	WaitSteps uint64 `json:"waitSteps"`
+}
+
+// FutexWaitInfo describes the futex a thread is blocked on.
+type FutexWaitInfo struct {
+	Addr        hexutil.Uint   `json:"addr"`
+	Val         hexutil.Uint   `json:"val"`
+	TimeoutStep hexutil.Uint64 `json:"timeoutStep"`
+	// WaitedSteps is the number of steps since the thread started waiting
+	WaitedSteps uint64 `json:"waitedSteps"`
+}
+
+type ThreadsInfo struct {
+	Step           uint64        `json:"step"`
+	ActiveThreadId uint32        `json:"activeThreadId"`
+	Wakeup         *hexutil.Uint `json:"wakeup,omitempty"`
+	Threads        []ThreadInfo  `json:"threads"`
+}
+
+type threadWaitStats struct {
+	wakeups   uint64
+	timeouts  uint64
+	waitSteps uint64
+	// waitStart is the step the current futex wait of the thread started at
+	waitStart uint64
+}
+
+func (m *InstrumentedState) threadWaitStats(thread *ThreadState) *threadWaitStats {
+	if m.waitStats == nil {
+		m.waitStats = make(map[uint32]*threadWaitStats)
+	}
+	stats, ok := m.waitStats[thread.ThreadId]
+	if !ok {
+		stats = new(threadWaitStats)
+		m.waitStats[thread.ThreadId] = stats
+	}
+	return stats
+}
+
+func (m *InstrumentedState) trackWaitStart(thread *ThreadState) {
+	m.threadWaitStats(thread).waitStart = m.state.Step
+}
+
+// trackRestoredWaits starts the wait accounting of the threads that were already blocked when the VM was created.
+func (m *InstrumentedState) trackRestoredWaits() {
+	for _, stack := range [][]*ThreadState{m.state.LeftThreadStack, m.state.RightThreadStack} {
+		for _, t := range stack {
+			if t.FutexAddr != exec.FutexEmptyAddr {
+				m.trackWaitStart(t)
+			}
+		}
+	}
+}
+
+// waitedSteps returns the number of steps since the blocked thread started waiting.
+func (m *InstrumentedState) waitedSteps(thread *ThreadState) uint64 {
+	return m.state.Step - m.threadWaitStats(thread).waitStart
+}
+
+func (m *InstrumentedState) trackWaitComplete(thread *ThreadState, isTimedOut bool) {
+	stats := m.threadWaitStats(thread)
+	stats.waitSteps += m.waitedSteps(thread)
+	if isTimedOut {
+		stats.timeouts++
+	} else {
+		stats.wakeups++
+	}
+}
+
+// ThreadsInfo returns the current state of all threads. It must not be called concurrently with Step.
+func (m *InstrumentedState) ThreadsInfo() *ThreadsInfo {
+	out := &ThreadsInfo{
+		Step:           m.state.Step,
+		ActiveThreadId: m.state.GetCurrentThread().ThreadId,
+	}
+	if m.state.Wakeup != exec.FutexEmptyAddr {
+		wakeup := hexutil.Uint(m.state.Wakeup)
+		out.Wakeup = &wakeup
+	}
+	add := func(stack []*ThreadState, name string) {
+		// the top of the stack is the next thread to run
+		for i := len(stack) - 1; i >= 0; i-- {
+			t := stack[i]
+			info := ThreadInfo{
+				ThreadId: t.ThreadId,
+				PC:       hexutil.Uint(t.Cpu.PC),
+				NextPC:   hexutil.Uint(t.Cpu.NextPC),
+				Exited:   t.Exited,
+				ExitCode: t.ExitCode,
+				Stack:    name,
+				Symbol:   m.LookupSymbol(t.Cpu.PC),
+			}
+			if t.FutexAddr != exec.FutexEmptyAddr {
+				info.Futex = &FutexWaitInfo{
+					Addr:        hexutil.Uint(t.FutexAddr),
+					Val:         hexutil.Uint(t.FutexVal),
+					TimeoutStep: hexutil.Uint64(t.FutexTimeoutStep),
+					WaitedSteps: m.waitedSteps(t),
+				}
+			}
+			if stats, ok := m.waitStats[t.ThreadId]; ok {
+				info.Wakeups = stats.wakeups
+				info.Timeouts = stats.timeouts
+				info.WaitSteps = stats.waitSteps
+			}
+			out.Threads = append(out.Threads, info)
+		}
+	}
+	if m.state.TraverseRight {
+		add(m.state.RightThreadStack, "right")
+		add(m.state.LeftThreadStack, "left")
+	} else {
+		add(m.state.LeftThreadStack, "left")
+		add(m.state.RightThreadStack, "right")
+	}
+	return out
+}
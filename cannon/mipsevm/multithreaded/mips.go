@@ -10,7 +10,6 @@ import (
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
-	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
 )
 
 func (m *InstrumentedState) handleSyscall() error {
@@ -20,14 +19,19 @@ func (m *InstrumentedState) handleSyscall() error {
 	v0 := uint32(0)
 	v1 := uint32(0)
 
+	if hv0, hv1, handled := exec.RunSyscallHooks(&exec.SyscallContext{SyscallNum: syscallNum, A0: a0, A1: a1, A2: a2, A3: a3, Step: m.state.Step, Memory: m.state.Memory}); handled {
+		exec.HandleSyscallUpdates(&thread.Cpu, &thread.Registers, hv0, hv1)
+		return nil
+	}
+
 	//fmt.Printf("syscall: %d\n", syscallNum)
 	switch syscallNum {
 	case exec.SysMmap:
 		var newHeap uint32
-		v0, v1, newHeap = exec.HandleSysMmap(a0, a1, m.state.Heap)
+		v0, v1, newHeap = exec.HandleSysMmap(a0, a1, m.state.Heap, m.layout)
 		m.state.Heap = newHeap
 	case exec.SysBrk:
-		v0 = program.PROGRAM_BREAK
+		v0, v1 = exec.HandleSysBrk(a0, m.layout)
 	case exec.SysClone: // clone
 		// a0 = flag bitmask, a1 = stack pointer
 		if exec.ValidCloneFlags != a0 {
@@ -110,6 +114,7 @@ func (m *InstrumentedState) handleSyscall() error {
 			} else {
 				thread.FutexAddr = a0
 				thread.FutexVal = a2
+				m.trackWaitStart(thread)
 				if a3 == 0 {
 					thread.FutexTimeoutStep = exec.FutexNoTimeout
 				} else {
@@ -119,6 +124,10 @@ func (m *InstrumentedState) handleSyscall() error {
 				return nil
 			}
 		case exec.FutexWakePrivate:
+			if m.sched.Policy == SchedPolicyRoundRobin {
+				// Keep running the current thread. Waiters notice the changed futex value once it's their turn.
+				break
+			}
 			// Trigger thread traversal starting from the left stack until we find one waiting on the wakeup
 			// address
 			m.state.Wakeup = a0
@@ -144,23 +153,14 @@ func (m *InstrumentedState) handleSyscall() error {
 		v0 = exec.SysErrorSignal
 		v1 = exec.MipsEBADF
 	case exec.SysClockGetTime:
-		switch a0 {
-		case exec.ClockGettimeRealtimeFlag, exec.ClockGettimeMonotonicFlag:
+		if secs, nsecs, ok := exec.ClockGettimeValue(a0, m.state.Step); ok {
 			v0, v1 = 0, 0
-			var secs, nsecs uint32
-			if a0 == exec.ClockGettimeMonotonicFlag {
-				// monotonic clock_gettime is used by Go guest programs for goroutine scheduling and to implement
-				// `time.Sleep` (and other sleep related operations).
-				secs = uint32(m.state.Step / exec.HZ)
-				nsecs = uint32((m.state.Step % exec.HZ) * (1_000_000_000 / exec.HZ))
-			} // else realtime set to Unix Epoch
-
 			effAddr := a1 & 0xFFffFFfc
 			m.memoryTracker.TrackMemAccess(effAddr)
 			m.state.Memory.SetMemory(effAddr, secs)
 			m.memoryTracker.TrackMemAccess2(effAddr + 4)
 			m.state.Memory.SetMemory(effAddr+4, nsecs)
-		default:
+		} else {
 			v0 = exec.SysErrorSignal
 			v1 = exec.MipsEINVAL
 		}
@@ -168,6 +168,17 @@ func (m *InstrumentedState) handleSyscall() error {
 		v0 = 0
 		v1 = 0
 	case exec.SysMunmap:
+		v0, v1 = exec.HandleSysMunmap(a0, a1, m.state.Memory)
+	case exec.SysGetRandom:
+		v0, v1 = exec.HandleSysGetRandom(a0, a1, m.state.Step, m.state.Memory, m.memoryTracker)
+	case exec.SysEpollCreate1:
+		v0, v1 = exec.FdEpoll, 0
+	case exec.SysEpollCtl:
+		v0, v1 = exec.HandleSysEpollCtl(a0)
+	case exec.SysEpollPwait:
+		v0, v1 = exec.HandleSysEpollPwait(a0)
+	case exec.SysEventFd2:
+		v0, v1 = exec.FdEventFd, 0
 	case exec.SysGetAffinity:
 	case exec.SysMadvise:
 	case exec.SysRtSigprocmask:
@@ -177,15 +188,12 @@ func (m *InstrumentedState) handleSyscall() error {
 	case exec.SysClose:
 	case exec.SysPread64:
 	case exec.SysFstat64:
+		v0, v1 = exec.HandleSysFstat64(a0, a1, m.state.Memory, m.memoryTracker)
 	case exec.SysOpenAt:
 	case exec.SysReadlink:
 	case exec.SysReadlinkAt:
 	case exec.SysIoctl:
-	case exec.SysEpollCreate1:
 	case exec.SysPipe2:
-	case exec.SysEpollCtl:
-	case exec.SysEpollPwait:
-	case exec.SysGetRandom:
 	case exec.SysUname:
 	case exec.SysStat64:
 	case exec.SysGetuid:
@@ -194,9 +202,13 @@ func (m *InstrumentedState) handleSyscall() error {
 	case exec.SysMinCore:
 	case exec.SysTgkill:
 	case exec.SysSetITimer:
+		v0, v1 = exec.HandleSysSetITimer(a0, a2)
 	case exec.SysTimerCreate:
+		v0, v1 = exec.HandleSysTimerCreate(a0, a2, m.state.Memory, m.memoryTracker)
 	case exec.SysTimerSetTime:
+		v0, v1 = exec.HandleSysTimerSetTime(a0, a3)
 	case exec.SysTimerDelete:
+		v0, v1 = exec.HandleSysTimerDelete(a0)
 	default:
 		m.Traceback()
 		panic(fmt.Sprintf("unrecognized syscall: %d", syscallNum))
@@ -252,6 +264,9 @@ func (m *InstrumentedState) mipsStep() error {
 			mem := m.state.Memory.GetMemory(thread.FutexAddr)
 			if thread.FutexVal == mem {
 				// still got expected value, continue sleeping, try next thread.
+				if err := m.checkDeadlock(thread); err != nil {
+					return err
+				}
 				m.preemptThread(thread)
 				return nil
 			} else {
@@ -263,12 +278,12 @@ func (m *InstrumentedState) mipsStep() error {
 		}
 	}
 
-	if m.state.StepsSinceLastContextSwitch >= exec.SchedQuantum {
+	if m.state.StepsSinceLastContextSwitch >= m.sched.Quantum {
 		// Force a context switch as this thread has been active too long
 		if m.state.ThreadCount() > 1 {
 			// Log if we're hitting our context switch limit - only matters if we have > 1 thread
 			if m.log.Enabled(context.Background(), log.LevelTrace) {
-				msg := fmt.Sprintf("Thread has reached maximum execution steps (%v) - preempting.", exec.SchedQuantum)
+				msg := fmt.Sprintf("Thread has reached maximum execution steps (%v) - preempting.", m.sched.Quantum)
 				m.log.Trace(msg, "threadId", thread.ThreadId, "threadCount", m.state.ThreadCount(), "pc", thread.Cpu.PC)
 			}
 		}
@@ -291,6 +306,8 @@ func (m *InstrumentedState) mipsStep() error {
 }
 
 func (m *InstrumentedState) onWaitComplete(thread *ThreadState, isTimedOut bool) {
+	m.trackWaitComplete(thread, isTimedOut)
+
 	// Clear the futex state
 	thread.FutexAddr = exec.FutexEmptyAddr
 	thread.FutexVal = 0
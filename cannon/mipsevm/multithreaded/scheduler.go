@@ -0,0 +1,110 @@
+package multithreaded
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/serialize"
+)
+
+// SCHEDULER_WITNESS_SIZE is the size of the scheduler config witness encoding in bytes.
+const SCHEDULER_WITNESS_SIZE = 8 + 1
+
+// SchedPolicy selects how the VM picks the next thread to run.
+type SchedPolicy uint8
+
+const (
+	// SchedPolicyWakeupPriority preempts the waking thread on a futex wake and traverses the thread stacks
+	// to run a thread that is waiting on the woken address first. This is the policy of the onchain VM.
+	SchedPolicyWakeupPriority SchedPolicy = iota
+	// SchedPolicyRoundRobin only switches threads when the quantum is exhausted or the active thread blocks.
+	// Woken threads resume when their turn comes up.
+	SchedPolicyRoundRobin
+)
+
+func (p SchedPolicy) String() string {
+	switch p {
+	case SchedPolicyWakeupPriority:
+		return "wakeup-priority"
+	case SchedPolicyRoundRobin:
+		return "round-robin"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(p))
+	}
+}
+
+func ParseSchedPolicy(value string) (SchedPolicy, error) {
+	switch value {
+	case "wakeup-priority":
+		return SchedPolicyWakeupPriority, nil
+	case "round-robin":
+		return SchedPolicyRoundRobin, nil
+	default:
+		return 0, fmt.Errorf("unknown scheduling policy %q", value)
+	}
+}
+
+// SchedulerConfig configures the thread scheduler of the VM.
+// Only the default configuration matches the onchain VM: steps executed with any other configuration
+// can't be proven onchain, and are only meant for evaluating scheduling trade-offs offchain.
+type SchedulerConfig struct {
+	// Quantum is the number of steps a thread may run before it's preempted
+	Quantum uint64
+	Policy  SchedPolicy
+}
+
+func DefaultSchedulerConfig() SchedulerConfig {
+	return SchedulerConfig{
+		Quantum: exec.SchedQuantum,
+		Policy:  SchedPolicyWakeupPriority,
+	}
+}
+
+// IsDefault returns true if the config matches the scheduler of the onchain VM.
+func (c SchedulerConfig) IsDefault() bool {
+	return c == DefaultSchedulerConfig()
+}
+
+// IsDefaultSchedulerConfig returns true if the config is nil, or matches the scheduler of the onchain VM.
+func IsDefaultSchedulerConfig(c *SchedulerConfig) bool {
+	return c == nil || c.IsDefault()
+}
+
+func (c SchedulerConfig) Check() error {
+	if c.Quantum == 0 {
+		return fmt.Errorf("scheduler quantum must be non-zero")
+	}
+	if c.Policy > SchedPolicyRoundRobin {
+		return fmt.Errorf("unknown scheduling policy %v", c.Policy)
+	}
+	return nil
+}
+
+// EncodeWitness encodes the config fields in order. States with a custom scheduler extend their witness with it.
+func (c *SchedulerConfig) EncodeWitness() []byte {
+	out := make([]byte, 0, SCHEDULER_WITNESS_SIZE)
+	out = binary.BigEndian.AppendUint64(out, c.Quantum)
+	return append(out, uint8(c.Policy))
+}
+
+// Serialize writes the config in the same encoding as the witness.
+func (c *SchedulerConfig) Serialize(w io.Writer) error {
+	bout := serialize.NewBinaryWriter(w)
+	if err := bout.WriteUInt(c.Quantum); err != nil {
+		return err
+	}
+	return bout.WriteUInt(c.Policy)
+}
+
+func (c *SchedulerConfig) Deserialize(in io.Reader) error {
+	bin := serialize.NewBinaryReader(in)
+	if err := bin.ReadUInt(&c.Quantum); err != nil {
+		return err
+	}
+	if err := bin.ReadUInt(&c.Policy); err != nil {
+		return err
+	}
+	return c.Check()
+}
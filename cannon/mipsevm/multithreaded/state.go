@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
 )
 
 // STATE_WITNESS_SIZE is the size of the state witness encoding in bytes.
@@ -54,6 +55,13 @@ type State struct {
 	RightThreadStack []*ThreadState
 	NextThreadId     uint32
 
+	// Layout is the memory layout of the guest. It's nil for the default layout,
+	// other layouts are not supported onchain.
+	Layout *mipsevm.Layout
+	// Scheduler is the thread scheduler configuration. It's nil for the default scheduler,
+	// other schedulers are not supported onchain.
+	Scheduler *SchedulerConfig
+
 	// LastHint is optional metadata, and not part of the VM state itself.
 	LastHint hexutil.Bytes
 }
@@ -172,6 +180,21 @@ func (s *State) GetHeap() uint32 {
 	return s.Heap
 }
 
+func (s *State) GetLayout() *mipsevm.Layout {
+	if s.Layout == nil {
+		return program.DefaultLayout()
+	}
+	return s.Layout
+}
+
+// GetSchedulerConfig returns the thread scheduler configuration of the state.
+func (s *State) GetSchedulerConfig() SchedulerConfig {
+	if s.Scheduler == nil {
+		return DefaultSchedulerConfig()
+	}
+	return *s.Scheduler
+}
+
 func (s *State) GetPreimageKey() common.Hash {
 	return s.PreimageKey
 }
@@ -200,6 +223,14 @@ func (s *State) EncodeWitness() ([]byte, common.Hash) {
 	out = append(out, (leftStackRoot)[:]...)
 	out = append(out, (rightStackRoot)[:]...)
 	out = binary.BigEndian.AppendUint32(out, s.NextThreadId)
+	if !program.IsDefaultLayout(s.Layout) {
+		// a custom layout extends the witness, so the state can't be proven onchain
+		out = append(out, s.Layout.EncodeWitness()...)
+	}
+	if !IsDefaultSchedulerConfig(s.Scheduler) {
+		// likewise a custom scheduler extends the witness
+		out = append(out, s.Scheduler.EncodeWitness()...)
+	}
 
 	return out, stateHashFromWitness(out)
 }
@@ -379,8 +410,18 @@ func (s *State) Deserialize(in io.Reader) error {
 
 type StateWitness []byte
 
+// validWitnessSize returns true for the witness sizes of states with and without custom layout and scheduler.
+func validWitnessSize(n int) bool {
+	for _, ext := range []int{0, mipsevm.LAYOUT_WITNESS_SIZE, SCHEDULER_WITNESS_SIZE, mipsevm.LAYOUT_WITNESS_SIZE + SCHEDULER_WITNESS_SIZE} {
+		if n == STATE_WITNESS_SIZE+ext {
+			return true
+		}
+	}
+	return false
+}
+
 func (sw StateWitness) StateHash() (common.Hash, error) {
-	if len(sw) != STATE_WITNESS_SIZE {
+	if !validWitnessSize(len(sw)) {
 		return common.Hash{}, fmt.Errorf("Invalid witness length. Got %d, expected %d", len(sw), STATE_WITNESS_SIZE)
 	}
 	return stateHashFromWitness(sw), nil
@@ -393,7 +434,7 @@ func GetStateHashFn() mipsevm.HashFn {
 }
 
 func stateHashFromWitness(sw []byte) common.Hash {
-	if len(sw) != STATE_WITNESS_SIZE {
+	if !validWitnessSize(len(sw)) {
 		panic("Invalid witness length")
 	}
 	hash := crypto.Keccak256Hash(sw)
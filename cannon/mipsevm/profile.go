@@ -0,0 +1,107 @@
+package mipsevm
+
+import (
+	"io"
+	"sort"
+
+	"github.com/google/pprof/profile"
+)
+
+// PCProfiler counts how often each guest program counter is executed.
+// With a sample rate of 1 the histogram is exact; higher rates only record every n-th step.
+type PCProfiler struct {
+	sampleRate uint64
+	counts     map[uint32]uint64
+	total      uint64
+}
+
+func NewPCProfiler(sampleRate uint64) *PCProfiler {
+	if sampleRate == 0 {
+		sampleRate = 1
+	}
+	return &PCProfiler{
+		sampleRate: sampleRate,
+		counts:     make(map[uint32]uint64),
+	}
+}
+
+// Sample records the PC of the given state, if the current step is to be sampled.
+func (p *PCProfiler) Sample(state FPVMState) {
+	if state.GetStep()%p.sampleRate != 0 {
+		return
+	}
+	p.counts[state.GetPC()]++
+	p.total++
+}
+
+// TotalSamples returns the number of recorded samples.
+func (p *PCProfiler) TotalSamples() uint64 {
+	return p.total
+}
+
+type FunctionSamples struct {
+	Name    string
+	Samples uint64
+}
+
+// HotFunctions aggregates the samples per symbol, and returns the top n functions with the most samples.
+// All functions are returned if n is 0.
+func (p *PCProfiler) HotFunctions(meta Metadata, n int) []FunctionSamples {
+	perFn := make(map[string]uint64)
+	for pc, count := range p.counts {
+		perFn[meta.LookupSymbol(pc)] += count
+	}
+	out := make([]FunctionSamples, 0, len(perFn))
+	for name, count := range perFn {
+		out = append(out, FunctionSamples{Name: name, Samples: count})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Samples != out[j].Samples {
+			return out[i].Samples > out[j].Samples
+		}
+		return out[i].Name < out[j].Name
+	})
+	if n > 0 && len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+// WritePprof writes the samples as gzipped pprof protobuf, with a single-frame location per sampled PC.
+func (p *PCProfiler) WritePprof(meta Metadata, w io.Writer) error {
+	prof := &profile.Profile{
+		SampleType: []*profile.ValueType{
+			{Type: "samples", Unit: "count"},
+			{Type: "steps", Unit: "count"},
+		},
+		PeriodType: &profile.ValueType{Type: "steps", Unit: "count"},
+		Period:     int64(p.sampleRate),
+	}
+	functions := make(map[string]*profile.Function)
+	pcs := make([]uint32, 0, len(p.counts))
+	for pc := range p.counts {
+		pcs = append(pcs, pc)
+	}
+	sort.Slice(pcs, func(i, j int) bool { return pcs[i] < pcs[j] })
+	for _, pc := range pcs {
+		name := meta.LookupSymbol(pc)
+		fn, ok := functions[name]
+		if !ok {
+			fn = &profile.Function{ID: uint64(len(prof.Function) + 1), Name: name, SystemName: name}
+			functions[name] = fn
+			prof.Function = append(prof.Function, fn)
+		}
+		loc := &profile.Location{
+			ID:      uint64(len(prof.Location) + 1),
+			Address: uint64(pc),
+			Line:    []profile.Line{{Function: fn}},
+		}
+		prof.Location = append(prof.Location, loc)
+		count := p.counts[pc]
+		prof.Sample = append(prof.Sample, &profile.Sample{
+			Location: []*profile.Location{loc},
+			Value:    []int64{int64(count), int64(count * p.sampleRate)},
+		})
+	}
+	return prof.Write(w)
+}
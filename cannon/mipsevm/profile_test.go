@@ -0,0 +1,50 @@
+package mipsevm_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+)
+
+func TestPCProfiler(t *testing.T) {
+	meta := &program.Metadata{Symbols: []program.Symbol{
+		{Name: "main.a", Start: 0x1000, Size: 0x100},
+		{Name: "main.b", Start: 0x2000, Size: 0x100},
+	}}
+	state := singlethreaded.CreateEmptyState()
+	prof := mipsevm.NewPCProfiler(2)
+	run := func(pc uint32, steps int) {
+		for i := 0; i < steps; i++ {
+			state.Cpu.PC = pc
+			prof.Sample(state)
+			state.Step++
+		}
+	}
+	run(0x1004, 10)
+	run(0x2008, 30)
+	run(0x2010, 10)
+	require.Equal(t, uint64(25), prof.TotalSamples())
+
+	hot := prof.HotFunctions(meta, 0)
+	require.Equal(t, []mipsevm.FunctionSamples{{Name: "main.b", Samples: 20}, {Name: "main.a", Samples: 5}}, hot)
+	require.Len(t, prof.HotFunctions(meta, 1), 1)
+
+	var buf bytes.Buffer
+	require.NoError(t, prof.WritePprof(meta, &buf))
+	parsed, err := profile.Parse(&buf)
+	require.NoError(t, err)
+	require.NoError(t, parsed.CheckValid())
+	require.Len(t, parsed.Function, 2)
+	require.Len(t, parsed.Sample, 3)
+	var steps int64
+	for _, s := range parsed.Sample {
+		steps += s.Value[1]
+	}
+	require.Equal(t, int64(50), steps)
+}
@@ -9,17 +9,50 @@ import (
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 )
 
+// Default guest memory layout: anonymous mappings grow from HEAP_START up to HEAP_END,
+// mappings at fixed addresses must end below the stack region, which starts at STACK_LIMIT.
 const (
 	HEAP_START    = 0x05_00_00_00
 	HEAP_END      = 0x60_00_00_00
 	PROGRAM_BREAK = 0x40_00_00_00
+	STACK_LIMIT   = 0x7f_00_00_00
+	STACK_POINTER = 0x7f_ff_d0_00
 )
 
+// DefaultLayout returns the guest memory layout of the onchain VMs.
+func DefaultLayout() *mipsevm.Layout {
+	return &mipsevm.Layout{
+		HeapStart:    HEAP_START,
+		HeapEnd:      HEAP_END,
+		ProgramBreak: PROGRAM_BREAK,
+		StackPointer: STACK_POINTER,
+		StackLimit:   STACK_LIMIT,
+	}
+}
+
+// IsDefaultLayout returns true if the layout is nil or equal to the default layout.
+func IsDefaultLayout(layout *mipsevm.Layout) bool {
+	return layout == nil || *layout == *DefaultLayout()
+}
+
 type CreateInitialFPVMState[T mipsevm.FPVMState] func(pc, heapStart uint32) T
 
 func LoadELF[T mipsevm.FPVMState](f *elf.File, initState CreateInitialFPVMState[T]) (T, error) {
+	return LoadELFWithLayout(f, initState, DefaultLayout())
+}
+
+// LoadELFWithLayout loads the ELF program below the heap start of the given layout.
+// The caller is responsible for configuring the layout on the returned state.
+func LoadELFWithLayout[T mipsevm.FPVMState](f *elf.File, initState CreateInitialFPVMState[T], layout *mipsevm.Layout) (T, error) {
 	var empty T
-	s := initState(uint32(f.Entry), HEAP_START)
+	if f.Type != elf.ET_EXEC && f.Type != elf.ET_DYN {
+		return empty, fmt.Errorf("unsupported ELF type %v, expected an executable", f.Type)
+	}
+	if err := layout.Check(); err != nil {
+		return empty, fmt.Errorf("invalid memory layout: %w", err)
+	}
+	base := LoadBase(f)
+	s := initState(uint32(f.Entry)+base, layout.HeapStart)
 
 	for i, prog := range f.Progs {
 		if prog.Type == 0x70000003 { // MIPS_ABIFLAGS
@@ -39,16 +72,23 @@ func LoadELF[T mipsevm.FPVMState](f *elf.File, initState CreateInitialFPVMState[
 			}
 		}
 
-		if prog.Vaddr+prog.Memsz >= uint64(1<<32) {
-			return empty, fmt.Errorf("program %d out of 32-bit mem range: %x - %x (size: %x)", i, prog.Vaddr, prog.Vaddr+prog.Memsz, prog.Memsz)
+		vaddr := prog.Vaddr + uint64(base)
+		if vaddr+prog.Memsz >= uint64(1<<32) {
+			return empty, fmt.Errorf("program %d out of 32-bit mem range: %x - %x (size: %x)", i, vaddr, vaddr+prog.Memsz, prog.Memsz)
 		}
-		if prog.Vaddr+prog.Memsz >= HEAP_START {
-			return empty, fmt.Errorf("program %d overlaps with heap: %x - %x (size: %x). The heap start offset must be reconfigured", i, prog.Vaddr, prog.Vaddr+prog.Memsz, prog.Memsz)
+		if vaddr+prog.Memsz >= uint64(layout.HeapStart) {
+			return empty, fmt.Errorf("program %d overlaps with heap: %x - %x (size: %x). The heap start offset must be reconfigured", i, vaddr, vaddr+prog.Memsz, prog.Memsz)
 		}
-		if err := s.GetMemory().SetMemoryRange(uint32(prog.Vaddr), r); err != nil {
+		if err := s.GetMemory().SetMemoryRange(uint32(vaddr), r); err != nil {
 			return empty, fmt.Errorf("failed to read program segment %d: %w", i, err)
 		}
 	}
 
+	if f.Type == elf.ET_DYN {
+		if err := relocate(f, s.GetMemory(), base); err != nil {
+			return empty, fmt.Errorf("failed to relocate position-independent executable: %w", err)
+		}
+	}
+
 	return s, nil
 }
@@ -29,9 +29,10 @@ func MakeMetadata(elfProgram *elf.File) (*Metadata, error) {
 	sort.Slice(syms, func(i, j int) bool {
 		return syms[i].Value < syms[j].Value
 	})
+	base := LoadBase(elfProgram)
 	out := &Metadata{Symbols: make([]Symbol, len(syms))}
 	for i, s := range syms {
-		out.Symbols[i] = Symbol{Name: s.Name, Start: uint32(s.Value), Size: uint32(s.Size)}
+		out.Symbols[i] = Symbol{Name: s.Name, Start: uint32(s.Value) + base, Size: uint32(s.Size)}
 	}
 	return out, nil
 }
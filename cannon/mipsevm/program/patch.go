@@ -6,56 +6,152 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
 )
 
-// TODO(cp-903) Consider breaking up go patching into performance and threading-related patches so we can
-// selectively apply the perf patching to MTCannon
+// PatchSet is a named set of patches that load-elf can apply to the program.
+type PatchSet string
+
+const (
+	// PatchSetGoRuntime patches out the Go runtime functions that cannon can't run, like the GC
+	PatchSetGoRuntime PatchSet = "go-runtime"
+	// PatchSetStackInit sets up the initial stack with the program arguments, environment and auxiliary vector
+	PatchSetStackInit PatchSet = "stack-init"
+	// PatchSetNone applies no patches. It can't be combined with other patch sets.
+	PatchSetNone PatchSet = "none"
+)
+
+// deprecatedPatchSets are the names of the patch sets of older cannon versions
+var deprecatedPatchSets = map[string]PatchSet{
+	"go":    PatchSetGoRuntime,
+	"stack": PatchSetStackInit,
+}
+
+// ParsePatchSets parses patch set names, and returns the patch sets to apply in order.
+func ParsePatchSets(names []string) ([]PatchSet, error) {
+	var sets []PatchSet
+	for _, name := range names {
+		set := PatchSet(name)
+		if deprecated, ok := deprecatedPatchSets[name]; ok {
+			set = deprecated
+		}
+		switch set {
+		case PatchSetGoRuntime, PatchSetStackInit:
+			if slices.Contains(sets, set) {
+				return nil, fmt.Errorf("duplicate patch set %q", name)
+			}
+			sets = append(sets, set)
+		case PatchSetNone:
+			if len(names) != 1 {
+				return nil, fmt.Errorf("patch set %q can't be combined with other patch sets", PatchSetNone)
+			}
+		default:
+			return nil, fmt.Errorf("unrecognized patch set: %q", name)
+		}
+	}
+	return sets, nil
+}
+
+// PatchedSymbol is a function that was patched out of the program.
+type PatchedSymbol struct {
+	Name string       `json:"name"`
+	Addr hexutil.Uint `json:"addr"`
+}
+
+// PatchResult describes the changes a patch set made to the program.
+type PatchResult struct {
+	Set     PatchSet        `json:"set"`
+	Symbols []PatchedSymbol `json:"symbols"`
+}
+
+// ApplyPatches applies the patch sets to the state loaded from the ELF file, and reports what each of them patched.
+func ApplyPatches(f *elf.File, st mipsevm.FPVMState, sets []PatchSet) ([]PatchResult, error) {
+	results := make([]PatchResult, 0, len(sets))
+	for _, set := range sets {
+		result := PatchResult{Set: set, Symbols: []PatchedSymbol{}}
+		var err error
+		switch set {
+		case PatchSetGoRuntime:
+			result.Symbols, err = PatchGoRuntime(f, st)
+		case PatchSetStackInit:
+			err = PatchStack(st)
+		default:
+			err = fmt.Errorf("unrecognized patch set: %q", set)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply patch set %s: %w", set, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// goRuntimePatchSymbols are the functions patched out by the go-runtime patch set.
+// TODO(cp-903) Split these into a performance and a threading-related PatchSet, so load-elf can
+// selectively apply the performance patches to MTCannon.
+var goRuntimePatchSymbols = []string{
+	// Disable Golang GC by patching the functions that enable the GC to a no-op function.
+	"runtime.gcenable",
+	"runtime.init.5",            // patch out: init() { go forcegchelper() }
+	"runtime.main.func1",        // patch out: main.func() { newm(sysmon, ....) }
+	"runtime.deductSweepCredit", // uses floating point nums and interacts with gc we disabled
+	"runtime.(*gcControllerState).commit",
+	// these prometheus packages rely on concurrent background things. We cannot run those.
+	"github.com/prometheus/client_golang/prometheus.init",
+	"github.com/prometheus/client_golang/prometheus.init.0",
+	"github.com/prometheus/procfs.init",
+	"github.com/prometheus/common/model.init",
+	"github.com/prometheus/client_model/go.init",
+	"github.com/prometheus/client_model/go.init.0",
+	"github.com/prometheus/client_model/go.init.1",
+	// skip flag pkg init, we need to debug arg-processing more to see why this fails
+	"flag.init",
+	// We need to patch this out, we don't pass float64nan because we don't support floats
+	"runtime.check",
+}
+
+// PatchGo applies the go-runtime patch set.
 func PatchGo(f *elf.File, st mipsevm.FPVMState) error {
+	_, err := PatchGoRuntime(f, st)
+	return err
+}
+
+// PatchGoRuntime applies the go-runtime patch set, and returns the patched symbols in symbol table order.
+func PatchGoRuntime(f *elf.File, st mipsevm.FPVMState) ([]PatchedSymbol, error) {
 	symbols, err := f.Symbols()
 	if err != nil {
-		return fmt.Errorf("failed to read symbols data, cannot patch program: %w", err)
+		return nil, fmt.Errorf("failed to read symbols data, cannot patch program: %w", err)
 	}
+	base := LoadBase(f)
 
+	patched := []PatchedSymbol{}
 	for _, s := range symbols {
-		// Disable Golang GC by patching the functions that enable the GC to a no-op function.
-		switch s.Name {
-		case "runtime.gcenable",
-			"runtime.init.5",            // patch out: init() { go forcegchelper() }
-			"runtime.main.func1",        // patch out: main.func() { newm(sysmon, ....) }
-			"runtime.deductSweepCredit", // uses floating point nums and interacts with gc we disabled
-			"runtime.(*gcControllerState).commit",
-			// these prometheus packages rely on concurrent background things. We cannot run those.
-			"github.com/prometheus/client_golang/prometheus.init",
-			"github.com/prometheus/client_golang/prometheus.init.0",
-			"github.com/prometheus/procfs.init",
-			"github.com/prometheus/common/model.init",
-			"github.com/prometheus/client_model/go.init",
-			"github.com/prometheus/client_model/go.init.0",
-			"github.com/prometheus/client_model/go.init.1",
-			// skip flag pkg init, we need to debug arg-processing more to see why this fails
-			"flag.init",
-			// We need to patch this out, we don't pass float64nan because we don't support floats
-			"runtime.check":
-			// MIPS32 patch: ret (pseudo instruction)
-			// 03e00008 = jr $ra = ret (pseudo instruction)
-			// 00000000 = nop (executes with delay-slot, but does nothing)
-			if err := st.GetMemory().SetMemoryRange(uint32(s.Value), bytes.NewReader([]byte{
-				0x03, 0xe0, 0x00, 0x08,
-				0, 0, 0, 0,
-			})); err != nil {
-				return fmt.Errorf("failed to patch Go runtime.gcenable: %w", err)
-			}
+		if !slices.Contains(goRuntimePatchSymbols, s.Name) {
+			continue
+		}
+		addr := uint32(s.Value) + base
+		// MIPS32 patch: ret (pseudo instruction)
+		// 03e00008 = jr $ra = ret (pseudo instruction)
+		// 00000000 = nop (executes with delay-slot, but does nothing)
+		if err := st.GetMemory().SetMemoryRange(addr, bytes.NewReader([]byte{
+			0x03, 0xe0, 0x00, 0x08,
+			0, 0, 0, 0,
+		})); err != nil {
+			return nil, fmt.Errorf("failed to patch Go %s: %w", s.Name, err)
 		}
+		patched = append(patched, PatchedSymbol{Name: s.Name, Addr: hexutil.Uint(addr)})
 	}
-	return nil
+	return patched, nil
 }
 
 func PatchStack(st mipsevm.FPVMState) error {
 	// setup stack pointer
-	sp := uint32(0x7f_ff_d0_00)
+	sp := st.GetLayout().StackPointer
 	// allocate 1 page for the initial stack data, and 16KB = 4 pages for the stack to grow
 	if err := st.GetMemory().SetMemoryRange(sp-4*memory.PageSize, bytes.NewReader(make([]byte, 5*memory.PageSize))); err != nil {
 		return errors.New("failed to allocate page for stack content")
@@ -0,0 +1,94 @@
+package program_test
+
+import (
+	"debug/elf"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+)
+
+func TestParsePatchSets(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		sets  []program.PatchSet
+		err   string
+	}{
+		{name: "Default", names: []string{"go-runtime", "stack-init"}, sets: []program.PatchSet{program.PatchSetGoRuntime, program.PatchSetStackInit}},
+		{name: "Order", names: []string{"stack-init", "go-runtime"}, sets: []program.PatchSet{program.PatchSetStackInit, program.PatchSetGoRuntime}},
+		{name: "Deprecated", names: []string{"go", "stack"}, sets: []program.PatchSet{program.PatchSetGoRuntime, program.PatchSetStackInit}},
+		{name: "None", names: []string{"none"}},
+		{name: "Empty", names: nil},
+		{name: "NoneCombined", names: []string{"none", "stack-init"}, err: "can't be combined with other patch sets"},
+		{name: "Duplicate", names: []string{"stack-init", "stack-init"}, err: `duplicate patch set "stack-init"`},
+		{name: "DeprecatedDuplicate", names: []string{"go-runtime", "go"}, err: `duplicate patch set "go"`},
+		{name: "Unknown", names: []string{"gc"}, err: `unrecognized patch set: "gc"`},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			sets, err := program.ParsePatchSets(test.names)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.sets, sets)
+		})
+	}
+}
+
+func TestApplyPatches(t *testing.T) {
+	elfProgram, err := elf.Open("../../testdata/example/bin/hello.elf")
+	require.NoError(t, err, "open ELF file")
+
+	t.Run("GoRuntimeAndStack", func(t *testing.T) {
+		state, err := program.LoadELF(elfProgram, singlethreaded.CreateInitialState)
+		require.NoError(t, err)
+		results, err := program.ApplyPatches(elfProgram, state, []program.PatchSet{program.PatchSetGoRuntime, program.PatchSetStackInit})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+
+		require.Equal(t, program.PatchSetGoRuntime, results[0].Set)
+		names := make([]string, len(results[0].Symbols))
+		for i, sym := range results[0].Symbols {
+			names[i] = sym.Name
+			require.Equal(t, uint32(0x03e00008), state.GetMemory().GetMemory(uint32(sym.Addr)), "%s must return immediately", sym.Name)
+			require.Equal(t, uint32(0), state.GetMemory().GetMemory(uint32(sym.Addr)+4), "%s must have a nop delay slot", sym.Name)
+		}
+		require.Contains(t, names, "runtime.gcenable")
+		require.Contains(t, names, "runtime.main.func1")
+
+		require.Equal(t, program.PatchSetStackInit, results[1].Set)
+		require.Empty(t, results[1].Symbols)
+		require.NotNil(t, results[1].Symbols, "must report an empty list of symbols")
+		require.Equal(t, state.GetLayout().StackPointer, state.GetRegistersRef()[29])
+	})
+
+	t.Run("None", func(t *testing.T) {
+		state, err := program.LoadELF(elfProgram, singlethreaded.CreateInitialState)
+		require.NoError(t, err)
+		results, err := program.ApplyPatches(elfProgram, state, nil)
+		require.NoError(t, err)
+		require.Empty(t, results)
+		require.Zero(t, state.GetRegistersRef()[29], "must not set up the stack")
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		state, err := program.LoadELF(elfProgram, singlethreaded.CreateInitialState)
+		require.NoError(t, err)
+		_, err = program.ApplyPatches(elfProgram, state, []program.PatchSet{program.PatchSetNone})
+		require.ErrorContains(t, err, "failed to apply patch set none")
+	})
+
+	t.Run("NoSymbols", func(t *testing.T) {
+		pie := defaultTestPIE().build(t)
+		state, err := program.LoadELF(pie, singlethreaded.CreateInitialState)
+		require.NoError(t, err)
+		_, err = program.ApplyPatches(pie, state, []program.PatchSet{program.PatchSetGoRuntime})
+		require.ErrorContains(t, err, "failed to read symbols data")
+	})
+}
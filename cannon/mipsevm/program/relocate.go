@@ -0,0 +1,173 @@
+package program
+
+import (
+	"debug/elf"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+)
+
+const (
+	// PIE_BASE is the address position-independent executables are loaded at.
+	PIE_BASE = 0x00_40_00_00
+)
+
+// MIPS specific dynamic tags, see the MIPS psABI
+const (
+	dtMipsLocalGotNo = elf.DynTag(0x7000000a)
+	dtMipsSymTabNo   = elf.DynTag(0x70000011)
+	dtMipsGotSym     = elf.DynTag(0x70000013)
+)
+
+// LoadBase returns the offset the ELF program is loaded at. Position-independent executables are linked
+// relative to address 0 and are relocated to PIE_BASE, other executables are loaded at their linked addresses.
+func LoadBase(f *elf.File) uint32 {
+	if f.Type == elf.ET_DYN {
+		return PIE_BASE
+	}
+	return 0
+}
+
+// relocate applies the dynamic relocations of a position-independent executable that was loaded at base.
+// Only self-contained (static-PIE) executables are supported: there is no dynamic linker to load shared libraries.
+func relocate(f *elf.File, mem *memory.Memory, base uint32) error {
+	libs, err := f.ImportedLibraries()
+	if err != nil {
+		return fmt.Errorf("failed to read imported libraries: %w", err)
+	}
+	if len(libs) > 0 {
+		return fmt.Errorf("program is dynamically linked against %v, only static executables are supported", libs)
+	}
+	syms, err := f.DynamicSymbols()
+	if err != nil && !errors.Is(err, elf.ErrNoSymbols) {
+		return fmt.Errorf("failed to read dynamic symbols: %w", err)
+	}
+	// resolves a dynamic symbol by index. Note that DynamicSymbols omits the null symbol at index 0.
+	resolve := func(idx uint32) (uint32, error) {
+		if idx == 0 {
+			return 0, nil
+		}
+		if int(idx) > len(syms) {
+			return 0, fmt.Errorf("invalid dynamic symbol index %d", idx)
+		}
+		sym := syms[idx-1]
+		switch sym.Section {
+		case elf.SHN_UNDEF:
+			if elf.ST_BIND(sym.Info) == elf.STB_WEAK {
+				return 0, nil
+			}
+			return 0, fmt.Errorf("undefined symbol %q", sym.Name)
+		case elf.SHN_ABS:
+			return uint32(sym.Value), nil
+		default:
+			return uint32(sym.Value) + base, nil
+		}
+	}
+
+	// The GOT is relocated implicitly: local entries are offset by the load base,
+	// and global entries are set to the address of the symbol they correspond with.
+	pltGot, hasGot, err := dynValue(f, elf.DT_PLTGOT)
+	if err != nil {
+		return err
+	}
+	localGotNo, _, err := dynValue(f, dtMipsLocalGotNo)
+	if err != nil {
+		return err
+	}
+	gotSym, _, err := dynValue(f, dtMipsGotSym)
+	if err != nil {
+		return err
+	}
+	symTabNo, _, err := dynValue(f, dtMipsSymTabNo)
+	if err != nil {
+		return err
+	}
+	got := pltGot + base
+	if hasGot {
+		if got&3 != 0 {
+			return fmt.Errorf("unaligned GOT at %08x", got)
+		}
+		// entry 0 is reserved for the lazy resolver, entry 1 too if it has the GNU extension bit set
+		i := uint32(1)
+		if mem.GetMemory(got+4)&0x80000000 != 0 {
+			i = 2
+		}
+		for ; i < localGotNo; i++ {
+			mem.SetMemory(got+4*i, mem.GetMemory(got+4*i)+base)
+		}
+		for idx := gotSym; idx < symTabNo; idx++ {
+			v, err := resolve(idx)
+			if err != nil {
+				return fmt.Errorf("failed to relocate GOT entry of symbol %d: %w", idx, err)
+			}
+			mem.SetMemory(got+4*(localGotNo+idx-gotSym), v)
+		}
+	}
+
+	if rela, _, err := dynValue(f, elf.DT_RELASZ); err != nil {
+		return err
+	} else if rela != 0 {
+		return errors.New("RELA relocations are not supported on MIPS32")
+	}
+	rel, hasRel, err := dynValue(f, elf.DT_REL)
+	if err != nil || !hasRel {
+		return err
+	}
+	relSz, _, err := dynValue(f, elf.DT_RELSZ)
+	if err != nil {
+		return err
+	}
+	relEnt, hasRelEnt, err := dynValue(f, elf.DT_RELENT)
+	if err != nil {
+		return err
+	}
+	if !hasRelEnt {
+		relEnt = 8
+	}
+	if relEnt != 8 || (rel+base)&3 != 0 {
+		return fmt.Errorf("invalid relocation table at %08x with entry size %d", rel, relEnt)
+	}
+	for offset := uint32(0); offset < relSz; offset += relEnt {
+		rOffset := mem.GetMemory(rel + base + offset)
+		rInfo := mem.GetMemory(rel + base + offset + 4)
+		symIdx, typ := elf.R_SYM32(rInfo), elf.R_MIPS(elf.R_TYPE32(rInfo))
+		switch typ {
+		case elf.R_MIPS_NONE:
+			continue
+		case elf.R_MIPS_REL32:
+			addr := rOffset + base
+			if addr&3 != 0 {
+				return fmt.Errorf("unaligned relocation at %08x", addr)
+			}
+			var v uint32
+			switch {
+			case symIdx == 0:
+				v = base
+			case symIdx < gotSym || !hasGot:
+				v, err = resolve(symIdx)
+			default:
+				v = mem.GetMemory(got + 4*(localGotNo+symIdx-gotSym))
+			}
+			if err != nil {
+				return fmt.Errorf("failed to apply relocation at %08x: %w", addr, err)
+			}
+			mem.SetMemory(addr, mem.GetMemory(addr)+v)
+		default:
+			return fmt.Errorf("unsupported relocation type %v at %08x", typ, rOffset)
+		}
+	}
+	return nil
+}
+
+// dynValue returns the value of a dynamic tag that occurs at most once.
+func dynValue(f *elf.File, tag elf.DynTag) (uint32, bool, error) {
+	values, err := f.DynValue(tag)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read dynamic tag %v: %w", tag, err)
+	}
+	if len(values) == 0 {
+		return 0, false, nil
+	}
+	return uint32(values[0]), true, nil
+}
@@ -0,0 +1,203 @@
+package program_test
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+)
+
+// Addresses of the test PIE, as linked. The whole file is a single segment, loaded at its file offsets.
+const (
+	pieEntry   = 0x80
+	pieDynStr  = 0x100
+	pieDynSym  = 0x140
+	pieGot     = 0x200
+	pieRel     = 0x240
+	pieData    = 0x300
+	pieDynamic = 0x380
+	pieSymA    = 0x84  // sym_a, a symbol outside of the global GOT entries
+	pieSymB    = 0x310 // sym_b, a symbol with a global GOT entry
+	pieLinked  = 0x120 // link-time address stored in the local GOT entries and data
+)
+
+type pieRelocation struct {
+	offset uint32
+	sym    uint32
+	typ    elf.R_MIPS
+}
+
+// testPIE describes a small big-endian MIPS32 static position-independent executable,
+// with a GOT of 2 reserved, 2 local and 2 global entries, and dynamic relocations.
+type testPIE struct {
+	needed bool
+	relocs []pieRelocation
+}
+
+func defaultTestPIE() *testPIE {
+	return &testPIE{relocs: []pieRelocation{
+		{offset: pieData, sym: 0, typ: elf.R_MIPS_REL32},
+		{offset: pieData + 4, sym: 1, typ: elf.R_MIPS_REL32},
+		{offset: pieData + 8, sym: 2, typ: elf.R_MIPS_REL32},
+		{offset: 0, sym: 0, typ: elf.R_MIPS_NONE},
+	}}
+}
+
+func (p *testPIE) build(t *testing.T) *elf.File {
+	img := make([]byte, 0x400)
+	be := binary.BigEndian
+	put := func(off uint32, vals ...uint32) {
+		for i, v := range vals {
+			be.PutUint32(img[off+4*uint32(i):], v)
+		}
+	}
+
+	// .dynstr
+	dynstr := "\x00libfoo.so\x00sym_a\x00sym_b\x00weak_c\x00"
+	copy(img[pieDynStr:], dynstr)
+	strOff := func(name string) uint32 {
+		return uint32(bytes.Index([]byte(dynstr), []byte("\x00"+name+"\x00")) + 1)
+	}
+	// .dynsym: null symbol, sym_a, sym_b and an undefined weak symbol
+	sym := func(idx uint32, name string, value uint32, info uint8, shndx uint16) {
+		off := pieDynSym + 16*idx
+		put(off, strOff(name), value, 4)
+		img[off+12] = info
+		be.PutUint16(img[off+14:], shndx)
+	}
+	global := uint8(elf.STB_GLOBAL)<<4 | uint8(elf.STT_FUNC)
+	sym(1, "sym_a", pieSymA, global, 1)
+	sym(2, "sym_b", pieSymB, global, 1)
+	sym(3, "weak_c", 0, uint8(elf.STB_WEAK)<<4|uint8(elf.STT_FUNC), uint16(elf.SHN_UNDEF))
+
+	// GOT: lazy resolver, GNU extension module pointer, 2 local entries, and the entries of sym_b and weak_c
+	put(pieGot, 0, 0x80000000, pieLinked, pieLinked+4, 0xdead, 0xbeef)
+	// Relocation table
+	for i, r := range p.relocs {
+		put(pieRel+8*uint32(i), r.offset, r.sym<<8|uint32(r.typ))
+	}
+	// Data to relocate
+	put(pieData, pieLinked, 0x10, 0x20)
+
+	// .dynamic
+	dyn := []uint32{
+		uint32(elf.DT_STRTAB), pieDynStr,
+		uint32(elf.DT_SYMTAB), pieDynSym,
+		uint32(elf.DT_PLTGOT), pieGot,
+		0x7000000a, 4, // DT_MIPS_LOCAL_GOTNO
+		0x70000013, 2, // DT_MIPS_GOTSYM
+		0x70000011, 4, // DT_MIPS_SYMTABNO
+		uint32(elf.DT_REL), pieRel,
+		uint32(elf.DT_RELSZ), 8 * uint32(len(p.relocs)),
+		uint32(elf.DT_RELENT), 8,
+	}
+	if p.needed {
+		dyn = append(dyn, uint32(elf.DT_NEEDED), strOff("libfoo.so"))
+	}
+	dyn = append(dyn, uint32(elf.DT_NULL), 0)
+	put(pieDynamic, dyn...)
+	dynSize := 4 * uint32(len(dyn))
+
+	// Section header string table, after the image
+	shstrtab := "\x00.dynstr\x00.dynsym\x00.dynamic\x00.shstrtab\x00"
+	shstrOff := uint32(len(img))
+	img = append(img, shstrtab...)
+	for len(img)%4 != 0 {
+		img = append(img, 0)
+	}
+	shName := func(name string) uint32 {
+		return uint32(bytes.Index([]byte(shstrtab), []byte("\x00"+name+"\x00")) + 1)
+	}
+	shOff := uint32(len(img))
+	section := func(name string, typ elf.SectionType, flags elf.SectionFlag, addr, size, link, info, entsize uint32) {
+		var sh [40]byte
+		for i, v := range []uint32{shName(name), uint32(typ), uint32(flags), addr, addr, size, link, info, 4, entsize} {
+			be.PutUint32(sh[4*i:], v)
+		}
+		img = append(img, sh[:]...)
+	}
+	img = append(img, make([]byte, 40)...) // null section
+	section(".dynstr", elf.SHT_STRTAB, elf.SHF_ALLOC, pieDynStr, uint32(len(dynstr)), 0, 0, 0)
+	section(".dynsym", elf.SHT_DYNSYM, elf.SHF_ALLOC, pieDynSym, 16*4, 1, 1, 16)
+	section(".dynamic", elf.SHT_DYNAMIC, elf.SHF_ALLOC|elf.SHF_WRITE, pieDynamic, dynSize, 1, 0, 8)
+	var shstr [40]byte
+	for i, v := range []uint32{shName(".shstrtab"), uint32(elf.SHT_STRTAB), 0, 0, shstrOff, uint32(len(shstrtab)), 0, 0, 1, 0} {
+		be.PutUint32(shstr[4*i:], v)
+	}
+	img = append(img, shstr[:]...)
+
+	// ELF header
+	copy(img, []byte{0x7f, 'E', 'L', 'F', byte(elf.ELFCLASS32), byte(elf.ELFDATA2MSB), byte(elf.EV_CURRENT)})
+	be.PutUint16(img[16:], uint16(elf.ET_DYN))
+	be.PutUint16(img[18:], uint16(elf.EM_MIPS))
+	put(20, uint32(elf.EV_CURRENT), pieEntry, 0x34, shOff, 0)
+	be.PutUint16(img[40:], 0x34) // header size
+	be.PutUint16(img[42:], 32)   // program header size
+	be.PutUint16(img[44:], 2)    // program headers
+	be.PutUint16(img[46:], 40)   // section header size
+	be.PutUint16(img[48:], 5)    // section headers
+	be.PutUint16(img[50:], 4)    // section header string table index
+	// Program headers: the whole image, and the dynamic section
+	put(0x34, uint32(elf.PT_LOAD), 0, 0, 0, 0x400, 0x400, uint32(elf.PF_R|elf.PF_W|elf.PF_X), 0x1000)
+	put(0x54, uint32(elf.PT_DYNAMIC), pieDynamic, pieDynamic, pieDynamic, dynSize, dynSize, uint32(elf.PF_R|elf.PF_W), 4)
+
+	f, err := elf.NewFile(bytes.NewReader(img))
+	require.NoError(t, err)
+	return f
+}
+
+func TestLoadPIE(t *testing.T) {
+	f := defaultTestPIE().build(t)
+	require.Equal(t, uint32(program.PIE_BASE), program.LoadBase(f))
+
+	state, err := program.LoadELF(f, singlethreaded.CreateInitialState)
+	require.NoError(t, err)
+	require.Equal(t, uint32(program.PIE_BASE+pieEntry), state.GetPC())
+
+	mem := state.GetMemory()
+	got := func(i uint32) uint32 {
+		return mem.GetMemory(program.PIE_BASE + pieGot + 4*i)
+	}
+	require.Equal(t, uint32(0), got(0), "lazy resolver entry is reserved")
+	require.Equal(t, uint32(0x80000000), got(1), "GNU extension entry is reserved")
+	require.Equal(t, uint32(program.PIE_BASE+pieLinked), got(2), "local GOT entries are offset by the load base")
+	require.Equal(t, uint32(program.PIE_BASE+pieLinked+4), got(3))
+	require.Equal(t, uint32(program.PIE_BASE+pieSymB), got(4), "global GOT entries are set to the symbol address")
+	require.Equal(t, uint32(0), got(5), "undefined weak symbols resolve to 0")
+
+	data := func(i uint32) uint32 {
+		return mem.GetMemory(program.PIE_BASE + pieData + 4*i)
+	}
+	require.Equal(t, uint32(program.PIE_BASE+pieLinked), data(0), "R_MIPS_REL32 without symbol adds the load base")
+	require.Equal(t, uint32(0x10+program.PIE_BASE+pieSymA), data(1), "R_MIPS_REL32 of a local symbol adds its address")
+	// the global GOT entry of sym_b is relocated before the relocations are applied
+	require.Equal(t, uint32(0x20+program.PIE_BASE+pieSymB), data(2), "R_MIPS_REL32 of a global symbol adds its GOT entry")
+}
+
+func TestLoadPIEErrors(t *testing.T) {
+	t.Run("DynamicallyLinked", func(t *testing.T) {
+		pie := defaultTestPIE()
+		pie.needed = true
+		_, err := program.LoadELF(pie.build(t), singlethreaded.CreateInitialState)
+		require.ErrorContains(t, err, "dynamically linked")
+	})
+
+	t.Run("UnsupportedRelocation", func(t *testing.T) {
+		pie := defaultTestPIE()
+		pie.relocs = append(pie.relocs, pieRelocation{offset: pieData, sym: 0, typ: elf.R_MIPS_26})
+		_, err := program.LoadELF(pie.build(t), singlethreaded.CreateInitialState)
+		require.ErrorContains(t, err, "unsupported relocation type")
+	})
+
+	t.Run("UnalignedRelocation", func(t *testing.T) {
+		pie := defaultTestPIE()
+		pie.relocs = []pieRelocation{{offset: pieData + 2, sym: 0, typ: elf.R_MIPS_REL32}}
+		_, err := program.LoadELF(pie.build(t), singlethreaded.CreateInitialState)
+		require.ErrorContains(t, err, "unaligned relocation")
+	})
+}
@@ -21,6 +21,8 @@ type InstrumentedState struct {
 	stackTracker  exec.TraceableStackTracker
 
 	preimageOracle *exec.TrackingPreimageOracleReader
+
+	layout *mipsevm.Layout
 }
 
 var _ mipsevm.FPVM = (*InstrumentedState)(nil)
@@ -41,6 +43,7 @@ func NewInstrumentedState(state *State, po mipsevm.PreimageOracle, stdOut, stdEr
 		stackTracker:   &exec.NoopStackTracker{},
 		preimageOracle: exec.NewTrackingPreimageOracleReader(po),
 		meta:           meta,
+		layout:         state.GetLayout(),
 	}
 }
 
@@ -70,6 +73,9 @@ func (m *InstrumentedState) Step(proof bool) (wit *mipsevm.StepWitness, err erro
 	if err != nil {
 		return nil, err
 	}
+	if err := m.layout.CheckMemoryLimit(m.state.Memory.UsageRaw()); err != nil {
+		return nil, err
+	}
 
 	if proof {
 		memProof := m.memoryTracker.MemProof()
@@ -102,6 +108,9 @@ func (m *InstrumentedState) GetDebugInfo() *mipsevm.DebugInfo {
 		MemoryUsed:          hexutil.Uint64(m.state.Memory.UsageRaw()),
 		NumPreimageRequests: m.preimageOracle.NumPreimageRequests(),
 		TotalPreimageSize:   m.preimageOracle.TotalPreimageSize(),
+		NumHints:            m.preimageOracle.NumHints(),
+		TotalHintSize:       m.preimageOracle.TotalHintSize(),
+		MerkleNodes:         m.state.Memory.NodeCount(),
 	}
 }
 
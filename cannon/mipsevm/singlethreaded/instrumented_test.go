@@ -1,12 +1,17 @@
 package singlethreaded
 
 import (
+	"bytes"
 	"io"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
 )
 
@@ -25,3 +30,70 @@ func TestInstrumentedState_Hello(t *testing.T) {
 func TestInstrumentedState_Claim(t *testing.T) {
 	testutil.RunVMTest_Claim(t, CreateInitialState, vmFactory, true)
 }
+
+func TestInstrumentedState_SyscallHook(t *testing.T) {
+	unregister := exec.RegisterSyscallHook(exec.SysWrite, func(ctx *exec.SyscallContext) (uint32, uint32, bool) {
+		// short write
+		return ctx.A2 / 2, 0, true
+	})
+	defer unregister()
+
+	state := CreateEmptyState()
+	state.Memory.SetMemory(state.GetPC(), 0x0000000c) // syscall
+	state.Registers[2] = exec.SysWrite
+	state.Registers[4] = exec.FdStdout
+	state.Registers[6] = 10
+	var stdOut bytes.Buffer
+	vm := NewInstrumentedState(state, nil, &stdOut, io.Discard, nil)
+	_, err := vm.Step(false)
+	require.NoError(t, err)
+	require.Equal(t, uint32(5), state.Registers[2])
+	require.Equal(t, uint32(0), state.Registers[7])
+	require.Equal(t, uint32(4), state.Cpu.PC)
+	require.Empty(t, stdOut.Bytes(), "syscall handling overridden")
+}
+
+func TestInstrumentedState_FPU(t *testing.T) {
+	state := CreateEmptyState()
+	state.FPU = exec.NewFPUState()
+	state.FPU.FPR[0] = 0x3FC0_0000                    // 1.5
+	state.FPU.FPR[1] = 0x4010_0000                    // 2.25
+	state.Memory.SetMemory(state.GetPC(), 0x46010080) // add.s $f2, $f0, $f1
+	vm := NewInstrumentedState(state, nil, io.Discard, io.Discard, nil)
+	wit, err := vm.Step(true)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0x4070_0000), state.FPU.FPR[2]) // 3.75
+	require.Equal(t, uint32(4), state.Cpu.PC)
+
+	// the FPU registers are committed to in the witness
+	require.Len(t, wit.State, STATE_WITNESS_SIZE+exec.FPU_WITNESS_SIZE)
+	witness, hash := state.EncodeWitness()
+	expected, err := StateWitness(witness).StateHash()
+	require.NoError(t, err)
+	require.Equal(t, expected, hash)
+	state.FPU.FPR[2] = 0
+	_, changed := state.EncodeWitness()
+	require.NotEqual(t, hash, changed)
+}
+
+func TestInstrumentedState_CustomLayout(t *testing.T) {
+	state := CreateEmptyState()
+	state.Layout = program.DefaultLayout()
+	state.Layout.ProgramBreak = 0x2000_0000
+	state.Layout.MaxMemory = 2 * memory.PageSize
+	state.Memory.SetMemory(0, 0x0000000c) // syscall
+	state.Memory.SetMemory(4, 0xAD090000) // sw $t1, 0($t0)
+	state.Registers[2] = exec.SysBrk
+	state.Registers[8] = 0x1000_0000
+	vm := NewInstrumentedState(state, nil, io.Discard, io.Discard, nil)
+
+	wit, err := vm.Step(true)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0x2000_0000), state.Registers[2], "program break of the layout")
+	require.Len(t, wit.State, STATE_WITNESS_SIZE+mipsevm.LAYOUT_WITNESS_SIZE, "the layout is committed to in the witness")
+
+	// with a second page allocated, the store to a third page exceeds the memory limit
+	state.Memory.SetMemory(memory.PageSize, 0)
+	_, err = vm.Step(false)
+	require.ErrorIs(t, err, mipsevm.ErrMemoryLimit)
+}
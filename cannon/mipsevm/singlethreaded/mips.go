@@ -5,23 +5,29 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
-	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
 )
 
 func (m *InstrumentedState) handleSyscall() error {
-	syscallNum, a0, a1, a2, _ := exec.GetSyscallArgs(&m.state.Registers)
+	syscallNum, a0, a1, a2, a3 := exec.GetSyscallArgs(&m.state.Registers)
 
 	v0 := uint32(0)
 	v1 := uint32(0)
 
+	if hv0, hv1, handled := exec.RunSyscallHooks(&exec.SyscallContext{SyscallNum: syscallNum, A0: a0, A1: a1, A2: a2, A3: a3, Step: m.state.Step, Memory: m.state.Memory}); handled {
+		exec.HandleSyscallUpdates(&m.state.Cpu, &m.state.Registers, hv0, hv1)
+		return nil
+	}
+
 	//fmt.Printf("syscall: %d\n", syscallNum)
 	switch syscallNum {
 	case exec.SysMmap:
 		var newHeap uint32
-		v0, v1, newHeap = exec.HandleSysMmap(a0, a1, m.state.Heap)
+		v0, v1, newHeap = exec.HandleSysMmap(a0, a1, m.state.Heap, m.layout)
 		m.state.Heap = newHeap
+	case exec.SysMunmap:
+		v0, v1 = exec.HandleSysMunmap(a0, a1, m.state.Memory)
 	case exec.SysBrk:
-		v0 = program.PROGRAM_BREAK
+		v0, v1 = exec.HandleSysBrk(a0, m.layout)
 	case exec.SysClone: // clone (not supported)
 		v0 = 1
 	case exec.SysExitGroup:
@@ -42,6 +48,8 @@ func (m *InstrumentedState) handleSyscall() error {
 		m.state.PreimageOffset = newPreimageOffset
 	case exec.SysFcntl:
 		v0, v1 = exec.HandleSysFcntl(a0, a1)
+	case exec.SysFstat64:
+		v0, v1 = exec.HandleSysFstat64(a0, a1, m.state.Memory, m.memoryTracker)
 	}
 
 	exec.HandleSyscallUpdates(&m.state.Cpu, &m.state.Registers, v0, v1)
@@ -62,6 +70,10 @@ func (m *InstrumentedState) mipsStep() error {
 		return m.handleSyscall()
 	}
 
+	if m.state.FPU != nil && exec.IsFPUInstruction(opcode, fun) {
+		return exec.ExecFPUStepLogic(&m.state.Cpu, &m.state.Registers, m.state.FPU, m.state.Memory, insn, opcode, fun, m.memoryTracker)
+	}
+
 	// Exec the rest of the step logic
 	return exec.ExecMipsCoreStepLogic(&m.state.Cpu, &m.state.Registers, m.state.Memory, insn, opcode, fun, m.memoryTracker, m.stackTracker)
 }
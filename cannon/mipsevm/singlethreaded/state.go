@@ -13,7 +13,9 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
 )
 
 // STATE_WITNESS_SIZE is the size of the state witness encoding in bytes.
@@ -36,6 +38,14 @@ type State struct {
 
 	Registers [32]uint32 `json:"registers"`
 
+	// FPU is the state of the emulated FPU. It's nil unless FPU emulation is enabled,
+	// which is not supported onchain.
+	FPU *exec.FPUState `json:"fpu,omitempty"`
+
+	// Layout is the memory layout of the guest. It's nil for the default layout,
+	// other layouts are not supported onchain.
+	Layout *mipsevm.Layout `json:"layout,omitempty"`
+
 	// LastHint is optional metadata, and not part of the VM state itself.
 	LastHint hexutil.Bytes `json:"lastHint,omitempty"`
 }
@@ -74,19 +84,21 @@ func (s *State) CreateVM(logger log.Logger, po mipsevm.PreimageOracle, stdOut, s
 }
 
 type stateMarshaling struct {
-	Memory         *memory.Memory `json:"memory"`
-	PreimageKey    common.Hash    `json:"preimageKey"`
-	PreimageOffset uint32         `json:"preimageOffset"`
-	PC             uint32         `json:"pc"`
-	NextPC         uint32         `json:"nextPC"`
-	LO             uint32         `json:"lo"`
-	HI             uint32         `json:"hi"`
-	Heap           uint32         `json:"heap"`
-	ExitCode       uint8          `json:"exit"`
-	Exited         bool           `json:"exited"`
-	Step           uint64         `json:"step"`
-	Registers      [32]uint32     `json:"registers"`
-	LastHint       hexutil.Bytes  `json:"lastHint,omitempty"`
+	Memory         *memory.Memory  `json:"memory"`
+	PreimageKey    common.Hash     `json:"preimageKey"`
+	PreimageOffset uint32          `json:"preimageOffset"`
+	PC             uint32          `json:"pc"`
+	NextPC         uint32          `json:"nextPC"`
+	LO             uint32          `json:"lo"`
+	HI             uint32          `json:"hi"`
+	Heap           uint32          `json:"heap"`
+	ExitCode       uint8           `json:"exit"`
+	Exited         bool            `json:"exited"`
+	Step           uint64          `json:"step"`
+	Registers      [32]uint32      `json:"registers"`
+	FPU            *exec.FPUState  `json:"fpu,omitempty"`
+	Layout         *mipsevm.Layout `json:"layout,omitempty"`
+	LastHint       hexutil.Bytes   `json:"lastHint,omitempty"`
 }
 
 func (s *State) MarshalJSON() ([]byte, error) { // nosemgrep
@@ -103,6 +115,8 @@ func (s *State) MarshalJSON() ([]byte, error) { // nosemgrep
 		Exited:         s.Exited,
 		Step:           s.Step,
 		Registers:      s.Registers,
+		FPU:            s.FPU,
+		Layout:         s.Layout,
 		LastHint:       s.LastHint,
 	}
 	return json.Marshal(sm)
@@ -125,6 +139,8 @@ func (s *State) UnmarshalJSON(data []byte) error {
 	s.Exited = sm.Exited
 	s.Step = sm.Step
 	s.Registers = sm.Registers
+	s.FPU = sm.FPU
+	s.Layout = sm.Layout
 	s.LastHint = sm.LastHint
 	return nil
 }
@@ -157,6 +173,13 @@ func (s *State) GetHeap() uint32 {
 	return s.Heap
 }
 
+func (s *State) GetLayout() *mipsevm.Layout {
+	if s.Layout == nil {
+		return program.DefaultLayout()
+	}
+	return s.Layout
+}
+
 func (s *State) GetPreimageKey() common.Hash {
 	return s.PreimageKey
 }
@@ -182,6 +205,14 @@ func (s *State) EncodeWitness() ([]byte, common.Hash) {
 	for _, r := range s.Registers {
 		out = binary.BigEndian.AppendUint32(out, r)
 	}
+	if s.FPU != nil {
+		// the FPU registers extend the witness, so FPU states can't be proven onchain
+		out = append(out, s.FPU.EncodeWitness()...)
+	}
+	if !program.IsDefaultLayout(s.Layout) {
+		// likewise a custom layout extends the witness
+		out = append(out, s.Layout.EncodeWitness()...)
+	}
 	return out, stateHashFromWitness(out)
 }
 
@@ -204,6 +235,8 @@ func (s *State) EncodeWitness() ([]byte, common.Hash) {
 // Registers                   [32]uint32
 // len(LastHint)			   uint32 (0 when LastHint is nil)
 // LastHint 				   []byte
+//
+// The FPU state is not included, it's serialized by the versioned state for FPU enabled states.
 func (s *State) Serialize(out io.Writer) error {
 	bout := serialize.NewBinaryWriter(out)
 
@@ -300,8 +333,18 @@ func (s *State) Deserialize(in io.Reader) error {
 
 type StateWitness []byte
 
+// validWitnessSize returns true for the witness sizes of states with and without FPU and custom layout.
+func validWitnessSize(n int) bool {
+	for _, ext := range []int{0, exec.FPU_WITNESS_SIZE, mipsevm.LAYOUT_WITNESS_SIZE, exec.FPU_WITNESS_SIZE + mipsevm.LAYOUT_WITNESS_SIZE} {
+		if n == STATE_WITNESS_SIZE+ext {
+			return true
+		}
+	}
+	return false
+}
+
 func (sw StateWitness) StateHash() (common.Hash, error) {
-	if len(sw) != STATE_WITNESS_SIZE {
+	if !validWitnessSize(len(sw)) {
 		return common.Hash{}, fmt.Errorf("Invalid witness length. Got %d, expected %d", len(sw), STATE_WITNESS_SIZE)
 	}
 	return stateHashFromWitness(sw), nil
@@ -314,7 +357,7 @@ func GetStateHashFn() mipsevm.HashFn {
 }
 
 func stateHashFromWitness(sw []byte) common.Hash {
-	if len(sw) != STATE_WITNESS_SIZE {
+	if !validWitnessSize(len(sw)) {
 		panic("Invalid witness length")
 	}
 	hash := crypto.Keccak256Hash(sw)
@@ -0,0 +1,185 @@
+package mipsevm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+)
+
+// The SSZ encoding of witness and proof artifacts, for tools that don't want to reimplement the Go layouts.
+// StepProof-s are not part of any hash-tree, so only the serialization is implemented, not the merkleization.
+//
+//	StateWitness = Container(
+//	    state_hash: Bytes32,
+//	    state: ByteList[MAX_STATE_WITNESS_SIZE],
+//	)
+//	MemoryProof = Vector[Bytes32, 28]
+//	PreimageAccess = Container(
+//	    key: Bytes32,
+//	    offset: uint32,
+//	    value: ByteList[MAX_PREIMAGE_VALUE_SIZE], # including the 8-byte length prefix
+//	)
+//	StepProof = Container(
+//	    step: uint64,
+//	    pre: StateWitness,
+//	    post: Bytes32,
+//	    thread_witness: ByteList[MAX_THREAD_WITNESS_SIZE], # empty for single-threaded VMs
+//	    memory_proofs: List[MemoryProof, MAX_STEP_MEMORY_PROOFS], # instruction proof first
+//	    preimage: List[PreimageAccess, 1], # empty when no pre-image is accessed
+//	)
+
+const (
+	MaxStateWitnessSize   = 1 << 12
+	MaxThreadWitnessSize  = memory.MEM_PROOF_SIZE - 1
+	MaxStepMemoryProofs   = 3
+	MaxPreimageValueSize  = 1 << 24
+	sszOffsetSize         = 4
+	stateWitnessFixedPart = 32 + sszOffsetSize
+	preimageFixedPart     = 32 + 4 + sszOffsetSize
+	stepProofFixedPart    = 8 + sszOffsetSize + 32 + 3*sszOffsetSize
+)
+
+var ErrInvalidSSZ = errors.New("invalid SSZ encoding")
+
+// MarshalSSZ encodes the step proof as a StepProof SSZ container.
+func (p *StepProof) MarshalSSZ() ([]byte, error) {
+	wit := p.Witness
+	if len(wit.State) > MaxStateWitnessSize {
+		return nil, fmt.Errorf("state witness of %d bytes exceeds limit of %d", len(wit.State), MaxStateWitnessSize)
+	}
+	if len(wit.PreimageValue) > MaxPreimageValueSize {
+		return nil, fmt.Errorf("pre-image value of %d bytes exceeds limit of %d", len(wit.PreimageValue), MaxPreimageValueSize)
+	}
+	// The proof data is the thread witness, if any, followed by the memory proofs.
+	// Thread witnesses are shorter than a memory proof, so they can be split without knowing the VM type.
+	threadWitnessSize := len(wit.ProofData) % memory.MEM_PROOF_SIZE
+	if proofs := len(wit.ProofData) / memory.MEM_PROOF_SIZE; proofs > MaxStepMemoryProofs {
+		return nil, fmt.Errorf("%d memory proofs exceed limit of %d", proofs, MaxStepMemoryProofs)
+	}
+
+	pre := encodeStateWitnessSSZ(wit.State, wit.StateHash)
+	var preimage []byte
+	if wit.HasPreimage() {
+		preimage = binary.LittleEndian.AppendUint32(preimage, sszOffsetSize) // offset of the single list element
+		preimage = append(preimage, wit.PreimageKey[:]...)
+		preimage = binary.LittleEndian.AppendUint32(preimage, wit.PreimageOffset)
+		preimage = binary.LittleEndian.AppendUint32(preimage, preimageFixedPart)
+		preimage = append(preimage, wit.PreimageValue...)
+	}
+
+	out := make([]byte, 0, stepProofFixedPart+len(pre)+len(wit.ProofData)+len(preimage))
+	offset := uint32(stepProofFixedPart)
+	out = binary.LittleEndian.AppendUint64(out, p.Step)
+	out = binary.LittleEndian.AppendUint32(out, offset)
+	offset += uint32(len(pre))
+	out = append(out, p.PostHash[:]...)
+	out = binary.LittleEndian.AppendUint32(out, offset)
+	offset += uint32(threadWitnessSize)
+	out = binary.LittleEndian.AppendUint32(out, offset)
+	offset += uint32(len(wit.ProofData) - threadWitnessSize)
+	out = binary.LittleEndian.AppendUint32(out, offset)
+	out = append(out, pre...)
+	out = append(out, wit.ProofData...)
+	return append(out, preimage...), nil
+}
+
+// UnmarshalSSZ decodes a StepProof SSZ container.
+func (p *StepProof) UnmarshalSSZ(data []byte) error {
+	if len(data) < stepProofFixedPart {
+		return fmt.Errorf("%w: step proof of %d bytes is shorter than its fixed part", ErrInvalidSSZ, len(data))
+	}
+	offsets := []uint32{
+		binary.LittleEndian.Uint32(data[8:12]),
+		binary.LittleEndian.Uint32(data[44:48]),
+		binary.LittleEndian.Uint32(data[48:52]),
+		binary.LittleEndian.Uint32(data[52:56]),
+	}
+	fields, err := splitSSZOffsets(data, stepProofFixedPart, offsets)
+	if err != nil {
+		return fmt.Errorf("step proof: %w", err)
+	}
+	wit := &StepWitness{}
+	if wit.State, wit.StateHash, err = DecodeStateWitnessSSZ(fields[0]); err != nil {
+		return err
+	}
+	threadWitness, memProofs := fields[1], fields[2]
+	if len(threadWitness) > MaxThreadWitnessSize {
+		return fmt.Errorf("%w: thread witness of %d bytes exceeds limit of %d", ErrInvalidSSZ, len(threadWitness), MaxThreadWitnessSize)
+	}
+	if len(memProofs)%memory.MEM_PROOF_SIZE != 0 || len(memProofs)/memory.MEM_PROOF_SIZE > MaxStepMemoryProofs {
+		return fmt.Errorf("%w: invalid memory proofs of %d bytes", ErrInvalidSSZ, len(memProofs))
+	}
+	wit.ProofData = append(append([]byte{}, threadWitness...), memProofs...)
+	if preimage := fields[3]; len(preimage) != 0 {
+		if len(preimage) < sszOffsetSize || binary.LittleEndian.Uint32(preimage[:sszOffsetSize]) != sszOffsetSize {
+			return fmt.Errorf("%w: pre-image list must have a single element", ErrInvalidSSZ)
+		}
+		preimage = preimage[sszOffsetSize:]
+		if len(preimage) < preimageFixedPart || binary.LittleEndian.Uint32(preimage[36:40]) != preimageFixedPart {
+			return fmt.Errorf("%w: invalid pre-image access", ErrInvalidSSZ)
+		}
+		if len(preimage)-preimageFixedPart > MaxPreimageValueSize {
+			return fmt.Errorf("%w: pre-image value exceeds limit of %d", ErrInvalidSSZ, MaxPreimageValueSize)
+		}
+		copy(wit.PreimageKey[:], preimage[:32])
+		wit.PreimageOffset = binary.LittleEndian.Uint32(preimage[32:36])
+		wit.PreimageValue = append([]byte{}, preimage[preimageFixedPart:]...)
+		if !wit.HasPreimage() {
+			return fmt.Errorf("%w: pre-image access with zero key", ErrInvalidSSZ)
+		}
+	}
+	p.Step = binary.LittleEndian.Uint64(data[:8])
+	p.Witness = wit
+	copy(p.PostHash[:], data[12:44])
+	return nil
+}
+
+// EncodeStateWitnessSSZ encodes a state witness and its hash as a StateWitness SSZ container.
+func EncodeStateWitnessSSZ(witness []byte, hash common.Hash) ([]byte, error) {
+	if len(witness) > MaxStateWitnessSize {
+		return nil, fmt.Errorf("state witness of %d bytes exceeds limit of %d", len(witness), MaxStateWitnessSize)
+	}
+	return encodeStateWitnessSSZ(witness, hash), nil
+}
+
+func encodeStateWitnessSSZ(witness []byte, hash common.Hash) []byte {
+	out := make([]byte, 0, stateWitnessFixedPart+len(witness))
+	out = append(out, hash[:]...)
+	out = binary.LittleEndian.AppendUint32(out, stateWitnessFixedPart)
+	return append(out, witness...)
+}
+
+// DecodeStateWitnessSSZ decodes a StateWitness SSZ container.
+func DecodeStateWitnessSSZ(data []byte) (witness []byte, hash common.Hash, err error) {
+	if len(data) < stateWitnessFixedPart || binary.LittleEndian.Uint32(data[32:36]) != stateWitnessFixedPart {
+		return nil, common.Hash{}, fmt.Errorf("%w: invalid state witness", ErrInvalidSSZ)
+	}
+	if len(data)-stateWitnessFixedPart > MaxStateWitnessSize {
+		return nil, common.Hash{}, fmt.Errorf("%w: state witness exceeds limit of %d", ErrInvalidSSZ, MaxStateWitnessSize)
+	}
+	return append([]byte{}, data[stateWitnessFixedPart:]...), common.Hash(data[:32]), nil
+}
+
+// splitSSZOffsets returns the variable-size fields of a container, given the offsets of its fixed part.
+// Like SSZ requires, the first offset must point to the end of the fixed part, and offsets must be ascending.
+func splitSSZOffsets(data []byte, fixedPart uint32, offsets []uint32) ([][]byte, error) {
+	if offsets[0] != fixedPart {
+		return nil, fmt.Errorf("%w: first offset %d does not match fixed part size %d", ErrInvalidSSZ, offsets[0], fixedPart)
+	}
+	fields := make([][]byte, len(offsets))
+	for i, start := range offsets {
+		end := uint32(len(data))
+		if i+1 < len(offsets) {
+			end = offsets[i+1]
+		}
+		if start > end || end > uint32(len(data)) {
+			return nil, fmt.Errorf("%w: offset %d is out of bounds", ErrInvalidSSZ, start)
+		}
+		fields[i] = data[start:end]
+	}
+	return fields, nil
+}
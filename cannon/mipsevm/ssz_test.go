@@ -0,0 +1,126 @@
+package mipsevm_test
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+)
+
+func randomBytes(rng *rand.Rand, n int) []byte {
+	out := make([]byte, n)
+	rng.Read(out)
+	return out
+}
+
+func TestStepProofSSZ(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	cases := map[string]*mipsevm.StepWitness{
+		"single-threaded": {
+			State:     randomBytes(rng, singlethreaded.STATE_WITNESS_SIZE),
+			StateHash: common.Hash(randomBytes(rng, 32)),
+			ProofData: randomBytes(rng, 2*memory.MEM_PROOF_SIZE),
+		},
+		"multi-threaded with pre-image": {
+			State:          randomBytes(rng, multithreaded.STATE_WITNESS_SIZE),
+			StateHash:      common.Hash(randomBytes(rng, 32)),
+			ProofData:      randomBytes(rng, multithreaded.THREAD_WITNESS_SIZE+3*memory.MEM_PROOF_SIZE),
+			PreimageKey:    [32]byte(randomBytes(rng, 32)),
+			PreimageValue:  randomBytes(rng, 8+100),
+			PreimageOffset: 12,
+		},
+	}
+	for name, wit := range cases {
+		t.Run(name, func(t *testing.T) {
+			proof := &mipsevm.StepProof{Step: 42, Witness: wit, PostHash: common.Hash(randomBytes(rng, 32))}
+			data, err := proof.MarshalSSZ()
+			require.NoError(t, err)
+
+			require.Equal(t, uint64(42), binary.LittleEndian.Uint64(data[:8]))
+			require.Equal(t, proof.PostHash[:], data[12:44])
+			preOffset := binary.LittleEndian.Uint32(data[8:12])
+			threadWitnessOffset := binary.LittleEndian.Uint32(data[44:48])
+			memProofsOffset := binary.LittleEndian.Uint32(data[48:52])
+			require.Equal(t, uint32(56), preOffset, "variable-size fields start after the fixed part")
+			require.Equal(t, wit.StateHash[:], data[preOffset:preOffset+32])
+			require.Equal(t, wit.State, data[preOffset+36:threadWitnessOffset])
+			require.Equal(t, wit.ProofData, data[threadWitnessOffset:threadWitnessOffset+uint32(len(wit.ProofData))])
+			require.Zero(t, (binary.LittleEndian.Uint32(data[52:56])-memProofsOffset)%memory.MEM_PROOF_SIZE)
+
+			var decoded mipsevm.StepProof
+			require.NoError(t, decoded.UnmarshalSSZ(data))
+			require.Equal(t, proof.Step, decoded.Step)
+			require.Equal(t, proof.PostHash, decoded.PostHash)
+			require.Equal(t, wit.State, decoded.Witness.State)
+			require.Equal(t, wit.StateHash, decoded.Witness.StateHash)
+			require.Equal(t, wit.ProofData, decoded.Witness.ProofData)
+			require.Equal(t, wit.PreimageKey, decoded.Witness.PreimageKey)
+			require.Equal(t, wit.PreimageOffset, decoded.Witness.PreimageOffset)
+			require.Equal(t, wit.HasPreimage(), decoded.Witness.HasPreimage())
+			if wit.HasPreimage() {
+				require.Equal(t, wit.PreimageValue, decoded.Witness.PreimageValue)
+			}
+
+			reencoded, err := decoded.MarshalSSZ()
+			require.NoError(t, err)
+			require.Equal(t, data, reencoded)
+		})
+	}
+}
+
+func TestStepProofSSZInvalid(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	proof := &mipsevm.StepProof{Step: 1, Witness: &mipsevm.StepWitness{
+		State:         randomBytes(rng, multithreaded.STATE_WITNESS_SIZE),
+		ProofData:     randomBytes(rng, multithreaded.THREAD_WITNESS_SIZE+3*memory.MEM_PROOF_SIZE),
+		PreimageKey:   [32]byte{1},
+		PreimageValue: randomBytes(rng, 16),
+	}}
+	data, err := proof.MarshalSSZ()
+	require.NoError(t, err)
+
+	var decoded mipsevm.StepProof
+	require.ErrorIs(t, decoded.UnmarshalSSZ(data[:55]), mipsevm.ErrInvalidSSZ, "truncated fixed part")
+
+	bad := append([]byte{}, data...)
+	binary.LittleEndian.PutUint32(bad[8:12], 60)
+	require.ErrorIs(t, decoded.UnmarshalSSZ(bad), mipsevm.ErrInvalidSSZ, "first offset after the fixed part")
+
+	bad = append([]byte{}, data...)
+	binary.LittleEndian.PutUint32(bad[48:52], binary.LittleEndian.Uint32(bad[44:48])-1)
+	require.ErrorIs(t, decoded.UnmarshalSSZ(bad), mipsevm.ErrInvalidSSZ, "descending offsets")
+
+	bad = append([]byte{}, data...)
+	binary.LittleEndian.PutUint32(bad[52:56], binary.LittleEndian.Uint32(bad[52:56])-1)
+	require.ErrorIs(t, decoded.UnmarshalSSZ(bad), mipsevm.ErrInvalidSSZ, "partial memory proof")
+
+	proof.Witness.ProofData = randomBytes(rng, 4*memory.MEM_PROOF_SIZE)
+	_, err = proof.MarshalSSZ()
+	require.Error(t, err, "too many memory proofs")
+}
+
+func TestStateWitnessSSZ(t *testing.T) {
+	witness := []byte{1, 2, 3, 4, 5}
+	hash := common.Hash{0xaa}
+	data, err := mipsevm.EncodeStateWitnessSSZ(witness, hash)
+	require.NoError(t, err)
+	require.Len(t, data, 32+4+len(witness))
+	require.Equal(t, uint32(36), binary.LittleEndian.Uint32(data[32:36]))
+
+	decodedWitness, decodedHash, err := mipsevm.DecodeStateWitnessSSZ(data)
+	require.NoError(t, err)
+	require.Equal(t, witness, decodedWitness)
+	require.Equal(t, hash, decodedHash)
+
+	_, _, err = mipsevm.DecodeStateWitnessSSZ(data[:35])
+	require.ErrorIs(t, err, mipsevm.ErrInvalidSSZ)
+	_, err = mipsevm.EncodeStateWitnessSSZ(make([]byte, mipsevm.MaxStateWitnessSize+1), hash)
+	require.Error(t, err)
+}
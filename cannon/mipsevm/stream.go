@@ -0,0 +1,84 @@
+package mipsevm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrStepInPast = errors.New("requested step is before the current VM step")
+	ErrVMExited   = errors.New("VM exited before the requested step")
+)
+
+// StepProof is the witness of a single step, as produced by a WitnessStream.
+type StepProof struct {
+	Step     uint64
+	Witness  *StepWitness
+	PostHash common.Hash
+}
+
+// WitnessStream keeps a VM warm and produces step proofs on demand,
+// so that consumers don't have to start a new VM from a snapshot for every proof.
+// Steps must be requested in ascending order: the VM only executes forwards.
+type WitnessStream struct {
+	vm FPVM
+}
+
+func NewWitnessStream(vm FPVM) *WitnessStream {
+	return &WitnessStream{vm: vm}
+}
+
+// ProveStep executes the VM up to the requested step, and returns the proof for executing that step.
+// The VM state is afterward positioned at step+1.
+func (s *WitnessStream) ProveStep(ctx context.Context, step uint64) (*StepProof, error) {
+	state := s.vm.GetState()
+	if current := state.GetStep(); step < current {
+		return nil, fmt.Errorf("%w: requested %d, at %d", ErrStepInPast, step, current)
+	}
+	for state.GetStep() < step {
+		if state.GetExited() {
+			return nil, fmt.Errorf("%w: requested %d, exited at %d", ErrVMExited, step, state.GetStep())
+		}
+		if state.GetStep()%100 == 0 { // don't check the context too often
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := s.vm.Step(false); err != nil {
+			return nil, fmt.Errorf("failed at step %d: %w", state.GetStep(), err)
+		}
+	}
+	witness, err := s.vm.Step(true)
+	if err != nil {
+		return nil, fmt.Errorf("failed at proof-gen step %d: %w", step, err)
+	}
+	_, postHash := state.EncodeWitness()
+	return &StepProof{Step: step, Witness: witness, PostHash: postHash}, nil
+}
+
+// Stream proves every step received from steps and sends the proofs to out, until steps is closed.
+// out is not closed, so the caller can tell a closed stream from an error.
+func (s *WitnessStream) Stream(ctx context.Context, steps <-chan uint64, out chan<- *StepProof) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case step, ok := <-steps:
+			if !ok {
+				return nil
+			}
+			proof, err := s.ProveStep(ctx, step)
+			if err != nil {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case out <- proof:
+			}
+		}
+	}
+}
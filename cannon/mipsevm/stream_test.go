@@ -0,0 +1,40 @@
+package mipsevm_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+)
+
+func TestWitnessStream(t *testing.T) {
+	// an empty memory decodes as a sequence of nops
+	state := singlethreaded.CreateEmptyState()
+	vm := singlethreaded.NewInstrumentedState(state, nil, nil, nil, nil)
+	stream := mipsevm.NewWitnessStream(vm)
+
+	steps := make(chan uint64, 2)
+	out := make(chan *mipsevm.StepProof, 2)
+	steps <- 3
+	steps <- 5
+	close(steps)
+	require.NoError(t, stream.Stream(context.Background(), steps, out))
+
+	for _, step := range []uint64{3, 5} {
+		proof := <-out
+		require.Equal(t, step, proof.Step)
+		require.NotEmpty(t, proof.Witness.ProofData)
+		require.NotEqual(t, proof.Witness.StateHash, proof.PostHash)
+	}
+	require.Equal(t, uint64(6), state.GetStep())
+
+	_, err := stream.ProveStep(context.Background(), 4)
+	require.ErrorIs(t, err, mipsevm.ErrStepInPast)
+
+	state.Exited = true
+	_, err = stream.ProveStep(context.Background(), 10)
+	require.ErrorIs(t, err, mipsevm.ErrVMExited)
+}
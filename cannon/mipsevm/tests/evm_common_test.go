@@ -186,6 +186,9 @@ func TestEVM_MMap(t *testing.T) {
 		{name: "Increment heap to limit", heap: program.HEAP_END - memory.PageSize, address: 0, size: 1, shouldFail: false, expectedHeap: program.HEAP_END},
 		{name: "Increment heap within limit", heap: program.HEAP_END - 2*memory.PageSize, address: 0, size: 1, shouldFail: false, expectedHeap: program.HEAP_END - memory.PageSize},
 		{name: "Request specific address", heap: program.HEAP_START, address: 0x50_00_00_00, size: 0, shouldFail: false, expectedHeap: program.HEAP_START},
+		{name: "Request specific address below stack", heap: program.HEAP_START, address: program.STACK_LIMIT - memory.PageSize, size: 1, shouldFail: false, expectedHeap: program.HEAP_START},
+		{name: "Request specific address overlapping stack", heap: program.HEAP_START, address: program.STACK_LIMIT - memory.PageSize, size: memory.PageSize + 1, shouldFail: true},
+		{name: "Request specific address wrapping around", heap: program.HEAP_START, address: 0xFF_FF_F0_00, size: 2 * memory.PageSize, shouldFail: true},
 	}
 
 	for _, v := range versions {
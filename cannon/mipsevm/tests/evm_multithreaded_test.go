@@ -583,15 +583,284 @@ func TestEVM_SysGetPID(t *testing.T) {
 	testutil.ValidateEVM(t, stepWitness, step, goVm, multithreaded.GetStateHashFn(), contracts, tracer)
 }
 
+func TestEVM_SysGetRandom(t *testing.T) {
+	var tracer *tracing.Hooks
+
+	cases := []struct {
+		name          string
+		bufAddr       uint32
+		count         uint32
+		expectedCount uint32
+	}{
+		{name: "aligned full word", bufAddr: 0x1000, count: 16, expectedCount: 4},
+		{name: "aligned short", bufAddr: 0x1000, count: 3, expectedCount: 3},
+		{name: "unaligned", bufAddr: 0x1001, count: 16, expectedCount: 3},
+		{name: "unaligned short", bufAddr: 0x1002, count: 1, expectedCount: 1},
+		{name: "zero count", bufAddr: 0x1000, count: 0, expectedCount: 0},
+	}
+	for i, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			goVm, state, contracts := setup(t, 3300+i)
+
+			state.Memory.SetMemory(state.GetPC(), syscallInsn)
+			state.Memory.SetMemory(0x1000, 0xAABBCCDD)
+			state.GetRegistersRef()[2] = exec.SysGetRandom // Set syscall number
+			state.GetRegistersRef()[4] = c.bufAddr         // a0
+			state.GetRegistersRef()[5] = c.count           // a1
+			step := state.Step
+
+			// the random word is derived from the post-increment step count
+			random := uint32(splitmix64(state.Step + 1))
+			alignment := c.bufAddr & 3
+			mask := uint32(0)
+			for b := alignment; b < alignment+c.expectedCount; b++ {
+				mask |= 0xFF << (8 * (3 - b))
+			}
+			expected := mttestutil.NewExpectedMTState(state)
+			expected.ExpectStep()
+			expected.ActiveThread().Registers[2] = c.expectedCount
+			expected.ActiveThread().Registers[7] = 0
+			expected.ExpectMemoryWrite(0x1000, (0xAABBCCDD&^mask)|(random&mask))
+
+			var err error
+			var stepWitness *mipsevm.StepWitness
+			stepWitness, err = goVm.Step(true)
+			require.NoError(t, err)
+
+			// Validate post-state
+			expected.Validate(t, state)
+			testutil.ValidateEVM(t, stepWitness, step, goVm, multithreaded.GetStateHashFn(), contracts, tracer)
+		})
+	}
+}
+
+func TestEVM_SysFstat64(t *testing.T) {
+	var tracer *tracing.Hooks
+
+	cases := []struct {
+		name    string
+		fd      uint32
+		statBuf uint32
+		mode    uint32
+	}{
+		{name: "stdout", fd: exec.FdStdout, statBuf: 0x1000, mode: 0o020666},
+		{name: "preimage read", fd: exec.FdPreimageRead, statBuf: 0x1000, mode: 0o010600},
+		{name: "epoll", fd: exec.FdEpoll, statBuf: 0x1000, mode: 0o600},
+		{name: "unaligned buffer", fd: exec.FdHintWrite, statBuf: 0x1002, mode: 0o010600},
+	}
+	for i, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			goVm, state, contracts := setup(t, 3400+i)
+
+			state.Memory.SetMemory(state.GetPC(), syscallInsn)
+			state.GetRegistersRef()[2] = exec.SysFstat64 // Set syscall number
+			state.GetRegistersRef()[4] = c.fd            // a0
+			state.GetRegistersRef()[5] = c.statBuf       // a1
+			step := state.Step
+
+			expected := mttestutil.NewExpectedMTState(state)
+			expected.ExpectStep()
+			expected.ActiveThread().Registers[2] = 0
+			expected.ActiveThread().Registers[7] = 0
+			expected.ExpectMemoryWrite((c.statBuf+exec.Stat64ModeOffset)&^3, c.mode)
+
+			var err error
+			var stepWitness *mipsevm.StepWitness
+			stepWitness, err = goVm.Step(true)
+			require.NoError(t, err)
+
+			// Validate post-state
+			expected.Validate(t, state)
+			testutil.ValidateEVM(t, stepWitness, step, goVm, multithreaded.GetStateHashFn(), contracts, tracer)
+		})
+	}
+}
+
+func TestEVM_SysFstat64_BadFd(t *testing.T) {
+	var tracer *tracing.Hooks
+	goVm, state, contracts := setup(t, 3500)
+
+	state.Memory.SetMemory(state.GetPC(), syscallInsn)
+	state.GetRegistersRef()[2] = exec.SysFstat64 // Set syscall number
+	state.GetRegistersRef()[4] = 100             // a0
+	state.GetRegistersRef()[5] = 0x1000          // a1
+	step := state.Step
+
+	expected := mttestutil.NewExpectedMTState(state)
+	expected.ExpectStep()
+	expected.ActiveThread().Registers[2] = exec.SysErrorSignal
+	expected.ActiveThread().Registers[7] = exec.MipsEBADF
+
+	var err error
+	var stepWitness *mipsevm.StepWitness
+	stepWitness, err = goVm.Step(true)
+	require.NoError(t, err)
+
+	// Validate post-state
+	expected.Validate(t, state)
+	testutil.ValidateEVM(t, stepWitness, step, goVm, multithreaded.GetStateHashFn(), contracts, tracer)
+}
+
+func TestEVM_SysTimers(t *testing.T) {
+	var tracer *tracing.Hooks
+
+	cases := []struct {
+		name    string
+		syscall uint32
+		args    [4]uint32
+		v0      uint32
+		v1      uint32
+	}{
+		{name: "setitimer prof", syscall: exec.SysSetITimer, args: [4]uint32{exec.ITimerProf, 0x2000, 0, 0}},
+		{name: "setitimer old value", syscall: exec.SysSetITimer, args: [4]uint32{exec.ITimerProf, 0x2000, 0x3000, 0}, v0: exec.SysErrorSignal, v1: exec.MipsEINVAL},
+		{name: "timer_settime", syscall: exec.SysTimerSetTime, args: [4]uint32{exec.TimerID, 0, 0x2000, 0}},
+		{name: "timer_settime unknown timer", syscall: exec.SysTimerSetTime, args: [4]uint32{exec.TimerID + 1, 0, 0x2000, 0}, v0: exec.SysErrorSignal, v1: exec.MipsEINVAL},
+		{name: "timer_delete", syscall: exec.SysTimerDelete, args: [4]uint32{exec.TimerID}},
+		{name: "timer_delete unknown timer", syscall: exec.SysTimerDelete, args: [4]uint32{exec.TimerID + 1}, v0: exec.SysErrorSignal, v1: exec.MipsEINVAL},
+	}
+	for i, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			goVm, state, contracts := setup(t, 3600+i)
+
+			state.Memory.SetMemory(state.GetPC(), syscallInsn)
+			state.GetRegistersRef()[2] = c.syscall // Set syscall number
+			copy(state.GetRegistersRef()[4:8], c.args[:])
+			step := state.Step
+
+			expected := mttestutil.NewExpectedMTState(state)
+			expected.ExpectStep()
+			expected.ActiveThread().Registers[2] = c.v0
+			expected.ActiveThread().Registers[7] = c.v1
+
+			var err error
+			var stepWitness *mipsevm.StepWitness
+			stepWitness, err = goVm.Step(true)
+			require.NoError(t, err)
+
+			// Validate post-state
+			expected.Validate(t, state)
+			testutil.ValidateEVM(t, stepWitness, step, goVm, multithreaded.GetStateHashFn(), contracts, tracer)
+		})
+	}
+}
+
+func TestEVM_SysTimerCreate(t *testing.T) {
+	var tracer *tracing.Hooks
+	goVm, state, contracts := setup(t, 3700)
+
+	state.Memory.SetMemory(state.GetPC(), syscallInsn)
+	state.Memory.SetMemory(0x1000, 0xAABBCCDD)
+	state.GetRegistersRef()[2] = exec.SysTimerCreate                // Set syscall number
+	state.GetRegistersRef()[4] = exec.ClockGettimeThreadCPUTimeFlag // a0
+	state.GetRegistersRef()[5] = 0x2000                             // a1
+	state.GetRegistersRef()[6] = 0x1000                             // a2
+	step := state.Step
+
+	expected := mttestutil.NewExpectedMTState(state)
+	expected.ExpectStep()
+	expected.ActiveThread().Registers[2] = 0
+	expected.ActiveThread().Registers[7] = 0
+	expected.ExpectMemoryWrite(0x1000, exec.TimerID)
+
+	var err error
+	var stepWitness *mipsevm.StepWitness
+	stepWitness, err = goVm.Step(true)
+	require.NoError(t, err)
+
+	// Validate post-state
+	expected.Validate(t, state)
+	testutil.ValidateEVM(t, stepWitness, step, goVm, multithreaded.GetStateHashFn(), contracts, tracer)
+}
+
+// splitmix64 is a reference implementation of https://prng.di.unimi.it/splitmix64.c
+func splitmix64(seed uint64) uint64 {
+	z := seed + 0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func TestEVM_SysEpoll(t *testing.T) {
+	var tracer *tracing.Hooks
+
+	cases := []struct {
+		name       string
+		syscallNum uint32
+		a0         uint32
+		expectedV0 uint32
+		expectedV1 uint32
+	}{
+		{name: "epoll_create1", syscallNum: exec.SysEpollCreate1, a0: 0, expectedV0: exec.FdEpoll, expectedV1: 0},
+		{name: "eventfd2", syscallNum: exec.SysEventFd2, a0: 0, expectedV0: exec.FdEventFd, expectedV1: 0},
+		{name: "epoll_ctl", syscallNum: exec.SysEpollCtl, a0: exec.FdEpoll, expectedV0: 0, expectedV1: 0},
+		{name: "epoll_ctl bad fd", syscallNum: exec.SysEpollCtl, a0: exec.FdEventFd, expectedV0: exec.SysErrorSignal, expectedV1: exec.MipsEBADF},
+		{name: "epoll_pwait", syscallNum: exec.SysEpollPwait, a0: exec.FdEpoll, expectedV0: 0, expectedV1: 0},
+		{name: "epoll_pwait bad fd", syscallNum: exec.SysEpollPwait, a0: exec.FdStdin, expectedV0: exec.SysErrorSignal, expectedV1: exec.MipsEBADF},
+		{name: "eventfd read", syscallNum: exec.SysRead, a0: exec.FdEventFd, expectedV0: exec.SysErrorSignal, expectedV1: exec.MipsEAGAIN},
+		{name: "eventfd write", syscallNum: exec.SysWrite, a0: exec.FdEventFd, expectedV0: 8, expectedV1: 0},
+	}
+
+	for i, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			goVm, state, contracts := setup(t, 2000+i)
+
+			state.Memory.SetMemory(state.GetPC(), syscallInsn)
+			state.GetRegistersRef()[2] = c.syscallNum // Set syscall number
+			state.GetRegistersRef()[4] = c.a0
+			state.GetRegistersRef()[5] = 0x1000
+			state.GetRegistersRef()[6] = 8
+			step := state.Step
+
+			// Set up post-state expectations
+			expected := mttestutil.NewExpectedMTState(state)
+			expected.ExpectStep()
+			expected.ActiveThread().Registers[2] = c.expectedV0
+			expected.ActiveThread().Registers[7] = c.expectedV1
+
+			// State transition
+			var err error
+			var stepWitness *mipsevm.StepWitness
+			stepWitness, err = goVm.Step(true)
+			require.NoError(t, err)
+
+			// Validate post-state
+			expected.Validate(t, state)
+			testutil.ValidateEVM(t, stepWitness, step, goVm, multithreaded.GetStateHashFn(), contracts, tracer)
+		})
+	}
+}
+
 func TestEVM_SysClockGettimeMonotonic(t *testing.T) {
-	testEVM_SysClockGettime(t, exec.ClockGettimeMonotonicFlag)
+	clockIDs := []uint32{
+		exec.ClockGettimeMonotonicFlag,
+		exec.ClockGettimeProcessCPUTimeFlag,
+		exec.ClockGettimeThreadCPUTimeFlag,
+		exec.ClockGettimeMonotonicRawFlag,
+		exec.ClockGettimeMonotonicCoarseFlag,
+		exec.ClockGettimeBoottimeFlag,
+		exec.ClockGettimeBoottimeAlarmFlag,
+	}
+	for _, clkid := range clockIDs {
+		t.Run(fmt.Sprintf("clock %d", clkid), func(t *testing.T) {
+			testEVM_SysClockGettime(t, clkid, true)
+		})
+	}
 }
 
 func TestEVM_SysClockGettimeRealtime(t *testing.T) {
-	testEVM_SysClockGettime(t, exec.ClockGettimeRealtimeFlag)
+	clockIDs := []uint32{
+		exec.ClockGettimeRealtimeFlag,
+		exec.ClockGettimeRealtimeCoarseFlag,
+		exec.ClockGettimeRealtimeAlarmFlag,
+	}
+	for _, clkid := range clockIDs {
+		t.Run(fmt.Sprintf("clock %d", clkid), func(t *testing.T) {
+			testEVM_SysClockGettime(t, clkid, false)
+		})
+	}
 }
 
-func testEVM_SysClockGettime(t *testing.T, clkid uint32) {
+func testEVM_SysClockGettime(t *testing.T, clkid uint32, monotonic bool) {
 	var tracer *tracing.Hooks
 
 	cases := []struct {
@@ -617,7 +886,7 @@ func testEVM_SysClockGettime(t *testing.T, clkid uint32) {
 			expected.ActiveThread().Registers[7] = 0
 			next := state.Step + 1
 			var secs, nsecs uint32
-			if clkid == exec.ClockGettimeMonotonicFlag {
+			if monotonic {
 				secs = uint32(next / exec.HZ)
 				nsecs = uint32((next % exec.HZ) * (1_000_000_000 / exec.HZ))
 			}
@@ -672,16 +941,11 @@ var NoopSyscalls = map[string]uint32{
 	"SysPrlimit64":     4338,
 	"SysClose":         4006,
 	"SysPread64":       4200,
-	"SysFstat64":       4215,
 	"SysOpenAt":        4288,
 	"SysReadlink":      4085,
 	"SysReadlinkAt":    4298,
 	"SysIoctl":         4054,
-	"SysEpollCreate1":  4326,
 	"SysPipe2":         4328,
-	"SysEpollCtl":      4249,
-	"SysEpollPwait":    4313,
-	"SysGetRandom":     4353,
 	"SysUname":         4122,
 	"SysStat64":        4213,
 	"SysGetuid":        4024,
@@ -690,10 +954,6 @@ var NoopSyscalls = map[string]uint32{
 	"SysMinCore":       4217,
 	"SysTgkill":        4266,
 	"SysMunmap":        4091,
-	"SysSetITimer":     4104,
-	"SysTimerCreate":   4257,
-	"SysTimerSetTime":  4258,
-	"SysTimerDelete":   4261,
 }
 
 func TestEVM_NoopSyscall(t *testing.T) {
@@ -731,7 +991,7 @@ func TestEVM_UnsupportedSyscall(t *testing.T) {
 	var tracer *tracing.Hooks
 
 	var NoopSyscallNums = maps.Values(NoopSyscalls)
-	var SupportedSyscalls = []uint32{exec.SysMmap, exec.SysBrk, exec.SysClone, exec.SysExitGroup, exec.SysRead, exec.SysWrite, exec.SysFcntl, exec.SysExit, exec.SysSchedYield, exec.SysGetTID, exec.SysFutex, exec.SysOpen, exec.SysNanosleep, exec.SysClockGetTime, exec.SysGetpid}
+	var SupportedSyscalls = []uint32{exec.SysMmap, exec.SysBrk, exec.SysClone, exec.SysExitGroup, exec.SysRead, exec.SysWrite, exec.SysFcntl, exec.SysExit, exec.SysSchedYield, exec.SysGetTID, exec.SysFutex, exec.SysOpen, exec.SysNanosleep, exec.SysClockGetTime, exec.SysGetpid, exec.SysGetRandom, exec.SysEpollCreate1, exec.SysEpollCtl, exec.SysEpollPwait, exec.SysEventFd2, exec.SysFstat64, exec.SysSetITimer, exec.SysTimerCreate, exec.SysTimerSetTime, exec.SysTimerDelete}
 	unsupportedSyscalls := make([]uint32, 0, 400)
 	for i := 4000; i < 4400; i++ {
 		candidate := uint32(i)
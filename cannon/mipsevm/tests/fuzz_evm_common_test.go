@@ -20,12 +20,17 @@ const syscallInsn = uint32(0x00_00_00_0c)
 
 func FuzzStateSyscallBrk(f *testing.F) {
 	versions := GetMipsVersionTestCases(f)
-	f.Fuzz(func(t *testing.T, seed int64) {
+	f.Add(uint32(0), int64(1))
+	f.Add(uint32(program.PROGRAM_BREAK), int64(2))
+	f.Add(uint32(program.PROGRAM_BREAK-memory.PageSize), int64(3))
+	f.Add(uint32(program.PROGRAM_BREAK+memory.PageSize), int64(4))
+	f.Fuzz(func(t *testing.T, brk uint32, seed int64) {
 		for _, v := range versions {
 			t.Run(v.Name, func(t *testing.T) {
 				goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger(), testutil.WithRandomization(seed))
 				state := goVm.GetState()
 				state.GetRegistersRef()[2] = exec.SysBrk
+				state.GetRegistersRef()[4] = brk
 				state.GetMemory().SetMemory(state.GetPC(), syscallInsn)
 				step := state.GetStep()
 
@@ -33,8 +38,13 @@ func FuzzStateSyscallBrk(f *testing.F) {
 				expected.Step += 1
 				expected.PC = state.GetCpu().NextPC
 				expected.NextPC = state.GetCpu().NextPC + 4
-				expected.Registers[2] = program.PROGRAM_BREAK // Return fixed BRK value
-				expected.Registers[7] = 0                     // No error
+				if brk == 0 || brk == program.PROGRAM_BREAK {
+					expected.Registers[2] = program.PROGRAM_BREAK // Return fixed BRK value
+					expected.Registers[7] = 0                     // No error
+				} else {
+					expected.Registers[2] = exec.SysErrorSignal // The break can't be moved
+					expected.Registers[7] = exec.MipsENOMEM
+				}
 
 				stepWitness, err := goVm.Step(true)
 				require.NoError(t, err)
@@ -53,6 +63,9 @@ func FuzzStateSyscallMmap(f *testing.F) {
 	f.Add(uint32(0), uint32(1<<31), uint32(program.HEAP_START), int64(2))
 	// Check edge case - just within bounds
 	f.Add(uint32(0), uint32(0x1000), uint32(program.HEAP_END-4096), int64(3))
+	// Fixed mappings next to the stack region
+	f.Add(uint32(program.STACK_LIMIT-4096), uint32(0x1000), uint32(program.HEAP_START), int64(4))
+	f.Add(uint32(program.STACK_LIMIT-4096), uint32(0x1001), uint32(program.HEAP_START), int64(5))
 
 	versions := GetMipsVersionTestCases(f)
 	f.Fuzz(func(t *testing.T, addr uint32, siz uint32, heap uint32, seed int64) {
@@ -87,8 +100,18 @@ func FuzzStateSyscallMmap(f *testing.F) {
 						expected.Registers[7] = 0 // no error
 					}
 				} else {
-					expected.Registers[2] = addr
-					expected.Registers[7] = 0 // no error
+					sizAlign := siz
+					if sizAlign&memory.PageAddrMask != 0 { // adjust size to align with page size
+						sizAlign = siz + memory.PageSize - (siz & memory.PageAddrMask)
+					}
+					end := addr + sizAlign
+					if end < addr || end > program.STACK_LIMIT || sizAlign < siz {
+						expected.Registers[2] = exec.SysErrorSignal
+						expected.Registers[7] = exec.MipsEINVAL
+					} else {
+						expected.Registers[2] = addr
+						expected.Registers[7] = 0 // no error
+					}
 				}
 
 				stepWitness, err := goVm.Step(true)
@@ -149,19 +172,34 @@ func FuzzStateSyscallFcntl(f *testing.F) {
 				expected.Step += 1
 				expected.PC = state.GetCpu().NextPC
 				expected.NextPC = state.GetCpu().NextPC + 4
-				if cmd == 3 {
-					switch fd {
-					case exec.FdStdin, exec.FdPreimageRead, exec.FdHintRead:
-						expected.Registers[2] = 0
-						expected.Registers[7] = 0
-					case exec.FdStdout, exec.FdStderr, exec.FdPreimageWrite, exec.FdHintWrite:
-						expected.Registers[2] = 1
-						expected.Registers[7] = 0
-					default:
+				knownFd := true
+				accessMode := uint32(0)
+				switch fd {
+				case exec.FdStdin, exec.FdPreimageRead, exec.FdHintRead:
+					accessMode = 0
+				case exec.FdStdout, exec.FdStderr, exec.FdPreimageWrite, exec.FdHintWrite:
+					accessMode = 1
+				case exec.FdEventFd, exec.FdEpoll:
+					accessMode = 2
+				default:
+					knownFd = false
+				}
+				switch cmd {
+				case exec.FcntlGetFl, exec.FcntlGetFd, exec.FcntlSetFd, exec.FcntlSetFl, exec.FcntlDupFd, exec.FcntlDupFdCloexec:
+					if !knownFd {
 						expected.Registers[2] = 0xFF_FF_FF_FF
 						expected.Registers[7] = exec.MipsEBADF
+					} else if cmd == exec.FcntlGetFl {
+						expected.Registers[2] = accessMode
+						expected.Registers[7] = 0
+					} else if cmd == exec.FcntlDupFd || cmd == exec.FcntlDupFdCloexec {
+						expected.Registers[2] = 0xFF_FF_FF_FF
+						expected.Registers[7] = exec.MipsEMFILE
+					} else {
+						expected.Registers[2] = 0
+						expected.Registers[7] = 0
 					}
-				} else {
+				default:
 					expected.Registers[2] = 0xFF_FF_FF_FF
 					expected.Registers[7] = exec.MipsEINVAL
 				}
@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+var errUnknownPreimage = errors.New("unknown preimage")
+
+// fuzzOracle serves a single keccak256 preimage.
+// Other keys can't be loaded into the onchain oracle, so steps that request them are not compared.
+type fuzzOracle struct {
+	key   [32]byte
+	value []byte
+}
+
+func (o *fuzzOracle) Hint(v []byte) {}
+
+func (o *fuzzOracle) GetPreimage(k [32]byte) []byte {
+	if k != o.key {
+		panic(errUnknownPreimage)
+	}
+	return o.value
+}
+
+// stepOrPanic executes a step, and converts panics of the Go VM into errors.
+func stepOrPanic(goVm mipsevm.FPVM) (wit *mipsevm.StepWitness, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = e
+			} else {
+				err = fmt.Errorf("vm panicked: %v", r)
+			}
+		}
+	}()
+	return goVm.Step(true)
+}
+
+// FuzzStateDifferential executes random instruction sequences and syscalls with both the Go VM and the onchain VM,
+// and checks that every step produces the same post-state. Steps that fail in the Go VM must revert onchain.
+func FuzzStateDifferential(f *testing.F) {
+	f.Add(int64(1), uint8(16))
+	f.Add(int64(2), uint8(64))
+	versions := GetMipsVersionTestCases(f)
+	f.Fuzz(func(t *testing.T, seed int64, length uint8) {
+		for _, v := range versions {
+			t.Run(v.Name, func(t *testing.T) {
+				r := rand.New(rand.NewSource(seed))
+				preimageValue := make([]byte, r.Intn(100))
+				_, _ = r.Read(preimageValue)
+				po := &fuzzOracle{key: preimage.Keccak256Key(crypto.Keccak256Hash(preimageValue)).PreimageKey()}
+				po.value = preimageValue
+				goVm := v.VMFactory(po, io.Discard, io.Discard, testutil.CreateLogger(),
+					testutil.WithRandomization(seed), testutil.WithPreimageKey(po.key), testutil.WithPreimageOffset(uint32(r.Intn(len(preimageValue)+8))))
+				state := goVm.GetState()
+
+				for i := 0; i < int(length%64)+1 && !state.GetExited(); i++ {
+					insn := testutil.RandInstruction(r)
+					if insn == testutil.SyscallInsn {
+						testutil.RandSyscallArgs(r, state)
+					}
+					// the instruction fetch fails for an unaligned PC, after jumping to a random register
+					if state.GetPC()&3 == 0 {
+						state.GetMemory().SetMemory(state.GetPC(), insn)
+					}
+					step := state.GetStep()
+					t.Logf("step %d: pc %08x insn %08x", step, state.GetPC(), insn)
+
+					// the Go VM modifies the state, even if the step fails
+					insnProof := state.GetMemory().MerkleProof(state.GetPC())
+					preState, _ := state.EncodeWitness()
+					pre := &mipsevm.StepWitness{State: preState, ProofData: insnProof[:]}
+					stepWitness, err := stepOrPanic(goVm)
+					if errors.Is(err, errUnknownPreimage) {
+						return
+					}
+					if err != nil {
+						t.Logf("step %d failed: %v", step, err)
+						testutil.AssertEVMRevertsWitness(t, pre, v.Contracts, nil)
+						return
+					}
+					testutil.ValidateEVM(t, stepWitness, step, goVm, v.StateHashFn, v.Contracts, nil)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package testutil
+
+import (
+	"math/rand"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+)
+
+const SyscallInsn = uint32(0x00_00_00_0c)
+
+// fuzzRegs is a small set of registers, so that generated instructions depend on each other.
+// Includes the syscall number and argument registers.
+var fuzzRegs = []uint32{0, 2, 4, 5, 6, 7, 8, 9, 29, 31}
+
+var (
+	specialFuncs  = []uint32{0x00, 0x02, 0x03, 0x04, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x18, 0x19, 0x1a, 0x1b, 0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, 0x2a, 0x2b}
+	special2Funcs = []uint32{0x02, 0x20, 0x21}
+	regimmRts     = []uint32{0x00, 0x01, 0x10, 0x11}
+	immOpcodes    = []uint32{0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f}
+	memOpcodes    = []uint32{0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x28, 0x29, 0x2a, 0x2b, 0x2e, 0x30, 0x38}
+	fuzzSyscalls  = []uint32{
+		exec.SysMmap, exec.SysBrk, exec.SysClone, exec.SysExitGroup, exec.SysRead, exec.SysWrite, exec.SysFcntl,
+		exec.SysExit, exec.SysSchedYield, exec.SysGetTID, exec.SysFutex, exec.SysOpen, exec.SysNanosleep, exec.SysClockGetTime,
+		exec.SysGetpid, exec.SysMunmap,
+	}
+	fuzzFds = []uint32{exec.FdStdin, exec.FdStdout, exec.FdStderr, exec.FdHintRead, exec.FdHintWrite, exec.FdPreimageRead, exec.FdPreimageWrite, 100}
+)
+
+func randReg(r *rand.Rand) uint32 {
+	return fuzzRegs[r.Intn(len(fuzzRegs))]
+}
+
+func pick(r *rand.Rand, options []uint32) uint32 {
+	return options[r.Intn(len(options))]
+}
+
+// RandInstruction returns a random instruction, mostly from the instructions supported by the VM.
+// Some instructions are fully random words, to cover invalid encodings.
+func RandInstruction(r *rand.Rand) uint32 {
+	rs, rt, rd := randReg(r), randReg(r), randReg(r)
+	switch n := r.Intn(16); {
+	case n < 4:
+		return rs<<21 | rt<<16 | rd<<11 | uint32(r.Intn(32))<<6 | pick(r, specialFuncs)
+	case n < 5:
+		return 0x1c<<26 | rs<<21 | rt<<16 | rd<<11 | pick(r, special2Funcs)
+	case n < 6:
+		return 1<<26 | rs<<21 | pick(r, regimmRts)<<16 | uint32(RandEdgeValue(r)&0xffff)
+	case n < 7:
+		return (2+uint32(r.Intn(2)))<<26 | (r.Uint32() & 0x03ff_ffff)
+	case n < 10:
+		return pick(r, immOpcodes)<<26 | rs<<21 | rt<<16 | (RandEdgeValue(r) & 0xffff)
+	case n < 14:
+		return pick(r, memOpcodes)<<26 | rs<<21 | rt<<16 | (RandEdgeValue(r) & 0xffff)
+	case n < 15:
+		return SyscallInsn
+	default:
+		return r.Uint32()
+	}
+}
+
+// RandEdgeValue returns a random word, biased towards values that are likely to hit edge cases:
+// boundaries of integer ranges, and of words and pages.
+func RandEdgeValue(r *rand.Rand) uint32 {
+	switch r.Intn(8) {
+	case 0:
+		return pick(r, []uint32{0, 1, 2, 3, 4, 0x7fff_ffff, 0x8000_0000, 0xffff_ffff, 0xffff_fffc, 0x7fff, 0x8000, 0xffff})
+	case 1:
+		// close to a page boundary, possibly unaligned
+		return uint32(r.Intn(1<<20))<<12 + uint32(r.Intn(16)) - 8
+	case 2:
+		return uint32(r.Intn(64))
+	default:
+		return r.Uint32()
+	}
+}
+
+// RandSyscallArgs sets up a random syscall: the syscall number and its arguments.
+func RandSyscallArgs(r *rand.Rand, state mipsevm.FPVMState) {
+	regs := state.GetRegistersRef()
+	if r.Intn(16) == 0 {
+		regs[2] = 4000 + uint32(r.Intn(400))
+	} else {
+		regs[2] = pick(r, fuzzSyscalls)
+	}
+	switch regs[2] {
+	case exec.SysRead, exec.SysWrite, exec.SysFcntl:
+		regs[4] = pick(r, fuzzFds)
+	case exec.SysMmap:
+		regs[4] = pick(r, []uint32{0, program.HEAP_START, RandEdgeValue(r)})
+	default:
+		regs[4] = RandEdgeValue(r)
+	}
+	regs[5] = RandEdgeValue(r)
+	regs[6] = RandEdgeValue(r)
+	if regs[2] == exec.SysRead || regs[2] == exec.SysWrite {
+		// mostly small lengths, so that reads and writes stay within the word or the preimage
+		regs[6] = uint32(r.Intn(40))
+	}
+	regs[7] = RandEdgeValue(r)
+}
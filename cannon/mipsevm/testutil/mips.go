@@ -188,6 +188,11 @@ func AssertEVMReverts(t *testing.T, state mipsevm.FPVMState, contracts *Contract
 		State:     encodedWitness,
 		ProofData: insnProof[:],
 	}
+	AssertEVMRevertsWitness(t, stepWitness, contracts, tracer)
+}
+
+// AssertEVMRevertsWitness runs a single evm step with the given witness and asserts that the VM panics
+func AssertEVMRevertsWitness(t *testing.T, stepWitness *mipsevm.StepWitness, contracts *ContractMetadata, tracer *tracing.Hooks) {
 	input := EncodeStepInput(t, stepWitness, mipsevm.LocalContext{}, contracts.Artifacts.MIPS)
 	startingGas := uint64(30_000_000)
 
@@ -7,7 +7,9 @@ import (
 	"io"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
 	"github.com/ethereum-optimism/optimism/cannon/serialize"
 	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
@@ -18,6 +20,17 @@ type StateVersion uint8
 const (
 	VersionSingleThreaded StateVersion = iota
 	VersionMultiThreaded
+	// VersionSingleThreadedFPU is a singlethreaded state with FPU emulation enabled.
+	// It's only supported offchain: the onchain VM does not emulate the FPU.
+	VersionSingleThreadedFPU
+	// VersionCustomLayout prefixes the version of a state that has a custom memory layout.
+	// It's followed by the layout, and then by the version and data of the state itself.
+	// Custom layouts are only supported offchain: the onchain VMs use the default layout.
+	VersionCustomLayout
+	// VersionCustomScheduler prefixes the version of a multithreaded state that has a custom thread scheduler.
+	// It's followed by the scheduler config, and then by the custom layout or the version and data of the state itself.
+	// Custom schedulers are only supported offchain: the onchain VM uses the default scheduler.
+	VersionCustomScheduler
 )
 
 var (
@@ -40,6 +53,12 @@ func LoadStateFromFile(path string) (*VersionedState, error) {
 func NewFromState(state mipsevm.FPVMState) (*VersionedState, error) {
 	switch state := state.(type) {
 	case *singlethreaded.State:
+		if state.FPU != nil {
+			return &VersionedState{
+				Version:   VersionSingleThreadedFPU,
+				FPVMState: state,
+			}, nil
+		}
 		return &VersionedState{
 			Version:   VersionSingleThreaded,
 			FPVMState: state,
@@ -61,12 +80,61 @@ type VersionedState struct {
 	mipsevm.FPVMState
 }
 
+// customLayout returns the memory layout of the state, or nil if it uses the default layout.
+func customLayout(state mipsevm.FPVMState) *mipsevm.Layout {
+	var layout *mipsevm.Layout
+	switch state := state.(type) {
+	case *singlethreaded.State:
+		layout = state.Layout
+	case *multithreaded.State:
+		layout = state.Layout
+	}
+	if program.IsDefaultLayout(layout) {
+		return nil
+	}
+	return layout
+}
+
+// customScheduler returns the thread scheduler config of the state, or nil if it uses the default scheduler.
+func customScheduler(state mipsevm.FPVMState) *multithreaded.SchedulerConfig {
+	if state, ok := state.(*multithreaded.State); ok && !multithreaded.IsDefaultSchedulerConfig(state.Scheduler) {
+		return state.Scheduler
+	}
+	return nil
+}
+
 func (s *VersionedState) Serialize(w io.Writer) error {
 	bout := serialize.NewBinaryWriter(w)
+	if sched := customScheduler(s.FPVMState); sched != nil {
+		if err := bout.WriteUInt(VersionCustomScheduler); err != nil {
+			return err
+		}
+		if err := sched.Serialize(w); err != nil {
+			return err
+		}
+	}
+	if layout := customLayout(s.FPVMState); layout != nil {
+		if err := bout.WriteUInt(VersionCustomLayout); err != nil {
+			return err
+		}
+		if err := layout.Serialize(w); err != nil {
+			return err
+		}
+	}
 	if err := bout.WriteUInt(s.Version); err != nil {
 		return err
 	}
-	return s.FPVMState.Serialize(w)
+	if err := s.FPVMState.Serialize(w); err != nil {
+		return err
+	}
+	if s.Version == VersionSingleThreadedFPU {
+		state, ok := s.FPVMState.(*singlethreaded.State)
+		if !ok || state.FPU == nil {
+			return fmt.Errorf("%w: version %d without FPU state", ErrUnknownVersion, s.Version)
+		}
+		return state.FPU.Serialize(w)
+	}
+	return nil
 }
 
 func (s *VersionedState) Deserialize(in io.Reader) error {
@@ -74,17 +142,52 @@ func (s *VersionedState) Deserialize(in io.Reader) error {
 	if err := bin.ReadUInt(&s.Version); err != nil {
 		return err
 	}
+	var sched *multithreaded.SchedulerConfig
+	if s.Version == VersionCustomScheduler {
+		sched = new(multithreaded.SchedulerConfig)
+		if err := sched.Deserialize(in); err != nil {
+			return fmt.Errorf("invalid scheduler config: %w", err)
+		}
+		if err := bin.ReadUInt(&s.Version); err != nil {
+			return err
+		}
+	}
+	var layout *mipsevm.Layout
+	if s.Version == VersionCustomLayout {
+		layout = new(mipsevm.Layout)
+		if err := layout.Deserialize(in); err != nil {
+			return fmt.Errorf("invalid memory layout: %w", err)
+		}
+		if err := bin.ReadUInt(&s.Version); err != nil {
+			return err
+		}
+	}
+
+	if sched != nil && s.Version != VersionMultiThreaded {
+		return fmt.Errorf("%w: scheduler config for version %d", ErrUnknownVersion, s.Version)
+	}
 
 	switch s.Version {
 	case VersionSingleThreaded:
-		state := &singlethreaded.State{}
+		state := &singlethreaded.State{Layout: layout}
+		if err := state.Deserialize(in); err != nil {
+			return err
+		}
+		s.FPVMState = state
+		return nil
+	case VersionSingleThreadedFPU:
+		state := &singlethreaded.State{Layout: layout}
 		if err := state.Deserialize(in); err != nil {
 			return err
 		}
+		state.FPU = exec.NewFPUState()
+		if err := state.FPU.Deserialize(in); err != nil {
+			return err
+		}
 		s.FPVMState = state
 		return nil
 	case VersionMultiThreaded:
-		state := &multithreaded.State{}
+		state := &multithreaded.State{Layout: layout, Scheduler: sched}
 		if err := state.Deserialize(in); err != nil {
 			return err
 		}
@@ -98,7 +201,7 @@ func (s *VersionedState) Deserialize(in io.Reader) error {
 // MarshalJSON marshals the underlying state without adding version prefix.
 // JSON states are always assumed to be single threaded
 func (s *VersionedState) MarshalJSON() ([]byte, error) {
-	if s.Version != VersionSingleThreaded {
+	if s.Version != VersionSingleThreaded && s.Version != VersionSingleThreadedFPU {
 		return nil, fmt.Errorf("%w for type %T", ErrJsonNotSupported, s.FPVMState)
 	}
 	return json.Marshal(s.FPVMState)
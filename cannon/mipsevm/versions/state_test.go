@@ -1,10 +1,14 @@
 package versions
 
 import (
+	"bytes"
 	"path/filepath"
 	"testing"
 
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
 	"github.com/ethereum-optimism/optimism/cannon/serialize"
 	"github.com/stretchr/testify/require"
@@ -18,6 +22,15 @@ func TestNewFromState(t *testing.T) {
 		require.Equal(t, VersionSingleThreaded, actual.Version)
 	})
 
+	t.Run("singlethreadedFPU", func(t *testing.T) {
+		state := singlethreaded.CreateEmptyState()
+		state.FPU = exec.NewFPUState()
+		actual, err := NewFromState(state)
+		require.NoError(t, err)
+		require.IsType(t, &singlethreaded.State{}, actual.FPVMState)
+		require.Equal(t, VersionSingleThreadedFPU, actual.Version)
+	})
+
 	t.Run("multithreaded", func(t *testing.T) {
 		actual, err := NewFromState(multithreaded.CreateEmptyState())
 		require.NoError(t, err)
@@ -47,6 +60,22 @@ func TestLoadStateFromFile(t *testing.T) {
 		require.Equal(t, expected, actual)
 	})
 
+	t.Run("SinglethreadedFPU", func(t *testing.T) {
+		state := singlethreaded.CreateEmptyState()
+		state.FPU = exec.NewFPUState()
+		state.FPU.FPR[3] = 0x3F80_0000
+		state.FPU.FCSR = 0x0080_0001
+		expected, err := NewFromState(state)
+		require.NoError(t, err)
+
+		for _, name := range []string{"state.json", "state.bin.gz"} {
+			path := writeToFile(t, name, expected)
+			actual, err := LoadStateFromFile(path)
+			require.NoError(t, err)
+			require.Equal(t, expected, actual, name)
+		}
+	})
+
 	t.Run("MultithreadedFromBinary", func(t *testing.T) {
 		expected, err := NewFromState(multithreaded.CreateEmptyState())
 		require.NoError(t, err)
@@ -56,6 +85,81 @@ func TestLoadStateFromFile(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, expected, actual)
 	})
+
+	t.Run("CustomLayout", func(t *testing.T) {
+		layout := program.DefaultLayout()
+		layout.HeapStart = 0x1000_0000
+		layout.MaxMemory = 1 << 30
+		st := singlethreaded.CreateEmptyState()
+		st.Layout = layout
+		st.FPU = exec.NewFPUState()
+		mt := multithreaded.CreateEmptyState()
+		mt.Layout = layout
+		for _, state := range []mipsevm.FPVMState{st, mt} {
+			expected, err := NewFromState(state)
+			require.NoError(t, err)
+			for _, name := range []string{"state.json", "state.bin.gz"} {
+				if name == "state.json" && state == mt {
+					continue
+				}
+				path := writeToFile(t, name, expected)
+				actual, err := LoadStateFromFile(path)
+				require.NoError(t, err)
+				require.Equal(t, expected, actual, name)
+				require.Equal(t, layout, actual.GetLayout())
+			}
+		}
+	})
+
+	t.Run("CustomScheduler", func(t *testing.T) {
+		sched := &multithreaded.SchedulerConfig{Quantum: 1000, Policy: multithreaded.SchedPolicyRoundRobin}
+		plain := multithreaded.CreateEmptyState()
+		plain.Scheduler = sched
+		withLayout := multithreaded.CreateEmptyState()
+		withLayout.Scheduler = sched
+		withLayout.Layout = program.DefaultLayout()
+		withLayout.Layout.MaxMemory = 1 << 30
+		for _, state := range []*multithreaded.State{plain, withLayout} {
+			expected, err := NewFromState(state)
+			require.NoError(t, err)
+			path := writeToFile(t, "state.bin.gz", expected)
+			actual, err := LoadStateFromFile(path)
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+			require.Equal(t, *sched, actual.FPVMState.(*multithreaded.State).GetSchedulerConfig())
+		}
+	})
+}
+
+func TestDefaultSchedulerNotSerialized(t *testing.T) {
+	plain := multithreaded.CreateEmptyState()
+	withDefault := multithreaded.CreateEmptyState()
+	sched := multithreaded.DefaultSchedulerConfig()
+	withDefault.Scheduler = &sched
+	var a, b bytes.Buffer
+	require.NoError(t, (&VersionedState{Version: VersionMultiThreaded, FPVMState: plain}).Serialize(&a))
+	require.NoError(t, (&VersionedState{Version: VersionMultiThreaded, FPVMState: withDefault}).Serialize(&b))
+	require.Equal(t, a.Bytes(), b.Bytes(), "states with the default scheduler keep the onchain compatible version")
+}
+
+func TestSchedulerRequiresMultithreaded(t *testing.T) {
+	var buf bytes.Buffer
+	sched := multithreaded.SchedulerConfig{Quantum: 1000, Policy: multithreaded.SchedPolicyRoundRobin}
+	require.NoError(t, serialize.NewBinaryWriter(&buf).WriteUInt(VersionCustomScheduler))
+	require.NoError(t, sched.Serialize(&buf))
+	require.NoError(t, (&VersionedState{Version: VersionSingleThreaded, FPVMState: singlethreaded.CreateEmptyState()}).Serialize(&buf))
+	var res VersionedState
+	require.ErrorIs(t, res.Deserialize(&buf), ErrUnknownVersion)
+}
+
+func TestDefaultLayoutNotSerialized(t *testing.T) {
+	plain := singlethreaded.CreateEmptyState()
+	withDefault := singlethreaded.CreateEmptyState()
+	withDefault.Layout = program.DefaultLayout()
+	var a, b bytes.Buffer
+	require.NoError(t, (&VersionedState{Version: VersionSingleThreaded, FPVMState: plain}).Serialize(&a))
+	require.NoError(t, (&VersionedState{Version: VersionSingleThreaded, FPVMState: withDefault}).Serialize(&b))
+	require.Equal(t, a.Bytes(), b.Bytes(), "states with the default layout keep the onchain compatible version")
 }
 
 func TestMultithreadedDoesNotSupportJSON(t *testing.T) {
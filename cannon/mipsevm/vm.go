@@ -0,0 +1,144 @@
+package mipsevm
+
+import (
+	"errors"
+	"io"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ErrStopIteration can be returned by a step hook to stop the VM without an error.
+var ErrStopIteration = errors.New("stop iteration")
+
+// StepHook is called around each step of a VM. The witness is nil for hooks that run before a step,
+// and after steps that don't include proofs.
+// Returning ErrStopIteration ends the iteration cleanly, any other error is reported by VM.Err.
+type StepHook func(state FPVMState, witness *StepWitness) error
+
+// VMOptions configure an embedded VM. The zero value is valid.
+type VMOptions struct {
+	// Logger defaults to the root logger
+	Logger log.Logger
+	// Stdout and Stderr of the program, discarded if nil
+	Stdout io.Writer
+	Stderr io.Writer
+	// Meta is used to lookup symbols, optional
+	Meta Metadata
+	// Proofs enables the generation of a proof for every step, available through VM.Witness
+	Proofs bool
+	// StopAt ends the iteration once the VM reaches this step, if non-zero
+	StopAt uint64
+	// BeforeStep hooks run before each step, in order
+	BeforeStep []StepHook
+	// AfterStep hooks run after each step, in order
+	AfterStep []StepHook
+}
+
+// VM runs a FPVM as an iterator, for programs that embed the VM rather than running the cannon binary:
+//
+//	vm := mipsevm.New(state, oracle, opts)
+//	for vm.Step() {
+//		...
+//	}
+//	if err := vm.Err(); err != nil {
+//		...
+//	}
+type VM struct {
+	fpvm    FPVM
+	opts    VMOptions
+	witness *StepWitness
+	err     error
+	done    bool
+}
+
+func New(state FPVMState, po PreimageOracle, opts *VMOptions) *VM {
+	var o VMOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Logger == nil {
+		o.Logger = log.Root()
+	}
+	if o.Stdout == nil {
+		o.Stdout = io.Discard
+	}
+	if o.Stderr == nil {
+		o.Stderr = io.Discard
+	}
+	return &VM{fpvm: state.CreateVM(o.Logger, po, o.Stdout, o.Stderr, o.Meta), opts: o}
+}
+
+// OnBeforeStep adds a hook that runs before each step.
+func (vm *VM) OnBeforeStep(hook StepHook) {
+	vm.opts.BeforeStep = append(vm.opts.BeforeStep, hook)
+}
+
+// OnAfterStep adds a hook that runs after each step.
+func (vm *VM) OnAfterStep(hook StepHook) {
+	vm.opts.AfterStep = append(vm.opts.AfterStep, hook)
+}
+
+// Step executes the next instruction, and returns false once the iteration ended:
+// when the program exited, the StopAt step was reached, a hook stopped the iteration, or an error occurred.
+func (vm *VM) Step() bool {
+	if vm.done {
+		return false
+	}
+	state := vm.fpvm.GetState()
+	if state.GetExited() || (vm.opts.StopAt != 0 && state.GetStep() >= vm.opts.StopAt) {
+		vm.done = true
+		return false
+	}
+	vm.witness = nil
+	if !vm.runHooks(vm.opts.BeforeStep, state, nil) {
+		return false
+	}
+	witness, err := vm.fpvm.Step(vm.opts.Proofs)
+	if err != nil {
+		vm.done = true
+		vm.err = err
+		return false
+	}
+	vm.witness = witness
+	return vm.runHooks(vm.opts.AfterStep, state, witness)
+}
+
+func (vm *VM) runHooks(hooks []StepHook, state FPVMState, witness *StepWitness) bool {
+	for _, hook := range hooks {
+		if err := hook(state, witness); err != nil {
+			vm.done = true
+			if !errors.Is(err, ErrStopIteration) {
+				vm.err = err
+			}
+			return false
+		}
+	}
+	return true
+}
+
+// Run steps the VM until the iteration ends, and returns the error that ended it, if any.
+func (vm *VM) Run() error {
+	for vm.Step() {
+	}
+	return vm.err
+}
+
+// Err returns the error that ended the iteration, if any.
+func (vm *VM) Err() error {
+	return vm.err
+}
+
+// Witness returns the witness of the last step, if the step included a proof.
+func (vm *VM) Witness() *StepWitness {
+	return vm.witness
+}
+
+// State returns the current state of the VM.
+func (vm *VM) State() FPVMState {
+	return vm.fpvm.GetState()
+}
+
+// FPVM returns the underlying FPVM, e.g. for debug info and tracebacks.
+func (vm *VM) FPVM() FPVM {
+	return vm.fpvm
+}
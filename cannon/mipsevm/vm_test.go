@@ -0,0 +1,77 @@
+package mipsevm_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+)
+
+func TestVM(t *testing.T) {
+	t.Run("stop at", func(t *testing.T) {
+		// an empty memory decodes as a sequence of nops
+		state := singlethreaded.CreateEmptyState()
+		var before, after int
+		vm := mipsevm.New(state, nil, &mipsevm.VMOptions{
+			Proofs: true,
+			StopAt: 10,
+			BeforeStep: []mipsevm.StepHook{func(state mipsevm.FPVMState, witness *mipsevm.StepWitness) error {
+				require.Nil(t, witness)
+				before++
+				return nil
+			}},
+		})
+		vm.OnAfterStep(func(state mipsevm.FPVMState, witness *mipsevm.StepWitness) error {
+			require.NotEmpty(t, witness.ProofData)
+			after++
+			return nil
+		})
+		steps := 0
+		for vm.Step() {
+			steps++
+			require.NotNil(t, vm.Witness())
+		}
+		require.NoError(t, vm.Err())
+		require.Equal(t, 10, steps)
+		require.Equal(t, 10, before)
+		require.Equal(t, 10, after)
+		require.Equal(t, uint64(10), vm.State().GetStep())
+		require.False(t, vm.Step(), "iteration ended")
+	})
+
+	t.Run("hook stops", func(t *testing.T) {
+		state := singlethreaded.CreateEmptyState()
+		vm := mipsevm.New(state, nil, nil)
+		vm.OnAfterStep(func(state mipsevm.FPVMState, witness *mipsevm.StepWitness) error {
+			require.Nil(t, witness, "no proofs")
+			if state.GetStep() == 3 {
+				return mipsevm.ErrStopIteration
+			}
+			return nil
+		})
+		require.NoError(t, vm.Run())
+		require.Equal(t, uint64(3), state.GetStep())
+	})
+
+	t.Run("hook error", func(t *testing.T) {
+		state := singlethreaded.CreateEmptyState()
+		vm := mipsevm.New(state, nil, nil)
+		errHook := errors.New("hook failed")
+		vm.OnBeforeStep(func(state mipsevm.FPVMState, witness *mipsevm.StepWitness) error {
+			return errHook
+		})
+		require.ErrorIs(t, vm.Run(), errHook)
+		require.Equal(t, uint64(0), state.GetStep())
+	})
+
+	t.Run("exited", func(t *testing.T) {
+		state := singlethreaded.CreateEmptyState()
+		state.Exited = true
+		vm := mipsevm.New(state, nil, nil)
+		require.False(t, vm.Step())
+		require.NoError(t, vm.Err())
+	})
+}
@@ -9,12 +9,17 @@ import (
 )
 
 func Write[X Serializable](outputPath string, x X, perm os.FileMode) error {
-	if IsBinaryFile(outputPath) {
-		return WriteSerializedBinary(x, ioutil.ToStdOutOrFileOrNoop(outputPath, perm))
+	return WriteTo(outputPath, x, ioutil.ToStdOutOrFileOrNoop(outputPath, perm))
+}
+
+// WriteTo writes x to the target, in the binary or JSON encoding depending on the name.
+func WriteTo[X Serializable](name string, x X, target ioutil.OutputTarget) error {
+	if IsBinaryFile(name) {
+		return WriteSerializedBinary(x, target)
 	}
-	return jsonutil.WriteJSON[X](x, ioutil.ToStdOutOrFileOrNoop(outputPath, perm))
+	return jsonutil.WriteJSON[X](x, target)
 }
 
 func IsBinaryFile(path string) bool {
-	return strings.HasSuffix(path, ".bin") || strings.HasSuffix(path, ".bin.gz")
+	return strings.HasSuffix(path, ".bin") || strings.HasSuffix(path, ".bin.gz") || strings.HasSuffix(path, ".bin.zst")
 }
@@ -22,6 +22,8 @@ func TestRoundtrip(t *testing.T) {
 		{filename: "test.foo.gz", expectJSON: true, expectGzip: true},
 		{filename: "test.bin", expectJSON: false, expectGzip: false},
 		{filename: "test.bin.gz", expectJSON: false, expectGzip: true},
+		{filename: "test.bin.zst", expectJSON: false, expectGzip: false},
+		{filename: "test.json.zst", expectJSON: true, expectGzip: false},
 	}
 
 	for _, test := range tests {
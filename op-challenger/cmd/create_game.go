@@ -2,60 +2,211 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/flags"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
 	contractMetrics "github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/tools"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli/v2"
 )
 
 var (
+	GameTypeFlag = &cli.UintFlag{
+		Name:    "game-type",
+		Usage:   "The game type of the dispute game to create. Defaults to the game type of --trace-type.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "GAME_TYPE"),
+	}
 	TraceTypeFlag = &cli.StringFlag{
 		Name:    "trace-type",
-		Usage:   "Trace types to support.",
+		Usage:   "The trace type of the dispute game to create, used when --game-type is not set.",
 		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "TRACE_TYPE"),
 		Value:   types.TraceTypeCannon.String(),
 	}
 	OutputRootFlag = &cli.StringFlag{
-		Name:    "output-root",
-		Usage:   "The output root for the fault dispute game.",
+		Name: "output-root",
+		Usage: "The output root for the fault dispute game. " +
+			"If not set, the honest output root at the L2 block is loaded from --rollup-rpc.",
 		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "OUTPUT_ROOT"),
 	}
-	L2BlockNumFlag = &cli.StringFlag{
-		Name:    "l2-block-num",
+	InvalidOutputRootFlag = &cli.BoolFlag{
+		Name: "invalid-output-root",
+		Usage: "Propose an intentionally invalid output root, derived from the honest output root loaded from " +
+			"--rollup-rpc, for testing challengers.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "INVALID_OUTPUT_ROOT"),
+	}
+	L2BlockNumFlag = &cli.Uint64Flag{
+		Name:    "l2-block",
+		Aliases: []string{"l2-block-num"},
 		Usage:   "The l2 block number for the game.",
-		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "L2_BLOCK_NUM"),
+		EnvVars: append(opservice.PrefixEnvVar(flags.EnvVarPrefix, "L2_BLOCK"), opservice.PrefixEnvVar(flags.EnvVarPrefix, "L2_BLOCK_NUM")...),
+	}
+	FollowFlag = &cli.BoolFlag{
+		Name:    "follow",
+		Usage:   "Follow the game after creating it, printing its claims as they change until it resolves.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "FOLLOW"),
+	}
+	FollowIntervalFlag = &cli.DurationFlag{
+		Name:    "follow-interval",
+		Usage:   "Time between checks for changes to the game when following it.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "FOLLOW_INTERVAL"),
+		Value:   12 * time.Second,
 	}
 )
 
 func CreateGame(ctx *cli.Context) error {
-	outputRoot := common.HexToHash(ctx.String(OutputRootFlag.Name))
-	traceType := ctx.Uint64(TraceTypeFlag.Name)
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return err
+	}
+	gameType, err := gameTypeFromCLI(ctx)
+	if err != nil {
+		return err
+	}
+	if !ctx.IsSet(L2BlockNumFlag.Name) {
+		return fmt.Errorf("missing %v", L2BlockNumFlag.Name)
+	}
 	l2BlockNum := ctx.Uint64(L2BlockNumFlag.Name)
+	outputRoot, err := outputRootFromCLI(ctx, logger, l2BlockNum)
+	if err != nil {
+		return err
+	}
 
-	contract, txMgr, err := NewContractWithTxMgr[*contracts.DisputeGameFactoryContract](ctx, flags.FactoryAddress,
-		func(ctx context.Context, metricer contractMetrics.ContractMetricer, address common.Address, caller *batching.MultiCaller) (*contracts.DisputeGameFactoryContract, error) {
-			return contracts.NewDisputeGameFactoryContract(metricer, address, caller), nil
-		})
+	rpcUrl := ctx.String(flags.L1EthRpcFlag.Name)
+	if rpcUrl == "" {
+		return fmt.Errorf("missing %v", flags.L1EthRpcFlag.Name)
+	}
+	factoryAddr, err := flags.FactoryAddress(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to create dispute game factory bindings: %w", err)
+		return err
 	}
+	l1Client, err := dial.DialEthClientWithTimeout(ctx.Context, dial.DefaultDialTimeout, logger, rpcUrl)
+	if err != nil {
+		return fmt.Errorf("failed to dial L1: %w", err)
+	}
+	defer l1Client.Close()
+	caller := batching.NewMultiCaller(l1Client.Client(), batching.DefaultBatchSize)
+	txMgr, err := txmgr.NewSimpleTxManager("challenger", logger, &metrics.NoopTxMetrics{}, txmgr.ReadCLIConfig(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to create the transaction manager: %w", err)
+	}
+	defer txMgr.Close()
 
+	contract := contracts.NewDisputeGameFactoryContract(contractMetrics.NoopContractMetrics, factoryAddr, caller)
 	creator := tools.NewGameCreator(contract, txMgr)
-	gameAddr, err := creator.CreateGame(ctx.Context, outputRoot, traceType, l2BlockNum)
+	gameAddr, err := creator.CreateGame(ctx.Context, outputRoot, uint64(gameType), l2BlockNum)
 	if err != nil {
 		return fmt.Errorf("failed to create game: %w", err)
 	}
 	fmt.Printf("Fetched Game Address: %s\n", gameAddr.String())
-	return nil
+	if !ctx.Bool(FollowFlag.Name) {
+		return nil
+	}
+	game, err := contracts.NewFaultDisputeGameContract(ctx.Context, contractMetrics.NoopContractMetrics, gameAddr, caller)
+	if err != nil {
+		return fmt.Errorf("failed to create game bindings: %w", err)
+	}
+	return followGame(ctx.Context, os.Stdout, game, ctx.Duration(FollowIntervalFlag.Name))
+}
+
+// gameTypeFromCLI returns the game type set by --game-type, or the game type of --trace-type if not set.
+func gameTypeFromCLI(ctx *cli.Context) (types.GameType, error) {
+	if ctx.IsSet(GameTypeFlag.Name) {
+		return types.GameType(ctx.Uint(GameTypeFlag.Name)), nil
+	}
+	var traceType types.TraceType
+	if err := traceType.Set(ctx.String(TraceTypeFlag.Name)); err != nil {
+		return 0, err
+	}
+	return traceType.GameType(), nil
+}
+
+// outputRootFromCLI returns the output root to propose, either as set by --output-root or the honest output root at the
+// L2 block, which is replaced by an invalid root if --invalid-output-root is set.
+func outputRootFromCLI(ctx *cli.Context, logger log.Logger, l2BlockNum uint64) (common.Hash, error) {
+	invalid := ctx.Bool(InvalidOutputRootFlag.Name)
+	if ctx.IsSet(OutputRootFlag.Name) {
+		if invalid {
+			return common.Hash{}, fmt.Errorf("only specify one of %v and %v", OutputRootFlag.Name, InvalidOutputRootFlag.Name)
+		}
+		return common.HexToHash(ctx.String(OutputRootFlag.Name)), nil
+	}
+	rollupRpc := ctx.String(flags.RollupRpcFlag.Name)
+	if rollupRpc == "" {
+		return common.Hash{}, fmt.Errorf("missing %v or %v to load the honest output root", OutputRootFlag.Name, flags.RollupRpcFlag.Name)
+	}
+	rollupClient, err := dial.DialRollupClientWithTimeout(ctx.Context, dial.DefaultDialTimeout, logger, rollupRpc)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to dial rollup client: %w", err)
+	}
+	defer rollupClient.Close()
+	output, err := rollupClient.OutputAtBlock(ctx.Context, l2BlockNum)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to load output root at block %v: %w", l2BlockNum, err)
+	}
+	root := common.Hash(output.OutputRoot)
+	if invalid {
+		invalidRoot := invalidOutputRoot(root)
+		logger.Info("Proposing invalid output root", "honest", root, "invalid", invalidRoot)
+		return invalidRoot, nil
+	}
+	return root, nil
+}
+
+// invalidOutputRoot derives an output root that differs from the honest root, so the game can be used to test that
+// challengers dispute it.
+func invalidOutputRoot(honest common.Hash) common.Hash {
+	return crypto.Keccak256Hash([]byte("invalid"), honest.Bytes())
+}
+
+// followGame prints the claims of the game each time they change until the game resolves or ctx is done.
+func followGame(ctx context.Context, out io.Writer, game contracts.FaultDisputeGameContract, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var lastCount uint64
+	for {
+		status, err := game.GetStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load game status: %w", err)
+		}
+		count, err := game.GetClaimCount(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load claim count: %w", err)
+		}
+		if count != lastCount || status != gameTypes.GameStatusInProgress {
+			lastCount = count
+			if err := listClaims(ctx, out, game, "table", false); err != nil {
+				return err
+			}
+		}
+		if status != gameTypes.GameStatusInProgress {
+			_, _ = fmt.Fprintf(out, "Game resolved: %v\n", status)
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return nil
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
 }
 
 func createGameFlags() []cli.Flag {
@@ -63,9 +214,14 @@ func createGameFlags() []cli.Flag {
 		flags.L1EthRpcFlag,
 		flags.NetworkFlag,
 		flags.FactoryAddressFlag,
+		flags.RollupRpcFlag,
+		GameTypeFlag,
 		TraceTypeFlag,
 		OutputRootFlag,
+		InvalidOutputRootFlag,
 		L2BlockNumFlag,
+		FollowFlag,
+		FollowIntervalFlag,
 	}
 	cliFlags = append(cliFlags, txmgr.CLIFlagsWithDefaults(flags.EnvVarPrefix, txmgr.DefaultChallengerFlagValues)...)
 	cliFlags = append(cliFlags, oplog.CLIFlags(flags.EnvVarPrefix)...)
@@ -73,9 +229,10 @@ func createGameFlags() []cli.Flag {
 }
 
 var CreateGameCommand = &cli.Command{
-	Name:        "create-game",
-	Usage:       "Creates a dispute game via the factory",
-	Description: "Creates a dispute game via the factory",
-	Action:      Interruptible(CreateGame),
-	Flags:       createGameFlags(),
+	Name:  "create-game",
+	Usage: "Creates a dispute game via the factory",
+	Description: "Creates a dispute game via the factory, proposing the specified output root or the honest " +
+		"output root at the L2 block, or an intentionally invalid root for testing. Optionally follows the game afterward.",
+	Action: Interruptible(CreateGame),
+	Flags:  createGameFlags(),
 }
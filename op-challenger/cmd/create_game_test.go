@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestGameTypeFromCLI(t *testing.T) {
+	gameTypeFor := func(args ...string) (types.GameType, error) {
+		var gameType types.GameType
+		app := cli.NewApp()
+		app.Flags = []cli.Flag{GameTypeFlag, TraceTypeFlag}
+		app.Action = func(ctx *cli.Context) error {
+			var err error
+			gameType, err = gameTypeFromCLI(ctx)
+			return err
+		}
+		err := app.Run(append([]string{"op-challenger"}, args...))
+		return gameType, err
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		gameType, err := gameTypeFor()
+		require.NoError(t, err)
+		require.Equal(t, types.CannonGameType, gameType)
+	})
+
+	t.Run("TraceType", func(t *testing.T) {
+		gameType, err := gameTypeFor("--trace-type", types.TraceTypeAsterisc.String())
+		require.NoError(t, err)
+		require.Equal(t, types.AsteriscGameType, gameType)
+	})
+
+	t.Run("GameType", func(t *testing.T) {
+		gameType, err := gameTypeFor("--game-type", "42", "--trace-type", types.TraceTypeAsterisc.String())
+		require.NoError(t, err)
+		require.Equal(t, types.GameType(42), gameType, "game type takes precedence over the trace type")
+	})
+
+	t.Run("InvalidTraceType", func(t *testing.T) {
+		_, err := gameTypeFor("--trace-type", "foo")
+		require.ErrorContains(t, err, "foo")
+	})
+}
+
+type stubRollupAPI struct {
+	root eth.Bytes32
+}
+
+func (s *stubRollupAPI) OutputAtBlock(_ context.Context, blockNum hexutil.Uint64) (*eth.OutputResponse, error) {
+	return &eth.OutputResponse{
+		Version:    eth.OutputVersionV0,
+		OutputRoot: s.root,
+		BlockRef:   eth.L2BlockRef{Number: uint64(blockNum)},
+	}, nil
+}
+
+func TestOutputRootFromCLI(t *testing.T) {
+	honest := common.Hash{0xaa, 0xbb}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("optimism", &stubRollupAPI{root: eth.Bytes32(honest)}))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+
+	outputRootFor := func(t *testing.T, args ...string) (common.Hash, error) {
+		var root common.Hash
+		app := cli.NewApp()
+		app.Flags = []cli.Flag{OutputRootFlag, InvalidOutputRootFlag, flags.RollupRpcFlag}
+		app.Action = func(ctx *cli.Context) error {
+			var err error
+			root, err = outputRootFromCLI(ctx, testlog.Logger(t, log.LevelInfo), 100)
+			return err
+		}
+		err := app.Run(append([]string{"op-challenger"}, args...))
+		return root, err
+	}
+
+	t.Run("Explicit", func(t *testing.T) {
+		root, err := outputRootFor(t, "--output-root", common.Hash{0x01}.Hex())
+		require.NoError(t, err)
+		require.Equal(t, common.Hash{0x01}, root)
+	})
+
+	t.Run("Honest", func(t *testing.T) {
+		root, err := outputRootFor(t, "--rollup-rpc", httpServer.URL)
+		require.NoError(t, err)
+		require.Equal(t, honest, root)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		root, err := outputRootFor(t, "--rollup-rpc", httpServer.URL, "--invalid-output-root")
+		require.NoError(t, err)
+		require.Equal(t, invalidOutputRoot(honest), root)
+		require.NotEqual(t, honest, root)
+	})
+
+	t.Run("ExplicitAndInvalid", func(t *testing.T) {
+		_, err := outputRootFor(t, "--output-root", common.Hash{0x01}.Hex(), "--invalid-output-root")
+		require.ErrorContains(t, err, "only specify one of output-root and invalid-output-root")
+	})
+
+	t.Run("MissingRollupRpc", func(t *testing.T) {
+		_, err := outputRootFor(t)
+		require.ErrorContains(t, err, "missing output-root or rollup-rpc")
+	})
+}
+
+func TestInvalidOutputRoot(t *testing.T) {
+	a, b := common.Hash{0x01}, common.Hash{0x02}
+	require.NotEqual(t, a, invalidOutputRoot(a))
+	require.Equal(t, invalidOutputRoot(a), invalidOutputRoot(a), "must be deterministic")
+	require.NotEqual(t, invalidOutputRoot(a), invalidOutputRoot(b))
+}
+
+// stubFollowGame reports the statuses and claim counts in order, one per poll.
+type stubFollowGame struct {
+	*stubClaimsGame
+	statuses []gameTypes.GameStatus
+	counts   []int
+	polls    int
+}
+
+func (s *stubFollowGame) GetStatus(_ context.Context) (gameTypes.GameStatus, error) {
+	s.polls++
+	return s.statuses[s.polls-1], nil
+}
+
+func (s *stubFollowGame) GetClaimCount(_ context.Context) (uint64, error) {
+	s.stubClaimsGame.claims = newStubClaimsGame().claims[:s.counts[s.polls-1]]
+	return uint64(s.counts[s.polls-1]), nil
+}
+
+func TestFollowGame(t *testing.T) {
+	t.Run("UntilResolved", func(t *testing.T) {
+		inProgress := gameTypes.GameStatusInProgress
+		game := &stubFollowGame{
+			stubClaimsGame: newStubClaimsGame(),
+			statuses:       []gameTypes.GameStatus{inProgress, inProgress, inProgress, gameTypes.GameStatusChallengerWon},
+			counts:         []int{1, 1, 2, 2},
+		}
+		game.metadata.Status = inProgress
+		var out bytes.Buffer
+		require.NoError(t, followGame(context.Background(), &out, game, time.Millisecond))
+		require.Equal(t, 4, game.polls, "must poll until the game resolves")
+		output := out.String()
+		require.Equal(t, 3, strings.Count(output, "Status: "), "must only list the claims when they change, and once resolved")
+		require.True(t, strings.HasSuffix(output, "Game resolved: "+gameTypes.GameStatusChallengerWon.String()+"\n"))
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		game := &stubFollowGame{
+			stubClaimsGame: newStubClaimsGame(),
+			statuses:       []gameTypes.GameStatus{gameTypes.GameStatusInProgress},
+			counts:         []int{1},
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var out bytes.Buffer
+		require.NoError(t, followGame(ctx, &out, game, time.Hour))
+		require.Equal(t, 1, game.polls)
+		require.NotContains(t, out.String(), "Game resolved")
+	})
+}
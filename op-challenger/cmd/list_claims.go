@@ -2,8 +2,12 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"math/big"
+	"os"
+	"slices"
 	"strconv"
 	"time"
 
@@ -14,6 +18,7 @@ import (
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
@@ -22,9 +27,12 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
+var ClaimFormats = []string{"table", "tree", "json"}
+
 var (
 	GameAddressFlag = &cli.StringFlag{
 		Name:    "game-address",
+		Aliases: []string{"game"},
 		Usage:   "Address of the fault game contract.",
 		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "GAME_ADDRESS"),
 	}
@@ -34,6 +42,12 @@ var (
 		Usage:   "Verbose output",
 		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "VERBOSE"),
 	}
+	ClaimFormatFlag = &cli.StringFlag{
+		Name:    "format",
+		Usage:   "Output format for claims. Valid options: " + openum.EnumString(ClaimFormats),
+		Value:   "table",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "FORMAT"),
+	}
 )
 
 func ListClaims(ctx *cli.Context) error {
@@ -49,6 +63,10 @@ func ListClaims(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	format := ctx.String(ClaimFormatFlag.Name)
+	if !slices.Contains(ClaimFormats, format) {
+		return fmt.Errorf("invalid format value: %v", format)
+	}
 
 	l1Client, err := dial.DialEthClientWithTimeout(ctx.Context, dial.DefaultDialTimeout, logger, rpcUrl)
 	if err != nil {
@@ -61,43 +79,104 @@ func ListClaims(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	return listClaims(ctx.Context, contract, ctx.Bool(VerboseFlag.Name))
+	return listClaims(ctx.Context, os.Stdout, contract, format, ctx.Bool(VerboseFlag.Name))
+}
+
+type gameClaims struct {
+	Status                  string          `json:"status"`
+	ResolvedAt              *time.Time      `json:"resolvedAt,omitempty"`
+	L2StartBlockNum         uint64          `json:"l2StartBlockNum"`
+	L2BlockNum              uint64          `json:"l2BlockNum"`
+	L2BlockNumberChallenger *common.Address `json:"l2BlockNumberChallenger,omitempty"`
+	SplitDepth              types.Depth     `json:"splitDepth"`
+	MaxDepth                types.Depth     `json:"maxDepth"`
+	Claims                  []claimInfo     `json:"claims"`
+}
+
+type claimInfo struct {
+	Index  int         `json:"index"`
+	Parent int         `json:"parent"` // -1 for the root claim
+	Move   string      `json:"move"`
+	Depth  types.Depth `json:"depth"`
+	// TraceIndex is the trace index of the claim within its top or bottom game
+	TraceIndex *big.Int `json:"traceIndex"`
+	// AncestorTraceIndex is the trace index of the output root claim at split depth that a bottom game claim is under
+	AncestorTraceIndex *big.Int        `json:"ancestorTraceIndex,omitempty"`
+	Value              common.Hash     `json:"value"`
+	Claimant           common.Address  `json:"claimant"`
+	Bond               *big.Int        `json:"bond"`
+	Timestamp          time.Time       `json:"timestamp"`
+	ClockUsed          time.Duration   `json:"clockUsed"`
+	Resolved           bool            `json:"resolved"`
+	ResolvableAt       *time.Time      `json:"resolvableAt,omitempty"`
+	CounteredBy        *common.Address `json:"counteredBy,omitempty"`
+}
+
+func listClaims(ctx context.Context, out io.Writer, game contracts.FaultDisputeGameContract, format string, verbose bool) error {
+	info, err := loadGameClaims(ctx, game)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode claims: %w", err)
+		}
+		_, _ = fmt.Fprintln(out, string(data))
+	case "tree":
+		_, _ = fmt.Fprintln(out, gameSummary(info))
+		printClaimTree(out, info, verbose)
+	default:
+		_, _ = fmt.Fprintln(out, gameSummary(info))
+		printClaimTable(out, info, verbose)
+	}
+	return nil
 }
 
-func listClaims(ctx context.Context, game contracts.FaultDisputeGameContract, verbose bool) error {
+func loadGameClaims(ctx context.Context, game contracts.FaultDisputeGameContract) (gameClaims, error) {
 	metadata, err := game.GetGameMetadata(ctx, rpcblock.Latest)
 	if err != nil {
-		return fmt.Errorf("failed to retrieve metadata: %w", err)
+		return gameClaims{}, fmt.Errorf("failed to retrieve metadata: %w", err)
 	}
 	maxDepth, err := game.GetMaxGameDepth(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to retrieve max depth: %w", err)
+		return gameClaims{}, fmt.Errorf("failed to retrieve max depth: %w", err)
 	}
 	maxClockDuration, err := game.GetMaxClockDuration(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to retrieve max clock duration: %w", err)
+		return gameClaims{}, fmt.Errorf("failed to retrieve max clock duration: %w", err)
 	}
 	splitDepth, err := game.GetSplitDepth(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to retrieve split depth: %w", err)
+		return gameClaims{}, fmt.Errorf("failed to retrieve split depth: %w", err)
 	}
-	status := metadata.Status
 	l2StartBlockNum, l2BlockNum, err := game.GetBlockRange(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to retrieve status: %w", err)
+		return gameClaims{}, fmt.Errorf("failed to retrieve status: %w", err)
 	}
 
 	claims, err := game.GetAllClaims(ctx, rpcblock.Latest)
 	if err != nil {
-		return fmt.Errorf("failed to retrieve claims: %w", err)
+		return gameClaims{}, fmt.Errorf("failed to retrieve claims: %w", err)
 	}
 
-	var resolutionTime time.Time
-	if status != gameTypes.GameStatusInProgress {
-		resolutionTime, err = game.GetResolvedAt(ctx, rpcblock.Latest)
+	info := gameClaims{
+		Status:          metadata.Status.String(),
+		L2StartBlockNum: l2StartBlockNum,
+		L2BlockNum:      l2BlockNum,
+		SplitDepth:      splitDepth,
+		MaxDepth:        maxDepth,
+	}
+	if metadata.Status != gameTypes.GameStatusInProgress {
+		resolutionTime, err := game.GetResolvedAt(ctx, rpcblock.Latest)
 		if err != nil {
-			return fmt.Errorf("failed to retrieve resolved at: %w", err)
+			return gameClaims{}, fmt.Errorf("failed to retrieve resolved at: %w", err)
 		}
+		info.ResolvedAt = &resolutionTime
+	}
+	if metadata.L2BlockNumberChallenged {
+		info.L2BlockNumberChallenger = &metadata.L2BlockNumberChallenger
 	}
 
 	// The top game runs from depth 0 to split depth *inclusive*.
@@ -106,82 +185,149 @@ func listClaims(ctx context.Context, game contracts.FaultDisputeGameContract, ve
 
 	resolved, err := game.IsResolved(ctx, rpcblock.Latest, claims...)
 	if err != nil {
-		return fmt.Errorf("failed to retrieve claim resolution: %w", err)
+		return gameClaims{}, fmt.Errorf("failed to retrieve claim resolution: %w", err)
 	}
 
 	gameState := types.NewGameState(claims, maxDepth)
-	valueFormat := "%-14v"
-	if verbose {
-		valueFormat = "%-66v"
-	}
 	now := time.Now()
-	lineFormat := "%3v %-7v %6v %5v %14v " + valueFormat + " %-42v %12v %-19v %10v %v\n"
-	info := fmt.Sprintf(lineFormat, "Idx", "Move", "Parent", "Depth", "Trace", "Value", "Claimant", "Bond (ETH)", "Time", "Clock Used", "Resolution")
 	for i, claim := range claims {
-		pos := claim.Position
-		parent := strconv.Itoa(claim.ParentContractIndex)
-		var elapsed time.Duration // Root claim does not accumulate any time on its team's chess clock
+		claimInfo := claimInfo{
+			Index:      i,
+			Parent:     claim.ParentContractIndex,
+			Move:       "Attack",
+			Depth:      claim.Depth(),
+			TraceIndex: big.NewInt(-1),
+			Value:      claim.Value,
+			Claimant:   claim.Claimant,
+			Bond:       claim.Bond,
+			Timestamp:  claim.Clock.Timestamp,
+			Resolved:   resolved[i],
+		}
 		if claim.IsRoot() {
-			parent = "-"
+			// Root claim does not accumulate any time on its team's chess clock
+			claimInfo.Parent = -1
 		} else {
 			parentClaim, err := gameState.GetParent(claim)
 			if err != nil {
-				return fmt.Errorf("failed to retrieve parent claim: %w", err)
+				return gameClaims{}, fmt.Errorf("failed to retrieve parent claim: %w", err)
 			}
 			// Get the total chess clock time accumulated by the team that posted this claim at the time of the claim.
-			elapsed = gameState.ChessClock(claim.Clock.Timestamp, parentClaim)
+			claimInfo.ClockUsed = gameState.ChessClock(claim.Clock.Timestamp, parentClaim)
+		}
+		if gameState.DefendsParent(claim) {
+			claimInfo.Move = "Defend"
 		}
-		var countered string
 		if !resolved[i] {
-			clock := gameState.ChessClock(now, claim)
-			resolvableAt := now.Add(maxClockDuration - clock).Format(time.DateTime)
-			countered = fmt.Sprintf("⏱️  %v", resolvableAt)
+			resolvableAt := now.Add(maxClockDuration - gameState.ChessClock(now, claim))
+			claimInfo.ResolvableAt = &resolvableAt
 		} else if claim.IsRoot() && metadata.L2BlockNumberChallenged {
-			countered = "❌ " + metadata.L2BlockNumberChallenger.Hex()
+			claimInfo.CounteredBy = &metadata.L2BlockNumberChallenger
 		} else if claim.CounteredBy != (common.Address{}) {
-			countered = "❌ " + claim.CounteredBy.Hex()
-		} else {
-			countered = "✅"
+			counteredBy := claim.CounteredBy
+			claimInfo.CounteredBy = &counteredBy
 		}
-		move := "Attack"
-		if gameState.DefendsParent(claim) {
-			move = "Defend"
-		}
-		var traceIdx *big.Int
 		if claim.Depth() <= splitDepth {
-			traceIdx = claim.TraceIndex(splitDepth)
+			claimInfo.TraceIndex = claim.TraceIndex(splitDepth)
 		} else {
+			ancestorPos := types.NewPosition(splitDepth, new(big.Int).Rsh(claim.IndexAtDepth(), uint(claim.Depth()-splitDepth)))
+			claimInfo.AncestorTraceIndex = ancestorPos.TraceIndex(splitDepth)
 			relativePos, err := claim.Position.RelativeToAncestorAtDepth(splitDepth + 1)
 			if err != nil {
 				fmt.Printf("Error calculating relative position for claim %v: %v", claim.ContractIndex, err)
-				traceIdx = big.NewInt(-1)
 			} else {
-				traceIdx = relativePos.TraceIndex(bottomDepth)
+				claimInfo.TraceIndex = relativePos.TraceIndex(bottomDepth)
 			}
 		}
-		value := claim.Value.TerminalString()
-		if verbose {
-			value = claim.Value.Hex()
+		info.Claims = append(info.Claims, claimInfo)
+	}
+	return info, nil
+}
+
+func gameSummary(info gameClaims) string {
+	blockNumChallenger := "Unchallenged"
+	if info.L2BlockNumberChallenger != nil {
+		blockNumChallenger = "❌ " + info.L2BlockNumberChallenger.Hex()
+	}
+	statusStr := info.Status
+	if info.ResolvedAt != nil {
+		statusStr = fmt.Sprintf("%v • Resolution Time: %v", statusStr, info.ResolvedAt.Format(time.DateTime))
+	}
+	return fmt.Sprintf("Status: %v • L2 Blocks: %v to %v (%v) • Split Depth: %v • Max Depth: %v • Claim Count: %v",
+		statusStr, info.L2StartBlockNum, info.L2BlockNum, blockNumChallenger, info.SplitDepth, info.MaxDepth, len(info.Claims))
+}
+
+func printClaimTable(out io.Writer, info gameClaims, verbose bool) {
+	valueFormat := "%-14v"
+	if verbose {
+		valueFormat = "%-66v"
+	}
+	lineFormat := "%3v %-7v %6v %5v %14v %8v " + valueFormat + " %-42v %12v %-19v %10v %v\n"
+	_, _ = fmt.Fprintf(out, lineFormat, "Idx", "Move", "Parent", "Depth", "Trace", "Ancestor", "Value", "Claimant", "Bond (ETH)", "Time", "Clock Used", "Resolution")
+	for _, claim := range info.Claims {
+		parent := strconv.Itoa(claim.Parent)
+		if claim.Parent < 0 {
+			parent = "-"
 		}
-		timestamp := claim.Clock.Timestamp.Format(time.DateTime)
-		bond := fmt.Sprintf("%12.8f", eth.WeiToEther(claim.Bond))
-		if verbose {
-			bond = fmt.Sprintf("%f", eth.WeiToEther(claim.Bond))
+		ancestor := "-"
+		if claim.AncestorTraceIndex != nil {
+			ancestor = claim.AncestorTraceIndex.String()
 		}
-		info = info + fmt.Sprintf(lineFormat,
-			i, move, parent, pos.Depth(), traceIdx, value, claim.Claimant, bond, timestamp, elapsed, countered)
+		_, _ = fmt.Fprintf(out, lineFormat,
+			claim.Index, claim.Move, parent, claim.Depth, claim.TraceIndex, ancestor, claimValue(claim, verbose), claim.Claimant,
+			claimBond(claim, verbose), claim.Timestamp.Format(time.DateTime), claim.ClockUsed, claimResolution(claim))
 	}
-	blockNumChallenger := "Unchallenged"
-	if metadata.L2BlockNumberChallenged {
-		blockNumChallenger = "❌ " + metadata.L2BlockNumberChallenger.Hex()
+}
+
+// printClaimTree prints each claim nested under its parent claim.
+func printClaimTree(out io.Writer, info gameClaims, verbose bool) {
+	children := make(map[int][]claimInfo)
+	for _, claim := range info.Claims {
+		children[claim.Parent] = append(children[claim.Parent], claim)
 	}
-	statusStr := status.String()
-	if status != gameTypes.GameStatusInProgress {
-		statusStr = fmt.Sprintf("%v • Resolution Time: %v", statusStr, resolutionTime.Format(time.DateTime))
+	var printClaims func(claims []claimInfo, prefix string)
+	printClaims = func(claims []claimInfo, prefix string) {
+		for i, claim := range claims {
+			branch, indent := "├── ", "│   "
+			if i == len(claims)-1 {
+				branch, indent = "└── ", "    "
+			}
+			if claim.Parent < 0 {
+				branch, indent = "", ""
+			}
+			trace := claim.TraceIndex.String()
+			if claim.AncestorTraceIndex != nil {
+				trace = fmt.Sprintf("%v (ancestor %v)", claim.TraceIndex, claim.AncestorTraceIndex)
+			}
+			_, _ = fmt.Fprintf(out, "%v%v %v %v • Depth: %v • Trace: %v • Claimant: %v • Bond: %v ETH • %v\n",
+				prefix+branch, claim.Index, claim.Move, claimValue(claim, verbose), claim.Depth, trace, claim.Claimant,
+				claimBond(claim, verbose), claimResolution(claim))
+			printClaims(children[claim.Index], prefix+indent)
+		}
 	}
-	fmt.Printf("Status: %v • L2 Blocks: %v to %v (%v) • Split Depth: %v • Max Depth: %v • Claim Count: %v\n%v\n",
-		statusStr, l2StartBlockNum, l2BlockNum, blockNumChallenger, splitDepth, maxDepth, len(claims), info)
-	return nil
+	printClaims(children[-1], "")
+}
+
+func claimValue(claim claimInfo, verbose bool) string {
+	if verbose {
+		return claim.Value.Hex()
+	}
+	return claim.Value.TerminalString()
+}
+
+func claimBond(claim claimInfo, verbose bool) string {
+	if verbose {
+		return fmt.Sprintf("%f", eth.WeiToEther(claim.Bond))
+	}
+	return fmt.Sprintf("%12.8f", eth.WeiToEther(claim.Bond))
+}
+
+func claimResolution(claim claimInfo) string {
+	if !claim.Resolved {
+		return fmt.Sprintf("⏱️  %v", claim.ResolvableAt.Format(time.DateTime))
+	} else if claim.CounteredBy != nil {
+		return "❌ " + claim.CounteredBy.Hex()
+	}
+	return "✅"
 }
 
 func listClaimsFlags() []cli.Flag {
@@ -189,6 +335,7 @@ func listClaimsFlags() []cli.Flag {
 		flags.L1EthRpcFlag,
 		GameAddressFlag,
 		VerboseFlag,
+		ClaimFormatFlag,
 	}
 	cliFlags = append(cliFlags, oplog.CLIFlags(flags.EnvVarPrefix)...)
 	return cliFlags
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+)
+
+// stubClaimsGame is a game with a split depth of 2 and a max depth of 4.
+type stubClaimsGame struct {
+	contracts.FaultDisputeGameContract
+	metadata contracts.GameMetadata
+	claims   []types.Claim
+	resolved []bool
+}
+
+func (s *stubClaimsGame) GetGameMetadata(_ context.Context, _ rpcblock.Block) (contracts.GameMetadata, error) {
+	return s.metadata, nil
+}
+
+func (s *stubClaimsGame) GetMaxGameDepth(_ context.Context) (types.Depth, error) {
+	return 4, nil
+}
+
+func (s *stubClaimsGame) GetMaxClockDuration(_ context.Context) (time.Duration, error) {
+	return time.Hour, nil
+}
+
+func (s *stubClaimsGame) GetSplitDepth(_ context.Context) (types.Depth, error) {
+	return 2, nil
+}
+
+func (s *stubClaimsGame) GetBlockRange(_ context.Context) (uint64, uint64, error) {
+	return 100, 200, nil
+}
+
+func (s *stubClaimsGame) GetAllClaims(_ context.Context, _ rpcblock.Block) ([]types.Claim, error) {
+	return s.claims, nil
+}
+
+func (s *stubClaimsGame) GetResolvedAt(_ context.Context, _ rpcblock.Block) (time.Time, error) {
+	return time.Unix(2000, 0), nil
+}
+
+func (s *stubClaimsGame) IsResolved(_ context.Context, _ rpcblock.Block, claims ...types.Claim) ([]bool, error) {
+	return s.resolved[:len(claims)], nil
+}
+
+func newStubClaimsGame() *stubClaimsGame {
+	root := types.NewPositionFromGIndex(big.NewInt(1))
+	topAttack := root.Attack()
+	splitDefend := topAttack.Defend()
+	bottomAttack := splitDefend.Attack()
+	bottomDefend := bottomAttack.Defend()
+	claim := func(idx int, parent int, pos types.Position, value byte) types.Claim {
+		return types.Claim{
+			ClaimData:           types.ClaimData{Value: common.Hash{value}, Bond: big.NewInt(1e18), Position: pos},
+			Claimant:            common.Address{value},
+			Clock:               types.Clock{Timestamp: time.Unix(int64(1000+idx*10), 0)},
+			ContractIndex:       idx,
+			ParentContractIndex: parent,
+		}
+	}
+	claims := []types.Claim{
+		claim(0, 0, root, 0xa0),
+		claim(1, 0, topAttack, 0xa1),
+		claim(2, 1, splitDefend, 0xa2),
+		claim(3, 2, bottomAttack, 0xa3),
+		claim(4, 3, bottomDefend, 0xa4),
+		claim(5, 0, topAttack, 0xa5),
+	}
+	claims[1].CounteredBy = common.Address{0xa2}
+	return &stubClaimsGame{
+		metadata: contracts.GameMetadata{Status: gameTypes.GameStatusDefenderWon},
+		claims:   claims,
+		resolved: []bool{true, true, true, true, true, true},
+	}
+}
+
+func TestLoadGameClaims(t *testing.T) {
+	game := newStubClaimsGame()
+	game.resolved[5] = false
+	info, err := loadGameClaims(context.Background(), game)
+	require.NoError(t, err)
+	require.Equal(t, gameTypes.GameStatusDefenderWon.String(), info.Status)
+	require.Equal(t, time.Unix(2000, 0), *info.ResolvedAt)
+	require.Equal(t, uint64(100), info.L2StartBlockNum)
+	require.Equal(t, uint64(200), info.L2BlockNum)
+
+	type expectedClaim struct {
+		parent   int
+		move     string
+		trace    int64
+		ancestor int64 // -1 for claims in the top game
+	}
+	expected := []expectedClaim{
+		{parent: -1, move: "Attack", trace: 3, ancestor: -1},
+		{parent: 0, move: "Attack", trace: 1, ancestor: -1},
+		{parent: 1, move: "Defend", trace: 2, ancestor: -1},
+		{parent: 2, move: "Attack", trace: 1, ancestor: 2},
+		{parent: 3, move: "Defend", trace: 0, ancestor: 2},
+		{parent: 0, move: "Attack", trace: 1, ancestor: -1},
+	}
+	require.Len(t, info.Claims, len(expected))
+	for i, exp := range expected {
+		claim := info.Claims[i]
+		require.Equal(t, i, claim.Index)
+		require.Equal(t, exp.parent, claim.Parent, "parent of claim %d", i)
+		require.Equal(t, exp.move, claim.Move, "move of claim %d", i)
+		require.Equal(t, big.NewInt(exp.trace), claim.TraceIndex, "trace index of claim %d", i)
+		if exp.ancestor < 0 {
+			require.Nil(t, claim.AncestorTraceIndex, "claim %d is in the top game", i)
+		} else {
+			require.Equal(t, big.NewInt(exp.ancestor), claim.AncestorTraceIndex, "ancestor trace index of claim %d", i)
+		}
+	}
+	require.Equal(t, common.Address{0xa2}, *info.Claims[1].CounteredBy)
+	require.Nil(t, info.Claims[2].CounteredBy)
+	require.Nil(t, info.Claims[0].ResolvableAt)
+	require.NotNil(t, info.Claims[5].ResolvableAt, "unresolved claims must report when they can be resolved")
+}
+
+func TestListClaimsFormats(t *testing.T) {
+	list := func(t *testing.T, format string) string {
+		var out bytes.Buffer
+		require.NoError(t, listClaims(context.Background(), &out, newStubClaimsGame(), format, false))
+		return out.String()
+	}
+
+	t.Run("Tree", func(t *testing.T) {
+		lines := strings.Split(strings.TrimSuffix(list(t, "tree"), "\n"), "\n")
+		require.Len(t, lines, 7)
+		require.True(t, strings.HasPrefix(lines[0], "Status: "+gameTypes.GameStatusDefenderWon.String()))
+		prefixes := []string{
+			"0 Attack ",
+			"├── 1 Attack ",
+			"│   └── 2 Defend ",
+			"│       └── 3 Attack ",
+			"│           └── 4 Defend ",
+			"└── 5 Attack ",
+		}
+		for i, prefix := range prefixes {
+			require.True(t, strings.HasPrefix(lines[i+1], prefix), "line %q must start with %q", lines[i+1], prefix)
+		}
+		require.Contains(t, lines[4], "Trace: 1 (ancestor 2)")
+		require.Contains(t, lines[2], "❌ "+common.Address{0xa2}.Hex())
+	})
+
+	t.Run("Table", func(t *testing.T) {
+		lines := strings.Split(strings.TrimSuffix(list(t, "table"), "\n"), "\n")
+		require.Len(t, lines, 8)
+		require.Regexp(t, `^Idx\s+Move\s+Parent\s+Depth\s+Trace\s+Ancestor\s+Value`, lines[1])
+		require.Regexp(t, `^  0 Attack\s+-\s+0\s+3\s+-\s`, lines[2])
+		require.Regexp(t, `^  3 Attack\s+2\s+3\s+1\s+2\s`, lines[5])
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var info gameClaims
+		require.NoError(t, json.Unmarshal([]byte(list(t, "json")), &info))
+		require.Len(t, info.Claims, 6)
+		require.Equal(t, -1, info.Claims[0].Parent)
+		require.Equal(t, big.NewInt(1), info.Claims[3].TraceIndex)
+		require.Equal(t, big.NewInt(2), info.Claims[3].AncestorTraceIndex)
+		require.Nil(t, info.Claims[2].AncestorTraceIndex)
+		require.Equal(t, common.Hash{0xa4}, info.Claims[4].Value)
+		require.Equal(t, uint64(200), info.L2BlockNum)
+	})
+}
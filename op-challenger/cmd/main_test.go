@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -14,8 +16,15 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/asterisc"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/participation"
+	"github.com/ethereum-optimism/optimism/op-challenger/notify"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
@@ -202,6 +211,23 @@ func TestTxManagerFlagsSupported(t *testing.T) {
 	require.Equal(t, uint64(7), cfg.TxMgrConfig.NumConfirmations)
 }
 
+func TestRemoteSigner(t *testing.T) {
+	cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet,
+		"--signer.endpoint", "https://web3signer:9000",
+		"--signer.address", "0x1234567890123456789012345678901234567890",
+		"--signer.tls.ca", "/certs/ca.crt",
+		"--signer.tls.cert", "/certs/challenger.crt",
+		"--signer.tls.key", "/certs/challenger.key"))
+	signerCfg := cfg.TxMgrConfig.SignerCLIConfig
+	require.True(t, signerCfg.Enabled())
+	require.Equal(t, "https://web3signer:9000", signerCfg.Endpoint)
+	require.Equal(t, "0x1234567890123456789012345678901234567890", signerCfg.Address)
+	require.Equal(t, "/certs/ca.crt", signerCfg.TLSConfig.TLSCaCert)
+	require.Equal(t, "/certs/challenger.crt", signerCfg.TLSConfig.TLSCert)
+	require.Equal(t, "/certs/challenger.key", signerCfg.TLSConfig.TLSKey)
+	require.NoError(t, cfg.TxMgrConfig.Check())
+}
+
 func TestMaxConcurrency(t *testing.T) {
 	t.Run("Valid", func(t *testing.T) {
 		expected := uint(345)
@@ -790,6 +816,79 @@ func TestGameWindow(t *testing.T) {
 	})
 }
 
+func TestGameData(t *testing.T) {
+	t.Run("DefaultsToRemoveImmediatelyWithNoLimit", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.Zero(t, cfg.GameDataRetention)
+		require.Zero(t, cfg.GameDataMaxSize)
+	})
+
+	t.Run("Retention", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--game-data-retention=48h"))
+		require.Equal(t, 48*time.Hour, cfg.GameDataRetention)
+	})
+
+	t.Run("MaxSize", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--game-data-max-size-mb=512"))
+		require.Equal(t, uint64(512*1024*1024), cfg.GameDataMaxSize)
+	})
+}
+
+func TestVMExecutionLimits(t *testing.T) {
+	t.Run("DefaultsToNoLimit", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeCannon))
+		require.Nil(t, cfg.Cannon.Limiter)
+		require.Nil(t, cfg.Asterisc.Limiter)
+	})
+
+	t.Run("MaxCPUs", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeCannon, "--vm-max-cpus=2"))
+		require.NotNil(t, cfg.Cannon.Limiter)
+		require.Same(t, cfg.Cannon.Limiter, cfg.Asterisc.Limiter, "should share limits between VMs")
+		require.Same(t, cfg.Cannon.Limiter, cfg.AsteriscKona.Limiter, "should share limits between VMs")
+	})
+
+	t.Run("MaxMemory", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeCannon, "--vm-max-memory-mb=8192", "--vm-memory-estimate-mb=1024"))
+		require.NotNil(t, cfg.Cannon.Limiter)
+	})
+}
+
+func TestNotifications(t *testing.T) {
+	t.Run("DefaultsToDisabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.False(t, cfg.Notifications.Enabled())
+		require.Equal(t, notify.DefaultPagerDutyURL, cfg.Notifications.PagerDutyURL)
+		require.Empty(t, cfg.Notifications.Events)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet,
+			"--notify-webhook-url=https://example.com/hook",
+			"--notify-slack-webhook-url=https://hooks.slack.com/services/abc",
+			"--notify-pagerduty-routing-key=key",
+			"--notify-pagerduty-url=https://pagerduty.example.com/enqueue",
+			"--notify-events=game_lost,bond_claim_failed"))
+		require.Equal(t, notify.Config{
+			WebhookURL:          "https://example.com/hook",
+			SlackWebhookURL:     "https://hooks.slack.com/services/abc",
+			PagerDutyRoutingKey: "key",
+			PagerDutyURL:        "https://pagerduty.example.com/enqueue",
+			Events:              []notify.EventType{notify.EventGameLost, notify.EventBondClaimFailed},
+		}, cfg.Notifications)
+	})
+
+	t.Run("InvalidURL", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--notify-webhook-url=example.com"))
+		require.ErrorIs(t, cfg.Check(), notify.ErrInvalidURL)
+	})
+
+	t.Run("UnknownEvent", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--notify-events=game_won"))
+		require.ErrorIs(t, cfg.Check(), notify.ErrUnknownEvent)
+	})
+}
+
 func TestUnsafeAllowInvalidPrestate(t *testing.T) {
 	t.Run("DefaultsToFalse", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgsExcept(types.TraceTypeAlphabet, "--unsafe-allow-invalid-prestate"))
@@ -850,6 +949,135 @@ func TestAdditionalBondClaimants(t *testing.T) {
 	})
 }
 
+func TestBondClaimPolicy(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.Equal(t, claims.ClaimPolicy{Multicall: predeploys.MultiCall3Addr}, cfg.BondClaimPolicy)
+	})
+
+	t.Run("MinValue", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--bond-claim-min-value", "10000000000000000000"))
+		require.Equal(t, "10000000000000000000", cfg.BondClaimPolicy.MinValue.String())
+	})
+
+	t.Run("InvalidMinValue", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid bond-claim-min-value",
+			addRequiredArgs(types.TraceTypeAlphabet, "--bond-claim-min-value", "1eth"))
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		multicall := common.Address{0xca, 0x11}
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--bond-claim-batch-size", "20", "--bond-claim-multicall", multicall.Hex()))
+		require.Equal(t, uint(20), cfg.BondClaimPolicy.BatchSize)
+		require.Equal(t, multicall, cfg.BondClaimPolicy.Multicall)
+	})
+
+	t.Run("InvalidMulticall", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid bond-claim-multicall",
+			addRequiredArgs(types.TraceTypeAlphabet, "--bond-claim-multicall", "nope"))
+	})
+
+	t.Run("Windows", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--bond-claim-windows", "02:00-04:00,22:30-23:00"))
+		require.Equal(t, []claims.TimeWindow{
+			{Start: 2 * time.Hour, End: 4 * time.Hour},
+			{Start: 22*time.Hour + 30*time.Minute, End: 23 * time.Hour},
+		}, cfg.BondClaimPolicy.Windows)
+	})
+
+	t.Run("InvalidWindows", func(t *testing.T) {
+		verifyArgsInvalid(t, claims.ErrInvalidTimeWindow.Error(),
+			addRequiredArgs(types.TraceTypeAlphabet, "--bond-claim-windows", "02:00"))
+	})
+}
+
+func TestParticipationPolicy(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.Equal(t, participation.Policy{}, cfg.ParticipationPolicy)
+	})
+
+	t.Run("Proposers", func(t *testing.T) {
+		allowed := []common.Address{{0x01}, {0x02}}
+		denied := []common.Address{{0x03}}
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet,
+			"--proposer-allowlist", allowed[0].Hex()+","+allowed[1].Hex(),
+			"--proposer-denylist", denied[0].Hex()))
+		require.Equal(t, allowed, cfg.ParticipationPolicy.AllowedProposers)
+		require.Equal(t, denied, cfg.ParticipationPolicy.DeniedProposers)
+	})
+
+	t.Run("InvalidAllowedProposer", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid proposer-allowlist",
+			addRequiredArgs(types.TraceTypeAlphabet, "--proposer-allowlist", "nope"))
+	})
+
+	t.Run("InvalidDeniedProposer", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid proposer-denylist",
+			addRequiredArgs(types.TraceTypeAlphabet, "--proposer-denylist", "nope"))
+	})
+
+	t.Run("Limits", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet,
+			"--max-concurrent-games", "5",
+			"--min-remaining-clock", "2h",
+			"--ignore-games-older-than", "72h"))
+		require.Equal(t, participation.Policy{
+			MaxGames:          5,
+			MinRemainingClock: 2 * time.Hour,
+			MaxGameAge:        72 * time.Hour,
+		}, cfg.ParticipationPolicy)
+	})
+}
+
+func TestClaimResolution(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.Equal(t, uint(config.DefaultResolutionConcurrency), cfg.ResolutionConcurrency)
+		require.Zero(t, cfg.ResolutionBatchSize)
+	})
+
+	t.Run("Concurrency", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--resolution-concurrency", "32"))
+		require.Equal(t, uint(32), cfg.ResolutionConcurrency)
+	})
+
+	t.Run("BatchSize", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--resolution-batch-size", "50"))
+		require.Equal(t, uint(50), cfg.ResolutionBatchSize)
+	})
+}
+
+func TestDryRun(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.False(t, cfg.DryRun)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--dry-run"))
+		require.True(t, cfg.DryRun)
+	})
+}
+
+func TestRunTrace(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.False(t, cfg.RunTrace)
+		require.Equal(t, config.DefaultRunTraceInterval, cfg.RunTraceInterval)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--run-trace"))
+		require.True(t, cfg.RunTrace)
+	})
+
+	t.Run("Interval", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--run-trace-interval", "2h"))
+		require.Equal(t, 2*time.Hour, cfg.RunTraceInterval)
+	})
+}
+
 func verifyArgsInvalid(t *testing.T, messageContains string, cliArgs []string) {
 	_, _, err := dryRunWithArgs(cliArgs)
 	require.ErrorContains(t, err, messageContains)
@@ -941,3 +1169,140 @@ func toArgList(req map[string]string) []string {
 	}
 	return combined
 }
+
+// testVMTraceType is provided by a VM registered as a trace provider.
+var testVMTraceType = registerTestVM("test-vm", 42)
+
+func TestVMConfig(t *testing.T) {
+	traceType := testVMTraceType
+
+	writeConfig := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "vms.json")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+
+	t.Run("NotRequiredWithoutRegisteredVM", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.Empty(t, cfg.VMs)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		path := writeConfig(t, `{"test-vm": {"vmBin": "./bin/test-vm", "server": "./bin/op-program", "prestatesUrl": "https://example.com/prestates", "infoFreq": 5}}`)
+		cfg := configForArgs(t, addRequiredArgs(traceType, "--vm-config", path, "--network", testNetwork))
+		require.Equal(t, []types.TraceType{traceType}, cfg.TraceTypes)
+		vmCfg := cfg.VMs[traceType]
+		require.Equal(t, traceType, vmCfg.VM.VmType)
+		require.Equal(t, "./bin/test-vm", vmCfg.VM.VmBin)
+		require.Equal(t, "./bin/op-program", vmCfg.VM.Server)
+		require.Equal(t, l1EthRpc, vmCfg.VM.L1)
+		require.Equal(t, l1Beacon, vmCfg.VM.L1Beacon)
+		require.Equal(t, l2EthRpc, vmCfg.VM.L2)
+		require.Equal(t, testNetwork, vmCfg.VM.Network, "should use the network of the other trace types")
+		require.Equal(t, config.DefaultAsteriscSnapshotFreq, vmCfg.VM.SnapshotFreq)
+		require.Equal(t, uint(5), vmCfg.VM.InfoFreq)
+		require.Equal(t, "https://example.com/prestates", vmCfg.AbsolutePreStateBaseURL.String())
+	})
+
+	t.Run("RollupConfig", func(t *testing.T) {
+		path := writeConfig(t, `{"test-vm": {"rollupConfig": "rollup.json", "l2Genesis": "genesis.json"}}`)
+		cfg := configForArgs(t, addRequiredArgs(traceType, "--vm-config", path, "--network", testNetwork))
+		vmCfg := cfg.VMs[traceType]
+		require.Empty(t, vmCfg.VM.Network)
+		require.Equal(t, "rollup.json", vmCfg.VM.RollupConfigPath)
+		require.Equal(t, "genesis.json", vmCfg.VM.L2GenesisPath)
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		verifyArgsInvalid(t, "failed to read vm config",
+			addRequiredArgs(traceType, "--vm-config", filepath.Join(t.TempDir(), "missing.json")))
+	})
+
+	t.Run("InvalidJSON", func(t *testing.T) {
+		verifyArgsInvalid(t, "failed to parse vm config",
+			addRequiredArgs(traceType, "--vm-config", writeConfig(t, "{")))
+	})
+
+	t.Run("InvalidPrestatesURL", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid test-vm pre states url",
+			addRequiredArgs(traceType, "--vm-config", writeConfig(t, `{"test-vm": {"prestatesUrl": ":foo"}}`)))
+	})
+}
+
+func TestDeclaredGameTypes(t *testing.T) {
+	writeConfig := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "vms.json")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		path := writeConfig(t, `{"test-declared": {"gameType": 43, "vm": "asterisc", "oracle": "kona", "vmBin": "./bin/asterisc", "server": "./bin/kona-host", "prestatesUrl": "https://example.com/prestates"}}`)
+		// The config can be loaded more than once
+		for i := 0; i < 2; i++ {
+			cfg := configForArgs(t, addRequiredArgs("test-declared", "--vm-config", path, "--network", testNetwork))
+			require.Equal(t, []types.TraceType{"test-declared"}, cfg.TraceTypes)
+			require.Equal(t, "./bin/asterisc", cfg.VMs["test-declared"].VM.VmBin)
+		}
+		require.Equal(t, types.GameType(43), types.TraceType("test-declared").GameType())
+		provider, ok := vm.LookupTraceProvider("test-declared")
+		require.True(t, ok)
+		require.IsType(t, &asterisc.StateConverter{}, provider.StateConverter())
+		require.IsType(t, &vm.KonaServerExecutor{}, provider.OracleServerExecutor())
+	})
+
+	t.Run("DefaultOracle", func(t *testing.T) {
+		path := writeConfig(t, `{"test-declared-cannon": {"gameType": 44, "vm": "cannon"}}`)
+		configForArgs(t, addRequiredArgs("test-declared-cannon", "--vm-config", path, "--network", testNetwork))
+		provider, ok := vm.LookupTraceProvider("test-declared-cannon")
+		require.True(t, ok)
+		require.IsType(t, &cannon.StateConverter{}, provider.StateConverter())
+		require.IsType(t, &vm.OpProgramServerExecutor{}, provider.OracleServerExecutor())
+	})
+
+	t.Run("UnknownVM", func(t *testing.T) {
+		verifyArgsInvalid(t, "unknown vm",
+			addRequiredArgs(types.TraceTypeAlphabet, "--vm-config", writeConfig(t, `{"test-unknown-vm": {"gameType": 45, "vm": "foo"}}`)))
+	})
+
+	t.Run("UnknownOracle", func(t *testing.T) {
+		verifyArgsInvalid(t, "unknown oracle",
+			addRequiredArgs(types.TraceTypeAlphabet, "--vm-config", writeConfig(t, `{"test-unknown-oracle": {"gameType": 46, "vm": "cannon", "oracle": "foo"}}`)))
+	})
+
+	t.Run("BuiltInGameType", func(t *testing.T) {
+		verifyArgsInvalid(t, "game type already registered",
+			addRequiredArgs(types.TraceTypeAlphabet, "--vm-config", writeConfig(t, `{"test-built-in": {"gameType": 0, "vm": "cannon"}}`)))
+	})
+
+	t.Run("BuiltInTraceType", func(t *testing.T) {
+		verifyArgsInvalid(t, "trace type already registered",
+			addRequiredArgs(types.TraceTypeAlphabet, "--vm-config", writeConfig(t, `{"cannon": {"gameType": 47, "vm": "cannon"}}`)))
+	})
+
+	t.Run("VMWithoutGameType", func(t *testing.T) {
+		verifyArgsInvalid(t, "without declaring a game type",
+			addRequiredArgs(types.TraceTypeAlphabet, "--vm-config", writeConfig(t, `{"test-no-game-type": {"vm": "cannon"}}`)))
+	})
+}
+
+type stubVM struct {
+	vm.TraceProvider
+	traceType types.TraceType
+	gameType  types.GameType
+}
+
+func (s *stubVM) TraceType() types.TraceType {
+	return s.traceType
+}
+
+func (s *stubVM) GameType() types.GameType {
+	return s.gameType
+}
+
+func registerTestVM(traceType types.TraceType, gameType types.GameType) types.TraceType {
+	if err := vm.RegisterTraceProvider(&stubVM{traceType: traceType, gameType: gameType}); err != nil {
+		panic(err)
+	}
+	return traceType
+}
@@ -8,11 +8,16 @@ import (
 	"slices"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/prestates"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/participation"
+	"github.com/ethereum-optimism/optimism/op-challenger/notify"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -26,6 +31,7 @@ var (
 	ErrMissingCannonServer              = errors.New("missing cannon server")
 	ErrMissingCannonAbsolutePreState    = errors.New("missing cannon absolute pre-state")
 	ErrCannonAbsolutePreStateAndBaseURL = errors.New("only specify one of cannon absolute pre-state and cannon absolute pre-state base URL")
+	ErrInvalidCannonPreStateBaseURL     = errors.New("invalid cannon absolute pre-state base URL")
 	ErrMissingL1EthRPC                  = errors.New("missing l1 eth rpc url")
 	ErrMissingL1Beacon                  = errors.New("missing l1 beacon url")
 	ErrMissingGameFactoryAddress        = errors.New("missing game factory address")
@@ -42,6 +48,7 @@ var (
 	ErrMissingAsteriscServer              = errors.New("missing asterisc server")
 	ErrMissingAsteriscAbsolutePreState    = errors.New("missing asterisc absolute pre-state")
 	ErrAsteriscAbsolutePreStateAndBaseURL = errors.New("only specify one of asterisc absolute pre-state and asterisc absolute pre-state base URL")
+	ErrInvalidAsteriscPreStateBaseURL     = errors.New("invalid asterisc absolute pre-state base URL")
 	ErrMissingAsteriscSnapshotFreq        = errors.New("missing asterisc snapshot freq")
 	ErrMissingAsteriscInfoFreq            = errors.New("missing asterisc info freq")
 	ErrMissingAsteriscRollupConfig        = errors.New("missing asterisc network or rollup config path")
@@ -49,6 +56,16 @@ var (
 	ErrAsteriscNetworkAndRollupConfig     = errors.New("only specify one of network or rollup config path")
 	ErrAsteriscNetworkAndL2Genesis        = errors.New("only specify one of network or l2 genesis path")
 	ErrAsteriscNetworkUnknown             = errors.New("unknown asterisc network")
+
+	ErrNegativeBondClaimMinValue = errors.New("bond claim min value must not be negative")
+	ErrMissingBondClaimMulticall = errors.New("missing multicall address to batch bond claims")
+
+	ErrProposerAllowedAndDenied = errors.New("proposer is both allowed and denied")
+
+	ErrResolutionConcurrencyZero  = errors.New("resolution concurrency must not be 0")
+	ErrMissingResolutionMulticall = errors.New("missing multicall address to batch claim resolutions")
+
+	ErrRunTraceIntervalZero = errors.New("run trace interval must not be 0")
 )
 
 const (
@@ -64,6 +81,13 @@ const (
 	// buffer to monitor games to ensure bonds are claimed.
 	DefaultGameWindow   = time.Duration(28 * 24 * time.Hour)
 	DefaultMaxPendingTx = 10
+	// DefaultResolutionConcurrency is the default maximum number of claims of a game to check for resolution concurrently.
+	DefaultResolutionConcurrency = 8
+	// DefaultRunTraceInterval is the default minimum time between runs of each trace provider by run-trace.
+	DefaultRunTraceInterval = time.Minute
+	// DefaultVMMemoryEstimateMB is the default memory in MiB assumed to be used by a VM execution until the memory
+	// used by a previous execution of the same VM is known.
+	DefaultVMMemoryEstimateMB = 2048
 )
 
 // Config is a well typed config that is parsed from the CLI params.
@@ -76,16 +100,29 @@ type Config struct {
 	GameAllowlist        []common.Address // Allowlist of fault game addresses
 	GameWindow           time.Duration    // Maximum time duration to look for games to progress
 	Datadir              string           // Data Directory
+	GameDataRetention    time.Duration    // How long to keep the data of games that are no longer active (0 == remove immediately)
+	GameDataMaxSize      uint64           // Maximum total size in bytes of game data before removing data of inactive games early (0 == no limit)
 	MaxConcurrency       uint             // Maximum number of threads to use when progressing games
 	PollInterval         time.Duration    // Polling interval for latest-block subscription when using an HTTP RPC provider
 	AllowInvalidPrestate bool             // Whether to allow responding to games where the prestate does not match
+	DryRun               bool             // Whether to log the transactions the challenger would send instead of sending them
 
-	AdditionalBondClaimants []common.Address // List of addresses to claim bonds for in addition to the tx manager sender
+	ParticipationPolicy participation.Policy // Policy controlling which games to play
+
+	AdditionalBondClaimants []common.Address   // List of addresses to claim bonds for in addition to the tx manager sender
+	BondClaimPolicy         claims.ClaimPolicy // Policy controlling when and how bonds are claimed
 
 	SelectiveClaimResolution bool // Whether to only resolve claims for the claimants in AdditionalBondClaimants union [TxSender.From()]
+	ResolutionConcurrency    uint // Maximum number of claims of a game to check for resolution concurrently
+	ResolutionBatchSize      uint // Maximum number of claim resolutions to batch into one transaction via the multicall contract (0 or 1 == no batching)
 
 	TraceTypes []types.TraceType // Type of traces supported
 
+	RunTrace         bool          // Whether to continuously run the VM trace providers in the background to validate them
+	RunTraceInterval time.Duration // Minimum time between runs of each trace provider by run-trace
+
+	Notifications notify.Config // Where to send notifications of key events in games, and which events to send them for
+
 	RollupRpc string // L2 Rollup RPC Url
 
 	L2Rpc string // L2 RPC Url
@@ -103,6 +140,9 @@ type Config struct {
 	AsteriscKonaAbsolutePreState        string   // File to load the absolute pre-state for AsteriscKona traces from
 	AsteriscKonaAbsolutePreStateBaseURL *url.URL // Base URL to retrieve absolute pre-states for AsteriscKona traces from
 
+	// VMs configures the VMs registered as trace providers, by trace type
+	VMs map[types.TraceType]VMConfig
+
 	MaxPendingTx uint64 // Maximum number of pending transactions (0 == no limit)
 
 	TxMgrConfig   txmgr.CLIConfig
@@ -132,6 +172,14 @@ func NewConfig(
 
 		MaxPendingTx: DefaultMaxPendingTx,
 
+		BondClaimPolicy: claims.ClaimPolicy{Multicall: predeploys.MultiCall3Addr},
+
+		Notifications: notify.Config{PagerDutyURL: notify.DefaultPagerDutyURL},
+
+		ResolutionConcurrency: DefaultResolutionConcurrency,
+
+		RunTraceInterval: DefaultRunTraceInterval,
+
 		TxMgrConfig:   txmgr.NewCLIConfig(l1EthRpc, txmgr.DefaultChallengerFlagValues),
 		MetricsConfig: opmetrics.DefaultCLIConfig(),
 		PprofConfig:   oppprof.DefaultCLIConfig(),
@@ -228,6 +276,11 @@ func (c Config) Check() error {
 		if c.CannonAbsolutePreState != "" && c.CannonAbsolutePreStateBaseURL != nil {
 			return ErrCannonAbsolutePreStateAndBaseURL
 		}
+		if c.CannonAbsolutePreStateBaseURL != nil {
+			if err := prestates.CheckBaseURL(c.CannonAbsolutePreStateBaseURL); err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidCannonPreStateBaseURL, err)
+			}
+		}
 		if c.Cannon.SnapshotFreq == 0 {
 			return ErrMissingCannonSnapshotFreq
 		}
@@ -266,6 +319,11 @@ func (c Config) Check() error {
 		if c.AsteriscAbsolutePreState != "" && c.AsteriscAbsolutePreStateBaseURL != nil {
 			return ErrAsteriscAbsolutePreStateAndBaseURL
 		}
+		if c.AsteriscAbsolutePreStateBaseURL != nil {
+			if err := prestates.CheckBaseURL(c.AsteriscAbsolutePreStateBaseURL); err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidAsteriscPreStateBaseURL, err)
+			}
+		}
 		if c.Asterisc.SnapshotFreq == 0 {
 			return ErrMissingAsteriscSnapshotFreq
 		}
@@ -273,6 +331,32 @@ func (c Config) Check() error {
 			return ErrMissingAsteriscInfoFreq
 		}
 	}
+	for _, proposer := range c.ParticipationPolicy.AllowedProposers {
+		if slices.Contains(c.ParticipationPolicy.DeniedProposers, proposer) {
+			return fmt.Errorf("%w: %v", ErrProposerAllowedAndDenied, proposer)
+		}
+	}
+	if err := c.checkVMs(); err != nil {
+		return err
+	}
+	if c.BondClaimPolicy.MinValue != nil && c.BondClaimPolicy.MinValue.Sign() < 0 {
+		return ErrNegativeBondClaimMinValue
+	}
+	if c.BondClaimPolicy.BatchSize > 1 && c.BondClaimPolicy.Multicall == (common.Address{}) {
+		return ErrMissingBondClaimMulticall
+	}
+	if c.ResolutionConcurrency == 0 {
+		return ErrResolutionConcurrencyZero
+	}
+	if c.ResolutionBatchSize > 1 && c.BondClaimPolicy.Multicall == (common.Address{}) {
+		return ErrMissingResolutionMulticall
+	}
+	if c.RunTraceInterval == 0 {
+		return ErrRunTraceIntervalZero
+	}
+	if err := c.Notifications.Check(); err != nil {
+		return err
+	}
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"math/big"
 	"net/url"
 	"runtime"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/notify"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
@@ -154,6 +156,19 @@ func TestCannonRequiredArgs(t *testing.T) {
 			require.ErrorIs(t, config.Check(), ErrCannonAbsolutePreStateAndBaseURL)
 		})
 
+		t.Run(fmt.Sprintf("TestInvalidCannonAbsolutePreStateBaseURL-%v", traceType), func(t *testing.T) {
+			for _, invalid := range []string{"s3:///prestates", "gs:///prestates", "ftp://localhost/prestates", "/prestates"} {
+				config := validConfig(traceType)
+				config.CannonAbsolutePreState = ""
+				config.CannonAbsolutePreStateBaseURL, _ = url.Parse(invalid)
+				require.ErrorIs(t, config.Check(), ErrInvalidCannonPreStateBaseURL, invalid)
+			}
+			config := validConfig(traceType)
+			config.CannonAbsolutePreState = ""
+			config.CannonAbsolutePreStateBaseURL, _ = url.Parse("s3://bucket/prestates?region=us-east-1")
+			require.NoError(t, config.Check())
+		})
+
 		t.Run(fmt.Sprintf("TestL2RpcRequired-%v", traceType), func(t *testing.T) {
 			config := validConfig(traceType)
 			config.L2Rpc = ""
@@ -265,6 +280,13 @@ func TestAsteriscRequiredArgs(t *testing.T) {
 			require.ErrorIs(t, config.Check(), ErrAsteriscAbsolutePreStateAndBaseURL)
 		})
 
+		t.Run(fmt.Sprintf("TestInvalidAsteriscAbsolutePreStateBaseURL-%v", traceType), func(t *testing.T) {
+			config := validConfig(traceType)
+			config.AsteriscAbsolutePreState = ""
+			config.AsteriscAbsolutePreStateBaseURL, _ = url.Parse("gs:///prestates")
+			require.ErrorIs(t, config.Check(), ErrInvalidAsteriscPreStateBaseURL)
+		})
+
 		t.Run(fmt.Sprintf("TestL2RpcRequired-%v", traceType), func(t *testing.T) {
 			config := validConfig(traceType)
 			config.L2Rpc = ""
@@ -351,6 +373,79 @@ func TestMaxConcurrency(t *testing.T) {
 	})
 }
 
+func TestBondClaimPolicy(t *testing.T) {
+	t.Run("NegativeMinValue", func(t *testing.T) {
+		config := validConfig(types.TraceTypeAlphabet)
+		config.BondClaimPolicy.MinValue = big.NewInt(-1)
+		require.ErrorIs(t, config.Check(), ErrNegativeBondClaimMinValue)
+	})
+
+	t.Run("BatchRequiresMulticall", func(t *testing.T) {
+		config := validConfig(types.TraceTypeAlphabet)
+		config.BondClaimPolicy.BatchSize = 2
+		config.BondClaimPolicy.Multicall = common.Address{}
+		require.ErrorIs(t, config.Check(), ErrMissingBondClaimMulticall)
+	})
+
+	t.Run("NoBatchWithoutMulticall", func(t *testing.T) {
+		config := validConfig(types.TraceTypeAlphabet)
+		config.BondClaimPolicy.BatchSize = 1
+		config.BondClaimPolicy.Multicall = common.Address{}
+		require.NoError(t, config.Check())
+	})
+}
+
+func TestParticipationPolicy(t *testing.T) {
+	t.Run("ProposerAllowedAndDenied", func(t *testing.T) {
+		config := validConfig(types.TraceTypeAlphabet)
+		config.ParticipationPolicy.AllowedProposers = []common.Address{{0x01}, {0x02}}
+		config.ParticipationPolicy.DeniedProposers = []common.Address{{0x02}}
+		require.ErrorIs(t, config.Check(), ErrProposerAllowedAndDenied)
+	})
+}
+
+func TestClaimResolution(t *testing.T) {
+	t.Run("ConcurrencyZero", func(t *testing.T) {
+		config := validConfig(types.TraceTypeAlphabet)
+		config.ResolutionConcurrency = 0
+		require.ErrorIs(t, config.Check(), ErrResolutionConcurrencyZero)
+	})
+
+	t.Run("BatchRequiresMulticall", func(t *testing.T) {
+		config := validConfig(types.TraceTypeAlphabet)
+		config.ResolutionBatchSize = 2
+		config.BondClaimPolicy.Multicall = common.Address{}
+		require.ErrorIs(t, config.Check(), ErrMissingResolutionMulticall)
+	})
+}
+
+func TestRunTraceInterval(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		config := validConfig(types.TraceTypeCannon)
+		require.Equal(t, DefaultRunTraceInterval, config.RunTraceInterval)
+	})
+
+	t.Run("Zero", func(t *testing.T) {
+		config := validConfig(types.TraceTypeCannon)
+		config.RunTraceInterval = 0
+		require.ErrorIs(t, config.Check(), ErrRunTraceIntervalZero)
+	})
+}
+
+func TestNotifications(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		config := validConfig(types.TraceTypeCannon)
+		require.False(t, config.Notifications.Enabled())
+		require.Equal(t, notify.DefaultPagerDutyURL, config.Notifications.PagerDutyURL)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		config := validConfig(types.TraceTypeCannon)
+		config.Notifications.WebhookURL = "not a url"
+		require.ErrorIs(t, config.Check(), notify.ErrInvalidURL)
+	})
+}
+
 func TestHttpPollInterval(t *testing.T) {
 	t.Run("Default", func(t *testing.T) {
 		config := validConfig(types.TraceTypeAlphabet)
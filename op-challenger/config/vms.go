@@ -0,0 +1,108 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/prestates"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+)
+
+var (
+	ErrMissingVMConfig               = errors.New("missing vm config")
+	ErrMissingVMBin                  = errors.New("missing vm bin")
+	ErrMissingVMServer               = errors.New("missing vm server")
+	ErrMissingVMAbsolutePreState     = errors.New("missing vm absolute pre-state")
+	ErrVMAbsolutePreStateAndBaseURL  = errors.New("only specify one of vm absolute pre-state and vm absolute pre-state base URL")
+	ErrInvalidVMPreStateBaseURL      = errors.New("invalid vm absolute pre-state base URL")
+	ErrMissingVMSnapshotFreq         = errors.New("missing vm snapshot freq")
+	ErrMissingVMInfoFreq             = errors.New("missing vm info freq")
+	ErrMissingVMRollupConfig         = errors.New("missing vm network or rollup config path")
+	ErrMissingVML2Genesis            = errors.New("missing vm network or l2 genesis path")
+	ErrVMNetworkAndRollupConfig      = errors.New("only specify one of vm network or rollup config path")
+	ErrVMNetworkAndL2Genesis         = errors.New("only specify one of vm network or l2 genesis path")
+	ErrVMNetworkUnknown              = errors.New("unknown vm network")
+	ErrVMConfigForUnregisteredTraces = errors.New("vm config for trace type without a registered trace provider")
+)
+
+// VMConfig configures a VM registered as a [vm.TraceProvider] for a trace type that isn't built in to the challenger.
+type VMConfig struct {
+	VM                      vm.Config
+	AbsolutePreState        string   // File to load the absolute pre-state for the VM traces from
+	AbsolutePreStateBaseURL *url.URL // Base URL to retrieve absolute pre-states for the VM traces from
+}
+
+func (c Config) checkVMs() error {
+	for traceType := range c.VMs {
+		if !registeredVM(traceType) {
+			return fmt.Errorf("%w: %v", ErrVMConfigForUnregisteredTraces, traceType)
+		}
+	}
+	for _, traceType := range c.TraceTypes {
+		if !registeredVM(traceType) {
+			continue
+		}
+		vmCfg, ok := c.VMs[traceType]
+		if !ok {
+			return fmt.Errorf("%w: %v", ErrMissingVMConfig, traceType)
+		}
+		if err := vmCfg.check(); err != nil {
+			return fmt.Errorf("invalid %v vm config: %w", traceType, err)
+		}
+	}
+	return nil
+}
+
+func (c VMConfig) check() error {
+	if c.VM.VmBin == "" {
+		return ErrMissingVMBin
+	}
+	if c.VM.Server == "" {
+		return ErrMissingVMServer
+	}
+	if c.VM.Network == "" {
+		if c.VM.RollupConfigPath == "" {
+			return ErrMissingVMRollupConfig
+		}
+		if c.VM.L2GenesisPath == "" {
+			return ErrMissingVML2Genesis
+		}
+	} else {
+		if c.VM.RollupConfigPath != "" {
+			return ErrVMNetworkAndRollupConfig
+		}
+		if c.VM.L2GenesisPath != "" {
+			return ErrVMNetworkAndL2Genesis
+		}
+		if ch := chaincfg.ChainByName(c.VM.Network); ch == nil {
+			return fmt.Errorf("%w: %v", ErrVMNetworkUnknown, c.VM.Network)
+		}
+	}
+	if c.AbsolutePreState == "" && c.AbsolutePreStateBaseURL == nil {
+		return ErrMissingVMAbsolutePreState
+	}
+	if c.AbsolutePreState != "" && c.AbsolutePreStateBaseURL != nil {
+		return ErrVMAbsolutePreStateAndBaseURL
+	}
+	if c.AbsolutePreStateBaseURL != nil {
+		if err := prestates.CheckBaseURL(c.AbsolutePreStateBaseURL); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidVMPreStateBaseURL, err)
+		}
+	}
+	if c.VM.SnapshotFreq == 0 {
+		return ErrMissingVMSnapshotFreq
+	}
+	if c.VM.InfoFreq == 0 {
+		return ErrMissingVMInfoFreq
+	}
+	return nil
+}
+
+// registeredVM returns whether the trace type is provided by a VM registered as a trace provider.
+func registeredVM(traceType types.TraceType) bool {
+	_, ok := vm.LookupTraceProvider(traceType)
+	return ok
+}
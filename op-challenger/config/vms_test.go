@@ -0,0 +1,107 @@
+package config
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/stretchr/testify/require"
+)
+
+// testVMTraceType is provided by a VM registered as a trace provider.
+var testVMTraceType = registerTestVM("test-vm", 42)
+
+type stubVM struct {
+	vm.TraceProvider
+	traceType types.TraceType
+	gameType  types.GameType
+}
+
+func (s *stubVM) TraceType() types.TraceType {
+	return s.traceType
+}
+
+func (s *stubVM) GameType() types.GameType {
+	return s.gameType
+}
+
+func registerTestVM(traceType types.TraceType, gameType types.GameType) types.TraceType {
+	if err := vm.RegisterTraceProvider(&stubVM{traceType: traceType, gameType: gameType}); err != nil {
+		panic(err)
+	}
+	return traceType
+}
+
+func validVMConfig() Config {
+	cfg := validConfig(testVMTraceType)
+	cfg.VMs = map[types.TraceType]VMConfig{
+		testVMTraceType: {
+			VM: vm.Config{
+				VmType:       testVMTraceType,
+				VmBin:        "./bin/test-vm",
+				Server:       "./bin/op-program",
+				Network:      "mainnet",
+				SnapshotFreq: DefaultAsteriscSnapshotFreq,
+				InfoFreq:     DefaultAsteriscInfoFreq,
+			},
+			AbsolutePreStateBaseURL: validAsteriscAbsolutePreStateBaseURL,
+		},
+	}
+	return cfg
+}
+
+func TestRegisteredVM(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		require.NoError(t, validVMConfig().Check())
+	})
+
+	t.Run("MissingConfig", func(t *testing.T) {
+		cfg := validVMConfig()
+		cfg.VMs = nil
+		require.ErrorIs(t, cfg.Check(), ErrMissingVMConfig)
+	})
+
+	t.Run("NotEnabled", func(t *testing.T) {
+		cfg := validConfig(types.TraceTypeAlphabet)
+		cfg.VMs = validVMConfig().VMs
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("UnregisteredTraceType", func(t *testing.T) {
+		cfg := validVMConfig()
+		cfg.VMs["unknown-vm"] = cfg.VMs[testVMTraceType]
+		require.ErrorIs(t, cfg.Check(), ErrVMConfigForUnregisteredTraces)
+	})
+
+	tests := []struct {
+		name     string
+		modify   func(cfg *VMConfig)
+		expected error
+	}{
+		{"MissingBin", func(cfg *VMConfig) { cfg.VM.VmBin = "" }, ErrMissingVMBin},
+		{"MissingServer", func(cfg *VMConfig) { cfg.VM.Server = "" }, ErrMissingVMServer},
+		{"MissingRollupConfig", func(cfg *VMConfig) { cfg.VM.Network = "" }, ErrMissingVMRollupConfig},
+		{"MissingL2Genesis", func(cfg *VMConfig) {
+			cfg.VM.Network = ""
+			cfg.VM.RollupConfigPath = "rollup.json"
+		}, ErrMissingVML2Genesis},
+		{"NetworkAndRollupConfig", func(cfg *VMConfig) { cfg.VM.RollupConfigPath = "rollup.json" }, ErrVMNetworkAndRollupConfig},
+		{"UnknownNetwork", func(cfg *VMConfig) { cfg.VM.Network = "unknown" }, ErrVMNetworkUnknown},
+		{"MissingPrestate", func(cfg *VMConfig) { cfg.AbsolutePreStateBaseURL = nil }, ErrMissingVMAbsolutePreState},
+		{"PrestateAndBaseURL", func(cfg *VMConfig) { cfg.AbsolutePreState = "pre.json" }, ErrVMAbsolutePreStateAndBaseURL},
+		{"InvalidBaseURL", func(cfg *VMConfig) { cfg.AbsolutePreStateBaseURL, _ = url.Parse("s3:///prestates") }, ErrInvalidVMPreStateBaseURL},
+		{"MissingSnapshotFreq", func(cfg *VMConfig) { cfg.VM.SnapshotFreq = 0 }, ErrMissingVMSnapshotFreq},
+		{"MissingInfoFreq", func(cfg *VMConfig) { cfg.VM.InfoFreq = 0 }, ErrMissingVMInfoFreq},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := validVMConfig()
+			vmCfg := cfg.VMs[testVMTraceType]
+			test.modify(&vmCfg)
+			cfg.VMs[testVMTraceType] = vmCfg
+			require.ErrorIs(t, cfg.Check(), test.expected)
+		})
+	}
+}
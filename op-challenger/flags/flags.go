@@ -2,13 +2,17 @@ package flags
 
 import (
 	"fmt"
+	"math/big"
 	"net/url"
 	"runtime"
 	"slices"
 	"strings"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/participation"
+	"github.com/ethereum-optimism/optimism/op-challenger/notify"
 	"github.com/ethereum-optimism/optimism/op-service/flags"
 	"github.com/ethereum-optimism/superchain-registry/superchain"
 	"github.com/ethereum/go-ethereum/common"
@@ -22,6 +26,7 @@ import (
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
@@ -100,6 +105,52 @@ var (
 		Usage:   "List of addresses to claim bonds for, in addition to the configured transaction sender",
 		EnvVars: prefixEnvVars("ADDITIONAL_BOND_CLAIMANTS"),
 	}
+	BondClaimMinValueFlag = &cli.StringFlag{
+		Name:    "bond-claim-min-value",
+		Usage:   "Minimum total credit in wei of the claimable bonds to claim them. Bonds with any credit are claimed if not set.",
+		EnvVars: prefixEnvVars("BOND_CLAIM_MIN_VALUE"),
+	}
+	BondClaimBatchSizeFlag = &cli.UintFlag{
+		Name:    "bond-claim-batch-size",
+		Usage:   "Maximum number of bond claims to batch into one transaction via the multicall contract. 0 or 1 claims bonds in separate transactions.",
+		EnvVars: prefixEnvVars("BOND_CLAIM_BATCH_SIZE"),
+	}
+	BondClaimMulticallFlag = &cli.StringFlag{
+		Name:    "bond-claim-multicall",
+		Usage:   "Address of the Multicall3 contract used to batch bond claims and claim resolutions",
+		EnvVars: prefixEnvVars("BOND_CLAIM_MULTICALL"),
+		Value:   predeploys.MultiCall3,
+	}
+	BondClaimWindowsFlag = &cli.StringSliceFlag{
+		Name:    "bond-claim-windows",
+		Usage:   "Daily time windows in UTC to claim bonds in, in the format HH:MM-HH:MM. Bonds are claimed at any time if not set.",
+		EnvVars: prefixEnvVars("BOND_CLAIM_WINDOWS"),
+	}
+	ProposerAllowlistFlag = &cli.StringSliceFlag{
+		Name:    "proposer-allowlist",
+		Usage:   "List of proposers to play the games of. Games of any proposer are played if not set.",
+		EnvVars: prefixEnvVars("PROPOSER_ALLOWLIST"),
+	}
+	ProposerDenylistFlag = &cli.StringSliceFlag{
+		Name:    "proposer-denylist",
+		Usage:   "List of proposers to not play the games of",
+		EnvVars: prefixEnvVars("PROPOSER_DENYLIST"),
+	}
+	MaxConcurrentGamesFlag = &cli.UintFlag{
+		Name:    "max-concurrent-games",
+		Usage:   "Maximum number of in progress games to play at once. Games already being played are always played until resolved. 0 disables the limit.",
+		EnvVars: prefixEnvVars("MAX_CONCURRENT_GAMES"),
+	}
+	MinRemainingClockFlag = &cli.DurationFlag{
+		Name:    "min-remaining-clock",
+		Usage:   "Minimum time left to counter a claim in a game to start playing it",
+		EnvVars: prefixEnvVars("MIN_REMAINING_CLOCK"),
+	}
+	IgnoreGamesOlderThanFlag = &cli.DurationFlag{
+		Name:    "ignore-games-older-than",
+		Usage:   "Maximum time since a game was created to start playing it. 0 disables the limit.",
+		EnvVars: prefixEnvVars("IGNORE_GAMES_OLDER_THAN"),
+	}
 	CannonNetworkFlag = &cli.StringFlag{
 		Name:    "cannon-network",
 		Usage:   fmt.Sprintf("Deprecated: Use %v instead", flags.NetworkFlagName),
@@ -132,7 +183,7 @@ var (
 	}
 	CannonPreStatesURLFlag = &cli.StringFlag{
 		Name: "cannon-prestates-url",
-		Usage: "Base URL to absolute prestates to use when generating trace data. " +
+		Usage: "Base URL to absolute prestates to use when generating trace data, or s3://bucket/prefix or gs://bucket/prefix. " +
 			"Prestates in this directory should be name as <commitment>.json (cannon trace type only)",
 		EnvVars: prefixEnvVars("CANNON_PRESTATES_URL"),
 	}
@@ -195,13 +246,13 @@ var (
 	}
 	AsteriscPreStatesURLFlag = &cli.StringFlag{
 		Name: "asterisc-prestates-url",
-		Usage: "Base URL to absolute prestates to use when generating trace data. " +
+		Usage: "Base URL to absolute prestates to use when generating trace data, or s3://bucket/prefix or gs://bucket/prefix. " +
 			"Prestates in this directory should be name as <commitment>.json (asterisc trace type only)",
 		EnvVars: prefixEnvVars("ASTERISC_PRESTATES_URL"),
 	}
 	AsteriscKonaPreStatesURLFlag = &cli.StringFlag{
 		Name: "asterisc-kona-prestates-url",
-		Usage: "Base URL to absolute prestates to use when generating trace data. " +
+		Usage: "Base URL to absolute prestates to use when generating trace data, or s3://bucket/prefix or gs://bucket/prefix. " +
 			"Prestates in this directory should be name as <commitment>.json (asterisc-kona trace type only)",
 		EnvVars: prefixEnvVars("ASTERISC_KONA_PRESTATES_URL"),
 	}
@@ -224,11 +275,108 @@ var (
 		EnvVars: prefixEnvVars("GAME_WINDOW"),
 		Value:   config.DefaultGameWindow,
 	}
+	GameDataRetentionFlag = &cli.DurationFlag{
+		Name: "game-data-retention",
+		Usage: "How long to keep the data directory of each game, including VM snapshots, proofs and preimages, " +
+			"after the game is resolved or leaves the game window. By default it is removed immediately.",
+		EnvVars: prefixEnvVars("GAME_DATA_RETENTION"),
+	}
+	GameDataMaxSizeFlag = &cli.Uint64Flag{
+		Name: "game-data-max-size-mb",
+		Usage: "Maximum total size in MiB of game data directories. When exceeded, the data of resolved games is removed " +
+			"before its retention period ends, oldest first. Data of games in progress is never removed. 0 for no limit.",
+		EnvVars: prefixEnvVars("GAME_DATA_MAX_SIZE_MB"),
+	}
+	VMMaxCPUsFlag = &cli.UintFlag{
+		Name: "vm-max-cpus",
+		Usage: "Maximum number of VM executions (cannon, asterisc or other VMs) to run concurrently. " +
+			"Executions beyond the limit wait, with those for games with the earliest deadlines starting first. 0 for no limit.",
+		EnvVars: prefixEnvVars("VM_MAX_CPUS"),
+	}
+	VMMaxMemoryFlag = &cli.Uint64Flag{
+		Name: "vm-max-memory-mb",
+		Usage: "Maximum total memory in MiB to be used by concurrent VM executions. " +
+			"Executions that would exceed the limit wait, with those for games with the earliest deadlines starting first. 0 for no limit.",
+		EnvVars: prefixEnvVars("VM_MAX_MEMORY_MB"),
+	}
+	VMMemoryEstimateFlag = &cli.Uint64Flag{
+		Name: "vm-memory-estimate-mb",
+		Usage: "Memory in MiB assumed to be used by a VM execution for vm-max-memory-mb, until the memory used by " +
+			"a previous execution of the same VM is known",
+		EnvVars: prefixEnvVars("VM_MEMORY_ESTIMATE_MB"),
+		Value:   config.DefaultVMMemoryEstimateMB,
+	}
 	SelectiveClaimResolutionFlag = &cli.BoolFlag{
 		Name:    "selective-claim-resolution",
 		Usage:   "Only resolve claims for the configured claimants",
 		EnvVars: prefixEnvVars("SELECTIVE_CLAIM_RESOLUTION"),
 	}
+	ResolutionConcurrencyFlag = &cli.UintFlag{
+		Name:    "resolution-concurrency",
+		Usage:   "Maximum number of claims of a game to check for resolution concurrently",
+		EnvVars: prefixEnvVars("RESOLUTION_CONCURRENCY"),
+		Value:   config.DefaultResolutionConcurrency,
+	}
+	ResolutionBatchSizeFlag = &cli.UintFlag{
+		Name: "resolution-batch-size",
+		Usage: "Maximum number of claim resolutions, across games, to batch into one transaction via the multicall contract. " +
+			"0 or 1 resolves claims in separate transactions.",
+		EnvVars: prefixEnvVars("RESOLUTION_BATCH_SIZE"),
+	}
+	DryRunFlag = &cli.BoolFlag{
+		Name: "dry-run",
+		Usage: "Calculate and log the moves, steps, resolutions and bond claims the challenger would make, without " +
+			"sending any transactions. The same actions are logged again on each update as they have no effect.",
+		EnvVars: prefixEnvVars("DRY_RUN"),
+	}
+	RunTraceFlag = &cli.BoolFlag{
+		Name: "run-trace",
+		Usage: "Continuously run the cannon, asterisc and registered VM trace providers in the background against recent " +
+			"safe chain data, without posting claims, to detect prestate and VM issues before they're needed in a game",
+		EnvVars: prefixEnvVars("RUN_TRACE"),
+	}
+	RunTraceIntervalFlag = &cli.DurationFlag{
+		Name:    "run-trace-interval",
+		Usage:   "Minimum time between runs of each trace provider when running traces, including in the run-trace command",
+		EnvVars: prefixEnvVars("RUN_TRACE_INTERVAL"),
+		Value:   config.DefaultRunTraceInterval,
+	}
+	NotifyWebhookURLFlag = &cli.StringFlag{
+		Name:    "notify-webhook-url",
+		Usage:   "URL to post JSON notifications of key events in games to",
+		EnvVars: prefixEnvVars("NOTIFY_WEBHOOK_URL"),
+	}
+	NotifySlackWebhookURLFlag = &cli.StringFlag{
+		Name:    "notify-slack-webhook-url",
+		Usage:   "Slack incoming webhook URL to post notifications of key events in games to",
+		EnvVars: prefixEnvVars("NOTIFY_SLACK_WEBHOOK_URL"),
+	}
+	NotifyPagerDutyRoutingKeyFlag = &cli.StringFlag{
+		Name:    "notify-pagerduty-routing-key",
+		Usage:   "PagerDuty Events API v2 routing key to trigger alerts for key events in games with",
+		EnvVars: prefixEnvVars("NOTIFY_PAGERDUTY_ROUTING_KEY"),
+	}
+	NotifyPagerDutyURLFlag = &cli.StringFlag{
+		Name:    "notify-pagerduty-url",
+		Usage:   "PagerDuty Events API v2 endpoint to send alerts to",
+		EnvVars: prefixEnvVars("NOTIFY_PAGERDUTY_URL"),
+		Value:   notify.DefaultPagerDutyURL,
+	}
+	NotifyEventsFlag = &cli.StringSliceFlag{
+		Name: "notify-events",
+		Usage: "List of events to send notifications for. Valid options: " + openum.EnumString(notify.EventTypes) +
+			". If empty, notifications are sent for all events.",
+		EnvVars: prefixEnvVars("NOTIFY_EVENTS"),
+	}
+	VMConfigFlag = &cli.StringFlag{
+		Name: "vm-config",
+		Usage: "Path to a JSON file configuring the VMs added as trace providers for trace types that aren't built in, by trace type. " +
+			"Each VM config sets vmBin, server and prestate or prestatesUrl, and optionally snapshotFreq, infoFreq, debugInfo, " +
+			"binarySnapshots, network, rollupConfig and l2Genesis. " +
+			"A VM config that sets gameType declares a new trace type for the game type, played with the built in vm (cannon or asterisc) " +
+			"and oracle server (op-program or kona, default op-program).",
+		EnvVars: prefixEnvVars("VM_CONFIG"),
+	}
 	UnsafeAllowInvalidPrestate = &cli.BoolFlag{
 		Name:    "unsafe-allow-invalid-prestate",
 		Usage:   "Allow responding to games where the absolute prestate is configured incorrectly. THIS IS UNSAFE!",
@@ -255,7 +403,16 @@ var optionalFlags = []cli.Flag{
 	MaxPendingTransactionsFlag,
 	HTTPPollInterval,
 	AdditionalBondClaimants,
+	BondClaimMinValueFlag,
+	BondClaimBatchSizeFlag,
+	BondClaimMulticallFlag,
+	BondClaimWindowsFlag,
 	GameAllowlistFlag,
+	ProposerAllowlistFlag,
+	ProposerDenylistFlag,
+	MaxConcurrentGamesFlag,
+	MinRemainingClockFlag,
+	IgnoreGamesOlderThanFlag,
 	CannonNetworkFlag,
 	CannonRollupConfigFlag,
 	CannonL2GenesisFlag,
@@ -279,7 +436,23 @@ var optionalFlags = []cli.Flag{
 	AsteriscSnapshotFreqFlag,
 	AsteriscInfoFreqFlag,
 	GameWindowFlag,
+	GameDataRetentionFlag,
+	GameDataMaxSizeFlag,
+	VMMaxCPUsFlag,
+	VMMaxMemoryFlag,
+	VMMemoryEstimateFlag,
 	SelectiveClaimResolutionFlag,
+	ResolutionConcurrencyFlag,
+	ResolutionBatchSizeFlag,
+	DryRunFlag,
+	RunTraceFlag,
+	RunTraceIntervalFlag,
+	NotifyWebhookURLFlag,
+	NotifySlackWebhookURLFlag,
+	NotifyPagerDutyRoutingKeyFlag,
+	NotifyPagerDutyURLFlag,
+	NotifyEventsFlag,
+	VMConfigFlag,
 	UnsafeAllowInvalidPrestate,
 }
 
@@ -405,6 +578,10 @@ func CheckRequired(ctx *cli.Context, traceTypes []types.TraceType) error {
 			}
 		case types.TraceTypeAlphabet, types.TraceTypeFast:
 		default:
+			if _, ok := vm.LookupTraceProvider(traceType); ok {
+				// Registered VMs are configured by the vm-config file, which is checked with the config
+				continue
+			}
 			return fmt.Errorf("invalid trace type %v. must be one of %v", traceType, types.TraceTypes)
 		}
 	}
@@ -471,8 +648,76 @@ func FactoryAddress(ctx *cli.Context) (common.Address, error) {
 	return common.Address{}, fmt.Errorf("flag %v or %v is required", FactoryAddressFlag.Name, flags.NetworkFlagName)
 }
 
+func parseBondClaimPolicy(ctx *cli.Context) (claims.ClaimPolicy, error) {
+	policy := claims.ClaimPolicy{BatchSize: ctx.Uint(BondClaimBatchSizeFlag.Name)}
+	if ctx.IsSet(BondClaimMinValueFlag.Name) {
+		minValue, ok := new(big.Int).SetString(ctx.String(BondClaimMinValueFlag.Name), 10)
+		if !ok {
+			return claims.ClaimPolicy{}, fmt.Errorf("invalid %v: %v", BondClaimMinValueFlag.Name, ctx.String(BondClaimMinValueFlag.Name))
+		}
+		policy.MinValue = minValue
+	}
+	multicall, err := opservice.ParseAddress(ctx.String(BondClaimMulticallFlag.Name))
+	if err != nil {
+		return claims.ClaimPolicy{}, fmt.Errorf("invalid %v: %w", BondClaimMulticallFlag.Name, err)
+	}
+	policy.Multicall = multicall
+	for _, windowStr := range ctx.StringSlice(BondClaimWindowsFlag.Name) {
+		window, err := claims.ParseTimeWindow(windowStr)
+		if err != nil {
+			return claims.ClaimPolicy{}, fmt.Errorf("invalid %v: %w", BondClaimWindowsFlag.Name, err)
+		}
+		policy.Windows = append(policy.Windows, window)
+	}
+	return policy, nil
+}
+
+func parseParticipationPolicy(ctx *cli.Context) (participation.Policy, error) {
+	policy := participation.Policy{
+		MaxGames:          ctx.Uint(MaxConcurrentGamesFlag.Name),
+		MinRemainingClock: ctx.Duration(MinRemainingClockFlag.Name),
+		MaxGameAge:        ctx.Duration(IgnoreGamesOlderThanFlag.Name),
+	}
+	for _, addrStr := range ctx.StringSlice(ProposerAllowlistFlag.Name) {
+		proposer, err := opservice.ParseAddress(addrStr)
+		if err != nil {
+			return participation.Policy{}, fmt.Errorf("invalid %v: %w", ProposerAllowlistFlag.Name, err)
+		}
+		policy.AllowedProposers = append(policy.AllowedProposers, proposer)
+	}
+	for _, addrStr := range ctx.StringSlice(ProposerDenylistFlag.Name) {
+		proposer, err := opservice.ParseAddress(addrStr)
+		if err != nil {
+			return participation.Policy{}, fmt.Errorf("invalid %v: %w", ProposerDenylistFlag.Name, err)
+		}
+		policy.DeniedProposers = append(policy.DeniedProposers, proposer)
+	}
+	return policy, nil
+}
+
+func parseNotifications(ctx *cli.Context) notify.Config {
+	cfg := notify.Config{
+		WebhookURL:          ctx.String(NotifyWebhookURLFlag.Name),
+		SlackWebhookURL:     ctx.String(NotifySlackWebhookURLFlag.Name),
+		PagerDutyRoutingKey: ctx.String(NotifyPagerDutyRoutingKeyFlag.Name),
+		PagerDutyURL:        ctx.String(NotifyPagerDutyURLFlag.Name),
+	}
+	for _, event := range ctx.StringSlice(NotifyEventsFlag.Name) {
+		cfg.Events = append(cfg.Events, notify.EventType(event))
+	}
+	return cfg
+}
+
 // NewConfigFromCLI parses the Config from the provided flags or environment variables.
 func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, error) {
+	// Game types declared in the VM configs are registered first, so their trace types can be enabled
+	rawVMConfigs, err := loadVMConfigs(ctx.String(VMConfigFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+	if err := registerDeclaredGameTypes(rawVMConfigs); err != nil {
+		return nil, err
+	}
 	traceTypes, err := parseTraceTypes(ctx)
 	if err != nil {
 		return nil, err
@@ -513,6 +758,14 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 			claimants = append(claimants, claimant)
 		}
 	}
+	bondClaimPolicy, err := parseBondClaimPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	participationPolicy, err := parseParticipationPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
 	var cannonPrestatesURL *url.URL
 	if ctx.IsSet(CannonPreStatesURLFlag.Name) {
 		parsed, err := url.Parse(ctx.String(CannonPreStatesURLFlag.Name))
@@ -551,6 +804,15 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 	}
 	l1EthRpc := ctx.String(L1EthRpcFlag.Name)
 	l1Beacon := ctx.String(L1BeaconFlag.Name)
+	vmConfigs, err := parseVMConfigs(rawVMConfigs, l1EthRpc, l1Beacon, l2Rpc, ctx.String(flags.NetworkFlagName))
+	if err != nil {
+		return nil, err
+	}
+	limiter := newExecutionLimiter(ctx)
+	for traceType, vmConfig := range vmConfigs {
+		vmConfig.VM.Limiter = limiter
+		vmConfigs[traceType] = vmConfig
+	}
 	return &config.Config{
 		// Required Flags
 		L1EthRpc:                l1EthRpc,
@@ -564,6 +826,8 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 		MaxPendingTx:            ctx.Uint64(MaxPendingTransactionsFlag.Name),
 		PollInterval:            ctx.Duration(HTTPPollInterval.Name),
 		AdditionalBondClaimants: claimants,
+		BondClaimPolicy:         bondClaimPolicy,
+		ParticipationPolicy:     participationPolicy,
 		RollupRpc:               ctx.String(RollupRpcFlag.Name),
 		Cannon: vm.Config{
 			VmType:           types.TraceTypeCannon,
@@ -579,10 +843,13 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 			InfoFreq:         ctx.Uint(CannonInfoFreqFlag.Name),
 			DebugInfo:        true,
 			BinarySnapshots:  true,
+			Limiter:          limiter,
 		},
 		CannonAbsolutePreState:        ctx.String(CannonPreStateFlag.Name),
 		CannonAbsolutePreStateBaseURL: cannonPrestatesURL,
 		Datadir:                       ctx.String(DatadirFlag.Name),
+		GameDataRetention:             ctx.Duration(GameDataRetentionFlag.Name),
+		GameDataMaxSize:               ctx.Uint64(GameDataMaxSizeFlag.Name) * 1024 * 1024,
 		Asterisc: vm.Config{
 			VmType:           types.TraceTypeAsterisc,
 			L1:               l1EthRpc,
@@ -595,6 +862,7 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 			L2GenesisPath:    ctx.String(AsteriscL2GenesisFlag.Name),
 			SnapshotFreq:     ctx.Uint(AsteriscSnapshotFreqFlag.Name),
 			InfoFreq:         ctx.Uint(AsteriscInfoFreqFlag.Name),
+			Limiter:          limiter,
 		},
 		AsteriscAbsolutePreState:        ctx.String(AsteriscPreStateFlag.Name),
 		AsteriscAbsolutePreStateBaseURL: asteriscPreStatesURL,
@@ -610,13 +878,31 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 			L2GenesisPath:    ctx.String(AsteriscL2GenesisFlag.Name),
 			SnapshotFreq:     ctx.Uint(AsteriscSnapshotFreqFlag.Name),
 			InfoFreq:         ctx.Uint(AsteriscInfoFreqFlag.Name),
+			Limiter:          limiter,
 		},
 		AsteriscKonaAbsolutePreState:        ctx.String(AsteriscKonaPreStateFlag.Name),
 		AsteriscKonaAbsolutePreStateBaseURL: asteriscKonaPreStatesURL,
+		VMs:                                 vmConfigs,
 		TxMgrConfig:                         txMgrConfig,
 		MetricsConfig:                       metricsConfig,
 		PprofConfig:                         pprofConfig,
 		SelectiveClaimResolution:            ctx.Bool(SelectiveClaimResolutionFlag.Name),
+		ResolutionConcurrency:               ctx.Uint(ResolutionConcurrencyFlag.Name),
+		ResolutionBatchSize:                 ctx.Uint(ResolutionBatchSizeFlag.Name),
+		RunTrace:                            ctx.Bool(RunTraceFlag.Name),
+		RunTraceInterval:                    ctx.Duration(RunTraceIntervalFlag.Name),
+		Notifications:                       parseNotifications(ctx),
 		AllowInvalidPrestate:                ctx.Bool(UnsafeAllowInvalidPrestate.Name),
+		DryRun:                              ctx.Bool(DryRunFlag.Name),
 	}, nil
 }
+
+// newExecutionLimiter creates the limiter shared by all VMs, or nil if VM executions are not limited.
+func newExecutionLimiter(ctx *cli.Context) *vm.ExecutionLimiter {
+	maxCPUs := ctx.Uint(VMMaxCPUsFlag.Name)
+	maxMemory := ctx.Uint64(VMMaxMemoryFlag.Name) * 1024 * 1024
+	if maxCPUs == 0 && maxMemory == 0 {
+		return nil
+	}
+	return vm.NewExecutionLimiter(maxCPUs, maxMemory, ctx.Uint64(VMMemoryEstimateFlag.Name)*1024*1024)
+}
@@ -0,0 +1,127 @@
+package flags
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/declared"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+)
+
+// vmConfigJSON is the config of a VM registered as a trace provider, in the file of the vm-config flag.
+type vmConfigJSON struct {
+	VmBin           string `json:"vmBin"`
+	Server          string `json:"server"`
+	Prestate        string `json:"prestate"`
+	PrestatesURL    string `json:"prestatesUrl"`
+	SnapshotFreq    uint   `json:"snapshotFreq"`
+	InfoFreq        uint   `json:"infoFreq"`
+	DebugInfo       bool   `json:"debugInfo"`
+	BinarySnapshots bool   `json:"binarySnapshots"`
+	Network         string `json:"network"`
+	RollupConfig    string `json:"rollupConfig"`
+	L2Genesis       string `json:"l2Genesis"`
+
+	// Declares a game type played by the trace type with a VM and oracle server built in to the challenger
+	GameType *uint32 `json:"gameType"`
+	VM       string  `json:"vm"`
+	Oracle   string  `json:"oracle"`
+}
+
+// loadVMConfigs reads the configs of the VMs added as trace providers, by trace type, from the vm-config file.
+func loadVMConfigs(path string) (map[types.TraceType]vmConfigJSON, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vm config %v: %w", path, err)
+	}
+	var vmConfigs map[types.TraceType]vmConfigJSON
+	if err := json.Unmarshal(data, &vmConfigs); err != nil {
+		return nil, fmt.Errorf("failed to parse vm config %v: %w", path, err)
+	}
+	return vmConfigs, nil
+}
+
+// registerDeclaredGameTypes registers the trace providers of the game types declared in the VM configs, so their
+// trace types can be enabled. The oracle server defaults to op-program.
+func registerDeclaredGameTypes(vmConfigs map[types.TraceType]vmConfigJSON) error {
+	for traceType, vmConfig := range vmConfigs {
+		if vmConfig.GameType == nil {
+			if vmConfig.VM != "" || vmConfig.Oracle != "" {
+				return fmt.Errorf("%v vm config sets vm or oracle without declaring a game type", traceType)
+			}
+			continue
+		}
+		oracle := declared.Oracle(vmConfig.Oracle)
+		if oracle == "" {
+			oracle = declared.OracleOpProgram
+		}
+		err := declared.Register(declared.GameType{
+			TraceType: traceType,
+			GameType:  types.GameType(*vmConfig.GameType),
+			VM:        declared.VM(vmConfig.VM),
+			Oracle:    oracle,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to declare game type %v for trace type %v: %w", *vmConfig.GameType, traceType, err)
+		}
+	}
+	return nil
+}
+
+// parseVMConfigs converts the configs of the VMs added as trace providers, by trace type.
+// The L1 and L2 RPCs are shared with the other trace types, as is the network unless the VM config sets one.
+func parseVMConfigs(vmConfigs map[types.TraceType]vmConfigJSON, l1EthRpc string, l1Beacon string, l2Rpc string, network string) (map[types.TraceType]config.VMConfig, error) {
+	if vmConfigs == nil {
+		return nil, nil
+	}
+	result := make(map[types.TraceType]config.VMConfig, len(vmConfigs))
+	for traceType, vmConfig := range vmConfigs {
+		var prestatesURL *url.URL
+		var err error
+		if vmConfig.PrestatesURL != "" {
+			prestatesURL, err = url.Parse(vmConfig.PrestatesURL)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %v pre states url (%v): %w", traceType, vmConfig.PrestatesURL, err)
+			}
+		}
+		snapshotFreq := vmConfig.SnapshotFreq
+		if snapshotFreq == 0 {
+			snapshotFreq = config.DefaultAsteriscSnapshotFreq
+		}
+		infoFreq := vmConfig.InfoFreq
+		if infoFreq == 0 {
+			infoFreq = config.DefaultAsteriscInfoFreq
+		}
+		vmNetwork := vmConfig.Network
+		if vmNetwork == "" && vmConfig.RollupConfig == "" && vmConfig.L2Genesis == "" {
+			vmNetwork = network
+		}
+		result[traceType] = config.VMConfig{
+			VM: vm.Config{
+				VmType:           traceType,
+				L1:               l1EthRpc,
+				L1Beacon:         l1Beacon,
+				L2:               l2Rpc,
+				VmBin:            vmConfig.VmBin,
+				Server:           vmConfig.Server,
+				Network:          vmNetwork,
+				RollupConfigPath: vmConfig.RollupConfig,
+				L2GenesisPath:    vmConfig.L2Genesis,
+				SnapshotFreq:     snapshotFreq,
+				InfoFreq:         infoFreq,
+				DebugInfo:        vmConfig.DebugInfo,
+				BinarySnapshots:  vmConfig.BinarySnapshots,
+			},
+			AbsolutePreState:        vmConfig.Prestate,
+			AbsolutePreStateBaseURL: prestatesURL,
+		}
+	}
+	return result, nil
+}
@@ -3,35 +3,125 @@ package game
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/statedb"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
 )
 
 const gameDirPrefix = "game-"
 
+// sizeCheckInterval is the minimum time between checks of the size of game data, which walks every game directory.
+const sizeCheckInterval = time.Minute
+
+const (
+	removedResolved = "resolved"
+	removedSizeCap  = "size_cap"
+)
+
+type DiskMetricer interface {
+	RecordGameDataSize(size uint64)
+	RecordGameDataRemoved(reason string)
+}
+
 // diskManager coordinates the storage of game data on disk.
+//
+// The data of games that are no longer active, because they are resolved or outside the game window, is kept for the
+// retention period after they became inactive and then removed. If the total size of game data exceeds maxSize, the data
+// of inactive games is removed early, starting with those that have been inactive longest. Data of active games is
+// never removed.
 type diskManager struct {
+	logger  log.Logger
+	m       DiskMetricer
+	clock   clock.Clock
 	datadir string
+	stateDB *statedb.DB
+
+	retention time.Duration
+	maxSize   uint64
+
+	// inactiveSince records when the retained data of each inactive game was first found to no longer be active.
+	inactiveSince map[common.Address]time.Time
+	lastSizeCheck time.Time
 }
 
-func newDiskManager(dir string) *diskManager {
-	return &diskManager{datadir: dir}
+type gameDir struct {
+	addr common.Address
+	path string
+}
+
+// newDiskManager creates a disk manager for the game directories in dir.
+// If stateDB is not nil, the state of games is removed from it once they are no longer active.
+// A retention of 0 removes the data of games as soon as they are no longer active and a maxSize of 0 disables the size cap.
+func newDiskManager(logger log.Logger, m DiskMetricer, cl clock.Clock, dir string, stateDB *statedb.DB, retention time.Duration, maxSize uint64) *diskManager {
+	return &diskManager{
+		logger:        logger,
+		m:             m,
+		clock:         cl,
+		datadir:       dir,
+		stateDB:       stateDB,
+		retention:     retention,
+		maxSize:       maxSize,
+		inactiveSince: make(map[common.Address]time.Time),
+	}
 }
 
 func (d *diskManager) DirForGame(addr common.Address) string {
 	return filepath.Join(d.datadir, gameDirPrefix+addr.Hex())
 }
 
+// RemoveAllExcept removes the data of all games except the specified active games, once their retention period has
+// passed or to keep the game data below the maximum size.
 func (d *diskManager) RemoveAllExcept(keep []common.Address) error {
-	entries, err := os.ReadDir(d.datadir)
+	dirs, err := d.gameDirs()
 	if err != nil {
-		return fmt.Errorf("failed to list directory: %w", err)
+		return err
 	}
+	now := d.clock.Now()
 	var errs []error
+	var retained []gameDir
+	inactiveSince := make(map[common.Address]time.Time)
+	for _, dir := range dirs {
+		if slices.Contains(keep, dir.addr) {
+			// Preserve data for games we should keep.
+			retained = append(retained, dir)
+			continue
+		}
+		since, ok := d.inactiveSince[dir.addr]
+		if !ok {
+			since = now
+		}
+		if now.Sub(since) < d.retention {
+			inactiveSince[dir.addr] = since
+			retained = append(retained, dir)
+			continue
+		}
+		errs = append(errs, d.remove(dir, removedResolved))
+	}
+	d.inactiveSince = inactiveSince
+	if d.lastSizeCheck.IsZero() || now.Sub(d.lastSizeCheck) >= sizeCheckInterval {
+		d.lastSizeCheck = now
+		errs = append(errs, d.enforceMaxSize(retained))
+	}
+	if d.stateDB != nil {
+		errs = append(errs, d.stateDB.RemoveAllExcept(keep))
+	}
+	return errors.Join(errs...)
+}
+
+func (d *diskManager) gameDirs() ([]gameDir, error) {
+	entries, err := os.ReadDir(d.datadir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory: %w", err)
+	}
+	var dirs []gameDir
 	for _, entry := range entries {
 		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), gameDirPrefix) {
 			// Skip files and directories that don't have the game directory prefix.
@@ -45,11 +135,81 @@ func (d *diskManager) RemoveAllExcept(keep []common.Address) error {
 			// Ignore directories with non-address names.
 			continue
 		}
-		if slices.Contains(keep, addr) {
-			// Preserve data for games we should keep.
-			continue
+		dirs = append(dirs, gameDir{addr: addr, path: filepath.Join(d.datadir, entry.Name())})
+	}
+	return dirs, nil
+}
+
+// enforceMaxSize records the total size of the retained game data and, if it exceeds the maximum size, removes the
+// data of inactive games until it doesn't.
+func (d *diskManager) enforceMaxSize(dirs []gameDir) error {
+	sizes := make(map[common.Address]uint64, len(dirs))
+	var total uint64
+	for _, dir := range dirs {
+		size, err := dirSize(dir.path)
+		if err != nil {
+			return fmt.Errorf("failed to get size of game data in %v: %w", dir.path, err)
 		}
-		errs = append(errs, os.RemoveAll(filepath.Join(d.datadir, entry.Name())))
+		sizes[dir.addr] = size
+		total += size
 	}
+	var errs []error
+	if d.maxSize != 0 && total > d.maxSize {
+		inactive := slices.DeleteFunc(slices.Clone(dirs), func(dir gameDir) bool {
+			_, ok := d.inactiveSince[dir.addr]
+			return !ok
+		})
+		slices.SortFunc(inactive, func(a, b gameDir) int {
+			return d.inactiveSince[a.addr].Compare(d.inactiveSince[b.addr])
+		})
+		for _, dir := range inactive {
+			if total <= d.maxSize {
+				break
+			}
+			if err := d.remove(dir, removedSizeCap); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			delete(d.inactiveSince, dir.addr)
+			total -= sizes[dir.addr]
+		}
+		if total > d.maxSize {
+			d.logger.Warn("Data of active games exceeds maximum game data size", "size", total, "max", d.maxSize)
+		}
+	}
+	d.m.RecordGameDataSize(total)
 	return errors.Join(errs...)
 }
+
+func (d *diskManager) remove(dir gameDir, reason string) error {
+	if err := os.RemoveAll(dir.path); err != nil {
+		return err
+	}
+	d.m.RecordGameDataRemoved(reason)
+	return nil
+}
+
+// dirSize returns the total size of the files in dir.
+// Files removed while walking the directory, such as by a running VM, are ignored.
+func dirSize(dir string) (uint64, error) {
+	var size uint64
+	err := filepath.WalkDir(dir, func(_ string, entry fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		size += uint64(info.Size())
+		return nil
+	})
+	return size, err
+}
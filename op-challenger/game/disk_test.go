@@ -4,15 +4,20 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/statedb"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 )
 
 func TestDiskManager_DirForGame(t *testing.T) {
 	baseDir := t.TempDir()
 	addr := common.Address{0x53}
-	disk := newDiskManager(baseDir)
+	disk, _, _ := newTestDiskManager(t, baseDir, nil, 0, 0)
 	result := disk.DirForGame(addr)
 	require.Equal(t, filepath.Join(baseDir, gameDirPrefix+addr.Hex()), result)
 }
@@ -21,7 +26,7 @@ func TestDiskManager_RemoveAllExcept(t *testing.T) {
 	baseDir := t.TempDir()
 	keep := common.Address{0x53}
 	delete := common.Address{0xaa}
-	disk := newDiskManager(baseDir)
+	disk, _, m := newTestDiskManager(t, baseDir, nil, 0, 0)
 	keepDir := disk.DirForGame(keep)
 	deleteDir := disk.DirForGame(delete)
 
@@ -32,19 +37,8 @@ func TestDiskManager_RemoveAllExcept(t *testing.T) {
 	invalidHexDir := filepath.Join(baseDir, gameDirPrefix+"0xNOPE")
 	require.NoError(t, os.MkdirAll(invalidHexDir, 0777))
 
-	populateDir := func(dir string) []string {
-		require.NoError(t, os.MkdirAll(dir, 0777))
-		file1 := filepath.Join(dir, "test.txt")
-		require.NoError(t, os.WriteFile(file1, []byte("foo"), 0644))
-		nestedDirs := filepath.Join(dir, "subdir", "deep")
-		require.NoError(t, os.MkdirAll(nestedDirs, 0777))
-		file2 := filepath.Join(nestedDirs, ".foo.txt")
-		require.NoError(t, os.WriteFile(file2, []byte("foo"), 0644))
-		return []string{file1, file2}
-	}
-
-	keepFiles := populateDir(keepDir)
-	populateDir(deleteDir)
+	keepFiles := populateDir(t, keepDir)
+	populateDir(t, deleteDir)
 
 	require.NoError(t, disk.RemoveAllExcept([]common.Address{keep}))
 	require.NoDirExists(t, deleteDir, "should have deleted directory")
@@ -54,4 +48,135 @@ func TestDiskManager_RemoveAllExcept(t *testing.T) {
 	require.FileExists(t, unexpectedFile, "should not delete unexpected file")
 	require.DirExists(t, unexpectedDir, "should not delete unexpected dir")
 	require.DirExists(t, invalidHexDir, "should not delete dir with invalid address")
+	require.Equal(t, 1, m.removed[removedResolved])
+	require.Equal(t, uint64(6), m.size, "should record size of retained game data")
+}
+
+func TestDiskManager_RemoveAllExceptRemovesGameState(t *testing.T) {
+	baseDir := t.TempDir()
+	keep := common.Address{0x53}
+	delete := common.Address{0xaa}
+	stateDB, err := statedb.NewDB(testlog.Logger(t, log.LevelInfo), filepath.Join(baseDir, stateDBDir))
+	require.NoError(t, err)
+	defer stateDB.Close()
+	require.NoError(t, stateDB.ForGame(keep).SetClaimsSeen(1))
+	require.NoError(t, stateDB.ForGame(delete).SetClaimsSeen(1))
+	disk, _, _ := newTestDiskManager(t, baseDir, stateDB, 0, 0)
+
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{keep}))
+	seen, err := stateDB.ForGame(keep).ClaimsSeen()
+	require.NoError(t, err)
+	require.Equal(t, 1, seen, "should keep state for active game")
+	seen, err = stateDB.ForGame(delete).ClaimsSeen()
+	require.NoError(t, err)
+	require.Zero(t, seen, "should delete state for other games")
+	require.DirExists(t, filepath.Join(baseDir, stateDBDir), "should not delete state db")
+}
+
+func TestDiskManager_RetainInactiveGames(t *testing.T) {
+	baseDir := t.TempDir()
+	active := common.Address{0x53}
+	inactive := common.Address{0xaa}
+	disk, cl, m := newTestDiskManager(t, baseDir, nil, time.Hour, 0)
+	populateDir(t, disk.DirForGame(active))
+	populateDir(t, disk.DirForGame(inactive))
+
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{active}))
+	require.DirExists(t, disk.DirForGame(inactive), "should retain data of inactive game")
+
+	cl.AdvanceTime(59 * time.Minute)
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{active}))
+	require.DirExists(t, disk.DirForGame(inactive), "should retain data until retention period has passed")
+
+	cl.AdvanceTime(time.Minute)
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{active}))
+	require.NoDirExists(t, disk.DirForGame(inactive), "should remove data after retention period")
+	require.DirExists(t, disk.DirForGame(active))
+	require.Equal(t, 1, m.removed[removedResolved])
+}
+
+func TestDiskManager_RestartRetentionWhenGameActiveAgain(t *testing.T) {
+	baseDir := t.TempDir()
+	game := common.Address{0xaa}
+	disk, cl, _ := newTestDiskManager(t, baseDir, nil, time.Hour, 0)
+	populateDir(t, disk.DirForGame(game))
+
+	require.NoError(t, disk.RemoveAllExcept(nil))
+	cl.AdvanceTime(30 * time.Minute)
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{game}))
+	cl.AdvanceTime(30 * time.Minute)
+	require.NoError(t, disk.RemoveAllExcept(nil))
+	require.DirExists(t, disk.DirForGame(game), "should restart retention period")
+}
+
+func TestDiskManager_MaxSize(t *testing.T) {
+	baseDir := t.TempDir()
+	active := common.Address{0x01}
+	oldest := common.Address{0x02}
+	newest := common.Address{0x03}
+	// Each populated dir contains 6 bytes
+	disk, cl, m := newTestDiskManager(t, baseDir, nil, time.Hour, 12)
+	for _, game := range []common.Address{active, oldest, newest} {
+		populateDir(t, disk.DirForGame(game))
+	}
+
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{active, newest}))
+	cl.AdvanceTime(sizeCheckInterval)
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{active}))
+	require.NoDirExists(t, disk.DirForGame(oldest), "should remove game inactive longest")
+	require.DirExists(t, disk.DirForGame(newest), "should retain game once below max size")
+	require.DirExists(t, disk.DirForGame(active))
+	require.Equal(t, 1, m.removed[removedSizeCap])
+	require.Equal(t, uint64(12), m.size)
+
+	// Only checks the size once per interval
+	cl.AdvanceTime(sizeCheckInterval / 2)
+	populateDir(t, disk.DirForGame(oldest))
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{active}))
+	require.DirExists(t, disk.DirForGame(oldest))
+	cl.AdvanceTime(sizeCheckInterval / 2)
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{active}))
+	require.NoDirExists(t, disk.DirForGame(newest), "should remove game inactive longest")
+	require.DirExists(t, disk.DirForGame(oldest))
+}
+
+func TestDiskManager_MaxSizeKeepsActiveGames(t *testing.T) {
+	baseDir := t.TempDir()
+	active := common.Address{0x01}
+	disk, _, m := newTestDiskManager(t, baseDir, nil, time.Hour, 1)
+	populateDir(t, disk.DirForGame(active))
+
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{active}))
+	require.DirExists(t, disk.DirForGame(active))
+	require.Equal(t, uint64(6), m.size)
+}
+
+func populateDir(t *testing.T, dir string) []string {
+	require.NoError(t, os.MkdirAll(dir, 0777))
+	file1 := filepath.Join(dir, "test.txt")
+	require.NoError(t, os.WriteFile(file1, []byte("foo"), 0644))
+	nestedDirs := filepath.Join(dir, "subdir", "deep")
+	require.NoError(t, os.MkdirAll(nestedDirs, 0777))
+	file2 := filepath.Join(nestedDirs, ".foo.txt")
+	require.NoError(t, os.WriteFile(file2, []byte("foo"), 0644))
+	return []string{file1, file2}
+}
+
+func newTestDiskManager(t *testing.T, dir string, stateDB *statedb.DB, retention time.Duration, maxSize uint64) (*diskManager, *clock.DeterministicClock, *stubDiskMetrics) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	m := &stubDiskMetrics{removed: make(map[string]int)}
+	return newDiskManager(testlog.Logger(t, log.LevelInfo), m, cl, dir, stateDB, retention, maxSize), cl, m
+}
+
+type stubDiskMetrics struct {
+	size    uint64
+	removed map[string]int
+}
+
+func (s *stubDiskMetrics) RecordGameDataSize(size uint64) {
+	s.size = size
+}
+
+func (s *stubDiskMetrics) RecordGameDataRemoved(reason string) {
+	s.removed[reason]++
 }
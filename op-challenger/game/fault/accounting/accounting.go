@@ -0,0 +1,113 @@
+package accounting
+
+import (
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type FeeTxSender interface {
+	From() common.Address
+	SendAndWaitFees(txPurpose string, txs ...txmgr.TxCandidate) (*big.Int, error)
+}
+
+// Ledger tracks the fees paid for the transactions sent by the challenger to play a game.
+type Ledger struct {
+	mu       sync.Mutex
+	gasSpent *big.Int
+}
+
+func NewLedger() *Ledger {
+	return &Ledger{gasSpent: new(big.Int)}
+}
+
+func (l *Ledger) RecordGasSpent(fee *big.Int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.gasSpent.Add(l.gasSpent, fee)
+}
+
+// GasSpent returns the total fees paid for the game's transactions since the ledger was created.
+func (l *Ledger) GasSpent() *big.Int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return new(big.Int).Set(l.gasSpent)
+}
+
+// TxSender sends transactions for a game, recording the fees paid for them in the game's ledger.
+type TxSender struct {
+	sender FeeTxSender
+	ledger *Ledger
+}
+
+func NewTxSender(sender FeeTxSender, ledger *Ledger) *TxSender {
+	return &TxSender{sender: sender, ledger: ledger}
+}
+
+func (s *TxSender) From() common.Address {
+	return s.sender.From()
+}
+
+func (s *TxSender) SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error {
+	fees, err := s.sender.SendAndWaitFees(txPurpose, txs...)
+	s.ledger.RecordGasSpent(fees)
+	return err
+}
+
+// Summary is the economic outcome of a resolved game for the challenger.
+type Summary struct {
+	// GasSpent is the fees paid for the transactions sent for the game.
+	GasSpent *big.Int
+	// BondsPosted is the bonds of claims made by the challenger.
+	BondsPosted *big.Int
+	// BondsReturned is the bonds of claims made by the challenger that were not countered, which are paid back.
+	BondsReturned *big.Int
+	// BondsWon is the bonds of claims made by others that the challenger countered.
+	BondsWon *big.Int
+	// BondsLost is the bonds of claims made by the challenger that others countered.
+	BondsLost *big.Int
+}
+
+// Profit returns the bonds won less the bonds lost and gas spent.
+func (s Summary) Profit() *big.Int {
+	profit := new(big.Int).Sub(s.BondsWon, s.BondsLost)
+	return profit.Sub(profit, s.GasSpent)
+}
+
+// Summarize determines the outcome for the claimants of a resolved game from its claims.
+// The bond of each claim is paid to the claimant that countered it or, if uncountered, back to the claim's claimant.
+func Summarize(claims []types.Claim, claimants []common.Address, gasSpent *big.Int) Summary {
+	summary := Summary{
+		GasSpent:      new(big.Int).Set(gasSpent),
+		BondsPosted:   new(big.Int),
+		BondsReturned: new(big.Int),
+		BondsWon:      new(big.Int),
+		BondsLost:     new(big.Int),
+	}
+	for _, claim := range claims {
+		if claim.Bond == nil {
+			continue
+		}
+		recipient := claim.Claimant
+		if claim.CounteredBy != (common.Address{}) {
+			recipient = claim.CounteredBy
+		}
+		posted := slices.Contains(claimants, claim.Claimant)
+		received := slices.Contains(claimants, recipient)
+		switch {
+		case posted && received:
+			summary.BondsPosted.Add(summary.BondsPosted, claim.Bond)
+			summary.BondsReturned.Add(summary.BondsReturned, claim.Bond)
+		case posted:
+			summary.BondsPosted.Add(summary.BondsPosted, claim.Bond)
+			summary.BondsLost.Add(summary.BondsLost, claim.Bond)
+		case received:
+			summary.BondsWon.Add(summary.BondsWon, claim.Bond)
+		}
+	}
+	return summary
+}
@@ -0,0 +1,86 @@
+package accounting
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	challenger = common.Address{0xaa}
+	other      = common.Address{0xbb}
+	proposer   = common.Address{0xcc}
+)
+
+func TestTxSenderRecordsFees(t *testing.T) {
+	ledger := NewLedger()
+	stub := &stubFeeTxSender{fees: big.NewInt(10)}
+	sender := NewTxSender(stub, ledger)
+	require.Equal(t, challenger, sender.From())
+
+	require.NoError(t, sender.SendAndWaitSimple("testing", txmgr.TxCandidate{}))
+	stub.err = errors.New("reverted")
+	require.ErrorIs(t, sender.SendAndWaitSimple("testing", txmgr.TxCandidate{}), stub.err)
+	require.Equal(t, big.NewInt(20), ledger.GasSpent(), "should record fees of failed transactions")
+}
+
+func TestSummarize(t *testing.T) {
+	claims := []types.Claim{
+		// Root claim countered by the challenger
+		claim(100, proposer, challenger),
+		// Uncountered challenger claim
+		claim(200, challenger, common.Address{}),
+		// Challenger claim countered by another challenger
+		claim(400, challenger, other),
+		// Countered claim of another challenger
+		claim(800, other, challenger),
+		// Claims the challenger played no part in
+		claim(1600, other, proposer),
+		claim(3200, proposer, common.Address{}),
+	}
+	summary := Summarize(claims, []common.Address{challenger}, big.NewInt(50))
+	require.Equal(t, big.NewInt(50), summary.GasSpent)
+	require.Equal(t, big.NewInt(600), summary.BondsPosted)
+	require.Equal(t, big.NewInt(200), summary.BondsReturned)
+	require.Equal(t, big.NewInt(900), summary.BondsWon)
+	require.Equal(t, big.NewInt(400), summary.BondsLost)
+	require.Equal(t, big.NewInt(450), summary.Profit())
+}
+
+func TestSummarizeMultipleClaimants(t *testing.T) {
+	claims := []types.Claim{
+		claim(100, proposer, other),
+		claim(200, challenger, other),
+	}
+	summary := Summarize(claims, []common.Address{challenger, other}, big.NewInt(0))
+	require.Equal(t, big.NewInt(200), summary.BondsPosted)
+	require.Equal(t, big.NewInt(200), summary.BondsReturned)
+	require.Equal(t, big.NewInt(100), summary.BondsWon)
+	require.Zero(t, summary.BondsLost.Sign())
+}
+
+func claim(bond int64, claimant common.Address, counteredBy common.Address) types.Claim {
+	return types.Claim{
+		ClaimData:   types.ClaimData{Bond: big.NewInt(bond)},
+		Claimant:    claimant,
+		CounteredBy: counteredBy,
+	}
+}
+
+type stubFeeTxSender struct {
+	fees *big.Int
+	err  error
+}
+
+func (s *stubFeeTxSender) From() common.Address {
+	return challenger
+}
+
+func (s *stubFeeTxSender) SendAndWaitFees(_ string, _ ...txmgr.TxCandidate) (*big.Int, error) {
+	return s.fees, s.err
+}
@@ -5,17 +5,21 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strconv"
 	"sync"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/preimages"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-challenger/notify"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/sync/errgroup"
 )
 
 // Responder takes a response action & executes.
@@ -33,18 +37,42 @@ type ClaimLoader interface {
 	IsL2BlockNumberChallenged(ctx context.Context, block rpcblock.Block) (bool, error)
 }
 
+// StateStore persists the progress of the agent in a game so it isn't repeated when the challenger restarts.
+// Actions are not persisted: they are always calculated from the claims on chain, so an action whose transaction
+// was dropped or reorged out is performed again.
+type StateStore interface {
+	ClaimsSeen() (int, error)
+	SetClaimsSeen(count int) error
+}
+
+// TracePrefetcher is implemented by trace accessors that can load the trace values required for a game in advance,
+// with fewer requests than loading each value when it is needed.
+type TracePrefetcher interface {
+	Prefetch(ctx context.Context, game types.Game) error
+}
+
 type Agent struct {
 	metrics          metrics.Metricer
 	systemClock      clock.Clock
 	l1Clock          types.ClockReader
 	solver           *solver.GameSolver
+	prefetcher       TracePrefetcher
 	loader           ClaimLoader
 	responder        Responder
 	selective        bool
 	claimants        []common.Address
+	resolveLimit     int
 	maxDepth         types.Depth
 	maxClockDuration time.Duration
 	log              log.Logger
+	state            StateStore
+	notifier         *GameNotifier
+
+	// counteredChecked is the number of claims already checked for counters to the claimants' claims.
+	counteredChecked int
+
+	// deadline is the earliest time the clock of a claim expires, as of the last time the agent acted.
+	deadline time.Time
 }
 
 func NewAgent(
@@ -59,25 +87,34 @@ func NewAgent(
 	log log.Logger,
 	selective bool,
 	claimants []common.Address,
+	resolveConcurrency int,
+	state StateStore,
+	notifier *GameNotifier,
 ) *Agent {
+	prefetcher, _ := trace.(TracePrefetcher)
 	return &Agent{
 		metrics:          m,
 		systemClock:      systemClock,
 		l1Clock:          l1Clock,
 		solver:           solver.NewGameSolver(maxDepth, trace),
+		prefetcher:       prefetcher,
 		loader:           loader,
 		responder:        responder,
 		selective:        selective,
 		claimants:        claimants,
+		resolveLimit:     max(resolveConcurrency, 1),
 		maxDepth:         maxDepth,
 		maxClockDuration: maxClockDuration,
 		log:              log,
+		state:            state,
+		notifier:         notifier,
 	}
 }
 
 // Act iterates the game & performs all of the next actions.
 func (a *Agent) Act(ctx context.Context) error {
 	if a.tryResolve(ctx) {
+		a.deadline = time.Time{}
 		return nil
 	}
 
@@ -97,10 +134,24 @@ func (a *Agent) Act(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("create game from contracts: %w", err)
 	}
+	a.deadline = a.nextDeadline(game)
+	a.notifyCountered(game)
 
+	if a.claimsSeen(game) {
+		a.log.Debug("Skipping game with no new claims since no actions were required")
+		return nil
+	}
+	if a.prefetcher != nil {
+		if err := a.prefetcher.Prefetch(ctx, game); err != nil {
+			// Values that failed to prefetch are loaded individually when they are needed
+			a.log.Warn("Failed to prefetch trace values", "err", err)
+		}
+	}
 	actions, err := a.solver.CalculateNextActions(ctx, game)
 	if err != nil {
 		a.log.Error("Failed to calculate all required moves", "err", err)
+	} else if len(actions) == 0 {
+		a.recordClaimsSeen(game)
 	}
 
 	var wg sync.WaitGroup
@@ -143,11 +194,85 @@ func (a *Agent) performAction(ctx context.Context, wg *sync.WaitGroup, action ty
 	}
 	actionLog.Info("Performing action")
 	err := a.responder.PerformAction(ctx, action)
-	if err != nil {
+	if errors.Is(err, preimages.ErrChallengePeriodNotOver) {
+		// The step is performed once the large preimage it requires has been squeezed, after the challenge period.
+		actionLog.Info("Waiting for large preimage challenge period")
+		return
+	} else if err != nil {
 		actionLog.Error("Action failed", "err", err)
 	}
 }
 
+// claimsSeen returns true if the game was previously found to require no actions and no claims have been added since.
+// Since the required actions depend only on the claims, the solver doesn't need to check the claims again.
+func (a *Agent) claimsSeen(game types.Game) bool {
+	if a.state == nil {
+		return false
+	}
+	seen, err := a.state.ClaimsSeen()
+	if err != nil {
+		a.log.Warn("Failed to load claims seen", "err", err)
+		return false
+	}
+	return seen != 0 && seen == len(game.Claims())
+}
+
+func (a *Agent) recordClaimsSeen(game types.Game) {
+	if a.state == nil {
+		return
+	}
+	if err := a.state.SetClaimsSeen(len(game.Claims())); err != nil {
+		a.log.Warn("Failed to record claims seen", "err", err)
+	}
+}
+
+// notifyCountered sends a notification for each claim by the claimants that has been countered by another claimant
+// since the claims were last checked.
+func (a *Agent) notifyCountered(game types.Game) {
+	claims := game.Claims()
+	for _, claim := range claims[min(a.counteredChecked, len(claims)):] {
+		if claim.IsRoot() || slices.Contains(a.claimants, claim.Claimant) {
+			continue
+		}
+		parent := claims[claim.ParentContractIndex]
+		if !slices.Contains(a.claimants, parent.Claimant) {
+			continue
+		}
+		a.notifier.NotifyOnce(fmt.Sprintf("%v:%v", notify.EventClaimCountered, claim.ContractIndex), notify.Event{
+			Type:    notify.EventClaimCountered,
+			Summary: fmt.Sprintf("Claim %v by %v was countered by claim %v", parent.ContractIndex, parent.Claimant, claim.ContractIndex),
+			Details: map[string]string{
+				"claimIdx":        strconv.Itoa(parent.ContractIndex),
+				"counterIdx":      strconv.Itoa(claim.ContractIndex),
+				"counterClaimant": claim.Claimant.Hex(),
+				"position":        claim.Position.ToGIndex().String(),
+			},
+		})
+	}
+	a.counteredChecked = len(claims)
+}
+
+// Deadline returns the earliest time that the clock of a claim in the game expires, after which it can no longer be
+// countered, as of the last time the agent acted. Returns the zero time if unknown or if no claims can be countered.
+func (a *Agent) Deadline() time.Time {
+	return a.deadline
+}
+
+func (a *Agent) nextDeadline(game types.Game) time.Time {
+	now := a.l1Clock.Now()
+	var deadline time.Time
+	for _, claim := range game.Claims() {
+		remaining := a.maxClockDuration - game.ChessClock(now, claim)
+		if remaining <= 0 {
+			continue
+		}
+		if expiry := now.Add(remaining); deadline.IsZero() || expiry.Before(deadline) {
+			deadline = expiry
+		}
+	}
+	return deadline
+}
+
 // tryResolve resolves the game if it is in a winning state
 // Returns true if the game is resolvable (regardless of whether it was actually resolved)
 func (a *Agent) tryResolve(ctx context.Context) bool {
@@ -164,25 +289,28 @@ func (a *Agent) tryResolve(ctx context.Context) bool {
 	if err != nil || status == gameTypes.GameStatusInProgress {
 		return false
 	}
-	a.log.Info("Resolving game")
+	a.log.Info("Resolving game", "status", status)
 	if err := a.responder.Resolve(); err != nil {
 		a.log.Error("Failed to resolve the game", "err", err)
+		a.notifyResolutionFailed(string(notify.EventResolutionFailed), fmt.Sprintf("Failed to resolve game as %v", status), err)
 	}
 	return true
 }
 
 var errNoResolvableClaims = errors.New("no resolvable claims")
 
-func (a *Agent) tryResolveClaims(ctx context.Context) error {
+// tryResolveClaims resolves the claims that are currently resolvable and returns them.
+// prevClaims are the claims resolved by the previous attempt, which aren't resolved again if they're still resolvable.
+func (a *Agent) tryResolveClaims(ctx context.Context, prevClaims []uint64) ([]uint64, error) {
 	claims, err := a.loader.GetAllClaims(ctx, rpcblock.Latest)
 	if err != nil {
-		return fmt.Errorf("failed to fetch claims: %w", err)
+		return nil, fmt.Errorf("failed to fetch claims: %w", err)
 	}
 	if len(claims) == 0 {
-		return errNoResolvableClaims
+		return nil, errNoResolvableClaims
 	}
 
-	var resolvableClaims []uint64
+	var candidates []uint64
 	for _, claim := range claims {
 		var parent types.Claim
 		if !claim.IsRootPosition() {
@@ -200,21 +328,54 @@ func (a *Agent) tryResolveClaims(ctx context.Context) error {
 				continue
 			}
 		}
-		a.log.Trace("Checking if claim is resolvable", "claimIdx", claim.ContractIndex)
-		if err := a.responder.CallResolveClaim(ctx, uint64(claim.ContractIndex)); err == nil {
-			a.log.Info("Resolving claim", "claimIdx", claim.ContractIndex)
-			resolvableClaims = append(resolvableClaims, uint64(claim.ContractIndex))
+		candidates = append(candidates, uint64(claim.ContractIndex))
+	}
+
+	// Check the claims concurrently, as each check is a separate call to the L1 node
+	resolvable := make([]bool, len(candidates))
+	var group errgroup.Group
+	group.SetLimit(a.resolveLimit)
+	for i, claimIdx := range candidates {
+		i, claimIdx := i, claimIdx
+		group.Go(func() error {
+			a.log.Trace("Checking if claim is resolvable", "claimIdx", claimIdx)
+			resolvable[i] = a.responder.CallResolveClaim(ctx, claimIdx) == nil
+			return nil
+		})
+	}
+	_ = group.Wait()
+	var resolvableClaims []uint64
+	for i, claimIdx := range candidates {
+		if resolvable[i] {
+			a.log.Info("Resolving claim", "claimIdx", claimIdx)
+			resolvableClaims = append(resolvableClaims, claimIdx)
 		}
 	}
 	if len(resolvableClaims) == 0 {
-		return errNoResolvableClaims
+		return nil, errNoResolvableClaims
+	}
+	if slices.Equal(resolvableClaims, prevClaims) {
+		// Resolving the claims had no effect, e.g. because the transactions failed or weren't sent in dry run mode.
+		// Wait for the next update rather than retrying immediately.
+		a.log.Debug("Claims still resolvable after resolving them", "numClaims", len(resolvableClaims))
+		return nil, errNoResolvableClaims
 	}
 	a.log.Info("Resolving claims", "numClaims", len(resolvableClaims))
 
 	if err := a.responder.ResolveClaims(resolvableClaims...); err != nil {
 		a.log.Error("Failed to resolve claims", "err", err)
+		a.notifyResolutionFailed("claim_"+string(notify.EventResolutionFailed), fmt.Sprintf("Failed to resolve %v claims", len(resolvableClaims)), err)
 	}
-	return nil
+	return resolvableClaims, nil
+}
+
+// notifyResolutionFailed sends a notification the first time resolving the game or its claims fails.
+func (a *Agent) notifyResolutionFailed(key string, summary string, err error) {
+	a.notifier.NotifyOnce(key, notify.Event{
+		Type:    notify.EventResolutionFailed,
+		Summary: summary,
+		Details: map[string]string{"error": err.Error()},
+	})
 }
 
 func (a *Agent) resolveClaims(ctx context.Context) error {
@@ -222,8 +383,10 @@ func (a *Agent) resolveClaims(ctx context.Context) error {
 	defer func() {
 		a.metrics.RecordClaimResolutionTime(a.systemClock.Since(start).Seconds())
 	}()
+	var resolved []uint64
 	for {
-		err := a.tryResolveClaims(ctx)
+		var err error
+		resolved, err = a.tryResolveClaims(ctx, resolved)
 		switch err {
 		case errNoResolvableClaims:
 			return nil
@@ -3,6 +3,7 @@ package fault
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"slices"
 	"sync"
@@ -17,11 +18,13 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/preimages"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-challenger/notify"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
 
@@ -153,6 +156,44 @@ func TestAgent_SelectiveClaimResolution(t *testing.T) {
 	}
 }
 
+func TestCheckClaimResolutionConcurrently(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t)
+	agent.resolveLimit = 2
+	claimLoader.maxLoads = 1
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+	rootTime := l1Time.Add(-agent.maxClockDuration - time.Hour)
+	gameBuilder := claimBuilder.GameBuilder(test.WithClock(rootTime, 0))
+	gameBuilder.Seq().
+		Attack(test.WithClock(rootTime, 0)).
+		Attack(test.WithClock(rootTime, 0))
+	claimLoader.claims = gameBuilder.Game.Claims()
+	responder.callResolveStatus = gameTypes.GameStatusDefenderWon
+	responder.callResolveClaimDelay = 20 * time.Millisecond
+
+	require.NoError(t, agent.Act(context.Background()))
+
+	require.Equal(t, 3, responder.callResolveClaimCount)
+	require.Equal(t, 2, responder.maxChecking, "should check claims concurrently up to the limit")
+	require.Equal(t, []uint64{0, 1, 2}, responder.resolvedClaims, "should resolve claims in order")
+}
+
+func TestStopResolvingClaimsWhenResolutionHasNoEffect(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t)
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+	rootTime := l1Time.Add(-agent.maxClockDuration - time.Hour)
+	gameBuilder := claimBuilder.GameBuilder(test.WithClock(rootTime, 0))
+	gameBuilder.Seq().Attack(test.WithClock(rootTime, 0))
+	claimLoader.claims = gameBuilder.Game.Claims()
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.resolveClaimErr = errors.New("boom")
+
+	require.NoError(t, agent.Act(context.Background()))
+
+	require.Equal(t, 2, responder.resolveClaimCount, "should attempt to resolve the claims once")
+}
+
 func TestSkipAttemptingToResolveClaimsWhenClockNotExpired(t *testing.T) {
 	agent, claimLoader, responder := setupTestAgent(t)
 	responder.callResolveErr = errors.New("game is not resolvable")
@@ -194,6 +235,161 @@ func TestLoadClaimsWhenGameNotResolvable(t *testing.T) {
 	require.Zero(t, responder.resolveClaimCount, "should not send resolveClaim")
 }
 
+func TestDeadline(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t)
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.callResolveClaimErr = errors.New("claim is not resolvable")
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+	require.Zero(t, agent.Deadline(), "should not have deadline before acting")
+
+	gameBuilder := claimBuilder.GameBuilder(test.WithClock(l1Time.Add(-time.Minute), 0))
+	gameBuilder.Seq().
+		Attack(test.WithClock(l1Time.Add(-30*time.Second), time.Minute)).
+		Defend(test.WithClock(l1Time.Add(-10*time.Second), 20*time.Second))
+	claimLoader.claims = gameBuilder.Game.Claims()
+	require.NoError(t, agent.Act(context.Background()))
+	// The defend claim has the least remaining time as the attack claim already used a minute of its clock
+	require.Equal(t, l1Time.Add(agent.maxClockDuration-time.Minute-10*time.Second), agent.Deadline())
+
+	// No deadline if no claims can be countered
+	expiredTime := l1Time.Add(-agent.maxClockDuration - time.Minute)
+	claimLoader.claims = claimBuilder.GameBuilder(test.WithClock(expiredTime, 0)).Game.Claims()
+	require.NoError(t, agent.Act(context.Background()))
+	require.Zero(t, agent.Deadline())
+}
+
+func TestSkipCheckingClaimsWhenNoActionsRequired(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t)
+	state := &stubStateStore{}
+	agent.state = state
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.callResolveClaimErr = errors.New("claim is not resolvable")
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+
+	// Agree with the root claim so no actions are required
+	claimLoader.claims = []types.Claim{claimBuilder.CreateRootClaim()}
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, 1, state.claimsSeen)
+	require.Empty(t, responder.actions)
+
+	// The claims would now require an action, but the agent doesn't check the claims again without new claims
+	claimLoader.claims = []types.Claim{claimBuilder.CreateRootClaim(test.WithInvalidValue(true))}
+	require.NoError(t, agent.Act(context.Background()))
+	require.Empty(t, responder.actions)
+
+	// Checks the claims once there are new claims
+	root := claimBuilder.CreateRootClaim(test.WithInvalidValue(true))
+	claimLoader.claims = []types.Claim{root, claimBuilder.AttackClaim(root, test.WithInvalidValue(true))}
+	require.NoError(t, agent.Act(context.Background()))
+	require.NotEmpty(t, responder.actions)
+	require.Equal(t, 1, state.claimsSeen, "should not record claims seen when actions are required")
+}
+
+func TestRepeatActionsMissingFromChain(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t)
+	agent.state = &stubStateStore{}
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.callResolveClaimErr = errors.New("claim is not resolvable")
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+	root := claimBuilder.CreateRootClaim(test.WithInvalidValue(true))
+	claimLoader.claims = []types.Claim{root}
+
+	responder.performActionErr = errors.New("boom")
+	require.NoError(t, agent.Act(context.Background()))
+	require.Len(t, responder.actions, 1)
+
+	responder.performActionErr = fmt.Errorf("failed to upload preimage: %w", preimages.ErrChallengePeriodNotOver)
+	require.NoError(t, agent.Act(context.Background()))
+	require.Len(t, responder.actions, 2)
+
+	responder.performActionErr = nil
+	require.NoError(t, agent.Act(context.Background()))
+	require.Len(t, responder.actions, 3)
+
+	// The action succeeded but isn't in the game on chain, e.g. because it was reorged out, so is performed again
+	require.NoError(t, agent.Act(context.Background()))
+	require.Len(t, responder.actions, 4, "should perform action again")
+	require.Equal(t, responder.actions[2], responder.actions[3])
+
+	// Once the counter claim is on chain, no further action is required
+	claimLoader.claims = []types.Claim{root, claimBuilder.AttackClaim(root)}
+	require.NoError(t, agent.Act(context.Background()))
+	require.Len(t, responder.actions, 4)
+}
+
+func TestPrefetchTraceValues(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t)
+	prefetcher := &stubPrefetcher{err: errors.New("boom")}
+	agent.prefetcher = prefetcher
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.callResolveClaimErr = errors.New("claim is not resolvable")
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+	claimLoader.claims = []types.Claim{claimBuilder.CreateRootClaim(test.WithInvalidValue(true))}
+
+	require.NoError(t, agent.Act(context.Background()))
+	require.Len(t, prefetcher.games, 1)
+	require.Equal(t, claimLoader.claims, prefetcher.games[0].Claims())
+	require.Len(t, responder.actions, 1, "should continue acting when prefetch fails")
+}
+
+func TestNotifyClaimCountered(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t)
+	notifier := &stubNotifier{}
+	agent.notifier = NewGameNotifier(agent.log, notifier, common.Address{0xaa}, nil)
+	ours := common.Address{0x01}
+	other := common.Address{0x02}
+	agent.claimants = []common.Address{ours}
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.callResolveClaimErr = errors.New("claim is not resolvable")
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+	gameBuilder := claimBuilder.GameBuilder(test.WithInvalidValue(true), test.WithClaimant(other))
+	seq := gameBuilder.Seq().Attack(test.WithClaimant(ours))
+	claimLoader.claims = gameBuilder.Game.Claims()
+
+	require.NoError(t, agent.Act(context.Background()))
+	require.Empty(t, notifier.events, "should not notify for counters to other claimants")
+
+	seq.Attack(test.WithClaimant(other))
+	claimLoader.claims = gameBuilder.Game.Claims()
+	require.NoError(t, agent.Act(context.Background()))
+	require.NoError(t, agent.Act(context.Background()))
+	require.Len(t, notifier.events, 1, "should notify once for each counter")
+	event := notifier.events[0]
+	require.Equal(t, notify.EventClaimCountered, event.Type)
+	require.Equal(t, common.Address{0xaa}, event.Game)
+	require.Equal(t, "1", event.Details["claimIdx"])
+	require.Equal(t, "2", event.Details["counterIdx"])
+	require.Equal(t, other.Hex(), event.Details["counterClaimant"])
+}
+
+func TestNotifyResolutionFailed(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t)
+	notifier := &stubNotifier{}
+	agent.notifier = NewGameNotifier(agent.log, notifier, common.Address{0xaa}, nil)
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+	rootTime := l1Time.Add(-agent.maxClockDuration - time.Hour)
+	gameBuilder := claimBuilder.GameBuilder(test.WithClock(rootTime, 0))
+	claimLoader.claims = gameBuilder.Game.Claims()
+	responder.callResolveStatus = gameTypes.GameStatusDefenderWon
+	responder.resolveClaimErr = errors.New("claim boom")
+	responder.resolveErr = errors.New("game boom")
+
+	require.NoError(t, agent.Act(context.Background()))
+	require.NoError(t, agent.Act(context.Background()))
+
+	require.Len(t, notifier.events, 2, "should notify once for claim and game resolution failures")
+	require.Equal(t, notify.EventResolutionFailed, notifier.events[0].Type)
+	require.Equal(t, "claim boom", notifier.events[0].Details["error"])
+	require.Equal(t, notify.EventResolutionFailed, notifier.events[1].Type)
+	require.Equal(t, "game boom", notifier.events[1].Details["error"])
+}
+
 func setupTestAgent(t *testing.T) (*Agent, *stubClaimLoader, *stubResponder) {
 	logger := testlog.Logger(t, log.LevelInfo)
 	claimLoader := &stubClaimLoader{}
@@ -203,7 +399,7 @@ func setupTestAgent(t *testing.T) (*Agent, *stubClaimLoader, *stubResponder) {
 	responder := &stubResponder{}
 	systemClock := clock.NewDeterministicClock(time.UnixMilli(120200))
 	l1Clock := clock.NewDeterministicClock(l1Time)
-	agent := NewAgent(metrics.NoopMetrics, systemClock, l1Clock, claimLoader, depth, gameDuration, trace.NewSimpleTraceAccessor(provider), responder, logger, false, []common.Address{})
+	agent := NewAgent(metrics.NoopMetrics, systemClock, l1Clock, claimLoader, depth, gameDuration, trace.NewSimpleTraceAccessor(provider), responder, logger, false, []common.Address{}, 1, nil, nil)
 	return agent, claimLoader, responder
 }
 
@@ -238,7 +434,16 @@ type stubResponder struct {
 	callResolveClaimCount int
 	callResolveClaimErr   error
 	resolveClaimCount     int
+	resolveClaimErr       error
 	resolvedClaims        []uint64
+
+	performActionErr error
+	actions          []types.Action
+
+	// Time each resolveClaim check takes, to track the number of concurrent checks
+	callResolveClaimDelay time.Duration
+	checking              int
+	maxChecking           int
 }
 
 func (s *stubResponder) CallResolve(_ context.Context) (gameTypes.GameStatus, error) {
@@ -262,6 +467,12 @@ func (s *stubResponder) CallResolveClaim(_ context.Context, idx uint64) error {
 		return errors.New("already resolved")
 	}
 	s.callResolveClaimCount++
+	s.checking++
+	s.maxChecking = max(s.maxChecking, s.checking)
+	s.l.Unlock()
+	time.Sleep(s.callResolveClaimDelay)
+	s.l.Lock()
+	s.checking--
 	return s.callResolveClaimErr
 }
 
@@ -269,10 +480,44 @@ func (s *stubResponder) ResolveClaims(claims ...uint64) error {
 	s.l.Lock()
 	defer s.l.Unlock()
 	s.resolveClaimCount += len(claims)
+	if s.resolveClaimErr != nil {
+		return s.resolveClaimErr
+	}
 	s.resolvedClaims = append(s.resolvedClaims, claims...)
 	return nil
 }
 
-func (s *stubResponder) PerformAction(_ context.Context, _ types.Action) error {
+func (s *stubResponder) PerformAction(_ context.Context, action types.Action) error {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.actions = append(s.actions, action)
+	return s.performActionErr
+}
+
+type stubStateStore struct {
+	l          sync.Mutex
+	claimsSeen int
+}
+
+func (s *stubStateStore) ClaimsSeen() (int, error) {
+	s.l.Lock()
+	defer s.l.Unlock()
+	return s.claimsSeen, nil
+}
+
+func (s *stubStateStore) SetClaimsSeen(count int) error {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.claimsSeen = count
 	return nil
 }
+
+type stubPrefetcher struct {
+	games []types.Game
+	err   error
+}
+
+func (s *stubPrefetcher) Prefetch(_ context.Context, game types.Game) error {
+	s.games = append(s.games, game)
+	return s.err
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/notify"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -26,6 +28,10 @@ type BondContract interface {
 	ClaimCreditTx(ctx context.Context, recipient common.Address) (txmgr.TxCandidate, error)
 }
 
+type Notifier interface {
+	Notify(event notify.Event)
+}
+
 type BondContractCreator func(game types.GameMetadata) (BondContract, error)
 
 type Claimer struct {
@@ -33,64 +39,161 @@ type Claimer struct {
 	metrics         BondClaimMetrics
 	contractCreator BondContractCreator
 	txSender        TxSender
+	clock           clock.Clock
+	policy          ClaimPolicy
+	multicall       *contracts.Multicall3Contract
+	notifier        Notifier
 	claimants       []common.Address
+
+	// notified is the games a bond claim failure has been notified for, so failures aren't notified each retry.
+	notified map[common.Address]bool
 }
 
 var _ BondClaimer = (*Claimer)(nil)
 
-func NewBondClaimer(l log.Logger, m BondClaimMetrics, contractCreator BondContractCreator, txSender TxSender, claimants ...common.Address) *Claimer {
+// bondClaim is a bond that can be claimed now.
+type bondClaim struct {
+	game     common.Address
+	claimant common.Address
+	credit   *big.Int
+	tx       txmgr.TxCandidate
+}
+
+func NewBondClaimer(l log.Logger, m BondClaimMetrics, contractCreator BondContractCreator, txSender TxSender, cl clock.Clock, policy ClaimPolicy, notifier Notifier, claimants ...common.Address) *Claimer {
+	var multicall *contracts.Multicall3Contract
+	if policy.BatchSize > 1 {
+		multicall = contracts.NewMulticall3Contract(policy.Multicall)
+	}
 	return &Claimer{
 		logger:          l,
 		metrics:         m,
 		contractCreator: contractCreator,
 		txSender:        txSender,
+		clock:           cl,
+		policy:          policy,
+		multicall:       multicall,
+		notifier:        notifier,
 		claimants:       claimants,
+		notified:        make(map[common.Address]bool),
 	}
 }
 
 func (c *Claimer) ClaimBonds(ctx context.Context, games []types.GameMetadata) (err error) {
+	if now := c.clock.Now(); !c.policy.InWindow(now) {
+		c.logger.Trace("Not claiming bonds outside of claim windows", "time", now.UTC(), "windows", c.policy.Windows)
+		return nil
+	}
+	var pending []bondClaim
+	total := new(big.Int)
 	for _, game := range games {
 		for _, claimant := range c.claimants {
-			err = errors.Join(err, c.claimBond(ctx, game, claimant))
+			claim, claimErr := c.claimableBond(ctx, game, claimant)
+			if claimErr != nil {
+				err = errors.Join(err, claimErr)
+				continue
+			}
+			if claim != nil {
+				pending = append(pending, *claim)
+				total.Add(total, claim.credit)
+			}
+		}
+	}
+	if len(pending) == 0 {
+		return err
+	}
+	if c.policy.MinValue != nil && total.Cmp(c.policy.MinValue) < 0 {
+		c.logger.Debug("Not claiming bonds below minimum value", "claims", len(pending), "total", total, "min", c.policy.MinValue)
+		return err
+	}
+	batchSize := int(max(c.policy.BatchSize, 1))
+	for start := 0; start < len(pending); start += batchSize {
+		batch := pending[start:min(start+batchSize, len(pending))]
+		if sendErr := c.sendClaims(batch); sendErr != nil {
+			c.notifyFailed(batch, sendErr)
+			err = errors.Join(err, sendErr)
 		}
 	}
 	return err
 }
 
-func (c *Claimer) claimBond(ctx context.Context, game types.GameMetadata, addr common.Address) error {
+// claimableBond returns the bond of addr in the game if it can be claimed now, or nil if not.
+func (c *Claimer) claimableBond(ctx context.Context, game types.GameMetadata, addr common.Address) (*bondClaim, error) {
 	c.logger.Debug("Attempting to claim bonds for", "game", game.Proxy, "addr", addr)
 
 	contract, err := c.contractCreator(game)
 	if err != nil {
-		return fmt.Errorf("failed to create bond contract: %w", err)
+		return nil, fmt.Errorf("failed to create bond contract: %w", err)
 	}
 
 	credit, status, err := contract.GetCredit(ctx, addr)
 	if err != nil {
-		return fmt.Errorf("failed to get credit: %w", err)
+		return nil, fmt.Errorf("failed to get credit: %w", err)
 	}
 
 	if status == types.GameStatusInProgress {
 		c.logger.Debug("Not claiming credit from in progress game", "game", game.Proxy, "addr", addr, "status", status)
-		return nil
+		return nil, nil
 	}
 	if credit.Cmp(big.NewInt(0)) == 0 {
 		c.logger.Debug("No credit to claim", "game", game.Proxy, "addr", addr)
-		return nil
+		return nil, nil
 	}
 
 	candidate, err := contract.ClaimCreditTx(ctx, addr)
 	if errors.Is(err, contracts.ErrSimulationFailed) {
 		c.logger.Debug("Credit still locked", "game", game.Proxy, "addr", addr)
-		return nil
+		return nil, nil
 	} else if err != nil {
-		return fmt.Errorf("failed to create credit claim tx: %w", err)
+		return nil, fmt.Errorf("failed to create credit claim tx: %w", err)
+	}
+	return &bondClaim{game: game.Proxy, claimant: addr, credit: credit, tx: candidate}, nil
+}
+
+// sendClaims claims the bonds in one transaction, batching them via the multicall contract if there are several.
+func (c *Claimer) sendClaims(claims []bondClaim) error {
+	candidate := claims[0].tx
+	if len(claims) > 1 {
+		txs := make([]txmgr.TxCandidate, 0, len(claims))
+		for _, claim := range claims {
+			txs = append(txs, claim.tx)
+		}
+		var err error
+		candidate, err = c.multicall.Aggregate3Tx(txs...)
+		if err != nil {
+			return fmt.Errorf("failed to create batched credit claim tx: %w", err)
+		}
+		c.logger.Debug("Claiming bonds in batch", "claims", len(claims), "multicall", c.multicall.Addr())
 	}
 
-	if err = c.txSender.SendAndWaitSimple("claim credit", candidate); err != nil {
+	if err := c.txSender.SendAndWaitSimple("claim credit", candidate); err != nil {
 		return fmt.Errorf("failed to claim credit: %w", err)
 	}
 
-	c.metrics.RecordBondClaimed(credit.Uint64())
+	for _, claim := range claims {
+		c.metrics.RecordBondClaimed(claim.credit.Uint64())
+	}
 	return nil
 }
+
+// notifyFailed sends a notification for each game that the bonds failed to be claimed from, once per game.
+func (c *Claimer) notifyFailed(claims []bondClaim, err error) {
+	if c.notifier == nil {
+		return
+	}
+	for _, claim := range claims {
+		if c.notified[claim.game] {
+			continue
+		}
+		c.notified[claim.game] = true
+		c.notifier.Notify(notify.Event{
+			Type:    notify.EventBondClaimFailed,
+			Game:    claim.game,
+			Summary: fmt.Sprintf("Failed to claim bond of %v wei", claim.credit),
+			Details: map[string]string{
+				"claimant": claim.claimant.Hex(),
+				"credit":   claim.credit.String(),
+				"error":    err.Error(),
+			},
+		})
+	}
+}
@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/notify"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
@@ -18,6 +21,8 @@ import (
 
 var (
 	mockTxMgrSendError = errors.New("mock tx mgr send error")
+	bondGameAddr       = common.HexToAddress("0x1234")
+	testClaimTime      = time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)
 )
 
 func TestClaimer_ClaimBonds(t *testing.T) {
@@ -109,9 +114,115 @@ func TestClaimer_ClaimBonds(t *testing.T) {
 		require.Equal(t, 3, txSender.sends)
 		require.Equal(t, 0, m.RecordBondClaimedCalls)
 	})
+
+	t.Run("NotifyFailureOncePerGame", func(t *testing.T) {
+		game1 := common.HexToAddress("0x1234")
+		game2 := common.HexToAddress("0x5678")
+		c, _, contract, txSender := newTestClaimer(t)
+		notifier := &stubNotifier{}
+		c.notifier = notifier
+		contract.credit[txSender.From()] = 1
+		txSender.sendFails = true
+		games := []types.GameMetadata{{Proxy: game1}, {Proxy: game2}}
+		require.ErrorIs(t, c.ClaimBonds(context.Background(), games), mockTxMgrSendError)
+		require.ErrorIs(t, c.ClaimBonds(context.Background(), games), mockTxMgrSendError)
+
+		require.Len(t, notifier.events, 2)
+		for i, game := range []common.Address{game1, game2} {
+			require.Equal(t, notify.EventBondClaimFailed, notifier.events[i].Type)
+			require.Equal(t, game, notifier.events[i].Game)
+			require.Equal(t, txSender.From().Hex(), notifier.events[i].Details["claimant"])
+		}
+	})
+}
+
+func TestClaimer_ClaimPolicy(t *testing.T) {
+	claimant1 := common.Address{0xaa}
+	claimant2 := common.Address{0xbb}
+	games := []types.GameMetadata{{Proxy: common.Address{0x01}}, {Proxy: common.Address{0x02}}, {Proxy: common.Address{0x03}}}
+
+	t.Run("BelowMinValue", func(t *testing.T) {
+		c, m, contract, txSender := newTestClaimerWithPolicy(t, ClaimPolicy{MinValue: big.NewInt(10)}, claimant1, claimant2)
+		contract.credit[claimant1] = 1
+		contract.credit[claimant2] = 2
+		err := c.ClaimBonds(context.Background(), games)
+		require.NoError(t, err)
+		require.Equal(t, 0, txSender.sends)
+		require.Equal(t, 0, m.RecordBondClaimedCalls)
+	})
+
+	t.Run("AggregateValueAboveMinValue", func(t *testing.T) {
+		c, m, contract, txSender := newTestClaimerWithPolicy(t, ClaimPolicy{MinValue: big.NewInt(9)}, claimant1, claimant2)
+		contract.credit[claimant1] = 1
+		contract.credit[claimant2] = 2
+		err := c.ClaimBonds(context.Background(), games)
+		require.NoError(t, err)
+		require.Equal(t, 6, txSender.sends)
+		require.Equal(t, 6, m.RecordBondClaimedCalls)
+	})
+
+	t.Run("Batched", func(t *testing.T) {
+		multicall := common.Address{0xca, 0x11}
+		c, m, contract, txSender := newTestClaimerWithPolicy(t, ClaimPolicy{BatchSize: 4, Multicall: multicall}, claimant1, claimant2)
+		contract.credit[claimant1] = 1
+		contract.credit[claimant2] = 2
+		err := c.ClaimBonds(context.Background(), games)
+		require.NoError(t, err)
+		require.Equal(t, 2, txSender.sends)
+		require.Len(t, txSender.sent, 2)
+		for _, tx := range txSender.sent {
+			require.Equal(t, multicall, *tx.To)
+		}
+		require.Equal(t, 6, m.RecordBondClaimedCalls)
+	})
+
+	t.Run("SingleClaimNotBatched", func(t *testing.T) {
+		c, m, contract, txSender := newTestClaimerWithPolicy(t, ClaimPolicy{BatchSize: 4, Multicall: common.Address{0xca, 0x11}})
+		contract.credit[txSender.From()] = 1
+		err := c.ClaimBonds(context.Background(), games[:1])
+		require.NoError(t, err)
+		require.Len(t, txSender.sent, 1)
+		require.Equal(t, bondGameAddr, *txSender.sent[0].To)
+		require.Equal(t, 1, m.RecordBondClaimedCalls)
+	})
+
+	t.Run("BatchFails", func(t *testing.T) {
+		c, m, contract, txSender := newTestClaimerWithPolicy(t, ClaimPolicy{BatchSize: 4, Multicall: common.Address{0xca, 0x11}}, claimant1, claimant2)
+		contract.credit[claimant1] = 1
+		contract.credit[claimant2] = 2
+		txSender.sendFails = true
+		err := c.ClaimBonds(context.Background(), games)
+		require.ErrorIs(t, err, mockTxMgrSendError)
+		require.Equal(t, 2, txSender.sends)
+		require.Equal(t, 0, m.RecordBondClaimedCalls)
+	})
+
+	t.Run("OutsideWindow", func(t *testing.T) {
+		window := TimeWindow{Start: 2 * time.Hour, End: 4 * time.Hour}
+		c, m, contract, txSender := newTestClaimerWithPolicy(t, ClaimPolicy{Windows: []TimeWindow{window}})
+		contract.credit[txSender.From()] = 1
+		err := c.ClaimBonds(context.Background(), games)
+		require.NoError(t, err)
+		require.Equal(t, 0, txSender.sends)
+		require.Equal(t, 0, m.RecordBondClaimedCalls)
+	})
+
+	t.Run("InsideWindow", func(t *testing.T) {
+		windows := []TimeWindow{{Start: 2 * time.Hour, End: 4 * time.Hour}, {Start: 12 * time.Hour, End: 13 * time.Hour}}
+		c, m, contract, txSender := newTestClaimerWithPolicy(t, ClaimPolicy{Windows: windows})
+		contract.credit[txSender.From()] = 1
+		err := c.ClaimBonds(context.Background(), games)
+		require.NoError(t, err)
+		require.Equal(t, 3, txSender.sends)
+		require.Equal(t, 3, m.RecordBondClaimedCalls)
+	})
 }
 
 func newTestClaimer(t *testing.T, claimants ...common.Address) (*Claimer, *mockClaimMetrics, *stubBondContract, *mockTxSender) {
+	return newTestClaimerWithPolicy(t, ClaimPolicy{}, claimants...)
+}
+
+func newTestClaimerWithPolicy(t *testing.T, policy ClaimPolicy, claimants ...common.Address) (*Claimer, *mockClaimMetrics, *stubBondContract, *mockTxSender) {
 	logger := testlog.Logger(t, log.LvlDebug)
 	m := &mockClaimMetrics{}
 	txSender := &mockTxSender{}
@@ -122,10 +233,18 @@ func newTestClaimer(t *testing.T, claimants ...common.Address) (*Claimer, *mockC
 	if len(claimants) == 0 {
 		claimants = []common.Address{txSender.From()}
 	}
-	c := NewBondClaimer(logger, m, contractCreator, txSender, claimants...)
+	c := NewBondClaimer(logger, m, contractCreator, txSender, clock.NewDeterministicClock(testClaimTime), policy, nil, claimants...)
 	return c, m, bondContract, txSender
 }
 
+type stubNotifier struct {
+	events []notify.Event
+}
+
+func (s *stubNotifier) Notify(event notify.Event) {
+	s.events = append(s.events, event)
+}
+
 type mockClaimMetrics struct {
 	RecordBondClaimedCalls int
 }
@@ -136,6 +255,7 @@ func (m *mockClaimMetrics) RecordBondClaimed(amount uint64) {
 
 type mockTxSender struct {
 	sends      int
+	sent       []txmgr.TxCandidate
 	sendFails  bool
 	statusFail bool
 }
@@ -144,8 +264,9 @@ func (s *mockTxSender) From() common.Address {
 	return common.HexToAddress("0x33333")
 }
 
-func (s *mockTxSender) SendAndWaitSimple(_ string, _ ...txmgr.TxCandidate) error {
+func (s *mockTxSender) SendAndWaitSimple(_ string, txs ...txmgr.TxCandidate) error {
 	s.sends++
+	s.sent = append(s.sent, txs...)
 	if s.sendFails {
 		return mockTxMgrSendError
 	}
@@ -169,5 +290,5 @@ func (s *stubBondContract) ClaimCreditTx(_ context.Context, _ common.Address) (t
 	if s.claimSimulationFails {
 		return txmgr.TxCandidate{}, fmt.Errorf("failed: %w", contracts.ErrSimulationFailed)
 	}
-	return txmgr.TxCandidate{}, nil
+	return txmgr.TxCandidate{To: &bondGameAddr, TxData: []byte{0xaa}}, nil
 }
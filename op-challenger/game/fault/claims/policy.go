@@ -0,0 +1,89 @@
+package claims
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrInvalidTimeWindow = errors.New("invalid time window")
+
+// ClaimPolicy controls when and how bonds are claimed.
+// The zero value claims each bond in its own transaction as soon as it can be claimed.
+type ClaimPolicy struct {
+	// MinValue is the minimum total credit of the claimable bonds to claim them. Nil claims any credit.
+	MinValue *big.Int
+	// BatchSize is the maximum number of bond claims to send in one transaction via the Multicall contract.
+	// Bonds are claimed in separate transactions if it's 0 or 1.
+	BatchSize uint
+	// Multicall is the address of the Multicall3 contract used to batch bond claims.
+	Multicall common.Address
+	// Windows are the daily time windows to claim bonds in. Bonds are claimed at any time if empty.
+	Windows []TimeWindow
+}
+
+// InWindow returns true if bonds can be claimed at t.
+func (p ClaimPolicy) InWindow(t time.Time) bool {
+	if len(p.Windows) == 0 {
+		return true
+	}
+	for _, w := range p.Windows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// TimeWindow is a daily time window in UTC, from Start (inclusive) to End (exclusive), as offsets from midnight.
+// The window spans midnight if End is before Start.
+type TimeWindow struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// ParseTimeWindow parses a time window in the format HH:MM-HH:MM, in UTC.
+func ParseTimeWindow(s string) (TimeWindow, error) {
+	start, end, ok := strings.Cut(s, "-")
+	if !ok {
+		return TimeWindow{}, fmt.Errorf("%w %q: expected HH:MM-HH:MM", ErrInvalidTimeWindow, s)
+	}
+	startOffset, err := parseTimeOfDay(start)
+	if err != nil {
+		return TimeWindow{}, fmt.Errorf("%w %q: %w", ErrInvalidTimeWindow, s, err)
+	}
+	endOffset, err := parseTimeOfDay(end)
+	if err != nil {
+		return TimeWindow{}, fmt.Errorf("%w %q: %w", ErrInvalidTimeWindow, s, err)
+	}
+	if startOffset == endOffset {
+		return TimeWindow{}, fmt.Errorf("%w %q: window is empty", ErrInvalidTimeWindow, s)
+	}
+	return TimeWindow{Start: startOffset, End: endOffset}, nil
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Contains returns true if the time of day of t, in UTC, is in the window.
+func (w TimeWindow) Contains(t time.Time) bool {
+	t = t.UTC()
+	offset := t.Sub(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC))
+	if w.Start < w.End {
+		return offset >= w.Start && offset < w.End
+	}
+	return offset >= w.Start || offset < w.End
+}
+
+func (w TimeWindow) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", int(w.Start.Hours()), int(w.Start.Minutes())%60, int(w.End.Hours()), int(w.End.Minutes())%60)
+}
@@ -0,0 +1,52 @@
+package claims
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeWindow(t *testing.T) {
+	window, err := ParseTimeWindow("02:30-04:00")
+	require.NoError(t, err)
+	require.Equal(t, TimeWindow{Start: 2*time.Hour + 30*time.Minute, End: 4 * time.Hour}, window)
+	require.Equal(t, "02:30-04:00", window.String())
+
+	window, err = ParseTimeWindow("22:00-01:15")
+	require.NoError(t, err)
+	require.Equal(t, TimeWindow{Start: 22 * time.Hour, End: time.Hour + 15*time.Minute}, window)
+
+	for _, invalid := range []string{"", "02:00", "02:00-", "25:00-26:00", "02:00-02:00", "2pm-4pm"} {
+		_, err := ParseTimeWindow(invalid)
+		require.ErrorIsf(t, err, ErrInvalidTimeWindow, "window %q", invalid)
+	}
+}
+
+func TestTimeWindowContains(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	window := TimeWindow{Start: 2 * time.Hour, End: 4 * time.Hour}
+	require.False(t, window.Contains(at(1, 59)))
+	require.True(t, window.Contains(at(2, 0)))
+	require.True(t, window.Contains(at(3, 59)))
+	require.False(t, window.Contains(at(4, 0)))
+	// Times are compared in UTC
+	require.True(t, window.Contains(at(3, 0).In(time.FixedZone("UTC+5", 5*60*60))))
+
+	overnight := TimeWindow{Start: 22 * time.Hour, End: 2 * time.Hour}
+	require.True(t, overnight.Contains(at(23, 0)))
+	require.True(t, overnight.Contains(at(0, 0)))
+	require.True(t, overnight.Contains(at(1, 59)))
+	require.False(t, overnight.Contains(at(2, 0)))
+	require.False(t, overnight.Contains(at(12, 0)))
+}
+
+func TestClaimPolicyInWindow(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	require.True(t, ClaimPolicy{}.InWindow(now))
+	require.False(t, ClaimPolicy{Windows: []TimeWindow{{Start: 2 * time.Hour, End: 4 * time.Hour}}}.InWindow(now))
+	require.True(t, ClaimPolicy{Windows: []TimeWindow{{Start: 2 * time.Hour, End: 4 * time.Hour}, {Start: 11 * time.Hour, End: 13 * time.Hour}}}.InWindow(now))
+}
@@ -0,0 +1,56 @@
+package contracts
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:embed abis/Multicall3.json
+var multicall3Abi []byte
+
+var methodAggregate3 = "aggregate3"
+
+var ErrValueNotSupported = errors.New("multicall does not support calls with value")
+
+// Multicall3Contract batches transactions into a single transaction via a Multicall3 contract.
+type Multicall3Contract struct {
+	contract *batching.BoundContract
+}
+
+// call3 is the Multicall3.Call3 struct of aggregate3.
+type call3 struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+func NewMulticall3Contract(addr common.Address) *Multicall3Contract {
+	return &Multicall3Contract{
+		contract: batching.NewBoundContract(mustParseAbi(multicall3Abi), addr),
+	}
+}
+
+func (m *Multicall3Contract) Addr() common.Address {
+	return m.contract.Addr()
+}
+
+// Aggregate3Tx creates a transaction that executes the candidates in order. The transaction reverts if any of them fail.
+// Note that the candidates are called by the Multicall3 contract, not the transaction sender.
+func (m *Multicall3Contract) Aggregate3Tx(candidates ...txmgr.TxCandidate) (txmgr.TxCandidate, error) {
+	calls := make([]call3, 0, len(candidates))
+	for i, candidate := range candidates {
+		if candidate.To == nil {
+			return txmgr.TxCandidate{}, fmt.Errorf("call %v has no target", i)
+		}
+		if candidate.Value != nil && candidate.Value.Sign() != 0 {
+			return txmgr.TxCandidate{}, fmt.Errorf("call %v: %w", i, ErrValueNotSupported)
+		}
+		calls = append(calls, call3{Target: *candidate.To, CallData: candidate.TxData})
+	}
+	return m.contract.Call(methodAggregate3, calls).ToTxCandidate()
+}
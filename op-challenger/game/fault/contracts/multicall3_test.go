@@ -0,0 +1,52 @@
+package contracts
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMulticall3_Aggregate3Tx(t *testing.T) {
+	multicallAddr := common.Address{0xca, 0x11}
+	multicall := NewMulticall3Contract(multicallAddr)
+	game1 := common.Address{0x01}
+	game2 := common.Address{0x02}
+
+	t.Run("Valid", func(t *testing.T) {
+		tx, err := multicall.Aggregate3Tx(
+			txmgr.TxCandidate{To: &game1, TxData: []byte{0xaa, 0xbb}},
+			txmgr.TxCandidate{To: &game2, TxData: []byte{0xcc}})
+		require.NoError(t, err)
+		require.Equal(t, multicallAddr, *tx.To)
+
+		method := mustParseAbi(multicall3Abi).Methods[methodAggregate3]
+		require.Equal(t, method.ID, tx.TxData[:4])
+		args, err := method.Inputs.Unpack(tx.TxData[4:])
+		require.NoError(t, err)
+		calls := args[0].([]struct {
+			Target       common.Address `json:"target"`
+			AllowFailure bool           `json:"allowFailure"`
+			CallData     []byte         `json:"callData"`
+		})
+		require.Len(t, calls, 2)
+		require.Equal(t, game1, calls[0].Target)
+		require.False(t, calls[0].AllowFailure)
+		require.Equal(t, []byte{0xaa, 0xbb}, calls[0].CallData)
+		require.Equal(t, game2, calls[1].Target)
+		require.False(t, calls[1].AllowFailure)
+		require.Equal(t, []byte{0xcc}, calls[1].CallData)
+	})
+
+	t.Run("MissingTarget", func(t *testing.T) {
+		_, err := multicall.Aggregate3Tx(txmgr.TxCandidate{TxData: []byte{0xaa}})
+		require.ErrorContains(t, err, "no target")
+	})
+
+	t.Run("WithValue", func(t *testing.T) {
+		_, err := multicall.Aggregate3Tx(txmgr.TxCandidate{To: &game1, Value: big.NewInt(1)})
+		require.ErrorIs(t, err, ErrValueNotSupported)
+	})
+}
@@ -0,0 +1,107 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	methodProvenWithdrawals = "provenWithdrawals"
+
+	eventWithdrawalProvenExtension1 = "WithdrawalProvenExtension1"
+)
+
+type OptimismPortal2Contract struct {
+	metrics     metrics.ContractMetricer
+	multiCaller *batching.MultiCaller
+	abi         *abi.ABI
+	contract    *batching.BoundContract
+}
+
+// WithdrawalProof is a proof of a withdrawal submitted to the portal.
+type WithdrawalProof struct {
+	WithdrawalHash common.Hash
+	ProofSubmitter common.Address
+	// L1Block is the number of the block the proof was submitted in.
+	L1Block uint64
+}
+
+// ProvenWithdrawal is the dispute game a withdrawal proof was made against.
+type ProvenWithdrawal struct {
+	DisputeGame common.Address
+	Timestamp   uint64
+}
+
+func NewOptimismPortal2Contract(metrics metrics.ContractMetricer, addr common.Address, caller *batching.MultiCaller) *OptimismPortal2Contract {
+	contractAbi := snapshots.LoadOptimismPortal2ABI()
+	return &OptimismPortal2Contract{
+		metrics:     metrics,
+		multiCaller: caller,
+		abi:         contractAbi,
+		contract:    batching.NewBoundContract(contractAbi, addr),
+	}
+}
+
+func (p *OptimismPortal2Contract) Addr() common.Address {
+	return p.contract.Addr()
+}
+
+// WithdrawalProofsQuery returns the filter query for logs of withdrawal proofs submitted in the inclusive block range.
+func (p *OptimismPortal2Contract) WithdrawalProofsQuery(fromBlock uint64, toBlock uint64) ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Addresses: []common.Address{p.contract.Addr()},
+		Topics:    [][]common.Hash{{p.abi.Events[eventWithdrawalProvenExtension1].ID}},
+	}
+}
+
+// DecodeWithdrawalProofLog decodes a log returned by the WithdrawalProofsQuery.
+func (p *OptimismPortal2Contract) DecodeWithdrawalProofLog(log *ethTypes.Log) (WithdrawalProof, error) {
+	if log.Address != p.contract.Addr() {
+		return WithdrawalProof{}, fmt.Errorf("%w: log from %v", ErrEventNotFound, log.Address)
+	}
+	name, result, err := p.contract.DecodeEvent(log)
+	if err != nil {
+		return WithdrawalProof{}, fmt.Errorf("failed to decode withdrawal proof: %w", err)
+	}
+	if name != eventWithdrawalProvenExtension1 {
+		return WithdrawalProof{}, fmt.Errorf("%w: %v", ErrEventNotFound, eventWithdrawalProvenExtension1)
+	}
+	return WithdrawalProof{
+		WithdrawalHash: result.GetHash(0),
+		ProofSubmitter: result.GetAddress(1),
+		L1Block:        log.BlockNumber,
+	}, nil
+}
+
+// GetProvenWithdrawals returns the dispute game each of the withdrawal proofs was made against.
+func (p *OptimismPortal2Contract) GetProvenWithdrawals(ctx context.Context, block rpcblock.Block, proofs ...WithdrawalProof) ([]ProvenWithdrawal, error) {
+	defer p.metrics.StartContractRequest("GetProvenWithdrawals")()
+	calls := make([]batching.Call, 0, len(proofs))
+	for _, proof := range proofs {
+		calls = append(calls, p.contract.Call(methodProvenWithdrawals, proof.WithdrawalHash, proof.ProofSubmitter))
+	}
+	results, err := p.multiCaller.Call(ctx, block, calls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch proven withdrawals: %w", err)
+	}
+	proven := make([]ProvenWithdrawal, len(results))
+	for i, result := range results {
+		proven[i] = ProvenWithdrawal{
+			DisputeGame: result.GetAddress(0),
+			Timestamp:   result.GetUint64(1),
+		}
+	}
+	return proven, nil
+}
@@ -0,0 +1,109 @@
+package contracts
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	contractMetrics "github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	batchingTest "github.com/ethereum-optimism/optimism/op-service/sources/batching/test"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	portalAddr = common.HexToAddress("0x4a3bc9712B5cAE3D87AC1C8a7E1F0b521fA7E0F1")
+)
+
+func TestOptimismPortal2_GetProvenWithdrawals(t *testing.T) {
+	stubRpc, portal := setupOptimismPortal2Test(t)
+	block := rpcblock.ByNumber(482)
+
+	proofs := []WithdrawalProof{
+		{WithdrawalHash: common.Hash{0xaa}, ProofSubmitter: common.Address{0x01}},
+		{WithdrawalHash: common.Hash{0xbb}, ProofSubmitter: common.Address{0x02}},
+	}
+	expected := []ProvenWithdrawal{
+		{DisputeGame: common.Address{0x11}, Timestamp: 1234},
+		{DisputeGame: common.Address{0x22}, Timestamp: 5678},
+	}
+	for i, proof := range proofs {
+		stubRpc.SetResponse(portalAddr, methodProvenWithdrawals, block,
+			[]interface{}{proof.WithdrawalHash, proof.ProofSubmitter},
+			[]interface{}{expected[i].DisputeGame, expected[i].Timestamp})
+	}
+
+	actual, err := portal.GetProvenWithdrawals(context.Background(), block, proofs...)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+}
+
+func TestOptimismPortal2_WithdrawalProofsQuery(t *testing.T) {
+	_, portal := setupOptimismPortal2Test(t)
+	query := portal.WithdrawalProofsQuery(10, 20)
+	require.Equal(t, big.NewInt(10), query.FromBlock)
+	require.Equal(t, big.NewInt(20), query.ToBlock)
+	require.Equal(t, []common.Address{portalAddr}, query.Addresses)
+	require.Equal(t, [][]common.Hash{{snapshots.LoadOptimismPortal2ABI().Events[eventWithdrawalProvenExtension1].ID}}, query.Topics)
+}
+
+func TestOptimismPortal2_DecodeWithdrawalProofLog(t *testing.T) {
+	_, portal := setupOptimismPortal2Test(t)
+	portalAbi := snapshots.LoadOptimismPortal2ABI()
+	withdrawalHash := common.Hash{0xaa, 0xbb}
+	submitter := common.Address{0x33}
+
+	createValidLog := func() *ethTypes.Log {
+		return &ethTypes.Log{
+			Address:     portalAddr,
+			BlockNumber: 42,
+			Topics: []common.Hash{
+				portalAbi.Events[eventWithdrawalProvenExtension1].ID,
+				withdrawalHash,
+				common.BytesToHash(submitter.Bytes()),
+			},
+		}
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		proof, err := portal.DecodeWithdrawalProofLog(createValidLog())
+		require.NoError(t, err)
+		require.Equal(t, WithdrawalProof{WithdrawalHash: withdrawalHash, ProofSubmitter: submitter, L1Block: 42}, proof)
+	})
+
+	t.Run("IncorrectContract", func(t *testing.T) {
+		log := createValidLog()
+		log.Address = common.Address{0xff}
+		_, err := portal.DecodeWithdrawalProofLog(log)
+		require.ErrorIs(t, err, ErrEventNotFound)
+	})
+
+	t.Run("InvalidEvent", func(t *testing.T) {
+		log := createValidLog()
+		log.Topics = log.Topics[0:2]
+		_, err := portal.DecodeWithdrawalProofLog(log)
+		require.ErrorIs(t, err, batching.ErrInvalidEvent)
+	})
+
+	t.Run("WrongEvent", func(t *testing.T) {
+		log := createValidLog()
+		log.Topics = []common.Hash{
+			portalAbi.Events["DisputeGameBlacklisted"].ID,
+			common.BytesToHash(common.Address{0x11}.Bytes()),
+		}
+		_, err := portal.DecodeWithdrawalProofLog(log)
+		require.ErrorIs(t, err, ErrEventNotFound)
+	})
+}
+
+func setupOptimismPortal2Test(t *testing.T) (*batchingTest.AbiBasedRpc, *OptimismPortal2Contract) {
+	portalAbi := snapshots.LoadOptimismPortal2ABI()
+	stubRpc := batchingTest.NewAbiBasedRpc(t, portalAddr, portalAbi)
+	caller := batching.NewMultiCaller(stubRpc, batching.DefaultBatchSize)
+	portal := NewOptimismPortal2Contract(contractMetrics.NoopContractMetrics, portalAddr, caller)
+	return stubRpc, portal
+}
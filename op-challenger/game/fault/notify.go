@@ -0,0 +1,73 @@
+package fault
+
+import (
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/notify"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Notifier sends notifications of key events in games to the operator.
+type Notifier interface {
+	Notify(event notify.Event)
+}
+
+// NotificationStore records the notifications sent for a game so they aren't repeated when the challenger restarts.
+type NotificationStore interface {
+	Notified(key string) (bool, error)
+	RecordNotified(key string) error
+}
+
+// GameNotifier sends notifications of events in a single game, each at most once.
+// A nil GameNotifier sends no notifications.
+type GameNotifier struct {
+	logger   log.Logger
+	notifier Notifier
+	game     common.Address
+	store    NotificationStore
+
+	mu   sync.Mutex
+	sent map[string]bool
+}
+
+// NewGameNotifier creates a GameNotifier for the game. If store is nil, notifications are only deduplicated until the
+// challenger restarts.
+func NewGameNotifier(logger log.Logger, notifier Notifier, game common.Address, store NotificationStore) *GameNotifier {
+	return &GameNotifier{
+		logger:   logger,
+		notifier: notifier,
+		game:     game,
+		store:    store,
+		sent:     make(map[string]bool),
+	}
+}
+
+// NotifyOnce sends a notification of the event unless a notification with the same key was already sent for the game.
+func (n *GameNotifier) NotifyOnce(key string, event notify.Event) {
+	if n == nil || n.notifier == nil {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.sent[key] {
+		return
+	}
+	if n.store != nil {
+		notified, err := n.store.Notified(key)
+		if err != nil {
+			n.logger.Warn("Failed to check if notification was already sent", "key", key, "err", err)
+		} else if notified {
+			n.sent[key] = true
+			return
+		}
+	}
+	event.Game = n.game
+	n.notifier.Notify(event)
+	n.sent[key] = true
+	if n.store != nil {
+		if err := n.store.RecordNotified(key); err != nil {
+			n.logger.Warn("Failed to record notification", "key", key, "err", err)
+		}
+	}
+}
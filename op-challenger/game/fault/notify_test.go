@@ -0,0 +1,86 @@
+package fault
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/notify"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameNotifier(t *testing.T) {
+	game := common.Address{0xaa}
+	event := notify.Event{Type: notify.EventGameSeen, Summary: "Playing new game"}
+
+	t.Run("NilNotifier", func(t *testing.T) {
+		var n *GameNotifier
+		n.NotifyOnce("key", event)
+		n = NewGameNotifier(testlog.Logger(t, log.LevelInfo), nil, game, &stubNotificationStore{})
+		n.NotifyOnce("key", event)
+	})
+
+	t.Run("NotifyOncePerKey", func(t *testing.T) {
+		notifier := &stubNotifier{}
+		n := NewGameNotifier(testlog.Logger(t, log.LevelInfo), notifier, game, nil)
+		n.NotifyOnce("key", event)
+		n.NotifyOnce("key", event)
+		n.NotifyOnce("other", event)
+		require.Len(t, notifier.events, 2)
+		require.Equal(t, game, notifier.events[0].Game)
+	})
+
+	t.Run("NotifyOnceAcrossRestarts", func(t *testing.T) {
+		notifier := &stubNotifier{}
+		store := &stubNotificationStore{}
+		NewGameNotifier(testlog.Logger(t, log.LevelInfo), notifier, game, store).NotifyOnce("key", event)
+		NewGameNotifier(testlog.Logger(t, log.LevelInfo), notifier, game, store).NotifyOnce("key", event)
+		require.Len(t, notifier.events, 1)
+	})
+
+	t.Run("NotifyWhenStoreFails", func(t *testing.T) {
+		notifier := &stubNotifier{}
+		store := &stubNotificationStore{err: errors.New("boom")}
+		n := NewGameNotifier(testlog.Logger(t, log.LevelInfo), notifier, game, store)
+		n.NotifyOnce("key", event)
+		n.NotifyOnce("key", event)
+		require.Len(t, notifier.events, 1)
+	})
+}
+
+type stubNotifier struct {
+	l      sync.Mutex
+	events []notify.Event
+}
+
+func (s *stubNotifier) Notify(event notify.Event) {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.events = append(s.events, event)
+}
+
+type stubNotificationStore struct {
+	notified map[string]bool
+	err      error
+}
+
+func (s *stubNotificationStore) Notified(key string) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+	return s.notified[key], nil
+}
+
+func (s *stubNotificationStore) RecordNotified(key string) error {
+	if s.err != nil {
+		return s.err
+	}
+	if s.notified == nil {
+		s.notified = make(map[string]bool)
+	}
+	s.notified[key] = true
+	return nil
+}
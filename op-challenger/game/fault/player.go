@@ -4,17 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/accounting"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/preimages"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/responder"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-challenger/notify"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 	gethTypes "github.com/ethereum/go-ethereum/core/types"
@@ -38,17 +43,29 @@ type L1HeaderSource interface {
 
 type TxSender interface {
 	From() common.Address
-	SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error
+	SendAndWaitFees(txPurpose string, txs ...txmgr.TxCandidate) (*big.Int, error)
+}
+
+type ClaimResolver interface {
+	ResolveClaims(txs ...txmgr.TxCandidate) error
 }
 
 type GamePlayer struct {
 	act                actor
+	deadline           func() time.Time
 	loader             GameInfo
 	logger             log.Logger
 	syncValidator      SyncValidator
 	prestateValidators []Validator
 	status             gameTypes.GameStatus
 	gameL1Head         eth.BlockID
+
+	// Accounting of the game, recorded when it resolves. Not set if the game was already resolved when loaded.
+	metrics     metrics.Metricer
+	ledger      *accounting.Ledger
+	claimLoader ClaimLoader
+	claimants   []common.Address
+	notifier    *GameNotifier
 }
 
 type GameContract interface {
@@ -79,6 +96,8 @@ func NewGamePlayer(
 	dir string,
 	addr common.Address,
 	txSender TxSender,
+	resolver ClaimResolver,
+	resolveConcurrency int,
 	loader GameContract,
 	syncValidator SyncValidator,
 	validators []Validator,
@@ -86,6 +105,8 @@ func NewGamePlayer(
 	l1HeaderSource L1HeaderSource,
 	selective bool,
 	claimants []common.Address,
+	state StateStore,
+	notifier Notifier,
 ) (*GamePlayer, error) {
 	logger = logger.New("game", addr)
 
@@ -140,23 +161,39 @@ func NewGamePlayer(
 	if err != nil {
 		return nil, fmt.Errorf("failed to load min large preimage size: %w", err)
 	}
-	direct := preimages.NewDirectPreimageUploader(logger, txSender, loader)
-	large := preimages.NewLargePreimageUploader(logger, l1Clock, txSender, oracle)
+	ledger := accounting.NewLedger()
+	gameTxSender := accounting.NewTxSender(txSender, ledger)
+	direct := preimages.NewDirectPreimageUploader(logger, gameTxSender, loader)
+	large := preimages.NewLargePreimageUploader(logger, m, l1Clock, gameTxSender, oracle)
 	uploader := preimages.NewSplitPreimageUploader(direct, large, minLargePreimageSize)
-	responder, err := responder.NewFaultResponder(logger, txSender, loader, uploader, oracle)
+	responder, err := responder.NewFaultResponder(logger, gameTxSender, resolver, loader, uploader, oracle)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the responder: %w", err)
 	}
 
-	agent := NewAgent(m, systemClock, l1Clock, loader, gameDepth, maxClockDuration, accessor, responder, logger, selective, claimants)
+	notifications, _ := state.(NotificationStore)
+	gameNotifier := NewGameNotifier(logger, notifier, addr, notifications)
+	gameNotifier.NotifyOnce(string(notify.EventGameSeen), notify.Event{
+		Type:    notify.EventGameSeen,
+		Summary: "Playing new game",
+		Details: map[string]string{"l1Head": l1Head.String()},
+	})
+
+	agent := NewAgent(m, systemClock, l1Clock, loader, gameDepth, maxClockDuration, accessor, responder, logger, selective, claimants, resolveConcurrency, state, gameNotifier)
 	return &GamePlayer{
 		act:                agent.Act,
+		deadline:           agent.Deadline,
 		loader:             loader,
 		logger:             logger,
 		status:             status,
 		gameL1Head:         l1Head,
 		syncValidator:      syncValidator,
 		prestateValidators: validators,
+		metrics:            m,
+		ledger:             ledger,
+		claimLoader:        loader,
+		claimants:          claimants,
+		notifier:           gameNotifier,
 	}, nil
 }
 
@@ -173,6 +210,15 @@ func (g *GamePlayer) Status() gameTypes.GameStatus {
 	return g.status
 }
 
+// Deadline returns the time by which the game must next be progressed to counter claims before their clocks expire.
+// Returns the zero time if unknown or if there are no claims left to counter.
+func (g *GamePlayer) Deadline() time.Time {
+	if g.deadline == nil || g.status != gameTypes.GameStatusInProgress {
+		return time.Time{}
+	}
+	return g.deadline()
+}
+
 func (g *GamePlayer) ProgressGame(ctx context.Context) gameTypes.GameStatus {
 	if g.status != gameTypes.GameStatusInProgress {
 		// Game is already complete so don't try to perform further actions.
@@ -187,7 +233,7 @@ func (g *GamePlayer) ProgressGame(ctx context.Context) gameTypes.GameStatus {
 		return g.status
 	}
 	g.logger.Trace("Checking if actions are required")
-	if err := g.act(ctx); err != nil {
+	if err := g.act(vm.WithPriority(ctx, g.Deadline())); err != nil {
 		g.logger.Error("Error when acting on game", "err", err)
 	}
 	status, err := g.loader.GetStatus(ctx)
@@ -200,10 +246,47 @@ func (g *GamePlayer) ProgressGame(ctx context.Context) gameTypes.GameStatus {
 	if status != gameTypes.GameStatusInProgress {
 		// Release the agent as we will no longer need to act on this game.
 		g.act = actNoop
+		g.recordAccounting(ctx)
 	}
 	return status
 }
 
+// recordAccounting logs and records the metrics of the bonds and gas spent by the challenger in the resolved game,
+// and sends a notification if the game was lost.
+// Fees of claim resolution transactions, which may be batched across games, and bond claims are only included in
+// the total gas spent metric.
+func (g *GamePlayer) recordAccounting(ctx context.Context) {
+	if g.ledger == nil {
+		return
+	}
+	claims, err := g.claimLoader.GetAllClaims(ctx, rpcblock.Latest)
+	if err != nil {
+		g.logger.Error("Failed to load claims to record game accounting", "err", err)
+		return
+	}
+	summary := accounting.Summarize(claims, g.claimants, g.ledger.GasSpent())
+	g.metrics.RecordGameBonds(summary.BondsPosted, summary.BondsWon, summary.BondsLost)
+	g.logger.Info("Game accounting",
+		"gasSpent", summary.GasSpent,
+		"bondsPosted", summary.BondsPosted,
+		"bondsReturned", summary.BondsReturned,
+		"bondsWon", summary.BondsWon,
+		"bondsLost", summary.BondsLost,
+		"profit", summary.Profit())
+	if summary.BondsLost.Sign() > 0 {
+		g.notifier.NotifyOnce(string(notify.EventGameLost), notify.Event{
+			Type:    notify.EventGameLost,
+			Summary: fmt.Sprintf("Game resolved as %v and bonds of %v wei were lost", g.status, summary.BondsLost),
+			Details: map[string]string{
+				"status":      g.status.String(),
+				"bondsPosted": summary.BondsPosted.String(),
+				"bondsWon":    summary.BondsWon.String(),
+				"bondsLost":   summary.BondsLost.String(),
+			},
+		})
+	}
+}
+
 func (g *GamePlayer) logGameStatus(ctx context.Context, status gameTypes.GameStatus) {
 	if status == gameTypes.GameStatusInProgress {
 		claimCount, err := g.loader.GetClaimCount(ctx)
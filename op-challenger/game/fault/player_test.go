@@ -4,9 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"testing"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/accounting"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-challenger/notify"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
@@ -99,6 +104,57 @@ func TestDoNotActOnCompleteGame(t *testing.T) {
 	}
 }
 
+func TestRecordAccountingWhenGameResolves(t *testing.T) {
+	handler, game, gameState, _ := setupProgressGameTest(t)
+	challenger := common.Address{0xaa}
+	proposer := common.Address{0xbb}
+	m := &stubAccountingMetrics{}
+	game.metrics = m
+	game.ledger = accounting.NewLedger()
+	game.ledger.RecordGasSpent(big.NewInt(5))
+	game.claimants = []common.Address{challenger}
+	game.claimLoader = &stubClaimLoader{claims: []faultTypes.Claim{
+		{ClaimData: faultTypes.ClaimData{Bond: big.NewInt(100)}, Claimant: proposer, CounteredBy: challenger},
+		{ClaimData: faultTypes.ClaimData{Bond: big.NewInt(200)}, Claimant: challenger},
+	}}
+
+	game.ProgressGame(context.Background())
+	require.Nil(t, handler.FindLog(testlog.NewMessageFilter("Game accounting")), "should not record accounting for in progress game")
+
+	gameState.status = types.GameStatusChallengerWon
+	game.ProgressGame(context.Background())
+	msg := handler.FindLog(testlog.NewLevelFilter(log.LevelInfo), testlog.NewMessageFilter("Game accounting"))
+	require.NotNil(t, msg)
+	require.Equal(t, big.NewInt(5), msg.AttrValue("gasSpent"))
+	require.Equal(t, big.NewInt(200), msg.AttrValue("bondsPosted"))
+	require.Equal(t, big.NewInt(100), msg.AttrValue("bondsWon"))
+	require.Equal(t, big.NewInt(95), msg.AttrValue("profit"))
+	require.Equal(t, []*big.Int{big.NewInt(200), big.NewInt(100), big.NewInt(0)}, []*big.Int{m.posted, m.won, m.lost})
+}
+
+func TestNotifyWhenGameLost(t *testing.T) {
+	_, game, gameState, _ := setupProgressGameTest(t)
+	challenger := common.Address{0xaa}
+	defender := common.Address{0xbb}
+	notifier := &stubNotifier{}
+	game.notifier = NewGameNotifier(game.logger, notifier, common.Address{0xcc}, nil)
+	game.metrics = &stubAccountingMetrics{}
+	game.ledger = accounting.NewLedger()
+	game.claimants = []common.Address{challenger}
+	game.claimLoader = &stubClaimLoader{claims: []faultTypes.Claim{
+		{ClaimData: faultTypes.ClaimData{Bond: big.NewInt(100)}, Claimant: defender},
+		{ClaimData: faultTypes.ClaimData{Bond: big.NewInt(200)}, Claimant: challenger, CounteredBy: defender},
+	}}
+
+	gameState.status = types.GameStatusDefenderWon
+	game.ProgressGame(context.Background())
+	require.Len(t, notifier.events, 1)
+	require.Equal(t, notify.EventGameLost, notifier.events[0].Type)
+	require.Equal(t, common.Address{0xcc}, notifier.events[0].Game)
+	require.Equal(t, "200", notifier.events[0].Details["bondsLost"])
+	require.Equal(t, types.GameStatusDefenderWon.String(), notifier.events[0].Details["status"])
+}
+
 func TestValidateLocalNodeSync(t *testing.T) {
 	_, game, gameState, syncValidator := setupProgressGameTest(t)
 
@@ -215,3 +271,14 @@ func (s *stubGameState) GetClaimCount(ctx context.Context) (uint64, error) {
 func (s *stubGameState) GetAbsolutePrestateHash(ctx context.Context) (common.Hash, error) {
 	return common.Hash{}, s.Err
 }
+
+type stubAccountingMetrics struct {
+	metrics.NoopMetricsImpl
+	posted, won, lost *big.Int
+}
+
+func (s *stubAccountingMetrics) RecordGameBonds(posted, won, lost *big.Int) {
+	s.posted = posted
+	s.won = won
+	s.lost = lost
+}
@@ -73,9 +73,10 @@ func (s *mockPreimageGameContract) UpdateOracleTx(_ context.Context, _ uint64, _
 }
 
 type mockTxSender struct {
-	sends      int
-	sendFails  bool
-	statusFail bool
+	sends        int
+	sendFails    bool
+	sendFailures int
+	statusFail   bool
 }
 
 func (s *mockTxSender) From() common.Address {
@@ -87,6 +88,10 @@ func (s *mockTxSender) SendAndWaitSimple(_ string, _ ...txmgr.TxCandidate) error
 	if s.sendFails {
 		return mockTxMgrSendError
 	}
+	if s.sendFailures > 0 {
+		s.sendFailures--
+		return mockTxMgrSendError
+	}
 	if s.statusFail {
 		return errors.New("transaction reverted")
 	}
@@ -8,10 +8,12 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/matrix"
 	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
@@ -25,6 +27,9 @@ var _ PreimageUploader = (*LargePreimageUploader)(nil)
 // ErrChallengePeriodNotOver is returned when the challenge period is not over.
 var ErrChallengePeriodNotOver = errors.New("challenge period not over")
 
+// ErrLargePreimageCountered is returned when the large preimage proposal was countered and can't be squeezed.
+var ErrLargePreimageCountered = errors.New("large preimage proposal countered")
+
 // MaxBlocksPerChunk is the maximum number of keccak blocks per chunk.
 const MaxBlocksPerChunk = 300
 
@@ -33,19 +38,55 @@ const MaxBlocksPerChunk = 300
 // The max chunk size is roughly 0.04MB to avoid memory expansion.
 const MaxChunkSize = MaxBlocksPerChunk * keccakTypes.BlockSize
 
+// maxStageAttempts is the maximum number of attempts to send the transactions of each stage of a large preimage upload.
+const maxStageAttempts = 3
+
+// LargePreimageStage is a stage of uploading a large preimage.
+type LargePreimageStage string
+
+const (
+	StageInit            LargePreimageStage = "init"
+	StageAddLeaves       LargePreimageStage = "add_leaves"
+	StageChallengePeriod LargePreimageStage = "challenge_period"
+	StageSqueeze         LargePreimageStage = "squeeze"
+)
+
+// sendError is a failure to send the transactions of a stage, which may succeed when the stage is retried.
+type sendError struct {
+	error
+}
+
+func (e sendError) Unwrap() error {
+	return e.error
+}
+
 // LargePreimageUploader handles uploading large preimages by
 // streaming the merkleized preimage to the PreimageOracle contract,
 // tightly packed across multiple transactions.
+//
+// The upload resumes from the stage recorded in the proposal metadata, so each call to UploadPreimage progresses
+// the proposal until it is squeezed. [ErrChallengePeriodNotOver] is returned until the challenge period of the
+// proposal has elapsed and the step requiring the preimage can't yet be performed.
 type LargePreimageUploader struct {
-	log log.Logger
+	log     log.Logger
+	metrics Metricer
 
 	clock    types.ClockReader
 	txSender TxSender
 	contract PreimageOracleContract
+
+	retryStrategy retry.Strategy
 }
 
-func NewLargePreimageUploader(logger log.Logger, cl types.ClockReader, txSender TxSender, contract PreimageOracleContract) *LargePreimageUploader {
-	return &LargePreimageUploader{logger, cl, txSender, contract}
+func NewLargePreimageUploader(logger log.Logger, m Metricer, cl types.ClockReader, txSender TxSender, contract PreimageOracleContract) *LargePreimageUploader {
+	return &LargePreimageUploader{
+		log:           logger,
+		metrics:       m,
+		clock:         cl,
+		txSender:      txSender,
+		contract:      contract,
+		retryStrategy: retry.Exponential(),
+	}
 }
 
 func (p *LargePreimageUploader) UploadPreimage(ctx context.Context, parent uint64, data *types.PreimageOracleData) error {
@@ -58,36 +99,75 @@ func (p *LargePreimageUploader) UploadPreimage(ctx context.Context, parent uint6
 	uuid := NewUUID(p.txSender.From(), data)
 
 	// Fetch the current metadata for this preimage data, if it exists.
-	ident := keccakTypes.LargePreimageIdent{Claimant: p.txSender.From(), UUID: uuid}
-	metadata, err := p.contract.GetProposalMetadata(ctx, rpcblock.Latest, ident)
+	metadata, err := p.proposalMetadata(ctx, uuid)
 	if err != nil {
-		return fmt.Errorf("failed to get pre-image oracle metadata: %w", err)
+		return err
 	}
 
 	// The proposal is not initialized if the queried metadata has a claimed size of 0.
-	if len(metadata) == 1 && metadata[0].ClaimedSize == 0 {
-		err = p.initLargePreimage(uuid, data.OracleOffset, uint32(len(data.GetPreimageWithoutSize())))
+	if metadata.ClaimedSize == 0 {
+		err = p.runStage(ctx, StageInit, uuid, func(_ int) error {
+			return p.initLargePreimage(uuid, data.OracleOffset, uint32(len(data.GetPreimageWithoutSize())))
+		})
 		if err != nil {
 			return fmt.Errorf("failed to initialize large preimage with uuid: %s: %w", uuid, err)
 		}
 	}
 
-	// Filter out any chunks that have already been uploaded to the Preimage Oracle.
-	if len(metadata) > 0 {
-		numSkip := metadata[0].BytesProcessed / MaxChunkSize
-		calls = calls[numSkip:]
-		// If the timestamp is non-zero, the preimage has been finalized.
-		if metadata[0].Timestamp != 0 {
-			calls = calls[len(calls):]
+	// If the timestamp is non-zero, all leaves have been added and the preimage has been finalized.
+	if metadata.Timestamp == 0 {
+		err = p.runStage(ctx, StageAddLeaves, uuid, func(attempt int) error {
+			if attempt > 0 {
+				// Leaves may have been added by the failed attempt so skip any that are now already uploaded.
+				metadata, err = p.proposalMetadata(ctx, uuid)
+				if err != nil {
+					return err
+				}
+			}
+			return p.addLargePreimageData(uuid, metadata.BytesProcessed, calls)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add leaves to large preimage with uuid: %s: %w", uuid, err)
 		}
 	}
 
-	err = p.addLargePreimageData(uuid, calls)
+	return p.Squeeze(ctx, uuid, stateMatrix)
+}
+
+// proposalMetadata fetches the metadata of the large preimage proposal with the given uuid from this challenger.
+func (p *LargePreimageUploader) proposalMetadata(ctx context.Context, uuid *big.Int) (keccakTypes.LargePreimageMetaData, error) {
+	ident := keccakTypes.LargePreimageIdent{Claimant: p.txSender.From(), UUID: uuid}
+	metadata, err := p.contract.GetProposalMetadata(ctx, rpcblock.Latest, ident)
 	if err != nil {
-		return fmt.Errorf("failed to add leaves to large preimage with uuid: %s: %w", uuid, err)
+		return keccakTypes.LargePreimageMetaData{}, fmt.Errorf("failed to get pre-image oracle metadata: %w", err)
+	}
+	if len(metadata) != 1 {
+		return keccakTypes.LargePreimageMetaData{}, fmt.Errorf("expected 1 pre-image oracle metadata for uuid %s but got %d", uuid, len(metadata))
 	}
+	return metadata[0], nil
+}
 
-	return p.Squeeze(ctx, uuid, stateMatrix)
+// runStage runs a stage of the large preimage upload, retrying it when its transactions fail to send.
+// The attempt number, starting from 0, is passed to the stage so it can reload any state the failure may have changed.
+func (p *LargePreimageUploader) runStage(ctx context.Context, stage LargePreimageStage, uuid *big.Int, fn func(attempt int) error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn(attempt)
+		if err == nil {
+			p.metrics.RecordLargePreimageStage(string(stage))
+			return nil
+		}
+		p.metrics.RecordLargePreimageStageFailure(string(stage))
+		var sendErr sendError
+		if !errors.As(err, &sendErr) || attempt+1 >= maxStageAttempts {
+			return err
+		}
+		p.log.Warn("Retrying large preimage stage", "stage", stage, "uuid", uuid, "attempt", attempt+1, "err", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(p.retryStrategy.Duration(attempt)):
+		}
+	}
 }
 
 // NewUUID generates a new unique identifier for the preimage by hashing the
@@ -130,30 +210,37 @@ func (p *LargePreimageUploader) Squeeze(ctx context.Context, uuid *big.Int, stat
 		return fmt.Errorf("failed to get challenge period: %w", err)
 	}
 	currentTimestamp := p.clock.Now().Unix()
-	ident := keccakTypes.LargePreimageIdent{Claimant: p.txSender.From(), UUID: uuid}
-	metadata, err := p.contract.GetProposalMetadata(ctx, rpcblock.Latest, ident)
+	metadata, err := p.proposalMetadata(ctx, uuid)
 	if err != nil {
-		return fmt.Errorf("failed to get pre-image oracle metadata: %w", err)
+		return err
 	}
-	if len(metadata) == 0 || metadata[0].ClaimedSize == 0 {
+	if metadata.ClaimedSize == 0 {
 		return fmt.Errorf("no metadata found for pre-image oracle with uuid: %s", uuid)
 	}
-	if uint64(currentTimestamp) < metadata[0].Timestamp+challengePeriod {
-		return ErrChallengePeriodNotOver
-	}
-	if err := p.contract.CallSqueeze(ctx, p.txSender.From(), uuid, prestateMatrix, prestate, prestateProof, poststate, poststateProof); err != nil {
-		p.log.Warn("Expected a successful squeeze call", "metadataTimestamp", metadata[0].Timestamp, "currentTimestamp", currentTimestamp, "err", err)
-		return fmt.Errorf("failed to call squeeze: %w", err)
-	}
-	p.log.Info("Squeezing large preimage", "uuid", uuid)
-	tx, err := p.contract.Squeeze(p.txSender.From(), uuid, prestateMatrix, prestate, prestateProof, poststate, poststateProof)
-	if err != nil {
-		return fmt.Errorf("failed to create pre-image oracle tx: %w", err)
+	if metadata.Countered {
+		return fmt.Errorf("%w: uuid %s", ErrLargePreimageCountered, uuid)
 	}
-	if err := p.txSender.SendAndWaitSimple("squeeze large preimage", tx); err != nil {
-		return fmt.Errorf("failed to populate pre-image oracle: %w", err)
+	if uint64(currentTimestamp) < metadata.Timestamp+challengePeriod {
+		remaining := time.Duration(metadata.Timestamp+challengePeriod-uint64(currentTimestamp)) * time.Second
+		p.log.Info("Waiting for large preimage challenge period", "uuid", uuid, "remaining", remaining)
+		return ErrChallengePeriodNotOver
 	}
-	return nil
+	p.metrics.RecordLargePreimageStage(string(StageChallengePeriod))
+	return p.runStage(ctx, StageSqueeze, uuid, func(_ int) error {
+		if err := p.contract.CallSqueeze(ctx, p.txSender.From(), uuid, prestateMatrix, prestate, prestateProof, poststate, poststateProof); err != nil {
+			p.log.Warn("Expected a successful squeeze call", "metadataTimestamp", metadata.Timestamp, "currentTimestamp", currentTimestamp, "err", err)
+			return fmt.Errorf("failed to call squeeze: %w", err)
+		}
+		p.log.Info("Squeezing large preimage", "uuid", uuid)
+		tx, err := p.contract.Squeeze(p.txSender.From(), uuid, prestateMatrix, prestate, prestateProof, poststate, poststateProof)
+		if err != nil {
+			return fmt.Errorf("failed to create pre-image oracle tx: %w", err)
+		}
+		if err := p.txSender.SendAndWaitSimple("squeeze large preimage", tx); err != nil {
+			return sendError{fmt.Errorf("failed to populate pre-image oracle: %w", err)}
+		}
+		return nil
+	})
 }
 
 // initLargePreimage initializes the large preimage proposal.
@@ -165,17 +252,23 @@ func (p *LargePreimageUploader) initLargePreimage(uuid *big.Int, partOffset uint
 		return fmt.Errorf("failed to create pre-image oracle tx: %w", err)
 	}
 	if err := p.txSender.SendAndWaitSimple("init large preimage", candidate); err != nil {
-		return fmt.Errorf("failed to populate pre-image oracle: %w", err)
+		return sendError{fmt.Errorf("failed to populate pre-image oracle: %w", err)}
 	}
 	return nil
 }
 
-// addLargePreimageData adds data to the large preimage proposal.
+// addLargePreimageData adds the chunks not yet processed by the large preimage proposal.
 // This method **must** be called after calling [initLargePreimage].
 // SAFETY: submits transactions in a [Queue] for latency while preserving submission order.
-func (p *LargePreimageUploader) addLargePreimageData(uuid *big.Int, chunks []keccakTypes.InputData) error {
+func (p *LargePreimageUploader) addLargePreimageData(uuid *big.Int, bytesProcessed uint32, chunks []keccakTypes.InputData) error {
+	// Filter out any chunks that have already been uploaded to the Preimage Oracle.
+	numSkip := min(int(bytesProcessed/MaxChunkSize), len(chunks))
+	chunks = chunks[numSkip:]
+	if len(chunks) == 0 {
+		return nil
+	}
 	txs := make([]txmgr.TxCandidate, len(chunks))
-	blocksProcessed := int64(0)
+	blocksProcessed := int64(numSkip * MaxBlocksPerChunk)
 	for i, chunk := range chunks {
 		tx, err := p.contract.AddLeaves(uuid, big.NewInt(blocksProcessed), chunk.Input, chunk.Commitments, chunk.Finalize)
 		if err != nil {
@@ -185,5 +278,8 @@ func (p *LargePreimageUploader) addLargePreimageData(uuid *big.Int, chunks []kec
 		txs[i] = tx
 	}
 	p.log.Info("Adding large preimage leaves", "uuid", uuid, "blocksProcessed", blocksProcessed, "txs", len(txs))
-	return p.txSender.SendAndWaitSimple("add leaf to large preimage", txs...)
+	if err := p.txSender.SendAndWaitSimple("add leaf to large preimage", txs...); err != nil {
+		return sendError{err}
+	}
+	return nil
 }
@@ -16,6 +16,7 @@ import (
 	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
@@ -150,6 +151,61 @@ func TestLargePreimageUploader_UploadPreimage_EdgeCases(t *testing.T) {
 		require.Equal(t, 1, contract.squeezeCalls)
 	})
 
+	t.Run("ResumeAddingLeaves", func(t *testing.T) {
+		oracle, _, _, contract := newTestLargePreimageUploader(t)
+		data := mockPreimageOracleData()
+		contract.initialized = true
+		contract.bytesProcessed = 2 * MaxChunkSize
+		contract.claimedSize = uint32(len(data.GetPreimageWithoutSize()))
+		err := oracle.UploadPreimage(context.Background(), 0, data)
+		require.NoError(t, err)
+		require.Equal(t, 0, contract.initCalls)
+		require.Equal(t, 4, contract.addCalls)
+		require.Equal(t, data.GetPreimageWithoutSize()[2*MaxChunkSize:], contract.addData)
+		require.Equal(t, []uint64{2 * MaxBlocksPerChunk, 3 * MaxBlocksPerChunk, 4 * MaxBlocksPerChunk, 5 * MaxBlocksPerChunk}, contract.addStartingBlocks)
+	})
+
+	t.Run("RetryFailedSends", func(t *testing.T) {
+		oracle, _, txSender, contract := newTestLargePreimageUploader(t)
+		data := mockPreimageOracleData()
+		contract.claimedSize = uint32(len(data.GetPreimageWithoutSize()))
+		txSender.sendFailures = 2
+		err := oracle.UploadPreimage(context.Background(), 0, data)
+		require.NoError(t, err)
+		require.Equal(t, 3, contract.initCalls)
+		require.Equal(t, 6, contract.addCalls)
+		require.Equal(t, 1, contract.squeezeCalls)
+		m := oracle.metrics.(*stubLargePreimageMetrics)
+		require.Equal(t, 2, m.failures[StageInit])
+		for _, stage := range []LargePreimageStage{StageInit, StageAddLeaves, StageChallengePeriod, StageSqueeze} {
+			require.Equal(t, 1, m.completed[stage], "stage %v", stage)
+		}
+	})
+
+	t.Run("SendFailsPermanently", func(t *testing.T) {
+		oracle, _, txSender, contract := newTestLargePreimageUploader(t)
+		data := mockPreimageOracleData()
+		txSender.sendFails = true
+		err := oracle.UploadPreimage(context.Background(), 0, data)
+		require.ErrorIs(t, err, mockTxMgrSendError)
+		require.Equal(t, maxStageAttempts, txSender.sends)
+		require.Equal(t, maxStageAttempts, contract.initCalls)
+		require.Equal(t, 0, contract.addCalls)
+		require.Equal(t, maxStageAttempts, oracle.metrics.(*stubLargePreimageMetrics).failures[StageInit])
+	})
+
+	t.Run("Countered", func(t *testing.T) {
+		oracle, _, _, contract := newTestLargePreimageUploader(t)
+		data := mockPreimageOracleData()
+		contract.bytesProcessed = 5*MaxChunkSize + 1
+		contract.timestamp = 123
+		contract.claimedSize = uint32(len(data.GetPreimageWithoutSize()))
+		contract.countered = true
+		err := oracle.UploadPreimage(context.Background(), 0, data)
+		require.ErrorIs(t, err, ErrLargePreimageCountered)
+		require.Equal(t, 0, contract.squeezeCalls)
+	})
+
 	t.Run("AllBytesProcessed", func(t *testing.T) {
 		oracle, _, _, contract := newTestLargePreimageUploader(t)
 		data := mockPreimageOracleData()
@@ -258,7 +314,29 @@ func newTestLargePreimageUploader(t *testing.T) (*LargePreimageUploader, *clock.
 	contract := &mockPreimageOracleContract{
 		addData: make([]byte, 0),
 	}
-	return NewLargePreimageUploader(logger, cl, txSender, contract), cl, txSender, contract
+	uploader := NewLargePreimageUploader(logger, newStubLargePreimageMetrics(), cl, txSender, contract)
+	uploader.retryStrategy = retry.Fixed(0)
+	return uploader, cl, txSender, contract
+}
+
+type stubLargePreimageMetrics struct {
+	completed map[LargePreimageStage]int
+	failures  map[LargePreimageStage]int
+}
+
+func newStubLargePreimageMetrics() *stubLargePreimageMetrics {
+	return &stubLargePreimageMetrics{
+		completed: make(map[LargePreimageStage]int),
+		failures:  make(map[LargePreimageStage]int),
+	}
+}
+
+func (s *stubLargePreimageMetrics) RecordLargePreimageStage(stage string) {
+	s.completed[LargePreimageStage(stage)]++
+}
+
+func (s *stubLargePreimageMetrics) RecordLargePreimageStageFailure(stage string) {
+	s.failures[LargePreimageStage(stage)]++
 }
 
 type mockPreimageOracleContract struct {
@@ -271,6 +349,8 @@ type mockPreimageOracleContract struct {
 	addCalls             int
 	addFails             bool
 	addData              []byte
+	addStartingBlocks    []uint64
+	countered            bool
 	squeezeCalls         int
 	squeezeFails         bool
 	squeezeCallFails     bool
@@ -287,8 +367,9 @@ func (s *mockPreimageOracleContract) InitLargePreimage(_ *big.Int, _ uint32, _ u
 	return txmgr.TxCandidate{}, nil
 }
 
-func (s *mockPreimageOracleContract) AddLeaves(_ *big.Int, _ *big.Int, input []byte, _ []common.Hash, _ bool) (txmgr.TxCandidate, error) {
+func (s *mockPreimageOracleContract) AddLeaves(_ *big.Int, startingBlockIndex *big.Int, input []byte, _ []common.Hash, _ bool) (txmgr.TxCandidate, error) {
 	s.addCalls++
+	s.addStartingBlocks = append(s.addStartingBlocks, startingBlockIndex.Uint64())
 	s.addData = append(s.addData, input...)
 	if s.addFails {
 		return txmgr.TxCandidate{}, mockAddLeavesError
@@ -319,6 +400,7 @@ func (s *mockPreimageOracleContract) GetProposalMetadata(_ context.Context, _ rp
 				ClaimedSize:        s.squeezeCallClaimSize,
 				BytesProcessed:     uint32(s.bytesProcessed),
 				Timestamp:          s.timestamp,
+				Countered:          s.countered,
 			})
 		}
 		return metadata, nil
@@ -331,6 +413,7 @@ func (s *mockPreimageOracleContract) GetProposalMetadata(_ context.Context, _ rp
 				ClaimedSize:        s.claimedSize,
 				BytesProcessed:     uint32(s.bytesProcessed),
 				Timestamp:          s.timestamp,
+				Countered:          s.countered,
 			})
 		}
 		return metadata, nil
@@ -21,6 +21,12 @@ type PreimageUploader interface {
 	UploadPreimage(ctx context.Context, claimIdx uint64, data *types.PreimageOracleData) error
 }
 
+// Metricer records the progress of large preimage uploads through each [LargePreimageStage].
+type Metricer interface {
+	RecordLargePreimageStage(stage string)
+	RecordLargePreimageStageFailure(stage string)
+}
+
 type TxSender interface {
 	From() common.Address
 	SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error
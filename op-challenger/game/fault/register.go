@@ -12,6 +12,7 @@ import (
 	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/statedb"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
@@ -54,17 +55,21 @@ func RegisterGameTypes(
 	oracles OracleRegistry,
 	rollupClient RollupClient,
 	txSender TxSender,
+	resolver ClaimResolver,
 	gameFactory *contracts.DisputeGameFactoryContract,
 	caller *batching.MultiCaller,
 	l1HeaderSource L1HeaderSource,
 	selective bool,
 	claimants []common.Address,
+	stateDB *statedb.DB,
+	notifier Notifier,
 ) (CloseFunc, error) {
 	l2Client, err := ethclient.DialContext(ctx, cfg.L2Rpc)
 	if err != nil {
 		return nil, fmt.Errorf("dial l2 client %v: %w", cfg.L2Rpc, err)
 	}
 	syncValidator := newSyncStatusValidator(rollupClient)
+	outputCache := outputs.NewOutputCache(m, rollupClient)
 
 	var registerTasks []*RegisterTask
 	if cfg.TraceTypeEnabled(faultTypes.TraceTypeCannon) {
@@ -85,8 +90,13 @@ func RegisterGameTypes(
 	if cfg.TraceTypeEnabled(faultTypes.TraceTypeAlphabet) {
 		registerTasks = append(registerTasks, NewAlphabetRegisterTask(faultTypes.AlphabetGameType))
 	}
+	for _, provider := range vm.TraceProviders() {
+		if cfg.TraceTypeEnabled(provider.TraceType()) {
+			registerTasks = append(registerTasks, NewVMRegisterTask(provider, cfg, m))
+		}
+	}
 	for _, task := range registerTasks {
-		if err := task.Register(ctx, registry, oracles, systemClock, l1Clock, logger, m, syncValidator, rollupClient, txSender, gameFactory, caller, l2Client, l1HeaderSource, selective, claimants); err != nil {
+		if err := task.Register(ctx, registry, oracles, systemClock, l1Clock, logger, m, syncValidator, outputCache, txSender, resolver, int(cfg.ResolutionConcurrency), gameFactory, caller, l2Client, l1HeaderSource, selective, claimants, stateDB, notifier); err != nil {
 			return nil, fmt.Errorf("failed to register %v game type: %w", task.gameType, err)
 		}
 	}
@@ -19,6 +19,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
 	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/statedb"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
@@ -41,6 +42,8 @@ type RegisterTask struct {
 		vmPrestateProvider faultTypes.PrestateProvider,
 		rollupClient outputs.OutputRollupClient,
 		dir string,
+		store trace.TraceStore,
+		traceCache trace.TraceCache,
 		l1Head eth.BlockID,
 		splitDepth faultTypes.Depth,
 		prestateBlock uint64,
@@ -69,12 +72,14 @@ func NewCannonRegisterTask(gameType faultTypes.GameType, cfg *config.Config, m c
 			vmPrestateProvider faultTypes.PrestateProvider,
 			rollupClient outputs.OutputRollupClient,
 			dir string,
+			store trace.TraceStore,
+			traceCache trace.TraceCache,
 			l1Head eth.BlockID,
 			splitDepth faultTypes.Depth,
 			prestateBlock uint64,
 			poststateBlock uint64) (*trace.Accessor, error) {
 			provider := vmPrestateProvider.(*vm.PrestateProvider)
-			return outputs.NewOutputCannonTraceAccessor(logger, m, cfg.Cannon, serverExecutor, l2Client, prestateProvider, provider.PrestatePath(), rollupClient, dir, l1Head, splitDepth, prestateBlock, poststateBlock)
+			return outputs.NewOutputCannonTraceAccessor(logger, m, cfg.Cannon, serverExecutor, l2Client, prestateProvider, provider.PrestatePath(), rollupClient, dir, store, traceCache, l1Head, splitDepth, prestateBlock, poststateBlock)
 		},
 	}
 }
@@ -101,12 +106,14 @@ func NewAsteriscRegisterTask(gameType faultTypes.GameType, cfg *config.Config, m
 			vmPrestateProvider faultTypes.PrestateProvider,
 			rollupClient outputs.OutputRollupClient,
 			dir string,
+			store trace.TraceStore,
+			traceCache trace.TraceCache,
 			l1Head eth.BlockID,
 			splitDepth faultTypes.Depth,
 			prestateBlock uint64,
 			poststateBlock uint64) (*trace.Accessor, error) {
 			provider := vmPrestateProvider.(*vm.PrestateProvider)
-			return outputs.NewOutputAsteriscTraceAccessor(logger, m, cfg.Asterisc, serverExecutor, l2Client, prestateProvider, provider.PrestatePath(), rollupClient, dir, l1Head, splitDepth, prestateBlock, poststateBlock)
+			return outputs.NewOutputAsteriscTraceAccessor(logger, m, cfg.Asterisc, serverExecutor, l2Client, prestateProvider, provider.PrestatePath(), rollupClient, dir, store, traceCache, l1Head, splitDepth, prestateBlock, poststateBlock)
 		},
 	}
 }
@@ -133,12 +140,52 @@ func NewAsteriscKonaRegisterTask(gameType faultTypes.GameType, cfg *config.Confi
 			vmPrestateProvider faultTypes.PrestateProvider,
 			rollupClient outputs.OutputRollupClient,
 			dir string,
+			store trace.TraceStore,
+			traceCache trace.TraceCache,
 			l1Head eth.BlockID,
 			splitDepth faultTypes.Depth,
 			prestateBlock uint64,
 			poststateBlock uint64) (*trace.Accessor, error) {
 			provider := vmPrestateProvider.(*vm.PrestateProvider)
-			return outputs.NewOutputAsteriscTraceAccessor(logger, m, cfg.AsteriscKona, serverExecutor, l2Client, prestateProvider, provider.PrestatePath(), rollupClient, dir, l1Head, splitDepth, prestateBlock, poststateBlock)
+			return outputs.NewOutputAsteriscTraceAccessor(logger, m, cfg.AsteriscKona, serverExecutor, l2Client, prestateProvider, provider.PrestatePath(), rollupClient, dir, store, traceCache, l1Head, splitDepth, prestateBlock, poststateBlock)
+		},
+	}
+}
+
+// NewVMRegisterTask creates the task to register the game type of a VM added as a [vm.TraceProvider].
+func NewVMRegisterTask(provider vm.TraceProvider, cfg *config.Config, m caching.Metrics) *RegisterTask {
+	gameType := provider.GameType()
+	vmCfg := cfg.VMs[provider.TraceType()]
+	stateConverter := provider.StateConverter()
+	serverExecutor := provider.OracleServerExecutor()
+	return &RegisterTask{
+		gameType: gameType,
+		getPrestateProvider: cachePrestates(
+			gameType,
+			stateConverter,
+			m,
+			vmCfg.AbsolutePreStateBaseURL,
+			vmCfg.AbsolutePreState,
+			filepath.Join(cfg.Datadir, fmt.Sprintf("%v-prestates", provider.TraceType())),
+			func(path string) faultTypes.PrestateProvider {
+				return vm.NewPrestateProvider(path, stateConverter)
+			}),
+		newTraceAccessor: func(
+			logger log.Logger,
+			m metrics.Metricer,
+			l2Client utils.L2HeaderSource,
+			prestateProvider faultTypes.PrestateProvider,
+			vmPrestateProvider faultTypes.PrestateProvider,
+			rollupClient outputs.OutputRollupClient,
+			dir string,
+			store trace.TraceStore,
+			traceCache trace.TraceCache,
+			l1Head eth.BlockID,
+			splitDepth faultTypes.Depth,
+			prestateBlock uint64,
+			poststateBlock uint64) (*trace.Accessor, error) {
+			vmPrestate := vmPrestateProvider.(*vm.PrestateProvider)
+			return outputs.NewOutputVMTraceAccessor(logger, m, vmCfg.VM, provider, serverExecutor, l2Client, prestateProvider, vmPrestate.PrestatePath(), rollupClient, dir, store, traceCache, l1Head, splitDepth, prestateBlock, poststateBlock)
 		},
 	}
 }
@@ -157,6 +204,8 @@ func NewAlphabetRegisterTask(gameType faultTypes.GameType) *RegisterTask {
 			vmPrestateProvider faultTypes.PrestateProvider,
 			rollupClient outputs.OutputRollupClient,
 			dir string,
+			store trace.TraceStore,
+			traceCache trace.TraceCache,
 			l1Head eth.BlockID,
 			splitDepth faultTypes.Depth,
 			prestateBlock uint64,
@@ -197,12 +246,16 @@ func (e *RegisterTask) Register(
 	syncValidator SyncValidator,
 	rollupClient outputs.OutputRollupClient,
 	txSender TxSender,
+	resolver ClaimResolver,
+	resolveConcurrency int,
 	gameFactory *contracts.DisputeGameFactoryContract,
 	caller *batching.MultiCaller,
 	l2Client utils.L2HeaderSource,
 	l1HeaderSource L1HeaderSource,
 	selective bool,
-	claimants []common.Address) error {
+	claimants []common.Address,
+	stateDB *statedb.DB,
+	notifier Notifier) error {
 
 	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
 		contract, err := contracts.NewFaultDisputeGameContract(ctx, m, game.Proxy, caller)
@@ -237,8 +290,10 @@ func (e *RegisterTask) Register(
 			return nil, err
 		}
 		prestateProvider := outputs.NewPrestateProvider(rollupClient, prestateBlock)
+		gameState := stateDB.ForGame(game.Proxy)
+		traceCache := gameState.TraceCache(requiredPrestatehash)
 		creator := func(ctx context.Context, logger log.Logger, gameDepth faultTypes.Depth, dir string) (faultTypes.TraceAccessor, error) {
-			accessor, err := e.newTraceAccessor(logger, m, l2Client, prestateProvider, vmPrestateProvider, rollupClient, dir, l1HeadID, splitDepth, prestateBlock, poststateBlock)
+			accessor, err := e.newTraceAccessor(logger, m, l2Client, prestateProvider, vmPrestateProvider, rollupClient, dir, gameState, traceCache, l1HeadID, splitDepth, prestateBlock, poststateBlock)
 			if err != nil {
 				return nil, err
 			}
@@ -246,7 +301,7 @@ func (e *RegisterTask) Register(
 		}
 		prestateValidator := NewPrestateValidator(e.gameType.String(), contract.GetAbsolutePrestateHash, vmPrestateProvider)
 		startingValidator := NewPrestateValidator("output root", contract.GetStartingRootHash, prestateProvider)
-		return NewGamePlayer(ctx, systemClock, l1Clock, logger, m, dir, game.Proxy, txSender, contract, syncValidator, []Validator{prestateValidator, startingValidator}, creator, l1HeaderSource, selective, claimants)
+		return NewGamePlayer(ctx, systemClock, l1Clock, logger, m, dir, game.Proxy, txSender, resolver, resolveConcurrency, contract, syncValidator, []Validator{prestateValidator, startingValidator}, creator, l1HeaderSource, selective, claimants, gameState, notifier)
 	}
 	err := registerOracle(ctx, m, oracles, gameFactory, caller, e.gameType)
 	if err != nil {
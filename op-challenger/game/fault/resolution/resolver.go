@@ -0,0 +1,164 @@
+package resolution
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type TxSender interface {
+	SendAndWaitDetailed(txPurpose string, txs ...txmgr.TxCandidate) []error
+}
+
+// Resolver sends the transactions to resolve claims for all games.
+//
+// When batching is enabled, the resolveClaim calls requested by games while the previous transactions are in flight
+// are batched into multicall transactions, across games. The batches are all sent concurrently, sharing the nonce
+// management of the tx sender. If a batch fails, its calls are retried in separate transactions so that a single
+// failing call doesn't prevent the other claims from being resolved.
+type Resolver struct {
+	logger    log.Logger
+	sender    TxSender
+	multicall *contracts.Multicall3Contract
+	batchSize int
+
+	mu      sync.Mutex
+	queue   []*request
+	sending bool
+}
+
+type request struct {
+	txs  []txmgr.TxCandidate
+	errs []error
+	done chan struct{}
+}
+
+// resolveCall is a resolveClaim call of a request.
+type resolveCall struct {
+	req *request
+	idx int
+}
+
+// NewResolver creates a resolver that batches up to batchSize calls into one transaction via the multicall contract.
+// Batching is disabled if batchSize is 0 or 1.
+func NewResolver(logger log.Logger, sender TxSender, multicall common.Address, batchSize uint) *Resolver {
+	r := &Resolver{
+		logger:    logger,
+		sender:    sender,
+		batchSize: int(max(batchSize, 1)),
+	}
+	if batchSize > 1 {
+		r.multicall = contracts.NewMulticall3Contract(multicall)
+	}
+	return r
+}
+
+// ResolveClaims sends the resolveClaim transactions and waits for them to be included.
+func (r *Resolver) ResolveClaims(txs ...txmgr.TxCandidate) error {
+	if len(txs) == 0 {
+		return nil
+	}
+	if r.multicall == nil {
+		return errors.Join(r.sender.SendAndWaitDetailed("resolve claim", txs...)...)
+	}
+	req := &request{txs: txs, errs: make([]error, len(txs)), done: make(chan struct{})}
+	r.mu.Lock()
+	r.queue = append(r.queue, req)
+	if !r.sending {
+		r.sending = true
+		go r.sendQueued()
+	}
+	r.mu.Unlock()
+	<-req.done
+	return errors.Join(req.errs...)
+}
+
+// sendQueued sends the queued requests until the queue is empty.
+func (r *Resolver) sendQueued() {
+	for {
+		r.mu.Lock()
+		reqs := r.queue
+		r.queue = nil
+		if len(reqs) == 0 {
+			r.sending = false
+			r.mu.Unlock()
+			return
+		}
+		r.mu.Unlock()
+		r.send(reqs)
+		for _, req := range reqs {
+			close(req.done)
+		}
+	}
+}
+
+func (r *Resolver) send(reqs []*request) {
+	var calls []resolveCall
+	for _, req := range reqs {
+		for i := range req.txs {
+			calls = append(calls, resolveCall{req: req, idx: i})
+		}
+	}
+	var batches [][]resolveCall
+	var txs []txmgr.TxCandidate
+	for start := 0; start < len(calls); start += r.batchSize {
+		batch := calls[start:min(start+r.batchSize, len(calls))]
+		tx, err := r.batchTx(batch)
+		if err != nil {
+			setErr(batch, err)
+			continue
+		}
+		batches = append(batches, batch)
+		txs = append(txs, tx)
+	}
+	if len(txs) == 0 {
+		return
+	}
+	r.logger.Debug("Resolving claims", "requests", len(reqs), "claims", len(calls), "txs", len(txs))
+
+	var retries []resolveCall
+	for i, err := range r.sender.SendAndWaitDetailed("resolve claims", txs...) {
+		if err != nil && len(batches[i]) > 1 {
+			r.logger.Warn("Failed to resolve batch of claims, resolving separately", "claims", len(batches[i]), "err", err)
+			retries = append(retries, batches[i]...)
+			continue
+		}
+		setErr(batches[i], err)
+	}
+	if len(retries) == 0 {
+		return
+	}
+	txs = make([]txmgr.TxCandidate, 0, len(retries))
+	for _, call := range retries {
+		txs = append(txs, call.req.txs[call.idx])
+	}
+	for i, err := range r.sender.SendAndWaitDetailed("resolve claim", txs...) {
+		setErr(retries[i:i+1], err)
+	}
+}
+
+func (r *Resolver) batchTx(batch []resolveCall) (txmgr.TxCandidate, error) {
+	if len(batch) == 1 {
+		return batch[0].req.txs[batch[0].idx], nil
+	}
+	txs := make([]txmgr.TxCandidate, 0, len(batch))
+	for _, call := range batch {
+		txs = append(txs, call.req.txs[call.idx])
+	}
+	tx, err := r.multicall.Aggregate3Tx(txs...)
+	if err != nil {
+		return txmgr.TxCandidate{}, fmt.Errorf("failed to create batched resolve claim tx: %w", err)
+	}
+	return tx, nil
+}
+
+func setErr(calls []resolveCall, err error) {
+	for _, call := range calls {
+		call.req.errs[call.idx] = err
+	}
+}
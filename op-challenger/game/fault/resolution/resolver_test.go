@@ -0,0 +1,134 @@
+package resolution
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	multicallAddr = common.Address{0xca, 0x11}
+	errBatch      = errors.New("batch failed")
+)
+
+func TestResolveClaimsWithoutBatching(t *testing.T) {
+	sender := newStubTxSender()
+	close(sender.release)
+	resolver := NewResolver(testlog.Logger(t, log.LevelInfo), sender, multicallAddr, 1)
+
+	require.NoError(t, resolver.ResolveClaims(resolveTx(1), resolveTx(2)))
+	require.Equal(t, [][]txmgr.TxCandidate{{resolveTx(1), resolveTx(2)}}, sender.sentTxs())
+}
+
+func TestBatchClaimsAcrossGames(t *testing.T) {
+	sender := newStubTxSender()
+	resolver := NewResolver(testlog.Logger(t, log.LevelInfo), sender, multicallAddr, 3)
+
+	var wg sync.WaitGroup
+	resolve := func(txs ...txmgr.TxCandidate) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, resolver.ResolveClaims(txs...))
+		}()
+	}
+	resolve(resolveTx(1))
+	// Wait for the first claim to be sent, so the next claims are queued while it's in flight
+	<-sender.started
+	resolve(resolveTx(2), resolveTx(3))
+	waitForQueued(t, resolver, 1)
+	resolve(resolveTx(4), resolveTx(5))
+	waitForQueued(t, resolver, 2)
+	close(sender.release)
+	wg.Wait()
+
+	sent := sender.sentTxs()
+	require.Len(t, sent, 2)
+	require.Equal(t, []txmgr.TxCandidate{resolveTx(1)}, sent[0], "single claim should not be batched")
+	require.Len(t, sent[1], 2, "queued claims should be sent concurrently in batches")
+	require.Equal(t, multicallAddr, *sent[1][0].To)
+	require.Equal(t, resolveTx(5), sent[1][1], "remaining single claim should not be batched")
+}
+
+func TestResolveSeparatelyWhenBatchFails(t *testing.T) {
+	sender := newStubTxSender()
+	close(sender.release)
+	failing := resolveTx(2)
+	sender.failing = []common.Address{multicallAddr, *failing.To}
+	resolver := NewResolver(testlog.Logger(t, log.LevelInfo), sender, multicallAddr, 2)
+
+	err := resolver.ResolveClaims(resolveTx(1), failing, resolveTx(3))
+	require.ErrorIs(t, err, errBatch)
+
+	sent := sender.sentTxs()
+	require.Len(t, sent, 2)
+	require.Equal(t, multicallAddr, *sent[0][0].To)
+	require.Equal(t, resolveTx(3), sent[0][1])
+	require.Equal(t, []txmgr.TxCandidate{resolveTx(1), failing}, sent[1], "calls of the failed batch should be sent separately")
+}
+
+func TestRejectInvalidResolveTxs(t *testing.T) {
+	sender := newStubTxSender()
+	close(sender.release)
+	resolver := NewResolver(testlog.Logger(t, log.LevelInfo), sender, multicallAddr, 2)
+
+	err := resolver.ResolveClaims(txmgr.TxCandidate{}, resolveTx(2))
+	require.ErrorContains(t, err, "failed to create batched resolve claim tx")
+	require.Empty(t, sender.sentTxs())
+}
+
+func resolveTx(game byte) txmgr.TxCandidate {
+	to := common.Address{game}
+	return txmgr.TxCandidate{To: &to, TxData: []byte{0x01, game}}
+}
+
+func waitForQueued(t *testing.T, r *Resolver, n int) {
+	require.Eventually(t, func() bool {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return len(r.queue) == n
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+// stubTxSender records the sent transactions, and blocks sending until it's released.
+// Transactions to the failing addresses fail.
+type stubTxSender struct {
+	mu      sync.Mutex
+	sent    [][]txmgr.TxCandidate
+	started chan struct{}
+	release chan struct{}
+	failing []common.Address
+}
+
+func newStubTxSender() *stubTxSender {
+	return &stubTxSender{started: make(chan struct{}, 100), release: make(chan struct{})}
+}
+
+func (s *stubTxSender) SendAndWaitDetailed(_ string, txs ...txmgr.TxCandidate) []error {
+	s.started <- struct{}{}
+	<-s.release
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, txs)
+	errs := make([]error, len(txs))
+	for i, tx := range txs {
+		if slices.Contains(s.failing, *tx.To) {
+			errs[i] = errBatch
+		}
+	}
+	return errs
+}
+
+func (s *stubTxSender) sentTxs() [][]txmgr.TxCandidate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sent
+}
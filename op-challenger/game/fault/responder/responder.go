@@ -2,7 +2,6 @@ package responder
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/preimages"
@@ -32,20 +31,27 @@ type TxSender interface {
 	SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error
 }
 
+// ClaimResolver sends resolveClaim transactions, possibly batched with the claims of other games.
+type ClaimResolver interface {
+	ResolveClaims(txs ...txmgr.TxCandidate) error
+}
+
 // FaultResponder implements the [Responder] interface to send onchain transactions.
 type FaultResponder struct {
 	log      log.Logger
 	sender   TxSender
+	resolver ClaimResolver
 	contract GameContract
 	uploader preimages.PreimageUploader
 	oracle   Oracle
 }
 
 // NewFaultResponder returns a new [FaultResponder].
-func NewFaultResponder(logger log.Logger, sender TxSender, contract GameContract, uploader preimages.PreimageUploader, oracle Oracle) (*FaultResponder, error) {
+func NewFaultResponder(logger log.Logger, sender TxSender, resolver ClaimResolver, contract GameContract, uploader preimages.PreimageUploader, oracle Oracle) (*FaultResponder, error) {
 	return &FaultResponder{
 		log:      logger,
 		sender:   sender,
+		resolver: resolver,
 		contract: contract,
 		uploader: uploader,
 		oracle:   oracle,
@@ -84,7 +90,7 @@ func (r *FaultResponder) ResolveClaims(claimIdxs ...uint64) error {
 		}
 		txs = append(txs, candidate)
 	}
-	return r.sender.SendAndWaitSimple("resolve claim", txs...)
+	return r.resolver.ResolveClaims(txs...)
 }
 
 func (r *FaultResponder) PerformAction(ctx context.Context, action types.Action) error {
@@ -99,11 +105,7 @@ func (r *FaultResponder) PerformAction(ctx context.Context, action types.Action)
 		}
 		// Always upload local preimages
 		if !preimageExists {
-			err := r.uploader.UploadPreimage(ctx, uint64(action.ParentClaim.ContractIndex), action.OracleData)
-			if errors.Is(err, preimages.ErrChallengePeriodNotOver) {
-				r.log.Debug("Large Preimage Squeeze failed, challenge period not over")
-				return nil
-			} else if err != nil {
+			if err := r.uploader.UploadPreimage(ctx, uint64(action.ParentClaim.ContractIndex), action.OracleData); err != nil {
 				return fmt.Errorf("failed to upload preimage: %w", err)
 			}
 		}
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/preimages"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
@@ -243,6 +244,25 @@ func TestPerformAction(t *testing.T) {
 		require.Equal(t, 1, uploader.updates)
 	})
 
+	t.Run("stepWithOracleDataAndChallengePeriodNotOver", func(t *testing.T) {
+		responder, mockTxMgr, _, uploader, _ := newTestFaultResponder(t)
+		uploader.uploadErr = preimages.ErrChallengePeriodNotOver
+		action := types.Action{
+			Type:        types.ActionTypeStep,
+			ParentClaim: types.Claim{ContractIndex: 123},
+			IsAttack:    true,
+			PreState:    []byte{1, 2, 3},
+			ProofData:   []byte{4, 5, 6},
+			OracleData: &types.PreimageOracleData{
+				IsLocal: true,
+			},
+		}
+		err := responder.PerformAction(context.Background(), action)
+		require.ErrorIs(t, err, preimages.ErrChallengePeriodNotOver)
+		require.Len(t, mockTxMgr.sent, 0)
+		require.Equal(t, 1, uploader.updates)
+	})
+
 	t.Run("stepWithOracleDataAndGlobalPreimageAlreadyExists", func(t *testing.T) {
 		responder, mockTxMgr, contract, uploader, oracle := newTestFaultResponder(t)
 		oracle.existsResult = true
@@ -305,7 +325,7 @@ func newTestFaultResponder(t *testing.T) (*FaultResponder, *mockTxManager, *mock
 	contract := &mockContract{}
 	uploader := &mockPreimageUploader{}
 	oracle := &mockOracle{}
-	responder, err := NewFaultResponder(log, mockTxMgr, contract, uploader, oracle)
+	responder, err := NewFaultResponder(log, mockTxMgr, mockTxMgr, contract, uploader, oracle)
 	require.NoError(t, err)
 	return responder, mockTxMgr, contract, uploader, oracle
 }
@@ -313,6 +333,7 @@ func newTestFaultResponder(t *testing.T) (*FaultResponder, *mockTxManager, *mock
 type mockPreimageUploader struct {
 	updates     int
 	uploadFails bool
+	uploadErr   error
 }
 
 func (m *mockPreimageUploader) UploadPreimage(ctx context.Context, parent uint64, data *types.PreimageOracleData) error {
@@ -320,7 +341,7 @@ func (m *mockPreimageUploader) UploadPreimage(ctx context.Context, parent uint64
 	if m.uploadFails {
 		return mockPreimageUploadErr
 	}
-	return nil
+	return m.uploadErr
 }
 
 type mockOracle struct {
@@ -355,6 +376,10 @@ func (m *mockTxManager) SendAndWaitSimple(_ string, txs ...txmgr.TxCandidate) er
 	return nil
 }
 
+func (m *mockTxManager) ResolveClaims(txs ...txmgr.TxCandidate) error {
+	return m.SendAndWaitSimple("resolve claim", txs...)
+}
+
 func (m *mockTxManager) BlockNumber(_ context.Context) (uint64, error) {
 	panic("not implemented")
 }
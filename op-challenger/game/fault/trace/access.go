@@ -16,12 +16,29 @@ func NewSimpleTraceAccessor(trace types.TraceProvider) *Accessor {
 
 type ProviderSelector func(ctx context.Context, game types.Game, ref types.Claim, pos types.Position) (types.TraceProvider, error)
 
-func NewAccessor(selector ProviderSelector) *Accessor {
-	return &Accessor{selector}
+// Prefetcher is implemented by trace providers that can load the values required for a game in advance, with fewer
+// requests than loading each value when it is needed.
+type Prefetcher interface {
+	Prefetch(ctx context.Context, game types.Game) error
+}
+
+func NewAccessor(selector ProviderSelector, prefetchers ...Prefetcher) *Accessor {
+	return &Accessor{selector, prefetchers}
 }
 
 type Accessor struct {
-	selector ProviderSelector
+	selector    ProviderSelector
+	prefetchers []Prefetcher
+}
+
+// Prefetch loads the values required for the game in advance from the providers that support it.
+func (t *Accessor) Prefetch(ctx context.Context, game types.Game) error {
+	for _, prefetcher := range t.prefetchers {
+		if err := prefetcher.Prefetch(ctx, game); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (t *Accessor) Get(ctx context.Context, game types.Game, ref types.Claim, pos types.Position) (common.Hash, error) {
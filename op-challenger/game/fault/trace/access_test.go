@@ -2,6 +2,7 @@ package trace
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"testing"
@@ -97,3 +98,28 @@ func (c *ChallengeTraceProvider) GetL2BlockNumberChallenge(_ context.Context) (*
 		Output: &eth.OutputResponse{OutputRoot: eth.Bytes32{0xaa, 0xbb}},
 	}, nil
 }
+
+func TestAccessor_Prefetch(t *testing.T) {
+	game := types.NewGameState([]types.Claim{{}}, 4)
+	prefetcher1 := &stubPrefetcher{}
+	prefetcher2 := &stubPrefetcher{}
+	accessor := NewAccessor(nil, prefetcher1, prefetcher2)
+	require.NoError(t, accessor.Prefetch(context.Background(), game))
+	require.Equal(t, []types.Game{game}, prefetcher1.games)
+	require.Equal(t, []types.Game{game}, prefetcher2.games)
+
+	prefetcher1.err = errors.New("boom")
+	require.ErrorIs(t, accessor.Prefetch(context.Background(), game), prefetcher1.err)
+
+	require.NoError(t, NewAccessor(nil).Prefetch(context.Background(), game), "should allow no prefetchers")
+}
+
+type stubPrefetcher struct {
+	games []types.Game
+	err   error
+}
+
+func (s *stubPrefetcher) Prefetch(_ context.Context, game types.Game) error {
+	s.games = append(s.games, game)
+	return s.err
+}
@@ -0,0 +1,61 @@
+package trace
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// StepData is the data required to execute a step, as returned by [types.TraceProvider.GetStepData].
+type StepData struct {
+	Prestate     []byte
+	ProofData    []byte
+	PreimageData *types.PreimageOracleData
+}
+
+// TraceCache persists the claim values and step data of traces, identified by the inputs they are computed from.
+type TraceCache interface {
+	TraceStore
+	StepData(inputs common.Hash, pos types.Position) (StepData, bool, error)
+	SetStepData(inputs common.Hash, pos types.Position, data StepData) error
+}
+
+// CachedTraceProvider is a [types.TraceProvider] that records the claim values and step data of the underlying
+// provider by the inputs of the trace, so they can be reused by other games with the same inputs and after the
+// challenger restarts.
+// Failures to read or record values are logged and the value is loaded from the underlying provider.
+type CachedTraceProvider struct {
+	*PersistentTraceProvider
+	cache  TraceCache
+	inputs common.Hash
+}
+
+func NewCachedTraceProvider(logger log.Logger, cache TraceCache, inputs common.Hash, provider types.TraceProvider) *CachedTraceProvider {
+	return &CachedTraceProvider{
+		PersistentTraceProvider: NewPersistentTraceProvider(logger, cache, inputs, provider),
+		cache:                   cache,
+		inputs:                  inputs,
+	}
+}
+
+func (p *CachedTraceProvider) GetStepData(ctx context.Context, pos types.Position) ([]byte, []byte, *types.PreimageOracleData, error) {
+	data, ok, err := p.cache.StepData(p.inputs, pos)
+	if err != nil {
+		p.logger.Warn("Failed to read cached step data", "pos", pos.ToGIndex(), "err", err)
+	} else if ok {
+		return data.Prestate, data.ProofData, data.PreimageData, nil
+	}
+	prestate, proofData, preimageData, err := p.TraceProvider.GetStepData(ctx, pos)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	data = StepData{Prestate: prestate, ProofData: proofData, PreimageData: preimageData}
+	if err := p.cache.SetStepData(p.inputs, pos, data); err != nil {
+		p.logger.Warn("Failed to cache step data", "pos", pos.ToGIndex(), "err", err)
+	}
+	return prestate, proofData, preimageData, nil
+}
+
+var _ types.TraceProvider = (*CachedTraceProvider)(nil)
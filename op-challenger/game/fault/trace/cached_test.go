@@ -0,0 +1,116 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedTraceProvider(t *testing.T) {
+	inputs := common.Hash{0x01}
+	pos := types.NewPosition(4, big.NewInt(5))
+
+	t.Run("ShareValuesBetweenProviders", func(t *testing.T) {
+		cache := newStubTraceCache()
+		provider := &countingProvider{TraceProvider: alphabet.NewTraceProvider(big.NewInt(0), 4)}
+		cached := NewCachedTraceProvider(testlog.Logger(t, log.LevelInfo), cache, inputs, provider)
+		expectedValue, err := provider.TraceProvider.Get(context.Background(), pos)
+		require.NoError(t, err)
+		expectedPrestate, expectedProof, expectedPreimage, err := provider.TraceProvider.GetStepData(context.Background(), pos)
+		require.NoError(t, err)
+
+		value, err := cached.Get(context.Background(), pos)
+		require.NoError(t, err)
+		require.Equal(t, expectedValue, value)
+		prestate, proof, preimage, err := cached.GetStepData(context.Background(), pos)
+		require.NoError(t, err)
+		require.Equal(t, expectedPrestate, prestate)
+		require.Equal(t, expectedProof, proof)
+		require.Equal(t, expectedPreimage, preimage)
+		require.Equal(t, 1, provider.gets)
+		require.Equal(t, 1, provider.stepDataGets)
+
+		// A provider for another game with the same inputs uses the cached values
+		other := &countingProvider{TraceProvider: alphabet.NewTraceProvider(big.NewInt(0), 4)}
+		cached = NewCachedTraceProvider(testlog.Logger(t, log.LevelInfo), cache, inputs, other)
+		value, err = cached.Get(context.Background(), pos)
+		require.NoError(t, err)
+		require.Equal(t, expectedValue, value)
+		prestate, proof, preimage, err = cached.GetStepData(context.Background(), pos)
+		require.NoError(t, err)
+		require.Equal(t, expectedPrestate, prestate)
+		require.Equal(t, expectedProof, proof)
+		require.Equal(t, expectedPreimage, preimage)
+		require.Zero(t, other.gets)
+		require.Zero(t, other.stepDataGets)
+
+		// Values for other inputs are not shared
+		cached = NewCachedTraceProvider(testlog.Logger(t, log.LevelInfo), cache, common.Hash{0x02}, other)
+		_, err = cached.Get(context.Background(), pos)
+		require.NoError(t, err)
+		_, _, _, err = cached.GetStepData(context.Background(), pos)
+		require.NoError(t, err)
+		require.Equal(t, 1, other.gets)
+		require.Equal(t, 1, other.stepDataGets)
+	})
+
+	t.Run("UseProviderWhenCacheFails", func(t *testing.T) {
+		cache := newStubTraceCache()
+		cache.err = errors.New("boom")
+		provider := &countingProvider{TraceProvider: alphabet.NewTraceProvider(big.NewInt(0), 4)}
+		cached := NewCachedTraceProvider(testlog.Logger(t, log.LevelInfo), cache, inputs, provider)
+		expectedPrestate, _, _, err := provider.TraceProvider.GetStepData(context.Background(), pos)
+		require.NoError(t, err)
+
+		prestate, _, _, err := cached.GetStepData(context.Background(), pos)
+		require.NoError(t, err)
+		require.Equal(t, expectedPrestate, prestate)
+		require.Equal(t, 1, provider.stepDataGets)
+	})
+
+	t.Run("DoNotCacheErrors", func(t *testing.T) {
+		cache := newStubTraceCache()
+		provider := &countingProvider{TraceProvider: alphabet.NewTraceProvider(big.NewInt(0), 4), err: errors.New("boom")}
+		cached := NewCachedTraceProvider(testlog.Logger(t, log.LevelInfo), cache, inputs, provider)
+
+		_, _, _, err := cached.GetStepData(context.Background(), pos)
+		require.ErrorIs(t, err, provider.err)
+		require.Empty(t, cache.stepData)
+	})
+}
+
+type stubTraceCache struct {
+	stubTraceStore
+	stepData map[common.Hash]StepData
+}
+
+func newStubTraceCache() *stubTraceCache {
+	return &stubTraceCache{
+		stubTraceStore: stubTraceStore{values: make(map[common.Hash]common.Hash)},
+		stepData:       make(map[common.Hash]StepData),
+	}
+}
+
+func (s *stubTraceCache) StepData(inputs common.Hash, pos types.Position) (StepData, bool, error) {
+	if s.err != nil {
+		return StepData{}, false, s.err
+	}
+	data, ok := s.stepData[s.key(inputs, pos)]
+	return data, ok, nil
+}
+
+func (s *stubTraceCache) SetStepData(inputs common.Hash, pos types.Position, data StepData) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.stepData[s.key(inputs, pos)] = data
+	return nil
+}
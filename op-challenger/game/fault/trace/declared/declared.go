@@ -0,0 +1,132 @@
+package declared
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/asterisc"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	ErrUnknownVM     = errors.New("unknown vm")
+	ErrUnknownOracle = errors.New("unknown oracle")
+)
+
+// VM is a VM built in to the challenger that can play declared game types.
+type VM string
+
+const (
+	VMCannon   VM = "cannon"
+	VMAsterisc VM = "asterisc"
+)
+
+// VMs are the VMs that can play declared game types.
+var VMs = []VM{VMCannon, VMAsterisc}
+
+// Oracle is a pre-image oracle server built in to the challenger that can be used by declared game types.
+type Oracle string
+
+const (
+	OracleOpProgram Oracle = "op-program"
+	OracleKona      Oracle = "kona"
+)
+
+// Oracles are the pre-image oracle servers that can be used by declared game types.
+var Oracles = []Oracle{OracleOpProgram, OracleKona}
+
+// GameType declares a game type, played by a trace type with a VM and pre-image oracle server that are built in to
+// the challenger, so that forks and experimental game types can be played without rebuilding the challenger.
+// The VM binary, server and prestates are configured like other VMs added as trace providers.
+type GameType struct {
+	TraceType types.TraceType
+	GameType  types.GameType
+	VM        VM
+	Oracle    Oracle
+}
+
+// Register adds the trace type of the declared game type, and uses its VM and oracle server for games of its type.
+// Registering the same declaration again has no effect, so config declaring game types can be loaded more than once.
+func Register(gameType GameType) error {
+	if existing, ok := vm.LookupTraceProvider(gameType.TraceType); ok {
+		if p, ok := existing.(*provider); ok && p.gameType == gameType {
+			return nil
+		}
+	}
+	p, err := newProvider(gameType)
+	if err != nil {
+		return err
+	}
+	return vm.RegisterTraceProvider(p)
+}
+
+// provider is the [vm.TraceProvider] for a declared game type.
+type provider struct {
+	gameType       GameType
+	stateConverter vm.StateConverter
+	serverExecutor vm.OracleServerExecutor
+	newProvider    func(logger log.Logger, m vm.Metricer, cfg vm.Config, serverExecutor vm.OracleServerExecutor, prestateProvider types.PrestateProvider, prestate string, localInputs utils.LocalGameInputs, dir string, gameDepth types.Depth) types.TraceProvider
+}
+
+func newProvider(gameType GameType) (*provider, error) {
+	p := &provider{gameType: gameType}
+	switch gameType.VM {
+	case VMCannon:
+		p.stateConverter = cannon.NewStateConverter()
+		p.newProvider = func(logger log.Logger, m vm.Metricer, cfg vm.Config, serverExecutor vm.OracleServerExecutor, prestateProvider types.PrestateProvider, prestate string, localInputs utils.LocalGameInputs, dir string, gameDepth types.Depth) types.TraceProvider {
+			return cannon.NewTraceProvider(logger, m, cfg, serverExecutor, prestateProvider, prestate, localInputs, dir, gameDepth)
+		}
+	case VMAsterisc:
+		p.stateConverter = asterisc.NewStateConverter()
+		p.newProvider = func(logger log.Logger, m vm.Metricer, cfg vm.Config, serverExecutor vm.OracleServerExecutor, prestateProvider types.PrestateProvider, prestate string, localInputs utils.LocalGameInputs, dir string, gameDepth types.Depth) types.TraceProvider {
+			return asterisc.NewTraceProvider(logger, m, cfg, serverExecutor, prestateProvider, prestate, localInputs, dir, gameDepth)
+		}
+	default:
+		return nil, fmt.Errorf("%w %q for trace type %v, must be one of %v", ErrUnknownVM, gameType.VM, gameType.TraceType, VMs)
+	}
+	switch gameType.Oracle {
+	case OracleOpProgram:
+		p.serverExecutor = vm.NewOpProgramServerExecutor()
+	case OracleKona:
+		p.serverExecutor = vm.NewKonaServerExecutor()
+	default:
+		return nil, fmt.Errorf("%w %q for trace type %v, must be one of %v", ErrUnknownOracle, gameType.Oracle, gameType.TraceType, Oracles)
+	}
+	return p, nil
+}
+
+func (p *provider) TraceType() types.TraceType {
+	return p.gameType.TraceType
+}
+
+func (p *provider) GameType() types.GameType {
+	return p.gameType.GameType
+}
+
+func (p *provider) StateConverter() vm.StateConverter {
+	return p.stateConverter
+}
+
+func (p *provider) OracleServerExecutor() vm.OracleServerExecutor {
+	return p.serverExecutor
+}
+
+func (p *provider) NewTraceProvider(
+	logger log.Logger,
+	m vm.Metricer,
+	cfg vm.Config,
+	serverExecutor vm.OracleServerExecutor,
+	prestateProvider types.PrestateProvider,
+	prestate string,
+	localInputs utils.LocalGameInputs,
+	dir string,
+	gameDepth types.Depth,
+) types.TraceProvider {
+	return p.newProvider(logger, m, cfg, serverExecutor, prestateProvider, prestate, localInputs, dir, gameDepth)
+}
+
+var _ vm.TraceProvider = (*provider)(nil)
@@ -0,0 +1,35 @@
+package declared
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	gameType := GameType{TraceType: "declared-test", GameType: 150, VM: VMCannon, Oracle: OracleKona}
+	require.NoError(t, Register(gameType))
+	require.NoError(t, Register(gameType), "should allow registering the same declaration again")
+
+	provider, ok := vm.LookupTraceProvider("declared-test")
+	require.True(t, ok)
+	require.Equal(t, gameType.TraceType, provider.TraceType())
+	require.Equal(t, gameType.GameType, provider.GameType())
+	require.IsType(t, &cannon.StateConverter{}, provider.StateConverter())
+	require.IsType(t, &vm.KonaServerExecutor{}, provider.OracleServerExecutor())
+	require.Equal(t, types.GameType(150), types.TraceType("declared-test").GameType())
+
+	conflicting := gameType
+	conflicting.GameType = 151
+	require.ErrorIs(t, Register(conflicting), types.ErrTraceTypeRegistered)
+}
+
+func TestRegisterInvalid(t *testing.T) {
+	require.ErrorIs(t, Register(GameType{TraceType: "declared-unknown-vm", GameType: 152, VM: "foo", Oracle: OracleKona}), ErrUnknownVM)
+	require.ErrorIs(t, Register(GameType{TraceType: "declared-unknown-oracle", GameType: 153, VM: VMAsterisc, Oracle: "foo"}), ErrUnknownOracle)
+	_, ok := vm.LookupTraceProvider("declared-unknown-vm")
+	require.False(t, ok)
+}
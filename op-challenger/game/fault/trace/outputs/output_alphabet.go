@@ -33,5 +33,5 @@ func NewOutputAlphabetTraceAccessor(
 	}
 	cache := NewProviderCache(m, "output_alphabet_provider", alphabetCreator)
 	selector := split.NewSplitProviderSelector(outputProvider, splitDepth, OutputRootSplitAdapter(outputProvider, cache.GetOrCreate))
-	return trace.NewAccessor(selector), nil
+	return trace.NewAccessor(selector, outputProvider), nil
 }
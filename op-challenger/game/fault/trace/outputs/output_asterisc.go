@@ -28,6 +28,8 @@ func NewOutputAsteriscTraceAccessor(
 	asteriscPrestate string,
 	rollupClient OutputRollupClient,
 	dir string,
+	store trace.TraceStore,
+	traceCache trace.TraceCache,
 	l1Head eth.BlockID,
 	splitDepth types.Depth,
 	prestateBlock uint64,
@@ -45,7 +47,7 @@ func NewOutputAsteriscTraceAccessor(
 		return provider, nil
 	}
 
-	cache := NewProviderCache(m, "output_asterisc_provider", asteriscCreator)
+	cache := NewProviderCache(m, "output_asterisc_provider", persistentCreator(logger, store, traceCache, l1Head, asteriscCreator))
 	selector := split.NewSplitProviderSelector(outputProvider, splitDepth, OutputRootSplitAdapter(outputProvider, cache.GetOrCreate))
-	return trace.NewAccessor(selector), nil
+	return trace.NewAccessor(selector, outputProvider), nil
 }
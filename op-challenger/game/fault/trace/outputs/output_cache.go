@@ -0,0 +1,84 @@
+package outputs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
+)
+
+const (
+	outputCacheSize = 10_000
+	outputBatchSize = 100
+)
+
+type BatchOutputRollupClient interface {
+	OutputsAtBlocks(ctx context.Context, blockNums []uint64) ([]*eth.OutputResponse, error)
+}
+
+// OutputCache is an [OutputRollupClient] that caches the outputs of safe blocks, so they are shared by all games
+// rather than fetched again each time a claim is checked.
+// Outputs can be prefetched in batch requests if the underlying client supports them.
+type OutputCache struct {
+	OutputRollupClient
+	cache *caching.LRUCache[uint64, *eth.OutputResponse]
+}
+
+func NewOutputCache(m caching.Metrics, client OutputRollupClient) *OutputCache {
+	return &OutputCache{
+		OutputRollupClient: client,
+		cache:              caching.NewLRUCache[uint64, *eth.OutputResponse](m, "outputs", outputCacheSize),
+	}
+}
+
+func (c *OutputCache) OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	if output, ok := c.cache.Get(blockNum); ok {
+		return output, nil
+	}
+	output, err := c.OutputRollupClient.OutputAtBlock(ctx, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	c.add(output)
+	return output, nil
+}
+
+// PrefetchOutputs loads the outputs of the specified blocks that are not already cached, in batches of up to
+// outputBatchSize blocks. Does nothing if the underlying client doesn't support batch requests.
+func (c *OutputCache) PrefetchOutputs(ctx context.Context, blockNums []uint64) error {
+	client, ok := c.OutputRollupClient.(BatchOutputRollupClient)
+	if !ok {
+		return nil
+	}
+	var missing []uint64
+	seen := make(map[uint64]bool, len(blockNums))
+	for _, blockNum := range blockNums {
+		if seen[blockNum] {
+			continue
+		}
+		seen[blockNum] = true
+		if _, ok := c.cache.Get(blockNum); !ok {
+			missing = append(missing, blockNum)
+		}
+	}
+	for start := 0; start < len(missing); start += outputBatchSize {
+		batch := missing[start:min(start+outputBatchSize, len(missing))]
+		outputs, err := client.OutputsAtBlocks(ctx, batch)
+		if err != nil {
+			return fmt.Errorf("failed to fetch outputs for %v blocks: %w", len(batch), err)
+		}
+		for _, output := range outputs {
+			c.add(output)
+		}
+	}
+	return nil
+}
+
+// add caches the output if its block is safe, so it can't be changed by a reorg of unsafe blocks.
+func (c *OutputCache) add(output *eth.OutputResponse) {
+	if output == nil || output.Status == nil || output.BlockRef.Number > output.Status.SafeL2.Number {
+		return
+	}
+	c.cache.Add(output.BlockRef.Number, output)
+}
@@ -0,0 +1,111 @@
+package outputs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputCache(t *testing.T) {
+	t.Run("CacheSafeOutputs", func(t *testing.T) {
+		client := newStubBatchRollupClient(20, 10)
+		cache := NewOutputCache(metrics.NoopMetrics, client)
+		output, err := cache.OutputAtBlock(context.Background(), 10)
+		require.NoError(t, err)
+		require.Equal(t, client.outputs[10], output)
+		_, err = cache.OutputAtBlock(context.Background(), 10)
+		require.NoError(t, err)
+		require.Equal(t, 1, client.calls)
+	})
+
+	t.Run("DoNotCacheUnsafeOutputs", func(t *testing.T) {
+		client := newStubBatchRollupClient(20, 10)
+		cache := NewOutputCache(metrics.NoopMetrics, client)
+		_, err := cache.OutputAtBlock(context.Background(), 20)
+		require.NoError(t, err)
+		_, err = cache.OutputAtBlock(context.Background(), 20)
+		require.NoError(t, err)
+		require.Equal(t, 2, client.calls)
+	})
+
+	t.Run("PrefetchInBatches", func(t *testing.T) {
+		client := newStubBatchRollupClient(outputBatchSize*2, outputBatchSize*2)
+		cache := NewOutputCache(metrics.NoopMetrics, client)
+		_, err := cache.OutputAtBlock(context.Background(), 1)
+		require.NoError(t, err)
+		var blocks []uint64
+		var expectedBatch []uint64
+		for i := uint64(1); i <= outputBatchSize+2; i++ {
+			blocks = append(blocks, i, i)
+			if i > 1 && i <= outputBatchSize+1 {
+				expectedBatch = append(expectedBatch, i)
+			}
+		}
+		require.NoError(t, cache.PrefetchOutputs(context.Background(), blocks))
+		// Already cached and duplicate blocks are not requested
+		require.Equal(t, [][]uint64{expectedBatch, {outputBatchSize + 2}}, client.batches)
+
+		for i := uint64(1); i <= outputBatchSize+2; i++ {
+			output, err := cache.OutputAtBlock(context.Background(), i)
+			require.NoError(t, err)
+			require.Equal(t, client.outputs[i], output)
+		}
+		require.Equal(t, 1, client.calls, "should use prefetched outputs")
+	})
+
+	t.Run("PrefetchError", func(t *testing.T) {
+		client := newStubBatchRollupClient(10, 10)
+		client.batchErr = errors.New("boom")
+		cache := NewOutputCache(metrics.NoopMetrics, client)
+		require.ErrorIs(t, cache.PrefetchOutputs(context.Background(), []uint64{1, 2}), client.batchErr)
+	})
+
+	t.Run("PrefetchNotSupported", func(t *testing.T) {
+		cache := NewOutputCache(metrics.NoopMetrics, &stubRollupClient{})
+		require.NoError(t, cache.PrefetchOutputs(context.Background(), []uint64{1, 2}))
+	})
+}
+
+type stubBatchRollupClient struct {
+	stubRollupClient
+	calls    int
+	batches  [][]uint64
+	batchErr error
+}
+
+func newStubBatchRollupClient(blocks uint64, safeHead uint64) *stubBatchRollupClient {
+	outputs := make(map[uint64]*eth.OutputResponse)
+	for i := uint64(0); i <= blocks; i++ {
+		outputs[i] = &eth.OutputResponse{
+			OutputRoot: eth.Bytes32{byte(i)},
+			BlockRef:   eth.L2BlockRef{Number: i},
+			Status:     &eth.SyncStatus{SafeL2: eth.L2BlockRef{Number: safeHead}},
+		}
+	}
+	return &stubBatchRollupClient{stubRollupClient: stubRollupClient{outputs: outputs}}
+}
+
+func (s *stubBatchRollupClient) OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	s.calls++
+	return s.stubRollupClient.OutputAtBlock(ctx, blockNum)
+}
+
+func (s *stubBatchRollupClient) OutputsAtBlocks(ctx context.Context, blockNums []uint64) ([]*eth.OutputResponse, error) {
+	s.batches = append(s.batches, blockNums)
+	if s.batchErr != nil {
+		return nil, s.batchErr
+	}
+	var outputs []*eth.OutputResponse
+	for _, blockNum := range blockNums {
+		output, err := s.stubRollupClient.OutputAtBlock(ctx, blockNum)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
@@ -28,6 +28,8 @@ func NewOutputCannonTraceAccessor(
 	cannonPrestate string,
 	rollupClient OutputRollupClient,
 	dir string,
+	store trace.TraceStore,
+	traceCache trace.TraceCache,
 	l1Head eth.BlockID,
 	splitDepth types.Depth,
 	prestateBlock uint64,
@@ -45,7 +47,7 @@ func NewOutputCannonTraceAccessor(
 		return provider, nil
 	}
 
-	cache := NewProviderCache(m, "output_cannon_provider", cannonCreator)
+	cache := NewProviderCache(m, "output_cannon_provider", persistentCreator(logger, store, traceCache, l1Head, cannonCreator))
 	selector := split.NewSplitProviderSelector(outputProvider, splitDepth, OutputRootSplitAdapter(outputProvider, cache.GetOrCreate))
-	return trace.NewAccessor(selector), nil
+	return trace.NewAccessor(selector, outputProvider), nil
 }
@@ -0,0 +1,54 @@
+package outputs
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/split"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// NewOutputVMTraceAccessor creates the trace accessor for output root games played with a VM registered as a
+// [vm.TraceProvider].
+func NewOutputVMTraceAccessor(
+	logger log.Logger,
+	m metrics.Metricer,
+	cfg vm.Config,
+	vmProvider vm.TraceProvider,
+	serverExecutor vm.OracleServerExecutor,
+	l2Client utils.L2HeaderSource,
+	prestateProvider types.PrestateProvider,
+	vmPrestate string,
+	rollupClient OutputRollupClient,
+	dir string,
+	store trace.TraceStore,
+	traceCache trace.TraceCache,
+	l1Head eth.BlockID,
+	splitDepth types.Depth,
+	prestateBlock uint64,
+	poststateBlock uint64,
+) (*trace.Accessor, error) {
+	outputProvider := NewTraceProvider(logger, prestateProvider, rollupClient, l2Client, l1Head, splitDepth, prestateBlock, poststateBlock)
+	vmCreator := func(ctx context.Context, localContext common.Hash, depth types.Depth, agreed contracts.Proposal, claimed contracts.Proposal) (types.TraceProvider, error) {
+		logger := logger.New("pre", agreed.OutputRoot, "post", claimed.OutputRoot, "localContext", localContext)
+		subdir := filepath.Join(dir, localContext.Hex())
+		localInputs, err := utils.FetchLocalInputsFromProposals(ctx, l1Head.Hash, l2Client, agreed, claimed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %v local inputs: %w", cfg.VmType, err)
+		}
+		return vmProvider.NewTraceProvider(logger, m, cfg, serverExecutor, prestateProvider, vmPrestate, localInputs, subdir, depth), nil
+	}
+
+	cache := NewProviderCache(m, fmt.Sprintf("output_%v_provider", cfg.VmType), persistentCreator(logger, store, traceCache, l1Head, vmCreator))
+	selector := split.NewSplitProviderSelector(outputProvider, splitDepth, OutputRootSplitAdapter(outputProvider, cache.GetOrCreate))
+	return trace.NewAccessor(selector, outputProvider), nil
+}
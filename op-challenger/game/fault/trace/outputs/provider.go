@@ -25,6 +25,11 @@ type OutputRollupClient interface {
 	SafeHeadAtL1Block(ctx context.Context, l1BlockNum uint64) (*eth.SafeHeadResponse, error)
 }
 
+// OutputPrefetcher is implemented by rollup clients that can load the outputs of many blocks in advance.
+type OutputPrefetcher interface {
+	PrefetchOutputs(ctx context.Context, blockNums []uint64) error
+}
+
 // OutputTraceProvider is a [types.TraceProvider] implementation that uses
 // output roots for given L2 Blocks as a trace.
 type OutputTraceProvider struct {
@@ -93,6 +98,41 @@ func (o *OutputTraceProvider) Get(ctx context.Context, pos types.Position) (comm
 	return o.outputAtBlock(ctx, outputBlock)
 }
 
+// Prefetch loads the output roots for the positions of the claims in the game and the positions they can be countered
+// at, so the rollup client can fetch them in batches rather than one at a time as each claim is checked.
+// Does nothing if the rollup client doesn't support prefetching outputs.
+func (o *OutputTraceProvider) Prefetch(ctx context.Context, game types.Game) error {
+	prefetcher, ok := o.rollupProvider.(OutputPrefetcher)
+	if !ok {
+		return nil
+	}
+	resp, err := o.rollupProvider.SafeHeadAtL1Block(ctx, o.l1Head.Number)
+	if err != nil {
+		return fmt.Errorf("failed to get safe head at L1 block %v: %w", o.l1Head, err)
+	}
+	var blocks []uint64
+	for _, claim := range game.Claims() {
+		if claim.Depth() > o.gameDepth {
+			continue
+		}
+		positions := []types.Position{claim.Position}
+		if claim.Depth() < o.gameDepth {
+			positions = append(positions, claim.Attack())
+			if !claim.IsRoot() {
+				positions = append(positions, claim.Defend())
+			}
+		}
+		for _, pos := range positions {
+			block, err := o.ClaimedBlockNumber(pos)
+			if err != nil {
+				return err
+			}
+			blocks = append(blocks, min(block, resp.SafeHead.Number))
+		}
+	}
+	return prefetcher.PrefetchOutputs(ctx, blocks)
+}
+
 // GetStepData is not supported in the [OutputTraceProvider].
 func (o *OutputTraceProvider) GetStepData(_ context.Context, _ types.Position) (prestate []byte, proofData []byte, preimageData *types.PreimageOracleData, err error) {
 	return nil, nil, nil, ErrGetStepData
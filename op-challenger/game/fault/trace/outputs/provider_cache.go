@@ -2,11 +2,16 @@ package outputs
 
 import (
 	"context"
+	"encoding/binary"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
 )
 
 type ProviderCache struct {
@@ -34,3 +39,39 @@ func NewProviderCache(m caching.Metrics, metricsLabel string, creator ProposalTr
 		creator: creator,
 	}
 }
+
+// persistentCreator wraps the providers created by creator so their trace values are recorded in store, and their
+// trace values and step data are cached in cache by the inputs of the trace.
+// Returns creator unchanged if both store and cache are nil.
+func persistentCreator(logger log.Logger, store trace.TraceStore, cache trace.TraceCache, l1Head eth.BlockID, creator ProposalTraceProviderCreator) ProposalTraceProviderCreator {
+	if store == nil && cache == nil {
+		return creator
+	}
+	return func(ctx context.Context, localContext common.Hash, depth types.Depth, agreed contracts.Proposal, claimed contracts.Proposal) (types.TraceProvider, error) {
+		provider, err := creator(ctx, localContext, depth, agreed, claimed)
+		if err != nil {
+			return nil, err
+		}
+		if cache != nil {
+			provider = trace.NewCachedTraceProvider(logger, cache, traceInputs(l1Head, depth, agreed, claimed), provider)
+		}
+		if store != nil {
+			provider = trace.NewPersistentTraceProvider(logger, store, localContext, provider)
+		}
+		return provider, nil
+	}
+}
+
+// traceInputs identifies the trace of a VM executed from the agreed to the claimed proposal with the specified L1
+// head. The trace of a VM prestate is the same for all games with the same inputs, even if the split position of the
+// disputed output roots in each game is different.
+func traceInputs(l1Head eth.BlockID, depth types.Depth, agreed contracts.Proposal, claimed contracts.Proposal) common.Hash {
+	data := make([]byte, 0, 5*common.HashLength+8)
+	data = append(data, l1Head.Hash.Bytes()...)
+	data = append(data, agreed.OutputRoot.Bytes()...)
+	data = append(data, agreed.L2BlockNumber.FillBytes(make([]byte, common.HashLength))...)
+	data = append(data, claimed.OutputRoot.Bytes()...)
+	data = append(data, claimed.L2BlockNumber.FillBytes(make([]byte, common.HashLength))...)
+	data = binary.BigEndian.AppendUint64(data, uint64(depth))
+	return crypto.Keccak256Hash(data)
+}
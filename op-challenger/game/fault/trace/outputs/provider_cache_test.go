@@ -4,13 +4,19 @@ import (
 	"context"
 	"errors"
 	"math/big"
+	"path/filepath"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/statedb"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 )
 
@@ -75,3 +81,67 @@ func TestProviderCache_DoNotCacheErrors(t *testing.T) {
 	require.ErrorIs(t, err, providerErr)
 	require.Equal(t, 2, callCount)
 }
+
+func TestTraceInputs(t *testing.T) {
+	l1Head := eth.BlockID{Hash: common.Hash{0x11}, Number: 100}
+	agreed := contracts.Proposal{L2BlockNumber: big.NewInt(34), OutputRoot: common.Hash{0xaa}}
+	claimed := contracts.Proposal{L2BlockNumber: big.NewInt(35), OutputRoot: common.Hash{0xcc}}
+	depth := types.Depth(6)
+	inputs := traceInputs(l1Head, depth, agreed, claimed)
+	require.Equal(t, inputs, traceInputs(l1Head, depth, agreed, claimed))
+
+	otherL1Head := l1Head
+	otherL1Head.Hash = common.Hash{0x22}
+	otherAgreedRoot := agreed
+	otherAgreedRoot.OutputRoot = common.Hash{0xbb}
+	otherAgreedBlock := agreed
+	otherAgreedBlock.L2BlockNumber = big.NewInt(33)
+	otherClaimedRoot := claimed
+	otherClaimedRoot.OutputRoot = common.Hash{0xdd}
+	otherClaimedBlock := claimed
+	otherClaimedBlock.L2BlockNumber = big.NewInt(36)
+	for _, other := range []common.Hash{
+		traceInputs(otherL1Head, depth, agreed, claimed),
+		traceInputs(l1Head, depth+1, agreed, claimed),
+		traceInputs(l1Head, depth, otherAgreedRoot, claimed),
+		traceInputs(l1Head, depth, otherAgreedBlock, claimed),
+		traceInputs(l1Head, depth, agreed, otherClaimedRoot),
+		traceInputs(l1Head, depth, agreed, otherClaimedBlock),
+	} {
+		require.NotEqual(t, inputs, other)
+	}
+}
+
+func TestPersistentCreator(t *testing.T) {
+	l1Head := eth.BlockID{Hash: common.Hash{0x11}, Number: 100}
+	agreed := contracts.Proposal{L2BlockNumber: big.NewInt(34), OutputRoot: common.Hash{0xaa}}
+	claimed := contracts.Proposal{L2BlockNumber: big.NewInt(35), OutputRoot: common.Hash{0xcc}}
+	creator := func(ctx context.Context, localContext common.Hash, depth types.Depth, agreed contracts.Proposal, claimed contracts.Proposal) (types.TraceProvider, error) {
+		return alphabet.NewTraceProvider(big.NewInt(0), depth), nil
+	}
+
+	t.Run("NoStores", func(t *testing.T) {
+		provider, err := persistentCreator(testlog.Logger(t, log.LevelInfo), nil, nil, l1Head, creator)(context.Background(), common.Hash{0xdd}, 6, agreed, claimed)
+		require.NoError(t, err)
+		require.IsType(t, &alphabet.AlphabetTraceProvider{}, provider)
+	})
+
+	t.Run("StoreAndCache", func(t *testing.T) {
+		db, err := statedb.NewDB(testlog.Logger(t, log.LevelInfo), filepath.Join(t.TempDir(), "db"))
+		require.NoError(t, err)
+		defer db.Close()
+		cache := db.ForGame(common.Address{0x01}).TraceCache(common.Hash{0xee})
+		create := persistentCreator(testlog.Logger(t, log.LevelInfo), db.ForGame(common.Address{0x01}), cache, l1Head, creator)
+		provider, err := create(context.Background(), common.Hash{0xdd}, 6, agreed, claimed)
+		require.NoError(t, err)
+		require.IsType(t, &trace.PersistentTraceProvider{}, provider)
+		pos := types.NewPosition(6, big.NewInt(3))
+		expected, err := provider.Get(context.Background(), pos)
+		require.NoError(t, err)
+
+		value, ok, err := cache.TraceValue(traceInputs(l1Head, 6, agreed, claimed), pos)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, expected, value)
+	})
+}
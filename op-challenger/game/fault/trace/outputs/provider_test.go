@@ -195,6 +195,36 @@ func TestGetStepData(t *testing.T) {
 	require.ErrorIs(t, err, ErrGetStepData)
 }
 
+func TestPrefetch(t *testing.T) {
+	t.Run("NotSupported", func(t *testing.T) {
+		provider, _, _ := setupWithTestData(t, prestateBlock, poststateBlock)
+		game := types.NewGameState([]types.Claim{{ClaimData: types.ClaimData{Position: types.RootPosition}}}, gameDepth+3)
+		require.NoError(t, provider.Prefetch(context.Background(), game))
+	})
+
+	t.Run("ClaimAndCounterPositions", func(t *testing.T) {
+		provider, rollupClient, _ := setupWithTestData(t, prestateBlock, poststateBlock)
+		rollupClient.maxSafeHead = 180
+		prefetcher := &stubOutputPrefetcher{stubRollupClient: rollupClient}
+		provider.rollupProvider = prefetcher
+		root := types.Claim{ClaimData: types.ClaimData{Position: types.RootPosition}}
+		attack := types.Claim{ClaimData: types.ClaimData{Position: root.Attack()}}
+		split := types.Claim{ClaimData: types.ClaimData{Position: types.NewPosition(gameDepth, big.NewInt(10))}}
+		bottom := types.Claim{ClaimData: types.ClaimData{Position: split.Attack()}}
+		game := types.NewGameState([]types.Claim{root, attack, split, bottom}, gameDepth+3)
+		require.NoError(t, provider.Prefetch(context.Background(), game))
+
+		var expected []uint64
+		for _, pos := range []types.Position{root.Position, root.Attack(), attack.Position, attack.Attack(), attack.Defend(), split.Position} {
+			block, err := provider.HonestBlockNumber(context.Background(), pos)
+			require.NoError(t, err)
+			expected = append(expected, block)
+		}
+		require.Equal(t, expected, prefetcher.blocks)
+		require.Contains(t, prefetcher.blocks, uint64(180), "should restrict blocks to the safe head")
+	})
+}
+
 func setupWithTestData(t *testing.T, prestateBlock, poststateBlock uint64, customGameDepth ...types.Depth) (*OutputTraceProvider, *stubRollupClient, *stubL2HeaderSource) {
 	rollupClient := &stubRollupClient{
 		outputs: map[uint64]*eth.OutputResponse{
@@ -261,3 +291,13 @@ func (s *stubL2HeaderSource) HeaderByNumber(_ context.Context, num *big.Int) (*e
 	}
 	return header, nil
 }
+
+type stubOutputPrefetcher struct {
+	*stubRollupClient
+	blocks []uint64
+}
+
+func (s *stubOutputPrefetcher) PrefetchOutputs(_ context.Context, blockNums []uint64) error {
+	s.blocks = append(s.blocks, blockNums...)
+	return nil
+}
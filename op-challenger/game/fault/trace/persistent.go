@@ -0,0 +1,53 @@
+package trace
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// TraceStore persists the claim values of traces, identified by their local context.
+type TraceStore interface {
+	TraceValue(localContext common.Hash, pos types.Position) (common.Hash, bool, error)
+	SetTraceValue(localContext common.Hash, pos types.Position, value common.Hash) error
+}
+
+// PersistentTraceProvider is a [types.TraceProvider] that records the claim values of the underlying provider so
+// they don't need to be computed again, even when the challenger restarts.
+// Failures to read or record values are logged and the value is loaded from the underlying provider.
+type PersistentTraceProvider struct {
+	types.TraceProvider
+	logger       log.Logger
+	store        TraceStore
+	localContext common.Hash
+}
+
+func NewPersistentTraceProvider(logger log.Logger, store TraceStore, localContext common.Hash, provider types.TraceProvider) *PersistentTraceProvider {
+	return &PersistentTraceProvider{
+		TraceProvider: provider,
+		logger:        logger,
+		store:         store,
+		localContext:  localContext,
+	}
+}
+
+func (p *PersistentTraceProvider) Get(ctx context.Context, pos types.Position) (common.Hash, error) {
+	value, ok, err := p.store.TraceValue(p.localContext, pos)
+	if err != nil {
+		p.logger.Warn("Failed to read stored trace value", "pos", pos.ToGIndex(), "err", err)
+	} else if ok {
+		return value, nil
+	}
+	value, err = p.TraceProvider.Get(ctx, pos)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := p.store.SetTraceValue(p.localContext, pos, value); err != nil {
+		p.logger.Warn("Failed to store trace value", "pos", pos.ToGIndex(), "err", err)
+	}
+	return value, nil
+}
+
+var _ types.TraceProvider = (*PersistentTraceProvider)(nil)
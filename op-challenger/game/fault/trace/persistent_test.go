@@ -0,0 +1,112 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistentTraceProvider(t *testing.T) {
+	localContext := common.Hash{0x01}
+	pos := types.NewPositionFromGIndex(big.NewInt(5))
+
+	t.Run("StoreValues", func(t *testing.T) {
+		store := &stubTraceStore{values: make(map[common.Hash]common.Hash)}
+		provider := &countingProvider{TraceProvider: alphabet.NewTraceProvider(big.NewInt(0), 4)}
+		persistent := NewPersistentTraceProvider(testlog.Logger(t, log.LevelInfo), store, localContext, provider)
+		expected, err := provider.TraceProvider.Get(context.Background(), pos)
+		require.NoError(t, err)
+
+		value, err := persistent.Get(context.Background(), pos)
+		require.NoError(t, err)
+		require.Equal(t, expected, value)
+		require.Equal(t, 1, provider.gets)
+		require.Equal(t, expected, store.values[store.key(localContext, pos)])
+
+		// Use stored value, including from new providers such as after a restart
+		persistent = NewPersistentTraceProvider(testlog.Logger(t, log.LevelInfo), store, localContext, provider)
+		value, err = persistent.Get(context.Background(), pos)
+		require.NoError(t, err)
+		require.Equal(t, expected, value)
+		require.Equal(t, 1, provider.gets)
+	})
+
+	t.Run("UseProviderWhenStoreFails", func(t *testing.T) {
+		store := &stubTraceStore{values: make(map[common.Hash]common.Hash), err: errors.New("boom")}
+		provider := &countingProvider{TraceProvider: alphabet.NewTraceProvider(big.NewInt(0), 4)}
+		persistent := NewPersistentTraceProvider(testlog.Logger(t, log.LevelInfo), store, localContext, provider)
+		expected, err := provider.TraceProvider.Get(context.Background(), pos)
+		require.NoError(t, err)
+
+		value, err := persistent.Get(context.Background(), pos)
+		require.NoError(t, err)
+		require.Equal(t, expected, value)
+		require.Equal(t, 1, provider.gets)
+	})
+
+	t.Run("DoNotStoreErrors", func(t *testing.T) {
+		store := &stubTraceStore{values: make(map[common.Hash]common.Hash)}
+		provider := &countingProvider{TraceProvider: alphabet.NewTraceProvider(big.NewInt(0), 4), err: errors.New("boom")}
+		persistent := NewPersistentTraceProvider(testlog.Logger(t, log.LevelInfo), store, localContext, provider)
+
+		_, err := persistent.Get(context.Background(), pos)
+		require.ErrorIs(t, err, provider.err)
+		require.Empty(t, store.values)
+	})
+}
+
+type countingProvider struct {
+	types.TraceProvider
+	gets         int
+	stepDataGets int
+	err          error
+}
+
+func (c *countingProvider) Get(ctx context.Context, pos types.Position) (common.Hash, error) {
+	c.gets++
+	if c.err != nil {
+		return common.Hash{}, c.err
+	}
+	return c.TraceProvider.Get(ctx, pos)
+}
+
+type stubTraceStore struct {
+	values map[common.Hash]common.Hash
+	err    error
+}
+
+func (s *stubTraceStore) key(localContext common.Hash, pos types.Position) common.Hash {
+	return common.BigToHash(new(big.Int).Add(localContext.Big(), pos.ToGIndex()))
+}
+
+func (s *stubTraceStore) TraceValue(localContext common.Hash, pos types.Position) (common.Hash, bool, error) {
+	if s.err != nil {
+		return common.Hash{}, false, s.err
+	}
+	value, ok := s.values[s.key(localContext, pos)]
+	return value, ok, nil
+}
+
+func (s *stubTraceStore) SetTraceValue(localContext common.Hash, pos types.Position, value common.Hash) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.values[s.key(localContext, pos)] = value
+	return nil
+}
+
+func (c *countingProvider) GetStepData(ctx context.Context, pos types.Position) ([]byte, []byte, *types.PreimageOracleData, error) {
+	c.stepDataGets++
+	if c.err != nil {
+		return nil, nil, nil, c.err
+	}
+	return c.TraceProvider.GetStepData(ctx, pos)
+}
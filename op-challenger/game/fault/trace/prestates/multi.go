@@ -1,10 +1,10 @@
 package prestates
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -22,14 +22,20 @@ var (
 )
 
 type MultiPrestateProvider struct {
-	baseUrl        *url.URL
+	store          prestateStore
 	dataDir        string
 	stateConverter vm.StateConverter
 }
 
+// NewMultiPrestateProvider creates a provider that downloads prestates from the base URL into the data dir.
+// The base URL is either an HTTP(S) URL, or the URL of an S3 or GCS bucket (see newPrestateStore).
 func NewMultiPrestateProvider(baseUrl *url.URL, dataDir string, stateConverter vm.StateConverter) *MultiPrestateProvider {
+	store, err := newPrestateStore(baseUrl, nil)
+	if err != nil {
+		store = &unavailableStore{err: err}
+	}
 	return &MultiPrestateProvider{
-		baseUrl:        baseUrl,
+		store:          store,
 		dataDir:        dataDir,
 		stateConverter: stateConverter,
 	}
@@ -66,15 +72,13 @@ func (m *MultiPrestateProvider) fetchPrestate(hash common.Hash, fileType string,
 	if err := os.MkdirAll(m.dataDir, 0755); err != nil {
 		return fmt.Errorf("error creating prestate dir: %w", err)
 	}
-	prestateUrl := m.baseUrl.JoinPath(hash.Hex() + fileType)
-	resp, err := http.Get(prestateUrl.String())
+	name := hash.Hex() + fileType
+	prestateUrl := m.store.Location(name)
+	body, err := m.store.Get(context.Background(), name)
 	if err != nil {
-		return fmt.Errorf("failed to fetch prestate from %v: %w", prestateUrl, err)
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("%w from url %v: status %v", ErrPrestateUnavailable, prestateUrl, resp.StatusCode)
+		return err
 	}
+	defer body.Close()
 	tmpFile := dest + ".tmp" + fileType // Preserve the file type extension so compression is applied correctly
 	out, err := ioutil.NewAtomicWriterCompressed(tmpFile, 0o644)
 	if err != nil {
@@ -84,7 +88,7 @@ func (m *MultiPrestateProvider) fetchPrestate(hash common.Hash, fileType string,
 		// If errors occur, try to clean up without renaming the file into its final destination as Close() would do
 		_ = out.Abort()
 	}()
-	if _, err := io.Copy(out, resp.Body); err != nil {
+	if _, err := io.Copy(out, body); err != nil {
 		return fmt.Errorf("failed to write file %v: %w", dest, err)
 	}
 	if err := out.Close(); err != nil {
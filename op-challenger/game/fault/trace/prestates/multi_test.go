@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
 	"github.com/ethereum-optimism/optimism/op-service/ioutil"
@@ -173,3 +174,70 @@ func (s *stubStateConverter) ConvertStateToProof(path string) (*utils.ProofData,
 	}
 	return &utils.ProofData{ClaimValue: s.hash}, 0, false, s.err
 }
+
+func TestDownloadPrestateFromObjectStore(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "access-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret-key")
+	hash := common.Hash{0xaa}
+	var requests []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if !strings.Contains(r.Header.Get("Authorization"), "access-key") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/bucket/prestates/"+hash.Hex()+".json.gz" {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code></Error>`))
+			return
+		}
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		_, _ = w.Write([]byte("content"))
+	}))
+	defer server.Close()
+
+	createProvider := func(t *testing.T, dir string) *MultiPrestateProvider {
+		baseUrl := parseURL(t, "s3://bucket/prestates?region=us-east-1&endpoint="+server.Listener.Addr().String())
+		store, err := newPrestateStore(baseUrl, server.Client().Transport)
+		require.NoError(t, err)
+		provider := NewMultiPrestateProvider(baseUrl, dir, &stubStateConverter{hash: hash})
+		provider.store = store
+		return provider
+	}
+
+	t.Run("Download", func(t *testing.T) {
+		requests = nil
+		dir := t.TempDir()
+		path, err := createProvider(t, dir).PrestatePath(hash)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, hash.Hex()+".json.gz"), path)
+		in, err := ioutil.OpenDecompressed(path)
+		require.NoError(t, err)
+		defer in.Close()
+		content, err := io.ReadAll(in)
+		require.NoError(t, err)
+		require.Equal(t, "content", string(content))
+		require.Equal(t, []string{
+			"GET /bucket/prestates/" + hash.Hex() + ".bin.gz",
+			"GET /bucket/prestates/" + hash.Hex() + ".json.gz",
+		}, requests, "must only send one request per prestate file")
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		requests = nil
+		_, err := createProvider(t, t.TempDir()).PrestatePath(common.Hash{0xbb})
+		require.ErrorIs(t, err, ErrPrestateUnavailable)
+		require.Len(t, requests, 3)
+		for _, request := range requests {
+			require.True(t, strings.HasPrefix(request, "GET "), "must only request the object: %v", request)
+		}
+	})
+}
+
+func TestInvalidObjectStoreURL(t *testing.T) {
+	provider := NewMultiPrestateProvider(parseURL(t, "s3:///prestates"), t.TempDir(), &stubStateConverter{hash: common.Hash{0xaa}})
+	_, err := provider.PrestatePath(common.Hash{0xaa})
+	require.ErrorContains(t, err, "missing bucket")
+	require.NotErrorIs(t, err, ErrPrestateUnavailable)
+}
@@ -0,0 +1,156 @@
+package prestates
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+
+	"github.com/ethereum-optimism/optimism/op-service/objstore"
+)
+
+// prestateStore downloads prestate files from the remote location of the prestates.
+type prestateStore interface {
+	// Get returns the content of the named prestate file, or an error wrapping ErrPrestateUnavailable if it doesn't exist.
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+	// Location describes where the named prestate file is downloaded from.
+	Location(name string) string
+}
+
+// CheckBaseURL returns an error if the prestates URL is not supported: an HTTP(S) base URL, s3://bucket/prefix
+// or gs://bucket/prefix.
+func CheckBaseURL(baseUrl *url.URL) error {
+	switch baseUrl.Scheme {
+	case "s3", "gs":
+		if baseUrl.Host == "" {
+			return fmt.Errorf("missing bucket in prestates url %v", baseUrl)
+		}
+	case "http", "https":
+	default:
+		return fmt.Errorf("unsupported scheme of prestates url %v", baseUrl)
+	}
+	return nil
+}
+
+// newPrestateStore creates the store for a prestates URL: an HTTP(S) base URL, s3://bucket/prefix for S3,
+// or gs://bucket/prefix for GCS. Buckets are accessed with the S3 API, with credentials from the AWS environment
+// variables or the instance IAM role. GCS requires HMAC keys.
+// The endpoint and region query parameters override the default endpoint and the region of the bucket,
+// e.g. s3://bucket/prefix?endpoint=minio.example.com:9000&region=us-east-1 for S3-compatible object stores.
+func newPrestateStore(baseUrl *url.URL, transport http.RoundTripper) (prestateStore, error) {
+	if err := CheckBaseURL(baseUrl); err != nil {
+		return nil, err
+	}
+	switch baseUrl.Scheme {
+	case "s3":
+		return newObjectStore(baseUrl, "s3.amazonaws.com", transport)
+	case "gs":
+		return newObjectStore(baseUrl, "storage.googleapis.com", transport)
+	default:
+		return &httpStore{baseUrl: baseUrl}, nil
+	}
+}
+
+type httpStore struct {
+	baseUrl *url.URL
+}
+
+func (s *httpStore) Location(name string) string {
+	return s.baseUrl.JoinPath(name).String()
+}
+
+func (s *httpStore) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	prestateUrl := s.Location(name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, prestateUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %v: %w", prestateUrl, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch prestate from %v: %w", prestateUrl, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("%w from url %v: status %v", ErrPrestateUnavailable, prestateUrl, resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+type objectStore struct {
+	client *minio.Client
+	scheme string
+	bucket string
+	prefix string
+}
+
+func newObjectStore(baseUrl *url.URL, defaultEndpoint string, transport http.RoundTripper) (*objectStore, error) {
+	endpoint := baseUrl.Query().Get("endpoint")
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+	client, err := objstore.NewClient(endpoint, baseUrl.Query().Get("region"), transport)
+	if err != nil {
+		return nil, err
+	}
+	return &objectStore{
+		client: client,
+		scheme: baseUrl.Scheme,
+		bucket: baseUrl.Host,
+		prefix: strings.TrimPrefix(baseUrl.Path, "/"),
+	}, nil
+}
+
+func (s *objectStore) key(name string) string {
+	return path.Join(s.prefix, name)
+}
+
+func (s *objectStore) Location(name string) string {
+	return fmt.Sprintf("%v://%v/%v", s.scheme, s.bucket, s.key(name))
+}
+
+func (s *objectStore) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	obj, err := s.client.GetObject(ctx, s.bucket, s.key(name), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch prestate from %v: %w", s.Location(name), err)
+	}
+	// The object is only requested when first read, so read ahead to report a missing prestate before it's copied
+	r := bufio.NewReader(obj)
+	if _, err := r.Peek(1); err != nil && !errors.Is(err, io.EOF) {
+		_ = obj.Close()
+		if objstore.IsNotFound(err) {
+			return nil, fmt.Errorf("%w from %v: %w", ErrPrestateUnavailable, s.Location(name), err)
+		}
+		return nil, fmt.Errorf("failed to fetch prestate from %v: %w", s.Location(name), err)
+	}
+	return &objectReader{Reader: r, obj: obj}, nil
+}
+
+// objectReader reads an object through the buffered reader that read ahead of it.
+type objectReader struct {
+	*bufio.Reader
+	obj *minio.Object
+}
+
+func (r *objectReader) Close() error {
+	return r.obj.Close()
+}
+
+// unavailableStore is used when the store for the prestates URL can't be created, to report the error on use.
+type unavailableStore struct {
+	err error
+}
+
+func (s *unavailableStore) Location(name string) string {
+	return name
+}
+
+func (s *unavailableStore) Get(_ context.Context, _ string) (io.ReadCloser, error) {
+	return nil, s.err
+}
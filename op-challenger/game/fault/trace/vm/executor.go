@@ -24,6 +24,7 @@ const (
 type Metricer interface {
 	RecordVmExecutionTime(vmType string, t time.Duration)
 	RecordVmMemoryUsed(vmType string, memoryUsed uint64)
+	RecordVmQueueTime(vmType string, t time.Duration)
 }
 
 type Config struct {
@@ -43,6 +44,9 @@ type Config struct {
 	Network          string
 	RollupConfigPath string
 	L2GenesisPath    string
+
+	// Limiter limits the resources used by concurrent executions of all VMs. Executions are not limited if nil.
+	Limiter *ExecutionLimiter
 }
 
 type OracleServerExecutor interface {
@@ -128,20 +132,29 @@ func (e *Executor) DoGenerateProof(ctx context.Context, dir string, begin uint64
 	if err := os.MkdirAll(proofDir, 0755); err != nil {
 		return fmt.Errorf("could not create proofs directory %v: %w", proofDir, err)
 	}
+	queueStart := time.Now()
+	release, err := e.cfg.Limiter.Acquire(ctx, e.cfg.VmType.String())
+	if err != nil {
+		return fmt.Errorf("failed to wait for vm execution resources: %w", err)
+	}
+	e.metrics.RecordVmQueueTime(e.cfg.VmType.String(), time.Since(queueStart))
 	e.logger.Info("Generating trace", "proof", end, "cmd", e.cfg.VmBin, "args", strings.Join(args, ", "))
 	execStart := time.Now()
 	err = e.cmdExecutor(ctx, e.logger.New("proof", end), e.cfg.VmBin, args...)
 	execTime := time.Since(execStart)
 	memoryUsed := "unknown"
+	var memoryUsedBytes uint64
 	e.metrics.RecordVmExecutionTime(e.cfg.VmType.String(), execTime)
 	if e.cfg.DebugInfo && err == nil {
 		if info, err := jsonutil.LoadJSON[debugInfo](filepath.Join(dataDir, debugFilename)); err != nil {
 			e.logger.Warn("Failed to load debug metrics", "err", err)
 		} else {
-			e.metrics.RecordVmMemoryUsed(e.cfg.VmType.String(), uint64(info.MemoryUsed))
-			memoryUsed = fmt.Sprintf("%d", uint64(info.MemoryUsed))
+			memoryUsedBytes = uint64(info.MemoryUsed)
+			e.metrics.RecordVmMemoryUsed(e.cfg.VmType.String(), memoryUsedBytes)
+			memoryUsed = fmt.Sprintf("%d", memoryUsedBytes)
 		}
 	}
+	release(memoryUsedBytes)
 	e.logger.Info("VM execution complete", "time", execTime, "memory", memoryUsed)
 	return err
 }
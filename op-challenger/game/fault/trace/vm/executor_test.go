@@ -144,9 +144,44 @@ func TestGenerateProof(t *testing.T) {
 	})
 }
 
+func TestGenerateProofWaitsForLimiter(t *testing.T) {
+	limiter := NewExecutionLimiter(1, 0, 0)
+	release, err := limiter.Acquire(context.Background(), "other")
+	require.NoError(t, err)
+	defer release(0)
+
+	cfg := Config{VmType: "test", VmBin: "./bin/testvm", Server: "./bin/testserver", Network: "op-test", Limiter: limiter}
+	m := &stubVmMetrics{}
+	executor := NewExecutor(testlog.Logger(t, log.LevelInfo), m, cfg, NewOpProgramServerExecutor(), "pre.json", utils.LocalGameInputs{L2BlockNumber: big.NewInt(1)})
+	executor.selectSnapshot = func(logger log.Logger, dir string, absolutePreState string, i uint64, binary bool) (string, error) {
+		return "starting.json", nil
+	}
+	executed := false
+	executor.cmdExecutor = func(ctx context.Context, l log.Logger, b string, a ...string) error {
+		executed = true
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = executor.GenerateProof(ctx, t.TempDir(), 10)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.False(t, executed, "should not execute vm while limiter is full")
+	require.Zero(t, m.queueTimeRecordCount)
+
+	release(0)
+	require.NoError(t, executor.GenerateProof(context.Background(), t.TempDir(), 10))
+	require.True(t, executed)
+	require.Equal(t, 1, m.queueTimeRecordCount, "Should record vm queue time")
+}
+
 type stubVmMetrics struct {
 	metrics.NoopMetricsImpl
 	executionTimeRecordCount int
+	queueTimeRecordCount     int
+}
+
+func (c *stubVmMetrics) RecordVmQueueTime(_ string, _ time.Duration) {
+	c.queueTimeRecordCount++
 }
 
 func (c *stubVmMetrics) RecordVmExecutionTime(_ string, _ time.Duration) {
@@ -0,0 +1,167 @@
+package vm
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+type priorityKey struct{}
+
+// priority orders waiting VM executions by the deadline by which their results are needed.
+type priority struct {
+	set      bool
+	deadline time.Time
+}
+
+// rank returns 0 for executions with a known deadline, 1 for those with an unknown deadline and 2 for those without
+// a priority.
+func (p priority) rank() int {
+	if !p.set {
+		return 2
+	}
+	if p.deadline.IsZero() {
+		return 1
+	}
+	return 0
+}
+
+// WithPriority returns a context that prioritises the VM executions it is used for by the deadline by which their
+// results are needed. Executions with earlier deadlines start first, followed by those with an unknown (zero) deadline
+// and then executions without a priority, such as background validation of traces.
+func WithPriority(ctx context.Context, deadline time.Time) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority{set: true, deadline: deadline})
+}
+
+func priorityFromContext(ctx context.Context) priority {
+	p, _ := ctx.Value(priorityKey{}).(priority)
+	return p
+}
+
+// ExecutionLimiter limits the CPUs and memory used by concurrent VM executions, shared by all VMs.
+// Executions that would exceed the limits wait, and the waiting execution with the highest priority starts first
+// when resources are released. Lower priority executions don't start ahead of it, even if they would fit.
+//
+// Each execution is assumed to use one CPU and the most memory used by previous executions of the same VM, or the
+// memory estimate until the VM has been executed. An execution that exceeds the limits on its own starts when no other
+// executions are running.
+type ExecutionLimiter struct {
+	maxCPUs        uint
+	maxMemory      uint64
+	memoryEstimate uint64
+
+	mu         sync.Mutex
+	cpus       uint
+	memory     uint64
+	memoryUsed map[string]uint64
+	waiting    []*waiter
+	nextSeq    uint64
+}
+
+type waiter struct {
+	priority priority
+	seq      uint64
+	memory   uint64
+	ready    chan struct{}
+}
+
+// NewExecutionLimiter creates a limiter for up to maxCPUs concurrent executions using up to maxMemory bytes.
+// A limit of 0 disables that limit.
+func NewExecutionLimiter(maxCPUs uint, maxMemory uint64, memoryEstimate uint64) *ExecutionLimiter {
+	return &ExecutionLimiter{
+		maxCPUs:        maxCPUs,
+		maxMemory:      maxMemory,
+		memoryEstimate: memoryEstimate,
+		memoryUsed:     make(map[string]uint64),
+	}
+}
+
+// Acquire waits until the resources to execute the VM are available and reserves them.
+// The returned function releases the resources and must be called once the execution completes, with the memory it
+// used or 0 if unknown. A nil limiter doesn't limit executions.
+func (l *ExecutionLimiter) Acquire(ctx context.Context, vmType string) (func(memoryUsed uint64), error) {
+	if l == nil {
+		return func(uint64) {}, nil
+	}
+	l.mu.Lock()
+	w := &waiter{
+		priority: priorityFromContext(ctx),
+		seq:      l.nextSeq,
+		memory:   l.estimate(vmType),
+		ready:    make(chan struct{}),
+	}
+	l.nextSeq++
+	l.waiting = append(l.waiting, w)
+	l.startWaiting()
+	l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		select {
+		case <-w.ready:
+			// Started while being cancelled so give the resources back.
+			l.release(w.memory)
+		default:
+			l.waiting = slices.DeleteFunc(l.waiting, func(o *waiter) bool { return o == w })
+			l.startWaiting()
+		}
+		return nil, ctx.Err()
+	}
+	var once sync.Once
+	return func(memoryUsed uint64) {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			l.memoryUsed[vmType] = max(l.memoryUsed[vmType], memoryUsed)
+			l.release(w.memory)
+		})
+	}, nil
+}
+
+func (l *ExecutionLimiter) estimate(vmType string) uint64 {
+	if used := l.memoryUsed[vmType]; used != 0 {
+		return used
+	}
+	return l.memoryEstimate
+}
+
+func (l *ExecutionLimiter) release(memory uint64) {
+	l.cpus--
+	l.memory -= memory
+	l.startWaiting()
+}
+
+// startWaiting starts waiting executions in priority order while there are resources available for them.
+func (l *ExecutionLimiter) startWaiting() {
+	slices.SortFunc(l.waiting, func(a, b *waiter) int {
+		if c := cmp.Compare(a.priority.rank(), b.priority.rank()); c != 0 {
+			return c
+		}
+		if c := a.priority.deadline.Compare(b.priority.deadline); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.seq, b.seq)
+	})
+	for len(l.waiting) > 0 && l.fits(l.waiting[0].memory) {
+		w := l.waiting[0]
+		l.waiting = l.waiting[1:]
+		l.cpus++
+		l.memory += w.memory
+		close(w.ready)
+	}
+}
+
+func (l *ExecutionLimiter) fits(memory uint64) bool {
+	if l.cpus == 0 {
+		return true
+	}
+	if l.maxCPUs != 0 && l.cpus >= l.maxCPUs {
+		return false
+	}
+	return l.maxMemory == 0 || l.memory+memory <= l.maxMemory
+}
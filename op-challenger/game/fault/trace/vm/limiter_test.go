@@ -0,0 +1,162 @@
+package vm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionLimiter(t *testing.T) {
+	t.Run("NilLimiterDoesNotLimit", func(t *testing.T) {
+		var l *ExecutionLimiter
+		for i := 0; i < 5; i++ {
+			release, err := l.Acquire(context.Background(), "cannon")
+			require.NoError(t, err)
+			defer release(0)
+		}
+	})
+
+	t.Run("LimitCPUs", func(t *testing.T) {
+		l := NewExecutionLimiter(2, 0, 0)
+		release1 := acquireNow(t, l, context.Background(), "cannon")
+		acquireNow(t, l, context.Background(), "asterisc")
+
+		third := acquireAsync(l, context.Background(), "cannon")
+		requireWaiting(t, third)
+		release1(0)
+		requireStarted(t, third)
+	})
+
+	t.Run("LimitMemoryWithEstimate", func(t *testing.T) {
+		l := NewExecutionLimiter(0, 5, 2)
+		release1 := acquireNow(t, l, context.Background(), "cannon")
+		acquireNow(t, l, context.Background(), "cannon")
+
+		third := acquireAsync(l, context.Background(), "cannon")
+		requireWaiting(t, third)
+		release1(0)
+		requireStarted(t, third)
+	})
+
+	t.Run("LimitMemoryWithMemoryUsed", func(t *testing.T) {
+		l := NewExecutionLimiter(0, 5, 1)
+		release := acquireNow(t, l, context.Background(), "cannon")
+		release(3)
+
+		// Uses the memory used by the previous execution of the same VM rather than the estimate
+		release = acquireNow(t, l, context.Background(), "cannon")
+		acquireNow(t, l, context.Background(), "asterisc")
+		second := acquireAsync(l, context.Background(), "cannon")
+		requireWaiting(t, second)
+		release(2) // Keeps the most memory used
+		requireStarted(t, second)
+		other := acquireAsync(l, context.Background(), "cannon")
+		requireWaiting(t, other)
+	})
+
+	t.Run("StartLargeExecutionWhenNothingRunning", func(t *testing.T) {
+		l := NewExecutionLimiter(0, 5, 10)
+		release := acquireNow(t, l, context.Background(), "cannon")
+		second := acquireAsync(l, context.Background(), "cannon")
+		requireWaiting(t, second)
+		release(0)
+		requireStarted(t, second)
+	})
+
+	t.Run("StartByPriority", func(t *testing.T) {
+		l := NewExecutionLimiter(1, 0, 0)
+		now := time.Unix(10000, 0)
+		release := acquireNow(t, l, context.Background(), "cannon")
+
+		background := acquireAsync(l, context.Background(), "cannon")
+		requireWaiting(t, background)
+		unknownDeadline := acquireAsync(l, WithPriority(context.Background(), time.Time{}), "cannon")
+		requireWaiting(t, unknownDeadline)
+		lateDeadline := acquireAsync(l, WithPriority(context.Background(), now.Add(time.Hour)), "cannon")
+		requireWaiting(t, lateDeadline)
+		earlyDeadline := acquireAsync(l, WithPriority(context.Background(), now), "cannon")
+		requireWaiting(t, earlyDeadline)
+
+		for _, next := range []chan acquired{earlyDeadline, lateDeadline, unknownDeadline, background} {
+			release(0)
+			release = requireStarted(t, next)
+		}
+	})
+
+	t.Run("DoNotStartLowerPriorityAhead", func(t *testing.T) {
+		l := NewExecutionLimiter(0, 5, 2)
+		release := acquireNow(t, l, context.Background(), "cannon")
+		release(4)
+		release = acquireNow(t, l, context.Background(), "asterisc")
+
+		large := acquireAsync(l, WithPriority(context.Background(), time.Unix(1, 0)), "cannon")
+		requireWaiting(t, large)
+		small := acquireAsync(l, context.Background(), "asterisc")
+		requireWaiting(t, small)
+
+		release(0)
+		release = requireStarted(t, large)
+		requireWaiting(t, small)
+		release(0)
+		requireStarted(t, small)
+	})
+
+	t.Run("CancelWhileWaiting", func(t *testing.T) {
+		l := NewExecutionLimiter(1, 0, 0)
+		release := acquireNow(t, l, context.Background(), "cannon")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancelled := acquireAsync(l, WithPriority(ctx, time.Unix(1, 0)), "cannon")
+		requireWaiting(t, cancelled)
+		other := acquireAsync(l, context.Background(), "cannon")
+		requireWaiting(t, other)
+
+		cancel()
+		result := <-cancelled
+		require.ErrorIs(t, result.err, context.Canceled)
+		requireWaiting(t, other)
+		release(0)
+		requireStarted(t, other)
+	})
+}
+
+type acquired struct {
+	release func(uint64)
+	err     error
+}
+
+func acquireNow(t *testing.T, l *ExecutionLimiter, ctx context.Context, vmType string) func(uint64) {
+	release, err := l.Acquire(ctx, vmType)
+	require.NoError(t, err)
+	return release
+}
+
+func acquireAsync(l *ExecutionLimiter, ctx context.Context, vmType string) chan acquired {
+	result := make(chan acquired, 1)
+	go func() {
+		release, err := l.Acquire(ctx, vmType)
+		result <- acquired{release: release, err: err}
+	}()
+	return result
+}
+
+func requireWaiting(t *testing.T, result chan acquired) {
+	select {
+	case <-result:
+		t.Fatal("should not have started")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func requireStarted(t *testing.T, result chan acquired) func(uint64) {
+	select {
+	case r := <-result:
+		require.NoError(t, r.err)
+		return r.release
+	case <-time.After(10 * time.Second):
+		t.Fatal("should have started")
+		return nil
+	}
+}
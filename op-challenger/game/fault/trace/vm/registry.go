@@ -0,0 +1,75 @@
+package vm
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// TraceProvider provides the execution traces of a VM that isn't built in to the challenger, such as a new version
+// of asterisc or an alternative FPVM, so it can be added for a new game type without changing the challenger.
+// The VM is configured by the VM config for its trace type, and is played in output root games like cannon.
+type TraceProvider interface {
+	// TraceType is the trace type that enables the VM.
+	TraceType() types.TraceType
+	// GameType is the type of the games played with the VM.
+	GameType() types.GameType
+	// StateConverter converts the VM state snapshots to proofs.
+	StateConverter() StateConverter
+	// OracleServerExecutor builds the command to run the pre-image oracle server for the VM.
+	OracleServerExecutor() OracleServerExecutor
+	// NewTraceProvider creates the trace provider for a VM execution trace, from the prestate to the local inputs.
+	NewTraceProvider(
+		logger log.Logger,
+		m Metricer,
+		cfg Config,
+		serverExecutor OracleServerExecutor,
+		prestateProvider types.PrestateProvider,
+		prestate string,
+		localInputs utils.LocalGameInputs,
+		dir string,
+		gameDepth types.Depth,
+	) types.TraceProvider
+}
+
+var (
+	providersLock sync.RWMutex
+	providers     = make(map[types.TraceType]TraceProvider)
+)
+
+// RegisterTraceProvider adds the trace type of the provider and uses the provider for games of its game type.
+// Providers must be registered before the challenger config is parsed, usually from an init function.
+func RegisterTraceProvider(provider TraceProvider) error {
+	providersLock.Lock()
+	defer providersLock.Unlock()
+	if err := types.RegisterTraceType(provider.TraceType(), provider.GameType()); err != nil {
+		return err
+	}
+	providers[provider.TraceType()] = provider
+	return nil
+}
+
+// LookupTraceProvider returns the registered provider for the trace type, if any.
+func LookupTraceProvider(traceType types.TraceType) (TraceProvider, bool) {
+	providersLock.RLock()
+	defer providersLock.RUnlock()
+	provider, ok := providers[traceType]
+	return provider, ok
+}
+
+// TraceProviders returns the registered providers, ordered by game type.
+func TraceProviders() []TraceProvider {
+	providersLock.RLock()
+	defer providersLock.RUnlock()
+	result := make([]TraceProvider, 0, len(providers))
+	for _, provider := range providers {
+		result = append(result, provider)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].GameType() < result[j].GameType()
+	})
+	return result
+}
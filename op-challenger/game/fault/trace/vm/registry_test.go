@@ -0,0 +1,53 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/stretchr/testify/require"
+)
+
+// Registered once, as registered trace types can't be removed
+var (
+	testProviderB = registerTestProvider("test-vm-b", 101)
+	testProviderA = registerTestProvider("test-vm-a", 100)
+)
+
+type stubTraceProvider struct {
+	TraceProvider
+	traceType types.TraceType
+	gameType  types.GameType
+}
+
+func (s *stubTraceProvider) TraceType() types.TraceType {
+	return s.traceType
+}
+
+func (s *stubTraceProvider) GameType() types.GameType {
+	return s.gameType
+}
+
+func registerTestProvider(traceType types.TraceType, gameType types.GameType) TraceProvider {
+	provider := &stubTraceProvider{traceType: traceType, gameType: gameType}
+	if err := RegisterTraceProvider(provider); err != nil {
+		panic(err)
+	}
+	return provider
+}
+
+func TestRegisterTraceProvider(t *testing.T) {
+	provider, ok := LookupTraceProvider("test-vm-a")
+	require.True(t, ok)
+	require.Same(t, testProviderA, provider)
+	_, ok = LookupTraceProvider(types.TraceTypeCannon)
+	require.False(t, ok, "built in trace types are not registered providers")
+
+	require.True(t, types.ValidTraceType("test-vm-a"))
+	require.Equal(t, types.GameType(100), types.TraceType("test-vm-a").GameType())
+	require.Equal(t, []TraceProvider{testProviderA, testProviderB}, TraceProviders(), "should order by game type")
+
+	err := RegisterTraceProvider(&stubTraceProvider{traceType: "test-vm-a", gameType: 102})
+	require.ErrorIs(t, err, types.ErrTraceTypeRegistered)
+	_, ok = LookupTraceProvider("test-vm-c")
+	require.False(t, ok)
+}
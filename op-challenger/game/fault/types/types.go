@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"slices"
+	"sync"
 	"time"
 
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
@@ -17,8 +19,11 @@ import (
 )
 
 var (
-	ErrGameDepthReached   = errors.New("game depth reached")
-	ErrL2BlockNumberValid = errors.New("l2 block number is valid")
+	ErrGameDepthReached     = errors.New("game depth reached")
+	ErrL2BlockNumberValid   = errors.New("l2 block number is valid")
+	ErrTraceTypeRegistered  = errors.New("trace type already registered")
+	ErrGameTypeRegistered   = errors.New("game type already registered")
+	ErrInvalidTraceTypeName = errors.New("invalid trace type name")
 )
 
 type GameType uint32
@@ -52,6 +57,9 @@ func (t GameType) String() string {
 	case AlphabetGameType:
 		return "alphabet"
 	default:
+		if traceType, ok := registeredTraceType(t); ok {
+			return traceType.String()
+		}
 		return fmt.Sprintf("<invalid: %d>", t)
 	}
 }
@@ -67,8 +75,68 @@ const (
 	TraceTypePermissioned TraceType = "permissioned"
 )
 
+// TraceTypes are the trace types built in to the challenger. Trace types added with RegisterTraceType are not included.
 var TraceTypes = []TraceType{TraceTypeAlphabet, TraceTypeCannon, TraceTypePermissioned, TraceTypeAsterisc, TraceTypeAsteriscKona, TraceTypeFast}
 
+var (
+	registryLock      sync.RWMutex
+	registeredTypes   = make(map[TraceType]GameType)
+	registeredByGames = make(map[GameType]TraceType)
+)
+
+// RegisterTraceType adds a trace type that plays the given game type, for VMs that aren't built in to the challenger.
+// Trace types must be registered before the challenger config is parsed.
+func RegisterTraceType(traceType TraceType, gameType GameType) error {
+	if traceType == "" {
+		return ErrInvalidTraceTypeName
+	}
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if _, ok := registeredTypes[traceType]; ok || slices.Contains(TraceTypes, traceType) {
+		return fmt.Errorf("%w: %v", ErrTraceTypeRegistered, traceType)
+	}
+	if _, ok := registeredByGames[gameType]; ok || gameType == UnknownGameType || builtInGameType(gameType) {
+		return fmt.Errorf("%w: %d", ErrGameTypeRegistered, gameType)
+	}
+	registeredTypes[traceType] = gameType
+	registeredByGames[gameType] = traceType
+	return nil
+}
+
+// RegisteredTraceTypes returns the trace types added with RegisterTraceType, in no particular order.
+func RegisteredTraceTypes() []TraceType {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	traceTypes := make([]TraceType, 0, len(registeredTypes))
+	for t := range registeredTypes {
+		traceTypes = append(traceTypes, t)
+	}
+	return traceTypes
+}
+
+func registeredTraceType(gameType GameType) (TraceType, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	traceType, ok := registeredByGames[gameType]
+	return traceType, ok
+}
+
+func registeredGameType(traceType TraceType) (GameType, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	gameType, ok := registeredTypes[traceType]
+	return gameType, ok
+}
+
+func builtInGameType(gameType GameType) bool {
+	for _, t := range TraceTypes {
+		if t.GameType() == gameType {
+			return true
+		}
+	}
+	return false
+}
+
 func (t TraceType) String() string {
 	return string(t)
 }
@@ -93,7 +161,8 @@ func ValidTraceType(value TraceType) bool {
 			return true
 		}
 	}
-	return false
+	_, ok := registeredGameType(value)
+	return ok
 }
 
 func (t TraceType) GameType() GameType {
@@ -111,6 +180,9 @@ func (t TraceType) GameType() GameType {
 	case TraceTypeAlphabet:
 		return AlphabetGameType
 	default:
+		if gameType, ok := registeredGameType(t); ok {
+			return gameType
+		}
 		return UnknownGameType
 	}
 }
@@ -60,3 +60,30 @@ func TestIsRootPosition(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterTraceType(t *testing.T) {
+	traceType := TraceType("test-vm")
+	gameType := GameType(42)
+	t.Cleanup(func() {
+		delete(registeredTypes, traceType)
+		delete(registeredByGames, gameType)
+	})
+	require.False(t, ValidTraceType(traceType))
+
+	require.NoError(t, RegisterTraceType(traceType, gameType))
+	require.True(t, ValidTraceType(traceType))
+	require.Equal(t, gameType, traceType.GameType())
+	require.Equal(t, "test-vm", gameType.String())
+	require.Contains(t, RegisteredTraceTypes(), traceType)
+	require.NotContains(t, TraceTypes, traceType, "should not add to built in trace types")
+
+	var parsed TraceType
+	require.NoError(t, parsed.Set("test-vm"))
+	require.Equal(t, traceType, parsed)
+
+	require.ErrorIs(t, RegisterTraceType(traceType, GameType(43)), ErrTraceTypeRegistered)
+	require.ErrorIs(t, RegisterTraceType(TraceTypeCannon, GameType(43)), ErrTraceTypeRegistered)
+	require.ErrorIs(t, RegisterTraceType("other-vm", gameType), ErrGameTypeRegistered)
+	require.ErrorIs(t, RegisterTraceType("other-vm", AsteriscGameType), ErrGameTypeRegistered)
+	require.ErrorIs(t, RegisterTraceType("", GameType(43)), ErrInvalidTraceTypeName)
+}
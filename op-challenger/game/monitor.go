@@ -41,6 +41,10 @@ type claimer interface {
 	Schedule(blockNumber uint64, games []types.GameMetadata) error
 }
 
+type participationFilter interface {
+	Filter(ctx context.Context, blockHash common.Hash, games []types.GameMetadata) []types.GameMetadata
+}
+
 type gameMonitor struct {
 	logger       log.Logger
 	clock        RWClock
@@ -50,6 +54,7 @@ type gameMonitor struct {
 	gameWindow   time.Duration
 	claimer      claimer
 	allowedGames []common.Address
+	filter       participationFilter
 	l1HeadsSub   ethereum.Subscription
 	l1Source     *headSource
 	runState     sync.Mutex
@@ -76,6 +81,7 @@ func newGameMonitor(
 	gameWindow time.Duration,
 	claimer claimer,
 	allowedGames []common.Address,
+	filter participationFilter,
 	l1Source MinimalSubscriber,
 ) *gameMonitor {
 	return &gameMonitor{
@@ -87,6 +93,7 @@ func newGameMonitor(
 		gameWindow:   gameWindow,
 		claimer:      claimer,
 		allowedGames: allowedGames,
+		filter:       filter,
 		l1Source:     &headSource{inner: l1Source},
 	}
 }
@@ -120,6 +127,8 @@ func (m *gameMonitor) progressGames(ctx context.Context, blockHash common.Hash,
 	if err := m.claimer.Schedule(blockNumber, gamesToPlay); err != nil {
 		return fmt.Errorf("failed to schedule bond claims: %w", err)
 	}
+	// Bonds are claimed from all allowed games, as the participation policy may have changed since they were played
+	gamesToPlay = m.filter.Filter(ctx, blockHash, gamesToPlay)
 	if err := m.scheduler.Schedule(gamesToPlay, blockNumber); errors.Is(err, scheduler.ErrBusy) {
 		m.logger.Info("Scheduler still busy with previous update")
 	} else if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"slices"
 	"sync"
 	"testing"
 	"time"
@@ -142,6 +143,20 @@ func TestMonitorOnlyScheduleSpecifiedGame(t *testing.T) {
 	require.Equal(t, 1, stubClaimer.scheduledGames)
 }
 
+func TestMonitorOnlyScheduleParticipatingGames(t *testing.T) {
+	addr1 := common.Address{0xaa}
+	addr2 := common.Address{0xbb}
+	monitor, source, sched, _, _, stubClaimer := setupMonitorTest(t, []common.Address{})
+	monitor.filter = &stubParticipationFilter{skip: []common.Address{addr1}}
+	source.games = []types.GameMetadata{newFDG(addr1, 9999), newFDG(addr2, 9999)}
+
+	require.NoError(t, monitor.progressGames(context.Background(), common.Hash{0x01}, 0))
+
+	require.Len(t, sched.Scheduled(), 1)
+	require.Equal(t, []common.Address{addr2}, sched.Scheduled()[0])
+	require.Equal(t, 2, stubClaimer.scheduledGames, "should claim bonds from games not played")
+}
+
 func newFDG(proxy common.Address, timestamp uint64) types.GameMetadata {
 	return types.GameMetadata{
 		Proxy:     proxy,
@@ -168,6 +183,7 @@ func setupMonitorTest(
 		time.Duration(0),
 		stubClaimer,
 		allowedGames,
+		&stubParticipationFilter{},
 		mockHeadSource,
 	)
 	return monitor, source, sched, mockHeadSource, preimages, stubClaimer
@@ -249,6 +265,20 @@ func (s *stubGameSource) GetGamesAtOrAfter(
 	return s.games, nil
 }
 
+type stubParticipationFilter struct {
+	skip []common.Address
+}
+
+func (s *stubParticipationFilter) Filter(_ context.Context, _ common.Hash, games []types.GameMetadata) []types.GameMetadata {
+	var result []types.GameMetadata
+	for _, game := range games {
+		if !slices.Contains(s.skip, game.Proxy) {
+			result = append(result, game)
+		}
+	}
+	return result
+}
+
 type stubScheduler struct {
 	sync.Mutex
 	scheduled [][]common.Address
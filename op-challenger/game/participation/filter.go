@@ -0,0 +1,226 @@
+package participation
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type GameContract interface {
+	GetGameMetadata(ctx context.Context, block rpcblock.Block) (contracts.GameMetadata, error)
+	GetAllClaims(ctx context.Context, block rpcblock.Block) ([]faultTypes.Claim, error)
+}
+
+type ContractCreator func(game types.GameMetadata) (GameContract, error)
+
+type decision int
+
+const (
+	undecided decision = iota
+	accepted
+	rejected
+)
+
+// Filter selects the games to play according to the participation policy.
+// Decisions to play or not play a game are kept, except for games skipped because the maximum number of games
+// are already being played, which are reconsidered when games are resolved.
+type Filter struct {
+	logger          log.Logger
+	clock           faultTypes.ClockReader
+	policy          Policy
+	contractCreator ContractCreator
+	claimants       []common.Address
+
+	decisions map[common.Address]decision
+	// inProgress is the set of accepted games that weren't resolved when last checked
+	inProgress map[common.Address]bool
+	contracts  map[common.Address]GameContract
+}
+
+func NewFilter(logger log.Logger, cl faultTypes.ClockReader, policy Policy, contractCreator ContractCreator, claimants ...common.Address) *Filter {
+	return &Filter{
+		logger:          logger,
+		clock:           cl,
+		policy:          policy,
+		contractCreator: contractCreator,
+		claimants:       claimants,
+		decisions:       make(map[common.Address]decision),
+		inProgress:      make(map[common.Address]bool),
+		contracts:       make(map[common.Address]GameContract),
+	}
+}
+
+// Filter returns the games to play, out of the games available at the block.
+func (f *Filter) Filter(ctx context.Context, blockHash common.Hash, games []types.GameMetadata) []types.GameMetadata {
+	if f.policy.Unrestricted() {
+		return games
+	}
+	block := rpcblock.ByHash(blockHash)
+	f.prune(games)
+
+	// Update the status of the games being played first, so resolved games no longer count towards the limit
+	for _, game := range games {
+		if f.decisions[game.Proxy] != accepted || !f.inProgress[game.Proxy] {
+			continue
+		}
+		contract, err := f.contract(game)
+		if err != nil {
+			f.logger.Warn("Failed to create contract to check game status", "game", game.Proxy, "err", err)
+			continue
+		}
+		metadata, err := contract.GetGameMetadata(ctx, block)
+		if err != nil {
+			f.logger.Warn("Failed to check game status", "game", game.Proxy, "err", err)
+			continue
+		}
+		if metadata.Status != types.GameStatusInProgress {
+			delete(f.inProgress, game.Proxy)
+		}
+	}
+
+	// Consider new games in the order they were created
+	sorted := slices.Clone(games)
+	slices.SortFunc(sorted, func(a, b types.GameMetadata) int {
+		return cmp.Compare(a.Index, b.Index)
+	})
+	for _, game := range sorted {
+		if f.decisions[game.Proxy] != undecided {
+			continue
+		}
+		if err := f.decide(ctx, block, game); err != nil {
+			f.logger.Warn("Failed to check if game should be played", "game", game.Proxy, "err", err)
+		}
+	}
+
+	var result []types.GameMetadata
+	for _, game := range games {
+		if f.decisions[game.Proxy] == accepted {
+			result = append(result, game)
+		}
+	}
+	return result
+}
+
+func (f *Filter) decide(ctx context.Context, block rpcblock.Block, game types.GameMetadata) error {
+	now := f.clock.Now()
+	if f.policy.MaxGameAge != 0 && now.Sub(time.Unix(int64(game.Timestamp), 0)) > f.policy.MaxGameAge {
+		f.logger.Debug("Not playing game older than max game age", "game", game.Proxy, "timestamp", game.Timestamp)
+		f.decisions[game.Proxy] = rejected
+		return nil
+	}
+	contract, err := f.contract(game)
+	if err != nil {
+		return fmt.Errorf("failed to create contract: %w", err)
+	}
+	metadata, err := contract.GetGameMetadata(ctx, block)
+	if err != nil {
+		return fmt.Errorf("failed to load game metadata: %w", err)
+	}
+	claims, err := contract.GetAllClaims(ctx, block)
+	if err != nil {
+		return fmt.Errorf("failed to load claims: %w", err)
+	}
+	inProgress := metadata.Status == types.GameStatusInProgress
+	if f.participating(claims) {
+		// Keep playing games the challenger has already made claims in, e.g. before restarting
+		f.accept(game, inProgress)
+		return nil
+	}
+	if !inProgress {
+		f.logger.Debug("Not playing resolved game", "game", game.Proxy, "status", metadata.Status)
+		f.decisions[game.Proxy] = rejected
+		return nil
+	}
+	if len(claims) == 0 {
+		return errors.New("no claims in game")
+	}
+	if proposer := claims[0].Claimant; !f.policy.AllowedProposer(proposer) {
+		f.logger.Debug("Not playing game of proposer", "game", game.Proxy, "proposer", proposer)
+		f.decisions[game.Proxy] = rejected
+		return nil
+	}
+	maxClockDuration := time.Duration(metadata.MaxClockDuration) * time.Second
+	if remaining := remainingClock(now, maxClockDuration, claims); remaining < f.policy.MinRemainingClock {
+		f.logger.Debug("Not playing game with too little clock remaining", "game", game.Proxy, "remaining", remaining)
+		f.decisions[game.Proxy] = rejected
+		return nil
+	}
+	if f.policy.MaxGames != 0 && uint(len(f.inProgress)) >= f.policy.MaxGames {
+		// Not recorded so the game is reconsidered once other games are resolved
+		f.logger.Debug("Not playing game while max games are in progress", "game", game.Proxy, "inProgress", len(f.inProgress))
+		return nil
+	}
+	f.accept(game, true)
+	return nil
+}
+
+func (f *Filter) contract(game types.GameMetadata) (GameContract, error) {
+	if contract, ok := f.contracts[game.Proxy]; ok {
+		return contract, nil
+	}
+	contract, err := f.contractCreator(game)
+	if err != nil {
+		return nil, err
+	}
+	f.contracts[game.Proxy] = contract
+	return contract, nil
+}
+
+func (f *Filter) accept(game types.GameMetadata, inProgress bool) {
+	f.logger.Info("Playing game", "game", game.Proxy, "inProgress", inProgress)
+	f.decisions[game.Proxy] = accepted
+	if inProgress {
+		f.inProgress[game.Proxy] = true
+	}
+}
+
+func (f *Filter) participating(claims []faultTypes.Claim) bool {
+	for _, claim := range claims {
+		if slices.Contains(f.claimants, claim.Claimant) {
+			return true
+		}
+	}
+	return false
+}
+
+// prune drops the decisions for games that are no longer available, e.g. because they're outside the game window.
+func (f *Filter) prune(games []types.GameMetadata) {
+	available := make(map[common.Address]bool, len(games))
+	for _, game := range games {
+		available[game.Proxy] = true
+	}
+	for addr := range f.decisions {
+		if !available[addr] {
+			delete(f.decisions, addr)
+			delete(f.inProgress, addr)
+		}
+	}
+	for addr := range f.contracts {
+		if !available[addr] {
+			delete(f.contracts, addr)
+		}
+	}
+}
+
+// remainingClock returns the most time left to counter any of the claims.
+func remainingClock(now time.Time, maxClockDuration time.Duration, claims []faultTypes.Claim) time.Duration {
+	var remaining time.Duration
+	for _, claim := range claims {
+		var parent faultTypes.Claim
+		if !claim.IsRootPosition() {
+			parent = claims[claim.ParentContractIndex]
+		}
+		remaining = max(remaining, maxClockDuration-faultTypes.ChessClock(now, claim, parent))
+	}
+	return remaining
+}
@@ -0,0 +1,191 @@
+package participation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	now              = time.Unix(1_000_000, 0)
+	maxClockDuration = time.Hour
+	proposer         = common.Address{0x01}
+	otherProposer    = common.Address{0x02}
+	claimant         = common.Address{0xcc}
+	blockHash        = common.Hash{0xbb}
+)
+
+func TestUnrestrictedPolicyPlaysAllGames(t *testing.T) {
+	filter := NewFilter(testlog.Logger(t, log.LevelInfo), clock.NewDeterministicClock(now), Policy{}, func(game types.GameMetadata) (GameContract, error) {
+		t.Fatal("should not load games")
+		return nil, nil
+	})
+	games := []types.GameMetadata{{Proxy: common.Address{0xaa}}, {Proxy: common.Address{0xbb}}}
+	require.Equal(t, games, filter.Filter(context.Background(), blockHash, games))
+}
+
+func TestProposers(t *testing.T) {
+	policy := Policy{AllowedProposers: []common.Address{proposer, otherProposer}, DeniedProposers: []common.Address{otherProposer}}
+	filter, stubs := setupFilterTest(t, policy)
+	games := []types.GameMetadata{
+		stubs.game(1, proposer),
+		stubs.game(2, otherProposer),
+		stubs.game(3, common.Address{0x03}),
+	}
+	require.Equal(t, games[:1], filter.Filter(context.Background(), blockHash, games))
+}
+
+func TestMaxGameAge(t *testing.T) {
+	filter, stubs := setupFilterTest(t, Policy{MaxGameAge: 2 * time.Hour})
+	old := stubs.game(1, proposer)
+	old.Timestamp = uint64(now.Add(-3 * time.Hour).Unix())
+	recent := stubs.game(2, proposer)
+	require.Equal(t, []types.GameMetadata{recent}, filter.Filter(context.Background(), blockHash, []types.GameMetadata{old, recent}))
+	require.Zero(t, stubs.contracts[old.Proxy].loads, "should not load old games")
+}
+
+func TestMinRemainingClock(t *testing.T) {
+	filter, stubs := setupFilterTest(t, Policy{MinRemainingClock: 15 * time.Minute})
+	expiring := stubs.game(1, proposer)
+	stubs.contracts[expiring.Proxy].claims[0].Clock = faultTypes.NewClock(0, now.Add(-50*time.Minute))
+	countered := stubs.game(2, proposer)
+	// The root claim can't be countered, but the counter claim can still be countered
+	stubs.contracts[countered.Proxy].claims = []faultTypes.Claim{
+		rootClaim(proposer, faultTypes.NewClock(0, now.Add(-2*time.Hour))),
+		counterClaim(otherProposer, faultTypes.NewClock(10*time.Minute, now.Add(-time.Minute))),
+	}
+	fresh := stubs.game(3, proposer)
+
+	games := []types.GameMetadata{expiring, countered, fresh}
+	require.Equal(t, games[1:], filter.Filter(context.Background(), blockHash, games))
+}
+
+func TestMaxGames(t *testing.T) {
+	filter, stubs := setupFilterTest(t, Policy{MaxGames: 2})
+	games := []types.GameMetadata{
+		stubs.game(3, proposer),
+		stubs.game(2, proposer),
+		stubs.game(1, proposer),
+	}
+	// The earliest games are played first
+	require.Equal(t, games[1:], filter.Filter(context.Background(), blockHash, games))
+	require.Equal(t, games[1:], filter.Filter(context.Background(), blockHash, games))
+
+	// Once a game is resolved, the next game is played. The resolved game is still played, to resolve claims.
+	stubs.contracts[games[2].Proxy].metadata.Status = types.GameStatusDefenderWon
+	require.Equal(t, games, filter.Filter(context.Background(), blockHash, games))
+	require.Equal(t, rpcblock.ByHash(blockHash), stubs.contracts[games[2].Proxy].block)
+}
+
+func TestKeepPlayingGamesWithClaimsFromClaimants(t *testing.T) {
+	filter, stubs := setupFilterTest(t, Policy{DeniedProposers: []common.Address{proposer}, MaxGames: 1})
+	first := stubs.game(1, otherProposer)
+	played := stubs.game(2, proposer)
+	stubs.contracts[played.Proxy].claims = append(stubs.contracts[played.Proxy].claims, counterClaim(claimant, faultTypes.NewClock(0, now)))
+	resolved := stubs.game(3, proposer)
+	stubs.contracts[resolved.Proxy].metadata.Status = types.GameStatusChallengerWon
+	stubs.contracts[resolved.Proxy].claims = append(stubs.contracts[resolved.Proxy].claims, counterClaim(claimant, faultTypes.NewClock(0, now)))
+
+	games := []types.GameMetadata{first, played, resolved}
+	require.Equal(t, games, filter.Filter(context.Background(), blockHash, games))
+}
+
+func TestDoNotPlayResolvedGames(t *testing.T) {
+	filter, stubs := setupFilterTest(t, Policy{MaxGames: 1})
+	resolved := stubs.game(1, proposer)
+	stubs.contracts[resolved.Proxy].metadata.Status = types.GameStatusDefenderWon
+	inProgress := stubs.game(2, proposer)
+	games := []types.GameMetadata{resolved, inProgress}
+	require.Equal(t, games[1:], filter.Filter(context.Background(), blockHash, games))
+}
+
+func TestRetryGamesThatFailToLoad(t *testing.T) {
+	filter, stubs := setupFilterTest(t, Policy{MaxGames: 1})
+	game := stubs.game(1, proposer)
+	stubs.contracts[game.Proxy].err = errors.New("boom")
+	require.Empty(t, filter.Filter(context.Background(), blockHash, []types.GameMetadata{game}))
+
+	stubs.contracts[game.Proxy].err = nil
+	require.Equal(t, []types.GameMetadata{game}, filter.Filter(context.Background(), blockHash, []types.GameMetadata{game}))
+}
+
+func TestForgetUnavailableGames(t *testing.T) {
+	filter, stubs := setupFilterTest(t, Policy{MaxGames: 1})
+	first := stubs.game(1, proposer)
+	second := stubs.game(2, proposer)
+	require.Equal(t, []types.GameMetadata{first}, filter.Filter(context.Background(), blockHash, []types.GameMetadata{first, second}))
+
+	// The first game is no longer available, e.g. because it's outside the game window, so no longer counts
+	require.Equal(t, []types.GameMetadata{second}, filter.Filter(context.Background(), blockHash, []types.GameMetadata{second}))
+	require.NotContains(t, filter.decisions, first.Proxy)
+	require.NotContains(t, filter.contracts, first.Proxy)
+}
+
+func setupFilterTest(t *testing.T, policy Policy) (*Filter, *stubContracts) {
+	stubs := &stubContracts{contracts: make(map[common.Address]*stubGameContract)}
+	creator := func(game types.GameMetadata) (GameContract, error) {
+		return stubs.contracts[game.Proxy], nil
+	}
+	filter := NewFilter(testlog.Logger(t, log.LevelInfo), clock.NewDeterministicClock(now), policy, creator, claimant)
+	return filter, stubs
+}
+
+type stubContracts struct {
+	contracts map[common.Address]*stubGameContract
+}
+
+// game creates an in progress game with only the root claim, created now.
+func (s *stubContracts) game(idx uint64, proposer common.Address) types.GameMetadata {
+	game := types.GameMetadata{Index: idx, Proxy: common.Address{0xaa, byte(idx)}, Timestamp: uint64(now.Unix())}
+	s.contracts[game.Proxy] = &stubGameContract{
+		metadata: contracts.GameMetadata{Status: types.GameStatusInProgress, MaxClockDuration: uint64(maxClockDuration.Seconds())},
+		claims:   []faultTypes.Claim{rootClaim(proposer, faultTypes.NewClock(0, now))},
+	}
+	return game
+}
+
+func rootClaim(claimant common.Address, clock faultTypes.Clock) faultTypes.Claim {
+	return faultTypes.Claim{
+		ClaimData: faultTypes.ClaimData{Position: faultTypes.RootPosition},
+		Claimant:  claimant,
+		Clock:     clock,
+	}
+}
+
+func counterClaim(claimant common.Address, clock faultTypes.Clock) faultTypes.Claim {
+	return faultTypes.Claim{
+		ClaimData:     faultTypes.ClaimData{Position: faultTypes.RootPosition.Attack()},
+		Claimant:      claimant,
+		Clock:         clock,
+		ContractIndex: 1,
+	}
+}
+
+type stubGameContract struct {
+	metadata contracts.GameMetadata
+	claims   []faultTypes.Claim
+	err      error
+	loads    int
+	block    rpcblock.Block
+}
+
+func (s *stubGameContract) GetGameMetadata(_ context.Context, block rpcblock.Block) (contracts.GameMetadata, error) {
+	s.loads++
+	s.block = block
+	return s.metadata, s.err
+}
+
+func (s *stubGameContract) GetAllClaims(_ context.Context, _ rpcblock.Block) ([]faultTypes.Claim, error) {
+	return s.claims, s.err
+}
@@ -0,0 +1,37 @@
+package participation
+
+import (
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Policy controls which games the challenger starts playing.
+// Once the challenger plays a game, it keeps playing it until the game is resolved, regardless of the policy.
+// The zero value plays all games.
+type Policy struct {
+	// AllowedProposers are the only proposers to play the games of. Games of any proposer are played if empty.
+	AllowedProposers []common.Address
+	// DeniedProposers are the proposers to not play the games of.
+	DeniedProposers []common.Address
+	// MaxGames is the maximum number of in progress games to play at once. There is no limit if it's 0.
+	MaxGames uint
+	// MinRemainingClock is the minimum time left to counter a claim in a game to start playing it.
+	MinRemainingClock time.Duration
+	// MaxGameAge is the maximum time since a game was created to start playing it. There is no limit if it's 0.
+	MaxGameAge time.Duration
+}
+
+// Unrestricted returns true if the policy plays all games.
+func (p Policy) Unrestricted() bool {
+	return len(p.AllowedProposers) == 0 && len(p.DeniedProposers) == 0 && p.MaxGames == 0 && p.MinRemainingClock == 0 && p.MaxGameAge == 0
+}
+
+// AllowedProposer returns true if the games of the proposer can be played.
+func (p Policy) AllowedProposer(proposer common.Address) bool {
+	if slices.Contains(p.DeniedProposers, proposer) {
+		return false
+	}
+	return len(p.AllowedProposers) == 0 || slices.Contains(p.AllowedProposers, proposer)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 
@@ -28,10 +29,28 @@ type gameState struct {
 	inflight              bool
 	lastProcessedBlockNum uint64
 	status                types.GameStatus
+
+	// created is the time the game was created, used to prioritise the game until its deadline is known.
+	created time.Time
+	// deadline is the time by which the game must next be progressed, as of its last progression.
+	deadline time.Time
+}
+
+// priority returns the time used to order the game's jobs, with earlier times being progressed first.
+// Games are prioritised by the deadline for their next move, so games with clocks closest to expiry are progressed
+// first. Games without a known deadline, such as new games, are prioritised by the time they were created, which is
+// always before the deadline of any move in them, so they are progressed promptly to find their deadline.
+func (s *gameState) priority() time.Time {
+	if s.deadline.IsZero() {
+		return s.created
+	}
+	return s.deadline
 }
 
 // coordinator manages the set of current games, queues games to be played (on separate worker threads) and
 // cleans up data files once a game is resolved.
+// Jobs wait in the pending queue until a worker is available, and the most urgent pending job is sent to the workers
+// first, so that urgent games scheduled later still progress before less urgent games that are pending.
 // All function calls must be made on the same thread.
 type coordinator struct {
 	// jobQueue is the outgoing queue for jobs being sent to workers for progression
@@ -46,6 +65,9 @@ type coordinator struct {
 	states       map[common.Address]*gameState
 	disk         DiskManager
 
+	// pending is the jobs waiting to be sent to workers. Pending games are considered to be in-flight.
+	pending []*job
+
 	allowInvalidPrestate bool
 
 	// lastScheduledBlockNum is the highest block number that the coordinator has seen and scheduled jobs.
@@ -53,16 +75,27 @@ type coordinator struct {
 }
 
 // schedule takes the current list of games to attempt to progress, filters out games that have previous
-// progressions already in-flight and schedules jobs to progress on the outbound jobQueue.
-// To avoid deadlock, it may process results from the inbound resultQueue while adding jobs to the outbound jobQueue.
+// progressions already in-flight and adds jobs to progress them to the pending queue. Pending jobs are then sent to
+// the outbound jobQueue, most urgent first, until the jobQueue is full. The remaining jobs are sent by dispatch.
 // Returns an error if a game couldn't be scheduled because of an error. It will continue attempting to progress
 // all games even if an error occurs with one game.
 func (c *coordinator) schedule(ctx context.Context, games []types.GameMetadata, blockNumber uint64) error {
-	// First remove any game states we no longer require
-	for addr, state := range c.states {
-		if !state.inflight && !slices.ContainsFunc(games, func(candidate types.GameMetadata) bool {
+	isRequired := func(addr common.Address) bool {
+		return slices.ContainsFunc(games, func(candidate types.GameMetadata) bool {
 			return candidate.Proxy == addr
-		}) {
+		})
+	}
+	// First remove any pending jobs and game states we no longer require
+	c.pending = slices.DeleteFunc(c.pending, func(j *job) bool {
+		if isRequired(j.addr) {
+			return false
+		}
+		c.states[j.addr].inflight = false
+		c.m.RecordGameUpdateCompleted()
+		return true
+	})
+	for addr, state := range c.states {
+		if !state.inflight && !isRequired(addr) {
 			delete(c.states, addr)
 		}
 	}
@@ -71,7 +104,7 @@ func (c *coordinator) schedule(ctx context.Context, games []types.GameMetadata,
 	var gamesChallengerWon int
 	var gamesDefenderWon int
 	var errs []error
-	var jobs []job
+	var jobs []*job
 	// Next collect all the jobs to schedule and ensure all games are recorded in the states map.
 	// Otherwise, results may start being processed before all games are recorded, resulting in existing
 	// data directories potentially being deleted for games that are required.
@@ -79,7 +112,7 @@ func (c *coordinator) schedule(ctx context.Context, games []types.GameMetadata,
 		if j, err := c.createJob(ctx, game, blockNumber); err != nil {
 			errs = append(errs, fmt.Errorf("failed to create job for game %v: %w", game.Proxy, err))
 		} else if j != nil {
-			jobs = append(jobs, *j)
+			jobs = append(jobs, j)
 			c.m.RecordGameUpdateScheduled()
 		}
 		state, ok := c.states[game.Proxy]
@@ -105,13 +138,45 @@ func (c *coordinator) schedule(ctx context.Context, games []types.GameMetadata,
 	c.lastScheduledBlockNum = blockNumber
 	c.m.RecordActedL1Block(lowestProcessedBlockNum)
 
-	// Finally, enqueue the jobs
-	for _, j := range jobs {
-		if err := c.enqueueJob(ctx, j); err != nil {
-			errs = append(errs, fmt.Errorf("failed to enqueue job for game %v: %w", j.addr, err))
+	// Finally, queue the jobs and send as many as possible to the workers
+	c.pending = append(c.pending, jobs...)
+	c.dispatch()
+	return errors.Join(errs...)
+}
+
+// nextJob returns the most urgent pending job, or nil if there are no pending jobs.
+// Jobs with the same priority are returned in the order they were scheduled.
+func (c *coordinator) nextJob() *job {
+	var next *job
+	for _, j := range c.pending {
+		if next == nil || c.states[j.addr].priority().Before(c.states[next.addr].priority()) {
+			next = j
+		}
+	}
+	return next
+}
+
+// jobSent removes a job that has been sent to the workers from the pending queue.
+func (c *coordinator) jobSent(sent *job) {
+	c.pending = slices.DeleteFunc(c.pending, func(j *job) bool {
+		return j == sent
+	})
+}
+
+// dispatch sends pending jobs to the jobQueue, most urgent first, until the jobQueue is full.
+func (c *coordinator) dispatch() {
+	for {
+		next := c.nextJob()
+		if next == nil {
+			return
+		}
+		select {
+		case c.jobQueue <- *next:
+			c.jobSent(next)
+		default:
+			return
 		}
 	}
-	return errors.Join(errs...)
 }
 
 // createJob updates the state for the specified game and returns the job to enqueue for it, if any
@@ -121,10 +186,17 @@ func (c *coordinator) createJob(ctx context.Context, game types.GameMetadata, bl
 	if !ok {
 		// This is the first time we're seeing this game, so its last processed block
 		// is the last block the coordinator processed (it didn't exist yet).
-		state = &gameState{lastProcessedBlockNum: c.lastScheduledBlockNum}
+		state = &gameState{
+			lastProcessedBlockNum: c.lastScheduledBlockNum,
+			created:               time.Unix(int64(game.Timestamp), 0),
+		}
 		c.states[game.Proxy] = state
 	}
 	if state.inflight {
+		if idx := slices.IndexFunc(c.pending, func(j *job) bool { return j.addr == game.Proxy }); idx >= 0 {
+			// The job hasn't started yet so will progress the game at the latest block anyway
+			c.pending[idx].block = blockNumber
+		}
 		c.logger.Debug("Not rescheduling already in-flight game", "game", game.Proxy)
 		return nil, nil
 	}
@@ -152,21 +224,6 @@ func (c *coordinator) createJob(ctx context.Context, game types.GameMetadata, bl
 	return newJob(blockNumber, game.Proxy, state.player, state.status), nil
 }
 
-func (c *coordinator) enqueueJob(ctx context.Context, j job) error {
-	for {
-		select {
-		case c.jobQueue <- j:
-			return nil
-		case result := <-c.resultQueue:
-			if err := c.processResult(result); err != nil {
-				c.logger.Error("Failed to process result", "err", err)
-			}
-		case <-ctx.Done():
-			return ctx.Err()
-		}
-	}
-}
-
 func (c *coordinator) processResult(j job) error {
 	state, ok := c.states[j.addr]
 	if !ok {
@@ -174,6 +231,7 @@ func (c *coordinator) processResult(j job) error {
 	}
 	state.inflight = false
 	state.status = j.status
+	state.deadline = j.deadline
 	state.lastProcessedBlockNum = j.block
 	c.deleteResolvedGameFiles()
 	c.m.RecordGameUpdateCompleted()
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
@@ -49,17 +50,22 @@ func TestSkipSchedulingInflightGames(t *testing.T) {
 	require.Len(t, workQueue, 1, "should not reschedule in-flight game")
 }
 
-func TestExitWhenContextDoneWhileSchedulingJob(t *testing.T) {
+func TestQueuePendingJobsWhenJobQueueFull(t *testing.T) {
 	// No space in buffer to schedule a job
-	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 0)
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 0)
 	gameAddr1 := common.Address{0xaa}
-	ctx, cancel := context.WithCancel(context.Background())
-	cancel() // Context is cancelled
+	ctx := context.Background()
 
-	// Should not block because the context is done.
-	err := c.schedule(ctx, asGames(gameAddr1), 0)
-	require.ErrorIs(t, err, context.Canceled)
-	require.Empty(t, workQueue, "should not have been able to schedule game")
+	// Should not block when the job can't be sent
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1), 0))
+	require.Empty(t, workQueue, "should not have been able to send job")
+	require.Len(t, games.created, 1, "should have created player")
+
+	next := c.nextJob()
+	require.NotNil(t, next, "should have pending job")
+	require.Equal(t, gameAddr1, next.addr)
+	c.jobSent(next)
+	require.Nil(t, c.nextJob(), "should not have pending jobs after job is sent")
 }
 
 func TestSchedule_PrestateValidationErrors(t *testing.T) {
@@ -125,8 +131,8 @@ func TestResultForUnknownGame(t *testing.T) {
 	require.ErrorIs(t, err, errUnknownGame)
 }
 
-func TestProcessResultsWhileJobQueueFull(t *testing.T) {
-	c, workQueue, resultQueue, games, disk, _ := setupCoordinatorTest(t, 0)
+func TestProcessResultsWhileJobsPending(t *testing.T) {
+	c, workQueue, _, games, disk, _ := setupCoordinatorTest(t, 1)
 	gameAddr1 := common.Address{0xaa}
 	gameAddr2 := common.Address{0xbb}
 	gameAddr3 := common.Address{0xcc}
@@ -137,34 +143,94 @@ func TestProcessResultsWhileJobQueueFull(t *testing.T) {
 	disk.DirForGame(gameAddr2)
 	disk.DirForGame(gameAddr3)
 
-	resultsSent := make(chan any)
-	go func() {
-		defer close(resultsSent)
-		// Process three jobs then exit
-		for i := 0; i < 3; i++ {
-			j := <-workQueue
-			resultQueue <- j
-		}
-	}()
-
 	// Even though work queue length is only 1, should be able to schedule all three games
-	// by reading and processing results
 	require.NoError(t, c.schedule(ctx, asGames(gameAddr1, gameAddr2, gameAddr3), 0))
 	require.Len(t, games.created, 3, "should have created 3 games")
-
-loop:
-	for {
-		select {
-		case <-resultQueue:
-			// Drain any remaining results
-		case <-resultsSent:
-			break loop
+	require.Len(t, workQueue, 1, "should send one job")
+	require.Len(t, c.pending, 2, "should queue remaining jobs")
+
+	// Process results while the remaining jobs are pending
+	for i := 0; i < 3; i++ {
+		var j job
+		if i == 0 {
+			j = <-workQueue
+		} else {
+			next := c.nextJob()
+			require.NotNil(t, next)
+			c.jobSent(next)
+			j = *next
 		}
+		require.NoError(t, c.processResult(j))
+		// Check that pre-existing directories weren't deleted.
+		require.Empty(t, disk.deletedDirs, "should not have deleted any directories")
 	}
+	require.Nil(t, c.nextJob(), "should not have pending jobs")
+}
 
-	// Check that pre-existing directories weren't deleted.
-	// This would fail if we start processing results before we've added all the required games to the state
-	require.Empty(t, disk.deletedDirs, "should not have deleted any directories")
+func TestSendMostUrgentJobFirst(t *testing.T) {
+	c, _, _, games, _, _ := setupCoordinatorTest(t, 0)
+	gameAddr1 := common.Address{0xaa}
+	gameAddr2 := common.Address{0xbb}
+	gameAddr3 := common.Address{0xcc}
+	ctx := context.Background()
+	gameList := []types.GameMetadata{
+		{Proxy: gameAddr1, Timestamp: 100},
+		{Proxy: gameAddr2, Timestamp: 200},
+		{Proxy: gameAddr3, Timestamp: 300},
+	}
+
+	// New games are prioritised by the time they were created
+	require.NoError(t, c.schedule(ctx, gameList, 0))
+	require.Equal(t, []common.Address{gameAddr1, gameAddr2, gameAddr3}, sendPendingJobs(c))
+
+	// Once progressed, games are prioritised by their next deadline
+	games.created[gameAddr1].DeadlineValue = time.Unix(5000, 0)
+	games.created[gameAddr2].DeadlineValue = time.Unix(3000, 0)
+	games.created[gameAddr3].DeadlineValue = time.Unix(4000, 0)
+	processPlayerResults(t, c, games, gameAddr1, gameAddr2, gameAddr3)
+	require.NoError(t, c.schedule(ctx, gameList, 1))
+	require.Equal(t, []common.Address{gameAddr2, gameAddr3, gameAddr1}, sendPendingJobs(c))
+
+	// Games without a deadline, such as games with no remaining clock time, fall back to the time they were created
+	games.created[gameAddr1].DeadlineValue = time.Time{}
+	processPlayerResults(t, c, games, gameAddr1, gameAddr2, gameAddr3)
+	require.NoError(t, c.schedule(ctx, gameList, 2))
+	require.Equal(t, []common.Address{gameAddr1, gameAddr2, gameAddr3}, sendPendingJobs(c))
+}
+
+func TestSendUrgentJobBeforeEarlierPendingJobs(t *testing.T) {
+	c, _, _, _, _, _ := setupCoordinatorTest(t, 0)
+	gameAddr1 := common.Address{0xaa}
+	gameAddr2 := common.Address{0xbb}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, []types.GameMetadata{{Proxy: gameAddr1, Timestamp: 200}}, 0))
+	// A later update includes a more urgent game while the first game's job is still pending
+	require.NoError(t, c.schedule(ctx, []types.GameMetadata{{Proxy: gameAddr1, Timestamp: 200}, {Proxy: gameAddr2, Timestamp: 100}}, 1))
+	require.Equal(t, []common.Address{gameAddr2, gameAddr1}, sendPendingJobs(c))
+}
+
+func TestUpdatePendingJobBlock(t *testing.T) {
+	c, _, _, _, _, _ := setupCoordinatorTest(t, 0)
+	gameAddr1 := common.Address{0xaa}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1), 1))
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1), 2))
+	require.Len(t, c.pending, 1, "should not schedule another job for the game")
+	require.Equal(t, uint64(2), c.nextJob().block, "should progress game at latest block")
+}
+
+func TestDropPendingJobsForRemovedGames(t *testing.T) {
+	c, _, _, _, _, _ := setupCoordinatorTest(t, 0)
+	gameAddr1 := common.Address{0xaa}
+	gameAddr2 := common.Address{0xbb}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1, gameAddr2), 0))
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr2), 1))
+	require.NotContains(t, c.states, gameAddr1, "should drop state for game 1")
+	require.Equal(t, []common.Address{gameAddr2}, sendPendingJobs(c))
 }
 
 func TestDeleteDataForResolvedGames(t *testing.T) {
@@ -392,6 +458,26 @@ func TestDropOldGameStates(t *testing.T) {
 	require.Contains(t, c.states, gameAddr4, "should create state for game 4")
 }
 
+// sendPendingJobs sends all pending jobs and returns the game addresses in the order their jobs were sent.
+func sendPendingJobs(c *coordinator) []common.Address {
+	var addrs []common.Address
+	for next := c.nextJob(); next != nil; next = c.nextJob() {
+		c.jobSent(next)
+		addrs = append(addrs, next.addr)
+	}
+	return addrs
+}
+
+// processPlayerResults processes a result for each game as if its player had progressed the game.
+func processPlayerResults(t *testing.T, c *coordinator, games *createdGames, addrs ...common.Address) {
+	for _, addr := range addrs {
+		player := games.created[addr]
+		j := newJob(0, addr, player, player.StatusValue)
+		j.deadline = player.Deadline()
+		require.NoError(t, c.processResult(*j))
+	}
+}
+
 func setupCoordinatorTest(t *testing.T, bufferSize int) (*coordinator, <-chan job, chan job, *createdGames, *stubDiskManager, *testlog.CapturingHandler) {
 	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
 	workQueue := make(chan job, bufferSize)
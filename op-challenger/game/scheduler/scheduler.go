@@ -40,9 +40,10 @@ type Scheduler struct {
 }
 
 func NewScheduler(logger log.Logger, m SchedulerMetricer, disk DiskManager, maxConcurrency uint, createPlayer PlayerCreator, allowInvalidPrestate bool) *Scheduler {
-	// Size job and results queues to be fairly small so backpressure is applied early
+	// The job queue is unbuffered so that the coordinator picks the most urgent pending job when a worker is available.
+	// Size the results queue to be fairly small so backpressure is applied early
 	// but with enough capacity to keep the workers busy
-	jobQueue := make(chan job, maxConcurrency*2)
+	jobQueue := make(chan job)
 	resultQueue := make(chan job, maxConcurrency*2)
 
 	// scheduleQueue has a size of 1 so backpressure quickly propagates to the caller
@@ -102,9 +103,19 @@ func (s *Scheduler) Schedule(games []types.GameMetadata, blockNumber uint64) err
 func (s *Scheduler) loop(ctx context.Context) {
 	defer s.wg.Done()
 	for {
+		// Only attempt to send a job when one is pending. Sending to a nil channel blocks forever.
+		var jobQueue chan<- job
+		var next job
+		nextJob := s.coordinator.nextJob()
+		if nextJob != nil {
+			jobQueue = s.jobQueue
+			next = *nextJob
+		}
 		select {
 		case <-ctx.Done():
 			return
+		case jobQueue <- next:
+			s.coordinator.jobSent(nextJob)
 		case blockGames := <-s.scheduleQueue:
 			if err := s.coordinator.schedule(ctx, blockGames.games, blockGames.blockNumber); err != nil {
 				s.logger.Error("Failed to schedule game updates", "err", err)
@@ -2,6 +2,7 @@ package test
 
 import (
 	"context"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -13,6 +14,7 @@ type StubGamePlayer struct {
 	StatusValue   types.GameStatus
 	Dir           string
 	PrestateErr   error
+	DeadlineValue time.Time
 }
 
 func (g *StubGamePlayer) ValidatePrestate(_ context.Context) error {
@@ -27,3 +29,7 @@ func (g *StubGamePlayer) ProgressGame(_ context.Context) types.GameStatus {
 func (g *StubGamePlayer) Status() types.GameStatus {
 	return g.StatusValue
 }
+
+func (g *StubGamePlayer) Deadline() time.Time {
+	return g.DeadlineValue
+}
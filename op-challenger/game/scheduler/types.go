@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"context"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -12,6 +13,9 @@ type GamePlayer interface {
 	ValidatePrestate(ctx context.Context) error
 	ProgressGame(ctx context.Context) types.GameStatus
 	Status() types.GameStatus
+	// Deadline returns the time by which the game must next be progressed to counter claims before their clocks
+	// expire, as of the last progression, or the zero time if unknown.
+	Deadline() time.Time
 }
 
 type DiskManager interface {
@@ -20,10 +24,11 @@ type DiskManager interface {
 }
 
 type job struct {
-	block  uint64
-	addr   common.Address
-	player GamePlayer
-	status types.GameStatus
+	block    uint64
+	addr     common.Address
+	player   GamePlayer
+	status   types.GameStatus
+	deadline time.Time
 }
 
 func newJob(block uint64, addr common.Address, player GamePlayer, status types.GameStatus) *job {
@@ -5,10 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
+	"path/filepath"
 	"sync/atomic"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/fetcher"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/participation"
 	"github.com/ethereum-optimism/optimism/op-challenger/sender"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/common"
@@ -19,9 +22,14 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/resolution"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/registry"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/statedb"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-challenger/notify"
+	"github.com/ethereum-optimism/optimism/op-challenger/runner"
 	"github.com/ethereum-optimism/optimism/op-challenger/version"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
@@ -33,6 +41,16 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
+// stateDBDir is the directory within the datadir used to store the state of games.
+const stateDBDir = "state-db"
+
+type txSender interface {
+	From() common.Address
+	SendAndWaitDetailed(txPurpose string, txs ...txmgr.TxCandidate) []error
+	SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error
+	SendAndWaitFees(txPurpose string, txs ...txmgr.TxCandidate) (*big.Int, error)
+}
+
 type Service struct {
 	logger  log.Logger
 	metrics metrics.Metricer
@@ -43,8 +61,14 @@ type Service struct {
 
 	preimages *keccak.LargePreimageScheduler
 
+	traceRunner *runner.Runner
+
+	stateDB *statedb.DB
+
+	notifier *notify.Notifier
+
 	txMgr    *txmgr.SimpleTxManager
-	txSender *sender.TxSender
+	txSender txSender
 
 	systemClock clock.Clock
 	l1Clock     *clock.SimpleClock
@@ -108,10 +132,14 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 	if err := s.initFactoryContract(cfg); err != nil {
 		return fmt.Errorf("failed to create factory contract bindings: %w", err)
 	}
+	if err := s.initStateDB(cfg); err != nil {
+		return fmt.Errorf("failed to init state db: %w", err)
+	}
+	s.initNotifier(cfg)
 	if err := s.registerGameTypes(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to register game types: %w", err)
 	}
-	if err := s.initBondClaims(); err != nil {
+	if err := s.initBondClaims(cfg); err != nil {
 		return fmt.Errorf("failed to init bond claiming: %w", err)
 	}
 	if err := s.initScheduler(cfg); err != nil {
@@ -121,7 +149,11 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 		return fmt.Errorf("failed to init large preimage scheduler: %w", err)
 	}
 
-	s.initMonitor(cfg)
+	if err := s.initTraceRunner(cfg); err != nil {
+		return fmt.Errorf("failed to init trace runner: %w", err)
+	}
+
+	s.initMonitor(ctx, cfg)
 
 	s.metrics.RecordInfo(version.SimpleWithMeta)
 	s.metrics.RecordUp()
@@ -139,7 +171,12 @@ func (s *Service) initTxManager(ctx context.Context, cfg *config.Config) error {
 		return fmt.Errorf("failed to create the transaction manager: %w", err)
 	}
 	s.txMgr = txMgr
-	s.txSender = sender.NewTxSender(ctx, s.logger, txMgr, cfg.MaxPendingTx)
+	if cfg.DryRun {
+		s.logger.Warn("Running in dry run mode, no transactions will be sent")
+		s.txSender = sender.NewDryRunTxSender(s.logger, s.metrics, txMgr.From())
+	} else {
+		s.txSender = sender.NewTxSender(ctx, s.logger, s.metrics, txMgr, cfg.MaxPendingTx)
+	}
 	return nil
 }
 
@@ -204,8 +241,8 @@ func (s *Service) initFactoryContract(cfg *config.Config) error {
 	return nil
 }
 
-func (s *Service) initBondClaims() error {
-	claimer := claims.NewBondClaimer(s.logger, s.metrics, s.registry.CreateBondContract, s.txSender, s.claimants...)
+func (s *Service) initBondClaims(cfg *config.Config) error {
+	claimer := claims.NewBondClaimer(s.logger, s.metrics, s.registry.CreateBondContract, s.txSender, s.systemClock, cfg.BondClaimPolicy, s.notifier, s.claimants...)
 	s.claimer = claims.NewBondClaimScheduler(s.logger, s.metrics, claimer)
 	return nil
 }
@@ -222,11 +259,25 @@ func (s *Service) initRollupClient(ctx context.Context, cfg *config.Config) erro
 	return nil
 }
 
+func (s *Service) initStateDB(cfg *config.Config) error {
+	stateDB, err := statedb.NewDB(s.logger, filepath.Join(cfg.Datadir, stateDBDir))
+	if err != nil {
+		return err
+	}
+	s.stateDB = stateDB
+	return nil
+}
+
+func (s *Service) initNotifier(cfg *config.Config) {
+	s.notifier = notify.NewNotifier(s.logger, cfg.Notifications)
+}
+
 func (s *Service) registerGameTypes(ctx context.Context, cfg *config.Config) error {
 	gameTypeRegistry := registry.NewGameTypeRegistry()
 	oracles := registry.NewOracleRegistry()
 	caller := batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize)
-	closer, err := fault.RegisterGameTypes(ctx, s.systemClock, s.l1Clock, s.logger, s.metrics, cfg, gameTypeRegistry, oracles, s.rollupClient, s.txSender, s.factoryContract, caller, s.l1Client, cfg.SelectiveClaimResolution, s.claimants)
+	resolver := resolution.NewResolver(s.logger, s.txSender, cfg.BondClaimPolicy.Multicall, cfg.ResolutionBatchSize)
+	closer, err := fault.RegisterGameTypes(ctx, s.systemClock, s.l1Clock, s.logger, s.metrics, cfg, gameTypeRegistry, oracles, s.rollupClient, s.txSender, resolver, s.factoryContract, caller, s.l1Client, cfg.SelectiveClaimResolution, s.claimants, s.stateDB, s.notifier)
 	if err != nil {
 		return err
 	}
@@ -237,7 +288,7 @@ func (s *Service) registerGameTypes(ctx context.Context, cfg *config.Config) err
 }
 
 func (s *Service) initScheduler(cfg *config.Config) error {
-	disk := newDiskManager(cfg.Datadir)
+	disk := newDiskManager(s.logger, s.metrics, s.systemClock, cfg.Datadir, s.stateDB, cfg.GameDataRetention, cfg.GameDataMaxSize)
 	s.sched = scheduler.NewScheduler(s.logger, s.metrics, disk, cfg.MaxConcurrency, s.registry.CreatePlayer, cfg.AllowInvalidPrestate)
 	return nil
 }
@@ -250,8 +301,25 @@ func (s *Service) initLargePreimages() error {
 	return nil
 }
 
-func (s *Service) initMonitor(cfg *config.Config) {
-	s.monitor = newGameMonitor(s.logger, s.l1Clock, s.factoryContract, s.sched, s.preimages, cfg.GameWindow, s.claimer, cfg.GameAllowlist, s.pollClient)
+func (s *Service) initTraceRunner(cfg *config.Config) error {
+	if !cfg.RunTrace {
+		return nil
+	}
+	if s.rollupClient == nil {
+		return config.ErrMissingRollupRpc
+	}
+	caller := batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize)
+	s.traceRunner = runner.NewBackgroundRunner(s.logger.New("role", "run-trace"), cfg, s.metrics, s.rollupClient, caller)
+	return nil
+}
+
+func (s *Service) initMonitor(ctx context.Context, cfg *config.Config) {
+	caller := batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize)
+	contractCreator := func(game types.GameMetadata) (participation.GameContract, error) {
+		return contracts.NewFaultDisputeGameContract(ctx, s.metrics, game.Proxy, caller)
+	}
+	filter := participation.NewFilter(s.logger, s.l1Clock, cfg.ParticipationPolicy, contractCreator, s.claimants...)
+	s.monitor = newGameMonitor(s.logger, s.l1Clock, s.factoryContract, s.sched, s.preimages, cfg.GameWindow, s.claimer, cfg.GameAllowlist, filter, s.pollClient)
 }
 
 func (s *Service) Start(ctx context.Context) error {
@@ -259,6 +327,12 @@ func (s *Service) Start(ctx context.Context) error {
 	s.sched.Start(ctx)
 	s.claimer.Start(ctx)
 	s.preimages.Start(ctx)
+	if s.traceRunner != nil {
+		s.logger.Info("starting trace runner")
+		if err := s.traceRunner.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start trace runner: %w", err)
+		}
+	}
 	s.logger.Info("starting monitoring")
 	s.monitor.StartMonitoring()
 	s.logger.Info("challenger game service start completed")
@@ -286,9 +360,22 @@ func (s *Service) Stop(ctx context.Context) error {
 			result = errors.Join(result, fmt.Errorf("failed to close claimer: %w", err))
 		}
 	}
+	if s.traceRunner != nil && !s.traceRunner.Stopped() {
+		if err := s.traceRunner.Stop(ctx); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close trace runner: %w", err))
+		}
+	}
 	if s.faultGamesCloser != nil {
 		s.faultGamesCloser()
 	}
+	if err := s.notifier.Close(ctx); err != nil {
+		result = errors.Join(result, fmt.Errorf("failed to send pending notifications: %w", err))
+	}
+	if s.stateDB != nil {
+		if err := s.stateDB.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close state db: %w", err))
+		}
+	}
 	if s.pprofService != nil {
 		if err := s.pprofService.Stop(ctx); err != nil {
 			result = errors.Join(result, fmt.Errorf("failed to close pprof server: %w", err))
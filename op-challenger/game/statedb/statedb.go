@@ -0,0 +1,374 @@
+package statedb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var ErrInvalidEntry = errors.New("invalid db entry")
+
+const (
+	// Keys are prefixed with the game address so all data for a game can be removed at once, followed by a constant
+	// byte to allow us to differentiate different "columns" within the data for the game.
+	columnTraceValue byte = 0
+	columnClaimsSeen byte = 1
+	// Column 2 previously recorded the actions taken. It is no longer written, and is removed with the game.
+	columnNotified byte = 5
+	// columnTraceRef records the cached traces used by the game, so that cached traces can be removed once no
+	// remaining game uses them.
+	columnTraceRef byte = 6
+
+	// Columns of the state shared by all games, stored with the sharedNamespace prefix.
+	columnCachedTraceValue byte = 3
+	columnCachedStepData   byte = 4
+
+	// columnEnd is greater than all columns so can be used as the upper bound of the keys for a game.
+	columnEnd byte = 0xff
+)
+
+// sharedNamespace is used in place of the game address for state shared by all games.
+// No game can have the zero address so this doesn't conflict with the state of any game.
+var sharedNamespace = common.Address{}
+
+func gameKey(game common.Address, column byte, data ...[]byte) []byte {
+	key := make([]byte, 0, common.AddressLength+1+len(data)*common.HashLength)
+	key = append(key, game.Bytes()...)
+	key = append(key, column)
+	for _, d := range data {
+		key = append(key, d...)
+	}
+	return key
+}
+
+func traceValueKey(game common.Address, localContext common.Hash, pos types.Position) []byte {
+	return gameKey(game, columnTraceValue, localContext.Bytes(), pos.ToGIndex().FillBytes(make([]byte, common.HashLength)))
+}
+
+func cachedTraceKey(column byte, prestate common.Hash, inputs common.Hash, pos types.Position) []byte {
+	return gameKey(sharedNamespace, column, prestate.Bytes(), inputs.Bytes(), pos.ToGIndex().FillBytes(make([]byte, common.HashLength)))
+}
+
+func traceRefKey(game common.Address, prestate common.Hash, inputs common.Hash) []byte {
+	return gameKey(game, columnTraceRef, prestate.Bytes(), inputs.Bytes())
+}
+
+// DB persists the state of the games being played by the challenger, so that it is retained when the challenger
+// restarts. This avoids recomputing expensive trace values and checking games that required no actions again.
+type DB struct {
+	// m ensures all read iterators are closed before closing the database by preventing concurrent read and write
+	// operations (with close considered a write operation).
+	m   sync.RWMutex
+	log log.Logger
+	db  *pebble.DB
+
+	writeOpts *pebble.WriteOptions
+
+	closed bool
+}
+
+func NewDB(logger log.Logger, path string) (*DB, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, err
+	}
+	return &DB{
+		log:       logger,
+		db:        db,
+		writeOpts: &pebble.WriteOptions{Sync: true},
+	}, nil
+}
+
+// ForGame returns the state of the specified game.
+func (d *DB) ForGame(game common.Address) *GameDB {
+	return &GameDB{db: d, game: game}
+}
+
+// RemoveAllExcept removes the state of all games except the specified games.
+// Cached traces are removed once none of the remaining games use them.
+func (d *DB) RemoveAllExcept(keep []common.Address) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	iter, err := d.db.NewIter(&pebble.IterOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+	batch := d.db.NewBatch()
+	defer batch.Close()
+	// The prestate and inputs of the cached traces used by the remaining games.
+	used := make(map[string]bool)
+	for valid := iter.First(); valid; {
+		if len(iter.Key()) < common.AddressLength {
+			return fmt.Errorf("%w: key %x", ErrInvalidEntry, iter.Key())
+		}
+		game := common.BytesToAddress(iter.Key()[:common.AddressLength])
+		end := gameKey(game, columnEnd)
+		switch {
+		case game == sharedNamespace:
+			// Shared state is pruned below, once the cached traces used by the remaining games are known.
+		case !slices.Contains(keep, game):
+			d.log.Debug("Removing game state", "game", game)
+			if err := batch.DeleteRange(gameKey(game, columnTraceValue), end, d.writeOpts); err != nil {
+				return fmt.Errorf("failed to remove state for game %v: %w", game, err)
+			}
+		default:
+			prefix := gameKey(game, columnTraceRef)
+			for valid = iter.SeekGE(prefix); valid && bytes.HasPrefix(iter.Key(), prefix); valid = iter.Next() {
+				used[string(iter.Key()[len(prefix):])] = true
+			}
+		}
+		valid = iter.SeekGE(end)
+	}
+	removed := 0
+	for _, column := range []byte{columnCachedTraceValue, columnCachedStepData} {
+		prefix := gameKey(sharedNamespace, column)
+		for valid := iter.SeekGE(prefix); valid && bytes.HasPrefix(iter.Key(), prefix); {
+			if len(iter.Key()) < len(prefix)+2*common.HashLength {
+				return fmt.Errorf("%w: cached trace key %x", ErrInvalidEntry, iter.Key())
+			}
+			trace := slices.Clone(iter.Key()[:len(prefix)+2*common.HashLength])
+			// All positions of the trace sort before the end key, as positions are a fixed length.
+			end := append(slices.Clone(trace), bytes.Repeat([]byte{0xff}, common.HashLength+1)...)
+			if !used[string(trace[len(prefix):])] {
+				if err := batch.DeleteRange(trace, end, d.writeOpts); err != nil {
+					return fmt.Errorf("failed to remove cached trace %x: %w", trace[len(prefix):], err)
+				}
+				removed++
+			}
+			valid = iter.SeekGE(end)
+		}
+	}
+	if removed > 0 {
+		d.log.Debug("Removing unused cached traces", "count", removed)
+	}
+	if err := batch.Commit(d.writeOpts); err != nil {
+		return fmt.Errorf("failed to commit game state removal: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) get(key []byte) ([]byte, bool, error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	val, closer, err := d.db.Get(key)
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	defer closer.Close()
+	return slices.Clone(val), true, nil
+}
+
+func (d *DB) set(key []byte, val []byte) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	return d.db.Set(key, val, d.writeOpts)
+}
+
+func (d *DB) Close() error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		// Already closed
+		return nil
+	}
+	d.closed = true
+	return d.db.Close()
+}
+
+// GameDB is the persisted state of a single game.
+type GameDB struct {
+	db   *DB
+	game common.Address
+}
+
+// TraceCache returns the trace values and step data cached for traces executed from the specified VM prestate.
+// Cached traces are shared by all games, and are retained until no remaining game uses them.
+func (g *GameDB) TraceCache(prestate common.Hash) *TraceCache {
+	return &TraceCache{db: g.db, game: g.game, prestate: prestate, used: make(map[common.Hash]bool)}
+}
+
+// TraceValue returns the value of the trace identified by localContext at pos, if it has been recorded.
+func (g *GameDB) TraceValue(localContext common.Hash, pos types.Position) (common.Hash, bool, error) {
+	val, ok, err := g.db.get(traceValueKey(g.game, localContext, pos))
+	if err != nil || !ok {
+		return common.Hash{}, false, err
+	}
+	if len(val) != common.HashLength {
+		return common.Hash{}, false, fmt.Errorf("%w: trace value %x", ErrInvalidEntry, val)
+	}
+	return common.BytesToHash(val), true, nil
+}
+
+// SetTraceValue records the value of the trace identified by localContext at pos.
+func (g *GameDB) SetTraceValue(localContext common.Hash, pos types.Position, value common.Hash) error {
+	if err := g.db.set(traceValueKey(g.game, localContext, pos), value.Bytes()); err != nil {
+		return fmt.Errorf("failed to record trace value: %w", err)
+	}
+	return nil
+}
+
+// ClaimsSeen returns the number of claims in the game when it was last found to require no actions.
+// Returns 0 if not recorded.
+func (g *GameDB) ClaimsSeen() (int, error) {
+	val, ok, err := g.db.get(gameKey(g.game, columnClaimsSeen))
+	if err != nil || !ok {
+		return 0, err
+	}
+	if len(val) != 8 {
+		return 0, fmt.Errorf("%w: claims seen %x", ErrInvalidEntry, val)
+	}
+	return int(binary.BigEndian.Uint64(val)), nil
+}
+
+// SetClaimsSeen records the number of claims in the game when it was found to require no actions.
+func (g *GameDB) SetClaimsSeen(count int) error {
+	if err := g.db.set(gameKey(g.game, columnClaimsSeen), binary.BigEndian.AppendUint64(nil, uint64(count))); err != nil {
+		return fmt.Errorf("failed to record claims seen: %w", err)
+	}
+	return nil
+}
+
+// Notified returns true if the notification identified by key has been recorded as sent for the game.
+func (g *GameDB) Notified(key string) (bool, error) {
+	_, ok, err := g.db.get(gameKey(g.game, columnNotified, []byte(key)))
+	return ok, err
+}
+
+// RecordNotified records that the notification identified by key was sent for the game.
+func (g *GameDB) RecordNotified(key string) error {
+	if err := g.db.set(gameKey(g.game, columnNotified, []byte(key)), []byte{1}); err != nil {
+		return fmt.Errorf("failed to record notification: %w", err)
+	}
+	return nil
+}
+
+// TraceCache is the persisted cache of trace values and step data for traces executed from a VM prestate.
+// Traces are identified by a hash of the other inputs they are computed from, so games with the same disputes can
+// use the values computed for each other.
+type TraceCache struct {
+	db       *DB
+	game     common.Address
+	prestate common.Hash
+
+	m sync.Mutex
+	// used tracks the inputs of the traces the game is already recorded to use.
+	used map[common.Hash]bool
+}
+
+// use records that the game uses the trace identified by inputs, so the cached trace is retained while the game is.
+func (c *TraceCache) use(inputs common.Hash) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if c.used[inputs] {
+		return nil
+	}
+	if err := c.db.set(traceRefKey(c.game, c.prestate, inputs), []byte{1}); err != nil {
+		return fmt.Errorf("failed to record use of cached trace: %w", err)
+	}
+	c.used[inputs] = true
+	return nil
+}
+
+// TraceValue returns the cached value of the trace identified by inputs at pos, if it has been recorded.
+func (c *TraceCache) TraceValue(inputs common.Hash, pos types.Position) (common.Hash, bool, error) {
+	if err := c.use(inputs); err != nil {
+		return common.Hash{}, false, err
+	}
+	val, ok, err := c.db.get(cachedTraceKey(columnCachedTraceValue, c.prestate, inputs, pos))
+	if err != nil || !ok {
+		return common.Hash{}, false, err
+	}
+	if len(val) != common.HashLength {
+		return common.Hash{}, false, fmt.Errorf("%w: cached trace value %x", ErrInvalidEntry, val)
+	}
+	return common.BytesToHash(val), true, nil
+}
+
+// SetTraceValue records the value of the trace identified by inputs at pos.
+func (c *TraceCache) SetTraceValue(inputs common.Hash, pos types.Position, value common.Hash) error {
+	if err := c.use(inputs); err != nil {
+		return err
+	}
+	if err := c.db.set(cachedTraceKey(columnCachedTraceValue, c.prestate, inputs, pos), value.Bytes()); err != nil {
+		return fmt.Errorf("failed to record cached trace value: %w", err)
+	}
+	return nil
+}
+
+type stepDataJSON struct {
+	Prestate  []byte            `json:"prestate"`
+	ProofData []byte            `json:"proofData"`
+	Preimage  *preimageDataJSON `json:"preimage,omitempty"`
+}
+
+type preimageDataJSON struct {
+	Key            []byte `json:"key"`
+	Data           []byte `json:"data"`
+	Offset         uint32 `json:"offset"`
+	BlobFieldIndex uint64 `json:"blobFieldIndex,omitempty"`
+	BlobCommitment []byte `json:"blobCommitment,omitempty"`
+	BlobProof      []byte `json:"blobProof,omitempty"`
+}
+
+// StepData returns the cached step data of the trace identified by inputs at pos, if it has been recorded.
+func (c *TraceCache) StepData(inputs common.Hash, pos types.Position) (trace.StepData, bool, error) {
+	if err := c.use(inputs); err != nil {
+		return trace.StepData{}, false, err
+	}
+	val, ok, err := c.db.get(cachedTraceKey(columnCachedStepData, c.prestate, inputs, pos))
+	if err != nil || !ok {
+		return trace.StepData{}, false, err
+	}
+	var stored stepDataJSON
+	if err := json.Unmarshal(val, &stored); err != nil {
+		return trace.StepData{}, false, fmt.Errorf("%w: cached step data: %w", ErrInvalidEntry, err)
+	}
+	data := trace.StepData{Prestate: stored.Prestate, ProofData: stored.ProofData}
+	if p := stored.Preimage; p != nil {
+		if p.BlobCommitment != nil {
+			data.PreimageData = types.NewPreimageOracleBlobData(p.Key, p.Data, p.Offset, p.BlobFieldIndex, p.BlobCommitment, p.BlobProof)
+		} else {
+			data.PreimageData = types.NewPreimageOracleData(p.Key, p.Data, p.Offset)
+		}
+	}
+	return data, true, nil
+}
+
+// SetStepData records the step data of the trace identified by inputs at pos.
+func (c *TraceCache) SetStepData(inputs common.Hash, pos types.Position, data trace.StepData) error {
+	if err := c.use(inputs); err != nil {
+		return err
+	}
+	stored := stepDataJSON{Prestate: data.Prestate, ProofData: data.ProofData}
+	if p := data.PreimageData; p != nil {
+		stored.Preimage = &preimageDataJSON{
+			Key:            p.OracleKey,
+			Data:           p.GetPreimageWithSize(),
+			Offset:         p.OracleOffset,
+			BlobFieldIndex: p.BlobFieldIndex,
+			BlobCommitment: p.BlobCommitment,
+			BlobProof:      p.BlobProof,
+		}
+	}
+	val, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("failed to encode step data: %w", err)
+	}
+	if err := c.db.set(cachedTraceKey(columnCachedStepData, c.prestate, inputs, pos), val); err != nil {
+		return fmt.Errorf("failed to record cached step data: %w", err)
+	}
+	return nil
+}
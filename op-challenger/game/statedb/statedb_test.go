@@ -0,0 +1,238 @@
+package statedb
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	game1 = common.Address{0xaa}
+	game2 = common.Address{0xbb}
+)
+
+func TestTraceValues(t *testing.T) {
+	db := createDB(t)
+	localContext := common.Hash{0x01}
+	pos := types.NewPositionFromGIndex(big.NewInt(5))
+	value := common.Hash{0xcc}
+
+	_, ok, err := db.ForGame(game1).TraceValue(localContext, pos)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, db.ForGame(game1).SetTraceValue(localContext, pos, value))
+	actual, ok, err := db.ForGame(game1).TraceValue(localContext, pos)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, value, actual)
+
+	// Values are specific to the game, local context and position
+	_, ok, err = db.ForGame(game2).TraceValue(localContext, pos)
+	require.NoError(t, err)
+	require.False(t, ok)
+	_, ok, err = db.ForGame(game1).TraceValue(common.Hash{0x02}, pos)
+	require.NoError(t, err)
+	require.False(t, ok)
+	_, ok, err = db.ForGame(game1).TraceValue(localContext, pos.Attack())
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestClaimsSeen(t *testing.T) {
+	db := createDB(t)
+	seen, err := db.ForGame(game1).ClaimsSeen()
+	require.NoError(t, err)
+	require.Zero(t, seen)
+
+	require.NoError(t, db.ForGame(game1).SetClaimsSeen(3))
+	require.NoError(t, db.ForGame(game1).SetClaimsSeen(5))
+	seen, err = db.ForGame(game1).ClaimsSeen()
+	require.NoError(t, err)
+	require.Equal(t, 5, seen)
+
+	seen, err = db.ForGame(game2).ClaimsSeen()
+	require.NoError(t, err)
+	require.Zero(t, seen)
+}
+
+func TestNotified(t *testing.T) {
+	db := createDB(t)
+	notified, err := db.ForGame(game1).Notified("game_seen")
+	require.NoError(t, err)
+	require.False(t, notified)
+
+	require.NoError(t, db.ForGame(game1).RecordNotified("game_seen"))
+	notified, err = db.ForGame(game1).Notified("game_seen")
+	require.NoError(t, err)
+	require.True(t, notified)
+
+	notified, err = db.ForGame(game1).Notified("game_lost")
+	require.NoError(t, err)
+	require.False(t, notified)
+	notified, err = db.ForGame(game2).Notified("game_seen")
+	require.NoError(t, err)
+	require.False(t, notified)
+}
+
+func TestPersistState(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	dir := filepath.Join(t.TempDir(), "db")
+	db, err := NewDB(logger, dir)
+	require.NoError(t, err)
+	require.NoError(t, db.ForGame(game1).SetClaimsSeen(3))
+	require.NoError(t, db.Close())
+
+	db, err = NewDB(logger, dir)
+	require.NoError(t, err)
+	defer db.Close()
+	seen, err := db.ForGame(game1).ClaimsSeen()
+	require.NoError(t, err)
+	require.Equal(t, 3, seen)
+}
+
+func TestRemoveAllExcept(t *testing.T) {
+	db := createDB(t)
+	game3 := common.Address{0xcc}
+	pos := types.NewPositionFromGIndex(big.NewInt(5))
+	for _, game := range []common.Address{game1, game2, game3} {
+		require.NoError(t, db.ForGame(game).SetClaimsSeen(3))
+		require.NoError(t, db.ForGame(game).SetTraceValue(common.Hash{0x01}, pos, common.Hash{0x02}))
+		require.NoError(t, db.ForGame(game).RecordNotified("resolved"))
+	}
+
+	require.NoError(t, db.RemoveAllExcept([]common.Address{game2}))
+
+	for _, game := range []common.Address{game1, game2, game3} {
+		keep := game == game2
+		seen, err := db.ForGame(game).ClaimsSeen()
+		require.NoError(t, err)
+		require.Equal(t, keep, seen != 0)
+		_, ok, err := db.ForGame(game).TraceValue(common.Hash{0x01}, pos)
+		require.NoError(t, err)
+		require.Equal(t, keep, ok)
+		notified, err := db.ForGame(game).Notified("resolved")
+		require.NoError(t, err)
+		require.Equal(t, keep, notified)
+	}
+}
+
+func TestTraceCache(t *testing.T) {
+	db := createDB(t)
+	prestate := common.Hash{0xaa}
+	inputs := common.Hash{0x01}
+	pos := types.NewPositionFromGIndex(big.NewInt(5))
+	value := common.Hash{0xcc}
+
+	_, ok, err := db.ForGame(game1).TraceCache(prestate).TraceValue(inputs, pos)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, db.ForGame(game1).TraceCache(prestate).SetTraceValue(inputs, pos, value))
+	actual, ok, err := db.ForGame(game1).TraceCache(prestate).TraceValue(inputs, pos)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, value, actual)
+
+	// Values are specific to the prestate, inputs and position
+	_, ok, err = db.ForGame(game1).TraceCache(common.Hash{0xbb}).TraceValue(inputs, pos)
+	require.NoError(t, err)
+	require.False(t, ok)
+	_, ok, err = db.ForGame(game1).TraceCache(prestate).TraceValue(common.Hash{0x02}, pos)
+	require.NoError(t, err)
+	require.False(t, ok)
+	_, ok, err = db.ForGame(game1).TraceCache(prestate).TraceValue(inputs, pos.Attack())
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestTraceCacheStepData(t *testing.T) {
+	db := createDB(t)
+	cache := db.ForGame(game1).TraceCache(common.Hash{0xaa})
+	inputs := common.Hash{0x01}
+	tests := []struct {
+		name string
+		data trace.StepData
+	}{
+		{"NoPreimage", trace.StepData{Prestate: []byte{1, 2}, ProofData: []byte{3, 4}}},
+		{"LocalPreimage", trace.StepData{Prestate: []byte{1}, ProofData: []byte{2}, PreimageData: types.NewPreimageOracleData([]byte{1, 2, 3}, []byte{4, 5, 6}, 7)}},
+		{"GlobalPreimage", trace.StepData{Prestate: []byte{1}, ProofData: []byte{2}, PreimageData: types.NewPreimageOracleData([]byte{2, 2, 3}, []byte{4, 5, 6}, 7)}},
+		{"BlobPreimage", trace.StepData{Prestate: []byte{1}, ProofData: []byte{2}, PreimageData: types.NewPreimageOracleBlobData([]byte{5, 2, 3}, []byte{4, 5, 6}, 7, 8, []byte{9}, []byte{10})}},
+	}
+	for i, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			pos := types.NewPositionFromGIndex(big.NewInt(int64(i + 1)))
+			_, ok, err := cache.StepData(inputs, pos)
+			require.NoError(t, err)
+			require.False(t, ok)
+
+			require.NoError(t, cache.SetStepData(inputs, pos, test.data))
+			actual, ok, err := cache.StepData(inputs, pos)
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, test.data, actual)
+		})
+	}
+}
+
+func TestRemoveAllExceptPrunesTraceCache(t *testing.T) {
+	db := createDB(t)
+	pos := types.NewPositionFromGIndex(big.NewInt(5))
+	prestate := common.Hash{0xaa}
+	shared := common.Hash{0x01}
+	unused := common.Hash{0x02}
+	stepData := trace.StepData{Prestate: []byte{1}, ProofData: []byte{2}}
+	// Both games use the shared trace, only game1 uses the other trace.
+	for _, game := range []common.Address{game1, game2} {
+		cache := db.ForGame(game).TraceCache(prestate)
+		require.NoError(t, cache.SetTraceValue(shared, pos, common.Hash{0xcc}))
+		require.NoError(t, cache.SetStepData(shared, pos, stepData))
+	}
+	cache := db.ForGame(game1).TraceCache(prestate)
+	require.NoError(t, cache.SetTraceValue(unused, pos, common.Hash{0xdd}))
+	require.NoError(t, cache.SetTraceValue(unused, pos.Attack(), common.Hash{0xee}))
+	require.NoError(t, cache.SetStepData(unused, pos, stepData))
+
+	requireCached := func(inputs common.Hash, expected bool) {
+		// Read the shared state directly, so the read doesn't record the trace as used.
+		for _, key := range [][]byte{
+			cachedTraceKey(columnCachedTraceValue, prestate, inputs, pos),
+			cachedTraceKey(columnCachedStepData, prestate, inputs, pos),
+		} {
+			_, ok, err := db.get(key)
+			require.NoError(t, err)
+			require.Equal(t, expected, ok)
+		}
+	}
+
+	require.NoError(t, db.RemoveAllExcept([]common.Address{game1, game2}))
+	requireCached(shared, true)
+	requireCached(unused, true)
+
+	require.NoError(t, db.RemoveAllExcept([]common.Address{game2}))
+	requireCached(shared, true)
+	requireCached(unused, false)
+	_, ok, err := db.get(cachedTraceKey(columnCachedTraceValue, prestate, unused, pos.Attack()))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, db.RemoveAllExcept(nil))
+	requireCached(shared, false)
+}
+
+func createDB(t *testing.T) *DB {
+	db, err := NewDB(testlog.Logger(t, log.LevelInfo), filepath.Join(t.TempDir(), "db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	return db
+}
@@ -2,8 +2,10 @@ package metrics
 
 import (
 	"io"
+	"math/big"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
 	"github.com/ethereum/go-ethereum/common"
@@ -12,6 +14,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	contractMetrics "github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 )
@@ -40,6 +43,7 @@ type Metricer interface {
 	RecordGameL2Challenge()
 	RecordVmExecutionTime(vmType string, t time.Duration)
 	RecordVmMemoryUsed(vmType string, memoryUsed uint64)
+	RecordVmQueueTime(vmType string, t time.Duration)
 	RecordClaimResolutionTime(t float64)
 	RecordGameActTime(t float64)
 
@@ -49,6 +53,9 @@ type Metricer interface {
 	RecordBondClaimFailed()
 	RecordBondClaimed(amount uint64)
 
+	RecordGasSpent(fee *big.Int)
+	RecordGameBonds(posted, won, lost *big.Int)
+
 	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
 
 	RecordGameUpdateScheduled()
@@ -56,6 +63,17 @@ type Metricer interface {
 
 	RecordLargePreimageCount(count int)
 
+	RecordGameDataSize(size uint64)
+	RecordGameDataRemoved(reason string)
+	RecordLargePreimageStage(stage string)
+	RecordLargePreimageStageFailure(stage string)
+
+	RecordRunTraceSuccess(traceType types.TraceType)
+	RecordRunTraceFailure(traceType types.TraceType)
+	RecordRunTraceInvalid(traceType types.TraceType)
+
+	RecordDryRunTx(txPurpose string)
+
 	IncActiveExecutors()
 	DecActiveExecutors()
 	IncIdleExecutors()
@@ -82,9 +100,17 @@ type Metrics struct {
 	bondClaimFailures prometheus.Counter
 	bondsClaimed      prometheus.Counter
 
+	gasSpent    prometheus.Counter
+	bondsPosted prometheus.Counter
+	bondsWon    prometheus.Counter
+	bondsLost   prometheus.Counter
+	netProfit   prometheus.Gauge
+
 	preimageChallenged      prometheus.Counter
 	preimageChallengeFailed prometheus.Counter
 	preimageCount           prometheus.Gauge
+	preimageStages          *prometheus.CounterVec
+	preimageStageFailures   *prometheus.CounterVec
 
 	highestActedL1Block prometheus.Gauge
 
@@ -96,9 +122,19 @@ type Metrics struct {
 	gameActTime         prometheus.Histogram
 	vmExecutionTime     *prometheus.HistogramVec
 	vmMemoryUsed        *prometheus.HistogramVec
+	vmQueueTime         *prometheus.HistogramVec
 
 	trackedGames  prometheus.GaugeVec
 	inflightGames prometheus.Gauge
+
+	gameDataSize    prometheus.Gauge
+	gameDataRemoved *prometheus.CounterVec
+
+	runTraceSuccess  *prometheus.CounterVec
+	runTraceFailures *prometheus.CounterVec
+	runTraceInvalid  *prometheus.CounterVec
+
+	dryRunTxs *prometheus.CounterVec
 }
 
 func (m *Metrics) Registry() *prometheus.Registry {
@@ -185,6 +221,14 @@ func NewMetrics() *Metrics {
 			// 100MiB increments from 0 to 1.5GiB
 			Buckets: prometheus.LinearBuckets(0, 1024*1024*100, 15),
 		}, []string{"vm"}),
+		vmQueueTime: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "vm_queue_time",
+			Help:      "Time (in seconds) spent waiting for resources to execute the fault proof VM",
+			Buckets: append(
+				[]float64{0.1, 1.0, 10.0},
+				prometheus.ExponentialBuckets(30.0, 2.0, 14)...),
+		}, []string{"vm"}),
 		bondClaimFailures: factory.NewCounter(prometheus.CounterOpts{
 			Namespace: Namespace,
 			Name:      "claim_failures",
@@ -195,6 +239,31 @@ func NewMetrics() *Metrics {
 			Name:      "bonds",
 			Help:      "Number of bonds claimed by the challenge agent",
 		}),
+		gasSpent: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "gas_spent_eth",
+			Help:      "Total fees paid for transactions sent by the challenger, in ETH",
+		}),
+		bondsPosted: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "bonds_posted_eth",
+			Help:      "Total bonds posted by the challenger in resolved games, in ETH",
+		}),
+		bondsWon: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "bonds_won_eth",
+			Help:      "Total bonds won by the challenger from claims it countered in resolved games, in ETH",
+		}),
+		bondsLost: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "bonds_lost_eth",
+			Help:      "Total bonds lost by the challenger from its claims that were countered in resolved games, in ETH",
+		}),
+		netProfit: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "net_profit_eth",
+			Help:      "Bonds won less bonds lost in resolved games and fees paid for all transactions, in ETH",
+		}),
 		preimageChallenged: factory.NewCounter(prometheus.CounterOpts{
 			Namespace: Namespace,
 			Name:      "preimage_challenged",
@@ -210,6 +279,26 @@ func NewMetrics() *Metrics {
 			Name:      "preimage_count",
 			Help:      "Number of large preimage proposals being tracked by the challenger",
 		}),
+		preimageStages: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "large_preimage_stages_total",
+			Help:      "Number of stages of large preimage uploads completed by the challenger",
+		}, []string{"stage"}),
+		preimageStageFailures: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "large_preimage_stage_failures_total",
+			Help:      "Number of failed attempts to complete a stage of large preimage uploads",
+		}, []string{"stage"}),
+		gameDataSize: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "game_data_size_bytes",
+			Help:      "Total size of the data directories of games retained on disk",
+		}),
+		gameDataRemoved: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "game_data_removed_total",
+			Help:      "Number of game data directories removed, by reason",
+		}, []string{"reason"}),
 		trackedGames: *factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: Namespace,
 			Name:      "tracked_games",
@@ -227,6 +316,26 @@ func NewMetrics() *Metrics {
 			Name:      "inflight_games",
 			Help:      "Number of games being tracked by the challenger",
 		}),
+		runTraceSuccess: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "run_trace_success_total",
+			Help:      "Number of background VM executions that successfully verified the output root",
+		}, []string{"type"}),
+		runTraceFailures: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "run_trace_failures_total",
+			Help:      "Number of failures to execute a VM in the background",
+		}, []string{"type"}),
+		runTraceInvalid: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "run_trace_invalid_total",
+			Help:      "Number of background VM executions that determined the output root was invalid",
+		}, []string{"type"}),
+		dryRunTxs: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "dry_run_txs_total",
+			Help:      "Number of transactions that would have been sent if not running in dry run mode",
+		}, []string{"purpose"}),
 	}
 }
 
@@ -282,6 +391,22 @@ func (m *Metrics) RecordLargePreimageCount(count int) {
 	m.preimageCount.Set(float64(count))
 }
 
+func (m *Metrics) RecordLargePreimageStage(stage string) {
+	m.preimageStages.WithLabelValues(stage).Inc()
+}
+
+func (m *Metrics) RecordLargePreimageStageFailure(stage string) {
+	m.preimageStageFailures.WithLabelValues(stage).Inc()
+}
+
+func (m *Metrics) RecordGameDataSize(size uint64) {
+	m.gameDataSize.Set(float64(size))
+}
+
+func (m *Metrics) RecordGameDataRemoved(reason string) {
+	m.gameDataRemoved.WithLabelValues(reason).Inc()
+}
+
 func (m *Metrics) RecordBondClaimFailed() {
 	m.bondClaimFailures.Add(1)
 }
@@ -290,10 +415,27 @@ func (m *Metrics) RecordBondClaimed(amount uint64) {
 	m.bondsClaimed.Add(float64(amount))
 }
 
+func (m *Metrics) RecordGasSpent(fee *big.Int) {
+	amount := eth.WeiToEther(fee)
+	m.gasSpent.Add(amount)
+	m.netProfit.Sub(amount)
+}
+
+func (m *Metrics) RecordGameBonds(posted, won, lost *big.Int) {
+	m.bondsPosted.Add(eth.WeiToEther(posted))
+	m.bondsWon.Add(eth.WeiToEther(won))
+	m.bondsLost.Add(eth.WeiToEther(lost))
+	m.netProfit.Add(eth.WeiToEther(new(big.Int).Sub(won, lost)))
+}
+
 func (m *Metrics) RecordVmExecutionTime(vmType string, dur time.Duration) {
 	m.vmExecutionTime.WithLabelValues(vmType).Observe(dur.Seconds())
 }
 
+func (m *Metrics) RecordVmQueueTime(vmType string, dur time.Duration) {
+	m.vmQueueTime.WithLabelValues(vmType).Observe(dur.Seconds())
+}
+
 func (m *Metrics) RecordVmMemoryUsed(vmType string, memoryUsed uint64) {
 	m.vmMemoryUsed.WithLabelValues(vmType).Observe(float64(memoryUsed))
 }
@@ -339,3 +481,19 @@ func (m *Metrics) RecordGameUpdateScheduled() {
 func (m *Metrics) RecordGameUpdateCompleted() {
 	m.inflightGames.Sub(1)
 }
+
+func (m *Metrics) RecordRunTraceSuccess(traceType types.TraceType) {
+	m.runTraceSuccess.WithLabelValues(traceType.String()).Inc()
+}
+
+func (m *Metrics) RecordRunTraceFailure(traceType types.TraceType) {
+	m.runTraceFailures.WithLabelValues(traceType.String()).Inc()
+}
+
+func (m *Metrics) RecordRunTraceInvalid(traceType types.TraceType) {
+	m.runTraceInvalid.WithLabelValues(traceType.String()).Inc()
+}
+
+func (m *Metrics) RecordDryRunTx(txPurpose string) {
+	m.dryRunTxs.WithLabelValues(txPurpose).Inc()
+}
@@ -2,9 +2,11 @@ package metrics
 
 import (
 	"io"
+	"math/big"
 	"time"
 
 	contractMetrics "github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
@@ -36,11 +38,21 @@ func (*NoopMetricsImpl) RecordPreimageChallenged()      {}
 func (*NoopMetricsImpl) RecordPreimageChallengeFailed() {}
 func (*NoopMetricsImpl) RecordLargePreimageCount(_ int) {}
 
+func (*NoopMetricsImpl) RecordGameDataSize(_ uint64)    {}
+func (*NoopMetricsImpl) RecordGameDataRemoved(_ string) {}
+
+func (*NoopMetricsImpl) RecordLargePreimageStage(_ string)        {}
+func (*NoopMetricsImpl) RecordLargePreimageStageFailure(_ string) {}
+
 func (*NoopMetricsImpl) RecordBondClaimFailed()   {}
 func (*NoopMetricsImpl) RecordBondClaimed(uint64) {}
 
+func (*NoopMetricsImpl) RecordGasSpent(_ *big.Int)        {}
+func (*NoopMetricsImpl) RecordGameBonds(_, _, _ *big.Int) {}
+
 func (*NoopMetricsImpl) RecordVmExecutionTime(_ string, _ time.Duration) {}
 func (*NoopMetricsImpl) RecordVmMemoryUsed(_ string, _ uint64)           {}
+func (*NoopMetricsImpl) RecordVmQueueTime(_ string, _ time.Duration)     {}
 func (*NoopMetricsImpl) RecordClaimResolutionTime(t float64)             {}
 func (*NoopMetricsImpl) RecordGameActTime(t float64)                     {}
 
@@ -49,6 +61,12 @@ func (*NoopMetricsImpl) RecordGamesStatus(inProgress, defenderWon, challengerWon
 func (*NoopMetricsImpl) RecordGameUpdateScheduled() {}
 func (*NoopMetricsImpl) RecordGameUpdateCompleted() {}
 
+func (*NoopMetricsImpl) RecordRunTraceSuccess(_ types.TraceType) {}
+func (*NoopMetricsImpl) RecordRunTraceFailure(_ types.TraceType) {}
+func (*NoopMetricsImpl) RecordRunTraceInvalid(_ types.TraceType) {}
+
+func (*NoopMetricsImpl) RecordDryRunTx(_ string) {}
+
 func (*NoopMetricsImpl) IncActiveExecutors() {}
 func (*NoopMetricsImpl) DecActiveExecutors() {}
 func (*NoopMetricsImpl) IncIdleExecutors()   {}
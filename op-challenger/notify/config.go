@@ -0,0 +1,60 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+// DefaultPagerDutyURL is the PagerDuty Events API v2 endpoint notifications are sent to by default.
+const DefaultPagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+
+var (
+	ErrInvalidURL   = errors.New("invalid notification url")
+	ErrUnknownEvent = errors.New("unknown notification event")
+)
+
+// Config configures where notifications are sent and which events they are sent for.
+type Config struct {
+	WebhookURL          string      // URL to post generic JSON notifications to
+	SlackWebhookURL     string      // Slack incoming webhook URL to post notifications to
+	PagerDutyRoutingKey string      // PagerDuty Events API v2 routing key to trigger alerts with
+	PagerDutyURL        string      // PagerDuty Events API v2 endpoint
+	Events              []EventType // Events to send notifications for (empty == all events)
+}
+
+// Enabled returns true if any notification target is configured.
+func (c Config) Enabled() bool {
+	return c.WebhookURL != "" || c.SlackWebhookURL != "" || c.PagerDutyRoutingKey != ""
+}
+
+func (c Config) Check() error {
+	for _, u := range []string{c.WebhookURL, c.SlackWebhookURL} {
+		if u == "" {
+			continue
+		}
+		if err := checkURL(u); err != nil {
+			return err
+		}
+	}
+	if c.PagerDutyRoutingKey != "" {
+		if err := checkURL(c.PagerDutyURL); err != nil {
+			return err
+		}
+	}
+	for _, event := range c.Events {
+		if !slices.Contains(EventTypes, event) {
+			return fmt.Errorf("%w: %v", ErrUnknownEvent, event)
+		}
+	}
+	return nil
+}
+
+func checkURL(u string) error {
+	parsed, err := url.Parse(u)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: %q", ErrInvalidURL, u)
+	}
+	return nil
+}
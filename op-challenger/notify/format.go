@@ -0,0 +1,87 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// source identifies the challenger as the sender of notifications.
+const source = "op-challenger"
+
+type webhookPayload struct {
+	Source  string            `json:"source"`
+	Event   EventType         `json:"event"`
+	Game    string            `json:"game"`
+	Summary string            `json:"summary"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// formatWebhook formats the event as a generic JSON payload.
+func formatWebhook(event Event) ([]byte, error) {
+	return json.Marshal(webhookPayload{
+		Source:  source,
+		Event:   event.Type,
+		Game:    event.Game.Hex(),
+		Summary: event.Summary,
+		Details: event.Details,
+	})
+}
+
+// formatSlack formats the event as a Slack incoming webhook message.
+func formatSlack(event Event) ([]byte, error) {
+	var text strings.Builder
+	fmt.Fprintf(&text, "*%v: %v*\n%v\n• game: `%v`", source, event.Type, event.Summary, event.Game.Hex())
+	keys := make([]string, 0, len(event.Details))
+	for key := range event.Details {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&text, "\n• %v: `%v`", key, event.Details[key])
+	}
+	return json.Marshal(map[string]string{"text": text.String()})
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string           `json:"routing_key"`
+	EventAction string           `json:"event_action"`
+	DedupKey    string           `json:"dedup_key"`
+	Payload     pagerDutyPayload `json:"payload"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Component     string            `json:"component"`
+	Class         EventType         `json:"class"`
+	CustomDetails map[string]string `json:"custom_details"`
+}
+
+// pagerDutyFormatter formats events as PagerDuty Events API v2 alerts, triggered with the routing key.
+// Alerts for the same event in the same game are deduplicated by PagerDuty.
+func pagerDutyFormatter(routingKey string) func(Event) ([]byte, error) {
+	return func(event Event) ([]byte, error) {
+		details := maps.Clone(event.Details)
+		if details == nil {
+			details = make(map[string]string)
+		}
+		details["game"] = event.Game.Hex()
+		return json.Marshal(pagerDutyEvent{
+			RoutingKey:  routingKey,
+			EventAction: "trigger",
+			DedupKey:    fmt.Sprintf("%v-%v-%v", source, event.Type, event.Game.Hex()),
+			Payload: pagerDutyPayload{
+				Summary:       fmt.Sprintf("%v: %v", source, event.Summary),
+				Source:        source,
+				Severity:      event.Type.Severity(),
+				Component:     event.Game.Hex(),
+				Class:         event.Type,
+				CustomDetails: details,
+			},
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	queueSize      = 100
+	requestTimeout = 10 * time.Second
+	maxAttempts    = 3
+)
+
+// EventType identifies the kind of event a notification is sent for.
+type EventType string
+
+const (
+	EventGameSeen         EventType = "game_seen"
+	EventClaimCountered   EventType = "claim_countered"
+	EventGameLost         EventType = "game_lost"
+	EventResolutionFailed EventType = "resolution_failed"
+	EventBondClaimFailed  EventType = "bond_claim_failed"
+)
+
+// EventTypes are all the events that notifications can be sent for.
+var EventTypes = []EventType{
+	EventGameSeen,
+	EventClaimCountered,
+	EventGameLost,
+	EventResolutionFailed,
+	EventBondClaimFailed,
+}
+
+// Severity is the PagerDuty severity of alerts for the event.
+func (e EventType) Severity() string {
+	switch e {
+	case EventGameLost:
+		return "critical"
+	case EventResolutionFailed, EventBondClaimFailed:
+		return "error"
+	case EventClaimCountered:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// Event is a notable event in a game.
+type Event struct {
+	Type    EventType
+	Game    common.Address
+	Summary string
+	// Details are additional context about the event included in the notification, such as claim indices or errors.
+	Details map[string]string
+}
+
+type target struct {
+	name   string
+	url    string
+	format func(Event) ([]byte, error)
+}
+
+// Notifier sends notifications of events to the configured webhook, Slack and PagerDuty targets.
+// Notifications are sent in the background, in the order the events occurred, and retried if they fail.
+// Events are dropped with a warning if too many notifications are pending.
+type Notifier struct {
+	logger   log.Logger
+	client   *http.Client
+	targets  []target
+	events   map[EventType]bool
+	strategy retry.Strategy
+
+	mu     sync.Mutex
+	closed bool
+	queue  chan Event
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewNotifier creates a Notifier and starts sending notifications in the background.
+// Returns nil, which sends no notifications, if no notification targets are configured.
+func NewNotifier(logger log.Logger, cfg Config) *Notifier {
+	return newNotifier(logger, cfg, retry.Exponential())
+}
+
+func newNotifier(logger log.Logger, cfg Config, strategy retry.Strategy) *Notifier {
+	if !cfg.Enabled() {
+		return nil
+	}
+	var targets []target
+	if cfg.WebhookURL != "" {
+		targets = append(targets, target{name: "webhook", url: cfg.WebhookURL, format: formatWebhook})
+	}
+	if cfg.SlackWebhookURL != "" {
+		targets = append(targets, target{name: "slack", url: cfg.SlackWebhookURL, format: formatSlack})
+	}
+	if cfg.PagerDutyRoutingKey != "" {
+		targets = append(targets, target{name: "pagerduty", url: cfg.PagerDutyURL, format: pagerDutyFormatter(cfg.PagerDutyRoutingKey)})
+	}
+	var events map[EventType]bool
+	if len(cfg.Events) > 0 {
+		events = make(map[EventType]bool, len(cfg.Events))
+		for _, event := range cfg.Events {
+			events[event] = true
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	n := &Notifier{
+		logger:   logger,
+		client:   &http.Client{Timeout: requestTimeout},
+		targets:  targets,
+		events:   events,
+		strategy: strategy,
+		queue:    make(chan Event, queueSize),
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	go n.run()
+	return n
+}
+
+// Notify queues a notification of the event to be sent, if notifications are enabled for its type.
+// A nil Notifier sends no notifications.
+func (n *Notifier) Notify(event Event) {
+	if n == nil || (n.events != nil && !n.events[event.Type]) {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.closed {
+		return
+	}
+	select {
+	case n.queue <- event:
+	default:
+		n.logger.Warn("Dropping notification as too many are pending", "event", event.Type, "game", event.Game)
+	}
+}
+
+// Close stops accepting new notifications and waits for pending notifications to be sent.
+// Pending notifications are abandoned if ctx is done first.
+func (n *Notifier) Close(ctx context.Context) error {
+	if n == nil {
+		return nil
+	}
+	n.mu.Lock()
+	if !n.closed {
+		n.closed = true
+		close(n.queue)
+	}
+	n.mu.Unlock()
+	defer n.cancel()
+	select {
+	case <-n.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (n *Notifier) run() {
+	defer close(n.done)
+	for event := range n.queue {
+		for _, t := range n.targets {
+			if err := n.send(t, event); err != nil {
+				n.logger.Error("Failed to send notification", "target", t.name, "event", event.Type, "game", event.Game, "err", err)
+			}
+		}
+	}
+}
+
+func (n *Notifier) send(t target, event Event) error {
+	body, err := t.format(event)
+	if err != nil {
+		return fmt.Errorf("failed to format notification: %w", err)
+	}
+	_, err = retry.Do(n.ctx, maxAttempts, n.strategy, func() (struct{}, error) {
+		return struct{}{}, n.post(t.url, body)
+	})
+	return err
+}
+
+func (n *Notifier) post(url string, body []byte) error {
+	req, err := http.NewRequestWithContext(n.ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code: %v", resp.StatusCode)
+	}
+	return nil
+}
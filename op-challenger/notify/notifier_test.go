@@ -0,0 +1,167 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var testEvent = Event{
+	Type:    EventClaimCountered,
+	Game:    common.Address{0xaa},
+	Summary: "Claim 3 was countered",
+	Details: map[string]string{"claimIdx": "3", "counterIdx": "5"},
+}
+
+func TestNotifier(t *testing.T) {
+	t.Run("NilWhenNotConfigured", func(t *testing.T) {
+		n := NewNotifier(testlog.Logger(t, log.LevelInfo), Config{})
+		require.Nil(t, n)
+		// Nil notifier is safe to use
+		n.Notify(testEvent)
+		require.NoError(t, n.Close(context.Background()))
+	})
+
+	t.Run("SendToAllTargets", func(t *testing.T) {
+		webhook, webhookBodies := newServer(t, 0)
+		slack, slackBodies := newServer(t, 0)
+		pagerDuty, pagerDutyBodies := newServer(t, 0)
+		n := newNotifier(testlog.Logger(t, log.LevelInfo), Config{
+			WebhookURL:          webhook.URL,
+			SlackWebhookURL:     slack.URL,
+			PagerDutyRoutingKey: "key",
+			PagerDutyURL:        pagerDuty.URL,
+		}, retry.Fixed(0))
+		n.Notify(testEvent)
+		require.NoError(t, n.Close(context.Background()))
+
+		var payload webhookPayload
+		require.NoError(t, json.Unmarshal(receive(t, webhookBodies), &payload))
+		require.Equal(t, webhookPayload{
+			Source:  "op-challenger",
+			Event:   EventClaimCountered,
+			Game:    testEvent.Game.Hex(),
+			Summary: testEvent.Summary,
+			Details: testEvent.Details,
+		}, payload)
+
+		var slackMsg map[string]string
+		require.NoError(t, json.Unmarshal(receive(t, slackBodies), &slackMsg))
+		require.Equal(t, "*op-challenger: claim_countered*\nClaim 3 was countered\n• game: `"+testEvent.Game.Hex()+"`\n• claimIdx: `3`\n• counterIdx: `5`", slackMsg["text"])
+
+		var alert pagerDutyEvent
+		require.NoError(t, json.Unmarshal(receive(t, pagerDutyBodies), &alert))
+		require.Equal(t, "key", alert.RoutingKey)
+		require.Equal(t, "trigger", alert.EventAction)
+		require.Equal(t, "op-challenger-claim_countered-"+testEvent.Game.Hex(), alert.DedupKey)
+		require.Equal(t, "op-challenger: Claim 3 was countered", alert.Payload.Summary)
+		require.Equal(t, "warning", alert.Payload.Severity)
+		require.Equal(t, map[string]string{"claimIdx": "3", "counterIdx": "5", "game": testEvent.Game.Hex()}, alert.Payload.CustomDetails)
+	})
+
+	t.Run("OnlySendEnabledEvents", func(t *testing.T) {
+		webhook, bodies := newServer(t, 0)
+		n := newNotifier(testlog.Logger(t, log.LevelInfo), Config{
+			WebhookURL: webhook.URL,
+			Events:     []EventType{EventGameLost},
+		}, retry.Fixed(0))
+		n.Notify(testEvent)
+		lost := testEvent
+		lost.Type = EventGameLost
+		n.Notify(lost)
+		require.NoError(t, n.Close(context.Background()))
+
+		var payload webhookPayload
+		require.NoError(t, json.Unmarshal(receive(t, bodies), &payload))
+		require.Equal(t, EventGameLost, payload.Event)
+		require.Empty(t, bodies)
+	})
+
+	t.Run("RetryFailedRequests", func(t *testing.T) {
+		webhook, bodies := newServer(t, 2)
+		n := newNotifier(testlog.Logger(t, log.LevelInfo), Config{WebhookURL: webhook.URL}, retry.Fixed(0))
+		n.Notify(testEvent)
+		require.NoError(t, n.Close(context.Background()))
+		receive(t, bodies)
+		require.Empty(t, bodies)
+	})
+
+	t.Run("IgnoreEventsAfterClose", func(t *testing.T) {
+		webhook, bodies := newServer(t, 0)
+		n := newNotifier(testlog.Logger(t, log.LevelInfo), Config{WebhookURL: webhook.URL}, retry.Fixed(0))
+		require.NoError(t, n.Close(context.Background()))
+		n.Notify(testEvent)
+		require.Empty(t, bodies)
+	})
+
+	t.Run("AbandonPendingWhenCloseTimesOut", func(t *testing.T) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}))
+		t.Cleanup(server.Close)
+		t.Cleanup(func() { close(release) })
+		n := newNotifier(testlog.Logger(t, log.LevelInfo), Config{WebhookURL: server.URL}, retry.Fixed(0))
+		n.Notify(testEvent)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, n.Close(ctx), context.DeadlineExceeded)
+	})
+}
+
+func TestConfigCheck(t *testing.T) {
+	require.NoError(t, Config{}.Check())
+	require.NoError(t, Config{
+		WebhookURL:          "https://example.com/hook",
+		SlackWebhookURL:     "https://hooks.slack.com/services/abc",
+		PagerDutyRoutingKey: "key",
+		PagerDutyURL:        DefaultPagerDutyURL,
+		Events:              EventTypes,
+	}.Check())
+	require.ErrorIs(t, Config{WebhookURL: "example.com"}.Check(), ErrInvalidURL)
+	require.ErrorIs(t, Config{SlackWebhookURL: "ftp://example.com"}.Check(), ErrInvalidURL)
+	require.ErrorIs(t, Config{PagerDutyRoutingKey: "key"}.Check(), ErrInvalidURL)
+	require.ErrorIs(t, Config{Events: []EventType{"unknown"}}.Check(), ErrUnknownEvent)
+}
+
+// newServer creates a server that records the bodies of requests, failing the first failures requests.
+func newServer(t *testing.T, failures int32) (*httptest.Server, chan []byte) {
+	bodies := make(chan []byte, 10)
+	var count atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if count.Add(1) <= failures {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		bodies <- body
+	}))
+	t.Cleanup(server.Close)
+	return server, bodies
+}
+
+func receive(t *testing.T, bodies chan []byte) []byte {
+	select {
+	case body := <-bodies:
+		return body
+	default:
+		t.Fatal("notification not sent")
+		return nil
+	}
+}
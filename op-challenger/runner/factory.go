@@ -17,6 +17,16 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
+// SupportsTraceType returns true if the runner can run the trace provider for the trace type.
+func SupportsTraceType(traceType types.TraceType) bool {
+	switch traceType {
+	case types.TraceTypeCannon, types.TraceTypeAsterisc, types.TraceTypeAsteriscKona:
+		return true
+	}
+	_, ok := vm.LookupTraceProvider(traceType)
+	return ok
+}
+
 func createTraceProvider(
 	logger log.Logger,
 	m vm.Metricer,
@@ -55,6 +65,16 @@ func createTraceProvider(
 		prestateProvider := vm.NewPrestateProvider(prestate, stateConverter)
 		return asterisc.NewTraceProvider(logger, m, cfg.AsteriscKona, vmConfig, prestateProvider, prestate, localInputs, dir, 42), nil
 	}
+	if provider, ok := vm.LookupTraceProvider(traceType); ok {
+		vmCfg := cfg.VMs[traceType]
+		stateConverter := provider.StateConverter()
+		prestate, err := getPrestate(prestateHash, vmCfg.AbsolutePreStateBaseURL, vmCfg.AbsolutePreState, dir, stateConverter)
+		if err != nil {
+			return nil, err
+		}
+		prestateProvider := vm.NewPrestateProvider(prestate, stateConverter)
+		return provider.NewTraceProvider(logger, m, vmCfg.VM, provider.OracleServerExecutor(), prestateProvider, prestate, localInputs, dir, 42), nil
+	}
 	return nil, errors.New("invalid trace type")
 }
 
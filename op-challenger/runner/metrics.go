@@ -5,6 +5,7 @@ import (
 
 	contractMetrics "github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -21,6 +22,7 @@ type Metrics struct {
 	vmLastExecutionTime *prometheus.GaugeVec
 	vmMemoryUsed        *prometheus.HistogramVec
 	vmLastMemoryUsed    *prometheus.GaugeVec
+	vmQueueTime         *prometheus.HistogramVec
 	successTotal        *prometheus.CounterVec
 	failuresTotal       *prometheus.CounterVec
 	invalidTotal        *prometheus.CounterVec
@@ -67,6 +69,14 @@ func NewMetrics() *Metrics {
 			Name:      "vm_last_memory_used",
 			Help:      "Memory used (in bytes) for the last execution of the fault proof VM",
 		}, []string{"vm"}),
+		vmQueueTime: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "vm_queue_time",
+			Help:      "Time (in seconds) spent waiting for resources to execute the fault proof VM",
+			Buckets: append(
+				[]float64{0.1, 1.0, 10.0},
+				prometheus.ExponentialBuckets(30.0, 2.0, 14)...),
+		}, []string{"vm"}),
 		successTotal: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: Namespace,
 			Name:      "success_total",
@@ -100,6 +110,10 @@ func (m *Metrics) RecordVmMemoryUsed(vmType string, memoryUsed uint64) {
 	m.vmLastMemoryUsed.WithLabelValues(vmType).Set(float64(memoryUsed))
 }
 
+func (m *Metrics) RecordVmQueueTime(vmType string, dur time.Duration) {
+	m.vmQueueTime.WithLabelValues(vmType).Observe(dur.Seconds())
+}
+
 func (m *Metrics) RecordSuccess(vmType types.TraceType) {
 	m.successTotal.WithLabelValues(vmType.String()).Inc()
 }
@@ -111,3 +125,22 @@ func (m *Metrics) RecordFailure(vmType types.TraceType) {
 func (m *Metrics) RecordInvalid(vmType types.TraceType) {
 	m.invalidTotal.WithLabelValues(vmType.String()).Inc()
 }
+
+// challengerMetrics records the results of a runner in the background of the challenger to the challenger metrics.
+type challengerMetrics struct {
+	metrics.Metricer
+}
+
+var _ Metricer = (*challengerMetrics)(nil)
+
+func (m *challengerMetrics) RecordSuccess(vmType types.TraceType) {
+	m.RecordRunTraceSuccess(vmType)
+}
+
+func (m *challengerMetrics) RecordFailure(vmType types.TraceType) {
+	m.RecordRunTraceFailure(vmType)
+}
+
+func (m *challengerMetrics) RecordInvalid(vmType types.TraceType) {
+	m.RecordRunTraceInvalid(vmType)
+}
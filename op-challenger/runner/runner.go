@@ -18,6 +18,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
@@ -42,9 +43,13 @@ type Metricer interface {
 }
 
 type Runner struct {
-	log log.Logger
-	cfg *config.Config
-	m   Metricer
+	log        log.Logger
+	cfg        *config.Config
+	m          Metricer
+	traceTypes []types.TraceType
+
+	rollupClient *sources.RollupClient
+	caller       *batching.MultiCaller
 
 	running    atomic.Bool
 	ctx        context.Context
@@ -55,9 +60,29 @@ type Runner struct {
 
 func NewRunner(logger log.Logger, cfg *config.Config) *Runner {
 	return &Runner{
-		log: logger,
-		cfg: cfg,
-		m:   NewMetrics(),
+		log:        logger,
+		cfg:        cfg,
+		m:          NewMetrics(),
+		traceTypes: cfg.TraceTypes,
+	}
+}
+
+// NewBackgroundRunner creates a runner that validates the enabled VM trace types alongside the challenger service,
+// reusing its clients and reporting results to its metrics. No claims are posted.
+func NewBackgroundRunner(logger log.Logger, cfg *config.Config, m metrics.Metricer, rollupClient *sources.RollupClient, caller *batching.MultiCaller) *Runner {
+	var traceTypes []types.TraceType
+	for _, traceType := range cfg.TraceTypes {
+		if SupportsTraceType(traceType) {
+			traceTypes = append(traceTypes, traceType)
+		}
+	}
+	return &Runner{
+		log:          logger,
+		cfg:          cfg,
+		m:            &challengerMetrics{m},
+		traceTypes:   traceTypes,
+		rollupClient: rollupClient,
+		caller:       caller,
 	}
 }
 
@@ -68,24 +93,28 @@ func (r *Runner) Start(ctx context.Context) error {
 	ctx, cancel := context.WithCancel(ctx)
 	r.ctx = ctx
 	r.cancel = cancel
-	if err := r.initMetricsServer(&r.cfg.MetricsConfig); err != nil {
-		return fmt.Errorf("failed to start metrics: %w", err)
-	}
+	if r.rollupClient == nil {
+		// Running standalone rather than in the background of the challenger, so needs its own metrics and clients.
+		if err := r.initMetricsServer(&r.cfg.MetricsConfig); err != nil {
+			return fmt.Errorf("failed to start metrics: %w", err)
+		}
 
-	rollupClient, err := dial.DialRollupClientWithTimeout(ctx, 1*time.Minute, r.log, r.cfg.RollupRpc)
-	if err != nil {
-		return fmt.Errorf("failed to dial rollup client: %w", err)
-	}
+		rollupClient, err := dial.DialRollupClientWithTimeout(ctx, 1*time.Minute, r.log, r.cfg.RollupRpc)
+		if err != nil {
+			return fmt.Errorf("failed to dial rollup client: %w", err)
+		}
+		r.rollupClient = rollupClient
 
-	l1Client, err := dial.DialRPCClientWithTimeout(ctx, 1*time.Minute, r.log, r.cfg.L1EthRpc)
-	if err != nil {
-		return fmt.Errorf("failed to dial l1 client: %w", err)
+		l1Client, err := dial.DialRPCClientWithTimeout(ctx, 1*time.Minute, r.log, r.cfg.L1EthRpc)
+		if err != nil {
+			return fmt.Errorf("failed to dial l1 client: %w", err)
+		}
+		r.caller = batching.NewMultiCaller(l1Client, batching.DefaultBatchSize)
 	}
-	caller := batching.NewMultiCaller(l1Client, batching.DefaultBatchSize)
 
-	for _, traceType := range r.cfg.TraceTypes {
+	for _, traceType := range r.traceTypes {
 		r.wg.Add(1)
-		go r.loop(ctx, traceType, rollupClient, caller)
+		go r.loop(ctx, traceType, r.rollupClient, r.caller)
 	}
 
 	r.log.Info("Runners started")
@@ -94,7 +123,7 @@ func (r *Runner) Start(ctx context.Context) error {
 
 func (r *Runner) loop(ctx context.Context, traceType types.TraceType, client *sources.RollupClient, caller *batching.MultiCaller) {
 	defer r.wg.Done()
-	t := time.NewTicker(1 * time.Minute)
+	t := time.NewTicker(r.cfg.RunTraceInterval)
 	defer t.Stop()
 	for {
 		if err := r.runOnce(ctx, traceType, client, caller); errors.Is(err, ErrUnexpectedStatusCode) {
@@ -0,0 +1,52 @@
+package sender
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type DryRunMetricer interface {
+	RecordDryRunTx(txPurpose string)
+}
+
+// DryRunTxSender logs the transactions it is asked to send instead of sending them, and reports them as successful.
+type DryRunTxSender struct {
+	log  log.Logger
+	m    DryRunMetricer
+	from common.Address
+}
+
+func NewDryRunTxSender(logger log.Logger, m DryRunMetricer, from common.Address) *DryRunTxSender {
+	return &DryRunTxSender{
+		log:  logger,
+		m:    m,
+		from: from,
+	}
+}
+
+func (s *DryRunTxSender) From() common.Address {
+	return s.from
+}
+
+func (s *DryRunTxSender) SendAndWaitDetailed(txPurpose string, txs ...txmgr.TxCandidate) []error {
+	for _, tx := range txs {
+		s.log.Info("Dry run: not sending transaction", "purpose", txPurpose, "to", tx.To, "value", tx.Value, "gasLimit", tx.GasLimit, "data", hexutil.Bytes(tx.TxData))
+		s.m.RecordDryRunTx(txPurpose)
+	}
+	return make([]error, len(txs))
+}
+
+func (s *DryRunTxSender) SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error {
+	errs := s.SendAndWaitDetailed(txPurpose, txs...)
+	return errors.Join(errs...)
+}
+
+// SendAndWaitFees logs the transactions like SendAndWaitSimple, and reports that no fees were paid.
+func (s *DryRunTxSender) SendAndWaitFees(txPurpose string, txs ...txmgr.TxCandidate) (*big.Int, error) {
+	return new(big.Int), s.SendAndWaitSimple(txPurpose, txs...)
+}
@@ -0,0 +1,43 @@
+package sender
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRunDoesNotSend(t *testing.T) {
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	m := &stubDryRunMetrics{}
+	from := common.Address{0xaa}
+	sender := NewDryRunTxSender(logger, m, from)
+	require.Equal(t, from, sender.From())
+
+	to := common.Address{0xbb}
+	errs := sender.SendAndWaitDetailed("testing", txmgr.TxCandidate{To: &to, TxData: []byte{1}}, txmgr.TxCandidate{To: &to, TxData: []byte{2}})
+	require.Equal(t, []error{nil, nil}, errs)
+	require.NoError(t, sender.SendAndWaitSimple("other", txmgr.TxCandidate{To: &to}))
+	fees, err := sender.SendAndWaitFees("other", txmgr.TxCandidate{To: &to})
+	require.NoError(t, err)
+	require.Zero(t, fees.Sign())
+
+	require.Equal(t, map[string]int{"testing": 2, "other": 2}, m.txs)
+	levelFilter := testlog.NewLevelFilter(log.LevelInfo)
+	msgFilter := testlog.NewMessageFilter("Dry run: not sending transaction")
+	require.Len(t, logs.FindLogs(levelFilter, msgFilter), 4)
+}
+
+type stubDryRunMetrics struct {
+	txs map[string]int
+}
+
+func (s *stubDryRunMetrics) RecordDryRunTx(txPurpose string) {
+	if s.txs == nil {
+		s.txs = make(map[string]int)
+	}
+	s.txs[txPurpose]++
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
@@ -13,17 +14,24 @@ import (
 
 var ErrTransactionReverted = errors.New("transaction published but reverted")
 
+// GasMetricer records the fees paid for transactions included on L1, including any that reverted.
+type GasMetricer interface {
+	RecordGasSpent(fee *big.Int)
+}
+
 type TxSender struct {
 	log log.Logger
+	m   GasMetricer
 
 	txMgr txmgr.TxManager
 	queue *txmgr.Queue[int]
 }
 
-func NewTxSender(ctx context.Context, logger log.Logger, txMgr txmgr.TxManager, maxPending uint64) *TxSender {
+func NewTxSender(ctx context.Context, logger log.Logger, m GasMetricer, txMgr txmgr.TxManager, maxPending uint64) *TxSender {
 	queue := txmgr.NewQueue[int](ctx, txMgr, maxPending)
 	return &TxSender{
 		log:   logger,
+		m:     m,
 		txMgr: txMgr,
 		queue: queue,
 	}
@@ -34,18 +42,39 @@ func (s *TxSender) From() common.Address {
 }
 
 func (s *TxSender) SendAndWaitDetailed(txPurpose string, txs ...txmgr.TxCandidate) []error {
+	_, errs := s.sendAndWait(txPurpose, txs...)
+	return errs
+}
+
+func (s *TxSender) SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error {
+	errs := s.SendAndWaitDetailed(txPurpose, txs...)
+	return errors.Join(errs...)
+}
+
+// SendAndWaitFees sends the transactions like SendAndWaitSimple and returns the total fees paid for the transactions
+// that were included, including any that reverted.
+func (s *TxSender) SendAndWaitFees(txPurpose string, txs ...txmgr.TxCandidate) (*big.Int, error) {
+	fees, errs := s.sendAndWait(txPurpose, txs...)
+	return fees, errors.Join(errs...)
+}
+
+func (s *TxSender) sendAndWait(txPurpose string, txs ...txmgr.TxCandidate) (*big.Int, []error) {
 	receiptsCh := make(chan txmgr.TxReceipt[int], len(txs))
 	for i, tx := range txs {
 		s.queue.Send(i, tx, receiptsCh)
 	}
 	completed := 0
 	errs := make([]error, len(txs))
+	fees := new(big.Int)
 	for completed < len(txs) {
 		rcpt := <-receiptsCh
 		completed++
 		if rcpt.Err != nil {
 			errs[rcpt.ID] = rcpt.Err
 		} else if rcpt.Receipt != nil {
+			fee := receiptFee(rcpt.Receipt)
+			fees.Add(fees, fee)
+			s.m.RecordGasSpent(fee)
 			if rcpt.Receipt.Status != types.ReceiptStatusSuccessful {
 				errs[rcpt.ID] = fmt.Errorf("%w purpose: %v hash: %v", ErrTransactionReverted, txPurpose, rcpt.Receipt.TxHash)
 			} else {
@@ -53,10 +82,13 @@ func (s *TxSender) SendAndWaitDetailed(txPurpose string, txs ...txmgr.TxCandidat
 			}
 		}
 	}
-	return errs
+	return fees, errs
 }
 
-func (s *TxSender) SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error {
-	errs := s.SendAndWaitDetailed(txPurpose, txs...)
-	return errors.Join(errs...)
+// receiptFee returns the fee paid for the transaction of the receipt.
+func receiptFee(rcpt *types.Receipt) *big.Int {
+	if rcpt.EffectiveGasPrice == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Mul(new(big.Int).SetUint64(rcpt.GasUsed), rcpt.EffectiveGasPrice)
 }
@@ -3,6 +3,7 @@ package sender
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"sync"
 	"testing"
 	"time"
@@ -21,7 +22,7 @@ func TestSendAndWaitQueueWithMaxPending(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 	txMgr := &stubTxMgr{sending: make(map[byte]chan *types.Receipt)}
-	sender := NewTxSender(ctx, testlog.Logger(t, log.LevelInfo), txMgr, 5)
+	sender := NewTxSender(ctx, testlog.Logger(t, log.LevelInfo), &stubGasMetrics{}, txMgr, 5)
 
 	tx := func(i byte) txmgr.TxCandidate {
 		return txmgr.TxCandidate{TxData: []byte{i}}
@@ -100,7 +101,7 @@ func TestSendAndWaitReturnIndividualErrors(t *testing.T) {
 			2: types.ReceiptStatusSuccessful,
 		},
 	}
-	sender := NewTxSender(ctx, testlog.Logger(t, log.LevelInfo), txMgr, 500)
+	sender := NewTxSender(ctx, testlog.Logger(t, log.LevelInfo), &stubGasMetrics{}, txMgr, 500)
 
 	tx := func(i byte) txmgr.TxCandidate {
 		return txmgr.TxCandidate{TxData: []byte{i}}
@@ -113,6 +114,48 @@ func TestSendAndWaitReturnIndividualErrors(t *testing.T) {
 	require.NoError(t, errs[2])
 }
 
+func TestSendAndWaitFees(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	txMgr := &stubTxMgr{
+		sending: make(map[byte]chan *types.Receipt),
+		syncStatus: map[byte]uint64{
+			0: types.ReceiptStatusSuccessful,
+			1: types.ReceiptStatusFailed,
+			2: types.ReceiptStatusSuccessful,
+		},
+	}
+	m := &stubGasMetrics{}
+	sender := NewTxSender(ctx, testlog.Logger(t, log.LevelInfo), m, txMgr, 500)
+
+	tx := func(i byte) txmgr.TxCandidate {
+		return txmgr.TxCandidate{TxData: []byte{i}}
+	}
+
+	// Fees of reverted transactions are still paid
+	fees, err := sender.SendAndWaitFees("testing", tx(0), tx(1), tx(2))
+	require.ErrorIs(t, err, ErrTransactionReverted)
+	expected := big.NewInt(stubGasUsed * (1 + 2 + 3))
+	require.Equal(t, expected, fees)
+	require.Equal(t, expected, m.spent)
+}
+
+const stubGasUsed = 21000
+
+type stubGasMetrics struct {
+	m     sync.Mutex
+	spent *big.Int
+}
+
+func (s *stubGasMetrics) RecordGasSpent(fee *big.Int) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.spent == nil {
+		s.spent = new(big.Int)
+	}
+	s.spent.Add(s.spent, fee)
+}
+
 type stubTxMgr struct {
 	m          sync.Mutex
 	sending    map[byte]chan *types.Receipt
@@ -142,7 +185,8 @@ func (s *stubTxMgr) recordTx(candidate txmgr.TxCandidate) chan *types.Receipt {
 	}
 	ch := make(chan *types.Receipt, 1)
 	if status, ok := s.syncStatus[id]; ok {
-		ch <- &types.Receipt{Status: status}
+		// Use a different gas price for each transaction so the fees of each are included
+		ch <- &types.Receipt{Status: status, GasUsed: stubGasUsed, EffectiveGasPrice: big.NewInt(int64(id) + 1)}
 	} else {
 		s.sending[id] = ch
 	}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	chainsPath = "/chains"
+	gamesPath  = "/games"
+	chainParam = "chain"
+)
+
+type ChainSummary struct {
+	Name    string `json:"name"`
+	Updated uint64 `json:"updated"`
+	Games   int    `json:"games"`
+}
+
+type GamesResponse struct {
+	Chain   string `json:"chain"`
+	Updated uint64 `json:"updated"`
+	Games   []Game `json:"games"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type handler struct {
+	logger log.Logger
+	store  *Store
+}
+
+// NewHandler creates a read-only http.Handler serving the games in store as JSON.
+//
+//	GET /chains                         lists the monitored chains
+//	GET /games?chain=<name>             lists the games for a chain, without their claims
+//	GET /games/<address>?chain=<name>   returns the full details of a game, including its claims
+//
+// The chain parameter may be omitted when only a single chain is monitored.
+func NewHandler(logger log.Logger, store *Store) http.Handler {
+	h := &handler{logger: logger, store: store}
+	mux := http.NewServeMux()
+	mux.HandleFunc(chainsPath, h.getOnly(h.handleChains))
+	mux.HandleFunc(gamesPath, h.getOnly(h.handleGames))
+	mux.HandleFunc(gamesPath+"/", h.getOnly(h.handleGame))
+	return mux
+}
+
+// StartServer starts an HTTP server serving the API for store.
+func StartServer(logger log.Logger, store *Store, hostname string, port int) (*httputil.HTTPServer, error) {
+	addr := net.JoinHostPort(hostname, strconv.Itoa(port))
+	return httputil.StartHTTPServer(addr, NewHandler(logger, store))
+}
+
+func (h *handler) getOnly(fn http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			h.writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %v not allowed", r.Method))
+			return
+		}
+		fn(w, r)
+	}
+}
+
+func (h *handler) handleChains(w http.ResponseWriter, _ *http.Request) {
+	names := h.store.Chains()
+	sort.Strings(names)
+	chains := make([]ChainSummary, 0, len(names))
+	for _, name := range names {
+		games, _ := h.store.Games(name)
+		chains = append(chains, ChainSummary{
+			Name:    name,
+			Updated: unixOrZero(games),
+			Games:   len(games.Games),
+		})
+	}
+	h.writeJSON(w, chains)
+}
+
+func (h *handler) handleGames(w http.ResponseWriter, r *http.Request) {
+	chain, ok := h.chain(w, r)
+	if !ok {
+		return
+	}
+	games, _ := h.store.Games(chain)
+	summaries := make([]Game, 0, len(games.Games))
+	for _, game := range games.Games {
+		game.Claims = nil
+		summaries = append(summaries, game)
+	}
+	h.writeJSON(w, GamesResponse{
+		Chain:   chain,
+		Updated: unixOrZero(games),
+		Games:   summaries,
+	})
+}
+
+func (h *handler) handleGame(w http.ResponseWriter, r *http.Request) {
+	chain, ok := h.chain(w, r)
+	if !ok {
+		return
+	}
+	addrStr := strings.TrimPrefix(r.URL.Path, gamesPath+"/")
+	if !common.IsHexAddress(addrStr) {
+		h.writeError(w, http.StatusBadRequest, fmt.Errorf("invalid game address: %v", addrStr))
+		return
+	}
+	game, ok := h.store.Game(chain, common.HexToAddress(addrStr))
+	if !ok {
+		h.writeError(w, http.StatusNotFound, fmt.Errorf("game %v not found", addrStr))
+		return
+	}
+	h.writeJSON(w, game)
+}
+
+// chain returns the chain requested, defaulting to the only chain if just one is monitored.
+// Writes an error response and returns false if the chain is not valid.
+func (h *handler) chain(w http.ResponseWriter, r *http.Request) (string, bool) {
+	if !r.URL.Query().Has(chainParam) {
+		chains := h.store.Chains()
+		if len(chains) != 1 {
+			h.writeError(w, http.StatusBadRequest, fmt.Errorf("%v parameter is required when monitoring multiple chains", chainParam))
+			return "", false
+		}
+		return chains[0], true
+	}
+	chain := r.URL.Query().Get(chainParam)
+	if _, ok := h.store.Games(chain); !ok {
+		h.writeError(w, http.StatusNotFound, fmt.Errorf("unknown chain: %v", chain))
+		return "", false
+	}
+	return chain, true
+}
+
+func (h *handler) writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		h.logger.Warn("Failed to write API response", "err", err)
+	}
+}
+
+func (h *handler) writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(errorResponse{Error: err.Error()}); err != nil {
+		h.logger.Warn("Failed to write API error response", "err", err)
+	}
+}
+
+func unixOrZero(games ChainGames) uint64 {
+	if games.Updated.IsZero() {
+		return 0
+	}
+	return uint64(games.Updated.Unix())
+}
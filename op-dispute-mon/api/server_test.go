@@ -0,0 +1,129 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	gameAddr1 = common.Address{0xaa}
+	gameAddr2 = common.Address{0xbb}
+	updated   = time.Unix(1234, 0)
+)
+
+func TestHandler_Chains(t *testing.T) {
+	store := NewStore("chain-b", "chain-a")
+	store.UpdateGames("chain-a", updated, []Game{{Address: gameAddr1}, {Address: gameAddr2}})
+	var chains []ChainSummary
+	requestJSON(t, store, "/chains", http.StatusOK, &chains)
+	require.Equal(t, []ChainSummary{
+		{Name: "chain-a", Updated: 1234, Games: 2},
+		{Name: "chain-b", Updated: 0, Games: 0},
+	}, chains)
+}
+
+func TestHandler_Games(t *testing.T) {
+	t.Run("SingleChain", func(t *testing.T) {
+		store := NewStore("")
+		store.UpdateGames("", updated, []Game{gameWithClaims(gameAddr1), gameWithClaims(gameAddr2)})
+		var resp GamesResponse
+		requestJSON(t, store, "/games", http.StatusOK, &resp)
+		require.Equal(t, uint64(1234), resp.Updated)
+		require.Len(t, resp.Games, 2)
+		require.Equal(t, gameAddr1, resp.Games[0].Address)
+		require.Equal(t, gameAddr2, resp.Games[1].Address)
+		for _, game := range resp.Games {
+			require.Empty(t, game.Claims, "should not include claims when listing games")
+		}
+	})
+
+	t.Run("ChainRequiredForMultipleChains", func(t *testing.T) {
+		store := NewStore("chain-a", "chain-b")
+		requestJSON(t, store, "/games", http.StatusBadRequest, nil)
+	})
+
+	t.Run("SelectChain", func(t *testing.T) {
+		store := NewStore("chain-a", "chain-b")
+		store.UpdateGames("chain-b", updated, []Game{{Address: gameAddr2}})
+		var resp GamesResponse
+		requestJSON(t, store, "/games?chain=chain-b", http.StatusOK, &resp)
+		require.Equal(t, "chain-b", resp.Chain)
+		require.Len(t, resp.Games, 1)
+		require.Equal(t, gameAddr2, resp.Games[0].Address)
+	})
+
+	t.Run("NotYetLoaded", func(t *testing.T) {
+		store := NewStore("chain-a")
+		var resp GamesResponse
+		requestJSON(t, store, "/games?chain=chain-a", http.StatusOK, &resp)
+		require.Zero(t, resp.Updated)
+		require.Empty(t, resp.Games)
+	})
+
+	t.Run("UnknownChain", func(t *testing.T) {
+		store := NewStore("chain-a")
+		requestJSON(t, store, "/games?chain=chain-z", http.StatusNotFound, nil)
+	})
+
+	t.Run("OnlyGetAllowed", func(t *testing.T) {
+		store := NewStore("")
+		handler := NewHandler(testlog.Logger(t, log.LvlInfo), store)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/games", nil))
+		require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
+
+func TestHandler_Game(t *testing.T) {
+	store := NewStore("")
+	store.UpdateGames("", updated, []Game{gameWithClaims(gameAddr1)})
+
+	t.Run("Found", func(t *testing.T) {
+		var game Game
+		requestJSON(t, store, "/games/"+gameAddr1.Hex(), http.StatusOK, &game)
+		require.Equal(t, gameWithClaims(gameAddr1), game)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		requestJSON(t, store, "/games/"+gameAddr2.Hex(), http.StatusNotFound, nil)
+	})
+
+	t.Run("InvalidAddress", func(t *testing.T) {
+		requestJSON(t, store, "/games/0xnope", http.StatusBadRequest, nil)
+	})
+}
+
+func requestJSON(t *testing.T, store *Store, path string, expectedStatus int, result any) {
+	handler := NewHandler(testlog.Logger(t, log.LvlInfo), store)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	require.Equal(t, expectedStatus, rec.Code, "unexpected status: %v", rec.Body.String())
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	if result == nil {
+		var errResp errorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errResp))
+		require.NotEmpty(t, errResp.Error)
+		return
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), result))
+}
+
+func gameWithClaims(addr common.Address) Game {
+	parent := 0
+	return Game{
+		Address: addr,
+		Status:  "In Progress",
+		Claims: []Claim{
+			{Index: 0, Claimant: common.Address{0x01}, OutputAgreement: "agree"},
+			{Index: 1, ParentIndex: &parent, Claimant: common.Address{0x02}, OutputAgreement: "disagree"},
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ChainGames is the latest set of games loaded for a chain.
+type ChainGames struct {
+	// Updated is the time the games were last updated. Zero if no games have been loaded yet.
+	Updated time.Time
+	Games   []Game
+}
+
+// Store holds the latest games loaded for each monitored chain so they can be served by the API.
+type Store struct {
+	mu     sync.RWMutex
+	chains map[string]*ChainGames
+}
+
+// NewStore creates a Store for the named chains.
+func NewStore(chains ...string) *Store {
+	s := &Store{chains: make(map[string]*ChainGames, len(chains))}
+	for _, chain := range chains {
+		s.chains[chain] = &ChainGames{}
+	}
+	return s
+}
+
+// UpdateGames replaces the games stored for chain.
+func (s *Store) UpdateGames(chain string, updated time.Time, games []Game) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chains[chain] = &ChainGames{Updated: updated, Games: games}
+}
+
+// Chains returns the names of the chains in the store.
+func (s *Store) Chains() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	chains := make([]string, 0, len(s.chains))
+	for chain := range s.chains {
+		chains = append(chains, chain)
+	}
+	return chains
+}
+
+// Games returns the latest games for chain.
+// Returns false if the chain is not in the store.
+func (s *Store) Games(chain string) (ChainGames, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	games, ok := s.chains[chain]
+	if !ok {
+		return ChainGames{}, false
+	}
+	return *games, true
+}
+
+// Game returns the latest data for the game at addr on chain.
+// Returns false if the chain is not in the store or doesn't have a game at addr.
+func (s *Store) Game(chain string, addr common.Address) (Game, bool) {
+	games, ok := s.Games(chain)
+	if !ok {
+		return Game{}, false
+	}
+	for _, game := range games.Games {
+		if game.Address == addr {
+			return game, true
+		}
+	}
+	return Game{}, false
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"math/big"
+	"time"
+
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Game is the JSON representation of a monitored dispute game.
+type Game struct {
+	Address               common.Address `json:"address"`
+	Index                 uint64         `json:"index"`
+	GameType              uint32         `json:"gameType"`
+	Timestamp             uint64         `json:"timestamp"`
+	L1Head                common.Hash    `json:"l1Head"`
+	L1HeadNum             uint64         `json:"l1HeadNum"`
+	L2BlockNumber         uint64         `json:"l2BlockNumber"`
+	RootClaim             common.Hash    `json:"rootClaim"`
+	Status                string         `json:"status"`
+	MaxClockDuration      uint64         `json:"maxClockDuration"`
+	BlockNumberChallenged bool           `json:"blockNumberChallenged"`
+	AgreeWithRootClaim    bool           `json:"agreeWithRootClaim"`
+	ExpectedRootClaim     common.Hash    `json:"expectedRootClaim"`
+
+	// Forecast is only set for games that are in progress.
+	Forecast *Forecast `json:"forecast,omitempty"`
+
+	Bonds              *ActorBondExposure                    `json:"bonds"`
+	Credits            map[common.Address]*hexutil.Big       `json:"credits"`
+	WithdrawalRequests map[common.Address]*WithdrawalRequest `json:"withdrawalRequests"`
+	WETHContract       common.Address                        `json:"wethContract"`
+	WETHDelay          uint64                                `json:"wethDelay"`
+	ETHCollateral      *hexutil.Big                          `json:"ethCollateral"`
+
+	// Claims are omitted when listing games.
+	Claims []Claim `json:"claims,omitempty"`
+}
+
+// Forecast is the expected resolution of an in progress game.
+type Forecast struct {
+	// Status is the status the game would resolve with based on its current claims.
+	Status string `json:"status"`
+	// ExpectedResolution is the status the game is expected to resolve with once honest actors
+	// have countered every claim they still can.
+	ExpectedResolution string `json:"expectedResolution"`
+}
+
+type Claim struct {
+	Index           int            `json:"index"`
+	ParentIndex     *int           `json:"parentIndex,omitempty"`
+	Depth           uint64         `json:"depth"`
+	GIndex          *hexutil.Big   `json:"gIndex"`
+	Value           common.Hash    `json:"value"`
+	Bond            *hexutil.Big   `json:"bond"`
+	Claimant        common.Address `json:"claimant"`
+	CounteredBy     common.Address `json:"counteredBy"`
+	ClockDuration   uint64         `json:"clockDuration"`
+	ClockTimestamp  uint64         `json:"clockTimestamp"`
+	Resolved        bool           `json:"resolved"`
+	OutputAgreement string         `json:"outputAgreement"`
+}
+
+type WithdrawalRequest struct {
+	Amount    *hexutil.Big `json:"amount"`
+	Timestamp *hexutil.Big `json:"timestamp"`
+}
+
+type BondExposure struct {
+	AtRisk             *hexutil.Big `json:"atRisk"`
+	LockedCredit       *hexutil.Big `json:"lockedCredit"`
+	WithdrawableCredit *hexutil.Big `json:"withdrawableCredit"`
+}
+
+type ActorBondExposure struct {
+	Honest   BondExposure `json:"honest"`
+	External BondExposure `json:"external"`
+}
+
+// NewForecast creates the JSON representation of a game forecast.
+func NewForecast(status gameTypes.GameStatus, expectedResolution gameTypes.GameStatus) *Forecast {
+	return &Forecast{
+		Status:             status.String(),
+		ExpectedResolution: expectedResolution.String(),
+	}
+}
+
+// NewGame creates the JSON representation of an enriched game.
+// forecast may be nil if the game is not in progress.
+func NewGame(game *monTypes.EnrichedGameData, forecast *Forecast, exposure *metrics.ActorBondExposure) Game {
+	result := Game{
+		Address:               game.Proxy,
+		Index:                 game.Index,
+		GameType:              game.GameType,
+		Timestamp:             game.Timestamp,
+		L1Head:                game.L1Head,
+		L1HeadNum:             game.L1HeadNum,
+		L2BlockNumber:         game.L2BlockNumber,
+		RootClaim:             game.RootClaim,
+		Status:                game.Status.String(),
+		MaxClockDuration:      game.MaxClockDuration,
+		BlockNumberChallenged: game.BlockNumberChallenged,
+		AgreeWithRootClaim:    game.AgreeWithClaim,
+		ExpectedRootClaim:     game.ExpectedRootClaim,
+		Forecast:              forecast,
+		Credits:               make(map[common.Address]*hexutil.Big, len(game.Credits)),
+		WithdrawalRequests:    make(map[common.Address]*WithdrawalRequest, len(game.WithdrawalRequests)),
+		WETHContract:          game.WETHContract,
+		WETHDelay:             uint64(game.WETHDelay / time.Second),
+		ETHCollateral:         toBig(game.ETHCollateral),
+		Claims:                make([]Claim, 0, len(game.Claims)),
+	}
+	if exposure != nil {
+		result.Bonds = &ActorBondExposure{
+			Honest:   newBondExposure(exposure.Honest),
+			External: newBondExposure(exposure.Unknown),
+		}
+	}
+	for recipient, credit := range game.Credits {
+		result.Credits[recipient] = toBig(credit)
+	}
+	for recipient, request := range game.WithdrawalRequests {
+		result.WithdrawalRequests[recipient] = &WithdrawalRequest{
+			Amount:    toBig(request.Amount),
+			Timestamp: toBig(request.Timestamp),
+		}
+	}
+	for _, claim := range game.Claims {
+		result.Claims = append(result.Claims, newClaim(claim))
+	}
+	return result
+}
+
+func newClaim(claim monTypes.EnrichedClaim) Claim {
+	result := Claim{
+		Index:           claim.ContractIndex,
+		Depth:           uint64(claim.Depth()),
+		GIndex:          toBig(claim.Position.ToGIndex()),
+		Value:           claim.Value,
+		Bond:            toBig(claim.Bond),
+		Claimant:        claim.Claimant,
+		CounteredBy:     claim.CounteredBy,
+		ClockDuration:   uint64(claim.Clock.Duration / time.Second),
+		ClockTimestamp:  uint64(claim.Clock.Timestamp.Unix()),
+		Resolved:        claim.Resolved,
+		OutputAgreement: outputAgreement(claim.OutputAgreement),
+	}
+	if !claim.IsRoot() {
+		parent := claim.ParentContractIndex
+		result.ParentIndex = &parent
+	}
+	return result
+}
+
+func outputAgreement(agreement monTypes.OutputAgreement) string {
+	switch agreement {
+	case monTypes.OutputAgreementAgree:
+		return "agree"
+	case monTypes.OutputAgreementDisagree:
+		return "disagree"
+	default:
+		return "unknown"
+	}
+}
+
+func newBondExposure(exposure metrics.BondExposure) BondExposure {
+	return BondExposure{
+		AtRisk:             toBig(exposure.AtRisk),
+		LockedCredit:       toBig(exposure.LockedCredit),
+		WithdrawableCredit: toBig(exposure.WithdrawableCredit),
+	}
+}
+
+func toBig(i *big.Int) *hexutil.Big {
+	if i == nil {
+		return nil
+	}
+	return (*hexutil.Big)(new(big.Int).Set(i))
+}
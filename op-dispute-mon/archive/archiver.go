@@ -0,0 +1,209 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"slices"
+
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	insertGame = `INSERT INTO games (
+		chain, address, game_index, game_type, created_at, l1_head, l1_head_num, l2_block_number, root_claim,
+		expected_root_claim, agree_with_root_claim, status, max_clock_duration, block_number_challenged,
+		block_number_challenger, weth_contract, weth_delay
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+	ON CONFLICT (chain, address) DO UPDATE SET
+		expected_root_claim = EXCLUDED.expected_root_claim,
+		agree_with_root_claim = EXCLUDED.agree_with_root_claim,
+		status = EXCLUDED.status,
+		block_number_challenged = EXCLUDED.block_number_challenged,
+		block_number_challenger = EXCLUDED.block_number_challenger,
+		archived_at = now()`
+
+	insertClaim = `INSERT INTO claims (
+		chain, game_address, claim_index, parent_index, move, depth, gindex, value, bond, claimant, countered_by,
+		clock_duration, clock_timestamp, response_time, resolved, output_agreement
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+	ON CONFLICT (chain, game_address, claim_index) DO UPDATE SET
+		bond = EXCLUDED.bond,
+		countered_by = EXCLUDED.countered_by,
+		clock_duration = EXCLUDED.clock_duration,
+		resolved = EXCLUDED.resolved,
+		output_agreement = EXCLUDED.output_agreement`
+
+	insertCredit = `INSERT INTO credits (chain, game_address, recipient, amount) VALUES ($1, $2, $3, $4)
+	ON CONFLICT (chain, game_address, recipient) DO UPDATE SET amount = EXCLUDED.amount`
+)
+
+// Archiver writes resolved games, including their claims and paid out credits, to a SQL database
+// for long-term analysis. Games are only archived once they are no longer in progress.
+// Credits and claims can still change after the game is resolved, e.g. when credits are claimed,
+// so archived games are updated whenever their data changes.
+type Archiver struct {
+	logger log.Logger
+	db     *sql.DB
+	chain  string
+
+	// archived tracks a digest of the games that have already been written so they are only rewritten when they change.
+	archived map[common.Address]common.Hash
+}
+
+func NewArchiver(logger log.Logger, db *sql.DB, chain string) *Archiver {
+	return &Archiver{
+		logger:   logger,
+		db:       db,
+		chain:    chain,
+		archived: make(map[common.Address]common.Hash),
+	}
+}
+
+func (a *Archiver) ArchiveGames(ctx context.Context, games []*types.EnrichedGameData) error {
+	archived := make(map[common.Address]common.Hash)
+	count := 0
+	var result error
+	for _, game := range games {
+		if game.Status == gameTypes.GameStatusInProgress {
+			continue
+		}
+		digest := gameDigest(game)
+		if prev, ok := a.archived[game.Proxy]; ok && prev == digest {
+			archived[game.Proxy] = digest
+			continue
+		}
+		if err := a.archiveGame(ctx, game); err != nil {
+			// Keep going so one bad game doesn't prevent the rest being archived. It will be retried next cycle.
+			result = fmt.Errorf("failed to archive game %v: %w", game.Proxy, err)
+			continue
+		}
+		archived[game.Proxy] = digest
+		count++
+	}
+	// Only retain games still being monitored so the set doesn't grow without bound.
+	a.archived = archived
+	if count > 0 {
+		a.logger.Info("Archived games", "count", count)
+	}
+	return result
+}
+
+func (a *Archiver) archiveGame(ctx context.Context, game *types.EnrichedGameData) error {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	if _, err := tx.ExecContext(ctx, insertGame,
+		a.chain,
+		game.Proxy.Hex(),
+		game.Index,
+		game.GameType,
+		game.Timestamp,
+		game.L1Head.Hex(),
+		game.L1HeadNum,
+		game.L2BlockNumber,
+		game.RootClaim.Hex(),
+		game.ExpectedRootClaim.Hex(),
+		game.AgreeWithClaim,
+		game.Status.String(),
+		game.MaxClockDuration,
+		game.BlockNumberChallenged,
+		game.BlockNumberChallenger.Hex(),
+		game.WETHContract.Hex(),
+		int64(game.WETHDelay.Seconds()),
+	); err != nil {
+		return fmt.Errorf("failed to insert game: %w", err)
+	}
+	for _, claim := range game.Claims {
+		var parentIndex, move, responseTime any
+		if !claim.IsRoot() {
+			parent := game.Claims[claim.ParentContractIndex]
+			parentIndex = claim.ParentContractIndex
+			move = "attack"
+			if claim.Position.RightOf(parent.Position) {
+				move = "defend"
+			}
+			responseTime = int64(claim.Clock.Timestamp.Sub(parent.Clock.Timestamp).Seconds())
+		}
+		if _, err := tx.ExecContext(ctx, insertClaim,
+			a.chain,
+			game.Proxy.Hex(),
+			claim.ContractIndex,
+			parentIndex,
+			move,
+			claim.Depth(),
+			claim.Position.ToGIndex().String(),
+			claim.Value.Hex(),
+			bigString(claim.Bond),
+			claim.Claimant.Hex(),
+			claim.CounteredBy.Hex(),
+			int64(claim.Clock.Duration.Seconds()),
+			claim.Clock.Timestamp.Unix(),
+			responseTime,
+			claim.Resolved,
+			outputAgreement(claim.OutputAgreement),
+		); err != nil {
+			return fmt.Errorf("failed to insert claim %v: %w", claim.ContractIndex, err)
+		}
+	}
+	for recipient, amount := range game.Credits {
+		if _, err := tx.ExecContext(ctx, insertCredit, a.chain, game.Proxy.Hex(), recipient.Hex(), bigString(amount)); err != nil {
+			return fmt.Errorf("failed to insert credit for %v: %w", recipient, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	return nil
+}
+
+// gameDigest summarises the game data that can change after the game is resolved,
+// to detect when an archived game has to be updated.
+func gameDigest(game *types.EnrichedGameData) common.Hash {
+	var buf bytes.Buffer
+	_, _ = fmt.Fprintf(&buf, "%v|%v|%v|%v|%v;", game.Status, game.ExpectedRootClaim, game.AgreeWithClaim,
+		game.BlockNumberChallenged, game.BlockNumberChallenger)
+	for _, claim := range game.Claims {
+		_, _ = fmt.Fprintf(&buf, "%v|%v|%v|%v|%v;", bigString(claim.Bond), claim.CounteredBy,
+			claim.Clock.Duration, claim.Resolved, claim.OutputAgreement)
+	}
+	recipients := make([]common.Address, 0, len(game.Credits))
+	for recipient := range game.Credits {
+		recipients = append(recipients, recipient)
+	}
+	slices.SortFunc(recipients, func(a, b common.Address) int {
+		return a.Cmp(b)
+	})
+	for _, recipient := range recipients {
+		_, _ = fmt.Fprintf(&buf, "%v|%v;", recipient, bigString(game.Credits[recipient]))
+	}
+	return crypto.Keccak256Hash(buf.Bytes())
+}
+
+func bigString(i *big.Int) string {
+	if i == nil {
+		return "0"
+	}
+	return i.String()
+}
+
+func outputAgreement(agreement types.OutputAgreement) string {
+	switch agreement {
+	case types.OutputAgreementAgree:
+		return "agree"
+	case types.OutputAgreementDisagree:
+		return "disagree"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,179 @@
+package archive
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	gameAddr  = common.Address{0xaa}
+	claimant1 = common.Address{0x01}
+	claimant2 = common.Address{0x02}
+	created   = time.Unix(1000, 0)
+)
+
+func TestArchiveGames(t *testing.T) {
+	t.Run("SkipInProgressGames", func(t *testing.T) {
+		archiver, d := setupArchiverTest(t)
+		game := resolvedGame()
+		game.Status = gameTypes.GameStatusInProgress
+		require.NoError(t, archiver.ArchiveGames(context.Background(), []*types.EnrichedGameData{game}))
+		require.Empty(t, d.execsMatching("INSERT INTO"))
+	})
+
+	t.Run("ArchiveResolvedGame", func(t *testing.T) {
+		archiver, d := setupArchiverTest(t)
+		game := resolvedGame()
+		require.NoError(t, archiver.ArchiveGames(context.Background(), []*types.EnrichedGameData{game}))
+		require.Equal(t, 1, d.commits)
+
+		games := d.execsMatching("INSERT INTO games")
+		require.Len(t, games, 1)
+		require.Equal(t, "chain-a", games[0].args[0])
+		require.Equal(t, gameAddr.Hex(), games[0].args[1])
+		require.EqualValues(t, 3, games[0].args[2])
+		require.Equal(t, gameTypes.GameStatusChallengerWon.String(), games[0].args[11])
+		require.EqualValues(t, 3600, games[0].args[16])
+
+		claims := d.execsMatching("INSERT INTO claims")
+		require.Len(t, claims, 3)
+		root := claims[0].args
+		require.EqualValues(t, 0, root[2])
+		require.Nil(t, root[3], "root claim has no parent")
+		require.Nil(t, root[4], "root claim has no move")
+		require.Nil(t, root[13], "root claim has no response time")
+		require.Equal(t, "1", root[6])
+		require.Equal(t, "100", root[8])
+		require.Equal(t, claimant2.Hex(), root[10])
+		require.Equal(t, "disagree", root[15])
+
+		attack := claims[1].args
+		require.EqualValues(t, 0, attack[3])
+		require.Equal(t, "attack", attack[4])
+		require.EqualValues(t, 1, attack[5])
+		require.Equal(t, "2", attack[6])
+		require.EqualValues(t, 30, attack[13])
+		require.Equal(t, "agree", attack[15])
+
+		defend := claims[2].args
+		require.EqualValues(t, 1, defend[3])
+		require.Equal(t, "defend", defend[4])
+		require.EqualValues(t, 2, defend[5])
+		require.EqualValues(t, 60, defend[13])
+		require.Equal(t, "unknown", defend[15])
+
+		credits := d.execsMatching("INSERT INTO credits")
+		require.Len(t, credits, 1)
+		require.Equal(t, claimant2.Hex(), credits[0].args[2])
+		require.Equal(t, "150", credits[0].args[3])
+	})
+
+	t.Run("OnlyArchiveOnce", func(t *testing.T) {
+		archiver, d := setupArchiverTest(t)
+		games := []*types.EnrichedGameData{resolvedGame()}
+		require.NoError(t, archiver.ArchiveGames(context.Background(), games))
+		require.NoError(t, archiver.ArchiveGames(context.Background(), games))
+		require.Len(t, d.execsMatching("INSERT INTO games"), 1)
+	})
+
+	t.Run("UpdateChangedGame", func(t *testing.T) {
+		archiver, d := setupArchiverTest(t)
+		game := resolvedGame()
+		games := []*types.EnrichedGameData{game}
+		require.NoError(t, archiver.ArchiveGames(context.Background(), games))
+
+		// Credits are claimed after the game is resolved
+		game.Credits = map[common.Address]*big.Int{claimant2: big.NewInt(0)}
+		require.NoError(t, archiver.ArchiveGames(context.Background(), games))
+		require.Len(t, d.execsMatching("INSERT INTO games"), 2)
+		credits := d.execsMatching("INSERT INTO credits")
+		require.Len(t, credits, 2)
+		require.Equal(t, "0", credits[1].args[3])
+		require.Equal(t, 2, d.commits)
+
+		// Unchanged games are not rewritten
+		require.NoError(t, archiver.ArchiveGames(context.Background(), games))
+		require.Len(t, d.execsMatching("INSERT INTO games"), 2)
+	})
+
+	t.Run("RetryAfterFailure", func(t *testing.T) {
+		archiver, d := setupArchiverTest(t)
+		games := []*types.EnrichedGameData{resolvedGame()}
+		d.failOn = "INSERT INTO credits"
+		require.ErrorIs(t, archiver.ArchiveGames(context.Background(), games), errFakeDB)
+		require.Zero(t, d.commits)
+		require.Equal(t, 1, d.rollbacks)
+
+		d.failOn = ""
+		require.NoError(t, archiver.ArchiveGames(context.Background(), games))
+		require.Equal(t, 1, d.commits)
+	})
+}
+
+func setupArchiverTest(t *testing.T) (*Archiver, *stubDriver) {
+	db, d := newStubDB(t)
+	return NewArchiver(testlog.Logger(t, log.LvlInfo), db, "chain-a"), d
+}
+
+func resolvedGame() *types.EnrichedGameData {
+	rootPos := faultTypes.RootPosition
+	attackPos := rootPos.Attack()
+	defendPos := attackPos.Defend()
+	return &types.EnrichedGameData{
+		GameMetadata: gameTypes.GameMetadata{
+			Index:     3,
+			GameType:  1,
+			Timestamp: uint64(created.Unix()),
+			Proxy:     gameAddr,
+		},
+		Status:           gameTypes.GameStatusChallengerWon,
+		MaxClockDuration: 7200,
+		WETHDelay:        time.Hour,
+		Claims: []types.EnrichedClaim{
+			{
+				Claim: faultTypes.Claim{
+					ClaimData:           faultTypes.ClaimData{Bond: big.NewInt(100), Position: rootPos},
+					Claimant:            claimant1,
+					CounteredBy:         claimant2,
+					ParentContractIndex: math.MaxUint32,
+					Clock:               faultTypes.Clock{Timestamp: created},
+				},
+				Resolved:        true,
+				OutputAgreement: types.OutputAgreementDisagree,
+			},
+			{
+				Claim: faultTypes.Claim{
+					ClaimData:           faultTypes.ClaimData{Bond: big.NewInt(50), Position: attackPos},
+					Claimant:            claimant2,
+					ContractIndex:       1,
+					ParentContractIndex: 0,
+					Clock:               faultTypes.Clock{Duration: 30 * time.Second, Timestamp: created.Add(30 * time.Second)},
+				},
+				Resolved:        true,
+				OutputAgreement: types.OutputAgreementAgree,
+			},
+			{
+				Claim: faultTypes.Claim{
+					ClaimData:           faultTypes.ClaimData{Bond: big.NewInt(25), Position: defendPos},
+					Claimant:            claimant1,
+					ContractIndex:       2,
+					ParentContractIndex: 1,
+					Clock:               faultTypes.Clock{Duration: time.Minute, Timestamp: created.Add(90 * time.Second)},
+				},
+				Resolved: true,
+			},
+		},
+		Credits: map[common.Address]*big.Int{claimant2: big.NewInt(150)},
+	}
+}
@@ -0,0 +1,149 @@
+package archive
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errFakeDB = errors.New("fake db error")
+
+// stubDriver is a minimal database/sql driver that records executed statements so tests can verify
+// the SQL issued without requiring a real database.
+type stubDriver struct {
+	mu sync.Mutex
+	// version is returned for any query, standing in for the current schema version.
+	version int64
+	// failOn causes any statement containing the string to fail.
+	failOn    string
+	execs     []stubExec
+	commits   int
+	rollbacks int
+}
+
+type stubExec struct {
+	query string
+	args  []driver.Value
+}
+
+var stubDriverCount atomic.Int64
+
+func newStubDB(t *testing.T) (*sql.DB, *stubDriver) {
+	d := &stubDriver{}
+	name := fmt.Sprintf("stub-%d", stubDriverCount.Add(1))
+	sql.Register(name, d)
+	db, err := sql.Open(name, "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	return db, d
+}
+
+func (d *stubDriver) Open(string) (driver.Conn, error) {
+	return &stubConn{d: d}, nil
+}
+
+// execsMatching returns the recorded statements containing the specified string.
+func (d *stubDriver) execsMatching(query string) []stubExec {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var result []stubExec
+	for _, exec := range d.execs {
+		if strings.Contains(exec.query, query) {
+			result = append(result, exec)
+		}
+	}
+	return result
+}
+
+type stubConn struct {
+	d *stubDriver
+}
+
+func (c *stubConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+
+func (c *stubConn) Close() error {
+	return nil
+}
+
+func (c *stubConn) Begin() (driver.Tx, error) {
+	return &stubTx{d: c.d}, nil
+}
+
+func (c *stubConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	if c.d.failOn != "" && strings.Contains(query, c.d.failOn) {
+		return nil, errFakeDB
+	}
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	c.d.execs = append(c.d.execs, stubExec{query: query, args: values})
+	return driver.RowsAffected(1), nil
+}
+
+func (c *stubConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	if c.d.failOn != "" && strings.Contains(query, c.d.failOn) {
+		return nil, errFakeDB
+	}
+	return &stubRows{values: []driver.Value{c.d.version}}, nil
+}
+
+type stubTx struct {
+	d *stubDriver
+}
+
+func (t *stubTx) Commit() error {
+	t.d.mu.Lock()
+	defer t.d.mu.Unlock()
+	if t.d.failOn == "COMMIT" {
+		return errFakeDB
+	}
+	t.d.commits++
+	return nil
+}
+
+func (t *stubTx) Rollback() error {
+	t.d.mu.Lock()
+	defer t.d.mu.Unlock()
+	t.d.rollbacks++
+	return nil
+}
+
+type stubRows struct {
+	values []driver.Value
+	done   bool
+}
+
+func (r *stubRows) Columns() []string {
+	return []string{"value"}
+}
+
+func (r *stubRows) Close() error {
+	return nil
+}
+
+func (r *stubRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.values)
+	return nil
+}
@@ -0,0 +1,57 @@
+CREATE TABLE games (
+    chain                   TEXT        NOT NULL,
+    address                 TEXT        NOT NULL,
+    game_index              BIGINT      NOT NULL,
+    game_type               INTEGER     NOT NULL,
+    created_at              BIGINT      NOT NULL,
+    l1_head                 TEXT        NOT NULL,
+    l1_head_num             BIGINT      NOT NULL,
+    l2_block_number         BIGINT      NOT NULL,
+    root_claim              TEXT        NOT NULL,
+    expected_root_claim     TEXT        NOT NULL,
+    agree_with_root_claim   BOOLEAN     NOT NULL,
+    status                  TEXT        NOT NULL,
+    max_clock_duration      BIGINT      NOT NULL,
+    block_number_challenged BOOLEAN     NOT NULL,
+    block_number_challenger TEXT        NOT NULL,
+    weth_contract           TEXT        NOT NULL,
+    weth_delay              BIGINT      NOT NULL,
+    archived_at             TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (chain, address)
+);
+
+CREATE INDEX games_created_at_idx ON games (chain, created_at);
+
+CREATE TABLE claims (
+    chain            TEXT    NOT NULL,
+    game_address     TEXT    NOT NULL,
+    claim_index      INTEGER NOT NULL,
+    parent_index     INTEGER,
+    move             TEXT,
+    depth            INTEGER NOT NULL,
+    gindex           NUMERIC NOT NULL,
+    value            TEXT    NOT NULL,
+    bond             NUMERIC NOT NULL,
+    claimant         TEXT    NOT NULL,
+    countered_by     TEXT    NOT NULL,
+    clock_duration   BIGINT  NOT NULL,
+    clock_timestamp  BIGINT  NOT NULL,
+    response_time    BIGINT,
+    resolved         BOOLEAN NOT NULL,
+    output_agreement TEXT    NOT NULL,
+    PRIMARY KEY (chain, game_address, claim_index),
+    FOREIGN KEY (chain, game_address) REFERENCES games (chain, address)
+);
+
+CREATE INDEX claims_claimant_idx ON claims (claimant);
+
+CREATE TABLE credits (
+    chain        TEXT    NOT NULL,
+    game_address TEXT    NOT NULL,
+    recipient    TEXT    NOT NULL,
+    amount       NUMERIC NOT NULL,
+    PRIMARY KEY (chain, game_address, recipient),
+    FOREIGN KEY (chain, game_address) REFERENCES games (chain, address)
+);
+
+CREATE INDEX credits_recipient_idx ON credits (recipient);
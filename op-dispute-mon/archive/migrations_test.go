@@ -0,0 +1,92 @@
+package archive
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	t.Run("Embedded", func(t *testing.T) {
+		migrations, err := loadMigrations(migrationFiles)
+		require.NoError(t, err)
+		require.NotEmpty(t, migrations)
+		for i, m := range migrations {
+			require.Equal(t, i+1, m.version, "migration versions should be sequential")
+			require.NotEmpty(t, m.sql)
+		}
+	})
+
+	t.Run("Ordered", func(t *testing.T) {
+		migrations, err := loadMigrations(fstest.MapFS{
+			"migrations/0010_later.sql":  {Data: []byte("later")},
+			"migrations/0002_second.sql": {Data: []byte("second")},
+			"migrations/0001_first.sql":  {Data: []byte("first")},
+		})
+		require.NoError(t, err)
+		require.Len(t, migrations, 3)
+		require.Equal(t, "first", migrations[0].sql)
+		require.Equal(t, "second", migrations[1].sql)
+		require.Equal(t, "later", migrations[2].sql)
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		_, err := loadMigrations(fstest.MapFS{"migrations/first.sql": {Data: []byte("first")}})
+		require.ErrorContains(t, err, "invalid migration name")
+	})
+
+	t.Run("InvalidVersion", func(t *testing.T) {
+		_, err := loadMigrations(fstest.MapFS{"migrations/abc_first.sql": {Data: []byte("first")}})
+		require.ErrorContains(t, err, "invalid migration version")
+	})
+
+	t.Run("DuplicateVersion", func(t *testing.T) {
+		_, err := loadMigrations(fstest.MapFS{
+			"migrations/0001_first.sql": {Data: []byte("first")},
+			"migrations/01_other.sql":   {Data: []byte("other")},
+		})
+		require.ErrorContains(t, err, "duplicate migration version")
+	})
+}
+
+func TestMigrate(t *testing.T) {
+	migrations, err := loadMigrations(migrationFiles)
+	require.NoError(t, err)
+	latest := migrations[len(migrations)-1].version
+
+	t.Run("ApplyAll", func(t *testing.T) {
+		db, d := newStubDB(t)
+		require.NoError(t, Migrate(context.Background(), testlog.Logger(t, log.LvlInfo), db))
+		require.Len(t, d.execsMatching("pg_advisory_xact_lock"), 1)
+		require.Len(t, d.execsMatching("CREATE TABLE IF NOT EXISTS schema_migrations"), 1)
+		recorded := d.execsMatching("INSERT INTO schema_migrations")
+		require.Len(t, recorded, len(migrations))
+		for i, exec := range recorded {
+			require.EqualValues(t, migrations[i].version, exec.args[0])
+		}
+		require.Len(t, d.execsMatching("CREATE TABLE games"), 1)
+		require.Equal(t, 1, d.commits)
+	})
+
+	t.Run("AlreadyUpToDate", func(t *testing.T) {
+		db, d := newStubDB(t)
+		d.version = int64(latest)
+		require.NoError(t, Migrate(context.Background(), testlog.Logger(t, log.LvlInfo), db))
+		require.Empty(t, d.execsMatching("INSERT INTO schema_migrations"))
+		require.Empty(t, d.execsMatching("CREATE TABLE games"))
+		require.Equal(t, 1, d.commits)
+	})
+
+	t.Run("FailedMigration", func(t *testing.T) {
+		db, d := newStubDB(t)
+		d.failOn = "CREATE TABLE games"
+		err := Migrate(context.Background(), testlog.Logger(t, log.LvlInfo), db)
+		require.ErrorIs(t, err, errFakeDB)
+		require.Zero(t, d.commits)
+		require.Equal(t, 1, d.rollbacks)
+	})
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -16,6 +17,9 @@ var (
 	ErrMissingGameFactoryAddress = errors.New("missing game factory address")
 	ErrMissingRollupRpc          = errors.New("missing rollup rpc url")
 	ErrMissingMaxConcurrency     = errors.New("missing max concurrency")
+	ErrMissingChainName          = errors.New("missing chain name")
+	ErrDuplicateChainName        = errors.New("duplicate chain name")
+	ErrInvalidAPIPort            = errors.New("invalid api port")
 )
 
 const (
@@ -30,6 +34,18 @@ const (
 
 	//DefaultMaxConcurrency is the default number of threads to use when fetching game data
 	DefaultMaxConcurrency = uint(5)
+
+	// DefaultResolutionDelayThreshold is the default time a game can remain unresolved
+	// after it becomes resolvable before it is reported as delayed.
+	DefaultResolutionDelayThreshold = time.Minute
+	// DefaultUncounteredClaimThreshold is the default time a claim the monitor disagrees with
+	// can remain uncountered before it is reported.
+	DefaultUncounteredClaimThreshold = time.Hour
+
+	// DefaultAPIListenAddr is the default address the API server listens on when enabled.
+	DefaultAPIListenAddr = "0.0.0.0"
+	// DefaultAPIListenPort is the default port the API server listens on when enabled.
+	DefaultAPIListenPort = 7310
 )
 
 // Config is a well typed config that is parsed from the CLI params.
@@ -45,10 +61,47 @@ type Config struct {
 	IgnoredGames    []common.Address // Games to exclude from monitoring
 	MaxConcurrency  uint             // Maximum number of threads to use when fetching game data
 
+	ResolutionDelayThreshold  time.Duration // Time a resolvable game can remain unresolved before it is reported as delayed.
+	UncounteredClaimThreshold time.Duration // Time an invalid claim can remain uncountered before it is reported.
+
+	OptimismPortalAddress common.Address // Address of the OptimismPortal to check withdrawal proofs for. Disabled if not set.
+
+	// Chains to monitor. If empty, only the chain specified by L1EthRpc, GameFactoryAddress,
+	// RollupRpc and OptimismPortalAddress is monitored.
+	Chains []ChainConfig
+
+	APIEnabled    bool   // Whether to serve the monitored game data via the HTTP API.
+	APIListenAddr string // Address the API server listens on.
+	APIListenPort int    // Port the API server listens on.
+
+	ArchiveDatabaseURL string // Postgres connection URL to archive resolved games to. Disabled if not set.
+
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
 }
 
+// ChainConfig specifies a single chain to monitor.
+type ChainConfig struct {
+	Name                  string         `json:"name"`                  // Name of the chain, used to label its metrics
+	L1EthRpc              string         `json:"l1EthRpc"`              // L1 RPC Url
+	GameFactoryAddress    common.Address `json:"gameFactoryAddress"`    // Address of the dispute game factory
+	RollupRpc             string         `json:"rollupRpc"`             // The rollup node RPC URL.
+	OptimismPortalAddress common.Address `json:"optimismPortalAddress"` // Address of the OptimismPortal. Optional.
+}
+
+func (c ChainConfig) Check() error {
+	if c.L1EthRpc == "" {
+		return ErrMissingL1EthRPC
+	}
+	if c.RollupRpc == "" {
+		return ErrMissingRollupRpc
+	}
+	if c.GameFactoryAddress == (common.Address{}) {
+		return ErrMissingGameFactoryAddress
+	}
+	return nil
+}
+
 func NewConfig(gameFactoryAddress common.Address, l1EthRpc string, rollupRpc string) Config {
 	return Config{
 		L1EthRpc:           l1EthRpc,
@@ -59,24 +112,40 @@ func NewConfig(gameFactoryAddress common.Address, l1EthRpc string, rollupRpc str
 		GameWindow:      DefaultGameWindow,
 		MaxConcurrency:  DefaultMaxConcurrency,
 
+		ResolutionDelayThreshold:  DefaultResolutionDelayThreshold,
+		UncounteredClaimThreshold: DefaultUncounteredClaimThreshold,
+
+		APIListenAddr: DefaultAPIListenAddr,
+		APIListenPort: DefaultAPIListenPort,
+
 		MetricsConfig: opmetrics.DefaultCLIConfig(),
 		PprofConfig:   oppprof.DefaultCLIConfig(),
 	}
 }
 
-func (c Config) Check() error {
-	if c.L1EthRpc == "" {
-		return ErrMissingL1EthRPC
+// ChainConfigs returns the configuration for each chain to monitor.
+func (c Config) ChainConfigs() []ChainConfig {
+	if len(c.Chains) > 0 {
+		return c.Chains
 	}
-	if c.RollupRpc == "" {
-		return ErrMissingRollupRpc
-	}
-	if c.GameFactoryAddress == (common.Address{}) {
-		return ErrMissingGameFactoryAddress
+	return []ChainConfig{{
+		L1EthRpc:              c.L1EthRpc,
+		GameFactoryAddress:    c.GameFactoryAddress,
+		RollupRpc:             c.RollupRpc,
+		OptimismPortalAddress: c.OptimismPortalAddress,
+	}}
+}
+
+func (c Config) Check() error {
+	if err := c.checkChains(); err != nil {
+		return err
 	}
 	if c.MaxConcurrency == 0 {
 		return ErrMissingMaxConcurrency
 	}
+	if c.APIEnabled && (c.APIListenPort < 0 || c.APIListenPort > math.MaxUint16) {
+		return ErrInvalidAPIPort
+	}
 	if err := c.MetricsConfig.Check(); err != nil {
 		return fmt.Errorf("metrics config: %w", err)
 	}
@@ -85,3 +154,23 @@ func (c Config) Check() error {
 	}
 	return nil
 }
+
+func (c Config) checkChains() error {
+	if len(c.Chains) == 0 {
+		return c.ChainConfigs()[0].Check()
+	}
+	names := make(map[string]bool, len(c.Chains))
+	for _, chain := range c.Chains {
+		if chain.Name == "" {
+			return ErrMissingChainName
+		}
+		if names[chain.Name] {
+			return fmt.Errorf("%w: %v", ErrDuplicateChainName, chain.Name)
+		}
+		names[chain.Name] = true
+		if err := chain.Check(); err != nil {
+			return fmt.Errorf("chain %v: %w", chain.Name, err)
+		}
+	}
+	return nil
+}
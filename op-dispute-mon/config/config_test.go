@@ -1,6 +1,7 @@
 package config
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -45,3 +46,95 @@ func TestMaxConcurrencyRequired(t *testing.T) {
 	config.MaxConcurrency = 0
 	require.ErrorIs(t, config.Check(), ErrMissingMaxConcurrency)
 }
+
+func TestChains(t *testing.T) {
+	validChains := func() []ChainConfig {
+		return []ChainConfig{
+			{Name: "chain-a", L1EthRpc: validL1EthRpc, GameFactoryAddress: common.Address{0xaa}, RollupRpc: "http://localhost:9545"},
+			{Name: "chain-b", L1EthRpc: validL1EthRpc, GameFactoryAddress: common.Address{0xbb}, RollupRpc: "http://localhost:9546"},
+		}
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		config := validConfig()
+		config.Chains = validChains()
+		require.NoError(t, config.Check())
+		require.Equal(t, config.Chains, config.ChainConfigs())
+	})
+
+	t.Run("SingleChainFieldsNotRequired", func(t *testing.T) {
+		config := validConfig()
+		config.L1EthRpc = ""
+		config.RollupRpc = ""
+		config.GameFactoryAddress = common.Address{}
+		config.Chains = validChains()
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("DefaultsToSingleChain", func(t *testing.T) {
+		config := validConfig()
+		config.OptimismPortalAddress = common.Address{0xcc}
+		require.Equal(t, []ChainConfig{{
+			L1EthRpc:              validL1EthRpc,
+			GameFactoryAddress:    validGameFactoryAddress,
+			RollupRpc:             validRollupRpc,
+			OptimismPortalAddress: common.Address{0xcc},
+		}}, config.ChainConfigs())
+	})
+
+	t.Run("NameRequired", func(t *testing.T) {
+		config := validConfig()
+		config.Chains = validChains()
+		config.Chains[1].Name = ""
+		require.ErrorIs(t, config.Check(), ErrMissingChainName)
+	})
+
+	t.Run("NamesUnique", func(t *testing.T) {
+		config := validConfig()
+		config.Chains = validChains()
+		config.Chains[1].Name = config.Chains[0].Name
+		require.ErrorIs(t, config.Check(), ErrDuplicateChainName)
+	})
+
+	t.Run("L1EthRpcRequired", func(t *testing.T) {
+		config := validConfig()
+		config.Chains = validChains()
+		config.Chains[1].L1EthRpc = ""
+		require.ErrorIs(t, config.Check(), ErrMissingL1EthRPC)
+	})
+
+	t.Run("RollupRpcRequired", func(t *testing.T) {
+		config := validConfig()
+		config.Chains = validChains()
+		config.Chains[1].RollupRpc = ""
+		require.ErrorIs(t, config.Check(), ErrMissingRollupRpc)
+	})
+
+	t.Run("GameFactoryAddressRequired", func(t *testing.T) {
+		config := validConfig()
+		config.Chains = validChains()
+		config.Chains[1].GameFactoryAddress = common.Address{}
+		require.ErrorIs(t, config.Check(), ErrMissingGameFactoryAddress)
+	})
+}
+
+func TestAPIPort(t *testing.T) {
+	t.Run("IgnoredWhenDisabled", func(t *testing.T) {
+		config := validConfig()
+		config.APIListenPort = -1
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		config := validConfig()
+		config.APIEnabled = true
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		config := validConfig()
+		config.APIEnabled = true
+		config.APIListenPort = math.MaxUint16 + 1
+		require.ErrorIs(t, config.Check(), ErrInvalidAPIPort)
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/config"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -71,8 +72,64 @@ var (
 		EnvVars: prefixEnvVars("MAX_CONCURRENCY"),
 		Value:   config.DefaultMaxConcurrency,
 	}
+	ResolutionDelayThresholdFlag = &cli.DurationFlag{
+		Name:    "resolution-delay-threshold",
+		Usage:   "Time a game can remain unresolved after it becomes resolvable before it is reported as delayed.",
+		EnvVars: prefixEnvVars("RESOLUTION_DELAY_THRESHOLD"),
+		Value:   config.DefaultResolutionDelayThreshold,
+	}
+	UncounteredClaimThresholdFlag = &cli.DurationFlag{
+		Name:    "uncountered-claim-threshold",
+		Usage:   "Time an unresolved claim the monitor disagrees with can remain uncountered before it is reported.",
+		EnvVars: prefixEnvVars("UNCOUNTERED_CLAIM_THRESHOLD"),
+		Value:   config.DefaultUncounteredClaimThreshold,
+	}
+	OptimismPortalAddressFlag = &cli.StringFlag{
+		Name: "optimism-portal-address",
+		Usage: "Address of the OptimismPortal contract. " +
+			"If set, withdrawal proofs are checked against the dispute games they were proven with.",
+		EnvVars: prefixEnvVars("OPTIMISM_PORTAL_ADDRESS"),
+	}
+	APIEnabledFlag = &cli.BoolFlag{
+		Name:    "api.enabled",
+		Usage:   "Enable the read-only HTTP API serving the monitored game data",
+		EnvVars: prefixEnvVars("API_ENABLED"),
+	}
+	APIListenAddrFlag = &cli.StringFlag{
+		Name:    "api.addr",
+		Usage:   "API listening address",
+		EnvVars: prefixEnvVars("API_ADDR"),
+		Value:   config.DefaultAPIListenAddr,
+	}
+	APIListenPortFlag = &cli.IntFlag{
+		Name:    "api.port",
+		Usage:   "API listening port",
+		EnvVars: prefixEnvVars("API_PORT"),
+		Value:   config.DefaultAPIListenPort,
+	}
+	ArchiveDatabaseURLFlag = &cli.StringFlag{
+		Name: "archive-database-url",
+		Usage: "Postgres connection URL to archive resolved games to for long-term analysis. " +
+			"Schema migrations are applied automatically at startup. Archival is disabled if not set.",
+		EnvVars: prefixEnvVars("ARCHIVE_DATABASE_URL"),
+	}
+	ChainsConfigFlag = &cli.StringFlag{
+		Name: "chains-config",
+		Usage: "Path to a JSON file listing the chains to monitor, each with a name, l1EthRpc, gameFactoryAddress, " +
+			"rollupRpc and optional optimismPortalAddress. Replaces the single chain flags.",
+		EnvVars: prefixEnvVars("CHAINS_CONFIG"),
+	}
 )
 
+// singleChainFlags configure a single chain to monitor and can't be used with ChainsConfigFlag.
+var singleChainFlags = []cli.Flag{
+	L1EthRpcFlag,
+	RollupRpcFlag,
+	GameFactoryAddressFlag,
+	NetworkFlag,
+	OptimismPortalAddressFlag,
+}
+
 // requiredFlags are checked by [CheckRequired]
 var requiredFlags = []cli.Flag{
 	L1EthRpcFlag,
@@ -88,6 +145,14 @@ var optionalFlags = []cli.Flag{
 	GameWindowFlag,
 	IgnoredGamesFlag,
 	MaxConcurrencyFlag,
+	ResolutionDelayThresholdFlag,
+	UncounteredClaimThresholdFlag,
+	OptimismPortalAddressFlag,
+	ChainsConfigFlag,
+	APIEnabledFlag,
+	APIListenAddrFlag,
+	APIListenPortFlag,
+	ArchiveDatabaseURLFlag,
 }
 
 func init() {
@@ -102,6 +167,14 @@ func init() {
 var Flags []cli.Flag
 
 func CheckRequired(ctx *cli.Context) error {
+	if ctx.IsSet(ChainsConfigFlag.Name) {
+		for _, f := range singleChainFlags {
+			if ctx.IsSet(f.Names()[0]) {
+				return fmt.Errorf("flag %s can't be used with %s", f.Names()[0], ChainsConfigFlag.Name)
+			}
+		}
+		return nil
+	}
 	for _, f := range requiredFlags {
 		if !ctx.IsSet(f.Names()[0]) {
 			return fmt.Errorf("flag %s is required", f.Names()[0])
@@ -115,9 +188,20 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 	if err := CheckRequired(ctx); err != nil {
 		return nil, err
 	}
-	gameFactoryAddress, err := challengerFlags.FactoryAddress(ctx)
-	if err != nil {
-		return nil, err
+	var chains []config.ChainConfig
+	var gameFactoryAddress common.Address
+	if ctx.IsSet(ChainsConfigFlag.Name) {
+		loaded, err := jsonutil.LoadJSON[[]config.ChainConfig](ctx.String(ChainsConfigFlag.Name))
+		if err != nil {
+			return nil, fmt.Errorf("invalid chains config: %w", err)
+		}
+		chains = *loaded
+	} else {
+		addr, err := challengerFlags.FactoryAddress(ctx)
+		if err != nil {
+			return nil, err
+		}
+		gameFactoryAddress = addr
 	}
 
 	var actors []common.Address
@@ -147,6 +231,15 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 		return nil, fmt.Errorf("%v must not be 0", MaxConcurrencyFlag.Name)
 	}
 
+	var portalAddress common.Address
+	if ctx.IsSet(OptimismPortalAddressFlag.Name) {
+		addr, err := opservice.ParseAddress(ctx.String(OptimismPortalAddressFlag.Name))
+		if err != nil {
+			return nil, fmt.Errorf("invalid optimism portal address: %w", err)
+		}
+		portalAddress = addr
+	}
+
 	metricsConfig := opmetrics.ReadCLIConfig(ctx)
 	pprofConfig := oppprof.ReadCLIConfig(ctx)
 
@@ -161,6 +254,18 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 		IgnoredGames:    ignoredGames,
 		MaxConcurrency:  maxConcurrency,
 
+		ResolutionDelayThreshold:  ctx.Duration(ResolutionDelayThresholdFlag.Name),
+		UncounteredClaimThreshold: ctx.Duration(UncounteredClaimThresholdFlag.Name),
+
+		OptimismPortalAddress: portalAddress,
+		Chains:                chains,
+
+		APIEnabled:    ctx.Bool(APIEnabledFlag.Name),
+		APIListenAddr: ctx.String(APIListenAddrFlag.Name),
+		APIListenPort: ctx.Int(APIListenPortFlag.Name),
+
+		ArchiveDatabaseURL: ctx.String(ArchiveDatabaseURLFlag.Name),
+
 		MetricsConfig: metricsConfig,
 		PprofConfig:   pprofConfig,
 	}, nil
@@ -37,6 +37,31 @@ const (
 	InProgressBeforeMaxDuration
 )
 
+type WithdrawalProofStatus uint8
+
+const (
+	// Proven against a game we agree with
+	WithdrawalProofValid WithdrawalProofStatus = iota
+	// Proven against a game we disagree with
+	WithdrawalProofInvalid
+	// Proven against a game that isn't being monitored
+	WithdrawalProofUnverified
+)
+
+// ExternalActor is the actor label used for claimants that are not configured honest actors.
+const ExternalActor = "external"
+
+type ClaimCorrectness uint8
+
+const (
+	// Unresolved claims we agree with or resolved claims that were not countered
+	ClaimCorrect ClaimCorrectness = iota
+	// Unresolved claims we disagree with or resolved claims that were countered
+	ClaimIncorrect
+	// Unresolved claims we can't determine agreement for
+	ClaimCorrectnessUnknown
+)
+
 type CreditExpectation uint8
 
 const (
@@ -149,9 +174,40 @@ type HonestActorData struct {
 	WonBonds          *big.Int
 }
 
+// BondExposure is the ETH, in wei, locked in dispute games by a group of actors.
+type BondExposure struct {
+	// AtRisk is the total bond posted on claims that are not yet resolved.
+	AtRisk *big.Int
+	// LockedCredit is the total credit from resolved claims that can't be withdrawn from DelayedWETH yet.
+	LockedCredit *big.Int
+	// WithdrawableCredit is the total credit from resolved claims that can be withdrawn from DelayedWETH.
+	WithdrawableCredit *big.Int
+}
+
+// ActorBondExposure is the bond exposure of honest actors and of unknown addresses.
+type ActorBondExposure struct {
+	Honest  BondExposure
+	Unknown BondExposure
+}
+
+func NewActorBondExposure() *ActorBondExposure {
+	newExposure := func() BondExposure {
+		return BondExposure{
+			AtRisk:             big.NewInt(0),
+			LockedCredit:       big.NewInt(0),
+			WithdrawableCredit: big.NewInt(0),
+		}
+	}
+	return &ActorBondExposure{
+		Honest:  newExposure(),
+		Unknown: newExposure(),
+	}
+}
+
 type Metricer interface {
 	RecordInfo(version string)
 	RecordUp()
+	RecordInitFailed()
 
 	RecordMonitorDuration(dur time.Duration)
 
@@ -179,10 +235,24 @@ type Metricer interface {
 
 	RecordIgnoredGames(count int)
 
+	RecordIncorrectForecasts(count int)
+
+	RecordDelayedResolutions(count int)
+
+	RecordUncounteredInvalidClaims(count int)
+
 	RecordBondCollateral(addr common.Address, required, available *big.Int)
 
+	RecordBondExposure(total *ActorBondExposure, games map[common.Address]*ActorBondExposure)
+
 	RecordL2Challenges(agreement bool, count int)
 
+	RecordWithdrawalProofs(status WithdrawalProofStatus, count int)
+
+	RecordActorClaims(actor string, correctness ClaimCorrectness, count int)
+	RecordActorInvalidClaimCounters(actor string, count int)
+	RecordActorResponseLatency(actor string, latency time.Duration)
+
 	caching.Metrics
 	contractMetrics.ContractMetricer
 }
@@ -209,8 +279,9 @@ type Metrics struct {
 
 	withdrawalRequests prometheus.GaugeVec
 
-	info prometheus.GaugeVec
-	up   prometheus.Gauge
+	info       prometheus.GaugeVec
+	up         prometheus.Gauge
+	initFailed prometheus.Gauge
 
 	credits                   prometheus.GaugeVec
 	honestWithdrawableAmounts prometheus.GaugeVec
@@ -222,10 +293,21 @@ type Metrics struct {
 	latestProposals            prometheus.GaugeVec
 	ignoredGames               prometheus.Gauge
 	failedGames                prometheus.Gauge
+	incorrectForecasts         prometheus.Gauge
+	delayedResolutions         prometheus.Gauge
+	uncounteredInvalidClaims   prometheus.Gauge
 	l2Challenges               prometheus.GaugeVec
+	withdrawalProofs           prometheus.GaugeVec
 
 	requiredCollateral  prometheus.GaugeVec
 	availableCollateral prometheus.GaugeVec
+
+	bondExposure     prometheus.GaugeVec
+	gameBondExposure prometheus.GaugeVec
+
+	actorClaims               prometheus.GaugeVec
+	actorInvalidClaimCounters prometheus.GaugeVec
+	actorResponseLatency      prometheus.HistogramVec
 }
 
 func (m *Metrics) Registry() *prometheus.Registry {
@@ -235,8 +317,18 @@ func (m *Metrics) Registry() *prometheus.Registry {
 var _ Metricer = (*Metrics)(nil)
 
 func NewMetrics() *Metrics {
-	registry := opmetrics.NewRegistry()
-	factory := opmetrics.With(registry)
+	return NewChainMetrics(opmetrics.NewRegistry(), "")
+}
+
+// NewChainMetrics creates the metrics for monitoring a single chain, registered with registry.
+// If chain is not empty, every metric is labelled with the chain name so that the metrics
+// for multiple chains can be served from the same registry.
+func NewChainMetrics(registry *prometheus.Registry, chain string) *Metrics {
+	var registerer prometheus.Registerer = registry
+	if chain != "" {
+		registerer = prometheus.WrapRegistererWith(prometheus.Labels{"chain": chain}, registry)
+	}
+	factory := opmetrics.With(registerer)
 
 	return &Metrics{
 		ns:       Namespace,
@@ -258,6 +350,11 @@ func NewMetrics() *Metrics {
 			Name:      "up",
 			Help:      "1 if the op-challenger has finished starting up",
 		}),
+		initFailed: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "init_failed",
+			Help:      "1 if monitoring failed to start, e.g. because the chain's RPC endpoints were unavailable",
+		}),
 		monitorDuration: factory.NewHistogram(prometheus.HistogramOpts{
 			Namespace: Namespace,
 			Name:      "monitor_duration_seconds",
@@ -367,6 +464,21 @@ func NewMetrics() *Metrics {
 			Name:      "failed_games",
 			Help:      "Number of games present in the game window but failed to be monitored",
 		}),
+		incorrectForecasts: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "incorrect_forecasts",
+			Help:      "Number of in progress games forecast to resolve incorrectly even if honest actors counter every claim they still can",
+		}),
+		delayedResolutions: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "delayed_resolutions",
+			Help:      "Number of resolvable games that have remained unresolved for longer than the resolution delay threshold",
+		}),
+		uncounteredInvalidClaims: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "uncountered_invalid_claims",
+			Help:      "Number of unresolved claims the monitor disagrees with that have not been countered within the uncountered claim threshold",
+		}),
 		availableCollateral: *factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: Namespace,
 			Name:      "bond_collateral_available",
@@ -386,6 +498,54 @@ func NewMetrics() *Metrics {
 			// An l2 block number challenge with an agreement means the challenge was invalid.
 			"root_agreement",
 		}),
+		bondExposure: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "bond_exposure",
+			Help:      "ETH locked in games in the game window as bonds at risk or credits, by actor",
+		}, []string{
+			// Either honest for configured honest actors or unknown for all other addresses
+			"actor",
+			"state",
+		}),
+		gameBondExposure: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "game_bond_exposure",
+			Help:      "ETH locked in a game as bonds at risk or credits, by actor",
+		}, []string{
+			"game",
+			"actor",
+			"state",
+		}),
+		withdrawalProofs: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "withdrawal_proofs",
+			Help:      "Number of withdrawal proofs against games in the game window, by agreement with the game's root claim",
+		}, []string{
+			"status",
+		}),
+		actorClaims: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "actor_claims",
+			Help:      "Number of claims in games in the game window by claimant and whether the claim is correct",
+		}, []string{
+			"actor",
+			"correctness",
+		}),
+		actorInvalidClaimCounters: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "actor_invalid_claim_counters",
+			Help:      "Number of claims the monitor disagrees with in games in the game window that were first countered by the actor",
+		}, []string{
+			"actor",
+		}),
+		actorResponseLatency: *factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "actor_response_latency_seconds",
+			Help:      "Time between a claim being posted and the actor countering it",
+			Buckets:   []float64{12, 30, 60, 120, 300, 600, 1800, 3600, 6 * 3600, 24 * 3600},
+		}, []string{
+			"actor",
+		}),
 	}
 }
 
@@ -413,6 +573,11 @@ func (m *Metrics) RecordUp() {
 	m.up.Set(1)
 }
 
+// RecordInitFailed sets the init_failed metric to 1.
+func (m *Metrics) RecordInitFailed() {
+	m.initFailed.Set(1)
+}
+
 func (m *Metrics) RecordMonitorDuration(dur time.Duration) {
 	m.monitorDuration.Observe(dur.Seconds())
 }
@@ -520,6 +685,18 @@ func (m *Metrics) RecordFailedGames(count int) {
 	m.failedGames.Set(float64(count))
 }
 
+func (m *Metrics) RecordIncorrectForecasts(count int) {
+	m.incorrectForecasts.Set(float64(count))
+}
+
+func (m *Metrics) RecordDelayedResolutions(count int) {
+	m.delayedResolutions.Set(float64(count))
+}
+
+func (m *Metrics) RecordUncounteredInvalidClaims(count int) {
+	m.uncounteredInvalidClaims.Set(float64(count))
+}
+
 func (m *Metrics) RecordBondCollateral(addr common.Address, required, available *big.Int) {
 	balanceLabel := "sufficient"
 	zeroBalanceLabel := "insufficient"
@@ -535,6 +712,26 @@ func (m *Metrics) RecordBondCollateral(addr common.Address, required, available
 	m.availableCollateral.WithLabelValues(addr.Hex(), zeroBalanceLabel).Set(0)
 }
 
+func (m *Metrics) RecordBondExposure(total *ActorBondExposure, games map[common.Address]*ActorBondExposure) {
+	record := func(gauge func(actor string, state string) prometheus.Gauge, exposure *ActorBondExposure) {
+		for actor, actorExposure := range map[string]BondExposure{"honest": exposure.Honest, "unknown": exposure.Unknown} {
+			gauge(actor, "at_risk").Set(weiToEther(actorExposure.AtRisk))
+			gauge(actor, "locked_credit").Set(weiToEther(actorExposure.LockedCredit))
+			gauge(actor, "withdrawable_credit").Set(weiToEther(actorExposure.WithdrawableCredit))
+		}
+	}
+	record(func(actor string, state string) prometheus.Gauge {
+		return m.bondExposure.WithLabelValues(actor, state)
+	}, total)
+	// Remove games that are no longer in the game window
+	m.gameBondExposure.Reset()
+	for game, exposure := range games {
+		record(func(actor string, state string) prometheus.Gauge {
+			return m.gameBondExposure.WithLabelValues(game.Hex(), actor, state)
+		}, exposure)
+	}
+}
+
 func (m *Metrics) RecordL2Challenges(agreement bool, count int) {
 	agree := "disagree"
 	if agreement {
@@ -543,6 +740,46 @@ func (m *Metrics) RecordL2Challenges(agreement bool, count int) {
 	m.l2Challenges.WithLabelValues(agree).Set(float64(count))
 }
 
+func (m *Metrics) RecordWithdrawalProofs(status WithdrawalProofStatus, count int) {
+	asLabel := func(status WithdrawalProofStatus) string {
+		switch status {
+		case WithdrawalProofValid:
+			return "valid"
+		case WithdrawalProofInvalid:
+			return "invalid"
+		case WithdrawalProofUnverified:
+			return "unverified"
+		default:
+			panic(fmt.Errorf("unknown withdrawal proof status: %v", status))
+		}
+	}
+	m.withdrawalProofs.WithLabelValues(asLabel(status)).Set(float64(count))
+}
+
+func (m *Metrics) RecordActorClaims(actor string, correctness ClaimCorrectness, count int) {
+	asLabel := func(correctness ClaimCorrectness) string {
+		switch correctness {
+		case ClaimCorrect:
+			return "correct"
+		case ClaimIncorrect:
+			return "incorrect"
+		case ClaimCorrectnessUnknown:
+			return "unknown"
+		default:
+			panic(fmt.Errorf("unknown claim correctness: %v", correctness))
+		}
+	}
+	m.actorClaims.WithLabelValues(actor, asLabel(correctness)).Set(float64(count))
+}
+
+func (m *Metrics) RecordActorInvalidClaimCounters(actor string, count int) {
+	m.actorInvalidClaimCounters.WithLabelValues(actor).Set(float64(count))
+}
+
+func (m *Metrics) RecordActorResponseLatency(actor string, latency time.Duration) {
+	m.actorResponseLatency.WithLabelValues(actor).Observe(latency.Seconds())
+}
+
 const (
 	inProgress = true
 	correct    = true
@@ -16,6 +16,7 @@ var NoopMetrics Metricer = new(NoopMetricsImpl)
 
 func (*NoopMetricsImpl) RecordInfo(_ string) {}
 func (*NoopMetricsImpl) RecordUp()           {}
+func (*NoopMetricsImpl) RecordInitFailed()   {}
 
 func (*NoopMetricsImpl) RecordMonitorDuration(_ time.Duration) {}
 
@@ -46,6 +47,23 @@ func (*NoopMetricsImpl) RecordIgnoredGames(_ int) {}
 
 func (*NoopMetricsImpl) RecordFailedGames(_ int) {}
 
+func (*NoopMetricsImpl) RecordIncorrectForecasts(_ int) {}
+
+func (*NoopMetricsImpl) RecordDelayedResolutions(_ int) {}
+
+func (*NoopMetricsImpl) RecordUncounteredInvalidClaims(_ int) {}
+
 func (*NoopMetricsImpl) RecordBondCollateral(_ common.Address, _, _ *big.Int) {}
 
+func (*NoopMetricsImpl) RecordBondExposure(_ *ActorBondExposure, _ map[common.Address]*ActorBondExposure) {
+}
+
 func (*NoopMetricsImpl) RecordL2Challenges(_ bool, _ int) {}
+
+func (*NoopMetricsImpl) RecordWithdrawalProofs(_ WithdrawalProofStatus, _ int) {}
+
+func (*NoopMetricsImpl) RecordActorClaims(_ string, _ ClaimCorrectness, _ int) {}
+
+func (*NoopMetricsImpl) RecordActorInvalidClaimCounters(_ string, _ int) {}
+
+func (*NoopMetricsImpl) RecordActorResponseLatency(_ string, _ time.Duration) {}
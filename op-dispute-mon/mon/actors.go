@@ -0,0 +1,127 @@
+package mon
+
+import (
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type ActorMetrics interface {
+	RecordActorClaims(actor string, correctness metrics.ClaimCorrectness, count int)
+	RecordActorInvalidClaimCounters(actor string, count int)
+	RecordActorResponseLatency(actor string, latency time.Duration)
+}
+
+type claimKey struct {
+	game  common.Address
+	index int
+}
+
+// ActorMonitor attributes claims to the actor that posted them, either one of the configured
+// honest actors or an external actor, and reports how correct and responsive each actor is.
+type ActorMonitor struct {
+	logger       log.Logger
+	metrics      ActorMetrics
+	honestActors types.HonestActors
+
+	// observed contains the counter claims that have already had their response latency recorded.
+	observed map[claimKey]bool
+}
+
+func NewActorMonitor(logger log.Logger, metrics ActorMetrics, honestActors types.HonestActors) *ActorMonitor {
+	return &ActorMonitor{
+		logger:       logger,
+		metrics:      metrics,
+		honestActors: honestActors,
+		observed:     make(map[claimKey]bool),
+	}
+}
+
+func (a *ActorMonitor) CheckActors(games []*types.EnrichedGameData) {
+	claims := make(map[string]map[metrics.ClaimCorrectness]int)
+	counters := make(map[string]int)
+	observed := make(map[claimKey]bool)
+	for _, game := range games {
+		a.checkGameActors(game, claims, counters, observed)
+	}
+	// Claims from games that have left the game window will not be seen again.
+	a.observed = observed
+
+	actors := []string{metrics.ExternalActor}
+	for actor := range a.honestActors {
+		actors = append(actors, actor.Hex())
+	}
+	for _, actor := range actors {
+		a.metrics.RecordActorClaims(actor, metrics.ClaimCorrect, claims[actor][metrics.ClaimCorrect])
+		a.metrics.RecordActorClaims(actor, metrics.ClaimIncorrect, claims[actor][metrics.ClaimIncorrect])
+		a.metrics.RecordActorClaims(actor, metrics.ClaimCorrectnessUnknown, claims[actor][metrics.ClaimCorrectnessUnknown])
+		a.metrics.RecordActorInvalidClaimCounters(actor, counters[actor])
+	}
+}
+
+func (a *ActorMonitor) checkGameActors(
+	game *types.EnrichedGameData,
+	claims map[string]map[metrics.ClaimCorrectness]int,
+	counters map[string]int,
+	observed map[claimKey]bool,
+) {
+	countered := make(map[int]bool)
+	for _, claim := range game.Claims {
+		actor := a.actorLabel(claim.Claimant)
+		if claims[actor] == nil {
+			claims[actor] = make(map[metrics.ClaimCorrectness]int)
+		}
+		claims[actor][claimCorrectness(&claim)]++
+
+		if claim.IsRoot() {
+			continue
+		}
+		parent := game.Claims[claim.ParentContractIndex]
+		key := claimKey{game: game.Proxy, index: claim.ContractIndex}
+		if !a.observed[key] {
+			a.metrics.RecordActorResponseLatency(actor, claim.Clock.Timestamp.Sub(parent.Clock.Timestamp))
+		}
+		observed[key] = true
+
+		// Claims are ordered by creation so the first child seen is the first counter to its parent.
+		if countered[parent.ContractIndex] {
+			continue
+		}
+		countered[parent.ContractIndex] = true
+		if parent.OutputAgreement != types.OutputAgreementDisagree {
+			continue
+		}
+		counters[actor]++
+		if actor == metrics.ExternalActor && len(a.honestActors) > 0 {
+			a.logger.Warn("Invalid claim first countered by external actor", "game", game.Proxy,
+				"claimContractIndex", parent.ContractIndex, "counterContractIndex", claim.ContractIndex, "counteredBy", claim.Claimant)
+		}
+	}
+}
+
+func (a *ActorMonitor) actorLabel(addr common.Address) string {
+	if a.honestActors.Contains(addr) {
+		return addr.Hex()
+	}
+	return metrics.ExternalActor
+}
+
+func claimCorrectness(claim *types.EnrichedClaim) metrics.ClaimCorrectness {
+	if claim.Resolved {
+		if claim.CounteredBy == (common.Address{}) {
+			return metrics.ClaimCorrect
+		}
+		return metrics.ClaimIncorrect
+	}
+	switch claim.OutputAgreement {
+	case types.OutputAgreementAgree:
+		return metrics.ClaimCorrect
+	case types.OutputAgreementDisagree:
+		return metrics.ClaimIncorrect
+	default:
+		return metrics.ClaimCorrectnessUnknown
+	}
+}
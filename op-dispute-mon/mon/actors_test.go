@@ -0,0 +1,160 @@
+package mon
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	actorHonest   = common.Address{0x01}
+	actorExternal = common.Address{0x99}
+)
+
+func TestActorMonitor_CheckActors(t *testing.T) {
+	t.Run("ClassifiesClaims", func(t *testing.T) {
+		monitor, m, _ := setupActorMonitorTest(t)
+		countered := actorClaim(actorExternal, 2, 3*time.Minute, types.OutputAgreementUnknown)
+		countered.Resolved = true
+		countered.CounteredBy = actorHonest
+		uncountered := actorClaim(actorExternal, 2, 4*time.Minute, types.OutputAgreementUnknown)
+		uncountered.Resolved = true
+		game := actorTestGame(common.Address{0xaa},
+			actorClaim(actorHonest, math.MaxUint32, 0, types.OutputAgreementAgree),
+			actorClaim(actorExternal, 0, time.Minute, types.OutputAgreementDisagree),
+			actorClaim(actorHonest, 1, 2*time.Minute, types.OutputAgreementUnknown),
+			countered,
+			uncountered,
+		)
+		monitor.CheckActors([]*types.EnrichedGameData{game})
+
+		honest := actorHonest.Hex()
+		require.Equal(t, 1, m.claims[honest][metrics.ClaimCorrect])
+		require.Equal(t, 0, m.claims[honest][metrics.ClaimIncorrect])
+		require.Equal(t, 1, m.claims[honest][metrics.ClaimCorrectnessUnknown])
+		require.Equal(t, 1, m.claims[metrics.ExternalActor][metrics.ClaimCorrect])
+		require.Equal(t, 2, m.claims[metrics.ExternalActor][metrics.ClaimIncorrect])
+		require.Equal(t, 0, m.claims[metrics.ExternalActor][metrics.ClaimCorrectnessUnknown])
+	})
+
+	t.Run("RecordsFirstCounterToInvalidClaims", func(t *testing.T) {
+		monitor, m, logs := setupActorMonitorTest(t)
+		game1 := actorTestGame(common.Address{0xaa},
+			actorClaim(actorExternal, math.MaxUint32, 0, types.OutputAgreementDisagree),
+			actorClaim(actorHonest, 0, time.Minute, types.OutputAgreementAgree),
+			// Second counter to the root isn't the first response
+			actorClaim(actorExternal, 0, 2*time.Minute, types.OutputAgreementAgree),
+		)
+		game2 := actorTestGame(common.Address{0xbb},
+			actorClaim(actorExternal, math.MaxUint32, 0, types.OutputAgreementDisagree),
+			actorClaim(actorExternal, 0, time.Minute, types.OutputAgreementAgree),
+			// Counter to a claim we agree with isn't defending the game
+			actorClaim(actorHonest, 1, 2*time.Minute, types.OutputAgreementDisagree),
+		)
+		monitor.CheckActors([]*types.EnrichedGameData{game1, game2})
+
+		require.Equal(t, 1, m.counters[actorHonest.Hex()])
+		require.Equal(t, 1, m.counters[metrics.ExternalActor])
+		msg := testlog.NewMessageFilter("Invalid claim first countered by external actor")
+		require.NotNil(t, logs.FindLog(msg, testlog.NewAttributesFilter("game", game2.Proxy.Hex())))
+		require.Len(t, logs.FindLogs(msg), 1)
+	})
+
+	t.Run("RecordsResponseLatencyOnce", func(t *testing.T) {
+		monitor, m, _ := setupActorMonitorTest(t)
+		game := actorTestGame(common.Address{0xaa},
+			actorClaim(actorExternal, math.MaxUint32, 0, types.OutputAgreementDisagree),
+			actorClaim(actorHonest, 0, time.Minute, types.OutputAgreementAgree),
+			actorClaim(actorExternal, 1, 3*time.Minute, types.OutputAgreementDisagree),
+		)
+		monitor.CheckActors([]*types.EnrichedGameData{game})
+		require.Equal(t, []time.Duration{time.Minute}, m.latencies[actorHonest.Hex()])
+		require.Equal(t, []time.Duration{2 * time.Minute}, m.latencies[metrics.ExternalActor])
+
+		game.Claims = append(game.Claims, actorClaim(actorHonest, 2, 10*time.Minute, types.OutputAgreementAgree))
+		game.Claims[3].ContractIndex = 3
+		monitor.CheckActors([]*types.EnrichedGameData{game})
+		require.Equal(t, []time.Duration{time.Minute, 7 * time.Minute}, m.latencies[actorHonest.Hex()])
+		require.Equal(t, []time.Duration{2 * time.Minute}, m.latencies[metrics.ExternalActor])
+	})
+
+	t.Run("RecordsZeroForActorsWithNoClaims", func(t *testing.T) {
+		monitor, m, _ := setupActorMonitorTest(t)
+		monitor.CheckActors(nil)
+		for _, actor := range []string{actorHonest.Hex(), metrics.ExternalActor} {
+			require.Contains(t, m.claims, actor)
+			require.Zero(t, m.claims[actor][metrics.ClaimCorrect])
+			require.Zero(t, m.claims[actor][metrics.ClaimIncorrect])
+			require.Zero(t, m.claims[actor][metrics.ClaimCorrectnessUnknown])
+			require.Contains(t, m.counters, actor)
+			require.Zero(t, m.counters[actor])
+		}
+	})
+}
+
+func setupActorMonitorTest(t *testing.T) (*ActorMonitor, *stubActorMetrics, *testlog.CapturingHandler) {
+	logger, logs := testlog.CaptureLogger(t, log.LvlInfo)
+	m := &stubActorMetrics{
+		claims:    make(map[string]map[metrics.ClaimCorrectness]int),
+		counters:  make(map[string]int),
+		latencies: make(map[string][]time.Duration),
+	}
+	return NewActorMonitor(logger, m, types.NewHonestActors([]common.Address{actorHonest})), m, logs
+}
+
+func actorTestGame(proxy common.Address, claims ...types.EnrichedClaim) *types.EnrichedGameData {
+	for i := range claims {
+		claims[i].ContractIndex = i
+	}
+	return &types.EnrichedGameData{
+		GameMetadata: gameTypes.GameMetadata{Proxy: proxy},
+		Claims:       claims,
+	}
+}
+
+func actorClaim(claimant common.Address, parent int, postedAfter time.Duration, agreement types.OutputAgreement) types.EnrichedClaim {
+	position := faultTypes.RootPosition
+	if parent != math.MaxUint32 {
+		position = faultTypes.NewPositionFromGIndex(big.NewInt(2))
+	}
+	return types.EnrichedClaim{
+		Claim: faultTypes.Claim{
+			ClaimData:           faultTypes.ClaimData{Position: position},
+			Claimant:            claimant,
+			ParentContractIndex: parent,
+			Clock:               faultTypes.Clock{Timestamp: frozen.Add(postedAfter)},
+		},
+		OutputAgreement: agreement,
+	}
+}
+
+type stubActorMetrics struct {
+	claims    map[string]map[metrics.ClaimCorrectness]int
+	counters  map[string]int
+	latencies map[string][]time.Duration
+}
+
+func (s *stubActorMetrics) RecordActorClaims(actor string, correctness metrics.ClaimCorrectness, count int) {
+	if s.claims[actor] == nil {
+		s.claims[actor] = make(map[metrics.ClaimCorrectness]int)
+	}
+	s.claims[actor][correctness] = count
+}
+
+func (s *stubActorMetrics) RecordActorInvalidClaimCounters(actor string, count int) {
+	s.counters[actor] = count
+}
+
+func (s *stubActorMetrics) RecordActorResponseLatency(actor string, latency time.Duration) {
+	s.latencies[actor] = append(s.latencies[actor], latency)
+}
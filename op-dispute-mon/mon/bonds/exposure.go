@@ -0,0 +1,69 @@
+package bonds
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CalculateBondExposure determines the ETH locked in each of a set of dispute games by honest actors and unknown
+// addresses, either as bonds on unresolved claims or as credits that are or are not yet withdrawable at now.
+// Returns the total exposure across all games and a map of game address to the exposure in that game.
+func CalculateBondExposure(games []*monTypes.EnrichedGameData, honestActors monTypes.HonestActors, now time.Time) (*metrics.ActorBondExposure, map[common.Address]*metrics.ActorBondExposure) {
+	total := metrics.NewActorBondExposure()
+	byGame := make(map[common.Address]*metrics.ActorBondExposure, len(games))
+	for _, game := range games {
+		exposure := bondExposureForGame(game, honestActors, now)
+		byGame[game.Proxy] = exposure
+		addExposure(&total.Honest, exposure.Honest)
+		addExposure(&total.Unknown, exposure.Unknown)
+	}
+	return total, byGame
+}
+
+func bondExposureForGame(game *monTypes.EnrichedGameData, honestActors monTypes.HonestActors, now time.Time) *metrics.ActorBondExposure {
+	exposure := metrics.NewActorBondExposure()
+	actorExposure := func(addr common.Address) *metrics.BondExposure {
+		if honestActors.Contains(addr) {
+			return &exposure.Honest
+		}
+		return &exposure.Unknown
+	}
+
+	for _, claim := range game.Claims {
+		if claim.Resolved {
+			continue
+		}
+		actor := actorExposure(claim.Claimant)
+		actor.AtRisk = new(big.Int).Add(actor.AtRisk, claim.Bond)
+	}
+
+	for recipient, credit := range game.Credits {
+		actor := actorExposure(recipient)
+		if creditWithdrawable(game, recipient, now) {
+			actor.WithdrawableCredit = new(big.Int).Add(actor.WithdrawableCredit, credit)
+		} else {
+			actor.LockedCredit = new(big.Int).Add(actor.LockedCredit, credit)
+		}
+	}
+	return exposure
+}
+
+// creditWithdrawable returns true if the DelayedWETH delay has passed since the recipient's credit was last unlocked.
+func creditWithdrawable(game *monTypes.EnrichedGameData, recipient common.Address, now time.Time) bool {
+	request := game.WithdrawalRequests[recipient]
+	if request == nil || request.Timestamp == nil || request.Timestamp.Sign() == 0 {
+		return false
+	}
+	unlockedAt := time.Unix(request.Timestamp.Int64(), 0)
+	return !now.Before(unlockedAt.Add(game.WETHDelay))
+}
+
+func addExposure(total *metrics.BondExposure, exposure metrics.BondExposure) {
+	total.AtRisk = new(big.Int).Add(total.AtRisk, exposure.AtRisk)
+	total.LockedCredit = new(big.Int).Add(total.LockedCredit, exposure.LockedCredit)
+	total.WithdrawableCredit = new(big.Int).Add(total.WithdrawableCredit, exposure.WithdrawableCredit)
+}
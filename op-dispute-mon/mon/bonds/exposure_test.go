@@ -0,0 +1,86 @@
+package bonds
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateBondExposure(t *testing.T) {
+	honest := common.Address{0x01}
+	unknown1 := common.Address{0x02}
+	unknown2 := common.Address{0x03}
+	honestActors := monTypes.NewHonestActors([]common.Address{honest})
+	delay := 10 * time.Minute
+
+	game1 := &monTypes.EnrichedGameData{
+		GameMetadata: gameTypes.GameMetadata{Proxy: common.Address{0xa1}},
+		WETHDelay:    delay,
+		Claims: []monTypes.EnrichedClaim{
+			exposureClaim(honest, 10, false),
+			exposureClaim(unknown1, 20, false),
+			exposureClaim(unknown2, 30, true), // Resolved so bond is no longer at risk
+		},
+		Credits: map[common.Address]*big.Int{
+			honest:   big.NewInt(100),
+			unknown1: big.NewInt(200),
+		},
+		WithdrawalRequests: map[common.Address]*contracts.WithdrawalRequest{
+			// Delay has passed
+			honest: {Amount: big.NewInt(100), Timestamp: big.NewInt(frozen.Add(-delay).Unix())},
+			// Delay hasn't passed
+			unknown1: {Amount: big.NewInt(200), Timestamp: big.NewInt(frozen.Add(-delay + time.Second).Unix())},
+		},
+	}
+	game2 := &monTypes.EnrichedGameData{
+		GameMetadata: gameTypes.GameMetadata{Proxy: common.Address{0xa2}},
+		WETHDelay:    delay,
+		Claims: []monTypes.EnrichedClaim{
+			exposureClaim(honest, 5, false),
+		},
+		Credits: map[common.Address]*big.Int{
+			// No withdrawal request so not withdrawable
+			unknown2: big.NewInt(300),
+		},
+	}
+
+	total, byGame := CalculateBondExposure([]*monTypes.EnrichedGameData{game1, game2}, honestActors, frozen)
+
+	requireExposure(t, metrics.BondExposure{AtRisk: big.NewInt(10), WithdrawableCredit: big.NewInt(100), LockedCredit: big.NewInt(0)}, byGame[game1.Proxy].Honest)
+	requireExposure(t, metrics.BondExposure{AtRisk: big.NewInt(20), WithdrawableCredit: big.NewInt(0), LockedCredit: big.NewInt(200)}, byGame[game1.Proxy].Unknown)
+	requireExposure(t, metrics.BondExposure{AtRisk: big.NewInt(5), WithdrawableCredit: big.NewInt(0), LockedCredit: big.NewInt(0)}, byGame[game2.Proxy].Honest)
+	requireExposure(t, metrics.BondExposure{AtRisk: big.NewInt(0), WithdrawableCredit: big.NewInt(0), LockedCredit: big.NewInt(300)}, byGame[game2.Proxy].Unknown)
+
+	requireExposure(t, metrics.BondExposure{AtRisk: big.NewInt(15), WithdrawableCredit: big.NewInt(100), LockedCredit: big.NewInt(0)}, total.Honest)
+	requireExposure(t, metrics.BondExposure{AtRisk: big.NewInt(20), WithdrawableCredit: big.NewInt(0), LockedCredit: big.NewInt(500)}, total.Unknown)
+}
+
+func TestCalculateBondExposure_NoGames(t *testing.T) {
+	total, byGame := CalculateBondExposure(nil, monTypes.NewHonestActors(nil), frozen)
+	require.Empty(t, byGame)
+	require.Equal(t, metrics.NewActorBondExposure(), total)
+}
+
+func requireExposure(t *testing.T, expected metrics.BondExposure, actual metrics.BondExposure) {
+	require.Zerof(t, expected.AtRisk.Cmp(actual.AtRisk), "at risk expected %v but was %v", expected.AtRisk, actual.AtRisk)
+	require.Zerof(t, expected.LockedCredit.Cmp(actual.LockedCredit), "locked credit expected %v but was %v", expected.LockedCredit, actual.LockedCredit)
+	require.Zerof(t, expected.WithdrawableCredit.Cmp(actual.WithdrawableCredit), "withdrawable credit expected %v but was %v", expected.WithdrawableCredit, actual.WithdrawableCredit)
+}
+
+func exposureClaim(claimant common.Address, bond int64, resolved bool) monTypes.EnrichedClaim {
+	return monTypes.EnrichedClaim{
+		Claim: faultTypes.Claim{
+			ClaimData: faultTypes.ClaimData{Bond: big.NewInt(bond)},
+			Claimant:  claimant,
+		},
+		Resolved: resolved,
+	}
+}
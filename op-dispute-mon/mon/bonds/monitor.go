@@ -17,19 +17,22 @@ type RClock interface {
 type BondMetrics interface {
 	RecordCredit(expectation metrics.CreditExpectation, count int)
 	RecordBondCollateral(addr common.Address, required *big.Int, available *big.Int)
+	RecordBondExposure(total *metrics.ActorBondExposure, games map[common.Address]*metrics.ActorBondExposure)
 }
 
 type Bonds struct {
-	logger  log.Logger
-	clock   RClock
-	metrics BondMetrics
+	logger       log.Logger
+	clock        RClock
+	metrics      BondMetrics
+	honestActors types.HonestActors
 }
 
-func NewBonds(logger log.Logger, metrics BondMetrics, clock RClock) *Bonds {
+func NewBonds(logger log.Logger, metrics BondMetrics, clock RClock, honestActors types.HonestActors) *Bonds {
 	return &Bonds{
-		logger:  logger,
-		clock:   clock,
-		metrics: metrics,
+		logger:       logger,
+		clock:        clock,
+		metrics:      metrics,
+		honestActors: honestActors,
 	}
 }
 
@@ -42,6 +45,9 @@ func (b *Bonds) CheckBonds(games []*types.EnrichedGameData) {
 		b.metrics.RecordBondCollateral(addr, collateral.Required, collateral.Actual)
 	}
 
+	total, byGame := CalculateBondExposure(games, b.honestActors, b.clock.Now())
+	b.metrics.RecordBondExposure(total, byGame)
+
 	b.checkCredits(games)
 }
 
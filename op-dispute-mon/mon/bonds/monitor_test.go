@@ -17,7 +17,8 @@ import (
 )
 
 var (
-	frozen = time.Unix(int64(time.Hour.Seconds()), 0)
+	frozen      = time.Unix(int64(time.Hour.Seconds()), 0)
+	honestActor = common.Address{0xad}
 )
 
 func TestCheckBonds(t *testing.T) {
@@ -51,6 +52,8 @@ func TestCheckBonds(t *testing.T) {
 	require.Equal(t, metrics.recorded[weth2].Required.Uint64(), uint64(46))
 	require.Equal(t, metrics.recorded[weth2].Actual.Uint64(), weth2Balance.Uint64())
 
+	require.Equal(t, big.NewInt(48), metrics.exposure.Unknown.LockedCredit)
+
 	require.NotNil(t, logs.FindLog(
 		testlog.NewMessageFilter("Insufficient collateral"),
 		testlog.NewAttributesFilter("delayedWETH", weth2.Hex()),
@@ -419,13 +422,20 @@ func setupBondMetricsTest(t *testing.T) (*Bonds, *stubBondMetrics, *testlog.Capt
 		credits:  make(map[metrics.CreditExpectation]int),
 		recorded: make(map[common.Address]Collateral),
 	}
-	bonds := NewBonds(logger, metrics, clock.NewDeterministicClock(frozen))
+	bonds := NewBonds(logger, metrics, clock.NewDeterministicClock(frozen), monTypes.NewHonestActors([]common.Address{honestActor}))
 	return bonds, metrics, logs
 }
 
 type stubBondMetrics struct {
-	credits  map[metrics.CreditExpectation]int
-	recorded map[common.Address]Collateral
+	credits      map[metrics.CreditExpectation]int
+	recorded     map[common.Address]Collateral
+	exposure     *metrics.ActorBondExposure
+	gameExposure map[common.Address]*metrics.ActorBondExposure
+}
+
+func (s *stubBondMetrics) RecordBondExposure(total *metrics.ActorBondExposure, games map[common.Address]*metrics.ActorBondExposure) {
+	s.exposure = total
+	s.gameExposure = games
 }
 
 func (s *stubBondMetrics) RecordBondCollateral(addr common.Address, required *big.Int, available *big.Int) {
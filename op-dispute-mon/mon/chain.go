@@ -0,0 +1,246 @@
+package mon
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/bonds"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/archive"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/config"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/extract"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/version"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+)
+
+// chainMonitor monitors the dispute games of a single chain.
+// Each chain has its own clients and monitoring loop so a failure on one chain doesn't affect the others.
+type chainMonitor struct {
+	logger       log.Logger
+	metrics      metrics.Metricer
+	monitor      *gameMonitor
+	honestActors types.HonestActors
+
+	factoryContract *contracts.DisputeGameFactoryContract
+
+	cl clock.Clock
+
+	extractor    *extract.Extractor
+	forecast     *Forecast
+	bonds        *bonds.Bonds
+	game         *extract.GameCallerCreator
+	resolutions  *ResolutionMonitor
+	claims       *ClaimMonitor
+	withdrawals  *WithdrawalMonitor
+	proofs       *WithdrawalProofMonitor
+	rollupClient *sources.RollupClient
+
+	l1Client *ethclient.Client
+
+	// store receives the latest games for the API, or nil if the API is disabled.
+	store GameStore
+	// archiveDB is the database resolved games are archived to, or nil if archival is disabled.
+	archiveDB *sql.DB
+}
+
+func newChainMonitor(ctx context.Context, logger log.Logger, cl clock.Clock, m metrics.Metricer, store GameStore, archiveDB *sql.DB, cfg *config.Config, chain config.ChainConfig) (*chainMonitor, error) {
+	if chain.Name != "" {
+		logger = logger.New("chain", chain.Name)
+	}
+	c := &chainMonitor{
+		cl:           cl,
+		logger:       logger,
+		metrics:      m,
+		honestActors: types.NewHonestActors(cfg.HonestActors),
+		store:        store,
+		archiveDB:    archiveDB,
+	}
+	if err := c.initFromConfig(ctx, cfg, chain); err != nil {
+		c.close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *chainMonitor) initFromConfig(ctx context.Context, cfg *config.Config, chain config.ChainConfig) error {
+	if err := c.initL1Client(ctx, chain); err != nil {
+		return fmt.Errorf("failed to init l1 client: %w", err)
+	}
+	if err := c.initFactoryContract(chain); err != nil {
+		return fmt.Errorf("failed to create factory contract bindings: %w", err)
+	}
+	if err := c.initOutputRollupClient(ctx, chain); err != nil {
+		return fmt.Errorf("failed to init rollup client: %w", err)
+	}
+
+	c.initClaimMonitor(cfg)
+	c.initResolutionMonitor(cfg)
+	c.initWithdrawalMonitor()
+	c.initWithdrawalProofMonitor(chain)
+
+	c.initGameCallerCreator() // Must be called before initForecast
+
+	c.initExtractor(cfg)
+	c.initForecast()
+	c.initBonds()
+
+	c.initMonitor(ctx, cfg, chain) // Monitor must be initialized last
+
+	c.metrics.RecordInfo(version.SimpleWithMeta)
+	c.metrics.RecordUp()
+
+	return nil
+}
+
+func (c *chainMonitor) initClaimMonitor(cfg *config.Config) {
+	c.claims = NewClaimMonitor(c.logger, c.cl, c.honestActors, c.metrics, cfg.UncounteredClaimThreshold)
+}
+
+func (c *chainMonitor) initResolutionMonitor(cfg *config.Config) {
+	c.resolutions = NewResolutionMonitor(c.logger, c.metrics, c.cl, cfg.ResolutionDelayThreshold)
+}
+
+func (c *chainMonitor) initWithdrawalMonitor() {
+	c.withdrawals = NewWithdrawalMonitor(c.logger, c.cl, c.metrics, c.honestActors)
+}
+
+func (c *chainMonitor) initWithdrawalProofMonitor(chain config.ChainConfig) {
+	if chain.OptimismPortalAddress == (common.Address{}) {
+		return
+	}
+	portal := contracts.NewOptimismPortal2Contract(c.metrics, chain.OptimismPortalAddress, batching.NewMultiCaller(c.l1Client.Client(), batching.DefaultBatchSize))
+	c.proofs = NewWithdrawalProofMonitor(c.logger, c.metrics, c.l1Client, portal)
+}
+
+func (c *chainMonitor) initGameCallerCreator() {
+	c.game = extract.NewGameCallerCreator(c.metrics, batching.NewMultiCaller(c.l1Client.Client(), batching.DefaultBatchSize))
+}
+
+func (c *chainMonitor) initExtractor(cfg *config.Config) {
+	c.extractor = extract.NewExtractor(
+		c.logger,
+		c.game.CreateContract,
+		c.factoryContract.GetGamesAtOrAfter,
+		cfg.IgnoredGames,
+		cfg.MaxConcurrency,
+		extract.NewClaimEnricher(),
+		extract.NewRecipientEnricher(), // Must be called before WithdrawalsEnricher and BondEnricher
+		extract.NewWithdrawalsEnricher(),
+		extract.NewBondEnricher(),
+		extract.NewBalanceEnricher(),
+		extract.NewL1HeadBlockNumEnricher(c.l1Client),
+		extract.NewAgreementEnricher(c.logger, c.metrics, c.rollupClient), // Must be called before ClaimAgreementEnricher
+		extract.NewClaimAgreementEnricher(c.rollupClient),
+	)
+}
+
+func (c *chainMonitor) initForecast() {
+	c.forecast = NewForecast(c.logger, c.metrics, c.cl)
+}
+
+func (c *chainMonitor) initBonds() {
+	c.bonds = bonds.NewBonds(c.logger, c.metrics, c.cl, c.honestActors)
+}
+
+func (c *chainMonitor) initOutputRollupClient(ctx context.Context, chain config.ChainConfig) error {
+	outputRollupClient, err := dial.DialRollupClientWithTimeout(ctx, dial.DefaultDialTimeout, c.logger, chain.RollupRpc)
+	if err != nil {
+		return fmt.Errorf("failed to dial rollup client: %w", err)
+	}
+	c.rollupClient = outputRollupClient
+	return nil
+}
+
+func (c *chainMonitor) initL1Client(ctx context.Context, chain config.ChainConfig) error {
+	l1Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, c.logger, chain.L1EthRpc)
+	if err != nil {
+		return fmt.Errorf("failed to dial L1: %w", err)
+	}
+	c.l1Client = l1Client
+	return nil
+}
+
+func (c *chainMonitor) initFactoryContract(chain config.ChainConfig) error {
+	factoryContract := contracts.NewDisputeGameFactoryContract(c.metrics, chain.GameFactoryAddress,
+		batching.NewMultiCaller(c.l1Client.Client(), batching.DefaultBatchSize))
+	c.factoryContract = factoryContract
+	return nil
+}
+
+func (c *chainMonitor) initMonitor(ctx context.Context, cfg *config.Config, chain config.ChainConfig) {
+	blockHashFetcher := func(ctx context.Context, blockNumber *big.Int) (common.Hash, error) {
+		block, err := c.l1Client.BlockByNumber(ctx, blockNumber)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("failed to fetch block by number: %w", err)
+		}
+		return block.Hash(), nil
+	}
+	l2ChallengesMonitor := NewL2ChallengesMonitor(c.logger, c.metrics)
+	actorMonitor := NewActorMonitor(c.logger, c.metrics, c.honestActors)
+	withdrawalProofs := func(context.Context, uint64, []*types.EnrichedGameData) error { return nil }
+	if c.proofs != nil {
+		withdrawalProofs = c.proofs.CheckWithdrawalProofs
+	}
+	publishGames := func([]*types.EnrichedGameData) {}
+	if c.store != nil {
+		publishGames = NewGamePublisher(chain.Name, c.store, c.forecast, c.honestActors, c.cl).PublishGames
+	}
+	archiveGames := func(context.Context, []*types.EnrichedGameData) error { return nil }
+	if c.archiveDB != nil {
+		archiveGames = archive.NewArchiver(c.logger, c.archiveDB, chain.Name).ArchiveGames
+	}
+	c.monitor = newGameMonitor(
+		ctx,
+		c.logger,
+		c.cl,
+		c.metrics,
+		cfg.MonitorInterval,
+		cfg.GameWindow,
+		c.forecast.Forecast,
+		c.bonds.CheckBonds,
+		c.resolutions.CheckResolutions,
+		c.claims.CheckClaims,
+		c.withdrawals.CheckWithdrawals,
+		l2ChallengesMonitor.CheckL2Challenges,
+		actorMonitor.CheckActors,
+		publishGames,
+		archiveGames,
+		withdrawalProofs,
+		c.extractor.Extract,
+		c.l1Client.BlockNumber,
+		blockHashFetcher,
+	)
+}
+
+func (c *chainMonitor) start() {
+	c.logger.Info("Starting monitoring")
+	c.monitor.StartMonitoring()
+}
+
+func (c *chainMonitor) stop() {
+	if c.monitor != nil {
+		c.monitor.StopMonitoring()
+	}
+	c.close()
+}
+
+func (c *chainMonitor) close() {
+	if c.rollupClient != nil {
+		c.rollupClient.Close()
+	}
+	if c.l1Client != nil {
+		c.l1Client.Close()
+	}
+}
@@ -20,17 +20,21 @@ type RClock interface {
 type ClaimMetrics interface {
 	RecordClaims(statuses *metrics.ClaimStatuses)
 	RecordHonestActorClaims(address common.Address, data *metrics.HonestActorData)
+	RecordUncounteredInvalidClaims(count int)
 }
 
 type ClaimMonitor struct {
-	logger       log.Logger
-	clock        RClock
-	honestActors types.HonestActors
-	metrics      ClaimMetrics
+	logger               log.Logger
+	clock                RClock
+	honestActors         types.HonestActors
+	metrics              ClaimMetrics
+	uncounteredThreshold time.Duration
 }
 
-func NewClaimMonitor(logger log.Logger, clock RClock, honestActors types.HonestActors, metrics ClaimMetrics) *ClaimMonitor {
-	return &ClaimMonitor{logger, clock, honestActors, metrics}
+// NewClaimMonitor creates a ClaimMonitor which reports claims the monitor disagrees with that
+// remain uncountered for longer than uncounteredThreshold.
+func NewClaimMonitor(logger log.Logger, clock RClock, honestActors types.HonestActors, metrics ClaimMetrics, uncounteredThreshold time.Duration) *ClaimMonitor {
+	return &ClaimMonitor{logger, clock, honestActors, metrics, uncounteredThreshold}
 }
 
 func (c *ClaimMonitor) CheckClaims(games []*types.EnrichedGameData) {
@@ -43,10 +47,12 @@ func (c *ClaimMonitor) CheckClaims(games []*types.EnrichedGameData) {
 			WonBonds:     big.NewInt(0),
 		}
 	}
+	uncountered := 0
 	for _, game := range games {
-		c.checkGameClaims(game, claimStatuses, honest)
+		uncountered += c.checkGameClaims(game, claimStatuses, honest)
 	}
 	c.metrics.RecordClaims(claimStatuses)
+	c.metrics.RecordUncounteredInvalidClaims(uncountered)
 	for actor := range c.honestActors {
 		c.metrics.RecordHonestActorClaims(actor, honest[actor])
 	}
@@ -80,13 +86,14 @@ func (c *ClaimMonitor) checkGameClaims(
 	game *types.EnrichedGameData,
 	claimStatuses *metrics.ClaimStatuses,
 	honest map[common.Address]*metrics.HonestActorData,
-) {
+) (uncountered int) {
 	// Check if the game is in the first half
 	now := c.clock.Now()
 	duration := uint64(now.Unix()) - game.Timestamp
 	firstHalf := duration <= game.MaxClockDuration
 
 	minDescendantAccumulatedTimeByIndex := make(map[int]time.Duration)
+	countered := make(map[int]bool)
 
 	// Iterate over the game's claims
 	// Reverse order so we can track whether the claim has unresolvable children
@@ -122,6 +129,19 @@ func (c *ClaimMonitor) checkGameClaims(
 		// This claim is only resolvable if it and all it's descendants have expired clocks
 		resolvable := minAccumulatedTime >= maxChessTime
 
+		// Children are always after their parent so have already been visited
+		if !claim.IsRoot() {
+			countered[claim.ParentContractIndex] = true
+		}
+		if !claim.Resolved && !countered[claim.ContractIndex] && claim.OutputAgreement == types.OutputAgreementDisagree {
+			uncounteredDuration := now.Sub(claim.Clock.Timestamp)
+			if uncounteredDuration >= c.uncounteredThreshold {
+				uncountered++
+				c.logger.Error("Invalid claim has not been countered", "game", game.Proxy, "claimContractIndex", claim.ContractIndex,
+					"uncounteredFor", uncounteredDuration, "clockExpired", clockExpired, "claimant", claim.Claimant)
+			}
+		}
+
 		claimStatuses.RecordClaim(firstHalf, clockExpired, resolvable, claim.Resolved)
 		if !claim.Resolved && resolvable {
 			// SAFETY: minAccumulatedTime must be larger than or equal to maxChessTime since the claim is resolvable
@@ -131,4 +151,5 @@ func (c *ClaimMonitor) checkGameClaims(
 			}
 		}
 	}
+	return uncountered
 }
@@ -2,6 +2,7 @@ package mon
 
 import (
 	"fmt"
+	"math"
 	"math/big"
 	"testing"
 	"time"
@@ -19,6 +20,8 @@ import (
 
 var frozen = time.Unix(int64(time.Hour.Seconds()), 0)
 
+const uncounteredThreshold = 10 * time.Minute
+
 func TestClaimMonitor_CheckClaims(t *testing.T) {
 	t.Run("RecordsClaims", func(t *testing.T) {
 		monitor, cl, cMetrics, _ := newTestClaimMonitor(t)
@@ -160,6 +163,67 @@ func TestClaimMonitor_CheckClaims(t *testing.T) {
 			"Claim 1 should have same delay as claim 3 as it could not be resolved before claim 3 clock expired")
 	})
 
+	t.Run("RecordsUncounteredInvalidClaims", func(t *testing.T) {
+		monitor, _, cMetrics, logs := newTestClaimMonitor(t)
+		chessClockDuration := 10 * time.Hour
+		gameStart := frozen.Add(-2 * time.Hour)
+		claimAt := func(gIndex int64, parent int, posted time.Time, agreement types.OutputAgreement) types.EnrichedClaim {
+			return types.EnrichedClaim{
+				Claim: faultTypes.Claim{
+					ClaimData:           faultTypes.ClaimData{Bond: big.NewInt(1), Position: faultTypes.NewPositionFromGIndex(big.NewInt(gIndex))},
+					ParentContractIndex: parent,
+					Clock:               faultTypes.Clock{Timestamp: posted},
+				},
+				OutputAgreement: agreement,
+			}
+		}
+		game := &types.EnrichedGameData{
+			MaxClockDuration: uint64(chessClockDuration.Seconds()),
+			GameMetadata:     gameTypes.GameMetadata{Proxy: common.Address{0xaa}, Timestamp: uint64(gameStart.Unix())},
+			Claims: []types.EnrichedClaim{
+				// Invalid but countered by claim 1
+				claimAt(1, math.MaxUint32, gameStart, types.OutputAgreementDisagree),
+				// Valid and uncountered
+				claimAt(2, 0, gameStart.Add(time.Minute), types.OutputAgreementAgree),
+				// Invalid and uncountered for longer than the threshold
+				claimAt(4, 1, frozen.Add(-uncounteredThreshold), types.OutputAgreementDisagree),
+				// Invalid but posted too recently to have been countered
+				claimAt(5, 1, frozen.Add(-uncounteredThreshold+time.Second), types.OutputAgreementDisagree),
+				// Unknown agreement so not reported
+				claimAt(4, 1, gameStart, types.OutputAgreementUnknown),
+			},
+		}
+		for i := range game.Claims {
+			game.Claims[i].ContractIndex = i
+		}
+		monitor.CheckClaims([]*types.EnrichedGameData{game})
+
+		require.Equal(t, 1, cMetrics.uncountered)
+		msg := testlog.NewMessageFilter("Invalid claim has not been countered")
+		require.NotNil(t, logs.FindLog(msg, testlog.NewAttributesFilter("claimContractIndex", "2")))
+		require.Len(t, logs.FindLogs(msg), 1)
+	})
+
+	t.Run("ResolvedInvalidClaimsNotReported", func(t *testing.T) {
+		monitor, _, cMetrics, _ := newTestClaimMonitor(t)
+		game := &types.EnrichedGameData{
+			MaxClockDuration: uint64(time.Minute.Seconds()),
+			Claims: []types.EnrichedClaim{
+				{
+					Claim: faultTypes.Claim{
+						ClaimData:           faultTypes.ClaimData{Bond: big.NewInt(1), Position: faultTypes.RootPosition},
+						ParentContractIndex: math.MaxUint32,
+						Clock:               faultTypes.Clock{Timestamp: frozen.Add(-time.Hour)},
+					},
+					Resolved:        true,
+					OutputAgreement: types.OutputAgreementDisagree,
+				},
+			},
+		}
+		monitor.CheckClaims([]*types.EnrichedGameData{game})
+		require.Zero(t, cMetrics.uncountered)
+	})
+
 	t.Run("RecordsUnexpectedClaimResolution", func(t *testing.T) {
 		monitor, cl, cMetrics, _ := newTestClaimMonitor(t)
 		games := makeMultipleTestGames(uint64(cl.Now().Unix()))
@@ -198,13 +262,18 @@ func newTestClaimMonitor(t *testing.T) (*ClaimMonitor, *clock.DeterministicClock
 		{0x01},
 		{0x02},
 	})
-	monitor := NewClaimMonitor(logger, cl, honestActors, metrics)
+	monitor := NewClaimMonitor(logger, cl, honestActors, metrics, uncounteredThreshold)
 	return monitor, cl, metrics, handler
 }
 
 type stubClaimMetrics struct {
-	calls  map[metrics.ClaimStatus]int
-	honest map[common.Address]metrics.HonestActorData
+	calls       map[metrics.ClaimStatus]int
+	honest      map[common.Address]metrics.HonestActorData
+	uncountered int
+}
+
+func (s *stubClaimMetrics) RecordUncounteredInvalidClaims(count int) {
+	s.uncountered = count
 }
 
 func (s *stubClaimMetrics) RecordClaims(statuses *metrics.ClaimStatuses) {
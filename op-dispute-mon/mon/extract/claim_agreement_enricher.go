@@ -0,0 +1,95 @@
+package extract
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ Enricher = (*ClaimAgreementEnricher)(nil)
+
+type OutputClaimCaller interface {
+	GetSplitDepth(ctx context.Context) (faultTypes.Depth, error)
+	GetBlockRange(ctx context.Context) (prestateBlock uint64, poststateBlock uint64, retErr error)
+}
+
+type ClaimAgreementEnricher struct {
+	client OutputRollupClient
+}
+
+func NewClaimAgreementEnricher(client OutputRollupClient) *ClaimAgreementEnricher {
+	return &ClaimAgreementEnricher{
+		client: client,
+	}
+}
+
+// Enrich records whether the monitor agrees with the output root of each claim at or above the split depth of
+// in progress games. Must be called after AgreementEnricher as the root claim uses the agreement it records.
+func (e *ClaimAgreementEnricher) Enrich(ctx context.Context, _ rpcblock.Block, caller GameCaller, game *monTypes.EnrichedGameData) error {
+	if game.Status != gameTypes.GameStatusInProgress || len(game.Claims) == 0 {
+		return nil
+	}
+	splitDepth, err := caller.GetSplitDepth(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve split depth: %w", err)
+	}
+	prestateBlock, poststateBlock, err := caller.GetBlockRange(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve block range: %w", err)
+	}
+	// Output roots by block number, with nil for blocks the rollup node doesn't have an output root for.
+	outputs := make(map[uint64]*common.Hash)
+	for i := range game.Claims {
+		claim := &game.Claims[i]
+		if claim.Depth() > splitDepth {
+			claim.OutputAgreement = monTypes.OutputAgreementUnknown
+			continue
+		}
+		if claim.IsRoot() {
+			claim.OutputAgreement = outputAgreement(game.AgreeWithClaim)
+			continue
+		}
+		traceIndex := claim.Position.TraceIndex(splitDepth)
+		if !traceIndex.IsUint64() {
+			return fmt.Errorf("trace index of claim %v too large: %v", claim.ContractIndex, traceIndex)
+		}
+		blockNum := min(prestateBlock+traceIndex.Uint64()+1, poststateBlock)
+		output, ok := outputs[blockNum]
+		if !ok {
+			output, err = e.outputRoot(ctx, blockNum)
+			if err != nil {
+				return err
+			}
+			outputs[blockNum] = output
+		}
+		claim.OutputAgreement = outputAgreement(output != nil && *output == claim.Value)
+	}
+	return nil
+}
+
+func (e *ClaimAgreementEnricher) outputRoot(ctx context.Context, blockNum uint64) (*common.Hash, error) {
+	output, err := e.client.OutputAtBlock(ctx, blockNum)
+	if err != nil {
+		// string match as the error comes from the remote server so we can't use Errors.Is sadly.
+		if strings.Contains(err.Error(), "not found") {
+			// Output root doesn't exist, so we must disagree with any claimed root.
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get output at block %v: %w", blockNum, err)
+	}
+	root := common.Hash(output.OutputRoot)
+	return &root, nil
+}
+
+func outputAgreement(agree bool) monTypes.OutputAgreement {
+	if agree {
+		return monTypes.OutputAgreementAgree
+	}
+	return monTypes.OutputAgreementDisagree
+}
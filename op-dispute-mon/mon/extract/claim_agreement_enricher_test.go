@@ -0,0 +1,130 @@
+package extract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimAgreementEnricher(t *testing.T) {
+	t.Run("RecordAgreementOfOutputClaims", func(t *testing.T) {
+		client := &stubOutputsClient{outputs: map[uint64]common.Hash{
+			102: {0x02},
+			104: {0x04},
+		}}
+		caller := &mockGameCaller{splitDepth: 2, prestateBlock: 100, poststateBlock: 104}
+		game := &types.EnrichedGameData{
+			Status:         gameTypes.GameStatusInProgress,
+			AgreeWithClaim: true,
+			Claims: []types.EnrichedClaim{
+				outputClaim(0, 0, common.Hash{0xaa}),
+				outputClaim(1, 0, common.Hash{0x02}),
+				outputClaim(2, 1, common.Hash{0xbb}),
+				outputClaim(2, 0, common.Hash{0x01}),
+				outputClaim(3, 0, common.Hash{0x02}),
+			},
+		}
+		enricher := NewClaimAgreementEnricher(client)
+		err := enricher.Enrich(context.Background(), rpcblock.Latest, caller, game)
+		require.NoError(t, err)
+		require.Equal(t, types.OutputAgreementAgree, game.Claims[0].OutputAgreement, "should use root agreement")
+		require.Equal(t, types.OutputAgreementAgree, game.Claims[1].OutputAgreement)
+		require.Equal(t, types.OutputAgreementDisagree, game.Claims[2].OutputAgreement)
+		require.Equal(t, types.OutputAgreementDisagree, game.Claims[3].OutputAgreement, "should disagree when output not found")
+		require.Equal(t, types.OutputAgreementUnknown, game.Claims[4].OutputAgreement, "should not check claims below split depth")
+		require.Equal(t, []uint64{102, 101}, client.requested, "should only request each output once")
+	})
+
+	t.Run("SkipResolvedGames", func(t *testing.T) {
+		client := &stubOutputsClient{}
+		caller := &mockGameCaller{splitDepthErr: errors.New("should not be called")}
+		game := &types.EnrichedGameData{
+			Status: gameTypes.GameStatusDefenderWon,
+			Claims: []types.EnrichedClaim{outputClaim(0, 0, common.Hash{0xaa})},
+		}
+		enricher := NewClaimAgreementEnricher(client)
+		err := enricher.Enrich(context.Background(), rpcblock.Latest, caller, game)
+		require.NoError(t, err)
+		require.Equal(t, types.OutputAgreementUnknown, game.Claims[0].OutputAgreement)
+		require.Empty(t, client.requested)
+	})
+
+	t.Run("SplitDepthError", func(t *testing.T) {
+		expectedErr := errors.New("boom")
+		caller := &mockGameCaller{splitDepthErr: expectedErr}
+		game := &types.EnrichedGameData{
+			Status: gameTypes.GameStatusInProgress,
+			Claims: []types.EnrichedClaim{outputClaim(0, 0, common.Hash{0xaa})},
+		}
+		err := NewClaimAgreementEnricher(&stubOutputsClient{}).Enrich(context.Background(), rpcblock.Latest, caller, game)
+		require.ErrorIs(t, err, expectedErr)
+	})
+
+	t.Run("BlockRangeError", func(t *testing.T) {
+		expectedErr := errors.New("boom")
+		caller := &mockGameCaller{splitDepth: 2, blockRangeErr: expectedErr}
+		game := &types.EnrichedGameData{
+			Status: gameTypes.GameStatusInProgress,
+			Claims: []types.EnrichedClaim{outputClaim(0, 0, common.Hash{0xaa})},
+		}
+		err := NewClaimAgreementEnricher(&stubOutputsClient{}).Enrich(context.Background(), rpcblock.Latest, caller, game)
+		require.ErrorIs(t, err, expectedErr)
+	})
+
+	t.Run("OutputFetchError", func(t *testing.T) {
+		client := &stubOutputsClient{err: errors.New("boom")}
+		caller := &mockGameCaller{splitDepth: 2, prestateBlock: 100, poststateBlock: 104}
+		game := &types.EnrichedGameData{
+			Status: gameTypes.GameStatusInProgress,
+			Claims: []types.EnrichedClaim{
+				outputClaim(0, 0, common.Hash{0xaa}),
+				outputClaim(1, 0, common.Hash{0x02}),
+			},
+		}
+		err := NewClaimAgreementEnricher(client).Enrich(context.Background(), rpcblock.Latest, caller, game)
+		require.ErrorIs(t, err, client.err)
+	})
+}
+
+func outputClaim(depth faultTypes.Depth, indexAtDepth int64, value common.Hash) types.EnrichedClaim {
+	return types.EnrichedClaim{
+		Claim: faultTypes.Claim{
+			ClaimData: faultTypes.ClaimData{
+				Value:    value,
+				Position: faultTypes.NewPosition(depth, big.NewInt(indexAtDepth)),
+			},
+		},
+	}
+}
+
+type stubOutputsClient struct {
+	err       error
+	outputs   map[uint64]common.Hash
+	requested []uint64
+}
+
+func (s *stubOutputsClient) OutputAtBlock(_ context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	s.requested = append(s.requested, blockNum)
+	if s.err != nil {
+		return nil, s.err
+	}
+	output, ok := s.outputs[blockNum]
+	if !ok {
+		return nil, fmt.Errorf("could not get payload: not found")
+	}
+	return &eth.OutputResponse{OutputRoot: eth.Bytes32(output)}, nil
+}
+
+func (s *stubOutputsClient) SafeHeadAtL1Block(_ context.Context, _ uint64) (*eth.SafeHeadResponse, error) {
+	return nil, errors.New("not supported")
+}
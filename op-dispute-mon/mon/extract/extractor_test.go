@@ -234,6 +234,11 @@ type mockGameCaller struct {
 	withdrawals      []*contracts.WithdrawalRequest
 	resolvedErr      error
 	resolved         map[int]bool
+	splitDepth       faultTypes.Depth
+	splitDepthErr    error
+	prestateBlock    uint64
+	poststateBlock   uint64
+	blockRangeErr    error
 }
 
 func (m *mockGameCaller) GetWithdrawals(_ context.Context, _ rpcblock.Block, _ ...common.Address) ([]*contracts.WithdrawalRequest, error) {
@@ -310,6 +315,20 @@ func (m *mockGameCaller) IsResolved(_ context.Context, _ rpcblock.Block, claims
 	return resolved, nil
 }
 
+func (m *mockGameCaller) GetSplitDepth(_ context.Context) (faultTypes.Depth, error) {
+	if m.splitDepthErr != nil {
+		return 0, m.splitDepthErr
+	}
+	return m.splitDepth, nil
+}
+
+func (m *mockGameCaller) GetBlockRange(_ context.Context) (uint64, uint64, error) {
+	if m.blockRangeErr != nil {
+		return 0, 0, m.blockRangeErr
+	}
+	return m.prestateBlock, m.poststateBlock, nil
+}
+
 type mockEnricher struct {
 	err   error
 	calls int
@@ -2,7 +2,9 @@ package mon
 
 import (
 	"errors"
+	"time"
 
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/transform"
@@ -20,6 +22,7 @@ type ForecastMetrics interface {
 	RecordLatestProposals(validTimestamp, invalidTimestamp uint64)
 	RecordIgnoredGames(count int)
 	RecordFailedGames(count int)
+	RecordIncorrectForecasts(count int)
 }
 
 type forecastBatch struct {
@@ -36,17 +39,21 @@ type forecastBatch struct {
 	LatestValidProposalL2Block uint64
 	LatestInvalidProposal      uint64
 	LatestValidProposal        uint64
+
+	IncorrectForecasts int
 }
 
 type Forecast struct {
 	logger  log.Logger
 	metrics ForecastMetrics
+	clock   RClock
 }
 
-func NewForecast(logger log.Logger, metrics ForecastMetrics) *Forecast {
+func NewForecast(logger log.Logger, metrics ForecastMetrics, clock RClock) *Forecast {
 	return &Forecast{
 		logger:  logger,
 		metrics: metrics,
+		clock:   clock,
 	}
 }
 
@@ -76,6 +83,7 @@ func (f *Forecast) recordBatch(batch forecastBatch, ignoredCount, failedCount in
 
 	f.metrics.RecordIgnoredGames(ignoredCount)
 	f.metrics.RecordFailedGames(failedCount)
+	f.metrics.RecordIncorrectForecasts(batch.IncorrectForecasts)
 }
 
 func (f *Forecast) forecastGame(game *monTypes.EnrichedGameData, metrics *forecastBatch) error {
@@ -122,18 +130,17 @@ func (f *Forecast) forecastGame(game *monTypes.EnrichedGameData, metrics *foreca
 		return nil
 	}
 
-	var forecastStatus types.GameStatus
-	// Games that have their block number challenged are won
-	// by the challenger since the counter is proven on-chain.
 	if game.BlockNumberChallenged {
 		f.logger.Debug("Found game with challenged block number",
 			"game", game.Proxy, "blockNum", game.L2BlockNumber, "agreement", agreement)
-		// If the block number is challenged the challenger will always win
-		forecastStatus = types.GameStatusChallengerWon
-	} else {
-		// Otherwise we go through the resolution process to determine who would win based on the current claims
-		tree := transform.CreateBidirectionalTree(game.Claims)
-		forecastStatus = Resolve(tree)
+	}
+	forecastStatus, expectedResolution := f.forecastResolution(game)
+
+	if expectedResolution != expectedResult {
+		metrics.IncorrectForecasts++
+		f.logger.Error("Forecasting incorrect game resolution", "expectedResolution", expectedResolution,
+			"game", game.Proxy, "blockNum", game.L2BlockNumber, "correctResult", expectedResult,
+			"rootClaim", game.RootClaim, "correctClaim", expected)
 	}
 
 	if agreement {
@@ -166,3 +173,35 @@ func (f *Forecast) forecastGame(game *monTypes.EnrichedGameData, metrics *foreca
 
 	return nil
 }
+
+// forecastResolution returns the status an in progress game would resolve with based on its current claims and
+// the status it is expected to resolve with once honest actors have countered every claim they still can.
+func (f *Forecast) forecastResolution(game *monTypes.EnrichedGameData) (forecastStatus types.GameStatus, expectedResolution types.GameStatus) {
+	// Games that have their block number challenged are won
+	// by the challenger since the counter is proven on-chain.
+	if game.BlockNumberChallenged {
+		return types.GameStatusChallengerWon, types.GameStatusChallengerWon
+	}
+	// Otherwise we go through the resolution process to determine who would win based on the current claims
+	forecastStatus = Resolve(transform.CreateBidirectionalTree(game.Claims))
+	// And who is expected to win once honest actors have countered the claims we disagree with
+	expectedResolution = ResolveWithCounters(transform.CreateBidirectionalTree(game.Claims), f.honestCounterPossible(game))
+	return forecastStatus, expectedResolution
+}
+
+// honestCounterPossible returns a function reporting whether honest actors can still counter a claim in the game,
+// which is the case for claims whose output root we disagree with while their chess clock has time remaining.
+func (f *Forecast) honestCounterPossible(game *monTypes.EnrichedGameData) func(claim *faultTypes.Claim) bool {
+	now := f.clock.Now()
+	maxChessTime := time.Duration(game.MaxClockDuration) * time.Second
+	return func(claim *faultTypes.Claim) bool {
+		if game.Claims[claim.ContractIndex].OutputAgreement != monTypes.OutputAgreementDisagree {
+			return false
+		}
+		var parent faultTypes.Claim
+		if !claim.IsRoot() {
+			parent = game.Claims[claim.ParentContractIndex].Claim
+		}
+		return faultTypes.ChessClock(now, *claim, parent) < maxChessTime
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"math"
 	"math/big"
 	"testing"
+	"time"
 
 	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
 	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -16,11 +18,12 @@ import (
 )
 
 var (
-	mockRootClaim       = common.Hash{0x11}
-	failedForecastLog   = "Failed to forecast game"
-	lostGameLog         = "Unexpected game result"
-	unexpectedResultLog = "Forecasting unexpected game result"
-	expectedResultLog   = "Forecasting expected game result"
+	mockRootClaim        = common.Hash{0x11}
+	failedForecastLog    = "Failed to forecast game"
+	lostGameLog          = "Unexpected game result"
+	unexpectedResultLog  = "Forecasting unexpected game result"
+	expectedResultLog    = "Forecasting expected game result"
+	incorrectForecastLog = "Forecasting incorrect game resolution"
 )
 
 func TestForecast_Forecast_BasicTests(t *testing.T) {
@@ -231,6 +234,103 @@ func TestForecast_Forecast_EndLogs(t *testing.T) {
 	})
 }
 
+func TestForecast_Forecast_ExpectedResolution(t *testing.T) {
+	t.Parallel()
+
+	maxClockDuration := 10 * time.Minute
+	clockRemaining := faultTypes.NewClock(0, frozen.Add(-time.Minute))
+	clockExpired := faultTypes.NewClock(0, frozen.Add(-maxClockDuration))
+
+	gameWithClaims := func(agree bool, claims []monTypes.EnrichedClaim) *monTypes.EnrichedGameData {
+		return &monTypes.EnrichedGameData{
+			Status:            types.GameStatusInProgress,
+			RootClaim:         mockRootClaim,
+			MaxClockDuration:  uint64(maxClockDuration.Seconds()),
+			Claims:            claims,
+			AgreeWithClaim:    agree,
+			ExpectedRootClaim: mockRootClaim,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		agree     bool
+		clock     faultTypes.Clock
+		agreement []monTypes.OutputAgreement
+		incorrect bool
+	}{
+		{
+			name:      "DisagreeDefenderAhead_ClockRemaining",
+			agree:     false,
+			clock:     clockRemaining,
+			agreement: []monTypes.OutputAgreement{monTypes.OutputAgreementDisagree},
+		},
+		{
+			name:      "DisagreeDefenderAhead_ClockExpired",
+			agree:     false,
+			clock:     clockExpired,
+			agreement: []monTypes.OutputAgreement{monTypes.OutputAgreementDisagree},
+			incorrect: true,
+		},
+		{
+			name:      "AgreeChallengerAhead_ClockRemaining",
+			agree:     true,
+			clock:     clockRemaining,
+			agreement: []monTypes.OutputAgreement{monTypes.OutputAgreementAgree, monTypes.OutputAgreementDisagree},
+		},
+		{
+			name:      "AgreeChallengerAhead_ClockExpired",
+			agree:     true,
+			clock:     clockExpired,
+			agreement: []monTypes.OutputAgreement{monTypes.OutputAgreementAgree, monTypes.OutputAgreementDisagree},
+			incorrect: true,
+		},
+		{
+			name:      "AgreeChallengerAhead_BelowSplitDepth",
+			agree:     true,
+			clock:     clockRemaining,
+			agreement: []monTypes.OutputAgreement{monTypes.OutputAgreementAgree, monTypes.OutputAgreementUnknown},
+			incorrect: true,
+		},
+		{
+			name:      "DisagreeChallengerAhead",
+			agree:     false,
+			clock:     clockExpired,
+			agreement: []monTypes.OutputAgreement{monTypes.OutputAgreementDisagree, monTypes.OutputAgreementAgree},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			forecast, m, logs := setupForecastTest(t)
+			claims := createDeepClaimList()[:len(test.agreement)]
+			for i := range claims {
+				claims[i].Clock = test.clock
+				claims[i].OutputAgreement = test.agreement[i]
+			}
+			game := gameWithClaims(test.agree, claims)
+			forecast.Forecast([]*monTypes.EnrichedGameData{game}, 0, 0)
+			l := logs.FindLog(testlog.NewLevelFilter(log.LevelError), testlog.NewMessageFilter(incorrectForecastLog))
+			if !test.incorrect {
+				require.Nil(t, l)
+				require.Zero(t, m.incorrectForecasts)
+				return
+			}
+			require.NotNil(t, l)
+			require.Equal(t, game.Proxy, l.AttrValue("game"))
+			require.Equal(t, mockRootClaim, l.AttrValue("correctClaim"))
+			if test.agree {
+				require.Equal(t, types.GameStatusDefenderWon, l.AttrValue("correctResult"))
+				require.Equal(t, types.GameStatusChallengerWon, l.AttrValue("expectedResolution"))
+			} else {
+				require.Equal(t, types.GameStatusChallengerWon, l.AttrValue("correctResult"))
+				require.Equal(t, types.GameStatusDefenderWon, l.AttrValue("expectedResolution"))
+			}
+			require.Equal(t, 1, m.incorrectForecasts)
+		})
+	}
+}
+
 func TestForecast_Forecast_MultipleGames(t *testing.T) {
 	forecast, m, logs := setupForecastTest(t)
 	gameStatus := []types.GameStatus{
@@ -303,7 +403,7 @@ func setupForecastTest(t *testing.T) (*Forecast, *mockForecastMetrics, *testlog.
 	m := &mockForecastMetrics{
 		gameAgreement: zeroGameAgreement(),
 	}
-	return NewForecast(logger, m), m, capturedLogs
+	return NewForecast(logger, m, clock.NewDeterministicClock(frozen)), m, capturedLogs
 }
 
 func zeroGameAgreement() map[metrics.GameAgreementStatus]int {
@@ -326,6 +426,7 @@ type mockForecastMetrics struct {
 	latestInvalidProposal      uint64
 	latestValidProposal        uint64
 	contractCreationFails      int
+	incorrectForecasts         int
 }
 
 func (m *mockForecastMetrics) RecordFailedGames(count int) {
@@ -349,6 +450,10 @@ func (m *mockForecastMetrics) RecordIgnoredGames(count int) {
 	m.ignoredGames = count
 }
 
+func (m *mockForecastMetrics) RecordIncorrectForecasts(count int) {
+	m.incorrectForecasts = count
+}
+
 func createDeepClaimList() []monTypes.EnrichedClaim {
 	return []monTypes.EnrichedClaim{
 		{
@@ -17,6 +17,8 @@ type ForecastResolution func(games []*types.EnrichedGameData, ignoredCount, fail
 type Bonds func(games []*types.EnrichedGameData)
 type Resolutions func(games []*types.EnrichedGameData)
 type Monitor func(games []*types.EnrichedGameData)
+type ArchiveGames func(ctx context.Context, games []*types.EnrichedGameData) error
+type WithdrawalProofs func(ctx context.Context, blockNumber uint64, games []*types.EnrichedGameData) error
 type BlockHashFetcher func(ctx context.Context, number *big.Int) (common.Hash, error)
 type BlockNumberFetcher func(ctx context.Context) (uint64, error)
 type Extract func(ctx context.Context, blockHash common.Hash, minTimestamp uint64) ([]*types.EnrichedGameData, int, int, error)
@@ -43,6 +45,10 @@ type gameMonitor struct {
 	claims           Monitor
 	withdrawals      Monitor
 	l2Challenges     Monitor
+	actors           Monitor
+	publishGames     Monitor
+	archiveGames     ArchiveGames
+	withdrawalProofs WithdrawalProofs
 	extract          Extract
 	fetchBlockHash   BlockHashFetcher
 	fetchBlockNumber BlockNumberFetcher
@@ -61,6 +67,10 @@ func newGameMonitor(
 	claims Monitor,
 	withdrawals Monitor,
 	l2Challenges Monitor,
+	actors Monitor,
+	publishGames Monitor,
+	archiveGames ArchiveGames,
+	withdrawalProofs WithdrawalProofs,
 	extract Extract,
 	fetchBlockNumber BlockNumberFetcher,
 	fetchBlockHash BlockHashFetcher,
@@ -79,6 +89,10 @@ func newGameMonitor(
 		claims:           claims,
 		withdrawals:      withdrawals,
 		l2Challenges:     l2Challenges,
+		actors:           actors,
+		publishGames:     publishGames,
+		archiveGames:     archiveGames,
+		withdrawalProofs: withdrawalProofs,
 		extract:          extract,
 		fetchBlockNumber: fetchBlockNumber,
 		fetchBlockHash:   fetchBlockHash,
@@ -107,6 +121,14 @@ func (m *gameMonitor) monitorGames() error {
 	m.claims(enrichedGames)
 	m.withdrawals(enrichedGames)
 	m.l2Challenges(enrichedGames)
+	m.actors(enrichedGames)
+	m.publishGames(enrichedGames)
+	if err := m.archiveGames(m.ctx, enrichedGames); err != nil {
+		m.logger.Error("Failed to archive games", "err", err)
+	}
+	if err := m.withdrawalProofs(m.ctx, blockNumber, enrichedGames); err != nil {
+		m.logger.Error("Failed to check withdrawal proofs", "err", err)
+	}
 	timeTaken := m.clock.Since(start)
 	m.metrics.RecordMonitorDuration(timeTaken)
 	m.logger.Info("Completed monitoring update", "blockNumber", blockNumber, "blockHash", blockHash, "duration", timeTaken, "games", len(enrichedGames), "ignored", ignored, "failed", failed)
@@ -69,6 +69,69 @@ func TestMonitor_MonitorGames(t *testing.T) {
 		require.Equal(t, 1, withdrawals.calls)
 		require.Equal(t, 1, l2Challenges.calls)
 	})
+
+	t.Run("ChecksWithdrawalProofs", func(t *testing.T) {
+		monitor, factory, forecast, _, _, _, _, _ := setupMonitorTest(t)
+		factory.games = []*monTypes.EnrichedGameData{{}, {}}
+		proofs := &mockWithdrawalProofs{}
+		monitor.withdrawalProofs = proofs.Check
+		err := monitor.monitorGames()
+		require.NoError(t, err)
+		require.Equal(t, 1, proofs.calls)
+		require.EqualValues(t, 1, proofs.blockNumber)
+		require.Len(t, proofs.games, 2)
+		require.Equal(t, 1, forecast.calls)
+	})
+
+	t.Run("ChecksActors", func(t *testing.T) {
+		monitor, factory, _, _, _, _, _, _ := setupMonitorTest(t)
+		factory.games = []*monTypes.EnrichedGameData{{}, {}}
+		actors := &mockMonitor{}
+		monitor.actors = actors.Check
+		err := monitor.monitorGames()
+		require.NoError(t, err)
+		require.Equal(t, 1, actors.calls)
+	})
+
+	t.Run("PublishesGames", func(t *testing.T) {
+		monitor, factory, _, _, _, _, _, _ := setupMonitorTest(t)
+		factory.games = []*monTypes.EnrichedGameData{{}, {}}
+		publisher := &mockMonitor{}
+		monitor.publishGames = publisher.Check
+		err := monitor.monitorGames()
+		require.NoError(t, err)
+		require.Equal(t, 1, publisher.calls)
+	})
+
+	t.Run("ArchivesGames", func(t *testing.T) {
+		monitor, factory, _, _, _, _, _, _ := setupMonitorTest(t)
+		factory.games = []*monTypes.EnrichedGameData{{}, {}}
+		archiver := &mockArchiver{}
+		monitor.archiveGames = archiver.Archive
+		err := monitor.monitorGames()
+		require.NoError(t, err)
+		require.Equal(t, 1, archiver.calls)
+		require.Len(t, archiver.games, 2)
+	})
+
+	t.Run("ContinuesWhenArchiveFails", func(t *testing.T) {
+		monitor, _, forecast, _, _, _, _, _ := setupMonitorTest(t)
+		archiver := &mockArchiver{err: errors.New("boom")}
+		monitor.archiveGames = archiver.Archive
+		err := monitor.monitorGames()
+		require.NoError(t, err)
+		require.Equal(t, 1, archiver.calls)
+		require.Equal(t, 1, forecast.calls)
+	})
+
+	t.Run("ContinuesWhenWithdrawalProofsFail", func(t *testing.T) {
+		monitor, _, _, _, _, _, _, _ := setupMonitorTest(t)
+		proofs := &mockWithdrawalProofs{err: errors.New("boom")}
+		monitor.withdrawalProofs = proofs.Check
+		err := monitor.monitorGames()
+		require.NoError(t, err)
+		require.Equal(t, 1, proofs.calls)
+	})
 }
 
 func TestMonitor_StartMonitoring(t *testing.T) {
@@ -141,6 +204,10 @@ func setupMonitorTest(t *testing.T) (*gameMonitor, *mockExtractor, *mockForecast
 		claims.Check,
 		withdrawals.Check,
 		l2Challenges.Check,
+		(&mockMonitor{}).Check,
+		(&mockMonitor{}).Check,
+		(&mockArchiver{}).Archive,
+		func(context.Context, uint64, []*monTypes.EnrichedGameData) error { return nil },
 		extractor.Extract,
 		fetchBlockNum,
 		fetchBlockHash,
@@ -164,6 +231,32 @@ func (m *mockMonitor) Check(games []*monTypes.EnrichedGameData) {
 	m.calls++
 }
 
+type mockWithdrawalProofs struct {
+	calls       int
+	blockNumber uint64
+	games       []*monTypes.EnrichedGameData
+	err         error
+}
+
+func (m *mockWithdrawalProofs) Check(_ context.Context, blockNumber uint64, games []*monTypes.EnrichedGameData) error {
+	m.calls++
+	m.blockNumber = blockNumber
+	m.games = games
+	return m.err
+}
+
+type mockArchiver struct {
+	calls int
+	games []*monTypes.EnrichedGameData
+	err   error
+}
+
+func (m *mockArchiver) Archive(_ context.Context, games []*monTypes.EnrichedGameData) error {
+	m.calls++
+	m.games = games
+	return m.err
+}
+
 type mockForecast struct {
 	calls int
 }
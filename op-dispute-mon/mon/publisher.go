@@ -0,0 +1,48 @@
+package mon
+
+import (
+	"time"
+
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/api"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/bonds"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+)
+
+type GameStore interface {
+	UpdateGames(chain string, updated time.Time, games []api.Game)
+}
+
+// GamePublisher publishes the latest enriched games for a chain, along with their forecast and bond exposure,
+// to a GameStore so they can be served by the API.
+type GamePublisher struct {
+	chain        string
+	store        GameStore
+	forecast     *Forecast
+	honestActors types.HonestActors
+	clock        RClock
+}
+
+func NewGamePublisher(chain string, store GameStore, forecast *Forecast, honestActors types.HonestActors, clock RClock) *GamePublisher {
+	return &GamePublisher{
+		chain:        chain,
+		store:        store,
+		forecast:     forecast,
+		honestActors: honestActors,
+		clock:        clock,
+	}
+}
+
+func (p *GamePublisher) PublishGames(games []*types.EnrichedGameData) {
+	now := p.clock.Now()
+	_, exposures := bonds.CalculateBondExposure(games, p.honestActors, now)
+	published := make([]api.Game, 0, len(games))
+	for _, game := range games {
+		var forecast *api.Forecast
+		if game.Status == gameTypes.GameStatusInProgress {
+			forecast = api.NewForecast(p.forecast.forecastResolution(game))
+		}
+		published = append(published, api.NewGame(game, forecast, exposures[game.Proxy]))
+	}
+	p.store.UpdateGames(p.chain, now, published)
+}
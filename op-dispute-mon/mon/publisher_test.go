@@ -0,0 +1,104 @@
+package mon
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/api"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGamePublisher_PublishGames(t *testing.T) {
+	honest := common.Address{0x01}
+	cl := clock.NewDeterministicClock(frozen)
+	store := &stubGameStore{}
+	forecast := NewForecast(testlog.Logger(t, log.LvlInfo), metrics.NoopMetrics, cl)
+	publisher := NewGamePublisher("chain-a", store, forecast, types.NewHonestActors([]common.Address{honest}), cl)
+
+	inProgress := &types.EnrichedGameData{
+		GameMetadata:     gameTypes.GameMetadata{Proxy: common.Address{0xaa}, GameType: 1, Timestamp: 50},
+		Status:           gameTypes.GameStatusInProgress,
+		MaxClockDuration: uint64(time.Hour.Seconds()),
+		AgreeWithClaim:   false,
+		Claims: []types.EnrichedClaim{
+			{
+				Claim: faultTypes.Claim{
+					ClaimData:           faultTypes.ClaimData{Value: common.Hash{0x11}, Bond: big.NewInt(10), Position: faultTypes.RootPosition},
+					Claimant:            common.Address{0x99},
+					ParentContractIndex: math.MaxUint32,
+					Clock:               faultTypes.Clock{Timestamp: frozen},
+				},
+				OutputAgreement: types.OutputAgreementDisagree,
+			},
+			{
+				Claim: faultTypes.Claim{
+					ClaimData:           faultTypes.ClaimData{Value: common.Hash{0x22}, Bond: big.NewInt(20), Position: faultTypes.NewPositionFromGIndex(big.NewInt(2))},
+					Claimant:            honest,
+					ContractIndex:       1,
+					ParentContractIndex: 0,
+					Clock:               faultTypes.Clock{Duration: time.Minute, Timestamp: frozen},
+				},
+				OutputAgreement: types.OutputAgreementAgree,
+			},
+		},
+		Credits:       map[common.Address]*big.Int{honest: big.NewInt(5)},
+		ETHCollateral: big.NewInt(1000),
+	}
+	complete := &types.EnrichedGameData{
+		GameMetadata: gameTypes.GameMetadata{Proxy: common.Address{0xbb}},
+		Status:       gameTypes.GameStatusDefenderWon,
+	}
+
+	publisher.PublishGames([]*types.EnrichedGameData{inProgress, complete})
+
+	require.Equal(t, "chain-a", store.chain)
+	require.Equal(t, frozen, store.updated)
+	require.Len(t, store.games, 2)
+
+	game := store.games[0]
+	require.Equal(t, inProgress.Proxy, game.Address)
+	require.Equal(t, uint32(1), game.GameType)
+	require.Equal(t, "In Progress", game.Status)
+	require.Equal(t, &api.Forecast{
+		Status:             gameTypes.GameStatusChallengerWon.String(),
+		ExpectedResolution: gameTypes.GameStatusChallengerWon.String(),
+	}, game.Forecast)
+	require.Equal(t, (*hexutil.Big)(big.NewInt(5)), game.Credits[honest])
+	require.Equal(t, (*hexutil.Big)(big.NewInt(1000)), game.ETHCollateral)
+	require.Equal(t, (*hexutil.Big)(big.NewInt(20)), game.Bonds.Honest.AtRisk)
+	require.Equal(t, (*hexutil.Big)(big.NewInt(10)), game.Bonds.External.AtRisk)
+	require.Len(t, game.Claims, 2)
+	require.Nil(t, game.Claims[0].ParentIndex)
+	require.Equal(t, "disagree", game.Claims[0].OutputAgreement)
+	require.Equal(t, 0, *game.Claims[1].ParentIndex)
+	require.Equal(t, uint64(1), game.Claims[1].Depth)
+	require.Equal(t, uint64(60), game.Claims[1].ClockDuration)
+	require.Equal(t, "agree", game.Claims[1].OutputAgreement)
+
+	require.Equal(t, complete.Proxy, store.games[1].Address)
+	require.Equal(t, "Defender Won", store.games[1].Status)
+	require.Nil(t, store.games[1].Forecast, "should not forecast completed games")
+}
+
+type stubGameStore struct {
+	chain   string
+	updated time.Time
+	games   []api.Game
+}
+
+func (s *stubGameStore) UpdateGames(chain string, updated time.Time, games []api.Game) {
+	s.chain = chain
+	s.updated = updated
+	s.games = games
+}
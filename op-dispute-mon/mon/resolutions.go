@@ -9,28 +9,32 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
-const MaxResolveDelay = time.Minute
-
 type ResolutionMetrics interface {
 	RecordGameResolutionStatus(status metrics.ResolutionStatus, count int)
+	RecordDelayedResolutions(count int)
 }
 
 type ResolutionMonitor struct {
-	logger  log.Logger
-	clock   RClock
-	metrics ResolutionMetrics
+	logger         log.Logger
+	clock          RClock
+	metrics        ResolutionMetrics
+	delayThreshold time.Duration
 }
 
-func NewResolutionMonitor(logger log.Logger, metrics ResolutionMetrics, clock RClock) *ResolutionMonitor {
+// NewResolutionMonitor creates a ResolutionMonitor which reports resolvable games that
+// remain unresolved for longer than delayThreshold as delayed.
+func NewResolutionMonitor(logger log.Logger, metrics ResolutionMetrics, clock RClock, delayThreshold time.Duration) *ResolutionMonitor {
 	return &ResolutionMonitor{
-		logger:  logger,
-		clock:   clock,
-		metrics: metrics,
+		logger:         logger,
+		clock:          clock,
+		metrics:        metrics,
+		delayThreshold: delayThreshold,
 	}
 }
 
 func (r *ResolutionMonitor) CheckResolutions(games []*types.EnrichedGameData) {
 	statusMetrics := make(map[metrics.ResolutionStatus]int)
+	delayed := 0
 	for _, game := range games {
 		complete := game.Status != gameTypes.GameStatusInProgress
 		duration := uint64(r.clock.Now().Unix()) - game.Timestamp
@@ -50,7 +54,8 @@ func (r *ResolutionMonitor) CheckResolutions(games []*types.EnrichedGameData) {
 			if maxDurationReached {
 				// SAFETY: since maxDurationReached is true, this cannot underflow
 				delay := duration - (2 * game.MaxClockDuration)
-				if delay > uint64(MaxResolveDelay.Seconds()) {
+				if delay > uint64(r.delayThreshold.Seconds()) {
+					delayed++
 					r.logger.Warn("Resolvable game has taken too long to resolve", "game", game.Proxy, "delay", delay)
 				}
 				statusMetrics[metrics.ResolvableMaxDuration]++
@@ -73,4 +78,5 @@ func (r *ResolutionMonitor) CheckResolutions(games []*types.EnrichedGameData) {
 	r.metrics.RecordGameResolutionStatus(metrics.ResolvableBeforeMaxDuration, statusMetrics[metrics.ResolvableBeforeMaxDuration])
 	r.metrics.RecordGameResolutionStatus(metrics.InProgressMaxDuration, statusMetrics[metrics.InProgressMaxDuration])
 	r.metrics.RecordGameResolutionStatus(metrics.InProgressBeforeMaxDuration, statusMetrics[metrics.InProgressBeforeMaxDuration])
+	r.metrics.RecordDelayedResolutions(delayed)
 }
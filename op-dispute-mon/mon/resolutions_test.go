@@ -24,17 +24,44 @@ func TestResolutionMonitor_CheckResolutions(t *testing.T) {
 	require.Equal(t, 1, m.calls[metrics.ResolvableBeforeMaxDuration])
 	require.Equal(t, 1, m.calls[metrics.InProgressMaxDuration])
 	require.Equal(t, 1, m.calls[metrics.InProgressBeforeMaxDuration])
+	require.Zero(t, m.delayed)
+}
+
+func TestResolutionMonitor_DelayedResolutions(t *testing.T) {
+	r, cl, m := newTestResolutionMonitor(t)
+	now := uint64(cl.Now().Unix())
+	maxClockDuration := uint64(10 * time.Minute.Seconds())
+	resolvableGame := func(delay time.Duration) *types.EnrichedGameData {
+		return &types.EnrichedGameData{
+			GameMetadata:     gameTypes.GameMetadata{Timestamp: now - 2*maxClockDuration - uint64(delay.Seconds())},
+			MaxClockDuration: maxClockDuration,
+			Status:           gameTypes.GameStatusInProgress,
+		}
+	}
+	games := []*types.EnrichedGameData{
+		resolvableGame(time.Minute),
+		resolvableGame(time.Minute + time.Second),
+		resolvableGame(time.Hour),
+	}
+	r.CheckResolutions(games)
+	require.Equal(t, 3, m.calls[metrics.ResolvableMaxDuration])
+	require.Equal(t, 2, m.delayed)
 }
 
 func newTestResolutionMonitor(t *testing.T) (*ResolutionMonitor, *clock.DeterministicClock, *stubResolutionMetrics) {
 	logger := testlog.Logger(t, log.LvlInfo)
 	cl := clock.NewDeterministicClock(time.Unix(int64(time.Hour.Seconds()), 0))
 	metrics := &stubResolutionMetrics{}
-	return NewResolutionMonitor(logger, metrics, cl), cl, metrics
+	return NewResolutionMonitor(logger, metrics, cl, time.Minute), cl, metrics
 }
 
 type stubResolutionMetrics struct {
-	calls map[metrics.ResolutionStatus]int
+	calls   map[metrics.ResolutionStatus]int
+	delayed int
+}
+
+func (s *stubResolutionMetrics) RecordDelayedResolutions(count int) {
+	s.delayed = count
 }
 
 func (s *stubResolutionMetrics) RecordGameResolutionStatus(status metrics.ResolutionStatus, count int) {
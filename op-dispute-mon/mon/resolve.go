@@ -5,6 +5,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
 )
@@ -34,3 +35,25 @@ func Resolve(tree *monTypes.BidirectionalTree) gameTypes.GameStatus {
 		return gameTypes.GameStatusChallengerWon
 	}
 }
+
+// ResolveWithCounters iterates backwards over the bidirectional tree like Resolve, but assumes
+// that any claim that is not yet countered will be countered if willCounter returns true for it.
+// Once the root claim is reached, the game status it is expected to resolve with is returned.
+func ResolveWithCounters(tree *monTypes.BidirectionalTree, willCounter func(claim *faultTypes.Claim) bool) gameTypes.GameStatus {
+	countered := make(map[*monTypes.BidirectionalClaim]bool, len(tree.Claims))
+	for i := len(tree.Claims) - 1; i >= 0; i-- {
+		claim := tree.Claims[i]
+		isCountered := claim.Claim.CounteredBy != (common.Address{})
+		for _, child := range claim.Children {
+			if !countered[child] {
+				isCountered = true
+				break
+			}
+		}
+		countered[claim] = isCountered || willCounter(claim.Claim)
+	}
+	if len(tree.Claims) == 0 || !countered[tree.Claims[0]] {
+		return gameTypes.GameStatusDefenderWon
+	}
+	return gameTypes.GameStatusChallengerWon
+}
@@ -169,6 +169,58 @@ func TestResolver_Resolve(t *testing.T) {
 	})
 }
 
+func TestResolver_ResolveWithCounters(t *testing.T) {
+	never := func(claim *faultTypes.Claim) bool { return false }
+
+	t.Run("NoClaims", func(t *testing.T) {
+		tree := transform.CreateBidirectionalTree([]monTypes.EnrichedClaim{})
+		status := ResolveWithCounters(tree, never)
+		require.Equal(t, gameTypes.GameStatusDefenderWon, status)
+	})
+
+	t.Run("MatchesResolveWithoutCounters", func(t *testing.T) {
+		builder := test.NewAlphabetClaimBuilder(t, big.NewInt(10), 5).GameBuilder()
+		builder.Seq().Attack().Attack().Defend()
+		tree := transform.CreateBidirectionalTree(enrichClaims(builder.Game.Claims()))
+		status := ResolveWithCounters(tree, never)
+		require.Equal(t, gameTypes.GameStatusChallengerWon, status)
+	})
+
+	t.Run("CounterRootClaim", func(t *testing.T) {
+		builder := test.NewAlphabetClaimBuilder(t, big.NewInt(10), 4).GameBuilder()
+		tree := transform.CreateBidirectionalTree(enrichClaims(builder.Game.Claims()))
+		status := ResolveWithCounters(tree, func(claim *faultTypes.Claim) bool {
+			return claim.IsRoot()
+		})
+		require.Equal(t, gameTypes.GameStatusChallengerWon, status)
+	})
+
+	t.Run("CounterLeafClaim", func(t *testing.T) {
+		builder := test.NewAlphabetClaimBuilder(t, big.NewInt(10), 5).GameBuilder()
+		builder.Seq(). // Defender winning
+				Attack(). // Challenger winning
+				Attack(). // Defender winning
+				Defend()  // Challenger winning
+		claims := builder.Game.Claims()
+		leaf := claims[len(claims)-1].ContractIndex
+		tree := transform.CreateBidirectionalTree(enrichClaims(claims))
+		status := ResolveWithCounters(tree, func(claim *faultTypes.Claim) bool {
+			return claim.ContractIndex == leaf
+		})
+		require.Equal(t, gameTypes.GameStatusDefenderWon, status)
+	})
+
+	t.Run("DoesNotModifyTree", func(t *testing.T) {
+		builder := test.NewAlphabetClaimBuilder(t, big.NewInt(10), 4).GameBuilder()
+		builder.Seq().Attack()
+		tree := transform.CreateBidirectionalTree(enrichClaims(builder.Game.Claims()))
+		ResolveWithCounters(tree, never)
+		for _, claim := range tree.Claims {
+			require.Equal(t, common.Address{}, claim.Claim.CounteredBy)
+		}
+	})
+}
+
 func enrichClaims(claims []faultTypes.Claim) []monTypes.EnrichedClaim {
 	enriched := make([]monTypes.EnrichedClaim, len(claims))
 	for i, claim := range claims {
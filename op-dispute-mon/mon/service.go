@@ -2,55 +2,38 @@ package mon
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
-	"math/big"
 	"sync/atomic"
 
-	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/bonds"
-	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	_ "github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/api"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/archive"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/config"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
-	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/extract"
-	"github.com/ethereum-optimism/optimism/op-dispute-mon/version"
 
-	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
-	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
-	"github.com/ethereum-optimism/optimism/op-service/sources"
-	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 )
 
 type Service struct {
-	logger       log.Logger
-	metrics      metrics.Metricer
-	monitor      *gameMonitor
-	honestActors types.HonestActors
-
-	factoryContract *contracts.DisputeGameFactoryContract
+	logger   log.Logger
+	registry *prometheus.Registry
+	chains   []*chainMonitor
 
 	cl clock.Clock
 
-	extractor    *extract.Extractor
-	forecast     *Forecast
-	bonds        *bonds.Bonds
-	game         *extract.GameCallerCreator
-	resolutions  *ResolutionMonitor
-	claims       *ClaimMonitor
-	withdrawals  *WithdrawalMonitor
-	rollupClient *sources.RollupClient
-
-	l1Client *ethclient.Client
-
 	pprofService *oppprof.Service
 	metricsSrv   *httputil.HTTPServer
+	apiStore     *api.Store
+	apiSrv       *httputil.HTTPServer
+	archiveDB    *sql.DB
 
 	stopped atomic.Bool
 }
@@ -58,10 +41,9 @@ type Service struct {
 // NewService creates a new Service.
 func NewService(ctx context.Context, logger log.Logger, cfg *config.Config) (*Service, error) {
 	s := &Service{
-		cl:           clock.SystemClock,
-		logger:       logger,
-		metrics:      metrics.NewMetrics(),
-		honestActors: types.NewHonestActors(cfg.HonestActors),
+		cl:       clock.SystemClock,
+		logger:   logger,
+		registry: opmetrics.NewRegistry(),
 	}
 
 	if err := s.initFromConfig(ctx, cfg); err != nil {
@@ -72,97 +54,51 @@ func NewService(ctx context.Context, logger log.Logger, cfg *config.Config) (*Se
 }
 
 func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error {
-	if err := s.initL1Client(ctx, cfg); err != nil {
-		return fmt.Errorf("failed to init l1 client: %w", err)
-	}
 	if err := s.initPProf(&cfg.PprofConfig); err != nil {
 		return fmt.Errorf("failed to init profiling: %w", err)
 	}
 	if err := s.initMetricsServer(&cfg.MetricsConfig); err != nil {
 		return fmt.Errorf("failed to init metrics server: %w", err)
 	}
-	if err := s.initFactoryContract(cfg); err != nil {
-		return fmt.Errorf("failed to create factory contract bindings: %w", err)
+	if err := s.initAPIServer(cfg); err != nil {
+		return fmt.Errorf("failed to init api server: %w", err)
 	}
-	if err := s.initOutputRollupClient(ctx, cfg); err != nil {
-		return fmt.Errorf("failed to init rollup client: %w", err)
+	if err := s.initArchiveDB(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to init archive database: %w", err)
 	}
-
-	s.initClaimMonitor(cfg)
-	s.initResolutionMonitor()
-	s.initWithdrawalMonitor()
-
-	s.initGameCallerCreator() // Must be called before initForecast
-
-	s.initExtractor(cfg)
-
-	s.initForecast(cfg)
-	s.initBonds()
-
-	s.initMonitor(ctx, cfg) // Monitor must be initialized last
-
-	s.metrics.RecordInfo(version.SimpleWithMeta)
-	s.metrics.RecordUp()
-
-	return nil
-}
-
-func (s *Service) initClaimMonitor(cfg *config.Config) {
-	s.claims = NewClaimMonitor(s.logger, s.cl, s.honestActors, s.metrics)
-}
-
-func (s *Service) initResolutionMonitor() {
-	s.resolutions = NewResolutionMonitor(s.logger, s.metrics, s.cl)
-}
-
-func (s *Service) initWithdrawalMonitor() {
-	s.withdrawals = NewWithdrawalMonitor(s.logger, s.cl, s.metrics, s.honestActors)
-}
-
-func (s *Service) initGameCallerCreator() {
-	s.game = extract.NewGameCallerCreator(s.metrics, batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize))
-}
-
-func (s *Service) initExtractor(cfg *config.Config) {
-	s.extractor = extract.NewExtractor(
-		s.logger,
-		s.game.CreateContract,
-		s.factoryContract.GetGamesAtOrAfter,
-		cfg.IgnoredGames,
-		cfg.MaxConcurrency,
-		extract.NewClaimEnricher(),
-		extract.NewRecipientEnricher(), // Must be called before WithdrawalsEnricher and BondEnricher
-		extract.NewWithdrawalsEnricher(),
-		extract.NewBondEnricher(),
-		extract.NewBalanceEnricher(),
-		extract.NewL1HeadBlockNumEnricher(s.l1Client),
-		extract.NewAgreementEnricher(s.logger, s.metrics, s.rollupClient),
-	)
-}
-
-func (s *Service) initForecast(cfg *config.Config) {
-	s.forecast = NewForecast(s.logger, s.metrics)
+	var store GameStore
+	if s.apiStore != nil {
+		store = s.apiStore
+	}
+	return s.initChains(cfg.ChainConfigs(), func(chainCfg config.ChainConfig, m metrics.Metricer) (*chainMonitor, error) {
+		return newChainMonitor(ctx, s.logger, s.cl, m, store, s.archiveDB, cfg, chainCfg)
+	})
 }
 
-func (s *Service) initBonds() {
-	s.bonds = bonds.NewBonds(s.logger, s.metrics, s.cl)
-}
+type chainMonitorFactory func(chainCfg config.ChainConfig, m metrics.Metricer) (*chainMonitor, error)
 
-func (s *Service) initOutputRollupClient(ctx context.Context, cfg *config.Config) error {
-	outputRollupClient, err := dial.DialRollupClientWithTimeout(ctx, dial.DefaultDialTimeout, s.logger, cfg.RollupRpc)
-	if err != nil {
-		return fmt.Errorf("failed to dial rollup client: %w", err)
+// initChains creates the monitor for each chain. A chain that fails to initialize is logged and recorded
+// in its metrics, but does not prevent the other chains from being monitored.
+// An error is only returned if none of the chains could be initialized.
+func (s *Service) initChains(chainCfgs []config.ChainConfig, newChain chainMonitorFactory) error {
+	var result error
+	for _, chainCfg := range chainCfgs {
+		m := metrics.NewChainMetrics(s.registry, chainCfg.Name)
+		chain, err := newChain(chainCfg, m)
+		if err != nil {
+			if chainCfg.Name != "" {
+				err = fmt.Errorf("failed to init chain %v: %w", chainCfg.Name, err)
+			}
+			s.logger.Error("Failed to init chain monitor, chain will not be monitored", "chain", chainCfg.Name, "err", err)
+			m.RecordInitFailed()
+			result = errors.Join(result, err)
+			continue
+		}
+		s.chains = append(s.chains, chain)
 	}
-	s.rollupClient = outputRollupClient
-	return nil
-}
-
-func (s *Service) initL1Client(ctx context.Context, cfg *config.Config) error {
-	l1Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, s.logger, cfg.L1EthRpc)
-	if err != nil {
-		return fmt.Errorf("failed to dial L1: %w", err)
+	if len(s.chains) == 0 {
+		return result
 	}
-	s.l1Client = l1Client
 	return nil
 }
 
@@ -188,11 +124,7 @@ func (s *Service) initMetricsServer(cfg *opmetrics.CLIConfig) error {
 		return nil
 	}
 	s.logger.Debug("starting metrics server", "addr", cfg.ListenAddr, "port", cfg.ListenPort)
-	m, ok := s.metrics.(opmetrics.RegistryMetricer)
-	if !ok {
-		return fmt.Errorf("metrics were enabled, but metricer %T does not expose registry for metrics-server", s.metrics)
-	}
-	metricsSrv, err := opmetrics.StartServer(m.Registry(), cfg.ListenAddr, cfg.ListenPort)
+	metricsSrv, err := opmetrics.StartServer(s.registry, cfg.ListenAddr, cfg.ListenPort)
 	if err != nil {
 		return fmt.Errorf("failed to start metrics server: %w", err)
 	}
@@ -201,45 +133,46 @@ func (s *Service) initMetricsServer(cfg *opmetrics.CLIConfig) error {
 	return nil
 }
 
-func (s *Service) initFactoryContract(cfg *config.Config) error {
-	factoryContract := contracts.NewDisputeGameFactoryContract(s.metrics, cfg.GameFactoryAddress,
-		batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize))
-	s.factoryContract = factoryContract
+func (s *Service) initAPIServer(cfg *config.Config) error {
+	if !cfg.APIEnabled {
+		return nil
+	}
+	var chains []string
+	for _, chain := range cfg.ChainConfigs() {
+		chains = append(chains, chain.Name)
+	}
+	s.apiStore = api.NewStore(chains...)
+	s.logger.Debug("starting api server", "addr", cfg.APIListenAddr, "port", cfg.APIListenPort)
+	apiSrv, err := api.StartServer(s.logger, s.apiStore, cfg.APIListenAddr, cfg.APIListenPort)
+	if err != nil {
+		return fmt.Errorf("failed to start api server: %w", err)
+	}
+	s.logger.Info("started api server", "addr", apiSrv.Addr())
+	s.apiSrv = apiSrv
 	return nil
 }
 
-func (s *Service) initMonitor(ctx context.Context, cfg *config.Config) {
-	blockHashFetcher := func(ctx context.Context, blockNumber *big.Int) (common.Hash, error) {
-		block, err := s.l1Client.BlockByNumber(ctx, blockNumber)
-		if err != nil {
-			return common.Hash{}, fmt.Errorf("failed to fetch block by number: %w", err)
-		}
-		return block.Hash(), nil
+func (s *Service) initArchiveDB(ctx context.Context, cfg *config.Config) error {
+	if cfg.ArchiveDatabaseURL == "" {
+		return nil
 	}
-	l2ChallengesMonitor := NewL2ChallengesMonitor(s.logger, s.metrics)
-	s.monitor = newGameMonitor(
-		ctx,
-		s.logger,
-		s.cl,
-		s.metrics,
-		cfg.MonitorInterval,
-		cfg.GameWindow,
-		s.forecast.Forecast,
-		s.bonds.CheckBonds,
-		s.resolutions.CheckResolutions,
-		s.claims.CheckClaims,
-		s.withdrawals.CheckWithdrawals,
-		l2ChallengesMonitor.CheckL2Challenges,
-		s.extractor.Extract,
-		s.l1Client.BlockNumber,
-		blockHashFetcher,
-	)
+	db, err := sql.Open("postgres", cfg.ArchiveDatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	s.archiveDB = db
+	if err := archive.Migrate(ctx, s.logger, db); err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+	s.logger.Info("Archiving resolved games")
+	return nil
 }
 
 func (s *Service) Start(ctx context.Context) error {
 	s.logger.Info("Starting scheduler")
-	s.logger.Info("Starting monitoring")
-	s.monitor.StartMonitoring()
+	for _, chain := range s.chains {
+		chain.start()
+	}
 	s.logger.Info("Dispute monitor game service start completed")
 	return nil
 }
@@ -252,6 +185,9 @@ func (s *Service) Stop(ctx context.Context) error {
 	s.logger.Info("Stopping dispute mon service")
 
 	var result error
+	for _, chain := range s.chains {
+		chain.stop()
+	}
 	if s.pprofService != nil {
 		if err := s.pprofService.Stop(ctx); err != nil {
 			result = errors.Join(result, fmt.Errorf("failed to close pprof server: %w", err))
@@ -262,6 +198,16 @@ func (s *Service) Stop(ctx context.Context) error {
 			result = errors.Join(result, fmt.Errorf("failed to close metrics server: %w", err))
 		}
 	}
+	if s.apiSrv != nil {
+		if err := s.apiSrv.Stop(ctx); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close api server: %w", err))
+		}
+	}
+	if s.archiveDB != nil {
+		if err := s.archiveDB.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close archive database: %w", err))
+		}
+	}
 	s.stopped.Store(true)
 	s.logger.Info("stopped dispute mon service", "err", result)
 	return result
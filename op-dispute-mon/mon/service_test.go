@@ -0,0 +1,58 @@
+package mon
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/config"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestInitChains(t *testing.T) {
+	errInit := errors.New("boom")
+	chainCfgs := []config.ChainConfig{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+
+	t.Run("IsolateFailedChain", func(t *testing.T) {
+		s := &Service{logger: testlog.Logger(t, log.LevelCrit), registry: opmetrics.NewRegistry()}
+		var created []string
+		err := s.initChains(chainCfgs, func(chainCfg config.ChainConfig, m metrics.Metricer) (*chainMonitor, error) {
+			created = append(created, chainCfg.Name)
+			if chainCfg.Name == "b" {
+				return nil, errInit
+			}
+			return &chainMonitor{}, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b", "c"}, created, "should init all chains")
+		require.Len(t, s.chains, 2)
+
+		count, err := testutil.GatherAndCount(s.registry, metrics.Namespace+"_init_failed")
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+		expected := `
+# HELP op_dispute_mon_init_failed 1 if monitoring failed to start, e.g. because the chain's RPC endpoints were unavailable
+# TYPE op_dispute_mon_init_failed gauge
+op_dispute_mon_init_failed{chain="a"} 0
+op_dispute_mon_init_failed{chain="b"} 1
+op_dispute_mon_init_failed{chain="c"} 0
+`
+		require.NoError(t, testutil.GatherAndCompare(s.registry, strings.NewReader(expected), metrics.Namespace+"_init_failed"))
+	})
+
+	t.Run("AllChainsFailed", func(t *testing.T) {
+		s := &Service{logger: testlog.Logger(t, log.LevelCrit), registry: opmetrics.NewRegistry()}
+		err := s.initChains(chainCfgs, func(chainCfg config.ChainConfig, m metrics.Metricer) (*chainMonitor, error) {
+			return nil, errInit
+		})
+		require.ErrorIs(t, err, errInit)
+		require.ErrorContains(t, err, "failed to init chain c")
+		require.Empty(t, s.chains)
+	})
+}
@@ -10,10 +10,21 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
+// OutputAgreement records whether the output root in a claim matches the monitor's own view of the output root.
+type OutputAgreement uint8
+
+const (
+	// OutputAgreementUnknown is used for claims below the split depth, which don't commit to an output root.
+	OutputAgreementUnknown OutputAgreement = iota
+	OutputAgreementAgree
+	OutputAgreementDisagree
+)
+
 // EnrichedClaim extends the faultTypes.Claim with additional context.
 type EnrichedClaim struct {
 	faultTypes.Claim
-	Resolved bool
+	Resolved        bool
+	OutputAgreement OutputAgreement
 }
 
 type EnrichedGameData struct {
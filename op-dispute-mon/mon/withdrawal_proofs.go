@@ -0,0 +1,171 @@
+package mon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// maxLogBlockRange is the maximum number of blocks to load withdrawal proof logs for in a single request.
+const maxLogBlockRange = 10_000
+
+type LogFetcher interface {
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]ethTypes.Log, error)
+}
+
+type PortalContract interface {
+	WithdrawalProofsQuery(fromBlock uint64, toBlock uint64) ethereum.FilterQuery
+	DecodeWithdrawalProofLog(log *ethTypes.Log) (contracts.WithdrawalProof, error)
+	GetProvenWithdrawals(ctx context.Context, block rpcblock.Block, proofs ...contracts.WithdrawalProof) ([]contracts.ProvenWithdrawal, error)
+}
+
+type WithdrawalProofMetrics interface {
+	RecordWithdrawalProofs(status metrics.WithdrawalProofStatus, count int)
+}
+
+type proofKey struct {
+	withdrawalHash common.Hash
+	proofSubmitter common.Address
+}
+
+type provenWithdrawal struct {
+	proof contracts.WithdrawalProof
+	game  common.Address
+}
+
+// WithdrawalProofMonitor watches for withdrawals proven in the OptimismPortal and checks
+// that the dispute game each withdrawal was proven against has a valid root claim.
+type WithdrawalProofMonitor struct {
+	logger  log.Logger
+	metrics WithdrawalProofMetrics
+	logs    LogFetcher
+	portal  PortalContract
+
+	// nextBlock is the next L1 block to load withdrawal proofs from, or 0 if none have been loaded yet.
+	nextBlock uint64
+	proofs    map[proofKey]provenWithdrawal
+}
+
+func NewWithdrawalProofMonitor(logger log.Logger, metrics WithdrawalProofMetrics, logs LogFetcher, portal PortalContract) *WithdrawalProofMonitor {
+	return &WithdrawalProofMonitor{
+		logger:  logger,
+		metrics: metrics,
+		logs:    logs,
+		portal:  portal,
+		proofs:  make(map[proofKey]provenWithdrawal),
+	}
+}
+
+// CheckWithdrawalProofs loads the withdrawal proofs submitted up to blockNumber and checks them against the games.
+// Proofs that were already loaded are still checked if loading new proofs fails.
+func (m *WithdrawalProofMonitor) CheckWithdrawalProofs(ctx context.Context, blockNumber uint64, games []*types.EnrichedGameData) error {
+	err := m.loadProofs(ctx, blockNumber, games)
+	m.checkProofs(games)
+	return err
+}
+
+func (m *WithdrawalProofMonitor) loadProofs(ctx context.Context, blockNumber uint64, games []*types.EnrichedGameData) error {
+	fromBlock := m.nextBlock
+	if fromBlock == 0 {
+		// Withdrawals can only be proven against a game after it is created, so start from the earliest game.
+		earliest, ok := earliestL1Head(games)
+		if !ok {
+			return nil
+		}
+		fromBlock = earliest
+	}
+	for fromBlock <= blockNumber {
+		toBlock := min(fromBlock+maxLogBlockRange-1, blockNumber)
+		logs, err := m.logs.FilterLogs(ctx, m.portal.WithdrawalProofsQuery(fromBlock, toBlock))
+		if err != nil {
+			return fmt.Errorf("failed to load withdrawal proof logs from block %v to %v: %w", fromBlock, toBlock, err)
+		}
+		proofs := make([]contracts.WithdrawalProof, 0, len(logs))
+		for i := range logs {
+			if logs[i].Removed {
+				continue
+			}
+			proof, err := m.portal.DecodeWithdrawalProofLog(&logs[i])
+			if err != nil {
+				m.logger.Warn("Failed to decode withdrawal proof log", "block", logs[i].BlockNumber, "tx", logs[i].TxHash, "err", err)
+				continue
+			}
+			proofs = append(proofs, proof)
+		}
+		if len(proofs) > 0 {
+			proven, err := m.portal.GetProvenWithdrawals(ctx, rpcblock.ByNumber(toBlock), proofs...)
+			if err != nil {
+				return fmt.Errorf("failed to load proven withdrawals: %w", err)
+			}
+			for i, proof := range proofs {
+				key := proofKey{withdrawalHash: proof.WithdrawalHash, proofSubmitter: proof.ProofSubmitter}
+				m.proofs[key] = provenWithdrawal{proof: proof, game: proven[i].DisputeGame}
+			}
+		}
+		fromBlock = toBlock + 1
+		m.nextBlock = fromBlock
+	}
+	return nil
+}
+
+func (m *WithdrawalProofMonitor) checkProofs(games []*types.EnrichedGameData) {
+	gamesByAddr := make(map[common.Address]*types.EnrichedGameData, len(games))
+	for _, game := range games {
+		gamesByAddr[game.Proxy] = game
+	}
+	earliest, hasGames := earliestL1Head(games)
+
+	var valid, invalid, unverified int
+	for key, proven := range m.proofs {
+		game, ok := gamesByAddr[proven.game]
+		if !ok {
+			if !hasGames || proven.proof.L1Block < earliest {
+				// The game has left the game window so the proof no longer needs to be checked.
+				delete(m.proofs, key)
+				continue
+			}
+			unverified++
+			m.logger.Warn("Unable to verify withdrawal proven against unmonitored game",
+				"withdrawalHash", proven.proof.WithdrawalHash, "proofSubmitter", proven.proof.ProofSubmitter,
+				"game", proven.game, "l1Block", proven.proof.L1Block)
+			continue
+		}
+		if game.AgreeWithClaim {
+			valid++
+			continue
+		}
+		invalid++
+		m.logger.Error("Withdrawal proven against game with invalid root claim",
+			"withdrawalHash", proven.proof.WithdrawalHash, "proofSubmitter", proven.proof.ProofSubmitter,
+			"game", game.Proxy, "status", game.Status, "blockNum", game.L2BlockNumber,
+			"rootClaim", game.RootClaim, "expected", game.ExpectedRootClaim, "l1Block", proven.proof.L1Block)
+	}
+	m.metrics.RecordWithdrawalProofs(metrics.WithdrawalProofValid, valid)
+	m.metrics.RecordWithdrawalProofs(metrics.WithdrawalProofInvalid, invalid)
+	m.metrics.RecordWithdrawalProofs(metrics.WithdrawalProofUnverified, unverified)
+}
+
+// earliestL1Head returns the lowest L1 head block number of the games.
+// Returns false if there are no games with a known L1 head.
+func earliestL1Head(games []*types.EnrichedGameData) (uint64, bool) {
+	var earliest uint64
+	found := false
+	for _, game := range games {
+		if game.L1HeadNum == 0 {
+			continue
+		}
+		if !found || game.L1HeadNum < earliest {
+			earliest = game.L1HeadNum
+			found = true
+		}
+	}
+	return earliest, found
+}
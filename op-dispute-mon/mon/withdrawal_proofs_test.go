@@ -0,0 +1,266 @@
+package mon
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	validGame       = common.Address{0xaa}
+	invalidGame     = common.Address{0xbb}
+	unmonitored     = common.Address{0xcc}
+	invalidProofLog = "Withdrawal proven against game with invalid root claim"
+)
+
+func TestWithdrawalProofMonitor_NoGames(t *testing.T) {
+	monitor, logs, _, m, _ := setupWithdrawalProofTest(t)
+	require.NoError(t, monitor.CheckWithdrawalProofs(context.Background(), 1000, nil))
+	require.Empty(t, logs.queries)
+	require.Equal(t, map[metrics.WithdrawalProofStatus]int{
+		metrics.WithdrawalProofValid:      0,
+		metrics.WithdrawalProofInvalid:    0,
+		metrics.WithdrawalProofUnverified: 0,
+	}, m.proofs)
+}
+
+func TestWithdrawalProofMonitor_LoadFromEarliestGameInChunks(t *testing.T) {
+	monitor, logs, _, _, _ := setupWithdrawalProofTest(t)
+	games := []*types.EnrichedGameData{
+		withdrawalProofGame(validGame, 100, true),
+		withdrawalProofGame(invalidGame, 50, false),
+	}
+	head := uint64(50 + 2*maxLogBlockRange)
+	require.NoError(t, monitor.CheckWithdrawalProofs(context.Background(), head, games))
+	require.Equal(t, [][2]uint64{
+		{50, 50 + maxLogBlockRange - 1},
+		{50 + maxLogBlockRange, 50 + 2*maxLogBlockRange - 1},
+		{head, head},
+	}, logs.queries)
+
+	// Only new blocks are loaded on the next check
+	logs.queries = nil
+	require.NoError(t, monitor.CheckWithdrawalProofs(context.Background(), head+5, games))
+	require.Equal(t, [][2]uint64{{head + 1, head + 5}}, logs.queries)
+}
+
+func TestWithdrawalProofMonitor_CheckProofs(t *testing.T) {
+	monitor, logs, portal, m, capturedLogs := setupWithdrawalProofTest(t)
+	games := []*types.EnrichedGameData{
+		withdrawalProofGame(validGame, 100, true),
+		withdrawalProofGame(invalidGame, 100, false),
+	}
+	logs.add(110, common.Hash{0x01}, common.Address{0x11})
+	logs.add(120, common.Hash{0x02}, common.Address{0x22})
+	logs.add(130, common.Hash{0x03}, common.Address{0x33})
+	portal.games[common.Hash{0x01}] = validGame
+	portal.games[common.Hash{0x02}] = invalidGame
+	portal.games[common.Hash{0x03}] = unmonitored
+
+	require.NoError(t, monitor.CheckWithdrawalProofs(context.Background(), 200, games))
+	require.Equal(t, map[metrics.WithdrawalProofStatus]int{
+		metrics.WithdrawalProofValid:      1,
+		metrics.WithdrawalProofInvalid:    1,
+		metrics.WithdrawalProofUnverified: 1,
+	}, m.proofs)
+	require.Equal(t, []rpcblock.Block{rpcblock.ByNumber(200)}, portal.blocks)
+
+	l := capturedLogs.FindLog(testlog.NewLevelFilter(log.LevelError), testlog.NewMessageFilter(invalidProofLog))
+	require.NotNil(t, l)
+	require.Equal(t, common.Hash{0x02}, l.AttrValue("withdrawalHash"))
+	require.Equal(t, common.Address{0x22}, l.AttrValue("proofSubmitter"))
+	require.Equal(t, invalidGame, l.AttrValue("game"))
+	require.Equal(t, mockRootClaim, l.AttrValue("rootClaim"))
+	require.Equal(t, common.Hash{0xee}, l.AttrValue("expected"))
+	require.EqualValues(t, 120, l.AttrValue("l1Block"))
+}
+
+func TestWithdrawalProofMonitor_ReplaceProofFromSameSubmitter(t *testing.T) {
+	monitor, logs, portal, m, _ := setupWithdrawalProofTest(t)
+	games := []*types.EnrichedGameData{
+		withdrawalProofGame(validGame, 100, true),
+		withdrawalProofGame(invalidGame, 100, false),
+	}
+	logs.add(110, common.Hash{0x01}, common.Address{0x11})
+	portal.games[common.Hash{0x01}] = invalidGame
+	require.NoError(t, monitor.CheckWithdrawalProofs(context.Background(), 200, games))
+	require.Equal(t, 1, m.proofs[metrics.WithdrawalProofInvalid])
+
+	// Withdrawal is re-proven against a valid game
+	logs.add(210, common.Hash{0x01}, common.Address{0x11})
+	portal.games[common.Hash{0x01}] = validGame
+	require.NoError(t, monitor.CheckWithdrawalProofs(context.Background(), 300, games))
+	require.Equal(t, 1, m.proofs[metrics.WithdrawalProofValid])
+	require.Equal(t, 0, m.proofs[metrics.WithdrawalProofInvalid])
+}
+
+func TestWithdrawalProofMonitor_IgnoreRemovedAndInvalidLogs(t *testing.T) {
+	monitor, logs, portal, m, _ := setupWithdrawalProofTest(t)
+	games := []*types.EnrichedGameData{withdrawalProofGame(invalidGame, 100, false)}
+	logs.add(110, common.Hash{0x01}, common.Address{0x11})
+	logs.logs[0].Removed = true
+	logs.add(120, common.Hash{0x02}, common.Address{0x22})
+	logs.logs[1].Data = []byte("invalid")
+	portal.games[common.Hash{0x01}] = invalidGame
+	portal.games[common.Hash{0x02}] = invalidGame
+
+	require.NoError(t, monitor.CheckWithdrawalProofs(context.Background(), 200, games))
+	require.Equal(t, 0, m.proofs[metrics.WithdrawalProofInvalid])
+	require.Empty(t, portal.blocks, "should not load proven withdrawals when there are no proofs")
+}
+
+func TestWithdrawalProofMonitor_PruneProofsOutsideGameWindow(t *testing.T) {
+	monitor, logs, portal, m, _ := setupWithdrawalProofTest(t)
+	logs.add(110, common.Hash{0x01}, common.Address{0x11})
+	logs.add(210, common.Hash{0x02}, common.Address{0x22})
+	portal.games[common.Hash{0x01}] = validGame
+	portal.games[common.Hash{0x02}] = unmonitored
+	require.NoError(t, monitor.CheckWithdrawalProofs(context.Background(), 300, []*types.EnrichedGameData{
+		withdrawalProofGame(validGame, 100, true),
+		withdrawalProofGame(invalidGame, 200, false),
+	}))
+	require.Len(t, monitor.proofs, 2)
+
+	// The valid game leaves the game window
+	require.NoError(t, monitor.CheckWithdrawalProofs(context.Background(), 300, []*types.EnrichedGameData{
+		withdrawalProofGame(invalidGame, 200, false),
+	}))
+	require.Len(t, monitor.proofs, 1)
+	require.Equal(t, 0, m.proofs[metrics.WithdrawalProofValid])
+	require.Equal(t, 1, m.proofs[metrics.WithdrawalProofUnverified])
+
+	// No games remain in the game window
+	require.NoError(t, monitor.CheckWithdrawalProofs(context.Background(), 300, nil))
+	require.Empty(t, monitor.proofs)
+}
+
+func TestWithdrawalProofMonitor_CheckExistingProofsWhenLoadFails(t *testing.T) {
+	monitor, logs, portal, m, _ := setupWithdrawalProofTest(t)
+	games := []*types.EnrichedGameData{withdrawalProofGame(invalidGame, 100, false)}
+	logs.add(110, common.Hash{0x01}, common.Address{0x11})
+	portal.games[common.Hash{0x01}] = invalidGame
+	require.NoError(t, monitor.CheckWithdrawalProofs(context.Background(), 200, games))
+
+	logs.err = errors.New("boom")
+	m.proofs = make(map[metrics.WithdrawalProofStatus]int)
+	err := monitor.CheckWithdrawalProofs(context.Background(), 300, games)
+	require.ErrorIs(t, err, logs.err)
+	require.Equal(t, 1, m.proofs[metrics.WithdrawalProofInvalid])
+
+	// Retries the failed blocks on the next check
+	logs.err = nil
+	logs.queries = nil
+	require.NoError(t, monitor.CheckWithdrawalProofs(context.Background(), 300, games))
+	require.Equal(t, [][2]uint64{{201, 300}}, logs.queries)
+}
+
+func TestWithdrawalProofMonitor_ProvenWithdrawalsError(t *testing.T) {
+	monitor, logs, portal, _, _ := setupWithdrawalProofTest(t)
+	logs.add(110, common.Hash{0x01}, common.Address{0x11})
+	portal.err = errors.New("boom")
+	err := monitor.CheckWithdrawalProofs(context.Background(), 200, []*types.EnrichedGameData{withdrawalProofGame(validGame, 100, true)})
+	require.ErrorIs(t, err, portal.err)
+}
+
+func setupWithdrawalProofTest(t *testing.T) (*WithdrawalProofMonitor, *stubLogFetcher, *stubPortal, *stubWithdrawalProofMetrics, *testlog.CapturingHandler) {
+	logger, capturedLogs := testlog.CaptureLogger(t, log.LvlDebug)
+	logs := &stubLogFetcher{}
+	portal := &stubPortal{games: make(map[common.Hash]common.Address)}
+	m := &stubWithdrawalProofMetrics{proofs: make(map[metrics.WithdrawalProofStatus]int)}
+	return NewWithdrawalProofMonitor(logger, m, logs, portal), logs, portal, m, capturedLogs
+}
+
+func withdrawalProofGame(proxy common.Address, l1HeadNum uint64, agree bool) *types.EnrichedGameData {
+	return &types.EnrichedGameData{
+		GameMetadata:      gameTypes.GameMetadata{Proxy: proxy},
+		L1HeadNum:         l1HeadNum,
+		RootClaim:         mockRootClaim,
+		AgreeWithClaim:    agree,
+		ExpectedRootClaim: common.Hash{0xee},
+	}
+}
+
+type stubLogFetcher struct {
+	err     error
+	logs    []ethTypes.Log
+	queries [][2]uint64
+}
+
+func (s *stubLogFetcher) add(block uint64, withdrawalHash common.Hash, submitter common.Address) {
+	s.logs = append(s.logs, ethTypes.Log{
+		BlockNumber: block,
+		Topics:      []common.Hash{withdrawalHash, common.BytesToHash(submitter.Bytes())},
+	})
+}
+
+func (s *stubLogFetcher) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]ethTypes.Log, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	s.queries = append(s.queries, [2]uint64{from, to})
+	var logs []ethTypes.Log
+	for _, log := range s.logs {
+		if log.BlockNumber >= from && log.BlockNumber <= to {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+type stubPortal struct {
+	err    error
+	games  map[common.Hash]common.Address
+	blocks []rpcblock.Block
+}
+
+func (s *stubPortal) WithdrawalProofsQuery(fromBlock uint64, toBlock uint64) ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+	}
+}
+
+func (s *stubPortal) DecodeWithdrawalProofLog(log *ethTypes.Log) (contracts.WithdrawalProof, error) {
+	if len(log.Data) > 0 {
+		return contracts.WithdrawalProof{}, errors.New("invalid log")
+	}
+	return contracts.WithdrawalProof{
+		WithdrawalHash: log.Topics[0],
+		ProofSubmitter: common.BytesToAddress(log.Topics[1].Bytes()),
+		L1Block:        log.BlockNumber,
+	}, nil
+}
+
+func (s *stubPortal) GetProvenWithdrawals(_ context.Context, block rpcblock.Block, proofs ...contracts.WithdrawalProof) ([]contracts.ProvenWithdrawal, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.blocks = append(s.blocks, block)
+	proven := make([]contracts.ProvenWithdrawal, len(proofs))
+	for i, proof := range proofs {
+		proven[i] = contracts.ProvenWithdrawal{DisputeGame: s.games[proof.WithdrawalHash]}
+	}
+	return proven, nil
+}
+
+type stubWithdrawalProofMetrics struct {
+	proofs map[metrics.WithdrawalProofStatus]int
+}
+
+func (s *stubWithdrawalProofMetrics) RecordWithdrawalProofs(status metrics.WithdrawalProofStatus, count int) {
+	s.proofs[status] = count
+}
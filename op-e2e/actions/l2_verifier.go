@@ -24,6 +24,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/interop"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sequencing"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/status"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-service/client"
@@ -191,7 +192,7 @@ func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher,
 		{
 			Namespace:     "admin",
 			Version:       "",
-			Service:       node.NewAdminAPI(backend, m, log),
+			Service:       node.NewAdminAPI(backend, nil, m, log),
 			Public:        true, // TODO: this field is deprecated. Do we even need this anymore?
 			Authenticated: false,
 		},
@@ -226,6 +227,14 @@ func (s *l2VerifierBackend) StopSequencer(ctx context.Context) (common.Hash, err
 	return common.Hash{}, errors.New("stopping the L2Verifier sequencer is not supported")
 }
 
+func (s *l2VerifierBackend) StartSequencerAt(ctx context.Context, target sequencing.HandoverTarget) (common.Hash, error) {
+	return common.Hash{}, errors.New("scheduling the L2Verifier sequencer start is not supported")
+}
+
+func (s *l2VerifierBackend) StopSequencerAt(ctx context.Context, target sequencing.HandoverTarget) (common.Hash, error) {
+	return common.Hash{}, errors.New("scheduling the L2Verifier sequencer stop is not supported")
+}
+
 func (s *l2VerifierBackend) SequencerActive(ctx context.Context) (bool, error) {
 	return false, nil
 }
@@ -234,6 +243,22 @@ func (s *l2VerifierBackend) OverrideLeader(ctx context.Context) error {
 	return nil
 }
 
+func (s *l2VerifierBackend) EventQueueDepth(ctx context.Context) (map[string]int, error) {
+	return nil, errors.New("event queue introspection of the L2Verifier is not supported")
+}
+
+func (s *l2VerifierBackend) SyncPhase(ctx context.Context) (*eth.SyncPhase, error) {
+	resp := &eth.SyncPhase{
+		Phase:      s.verifier.engine.SyncPhase(),
+		Checkpoint: s.verifier.syncCfg.ELSyncCheckpoint,
+		UnsafeL2:   s.verifier.engine.UnsafeL2Head(),
+	}
+	if start := s.verifier.engine.ELSyncStart(); !start.IsZero() {
+		resp.ELSyncStart = uint64(start.Unix())
+	}
+	return resp, nil
+}
+
 func (s *l2VerifierBackend) OnUnsafeL2Payload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
 	return nil
 }
@@ -279,9 +279,9 @@ type FaultProofProgramTestScenario struct {
 func testFaultProofProgramScenario(t *testing.T, ctx context.Context, sys *System, s *FaultProofProgramTestScenario) {
 	preimageDir := t.TempDir()
 	fppConfig := oppconf.NewConfig(sys.RollupConfig, sys.L2GenesisCfg.Config, s.L1Head, s.L2Head, s.L2OutputRoot, common.Hash(s.L2Claim), s.L2ClaimBlockNumber)
-	fppConfig.L1URL = sys.NodeEndpoint("l1").RPC()
-	fppConfig.L2URL = sys.NodeEndpoint("sequencer").RPC()
-	fppConfig.L1BeaconURL = sys.L1BeaconEndpoint().RestHTTP()
+	fppConfig.L1URLs = []string{sys.NodeEndpoint("l1").RPC()}
+	fppConfig.L2URLs = []string{sys.NodeEndpoint("sequencer").RPC()}
+	fppConfig.L1BeaconURLs = []string{sys.L1BeaconEndpoint().RestHTTP()}
 	fppConfig.DataDir = preimageDir
 	if s.Detached {
 		// When running in detached mode we need to compile the client executable since it will be called directly.
@@ -306,8 +306,8 @@ func testFaultProofProgramScenario(t *testing.T, ctx context.Context, sys *Syste
 
 	t.Log("Running fault proof in offline mode")
 	// Should be able to rerun in offline mode using the pre-fetched images
-	fppConfig.L1URL = ""
-	fppConfig.L2URL = ""
+	fppConfig.L1URLs = nil
+	fppConfig.L2URLs = nil
 	err = opp.FaultProofProgram(ctx, log, fppConfig)
 	require.NoError(t, err)
 
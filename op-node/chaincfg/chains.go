@@ -64,6 +64,13 @@ func ChainByName(name string) *superchain.ChainConfig {
 	return nil
 }
 
+// IsProductionChain returns true if the L2 chain ID belongs to a known chain of the mainnet or sepolia superchain.
+// These chains must not be configured with fork activation times that differ from the bundled configuration.
+func IsProductionChain(chainID uint64) bool {
+	chainCfg, ok := superchain.OPChains[chainID]
+	return ok && (chainCfg.Superchain == "mainnet" || chainCfg.Superchain == "sepolia")
+}
+
 func GetRollupConfig(name string) (*rollup.Config, error) {
 	chainCfg := ChainByName(name)
 	if chainCfg == nil {
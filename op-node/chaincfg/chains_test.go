@@ -34,6 +34,13 @@ func TestGetRollupConfig(t *testing.T) {
 	}
 }
 
+func TestIsProductionChain(t *testing.T) {
+	require.True(t, IsProductionChain(mainnetCfg.L2ChainID.Uint64()))
+	require.True(t, IsProductionChain(sepoliaCfg.L2ChainID.Uint64()))
+	require.False(t, IsProductionChain(sepoliaDev0Cfg.L2ChainID.Uint64()))
+	require.False(t, IsProductionChain(901))
+}
+
 var mainnetCfg = rollup.Config{
 	Genesis: rollup.Genesis{
 		L1: eth.BlockID{
@@ -66,6 +66,18 @@ var (
 		Destination: new(string),
 		Category:    RollupCategory,
 	}
+	L2EngineSecondaryAddr = &cli.StringFlag{
+		Name:     "l2.secondary",
+		Usage:    "Address of a secondary L2 Engine JSON-RPC endpoint, kept in sync with the primary endpoint, to fail over to when the primary endpoint fails",
+		EnvVars:  prefixEnvVars("L2_SECONDARY"),
+		Category: RollupCategory,
+	}
+	L2EngineSecondaryJWTSecret = &cli.StringFlag{
+		Name:     "l2.secondary.jwt-secret",
+		Usage:    "Path to JWT secret key of the secondary L2 Engine. Keys are 32 bytes, hex encoded in a file. Defaults to the primary L2 Engine JWT secret.",
+		EnvVars:  prefixEnvVars("L2_SECONDARY_JWT_SECRET"),
+		Category: RollupCategory,
+	}
 	BeaconAddr = &cli.StringFlag{
 		Name:     "l1.beacon",
 		Usage:    "Address of L1 Beacon-node HTTP endpoint to use.",
@@ -121,6 +133,18 @@ var (
 		}(),
 		Category: RollupCategory,
 	}
+	SyncCheckpointHashFlag = &cli.StringFlag{
+		Name:     "syncmode.checkpoint.hash",
+		Usage:    "Hash of the L2 block to execution-layer sync to, instead of syncing to the unsafe blocks of the network. Requires --syncmode=execution-layer and --syncmode.checkpoint.number.",
+		EnvVars:  prefixEnvVars("SYNCMODE_CHECKPOINT_HASH"),
+		Category: RollupCategory,
+	}
+	SyncCheckpointNumberFlag = &cli.Uint64Flag{
+		Name:     "syncmode.checkpoint.number",
+		Usage:    "Number of the L2 block to execution-layer sync to. Requires --syncmode.checkpoint.hash.",
+		EnvVars:  prefixEnvVars("SYNCMODE_CHECKPOINT_NUMBER"),
+		Category: RollupCategory,
+	}
 	RPCListenAddr = &cli.StringFlag{
 		Name:     "rpc.addr",
 		Usage:    "RPC listening address",
@@ -236,6 +260,31 @@ var (
 		Value:    4,
 		Category: SequencerCategory,
 	}
+	SequencerHealthELSyncFlag = &cli.BoolFlag{
+		Name:     "sequencer.health.el-sync",
+		Usage:    "Halt block-building while the execution engine is syncing.",
+		EnvVars:  prefixEnvVars("SEQUENCER_HEALTH_EL_SYNC"),
+		Category: SequencerCategory,
+	}
+	SequencerHealthMaxL1HeadAgeFlag = &cli.DurationFlag{
+		Name:     "sequencer.health.max-l1-head-age",
+		Usage:    "Halt block-building when the latest known L1 head is older than this duration. Disabled if 0.",
+		EnvVars:  prefixEnvVars("SEQUENCER_HEALTH_MAX_L1_HEAD_AGE"),
+		Value:    0,
+		Category: SequencerCategory,
+	}
+	SequencerHealthConductorFlag = &cli.BoolFlag{
+		Name:     "sequencer.health.conductor",
+		Usage:    "Halt block-building when the conductor does not confirm this sequencer is the leader, checked before each block.",
+		EnvVars:  prefixEnvVars("SEQUENCER_HEALTH_CONDUCTOR"),
+		Category: SequencerCategory,
+	}
+	SequencerStopOnUnhealthyFlag = &cli.BoolFlag{
+		Name:     "sequencer.health.stop-on-failure",
+		Usage:    "Stop the sequencer when a health check fails, instead of retrying block-building until the checks pass. The sequencer can be restarted using the admin_startSequencer RPC.",
+		EnvVars:  prefixEnvVars("SEQUENCER_HEALTH_STOP_ON_FAILURE"),
+		Category: SequencerCategory,
+	}
 	L1EpochPollIntervalFlag = &cli.DurationFlag{
 		Name:     "l1.epoch-poll-interval",
 		Usage:    "Poll interval for retrieving new L1 epoch updates such as safe and finalized block changes. Disabled if 0 or negative.",
@@ -316,6 +365,24 @@ var (
 		EnvVars:  prefixEnvVars("SAFEDB_PATH"),
 		Category: OperationsCategory,
 	}
+	EventTracingLogFlag = &cli.BoolFlag{
+		Name:     "event-tracing.log",
+		Usage:    "Log every event emitted and processed by the rollup driver. This is very verbose, and intended for debugging.",
+		EnvVars:  prefixEnvVars("EVENT_TRACING_LOG"),
+		Category: OperationsCategory,
+	}
+	EventTracingOTLPEndpointFlag = &cli.StringFlag{
+		Name:     "event-tracing.otlp-endpoint",
+		Usage:    "host:port of an OTLP/HTTP collector to export traces of rollup driver event processing to. Disabled if not set.",
+		EnvVars:  prefixEnvVars("EVENT_TRACING_OTLP_ENDPOINT"),
+		Category: OperationsCategory,
+	}
+	EventTracingOTLPInsecureFlag = &cli.BoolFlag{
+		Name:     "event-tracing.otlp-insecure",
+		Usage:    "Export event traces over plain HTTP instead of HTTPS.",
+		EnvVars:  prefixEnvVars("EVENT_TRACING_OTLP_INSECURE"),
+		Category: OperationsCategory,
+	}
 	/* Deprecated Flags */
 	L2EngineSyncEnabled = &cli.BoolFlag{
 		Name:    "l2.engine-sync",
@@ -381,6 +448,8 @@ var requiredFlags = []cli.Flag{
 }
 
 var optionalFlags = []cli.Flag{
+	L2EngineSecondaryAddr,
+	L2EngineSecondaryJWTSecret,
 	SupervisorAddr,
 	BeaconAddr,
 	BeaconHeader,
@@ -388,6 +457,8 @@ var optionalFlags = []cli.Flag{
 	BeaconCheckIgnore,
 	BeaconFetchAllSidecars,
 	SyncModeFlag,
+	SyncCheckpointHashFlag,
+	SyncCheckpointNumberFlag,
 	RPCListenAddr,
 	RPCListenPort,
 	L1TrustRPC,
@@ -401,6 +472,10 @@ var optionalFlags = []cli.Flag{
 	SequencerStoppedFlag,
 	SequencerMaxSafeLagFlag,
 	SequencerL1Confs,
+	SequencerHealthELSyncFlag,
+	SequencerHealthMaxL1HeadAgeFlag,
+	SequencerHealthConductorFlag,
+	SequencerStopOnUnhealthyFlag,
 	L1EpochPollIntervalFlag,
 	RuntimeConfigReloadIntervalFlag,
 	RPCEnableAdmin,
@@ -418,6 +493,9 @@ var optionalFlags = []cli.Flag{
 	ConductorRpcFlag,
 	ConductorRpcTimeoutFlag,
 	SafeDBPath,
+	EventTracingLogFlag,
+	EventTracingOTLPEndpointFlag,
+	EventTracingOTLPInsecureFlag,
 	L2EngineKind,
 }
 
@@ -54,9 +54,28 @@ var (
 	GossipFloodPublishName  = "p2p.gossip.mesh.floodpublish"
 	SyncReqRespName         = "p2p.sync.req-resp"
 	SyncOnlyReqToStaticName = "p2p.sync.onlyreqtostatic"
+	GossipBlobsName         = "p2p.gossip.blobs"
 	P2PPingName             = "p2p.ping"
 )
 
+// Names of the flags overriding the parameters of the peer scoring level.
+var (
+	ScoringDecayIntervalName               = "p2p.scoring.decay-interval"
+	ScoringDecayToZeroName                 = "p2p.scoring.decay-to-zero"
+	ScoringRetainName                      = "p2p.scoring.retain"
+	ScoringTopicWeightName                 = "p2p.scoring.weight.topic"
+	ScoringInvalidMessagesWeightName       = "p2p.scoring.weight.invalid-messages"
+	ScoringBehaviourPenaltyWeightName      = "p2p.scoring.weight.behaviour-penalty"
+	ScoringIPColocationWeightName          = "p2p.scoring.weight.ip-colocation"
+	ScoringIPColocationThresholdName       = "p2p.scoring.ip-colocation-threshold"
+	ScoringAppWeightName                   = "p2p.scoring.weight.app"
+	ScoringGossipThresholdName             = "p2p.scoring.threshold.gossip"
+	ScoringPublishThresholdName            = "p2p.scoring.threshold.publish"
+	ScoringGraylistThresholdName           = "p2p.scoring.threshold.graylist"
+	ScoringAcceptPXThresholdName           = "p2p.scoring.threshold.accept-px"
+	ScoringOpportunisticGraftThresholdName = "p2p.scoring.threshold.opportunistic-graft"
+)
+
 func deprecatedP2PFlags(envPrefix string) []cli.Flag {
 	return []cli.Flag{
 		&cli.StringFlag{
@@ -135,6 +154,105 @@ func P2PFlags(envPrefix string) []cli.Flag {
 			EnvVars:  p2pEnv(envPrefix, "PEER_BANNING_DURATION"),
 			Category: P2PCategory,
 		},
+		&cli.DurationFlag{
+			Name:     ScoringDecayIntervalName,
+			Usage:    "Overrides the interval at which peer scores are decayed. Defaults to the L2 block time.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SCORING_DECAY_INTERVAL"),
+			Category: P2PCategory,
+		},
+		&cli.Float64Flag{
+			Name:     ScoringDecayToZeroName,
+			Usage:    fmt.Sprintf("Overrides the value below which decayed peer scores are reset to zero. Defaults to %v.", p2p.DecayToZero),
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SCORING_DECAY_TO_ZERO"),
+			Category: P2PCategory,
+		},
+		&cli.DurationFlag{
+			Name: ScoringRetainName,
+			Usage: "Overrides how long the score of a disconnected peer is retained, including across restarts when the peerstore is persisted. " +
+				"Defaults to 600 L2 blocks.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SCORING_RETAIN"),
+			Category: P2PCategory,
+		},
+		&cli.Float64Flag{
+			Name:     ScoringTopicWeightName,
+			Usage:    "Overrides the weight of the gossip topic scores. Defaults to 0.8.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SCORING_WEIGHT_TOPIC"),
+			Category: P2PCategory,
+		},
+		&cli.Float64Flag{
+			Name:     ScoringInvalidMessagesWeightName,
+			Usage:    "Overrides the weight of invalid messages delivered on gossip topics. Should be negative. Defaults to -140.4475.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SCORING_WEIGHT_INVALID_MESSAGES"),
+			Category: P2PCategory,
+		},
+		&cli.Float64Flag{
+			Name:     ScoringBehaviourPenaltyWeightName,
+			Usage:    "Overrides the weight of the gossip misbehaviour penalty. Should be negative. Defaults to -16.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SCORING_WEIGHT_BEHAVIOUR_PENALTY"),
+			Category: P2PCategory,
+		},
+		&cli.Float64Flag{
+			Name:     ScoringIPColocationWeightName,
+			Usage:    "Overrides the weight of the penalty for peers sharing an IP. Should be negative. Defaults to -35.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SCORING_WEIGHT_IP_COLOCATION"),
+			Category: P2PCategory,
+		},
+		&cli.IntFlag{
+			Name:     ScoringIPColocationThresholdName,
+			Usage:    "Overrides the number of peers that can share an IP before being penalized. Defaults to 10.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SCORING_IP_COLOCATION_THRESHOLD"),
+			Category: P2PCategory,
+		},
+		&cli.Float64Flag{
+			Name:     ScoringAppWeightName,
+			Usage:    "Overrides the weight of the application (req-resp sync) score. Defaults to 1.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SCORING_WEIGHT_APP"),
+			Category: P2PCategory,
+		},
+		&cli.Float64Flag{
+			Name:     ScoringGossipThresholdName,
+			Usage:    "Overrides the score below which gossip is no longer exchanged with a peer. Should be negative. Defaults to -10.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SCORING_THRESHOLD_GOSSIP"),
+			Category: P2PCategory,
+		},
+		&cli.Float64Flag{
+			Name:     ScoringPublishThresholdName,
+			Usage:    "Overrides the score below which own messages are no longer published to a peer. Should be below the gossip threshold. Defaults to -40.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SCORING_THRESHOLD_PUBLISH"),
+			Category: P2PCategory,
+		},
+		&cli.Float64Flag{
+			Name:     ScoringGraylistThresholdName,
+			Usage:    "Overrides the score below which all messages from a peer are ignored. Should be below the publish threshold. Defaults to -40.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SCORING_THRESHOLD_GRAYLIST"),
+			Category: P2PCategory,
+		},
+		&cli.Float64Flag{
+			Name:     ScoringAcceptPXThresholdName,
+			Usage:    "Overrides the score a peer needs for peer exchange to be accepted from it. Defaults to 20.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SCORING_THRESHOLD_ACCEPT_PX"),
+			Category: P2PCategory,
+		},
+		&cli.Float64Flag{
+			Name:     ScoringOpportunisticGraftThresholdName,
+			Usage:    "Overrides the median mesh score below which better scoring peers are grafted. Defaults to 0.05.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SCORING_THRESHOLD_OPPORTUNISTIC_GRAFT"),
+			Category: P2PCategory,
+		},
 		&cli.StringFlag{
 			Name: P2PPrivPathName,
 			Usage: "Read the hex-encoded 32-byte private key for the peer ID from this txt file. Created if not already exists." +
@@ -402,6 +520,15 @@ func P2PFlags(envPrefix string) []cli.Flag {
 			EnvVars:  p2pEnv(envPrefix, "SYNC_ONLYREQTOSTATIC"),
 			Category: P2PCategory,
 		},
+		&cli.BoolFlag{
+			Name: GossipBlobsName,
+			Usage: "Share recently fetched L1 blob sidecars with peers over gossip, and use the blob sidecars received from peers " +
+				"as fallback when the L1 Beacon API is unavailable. Blob sidecars are verified against their KZG commitments.",
+			Value:    false,
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "GOSSIP_BLOBS"),
+			Category: P2PCategory,
+		},
 		&cli.BoolFlag{
 			Name:     P2PPingName,
 			Usage:    "Enables P2P ping-pong background service",
@@ -36,6 +36,10 @@ type Metricer interface {
 	RecordRPCClientResponse(method string, err error)
 	SetDerivationIdle(status bool)
 	RecordPipelineReset()
+	RecordDerivationStageStep(stage string, duration time.Duration, output bool)
+	RecordDerivationStageQueueDepth(stage string, depth int)
+	RecordDerivationStageReset(stage string)
+	RecordELSyncPhase(phase string)
 	RecordSequencingError()
 	RecordPublishingError()
 	RecordDerivationError()
@@ -95,6 +99,15 @@ type Metrics struct {
 	SequencingErrors *metrics.Event
 	PublishingErrors *metrics.Event
 
+	// Like the event processing, the derivation stage time is tracked with a counter rather than a histogram.
+	DerivationStageSteps   *prometheus.CounterVec
+	DerivationStageOutputs *prometheus.CounterVec
+	DerivationStageTime    *prometheus.CounterVec
+	DerivationStageQueue   *prometheus.GaugeVec
+	DerivationStageResets  metrics.EventVec
+
+	ELSyncPhase *prometheus.GaugeVec
+
 	EmittedEvents   *prometheus.CounterVec
 	ProcessedEvents *prometheus.CounterVec
 
@@ -210,6 +223,44 @@ func NewMetrics(procName string) *Metrics {
 		SequencingErrors: metrics.NewEvent(factory, ns, "", "sequencing_errors", "sequencing errors"),
 		PublishingErrors: metrics.NewEvent(factory, ns, "", "publishing_errors", "p2p publishing errors"),
 
+		DerivationStageSteps: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: ns,
+				Subsystem: "derivation_stage",
+				Name:      "steps",
+				Help:      "number of steps of each derivation stage",
+			}, []string{"stage"}),
+		DerivationStageOutputs: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: ns,
+				Subsystem: "derivation_stage",
+				Name:      "outputs",
+				Help:      "number of outputs of each derivation stage to the next stage",
+			}, []string{"stage"}),
+		DerivationStageTime: factory.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: ns,
+				Subsystem: "derivation_stage",
+				Name:      "time",
+				Help:      "total duration in seconds spent in each derivation stage, excluding the time of the stages it pulls from",
+			}, []string{"stage"}),
+		DerivationStageQueue: factory.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: ns,
+				Subsystem: "derivation_stage",
+				Name:      "queue_depth",
+				Help:      "number of items buffered by each derivation stage",
+			}, []string{"stage"}),
+		DerivationStageResets: metrics.NewEventVec(factory, ns, "derivation_stage", "resets", "derivation stage resets", []string{"stage"}),
+
+		ELSyncPhase: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "el_sync_phase",
+			Help:      "set to 1 for the current sync phase of the engine",
+		}, []string{
+			"phase",
+		}),
+
 		EmittedEvents: factory.NewCounterVec(
 			prometheus.CounterOpts{
 				Namespace: ns,
@@ -474,6 +525,27 @@ func (m *Metrics) RecordPipelineReset() {
 	m.PipelineResets.Record()
 }
 
+func (m *Metrics) RecordDerivationStageStep(stage string, duration time.Duration, output bool) {
+	m.DerivationStageSteps.WithLabelValues(stage).Inc()
+	if output {
+		m.DerivationStageOutputs.WithLabelValues(stage).Inc()
+	}
+	m.DerivationStageTime.WithLabelValues(stage).Add(duration.Seconds())
+}
+
+func (m *Metrics) RecordDerivationStageQueueDepth(stage string, depth int) {
+	m.DerivationStageQueue.WithLabelValues(stage).Set(float64(depth))
+}
+
+func (m *Metrics) RecordDerivationStageReset(stage string) {
+	m.DerivationStageResets.Record(stage)
+}
+
+func (m *Metrics) RecordELSyncPhase(phase string) {
+	m.ELSyncPhase.Reset()
+	m.ELSyncPhase.WithLabelValues(phase).Set(1)
+}
+
 func (m *Metrics) RecordSequencingError() {
 	m.SequencingErrors.Record()
 }
@@ -689,6 +761,18 @@ func (n *noopMetricer) SetDerivationIdle(status bool) {
 func (n *noopMetricer) RecordPipelineReset() {
 }
 
+func (n *noopMetricer) RecordDerivationStageStep(stage string, duration time.Duration, output bool) {
+}
+
+func (n *noopMetricer) RecordDerivationStageQueueDepth(stage string, depth int) {
+}
+
+func (n *noopMetricer) RecordDerivationStageReset(stage string) {
+}
+
+func (n *noopMetricer) RecordELSyncPhase(phase string) {
+}
+
 func (n *noopMetricer) RecordSequencingError() {
 }
 
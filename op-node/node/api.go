@@ -11,6 +11,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sequencing"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -27,13 +28,23 @@ type l2EthClient interface {
 
 type driverClient interface {
 	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
+	SyncPhase(ctx context.Context) (*eth.SyncPhase, error)
 	BlockRefWithStatus(ctx context.Context, num uint64) (eth.L2BlockRef, *eth.SyncStatus, error)
 	ResetDerivationPipeline(context.Context) error
 	StartSequencer(ctx context.Context, blockHash common.Hash) error
 	StopSequencer(context.Context) (common.Hash, error)
+	StartSequencerAt(ctx context.Context, target sequencing.HandoverTarget) (common.Hash, error)
+	StopSequencerAt(ctx context.Context, target sequencing.HandoverTarget) (common.Hash, error)
 	SequencerActive(context.Context) (bool, error)
 	OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
 	OverrideLeader(ctx context.Context) error
+	EventQueueDepth(ctx context.Context) (map[string]int, error)
+}
+
+// engineSwitcher switches the execution engine that the rollup node uses.
+type engineSwitcher interface {
+	ActiveEngine() string
+	SetActiveEngine(ctx context.Context, name string) error
 }
 
 type SafeDBReader interface {
@@ -43,12 +54,15 @@ type SafeDBReader interface {
 type adminAPI struct {
 	*rpc.CommonAdminAPI
 	dr driverClient
+	// engines is nil if there is no secondary execution engine to switch to
+	engines engineSwitcher
 }
 
-func NewAdminAPI(dr driverClient, m metrics.RPCMetricer, log log.Logger) *adminAPI {
+func NewAdminAPI(dr driverClient, engines engineSwitcher, m metrics.RPCMetricer, log log.Logger) *adminAPI {
 	return &adminAPI{
 		CommonAdminAPI: rpc.NewCommonAdminAPI(m, log),
 		dr:             dr,
+		engines:        engines,
 	}
 }
 
@@ -70,6 +84,39 @@ func (n *adminAPI) StopSequencer(ctx context.Context) (common.Hash, error) {
 	return n.dr.StopSequencer(ctx)
 }
 
+// StartSequencerAtBlock starts the sequencer on top of the given L2 block number, once it becomes the unsafe head,
+// and returns the hash of the block it builds on. The call blocks until the sequencer has started.
+func (n *adminAPI) StartSequencerAtBlock(ctx context.Context, number hexutil.Uint64) (common.Hash, error) {
+	recordDur := n.M.RecordRPCServerRequest("admin_startSequencerAtBlock")
+	defer recordDur()
+	return n.dr.StartSequencerAt(ctx, sequencing.HandoverTarget{Number: uint64(number)})
+}
+
+// StartSequencerAtTime starts the sequencer on top of the last L2 block with a timestamp at or before the given time,
+// once it becomes the unsafe head, and returns the hash of the block it builds on.
+// The call blocks until the sequencer has started.
+func (n *adminAPI) StartSequencerAtTime(ctx context.Context, timestamp hexutil.Uint64) (common.Hash, error) {
+	recordDur := n.M.RecordRPCServerRequest("admin_startSequencerAtTime")
+	defer recordDur()
+	return n.dr.StartSequencerAt(ctx, sequencing.HandoverTarget{Time: uint64(timestamp)})
+}
+
+// StopSequencerAtBlock stops the sequencer after it has built the given L2 block number,
+// and returns the hash of that block. The call blocks until the sequencer has stopped.
+func (n *adminAPI) StopSequencerAtBlock(ctx context.Context, number hexutil.Uint64) (common.Hash, error) {
+	recordDur := n.M.RecordRPCServerRequest("admin_stopSequencerAtBlock")
+	defer recordDur()
+	return n.dr.StopSequencerAt(ctx, sequencing.HandoverTarget{Number: uint64(number)})
+}
+
+// StopSequencerAtTime stops the sequencer after it has built the last L2 block with a timestamp at or before the given time,
+// and returns the hash of that block. The call blocks until the sequencer has stopped.
+func (n *adminAPI) StopSequencerAtTime(ctx context.Context, timestamp hexutil.Uint64) (common.Hash, error) {
+	recordDur := n.M.RecordRPCServerRequest("admin_stopSequencerAtTime")
+	defer recordDur()
+	return n.dr.StopSequencerAt(ctx, sequencing.HandoverTarget{Time: uint64(timestamp)})
+}
+
 func (n *adminAPI) SequencerActive(ctx context.Context) (bool, error) {
 	recordDur := n.M.RecordRPCServerRequest("admin_sequencerActive")
 	defer recordDur()
@@ -98,6 +145,39 @@ func (n *adminAPI) OverrideLeader(ctx context.Context) error {
 	return n.dr.OverrideLeader(ctx)
 }
 
+// EventQueueDepth returns the number of events queued up in the rollup driver, by name of the deriver.
+// This can be used to debug stalls in event processing.
+func (n *adminAPI) EventQueueDepth(ctx context.Context) (map[string]int, error) {
+	recordDur := n.M.RecordRPCServerRequest("admin_eventQueueDepth")
+	defer recordDur()
+	return n.dr.EventQueueDepth(ctx)
+}
+
+// ActiveEngine returns the name of the execution engine in use, "primary" or "secondary".
+func (n *adminAPI) ActiveEngine(ctx context.Context) (string, error) {
+	recordDur := n.M.RecordRPCServerRequest("admin_activeEngine")
+	defer recordDur()
+	if n.engines == nil {
+		return EnginePrimary, nil
+	}
+	return n.engines.ActiveEngine(), nil
+}
+
+// SetActiveEngine switches to the execution engine with the given name, "primary" or "secondary".
+// The rollup node also fails over to the secondary execution engine automatically, when the primary one fails.
+// Switching fails if the engine to switch to is not synced.
+func (n *adminAPI) SetActiveEngine(ctx context.Context, name string) error {
+	recordDur := n.M.RecordRPCServerRequest("admin_setActiveEngine")
+	defer recordDur()
+	if n.engines == nil {
+		return ErrNoSecondaryEngine
+	}
+	return n.engines.SetActiveEngine(ctx, name)
+}
+
+// maxOutputsRange is the maximum number of outputs that can be requested with optimism_outputsAtBlockRange
+const maxOutputsRange = 100
+
 type nodeAPI struct {
 	config *rollup.Config
 	client l2EthClient
@@ -121,8 +201,46 @@ func NewNodeAPI(config *rollup.Config, l2Client l2EthClient, dr driverClient, sa
 func (n *nodeAPI) OutputAtBlock(ctx context.Context, number hexutil.Uint64) (*eth.OutputResponse, error) {
 	recordDur := n.m.RecordRPCServerRequest("optimism_outputAtBlock")
 	defer recordDur()
+	return n.outputAtBlock(ctx, uint64(number))
+}
+
+// OutputAtTimestamp returns the output of the last L2 block with a timestamp at or before the given timestamp.
+func (n *nodeAPI) OutputAtTimestamp(ctx context.Context, timestamp hexutil.Uint64) (*eth.OutputResponse, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_outputAtTimestamp")
+	defer recordDur()
+	number, err := n.config.TargetBlockNumber(uint64(timestamp))
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine L2 block at timestamp %d: %w", timestamp, err)
+	}
+	return n.outputAtBlock(ctx, number)
+}
+
+// OutputsAtBlockRange returns the outputs of the L2 blocks from start to end, both inclusive.
+// At most maxOutputsRange outputs can be requested at once.
+func (n *nodeAPI) OutputsAtBlockRange(ctx context.Context, start hexutil.Uint64, end hexutil.Uint64) ([]*eth.OutputResponse, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_outputsAtBlockRange")
+	defer recordDur()
+	if end < start {
+		return nil, fmt.Errorf("invalid block range: end %d is before start %d", end, start)
+	}
+	// Check the distance before adding 1, so the full uint64 range cannot overflow the count.
+	if uint64(end-start) >= maxOutputsRange {
+		return nil, fmt.Errorf("block range from %d to %d exceeds the maximum of %d blocks", start, end, maxOutputsRange)
+	}
+	count := uint64(end-start) + 1
+	outputs := make([]*eth.OutputResponse, 0, count)
+	for i := uint64(0); i < count; i++ {
+		output, err := n.outputAtBlock(ctx, uint64(start)+i)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
 
-	ref, status, err := n.dr.BlockRefWithStatus(ctx, uint64(number))
+func (n *nodeAPI) outputAtBlock(ctx context.Context, number uint64) (*eth.OutputResponse, error) {
+	ref, status, err := n.dr.BlockRefWithStatus(ctx, number)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get L2 block ref with sync status: %w", err)
 	}
@@ -162,6 +280,13 @@ func (n *nodeAPI) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	return n.dr.SyncStatus(ctx)
 }
 
+// SyncPhase returns the sync phase of the rollup node, to track the EL sync of the execution engine.
+func (n *nodeAPI) SyncPhase(ctx context.Context) (*eth.SyncPhase, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_syncPhase")
+	defer recordDur()
+	return n.dr.SyncPhase(ctx)
+}
+
 func (n *nodeAPI) RollupConfig(_ context.Context) (*rollup.Config, error) {
 	recordDur := n.m.RecordRPCServerRequest("optimism_rollupConfig")
 	defer recordDur()
@@ -0,0 +1,65 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+type blobSidecarsPublisher interface {
+	PublishBlobSidecars(ctx context.Context, sidecars []*eth.APIBlobSidecar) error
+}
+
+// gossipBlobsFetcher fetches blobs from the L1 Beacon API, and shares the blob sidecars it fetched with its peers.
+// The L1 Beacon API client is expected to fall back to the blob sidecars cache, with the blob sidecars received from peers.
+type gossipBlobsFetcher struct {
+	log    log.Logger
+	beacon *sources.L1BeaconClient
+	cache  *p2p.BlobSidecarCache
+	// publisher returns the blob sidecars publisher, or nil if the P2P node is not available (yet).
+	publisher func() blobSidecarsPublisher
+}
+
+func (f *gossipBlobsFetcher) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	apiSidecars, err := f.beacon.GetAPIBlobSidecars(ctx, ref, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob sidecars for L1BlockRef %s: %w", ref, err)
+	}
+	sidecars := make([]*eth.BlobSidecar, 0, len(apiSidecars))
+	for _, sidecar := range apiSidecars {
+		sidecars = append(sidecars, sidecar.BlobSidecar())
+	}
+	blobs, err := sources.BlobsFromSidecars(sidecars, hashes)
+	if err != nil {
+		return nil, err
+	}
+	// Share the verified blob sidecars, unless we already have them, e.g. because they were received from peers.
+	// Peers only accept blob sidecars that are bound to their beacon block header by a valid inclusion proof.
+	var fresh []*eth.APIBlobSidecar
+	for _, sidecar := range apiSidecars {
+		if f.cache.Has(eth.KZGToVersionedHash(kzg4844.Commitment(sidecar.KZGCommitment))) {
+			continue
+		}
+		if err := sidecar.VerifyInclusionProof(); err != nil {
+			f.log.Debug("Not sharing blob sidecar without valid inclusion proof", "l1", ref, "index", sidecar.Index, "err", err)
+			continue
+		}
+		f.cache.Add(sidecar)
+		fresh = append(fresh, sidecar)
+	}
+	if pub := f.publisher(); pub != nil && len(fresh) > 0 {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		if err := pub.PublishBlobSidecars(ctx, fresh); err != nil {
+			f.log.Warn("Failed to publish blob sidecars", "l1", ref, "count", len(fresh), "err", err)
+		}
+	}
+	return blobs, nil
+}
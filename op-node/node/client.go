@@ -47,6 +47,13 @@ type L2EndpointConfig struct {
 	// JWT secrets for L2 Engine API authentication during HTTP or initial Websocket communication.
 	// Any value for an IPC connection.
 	L2EngineJWTSecret [32]byte
+
+	// L2EngineSecondaryAddr is the optional address of a secondary L2 Engine JSON-RPC endpoint,
+	// kept synced with the primary endpoint, to fail over to when the primary endpoint fails.
+	L2EngineSecondaryAddr string
+
+	// L2EngineSecondaryJWTSecret is the JWT secret for the secondary L2 Engine API.
+	L2EngineSecondaryJWTSecret [32]byte
 }
 
 var _ L2EndpointSetup = (*L2EndpointConfig)(nil)
@@ -55,6 +62,9 @@ func (cfg *L2EndpointConfig) Check() error {
 	if cfg.L2EngineAddr == "" {
 		return errors.New("empty L2 Engine Address")
 	}
+	if cfg.L2EngineSecondaryAddr == cfg.L2EngineAddr {
+		return errors.New("secondary L2 Engine Address must differ from the primary L2 Engine Address")
+	}
 
 	return nil
 }
@@ -63,19 +73,31 @@ func (cfg *L2EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCf
 	if err := cfg.Check(); err != nil {
 		return nil, nil, err
 	}
-	auth := rpc.WithHTTPAuth(gn.NewJWTAuth(cfg.L2EngineJWTSecret))
-	opts := []client.RPCOption{
-		client.WithGethRPCOptions(auth),
-		client.WithDialBackoff(10),
-	}
-	l2Node, err := client.NewRPC(ctx, log, cfg.L2EngineAddr, opts...)
+	l2Node, err := dialL2Engine(ctx, log, cfg.L2EngineAddr, cfg.L2EngineJWTSecret)
 	if err != nil {
 		return nil, nil, err
 	}
+	if cfg.L2EngineSecondaryAddr != "" {
+		secondary, err := dialL2Engine(ctx, log, cfg.L2EngineSecondaryAddr, cfg.L2EngineSecondaryJWTSecret)
+		if err != nil {
+			l2Node.Close()
+			return nil, nil, fmt.Errorf("failed to dial secondary L2 Engine: %w", err)
+		}
+		l2Node = NewEngineFailoverRPC(log.New("engine", "failover"), l2Node, secondary)
+	}
 
 	return l2Node, sources.EngineClientDefaultConfig(rollupCfg), nil
 }
 
+func dialL2Engine(ctx context.Context, log log.Logger, addr string, jwtSecret [32]byte) (client.RPC, error) {
+	auth := rpc.WithHTTPAuth(gn.NewJWTAuth(jwtSecret))
+	opts := []client.RPCOption{
+		client.WithGethRPCOptions(auth),
+		client.WithDialBackoff(10),
+	}
+	return client.NewRPC(ctx, log, addr, opts...)
+}
+
 // PreparedL2Endpoints enables testing with in-process pre-setup RPC connections to L2 engines
 type PreparedL2Endpoints struct {
 	Client client.RPC
@@ -74,6 +74,9 @@ type Config struct {
 
 	// AltDA config
 	AltDA altda.CLIConfig
+
+	// EventTracing configures tracing of the events processed by the rollup driver.
+	EventTracing EventTracingConfig
 }
 
 type RPCConfig struct {
@@ -0,0 +1,408 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Names of the execution-engine endpoints, as used in the admin API.
+const (
+	EnginePrimary   = "primary"
+	EngineSecondary = "secondary"
+)
+
+var (
+	ErrNoSecondaryEngine = errors.New("no secondary execution engine configured")
+	// ErrStandbyNotSynced is returned when switching to a standby engine that is not synced with the active engine.
+	ErrStandbyNotSynced = errors.New("standby execution engine is not synced")
+)
+
+const (
+	// engineMirrorQueueSize bounds the number of Engine API calls queued up to be mirrored to the standby engine.
+	engineMirrorQueueSize = 100
+	// engineMirrorTimeout bounds the duration of a single mirrored call to the standby engine.
+	engineMirrorTimeout = 10 * time.Second
+	// engineSwitchTimeout bounds the duration of the sync check and of the forkchoice update when switching engines.
+	engineSwitchTimeout = 5 * time.Second
+)
+
+type engineMirrorCall struct {
+	target int
+	method string
+	args   []any
+}
+
+// EngineFailoverRPC is an RPC to a primary and a secondary L2 execution engine.
+// Requests are sent to the active engine, and are retried on the standby engine when they fail with
+// a transport error, after which the standby engine becomes the active engine.
+// Payloads and forkchoice updates that succeed on the active engine are mirrored to the standby engine,
+// to keep it synced, so it can take over without delay. Payload attributes are not mirrored:
+// only the active engine builds blocks.
+//
+// The standby engine is only switched to if it is synced: no mirrored call was dropped or failed since
+// its last valid forkchoice update, and it has the head block of the active engine.
+// After switching, the last forkchoice update, including any payload attributes of an ongoing block-building job,
+// is re-issued to the new active engine, and the payload ID of the job is translated for engine_getPayload requests.
+type EngineFailoverRPC struct {
+	log  log.Logger
+	rpcs [2]client.RPC
+
+	mu     sync.Mutex
+	active int
+	// standbyLagging tracks if the standby engine missed mirrored calls, or failed them,
+	// since its last valid forkchoice update. A lagging standby engine is not switched to.
+	standbyLagging bool
+	// forkchoice is the last forkchoice update that succeeded on the active engine, nil if none yet.
+	// Its payload attributes are cleared once the payload of the block-building job is retrieved.
+	forkchoice *engineMirrorCall
+	// payloadID is the payload ID of the block-building job of the last forkchoice update, if any.
+	payloadID *eth.PayloadID
+	// payloadIDs translates the payload IDs of block-building jobs on a failed engine to
+	// the payload IDs of the jobs re-issued on the engine that took over.
+	payloadIDs map[eth.PayloadID]eth.PayloadID
+
+	mirror  chan engineMirrorCall
+	closing chan struct{}
+	wg      sync.WaitGroup
+}
+
+var _ client.RPC = (*EngineFailoverRPC)(nil)
+
+// NewEngineFailoverRPC creates an EngineFailoverRPC, with the primary engine as active engine.
+// Close must be called to stop the mirroring to the standby engine.
+func NewEngineFailoverRPC(log log.Logger, primary client.RPC, secondary client.RPC) *EngineFailoverRPC {
+	f := &EngineFailoverRPC{
+		log:        log,
+		rpcs:       [2]client.RPC{primary, secondary},
+		payloadIDs: make(map[eth.PayloadID]eth.PayloadID),
+		mirror:     make(chan engineMirrorCall, engineMirrorQueueSize),
+		closing:    make(chan struct{}),
+	}
+	f.wg.Add(1)
+	go f.mirrorLoop()
+	return f
+}
+
+func engineName(idx int) string {
+	if idx == 0 {
+		return EnginePrimary
+	}
+	return EngineSecondary
+}
+
+// ActiveEngine returns the name of the engine that requests are sent to.
+func (f *EngineFailoverRPC) ActiveEngine() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return engineName(f.active)
+}
+
+// SetActiveEngine switches the requests to the engine with the given name.
+// Switching to the standby engine fails if it is not synced with the active engine.
+func (f *EngineFailoverRPC) SetActiveEngine(ctx context.Context, name string) error {
+	var idx int
+	switch name {
+	case EnginePrimary:
+		idx = 0
+	case EngineSecondary:
+		idx = 1
+	default:
+		return fmt.Errorf("unknown engine %q, expected %q or %q", name, EnginePrimary, EngineSecondary)
+	}
+	if f.current() == idx {
+		return nil
+	}
+	if err := f.switchEngine(ctx, 1-idx); err != nil {
+		return err
+	}
+	f.log.Info("Switched active execution engine", "from", engineName(1-idx), "to", name)
+	return nil
+}
+
+func (f *EngineFailoverRPC) current() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.active
+}
+
+// failover makes the standby engine active, unless some other request already switched engines.
+// It returns an error if the standby engine can't take over.
+func (f *EngineFailoverRPC) failover(ctx context.Context, from int, cause error) error {
+	if err := f.switchEngine(ctx, from); err != nil {
+		f.log.Error("Execution engine failed, but cannot fail over", "from", engineName(from), "to", engineName(1-from), "cause", cause, "err", err)
+		return err
+	}
+	f.log.Warn("Execution engine failed, failed over", "from", engineName(from), "to", engineName(1-from), "err", cause)
+	return nil
+}
+
+// switchEngine makes the standby engine active, if the engine is still active and the standby engine is synced.
+// The last forkchoice update is re-issued to the standby engine before it becomes active.
+func (f *EngineFailoverRPC) switchEngine(ctx context.Context, from int) error {
+	to := 1 - from
+	f.mu.Lock()
+	if f.active != from {
+		f.mu.Unlock()
+		return nil
+	}
+	lagging, forkchoice, payloadID := f.standbyLagging, f.forkchoice, f.payloadID
+	f.mu.Unlock()
+
+	if lagging {
+		return fmt.Errorf("%w: %s engine missed mirrored calls", ErrStandbyNotSynced, engineName(to))
+	}
+	ctx, cancel := context.WithTimeout(ctx, engineSwitchTimeout)
+	defer cancel()
+	var result eth.ForkchoiceUpdatedResult
+	if forkchoice != nil {
+		state, ok := forkchoice.args[0].(*eth.ForkchoiceState)
+		if ok {
+			var head *struct {
+				Hash common.Hash `json:"hash"`
+			}
+			if err := f.rpcs[to].CallContext(ctx, &head, "eth_getBlockByHash", state.HeadBlockHash, false); err != nil {
+				return fmt.Errorf("failed to check sync status of %s engine: %w", engineName(to), err)
+			}
+			if head == nil {
+				return fmt.Errorf("%w: %s engine does not have head block %s", ErrStandbyNotSynced, engineName(to), state.HeadBlockHash)
+			}
+		}
+		if err := f.rpcs[to].CallContext(ctx, &result, forkchoice.method, forkchoice.args...); err != nil {
+			return fmt.Errorf("failed to re-issue forkchoice update to %s engine: %w", engineName(to), err)
+		}
+		if result.PayloadStatus.Status != eth.ExecutionValid {
+			return fmt.Errorf("%w: %s engine forkchoice status %s", ErrStandbyNotSynced, engineName(to), result.PayloadStatus.Status)
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.active != from {
+		return nil
+	}
+	f.active = to
+	// The mirrored calls to the previously active engine start from the re-issued forkchoice update.
+	f.standbyLagging = false
+	if payloadID != nil && result.PayloadID != nil {
+		f.payloadIDs[*payloadID] = *result.PayloadID
+		f.payloadID = result.PayloadID
+	}
+	return nil
+}
+
+// isTransportError returns true if the error is a failure to communicate with the engine,
+// rather than an error response of the engine.
+func isTransportError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
+
+// do runs the request on the active engine, and on the standby engine if the active engine fails
+// and the standby engine can take over.
+func (f *EngineFailoverRPC) do(ctx context.Context, req func(r client.RPC) error) (idx int, err error) {
+	idx = f.current()
+	err = req(f.rpcs[idx])
+	if !isTransportError(err) || ctx.Err() != nil {
+		return idx, err
+	}
+	if f.failover(ctx, idx, err) != nil {
+		return idx, err
+	}
+	idx = 1 - idx
+	return idx, req(f.rpcs[idx])
+}
+
+func (f *EngineFailoverRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	idx, err := f.do(ctx, func(r client.RPC) error {
+		return r.CallContext(ctx, result, method, f.translatePayloadID(method, args)...)
+	})
+	if err == nil {
+		f.track(method, args, result)
+		f.mirrorCall(1-idx, method, args)
+	}
+	return err
+}
+
+// translatePayloadID translates the payload ID of engine_getPayload requests,
+// if the block-building job was re-issued to the engine that took over.
+func (f *EngineFailoverRPC) translatePayloadID(method string, args []any) []any {
+	if !strings.HasPrefix(method, "engine_getPayload") || len(args) == 0 {
+		return args
+	}
+	id, ok := args[0].(eth.PayloadID)
+	if !ok {
+		return args
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if translated, ok := f.payloadIDs[id]; ok {
+		return append([]any{translated}, args[1:]...)
+	}
+	return args
+}
+
+// track records the forkchoice updates that succeeded on the active engine, to re-issue the last one after switching.
+func (f *EngineFailoverRPC) track(method string, args []any, result any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case strings.HasPrefix(method, "engine_forkchoiceUpdated") && len(args) > 0:
+		f.forkchoice = &engineMirrorCall{method: method, args: args}
+		f.payloadID = nil
+		if res, ok := result.(*eth.ForkchoiceUpdatedResult); ok && res != nil {
+			f.payloadID = res.PayloadID
+		}
+		// Only the payload IDs of the current block-building job may need translation
+		clear(f.payloadIDs)
+	case strings.HasPrefix(method, "engine_getPayload") && f.forkchoice != nil && len(f.forkchoice.args) > 1:
+		// The block-building job is done, and must not be restarted after switching
+		f.forkchoice = &engineMirrorCall{method: f.forkchoice.method, args: []any{f.forkchoice.args[0], nil}}
+		f.payloadID = nil
+	}
+}
+
+func (f *EngineFailoverRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	_, err := f.do(ctx, func(r client.RPC) error {
+		return r.BatchCallContext(ctx, b)
+	})
+	return err
+}
+
+// EthSubscribe subscribes on the active engine. If the subscription fails with a transport error,
+// e.g. because the connection to the active engine is lost, it fails over and resubscribes on the standby engine.
+func (f *EngineFailoverRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	var sub ethereum.Subscription
+	idx, err := f.do(ctx, func(r client.RPC) error {
+		var err error
+		sub, err = r.EthSubscribe(ctx, channel, args...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		for {
+			select {
+			case err := <-sub.Err():
+				if !isTransportError(err) {
+					return err
+				}
+				switchCtx, cancel := context.WithTimeout(context.Background(), engineSwitchTimeout)
+				if ferr := f.failover(switchCtx, idx, err); ferr != nil {
+					cancel()
+					return err
+				}
+				idx = f.current()
+				sub, err = f.rpcs[idx].EthSubscribe(switchCtx, channel, args...)
+				cancel()
+				if err != nil {
+					return fmt.Errorf("failed to resubscribe on %s engine: %w", engineName(idx), err)
+				}
+			case <-quit:
+				sub.Unsubscribe()
+				return nil
+			}
+		}
+	}), nil
+}
+
+// mirrorCall queues up the call to be mirrored to the given standby engine,
+// if it is a call that changes the chain of the engine.
+// If the queue is full the call is dropped, and the standby engine is marked as lagging,
+// until it confirms a valid forkchoice update again.
+func (f *EngineFailoverRPC) mirrorCall(target int, method string, args []any) {
+	switch {
+	case strings.HasPrefix(method, "engine_newPayload"):
+	case strings.HasPrefix(method, "engine_forkchoiceUpdated") && len(args) > 0:
+		// strip the payload attributes, to not start block-building on the standby engine
+		args = []any{args[0], nil}
+	default:
+		return
+	}
+	select {
+	case f.mirror <- engineMirrorCall{target: target, method: method, args: args}:
+	default:
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if !f.standbyLagging {
+			f.log.Warn("Standby execution engine is lagging, dropping mirrored call", "engine", engineName(target), "method", method)
+		}
+		f.standbyLagging = true
+	}
+}
+
+func (f *EngineFailoverRPC) mirrorLoop() {
+	defer f.wg.Done()
+	for {
+		select {
+		case call := <-f.mirror:
+			f.mirrorOne(call)
+		case <-f.closing:
+			return
+		}
+	}
+}
+
+// mirrorOne runs the mirrored call on the standby engine, and updates whether the standby engine is lagging:
+// any failed or non-valid call makes it lag, and a valid forkchoice update makes it synced again.
+func (f *EngineFailoverRPC) mirrorOne(call engineMirrorCall) {
+	if f.current() == call.target {
+		return // the engines were switched since the call was queued
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), engineMirrorTimeout)
+	defer cancel()
+	var status eth.ExecutePayloadStatus
+	var err error
+	isForkchoice := strings.HasPrefix(call.method, "engine_forkchoiceUpdated")
+	if isForkchoice {
+		var result eth.ForkchoiceUpdatedResult
+		err = f.rpcs[call.target].CallContext(ctx, &result, call.method, call.args...)
+		status = result.PayloadStatus.Status
+	} else {
+		var result eth.PayloadStatusV1
+		err = f.rpcs[call.target].CallContext(ctx, &result, call.method, call.args...)
+		status = result.Status
+	}
+	if err == nil && status != eth.ExecutionValid {
+		err = fmt.Errorf("unexpected payload status %s", status)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if call.target == f.active {
+		return
+	}
+	if err != nil {
+		if !f.standbyLagging {
+			f.log.Warn("Failed to mirror call to standby execution engine", "engine", engineName(call.target), "method", call.method, "err", err)
+		}
+		f.standbyLagging = true
+	} else if isForkchoice && f.standbyLagging {
+		f.log.Info("Standby execution engine is synced again", "engine", engineName(call.target))
+		f.standbyLagging = false
+	}
+}
+
+func (f *EngineFailoverRPC) Close() {
+	close(f.closing)
+	f.wg.Wait()
+	for _, r := range f.rpcs {
+		r.Close()
+	}
+}
@@ -0,0 +1,306 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type fakeEngineCall struct {
+	method string
+	args   []any
+}
+
+type fakeEngineRPCError struct {
+	code int
+}
+
+func (e *fakeEngineRPCError) Error() string {
+	return "engine error"
+}
+
+func (e *fakeEngineRPCError) ErrorCode() int {
+	return e.code
+}
+
+// fakeEngineRPC records the calls, and responds to them with the JSON response for the method, if any.
+// A fresh fake responds to forkchoice updates and payloads as valid, and has all blocks.
+type fakeEngineRPC struct {
+	mu        sync.Mutex
+	calls     []fakeEngineCall
+	err       error
+	responses map[string]string
+	subs      []*fakeEngineSubscription
+}
+
+type fakeEngineSubscription struct {
+	event.Subscription
+	fail chan error
+}
+
+func newFakeEngineRPC() *fakeEngineRPC {
+	return &fakeEngineRPC{responses: map[string]string{
+		"engine_forkchoiceUpdatedV3": `{"payloadStatus":{"status":"VALID"}}`,
+		"engine_newPayloadV3":        `{"status":"VALID"}`,
+		"eth_getBlockByHash":         `{"hash":"0x0100000000000000000000000000000000000000000000000000000000000000"}`,
+	}}
+}
+
+func (f *fakeEngineRPC) Close() {}
+
+func (f *fakeEngineRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, fakeEngineCall{method: method, args: args})
+	if f.err != nil {
+		return f.err
+	}
+	if resp, ok := f.responses[method]; ok && result != nil {
+		return json.Unmarshal([]byte(resp), result)
+	}
+	return nil
+}
+
+func (f *fakeEngineRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, fakeEngineCall{method: "batch"})
+	return f.err
+}
+
+func (f *fakeEngineRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, fakeEngineCall{method: "eth_subscribe", args: args})
+	if f.err != nil {
+		return nil, f.err
+	}
+	fail := make(chan error, 1)
+	sub := &fakeEngineSubscription{
+		Subscription: event.NewSubscription(func(quit <-chan struct{}) error {
+			select {
+			case err := <-fail:
+				return err
+			case <-quit:
+				return nil
+			}
+		}),
+		fail: fail,
+	}
+	f.subs = append(f.subs, sub)
+	return sub, nil
+}
+
+func (f *fakeEngineRPC) setResponse(method string, resp string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses[method] = resp
+}
+
+func (f *fakeEngineRPC) lastCall() fakeEngineCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[len(f.calls)-1]
+}
+
+func (f *fakeEngineRPC) setErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+func (f *fakeEngineRPC) methods() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []string
+	for _, c := range f.calls {
+		out = append(out, c.method)
+	}
+	return out
+}
+
+func setupEngineFailoverTest(t *testing.T) (*EngineFailoverRPC, *fakeEngineRPC, *fakeEngineRPC) {
+	primary, secondary := newFakeEngineRPC(), newFakeEngineRPC()
+	f := NewEngineFailoverRPC(testlog.Logger(t, log.LevelInfo), primary, secondary)
+	t.Cleanup(f.Close)
+	return f, primary, secondary
+}
+
+func TestEngineFailoverRPC(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("MirrorToStandby", func(t *testing.T) {
+		f, primary, secondary := setupEngineFailoverTest(t)
+		state := &eth.ForkchoiceState{HeadBlockHash: [32]byte{1}}
+		attrs := &eth.PayloadAttributes{}
+		require.NoError(t, f.CallContext(ctx, nil, "engine_newPayloadV3", "payload"))
+		require.NoError(t, f.CallContext(ctx, nil, "engine_forkchoiceUpdatedV3", state, attrs))
+		require.NoError(t, f.CallContext(ctx, nil, "eth_getBlockByNumber", "latest", false))
+		require.Equal(t, []string{"engine_newPayloadV3", "engine_forkchoiceUpdatedV3", "eth_getBlockByNumber"}, primary.methods())
+		require.Eventually(t, func() bool {
+			return len(secondary.methods()) == 2
+		}, 5*time.Second, 10*time.Millisecond)
+		require.Equal(t, []string{"engine_newPayloadV3", "engine_forkchoiceUpdatedV3"}, secondary.methods())
+		secondary.mu.Lock()
+		defer secondary.mu.Unlock()
+		require.Equal(t, []any{state, nil}, secondary.calls[1].args, "payload attributes must not be mirrored")
+	})
+
+	t.Run("FailoverOnTransportError", func(t *testing.T) {
+		f, primary, secondary := setupEngineFailoverTest(t)
+		primary.setErr(errors.New("connection refused"))
+		require.NoError(t, f.CallContext(ctx, nil, "eth_chainId"))
+		require.Equal(t, EngineSecondary, f.ActiveEngine())
+		require.Equal(t, []string{"eth_chainId"}, secondary.methods())
+
+		require.NoError(t, f.BatchCallContext(ctx, nil))
+		require.Equal(t, []string{"eth_chainId"}, primary.methods(), "no more requests to failed primary")
+	})
+
+	t.Run("NoFailoverOnRPCError", func(t *testing.T) {
+		f, primary, secondary := setupEngineFailoverTest(t)
+		rpcErr := &fakeEngineRPCError{code: -38002}
+		primary.setErr(rpcErr)
+		err := f.CallContext(ctx, nil, "engine_forkchoiceUpdatedV3", &eth.ForkchoiceState{}, nil)
+		require.ErrorIs(t, err, rpcErr)
+		require.Equal(t, EnginePrimary, f.ActiveEngine())
+		require.Empty(t, secondary.methods(), "failed calls are not mirrored")
+	})
+
+	t.Run("SetActiveEngine", func(t *testing.T) {
+		f, primary, secondary := setupEngineFailoverTest(t)
+		require.NoError(t, f.SetActiveEngine(ctx, EngineSecondary))
+		require.Equal(t, EngineSecondary, f.ActiveEngine())
+		require.NoError(t, f.CallContext(ctx, nil, "engine_newPayloadV3", "payload"))
+		require.Equal(t, []string{"engine_newPayloadV3"}, secondary.methods())
+		require.Eventually(t, func() bool {
+			return len(primary.methods()) == 1
+		}, 5*time.Second, 10*time.Millisecond, "mirrored to primary as standby")
+
+		require.ErrorContains(t, f.SetActiveEngine(ctx, "tertiary"), "unknown engine")
+		require.Equal(t, EngineSecondary, f.ActiveEngine())
+	})
+
+	t.Run("NoFailoverToLaggingStandby", func(t *testing.T) {
+		f, primary, secondary := setupEngineFailoverTest(t)
+		secondary.setResponse("engine_forkchoiceUpdatedV3", `{"payloadStatus":{"status":"SYNCING"}}`)
+		require.NoError(t, f.CallContext(ctx, nil, "engine_forkchoiceUpdatedV3", &eth.ForkchoiceState{HeadBlockHash: [32]byte{1}}, nil))
+		require.Eventually(t, func() bool {
+			return len(secondary.methods()) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+
+		transportErr := errors.New("connection refused")
+		primary.setErr(transportErr)
+		require.Eventually(t, func() bool {
+			return errors.Is(f.CallContext(ctx, nil, "eth_chainId"), transportErr)
+		}, 5*time.Second, 10*time.Millisecond, "must not fail over to lagging standby")
+		require.Equal(t, EnginePrimary, f.ActiveEngine())
+		require.ErrorIs(t, f.SetActiveEngine(ctx, EngineSecondary), ErrStandbyNotSynced)
+
+		// Once the standby confirms a valid forkchoice update again, it can take over
+		primary.setErr(nil)
+		secondary.setResponse("engine_forkchoiceUpdatedV3", `{"payloadStatus":{"status":"VALID"}}`)
+		require.NoError(t, f.CallContext(ctx, nil, "engine_forkchoiceUpdatedV3", &eth.ForkchoiceState{HeadBlockHash: [32]byte{1}}, nil))
+		require.Eventually(t, func() bool {
+			return f.SetActiveEngine(ctx, EngineSecondary) == nil
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("DroppedMirrorCalls", func(t *testing.T) {
+		f, _, _ := setupEngineFailoverTest(t)
+		// Stop the mirroring, to fill up the mirror queue
+		close(f.closing)
+		f.wg.Wait()
+		f.closing = make(chan struct{})
+		for i := 0; i <= engineMirrorQueueSize; i++ {
+			require.NoError(t, f.CallContext(ctx, nil, "engine_newPayloadV3", "payload"))
+		}
+		require.ErrorIs(t, f.SetActiveEngine(ctx, EngineSecondary), ErrStandbyNotSynced)
+	})
+
+	t.Run("NoFailoverToStandbyWithoutHead", func(t *testing.T) {
+		f, primary, secondary := setupEngineFailoverTest(t)
+		secondary.setResponse("eth_getBlockByHash", `null`)
+		require.NoError(t, f.CallContext(ctx, nil, "engine_forkchoiceUpdatedV3", &eth.ForkchoiceState{HeadBlockHash: [32]byte{1}}, nil))
+		transportErr := errors.New("connection refused")
+		primary.setErr(transportErr)
+		require.ErrorIs(t, f.CallContext(ctx, nil, "eth_chainId"), transportErr)
+		require.Equal(t, EnginePrimary, f.ActiveEngine())
+	})
+
+	t.Run("ReissueBuildAfterFailover", func(t *testing.T) {
+		f, primary, secondary := setupEngineFailoverTest(t)
+		primary.setResponse("engine_forkchoiceUpdatedV3", `{"payloadStatus":{"status":"VALID"},"payloadId":"0x0000000000000001"}`)
+		secondary.setResponse("engine_forkchoiceUpdatedV3", `{"payloadStatus":{"status":"VALID"},"payloadId":"0x0000000000000002"}`)
+		state := &eth.ForkchoiceState{HeadBlockHash: [32]byte{1}}
+		attrs := &eth.PayloadAttributes{}
+		var result eth.ForkchoiceUpdatedResult
+		require.NoError(t, f.CallContext(ctx, &result, "engine_forkchoiceUpdatedV3", state, attrs))
+		require.Equal(t, eth.PayloadID{0, 0, 0, 0, 0, 0, 0, 1}, *result.PayloadID)
+		require.Eventually(t, func() bool {
+			return len(secondary.methods()) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+
+		primary.setErr(errors.New("connection refused"))
+		require.NoError(t, f.CallContext(ctx, nil, "engine_getPayloadV3", *result.PayloadID))
+		require.Equal(t, EngineSecondary, f.ActiveEngine())
+		secondary.mu.Lock()
+		defer secondary.mu.Unlock()
+		var methods []string
+		for _, c := range secondary.calls {
+			methods = append(methods, c.method)
+		}
+		// The mirrored forkchoice update, the sync check, the re-issued forkchoice update, and the payload request
+		require.Equal(t, []string{"engine_forkchoiceUpdatedV3", "eth_getBlockByHash", "engine_forkchoiceUpdatedV3", "engine_getPayloadV3"}, methods)
+		require.Equal(t, []any{state, attrs}, secondary.calls[2].args, "block-building must be re-issued")
+		require.Equal(t, []any{eth.PayloadID{0, 0, 0, 0, 0, 0, 0, 2}}, secondary.calls[3].args, "payload ID must be translated")
+	})
+
+	t.Run("SubscriptionFailover", func(t *testing.T) {
+		f, primary, secondary := setupEngineFailoverTest(t)
+		ch := make(chan any)
+		sub, err := f.EthSubscribe(ctx, ch, "newHeads")
+		require.NoError(t, err)
+		defer sub.Unsubscribe()
+		require.Len(t, primary.subs, 1)
+
+		// The connection to the primary engine is lost
+		primary.setErr(errors.New("connection refused"))
+		primary.subs[0].fail <- errors.New("connection lost")
+		require.Eventually(t, func() bool {
+			secondary.mu.Lock()
+			defer secondary.mu.Unlock()
+			return len(secondary.subs) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		require.Equal(t, EngineSecondary, f.ActiveEngine())
+		require.Equal(t, "eth_subscribe", secondary.lastCall().method)
+		select {
+		case err := <-sub.Err():
+			t.Fatalf("subscription must not fail: %v", err)
+		default:
+		}
+
+		// Without an engine to resubscribe on, the subscription fails
+		secondary.setErr(errors.New("connection refused"))
+		secondary.subs[0].fail <- errors.New("connection lost")
+		select {
+		case err := <-sub.Err():
+			require.Error(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("subscription must fail")
+		}
+	})
+}
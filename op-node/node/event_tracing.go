@@ -0,0 +1,50 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+)
+
+type EventTracingConfig struct {
+	// Log logs every event emission and processing by the rollup driver. This is very verbose.
+	Log bool
+	// OTLPEndpoint is the host:port of the OTLP/HTTP collector to export event traces to.
+	// Disabled if empty.
+	OTLPEndpoint string
+	// OTLPInsecure exports event traces over plain HTTP, instead of HTTPS.
+	OTLPInsecure bool
+}
+
+func (n *OpNode) initEventTracing(ctx context.Context, cfg *Config) error {
+	if cfg.EventTracing.Log {
+		n.l2Driver.AddEventTracer(event.NewLogTracer(n.log, slog.LevelInfo))
+	}
+	if cfg.EventTracing.OTLPEndpoint == "" {
+		return nil
+	}
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.EventTracing.OTLPEndpoint)}
+	if cfg.EventTracing.OTLPInsecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+	res := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName("op-node"),
+		semconv.ServiceVersion(n.appVersion))
+	n.eventTraceProvider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res))
+	n.l2Driver.AddEventTracer(event.NewOTelTracer(n.eventTraceProvider))
+	n.log.Info("Exporting event traces", "endpoint", cfg.EventTracing.OTLPEndpoint)
+	return nil
+}
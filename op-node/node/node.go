@@ -12,6 +12,7 @@ import (
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/libp2p/go-libp2p/core/peer"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/event"
@@ -23,6 +24,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-service/client"
@@ -55,6 +57,7 @@ type OpNode struct {
 	l1Source  *sources.L1Client     // L1 Client to fetch data from
 	l2Driver  *driver.Driver        // L2 Engine to Sync
 	l2Source  *sources.EngineClient // L2 Execution Engine RPC bindings
+	l2Engines *EngineFailoverRPC    // L2 Execution Engine failover, nil if there is no secondary engine
 	server    *rpcServer            // RPC server hosting the rollup-node API
 	p2pNode   *p2p.NodeP2P          // P2P node functionality
 	p2pSigner p2p.Signer            // p2p gossip application messages will be signed with this signer
@@ -63,12 +66,16 @@ type OpNode struct {
 
 	safeDB closableSafeDB
 
+	eventTraceProvider *sdktrace.TracerProvider // exports event traces, nil if disabled
+
 	rollupHalt string // when to halt the rollup, disabled if empty
 
 	pprofService *oppprof.Service
 	metricsSrv   *httputil.HTTPServer
 
 	beacon *sources.L1BeaconClient
+	// blobCache retains the blob sidecars received from peers, nil if blob gossip is disabled.
+	blobCache *p2p.BlobSidecarCache
 
 	supervisor *sources.SupervisorClient
 
@@ -89,6 +96,7 @@ type OpNode struct {
 
 // The OpNode handles incoming gossip
 var _ p2p.GossipIn = (*OpNode)(nil)
+var _ p2p.BlobGossipIn = (*OpNode)(nil)
 
 // New creates a new OpNode instance.
 // The provided ctx argument is for the span of initialization only;
@@ -135,6 +143,9 @@ func (n *OpNode) init(ctx context.Context, cfg *Config) error {
 	if err := n.initL2(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init L2: %w", err)
 	}
+	if err := n.initEventTracing(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to init event tracing: %w", err)
+	}
 	if err := n.initRuntimeConfig(ctx, cfg); err != nil { // depends on L2, to signal initial runtime values to
 		return fmt.Errorf("failed to init the runtime config: %w", err)
 	}
@@ -321,6 +332,11 @@ func (n *OpNode) initL1BeaconAPI(ctx context.Context, cfg *Config) error {
 	beaconCfg := sources.L1BeaconClientConfig{
 		FetchAllSidecars: cfg.Beacon.ShouldFetchAllSidecars(),
 	}
+	if n.p2pEnabled() && cfg.P2P.BlobGossipEnabled() {
+		// The blob sidecars received from peers are the last resort, when all Beacon API endpoints fail.
+		n.blobCache = p2p.NewBlobSidecarCache(p2p.DefaultBlobSidecarCacheSize)
+		fallbacks = append(fallbacks, n.blobCache)
+	}
 	n.beacon = sources.NewL1BeaconClient(beaconClient, beaconCfg, fallbacks...)
 
 	// Retry retrieval of the Beacon API version, to be more robust on startup against Beacon API connection issues.
@@ -369,6 +385,9 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config) error {
 	if err != nil {
 		return fmt.Errorf("failed to setup L2 execution-engine RPC client: %w", err)
 	}
+	if engines, ok := rpcClient.(*EngineFailoverRPC); ok {
+		n.l2Engines = engines
+	}
 
 	n.l2Source, err = sources.NewEngineClient(
 		client.NewInstrumentedRPC(rpcClient, &n.metrics.RPCClientMetrics), n.log, n.metrics.L2SourceCache, rpcCfg,
@@ -411,10 +430,28 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config) error {
 		n.safeDB = safedb.Disabled
 	}
 	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source,
-		n.supervisor, n.beacon, n, n, n.log, n.metrics, cfg.ConfigPersistence, n.safeDB, &cfg.Sync, sequencerConductor, altDA)
+		n.supervisor, n.l1Blobs(), n, n, n.log, n.metrics, cfg.ConfigPersistence, n.safeDB, &cfg.Sync, sequencerConductor, altDA)
 	return nil
 }
 
+// l1Blobs returns the L1 blobs fetcher, which shares the fetched blobs with peers if blob gossip is enabled.
+func (n *OpNode) l1Blobs() derive.L1BlobsFetcher {
+	if n.blobCache == nil {
+		return n.beacon
+	}
+	return &gossipBlobsFetcher{
+		log:    n.log,
+		beacon: n.beacon,
+		cache:  n.blobCache,
+		publisher: func() blobSidecarsPublisher {
+			if n.p2pNode == nil || !n.p2pNode.BlobGossipEnabled() {
+				return nil
+			}
+			return n.p2pNode
+		},
+	}
+}
+
 func (n *OpNode) initRPCServer(cfg *Config) error {
 	server, err := newRPCServer(&cfg.RPC, &cfg.Rollup, n.l2Source.L2Client, n.l2Driver, n.safeDB, n.log, n.appVersion, n.metrics)
 	if err != nil {
@@ -424,7 +461,11 @@ func (n *OpNode) initRPCServer(cfg *Config) error {
 		server.EnableP2P(p2p.NewP2PAPIBackend(n.p2pNode, n.log, n.metrics))
 	}
 	if cfg.RPC.EnableAdmin {
-		server.EnableAdminAPI(NewAdminAPI(n.l2Driver, n.metrics, n.log))
+		var engines engineSwitcher
+		if n.l2Engines != nil {
+			engines = n.l2Engines
+		}
+		server.EnableAdminAPI(NewAdminAPI(n.l2Driver, engines, n.metrics, n.log))
 		n.log.Info("Admin RPC enabled")
 	}
 	n.log.Info("Starting JSON-RPC server")
@@ -512,6 +553,11 @@ func (n *OpNode) Start(ctx context.Context) error {
 func (n *OpNode) OnNewL1Head(ctx context.Context, sig eth.L1BlockRef) {
 	n.tracer.OnNewL1Head(ctx, sig)
 
+	// The L1 source only serves block references by number that link to the latest head
+	if n.l1Source != nil {
+		n.l1Source.OnNewL1Head(sig)
+	}
+
 	if n.l2Driver == nil {
 		return
 	}
@@ -563,6 +609,19 @@ func (n *OpNode) PublishL2Payload(ctx context.Context, envelope *eth.ExecutionPa
 	return nil
 }
 
+func (n *OpNode) OnBlobSidecar(ctx context.Context, from peer.ID, sidecar *eth.APIBlobSidecar) error {
+	// ignore if it's from ourselves
+	if n.p2pEnabled() && from == n.p2pNode.Host().ID() {
+		return nil
+	}
+	if n.blobCache == nil {
+		return nil
+	}
+	n.log.Debug("Received blob sidecar from p2p", "commitment", sidecar.KZGCommitment, "peer", from)
+	n.blobCache.Add(sidecar)
+	return nil
+}
+
 func (n *OpNode) OnUnsafeL2Payload(ctx context.Context, from peer.ID, envelope *eth.ExecutionPayloadEnvelope) error {
 	// ignore if it's from ourselves
 	if n.p2pEnabled() && from == n.p2pNode.Host().ID() {
@@ -679,6 +738,13 @@ func (n *OpNode) Stop(ctx context.Context) error {
 		}
 	}
 
+	// flush event traces, after the driver has stopped producing them
+	if n.eventTraceProvider != nil {
+		if err := n.eventTraceProvider.Shutdown(ctx); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to shutdown event trace exporter: %w", err))
+		}
+	}
+
 	if n.safeDB != nil {
 		if err := n.safeDB.Close(); err != nil {
 			result = multierror.Append(result, fmt.Errorf("failed to close safe head db: %w", err))
@@ -3,6 +3,7 @@ package node
 import (
 	"context"
 	"encoding/json"
+	"math"
 	"math/rand"
 	"testing"
 
@@ -16,6 +17,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sequencing"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	rpcclient "github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -126,6 +128,108 @@ func TestOutputAtBlock(t *testing.T) {
 	safeReader.Mock.AssertExpectations(t)
 }
 
+func TestOutputAtTimestamp(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		Genesis:   rollup.Genesis{L2: eth.BlockID{Number: 100}, L2Time: 1000},
+		BlockTime: 2,
+	}
+	rng := rand.New(rand.NewSource(1234))
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	status := randomSyncStatus(rng)
+	ref := testutils.RandomL2BlockRef(rng)
+	ref.Number = 102
+	output := &eth.OutputV0{
+		StateRoot:                eth.Bytes32(testutils.RandomHash(rng)),
+		BlockHash:                ref.Hash,
+		MessagePasserStorageRoot: eth.Bytes32(testutils.RandomHash(rng)),
+	}
+	// timestamp 1005 is between blocks 102 and 103, so resolves to block 102
+	drClient.ExpectBlockRefWithStatus(102, ref, status, nil)
+	l2Client.ExpectOutputV0AtBlock(ref.Hash, output, nil)
+
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var out *eth.OutputResponse
+	err = client.CallContext(context.Background(), &out, "optimism_outputAtTimestamp", hexutil.Uint64(1005))
+	require.NoError(t, err)
+	require.Equal(t, ref, out.BlockRef)
+	require.Equal(t, eth.OutputRoot(output), out.OutputRoot)
+	require.Equal(t, common.Hash(output.StateRoot), out.StateRoot)
+
+	err = client.CallContext(context.Background(), &out, "optimism_outputAtTimestamp", hexutil.Uint64(999))
+	require.ErrorContains(t, err, "genesis time")
+
+	l2Client.Mock.AssertExpectations(t)
+	drClient.Mock.AssertExpectations(t)
+}
+
+func TestOutputsAtBlockRange(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	rng := rand.New(rand.NewSource(1234))
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	status := randomSyncStatus(rng)
+	var refs []eth.L2BlockRef
+	for i := uint64(10); i <= 12; i++ {
+		ref := testutils.RandomL2BlockRef(rng)
+		ref.Number = i
+		refs = append(refs, ref)
+		drClient.ExpectBlockRefWithStatus(i, ref, status, nil)
+		l2Client.ExpectOutputV0AtBlock(ref.Hash, &eth.OutputV0{BlockHash: ref.Hash}, nil)
+	}
+
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var out []*eth.OutputResponse
+	err = client.CallContext(context.Background(), &out, "optimism_outputsAtBlockRange", hexutil.Uint64(10), hexutil.Uint64(12))
+	require.NoError(t, err)
+	require.Len(t, out, len(refs))
+	for i, ref := range refs {
+		require.Equal(t, ref, out[i].BlockRef)
+	}
+
+	err = client.CallContext(context.Background(), &out, "optimism_outputsAtBlockRange", hexutil.Uint64(12), hexutil.Uint64(10))
+	require.ErrorContains(t, err, "invalid block range")
+	err = client.CallContext(context.Background(), &out, "optimism_outputsAtBlockRange", hexutil.Uint64(0), hexutil.Uint64(maxOutputsRange))
+	require.ErrorContains(t, err, "exceeds the maximum")
+	err = client.CallContext(context.Background(), &out, "optimism_outputsAtBlockRange", hexutil.Uint64(0), hexutil.Uint64(math.MaxUint64))
+	require.ErrorContains(t, err, "exceeds the maximum")
+
+	l2Client.Mock.AssertExpectations(t)
+	drClient.Mock.AssertExpectations(t)
+}
+
 func TestVersion(t *testing.T) {
 	log := testlog.Logger(t, log.LevelError)
 	l2Client := &testutils.MockL2Client{}
@@ -200,6 +304,43 @@ func TestSyncStatus(t *testing.T) {
 	assert.Equal(t, status, out)
 }
 
+func TestSyncPhase(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	rng := rand.New(rand.NewSource(1234))
+	phase := &eth.SyncPhase{
+		Phase:       "el-syncing",
+		Checkpoint:  &eth.BlockID{Hash: testutils.RandomHash(rng), Number: 1000},
+		ELSyncStart: 1700000000,
+		UnsafeL2:    testutils.RandomL2BlockRef(rng),
+	}
+	drClient.On("SyncPhase").Return(phase)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var out *eth.SyncPhase
+	err = client.CallContext(context.Background(), &out, "optimism_syncPhase")
+	require.NoError(t, err)
+	require.Equal(t, phase, out)
+}
+
 func TestSafeHeadAtL1Block(t *testing.T) {
 	log := testlog.Logger(t, log.LevelError)
 	l2Client := &testutils.MockL2Client{}
@@ -275,6 +416,16 @@ func (c *mockDriverClient) StopSequencer(ctx context.Context) (common.Hash, erro
 	return c.Mock.MethodCalled("StopSequencer").Get(0).(common.Hash), nil
 }
 
+func (c *mockDriverClient) StartSequencerAt(ctx context.Context, target sequencing.HandoverTarget) (common.Hash, error) {
+	m := c.Mock.MethodCalled("StartSequencerAt", target)
+	return m.Get(0).(common.Hash), *m.Get(1).(*error)
+}
+
+func (c *mockDriverClient) StopSequencerAt(ctx context.Context, target sequencing.HandoverTarget) (common.Hash, error) {
+	m := c.Mock.MethodCalled("StopSequencerAt", target)
+	return m.Get(0).(common.Hash), *m.Get(1).(*error)
+}
+
 func (c *mockDriverClient) SequencerActive(ctx context.Context) (bool, error) {
 	return c.Mock.MethodCalled("SequencerActive").Get(0).(bool), nil
 }
@@ -287,6 +438,14 @@ func (c *mockDriverClient) OverrideLeader(ctx context.Context) error {
 	return c.Mock.MethodCalled("OverrideLeader").Get(0).(error)
 }
 
+func (c *mockDriverClient) SyncPhase(ctx context.Context) (*eth.SyncPhase, error) {
+	return c.Mock.MethodCalled("SyncPhase").Get(0).(*eth.SyncPhase), nil
+}
+
+func (c *mockDriverClient) EventQueueDepth(ctx context.Context) (map[string]int, error) {
+	return c.Mock.MethodCalled("EventQueueDepth").Get(0).(map[string]int), nil
+}
+
 type mockSafeDBReader struct {
 	mock.Mock
 }
@@ -0,0 +1,284 @@
+package p2p
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/snappy"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/time/rate"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// blobSidecarMsgSize is the size of an uncompressed blob sidecar gossip message:
+// the blob, the KZG commitment, the KZG proof, the blob index, the beacon block header
+// (slot, proposer index, parent root, state root and body root), and the inclusion proof of the KZG commitment.
+const blobSidecarMsgSize = eth.BlobSize + 48 + 48 + 8 + (8 + 8 + 32 + 32 + 32) + eth.KZGCommitmentInclusionProofDepth*32
+
+const (
+	// Do not accept more than 2 blob sidecars per second from the same peer, about twice the blob target of L1
+	peerBlobSidecarsRateLimit rate.Limit = 2
+	// Allow a peer to share the blobs of a full L1 block with the maximum blob count, twice
+	peerBlobSidecarsBurst = 18
+	// Do not accept more than 8 blob sidecars per second in total
+	globalBlobSidecarsRateLimit rate.Limit = 8
+	globalBlobSidecarsBurst                = 36
+)
+
+// DefaultBlobSidecarCacheSize is the number of gossiped blob sidecars to retain, about 32 MiB of blob data.
+const DefaultBlobSidecarCacheSize = 256
+
+var ErrBlobSidecarsNotFound = errors.New("blob sidecars not found")
+
+func blobSidecarsTopicV1(cfg *rollup.Config) string {
+	return fmt.Sprintf("/optimism/%s/0/blob_sidecars", cfg.L2ChainID.String())
+}
+
+// BlobGossipIn is implemented by the gossip input, if it handles blob sidecars gossip.
+type BlobGossipIn interface {
+	OnBlobSidecar(ctx context.Context, from peer.ID, sidecar *eth.APIBlobSidecar) error
+}
+
+// BlobSidecarCache retains recent blob sidecars, by versioned hash.
+// Blob sidecars are self-authenticating with their KZG commitment, and bound to their beacon block header
+// by the inclusion proof of the KZG commitment, so the cache can serve blobs from untrusted sources,
+// like gossip, as fallback to the Beacon API.
+type BlobSidecarCache struct {
+	sidecars *lru.Cache[common.Hash, *eth.APIBlobSidecar]
+}
+
+func NewBlobSidecarCache(size int) *BlobSidecarCache {
+	sidecars, err := lru.New[common.Hash, *eth.APIBlobSidecar](size)
+	if err != nil {
+		panic(fmt.Errorf("failed to set up blob sidecars LRU cache: %w", err))
+	}
+	return &BlobSidecarCache{sidecars: sidecars}
+}
+
+// Add adds the blob sidecar to the cache. The blob sidecar is expected to be verified.
+func (c *BlobSidecarCache) Add(sidecar *eth.APIBlobSidecar) {
+	c.sidecars.Add(eth.KZGToVersionedHash(kzg4844.Commitment(sidecar.KZGCommitment)), sidecar)
+}
+
+// Has returns true if the blob sidecar with the given versioned hash is in the cache.
+func (c *BlobSidecarCache) Has(hash common.Hash) bool {
+	return c.sidecars.Contains(hash)
+}
+
+// BeaconBlobSideCars serves the blob sidecars with the given hashes from the cache,
+// so the cache can be used as Beacon API fallback. Only the requested hashes are served,
+// and only if they are bound to a beacon block header of the requested slot, at the requested index.
+func (c *BlobSidecarCache) BeaconBlobSideCars(ctx context.Context, fetchAllSidecars bool, slot uint64, hashes []eth.IndexedBlobHash) (eth.APIGetBlobSidecarsResponse, error) {
+	var resp eth.APIGetBlobSidecarsResponse
+	for _, h := range hashes {
+		sidecar, ok := c.sidecars.Get(h.Hash)
+		if !ok || uint64(sidecar.SignedBlockHeader.Message.Slot) != slot || uint64(sidecar.Index) != h.Index {
+			return eth.APIGetBlobSidecarsResponse{}, fmt.Errorf("%w: missing blob %s at index %d of slot %d", ErrBlobSidecarsNotFound, h.Hash, h.Index, slot)
+		}
+		resp.Data = append(resp.Data, sidecar)
+	}
+	return resp, nil
+}
+
+func encodeBlobSidecar(sidecar *eth.APIBlobSidecar) []byte {
+	header := &sidecar.SignedBlockHeader.Message
+	data := make([]byte, 0, blobSidecarMsgSize)
+	data = append(data, sidecar.Blob[:]...)
+	data = append(data, sidecar.KZGCommitment[:]...)
+	data = append(data, sidecar.KZGProof[:]...)
+	data = binary.BigEndian.AppendUint64(data, uint64(sidecar.Index))
+	data = binary.BigEndian.AppendUint64(data, uint64(header.Slot))
+	data = binary.BigEndian.AppendUint64(data, uint64(header.ProposerIndex))
+	data = append(data, header.ParentRoot[:]...)
+	data = append(data, header.StateRoot[:]...)
+	data = append(data, header.BodyRoot[:]...)
+	for _, branch := range sidecar.InclusionProof {
+		data = append(data, branch[:]...)
+	}
+	return snappy.Encode(nil, data)
+}
+
+func decodeBlobSidecar(data []byte) *eth.APIBlobSidecar {
+	var sidecar eth.APIBlobSidecar
+	header := &sidecar.SignedBlockHeader.Message
+	offset := 0
+	next := func(n int) []byte {
+		offset += n
+		return data[offset-n : offset]
+	}
+	copy(sidecar.Blob[:], next(eth.BlobSize))
+	copy(sidecar.KZGCommitment[:], next(48))
+	copy(sidecar.KZGProof[:], next(48))
+	sidecar.Index = eth.Uint64String(binary.BigEndian.Uint64(next(8)))
+	header.Slot = eth.Uint64String(binary.BigEndian.Uint64(next(8)))
+	header.ProposerIndex = eth.Uint64String(binary.BigEndian.Uint64(next(8)))
+	copy(header.ParentRoot[:], next(32))
+	copy(header.StateRoot[:], next(32))
+	copy(header.BodyRoot[:], next(32))
+	sidecar.InclusionProof = make([]eth.Bytes32, eth.KZGCommitmentInclusionProofDepth)
+	for i := range sidecar.InclusionProof {
+		copy(sidecar.InclusionProof[i][:], next(32))
+	}
+	return &sidecar
+}
+
+// blobSidecarsRateLimiter limits the rate of blob sidecars accepted from each peer, and in total.
+type blobSidecarsRateLimiter struct {
+	mu     sync.Mutex
+	global *rate.Limiter
+	peers  *simplelru.LRU[peer.ID, *rate.Limiter]
+}
+
+func newBlobSidecarsRateLimiter() *blobSidecarsRateLimiter {
+	peers, _ := simplelru.NewLRU[peer.ID, *rate.Limiter](1000, nil)
+	return &blobSidecarsRateLimiter{
+		global: rate.NewLimiter(globalBlobSidecarsRateLimit, globalBlobSidecarsBurst),
+		peers:  peers,
+	}
+}
+
+// Allow returns true if a blob sidecar from the given peer is within the rate limits.
+func (l *blobSidecarsRateLimiter) Allow(id peer.ID) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	rl, ok := l.peers.Get(id)
+	if !ok {
+		rl = rate.NewLimiter(peerBlobSidecarsRateLimit, peerBlobSidecarsBurst)
+		l.peers.Add(id, rl)
+	}
+	return rl.Allow() && l.global.Allow()
+}
+
+// BuildBlobSidecarsValidator builds a validator for blob sidecar gossip messages.
+// A blob sidecar is valid if its blob matches its KZG commitment, as proven by its KZG proof,
+// and if its KZG commitment is included in the body of its beacon block header, as proven by its inclusion proof.
+// The signature of the beacon block header is not verified, since that requires the beacon state:
+// whether the blob is part of the canonical L1 chain is verified later, by its versioned hash, when it is used.
+// Blob sidecars beyond the rate limits are ignored, and not propagated.
+func BuildBlobSidecarsValidator(log log.Logger) pubsub.ValidatorEx {
+	limiter := newBlobSidecarsRateLimiter()
+	return func(ctx context.Context, id peer.ID, message *pubsub.Message) pubsub.ValidationResult {
+		outLen, err := snappy.DecodedLen(message.Data)
+		if err != nil {
+			log.Warn("invalid snappy compression length data", "err", err, "peer", id)
+			return pubsub.ValidationReject
+		}
+		if outLen != blobSidecarMsgSize {
+			log.Warn("invalid blob sidecar size", "size", outLen, "expected", blobSidecarMsgSize, "peer", id)
+			return pubsub.ValidationReject
+		}
+		data, err := snappy.Decode(nil, message.Data)
+		if err != nil {
+			log.Warn("invalid snappy compression", "err", err, "peer", id)
+			return pubsub.ValidationReject
+		}
+
+		// Rate-limit before the expensive proof verification
+		if !limiter.Allow(id) {
+			log.Debug("ignoring blob sidecar beyond rate limit", "peer", id)
+			return pubsub.ValidationIgnore
+		}
+
+		sidecar := decodeBlobSidecar(data)
+		if err := sidecar.VerifyInclusionProof(); err != nil {
+			log.Warn("invalid blob sidecar inclusion proof", "err", err, "peer", id)
+			return pubsub.ValidationReject
+		}
+		if err := eth.VerifyBlobProof(&sidecar.Blob, kzg4844.Commitment(sidecar.KZGCommitment), kzg4844.Proof(sidecar.KZGProof)); err != nil {
+			log.Warn("invalid blob sidecar proof", "err", err, "peer", id)
+			return pubsub.ValidationReject
+		}
+
+		message.ValidatorData = sidecar
+		return pubsub.ValidationAccept
+	}
+}
+
+// BlobSidecarsHandler passes validated blob sidecars on to the given function.
+func BlobSidecarsHandler(onBlobSidecar func(ctx context.Context, from peer.ID, sidecar *eth.APIBlobSidecar) error) MessageHandler {
+	return func(ctx context.Context, from peer.ID, msg any) error {
+		sidecar, ok := msg.(*eth.APIBlobSidecar)
+		if !ok {
+			return fmt.Errorf("expected topic validator to parse and validate data into blob sidecar, but got %T", msg)
+		}
+		return onBlobSidecar(ctx, from, sidecar)
+	}
+}
+
+// blobSidecarsTopic is the optional gossip topic, where nodes share the blob sidecars they fetched from L1,
+// to help nodes derive while their Beacon API is unavailable.
+type blobSidecarsTopic struct {
+	// p2pCancel cancels the topic event handling and the subscriber
+	p2pCancel context.CancelFunc
+
+	topic  *pubsub.Topic
+	events *pubsub.TopicEventHandler
+	sub    *pubsub.Subscription
+}
+
+func joinBlobSidecarsGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, gossipIn BlobGossipIn) (*blobSidecarsTopic, error) {
+	topicId := blobSidecarsTopicV1(cfg)
+	topicLog := log.New("topic", "blobSidecars")
+	validator := guardGossipValidator(log, logValidationResult(self, "validated blob sidecar", topicLog, BuildBlobSidecarsValidator(topicLog)))
+	err := ps.RegisterTopicValidator(topicId,
+		validator,
+		pubsub.WithValidatorTimeout(3*time.Second),
+		pubsub.WithValidatorConcurrency(4))
+	if err != nil {
+		return nil, fmt.Errorf("failed to register gossip topic: %w", err)
+	}
+
+	topic, err := ps.Join(topicId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to join gossip topic: %w", err)
+	}
+
+	events, err := topic.EventHandler()
+	if err != nil {
+		err = errors.Join(err, topic.Close())
+		return nil, fmt.Errorf("failed to create blob sidecars gossip topic handler: %w", err)
+	}
+
+	subscription, err := topic.Subscribe()
+	if err != nil {
+		events.Cancel()
+		err = errors.Join(err, topic.Close())
+		return nil, fmt.Errorf("failed to subscribe to blob sidecars gossip topic: %w", err)
+	}
+
+	p2pCtx, p2pCancel := context.WithCancel(context.Background())
+	go LogTopicEvents(p2pCtx, topicLog, events)
+	subscriber := MakeSubscriber(topicLog, BlobSidecarsHandler(gossipIn.OnBlobSidecar))
+	go subscriber(p2pCtx, subscription)
+
+	return &blobSidecarsTopic{
+		p2pCancel: p2pCancel,
+		topic:     topic,
+		events:    events,
+		sub:       subscription,
+	}, nil
+}
+
+func (bt *blobSidecarsTopic) Publish(ctx context.Context, sidecar *eth.APIBlobSidecar) error {
+	return bt.topic.Publish(ctx, encodeBlobSidecar(sidecar))
+}
+
+func (bt *blobSidecarsTopic) Close() error {
+	bt.p2pCancel()
+	bt.events.Cancel()
+	bt.sub.Cancel()
+	return bt.topic.Close()
+}
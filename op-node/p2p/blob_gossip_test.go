@@ -0,0 +1,146 @@
+package p2p
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/golang/snappy"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsub_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+const testBlobSlot = 123
+
+// makeTestBlobSidecar creates a blob sidecar at index first of testBlobSlot,
+// with a beacon block header that has the body root that the random inclusion proof proves.
+func makeTestBlobSidecar(t *testing.T, first byte) (eth.IndexedBlobHash, *eth.APIBlobSidecar) {
+	rng := rand.New(rand.NewSource(int64(first)))
+	blob := kzg4844.Blob{}
+	blob[0] = first
+	commit, err := kzg4844.BlobToCommitment(&blob)
+	require.NoError(t, err)
+	proof, err := kzg4844.ComputeBlobProof(&blob, commit)
+	require.NoError(t, err)
+	sidecar := &eth.APIBlobSidecar{
+		Index:          eth.Uint64String(first),
+		Blob:           eth.Blob(blob),
+		KZGCommitment:  eth.Bytes48(commit),
+		KZGProof:       eth.Bytes48(proof),
+		InclusionProof: make([]eth.Bytes32, eth.KZGCommitmentInclusionProofDepth),
+	}
+	for i := range sidecar.InclusionProof {
+		sidecar.InclusionProof[i] = eth.Bytes32(testutils.RandomHash(rng))
+	}
+	header := &sidecar.SignedBlockHeader.Message
+	header.Slot = testBlobSlot
+	header.ProposerIndex = eth.Uint64String(rng.Uint64())
+	header.ParentRoot = eth.Bytes32(testutils.RandomHash(rng))
+	header.StateRoot = eth.Bytes32(testutils.RandomHash(rng))
+	header.BodyRoot, err = sidecar.InclusionProofRoot()
+	require.NoError(t, err)
+	return eth.IndexedBlobHash{Index: uint64(first), Hash: eth.KZGToVersionedHash(commit)}, sidecar
+}
+
+func TestBlobSidecarsValidator(t *testing.T) {
+	validator := BuildBlobSidecarsValidator(testlog.Logger(t, log.LevelCrit))
+	validate := func(data []byte) (pubsub.ValidationResult, any) {
+		msg := &pubsub.Message{Message: &pubsub_pb.Message{Data: data}}
+		res := validator(context.Background(), peer.ID("foo"), msg)
+		return res, msg.ValidatorData
+	}
+	_, sidecar := makeTestBlobSidecar(t, 1)
+
+	t.Run("Valid", func(t *testing.T) {
+		res, data := validate(encodeBlobSidecar(sidecar))
+		require.Equal(t, pubsub.ValidationAccept, res)
+		require.Equal(t, sidecar, data)
+	})
+	t.Run("InvalidProof", func(t *testing.T) {
+		invalid := *sidecar
+		invalid.Blob[1] = 1
+		res, _ := validate(encodeBlobSidecar(&invalid))
+		require.Equal(t, pubsub.ValidationReject, res)
+	})
+	t.Run("InvalidInclusionProof", func(t *testing.T) {
+		// A valid blob, not included in the beacon block body
+		invalid := *sidecar
+		invalid.SignedBlockHeader.Message.BodyRoot[0] ^= 1
+		res, _ := validate(encodeBlobSidecar(&invalid))
+		require.Equal(t, pubsub.ValidationReject, res)
+	})
+	t.Run("MovedIndex", func(t *testing.T) {
+		// The inclusion proof binds the index of the blob
+		invalid := *sidecar
+		invalid.Index++
+		res, _ := validate(encodeBlobSidecar(&invalid))
+		require.Equal(t, pubsub.ValidationReject, res)
+	})
+	t.Run("InvalidSize", func(t *testing.T) {
+		res, _ := validate(snappy.Encode(nil, make([]byte, blobSidecarMsgSize-1)))
+		require.Equal(t, pubsub.ValidationReject, res)
+	})
+	t.Run("InvalidSnappy", func(t *testing.T) {
+		res, _ := validate([]byte{0xff, 0xff, 0xff})
+		require.Equal(t, pubsub.ValidationReject, res)
+	})
+}
+
+func TestBlobSidecarsValidatorRateLimit(t *testing.T) {
+	validator := BuildBlobSidecarsValidator(testlog.Logger(t, log.LevelCrit))
+	validate := func(id peer.ID, data []byte) pubsub.ValidationResult {
+		msg := &pubsub.Message{Message: &pubsub_pb.Message{Data: data}}
+		return validator(context.Background(), id, msg)
+	}
+	_, sidecar := makeTestBlobSidecar(t, 1)
+	data := encodeBlobSidecar(sidecar)
+	for i := 0; i < peerBlobSidecarsBurst; i++ {
+		require.Equal(t, pubsub.ValidationAccept, validate("foo", data))
+	}
+	// A peer beyond its rate limit is ignored, without penalty
+	require.Equal(t, pubsub.ValidationIgnore, validate("foo", data))
+	// Other peers are not affected
+	require.Equal(t, pubsub.ValidationAccept, validate("bar", data))
+}
+
+func TestBlobSidecarCache(t *testing.T) {
+	cache := NewBlobSidecarCache(2)
+	hashA, sidecarA := makeTestBlobSidecar(t, 1)
+	hashB, sidecarB := makeTestBlobSidecar(t, 2)
+	hashC, sidecarC := makeTestBlobSidecar(t, 3)
+	cache.Add(sidecarA)
+	cache.Add(sidecarB)
+	require.True(t, cache.Has(hashA.Hash))
+
+	resp, err := cache.BeaconBlobSideCars(context.Background(), false, testBlobSlot, []eth.IndexedBlobHash{hashB, hashA})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 2)
+	require.Equal(t, eth.Uint64String(hashB.Index), resp.Data[0].Index)
+	require.Equal(t, sidecarB.Blob, resp.Data[0].Blob)
+	require.Equal(t, eth.Uint64String(hashA.Index), resp.Data[1].Index)
+	require.Equal(t, sidecarA.KZGProof, resp.Data[1].KZGProof)
+	require.NoError(t, resp.Data[1].VerifyInclusionProof())
+
+	_, err = cache.BeaconBlobSideCars(context.Background(), false, testBlobSlot, []eth.IndexedBlobHash{hashA, hashC})
+	require.ErrorIs(t, err, ErrBlobSidecarsNotFound)
+
+	// Blob sidecars are only served for the slot and index they are bound to
+	_, err = cache.BeaconBlobSideCars(context.Background(), false, testBlobSlot+1, []eth.IndexedBlobHash{hashA})
+	require.ErrorIs(t, err, ErrBlobSidecarsNotFound)
+	_, err = cache.BeaconBlobSideCars(context.Background(), false, testBlobSlot, []eth.IndexedBlobHash{{Index: hashA.Index + 1, Hash: hashA.Hash}})
+	require.ErrorIs(t, err, ErrBlobSidecarsNotFound)
+
+	// least recently used sidecar is evicted
+	cache.Add(sidecarC)
+	require.False(t, cache.Has(hashB.Hash))
+	require.True(t, cache.Has(hashC.Hash))
+}
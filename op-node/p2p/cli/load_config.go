@@ -9,6 +9,7 @@ import (
 	"net"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	ds "github.com/ipfs/go-datastore"
@@ -67,6 +68,7 @@ func NewConfig(ctx *cli.Context, rollupCfg *rollup.Config) (*p2p.Config, error)
 	conf.EnableReqRespSync = ctx.Bool(flags.SyncReqRespName)
 	conf.EnablePingService = ctx.Bool(flags.P2PPingName)
 	conf.SyncOnlyReqToStatic = ctx.Bool(flags.SyncOnlyReqToStaticName)
+	conf.EnableBlobGossip = ctx.Bool(flags.GossipBlobsName)
 
 	return conf, nil
 }
@@ -99,12 +101,54 @@ func loadScoringParams(conf *p2p.Config, ctx *cli.Context, rollupCfg *rollup.Con
 		if err != nil {
 			return err
 		}
+		if params != nil {
+			params = loadScoringOverrides(ctx).Apply(params)
+		}
 		conf.ScoringParams = params
 	}
 
 	return nil
 }
 
+// loadScoringOverrides loads the scoring params explicitly set via the CLI context.
+func loadScoringOverrides(ctx *cli.Context) *p2p.ScoringOverrides {
+	float := func(name string) *float64 {
+		if !ctx.IsSet(name) {
+			return nil
+		}
+		v := ctx.Float64(name)
+		return &v
+	}
+	duration := func(name string) *time.Duration {
+		if !ctx.IsSet(name) {
+			return nil
+		}
+		v := ctx.Duration(name)
+		return &v
+	}
+	var colocationThreshold *int
+	if ctx.IsSet(flags.ScoringIPColocationThresholdName) {
+		v := ctx.Int(flags.ScoringIPColocationThresholdName)
+		colocationThreshold = &v
+	}
+	return &p2p.ScoringOverrides{
+		DecayInterval:                  duration(flags.ScoringDecayIntervalName),
+		DecayToZero:                    float(flags.ScoringDecayToZeroName),
+		RetainScore:                    duration(flags.ScoringRetainName),
+		TopicWeight:                    float(flags.ScoringTopicWeightName),
+		InvalidMessageDeliveriesWeight: float(flags.ScoringInvalidMessagesWeightName),
+		BehaviourPenaltyWeight:         float(flags.ScoringBehaviourPenaltyWeightName),
+		IPColocationFactorWeight:       float(flags.ScoringIPColocationWeightName),
+		IPColocationFactorThreshold:    colocationThreshold,
+		AppSpecificWeight:              float(flags.ScoringAppWeightName),
+		GossipThreshold:                float(flags.ScoringGossipThresholdName),
+		PublishThreshold:               float(flags.ScoringPublishThresholdName),
+		GraylistThreshold:              float(flags.ScoringGraylistThresholdName),
+		AcceptPXThreshold:              float(flags.ScoringAcceptPXThresholdName),
+		OpportunisticGraftThreshold:    float(flags.ScoringOpportunisticGraftThresholdName),
+	}
+}
+
 // loadBanningOptions loads whether or not to ban peers from the CLI context.
 func loadBanningOptions(conf *p2p.Config, ctx *cli.Context) error {
 	conf.BanningEnabled = ctx.Bool(flags.BanningName)
@@ -60,12 +60,16 @@ type SetupP2P interface {
 	BanDuration() time.Duration
 	GossipSetupConfigurables
 	ReqRespSyncEnabled() bool
+	// BlobGossipEnabled returns true if blob sidecars are shared and received over gossip.
+	BlobGossipEnabled() bool
 }
 
 // ScoringParams defines the various types of peer scoring parameters.
 type ScoringParams struct {
 	PeerScoring        pubsub.PeerScoreParams
 	ApplicationScoring ApplicationScoreParams
+	// Thresholds are the gossip score thresholds. The defaults from [NewPeerScoreThresholds] are used if nil.
+	Thresholds *pubsub.PeerScoreThresholds
 }
 
 // Config sets up a p2p host and discv5 service from configuration.
@@ -130,6 +134,8 @@ type Config struct {
 	EnableReqRespSync   bool
 	SyncOnlyReqToStatic bool
 
+	EnableBlobGossip bool
+
 	EnablePingService bool
 }
 
@@ -173,6 +179,10 @@ func (conf *Config) ReqRespSyncEnabled() bool {
 	return conf.EnableReqRespSync
 }
 
+func (conf *Config) BlobGossipEnabled() bool {
+	return conf.EnableBlobGossip
+}
+
 const maxMeshParam = 1000
 
 func (conf *Config) Check() error {
@@ -9,6 +9,8 @@ import (
 
 	peer "github.com/libp2p/go-libp2p/core/peer"
 
+	store "github.com/ethereum-optimism/optimism/op-node/p2p/store"
+
 	time "time"
 )
 
@@ -77,6 +79,58 @@ func (_c *ExpiryStore_GetIPBanExpiration_Call) RunAndReturn(run func(net.IP) (ti
 	return _c
 }
 
+// GetPeerBan provides a mock function with given fields: id
+func (_m *ExpiryStore) GetPeerBan(id peer.ID) (store.PeerBan, error) {
+	ret := _m.Called(id)
+
+	var r0 store.PeerBan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(peer.ID) (store.PeerBan, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(peer.ID) store.PeerBan); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(store.PeerBan)
+	}
+
+	if rf, ok := ret.Get(1).(func(peer.ID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExpiryStore_GetPeerBan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPeerBan'
+type ExpiryStore_GetPeerBan_Call struct {
+	*mock.Call
+}
+
+// GetPeerBan is a helper method to define mock.On call
+//   - id peer.ID
+func (_e *ExpiryStore_Expecter) GetPeerBan(id interface{}) *ExpiryStore_GetPeerBan_Call {
+	return &ExpiryStore_GetPeerBan_Call{Call: _e.mock.On("GetPeerBan", id)}
+}
+
+func (_c *ExpiryStore_GetPeerBan_Call) Run(run func(id peer.ID)) *ExpiryStore_GetPeerBan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(peer.ID))
+	})
+	return _c
+}
+
+func (_c *ExpiryStore_GetPeerBan_Call) Return(_a0 store.PeerBan, _a1 error) *ExpiryStore_GetPeerBan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExpiryStore_GetPeerBan_Call) RunAndReturn(run func(peer.ID) (store.PeerBan, error)) *ExpiryStore_GetPeerBan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetPeerBanExpiration provides a mock function with given fields: id
 func (_m *ExpiryStore) GetPeerBanExpiration(id peer.ID) (time.Time, error) {
 	ret := _m.Called(id)
@@ -172,6 +226,50 @@ func (_c *ExpiryStore_SetIPBanExpiration_Call) RunAndReturn(run func(net.IP, tim
 	return _c
 }
 
+// SetPeerBan provides a mock function with given fields: id, expiry, reason
+func (_m *ExpiryStore) SetPeerBan(id peer.ID, expiry time.Time, reason string) error {
+	ret := _m.Called(id, expiry, reason)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(peer.ID, time.Time, string) error); ok {
+		r0 = rf(id, expiry, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExpiryStore_SetPeerBan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPeerBan'
+type ExpiryStore_SetPeerBan_Call struct {
+	*mock.Call
+}
+
+// SetPeerBan is a helper method to define mock.On call
+//   - id peer.ID
+//   - expiry time.Time
+//   - reason string
+func (_e *ExpiryStore_Expecter) SetPeerBan(id interface{}, expiry interface{}, reason interface{}) *ExpiryStore_SetPeerBan_Call {
+	return &ExpiryStore_SetPeerBan_Call{Call: _e.mock.On("SetPeerBan", id, expiry, reason)}
+}
+
+func (_c *ExpiryStore_SetPeerBan_Call) Run(run func(id peer.ID, expiry time.Time, reason string)) *ExpiryStore_SetPeerBan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(peer.ID), args[1].(time.Time), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ExpiryStore_SetPeerBan_Call) Return(_a0 error) *ExpiryStore_SetPeerBan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExpiryStore_SetPeerBan_Call) RunAndReturn(run func(peer.ID, time.Time, string) error) *ExpiryStore_SetPeerBan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetPeerBanExpiration provides a mock function with given fields: id, expiry
 func (_m *ExpiryStore) SetPeerBanExpiration(id peer.ID, expiry time.Time) error {
 	ret := _m.Called(id, expiry)
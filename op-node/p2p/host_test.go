@@ -229,6 +229,25 @@ func TestP2PFull(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, uint(1), stats.Connected)
 
+	scoreB, err := p2pClientA.PeerScore(ctx, hostB.ID())
+	require.NoError(t, err)
+	require.Equal(t, hostB.ID(), scoreB.PeerID)
+	require.False(t, scoreB.Banned)
+	require.Zero(t, scoreB.BanExpiry)
+	require.Error(t, func() error { _, err := p2pClientA.PeerScore(ctx, ""); return err }())
+
+	pC, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	idC, err := peer.IDFromPublicKey(pC.GetPublic())
+	require.NoError(t, err)
+	banExpiry := time.Now().Add(time.Hour)
+	require.NoError(t, nodeA.store.SetPeerBan(idC, banExpiry, "score too low"))
+	scoreC, err := p2pClientA.PeerScore(ctx, idC)
+	require.NoError(t, err)
+	require.True(t, scoreC.Banned)
+	require.Equal(t, uint64(banExpiry.Unix()), scoreC.BanExpiry)
+	require.Equal(t, "score too low", scoreC.BanReason)
+
 	// disconnect
 	hostBId := hostB.ID().String()
 	peerDump, err = p2pClientA.Peers(ctx, false)
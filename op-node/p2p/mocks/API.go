@@ -459,6 +459,61 @@ func (_c *API_ListBlockedSubnets_Call) RunAndReturn(run func(context.Context) ([
 	return _c
 }
 
+// PeerScore provides a mock function with given fields: ctx, id
+func (_m *API) PeerScore(ctx context.Context, id peer.ID) (*p2p.PeerScoreInfo, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *p2p.PeerScoreInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, peer.ID) (*p2p.PeerScoreInfo, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, peer.ID) *p2p.PeerScoreInfo); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*p2p.PeerScoreInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, peer.ID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// API_PeerScore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PeerScore'
+type API_PeerScore_Call struct {
+	*mock.Call
+}
+
+// PeerScore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id peer.ID
+func (_e *API_Expecter) PeerScore(ctx interface{}, id interface{}) *API_PeerScore_Call {
+	return &API_PeerScore_Call{Call: _e.mock.On("PeerScore", ctx, id)}
+}
+
+func (_c *API_PeerScore_Call) Run(run func(ctx context.Context, id peer.ID)) *API_PeerScore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(peer.ID))
+	})
+	return _c
+}
+
+func (_c *API_PeerScore_Call) Return(_a0 *p2p.PeerScoreInfo, _a1 error) *API_PeerScore_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *API_PeerScore_Call) RunAndReturn(run func(context.Context, peer.ID) (*p2p.PeerScoreInfo, error)) *API_PeerScore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PeerStats provides a mock function with given fields: ctx
 func (_m *API) PeerStats(ctx context.Context) (*p2p.PeerStats, error) {
 	ret := _m.Called(ctx)
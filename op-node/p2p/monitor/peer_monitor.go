@@ -21,8 +21,8 @@ type PeerManager interface {
 	Peers() []peer.ID
 	GetPeerScore(id peer.ID) (float64, error)
 	IsStatic(peer.ID) bool
-	// BanPeer bans the peer until the specified time and disconnects any existing connections.
-	BanPeer(peer.ID, time.Time) error
+	// BanPeer bans the peer until the specified time for the given reason and disconnects any existing connections.
+	BanPeer(id peer.ID, expiration time.Time, reason string) error
 }
 
 // PeerMonitor runs a background process to periodically check for peers with scores below a minimum.
@@ -92,7 +92,9 @@ func (p *PeerMonitor) checkNextPeer() error {
 	if p.manager.IsStatic(id) {
 		return nil
 	}
-	if err := p.manager.BanPeer(id, p.clock.Now().Add(p.banDuration)); err != nil {
+	reason := fmt.Sprintf("score %v below ban threshold %v", score, p.minScore)
+	p.l.Info("Banning peer", "peer", id, "reason", reason, "duration", p.banDuration)
+	if err := p.manager.BanPeer(id, p.clock.Now().Add(p.banDuration), reason); err != nil {
 		return fmt.Errorf("banning peer %v: %w", id, err)
 	}
 
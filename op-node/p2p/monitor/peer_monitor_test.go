@@ -102,7 +102,7 @@ func TestCheckNextPeer(t *testing.T) {
 		manager.EXPECT().Peers().Return(peerIDs).Once()
 		manager.EXPECT().GetPeerScore(id).Return(-101, nil).Once()
 		manager.EXPECT().IsStatic(id).Return(false).Once()
-		manager.EXPECT().BanPeer(id, clock.Now().Add(testBanDuration)).Return(nil).Once()
+		manager.EXPECT().BanPeer(id, clock.Now().Add(testBanDuration), "score -101 below ban threshold -100").Return(nil).Once()
 
 		require.NoError(t, monitor.checkNextPeer())
 	})
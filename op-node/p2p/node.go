@@ -42,10 +42,11 @@ type NodeP2P struct {
 	appScorer   ApplicationScorer
 	log         log.Logger
 	// the below components are all optional, and may be nil. They require the host to not be nil.
-	dv5Local *enode.LocalNode // p2p discovery identity
-	dv5Udp   *discover.UDPv5  // p2p discovery service
-	gs       *pubsub.PubSub   // p2p gossip router
-	gsOut    GossipOut        // p2p gossip application interface for publishing
+	dv5Local *enode.LocalNode   // p2p discovery identity
+	dv5Udp   *discover.UDPv5    // p2p discovery service
+	gs       *pubsub.PubSub     // p2p gossip router
+	gsOut    GossipOut          // p2p gossip application interface for publishing
+	blobsGs  *blobSidecarsTopic // p2p blob sidecars gossip, nil if disabled
 	syncCl   *SyncClient
 	syncSrv  *ReqRespServer
 }
@@ -165,6 +166,16 @@ func (n *NodeP2P) init(
 	if err != nil {
 		return fmt.Errorf("failed to join blocks gossip topic: %w", err)
 	}
+	if setup.BlobGossipEnabled() {
+		blobsIn, ok := gossipIn.(BlobGossipIn)
+		if !ok {
+			return fmt.Errorf("blob sidecars gossip is enabled, but gossip input %T does not handle blob sidecars", gossipIn)
+		}
+		n.blobsGs, err = joinBlobSidecarsGossip(n.host.ID(), n.gs, log, rollupCfg, blobsIn)
+		if err != nil {
+			return fmt.Errorf("failed to join blob sidecars gossip topic: %w", err)
+		}
+	}
 	log.Info("started p2p host", "addrs", n.host.Addrs(), "peerID", n.host.ID().String())
 
 	tcpPort, err := FindActiveTCPPort(n.host)
@@ -222,6 +233,26 @@ func (n *NodeP2P) GossipOut() GossipOut {
 	return n.gsOut
 }
 
+// BlobGossipEnabled returns true if the node participates in blob sidecars gossip.
+func (n *NodeP2P) BlobGossipEnabled() bool {
+	return n.blobsGs != nil
+}
+
+// PublishBlobSidecars shares the given blob sidecars on the blob sidecars gossip topic.
+// The blob sidecars must be verified, including their inclusion proof: peers penalize invalid blob sidecars.
+func (n *NodeP2P) PublishBlobSidecars(ctx context.Context, sidecars []*eth.APIBlobSidecar) error {
+	if n.blobsGs == nil {
+		return errors.New("blob sidecars gossip is not enabled")
+	}
+	var result error
+	for _, sidecar := range sidecars {
+		if err := n.blobsGs.Publish(ctx, sidecar); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to publish blob sidecar %s: %w", sidecar.KZGCommitment, err))
+		}
+	}
+	return result
+}
+
 func (n *NodeP2P) ConnectionGater() gating.BlockingConnectionGater {
 	return n.gater
 }
@@ -242,8 +273,8 @@ func (n *NodeP2P) IsStatic(id peer.ID) bool {
 	return n.connMgr != nil && n.connMgr.IsProtected(id, staticPeerTag)
 }
 
-func (n *NodeP2P) BanPeer(id peer.ID, expiration time.Time) error {
-	if err := n.store.SetPeerBanExpiration(id, expiration); err != nil {
+func (n *NodeP2P) BanPeer(id peer.ID, expiration time.Time, reason string) error {
+	if err := n.store.SetPeerBan(id, expiration, reason); err != nil {
 		return fmt.Errorf("failed to set peer ban expiry: %w", err)
 	}
 	if err := n.host.Network().ClosePeer(id); err != nil {
@@ -280,6 +311,11 @@ func (n *NodeP2P) Close() error {
 	if n.dv5Udp != nil {
 		n.dv5Udp.Close()
 	}
+	if n.blobsGs != nil {
+		if err := n.blobsGs.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close blob sidecars gossip cleanly: %w", err))
+		}
+	}
 	if n.gsOut != nil {
 		if err := n.gsOut.Close(); err != nil {
 			result = multierror.Append(result, fmt.Errorf("failed to close gossip cleanly: %w", err))
@@ -64,6 +64,19 @@ func LightPeerScoreParams(cfg *rollup.Config) pubsub.PeerScoreParams {
 				InvalidMessageDeliveriesWeight:  -140.4475,
 				InvalidMessageDeliveriesDecay:   ScoreDecay(invalidDecayPeriod, slot),
 			},
+			// Blob sidecars are shared opportunistically by the peers that fetched them,
+			// so peers are not penalized for few deliveries, only for invalid blob sidecars.
+			blobSidecarsTopicV1(cfg): {
+				TopicWeight:                    0.2,
+				TimeInMeshWeight:               MaxInMeshScore / inMeshCap(slot),
+				TimeInMeshQuantum:              slot,
+				TimeInMeshCap:                  inMeshCap(slot),
+				FirstMessageDeliveriesWeight:   1,
+				FirstMessageDeliveriesDecay:    ScoreDecay(20*epoch, slot),
+				FirstMessageDeliveriesCap:      23,
+				InvalidMessageDeliveriesWeight: -140.4475,
+				InvalidMessageDeliveriesDecay:  ScoreDecay(invalidDecayPeriod, slot),
+			},
 		},
 		TopicScoreCap: 34,
 		AppSpecificScore: func(p peer.ID) float64 {
@@ -90,9 +103,11 @@ func inMeshCap(slot time.Duration) float64 {
 func GetScoringParams(name string, cfg *rollup.Config) (*ScoringParams, error) {
 	switch name {
 	case "light":
+		thresholds := NewPeerScoreThresholds()
 		return &ScoringParams{
 			PeerScoring:        LightPeerScoreParams(cfg),
 			ApplicationScoring: LightApplicationScoreParams(cfg),
+			Thresholds:         &thresholds,
 		}, nil
 	case "none":
 		return nil, nil
@@ -101,6 +116,84 @@ func GetScoringParams(name string, cfg *rollup.Config) (*ScoringParams, error) {
 	}
 }
 
+// ScoringOverrides replaces individual parameters of a scoring level, allowing operators to tune peer scoring.
+// Nil fields leave the parameter of the scoring level unchanged.
+type ScoringOverrides struct {
+	// DecayInterval is the interval at which scores are decayed.
+	DecayInterval *time.Duration
+	// DecayToZero is the value below which decayed scores are reset to zero.
+	DecayToZero *float64
+	// RetainScore is how long the score of a disconnected peer is retained, in gossip and in the peerstore.
+	RetainScore *time.Duration
+
+	// TopicWeight is the weight of the score of each gossip topic.
+	TopicWeight *float64
+	// InvalidMessageDeliveriesWeight is the weight of invalid messages delivered on each topic. Should be negative.
+	InvalidMessageDeliveriesWeight *float64
+	// BehaviourPenaltyWeight is the weight of the gossip protocol misbehaviour penalty. Should be negative.
+	BehaviourPenaltyWeight *float64
+	// IPColocationFactorWeight is the weight of the penalty for too many peers sharing an IP. Should be negative.
+	IPColocationFactorWeight *float64
+	// IPColocationFactorThreshold is the number of peers that can share an IP before they are penalized.
+	IPColocationFactorThreshold *int
+	// AppSpecificWeight is the weight of the application (req-resp) score.
+	AppSpecificWeight *float64
+
+	GossipThreshold             *float64
+	PublishThreshold            *float64
+	GraylistThreshold           *float64
+	AcceptPXThreshold           *float64
+	OpportunisticGraftThreshold *float64
+}
+
+// Apply returns a copy of the scoring params with the overrides applied.
+func (o *ScoringOverrides) Apply(params *ScoringParams) *ScoringParams {
+	result := *params
+	peerScoring := &result.PeerScoring
+	setDuration(&peerScoring.DecayInterval, o.DecayInterval)
+	setFloat(&peerScoring.DecayToZero, o.DecayToZero)
+	setDuration(&peerScoring.RetainScore, o.RetainScore)
+	setFloat(&peerScoring.BehaviourPenaltyWeight, o.BehaviourPenaltyWeight)
+	setFloat(&peerScoring.IPColocationFactorWeight, o.IPColocationFactorWeight)
+	if o.IPColocationFactorThreshold != nil {
+		peerScoring.IPColocationFactorThreshold = *o.IPColocationFactorThreshold
+	}
+	setFloat(&peerScoring.AppSpecificWeight, o.AppSpecificWeight)
+
+	topics := make(map[string]*pubsub.TopicScoreParams, len(peerScoring.Topics))
+	for name, params := range peerScoring.Topics {
+		topic := *params
+		setFloat(&topic.TopicWeight, o.TopicWeight)
+		setFloat(&topic.InvalidMessageDeliveriesWeight, o.InvalidMessageDeliveriesWeight)
+		topics[name] = &topic
+	}
+	peerScoring.Topics = topics
+
+	thresholds := NewPeerScoreThresholds()
+	if params.Thresholds != nil {
+		thresholds = *params.Thresholds
+	}
+	setFloat(&thresholds.GossipThreshold, o.GossipThreshold)
+	setFloat(&thresholds.PublishThreshold, o.PublishThreshold)
+	setFloat(&thresholds.GraylistThreshold, o.GraylistThreshold)
+	setFloat(&thresholds.AcceptPXThreshold, o.AcceptPXThreshold)
+	setFloat(&thresholds.OpportunisticGraftThreshold, o.OpportunisticGraftThreshold)
+	result.Thresholds = &thresholds
+	return &result
+}
+
+func setFloat(dest *float64, value *float64) {
+	if value != nil {
+		*dest = *value
+	}
+}
+
+func setDuration(dest *time.Duration, value *time.Duration) {
+	if value != nil {
+		*dest = *value
+	}
+}
+
 // NewPeerScoreThresholds returns a default [pubsub.PeerScoreThresholds].
 // See [PeerScoreThresholds] for detailed documentation.
 //
@@ -65,11 +65,15 @@ func (testSuite *PeerParamsTestSuite) TestGetPeerScoreParams_Light() {
 	scoringParams, err := GetScoringParams("light", cfg)
 	peerParams := scoringParams.PeerScoring
 	testSuite.NoError(err)
-	// Topics should contain options for block topic
-	testSuite.Len(peerParams.Topics, 1)
+	// Topics should contain options for block topic and blob sidecars topic
+	testSuite.Len(peerParams.Topics, 2)
 	topicParams, ok := peerParams.Topics[blocksTopicV1(cfg)]
 	testSuite.True(ok, "should have block topic params")
 	testSuite.NotZero(topicParams.TimeInMeshQuantum)
+	blobParams, ok := peerParams.Topics[blobSidecarsTopicV1(cfg)]
+	testSuite.True(ok, "should have blob sidecars topic params")
+	testSuite.Negative(blobParams.InvalidMessageDeliveriesWeight)
+	testSuite.Zero(blobParams.MeshMessageDeliveriesWeight)
 	testSuite.Equal(peerParams.TopicScoreCap, float64(34))
 	testSuite.Equal(peerParams.AppSpecificWeight, float64(1))
 	testSuite.Equal(peerParams.IPColocationFactorWeight, float64(-35))
@@ -106,3 +110,44 @@ func (testSuite *PeerParamsTestSuite) TestParamsZeroBlockTime() {
 	testSuite.Equal(params.PeerScoring.DecayInterval, slot)
 	testSuite.Equal(params.ApplicationScoring.DecayInterval, slot)
 }
+
+// TestScoringOverrides validates overrides replace only the specified scoring params.
+func (testSuite *PeerParamsTestSuite) TestScoringOverrides() {
+	cfg := chaincfg.Sepolia
+	params, err := GetScoringParams("light", cfg)
+	testSuite.NoError(err)
+	original := params.PeerScoring.Topics[blocksTopicV1(cfg)].TopicWeight
+
+	decayInterval := 5 * time.Second
+	retain := 24 * time.Hour
+	topicWeight := 0.5
+	behaviourPenaltyWeight := -20.0
+	colocationThreshold := 3
+	graylistThreshold := -80.0
+	overrides := &ScoringOverrides{
+		DecayInterval:               &decayInterval,
+		RetainScore:                 &retain,
+		TopicWeight:                 &topicWeight,
+		BehaviourPenaltyWeight:      &behaviourPenaltyWeight,
+		IPColocationFactorThreshold: &colocationThreshold,
+		GraylistThreshold:           &graylistThreshold,
+	}
+	result := overrides.Apply(params)
+
+	testSuite.Equal(decayInterval, result.PeerScoring.DecayInterval)
+	testSuite.Equal(retain, result.PeerScoring.RetainScore)
+	testSuite.Equal(behaviourPenaltyWeight, result.PeerScoring.BehaviourPenaltyWeight)
+	testSuite.Equal(colocationThreshold, result.PeerScoring.IPColocationFactorThreshold)
+	testSuite.Equal(topicWeight, result.PeerScoring.Topics[blocksTopicV1(cfg)].TopicWeight)
+	testSuite.Equal(graylistThreshold, result.Thresholds.GraylistThreshold)
+
+	// Params that aren't overridden are unchanged
+	testSuite.Equal(params.PeerScoring.DecayToZero, result.PeerScoring.DecayToZero)
+	testSuite.Equal(params.PeerScoring.IPColocationFactorWeight, result.PeerScoring.IPColocationFactorWeight)
+	testSuite.Equal(params.Thresholds.GossipThreshold, result.Thresholds.GossipThreshold)
+	testSuite.Equal(params.ApplicationScoring, result.ApplicationScoring)
+
+	// The original params are not modified
+	testSuite.Equal(original, params.PeerScoring.Topics[blocksTopicV1(cfg)].TopicWeight)
+	testSuite.Equal(NewPeerScoreThresholds(), *params.Thresholds)
+}
@@ -13,6 +13,9 @@ func ConfigurePeerScoring(gossipConf GossipSetupConfigurables, scorer Scorer, lo
 	opts := []pubsub.Option{}
 	if scoreParams != nil {
 		peerScoreThresholds := NewPeerScoreThresholds()
+		if scoreParams.Thresholds != nil {
+			peerScoreThresholds = *scoreParams.Thresholds
+		}
 		// Create copy of params before modifying the AppSpecificScore
 		params := scoreParams.PeerScoring
 		params.AppSpecificScore = scorer.ApplicationScore
@@ -25,6 +25,7 @@ type Prepared struct {
 	UDPv5     *discover.UDPv5
 
 	EnableReqRespSync bool
+	EnableBlobGossip  bool
 }
 
 var _ SetupP2P = (*Prepared)(nil)
@@ -92,3 +93,7 @@ func (p *Prepared) Disabled() bool {
 func (p *Prepared) ReqRespSyncEnabled() bool {
 	return p.EnableReqRespSync
 }
+
+func (p *Prepared) BlobGossipEnabled() bool {
+	return p.EnableBlobGossip
+}
@@ -41,11 +41,21 @@ type PeerDump struct {
 	BannedSubnets  []*net.IPNet         `json:"bannedSubnets"`
 }
 
+type PeerScoreInfo struct {
+	PeerID peer.ID          `json:"peerID"`
+	Scores store.PeerScores `json:"scores"`
+	// Banned is true if the peer is currently banned because of its score.
+	Banned    bool   `json:"banned"`
+	BanExpiry uint64 `json:"banExpiry,omitempty"` // unix timestamp in seconds, zero if the peer has not been banned
+	BanReason string `json:"banReason,omitempty"` // why the peer was last banned, may be empty if unknown
+}
+
 //go:generate mockery --name API --output mocks/ --with-expecter=true
 type API interface {
 	Self(ctx context.Context) (*PeerInfo, error)
 	Peers(ctx context.Context, connected bool) (*PeerDump, error)
 	PeerStats(ctx context.Context) (*PeerStats, error)
+	PeerScore(ctx context.Context, id peer.ID) (*PeerScoreInfo, error)
 	DiscoveryTable(ctx context.Context) ([]*enode.Node, error)
 	BlockPeer(ctx context.Context, p peer.ID) error
 	UnblockPeer(ctx context.Context, p peer.ID) error
@@ -44,6 +44,12 @@ func (c *Client) PeerStats(ctx context.Context) (*PeerStats, error) {
 	return out, err
 }
 
+func (c *Client) PeerScore(ctx context.Context, id peer.ID) (*PeerScoreInfo, error) {
+	var out *PeerScoreInfo
+	err := c.c.CallContext(ctx, &out, prefixRPC("peerScore"), id)
+	return out, err
+}
+
 func (c *Client) DiscoveryTable(ctx context.Context) ([]*enode.Node, error) {
 	var out []*enode.Node
 	err := c.c.CallContext(ctx, &out, prefixRPC("discoveryTable"))
@@ -37,6 +37,7 @@ var (
 	ErrNoConnectionManager = errors.New("no connection manager")
 	ErrNoConnectionGater   = errors.New("no connection gater")
 	ErrInvalidRequest      = errors.New("invalid request")
+	ErrNoPeerScores        = errors.New("peer scores not available")
 )
 
 type Node interface {
@@ -235,6 +236,36 @@ func (s *APIBackend) PeerStats(_ context.Context) (*PeerStats, error) {
 	return stats, nil
 }
 
+// PeerScore returns the latest recorded scores of a peer, along with whether and why it is banned.
+// Scores are retained for disconnected peers, so this can be used to inspect why a peer was pruned.
+func (s *APIBackend) PeerScore(_ context.Context, id peer.ID) (*PeerScoreInfo, error) {
+	recordDur := s.m.RecordRPCServerRequest("opp2p_peerScore")
+	if err := id.Validate(); err != nil {
+		s.log.Warn("invalid peer ID", "method", "PeerScore", "peer", id, "err", err)
+		return nil, ErrInvalidRequest
+	}
+	defer recordDur()
+	eps, ok := s.node.Host().Peerstore().(store.ExtendedPeerstore)
+	if !ok {
+		return nil, ErrNoPeerScores
+	}
+	scores, err := eps.GetPeerScores(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get peer scores: %w", err)
+	}
+	info := &PeerScoreInfo{PeerID: id, Scores: scores}
+	ban, err := eps.GetPeerBan(id)
+	if errors.Is(err, store.ErrUnknownBan) {
+		return info, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get peer ban: %w", err)
+	}
+	info.Banned = ban.Expiry.After(time.Now())
+	info.BanExpiry = uint64(ban.Expiry.Unix())
+	info.BanReason = ban.Reason
+	return info, nil
+}
+
 func (s *APIBackend) DiscoveryTable(_ context.Context) ([]*enode.Node, error) {
 	recordDur := s.m.RecordRPCServerRequest("opp2p_discoveryTable")
 	defer recordDur()
@@ -104,12 +104,23 @@ type ScoreDiff interface {
 
 var ErrUnknownBan = errors.New("unknown ban")
 
+// PeerBan records when a peer ban expires and why the peer was banned.
+type PeerBan struct {
+	Expiry time.Time
+	Reason string
+}
+
 type PeerBanStore interface {
 	// SetPeerBanExpiration create the peer ban with expiration time.
 	// If expiry == time.Time{} then the ban is deleted.
 	SetPeerBanExpiration(id peer.ID, expiry time.Time) error
 	// GetPeerBanExpiration gets the peer ban expiration time, or ErrUnknownBan error if none exists.
 	GetPeerBanExpiration(id peer.ID) (time.Time, error)
+	// SetPeerBan creates the peer ban with expiration time, recording the reason for the ban.
+	// If expiry == time.Time{} then the ban is deleted.
+	SetPeerBan(id peer.ID, expiry time.Time, reason string) error
+	// GetPeerBan gets the peer ban, or ErrUnknownBan error if none exists.
+	GetPeerBan(id peer.ID) (PeerBan, error)
 }
 
 type IPBanStore interface {
@@ -20,8 +20,9 @@ const (
 var peerBanExpirationsBase = ds.NewKey("/peers/ban_expiration")
 
 type peerBanRecord struct {
-	Expiry     int64 `json:"expiry"`     // unix timestamp in seconds
-	LastUpdate int64 `json:"lastUpdate"` // unix timestamp in seconds
+	Expiry     int64  `json:"expiry"`           // unix timestamp in seconds
+	LastUpdate int64  `json:"lastUpdate"`       // unix timestamp in seconds
+	Reason     string `json:"reason,omitempty"` // why the peer was banned, empty if unknown
 }
 
 func (s *peerBanRecord) SetLastUpdated(t time.Time) {
@@ -40,10 +41,11 @@ func (s *peerBanRecord) UnmarshalBinary(data []byte) error {
 	return json.Unmarshal(data, s)
 }
 
-type peerBanUpdate time.Time
+type peerBanUpdate PeerBan
 
 func (p peerBanUpdate) Apply(rec *peerBanRecord) {
-	rec.Expiry = time.Time(p).Unix()
+	rec.Expiry = p.Expiry.Unix()
+	rec.Reason = p.Reason
 }
 
 type peerBanBook struct {
@@ -64,25 +66,37 @@ func (d *peerBanBook) startGC() {
 }
 
 func (d *peerBanBook) GetPeerBanExpiration(id peer.ID) (time.Time, error) {
+	ban, err := d.GetPeerBan(id)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ban.Expiry, nil
+}
+
+func (d *peerBanBook) SetPeerBanExpiration(id peer.ID, expirationTime time.Time) error {
+	return d.SetPeerBan(id, expirationTime, "")
+}
+
+func (d *peerBanBook) GetPeerBan(id peer.ID) (PeerBan, error) {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 	rec, err := d.book.getRecord(id)
 	if err == errUnknownRecord {
-		return time.Time{}, ErrUnknownBan
+		return PeerBan{}, ErrUnknownBan
 	}
 	if err != nil {
-		return time.Time{}, err
+		return PeerBan{}, err
 	}
-	return time.Unix(rec.Expiry, 0), nil
+	return PeerBan{Expiry: time.Unix(rec.Expiry, 0), Reason: rec.Reason}, nil
 }
 
-func (d *peerBanBook) SetPeerBanExpiration(id peer.ID, expirationTime time.Time) error {
+func (d *peerBanBook) SetPeerBan(id peer.ID, expirationTime time.Time, reason string) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	if expirationTime == (time.Time{}) {
 		return d.book.deleteRecord(id)
 	}
-	_, err := d.book.setRecord(id, peerBanUpdate(expirationTime))
+	_, err := d.book.setRecord(id, peerBanUpdate{Expiry: expirationTime, Reason: reason})
 	return err
 }
 
@@ -31,6 +31,31 @@ func TestRoundTripPeerBan(t *testing.T) {
 	require.Equal(t, result, expiry)
 }
 
+func TestRoundTripPeerBanWithReason(t *testing.T) {
+	book := createMemoryPeerBanBook(t)
+	defer book.Close()
+	expiry := time.Unix(2484924, 0)
+	require.NoError(t, book.SetPeerBan("a", expiry, "score too low"))
+	result, err := book.GetPeerBan("a")
+	require.NoError(t, err)
+	require.Equal(t, PeerBan{Expiry: expiry, Reason: "score too low"}, result)
+
+	// Updating the ban by expiry alone clears the reason
+	require.NoError(t, book.SetPeerBanExpiration("a", expiry))
+	result, err = book.GetPeerBan("a")
+	require.NoError(t, err)
+	require.Equal(t, PeerBan{Expiry: expiry}, result)
+}
+
+func TestDeletePeerBan(t *testing.T) {
+	book := createMemoryPeerBanBook(t)
+	defer book.Close()
+	require.NoError(t, book.SetPeerBan("a", time.Unix(2484924, 0), "score too low"))
+	require.NoError(t, book.SetPeerBan("a", time.Time{}, ""))
+	_, err := book.GetPeerBan("a")
+	require.Same(t, ErrUnknownBan, err)
+}
+
 func createMemoryPeerBanBook(t *testing.T) *peerBanBook {
 	store := sync.MutexWrap(ds.NewMapDatastore())
 	logger := testlog.Logger(t, log.LevelInfo)
@@ -42,6 +42,7 @@ type AttributesQueue struct {
 	prev         *BatchQueue
 	batch        *SingularBatch
 	isLastInSpan bool
+	tracker      *stageTracker
 }
 
 func NewAttributesQueue(log log.Logger, cfg *rollup.Config, builder AttributesBuilder, prev *BatchQueue) *AttributesQueue {
@@ -57,7 +58,8 @@ func (aq *AttributesQueue) Origin() eth.L1BlockRef {
 	return aq.prev.Origin()
 }
 
-func (aq *AttributesQueue) NextAttributes(ctx context.Context, parent eth.L2BlockRef) (*AttributesWithParent, error) {
+func (aq *AttributesQueue) NextAttributes(ctx context.Context, parent eth.L2BlockRef) (out *AttributesWithParent, err error) {
+	defer aq.tracker.track(stageAttributesQueue)(&err)
 	// Get a batch if we need it
 	if aq.batch == nil {
 		batch, isLastInSpan, err := aq.prev.NextBatch(ctx, parent)
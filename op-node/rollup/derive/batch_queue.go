@@ -60,6 +60,8 @@ type BatchQueue struct {
 	nextSpan []*SingularBatch
 
 	l2 SafeBlockFetcher
+
+	tracker *stageTracker
 }
 
 // NewBatchQueue creates a BatchQueue, which should be Reset(origin) before use.
@@ -90,9 +92,14 @@ func (bq *BatchQueue) popNextBatch(parent eth.L2BlockRef) *SingularBatch {
 	return nextBatch
 }
 
+func (bq *BatchQueue) queueDepth() int {
+	return len(bq.batches)
+}
+
 // NextBatch return next valid batch upon the given safe head.
 // It also returns the boolean that indicates if the batch is the last block in the batch.
-func (bq *BatchQueue) NextBatch(ctx context.Context, parent eth.L2BlockRef) (*SingularBatch, bool, error) {
+func (bq *BatchQueue) NextBatch(ctx context.Context, parent eth.L2BlockRef) (out *SingularBatch, isLastInSpan bool, err error) {
+	defer bq.tracker.track(stageBatchQueue)(&err)
 	if len(bq.nextSpan) > 0 {
 		// There are cached singular batches derived from the span batch.
 		// Check if the next cached batch matches the given parent block.
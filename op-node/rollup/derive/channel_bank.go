@@ -39,6 +39,8 @@ type ChannelBank struct {
 
 	prev    NextFrameProvider
 	fetcher L1Fetcher
+
+	tracker *stageTracker
 }
 
 var _ ResettableStage = (*ChannelBank)(nil)
@@ -177,7 +179,8 @@ func (cb *ChannelBank) tryReadChannelAtIndex(i int) (data []byte, err error) {
 // loading data in (unlike most other stages). This is to ensure maintain
 // consistency around channel bank pruning which depends upon the order
 // of operations.
-func (cb *ChannelBank) NextData(ctx context.Context) ([]byte, error) {
+func (cb *ChannelBank) NextData(ctx context.Context) (out []byte, err error) {
+	defer cb.tracker.track(stageChannelBank)(&err)
 	// Do the read from the channel bank first
 	data, err := cb.Read()
 	if err == io.EOF {
@@ -199,6 +202,10 @@ func (cb *ChannelBank) NextData(ctx context.Context) ([]byte, error) {
 	}
 }
 
+func (cb *ChannelBank) queueDepth() int {
+	return len(cb.channelQueue)
+}
+
 func (cb *ChannelBank) Reset(ctx context.Context, base eth.L1BlockRef, _ eth.SystemConfig) error {
 	cb.channels = make(map[ChannelID]*Channel)
 	cb.channelQueue = make([]ChannelID, 0, 10)
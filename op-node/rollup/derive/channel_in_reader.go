@@ -23,6 +23,7 @@ type ChannelInReader struct {
 	nextBatchFn func() (*BatchData, error)
 	prev        *ChannelBank
 	metrics     Metrics
+	tracker     *stageTracker
 }
 
 var _ ResettableStage = (*ChannelInReader)(nil)
@@ -63,7 +64,8 @@ func (cr *ChannelInReader) NextChannel() {
 // NextBatch pulls out the next batch from the channel if it has it.
 // It returns io.EOF when it cannot make any more progress.
 // It will return a temporary error if it needs to be called again to advance some internal state.
-func (cr *ChannelInReader) NextBatch(ctx context.Context) (Batch, error) {
+func (cr *ChannelInReader) NextBatch(ctx context.Context) (out Batch, err error) {
+	defer cr.tracker.track(stageChannelInReader)(&err)
 	if cr.nextBatchFn == nil {
 		if data, err := cr.prev.NextData(ctx); err == io.EOF {
 			return nil, io.EOF
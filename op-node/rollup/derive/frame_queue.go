@@ -20,6 +20,8 @@ type FrameQueue struct {
 	log    log.Logger
 	frames []Frame
 	prev   NextDataProvider
+
+	tracker *stageTracker
 }
 
 func NewFrameQueue(log log.Logger, prev NextDataProvider) *FrameQueue {
@@ -33,7 +35,8 @@ func (fq *FrameQueue) Origin() eth.L1BlockRef {
 	return fq.prev.Origin()
 }
 
-func (fq *FrameQueue) NextFrame(ctx context.Context) (Frame, error) {
+func (fq *FrameQueue) NextFrame(ctx context.Context) (out Frame, err error) {
+	defer fq.tracker.track(stageFrameQueue)(&err)
 	// Find more frames if we need to
 	if len(fq.frames) == 0 {
 		if data, err := fq.prev.NextData(ctx); err != nil {
@@ -56,6 +59,10 @@ func (fq *FrameQueue) NextFrame(ctx context.Context) (Frame, error) {
 	return ret, nil
 }
 
+func (fq *FrameQueue) queueDepth() int {
+	return len(fq.frames)
+}
+
 func (fq *FrameQueue) Reset(_ context.Context, _ eth.L1BlockRef, _ eth.SystemConfig) error {
 	fq.frames = fq.frames[:0]
 	return io.EOF
@@ -27,6 +27,8 @@ type L1Retrieval struct {
 	prev    NextBlockProvider
 
 	datas DataIter
+
+	tracker *stageTracker
 }
 
 var _ ResettableStage = (*L1Retrieval)(nil)
@@ -46,7 +48,8 @@ func (l1r *L1Retrieval) Origin() eth.L1BlockRef {
 // NextData does an action in the L1 Retrieval stage
 // If there is data, it pushes it to the next stage.
 // If there is no more data open ourselves if we are closed or close ourselves if we are open
-func (l1r *L1Retrieval) NextData(ctx context.Context) ([]byte, error) {
+func (l1r *L1Retrieval) NextData(ctx context.Context) (out []byte, err error) {
+	defer l1r.tracker.track(stageL1Retrieval)(&err)
 	if l1r.datas == nil {
 		next, err := l1r.prev.NextL1Block(ctx)
 		if err == io.EOF {
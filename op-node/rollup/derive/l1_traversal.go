@@ -29,6 +29,7 @@ type L1Traversal struct {
 	log      log.Logger
 	sysCfg   eth.SystemConfig
 	cfg      *rollup.Config
+	tracker  *stageTracker
 }
 
 var _ ResettableStage = (*L1Traversal)(nil)
@@ -57,7 +58,8 @@ func (l1t *L1Traversal) NextL1Block(_ context.Context) (eth.L1BlockRef, error) {
 }
 
 // AdvanceL1Block advances the internal state of L1 Traversal
-func (l1t *L1Traversal) AdvanceL1Block(ctx context.Context) error {
+func (l1t *L1Traversal) AdvanceL1Block(ctx context.Context) (err error) {
+	defer l1t.tracker.track(stageL1Traversal)(&err)
 	origin := l1t.block
 	nextL1Origin, err := l1t.l1Blocks.L1BlockRefByNumber(ctx, origin.Number+1)
 	if errors.Is(err, ethereum.NotFound) {
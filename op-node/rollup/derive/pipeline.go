@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -23,6 +24,10 @@ type Metrics interface {
 	RecordDerivedBatches(batchType string)
 	SetDerivationIdle(idle bool)
 	RecordPipelineReset()
+	RecordDerivationStageStep(stage string, duration time.Duration, output bool)
+	RecordDerivationStageQueueDepth(stage string, depth int)
+	RecordDerivationStageReset(stage string)
+	RecordELSyncPhase(phase string)
 }
 
 type L1Fetcher interface {
@@ -60,6 +65,8 @@ type DerivationPipeline struct {
 	// >= len(stages) if no additional resetting is required
 	resetting int
 	stages    []ResettableStage
+	// names of the stages, for metrics
+	stageNames []string
 
 	// Special stages to keep track of
 	traversal *L1Traversal
@@ -90,22 +97,34 @@ func NewDerivationPipeline(log log.Logger, rollupCfg *rollup.Config, l1Fetcher L
 	attrBuilder := NewFetchingAttributesBuilder(rollupCfg, l1Fetcher, l2Source)
 	attributesQueue := NewAttributesQueue(log, rollupCfg, attrBuilder, batchQueue)
 
+	// The stages share a tracker, to attribute the time of each step to the stage that spent it.
+	tracker := newStageTracker(metrics)
+	l1Traversal.tracker = tracker
+	l1Src.tracker = tracker
+	frameQueue.tracker = tracker
+	bank.tracker = tracker
+	chInReader.tracker = tracker
+	batchQueue.tracker = tracker
+	attributesQueue.tracker = tracker
+
 	// Reset from ResetEngine then up from L1 Traversal. The stages do not talk to each other during
 	// the ResetEngine, but after the ResetEngine, this is the order in which the stages could talk to each other.
 	// Note: The ResetEngine is the only reset that can fail.
 	stages := []ResettableStage{l1Traversal, l1Src, altDA, frameQueue, bank, chInReader, batchQueue, attributesQueue}
+	stageNames := []string{stageL1Traversal, stageL1Retrieval, stageAltDA, stageFrameQueue, stageChannelBank, stageChannelInReader, stageBatchQueue, stageAttributesQueue}
 
 	return &DerivationPipeline{
-		log:       log,
-		rollupCfg: rollupCfg,
-		l1Fetcher: l1Fetcher,
-		altDA:     altDA,
-		resetting: 0,
-		stages:    stages,
-		metrics:   metrics,
-		traversal: l1Traversal,
-		attrib:    attributesQueue,
-		l2:        l2Source,
+		log:        log,
+		rollupCfg:  rollupCfg,
+		l1Fetcher:  l1Fetcher,
+		altDA:      altDA,
+		resetting:  0,
+		stages:     stages,
+		stageNames: stageNames,
+		metrics:    metrics,
+		traversal:  l1Traversal,
+		attrib:     attributesQueue,
+		l2:         l2Source,
 	}
 }
 
@@ -136,6 +155,7 @@ func (dp *DerivationPipeline) Origin() eth.L1BlockRef {
 // When Step returns nil, it should be called again, to continue the derivation process.
 func (dp *DerivationPipeline) Step(ctx context.Context, pendingSafeHead eth.L2BlockRef) (outAttrib *AttributesWithParent, outErr error) {
 	defer dp.metrics.RecordL1Ref("l1_derived", dp.Origin())
+	defer dp.recordQueueDepths()
 
 	dp.metrics.SetDerivationIdle(false)
 	defer func() {
@@ -161,6 +181,7 @@ func (dp *DerivationPipeline) Step(ctx context.Context, pendingSafeHead eth.L2Bl
 
 		if err := dp.stages[dp.resetting].Reset(ctx, dp.origin, dp.resetSysConfig); err == io.EOF {
 			dp.log.Debug("reset of stage completed", "stage", dp.resetting, "origin", dp.origin)
+			dp.metrics.RecordDerivationStageReset(dp.stageNames[dp.resetting])
 			dp.resetting += 1
 			return nil, nil
 		} else if err != nil {
@@ -192,6 +213,15 @@ func (dp *DerivationPipeline) Step(ctx context.Context, pendingSafeHead eth.L2Bl
 	}
 }
 
+// recordQueueDepths records the amount of data buffered by each of the stages
+func (dp *DerivationPipeline) recordQueueDepths() {
+	for i, stage := range dp.stages {
+		if s, ok := stage.(queueDepthStage); ok {
+			dp.metrics.RecordDerivationStageQueueDepth(dp.stageNames[i], s.queueDepth())
+		}
+	}
+}
+
 // initialReset does the initial reset work of finding the L1 point to rewind back to
 func (dp *DerivationPipeline) initialReset(ctx context.Context, resetL2Safe eth.L2BlockRef) error {
 	dp.log.Info("Rewinding derivation-pipeline L1 traversal to handle reset")
@@ -0,0 +1,56 @@
+package derive
+
+import (
+	"time"
+)
+
+// Names of the derivation stages, as used in the stage metrics.
+const (
+	stageL1Traversal     = "l1_traversal"
+	stageL1Retrieval     = "l1_retrieval"
+	stageAltDA           = "altda"
+	stageFrameQueue      = "frame_queue"
+	stageChannelBank     = "channel_bank"
+	stageChannelInReader = "channel_in_reader"
+	stageBatchQueue      = "batch_queue"
+	stageAttributesQueue = "attributes_queue"
+)
+
+// queueDepthStage is implemented by stages that buffer data for the next stage.
+type queueDepthStage interface {
+	queueDepth() int
+}
+
+// stageTracker tracks the steps of the derivation stages, for metrics.
+// The stages pull from one another, so the time spent in a stage includes the time of the stages it pulls from.
+// The tracker subtracts the time of nested stage steps, to attribute the time to the stage that spent it.
+// A nil stageTracker is valid and does not track anything, so stages can be used without metrics.
+type stageTracker struct {
+	metrics Metrics
+
+	// nested is the time spent in nested stage steps, during the current stage step
+	nested time.Duration
+
+	timeNow func() time.Time
+}
+
+func newStageTracker(m Metrics) *stageTracker {
+	return &stageTracker{metrics: m, timeNow: time.Now}
+}
+
+// track starts a step of the given stage, and returns the function to end it with.
+// The end function is meant to be deferred, with a pointer to the error result of the step:
+// steps that do not error are counted as output of the stage.
+func (st *stageTracker) track(stage string) func(err *error) {
+	if st == nil {
+		return func(*error) {}
+	}
+	start := st.timeNow()
+	outer := st.nested
+	st.nested = 0
+	return func(err *error) {
+		total := st.timeNow().Sub(start)
+		st.metrics.RecordDerivationStageStep(stage, total-st.nested, *err == nil)
+		st.nested = outer + total
+	}
+}
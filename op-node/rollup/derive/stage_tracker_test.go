@@ -0,0 +1,59 @@
+package derive
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type stageStep struct {
+	duration time.Duration
+	output   bool
+}
+
+type stageMetricsRecorder struct {
+	testutils.TestDerivationMetrics
+	steps map[string][]stageStep
+}
+
+func (m *stageMetricsRecorder) RecordDerivationStageStep(stage string, duration time.Duration, output bool) {
+	m.steps[stage] = append(m.steps[stage], stageStep{duration: duration, output: output})
+}
+
+func TestStageTracker(t *testing.T) {
+	m := &stageMetricsRecorder{steps: make(map[string][]stageStep)}
+	tracker := newStageTracker(m)
+	now := time.Unix(1000, 0)
+	tracker.timeNow = func() time.Time { return now }
+
+	inner := func(d time.Duration, err error) (outErr error) {
+		defer tracker.track("inner")(&outErr)
+		now = now.Add(d)
+		return err
+	}
+	outer := func() (outErr error) {
+		defer tracker.track("outer")(&outErr)
+		now = now.Add(time.Second)
+		_ = inner(2*time.Second, nil)
+		_ = inner(3*time.Second, errors.New("no data"))
+		now = now.Add(time.Second)
+		return nil
+	}
+
+	require.NoError(t, outer())
+	require.Equal(t, []stageStep{{duration: 2 * time.Second, output: true}}, m.steps["outer"])
+	require.Equal(t, []stageStep{
+		{duration: 2 * time.Second, output: true},
+		{duration: 3 * time.Second, output: false},
+	}, m.steps["inner"])
+
+	t.Run("NilTracker", func(t *testing.T) {
+		var tracker *stageTracker
+		err := errors.New("ignored")
+		tracker.track("any")(&err)
+	})
+}
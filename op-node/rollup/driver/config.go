@@ -1,5 +1,7 @@
 package driver
 
+import "time"
+
 type Config struct {
 	// VerifierConfDepth is the distance to keep from the L1 head when reading L1 data for L2 derivation.
 	VerifierConfDepth uint64 `json:"verifier_conf_depth"`
@@ -20,4 +22,18 @@ type Config struct {
 	// SequencerMaxSafeLag is the maximum number of L2 blocks for restricting the distance between L2 safe and unsafe.
 	// Disabled if 0.
 	SequencerMaxSafeLag uint64 `json:"sequencer_max_safe_lag"`
+
+	// SequencerHealthELSync enables the health check that stops block-building while the execution engine is syncing.
+	SequencerHealthELSync bool `json:"sequencer_health_el_sync"`
+
+	// SequencerHealthMaxL1HeadAge is the maximum age of the L1 head before block-building is halted.
+	// Disabled if 0.
+	SequencerHealthMaxL1HeadAge time.Duration `json:"sequencer_health_max_l1_head_age"`
+
+	// SequencerHealthConductor enables the health check that verifies conductor leadership before each block.
+	SequencerHealthConductor bool `json:"sequencer_health_conductor"`
+
+	// SequencerStopOnUnhealthy stops the sequencer when a health check fails,
+	// instead of delaying block-building until the checks pass again.
+	SequencerStopOnUnhealthy bool `json:"sequencer_stop_on_unhealthy"`
 }
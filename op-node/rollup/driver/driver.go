@@ -2,6 +2,7 @@ package driver
 
 import (
 	"context"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -46,6 +47,12 @@ type Metrics interface {
 
 	RecordDerivedBatches(batchType string)
 
+	RecordDerivationStageStep(stage string, duration time.Duration, output bool)
+	RecordDerivationStageQueueDepth(stage string, depth int)
+	RecordDerivationStageReset(stage string)
+
+	RecordELSyncPhase(phase string)
+
 	RecordUnsafePayloadsBuffer(length uint64, memSize uint64, next eth.BlockID)
 
 	SetDerivationIdle(idle bool)
@@ -84,6 +91,9 @@ type EngineController interface {
 	InsertUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope, ref eth.L2BlockRef) error
 	TryUpdateEngine(ctx context.Context) error
 	TryBackupUnsafeReorg(ctx context.Context) (bool, error)
+	TrySyncToCheckpoint(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) (bool, error)
+	SyncPhase() string
+	ELSyncStart() time.Time
 }
 
 type CLSync interface {
@@ -172,11 +182,13 @@ func NewDriver(
 
 	var executor event.Executor
 	var drain func() error
+	var queueDepth func() map[string]int
 	// This instantiation will be one of more options: soon there will be a parallel events executor
 	{
 		s := event.NewGlobalSynchronous(driverCtx)
 		executor = s
 		drain = s.Drain
+		queueDepth = s.QueueDepth
 	}
 	sys := event.NewSystem(log, executor)
 	sys.AddTracer(event.NewMetricsTracer(metrics))
@@ -251,8 +263,20 @@ func NewDriver(
 		attrBuilder := derive.NewFetchingAttributesBuilder(cfg, l1, l2)
 		sequencerConfDepth := confdepth.NewConfDepth(driverCfg.SequencerConfDepth, statusTracker.L1Head, l1)
 		findL1Origin := sequencing.NewL1OriginSelector(log, cfg, sequencerConfDepth)
-		sequencer = sequencing.NewSequencer(driverCtx, log, cfg, attrBuilder, findL1Origin,
+		seq := sequencing.NewSequencer(driverCtx, log, cfg, attrBuilder, findL1Origin,
 			sequencerStateListener, sequencerConductor, asyncGossiper, metrics)
+		var healthChecks []sequencing.HealthCheck
+		if driverCfg.SequencerHealthELSync {
+			healthChecks = append(healthChecks, sequencing.NewELSyncCheck(ec.IsEngineSyncing))
+		}
+		if driverCfg.SequencerHealthMaxL1HeadAge > 0 {
+			healthChecks = append(healthChecks, sequencing.NewL1FreshnessCheck(statusTracker.L1Head, driverCfg.SequencerHealthMaxL1HeadAge))
+		}
+		if driverCfg.SequencerHealthConductor {
+			healthChecks = append(healthChecks, sequencing.NewConductorLeaderCheck(sequencerConductor))
+		}
+		seq.SetHealthChecks(driverCfg.SequencerStopOnUnhealthy, healthChecks...)
+		sequencer = seq
 		sys.Register("sequencer", sequencer, opts)
 	} else {
 		sequencer = sequencing.DisabledSequencer{}
@@ -266,6 +290,7 @@ func NewDriver(
 		sched:            schedDeriv,
 		emitter:          driverEmitter,
 		drain:            drain,
+		queueDepth:       queueDepth,
 		stateReq:         make(chan chan struct{}),
 		forceReset:       make(chan chan struct{}, 10),
 		driverConfig:     driverCfg,
@@ -22,6 +22,9 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
+// checkpointSyncInterval is the interval at which the engine is directed to the EL sync checkpoint, until it is synced.
+const checkpointSyncInterval = 5 * time.Second
+
 // Deprecated: use eth.SyncStatus instead.
 type SyncStatus = eth.SyncStatus
 
@@ -37,6 +40,9 @@ type Driver struct {
 	emitter event.Emitter
 	drain   func() error
 
+	// queueDepth reports the events each deriver has yet to process, by deriver, for introspection
+	queueDepth func() map[string]int
+
 	// Requests to block the event loop for synchronous execution to avoid reading an inconsistent state
 	stateReq chan chan struct{}
 
@@ -65,6 +71,9 @@ type Driver struct {
 
 	unsafeL2Payloads chan *eth.ExecutionPayloadEnvelope
 
+	// checkpointPayload is the payload of the EL sync checkpoint, once received. Only accessed by the event loop.
+	checkpointPayload *eth.ExecutionPayloadEnvelope
+
 	sequencer sequencing.SequencerIface
 	network   Network // may be nil, network for is optional
 
@@ -199,6 +208,14 @@ func (s *Driver) eventLoop() {
 	defer altSyncTicker.Stop()
 	lastUnsafeL2 := s.Engine.UnsafeL2Head()
 
+	// When EL syncing to a checkpoint, the engine is directed to the checkpoint periodically, until it is synced.
+	var checkpointCh <-chan time.Time
+	if checkpoint := s.SyncCfg.ELSyncCheckpoint; checkpoint != nil && s.SyncCfg.SyncMode == sync.ELSync {
+		checkpointTicker := time.NewTicker(checkpointSyncInterval)
+		defer checkpointTicker.Stop()
+		checkpointCh = checkpointTicker.C
+	}
+
 	for {
 		if s.driverCtx.Err() != nil { // don't try to schedule/handle more work when we are closing.
 			return
@@ -235,6 +252,16 @@ func (s *Driver) eventLoop() {
 			if err != nil {
 				s.log.Warn("failed to check for unsafe L2 blocks to sync", "err", err)
 			}
+		case <-checkpointCh:
+			ctx, cancel := context.WithTimeout(s.driverCtx, time.Second*10)
+			synced, err := s.syncToCheckpoint(ctx)
+			cancel()
+			if err != nil {
+				s.log.Warn("Failed to sync to checkpoint", "checkpoint", s.SyncCfg.ELSyncCheckpoint, "err", err)
+			} else if synced {
+				checkpointCh = nil
+				reqStep()
+			}
 		case envelope := <-s.unsafeL2Payloads:
 			// If we are doing CL sync or done with engine syncing, fallback to the unsafe payload queue & CL P2P sync.
 			if s.SyncCfg.SyncMode == sync.CLSync || !s.Engine.IsEngineSyncing() {
@@ -251,6 +278,25 @@ func (s *Driver) eventLoop() {
 				if ref.Number <= s.Engine.UnsafeL2Head().Number {
 					continue
 				}
+				// When syncing to a checkpoint, the checkpoint is the only payload that drives the EL sync.
+				if checkpoint := s.SyncCfg.ELSyncCheckpoint; checkpoint != nil {
+					if ref.ID() != *checkpoint {
+						s.log.Debug("Ignoring unsafe L2 execution payload while EL syncing to checkpoint", "id", ref.ID(), "checkpoint", checkpoint)
+						continue
+					}
+					s.log.Info("Received checkpoint payload to drive EL sync", "checkpoint", checkpoint)
+					s.checkpointPayload = envelope
+					ctx, cancel := context.WithTimeout(s.driverCtx, time.Second*10)
+					synced, err := s.syncToCheckpoint(ctx)
+					cancel()
+					if err != nil {
+						s.log.Warn("Failed to sync to checkpoint", "checkpoint", checkpoint, "err", err)
+					} else if synced {
+						checkpointCh = nil
+						reqStep()
+					}
+					continue
+				}
 				s.log.Info("Optimistically inserting unsafe L2 execution payload to drive EL sync", "id", envelope.ExecutionPayload.ID())
 				if err := s.Engine.InsertUnsafePayload(s.driverCtx, envelope, ref); err != nil {
 					s.log.Warn("Failed to insert unsafe payload for EL sync", "id", envelope.ExecutionPayload.ID(), "err", err)
@@ -499,6 +545,24 @@ func (s *Driver) StopSequencer(ctx context.Context) (common.Hash, error) {
 	return s.sequencer.Stop(ctx)
 }
 
+func (s *Driver) StartSequencerAt(ctx context.Context, target sequencing.HandoverTarget) (common.Hash, error) {
+	return s.sequencer.StartAt(ctx, target)
+}
+
+func (s *Driver) StopSequencerAt(ctx context.Context, target sequencing.HandoverTarget) (common.Hash, error) {
+	return s.sequencer.StopAt(ctx, target)
+}
+
+// AddEventTracer adds a tracer to the event system of the driver.
+func (s *Driver) AddEventTracer(t event.Tracer) {
+	s.eventSys.AddTracer(t)
+}
+
+// EventQueueDepth returns the number of events each deriver has yet to process, by name of the deriver.
+func (s *Driver) EventQueueDepth(ctx context.Context) (map[string]int, error) {
+	return s.queueDepth(), nil
+}
+
 func (s *Driver) SequencerActive(ctx context.Context) (bool, error) {
 	return s.sequencer.Active(), nil
 }
@@ -507,6 +571,26 @@ func (s *Driver) OverrideLeader(ctx context.Context) error {
 	return s.sequencer.OverrideLeader(ctx)
 }
 
+// SyncPhase blocks the driver event loop and captures the sync phase of the engine.
+func (s *Driver) SyncPhase(ctx context.Context) (*eth.SyncPhase, error) {
+	wait := make(chan struct{})
+	select {
+	case s.stateReq <- wait:
+		resp := &eth.SyncPhase{
+			Phase:      s.Engine.SyncPhase(),
+			Checkpoint: s.SyncCfg.ELSyncCheckpoint,
+			UnsafeL2:   s.Engine.UnsafeL2Head(),
+		}
+		if start := s.Engine.ELSyncStart(); !start.IsZero() {
+			resp.ELSyncStart = uint64(start.Unix())
+		}
+		<-wait
+		return resp, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // SyncStatus blocks the driver event loop and captures the syncing status.
 func (s *Driver) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	return s.statusTracker.SyncStatus(), nil
@@ -533,6 +617,22 @@ func (s *Driver) BlockRefWithStatus(ctx context.Context, num uint64) (eth.L2Bloc
 	}
 }
 
+// syncToCheckpoint drives the engine to the EL sync checkpoint with the checkpoint payload, and requests
+// the checkpoint payload from the alt-sync method, if it was not received yet.
+// Results are received through OnUnsafeL2Payload.
+func (s *Driver) syncToCheckpoint(ctx context.Context) (bool, error) {
+	synced, err := s.Engine.TrySyncToCheckpoint(ctx, s.checkpointPayload)
+	if err != nil || synced || s.checkpointPayload != nil {
+		return synced, err
+	}
+	checkpoint := s.SyncCfg.ELSyncCheckpoint
+	// The alt-sync requests the blocks in between, and verifies them against the parent-hash of the end of the range.
+	start := eth.L2BlockRef{Number: checkpoint.Number - 1}
+	end := eth.L2BlockRef{Number: checkpoint.Number + 1, ParentHash: checkpoint.Hash}
+	s.log.Debug("Requesting checkpoint payload", "checkpoint", checkpoint)
+	return false, s.altSync.RequestL2Range(ctx, start, end)
+}
+
 // checkForGapInUnsafeQueue checks if there is a gap in the unsafe queue and attempts to retrieve the missing payloads from an alt-sync method.
 // WARNING: This is only an outgoing signal, the blocks are not guaranteed to be retrieved.
 // Results are received through OnUnsafeL2Payload.
@@ -22,6 +22,8 @@ func (eq *EngDeriver) onBuildStart(ev BuildStartEvent) {
 	ctx, cancel := context.WithTimeout(eq.ctx, buildStartTimeout)
 	defer cancel()
 
+	ev.Attributes = eq.replacementOf(ev.Attributes)
+
 	if ev.Attributes.DerivedFrom != (eth.L1BlockRef{}) &&
 		eq.ec.PendingSafeL2Head().Hash != ev.Attributes.Parent.Hash {
 		// Warn about small reorgs, happens when pending safe head is getting rolled back
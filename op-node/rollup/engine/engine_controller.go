@@ -33,6 +33,32 @@ const (
 	syncStatusFinishedEL                // EL sync is done & we should be performing consolidation
 )
 
+// Names of the sync phases, as exposed in the API and metrics.
+const (
+	SyncPhaseCL            = "consensus-layer"
+	SyncPhaseELPending     = "el-sync-pending"
+	SyncPhaseELSyncing     = "el-syncing"
+	SyncPhaseELFinalizing  = "el-sync-finalizing"
+	SyncPhaseConsolidation = "consolidation"
+)
+
+func (s syncStatusEnum) phase() string {
+	switch s {
+	case syncStatusCL:
+		return SyncPhaseCL
+	case syncStatusWillStartEL:
+		return SyncPhaseELPending
+	case syncStatusStartedEL:
+		return SyncPhaseELSyncing
+	case syncStatusFinishedELButNotFinalized:
+		return SyncPhaseELFinalizing
+	case syncStatusFinishedEL:
+		return SyncPhaseConsolidation
+	default:
+		return "unknown"
+	}
+}
+
 var ErrNoFCUNeeded = errors.New("no FCU call was needed")
 
 type ExecEngine interface {
@@ -92,6 +118,7 @@ func NewEngineController(engine ExecEngine, log log.Logger, metrics derive.Metri
 		syncStatus = syncStatusWillStartEL
 	}
 
+	metrics.RecordELSyncPhase(syncStatus.phase())
 	return &EngineController{
 		engine:     engine,
 		log:        log,
@@ -139,6 +166,21 @@ func (e *EngineController) IsEngineSyncing() bool {
 	return e.syncStatus == syncStatusWillStartEL || e.syncStatus == syncStatusStartedEL || e.syncStatus == syncStatusFinishedELButNotFinalized
 }
 
+// SyncPhase returns the name of the current sync phase.
+func (e *EngineController) SyncPhase() string {
+	return e.syncStatus.phase()
+}
+
+// ELSyncStart returns when the EL sync started, or the zero time if it did not start.
+func (e *EngineController) ELSyncStart() time.Time {
+	return e.elStart
+}
+
+func (e *EngineController) setSyncStatus(status syncStatusEnum) {
+	e.syncStatus = status
+	e.metrics.RecordELSyncPhase(status.phase())
+}
+
 // Setters
 
 // SetFinalizedHead implements LocalEngineControl.
@@ -239,7 +281,7 @@ func (e *EngineController) logSyncProgressMaybe() func() {
 func (e *EngineController) checkNewPayloadStatus(status eth.ExecutePayloadStatus) bool {
 	if e.syncCfg.SyncMode == sync.ELSync {
 		if status == eth.ExecutionValid && e.syncStatus == syncStatusStartedEL {
-			e.syncStatus = syncStatusFinishedELButNotFinalized
+			e.setSyncStatus(syncStatusFinishedELButNotFinalized)
 		}
 		// Allow SYNCING and ACCEPTED if engine EL sync is enabled
 		return status == eth.ExecutionValid || status == eth.ExecutionSyncing || status == eth.ExecutionAccepted
@@ -252,7 +294,7 @@ func (e *EngineController) checkNewPayloadStatus(status eth.ExecutePayloadStatus
 func (e *EngineController) checkForkchoiceUpdatedStatus(status eth.ExecutePayloadStatus) bool {
 	if e.syncCfg.SyncMode == sync.ELSync {
 		if status == eth.ExecutionValid && e.syncStatus == syncStatusStartedEL {
-			e.syncStatus = syncStatusFinishedELButNotFinalized
+			e.setSyncStatus(syncStatusFinishedELButNotFinalized)
 		}
 		// Allow SYNCING if engine P2P sync is enabled
 		return status == eth.ExecutionValid || status == eth.ExecutionSyncing
@@ -310,21 +352,118 @@ func (e *EngineController) TryUpdateEngine(ctx context.Context) error {
 	return nil
 }
 
+// startELSync starts the EL sync, unless there is a finalized head in the engine already:
+// then the EL sync is skipped, and the node continues with CL sync. It returns true if the EL sync is skipped.
+func (e *EngineController) startELSync(ctx context.Context) (skipped bool, err error) {
+	b, err := e.engine.L2BlockRefByLabel(ctx, eth.Finalized)
+	rollupGenesisIsFinalized := b.Hash == e.rollupCfg.Genesis.L2.Hash
+	if errors.Is(err, ethereum.NotFound) || rollupGenesisIsFinalized || e.syncCfg.SupportsPostFinalizationELSync {
+		e.setSyncStatus(syncStatusStartedEL)
+		e.log.Info("Starting EL sync", "checkpoint", e.syncCfg.ELSyncCheckpoint)
+		e.elStart = e.clock.Now()
+		return false, nil
+	} else if err == nil {
+		e.setSyncStatus(syncStatusFinishedEL)
+		e.log.Info("Skipping EL sync and going straight to CL sync because there is a finalized block", "id", b.ID())
+		return true, nil
+	} else {
+		return false, derive.NewTemporaryError(fmt.Errorf("failed to fetch finalized head: %w", err))
+	}
+}
+
+// TrySyncToCheckpoint drives the execution engine to sync to the configured EL sync checkpoint,
+// by inserting the checkpoint payload and making it the head of the chain. This is retried until the engine
+// reports the checkpoint as valid: the EL sync is then finished, and the node continues with consolidation.
+// The checkpoint is only marked as unsafe: it becomes safe once derivation consolidates the chain up to it.
+// The checkpoint payload may be nil if it was not received yet, in which case the engine cannot be directed to it.
+// It returns true if the engine is done syncing, or if there is no EL sync to a checkpoint in progress.
+func (e *EngineController) TrySyncToCheckpoint(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) (bool, error) {
+	checkpoint := e.syncCfg.ELSyncCheckpoint
+	if checkpoint == nil || !e.IsEngineSyncing() {
+		return true, nil
+	}
+	if e.syncStatus == syncStatusWillStartEL {
+		if skipped, err := e.startELSync(ctx); err != nil {
+			return false, err
+		} else if skipped {
+			return true, nil
+		}
+	}
+	if envelope == nil {
+		e.log.Debug("Waiting for checkpoint payload to EL sync to", "checkpoint", checkpoint)
+		return false, nil
+	}
+	payload := envelope.ExecutionPayload
+	if payload.ID() != *checkpoint {
+		return false, fmt.Errorf("payload %s is not the checkpoint %s", payload.ID(), checkpoint)
+	}
+	ref, err := derive.PayloadToBlockRef(e.rollupCfg, payload)
+	if err != nil {
+		return false, fmt.Errorf("failed to derive block ref of checkpoint %s: %w", checkpoint, err)
+	}
+
+	// The engine needs the checkpoint payload to know which chain to sync, and may need it again after a restart.
+	status, err := e.engine.NewPayload(ctx, payload, envelope.ParentBeaconBlockRoot)
+	if err != nil {
+		return false, derive.NewTemporaryError(fmt.Errorf("failed to insert checkpoint payload %s: %w", checkpoint, err))
+	}
+	switch status.Status {
+	case eth.ExecutionValid, eth.ExecutionSyncing, eth.ExecutionAccepted:
+	case eth.ExecutionInvalid:
+		e.emitter.Emit(PayloadInvalidEvent{Envelope: envelope, Err: eth.NewPayloadErr(payload, status)})
+		fallthrough
+	default:
+		return false, derive.NewTemporaryError(fmt.Errorf("execution engine did not accept checkpoint payload %s: %w",
+			checkpoint, eth.NewPayloadErr(payload, status)))
+	}
+
+	// Only the head is moved to the checkpoint: the safe and finalized blocks are left to derivation.
+	fc := eth.ForkchoiceState{
+		HeadBlockHash:      checkpoint.Hash,
+		SafeBlockHash:      e.safeHead.Hash,
+		FinalizedBlockHash: e.finalizedHead.Hash,
+	}
+	fcRes, err := e.engine.ForkchoiceUpdate(ctx, &fc, nil)
+	if err != nil {
+		var inputErr eth.InputError
+		if errors.As(err, &inputErr) && inputErr.Code == eth.InvalidForkchoiceState {
+			return false, derive.NewResetError(fmt.Errorf("checkpoint forkchoice update was inconsistent with engine, need reset to resolve: %w", inputErr.Unwrap()))
+		}
+		return false, derive.NewTemporaryError(fmt.Errorf("failed to direct engine to sync to checkpoint %s: %w", checkpoint, err))
+	}
+	switch fcRes.PayloadStatus.Status {
+	case eth.ExecutionSyncing, eth.ExecutionAccepted:
+		e.log.Info("Execution engine is syncing to checkpoint", "checkpoint", checkpoint, "elapsed", e.clock.Since(e.elStart))
+		return false, nil
+	case eth.ExecutionValid:
+	default:
+		return false, derive.NewTemporaryError(fmt.Errorf("execution engine did not accept checkpoint %s: %w",
+			checkpoint, eth.ForkchoiceUpdateErr(fcRes.PayloadStatus)))
+	}
+
+	logFn := e.logSyncProgressMaybe()
+	defer logFn()
+	e.SetUnsafeHead(ref)
+	e.needFCUCall = false
+	e.emitter.Emit(UnsafeUpdateEvent{Ref: ref})
+
+	e.log.Info("Finished EL sync", "sync_duration", e.clock.Since(e.elStart), "unsafe_block", ref.ID().String())
+	e.setSyncStatus(syncStatusFinishedEL)
+
+	e.emitter.Emit(ForkchoiceUpdateEvent{
+		UnsafeL2Head:    e.unsafeHead,
+		SafeL2Head:      e.safeHead,
+		FinalizedL2Head: e.finalizedHead,
+	})
+	return true, nil
+}
+
 func (e *EngineController) InsertUnsafePayload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope, ref eth.L2BlockRef) error {
-	// Check if there is a finalized head once when doing EL sync. If so, transition to CL sync
 	if e.syncStatus == syncStatusWillStartEL {
-		b, err := e.engine.L2BlockRefByLabel(ctx, eth.Finalized)
-		rollupGenesisIsFinalized := b.Hash == e.rollupCfg.Genesis.L2.Hash
-		if errors.Is(err, ethereum.NotFound) || rollupGenesisIsFinalized || e.syncCfg.SupportsPostFinalizationELSync {
-			e.syncStatus = syncStatusStartedEL
-			e.log.Info("Starting EL sync")
-			e.elStart = e.clock.Now()
-		} else if err == nil {
-			e.syncStatus = syncStatusFinishedEL
-			e.log.Info("Skipping EL sync and going straight to CL sync because there is a finalized block", "id", b.ID())
+		if skipped, err := e.startELSync(ctx); err != nil {
+			return err
+		} else if skipped {
 			return nil
-		} else {
-			return derive.NewTemporaryError(fmt.Errorf("failed to fetch finalized head: %w", err))
 		}
 	}
 	// Insert the payload & then call FCU
@@ -384,7 +523,7 @@ func (e *EngineController) InsertUnsafePayload(ctx context.Context, envelope *et
 
 	if e.syncStatus == syncStatusFinishedELButNotFinalized {
 		e.log.Info("Finished EL sync", "sync_duration", e.clock.Since(e.elStart), "finalized_block", ref.ID().String())
-		e.syncStatus = syncStatusFinishedEL
+		e.setSyncStatus(syncStatusFinishedEL)
 	}
 
 	if fcRes.PayloadStatus.Status == eth.ExecutionValid {
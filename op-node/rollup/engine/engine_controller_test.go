@@ -0,0 +1,132 @@
+package engine
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestTrySyncToCheckpoint(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	rollupCfg := &rollup.Config{Genesis: rollup.Genesis{L2: testutils.RandomBlockID(rng)}}
+	block, _ := test.RandomL2Block(rng, 2, time.Time{})
+	envelope, err := eth.BlockAsPayloadEnv(block, nil)
+	require.NoError(t, err)
+	ref, err := derive.PayloadToBlockRef(rollupCfg, envelope.ExecutionPayload)
+	require.NoError(t, err)
+	checkpoint := ref.ID()
+
+	setup := func(t *testing.T) (*EngineController, *testutils.MockEngine, *testutils.MockEmitter) {
+		engine := &testutils.MockEngine{}
+		emitter := &testutils.MockEmitter{}
+		syncCfg := &sync.Config{SyncMode: sync.ELSync, ELSyncCheckpoint: &checkpoint}
+		ec := NewEngineController(engine, testlog.Logger(t, log.LevelInfo), &testutils.TestDerivationMetrics{}, rollupCfg, syncCfg, emitter)
+		// The engine has nothing finalized yet, so it starts the EL sync
+		engine.ExpectL2BlockRefByLabel(eth.Finalized, eth.L2BlockRef{}, ethereum.NotFound)
+		return ec, engine, emitter
+	}
+	// Only the head is moved to the checkpoint while syncing
+	checkpointFC := &eth.ForkchoiceState{HeadBlockHash: checkpoint.Hash}
+	status := func(s eth.ExecutePayloadStatus) *eth.PayloadStatusV1 {
+		return &eth.PayloadStatusV1{Status: s}
+	}
+
+	t.Run("NoCheckpoint", func(t *testing.T) {
+		ec := NewEngineController(&testutils.MockEngine{}, testlog.Logger(t, log.LevelInfo), &testutils.TestDerivationMetrics{},
+			rollupCfg, &sync.Config{SyncMode: sync.ELSync}, &testutils.MockEmitter{})
+		synced, err := ec.TrySyncToCheckpoint(context.Background(), envelope)
+		require.NoError(t, err)
+		require.True(t, synced)
+	})
+
+	t.Run("WaitForPayload", func(t *testing.T) {
+		ec, engine, _ := setup(t)
+		synced, err := ec.TrySyncToCheckpoint(context.Background(), nil)
+		require.NoError(t, err)
+		require.False(t, synced)
+		require.Equal(t, SyncPhaseELSyncing, ec.SyncPhase())
+		engine.AssertExpectations(t)
+	})
+
+	t.Run("SyncUntilValid", func(t *testing.T) {
+		ec, engine, emitter := setup(t)
+
+		// The checkpoint payload is inserted, to drive the EL sync, until the engine is synced
+		engine.ExpectNewPayload(envelope.ExecutionPayload, nil, status(eth.ExecutionSyncing), nil)
+		engine.ExpectForkchoiceUpdate(checkpointFC, nil, &eth.ForkchoiceUpdatedResult{PayloadStatus: *status(eth.ExecutionSyncing)}, nil)
+		synced, err := ec.TrySyncToCheckpoint(context.Background(), envelope)
+		require.NoError(t, err)
+		require.False(t, synced)
+		require.Equal(t, SyncPhaseELSyncing, ec.SyncPhase())
+		require.Equal(t, eth.L2BlockRef{}, ec.UnsafeL2Head())
+
+		engine.ExpectNewPayload(envelope.ExecutionPayload, nil, status(eth.ExecutionValid), nil)
+		engine.ExpectForkchoiceUpdate(checkpointFC, nil, &eth.ForkchoiceUpdatedResult{PayloadStatus: *status(eth.ExecutionValid)}, nil)
+		emitter.ExpectOnce(UnsafeUpdateEvent{Ref: ref})
+		emitter.ExpectOnce(ForkchoiceUpdateEvent{UnsafeL2Head: ref})
+		synced, err = ec.TrySyncToCheckpoint(context.Background(), envelope)
+		require.NoError(t, err)
+		require.True(t, synced)
+		require.Equal(t, SyncPhaseConsolidation, ec.SyncPhase())
+		require.False(t, ec.IsEngineSyncing())
+
+		// The checkpoint is unsafe only, until derivation consolidates it
+		require.Equal(t, ref, ec.UnsafeL2Head())
+		require.Equal(t, eth.L2BlockRef{}, ec.SafeL2Head())
+		require.Equal(t, eth.L2BlockRef{}, ec.LocalSafeL2Head())
+		require.Equal(t, eth.L2BlockRef{}, ec.Finalized())
+		engine.AssertExpectations(t)
+		emitter.AssertExpectations(t)
+
+		// Once synced, the engine is not directed to the checkpoint anymore
+		synced, err = ec.TrySyncToCheckpoint(context.Background(), envelope)
+		require.NoError(t, err)
+		require.True(t, synced)
+	})
+
+	t.Run("NotCheckpointPayload", func(t *testing.T) {
+		ec, engine, _ := setup(t)
+		other, err := eth.BlockAsPayloadEnv(block.WithSeal(testutils.RandomHeader(rng)), nil)
+		require.NoError(t, err)
+		_, err = ec.TrySyncToCheckpoint(context.Background(), other)
+		require.ErrorContains(t, err, "is not the checkpoint")
+		engine.AssertExpectations(t)
+	})
+
+	t.Run("InvalidPayload", func(t *testing.T) {
+		ec, engine, emitter := setup(t)
+		engine.ExpectNewPayload(envelope.ExecutionPayload, nil, status(eth.ExecutionInvalid), nil)
+		emitter.ExpectOnceType("PayloadInvalidEvent")
+		synced, err := ec.TrySyncToCheckpoint(context.Background(), envelope)
+		require.ErrorIs(t, err, derive.ErrTemporary)
+		require.False(t, synced)
+		require.Equal(t, SyncPhaseELSyncing, ec.SyncPhase())
+		engine.AssertExpectations(t)
+		emitter.AssertExpectations(t)
+	})
+
+	t.Run("SkipIfFinalized", func(t *testing.T) {
+		engine := &testutils.MockEngine{}
+		syncCfg := &sync.Config{SyncMode: sync.ELSync, ELSyncCheckpoint: &checkpoint}
+		ec := NewEngineController(engine, testlog.Logger(t, log.LevelInfo), &testutils.TestDerivationMetrics{}, rollupCfg, syncCfg, &testutils.MockEmitter{})
+		engine.ExpectL2BlockRefByLabel(eth.Finalized, testutils.RandomL2BlockRef(rng), nil)
+		synced, err := ec.TrySyncToCheckpoint(context.Background(), envelope)
+		require.NoError(t, err)
+		require.True(t, synced)
+		require.Equal(t, SyncPhaseConsolidation, ec.SyncPhase())
+		engine.AssertExpectations(t)
+	})
+}
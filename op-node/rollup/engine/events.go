@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -247,6 +248,10 @@ type EngDeriver struct {
 	ec      *EngineController
 	ctx     context.Context
 	emitter event.Emitter
+
+	// replacements maps the parent hash of invalidated blocks to the attributes of their replacement,
+	// for the derivation to build the replacement instead of the invalidated block when re-deriving it.
+	replacements map[common.Hash]*derive.AttributesWithParent
 }
 
 var _ event.Deriver = (*EngDeriver)(nil)
@@ -259,6 +264,8 @@ func NewEngDeriver(log log.Logger, ctx context.Context, cfg *rollup.Config,
 		ec:      ec,
 		ctx:     ctx,
 		metrics: metrics,
+
+		replacements: make(map[common.Hash]*derive.AttributesWithParent),
 	}
 }
 
@@ -434,6 +441,8 @@ func (d *EngDeriver) OnEvent(ev event.Event) bool {
 				LocalSafe: d.ec.LocalSafeL2Head(),
 			})
 		}
+	case InvalidateBlockEvent:
+		d.onInvalidateBlock(x)
 	case BuildStartEvent:
 		d.onBuildStart(x)
 	case BuildStartedEvent:
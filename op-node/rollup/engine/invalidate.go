@@ -0,0 +1,100 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ErrReplacingBlock is the error of the reset that re-derives the chain to replace an invalidated block.
+var ErrReplacingBlock = errors.New("replacing invalidated block")
+
+// InvalidateBlockEvent signals that the given block is invalid, e.g. because it contains invalid executing messages,
+// and that the chain must be rewound to the parent of the block.
+// If a replacement is given, it takes the place of the invalidated block: the derivation pipeline is reset,
+// and the replacement is built when the derivation arrives at the invalidated block again.
+type InvalidateBlockEvent struct {
+	Invalidated eth.L2BlockRef
+	Parent      eth.L2BlockRef
+
+	// Replacement is nil if the block is to be dropped without replacement, e.g. when it is unsafe.
+	Replacement *derive.AttributesWithParent
+}
+
+func (ev InvalidateBlockEvent) String() string {
+	return "invalidate-block"
+}
+
+func (eq *EngDeriver) onInvalidateBlock(ev InvalidateBlockEvent) {
+	if ev.Invalidated.ParentHash != ev.Parent.Hash {
+		eq.log.Error("Cannot invalidate block, parent does not match", "invalidated", ev.Invalidated, "parent", ev.Parent)
+		return
+	}
+	if ev.Invalidated.Number <= eq.ec.SafeL2Head().Number {
+		eq.log.Error("Cannot invalidate cross-safe block", "invalidated", ev.Invalidated, "safe", eq.ec.SafeL2Head())
+		return
+	}
+	if ev.Replacement == nil && ev.Invalidated.Number <= eq.ec.LocalSafeL2Head().Number {
+		// Local-safe blocks are derived from L1, and have to be replaced, not dropped.
+		eq.log.Warn("Not dropping local-safe block without replacement", "invalidated", ev.Invalidated, "local_safe", eq.ec.LocalSafeL2Head())
+		return
+	}
+	if unsafe := eq.ec.UnsafeL2Head(); unsafe.Number < ev.Invalidated.Number {
+		eq.log.Debug("Invalidated block is not canonical anymore", "invalidated", ev.Invalidated, "unsafe", unsafe)
+		return
+	}
+	eq.log.Warn("Invalidating block", "invalidated", ev.Invalidated, "parent", ev.Parent, "replace", ev.Replacement != nil)
+
+	// Rewind all heads past the invalidated block to its parent.
+	eq.ec.SetUnsafeHead(ev.Parent)
+	if eq.ec.CrossUnsafeL2Head().Number >= ev.Invalidated.Number {
+		eq.ec.SetCrossUnsafeHead(ev.Parent)
+	}
+	if eq.ec.PendingSafeL2Head().Number >= ev.Invalidated.Number {
+		eq.ec.SetPendingSafeL2Head(ev.Parent)
+	}
+	if eq.ec.LocalSafeL2Head().Number >= ev.Invalidated.Number {
+		eq.ec.SetLocalSafeHead(ev.Parent)
+	}
+	// The backup unsafe head, if any, may be the invalidated block or one of its descendants.
+	eq.ec.SetBackupUnsafeL2Head(eth.L2BlockRef{}, false)
+
+	if ev.Replacement != nil {
+		// Derivation re-derives the invalidated block from L1, and must build the replacement instead.
+		eq.replacements[ev.Parent.Hash] = ev.Replacement
+		// Apply the rewind to the engine, before resetting the derivation pipeline to re-derive from the engine state.
+		eq.emitter.Emit(TryUpdateEngineEvent{})
+		eq.emitter.Emit(rollup.ResetEvent{Err: fmt.Errorf("%w %s", ErrReplacingBlock, ev.Invalidated)})
+	} else {
+		// Signal the rewind of the unsafe chain, this also applies the rewind to the engine.
+		eq.emitter.Emit(UnsafeUpdateEvent{Ref: ev.Parent})
+	}
+}
+
+// replacementOf returns the attributes to build instead of the given derived attributes,
+// if they are for a block that was invalidated and replaced.
+func (eq *EngDeriver) replacementOf(attrs *derive.AttributesWithParent) *derive.AttributesWithParent {
+	// Replacements of blocks that are cross-safe will not be re-derived anymore.
+	for parent, replacement := range eq.replacements {
+		if replacement.Parent.Number < eq.ec.SafeL2Head().Number {
+			delete(eq.replacements, parent)
+		}
+	}
+	if attrs.DerivedFrom == (eth.L1BlockRef{}) {
+		return attrs // from sequencing
+	}
+	replacement, ok := eq.replacements[attrs.Parent.Hash]
+	if !ok || replacement == attrs || replacement.Attributes.Timestamp != attrs.Attributes.Timestamp {
+		return attrs
+	}
+	eq.log.Info("Building replacement of invalidated block", "parent", attrs.Parent, "timestamp", uint64(attrs.Attributes.Timestamp))
+	return &derive.AttributesWithParent{
+		Attributes:   replacement.Attributes,
+		Parent:       attrs.Parent,
+		IsLastInSpan: attrs.IsLastInSpan,
+		DerivedFrom:  attrs.DerivedFrom,
+	}
+}
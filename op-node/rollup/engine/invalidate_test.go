@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type eventRecorder struct {
+	events []event.Event
+}
+
+func (r *eventRecorder) Emit(ev event.Event) {
+	r.events = append(r.events, ev)
+}
+
+func (r *eventRecorder) take() []event.Event {
+	events := r.events
+	r.events = nil
+	return events
+}
+
+func TestInvalidateBlockReplacement(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	a0 := testutils.RandomL2BlockRef(rng)
+	a1 := testutils.NextRandomL2Ref(rng, 2, a0, a0.L1Origin)
+	a2 := testutils.NextRandomL2Ref(rng, 2, a1, a1.L1Origin)
+	l1 := testutils.RandomBlockRef(rng)
+
+	attrs := func(parent eth.L2BlockRef, txs ...eth.Data) *derive.AttributesWithParent {
+		gasLimit := eth.Uint64Quantity(30_000_000)
+		return &derive.AttributesWithParent{
+			Attributes: &eth.PayloadAttributes{
+				Timestamp:    eth.Uint64Quantity(parent.Time + 2),
+				Transactions: txs,
+				NoTxPool:     true,
+				GasLimit:     &gasLimit,
+			},
+			Parent:       parent,
+			IsLastInSpan: true,
+			DerivedFrom:  l1,
+		}
+	}
+	deposit := eth.Data{0x7e, 0x01}
+	original := attrs(a0, deposit, eth.Data{0x02, 0x03})
+	replacement := attrs(a0, deposit)
+	replacement.DerivedFrom = testutils.RandomBlockRef(rng)
+
+	engine := &testutils.MockEngine{}
+	emitter := &eventRecorder{}
+	cfg := &rollup.Config{}
+	ec := NewEngineController(engine, testlog.Logger(t, log.LevelInfo), &testutils.TestDerivationMetrics{}, cfg, &sync.Config{}, emitter)
+	d := NewEngDeriver(testlog.Logger(t, log.LevelInfo), context.Background(), cfg, &testutils.TestDerivationMetrics{}, ec)
+	d.AttachEmitter(emitter)
+
+	ec.SetFinalizedHead(a0)
+	ec.SetSafeHead(a0)
+	ec.SetLocalSafeHead(a2)
+	ec.SetPendingSafeL2Head(a2)
+	ec.SetCrossUnsafeHead(a2)
+	ec.SetUnsafeHead(a2)
+
+	d.OnEvent(InvalidateBlockEvent{Invalidated: a1, Parent: a0, Replacement: replacement})
+	require.Equal(t, a0, ec.UnsafeL2Head())
+	require.Equal(t, a0, ec.CrossUnsafeL2Head())
+	require.Equal(t, a0, ec.PendingSafeL2Head())
+	require.Equal(t, a0, ec.LocalSafeL2Head())
+	require.Equal(t, a0, ec.SafeL2Head())
+
+	// The rewind is applied to the engine, before the derivation pipeline is reset
+	events := emitter.take()
+	require.Len(t, events, 2)
+	require.Equal(t, TryUpdateEngineEvent{}, events[0])
+	require.IsType(t, rollup.ResetEvent{}, events[1])
+
+	engine.ExpectForkchoiceUpdate(&eth.ForkchoiceState{HeadBlockHash: a0.Hash, SafeBlockHash: a0.Hash, FinalizedBlockHash: a0.Hash},
+		nil, &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionValid}}, nil)
+	d.OnEvent(events[0])
+	require.Equal(t, []event.Event{ForkchoiceUpdateEvent{UnsafeL2Head: a0, SafeL2Head: a0, FinalizedL2Head: a0}}, emitter.take())
+
+	// When re-derived, the replacement is built instead of the invalidated block
+	payloadID := eth.PayloadID{1}
+	engine.ExpectForkchoiceUpdate(&eth.ForkchoiceState{HeadBlockHash: a0.Hash, SafeBlockHash: a0.Hash, FinalizedBlockHash: a0.Hash},
+		replacement.Attributes, &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionValid}, PayloadID: &payloadID}, nil)
+	d.OnEvent(BuildStartEvent{Attributes: original})
+	events = emitter.take()
+	require.Len(t, events, 2)
+	started, ok := events[1].(BuildStartedEvent)
+	require.True(t, ok)
+	require.Equal(t, payloadID, started.Info.ID)
+	require.Equal(t, a0, started.Parent)
+	// The replacement is derived from the L1 block that the block is re-derived from
+	require.Equal(t, original.DerivedFrom, started.DerivedFrom)
+	require.True(t, started.IsLastInSpan)
+
+	// Blocks built by the sequencer are never replaced
+	sequenced := attrs(a0, deposit, eth.Data{0x02, 0x04})
+	sequenced.DerivedFrom = eth.L1BlockRef{}
+	require.Same(t, sequenced, d.replacementOf(sequenced))
+
+	// Once the replacement is cross-safe, it is not tracked anymore
+	ec.SetSafeHead(a1)
+	require.Same(t, original, d.replacementOf(original))
+	require.Empty(t, d.replacements)
+	engine.AssertExpectations(t)
+}
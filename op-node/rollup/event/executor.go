@@ -1,5 +1,7 @@
 package event
 
+import "fmt"
+
 type Executable interface {
 	RunEvent(ev AnnotatedEvent)
 }
@@ -13,6 +15,20 @@ func (fn ExecutableFunc) RunEvent(ev AnnotatedEvent) {
 	fn(ev)
 }
 
+// NamedExecutable is an Executable with a name, for introspection of the executor.
+type NamedExecutable interface {
+	Executable
+	Name() string
+}
+
+// executableName returns the name of the executable, or its type if it is not named.
+func executableName(d Executable) string {
+	if n, ok := d.(NamedExecutable); ok {
+		return n.Name()
+	}
+	return fmt.Sprintf("%T", d)
+}
+
 type Executor interface {
 	Add(d Executable, opts *ExecutorOpts) (leaveExecutor func())
 	Enqueue(ev AnnotatedEvent) error
@@ -16,6 +16,9 @@ const sanityEventLimit = 1000
 type GlobalSyncExec struct {
 	eventsLock sync.Mutex
 	events     []AnnotatedEvent
+	// popped is the number of events popped from the queue so far,
+	// and thus the sequence number of the last popped event.
+	popped uint64
 
 	handles     []*globalHandle
 	handlesLock sync.RWMutex
@@ -32,8 +35,12 @@ func NewGlobalSynchronous(ctx context.Context) *GlobalSyncExec {
 func (gs *GlobalSyncExec) Add(d Executable, _ *ExecutorOpts) (leaveExecutor func()) {
 	gs.handlesLock.Lock()
 	defer gs.handlesLock.Unlock()
-	h := &globalHandle{d: d}
+	h := &globalHandle{d: d, name: executableName(d)}
 	h.g.Store(gs)
+	// The handle only processes events that are popped after it is added.
+	gs.eventsLock.Lock()
+	h.processed.Store(gs.popped)
+	gs.eventsLock.Unlock()
 	gs.handles = append(gs.handles, h)
 	return h.leave
 }
@@ -62,24 +69,47 @@ func (gs *GlobalSyncExec) Enqueue(ev AnnotatedEvent) error {
 	return nil
 }
 
-func (gs *GlobalSyncExec) pop() AnnotatedEvent {
+// QueueDepth returns the number of events that each deriver has yet to process, by name of the deriver.
+// This includes the queued events, and any popped events that are still being processed by other derivers.
+// A deriver that stalls the event processing accumulates a larger depth than the derivers that ran before it.
+func (gs *GlobalSyncExec) QueueDepth() map[string]int {
+	gs.eventsLock.Lock()
+	last := gs.popped + uint64(len(gs.events))
+	gs.eventsLock.Unlock()
+
+	gs.handlesLock.RLock()
+	defer gs.handlesLock.RUnlock()
+	out := make(map[string]int, len(gs.handles))
+	for _, h := range gs.handles {
+		depth := 0
+		if processed := h.processed.Load(); processed < last {
+			depth = int(last - processed)
+		}
+		// Names of derivers are unique within a System, but other executables may share a name.
+		out[h.name] = max(out[h.name], depth)
+	}
+	return out
+}
+
+func (gs *GlobalSyncExec) pop() (AnnotatedEvent, uint64) {
 	gs.eventsLock.Lock()
 	defer gs.eventsLock.Unlock()
 
 	if len(gs.events) == 0 {
-		return AnnotatedEvent{}
+		return AnnotatedEvent{}, 0
 	}
 
 	first := gs.events[0]
 	gs.events = gs.events[1:]
-	return first
+	gs.popped += 1
+	return first, gs.popped
 }
 
-func (gs *GlobalSyncExec) processEvent(ev AnnotatedEvent) {
+func (gs *GlobalSyncExec) processEvent(ev AnnotatedEvent, seq uint64) {
 	gs.handlesLock.RLock() // read lock, to allow Drain() to be called during event processing.
 	defer gs.handlesLock.RUnlock()
 	for _, h := range gs.handles {
-		h.onEvent(ev)
+		h.onEvent(ev, seq)
 	}
 }
 
@@ -88,12 +118,12 @@ func (gs *GlobalSyncExec) Drain() error {
 		if gs.ctx.Err() != nil {
 			return gs.ctx.Err()
 		}
-		ev := gs.pop()
+		ev, seq := gs.pop()
 		if ev.Event == nil {
 			return nil
 		}
 		// Note: event execution may call Drain(), that is allowed.
-		gs.processEvent(ev)
+		gs.processEvent(ev, seq)
 	}
 }
 
@@ -103,12 +133,12 @@ func (gs *GlobalSyncExec) DrainUntil(fn func(ev Event) bool, excl bool) error {
 	// no stopExcl, and no event: EOF, exhausted events before condition hit.
 	// no stopExcl, and event: process event.
 	// stopIncl: stop draining, after having processed the event first.
-	iter := func() (ev AnnotatedEvent, stopIncl bool, stopExcl bool) {
+	iter := func() (ev AnnotatedEvent, seq uint64, stopIncl bool, stopExcl bool) {
 		gs.eventsLock.Lock()
 		defer gs.eventsLock.Unlock()
 
 		if len(gs.events) == 0 {
-			return AnnotatedEvent{}, false, false
+			return AnnotatedEvent{}, 0, false, false
 		}
 
 		ev = gs.events[0]
@@ -118,6 +148,8 @@ func (gs *GlobalSyncExec) DrainUntil(fn func(ev Event) bool, excl bool) error {
 			stopExcl = true
 		} else {
 			gs.events = gs.events[1:]
+			gs.popped += 1
+			seq = gs.popped
 		}
 		if stop {
 			stopIncl = true
@@ -130,14 +162,14 @@ func (gs *GlobalSyncExec) DrainUntil(fn func(ev Event) bool, excl bool) error {
 			return gs.ctx.Err()
 		}
 		// includes popping of the event, so we can handle Drain() calls by onEvent() execution
-		ev, stopIncl, stopExcl := iter()
+		ev, seq, stopIncl, stopExcl := iter()
 		if stopExcl {
 			return nil
 		}
 		if ev.Event == nil {
 			return io.EOF
 		}
-		gs.processEvent(ev)
+		gs.processEvent(ev, seq)
 		if stopIncl {
 			return nil
 		}
@@ -145,15 +177,26 @@ func (gs *GlobalSyncExec) DrainUntil(fn func(ev Event) bool, excl bool) error {
 }
 
 type globalHandle struct {
-	g atomic.Pointer[GlobalSyncExec]
-	d Executable
+	g    atomic.Pointer[GlobalSyncExec]
+	d    Executable
+	name string
+	// processed is the sequence number of the last event that was processed,
+	// to introspect the number of events the executable has yet to process.
+	processed atomic.Uint64
 }
 
-func (gh *globalHandle) onEvent(ev AnnotatedEvent) {
+func (gh *globalHandle) onEvent(ev AnnotatedEvent, seq uint64) {
 	if gh.g.Load() == nil { // don't process more events while we are being removed
 		return
 	}
 	gh.d.RunEvent(ev)
+	// Events may be processed out of order, when the processing of an event drains the events after it.
+	for {
+		prev := gh.processed.Load()
+		if prev >= seq || gh.processed.CompareAndSwap(prev, seq) {
+			return
+		}
+	}
 }
 
 func (gh *globalHandle) leave() {
@@ -156,3 +156,45 @@ func TestDrainUntilExcl(t *testing.T) {
 	require.NoError(t, exec.Drain())
 	require.Equal(t, 4, count, "Done")
 }
+
+type namedExecutable struct {
+	ExecutableFunc
+	name string
+}
+
+func (n namedExecutable) Name() string {
+	return n.name
+}
+
+func TestQueueDepth(t *testing.T) {
+	exec := NewGlobalSynchronous(context.Background())
+	var depthsA, depthsB []map[string]int
+	leaveA := exec.Add(namedExecutable{name: "a", ExecutableFunc: func(ev AnnotatedEvent) {
+		depthsA = append(depthsA, exec.QueueDepth())
+	}}, nil)
+	defer leaveA()
+	leaveB := exec.Add(namedExecutable{name: "b", ExecutableFunc: func(ev AnnotatedEvent) {
+		depthsB = append(depthsB, exec.QueueDepth())
+	}}, nil)
+	defer leaveB()
+
+	require.Equal(t, map[string]int{"a": 0, "b": 0}, exec.QueueDepth())
+	require.NoError(t, exec.Enqueue(AnnotatedEvent{Event: TestEvent{}}))
+	require.NoError(t, exec.Enqueue(AnnotatedEvent{Event: FooEvent{}}))
+	require.NoError(t, exec.Enqueue(AnnotatedEvent{Event: TestEvent{}}))
+	require.Equal(t, map[string]int{"a": 3, "b": 3}, exec.QueueDepth())
+
+	require.NoError(t, exec.DrainUntil(Is[FooEvent], false))
+	require.Equal(t, map[string]int{"a": 1, "b": 1}, exec.QueueDepth())
+	// While a deriver processes an event, the event remains pending for it and the derivers after it.
+	require.Equal(t, []map[string]int{{"a": 3, "b": 3}, {"a": 2, "b": 2}}, depthsA)
+	require.Equal(t, []map[string]int{{"a": 2, "b": 3}, {"a": 1, "b": 2}}, depthsB)
+
+	// A deriver that is added later does not have to process the events that were popped before.
+	leaveC := exec.Add(ExecutableFunc(func(ev AnnotatedEvent) {}), nil)
+	defer leaveC()
+	require.Equal(t, map[string]int{"a": 1, "b": 1, "event.ExecutableFunc": 1}, exec.QueueDepth())
+
+	require.NoError(t, exec.Drain())
+	require.Equal(t, map[string]int{"a": 0, "b": 0, "event.ExecutableFunc": 0}, exec.QueueDepth())
+}
@@ -64,6 +64,11 @@ func (r *systemActor) Emit(ev Event) {
 	r.sys.emit(r.name, r.currentEvent, ev)
 }
 
+// Name returns the name the actor is registered with.
+func (r *systemActor) Name() string {
+	return r.name
+}
+
 // RunEvent is called by the events executor.
 // While different things may execute in parallel, only one event is executed per entry at a time.
 func (r *systemActor) RunEvent(ev AnnotatedEvent) {
@@ -0,0 +1,120 @@
+package event
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// otelEmitsCacheSize bounds how many emitted events the OTelTracer remembers, to parent the derivers of the events.
+// This is much larger than the number of events the executor may queue up.
+const otelEmitsCacheSize = 10_000
+
+type otelDeriv struct {
+	name   string
+	ev     AnnotatedEvent
+	parent trace.SpanContext
+	start  time.Time
+	span   trace.Span
+}
+
+// OTelTracer exports event processing as OpenTelemetry spans:
+// every deriver that processes an event with effect gets a span,
+// which is parented by the span of the deriver that emitted the event.
+// Events emitted outside of event processing are recorded as root spans.
+// Derivers that process an event without any effect are omitted, to not overwhelm the trace with pass-through work.
+type OTelTracer struct {
+	tracer trace.Tracer
+
+	l      sync.Mutex
+	derivs map[uint64]*otelDeriv
+	emits  *lru.Cache[uint64, trace.SpanContext]
+}
+
+var _ Tracer = (*OTelTracer)(nil)
+
+func NewOTelTracer(provider trace.TracerProvider) *OTelTracer {
+	emits, _ := lru.New[uint64, trace.SpanContext](otelEmitsCacheSize) // only errors on invalid size
+	return &OTelTracer{
+		tracer: provider.Tracer("github.com/ethereum-optimism/optimism/op-node/rollup/event"),
+		derivs: make(map[uint64]*otelDeriv),
+		emits:  emits,
+	}
+}
+
+// span lazily starts the span of the deriver processing, since most processing is without effect, and not traced.
+func (ot *OTelTracer) span(d *otelDeriv) trace.Span {
+	if d.span == nil {
+		ctx := context.Background()
+		if d.parent.IsValid() {
+			ctx = trace.ContextWithSpanContext(ctx, d.parent)
+		}
+		_, d.span = ot.tracer.Start(ctx, d.name+": "+d.ev.Event.String(),
+			trace.WithTimestamp(d.start),
+			trace.WithAttributes(
+				attribute.String("deriver", d.name),
+				attribute.String("event", d.ev.Event.String()),
+				attribute.Int64("emit_context", int64(d.ev.EmitContext)),
+			))
+	}
+	return d.span
+}
+
+func (ot *OTelTracer) OnDeriveStart(name string, ev AnnotatedEvent, derivContext uint64, startTime time.Time) {
+	ot.l.Lock()
+	defer ot.l.Unlock()
+	parent, _ := ot.emits.Get(ev.EmitContext)
+	ot.derivs[derivContext] = &otelDeriv{name: name, ev: ev, parent: parent, start: startTime}
+}
+
+func (ot *OTelTracer) OnDeriveEnd(name string, ev AnnotatedEvent, derivContext uint64, startTime time.Time, duration time.Duration, effect bool) {
+	ot.l.Lock()
+	defer ot.l.Unlock()
+	d, ok := ot.derivs[derivContext]
+	if !ok {
+		return
+	}
+	delete(ot.derivs, derivContext)
+	if !effect && d.span == nil {
+		return
+	}
+	span := ot.span(d)
+	span.SetAttributes(attribute.Bool("effect", effect))
+	span.End(trace.WithTimestamp(startTime.Add(duration)))
+}
+
+func (ot *OTelTracer) OnRateLimited(name string, derivContext uint64) {
+	ot.l.Lock()
+	defer ot.l.Unlock()
+	if d, ok := ot.derivs[derivContext]; ok {
+		ot.span(d).AddEvent("rate-limited", trace.WithAttributes(attribute.String("emitter", name)))
+	}
+}
+
+func (ot *OTelTracer) OnEmit(name string, ev AnnotatedEvent, derivContext uint64, emitTime time.Time) {
+	ot.l.Lock()
+	defer ot.l.Unlock()
+	if d, ok := ot.derivs[derivContext]; ok {
+		span := ot.span(d)
+		span.AddEvent("emit", trace.WithTimestamp(emitTime), trace.WithAttributes(
+			attribute.String("event", ev.Event.String()),
+			attribute.Int64("emit_context", int64(ev.EmitContext)),
+		))
+		ot.emits.Add(ev.EmitContext, span.SpanContext())
+		return
+	}
+	// Not emitted during event processing: start a new trace with this emission.
+	_, span := ot.tracer.Start(context.Background(), name+": emit "+ev.Event.String(),
+		trace.WithTimestamp(emitTime),
+		trace.WithAttributes(
+			attribute.String("emitter", name),
+			attribute.String("event", ev.Event.String()),
+			attribute.Int64("emit_context", int64(ev.EmitContext)),
+		))
+	span.End(trace.WithTimestamp(emitTime))
+	ot.emits.Add(ev.EmitContext, span.SpanContext())
+}
@@ -0,0 +1,60 @@
+package event
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestOTelTracer(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	ex := NewGlobalSynchronous(context.Background())
+	sys := NewSystem(logger, ex)
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	sys.AddTracer(NewOTelTracer(provider))
+
+	var fooEmitter Emitter
+	fooEmitter = sys.Register("foo", DeriverFunc(func(ev Event) bool {
+		switch ev.(type) {
+		case TestEvent:
+			fooEmitter.Emit(FooEvent{})
+			return true
+		}
+		return false
+	}), DefaultRegisterOpts())
+	sys.Register("bar", DeriverFunc(func(ev Event) bool {
+		switch ev.(type) {
+		case FooEvent:
+			return true
+		}
+		return false
+	}), DefaultRegisterOpts())
+	// never has any effect, and should not be traced
+	sys.Register("idle", DeriverFunc(func(ev Event) bool {
+		return false
+	}), DefaultRegisterOpts())
+	em := sys.Register("external", nil, DefaultRegisterOpts())
+
+	em.Emit(TestEvent{})
+	require.NoError(t, ex.Drain())
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	root, foo, bar := spans[0], spans[1], spans[2]
+	require.Equal(t, "external: emit "+TestEvent{}.String(), root.Name())
+	require.Equal(t, "foo: "+TestEvent{}.String(), foo.Name())
+	require.Equal(t, "bar: "+FooEvent{}.String(), bar.Name())
+
+	require.Equal(t, root.SpanContext().SpanID(), foo.Parent().SpanID(), "foo derives the externally emitted event")
+	require.Equal(t, foo.SpanContext().SpanID(), bar.Parent().SpanID(), "bar derives the event emitted by foo")
+	require.Equal(t, root.SpanContext().TraceID(), bar.SpanContext().TraceID(), "single trace")
+	require.Len(t, foo.Events(), 1, "emission of the FooEvent")
+}
@@ -6,9 +6,11 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -24,11 +26,14 @@ type InteropBackend interface {
 
 type L2Source interface {
 	L2BlockRefByNumber(context.Context, uint64) (eth.L2BlockRef, error)
+	PayloadByHash(context.Context, common.Hash) (*eth.ExecutionPayloadEnvelope, error)
 }
 
 // InteropDeriver watches for update events (either real changes to block safety,
 // or updates published upon request), checks if there is some local data to cross-verify,
 // and then checks with the interop-backend, to try to promote to cross-verified safety.
+// Blocks with invalid executing messages are invalidated: unsafe blocks are dropped,
+// and local-safe blocks are replaced with a deposits-only block.
 type InteropDeriver struct {
 	log log.Logger
 	cfg *rollup.Config
@@ -105,6 +110,10 @@ func (d *InteropDeriver) OnEvent(ev event.Event) bool {
 			// Hold off on promoting higher than cross-unsafe,
 			// this will happen once we verify it to be local-safe first.
 			d.emitter.Emit(engine.PromoteCrossUnsafeEvent{Ref: candidate})
+		case types.Invalid:
+			// The sequencer, or the L1 derivation if the block is local-safe, provides the replacement.
+			d.log.Warn("Unsafe block has invalid executing messages, dropping it", "block", candidate)
+			d.emitter.Emit(engine.InvalidateBlockEvent{Invalidated: candidate, Parent: x.CrossUnsafe})
 		}
 	case engine.LocalSafeUpdateEvent:
 		d.derivedFrom[x.Ref.Hash] = x.DerivedFrom
@@ -152,6 +161,24 @@ func (d *InteropDeriver) OnEvent(ev event.Event) bool {
 			d.emitter.Emit(engine.PromoteFinalizedEvent{
 				Ref: candidate,
 			})
+		case types.Invalid:
+			envelope, err := d.l2.PayloadByHash(ctx, candidate.Hash)
+			if err != nil {
+				d.log.Warn("Failed to fetch invalid local-safe block to replace", "block", candidate, "err", err)
+				break
+			}
+			d.log.Warn("Local-safe block has invalid executing messages, replacing it with a deposits-only block", "block", candidate)
+			delete(d.derivedFrom, candidate.Hash)
+			d.emitter.Emit(engine.InvalidateBlockEvent{
+				Invalidated: candidate,
+				Parent:      x.CrossSafe,
+				Replacement: &derive.AttributesWithParent{
+					Attributes:   DepositsOnlyAttributes(envelope),
+					Parent:       x.CrossSafe,
+					IsLastInSpan: true,
+					DerivedFrom:  derivedFrom,
+				},
+			})
 		}
 	// no reorg support yet; the safe L2 head will finalize eventually, no exceptions
 	default:
@@ -159,3 +186,26 @@ func (d *InteropDeriver) OnEvent(ev event.Event) bool {
 	}
 	return true
 }
+
+// DepositsOnlyAttributes returns the attributes to replace the given block with a block with the same deposits,
+// and without any of the other transactions.
+func DepositsOnlyAttributes(envelope *eth.ExecutionPayloadEnvelope) *eth.PayloadAttributes {
+	payload := envelope.ExecutionPayload
+	var deposits []eth.Data
+	for _, tx := range payload.Transactions {
+		if len(tx) > 0 && tx[0] == gethtypes.DepositTxType {
+			deposits = append(deposits, tx)
+		}
+	}
+	gasLimit := payload.GasLimit
+	return &eth.PayloadAttributes{
+		Timestamp:             payload.Timestamp,
+		PrevRandao:            payload.PrevRandao,
+		SuggestedFeeRecipient: payload.FeeRecipient,
+		Withdrawals:           payload.Withdrawals,
+		ParentBeaconBlockRoot: envelope.ParentBeaconBlockRoot,
+		Transactions:          deposits,
+		NoTxPool:              true,
+		GasLimit:              &gasLimit,
+	}
+}
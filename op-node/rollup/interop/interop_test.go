@@ -8,10 +8,14 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
 	supervisortypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
@@ -133,4 +137,90 @@ func TestInteropDeriver(t *testing.T) {
 		emitter.AssertExpectations(t)
 		l2Source.AssertExpectations(t)
 	})
+	t.Run("invalidate unsafe", func(t *testing.T) {
+		crossUnsafe := testutils.RandomL2BlockRef(rng)
+		firstLocalUnsafe := testutils.NextRandomL2Ref(rng, 2, crossUnsafe, crossUnsafe.L1Origin)
+		lastLocalUnsafe := testutils.NextRandomL2Ref(rng, 2, firstLocalUnsafe, firstLocalUnsafe.L1Origin)
+		interopBackend.ExpectCheckBlock(
+			chainID, firstLocalUnsafe.Number, supervisortypes.Invalid, nil)
+		emitter.ExpectOnce(engine.InvalidateBlockEvent{
+			Invalidated: firstLocalUnsafe,
+			Parent:      crossUnsafe,
+		})
+		l2Source.ExpectL2BlockRefByNumber(firstLocalUnsafe.Number, firstLocalUnsafe, nil)
+		interopDeriver.OnEvent(engine.CrossUnsafeUpdateEvent{
+			CrossUnsafe: crossUnsafe,
+			LocalUnsafe: lastLocalUnsafe,
+		})
+		interopBackend.AssertExpectations(t)
+		emitter.AssertExpectations(t)
+		l2Source.AssertExpectations(t)
+	})
+	t.Run("replace invalid local-safe", func(t *testing.T) {
+		derivedFrom := testutils.RandomBlockRef(rng)
+		crossSafe := testutils.RandomL2BlockRef(rng)
+		firstLocalSafe := testutils.NextRandomL2Ref(rng, 2, crossSafe, crossSafe.L1Origin)
+		lastLocalSafe := testutils.NextRandomL2Ref(rng, 2, firstLocalSafe, firstLocalSafe.L1Origin)
+		emitter.ExpectOnce(engine.RequestCrossSafeEvent{})
+		interopDeriver.OnEvent(engine.LocalSafeUpdateEvent{
+			Ref:         firstLocalSafe,
+			DerivedFrom: derivedFrom,
+		})
+		envelope := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{
+			BlockHash:    firstLocalSafe.Hash,
+			Timestamp:    eth.Uint64Quantity(firstLocalSafe.Time),
+			Transactions: []eth.Data{{types.DepositTxType, 0x01}, {types.DynamicFeeTxType, 0x02}},
+		}}
+		interopBackend.ExpectCheckBlock(
+			chainID, firstLocalSafe.Number, supervisortypes.Invalid, nil)
+		l2Source.ExpectL2BlockRefByNumber(firstLocalSafe.Number, firstLocalSafe, nil)
+		l2Source.ExpectPayloadByHash(firstLocalSafe.Hash, envelope, nil)
+		emitter.ExpectOnce(engine.InvalidateBlockEvent{
+			Invalidated: firstLocalSafe,
+			Parent:      crossSafe,
+			Replacement: &derive.AttributesWithParent{
+				Attributes:   DepositsOnlyAttributes(envelope),
+				Parent:       crossSafe,
+				IsLastInSpan: true,
+				DerivedFrom:  derivedFrom,
+			},
+		})
+		interopDeriver.OnEvent(engine.CrossSafeUpdateEvent{
+			CrossSafe: crossSafe,
+			LocalSafe: lastLocalSafe,
+		})
+		require.NotContains(t, interopDeriver.derivedFrom, firstLocalSafe.Hash)
+		interopBackend.AssertExpectations(t)
+		emitter.AssertExpectations(t)
+		l2Source.AssertExpectations(t)
+	})
+}
+
+func TestDepositsOnlyAttributes(t *testing.T) {
+	beaconRoot := common.Hash{0xbb}
+	withdrawals := &types.Withdrawals{}
+	envelope := &eth.ExecutionPayloadEnvelope{
+		ParentBeaconBlockRoot: &beaconRoot,
+		ExecutionPayload: &eth.ExecutionPayload{
+			Timestamp:    1234,
+			PrevRandao:   eth.Bytes32{0xaa},
+			FeeRecipient: common.Address{0xcc},
+			GasLimit:     30_000_000,
+			Withdrawals:  withdrawals,
+			Transactions: []eth.Data{
+				{types.DepositTxType, 0x01},
+				{types.DynamicFeeTxType, 0x02},
+				{types.DepositTxType, 0x03},
+			},
+		},
+	}
+	attrs := DepositsOnlyAttributes(envelope)
+	require.Equal(t, eth.Uint64Quantity(1234), attrs.Timestamp)
+	require.Equal(t, eth.Bytes32{0xaa}, attrs.PrevRandao)
+	require.Equal(t, common.Address{0xcc}, attrs.SuggestedFeeRecipient)
+	require.Equal(t, eth.Uint64Quantity(30_000_000), *attrs.GasLimit)
+	require.Equal(t, withdrawals, attrs.Withdrawals)
+	require.Equal(t, &beaconRoot, attrs.ParentBeaconBlockRoot)
+	require.True(t, attrs.NoTxPool)
+	require.Equal(t, []eth.Data{{types.DepositTxType, 0x01}, {types.DepositTxType, 0x03}}, attrs.Transactions)
 }
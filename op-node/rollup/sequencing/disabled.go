@@ -40,6 +40,14 @@ func (ds DisabledSequencer) Stop(ctx context.Context) (hash common.Hash, err err
 	return common.Hash{}, ErrSequencerNotEnabled
 }
 
+func (ds DisabledSequencer) StartAt(ctx context.Context, target HandoverTarget) (hash common.Hash, err error) {
+	return common.Hash{}, ErrSequencerNotEnabled
+}
+
+func (ds DisabledSequencer) StopAt(ctx context.Context, target HandoverTarget) (hash common.Hash, err error) {
+	return common.Hash{}, ErrSequencerNotEnabled
+}
+
 func (ds DisabledSequencer) SetMaxSafeLag(ctx context.Context, v uint64) error {
 	return ErrSequencerNotEnabled
 }
@@ -0,0 +1,71 @@
+package sequencing
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	ErrHandoverAlreadyScheduled = errors.New("sequencer handover already scheduled")
+	ErrHandoverTargetPassed     = errors.New("sequencer handover target already passed")
+)
+
+// HandoverTarget identifies the L2 block at which sequencing is handed over between sequencers:
+// the handover block is the last block built by the stopping sequencer,
+// and the block the starting sequencer builds on top of.
+// If Number is set, the handover block is the block with that number.
+// Otherwise, the handover block is the last block with a timestamp at or before Time.
+type HandoverTarget struct {
+	Number uint64
+	Time   uint64
+}
+
+func (t HandoverTarget) Check() error {
+	if t.Number == 0 && t.Time == 0 {
+		return errors.New("handover target must specify a block number or timestamp")
+	}
+	if t.Number != 0 && t.Time != 0 {
+		return errors.New("handover target must not specify both a block number and timestamp")
+	}
+	return nil
+}
+
+func (t HandoverTarget) String() string {
+	if t.Number != 0 {
+		return fmt.Sprintf("block %d", t.Number)
+	}
+	return fmt.Sprintf("time %d", t.Time)
+}
+
+// exceededBy returns true if the block with the given number and timestamp comes after the handover block.
+func (t HandoverTarget) exceededBy(number uint64, timestamp uint64) bool {
+	if t.Number != 0 {
+		return number > t.Number
+	}
+	return timestamp > t.Time
+}
+
+// isHandover returns true if the given block is the handover block.
+func (t HandoverTarget) isHandover(ref eth.L2BlockRef, blockTime uint64) bool {
+	return !t.exceededBy(ref.Number, ref.Time) && t.exceededBy(ref.Number+1, ref.Time+blockTime)
+}
+
+type handoverResult struct {
+	head common.Hash
+	err  error
+}
+
+// scheduledHandover is a pending StopAt or StartAt request, completed from the sequencer event processing.
+type scheduledHandover struct {
+	target HandoverTarget
+	// result is buffered, so the event processing never blocks on a caller that stopped waiting.
+	result chan handoverResult
+}
+
+func newScheduledHandover(target HandoverTarget) *scheduledHandover {
+	return &scheduledHandover{target: target, result: make(chan handoverResult, 1)}
+}
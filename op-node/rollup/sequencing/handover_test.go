@@ -0,0 +1,228 @@
+package sequencing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestHandoverTarget(t *testing.T) {
+	require.Error(t, HandoverTarget{}.Check())
+	require.Error(t, HandoverTarget{Number: 1, Time: 1}.Check())
+	require.NoError(t, HandoverTarget{Number: 1}.Check())
+	require.NoError(t, HandoverTarget{Time: 1}.Check())
+
+	byNumber := HandoverTarget{Number: 10}
+	require.True(t, byNumber.isHandover(eth.L2BlockRef{Number: 10}, 2))
+	require.False(t, byNumber.isHandover(eth.L2BlockRef{Number: 9}, 2))
+	require.False(t, byNumber.isHandover(eth.L2BlockRef{Number: 11}, 2))
+	require.True(t, byNumber.exceededBy(11, 0))
+	require.False(t, byNumber.exceededBy(10, 0))
+
+	byTime := HandoverTarget{Time: 1001}
+	require.True(t, byTime.isHandover(eth.L2BlockRef{Time: 1000}, 2), "last block at or before the target time")
+	require.False(t, byTime.isHandover(eth.L2BlockRef{Time: 998}, 2))
+	require.True(t, HandoverTarget{Time: 1000}.isHandover(eth.L2BlockRef{Time: 1000}, 2))
+	require.False(t, HandoverTarget{Time: 1000}.isHandover(eth.L2BlockRef{Time: 1002}, 2))
+	require.True(t, byTime.exceededBy(0, 1002))
+	require.False(t, byTime.exceededBy(0, 1001))
+}
+
+func setupHandoverTest(t *testing.T, active bool) (*Sequencer, *sequencerTestDeps, *testutils.MockEmitter, eth.L2BlockRef) {
+	logger := testlog.Logger(t, log.LevelError)
+	seq, deps := createSequencer(logger)
+	testClock := clock.NewSimpleClock()
+	seq.timeNow = testClock.Now
+	testClock.SetTime(30000)
+	emitter := &testutils.MockEmitter{}
+	seq.AttachEmitter(emitter)
+	deps.conductor.leader = true
+
+	emitter.ExpectOnce(engine.ForkchoiceRequestEvent{})
+	require.NoError(t, seq.Init(context.Background(), false))
+	emitter.AssertExpectations(t)
+	head := eth.L2BlockRef{
+		Hash:     common.Hash{0x22},
+		Number:   100,
+		L1Origin: eth.BlockID{Hash: common.Hash{0x11, 0xa}, Number: 1000},
+		Time:     uint64(testClock.Now().Unix()),
+	}
+	seq.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: head})
+	// pretend the head was sealed by this sequencer, so stopping doesn't wait for it
+	seq.latestSealed = head
+	if active {
+		require.NoError(t, seq.Start(context.Background(), head.Hash))
+	}
+	deps.l1OriginSelector.l1OriginFn = func(l2Head eth.L2BlockRef) (eth.L1BlockRef, error) {
+		return eth.L1BlockRef{Hash: l2Head.L1Origin.Hash, Number: l2Head.L1Origin.Number, Time: head.Time}, nil
+	}
+	return seq, deps, emitter, head
+}
+
+func nextBlock(parent eth.L2BlockRef) eth.L2BlockRef {
+	return eth.L2BlockRef{
+		Hash:       common.Hash{byte(parent.Number + 1)},
+		Number:     parent.Number + 1,
+		ParentHash: parent.Hash,
+		Time:       parent.Time + 2,
+		L1Origin:   parent.L1Origin,
+	}
+}
+
+type handoverResponse struct {
+	head common.Hash
+	err  error
+}
+
+func asyncHandover(t *testing.T, seq *Sequencer, scheduled **scheduledHandover, fn func() (common.Hash, error)) chan handoverResponse {
+	out := make(chan handoverResponse, 1)
+	go func() {
+		head, err := fn()
+		out <- handoverResponse{head: head, err: err}
+	}()
+	require.Eventually(t, func() bool {
+		seq.l.Lock()
+		defer seq.l.Unlock()
+		return *scheduled != nil
+	}, 5*time.Second, 10*time.Millisecond, "handover must be scheduled")
+	return out
+}
+
+func TestSequencer_StopAt(t *testing.T) {
+	t.Run("block", func(t *testing.T) {
+		seq, deps, emitter, head := setupHandoverTest(t, true)
+		result := asyncHandover(t, seq, &seq.scheduledStop, func() (common.Hash, error) {
+			return seq.StopAt(context.Background(), HandoverTarget{Number: head.Number + 1})
+		})
+
+		// builds the handover block
+		emitter.ExpectOnceType("BuildStartEvent")
+		seq.OnEvent(SequencerActionEvent{})
+		emitter.AssertExpectations(t)
+		require.True(t, seq.Active())
+
+		// and stops before building the block after it
+		handover := nextBlock(head)
+		seq.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: handover})
+		seq.OnEvent(SequencerActionEvent{})
+		emitter.AssertExpectations(t)
+		res := <-result
+		require.NoError(t, res.err)
+		require.Equal(t, handover.Hash, res.head)
+		require.False(t, seq.Active())
+		require.False(t, deps.seqState.active, "sequencer signaled it is no longer active")
+	})
+
+	t.Run("time", func(t *testing.T) {
+		seq, _, emitter, head := setupHandoverTest(t, true)
+		result := asyncHandover(t, seq, &seq.scheduledStop, func() (common.Hash, error) {
+			return seq.StopAt(context.Background(), HandoverTarget{Time: head.Time + 1})
+		})
+		seq.OnEvent(SequencerActionEvent{})
+		emitter.AssertExpectations(t)
+		res := <-result
+		require.NoError(t, res.err)
+		require.Equal(t, head.Hash, res.head)
+		require.False(t, seq.Active())
+	})
+
+	t.Run("passed", func(t *testing.T) {
+		seq, _, _, head := setupHandoverTest(t, true)
+		_, err := seq.StopAt(context.Background(), HandoverTarget{Number: head.Number - 1})
+		require.ErrorIs(t, err, ErrHandoverTargetPassed)
+		require.True(t, seq.Active())
+	})
+
+	t.Run("inactive", func(t *testing.T) {
+		seq, _, _, head := setupHandoverTest(t, false)
+		_, err := seq.StopAt(context.Background(), HandoverTarget{Number: head.Number + 1})
+		require.ErrorIs(t, err, ErrSequencerAlreadyStopped)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		seq, _, _, head := setupHandoverTest(t, true)
+		ctx, cancel := context.WithCancel(context.Background())
+		result := asyncHandover(t, seq, &seq.scheduledStop, func() (common.Hash, error) {
+			return seq.StopAt(ctx, HandoverTarget{Number: head.Number + 10})
+		})
+		cancel()
+		require.ErrorIs(t, (<-result).err, context.Canceled)
+		require.Nil(t, seq.scheduledStop)
+		require.True(t, seq.Active())
+	})
+
+	t.Run("manual stop", func(t *testing.T) {
+		seq, _, _, head := setupHandoverTest(t, true)
+		result := asyncHandover(t, seq, &seq.scheduledStop, func() (common.Hash, error) {
+			return seq.StopAt(context.Background(), HandoverTarget{Number: head.Number + 10})
+		})
+		_, err := seq.Stop(context.Background())
+		require.NoError(t, err)
+		require.ErrorIs(t, (<-result).err, ErrSequencerAlreadyStopped)
+	})
+}
+
+func TestSequencer_StartAt(t *testing.T) {
+	t.Run("block", func(t *testing.T) {
+		seq, deps, _, head := setupHandoverTest(t, false)
+		result := asyncHandover(t, seq, &seq.scheduledStart, func() (common.Hash, error) {
+			return seq.StartAt(context.Background(), HandoverTarget{Number: head.Number + 1})
+		})
+		handover := nextBlock(head)
+		seq.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: handover})
+		res := <-result
+		require.NoError(t, res.err)
+		require.Equal(t, handover.Hash, res.head)
+		require.True(t, seq.Active())
+		require.True(t, deps.seqState.active, "sequencer signaled it is active")
+		_, ok := seq.NextAction()
+		require.True(t, ok, "ready to build on top of the handover block")
+	})
+
+	t.Run("time", func(t *testing.T) {
+		seq, _, _, head := setupHandoverTest(t, false)
+		result := asyncHandover(t, seq, &seq.scheduledStart, func() (common.Hash, error) {
+			return seq.StartAt(context.Background(), HandoverTarget{Time: head.Time + 3})
+		})
+		handover := nextBlock(head)
+		seq.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: handover})
+		res := <-result
+		require.NoError(t, res.err)
+		require.Equal(t, handover.Hash, res.head)
+		require.True(t, seq.Active())
+	})
+
+	t.Run("already at handover", func(t *testing.T) {
+		seq, _, _, head := setupHandoverTest(t, false)
+		hash, err := seq.StartAt(context.Background(), HandoverTarget{Number: head.Number})
+		require.NoError(t, err)
+		require.Equal(t, head.Hash, hash)
+		require.True(t, seq.Active())
+	})
+
+	t.Run("passed", func(t *testing.T) {
+		seq, _, _, head := setupHandoverTest(t, false)
+		_, err := seq.StartAt(context.Background(), HandoverTarget{Number: head.Number - 1})
+		require.ErrorIs(t, err, ErrHandoverTargetPassed)
+		require.False(t, seq.Active())
+	})
+
+	t.Run("not leader", func(t *testing.T) {
+		seq, deps, _, head := setupHandoverTest(t, false)
+		deps.conductor.leader = false
+		_, err := seq.StartAt(context.Background(), HandoverTarget{Number: head.Number})
+		require.ErrorContains(t, err, "not the leader")
+		require.False(t, seq.Active())
+	})
+}
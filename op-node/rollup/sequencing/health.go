@@ -0,0 +1,111 @@
+package sequencing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	ErrL1HeadStale = errors.New("L1 head is stale")
+	ErrNotLeader   = errors.New("sequencer is not the leader")
+)
+
+// HealthCheck is consulted by the sequencer before each block-building attempt.
+// A failing check prevents the block from being built, to avoid producing blocks
+// that would have to be reorged out, or that leave gaps, during partial outages.
+type HealthCheck interface {
+	// Name identifies the check in logs and events.
+	Name() string
+	// Check returns an error if the sequencer should not build a new block on top of the given L2 head.
+	Check(ctx context.Context, l2Head eth.L2BlockRef) error
+}
+
+// SequencerUnhealthyEvent is emitted when a health check fails before block-building.
+// Stopped indicates whether the sequencer was stopped as a result.
+type SequencerUnhealthyEvent struct {
+	Check   string
+	Err     error
+	Stopped bool
+}
+
+func (ev SequencerUnhealthyEvent) String() string {
+	return "sequencer-unhealthy"
+}
+
+type elSyncCheck struct {
+	isSyncing func() bool
+}
+
+// NewELSyncCheck creates a health check that fails while the execution engine is syncing.
+func NewELSyncCheck(isSyncing func() bool) HealthCheck {
+	return &elSyncCheck{isSyncing: isSyncing}
+}
+
+func (c *elSyncCheck) Name() string {
+	return "el-sync"
+}
+
+func (c *elSyncCheck) Check(ctx context.Context, l2Head eth.L2BlockRef) error {
+	if c.isSyncing() {
+		return engine.ErrEngineSyncing
+	}
+	return nil
+}
+
+type l1FreshnessCheck struct {
+	l1Head  func() eth.L1BlockRef
+	maxAge  time.Duration
+	timeNow func() time.Time
+}
+
+// NewL1FreshnessCheck creates a health check that fails when the latest known L1 head is older than maxAge.
+// A stale L1 head indicates the L1 data source is unavailable or lagging,
+// and the sequencer would otherwise keep building on an outdated L1 origin.
+func NewL1FreshnessCheck(l1Head func() eth.L1BlockRef, maxAge time.Duration) HealthCheck {
+	return &l1FreshnessCheck{l1Head: l1Head, maxAge: maxAge, timeNow: time.Now}
+}
+
+func (c *l1FreshnessCheck) Name() string {
+	return "l1-freshness"
+}
+
+func (c *l1FreshnessCheck) Check(ctx context.Context, l2Head eth.L2BlockRef) error {
+	head := c.l1Head()
+	if head == (eth.L1BlockRef{}) {
+		return fmt.Errorf("%w: no L1 head known", ErrL1HeadStale)
+	}
+	if age := c.timeNow().Sub(time.Unix(int64(head.Time), 0)); age > c.maxAge {
+		return fmt.Errorf("%w: L1 head %s is %s old, max age is %s", ErrL1HeadStale, head, age, c.maxAge)
+	}
+	return nil
+}
+
+type conductorLeaderCheck struct {
+	conductor conductor.SequencerConductor
+}
+
+// NewConductorLeaderCheck creates a health check that fails when the conductor does not consider this sequencer the leader.
+func NewConductorLeaderCheck(conductor conductor.SequencerConductor) HealthCheck {
+	return &conductorLeaderCheck{conductor: conductor}
+}
+
+func (c *conductorLeaderCheck) Name() string {
+	return "conductor"
+}
+
+func (c *conductorLeaderCheck) Check(ctx context.Context, l2Head eth.L2BlockRef) error {
+	isLeader, err := c.conductor.Leader(ctx)
+	if err != nil {
+		return fmt.Errorf("leader check failed: %w", err)
+	}
+	if !isLeader {
+		return ErrNotLeader
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package sequencing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestELSyncCheck(t *testing.T) {
+	syncing := true
+	check := NewELSyncCheck(func() bool { return syncing })
+	require.ErrorIs(t, check.Check(context.Background(), eth.L2BlockRef{}), engine.ErrEngineSyncing)
+	syncing = false
+	require.NoError(t, check.Check(context.Background(), eth.L2BlockRef{}))
+}
+
+func TestL1FreshnessCheck(t *testing.T) {
+	now := time.Unix(10000, 0)
+	var head eth.L1BlockRef
+	check := NewL1FreshnessCheck(func() eth.L1BlockRef { return head }, time.Minute).(*l1FreshnessCheck)
+	check.timeNow = func() time.Time { return now }
+
+	require.ErrorIs(t, check.Check(context.Background(), eth.L2BlockRef{}), ErrL1HeadStale, "no L1 head known")
+
+	head = eth.L1BlockRef{Number: 1, Time: uint64(now.Add(-time.Minute).Unix())}
+	require.NoError(t, check.Check(context.Background(), eth.L2BlockRef{}))
+
+	head.Time--
+	require.ErrorIs(t, check.Check(context.Background(), eth.L2BlockRef{}), ErrL1HeadStale)
+}
+
+type erroringConductor struct {
+	FakeConductor
+	err error
+}
+
+func (c *erroringConductor) Leader(ctx context.Context) (bool, error) {
+	return false, c.err
+}
+
+func TestConductorLeaderCheck(t *testing.T) {
+	fake := &FakeConductor{}
+	check := NewConductorLeaderCheck(fake)
+	require.ErrorIs(t, check.Check(context.Background(), eth.L2BlockRef{}), ErrNotLeader)
+	fake.leader = true
+	require.NoError(t, check.Check(context.Background(), eth.L2BlockRef{}))
+
+	rpcErr := errors.New("boom")
+	check = NewConductorLeaderCheck(&erroringConductor{err: rpcErr})
+	require.ErrorIs(t, check.Check(context.Background(), eth.L2BlockRef{}), rpcErr)
+}
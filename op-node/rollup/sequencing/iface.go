@@ -17,6 +17,10 @@ type SequencerIface interface {
 	Init(ctx context.Context, active bool) error
 	Start(ctx context.Context, head common.Hash) error
 	Stop(ctx context.Context) (hash common.Hash, err error)
+	// StartAt starts the sequencer on top of the handover block, once it is reached, and returns its hash.
+	StartAt(ctx context.Context, target HandoverTarget) (hash common.Hash, err error)
+	// StopAt stops the sequencer after building the handover block, and returns its hash.
+	StopAt(ctx context.Context, target HandoverTarget) (hash common.Hash, err error)
 	SetMaxSafeLag(ctx context.Context, v uint64) error
 	OverrideLeader(ctx context.Context) error
 	Close()
@@ -104,6 +104,15 @@ type Sequencer struct {
 
 	metrics Metrics
 
+	// scheduledStop and scheduledStart are pending handovers, completed upon reaching their target.
+	scheduledStop  *scheduledHandover
+	scheduledStart *scheduledHandover
+
+	// healthChecks are consulted before each block-building attempt.
+	healthChecks []HealthCheck
+	// stopOnUnhealthy stops the sequencer when a health check fails, instead of retrying later.
+	stopOnUnhealthy bool
+
 	// timeNow enables sequencer testing to mock the time
 	timeNow func() time.Time
 
@@ -146,6 +155,16 @@ func NewSequencer(driverCtx context.Context, log log.Logger, rollupCfg *rollup.C
 	}
 }
 
+// SetHealthChecks configures the checks to consult before each block-building attempt.
+// If stopOnUnhealthy is true, the sequencer is stopped when a check fails,
+// otherwise block-building is retried after one block worth of time.
+func (d *Sequencer) SetHealthChecks(stopOnUnhealthy bool, checks ...HealthCheck) {
+	d.l.Lock()
+	defer d.l.Unlock()
+	d.healthChecks = checks
+	d.stopOnUnhealthy = stopOnUnhealthy
+}
+
 func (d *Sequencer) AttachEmitter(em event.Emitter) {
 	d.emitter = em
 }
@@ -430,6 +449,7 @@ func (d *Sequencer) onForkchoiceUpdate(x engine.ForkchoiceUpdateEvent) {
 
 	if !d.active.Load() {
 		d.setLatestHead(x.UnsafeL2Head)
+		d.checkScheduledStart()
 		return
 	}
 	// If the safe head has fallen behind by a significant number of blocks, delay creating new blocks
@@ -486,6 +506,21 @@ func (d *Sequencer) startBuildingBlock() {
 		return
 	}
 
+	if s := d.scheduledStop; s != nil && s.target.exceededBy(l2Head.Number+1, l2Head.Time+d.rollupCfg.BlockTime) {
+		d.scheduledStop = nil
+		d.log.Info("Reached scheduled sequencer stop", "target", s.target, "head", l2Head)
+		if err := d.forceStop(); err != nil {
+			s.result <- handoverResult{err: err}
+		} else {
+			s.result <- handoverResult{head: l2Head.Hash}
+		}
+		return
+	}
+
+	if !d.checkHealth(l2Head) {
+		return
+	}
+
 	// Figure out which L1 origin block we're going to be building on top of.
 	l1Origin, err := d.l1OriginSelector.FindL1Origin(ctx, l2Head)
 	if err != nil {
@@ -570,6 +605,34 @@ func (d *Sequencer) startBuildingBlock() {
 	})
 }
 
+// checkHealth runs the health checks, and returns true if block-building on top of the given head may proceed.
+// Upon failure, block-building is either retried after one block worth of time, or the sequencer is stopped.
+func (d *Sequencer) checkHealth(l2Head eth.L2BlockRef) bool {
+	ctx, cancel := context.WithTimeout(d.ctx, time.Second*10)
+	defer cancel()
+	for _, check := range d.healthChecks {
+		err := check.Check(ctx, l2Head)
+		if err == nil {
+			continue
+		}
+		d.metrics.RecordSequencingError()
+		if d.stopOnUnhealthy {
+			d.log.Error("Sequencer health check failed, stopping sequencer", "check", check.Name(), "head", l2Head, "err", err)
+			if err := d.forceStop(); err != nil {
+				d.log.Error("Failed to stop unhealthy sequencer", "err", err)
+			}
+		} else {
+			d.log.Warn("Sequencer health check failed, delaying block-building", "check", check.Name(), "head", l2Head, "err", err)
+			blockTime := time.Duration(d.rollupCfg.BlockTime) * time.Second
+			d.nextAction = d.timeNow().Add(blockTime)
+			d.nextActionOK = d.active.Load()
+		}
+		d.emitter.Emit(SequencerUnhealthyEvent{Check: check.Name(), Err: err, Stopped: d.stopOnUnhealthy})
+		return false
+	}
+	return true
+}
+
 func (d *Sequencer) NextAction() (t time.Time, ok bool) {
 	d.l.Lock()
 	defer d.l.Unlock()
@@ -654,10 +717,148 @@ func (d *Sequencer) forceStart() error {
 	d.nextActionOK = true
 	d.nextAction = d.timeNow()
 	d.active.Store(true)
+	d.cancelScheduled(&d.scheduledStart, ErrSequencerAlreadyStarted)
 	d.log.Info("Sequencer has been started", "next action", d.nextAction)
 	return nil
 }
 
+// forceStop skips all the checks, and just stops the sequencer.
+func (d *Sequencer) forceStop() error {
+	if err := d.listener.SequencerStopped(); err != nil {
+		return fmt.Errorf("failed to notify sequencer-state listener of stop: %w", err)
+	}
+	// Cancel any inflight block building. If we don't cancel this, we can resume sequencing an old block
+	// even if we've received new unsafe heads in the interim, causing us to introduce a re-org.
+	d.latest = BuildingState{} // By wiping this state we cannot continue from it later.
+
+	d.nextActionOK = false
+	d.active.Store(false)
+	d.cancelScheduled(&d.scheduledStop, ErrSequencerAlreadyStopped)
+	d.log.Info("Sequencer has been stopped")
+	return nil
+}
+
+// cancelScheduled fails the given scheduled handover, if any, with the given error.
+func (d *Sequencer) cancelScheduled(scheduled **scheduledHandover, err error) {
+	if s := *scheduled; s != nil {
+		*scheduled = nil
+		d.log.Warn("Cancelled scheduled sequencer handover", "target", s.target, "err", err)
+		s.result <- handoverResult{err: err}
+	}
+}
+
+// checkScheduledStart starts the sequencer if the latest head is the handover block of a scheduled start.
+func (d *Sequencer) checkScheduledStart() {
+	s := d.scheduledStart
+	if s == nil {
+		return
+	}
+	head := d.latestHead
+	if s.target.isHandover(head, d.rollupCfg.BlockTime) {
+		d.scheduledStart = nil
+		d.log.Info("Reached scheduled sequencer start", "target", s.target, "head", head)
+		if err := d.forceStart(); err != nil {
+			s.result <- handoverResult{err: err}
+		} else {
+			s.result <- handoverResult{head: head.Hash}
+		}
+	} else if s.target.exceededBy(head.Number, head.Time) {
+		d.scheduledStart = nil
+		d.log.Warn("Scheduled sequencer start target was passed", "target", s.target, "head", head)
+		s.result <- handoverResult{err: fmt.Errorf("%w: head is %s", ErrHandoverTargetPassed, head)}
+	}
+}
+
+// StopAt schedules the sequencer to stop after building the handover block identified by the target,
+// and waits for the stop to happen. It returns the hash of the handover block.
+// If the context is cancelled before the target is reached, the scheduled stop is cancelled.
+func (d *Sequencer) StopAt(ctx context.Context, target HandoverTarget) (common.Hash, error) {
+	if err := target.Check(); err != nil {
+		return common.Hash{}, err
+	}
+	if err := d.l.LockCtx(ctx); err != nil {
+		return common.Hash{}, err
+	}
+	if !d.active.Load() {
+		d.l.Unlock()
+		return common.Hash{}, ErrSequencerAlreadyStopped
+	}
+	if d.scheduledStop != nil {
+		d.l.Unlock()
+		return common.Hash{}, ErrHandoverAlreadyScheduled
+	}
+	// The next block to be built, or the block currently being built, must not be past the handover block,
+	// unless the head is the handover block itself.
+	next := d.latestHead
+	if d.latest != (BuildingState{}) {
+		next = eth.L2BlockRef{Number: d.latest.Onto.Number + 1, Time: d.latest.Onto.Time + d.rollupCfg.BlockTime}
+	}
+	if target.exceededBy(next.Number, next.Time) {
+		d.l.Unlock()
+		return common.Hash{}, fmt.Errorf("%w: head is %s", ErrHandoverTargetPassed, d.latestHead)
+	}
+	s := newScheduledHandover(target)
+	d.scheduledStop = s
+	d.log.Info("Scheduled sequencer stop", "target", target, "head", d.latestHead)
+	d.l.Unlock()
+	return d.awaitHandover(ctx, s, &d.scheduledStop)
+}
+
+// StartAt schedules the sequencer to start building on top of the handover block identified by the target,
+// once it becomes the unsafe head, and waits for the start to happen. It returns the hash of the handover block.
+// If the context is cancelled before the target is reached, the scheduled start is cancelled.
+func (d *Sequencer) StartAt(ctx context.Context, target HandoverTarget) (common.Hash, error) {
+	if err := target.Check(); err != nil {
+		return common.Hash{}, err
+	}
+	// must be leading to activate
+	if isLeader, err := d.conductor.Leader(ctx); err != nil {
+		return common.Hash{}, fmt.Errorf("sequencer leader check failed: %w", err)
+	} else if !isLeader {
+		return common.Hash{}, errors.New("sequencer is not the leader, aborting")
+	}
+	if err := d.l.LockCtx(ctx); err != nil {
+		return common.Hash{}, err
+	}
+	if d.active.Load() {
+		d.l.Unlock()
+		return common.Hash{}, ErrSequencerAlreadyStarted
+	}
+	if d.scheduledStart != nil {
+		d.l.Unlock()
+		return common.Hash{}, ErrHandoverAlreadyScheduled
+	}
+	s := newScheduledHandover(target)
+	d.scheduledStart = s
+	d.log.Info("Scheduled sequencer start", "target", target, "head", d.latestHead)
+	// The handover block may already be the head.
+	d.checkScheduledStart()
+	d.l.Unlock()
+	return d.awaitHandover(ctx, s, &d.scheduledStart)
+}
+
+// awaitHandover waits for the scheduled handover to complete, and unschedules it if the context is cancelled first.
+func (d *Sequencer) awaitHandover(ctx context.Context, s *scheduledHandover, scheduled **scheduledHandover) (common.Hash, error) {
+	select {
+	case res := <-s.result:
+		return res.head, res.err
+	case <-ctx.Done():
+	}
+	d.l.Lock()
+	defer d.l.Unlock()
+	if *scheduled == s {
+		*scheduled = nil
+		d.log.Info("Cancelled scheduled sequencer handover", "target", s.target)
+	}
+	// The handover may have completed while we were waiting for the lock.
+	select {
+	case res := <-s.result:
+		return res.head, res.err
+	default:
+		return common.Hash{}, ctx.Err()
+	}
+}
+
 func (d *Sequencer) Stop(ctx context.Context) (common.Hash, error) {
 	if err := d.l.LockCtx(ctx); err != nil {
 		return common.Hash{}, err
@@ -689,17 +890,9 @@ func (d *Sequencer) Stop(ctx context.Context) (common.Hash, error) {
 		return common.Hash{}, ErrSequencerAlreadyStopped
 	}
 
-	if err := d.listener.SequencerStopped(); err != nil {
-		return common.Hash{}, fmt.Errorf("failed to notify sequencer-state listener of stop: %w", err)
+	if err := d.forceStop(); err != nil {
+		return common.Hash{}, err
 	}
-
-	// Cancel any inflight block building. If we don't cancel this, we can resume sequencing an old block
-	// even if we've received new unsafe heads in the interim, causing us to introduce a re-org.
-	d.latest = BuildingState{} // By wiping this state we cannot continue from it later.
-
-	d.nextActionOK = false
-	d.active.Store(false)
-	d.log.Info("Sequencer has been stopped")
 	return d.latestHead.Hash, nil
 }
 
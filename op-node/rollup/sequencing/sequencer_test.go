@@ -3,6 +3,7 @@ package sequencing
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"math/rand" // nosemgrep
 	"testing"
 	"time"
@@ -668,3 +669,78 @@ func createSequencer(log log.Logger) (*Sequencer, *sequencerTestDeps) {
 	}
 	return seq, deps
 }
+
+type FakeHealthCheck struct {
+	err error
+}
+
+func (f *FakeHealthCheck) Name() string {
+	return "fake"
+}
+
+func (f *FakeHealthCheck) Check(ctx context.Context, l2Head eth.L2BlockRef) error {
+	return f.err
+}
+
+var _ HealthCheck = (*FakeHealthCheck)(nil)
+
+// TestSequencer_HealthChecks checks that failing health checks prevent block-building,
+// and either delay it or stop the sequencer.
+func TestSequencer_HealthChecks(t *testing.T) {
+	setup := func(t *testing.T, stopOnUnhealthy bool) (*Sequencer, *sequencerTestDeps, *testutils.MockEmitter, *FakeHealthCheck, eth.L2BlockRef) {
+		logger := testlog.Logger(t, log.LevelError)
+		seq, deps := createSequencer(logger)
+		testClock := clock.NewSimpleClock()
+		seq.timeNow = testClock.Now
+		testClock.SetTime(30000)
+		emitter := &testutils.MockEmitter{}
+		seq.AttachEmitter(emitter)
+		deps.conductor.leader = true
+		check := &FakeHealthCheck{err: errors.New("unhealthy")}
+		seq.SetHealthChecks(stopOnUnhealthy, check)
+
+		emitter.ExpectOnce(engine.ForkchoiceRequestEvent{})
+		require.NoError(t, seq.Init(context.Background(), false))
+		head := eth.L2BlockRef{
+			Hash:     common.Hash{0x22},
+			Number:   100,
+			L1Origin: eth.BlockID{Hash: common.Hash{0x11, 0xa}, Number: 1000},
+			Time:     uint64(testClock.Now().Unix()),
+		}
+		seq.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: head})
+		require.NoError(t, seq.Start(context.Background(), head.Hash))
+		emitter.AssertExpectations(t)
+		deps.l1OriginSelector.l1OriginFn = func(l2Head eth.L2BlockRef) (eth.L1BlockRef, error) {
+			return eth.L1BlockRef{Hash: head.L1Origin.Hash, Number: head.L1Origin.Number, Time: head.Time}, nil
+		}
+		return seq, deps, emitter, check, head
+	}
+
+	t.Run("delay", func(t *testing.T) {
+		seq, _, emitter, check, head := setup(t, false)
+		emitter.ExpectOnce(SequencerUnhealthyEvent{Check: "fake", Err: check.err, Stopped: false})
+		seq.OnEvent(SequencerActionEvent{})
+		emitter.AssertExpectations(t)
+		require.True(t, seq.Active(), "sequencer remains active")
+		next, ok := seq.NextAction()
+		require.True(t, ok)
+		require.Equal(t, seq.timeNow().Add(time.Duration(seq.rollupCfg.BlockTime)*time.Second), next, "retry after a block")
+
+		check.err = nil
+		emitter.ExpectOnceType("BuildStartEvent")
+		seq.OnEvent(SequencerActionEvent{})
+		emitter.AssertExpectations(t)
+		require.Equal(t, head, seq.latest.Onto)
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		seq, deps, emitter, check, _ := setup(t, true)
+		emitter.ExpectOnce(SequencerUnhealthyEvent{Check: "fake", Err: check.err, Stopped: true})
+		seq.OnEvent(SequencerActionEvent{})
+		emitter.AssertExpectations(t)
+		require.False(t, seq.Active())
+		require.False(t, deps.seqState.active, "sequencer signaled it is no longer active")
+		_, ok := seq.NextAction()
+		require.False(t, ok)
+	})
+}
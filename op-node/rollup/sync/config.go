@@ -3,6 +3,8 @@ package sync
 import (
 	"fmt"
 	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 type Mode int
@@ -72,4 +74,9 @@ type Config struct {
 	SkipSyncStartCheck bool `json:"skip_sync_start_check"`
 
 	SupportsPostFinalizationELSync bool `json:"supports_post_finalization_elsync"`
+
+	// ELSyncCheckpoint is the block to EL sync to, if any. Instead of following the unsafe blocks of the network,
+	// the execution engine is directed to sync to the checkpoint payload, which is marked as unsafe once reached,
+	// after which the node consolidates the chain up to and beyond the checkpoint. Only applies to EL sync.
+	ELSyncCheckpoint *eth.BlockID `json:"el_sync_checkpoint,omitempty"`
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 )
 
@@ -113,6 +114,12 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		ConductorRpcTimeout: ctx.Duration(flags.ConductorRpcTimeoutFlag.Name),
 
 		AltDA: altda.ReadCLIConfig(ctx),
+
+		EventTracing: node.EventTracingConfig{
+			Log:          ctx.Bool(flags.EventTracingLogFlag.Name),
+			OTLPEndpoint: ctx.String(flags.EventTracingOTLPEndpointFlag.Name),
+			OTLPInsecure: ctx.Bool(flags.EventTracingOTLPInsecureFlag.Name),
+		},
 	}
 
 	if err := cfg.LoadPersisted(log); err != nil {
@@ -182,9 +189,24 @@ func NewL2EndpointConfig(ctx *cli.Context, log log.Logger) (*node.L2EndpointConf
 		}
 	}
 
+	secondarySecret := secret
+	if secondaryFile := strings.TrimSpace(ctx.String(flags.L2EngineSecondaryJWTSecret.Name)); secondaryFile != "" {
+		data, err := os.ReadFile(secondaryFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read secondary jwt secret: %w", err)
+		}
+		jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
+		if len(jwtSecret) != 32 {
+			return nil, fmt.Errorf("invalid jwt secret in path %s, not 32 hex-formatted bytes", secondaryFile)
+		}
+		copy(secondarySecret[:], jwtSecret)
+	}
+
 	return &node.L2EndpointConfig{
-		L2EngineAddr:      l2Addr,
-		L2EngineJWTSecret: secret,
+		L2EngineAddr:               l2Addr,
+		L2EngineJWTSecret:          secret,
+		L2EngineSecondaryAddr:      ctx.String(flags.L2EngineSecondaryAddr.Name),
+		L2EngineSecondaryJWTSecret: secondarySecret,
 	}, nil
 }
 
@@ -203,6 +225,11 @@ func NewDriverConfig(ctx *cli.Context) *driver.Config {
 		SequencerEnabled:    ctx.Bool(flags.SequencerEnabledFlag.Name),
 		SequencerStopped:    ctx.Bool(flags.SequencerStoppedFlag.Name),
 		SequencerMaxSafeLag: ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
+
+		SequencerHealthELSync:       ctx.Bool(flags.SequencerHealthELSyncFlag.Name),
+		SequencerHealthMaxL1HeadAge: ctx.Duration(flags.SequencerHealthMaxL1HeadAgeFlag.Name),
+		SequencerHealthConductor:    ctx.Bool(flags.SequencerHealthConductorFlag.Name),
+		SequencerStopOnUnhealthy:    ctx.Bool(flags.SequencerStopOnUnhealthyFlag.Name),
 	}
 }
 
@@ -216,7 +243,9 @@ func NewRollupConfigFromCLI(log log.Logger, ctx *cli.Context) (*rollup.Config, e
 	if err != nil {
 		return nil, err
 	}
-	applyOverrides(ctx, rollupConfig)
+	if err := applyOverrides(ctx, log, rollupConfig); err != nil {
+		return nil, err
+	}
 	return rollupConfig, nil
 }
 
@@ -250,31 +279,38 @@ Conflicting configuration is deprecated, and will stop the op-node from starting
 	return &rollupConfig, nil
 }
 
-func applyOverrides(ctx *cli.Context, rollupConfig *rollup.Config) {
-	if ctx.IsSet(opflags.CanyonOverrideFlagName) {
-		canyon := ctx.Uint64(opflags.CanyonOverrideFlagName)
-		rollupConfig.CanyonTime = &canyon
-	}
-	if ctx.IsSet(opflags.DeltaOverrideFlagName) {
-		delta := ctx.Uint64(opflags.DeltaOverrideFlagName)
-		rollupConfig.DeltaTime = &delta
-	}
-	if ctx.IsSet(opflags.EcotoneOverrideFlagName) {
-		ecotone := ctx.Uint64(opflags.EcotoneOverrideFlagName)
-		rollupConfig.EcotoneTime = &ecotone
-	}
-	if ctx.IsSet(opflags.FjordOverrideFlagName) {
-		fjord := ctx.Uint64(opflags.FjordOverrideFlagName)
-		rollupConfig.FjordTime = &fjord
-	}
-	if ctx.IsSet(opflags.GraniteOverrideFlagName) {
-		granite := ctx.Uint64(opflags.GraniteOverrideFlagName)
-		rollupConfig.GraniteTime = &granite
-	}
-	if ctx.IsSet(opflags.HoloceneOverrideFlagName) {
-		holocene := ctx.Uint64(opflags.HoloceneOverrideFlagName)
-		rollupConfig.HoloceneTime = &holocene
+// applyOverrides patches the fork activation times of the rollup config with the override flags.
+// Overrides are meant for devnets, and refused on known production chains.
+func applyOverrides(ctx *cli.Context, log log.Logger, rollupConfig *rollup.Config) error {
+	overrides := []struct {
+		flag string
+		fork **uint64
+	}{
+		{opflags.CanyonOverrideFlagName, &rollupConfig.CanyonTime},
+		{opflags.DeltaOverrideFlagName, &rollupConfig.DeltaTime},
+		{opflags.EcotoneOverrideFlagName, &rollupConfig.EcotoneTime},
+		{opflags.FjordOverrideFlagName, &rollupConfig.FjordTime},
+		{opflags.GraniteOverrideFlagName, &rollupConfig.GraniteTime},
+		{opflags.HoloceneOverrideFlagName, &rollupConfig.HoloceneTime},
+		{opflags.InteropOverrideFlagName, &rollupConfig.InteropTime},
+	}
+	for _, o := range overrides {
+		if !ctx.IsSet(o.flag) {
+			continue
+		}
+		if rollupConfig.L2ChainID != nil && chaincfg.IsProductionChain(rollupConfig.L2ChainID.Uint64()) {
+			return fmt.Errorf("refusing to apply --%s to known production chain %v: fork overrides are only meant for devnets", o.flag, rollupConfig.L2ChainID)
+		}
+		t := ctx.Uint64(o.flag)
+		prev := "unset"
+		if *o.fork != nil {
+			prev = fmt.Sprintf("%d", **o.fork)
+		}
+		log.Warn("OVERRIDING FORK ACTIVATION TIME, THIS CHAIN DIVERGES FROM ITS ROLLUP CONFIG",
+			"flag", o.flag, "time", t, "previous", prev, "chain_id", rollupConfig.L2ChainID)
+		*o.fork = &t
 	}
+	return nil
 }
 
 func NewSyncConfig(ctx *cli.Context, log log.Logger) (*sync.Config, error) {
@@ -297,6 +333,23 @@ func NewSyncConfig(ctx *cli.Context, log log.Logger) (*sync.Config, error) {
 	if ctx.Bool(flags.L2EngineSyncEnabled.Name) {
 		cfg.SyncMode = sync.ELSync
 	}
+	if ctx.IsSet(flags.SyncCheckpointHashFlag.Name) || ctx.IsSet(flags.SyncCheckpointNumberFlag.Name) {
+		if !ctx.IsSet(flags.SyncCheckpointHashFlag.Name) || !ctx.IsSet(flags.SyncCheckpointNumberFlag.Name) {
+			return nil, errors.New("both the hash and number of the EL sync checkpoint must be set")
+		}
+		if cfg.SyncMode != sync.ELSync {
+			return nil, fmt.Errorf("EL sync checkpoint requires sync mode %s", sync.ELSyncString)
+		}
+		var hash common.Hash
+		if err := hash.UnmarshalText([]byte(ctx.String(flags.SyncCheckpointHashFlag.Name))); err != nil {
+			return nil, fmt.Errorf("invalid EL sync checkpoint hash: %w", err)
+		}
+		number := ctx.Uint64(flags.SyncCheckpointNumberFlag.Name)
+		if number == 0 {
+			return nil, errors.New("EL sync checkpoint cannot be the genesis block")
+		}
+		cfg.ELSyncCheckpoint = &eth.BlockID{Hash: hash, Number: number}
+	}
 
 	return cfg, nil
 }
@@ -0,0 +1,97 @@
+package opnode
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestApplyOverrides(t *testing.T) {
+	const productionChainID = 10
+	const devnetChainID = 901
+	require.True(t, chaincfg.IsProductionChain(productionChainID))
+	require.False(t, chaincfg.IsProductionChain(devnetChainID))
+
+	u64 := func(v uint64) *uint64 { return &v }
+	tests := []struct {
+		name    string
+		chainID *big.Int
+		args    []string
+		expect  func(t *testing.T, cfg *rollup.Config)
+		err     string
+	}{
+		{
+			name:    "NoOverridesOnProductionChain",
+			chainID: big.NewInt(productionChainID),
+			expect: func(t *testing.T, cfg *rollup.Config) {
+				require.Equal(t, u64(10), cfg.GraniteTime)
+				require.Nil(t, cfg.InteropTime)
+			},
+		},
+		{
+			name:    "RefuseOnProductionChain",
+			chainID: big.NewInt(productionChainID),
+			args:    []string{"--" + opflags.GraniteOverrideFlagName, "100"},
+			err:     "refusing to apply --" + opflags.GraniteOverrideFlagName + " to known production chain 10",
+		},
+		{
+			name:    "ApplyOnDevnet",
+			chainID: big.NewInt(devnetChainID),
+			args:    []string{"--" + opflags.GraniteOverrideFlagName, "100", "--" + opflags.HoloceneOverrideFlagName, "200"},
+			expect: func(t *testing.T, cfg *rollup.Config) {
+				require.Equal(t, u64(100), cfg.GraniteTime, "must replace a set fork time")
+				require.Equal(t, u64(200), cfg.HoloceneTime, "must set an unset fork time")
+				require.Equal(t, u64(5), cfg.FjordTime, "must keep the forks that are not overridden")
+			},
+		},
+		{
+			name: "ApplyWithoutChainID",
+			args: []string{"--" + opflags.CanyonOverrideFlagName, "0"},
+			expect: func(t *testing.T, cfg *rollup.Config) {
+				require.Equal(t, u64(0), cfg.CanyonTime)
+			},
+		},
+		{
+			name:    "Interop",
+			chainID: big.NewInt(devnetChainID),
+			args:    []string{"--" + opflags.InteropOverrideFlagName, "300"},
+			expect: func(t *testing.T, cfg *rollup.Config) {
+				require.Equal(t, u64(300), cfg.InteropTime)
+				require.Nil(t, cfg.HoloceneTime)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := &rollup.Config{
+				L2ChainID:   test.chainID,
+				FjordTime:   u64(5),
+				GraniteTime: u64(10),
+			}
+			var applyErr error
+			app := cli.NewApp()
+			app.Flags = opflags.CLIFlags("OP_NODE", "")
+			app.Action = func(ctx *cli.Context) error {
+				applyErr = applyOverrides(ctx, testlog.Logger(t, log.LevelInfo), cfg)
+				return nil
+			}
+			require.NoError(t, app.Run(append([]string{"op-node"}, test.args...)))
+			if test.err != "" {
+				require.ErrorContains(t, applyErr, test.err)
+				require.Equal(t, u64(10), cfg.GraniteTime, "must not apply a refused override")
+				return
+			}
+			require.NoError(t, applyErr)
+			test.expect(t, cfg)
+		})
+	}
+}
@@ -3,12 +3,16 @@ package client
 import (
 	"encoding/binary"
 	"encoding/json"
+	"fmt"
 	"math"
+	"slices"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/client/interop"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
 )
 
@@ -22,10 +26,23 @@ const (
 	// These local keys are only used for custom chains
 	L2ChainConfigLocalIndex
 	RollupConfigLocalIndex
+
+	// These local keys are only used for interop
+	InteropChainsLocalIndex
+	DependencySetLocalIndex
+	L2ClaimChainIDLocalIndex
+
+	// This local key is only used for custom chains, to commit to their chain configuration
+	ChainConfigHashLocalIndex
 )
 
-// CustomChainIDIndicator is used to detect when the program should load custom chain configuration
-const CustomChainIDIndicator = uint64(math.MaxUint64)
+const (
+	// CustomChainIDIndicator is used to detect when the program should load custom chain configuration
+	CustomChainIDIndicator = uint64(math.MaxUint64)
+	// InteropChainIDIndicator is used to detect when the program should load the configuration of
+	// all chains in the dependency set, and validate cross-chain messages
+	InteropChainIDIndicator = uint64(math.MaxUint64 - 1)
+)
 
 type BootInfo struct {
 	L1Head             common.Hash
@@ -36,6 +53,31 @@ type BootInfo struct {
 
 	L2ChainConfig *params.ChainConfig
 	RollupConfig  *rollup.Config
+	// ChainConfigHash is the committed hash of the chain configuration, only set for custom chains
+	ChainConfigHash common.Hash
+
+	// Interop is only set when proving an interop-enabled chain
+	Interop *InteropBootInfo
+}
+
+// InteropChain is the configuration of a chain in the dependency set.
+type InteropChain struct {
+	ChainID uint64 `json:"chainID"`
+	// OutputRoot is the agreed output root of the chain.
+	// Initiating messages must be included in the chain at or before the block of the output root.
+	OutputRoot    common.Hash         `json:"outputRoot"`
+	RollupConfig  *rollup.Config      `json:"rollupConfig"`
+	L2ChainConfig *params.ChainConfig `json:"l2ChainConfig"`
+}
+
+type InteropBootInfo struct {
+	Chains        []InteropChain
+	DependencySet *interop.DependencySet
+}
+
+// CustomChainConfigHash returns the hash of the JSON encoded rollup config and L2 chain config of a custom chain.
+func CustomChainConfigHash(rollupConfig []byte, l2ChainConfig []byte) common.Hash {
+	return crypto.Keccak256Hash(crypto.Keccak256(rollupConfig), crypto.Keccak256(l2ChainConfig))
 }
 
 type oracleClient interface {
@@ -59,17 +101,34 @@ func (br *BootstrapClient) BootInfo() *BootInfo {
 
 	var l2ChainConfig *params.ChainConfig
 	var rollupConfig *rollup.Config
-	if l2ChainID == CustomChainIDIndicator {
+	var chainConfigHash common.Hash
+	var interopInfo *InteropBootInfo
+	if l2ChainID == InteropChainIDIndicator {
+		l2ChainID = binary.BigEndian.Uint64(br.r.Get(L2ClaimChainIDLocalIndex))
+		interopInfo = br.interopBootInfo(l2ChainID, l2OutputRoot)
+		for _, chain := range interopInfo.Chains {
+			if chain.ChainID == l2ChainID {
+				rollupConfig = chain.RollupConfig
+				l2ChainConfig = chain.L2ChainConfig
+			}
+		}
+	} else if l2ChainID == CustomChainIDIndicator {
+		l2ChainConfigData := br.r.Get(L2ChainConfigLocalIndex)
 		l2ChainConfig = new(params.ChainConfig)
-		err := json.Unmarshal(br.r.Get(L2ChainConfigLocalIndex), &l2ChainConfig)
+		err := json.Unmarshal(l2ChainConfigData, &l2ChainConfig)
 		if err != nil {
 			panic("failed to bootstrap l2ChainConfig")
 		}
+		rollupConfigData := br.r.Get(RollupConfigLocalIndex)
 		rollupConfig = new(rollup.Config)
-		err = json.Unmarshal(br.r.Get(RollupConfigLocalIndex), rollupConfig)
+		err = json.Unmarshal(rollupConfigData, rollupConfig)
 		if err != nil {
 			panic("failed to bootstrap rollup config")
 		}
+		chainConfigHash = common.BytesToHash(br.r.Get(ChainConfigHashLocalIndex))
+		if hash := CustomChainConfigHash(rollupConfigData, l2ChainConfigData); hash != chainConfigHash {
+			panic(fmt.Errorf("custom chain config hash %s does not match committed hash %s", hash, chainConfigHash))
+		}
 	} else {
 		var err error
 		rollupConfig, err = chainconfig.RollupConfigByChainID(l2ChainID)
@@ -90,5 +149,53 @@ func (br *BootstrapClient) BootInfo() *BootInfo {
 		L2ChainID:          l2ChainID,
 		L2ChainConfig:      l2ChainConfig,
 		RollupConfig:       rollupConfig,
+		ChainConfigHash:    chainConfigHash,
+		Interop:            interopInfo,
+	}
+}
+
+func (br *BootstrapClient) interopBootInfo(l2ChainID uint64, l2OutputRoot common.Hash) *InteropBootInfo {
+	var chains []InteropChain
+	if err := json.Unmarshal(br.r.Get(InteropChainsLocalIndex), &chains); err != nil {
+		panic("failed to bootstrap interop chains")
+	}
+	deps := new(interop.DependencySet)
+	if err := json.Unmarshal(br.r.Get(DependencySetLocalIndex), deps); err != nil {
+		panic("failed to bootstrap dependency set")
+	}
+	if err := deps.Check(); err != nil {
+		panic(fmt.Errorf("invalid dependency set: %w", err))
+	}
+	var claimed bool
+	for i, chain := range chains {
+		if chain.RollupConfig == nil || chain.L2ChainConfig == nil {
+			panic(fmt.Errorf("missing config of interop chain %d", chain.ChainID))
+		}
+		if chain.RollupConfig.L2ChainID == nil || chain.RollupConfig.L2ChainID.Uint64() != chain.ChainID {
+			panic(fmt.Errorf("rollup config does not match interop chain %d", chain.ChainID))
+		}
+		for _, prev := range chains[:i] {
+			if prev.ChainID == chain.ChainID {
+				panic(fmt.Errorf("duplicate interop chain %d", chain.ChainID))
+			}
+		}
+		if chain.ChainID == l2ChainID {
+			if chain.OutputRoot != l2OutputRoot {
+				panic(fmt.Errorf("agreed output root of interop chain %d does not match the L2 output root", chain.ChainID))
+			}
+			claimed = true
+		}
+	}
+	if !claimed {
+		panic(fmt.Errorf("missing config of claimed interop chain %d", l2ChainID))
+	}
+	if !deps.HasChain(l2ChainID) {
+		panic(fmt.Errorf("claimed interop chain %d is not in the dependency set", l2ChainID))
+	}
+	for _, id := range deps.Chains {
+		if !slices.ContainsFunc(chains, func(c InteropChain) bool { return c.ChainID == id }) {
+			panic(fmt.Errorf("missing config of dependency set chain %d", id))
+		}
 	}
+	return &InteropBootInfo{Chains: chains, DependencySet: deps}
 }
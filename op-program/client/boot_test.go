@@ -4,11 +4,13 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/client/interop"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
@@ -38,11 +40,35 @@ func TestBootstrapClient_CustomChain(t *testing.T) {
 		L2ChainConfig:      chainconfig.OPSepoliaChainConfig,
 		RollupConfig:       chaincfg.Sepolia,
 	}
+	bootInfo.ChainConfigHash = customChainConfigHash(t, bootInfo)
 	mockOracle := &mockBoostrapOracle{bootInfo, true}
 	readBootInfo := NewBootstrapClient(mockOracle).BootInfo()
 	require.EqualValues(t, bootInfo, readBootInfo)
 }
 
+func TestBootstrapClient_CustomChainHashMismatchPanics(t *testing.T) {
+	bootInfo := &BootInfo{
+		L1Head:             common.HexToHash("0x1111"),
+		L2OutputRoot:       common.HexToHash("0x2222"),
+		L2Claim:            common.HexToHash("0x3333"),
+		L2ClaimBlockNumber: 1,
+		L2ChainID:          CustomChainIDIndicator,
+		L2ChainConfig:      chainconfig.OPSepoliaChainConfig,
+		RollupConfig:       chaincfg.Sepolia,
+		ChainConfigHash:    common.HexToHash("0x4444"),
+	}
+	client := NewBootstrapClient(&mockBoostrapOracle{bootInfo, true})
+	require.Panics(t, func() { client.BootInfo() })
+}
+
+func customChainConfigHash(t *testing.T, bootInfo *BootInfo) common.Hash {
+	rollupConfig, err := json.Marshal(bootInfo.RollupConfig)
+	require.NoError(t, err)
+	l2ChainConfig, err := json.Marshal(bootInfo.L2ChainConfig)
+	require.NoError(t, err)
+	return CustomChainConfigHash(rollupConfig, l2ChainConfig)
+}
+
 func TestBootstrapClient_UnknownChainPanics(t *testing.T) {
 	bootInfo := &BootInfo{
 		L1Head:             common.HexToHash("0x1111"),
@@ -56,6 +82,86 @@ func TestBootstrapClient_UnknownChainPanics(t *testing.T) {
 	require.Panics(t, func() { client.BootInfo() })
 }
 
+func interopBootInfo() *BootInfo {
+	devnet := *chaincfg.Sepolia
+	devnet.L2ChainID = big.NewInt(901)
+	return &BootInfo{
+		L1Head:             common.HexToHash("0x1111"),
+		L2OutputRoot:       common.HexToHash("0x2222"),
+		L2Claim:            common.HexToHash("0x3333"),
+		L2ClaimBlockNumber: 1,
+		L2ChainID:          901,
+		L2ChainConfig:      chainconfig.OPSepoliaChainConfig,
+		RollupConfig:       &devnet,
+		Interop: &InteropBootInfo{
+			Chains: []InteropChain{
+				{ChainID: chaincfg.Sepolia.L2ChainID.Uint64(), OutputRoot: common.HexToHash("0x4444"), RollupConfig: chaincfg.Sepolia, L2ChainConfig: chainconfig.OPSepoliaChainConfig},
+				{ChainID: 901, OutputRoot: common.HexToHash("0x2222"), RollupConfig: &devnet, L2ChainConfig: chainconfig.OPSepoliaChainConfig},
+			},
+			DependencySet: &interop.DependencySet{
+				Chains:              []uint64{chaincfg.Sepolia.L2ChainID.Uint64(), 901},
+				MessageExpiryWindow: interop.DefaultMessageExpiryWindow,
+			},
+		},
+	}
+}
+
+func TestBootstrapClient_Interop(t *testing.T) {
+	bootInfo := interopBootInfo()
+	readBootInfo := NewBootstrapClient(&mockInteropBootstrapOracle{bootInfo}).BootInfo()
+	require.EqualValues(t, bootInfo, readBootInfo)
+}
+
+func TestBootstrapClient_InteropInvalidPanics(t *testing.T) {
+	t.Run("ClaimedChainMissing", func(t *testing.T) {
+		bootInfo := interopBootInfo()
+		bootInfo.L2ChainID = 902
+		client := NewBootstrapClient(&mockInteropBootstrapOracle{bootInfo})
+		require.Panics(t, func() { client.BootInfo() })
+	})
+	t.Run("OutputRootMismatch", func(t *testing.T) {
+		bootInfo := interopBootInfo()
+		bootInfo.Interop.Chains[1].OutputRoot = common.HexToHash("0x5555")
+		client := NewBootstrapClient(&mockInteropBootstrapOracle{bootInfo})
+		require.Panics(t, func() { client.BootInfo() })
+	})
+	t.Run("MissingDependencyChain", func(t *testing.T) {
+		bootInfo := interopBootInfo()
+		bootInfo.Interop.DependencySet.Chains = append(bootInfo.Interop.DependencySet.Chains, 902)
+		client := NewBootstrapClient(&mockInteropBootstrapOracle{bootInfo})
+		require.Panics(t, func() { client.BootInfo() })
+	})
+	t.Run("RollupConfigMismatch", func(t *testing.T) {
+		bootInfo := interopBootInfo()
+		bootInfo.Interop.Chains[0].ChainID = 902
+		client := NewBootstrapClient(&mockInteropBootstrapOracle{bootInfo})
+		require.Panics(t, func() { client.BootInfo() })
+	})
+}
+
+type mockInteropBootstrapOracle struct {
+	b *BootInfo
+}
+
+func (o *mockInteropBootstrapOracle) Get(key preimage.Key) []byte {
+	switch key.PreimageKey() {
+	case L2ChainIDLocalIndex.PreimageKey():
+		return binary.BigEndian.AppendUint64(nil, InteropChainIDIndicator)
+	case L2ClaimChainIDLocalIndex.PreimageKey():
+		return binary.BigEndian.AppendUint64(nil, o.b.L2ChainID)
+	case InteropChainsLocalIndex.PreimageKey():
+		b, _ := json.Marshal(o.b.Interop.Chains)
+		return b
+	case DependencySetLocalIndex.PreimageKey():
+		b, _ := json.Marshal(o.b.Interop.DependencySet)
+		return b
+	case L2ChainConfigLocalIndex.PreimageKey(), RollupConfigLocalIndex.PreimageKey(), ChainConfigHashLocalIndex.PreimageKey():
+		panic(fmt.Sprintf("unexpected oracle request for preimage key %x", key.PreimageKey()))
+	default:
+		return (&mockBoostrapOracle{o.b, false}).Get(key)
+	}
+}
+
 type mockBoostrapOracle struct {
 	b      *BootInfo
 	custom bool
@@ -85,6 +191,11 @@ func (o *mockBoostrapOracle) Get(key preimage.Key) []byte {
 		}
 		b, _ := json.Marshal(o.b.RollupConfig)
 		return b
+	case ChainConfigHashLocalIndex.PreimageKey():
+		if !o.custom {
+			panic(fmt.Sprintf("unexpected oracle request for preimage key %x", key.PreimageKey()))
+		}
+		return o.b.ChainConfigHash[:]
 	default:
 		panic("unknown key")
 	}
@@ -18,14 +18,29 @@ type L2Source interface {
 }
 
 func ValidateClaim(log log.Logger, l2ClaimBlockNum uint64, claimedOutputRoot eth.Bytes32, src L2Source) error {
+	l2Head, outputRoot, err := ComputeOutputRoot(l2ClaimBlockNum, src)
+	if err != nil {
+		return err
+	}
+	return CheckOutputRoot(log, l2Head, outputRoot, claimedOutputRoot)
+}
+
+// ComputeOutputRoot returns the safe head, and the output root of the claimed block,
+// or of the safe head if derivation did not reach the claimed block.
+func ComputeOutputRoot(l2ClaimBlockNum uint64, src L2Source) (eth.L2BlockRef, eth.Bytes32, error) {
 	l2Head, err := src.L2BlockRefByLabel(context.Background(), eth.Safe)
 	if err != nil {
-		return fmt.Errorf("cannot retrieve safe head: %w", err)
+		return eth.L2BlockRef{}, eth.Bytes32{}, fmt.Errorf("cannot retrieve safe head: %w", err)
 	}
 	outputRoot, err := src.L2OutputRoot(min(l2ClaimBlockNum, l2Head.Number))
 	if err != nil {
-		return fmt.Errorf("calculate L2 output root: %w", err)
+		return eth.L2BlockRef{}, eth.Bytes32{}, fmt.Errorf("calculate L2 output root: %w", err)
 	}
+	return l2Head, outputRoot, nil
+}
+
+// CheckOutputRoot returns an error wrapping ErrClaimNotValid if the computed output root does not match the claim.
+func CheckOutputRoot(log log.Logger, l2Head eth.L2BlockRef, outputRoot eth.Bytes32, claimedOutputRoot eth.Bytes32) error {
 	log.Info("Validating claim", "head", l2Head, "output", outputRoot, "claim", claimedOutputRoot)
 	if claimedOutputRoot != outputRoot {
 		return fmt.Errorf("%w: claim: %v actual: %v", ErrClaimNotValid, claimedOutputRoot, outputRoot)
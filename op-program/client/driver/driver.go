@@ -20,6 +20,11 @@ type EndCondition interface {
 	Result() error
 }
 
+// MemoryChecker checks the memory used by the program, and returns an error if it exceeds the memory limit.
+type MemoryChecker interface {
+	Check() error
+}
+
 type Driver struct {
 	logger log.Logger
 
@@ -27,13 +32,17 @@ type Driver struct {
 
 	end     EndCondition
 	deriver event.Deriver
+	memory  MemoryChecker
 }
 
+// NewDriver creates the driver of the derivation of the program.
+// The extra derivers, e.g. to promote blocks to cross-safe with interop, are attached to the events of the driver.
 func NewDriver(logger log.Logger, cfg *rollup.Config, l1Source derive.L1Fetcher,
-	l1BlobsSource derive.L1BlobsFetcher, l2Source engine.Engine, targetBlockNum uint64) *Driver {
+	l1BlobsSource derive.L1BlobsFetcher, l2Source engine.Engine, targetBlockNum uint64, memory MemoryChecker, extra ...event.Deriver) *Driver {
 
 	d := &Driver{
 		logger: logger,
+		memory: memory,
 	}
 
 	pipeline := derive.NewDerivationPipeline(logger, cfg, l1Source, l1BlobsSource, altda.Disabled, l2Source, metrics.NoopMetrics)
@@ -55,12 +64,19 @@ func NewDriver(logger log.Logger, cfg *rollup.Config, l1Source derive.L1Fetcher,
 		targetBlockNum: targetBlockNum,
 	}
 
-	d.deriver = &event.DeriverMux{
+	mux := event.DeriverMux{
 		prog,
 		engineDeriv,
 		pipelineDeriver,
 		engResetDeriv,
 	}
+	for _, deriv := range extra {
+		if attach, ok := deriv.(event.AttachEmitter); ok {
+			attach.AttachEmitter(d)
+		}
+		mux = append(mux, deriv)
+	}
+	d.deriver = &mux
 	d.end = prog
 
 	return d
@@ -86,6 +102,11 @@ func (d *Driver) RunComplete() error {
 		if len(d.events) > 10000 { // sanity check, in case of bugs. Better than going OOM.
 			return errors.New("way too many events queued up, something is wrong")
 		}
+		if d.memory != nil {
+			if err := d.memory.Check(); err != nil {
+				return err
+			}
+		}
 		ev := d.events[0]
 		d.events = d.events[1:]
 		d.deriver.OnEvent(ev)
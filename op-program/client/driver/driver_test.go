@@ -108,4 +108,27 @@ func TestDriver(t *testing.T) {
 		// add 1 for initial event that RunComplete fires
 		require.Equal(t, 1+3*2, count, "must have queued up 2 events 3 times")
 	})
+
+	t.Run("memory limit exceeded", func(t *testing.T) {
+		count := 0
+		mockErr := errors.New("memory limit exceeded")
+		d := newTestDriver(t, func(d *Driver, end *fakeEnd, ev event.Event) {
+			count += 1
+			d.Emit(TestEvent{})
+		})
+		d.memory = checkFn(func() error {
+			if count >= 3 {
+				return mockErr
+			}
+			return nil
+		})
+		require.ErrorIs(t, d.RunComplete(), mockErr)
+		require.Equal(t, 3, count)
+	})
+}
+
+type checkFn func() error
+
+func (fn checkFn) Check() error {
+	return fn()
 }
@@ -1,6 +1,7 @@
 package driver
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/log"
@@ -67,6 +68,12 @@ func (d *ProgramDeriver) OnEvent(ev event.Event) bool {
 		d.closing = true
 		d.logger.Info("Derivation complete: no further data to process")
 	case rollup.ResetEvent:
+		if errors.Is(x.Err, engine.ErrReplacingBlock) {
+			// An invalidated block is replaced: re-derive from the rewound engine state.
+			d.logger.Info("Resetting derivation to replace invalidated block", "err", x.Err)
+			d.Emitter.Emit(engine.ResetEngineRequestEvent{})
+			break
+		}
 		d.closing = true
 		d.result = fmt.Errorf("unexpected reset error: %w", x.Err)
 	case rollup.L1TemporaryErrorEvent:
@@ -2,6 +2,7 @@ package driver
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -120,6 +121,15 @@ func TestProgramDeriver(t *testing.T) {
 		require.True(t, p.closing)
 		require.NotNil(t, p.result)
 	})
+	// on replacement of an invalidated block: re-derive from the rewound engine
+	t.Run("replacement reset event", func(t *testing.T) {
+		p, m := newProgram(t, 1000)
+		m.ExpectOnce(engine.ResetEngineRequestEvent{})
+		p.OnEvent(rollup.ResetEvent{Err: fmt.Errorf("%w %s", engine.ErrReplacingBlock, eth.L2BlockRef{Number: 123})})
+		m.AssertExpectations(t)
+		require.False(t, p.closing)
+		require.NoError(t, p.result)
+	})
 	// on L1 temporary error: stop with error
 	t.Run("L1 temporary error event", func(t *testing.T) {
 		p, m := newProgram(t, 1000)
@@ -0,0 +1,89 @@
+package interop
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Chain provides the canonical blocks of a chain in the dependency set, up to the latest block known to the program.
+type Chain interface {
+	// HeaderByNumber returns the canonical header with the given number, or nil if it's after the latest known block.
+	HeaderByNumber(n uint64) *types.Header
+
+	// Receipts returns the receipts of the canonical block with the given header.
+	Receipts(header *types.Header) types.Receipts
+}
+
+// OracleChain is a Chain of blocks retrieved from the oracle, up to an agreed head block.
+type OracleChain struct {
+	chainID  uint64
+	oracle   Oracle
+	head     *types.Header
+	earliest *types.Header
+	byNum    map[uint64]*types.Header
+}
+
+var _ Chain = (*OracleChain)(nil)
+
+func NewOracleChain(chainID uint64, oracle Oracle, head common.Hash) *OracleChain {
+	header := oracle.HeaderByBlockHash(chainID, head)
+	return &OracleChain{
+		chainID:  chainID,
+		oracle:   oracle,
+		head:     header,
+		earliest: header,
+		byNum:    map[uint64]*types.Header{header.Number.Uint64(): header},
+	}
+}
+
+func (c *OracleChain) HeaderByNumber(n uint64) *types.Header {
+	if n > c.head.Number.Uint64() {
+		return nil
+	}
+	if h, ok := c.byNum[n]; ok {
+		return h
+	}
+	// Walk back from the earliest block retrieved so far to the requested block number
+	h := c.earliest
+	for h.Number.Uint64() > n {
+		h = c.oracle.HeaderByBlockHash(c.chainID, h.ParentHash)
+		c.byNum[h.Number.Uint64()] = h
+	}
+	c.earliest = h
+	return h
+}
+
+func (c *OracleChain) Receipts(header *types.Header) types.Receipts {
+	return c.oracle.ReceiptsByBlockHash(c.chainID, header.Hash())
+}
+
+// DerivedBackend is the L2 chain the program derives blocks into.
+type DerivedBackend interface {
+	GetHeaderByNumber(n uint64) *types.Header
+	InsertedReceipts(hash common.Hash) (types.Receipts, bool)
+}
+
+// DerivedChain is the Chain of the L2 blocks derived by the program, on top of the agreed head block.
+// The receipts of derived blocks are the receipts of their execution, older receipts are retrieved from the oracle.
+type DerivedChain struct {
+	chainID uint64
+	backend DerivedBackend
+	oracle  Oracle
+}
+
+var _ Chain = (*DerivedChain)(nil)
+
+func NewDerivedChain(chainID uint64, backend DerivedBackend, oracle Oracle) *DerivedChain {
+	return &DerivedChain{chainID: chainID, backend: backend, oracle: oracle}
+}
+
+func (c *DerivedChain) HeaderByNumber(n uint64) *types.Header {
+	return c.backend.GetHeaderByNumber(n)
+}
+
+func (c *DerivedChain) Receipts(header *types.Header) types.Receipts {
+	if receipts, ok := c.backend.InsertedReceipts(header.Hash()); ok {
+		return receipts
+	}
+	return c.oracle.ReceiptsByBlockHash(c.chainID, header.Hash())
+}
@@ -0,0 +1,58 @@
+package interop
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type stubOracle struct {
+	t        *testing.T
+	chainID  uint64
+	headers  map[common.Hash]*types.Header
+	requests int
+}
+
+func (o *stubOracle) HeaderByBlockHash(chainID uint64, blockHash common.Hash) *types.Header {
+	require.Equal(o.t, o.chainID, chainID)
+	header, ok := o.headers[blockHash]
+	require.Truef(o.t, ok, "unknown block %s", blockHash)
+	o.requests++
+	return header
+}
+
+func (o *stubOracle) ReceiptsByBlockHash(chainID uint64, blockHash common.Hash) types.Receipts {
+	o.t.Fatalf("unexpected receipts request for %s", blockHash)
+	return nil
+}
+
+func (o *stubOracle) OutputByRoot(chainID uint64, root common.Hash) eth.Output {
+	o.t.Fatalf("unexpected output request for %s", root)
+	return nil
+}
+
+func TestOracleChain(t *testing.T) {
+	oracle := &stubOracle{t: t, chainID: chainA, headers: make(map[common.Hash]*types.Header)}
+	var parent common.Hash
+	var hashes []common.Hash
+	for i := 0; i < 10; i++ {
+		header := &types.Header{Number: big.NewInt(int64(i)), ParentHash: parent, Time: uint64(i) * 2}
+		parent = header.Hash()
+		oracle.headers[parent] = header
+		hashes = append(hashes, parent)
+	}
+	chain := NewOracleChain(chainA, oracle, hashes[7])
+
+	require.Nil(t, chain.HeaderByNumber(8), "must not return blocks after the head")
+	require.Equal(t, hashes[3], chain.HeaderByNumber(3).Hash())
+	require.Equal(t, hashes[5], chain.HeaderByNumber(5).Hash())
+	require.Equal(t, hashes[7], chain.HeaderByNumber(7).Hash())
+	require.Equal(t, hashes[0], chain.HeaderByNumber(0).Hash())
+	// each header is only requested once
+	require.Equal(t, 8, oracle.requests)
+}
@@ -0,0 +1,45 @@
+package interop
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// DefaultMessageExpiryWindow is the number of seconds an initiating message can be executed for, 180 days.
+const DefaultMessageExpiryWindow = 180 * 24 * 60 * 60
+
+var (
+	ErrEmptyDependencySet     = errors.New("empty dependency set")
+	ErrDuplicateChain         = errors.New("duplicate chain in dependency set")
+	ErrInvalidMessageExpiry   = errors.New("invalid message expiry window")
+	ErrChainNotInDependencies = errors.New("chain not in dependency set")
+)
+
+// DependencySet is the set of chains that may execute messages initiated on each other.
+type DependencySet struct {
+	// Chains are the chain IDs of the chains in the dependency set
+	Chains []uint64 `json:"chains"`
+	// MessageExpiryWindow is the number of seconds after the initiating message that it can be executed for
+	MessageExpiryWindow uint64 `json:"messageExpiryWindow"`
+}
+
+func (d *DependencySet) Check() error {
+	if len(d.Chains) == 0 {
+		return ErrEmptyDependencySet
+	}
+	for i, chain := range d.Chains {
+		if slices.Contains(d.Chains[:i], chain) {
+			return fmt.Errorf("%w: %d", ErrDuplicateChain, chain)
+		}
+	}
+	if d.MessageExpiryWindow == 0 {
+		return ErrInvalidMessageExpiry
+	}
+	return nil
+}
+
+// HasChain returns true if the chain is part of the dependency set.
+func (d *DependencySet) HasChain(chainID uint64) bool {
+	return slices.Contains(d.Chains, chainID)
+}
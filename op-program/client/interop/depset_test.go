@@ -0,0 +1,14 @@
+package interop
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencySetCheck(t *testing.T) {
+	require.NoError(t, (&DependencySet{Chains: []uint64{chainA, chainB}, MessageExpiryWindow: 1}).Check())
+	require.ErrorIs(t, (&DependencySet{MessageExpiryWindow: 1}).Check(), ErrEmptyDependencySet)
+	require.ErrorIs(t, (&DependencySet{Chains: []uint64{chainA, chainA}, MessageExpiryWindow: 1}).Check(), ErrDuplicateChain)
+	require.ErrorIs(t, (&DependencySet{Chains: []uint64{chainA}}).Check(), ErrInvalidMessageExpiry)
+}
@@ -0,0 +1,133 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	nodeinterop "github.com/ethereum-optimism/optimism/op-node/rollup/interop"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type L2Source interface {
+	L2BlockRefByNumber(context.Context, uint64) (eth.L2BlockRef, error)
+	PayloadByHash(context.Context, common.Hash) (*eth.ExecutionPayloadEnvelope, error)
+}
+
+// Deriver promotes the local-safe blocks of the derived chain to cross-safe, once their executing messages are
+// validated against the chains in the dependency set. Like the interop deriver of the op-node,
+// a block with an invalid executing message is replaced with a deposits-only block.
+type Deriver struct {
+	log       log.Logger
+	cfg       *rollup.Config
+	chainID   uint64
+	validator *Validator
+	derived   Chain
+	l2        L2Source
+
+	// L2 blockhash -> derived from L1 block ref.
+	// Added to when a block is local-safe.
+	// Removed from when it is promoted to cross-safe, or replaced.
+	derivedFrom map[common.Hash]eth.L1BlockRef
+
+	emitter event.Emitter
+}
+
+var _ event.Deriver = (*Deriver)(nil)
+var _ event.AttachEmitter = (*Deriver)(nil)
+
+func NewDeriver(log log.Logger, cfg *rollup.Config, validator *Validator, derived Chain, l2 L2Source) *Deriver {
+	return &Deriver{
+		log:         log,
+		cfg:         cfg,
+		chainID:     cfg.L2ChainID.Uint64(),
+		validator:   validator,
+		derived:     derived,
+		l2:          l2,
+		derivedFrom: make(map[common.Hash]eth.L1BlockRef),
+	}
+}
+
+func (d *Deriver) AttachEmitter(em event.Emitter) {
+	d.emitter = em
+}
+
+func (d *Deriver) OnEvent(ev event.Event) bool {
+	switch x := ev.(type) {
+	case engine.LocalSafeUpdateEvent:
+		d.derivedFrom[x.Ref.Hash] = x.DerivedFrom
+		d.emitter.Emit(engine.RequestCrossSafeEvent{})
+	case engine.CrossSafeUpdateEvent:
+		if x.CrossSafe.Number >= x.LocalSafe.Number {
+			break // nothing left to promote
+		}
+		// Pre-interop the engine itself handles promotion to cross-safe.
+		if !d.cfg.IsInterop(d.cfg.TimestampForBlock(x.CrossSafe.Number + 1)) {
+			return false
+		}
+		d.onCrossSafeCandidate(x.CrossSafe)
+	default:
+		return false
+	}
+	return true
+}
+
+// onCrossSafeCandidate validates the block after the cross-safe head, and promotes or replaces it.
+// The program has all data locally, so any failure to validate is critical.
+func (d *Deriver) onCrossSafeCandidate(crossSafe eth.L2BlockRef) {
+	ctx := context.Background()
+	candidate, err := d.l2.L2BlockRefByNumber(ctx, crossSafe.Number+1)
+	if err != nil {
+		d.emitter.Emit(rollup.CriticalErrorEvent{Err: fmt.Errorf("failed to fetch next cross-safe candidate: %w", err)})
+		return
+	}
+	derivedFrom, ok := d.derivedFrom[candidate.Hash]
+	if !ok {
+		return
+	}
+	header := d.derived.HeaderByNumber(candidate.Number)
+	if header == nil || header.Hash() != candidate.Hash {
+		d.emitter.Emit(rollup.CriticalErrorEvent{Err: fmt.Errorf("cross-safe candidate %s is not canonical", candidate)})
+		return
+	}
+	if err := d.validator.ValidateBlock(d.chainID, header, d.derived.Receipts(header)); errors.Is(err, ErrInvalidExecutingMessage) {
+		d.log.Warn("Local-safe block has invalid executing messages, replacing it with a deposits-only block", "block", candidate, "err", err)
+		d.replace(ctx, candidate, crossSafe, derivedFrom)
+		return
+	} else if err != nil {
+		d.emitter.Emit(rollup.CriticalErrorEvent{Err: fmt.Errorf("failed to validate executing messages of block %s: %w", candidate, err)})
+		return
+	}
+	delete(d.derivedFrom, candidate.Hash)
+	d.emitter.Emit(engine.PromoteSafeEvent{
+		Ref:         candidate,
+		DerivedFrom: derivedFrom,
+	})
+}
+
+// replace invalidates the block, to be replaced with a block with only its deposits.
+func (d *Deriver) replace(ctx context.Context, invalid eth.L2BlockRef, parent eth.L2BlockRef, derivedFrom eth.L1BlockRef) {
+	envelope, err := d.l2.PayloadByHash(ctx, invalid.Hash)
+	if err != nil {
+		d.emitter.Emit(rollup.CriticalErrorEvent{Err: fmt.Errorf("failed to fetch invalid block %s to replace: %w", invalid, err)})
+		return
+	}
+	delete(d.derivedFrom, invalid.Hash)
+	d.emitter.Emit(engine.InvalidateBlockEvent{
+		Invalidated: invalid,
+		Parent:      parent,
+		Replacement: &derive.AttributesWithParent{
+			Attributes:   nodeinterop.DepositsOnlyAttributes(envelope),
+			Parent:       parent,
+			IsLastInSpan: true,
+			DerivedFrom:  derivedFrom,
+		},
+	})
+}
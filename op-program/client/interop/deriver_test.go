@@ -0,0 +1,149 @@
+package interop
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	nodeinterop "github.com/ethereum-optimism/optimism/op-node/rollup/interop"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type stubL2Source struct {
+	refs      map[uint64]eth.L2BlockRef
+	envelopes map[common.Hash]*eth.ExecutionPayloadEnvelope
+}
+
+func (s *stubL2Source) L2BlockRefByNumber(_ context.Context, n uint64) (eth.L2BlockRef, error) {
+	ref, ok := s.refs[n]
+	if !ok {
+		return eth.L2BlockRef{}, ethereum.NotFound
+	}
+	return ref, nil
+}
+
+func (s *stubL2Source) PayloadByHash(_ context.Context, hash common.Hash) (*eth.ExecutionPayloadEnvelope, error) {
+	envelope, ok := s.envelopes[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return envelope, nil
+}
+
+func (s *stubL2Source) add(header *types.Header) eth.L2BlockRef {
+	ref := eth.L2BlockRef{Hash: header.Hash(), Number: header.Number.Uint64(), ParentHash: header.ParentHash, Time: header.Time}
+	s.refs[ref.Number] = ref
+	s.envelopes[ref.Hash] = &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{
+		BlockHash:    ref.Hash,
+		BlockNumber:  eth.Uint64Quantity(ref.Number),
+		Timestamp:    eth.Uint64Quantity(ref.Time),
+		GasLimit:     30_000_000,
+		Transactions: []eth.Data{{types.DepositTxType, 0x01}, {types.DynamicFeeTxType, 0x02}},
+	}}
+	return ref
+}
+
+func TestDeriver(t *testing.T) {
+	deps := &DependencySet{Chains: []uint64{chainA, chainB}, MessageExpiryWindow: 1000}
+	l1 := eth.L1BlockRef{Hash: common.Hash{0x11}, Number: 100}
+	setup := func(t *testing.T, interopTime uint64) (*Deriver, *testutils.MockEmitter, *stubChain, *stubChain, *stubL2Source) {
+		a := newStubChain()
+		b := newStubChain()
+		a.addBlock(0)
+		b.addBlock(0)
+		l2 := &stubL2Source{refs: make(map[uint64]eth.L2BlockRef), envelopes: make(map[common.Hash]*eth.ExecutionPayloadEnvelope)}
+		l2.add(b.headers[0])
+		cfg := &rollup.Config{L2ChainID: new(big.Int).SetUint64(chainB), BlockTime: 2, InteropTime: &interopTime}
+		logger := testlog.Logger(t, log.LevelInfo)
+		v := NewValidator(logger, deps, map[uint64]Chain{chainA: a, chainB: b})
+		d := NewDeriver(logger, cfg, v, b, l2)
+		em := &testutils.MockEmitter{}
+		d.AttachEmitter(em)
+		return d, em, a, b, l2
+	}
+
+	t.Run("PromoteValid", func(t *testing.T) {
+		d, em, a, b, l2 := setup(t, 0)
+		initHeader, initLogs := a.addBlock(1, initiatingLog(1))
+		header, _ := b.addBlock(2, executingLog(t, chainA, initHeader, initLogs[0]))
+		ref := l2.add(header)
+
+		em.ExpectOnce(engine.RequestCrossSafeEvent{})
+		require.True(t, d.OnEvent(engine.LocalSafeUpdateEvent{Ref: ref, DerivedFrom: l1}))
+		em.AssertExpectations(t)
+
+		em.ExpectOnce(engine.PromoteSafeEvent{Ref: ref, DerivedFrom: l1})
+		require.True(t, d.OnEvent(engine.CrossSafeUpdateEvent{CrossSafe: l2.refs[0], LocalSafe: ref}))
+		em.AssertExpectations(t)
+		require.Empty(t, d.derivedFrom)
+	})
+
+	t.Run("ReplaceInvalid", func(t *testing.T) {
+		d, em, a, b, l2 := setup(t, 0)
+		initHeader, initLogs := a.addBlock(1, initiatingLog(1))
+		invalid := executingLog(t, chainA, initHeader, initLogs[0])
+		invalid.Topics[1] = common.Hash{0xff}
+		header, _ := b.addBlock(2, invalid)
+		ref := l2.add(header)
+
+		em.ExpectOnce(engine.RequestCrossSafeEvent{})
+		d.OnEvent(engine.LocalSafeUpdateEvent{Ref: ref, DerivedFrom: l1})
+		em.AssertExpectations(t)
+
+		attrs := nodeinterop.DepositsOnlyAttributes(l2.envelopes[ref.Hash])
+		require.Len(t, attrs.Transactions, 1)
+		em.ExpectOnce(engine.InvalidateBlockEvent{
+			Invalidated: ref,
+			Parent:      l2.refs[0],
+			Replacement: &derive.AttributesWithParent{
+				Attributes:   attrs,
+				Parent:       l2.refs[0],
+				IsLastInSpan: true,
+				DerivedFrom:  l1,
+			},
+		})
+		require.True(t, d.OnEvent(engine.CrossSafeUpdateEvent{CrossSafe: l2.refs[0], LocalSafe: ref}))
+		em.AssertExpectations(t)
+		require.Empty(t, d.derivedFrom)
+	})
+
+	t.Run("PreInterop", func(t *testing.T) {
+		d, em, _, b, l2 := setup(t, 1000)
+		header, _ := b.addBlock(2)
+		ref := l2.add(header)
+		em.ExpectOnce(engine.RequestCrossSafeEvent{})
+		d.OnEvent(engine.LocalSafeUpdateEvent{Ref: ref, DerivedFrom: l1})
+		// The engine promotes pre-interop blocks itself
+		require.False(t, d.OnEvent(engine.CrossSafeUpdateEvent{CrossSafe: l2.refs[0], LocalSafe: ref}))
+		em.AssertExpectations(t)
+	})
+
+	t.Run("NothingToPromote", func(t *testing.T) {
+		d, em, _, _, l2 := setup(t, 0)
+		require.True(t, d.OnEvent(engine.CrossSafeUpdateEvent{CrossSafe: l2.refs[0], LocalSafe: l2.refs[0]}))
+		em.AssertExpectations(t)
+	})
+
+	t.Run("ValidationFailure", func(t *testing.T) {
+		d, em, _, b, l2 := setup(t, 0)
+		d.chainID = 902 // not in the dependency set
+		header, _ := b.addBlock(2)
+		ref := l2.add(header)
+		em.ExpectOnce(engine.RequestCrossSafeEvent{})
+		d.OnEvent(engine.LocalSafeUpdateEvent{Ref: ref, DerivedFrom: l1})
+		em.ExpectOnceType("rollup.CriticalErrorEvent")
+		d.OnEvent(engine.CrossSafeUpdateEvent{CrossSafe: l2.refs[0], LocalSafe: ref})
+		em.AssertExpectations(t)
+	})
+}
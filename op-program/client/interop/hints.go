@@ -0,0 +1,68 @@
+package interop
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+// Pre-images are keyed by their hash, so the key space is shared by all chains.
+// The hints include the chain ID so the host knows which chain to fetch the data from.
+const (
+	HintL2ChainBlockHeader  = "l2-chain-block-header"
+	HintL2ChainTransactions = "l2-chain-transactions"
+	HintL2ChainReceipts     = "l2-chain-receipts"
+	HintL2ChainOutput       = "l2-chain-output"
+)
+
+// chainHintData encodes the hash followed by the big-endian uint64 chain ID.
+func chainHintData(chainID uint64, hash common.Hash) string {
+	return hexutil.Encode(binary.BigEndian.AppendUint64(hash.Bytes(), chainID))
+}
+
+type BlockHeaderHint struct {
+	ChainID uint64
+	Hash    common.Hash
+}
+
+var _ preimage.Hint = BlockHeaderHint{}
+
+func (l BlockHeaderHint) Hint() string {
+	return HintL2ChainBlockHeader + " " + chainHintData(l.ChainID, l.Hash)
+}
+
+type TransactionsHint struct {
+	ChainID uint64
+	Hash    common.Hash
+}
+
+var _ preimage.Hint = TransactionsHint{}
+
+func (l TransactionsHint) Hint() string {
+	return HintL2ChainTransactions + " " + chainHintData(l.ChainID, l.Hash)
+}
+
+type ReceiptsHint struct {
+	ChainID uint64
+	Hash    common.Hash
+}
+
+var _ preimage.Hint = ReceiptsHint{}
+
+func (l ReceiptsHint) Hint() string {
+	return HintL2ChainReceipts + " " + chainHintData(l.ChainID, l.Hash)
+}
+
+type L2OutputHint struct {
+	ChainID uint64
+	Root    common.Hash
+}
+
+var _ preimage.Hint = L2OutputHint{}
+
+func (l L2OutputHint) Hint() string {
+	return HintL2ChainOutput + " " + chainHintData(l.ChainID, l.Root)
+}
@@ -0,0 +1,85 @@
+package interop
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Oracle defines the high-level API used to retrieve the L2 data of the chains in the dependency set.
+// The returned data is always the preimage of the requested hash.
+type Oracle interface {
+	// HeaderByBlockHash retrieves the header of the block with the given hash on the given chain.
+	HeaderByBlockHash(chainID uint64, blockHash common.Hash) *types.Header
+
+	// ReceiptsByBlockHash retrieves the receipts of the block with the given hash on the given chain.
+	ReceiptsByBlockHash(chainID uint64, blockHash common.Hash) types.Receipts
+
+	// OutputByRoot retrieves the output with the given root on the given chain.
+	OutputByRoot(chainID uint64, root common.Hash) eth.Output
+}
+
+// PreimageOracle implements Oracle using by interfacing with the pure preimage.Oracle
+// to fetch pre-images to decode into the requested data.
+type PreimageOracle struct {
+	oracle preimage.Oracle
+	hint   preimage.Hinter
+}
+
+var _ Oracle = (*PreimageOracle)(nil)
+
+func NewPreimageOracle(raw preimage.Oracle, hint preimage.Hinter) *PreimageOracle {
+	return &PreimageOracle{
+		oracle: raw,
+		hint:   hint,
+	}
+}
+
+func (p *PreimageOracle) HeaderByBlockHash(chainID uint64, blockHash common.Hash) *types.Header {
+	p.hint.Hint(BlockHeaderHint{ChainID: chainID, Hash: blockHash})
+	headerRlp := p.oracle.Get(preimage.Keccak256Key(blockHash))
+	var header types.Header
+	if err := rlp.DecodeBytes(headerRlp, &header); err != nil {
+		panic(fmt.Errorf("invalid block header %s of chain %d: %w", blockHash, chainID, err))
+	}
+	return &header
+}
+
+func (p *PreimageOracle) ReceiptsByBlockHash(chainID uint64, blockHash common.Hash) types.Receipts {
+	header := p.HeaderByBlockHash(chainID, blockHash)
+
+	p.hint.Hint(TransactionsHint{ChainID: chainID, Hash: blockHash})
+	opaqueTxs := mpt.ReadTrie(header.TxHash, func(key common.Hash) []byte {
+		return p.oracle.Get(preimage.Keccak256Key(key))
+	})
+	txs, err := eth.DecodeTransactions(opaqueTxs)
+	if err != nil {
+		panic(fmt.Errorf("failed to decode list of txs: %w", err))
+	}
+
+	p.hint.Hint(ReceiptsHint{ChainID: chainID, Hash: blockHash})
+	opaqueReceipts := mpt.ReadTrie(header.ReceiptHash, func(key common.Hash) []byte {
+		return p.oracle.Get(preimage.Keccak256Key(key))
+	})
+	receipts, err := eth.DecodeRawReceipts(eth.HeaderBlockID(header), opaqueReceipts, eth.TransactionsToHashes(txs))
+	if err != nil {
+		panic(fmt.Errorf("bad receipts data for block %s of chain %d: %w", blockHash, chainID, err))
+	}
+	return receipts
+}
+
+func (p *PreimageOracle) OutputByRoot(chainID uint64, root common.Hash) eth.Output {
+	p.hint.Hint(L2OutputHint{ChainID: chainID, Root: root})
+	data := p.oracle.Get(preimage.Keccak256Key(root))
+	output, err := eth.UnmarshalOutput(data)
+	if err != nil {
+		panic(fmt.Errorf("invalid L2 output data for root %s of chain %d: %w", root, chainID, err))
+	}
+	return output
+}
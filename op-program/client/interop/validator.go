@@ -0,0 +1,118 @@
+package interop
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/source/contracts"
+	backendTypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/types"
+)
+
+var (
+	ErrInvalidExecutingMessage = errors.New("invalid executing message")
+	ErrUnknownChain            = errors.New("unknown chain")
+)
+
+// Validator checks that the executing messages of L2 blocks reference initiating messages
+// of the chains in the dependency set.
+type Validator struct {
+	logger log.Logger
+	deps   *DependencySet
+	chains map[uint64]Chain
+	inbox  *contracts.CrossL2Inbox
+}
+
+func NewValidator(logger log.Logger, deps *DependencySet, chains map[uint64]Chain) *Validator {
+	return &Validator{
+		logger: logger,
+		deps:   deps,
+		chains: chains,
+		inbox:  contracts.NewCrossL2Inbox(),
+	}
+}
+
+// ValidateBlock checks all executing messages in the receipts of a block of the given chain.
+// Returns an error wrapping ErrInvalidExecutingMessage if any of them is invalid.
+func (v *Validator) ValidateBlock(chainID uint64, header *types.Header, receipts types.Receipts) error {
+	if !v.deps.HasChain(chainID) {
+		return fmt.Errorf("%w: %d", ErrChainNotInDependencies, chainID)
+	}
+	var count int
+	for _, rcpt := range receipts {
+		for _, l := range rcpt.Logs {
+			msg, err := v.inbox.DecodeExecutingMessageLog(l)
+			if errors.Is(err, contracts.ErrEventNotFound) {
+				continue
+			} else if err != nil {
+				return fmt.Errorf("%w: log %d of block %d: %w", ErrInvalidExecutingMessage, l.Index, header.Number, err)
+			}
+			if err := v.validateMessage(chainID, header, l.Index, msg); err != nil {
+				return fmt.Errorf("%w: log %d of block %d: %w", ErrInvalidExecutingMessage, l.Index, header.Number, err)
+			}
+			count++
+		}
+	}
+	if count > 0 {
+		v.logger.Debug("Validated executing messages", "chain", chainID, "block", header.Number, "messages", count)
+	}
+	return nil
+}
+
+func (v *Validator) validateMessage(chainID uint64, header *types.Header, logIdx uint, msg backendTypes.ExecutingMessage) error {
+	initChainID := uint64(msg.Chain)
+	if !v.deps.HasChain(initChainID) {
+		return fmt.Errorf("%w: initiating chain %d", ErrChainNotInDependencies, initChainID)
+	}
+	if msg.Timestamp > header.Time {
+		return fmt.Errorf("initiating message timestamp %d is after block timestamp %d", msg.Timestamp, header.Time)
+	}
+	if msg.Timestamp+v.deps.MessageExpiryWindow < header.Time {
+		return fmt.Errorf("initiating message timestamp %d expired at block timestamp %d", msg.Timestamp, header.Time)
+	}
+	if initChainID == chainID && msg.BlockNum == header.Number.Uint64() && uint(msg.LogIdx) >= logIdx {
+		return fmt.Errorf("initiating message log %d is not before the executing message", msg.LogIdx)
+	}
+	chain, ok := v.chains[initChainID]
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrUnknownChain, initChainID)
+	}
+	initHeader := chain.HeaderByNumber(msg.BlockNum)
+	if initHeader == nil {
+		return fmt.Errorf("initiating block %d of chain %d is unknown", msg.BlockNum, initChainID)
+	}
+	if initHeader.Time != msg.Timestamp {
+		return fmt.Errorf("initiating block %d has timestamp %d, expected %d", msg.BlockNum, initHeader.Time, msg.Timestamp)
+	}
+	for _, rcpt := range chain.Receipts(initHeader) {
+		for _, l := range rcpt.Logs {
+			if l.Index != uint(msg.LogIdx) {
+				continue
+			}
+			if hash := logToLogHash(l); hash != msg.Hash {
+				return fmt.Errorf("initiating log %d of block %d has hash %s, expected %s", msg.LogIdx, msg.BlockNum, hash, msg.Hash)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("initiating log %d of block %d of chain %d not found", msg.LogIdx, msg.BlockNum, initChainID)
+}
+
+// logToLogHash computes the hash of a log that executing messages commit to,
+// like the log processor of the op-supervisor: the hash of the log address and the hash of its payload.
+func logToLogHash(l *types.Log) backendTypes.TruncatedHash {
+	payload := make([]byte, 0, len(l.Topics)*common.HashLength+len(l.Data))
+	for _, topic := range l.Topics {
+		payload = append(payload, topic.Bytes()...)
+	}
+	payload = append(payload, l.Data...)
+	payloadHash := crypto.Keccak256Hash(payload)
+	msg := make([]byte, 0, common.AddressLength+common.HashLength)
+	msg = append(msg, l.Address.Bytes()...)
+	msg = append(msg, payloadHash.Bytes()...)
+	return backendTypes.TruncateHash(crypto.Keccak256Hash(msg))
+}
@@ -0,0 +1,201 @@
+package interop
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+)
+
+const (
+	chainA = uint64(900)
+	chainB = uint64(901)
+)
+
+type identifier struct {
+	Origin      common.Address
+	BlockNumber *big.Int
+	LogIndex    *big.Int
+	Timestamp   *big.Int
+	ChainId     *big.Int
+}
+
+// executingLog creates the ExecutingMessage log of the CrossL2Inbox for the initiating log.
+func executingLog(t *testing.T, chainID uint64, initHeader *types.Header, init *types.Log) *types.Log {
+	payload := make([]byte, 0)
+	for _, topic := range init.Topics {
+		payload = append(payload, topic.Bytes()...)
+	}
+	payload = append(payload, init.Data...)
+	payloadHash := crypto.Keccak256Hash(payload)
+	event := snapshots.LoadCrossL2InboxABI().Events["ExecutingMessage"]
+	data, err := event.Inputs.Pack(payloadHash, identifier{
+		Origin:      init.Address,
+		BlockNumber: initHeader.Number,
+		LogIndex:    new(big.Int).SetUint64(uint64(init.Index)),
+		Timestamp:   new(big.Int).SetUint64(initHeader.Time),
+		ChainId:     new(big.Int).SetUint64(chainID),
+	})
+	require.NoError(t, err)
+	return &types.Log{
+		Address: predeploys.CrossL2InboxAddr,
+		Topics:  []common.Hash{event.ID, payloadHash},
+		Data:    data,
+	}
+}
+
+type stubChain struct {
+	headers  []*types.Header
+	receipts map[common.Hash]types.Receipts
+}
+
+func (c *stubChain) HeaderByNumber(n uint64) *types.Header {
+	if n >= uint64(len(c.headers)) {
+		return nil
+	}
+	return c.headers[n]
+}
+
+func (c *stubChain) Receipts(header *types.Header) types.Receipts {
+	return c.receipts[header.Hash()]
+}
+
+// addBlock adds a block with the logs, and returns its header and the logs with their log index.
+func (c *stubChain) addBlock(timestamp uint64, logs ...*types.Log) (*types.Header, []*types.Log) {
+	header := &types.Header{Number: big.NewInt(int64(len(c.headers))), Time: timestamp}
+	for i, l := range logs {
+		l.Index = uint(i)
+	}
+	c.headers = append(c.headers, header)
+	receipts := types.Receipts{&types.Receipt{Logs: logs}}
+	c.receipts[header.Hash()] = receipts
+	return header, logs
+}
+
+func newStubChain() *stubChain {
+	return &stubChain{receipts: make(map[common.Hash]types.Receipts)}
+}
+
+func initiatingLog(data byte) *types.Log {
+	return &types.Log{
+		Address: common.Address{0xaa},
+		Topics:  []common.Hash{{0x01}, {data}},
+		Data:    []byte{data, data},
+	}
+}
+
+func TestValidateBlock(t *testing.T) {
+	deps := &DependencySet{Chains: []uint64{chainA, chainB}, MessageExpiryWindow: 1000}
+	setup := func() (*Validator, *stubChain, *stubChain) {
+		a := newStubChain()
+		b := newStubChain()
+		a.addBlock(0)
+		b.addBlock(0)
+		v := NewValidator(testlog.Logger(t, log.LevelInfo), deps, map[uint64]Chain{chainA: a, chainB: b})
+		return v, a, b
+	}
+
+	t.Run("NoMessages", func(t *testing.T) {
+		v, _, b := setup()
+		header, logs := b.addBlock(10, initiatingLog(1))
+		require.NoError(t, v.ValidateBlock(chainB, header, types.Receipts{{Logs: logs}}))
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		v, a, b := setup()
+		initHeader, initLogs := a.addBlock(10, initiatingLog(1), initiatingLog(2))
+		header, logs := b.addBlock(12, executingLog(t, chainA, initHeader, initLogs[1]))
+		require.NoError(t, v.ValidateBlock(chainB, header, types.Receipts{{Logs: logs}}))
+	})
+
+	t.Run("ValidSameBlock", func(t *testing.T) {
+		v, _, b := setup()
+		init := initiatingLog(1)
+		header := &types.Header{Number: big.NewInt(1), Time: 12}
+		init.Index = 0
+		exec := executingLog(t, chainB, header, init)
+		header, logs := b.addBlock(12, init, exec)
+		require.NoError(t, v.ValidateBlock(chainB, header, types.Receipts{{Logs: logs}}))
+	})
+
+	t.Run("ExecutedBeforeInitiated", func(t *testing.T) {
+		v, _, b := setup()
+		init := initiatingLog(1)
+		header := &types.Header{Number: big.NewInt(1), Time: 12}
+		init.Index = 1
+		exec := executingLog(t, chainB, header, init)
+		header, logs := b.addBlock(12, exec, init)
+		require.ErrorIs(t, v.ValidateBlock(chainB, header, types.Receipts{{Logs: logs}}), ErrInvalidExecutingMessage)
+	})
+
+	t.Run("ChainNotInDependencySet", func(t *testing.T) {
+		v, a, b := setup()
+		initHeader, initLogs := a.addBlock(10, initiatingLog(1))
+		header, logs := b.addBlock(12, executingLog(t, 1234, initHeader, initLogs[0]))
+		err := v.ValidateBlock(chainB, header, types.Receipts{{Logs: logs}})
+		require.ErrorIs(t, err, ErrInvalidExecutingMessage)
+		require.ErrorIs(t, err, ErrChainNotInDependencies)
+	})
+
+	t.Run("FutureTimestamp", func(t *testing.T) {
+		v, a, b := setup()
+		initHeader, initLogs := a.addBlock(14, initiatingLog(1))
+		header, logs := b.addBlock(12, executingLog(t, chainA, initHeader, initLogs[0]))
+		require.ErrorIs(t, v.ValidateBlock(chainB, header, types.Receipts{{Logs: logs}}), ErrInvalidExecutingMessage)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		v, a, b := setup()
+		initHeader, initLogs := a.addBlock(10, initiatingLog(1))
+		header, logs := b.addBlock(1011, executingLog(t, chainA, initHeader, initLogs[0]))
+		require.ErrorIs(t, v.ValidateBlock(chainB, header, types.Receipts{{Logs: logs}}), ErrInvalidExecutingMessage)
+	})
+
+	t.Run("UnknownBlock", func(t *testing.T) {
+		v, _, b := setup()
+		initHeader := &types.Header{Number: big.NewInt(5), Time: 10}
+		header, logs := b.addBlock(12, executingLog(t, chainA, initHeader, initiatingLog(1)))
+		require.ErrorIs(t, v.ValidateBlock(chainB, header, types.Receipts{{Logs: logs}}), ErrInvalidExecutingMessage)
+	})
+
+	t.Run("WrongTimestamp", func(t *testing.T) {
+		v, a, b := setup()
+		initHeader, initLogs := a.addBlock(10, initiatingLog(1))
+		modified := types.CopyHeader(initHeader)
+		modified.Time = 11
+		header, logs := b.addBlock(12, executingLog(t, chainA, modified, initLogs[0]))
+		require.ErrorIs(t, v.ValidateBlock(chainB, header, types.Receipts{{Logs: logs}}), ErrInvalidExecutingMessage)
+	})
+
+	t.Run("WrongPayload", func(t *testing.T) {
+		v, a, b := setup()
+		initHeader, initLogs := a.addBlock(10, initiatingLog(1))
+		modified := initiatingLog(2)
+		modified.Index = initLogs[0].Index
+		header, logs := b.addBlock(12, executingLog(t, chainA, initHeader, modified))
+		require.ErrorIs(t, v.ValidateBlock(chainB, header, types.Receipts{{Logs: logs}}), ErrInvalidExecutingMessage)
+	})
+
+	t.Run("MissingLog", func(t *testing.T) {
+		v, a, b := setup()
+		initHeader, _ := a.addBlock(10, initiatingLog(1))
+		missing := initiatingLog(1)
+		missing.Index = 1
+		header, logs := b.addBlock(12, executingLog(t, chainA, initHeader, missing))
+		require.ErrorIs(t, v.ValidateBlock(chainB, header, types.Receipts{{Logs: logs}}), ErrInvalidExecutingMessage)
+	})
+
+	t.Run("ExecutingChainNotInDependencySet", func(t *testing.T) {
+		v, _, b := setup()
+		header, logs := b.addBlock(10)
+		require.ErrorIs(t, v.ValidateBlock(1234, header, types.Receipts{{Logs: logs}}), ErrChainNotInDependencies)
+	})
+}
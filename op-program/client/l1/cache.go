@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 
+	"github.com/ethereum-optimism/optimism/op-program/client/memory"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -104,3 +105,27 @@ func (o *CachingOracle) Precompile(address common.Address, input []byte, require
 	o.pcmps.Add(cacheKey, precompileResult{res, ok})
 	return res, ok
 }
+
+var _ memory.Reporter = (*CachingOracle)(nil)
+
+// MemoryUsage reports the number and estimated size of the cached results.
+func (o *CachingOracle) MemoryUsage() []memory.Usage {
+	var txsSize, rcptsSize uint64
+	for _, txs := range o.txs.Values() {
+		for _, tx := range txs {
+			txsSize += tx.Size()
+		}
+	}
+	for _, rcpts := range o.rcpts.Values() {
+		for _, rcpt := range rcpts {
+			rcptsSize += uint64(rcpt.Size())
+		}
+	}
+	return []memory.Usage{
+		{Name: "l1 headers cache", Entries: o.blocks.Len()},
+		{Name: "l1 transactions cache", Entries: o.txs.Len(), Bytes: txsSize},
+		{Name: "l1 receipts cache", Entries: o.rcpts.Len(), Bytes: rcptsSize},
+		{Name: "l1 blobs cache", Entries: o.blobs.Len(), Bytes: uint64(o.blobs.Len()) * eth.BlobSize},
+		{Name: "l1 precompile results cache", Entries: o.pcmps.Len()},
+	}
+}
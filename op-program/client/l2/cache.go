@@ -1,6 +1,7 @@
 package l2
 
 import (
+	"github.com/ethereum-optimism/optimism/op-program/client/memory"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -74,3 +75,25 @@ func (o *CachingOracle) OutputByRoot(root common.Hash) eth.Output {
 	o.outputs.Add(root, output)
 	return output
 }
+
+var _ memory.Reporter = (*CachingOracle)(nil)
+
+// MemoryUsage reports the number and estimated size of the cached results.
+func (o *CachingOracle) MemoryUsage() []memory.Usage {
+	var blocksSize, nodesSize, codesSize uint64
+	for _, block := range o.blocks.Values() {
+		blocksSize += block.Size()
+	}
+	for _, node := range o.nodes.Values() {
+		nodesSize += uint64(len(node))
+	}
+	for _, code := range o.codes.Values() {
+		codesSize += uint64(len(code))
+	}
+	return []memory.Usage{
+		{Name: "l2 blocks cache", Entries: o.blocks.Len(), Bytes: blocksSize},
+		{Name: "l2 trie nodes cache", Entries: o.nodes.Len(), Bytes: nodesSize},
+		{Name: "l2 code cache", Entries: o.codes.Len(), Bytes: codesSize},
+		{Name: "l2 outputs cache", Entries: o.outputs.Len()},
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+
+	"github.com/ethereum-optimism/optimism/op-program/client/memory"
 )
 
 var codePrefixedKeyLength = common.HashLength + len(rawdb.CodePrefix)
@@ -58,6 +60,15 @@ func (o *OracleKeyValueStore) Put(key []byte, value []byte) error {
 	return o.db.Put(key, value)
 }
 
+// MemoryUsage reports the number of trie nodes and code entries written to the in-memory database.
+func (o *OracleKeyValueStore) MemoryUsage() []memory.Usage {
+	var entries int
+	if db, ok := o.db.(*memorydb.Database); ok {
+		entries = db.Len()
+	}
+	return []memory.Usage{{Name: "l2 written state", Entries: entries}}
+}
+
 func (o *OracleKeyValueStore) Close() error {
 	return nil
 }
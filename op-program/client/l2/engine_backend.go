@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum-optimism/optimism/op-program/client/l2/engineapi"
+	"github.com/ethereum-optimism/optimism/op-program/client/memory"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
@@ -34,8 +35,9 @@ type OracleBackedL2Chain struct {
 	earliestIndexedBlock *types.Header
 
 	// Inserted blocks
-	blocks map[common.Hash]*types.Block
-	db     ethdb.KeyValueStore
+	blocks   map[common.Hash]*types.Block
+	receipts map[common.Hash]types.Receipts
+	db       ethdb.KeyValueStore
 }
 
 var _ engineapi.EngineBackend = (*OracleBackedL2Chain)(nil)
@@ -65,6 +67,7 @@ func NewOracleBackedL2Chain(logger log.Logger, oracle Oracle, precompileOracle e
 		finalized:  head.Header(),
 		oracleHead: head.Header(),
 		blocks:     make(map[common.Hash]*types.Block),
+		receipts:   make(map[common.Hash]types.Receipts),
 		db:         NewOracleBackedDB(oracle),
 		vmCfg: vm.Config{
 			PrecompileOverrides: engineapi.CreatePrecompileOverrides(precompileOracle),
@@ -72,6 +75,11 @@ func NewOracleBackedL2Chain(logger log.Logger, oracle Oracle, precompileOracle e
 	}, nil
 }
 
+// AgreedHeader returns the header of the agreed L2 block the chain was started from.
+func (o *OracleBackedL2Chain) AgreedHeader() *types.Header {
+	return o.oracleHead
+}
+
 func (o *OracleBackedL2Chain) CurrentHeader() *types.Header {
 	return o.head
 }
@@ -201,9 +209,40 @@ func (o *OracleBackedL2Chain) InsertBlockWithoutSetHead(block *types.Block) erro
 		return fmt.Errorf("commit block: %w", err)
 	}
 	o.blocks[block.Hash()] = block
+	o.receipts[block.Hash()] = processor.Receipts()
 	return nil
 }
 
+var _ memory.Reporter = (*OracleBackedL2Chain)(nil)
+
+// MemoryUsage reports the number and estimated size of the inserted blocks, their receipts and the state they wrote.
+func (o *OracleBackedL2Chain) MemoryUsage() []memory.Usage {
+	var blocksSize, rcptsSize uint64
+	for _, block := range o.blocks {
+		blocksSize += block.Size()
+	}
+	for _, rcpts := range o.receipts {
+		for _, rcpt := range rcpts {
+			rcptsSize += uint64(rcpt.Size())
+		}
+	}
+	usage := []memory.Usage{
+		{Name: "l2 inserted blocks", Entries: len(o.blocks), Bytes: blocksSize},
+		{Name: "l2 inserted receipts", Entries: len(o.receipts), Bytes: rcptsSize},
+	}
+	if db, ok := o.db.(memory.Reporter); ok {
+		usage = append(usage, db.MemoryUsage()...)
+	}
+	return usage
+}
+
+// InsertedReceipts returns the receipts of a block that was inserted into the chain.
+// Returns false if the block was not inserted, e.g. because it was retrieved from the oracle.
+func (o *OracleBackedL2Chain) InsertedReceipts(hash common.Hash) (types.Receipts, bool) {
+	receipts, ok := o.receipts[hash]
+	return receipts, ok
+}
+
 func (o *OracleBackedL2Chain) SetCanonical(head *types.Block) (common.Hash, error) {
 	oldHead := o.head
 	o.head = head.Header()
@@ -123,6 +123,11 @@ func (b *BlockProcessor) AddTx(tx *types.Transaction) error {
 	return nil
 }
 
+// Receipts returns the receipts of the transactions added to the block so far.
+func (b *BlockProcessor) Receipts() types.Receipts {
+	return b.receipts
+}
+
 func (b *BlockProcessor) Assemble() (*types.Block, error) {
 	body := types.Body{
 		Transactions: b.transactions,
@@ -1,6 +1,6 @@
 // This file contains code of the upstream go-ethereum kzgPointEvaluation implementation.
-// Modifications have been made, primarily to substitute kzgPointEvaluation, ecrecover, and runBn256Pairing
-// functions to interact with the preimage oracle.
+// Modifications have been made, primarily to substitute kzgPointEvaluation, ecrecover, runBn256Pairing
+// and the BLS12-381 functions to interact with the preimage oracle.
 //
 // Original copyright disclaimer, applicable only to this file:
 // -------------------------------------------------------------------
@@ -27,6 +27,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"reflect"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -41,6 +42,15 @@ var (
 	ecrecoverPrecompileAddress          = common.BytesToAddress([]byte{0x1})
 	bn256PairingPrecompileAddress       = common.BytesToAddress([]byte{0x8})
 	kzgPointEvaluationPrecompileAddress = common.BytesToAddress([]byte{0xa})
+
+	// The BLS12-381 precompiles, as specified by the final EIP-2537 and activated on L1 with Prague
+	bls12381G1AddPrecompileAddress   = common.BytesToAddress([]byte{0x0b})
+	bls12381G1MSMPrecompileAddress   = common.BytesToAddress([]byte{0x0c})
+	bls12381G2AddPrecompileAddress   = common.BytesToAddress([]byte{0x0d})
+	bls12381G2MSMPrecompileAddress   = common.BytesToAddress([]byte{0x0e})
+	bls12381PairingPrecompileAddress = common.BytesToAddress([]byte{0x0f})
+	bls12381MapG1PrecompileAddress   = common.BytesToAddress([]byte{0x10})
+	bls12381MapG2PrecompileAddress   = common.BytesToAddress([]byte{0x11})
 )
 
 // PrecompileOracle defines the high-level API used to retrieve the result of a precompile call
@@ -54,7 +64,8 @@ func CreatePrecompileOverrides(precompileOracle PrecompileOracle) vm.PrecompileO
 		if orig == nil { // Only override existing contracts. Never introduce a precompile that is not there.
 			return nil
 		}
-		// NOTE: Ignoring chain rules for now. We assume that precompile behavior won't change for the foreseeable future
+		// NOTE: Ignoring chain rules for now, except for the BLS12-381 precompiles.
+		// We assume that precompile behavior won't change for the foreseeable future
 		switch address {
 		case ecrecoverPrecompileAddress:
 			return &ecrecoverOracle{Orig: orig, Oracle: precompileOracle}
@@ -63,6 +74,11 @@ func CreatePrecompileOverrides(precompileOracle PrecompileOracle) vm.PrecompileO
 		case kzgPointEvaluationPrecompileAddress:
 			return &kzgPointEvaluationOracle{Orig: orig, Oracle: precompileOracle}
 		default:
+			// The BLS12-381 precompiles are only accelerated once activated by the fork,
+			// and only if the L2 precompile performs the same operation as the L1 precompile at the address.
+			if spec, ok := bls12381Precompiles[address]; ok && rules.IsPrague && spec.implementedBy(orig) {
+				return &bls12381Oracle{Orig: orig, Oracle: precompileOracle, Address: address, Spec: spec}
+			}
 			return orig
 		}
 	}
@@ -223,3 +239,105 @@ func (b *kzgPointEvaluationOracle) Run(input []byte) ([]byte, error) {
 	}
 	return result, nil
 }
+
+// bls12381Precompile describes the encoding of the input and output of an EIP-2537 BLS12-381 precompile.
+type bls12381Precompile struct {
+	// inputLength is the length of the input, or of each element of the input if the precompile takes a list
+	inputLength int
+	// list indicates the input is a non-empty list of elements of inputLength
+	list bool
+	// outputLength is the length of the output of a successful call
+	outputLength int
+	// contract is the geth implementation of the precompile
+	contract vm.PrecompiledContract
+}
+
+func (p bls12381Precompile) validInputLength(n int) bool {
+	if p.list {
+		return n > 0 && n%p.inputLength == 0
+	}
+	return n == p.inputLength
+}
+
+// implementedBy returns true if the given precompile performs the same operation as the L1 precompile.
+func (p bls12381Precompile) implementedBy(contract vm.PrecompiledContract) bool {
+	return reflect.TypeOf(contract) == reflect.TypeOf(p.contract)
+}
+
+const (
+	bls12381G1PointLength = 128
+	bls12381G2PointLength = 256
+)
+
+// bls12381Precompiles are the BLS12-381 precompiles by their address on L1.
+// The geth Prague precompiles still follow the draft EIP-2537 layout, with separate multiplication precompiles.
+// The L1 precompiles are implemented by the geth precompile of the same operation at its draft address.
+var bls12381Precompiles = map[common.Address]bls12381Precompile{
+	bls12381G1AddPrecompileAddress: {inputLength: 256, outputLength: bls12381G1PointLength,
+		contract: vm.PrecompiledContractsPrague[common.BytesToAddress([]byte{0x0b})]},
+	bls12381G1MSMPrecompileAddress: {inputLength: 160, list: true, outputLength: bls12381G1PointLength,
+		contract: vm.PrecompiledContractsPrague[common.BytesToAddress([]byte{0x0d})]},
+	bls12381G2AddPrecompileAddress: {inputLength: 512, outputLength: bls12381G2PointLength,
+		contract: vm.PrecompiledContractsPrague[common.BytesToAddress([]byte{0x0e})]},
+	bls12381G2MSMPrecompileAddress: {inputLength: 288, list: true, outputLength: bls12381G2PointLength,
+		contract: vm.PrecompiledContractsPrague[common.BytesToAddress([]byte{0x10})]},
+	bls12381PairingPrecompileAddress: {inputLength: 384, list: true, outputLength: 32,
+		contract: vm.PrecompiledContractsPrague[common.BytesToAddress([]byte{0x11})]},
+	bls12381MapG1PrecompileAddress: {inputLength: 64, outputLength: bls12381G1PointLength,
+		contract: vm.PrecompiledContractsPrague[common.BytesToAddress([]byte{0x12})]},
+	bls12381MapG2PrecompileAddress: {inputLength: 128, outputLength: bls12381G2PointLength,
+		contract: vm.PrecompiledContractsPrague[common.BytesToAddress([]byte{0x13})]},
+}
+
+// BLS12381PrecompiledContract returns the implementation of the L1 BLS12-381 precompile at the address,
+// or nil if the address is not a BLS12-381 precompile.
+func BLS12381PrecompiledContract(address common.Address) vm.PrecompiledContract {
+	return bls12381Precompiles[address].contract
+}
+
+// IsBLS12381Precompile returns true if the address is one of the EIP-2537 BLS12-381 precompiles.
+func IsBLS12381Precompile(address common.Address) bool {
+	_, ok := bls12381Precompiles[address]
+	return ok
+}
+
+// ValidBLS12381InputLength returns true if the input has a valid length for the BLS12-381 precompile at the address.
+// Only inputs of a valid length are sent to the preimage oracle.
+func ValidBLS12381InputLength(address common.Address, input []byte) bool {
+	spec, ok := bls12381Precompiles[address]
+	return ok && spec.validInputLength(len(input))
+}
+
+// bls12381Oracle implements the EIP-2537 BLS12-381 precompiles,
+// using the preimage-oracle to perform the point verification and curve operations.
+type bls12381Oracle struct {
+	Orig    vm.PrecompiledContract
+	Oracle  PrecompileOracle
+	Address common.Address
+	Spec    bls12381Precompile
+}
+
+func (b *bls12381Oracle) RequiredGas(input []byte) uint64 {
+	return b.Orig.RequiredGas(input)
+}
+
+func (b *bls12381Oracle) Run(input []byte) ([]byte, error) {
+	// Modification note: inputs of an invalid length are rejected by the original precompile
+	// before doing any curve arithmetic, so they are cheap to handle without the oracle.
+	if !b.Spec.validInputLength(len(input)) {
+		return b.Orig.Run(input)
+	}
+	// Modification note: below replaces point decoding, subgroup checks and the curve operation.
+	// Assumes both L2 and the L1 oracle have an identical range of valid points
+	result, ok := b.Oracle.Precompile(b.Address, input, b.RequiredGas(input))
+	if !ok {
+		return nil, fmt.Errorf("invalid BLS12-381 precompile %s input", b.Address)
+	}
+	if len(result) != b.Spec.outputLength {
+		panic(fmt.Errorf("unexpected result length %d from BLS12-381 precompile %s", len(result), b.Address))
+	}
+	if b.Address == bls12381PairingPrecompileAddress && !bytes.Equal(result, true32Byte) && !bytes.Equal(result, false32Byte) {
+		panic("unexpected result from BLS12-381 pairing check")
+	}
+	return result, nil
+}
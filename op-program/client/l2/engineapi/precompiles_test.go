@@ -0,0 +1,80 @@
+package engineapi
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+type stubPrecompileOracle struct {
+	calls  []common.Address
+	result []byte
+}
+
+func (s *stubPrecompileOracle) Precompile(address common.Address, input []byte, requiredGas uint64) ([]byte, bool) {
+	s.calls = append(s.calls, address)
+	return s.result, true
+}
+
+func TestBLS12381PrecompileOverrides(t *testing.T) {
+	g1MSM := vm.PrecompiledContractsPrague[common.BytesToAddress([]byte{0x0d})]
+	draftG1Mul := vm.PrecompiledContractsPrague[common.BytesToAddress([]byte{0x0c})]
+
+	t.Run("NotActive", func(t *testing.T) {
+		overrides := CreatePrecompileOverrides(&stubPrecompileOracle{})
+		require.Same(t, g1MSM, overrides(params.Rules{IsCancun: true}, g1MSM, bls12381G1MSMPrecompileAddress))
+	})
+
+	t.Run("DifferentOperation", func(t *testing.T) {
+		// A precompile that does not match the L1 precompile at the address must not be accelerated
+		overrides := CreatePrecompileOverrides(&stubPrecompileOracle{})
+		require.Same(t, draftG1Mul, overrides(params.Rules{IsPrague: true}, draftG1Mul, bls12381G1MSMPrecompileAddress))
+	})
+
+	t.Run("Accelerated", func(t *testing.T) {
+		oracle := &stubPrecompileOracle{result: make([]byte, bls12381G1PointLength)}
+		overrides := CreatePrecompileOverrides(oracle)
+		precompile := overrides(params.Rules{IsPrague: true}, g1MSM, bls12381G1MSMPrecompileAddress)
+		require.IsType(t, &bls12381Oracle{}, precompile)
+
+		result, err := precompile.Run(make([]byte, 320))
+		require.NoError(t, err)
+		require.Equal(t, make([]byte, bls12381G1PointLength), result)
+		require.Equal(t, []common.Address{bls12381G1MSMPrecompileAddress}, oracle.calls)
+
+		// Inputs of an invalid length are handled without the oracle
+		_, err = precompile.Run(make([]byte, 100))
+		require.Error(t, err)
+		require.Len(t, oracle.calls, 1)
+	})
+}
+
+func TestBLS12381PrecompilesMatchL1(t *testing.T) {
+	// The final EIP-2537 layout, as activated on L1 with Prague
+	expected := map[byte]int{
+		0x0b: bls12381G1PointLength, // G1 Add
+		0x0c: bls12381G1PointLength, // G1 MSM
+		0x0d: bls12381G2PointLength, // G2 Add
+		0x0e: bls12381G2PointLength, // G2 MSM
+		0x0f: 32,                    // Pairing
+		0x10: bls12381G1PointLength, // Map Fp to G1
+		0x11: bls12381G2PointLength, // Map Fp2 to G2
+	}
+	require.Len(t, bls12381Precompiles, len(expected))
+	for addr, outputLength := range expected {
+		address := common.BytesToAddress([]byte{addr})
+		spec, ok := bls12381Precompiles[address]
+		require.True(t, ok, "missing precompile %s", address)
+		require.Equal(t, outputLength, spec.outputLength)
+		require.NotNil(t, BLS12381PrecompiledContract(address))
+		// The implementation must perform the operation of the L1 precompile, on an input of points at infinity or zero
+		input := make([]byte, spec.inputLength)
+		require.True(t, spec.validInputLength(len(input)))
+		result, err := BLS12381PrecompiledContract(address).Run(input)
+		require.NoError(t, err)
+		require.Len(t, result, outputLength)
+	}
+}
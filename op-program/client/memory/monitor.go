@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"runtime/metrics"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var ErrMemoryLimitExceeded = errors.New("memory limit exceeded")
+
+const (
+	liveHeapMetric    = "/gc/heap/live:bytes"
+	heapObjectsMetric = "/gc/heap/objects:objects"
+	totalMemoryMetric = "/memory/classes/total:bytes"
+)
+
+// Usage is the memory used by a component of the program, reported when the memory limit is exceeded.
+type Usage struct {
+	Name string
+	// Entries is the number of items the component holds
+	Entries int
+	// Bytes is an estimate of the size of the items, or 0 if it is not known
+	Bytes uint64
+}
+
+// Reporter is a component of the program that holds on to a significant amount of memory, like caches.
+type Reporter interface {
+	MemoryUsage() []Usage
+}
+
+// Monitor checks the live heap of the program against a memory limit,
+// and logs the memory usage of the reporters when it is exceeded.
+type Monitor struct {
+	logger    log.Logger
+	limit     uint64
+	reporters []Reporter
+	samples   []metrics.Sample
+}
+
+// NewMonitor creates a Monitor for the limit in bytes. The monitor is disabled if the limit is 0.
+// The limit is also set as the soft memory limit of the Go runtime,
+// so the garbage collector tries to keep the heap below it before the monitor reports it as exceeded.
+func NewMonitor(logger log.Logger, limit uint64) *Monitor {
+	if limit != 0 {
+		debug.SetMemoryLimit(int64(limit))
+	}
+	return &Monitor{
+		logger: logger,
+		limit:  limit,
+		samples: []metrics.Sample{
+			{Name: liveHeapMetric},
+			{Name: heapObjectsMetric},
+			{Name: totalMemoryMetric},
+		},
+	}
+}
+
+// AddReporter adds a component to report the memory usage of when the limit is exceeded.
+func (m *Monitor) AddReporter(r Reporter) {
+	m.reporters = append(m.reporters, r)
+}
+
+// Check returns an error wrapping ErrMemoryLimitExceeded if the live heap, as of the last garbage collection,
+// exceeds the memory limit. The memory usage of the reporters is logged before returning the error.
+func (m *Monitor) Check() error {
+	if m.limit == 0 {
+		return nil
+	}
+	metrics.Read(m.samples)
+	if m.samples[0].Value.Kind() != metrics.KindUint64 {
+		return nil
+	}
+	live := m.samples[0].Value.Uint64()
+	if live <= m.limit {
+		return nil
+	}
+	m.logDiagnostics(live)
+	return fmt.Errorf("%w: live heap of %d MiB exceeds the limit of %d MiB", ErrMemoryLimitExceeded, live>>20, m.limit>>20)
+}
+
+func (m *Monitor) logDiagnostics(live uint64) {
+	m.logger.Error("Memory limit exceeded", "liveHeap", live, "limit", m.limit,
+		"heapObjects", sampleUint64(m.samples[1]), "totalMemory", sampleUint64(m.samples[2]))
+	var usages []Usage
+	for _, r := range m.reporters {
+		usages = append(usages, r.MemoryUsage()...)
+	}
+	// Largest first, by estimated size if known, and by number of entries otherwise
+	slices.SortStableFunc(usages, func(a, b Usage) int {
+		if c := cmp.Compare(b.Bytes, a.Bytes); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.Entries, a.Entries)
+	})
+	for _, u := range usages {
+		m.logger.Error("Memory usage", "component", u.Name, "entries", u.Entries, "bytes", u.Bytes)
+	}
+}
+
+func sampleUint64(s metrics.Sample) uint64 {
+	if s.Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return s.Value.Uint64()
+}
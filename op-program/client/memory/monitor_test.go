@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"math"
+	"runtime"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type stubReporter []Usage
+
+func (s stubReporter) MemoryUsage() []Usage {
+	return s
+}
+
+func TestMonitor(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		m := NewMonitor(testlog.Logger(t, log.LevelInfo), 0)
+		require.NoError(t, m.Check())
+	})
+
+	t.Run("BelowLimit", func(t *testing.T) {
+		// MaxInt64 is the default memory limit of the Go runtime
+		m := NewMonitor(testlog.Logger(t, log.LevelInfo), math.MaxInt64)
+		runtime.GC()
+		require.NoError(t, m.Check())
+	})
+
+	t.Run("Exceeded", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+		// Construct directly to not change the memory limit of the test process
+		m := &Monitor{logger: logger, limit: 1, samples: NewMonitor(logger, 0).samples}
+		m.AddReporter(stubReporter{{Name: "small", Entries: 100, Bytes: 10}, {Name: "unknown", Entries: 5}})
+		m.AddReporter(stubReporter{{Name: "large", Entries: 1, Bytes: 1000}})
+		runtime.GC() // the live heap is only known after a garbage collection
+		require.ErrorIs(t, m.Check(), ErrMemoryLimitExceeded)
+
+		require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Memory limit exceeded")))
+		usages := logs.FindLogs(testlog.NewMessageFilter("Memory usage"))
+		require.Len(t, usages, 3)
+		require.Equal(t, "large", usages[0].AttrValue("component"))
+		require.Equal(t, "small", usages[1].AttrValue("component"))
+		require.Equal(t, "unknown", usages[2].AttrValue("component"))
+	})
+}
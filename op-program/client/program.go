@@ -5,26 +5,43 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-program/client/claim"
 	cldr "github.com/ethereum-optimism/optimism/op-program/client/driver"
+	"github.com/ethereum-optimism/optimism/op-program/client/interop"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/client/l2"
+	"github.com/ethereum-optimism/optimism/op-program/client/memory"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 )
 
+// MemoryLimitEnvVar is the environment variable with the memory limit of the client program in MiB.
+// The host sets it when running the client program with --exec.
+const MemoryLimitEnvVar = "OP_PROGRAM_CLIENT_MEMORY_LIMIT"
+
+// DefaultMemoryLimitMiB is the memory limit of the client program in MiB if MemoryLimitEnvVar is not set,
+// e.g. when running in a fault proof VM. It can be set at build time with -ldflags. No limit if empty or 0.
+var DefaultMemoryLimitMiB = ""
+
 // Main executes the client program in a detached context and exits the current process.
 // The client runtime environment must be preset before calling this function.
 func Main(logger log.Logger) {
 	log.Info("Starting fault proof program client")
+	memoryLimit, err := memoryLimitFromEnv()
+	if err != nil {
+		log.Error("Invalid memory limit", "err", err)
+		os.Exit(2)
+	}
 	preimageOracle := preimage.ClientPreimageChannel()
 	preimageHinter := preimage.ClientHinterChannel()
-	if err := RunProgram(logger, preimageOracle, preimageHinter); errors.Is(err, claim.ErrClaimNotValid) {
+	if err := RunProgram(logger, preimageOracle, preimageHinter, WithMemoryLimit(memoryLimit)); errors.Is(err, claim.ErrClaimNotValid) {
 		log.Error("Claim is invalid", "err", err)
 		os.Exit(1)
 	} else if err != nil {
@@ -36,42 +53,181 @@ func Main(logger log.Logger) {
 	}
 }
 
+// memoryLimitFromEnv returns the memory limit in bytes from MemoryLimitEnvVar, or DefaultMemoryLimitMiB if unset.
+func memoryLimitFromEnv() (uint64, error) {
+	limit, ok := os.LookupEnv(MemoryLimitEnvVar)
+	if !ok {
+		limit = DefaultMemoryLimitMiB
+	}
+	if limit == "" {
+		return 0, nil
+	}
+	mib, err := strconv.ParseUint(limit, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid memory limit %q: %w", limit, err)
+	}
+	return mib << 20, nil
+}
+
+type programCfg struct {
+	memoryLimit uint64
+}
+
+type ProgramOpt func(c *programCfg)
+
+// WithMemoryLimit limits the live heap of the program to the given number of bytes.
+// The program fails with memory.ErrMemoryLimitExceeded, after logging the memory usage of its caches,
+// when the limit is exceeded. No limit if 0.
+func WithMemoryLimit(limit uint64) ProgramOpt {
+	return func(c *programCfg) {
+		c.memoryLimit = limit
+	}
+}
+
 // RunProgram executes the Program, while attached to an IO based pre-image oracle, to be served by a host.
-func RunProgram(logger log.Logger, preimageOracle io.ReadWriter, preimageHinter io.ReadWriter) error {
+func RunProgram(logger log.Logger, preimageOracle io.ReadWriter, preimageHinter io.ReadWriter, opts ...ProgramOpt) error {
+	return runProgram(logger, preimageOracle, preimageHinter, nil, opts...)
+}
+
+// RangeClaim is a claimed output root of an L2 block before the claimed block, verified in range mode.
+type RangeClaim struct {
+	BlockNumber uint64
+	OutputRoot  common.Hash
+}
+
+// RunRangeProgram executes the Program like RunProgram, and additionally verifies the claimed output roots of
+// blocks before the claimed block. Derivation only runs once, up to the claimed block, and the output roots of the
+// range claims are computed from the derived chain.
+// The range claims are not pre-images, so range mode is only available when the program runs natively in the host.
+func RunRangeProgram(logger log.Logger, preimageOracle io.ReadWriter, preimageHinter io.ReadWriter, rangeClaims []RangeClaim, opts ...ProgramOpt) error {
+	return runProgram(logger, preimageOracle, preimageHinter, rangeClaims, opts...)
+}
+
+func runProgram(logger log.Logger, preimageOracle io.ReadWriter, preimageHinter io.ReadWriter, rangeClaims []RangeClaim, opts ...ProgramOpt) error {
+	cfg := &programCfg{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
 	pClient := preimage.NewOracleClient(preimageOracle)
 	hClient := preimage.NewHintWriter(preimageHinter)
 	l1PreimageOracle := l1.NewCachingOracle(l1.NewPreimageOracle(pClient, hClient))
 	l2PreimageOracle := l2.NewCachingOracle(l2.NewPreimageOracle(pClient, hClient))
+	mem := memory.NewMonitor(logger, cfg.memoryLimit)
+	mem.AddReporter(l1PreimageOracle)
+	mem.AddReporter(l2PreimageOracle)
 
 	bootInfo := NewBootstrapClient(pClient).BootInfo()
 	logger.Info("Program Bootstrapped", "bootInfo", bootInfo)
+	if bootInfo.Interop != nil {
+		if len(rangeClaims) != 0 {
+			return errors.New("range verification is not supported for interop chains")
+		}
+		return runInteropDerivation(
+			logger,
+			hClient,
+			mem,
+			bootInfo,
+			l1PreimageOracle,
+			l2PreimageOracle,
+			interop.NewPreimageOracle(pClient, hClient),
+		)
+	}
 	return runDerivation(
 		logger,
+		hClient,
+		mem,
 		bootInfo.RollupConfig,
 		bootInfo.L2ChainConfig,
 		bootInfo.L1Head,
 		bootInfo.L2OutputRoot,
 		bootInfo.L2Claim,
 		bootInfo.L2ClaimBlockNumber,
+		rangeClaims,
 		l1PreimageOracle,
 		l2PreimageOracle,
 	)
 }
 
 // runDerivation executes the L2 state transition, given a minimal interface to retrieve data.
-func runDerivation(logger log.Logger, cfg *rollup.Config, l2Cfg *params.ChainConfig, l1Head common.Hash, l2OutputRoot common.Hash, l2Claim common.Hash, l2ClaimBlockNum uint64, l1Oracle l1.Oracle, l2Oracle l2.Oracle) error {
-	l1Source := l1.NewOracleL1Client(logger, l1Oracle, l1Head)
-	l1BlobsSource := l1.NewBlobFetcher(logger, l1Oracle)
+// The output roots of the range claims, if any, are validated before the claim.
+func runDerivation(logger log.Logger, hinter preimage.Hinter, mem *memory.Monitor, cfg *rollup.Config, l2Cfg *params.ChainConfig, l1Head common.Hash, l2OutputRoot common.Hash, l2Claim common.Hash, l2ClaimBlockNum uint64, rangeClaims []RangeClaim, l1Oracle l1.Oracle, l2Oracle l2.Oracle) error {
+	engineBackend, l2Source, err := newL2Engine(logger, mem, cfg, l2Cfg, l2OutputRoot, l1Oracle, l2Oracle)
+	if err != nil {
+		return err
+	}
+	if err := derive(logger, mem, cfg, l1Head, l2ClaimBlockNum, l1Oracle, l2Source); err != nil {
+		return err
+	}
+	for _, rangeClaim := range rangeClaims {
+		if err := claim.ValidateClaim(logger, rangeClaim.BlockNumber, eth.Bytes32(rangeClaim.OutputRoot), l2Source); err != nil {
+			return fmt.Errorf("range claim at block %d: %w", rangeClaim.BlockNumber, err)
+		}
+	}
+	return validateClaim(logger, hinter, engineBackend, l2ClaimBlockNum, eth.Bytes32(l2Claim), l2Source)
+}
+
+// runInteropDerivation executes the L2 state transition of an interop-enabled chain. Derived blocks are only
+// promoted to cross-safe once their executing messages are validated against the other chains in the dependency set,
+// and a derived block with an invalid executing message is replaced with a deposits-only block.
+func runInteropDerivation(logger log.Logger, hinter preimage.Hinter, mem *memory.Monitor, bootInfo *BootInfo, l1Oracle l1.Oracle, l2Oracle l2.Oracle, interopOracle interop.Oracle) error {
+	engineBackend, l2Source, err := newL2Engine(logger, mem, bootInfo.RollupConfig, bootInfo.L2ChainConfig, bootInfo.L2OutputRoot, l1Oracle, l2Oracle)
+	if err != nil {
+		return err
+	}
+	chains := make(map[uint64]interop.Chain, len(bootInfo.Interop.Chains))
+	for _, chain := range bootInfo.Interop.Chains {
+		if chain.ChainID == bootInfo.L2ChainID {
+			chains[chain.ChainID] = interop.NewDerivedChain(chain.ChainID, engineBackend, interopOracle)
+			continue
+		}
+		output, ok := interopOracle.OutputByRoot(chain.ChainID, chain.OutputRoot).(*eth.OutputV0)
+		if !ok {
+			return fmt.Errorf("unsupported L2 output version of chain %d", chain.ChainID)
+		}
+		chains[chain.ChainID] = interop.NewOracleChain(chain.ChainID, interopOracle, output.BlockHash)
+	}
+	validator := interop.NewValidator(logger, bootInfo.Interop.DependencySet, chains)
+	interopDeriver := interop.NewDeriver(logger, bootInfo.RollupConfig, validator, chains[bootInfo.L2ChainID], l2Source)
+	if err := derive(logger, mem, bootInfo.RollupConfig, bootInfo.L1Head, bootInfo.L2ClaimBlockNumber, l1Oracle, l2Source, interopDeriver); err != nil {
+		return err
+	}
+	return validateClaim(logger, hinter, engineBackend, bootInfo.L2ClaimBlockNumber, eth.Bytes32(bootInfo.L2Claim), l2Source)
+}
+
+// validateClaim computes the output root of the claimed block, reports it to the host, and validates the claim.
+func validateClaim(logger log.Logger, hinter preimage.Hinter, engineBackend *l2.OracleBackedL2Chain, l2ClaimBlockNum uint64, l2Claim eth.Bytes32, l2Source claim.L2Source) error {
+	l2Head, outputRoot, err := claim.ComputeOutputRoot(l2ClaimBlockNum, l2Source)
+	if err != nil {
+		return err
+	}
+	hinter.Hint(ProgramResultHint{
+		OutputRoot:        outputRoot,
+		BlockHash:         engineBackend.GetCanonicalHash(min(l2ClaimBlockNum, l2Head.Number)),
+		AgreedBlockNumber: engineBackend.AgreedHeader().Number.Uint64(),
+		SafeHeadNumber:    l2Head.Number,
+	})
+	return claim.CheckOutputRoot(logger, l2Head, outputRoot, l2Claim)
+}
+
+// newL2Engine creates the L2 engine, backed by the oracle, on top of the agreed output root.
+func newL2Engine(logger log.Logger, mem *memory.Monitor, cfg *rollup.Config, l2Cfg *params.ChainConfig, l2OutputRoot common.Hash, l1Oracle l1.Oracle, l2Oracle l2.Oracle) (*l2.OracleBackedL2Chain, *l2.OracleEngine, error) {
 	engineBackend, err := l2.NewOracleBackedL2Chain(logger, l2Oracle, l1Oracle /* kzg oracle */, l2Cfg, l2OutputRoot)
 	if err != nil {
-		return fmt.Errorf("failed to create oracle-backed L2 chain: %w", err)
+		return nil, nil, fmt.Errorf("failed to create oracle-backed L2 chain: %w", err)
 	}
-	l2Source := l2.NewOracleEngine(cfg, logger, engineBackend)
+	mem.AddReporter(engineBackend)
+	return engineBackend, l2.NewOracleEngine(cfg, logger, engineBackend), nil
+}
+
+// derive runs the derivation of the L2 chain from the agreed output root up to the claimed block number.
+func derive(logger log.Logger, mem *memory.Monitor, cfg *rollup.Config, l1Head common.Hash, l2ClaimBlockNum uint64, l1Oracle l1.Oracle, l2Source *l2.OracleEngine, extra ...event.Deriver) error {
+	l1Source := l1.NewOracleL1Client(logger, l1Oracle, l1Head)
+	l1BlobsSource := l1.NewBlobFetcher(logger, l1Oracle)
 
 	logger.Info("Starting derivation")
-	d := cldr.NewDriver(logger, cfg, l1Source, l1BlobsSource, l2Source, l2ClaimBlockNum)
+	d := cldr.NewDriver(logger, cfg, l1Source, l1BlobsSource, l2Source, l2ClaimBlockNum, mem, extra...)
 	if err := d.RunComplete(); err != nil {
 		return fmt.Errorf("failed to run program to completion: %w", err)
 	}
-	return claim.ValidateClaim(logger, l2ClaimBlockNum, eth.Bytes32(l2Claim), l2Source)
+	return nil
 }
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// HintProgramResult is the hint the program sends with its ProgramResult once derivation completes.
+// The host does not fetch any pre-images for it, it's only used to report the run.
+const HintProgramResult = "program-result"
+
+const programResultLength = 32 + 32 + 8 + 8
+
+// ProgramResult is the result of the derivation of the program, before the claim is validated.
+type ProgramResult struct {
+	// OutputRoot is the output root computed for the claimed block, or for the safe head if the claimed block was not reached
+	OutputRoot eth.Bytes32
+	// BlockHash is the hash of the L2 block of the output root
+	BlockHash common.Hash
+	// AgreedBlockNumber is the number of the L2 block of the agreed output root derivation started from
+	AgreedBlockNumber uint64
+	// SafeHeadNumber is the number of the L2 safe head once derivation completed
+	SafeHeadNumber uint64
+}
+
+type ProgramResultHint ProgramResult
+
+var _ preimage.Hint = ProgramResultHint{}
+
+func (r ProgramResultHint) Hint() string {
+	data := make([]byte, 0, programResultLength)
+	data = append(data, r.OutputRoot[:]...)
+	data = append(data, r.BlockHash[:]...)
+	data = binary.BigEndian.AppendUint64(data, r.AgreedBlockNumber)
+	data = binary.BigEndian.AppendUint64(data, r.SafeHeadNumber)
+	return HintProgramResult + " " + hexutil.Encode(data)
+}
+
+// ParseProgramResult decodes the data of a HintProgramResult hint.
+func ParseProgramResult(data []byte) (ProgramResult, error) {
+	if len(data) != programResultLength {
+		return ProgramResult{}, fmt.Errorf("invalid program result length: %d", len(data))
+	}
+	return ProgramResult{
+		OutputRoot:        eth.Bytes32(common.BytesToHash(data[:32])),
+		BlockHash:         common.BytesToHash(data[32:64]),
+		AgreedBlockNumber: binary.BigEndian.Uint64(data[64:72]),
+		SafeHeadNumber:    binary.BigEndian.Uint64(data[72:80]),
+	}, nil
+}
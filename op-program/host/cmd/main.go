@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/ethereum-optimism/optimism/op-program/host"
@@ -44,7 +45,26 @@ func run(args []string, action ConfigAction) error {
 	app.Name = "op-program"
 	app.Usage = "Optimism Fault Proof Program"
 	app.Description = "The Optimism Fault Proof Program fault proof program that runs through the rollup state-transition to verify an L2 output from L1 inputs."
-	app.Action = func(ctx *cli.Context) error {
+	app.Action = configAction(action, false)
+	app.Commands = []*cli.Command{
+		{
+			Name:  "server",
+			Usage: "Serve pre-images to fault proof VMs over a socket",
+			Description: "Run in pre-image server mode, serving hints and pre-image requests of any number of clients on the --listen address, " +
+				"instead of the inherited file descriptors. The first byte a client writes on a connection selects the channel: 'h' for hints, 'p' for pre-images.",
+			Flags:  flags.ServerFlags,
+			Action: configAction(action, true),
+		},
+		MigrateKVCommand,
+	}
+
+	return app.Run(args)
+}
+
+// configAction creates the config from the CLI arguments, and calls the supplied ConfigAction.
+// The server command always runs in server mode, and requires a listen address.
+func configAction(action ConfigAction, serverCmd bool) cli.ActionFunc {
+	return func(ctx *cli.Context) error {
 		logger, err := setupLogging(ctx)
 		if err != nil {
 			return err
@@ -55,10 +75,14 @@ func run(args []string, action ConfigAction) error {
 		if err != nil {
 			return err
 		}
+		if serverCmd {
+			if cfg.ServerListenAddr == "" {
+				return fmt.Errorf("flag %s is required", flags.ServerListen.Name)
+			}
+			cfg.ServerMode = true
+		}
 		return action(logger, cfg)
 	}
-
-	return app.Run(args)
 }
 
 func setupLogging(ctx *cli.Context) (log.Logger, error) {
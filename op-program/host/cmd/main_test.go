@@ -4,12 +4,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/flags"
 	"github.com/ethereum-optimism/optimism/op-program/host/types"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
@@ -88,7 +91,7 @@ func TestNetwork(t *testing.T) {
 	})
 
 	t.Run("Required", func(t *testing.T) {
-		verifyArgsInvalid(t, "flag rollup.config or network is required", addRequiredArgsExcept("--network"))
+		verifyArgsInvalid(t, "flag rollup.config, network or network.config is required", addRequiredArgsExcept("--network"))
 	})
 
 	t.Run("DisallowNetworkAndRollupConfig", func(t *testing.T) {
@@ -115,12 +118,94 @@ func TestNetwork(t *testing.T) {
 	}
 }
 
+func TestNetworkConfig(t *testing.T) {
+	configHash, err := config.ChainConfigHash(chaincfg.Sepolia, l2GenesisConfig)
+	require.NoError(t, err)
+
+	t.Run("Bundle", func(t *testing.T) {
+		dir := writeNetworkBundle(t, nil)
+		cfg := configForArgs(t, addRequiredArgsExcept("--network", "--network.config", dir))
+		require.Equal(t, *chaincfg.Sepolia, *cfg.Rollup)
+		require.Equal(t, l2GenesisConfig, cfg.L2ChainConfig)
+		require.True(t, cfg.IsCustomChainConfig)
+	})
+
+	t.Run("PrestatePinsHash", func(t *testing.T) {
+		dir := writeNetworkBundle(t, &config.PrestateMetadata{AbsolutePrestate: common.Hash{0xaa}, ConfigHash: configHash})
+		cfg := configForArgs(t, addRequiredArgsExcept("--network", "--network.config", dir))
+		require.Equal(t, *chaincfg.Sepolia, *cfg.Rollup)
+	})
+
+	t.Run("PrestateHashMismatch", func(t *testing.T) {
+		dir := writeNetworkBundle(t, &config.PrestateMetadata{AbsolutePrestate: common.Hash{0xaa}, ConfigHash: common.Hash{0xbb}})
+		verifyArgsInvalid(t, config.ErrChainConfigHashMismatch.Error(), addRequiredArgsExcept("--network", "--network.config", dir))
+	})
+
+	t.Run("DisallowNetworkAndBundle", func(t *testing.T) {
+		dir := writeNetworkBundle(t, nil)
+		verifyArgsInvalid(t, "cannot specify network.config with rollup.config, network or l2.genesis", addRequiredArgs("--network.config", dir))
+	})
+
+	t.Run("PinnedHash", func(t *testing.T) {
+		dir := writeNetworkBundle(t, nil)
+		cfg := configForArgs(t, addRequiredArgsExcept("--network", "--network.config", dir, "--network.config.hash", configHash.Hex()))
+		require.Equal(t, *chaincfg.Sepolia, *cfg.Rollup)
+	})
+
+	t.Run("PinnedHashRollupConfig", func(t *testing.T) {
+		configFile := writeValidRollupConfig(t)
+		genesisFile := writeValidGenesis(t)
+		cfg := configForArgs(t, addRequiredArgsExcept("--network", "--rollup.config", configFile, "--l2.genesis", genesisFile,
+			"--network.config.hash", configHash.Hex()))
+		require.Equal(t, *chaincfg.Sepolia, *cfg.Rollup)
+	})
+
+	t.Run("PinnedHashMismatch", func(t *testing.T) {
+		dir := writeNetworkBundle(t, nil)
+		verifyArgsInvalid(t, config.ErrChainConfigHashMismatch.Error(),
+			addRequiredArgsExcept("--network", "--network.config", dir, "--network.config.hash", common.Hash{0xbb}.Hex()))
+	})
+
+	t.Run("PinnedHashPredefinedNetwork", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag network.config.hash is only supported for custom chains",
+			addRequiredArgs("--network.config.hash", configHash.Hex()))
+	})
+}
+
 func TestDataDir(t *testing.T) {
 	expected := "/tmp/mainTestDataDir"
 	cfg := configForArgs(t, addRequiredArgs("--datadir", expected))
 	require.Equal(t, expected, cfg.DataDir)
 }
 
+func TestCache(t *testing.T) {
+	t.Run("DefaultDisabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, "", cfg.CacheDir)
+		require.Equal(t, uint64(10<<30), cfg.CacheMaxSize)
+	})
+	t.Run("Set", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--cache.dir", "/tmp/mainTestCacheDir", "--cache.max-size", "512"))
+		require.Equal(t, "/tmp/mainTestCacheDir", cfg.CacheDir)
+		require.Equal(t, uint64(512<<20), cfg.CacheMaxSize)
+	})
+}
+
+func TestPrefetchParallelism(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, uint64(flags.DefaultPrefetchParallelism), cfg.PrefetchParallelism)
+	})
+	t.Run("Set", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--prefetch.parallelism", "16"))
+		require.Equal(t, uint64(16), cfg.PrefetchParallelism)
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--prefetch.parallelism", "0"))
+		require.Equal(t, uint64(0), cfg.PrefetchParallelism)
+	})
+}
+
 func TestDataFormat(t *testing.T) {
 	for _, format := range types.SupportedDataFormats {
 		format := format
@@ -138,7 +223,42 @@ func TestDataFormat(t *testing.T) {
 func TestL2(t *testing.T) {
 	expected := "https://example.com:8545"
 	cfg := configForArgs(t, addRequiredArgs("--l2", expected))
-	require.Equal(t, expected, cfg.L2URL)
+	require.Equal(t, []string{expected}, cfg.L2URLs)
+}
+
+func TestMultipleEndpoints(t *testing.T) {
+	t.Run("RepeatedFlags", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--l1", "http://a:8545", "--l1", "http://b:8545", "--l2", "http://c:8545", "--l1.beacon", "http://d:5052"))
+		require.Equal(t, []string{"http://a:8545", "http://b:8545"}, cfg.L1URLs)
+		require.Equal(t, []string{"http://c:8545"}, cfg.L2URLs)
+		require.Equal(t, []string{"http://d:5052"}, cfg.L1BeaconURLs)
+	})
+	t.Run("CommaSeparated", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--l2", "http://a:8545,http://b:8545", "--l1.beacon", "http://c:5052,http://d:5052"))
+		require.Equal(t, []string{"http://a:8545", "http://b:8545"}, cfg.L2URLs)
+		require.Equal(t, []string{"http://c:5052", "http://d:5052"}, cfg.L1BeaconURLs)
+	})
+}
+
+func TestL1BlobSources(t *testing.T) {
+	t.Run("DefaultNone", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Empty(t, cfg.L1BlobSources)
+	})
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(
+			"--l1.blob-sources", "http://archiver:5052",
+			"--l1.blob-sources", "blobscan:https://api.blobscan.com",
+			"--l1.blob-sources", "beacon:http://beacon:5052"))
+		require.Equal(t, []types.BlobSource{
+			{Kind: types.BlobSourceBeacon, URL: "http://archiver:5052"},
+			{Kind: types.BlobSourceBlobscan, URL: "https://api.blobscan.com"},
+			{Kind: types.BlobSourceBeacon, URL: "http://beacon:5052"},
+		}, cfg.L1BlobSources)
+	})
+	t.Run("MissingURL", func(t *testing.T) {
+		verifyArgsInvalid(t, "missing url in blobscan blob source", addRequiredArgs("--l1.blob-sources", "blobscan:"))
+	})
 }
 
 func TestL2Genesis(t *testing.T) {
@@ -208,7 +328,7 @@ func TestL1Head(t *testing.T) {
 func TestL1(t *testing.T) {
 	expected := "https://example.com:8545"
 	cfg := configForArgs(t, addRequiredArgs("--l1", expected))
-	require.Equal(t, expected, cfg.L1URL)
+	require.Equal(t, []string{expected}, cfg.L1URLs)
 }
 
 func TestL1TrustRPC(t *testing.T) {
@@ -301,6 +421,83 @@ func TestExec(t *testing.T) {
 	})
 }
 
+func TestVerifyRange(t *testing.T) {
+	t.Run("DefaultDisabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.False(t, cfg.VerifyRange)
+		require.EqualValues(t, 1, cfg.RangeStep)
+	})
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--l2.range", "--l2.range.step", "10"))
+		require.True(t, cfg.VerifyRange)
+		require.EqualValues(t, 10, cfg.RangeStep)
+	})
+}
+
+func TestL2CrossCheck(t *testing.T) {
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, "", cfg.L2CrossCheckURL)
+	})
+	t.Run("Set", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--l2.crosscheck", "http://reth:8545"))
+		require.Equal(t, "http://reth:8545", cfg.L2CrossCheckURL)
+	})
+	t.Run("JWTSecret", func(t *testing.T) {
+		secretFile := filepath.Join(t.TempDir(), "jwt.txt")
+		require.NoError(t, os.WriteFile(secretFile, []byte("0x"+strings.Repeat("ab", 32)+"\n"), 0600))
+		cfg := configForArgs(t, addRequiredArgs("--l2.crosscheck", "http://reth:8551", "--l2.crosscheck.jwt-secret", secretFile))
+		var expected [32]byte
+		for i := range expected {
+			expected[i] = 0xab
+		}
+		require.Equal(t, expected, cfg.L2CrossCheckJWT)
+	})
+	t.Run("InvalidJWTSecret", func(t *testing.T) {
+		secretFile := filepath.Join(t.TempDir(), "jwt.txt")
+		require.NoError(t, os.WriteFile(secretFile, []byte("0x1234"), 0600))
+		verifyArgsInvalid(t, "invalid jwt secret", addRequiredArgs("--l2.crosscheck.jwt-secret", secretFile))
+	})
+	t.Run("MissingJWTSecret", func(t *testing.T) {
+		verifyArgsInvalid(t, "failed to read cross-check jwt secret", addRequiredArgs("--l2.crosscheck.jwt-secret", filepath.Join(t.TempDir(), "missing")))
+	})
+}
+
+func TestClientMemoryLimit(t *testing.T) {
+	t.Run("DefaultUnlimited", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, uint64(0), cfg.ClientMemoryLimit)
+	})
+	t.Run("Set", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--client.memory-limit", "2048"))
+		require.Equal(t, uint64(2048<<20), cfg.ClientMemoryLimit)
+	})
+}
+
+func TestReport(t *testing.T) {
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, "", cfg.ReportPath)
+	})
+	t.Run("Set", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--report", "/tmp/report.json"))
+		require.Equal(t, "/tmp/report.json", cfg.ReportPath)
+	})
+}
+
+func TestMetrics(t *testing.T) {
+	t.Run("DefaultDisabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.False(t, cfg.MetricsConfig.Enabled)
+	})
+	t.Run("ServerMode", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--server", "--metrics.enabled", "--metrics.addr", "127.0.0.1", "--metrics.port", "7301"))
+		require.True(t, cfg.MetricsConfig.Enabled)
+		require.Equal(t, "127.0.0.1", cfg.MetricsConfig.ListenAddr)
+		require.Equal(t, 7301, cfg.MetricsConfig.ListenPort)
+	})
+}
+
 func TestServerMode(t *testing.T) {
 	t.Run("DefaultFalse", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs())
@@ -323,6 +520,20 @@ func TestServerMode(t *testing.T) {
 	})
 }
 
+func TestServerCommand(t *testing.T) {
+	t.Run("Listen", func(t *testing.T) {
+		cfg := configForArgs(t, append([]string{"server"}, addRequiredArgs("--listen", "unix:///tmp/host.sock")...))
+		require.True(t, cfg.ServerMode)
+		require.Equal(t, "unix:///tmp/host.sock", cfg.ServerListenAddr)
+	})
+	t.Run("ListenRequired", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag listen is required", append([]string{"server"}, addRequiredArgs()...))
+	})
+	t.Run("NotAvailableWithoutCommand", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag provided but not defined: -listen", addRequiredArgs("--listen", "unix:///tmp/host.sock"))
+	})
+}
+
 func verifyArgsInvalid(t *testing.T, messageContains string, cliArgs []string) {
 	_, _, err := runWithArgs(cliArgs)
 	require.ErrorContains(t, err, messageContains)
@@ -386,6 +597,22 @@ func writeValidGenesis(t *testing.T) string {
 	return genesisFile
 }
 
+func writeNetworkBundle(t *testing.T, prestate *config.PrestateMetadata) string {
+	dir := t.TempDir()
+	rollupCfg, err := json.Marshal(chaincfg.Sepolia)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, config.BundleRollupConfigFile), rollupCfg, 0666))
+	genesis, err := json.Marshal(l2Genesis)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, config.BundleGenesisFile), genesis, 0666))
+	if prestate != nil {
+		metadata, err := json.Marshal(prestate)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, config.BundlePrestateFile), metadata, 0666))
+	}
+	return dir
+}
+
 func writeValidRollupConfig(t *testing.T) string {
 	dir := t.TempDir()
 	j, err := json.Marshal(chaincfg.Sepolia)
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	MigrateKVSourceFlag = &cli.StringFlag{
+		Name:     "source",
+		Usage:    "Directory of the existing preimage data storage, in the file or directory format",
+		Required: true,
+	}
+	MigrateKVDestFlag = &cli.StringFlag{
+		Name:     "dest",
+		Usage:    "Directory to write the migrated preimage data storage to. Must not exist or be empty",
+		Required: true,
+	}
+	MigrateKVDestFormatFlag = &cli.StringFlag{
+		Name:  "dest.format",
+		Usage: fmt.Sprintf("Format of the migrated preimage data storage. Available formats: %s", openum.EnumString(types.SupportedDataFormats)),
+		Value: string(types.DataFormatPebble),
+	}
+)
+
+var MigrateKVCommand = &cli.Command{
+	Name:  "migrate-kv",
+	Usage: "Migrate preimage data storage to a different format",
+	Description: "Copy all preimages of a file or directory format data directory into a new data directory of the given format. " +
+		"The source data directory is not modified.",
+	Action: func(ctx *cli.Context) error {
+		logger, err := setupLogging(ctx)
+		if err != nil {
+			return err
+		}
+		format := types.DataFormat(ctx.String(MigrateKVDestFormatFlag.Name))
+		if !slices.Contains(types.SupportedDataFormats, format) {
+			return fmt.Errorf("invalid data format: %s", format)
+		}
+		src, dest := ctx.String(MigrateKVSourceFlag.Name), ctx.String(MigrateKVDestFlag.Name)
+		logger.Info("Migrating preimage data", "source", src, "dest", dest, "format", format)
+		count, err := kvstore.MigrateDiskKV(logger, src, dest, format)
+		if err != nil {
+			return fmt.Errorf("failed to migrate preimage data: %w", err)
+		}
+		logger.Info("Migrated preimage data", "count", count)
+		return nil
+	},
+	Flags: []cli.Flag{
+		MigrateKVSourceFlag,
+		MigrateKVDestFlag,
+		MigrateKVDestFormatFlag,
+	},
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	opnode "github.com/ethereum-optimism/optimism/op-node"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-program/client"
+)
+
+const (
+	BundleRollupConfigFile = "rollup.json"
+	BundleGenesisFile      = "genesis.json"
+	BundlePrestateFile     = "prestate.json"
+)
+
+var ErrChainConfigHashMismatch = errors.New("chain config hash mismatch")
+
+// PrestateMetadata describes the absolute prestate of the fault proof program built for the chain of a bundle.
+type PrestateMetadata struct {
+	// AbsolutePrestate is the absolute prestate hash of the fault proof program
+	AbsolutePrestate common.Hash `json:"absolutePrestate"`
+	// ConfigHash is the hash of the chain configuration of the bundle, pinned by the prestate
+	ConfigHash common.Hash `json:"configHash"`
+}
+
+// NetworkBundle is the configuration of a chain that is not embedded in the program, loaded from a directory.
+type NetworkBundle struct {
+	Rollup        *rollup.Config
+	L2ChainConfig *params.ChainConfig
+	// Prestate is nil if the bundle has no prestate metadata
+	Prestate *PrestateMetadata
+}
+
+// LoadNetworkBundle loads a network config bundle from a directory with a rollup config, an op-geth genesis file and
+// optionally the prestate metadata. The config hash of the prestate metadata must match the chain configuration.
+func LoadNetworkBundle(logger log.Logger, dir string) (*NetworkBundle, error) {
+	rollupCfg, err := opnode.NewRollupConfig(logger, "", filepath.Join(dir, BundleRollupConfigFile))
+	if err != nil {
+		return nil, fmt.Errorf("invalid rollup config in bundle %s: %w", dir, err)
+	}
+	l2ChainConfig, err := loadChainConfigFromGenesis(filepath.Join(dir, BundleGenesisFile))
+	if err != nil {
+		return nil, fmt.Errorf("invalid genesis in bundle %s: %w", dir, err)
+	}
+	bundle := &NetworkBundle{Rollup: rollupCfg, L2ChainConfig: l2ChainConfig}
+	data, err := os.ReadFile(filepath.Join(dir, BundlePrestateFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return bundle, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read prestate metadata: %w", err)
+	}
+	var prestate PrestateMetadata
+	if err := json.Unmarshal(data, &prestate); err != nil {
+		return nil, fmt.Errorf("parse prestate metadata: %w", err)
+	}
+	hash, err := ChainConfigHash(rollupCfg, l2ChainConfig)
+	if err != nil {
+		return nil, err
+	}
+	if hash != prestate.ConfigHash {
+		return nil, fmt.Errorf("%w: bundle %s has hash %s, prestate metadata pins %s", ErrChainConfigHashMismatch, dir, hash, prestate.ConfigHash)
+	}
+	bundle.Prestate = &prestate
+	return bundle, nil
+}
+
+// ChainConfigHash returns the hash of a custom chain configuration that the client program commits to in its boot info.
+func ChainConfigHash(rollupCfg *rollup.Config, l2ChainConfig *params.ChainConfig) (common.Hash, error) {
+	rollupData, err := json.Marshal(rollupCfg)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("encode rollup config: %w", err)
+	}
+	l2ChainConfigData, err := json.Marshal(l2ChainConfig)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("encode l2 chain config: %w", err)
+	}
+	return client.CustomChainConfigHash(rollupData, l2ChainConfigData), nil
+}
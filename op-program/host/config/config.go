@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"slices"
+	"strings"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-program/host/types"
@@ -13,6 +14,7 @@ import (
 	opnode "github.com/ethereum-optimism/optimism/op-node"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-program/host/flags"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -32,7 +34,16 @@ var (
 	ErrInvalidL2ClaimBlock = errors.New("invalid l2 claim block number")
 	ErrDataDirRequired     = errors.New("datadir must be specified when in non-fetching mode")
 	ErrNoExecInServerMode  = errors.New("exec command must not be set when in server mode")
+	ErrListenNotServerMode = errors.New("listen address must only be set in server mode")
 	ErrInvalidDataFormat   = errors.New("invalid data format")
+	ErrRangeNotNative      = errors.New("range mode requires running the client program in the host, without exec or server mode")
+	ErrRangeNoFetching     = errors.New("range mode requires l1 and l2 options to fetch the output roots to verify")
+	ErrInvalidRangeStep    = errors.New("invalid range step")
+	ErrCrossCheckServer    = errors.New("cross-check must not be set when in server mode")
+	ErrCrossCheckNoL2      = errors.New("cross-check requires l2 options to fetch the block to recompute")
+	ErrCrossCheckNoJWT     = errors.New("cross-check requires the jwt secret of the cross-check engine API")
+	ErrMetricsNotServer    = errors.New("metrics are only supported in server mode")
+	ErrRangeInterop        = errors.New("range mode is not supported for interop chains")
 )
 
 type Config struct {
@@ -43,19 +54,32 @@ type Config struct {
 
 	// DataFormat specifies the format to use for on-disk storage. Only applies when DataDir is set.
 	DataFormat types.DataFormat
+	// CacheDir is the directory of a preimage cache shared between runs. Disabled if empty.
+	CacheDir string
+	// CacheMaxSize is the maximum size of the shared preimage cache in bytes. Unlimited if 0.
+	CacheMaxSize uint64
+	// PrefetchParallelism is the maximum number of pre-images fetched concurrently in the background.
+	// Pre-images are only fetched when requested if 0.
+	PrefetchParallelism uint64
 
 	// L1Head is the block hash of the L1 chain head block
-	L1Head      common.Hash
-	L1URL       string
-	L1BeaconURL string
-	L1TrustRPC  bool
-	L1RPCKind   sources.RPCProviderKind
+	L1Head common.Hash
+	// L1URLs are interchangeable L1 RPC endpoints. Requests fail over to the next endpoint on endpoint errors.
+	L1URLs []string
+	// L1BeaconURLs are interchangeable L1 beacon API endpoints
+	L1BeaconURLs []string
+	// L1BlobSources are fallback sources for blobs that are not available from the L1 beacon API endpoints,
+	// e.g. because they expired. They are tried in order.
+	L1BlobSources []types.BlobSource
+	L1TrustRPC    bool
+	L1RPCKind     sources.RPCProviderKind
 
 	// L2Head is the l2 block hash contained in the L2 Output referenced by the L2OutputRoot
 	L2Head common.Hash
 	// L2OutputRoot is the agreed L2 output root to start derivation from
 	L2OutputRoot common.Hash
-	L2URL        string
+	// L2URLs are interchangeable L2 RPC endpoints
+	L2URLs []string
 	// L2Claim is the claimed L2 output root to verify
 	L2Claim common.Hash
 	// L2ClaimBlockNumber is the block number the claimed L2 output root is from
@@ -63,16 +87,40 @@ type Config struct {
 	L2ClaimBlockNumber uint64
 	// L2ChainConfig is the op-geth chain config for the L2 execution engine
 	L2ChainConfig *params.ChainConfig
+	// VerifyRange enables range mode: the output roots of the blocks between L2Head and L2ClaimBlockNumber are
+	// fetched from the L2 node and verified too, reusing the derivation up to the claimed block.
+	VerifyRange bool
+	// RangeStep is the number of blocks between the output roots verified in range mode
+	RangeStep uint64
+	// L2CrossCheckURL is the engine API endpoint of an L2 execution client to recompute the block of the output
+	// root computed by the program with, typically of a different implementation than op-geth. Disabled if empty.
+	L2CrossCheckURL string
+	// L2CrossCheckJWT is the JWT secret of the engine API of the cross-check execution client
+	L2CrossCheckJWT [32]byte
 	// ExecCmd specifies the client program to execute in a separate process.
 	// If unset, the fault proof client is run in the same process.
 	ExecCmd string
+	// ClientMemoryLimit is the maximum live heap of the client program in bytes. Unlimited if 0.
+	ClientMemoryLimit uint64
+	// ReportPath is the path to write a JSON report of the run to, or "-" for stdout. Disabled if empty.
+	// Not written in server mode.
+	ReportPath string
 
 	// ServerMode indicates that the program should run in pre-image server mode and wait for requests.
 	// No client program is run.
 	ServerMode bool
+	// ServerListenAddr is the address to serve hints and pre-image requests on in server mode.
+	// The pre-image and hint file descriptors inherited from the parent process are used if empty.
+	ServerListenAddr string
+	// MetricsConfig configures the metrics server, only supported in server mode.
+	MetricsConfig opmetrics.CLIConfig
 
 	// IsCustomChainConfig indicates that the program uses a custom chain configuration
 	IsCustomChainConfig bool
+
+	// Interop is the configuration of the chains in the dependency set, to prove an interop-enabled chain.
+	// The claimed chain is the chain of the rollup config. Disabled if nil.
+	Interop *InteropConfig
 }
 
 func (c *Config) Check() error {
@@ -97,7 +145,7 @@ func (c *Config) Check() error {
 	if c.L2ChainConfig == nil {
 		return ErrMissingL2Genesis
 	}
-	if (c.L1URL != "") != (c.L2URL != "") {
+	if (len(c.L1URLs) != 0) != (len(c.L2URLs) != 0) {
 		return ErrL1AndL2Inconsistent
 	}
 	if !c.FetchingEnabled() && c.DataDir == "" {
@@ -106,14 +154,53 @@ func (c *Config) Check() error {
 	if c.ServerMode && c.ExecCmd != "" {
 		return ErrNoExecInServerMode
 	}
+	if !c.ServerMode && c.ServerListenAddr != "" {
+		return ErrListenNotServerMode
+	}
 	if c.DataDir != "" && !slices.Contains(types.SupportedDataFormats, c.DataFormat) {
 		return ErrInvalidDataFormat
 	}
+	if c.L2CrossCheckURL != "" {
+		if c.ServerMode {
+			return ErrCrossCheckServer
+		}
+		if len(c.L2URLs) == 0 {
+			return ErrCrossCheckNoL2
+		}
+		if c.L2CrossCheckJWT == ([32]byte{}) {
+			return ErrCrossCheckNoJWT
+		}
+	}
+	if c.MetricsConfig.Enabled && !c.ServerMode {
+		return ErrMetricsNotServer
+	}
+	if err := c.MetricsConfig.Check(); err != nil {
+		return err
+	}
+	if c.Interop != nil {
+		if err := c.Interop.Check(c.Rollup.L2ChainID.Uint64(), c.L2OutputRoot); err != nil {
+			return err
+		}
+		if c.VerifyRange {
+			return ErrRangeInterop
+		}
+	}
+	if c.VerifyRange {
+		if c.ServerMode || c.ExecCmd != "" {
+			return ErrRangeNotNative
+		}
+		if !c.FetchingEnabled() {
+			return ErrRangeNoFetching
+		}
+		if c.RangeStep == 0 {
+			return ErrInvalidRangeStep
+		}
+	}
 	return nil
 }
 
 func (c *Config) FetchingEnabled() bool {
-	return c.L1URL != "" && c.L2URL != "" && c.L1BeaconURL != ""
+	return len(c.L1URLs) != 0 && len(c.L2URLs) != 0 && len(c.L1BeaconURLs) != 0
 }
 
 // NewConfig creates a Config with all optional values set to the CLI default value
@@ -139,6 +226,10 @@ func NewConfig(
 		L1RPCKind:           sources.RPCKindStandard,
 		IsCustomChainConfig: isCustomConfig,
 		DataFormat:          types.DataFormatDirectory,
+		CacheMaxSize:        flags.DefaultCacheMaxSizeMiB << 20,
+		PrefetchParallelism: flags.DefaultPrefetchParallelism,
+		RangeStep:           1,
+		MetricsConfig:       opmetrics.DefaultCLIConfig(),
 	}
 }
 
@@ -146,8 +237,32 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 	if err := flags.CheckRequired(ctx); err != nil {
 		return nil, err
 	}
-	rollupCfg, err := opnode.NewRollupConfigFromCLI(log, ctx)
-	if err != nil {
+	var rollupCfg *rollup.Config
+	var l2ChainConfig *params.ChainConfig
+	var isCustomConfig bool
+	if bundleDir := ctx.String(flags.NetworkConfig.Name); bundleDir != "" {
+		bundle, err := LoadNetworkBundle(log, bundleDir)
+		if err != nil {
+			return nil, err
+		}
+		rollupCfg = bundle.Rollup
+		l2ChainConfig = bundle.L2ChainConfig
+		isCustomConfig = true
+		if bundle.Prestate != nil {
+			log.Info("Loaded network config bundle", "dir", bundleDir, "absolutePrestate", bundle.Prestate.AbsolutePrestate)
+		}
+	} else {
+		var err error
+		rollupCfg, err = opnode.NewRollupConfigFromCLI(log, ctx)
+		if err != nil {
+			return nil, err
+		}
+		l2ChainConfig, isCustomConfig, err = chainConfigFromCLI(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("invalid genesis: %w", err)
+		}
+	}
+	if err := checkChainConfigHash(log, ctx, rollupCfg, l2ChainConfig, isCustomConfig); err != nil {
 		return nil, err
 	}
 	l2Head := common.HexToHash(ctx.String(flags.L2Head.Name))
@@ -171,52 +286,119 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 	if l1Head == (common.Hash{}) {
 		return nil, ErrInvalidL1Head
 	}
-	l2GenesisPath := ctx.String(flags.L2GenesisPath.Name)
-	var l2ChainConfig *params.ChainConfig
-	var isCustomConfig bool
-	if l2GenesisPath == "" {
-		networkName := ctx.String(flags.Network.Name)
-		ch := chaincfg.ChainByName(networkName)
-		if ch == nil {
-			return nil, fmt.Errorf("flag %s is required for network %s", flags.L2GenesisPath.Name, networkName)
-		}
-		cfg, err := params.LoadOPStackChainConfig(ch.ChainID)
+	dbFormat := types.DataFormat(ctx.String(flags.DataFormat.Name))
+	if !slices.Contains(types.SupportedDataFormats, dbFormat) {
+		return nil, fmt.Errorf("invalid %w: %v", ErrInvalidDataFormat, dbFormat)
+	}
+	var interopCfg *InteropConfig
+	if path := ctx.String(flags.InteropConfig.Name); path != "" {
+		var err error
+		interopCfg, err = LoadInteropConfig(path)
 		if err != nil {
-			return nil, fmt.Errorf("failed to load chain config for chain %d: %w", ch.ChainID, err)
+			return nil, err
 		}
-		l2ChainConfig = cfg
-	} else {
-		l2ChainConfig, err = loadChainConfigFromGenesis(l2GenesisPath)
-		isCustomConfig = true
 	}
-	if err != nil {
-		return nil, fmt.Errorf("invalid genesis: %w", err)
+	var blobSources []types.BlobSource
+	for _, s := range ctx.StringSlice(flags.L1BlobSources.Name) {
+		source, err := types.ParseBlobSource(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", flags.L1BlobSources.Name, err)
+		}
+		blobSources = append(blobSources, source)
 	}
-	dbFormat := types.DataFormat(ctx.String(flags.DataFormat.Name))
-	if !slices.Contains(types.SupportedDataFormats, dbFormat) {
-		return nil, fmt.Errorf("invalid %w: %v", ErrInvalidDataFormat, dbFormat)
+	var crossCheckSecret [32]byte
+	if path := strings.TrimSpace(ctx.String(flags.L2CrossCheckJWTSecret.Name)); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cross-check jwt secret: %w", err)
+		}
+		secret := common.FromHex(strings.TrimSpace(string(data)))
+		if len(secret) != 32 {
+			return nil, fmt.Errorf("invalid jwt secret in path %s, not 32 hex-formatted bytes", path)
+		}
+		copy(crossCheckSecret[:], secret)
 	}
 	return &Config{
 		Rollup:              rollupCfg,
 		DataDir:             ctx.String(flags.DataDir.Name),
 		DataFormat:          dbFormat,
-		L2URL:               ctx.String(flags.L2NodeAddr.Name),
+		CacheDir:            ctx.String(flags.CacheDir.Name),
+		CacheMaxSize:        ctx.Uint64(flags.CacheMaxSize.Name) << 20,
+		PrefetchParallelism: ctx.Uint64(flags.PrefetchParallelism.Name),
+		L2URLs:              ctx.StringSlice(flags.L2NodeAddr.Name),
 		L2ChainConfig:       l2ChainConfig,
 		L2Head:              l2Head,
 		L2OutputRoot:        l2OutputRoot,
 		L2Claim:             l2Claim,
 		L2ClaimBlockNumber:  l2ClaimBlockNum,
 		L1Head:              l1Head,
-		L1URL:               ctx.String(flags.L1NodeAddr.Name),
-		L1BeaconURL:         ctx.String(flags.L1BeaconAddr.Name),
+		L1URLs:              ctx.StringSlice(flags.L1NodeAddr.Name),
+		L1BeaconURLs:        ctx.StringSlice(flags.L1BeaconAddr.Name),
+		L1BlobSources:       blobSources,
 		L1TrustRPC:          ctx.Bool(flags.L1TrustRPC.Name),
 		L1RPCKind:           sources.RPCProviderKind(ctx.String(flags.L1RPCProviderKind.Name)),
 		ExecCmd:             ctx.String(flags.Exec.Name),
+		ClientMemoryLimit:   ctx.Uint64(flags.ClientMemoryLimit.Name) << 20,
+		ReportPath:          ctx.String(flags.Report.Name),
+		VerifyRange:         ctx.Bool(flags.VerifyRange.Name),
+		RangeStep:           ctx.Uint64(flags.RangeStep.Name),
+		L2CrossCheckURL:     ctx.String(flags.L2CrossCheck.Name),
+		L2CrossCheckJWT:     crossCheckSecret,
 		ServerMode:          ctx.Bool(flags.Server.Name),
+		ServerListenAddr:    ctx.String(flags.ServerListen.Name),
+		MetricsConfig:       opmetrics.ReadCLIConfig(ctx),
 		IsCustomChainConfig: isCustomConfig,
+		Interop:             interopCfg,
 	}, nil
 }
 
+// chainConfigFromCLI returns the chain config of the predefined network, or from the l2 genesis file of a custom chain.
+func chainConfigFromCLI(ctx *cli.Context) (*params.ChainConfig, bool, error) {
+	l2GenesisPath := ctx.String(flags.L2GenesisPath.Name)
+	if l2GenesisPath != "" {
+		l2ChainConfig, err := loadChainConfigFromGenesis(l2GenesisPath)
+		return l2ChainConfig, true, err
+	}
+	networkName := ctx.String(flags.Network.Name)
+	ch := chaincfg.ChainByName(networkName)
+	if ch == nil {
+		return nil, false, fmt.Errorf("flag %s is required for network %s", flags.L2GenesisPath.Name, networkName)
+	}
+	cfg, err := params.LoadOPStackChainConfig(ch.ChainID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load chain config for chain %d: %w", ch.ChainID, err)
+	}
+	return cfg, false, nil
+}
+
+// checkChainConfigHash checks the hash of a custom chain configuration against the hash pinned with the
+// network.config.hash flag, if any.
+func checkChainConfigHash(log log.Logger, ctx *cli.Context, rollupCfg *rollup.Config, l2ChainConfig *params.ChainConfig, isCustomConfig bool) error {
+	pinned := ctx.String(flags.NetworkConfigHash.Name)
+	if !isCustomConfig {
+		if pinned != "" {
+			return fmt.Errorf("flag %s is only supported for custom chains", flags.NetworkConfigHash.Name)
+		}
+		return nil
+	}
+	hash, err := ChainConfigHash(rollupCfg, l2ChainConfig)
+	if err != nil {
+		return err
+	}
+	log.Info("Using custom chain config", "chainID", rollupCfg.L2ChainID, "configHash", hash)
+	if pinned == "" {
+		return nil
+	}
+	expected := common.HexToHash(pinned)
+	if expected == (common.Hash{}) {
+		return fmt.Errorf("invalid %s: %v", flags.NetworkConfigHash.Name, pinned)
+	}
+	if hash != expected {
+		return fmt.Errorf("%w: chain config has hash %s, expected %s", ErrChainConfigHashMismatch, hash, expected)
+	}
+	return nil
+}
+
 func loadChainConfigFromGenesis(path string) (*params.ChainConfig, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -8,7 +8,10 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/client"
+	"github.com/ethereum-optimism/optimism/op-program/client/interop"
 	"github.com/ethereum-optimism/optimism/op-program/host/types"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
@@ -91,24 +94,24 @@ func TestL2GenesisRequired(t *testing.T) {
 func TestFetchingArgConsistency(t *testing.T) {
 	t.Run("RequireL2WhenL1Set", func(t *testing.T) {
 		cfg := validConfig()
-		cfg.L1URL = "https://example.com:1234"
+		cfg.L1URLs = []string{"https://example.com:1234"}
 		require.ErrorIs(t, cfg.Check(), ErrL1AndL2Inconsistent)
 	})
 	t.Run("RequireL1WhenL2Set", func(t *testing.T) {
 		cfg := validConfig()
-		cfg.L2URL = "https://example.com:1234"
+		cfg.L2URLs = []string{"https://example.com:1234"}
 		require.ErrorIs(t, cfg.Check(), ErrL1AndL2Inconsistent)
 	})
 	t.Run("AllowNeitherSet", func(t *testing.T) {
 		cfg := validConfig()
-		cfg.L1URL = ""
-		cfg.L2URL = ""
+		cfg.L1URLs = nil
+		cfg.L2URLs = nil
 		require.NoError(t, cfg.Check())
 	})
 	t.Run("AllowBothSet", func(t *testing.T) {
 		cfg := validConfig()
-		cfg.L1URL = "https://example.com:1234"
-		cfg.L2URL = "https://example.com:4678"
+		cfg.L1URLs = []string{"https://example.com:1234"}
+		cfg.L2URLs = []string{"https://example.com:4678"}
 		require.NoError(t, cfg.Check())
 	})
 }
@@ -121,27 +124,27 @@ func TestFetchingEnabled(t *testing.T) {
 
 	t.Run("FetchingEnabledWhenFetcherUrlsSpecified", func(t *testing.T) {
 		cfg := validConfig()
-		cfg.L2URL = "https://example.com:1234"
+		cfg.L2URLs = []string{"https://example.com:1234"}
 		require.False(t, cfg.FetchingEnabled(), "Should not enable fetching when node URL not supplied")
 	})
 
 	t.Run("FetchingNotEnabledWhenNoL1UrlSpecified", func(t *testing.T) {
 		cfg := validConfig()
-		cfg.L2URL = "https://example.com:1234"
+		cfg.L2URLs = []string{"https://example.com:1234"}
 		require.False(t, cfg.FetchingEnabled(), "Should not enable L1 fetching when L1 node URL not supplied")
 	})
 
 	t.Run("FetchingNotEnabledWhenNoL2UrlSpecified", func(t *testing.T) {
 		cfg := validConfig()
-		cfg.L1URL = "https://example.com:1234"
+		cfg.L1URLs = []string{"https://example.com:1234"}
 		require.False(t, cfg.FetchingEnabled(), "Should not enable L2 fetching when L2 node URL not supplied")
 	})
 
 	t.Run("FetchingEnabledWhenBothFetcherUrlsSpecified", func(t *testing.T) {
 		cfg := validConfig()
-		cfg.L1URL = "https://example.com:1234"
-		cfg.L1BeaconURL = "https://example.com:5678"
-		cfg.L2URL = "https://example.com:91011"
+		cfg.L1URLs = []string{"https://example.com:1234"}
+		cfg.L1BeaconURLs = []string{"https://example.com:5678"}
+		cfg.L2URLs = []string{"https://example.com:91011"}
 		require.True(t, cfg.FetchingEnabled(), "Should enable fetching when node URL supplied")
 	})
 }
@@ -149,8 +152,8 @@ func TestFetchingEnabled(t *testing.T) {
 func TestRequireDataDirInNonFetchingMode(t *testing.T) {
 	cfg := validConfig()
 	cfg.DataDir = ""
-	cfg.L1URL = ""
-	cfg.L2URL = ""
+	cfg.L1URLs = nil
+	cfg.L2URLs = nil
 	err := cfg.Check()
 	require.ErrorIs(t, err, ErrDataDirRequired)
 }
@@ -163,6 +166,137 @@ func TestRejectExecAndServerMode(t *testing.T) {
 	require.ErrorIs(t, err, ErrNoExecInServerMode)
 }
 
+func TestListenAddrRequiresServerMode(t *testing.T) {
+	cfg := validConfig()
+	cfg.ServerListenAddr = "unix:///tmp/host.sock"
+	require.ErrorIs(t, cfg.Check(), ErrListenNotServerMode)
+	cfg.ServerMode = true
+	require.NoError(t, cfg.Check())
+}
+
+func TestRejectCrossCheckInServerMode(t *testing.T) {
+	cfg := validConfig()
+	cfg.L2CrossCheckURL = "http://localhost:9546"
+	cfg.L2CrossCheckJWT = [32]byte{0x01}
+	cfg.L2URLs = []string{"http://localhost:9545"}
+	cfg.L1URLs = []string{"http://localhost:8545"}
+	require.NoError(t, cfg.Check())
+	cfg.ServerMode = true
+	require.ErrorIs(t, cfg.Check(), ErrCrossCheckServer)
+}
+
+func TestCrossCheckRequiresL2(t *testing.T) {
+	cfg := validConfig()
+	cfg.L2CrossCheckURL = "http://localhost:9546"
+	cfg.L2CrossCheckJWT = [32]byte{0x01}
+	require.ErrorIs(t, cfg.Check(), ErrCrossCheckNoL2)
+}
+
+func TestCrossCheckRequiresJWT(t *testing.T) {
+	cfg := validConfig()
+	cfg.L2CrossCheckURL = "http://localhost:9546"
+	cfg.L2URLs = []string{"http://localhost:9545"}
+	cfg.L1URLs = []string{"http://localhost:8545"}
+	require.ErrorIs(t, cfg.Check(), ErrCrossCheckNoJWT)
+}
+
+func TestMetricsOnlyInServerMode(t *testing.T) {
+	cfg := validConfig()
+	cfg.MetricsConfig.Enabled = true
+	require.ErrorIs(t, cfg.Check(), ErrMetricsNotServer)
+	cfg.ServerMode = true
+	require.NoError(t, cfg.Check())
+	cfg.MetricsConfig.ListenPort = -1
+	require.ErrorIs(t, cfg.Check(), opmetrics.ErrInvalidPort)
+}
+
+func TestVerifyRange(t *testing.T) {
+	fetchingConfig := func() *Config {
+		cfg := validConfig()
+		cfg.VerifyRange = true
+		cfg.L1URLs = []string{"http://localhost:8545"}
+		cfg.L1BeaconURLs = []string{"http://localhost:5052"}
+		cfg.L2URLs = []string{"http://localhost:9545"}
+		return cfg
+	}
+	t.Run("Valid", func(t *testing.T) {
+		require.NoError(t, fetchingConfig().Check())
+	})
+	t.Run("RequiresFetching", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.VerifyRange = true
+		require.ErrorIs(t, cfg.Check(), ErrRangeNoFetching)
+	})
+	t.Run("RejectExec", func(t *testing.T) {
+		cfg := fetchingConfig()
+		cfg.ExecCmd = "./bin/op-program-client"
+		require.ErrorIs(t, cfg.Check(), ErrRangeNotNative)
+	})
+	t.Run("RejectServerMode", func(t *testing.T) {
+		cfg := fetchingConfig()
+		cfg.ServerMode = true
+		require.ErrorIs(t, cfg.Check(), ErrRangeNotNative)
+	})
+	t.Run("InvalidStep", func(t *testing.T) {
+		cfg := fetchingConfig()
+		cfg.RangeStep = 0
+		require.ErrorIs(t, cfg.Check(), ErrInvalidRangeStep)
+	})
+}
+
+func TestInterop(t *testing.T) {
+	interopConfig := func() *Config {
+		cfg := validConfig()
+		chainID := validRollupConfig.L2ChainID.Uint64()
+		otherRollup := *validRollupConfig
+		otherRollup.L2ChainID = new(big.Int).SetUint64(chainID + 1)
+		cfg.Interop = &InteropConfig{
+			Chains: []client.InteropChain{
+				{ChainID: chainID, OutputRoot: validL2OutputRoot, RollupConfig: validRollupConfig, L2ChainConfig: validL2Genesis},
+				{ChainID: chainID + 1, OutputRoot: common.Hash{0xee}, RollupConfig: &otherRollup, L2ChainConfig: validL2Genesis},
+			},
+			DependencySet: &interop.DependencySet{
+				Chains:              []uint64{chainID, chainID + 1},
+				MessageExpiryWindow: interop.DefaultMessageExpiryWindow,
+			},
+		}
+		return cfg
+	}
+	t.Run("Valid", func(t *testing.T) {
+		require.NoError(t, interopConfig().Check())
+	})
+	t.Run("MissingDependencySet", func(t *testing.T) {
+		cfg := interopConfig()
+		cfg.Interop.DependencySet = nil
+		require.ErrorIs(t, cfg.Check(), ErrInvalidInteropConfig)
+	})
+	t.Run("ClaimedChainNotInDependencySet", func(t *testing.T) {
+		cfg := interopConfig()
+		cfg.Interop.DependencySet.Chains = cfg.Interop.DependencySet.Chains[1:]
+		require.ErrorIs(t, cfg.Check(), ErrInvalidInteropConfig)
+	})
+	t.Run("MissingChainConfig", func(t *testing.T) {
+		cfg := interopConfig()
+		cfg.Interop.Chains = cfg.Interop.Chains[:1]
+		require.ErrorIs(t, cfg.Check(), ErrInvalidInteropConfig)
+	})
+	t.Run("OutputRootMismatch", func(t *testing.T) {
+		cfg := interopConfig()
+		cfg.Interop.Chains[0].OutputRoot = common.Hash{0xff}
+		require.ErrorIs(t, cfg.Check(), ErrInvalidInteropConfig)
+	})
+	t.Run("RollupConfigMismatch", func(t *testing.T) {
+		cfg := interopConfig()
+		cfg.Interop.Chains[1].RollupConfig = validRollupConfig
+		require.ErrorIs(t, cfg.Check(), ErrInvalidInteropConfig)
+	})
+	t.Run("RejectRange", func(t *testing.T) {
+		cfg := interopConfig()
+		cfg.VerifyRange = true
+		require.ErrorIs(t, cfg.Check(), ErrRangeInterop)
+	})
+}
+
 func TestIsCustomChainConfig(t *testing.T) {
 	t.Run("nonCustom", func(t *testing.T) {
 		cfg := validConfig()
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/ethereum-optimism/optimism/op-program/client"
+	"github.com/ethereum-optimism/optimism/op-program/client/interop"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrInvalidInteropConfig = errors.New("invalid interop config")
+
+// InteropConfig is the configuration of the chains in the dependency set of an interop-enabled chain,
+// served to the client program as local pre-images.
+type InteropConfig struct {
+	// Chains are the configs and agreed output roots of all chains in the dependency set
+	Chains []client.InteropChain `json:"chains"`
+	// DependencySet is the dependency set of the claimed chain
+	DependencySet *interop.DependencySet `json:"dependencySet"`
+}
+
+// LoadInteropConfig reads the interop config from the JSON file at the given path.
+func LoadInteropConfig(path string) (*InteropConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read interop config: %w", err)
+	}
+	var cfg InteropConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse interop config: %w", err)
+	}
+	return &cfg, nil
+}
+
+// Check checks the interop config includes the claimed chain, with the agreed L2 output root,
+// and the configs of all chains in the dependency set.
+func (c *InteropConfig) Check(claimedChainID uint64, l2OutputRoot common.Hash) error {
+	if c.DependencySet == nil {
+		return fmt.Errorf("%w: missing dependency set", ErrInvalidInteropConfig)
+	}
+	if err := c.DependencySet.Check(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidInteropConfig, err)
+	}
+	if !c.DependencySet.HasChain(claimedChainID) {
+		return fmt.Errorf("%w: claimed chain %d is not in the dependency set", ErrInvalidInteropConfig, claimedChainID)
+	}
+	for i, chain := range c.Chains {
+		if chain.RollupConfig == nil || chain.L2ChainConfig == nil {
+			return fmt.Errorf("%w: missing config of chain %d", ErrInvalidInteropConfig, chain.ChainID)
+		}
+		if chain.RollupConfig.L2ChainID == nil || chain.RollupConfig.L2ChainID.Uint64() != chain.ChainID {
+			return fmt.Errorf("%w: rollup config does not match chain %d", ErrInvalidInteropConfig, chain.ChainID)
+		}
+		if slices.ContainsFunc(c.Chains[:i], func(prev client.InteropChain) bool { return prev.ChainID == chain.ChainID }) {
+			return fmt.Errorf("%w: duplicate chain %d", ErrInvalidInteropConfig, chain.ChainID)
+		}
+		if chain.ChainID == claimedChainID && chain.OutputRoot != l2OutputRoot {
+			return fmt.Errorf("%w: agreed output root of chain %d does not match the L2 output root", ErrInvalidInteropConfig, chain.ChainID)
+		}
+	}
+	for _, id := range c.DependencySet.Chains {
+		if !slices.ContainsFunc(c.Chains, func(chain client.InteropChain) bool { return chain.ChainID == id }) {
+			return fmt.Errorf("%w: missing config of chain %d", ErrInvalidInteropConfig, id)
+		}
+	}
+	return nil
+}
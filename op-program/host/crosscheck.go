@@ -0,0 +1,127 @@
+package host
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	cl "github.com/ethereum-optimism/optimism/op-program/client"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	ErrCrossCheckMismatch = errors.New("output root of the cross-check execution client does not match the program")
+	ErrCrossCheckNoResult = errors.New("cannot cross-check, the client program did not report its result")
+)
+
+// crossCheckBlockSource provides the block of the output root computed by the program, to recompute it.
+type crossCheckBlockSource interface {
+	PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayloadEnvelope, error)
+}
+
+// crossCheckEngine is the engine API of the cross-check execution client, to recompute blocks with.
+type crossCheckEngine interface {
+	ForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceState, attributes *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error)
+	GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error)
+	NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error)
+	OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error)
+}
+
+// crossCheck recomputes the block of the output root computed by the client program with the cross-check execution
+// client, typically a different implementation than the op-geth EVM of the program, and compares the results.
+// Returns an error wrapping ErrCrossCheckMismatch if they differ, or any other error if the check could not run.
+func crossCheck(ctx context.Context, logger log.Logger, cfg *config.Config, result cl.ProgramResult) error {
+	l2RPC, err := dialRPCs(ctx, logger, "L2", cfg.L2URLs)
+	if err != nil {
+		return fmt.Errorf("failed to setup L2 RPC: %w", err)
+	}
+	defer l2RPC.Close()
+	l2Cl, err := sources.NewL2Client(l2RPC, logger, nil, sources.L2ClientDefaultConfig(cfg.Rollup, false))
+	if err != nil {
+		return fmt.Errorf("failed to create L2 client: %w", err)
+	}
+
+	logger.Info("Connecting to cross-check L2 engine", "url", cfg.L2CrossCheckURL)
+	auth := rpc.WithHTTPAuth(node.NewJWTAuth(cfg.L2CrossCheckJWT))
+	engineRPC, err := client.NewRPC(ctx, logger, cfg.L2CrossCheckURL, client.WithGethRPCOptions(auth), client.WithDialBackoff(10))
+	if err != nil {
+		return fmt.Errorf("failed to dial cross-check L2 engine: %w", err)
+	}
+	defer engineRPC.Close()
+	engine, err := sources.NewEngineClient(engineRPC, logger, nil, sources.EngineClientDefaultConfig(cfg.Rollup))
+	if err != nil {
+		return fmt.Errorf("failed to create cross-check engine client: %w", err)
+	}
+	return recomputeBlock(ctx, logger, l2Cl, engine, result)
+}
+
+// recomputeBlock builds the block of the program result again on the engine, on top of its parent, from the same
+// inputs, and compares the block hash and the output root of the rebuilt block with the program.
+// The forkchoice of the engine is reset to the parent of the block.
+func recomputeBlock(ctx context.Context, logger log.Logger, src crossCheckBlockSource, engine crossCheckEngine, result cl.ProgramResult) error {
+	envelope, err := src.PayloadByHash(ctx, result.BlockHash)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve L2 block %s to recompute: %w", result.BlockHash, err)
+	}
+	payload := envelope.ExecutionPayload
+	gasLimit := payload.GasLimit
+	attrs := &eth.PayloadAttributes{
+		Timestamp:             payload.Timestamp,
+		PrevRandao:            payload.PrevRandao,
+		SuggestedFeeRecipient: payload.FeeRecipient,
+		Withdrawals:           payload.Withdrawals,
+		ParentBeaconBlockRoot: envelope.ParentBeaconBlockRoot,
+		Transactions:          payload.Transactions,
+		NoTxPool:              true,
+		GasLimit:              &gasLimit,
+	}
+	fc := &eth.ForkchoiceState{HeadBlockHash: payload.ParentHash}
+	fcRes, err := engine.ForkchoiceUpdate(ctx, fc, attrs)
+	if err != nil {
+		return fmt.Errorf("failed to start recomputing block %s on the cross-check engine: %w", payload.ID(), err)
+	}
+	if fcRes.PayloadStatus.Status != eth.ExecutionValid {
+		return fmt.Errorf("cross-check engine cannot build on parent %s of block %s: %w", payload.ParentHash, payload.ID(), eth.ForkchoiceUpdateErr(fcRes.PayloadStatus))
+	}
+	if fcRes.PayloadID == nil {
+		return fmt.Errorf("cross-check engine did not start recomputing block %s", payload.ID())
+	}
+	rebuilt, err := engine.GetPayload(ctx, eth.PayloadInfo{ID: *fcRes.PayloadID, Timestamp: uint64(payload.Timestamp)})
+	if err != nil {
+		return fmt.Errorf("failed to retrieve recomputed block %s from the cross-check engine: %w", payload.ID(), err)
+	}
+	recomputed := rebuilt.ExecutionPayload
+	if recomputed.BlockHash != payload.BlockHash {
+		logger.Error("Cross-check execution client recomputed a different block", "block", payload.ID(),
+			"stateRoot", payload.StateRoot, "crossCheckHash", recomputed.BlockHash, "crossCheckStateRoot", recomputed.StateRoot)
+		return fmt.Errorf("%w: block %s state root program: %v cross-check: %v (block %v)",
+			ErrCrossCheckMismatch, payload.ID(), payload.StateRoot, recomputed.StateRoot, recomputed.BlockHash)
+	}
+
+	status, err := engine.NewPayload(ctx, recomputed, rebuilt.ParentBeaconBlockRoot)
+	if err != nil {
+		return fmt.Errorf("failed to insert recomputed block %s in the cross-check engine: %w", payload.ID(), err)
+	}
+	if status.Status != eth.ExecutionValid {
+		return fmt.Errorf("cross-check engine did not accept recomputed block %s: %w", payload.ID(), eth.NewPayloadErr(recomputed, status))
+	}
+	output, err := engine.OutputV0AtBlock(ctx, recomputed.BlockHash)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve output of recomputed block %s from the cross-check engine: %w", payload.ID(), err)
+	}
+	expected := common.Hash(eth.OutputRoot(output))
+	computed := common.Hash(result.OutputRoot)
+	if expected != computed {
+		logger.Error("Cross-check execution client disagrees with the program", "block", payload.ID(), "program", computed, "crossCheck", expected)
+		return fmt.Errorf("%w: block %s program: %v cross-check: %v", ErrCrossCheckMismatch, payload.ID(), computed, expected)
+	}
+	logger.Info("Cross-check execution client agrees with the program", "block", payload.ID(), "output", computed)
+	return nil
+}
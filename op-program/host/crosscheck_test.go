@@ -0,0 +1,135 @@
+package host
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	cl "github.com/ethereum-optimism/optimism/op-program/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+type stubCrossCheckBlockSource struct {
+	envelope *eth.ExecutionPayloadEnvelope
+}
+
+func (s *stubCrossCheckBlockSource) PayloadByHash(_ context.Context, hash common.Hash) (*eth.ExecutionPayloadEnvelope, error) {
+	if s.envelope.ExecutionPayload.BlockHash != hash {
+		return nil, errors.New("not found")
+	}
+	return s.envelope, nil
+}
+
+type stubCrossCheckEngine struct {
+	fcStatus      eth.ExecutePayloadStatus
+	rebuilt       eth.ExecutionPayload
+	insertStatus  eth.ExecutePayloadStatus
+	fc            *eth.ForkchoiceState
+	attrs         *eth.PayloadAttributes
+	inserted      *eth.ExecutionPayload
+	outputStorage common.Hash
+}
+
+func (s *stubCrossCheckEngine) ForkchoiceUpdate(_ context.Context, fc *eth.ForkchoiceState, attributes *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	s.fc, s.attrs = fc, attributes
+	res := &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: s.fcStatus}}
+	if s.fcStatus == eth.ExecutionValid {
+		res.PayloadID = &eth.PayloadID{0x01}
+	}
+	return res, nil
+}
+
+func (s *stubCrossCheckEngine) GetPayload(_ context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error) {
+	if payloadInfo.ID != (eth.PayloadID{0x01}) {
+		return nil, errors.New("unknown payload")
+	}
+	return &eth.ExecutionPayloadEnvelope{ExecutionPayload: &s.rebuilt}, nil
+}
+
+func (s *stubCrossCheckEngine) NewPayload(_ context.Context, payload *eth.ExecutionPayload, _ *common.Hash) (*eth.PayloadStatusV1, error) {
+	s.inserted = payload
+	return &eth.PayloadStatusV1{Status: s.insertStatus}, nil
+}
+
+func (s *stubCrossCheckEngine) OutputV0AtBlock(_ context.Context, blockHash common.Hash) (*eth.OutputV0, error) {
+	if s.inserted == nil || s.inserted.BlockHash != blockHash {
+		return nil, errors.New("not found")
+	}
+	return &eth.OutputV0{StateRoot: eth.Bytes32(s.inserted.StateRoot), MessagePasserStorageRoot: eth.Bytes32(s.outputStorage), BlockHash: blockHash}, nil
+}
+
+func TestRecomputeBlock(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	block := eth.ExecutionPayload{
+		ParentHash:   common.Hash{0x01},
+		BlockHash:    common.Hash{0x02},
+		StateRoot:    eth.Bytes32{0x03},
+		BlockNumber:  10,
+		Timestamp:    1000,
+		GasLimit:     30_000_000,
+		Transactions: []eth.Data{{0x7e, 0x01}, {0x02, 0x03}},
+	}
+	src := &stubCrossCheckBlockSource{envelope: &eth.ExecutionPayloadEnvelope{ExecutionPayload: &block}}
+	result := cl.ProgramResult{
+		OutputRoot: eth.OutputRoot(&eth.OutputV0{StateRoot: block.StateRoot, MessagePasserStorageRoot: eth.Bytes32{0x04}, BlockHash: block.BlockHash}),
+		BlockHash:  block.BlockHash,
+	}
+	newEngine := func() *stubCrossCheckEngine {
+		return &stubCrossCheckEngine{
+			fcStatus:      eth.ExecutionValid,
+			rebuilt:       block,
+			insertStatus:  eth.ExecutionValid,
+			outputStorage: common.Hash{0x04},
+		}
+	}
+
+	t.Run("Match", func(t *testing.T) {
+		engine := newEngine()
+		require.NoError(t, recomputeBlock(context.Background(), logger, src, engine, result))
+		require.Equal(t, block.ParentHash, engine.fc.HeadBlockHash, "must build on the parent")
+		require.Equal(t, block.Transactions, engine.attrs.Transactions)
+		require.True(t, engine.attrs.NoTxPool)
+		require.Equal(t, block.Timestamp, engine.attrs.Timestamp)
+		require.Equal(t, block.GasLimit, *engine.attrs.GasLimit)
+	})
+	t.Run("BlockMismatch", func(t *testing.T) {
+		engine := newEngine()
+		engine.rebuilt.BlockHash = common.Hash{0xaa}
+		engine.rebuilt.StateRoot = eth.Bytes32{0xbb}
+		err := recomputeBlock(context.Background(), logger, src, engine, result)
+		require.ErrorIs(t, err, ErrCrossCheckMismatch)
+		require.Equal(t, ExitCrossCheckMismatch, exitReason(err))
+		require.Nil(t, engine.inserted, "must not insert a different block")
+	})
+	t.Run("OutputMismatch", func(t *testing.T) {
+		engine := newEngine()
+		engine.outputStorage = common.Hash{0xaa}
+		err := recomputeBlock(context.Background(), logger, src, engine, result)
+		require.ErrorIs(t, err, ErrCrossCheckMismatch)
+	})
+	t.Run("UnknownBlock", func(t *testing.T) {
+		res := result
+		res.BlockHash = common.Hash{0xaa}
+		err := recomputeBlock(context.Background(), logger, src, newEngine(), res)
+		require.ErrorContains(t, err, "failed to retrieve L2 block")
+		require.NotErrorIs(t, err, ErrCrossCheckMismatch)
+	})
+	t.Run("UnknownParent", func(t *testing.T) {
+		engine := newEngine()
+		engine.fcStatus = eth.ExecutionSyncing
+		err := recomputeBlock(context.Background(), logger, src, engine, result)
+		require.ErrorContains(t, err, "cannot build on parent")
+		require.NotErrorIs(t, err, ErrCrossCheckMismatch)
+	})
+	t.Run("InsertInvalid", func(t *testing.T) {
+		engine := newEngine()
+		engine.insertStatus = eth.ExecutionInvalid
+		err := recomputeBlock(context.Background(), logger, src, engine, result)
+		require.ErrorContains(t, err, "did not accept recomputed block")
+		require.NotErrorIs(t, err, ErrCrossCheckMismatch)
+	})
+}
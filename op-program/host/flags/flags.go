@@ -11,6 +11,7 @@ import (
 	service "github.com/ethereum-optimism/optimism/op-service"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 )
 
@@ -31,6 +32,17 @@ var (
 		Usage:   fmt.Sprintf("Predefined network selection. Available networks: %s", strings.Join(chaincfg.AvailableNetworks(), ", ")),
 		EnvVars: prefixEnvVars("NETWORK"),
 	}
+	NetworkConfig = &cli.StringFlag{
+		Name: "network.config",
+		Usage: "Directory of the config bundle of a network that is not predefined, with the rollup config (rollup.json), " +
+			"the op-geth genesis file (genesis.json) and optionally the prestate metadata pinning the chain config hash (prestate.json)",
+		EnvVars: prefixEnvVars("NETWORK_CONFIG"),
+	}
+	NetworkConfigHash = &cli.StringFlag{
+		Name:    "network.config.hash",
+		Usage:   "Expected hash of the custom chain configuration, from network.config or rollup.config and l2.genesis. Not checked if empty",
+		EnvVars: prefixEnvVars("NETWORK_CONFIG_HASH"),
+	}
 	DataDir = &cli.StringFlag{
 		Name:    "datadir",
 		Usage:   "Directory to use for preimage data storage. Default uses in-memory storage",
@@ -42,9 +54,28 @@ var (
 		EnvVars: prefixEnvVars("DATA_FORMAT"),
 		Value:   string(types.DataFormatDirectory),
 	}
-	L2NodeAddr = &cli.StringFlag{
+	CacheDir = &cli.StringFlag{
+		Name: "cache.dir",
+		Usage: "Directory of a preimage cache shared between runs, e.g. repeated runs over the same block range. " +
+			"Preimages missing from the datadir are read from the cache before they are fetched. Disabled if empty",
+		EnvVars: prefixEnvVars("CACHE_DIR"),
+	}
+	CacheMaxSize = &cli.Uint64Flag{
+		Name:    "cache.max-size",
+		Usage:   "Maximum size of the shared preimage cache in MiB. The least recently used preimages are evicted once it's exceeded. Unlimited if 0",
+		EnvVars: prefixEnvVars("CACHE_MAX_SIZE"),
+		Value:   DefaultCacheMaxSizeMiB,
+	}
+	PrefetchParallelism = &cli.Uint64Flag{
+		Name: "prefetch.parallelism",
+		Usage: "Maximum number of pre-images fetched concurrently in the background, ahead of the client program requesting them, " +
+			"e.g. the receipts of the next L1 blocks. Pre-images are only fetched when requested if 0",
+		EnvVars: prefixEnvVars("PREFETCH_PARALLELISM"),
+		Value:   DefaultPrefetchParallelism,
+	}
+	L2NodeAddr = &cli.StringSliceFlag{
 		Name:    "l2",
-		Usage:   "Address of L2 JSON-RPC endpoint to use (eth and debug namespace required)",
+		Usage:   "Address of L2 JSON-RPC endpoint to use (eth and debug namespace required). Multiple endpoints are tried in order when one fails",
 		EnvVars: prefixEnvVars("L2_RPC"),
 	}
 	L1Head = &cli.StringFlag{
@@ -72,21 +103,57 @@ var (
 		Usage:   "Number of the L2 block that the claim is from",
 		EnvVars: prefixEnvVars("L2_BLOCK_NUM"),
 	}
+	VerifyRange = &cli.BoolFlag{
+		Name: "l2.range",
+		Usage: "Also verify the output roots of the blocks between l2.head and l2.blocknumber, as reported by the l2 node. " +
+			"Derivation runs once up to l2.blocknumber. Requires running the client program in the host",
+		EnvVars: prefixEnvVars("L2_RANGE"),
+	}
+	RangeStep = &cli.Uint64Flag{
+		Name:    "l2.range.step",
+		Usage:   "Number of blocks between the output roots verified with l2.range",
+		EnvVars: prefixEnvVars("L2_RANGE_STEP"),
+		Value:   1,
+	}
+	L2CrossCheck = &cli.StringFlag{
+		Name: "l2.crosscheck",
+		Usage: "Address of the L2 engine API of a different execution client, e.g. op-reth, to recompute the block of the output root " +
+			"computed by the program with. The forkchoice of the engine is reset to the parent of the block. " +
+			"A discrepancy fails the run, even if the claim is valid",
+		EnvVars: prefixEnvVars("L2_CROSSCHECK_RPC"),
+	}
+	L2CrossCheckJWTSecret = &cli.StringFlag{
+		Name:    "l2.crosscheck.jwt-secret",
+		Usage:   "Path to the JWT secret of the engine API of l2.crosscheck. Keys are 32 bytes, hex encoded in a file",
+		EnvVars: prefixEnvVars("L2_CROSSCHECK_JWT_SECRET"),
+	}
 	L2GenesisPath = &cli.StringFlag{
 		Name:    "l2.genesis",
 		Usage:   "Path to the op-geth genesis file",
 		EnvVars: prefixEnvVars("L2_GENESIS"),
 	}
-	L1NodeAddr = &cli.StringFlag{
+	InteropConfig = &cli.StringFlag{
+		Name: "interop.config",
+		Usage: "Path to the JSON file with the dependency set, and the configs and agreed output roots of the chains in it, " +
+			"to prove an interop-enabled chain",
+		EnvVars: prefixEnvVars("INTEROP_CONFIG"),
+	}
+	L1NodeAddr = &cli.StringSliceFlag{
 		Name:    "l1",
-		Usage:   "Address of L1 JSON-RPC endpoint to use (eth namespace required)",
+		Usage:   "Address of L1 JSON-RPC endpoint to use (eth namespace required). Multiple endpoints are tried in order when one fails",
 		EnvVars: prefixEnvVars("L1_RPC"),
 	}
-	L1BeaconAddr = &cli.StringFlag{
+	L1BeaconAddr = &cli.StringSliceFlag{
 		Name:    "l1.beacon",
-		Usage:   "Address of L1 Beacon API endpoint to use",
+		Usage:   "Address of L1 Beacon API endpoint to use. Multiple endpoints are tried in order when one fails",
 		EnvVars: prefixEnvVars("L1_BEACON_API"),
 	}
+	L1BlobSources = &cli.StringSliceFlag{
+		Name: "l1.blob-sources",
+		Usage: "Fallback sources for blobs that are not available from the l1.beacon endpoints, e.g. expired blobs. " +
+			"Tried in order. Either the address of a beacon API compatible blob archiver, or blobscan:<address> for a blobscan-style API",
+		EnvVars: prefixEnvVars("L1_BLOB_SOURCES"),
+	}
 	L1TrustRPC = &cli.BoolFlag{
 		Name:    "l1.trustrpc",
 		Usage:   "Trust the L1 RPC, sync faster at risk of malicious/buggy RPC providing bad or inconsistent L1 data",
@@ -107,16 +174,43 @@ var (
 		Usage:   "Run the specified client program as a separate process detached from the host. Default is to run the client program in the host process.",
 		EnvVars: prefixEnvVars("EXEC"),
 	}
+	ClientMemoryLimit = &cli.Uint64Flag{
+		Name: "client.memory-limit",
+		Usage: "Maximum live heap of the client program in MiB, when running natively or with exec. The client program fails and " +
+			"logs the memory usage of its caches once it's exceeded. Unlimited if 0",
+		EnvVars: prefixEnvVars("CLIENT_MEMORY_LIMIT"),
+	}
+	Report = &cli.StringFlag{
+		Name: "report",
+		Usage: "Path to write a JSON report of the run to, including the claimed and computed output roots, blocks derived, " +
+			"pre-images served by type, wall time and exit reason. Use - for stdout. Disabled if empty",
+		EnvVars: prefixEnvVars("REPORT"),
+	}
 	Server = &cli.BoolFlag{
 		Name:    "server",
 		Usage:   "Run in pre-image server mode without executing any client program.",
 		EnvVars: prefixEnvVars("SERVER"),
 	}
+	ServerListen = &cli.StringFlag{
+		Name: "listen",
+		Usage: "Address to serve hints and pre-image requests on, instead of the inherited file descriptors: " +
+			"unix:///path/to/socket or tcp://host:port. A single host serves any number of connections.",
+		EnvVars: prefixEnvVars("SERVER_LISTEN"),
+	}
 )
 
+// DefaultCacheMaxSizeMiB is the default maximum size of the shared preimage cache
+const DefaultCacheMaxSizeMiB = 10 * 1024
+
+// DefaultPrefetchParallelism is the default maximum number of pre-images fetched concurrently in the background
+const DefaultPrefetchParallelism = 4
+
 // Flags contains the list of configuration options available to the binary.
 var Flags []cli.Flag
 
+// ServerFlags contains the list of configuration options available to the server command.
+var ServerFlags []cli.Flag
+
 var requiredFlags = []cli.Flag{
 	L1Head,
 	L2Head,
@@ -128,35 +222,56 @@ var requiredFlags = []cli.Flag{
 var programFlags = []cli.Flag{
 	RollupConfig,
 	Network,
+	NetworkConfig,
+	NetworkConfigHash,
 	DataDir,
 	DataFormat,
+	CacheDir,
+	CacheMaxSize,
+	PrefetchParallelism,
 	L2NodeAddr,
+	VerifyRange,
+	RangeStep,
+	L2CrossCheck,
+	L2CrossCheckJWTSecret,
 	L2GenesisPath,
+	InteropConfig,
 	L1NodeAddr,
 	L1BeaconAddr,
+	L1BlobSources,
 	L1TrustRPC,
 	L1RPCProviderKind,
 	Exec,
+	ClientMemoryLimit,
+	Report,
 	Server,
 }
 
 func init() {
 	Flags = append(Flags, oplog.CLIFlags(EnvVarPrefix)...)
+	Flags = append(Flags, opmetrics.CLIFlags(EnvVarPrefix)...)
 	Flags = append(Flags, requiredFlags...)
 	Flags = append(Flags, programFlags...)
+	ServerFlags = append(append(ServerFlags, Flags...), ServerListen)
 }
 
 func CheckRequired(ctx *cli.Context) error {
 	rollupConfig := ctx.String(RollupConfig.Name)
 	network := ctx.String(Network.Name)
-	if rollupConfig == "" && network == "" {
-		return fmt.Errorf("flag %s or %s is required", RollupConfig.Name, Network.Name)
-	}
-	if rollupConfig != "" && network != "" {
-		return fmt.Errorf("cannot specify both %s and %s", RollupConfig.Name, Network.Name)
-	}
-	if network == "" && ctx.String(L2GenesisPath.Name) == "" {
-		return fmt.Errorf("flag %s is required for custom networks", L2GenesisPath.Name)
+	if ctx.String(NetworkConfig.Name) != "" {
+		if rollupConfig != "" || network != "" || ctx.String(L2GenesisPath.Name) != "" {
+			return fmt.Errorf("cannot specify %s with %s, %s or %s", NetworkConfig.Name, RollupConfig.Name, Network.Name, L2GenesisPath.Name)
+		}
+	} else {
+		if rollupConfig == "" && network == "" {
+			return fmt.Errorf("flag %s, %s or %s is required", RollupConfig.Name, Network.Name, NetworkConfig.Name)
+		}
+		if rollupConfig != "" && network != "" {
+			return fmt.Errorf("cannot specify both %s and %s", RollupConfig.Name, Network.Name)
+		}
+		if network == "" && ctx.String(L2GenesisPath.Name) == "" {
+			return fmt.Errorf("flag %s is required for custom networks", L2GenesisPath.Name)
+		}
 	}
 	for _, flag := range requiredFlags {
 		if !ctx.IsSet(flag.Names()[0]) {
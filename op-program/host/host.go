@@ -8,6 +8,7 @@ import (
 	"io/fs"
 	"os"
 	"os/exec"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
@@ -15,7 +16,10 @@ import (
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum-optimism/optimism/op-program/host/flags"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-program/host/metrics"
 	"github.com/ethereum-optimism/optimism/op-program/host/prefetcher"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
+	"github.com/ethereum-optimism/optimism/op-program/host/version"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/ctxinterrupt"
@@ -51,16 +55,37 @@ func Main(logger log.Logger, cfg *config.Config) error {
 	if err := cfg.Check(); err != nil {
 		return fmt.Errorf("invalid config: %w", err)
 	}
-	opservice.ValidateEnvVars(flags.EnvVarPrefix, flags.Flags, logger)
+	opservice.ValidateEnvVars(flags.EnvVarPrefix, flags.ServerFlags, logger)
 	cfg.Rollup.LogDescription(logger, chaincfg.L2ChainIDToNetworkDisplayName)
 
 	hostCtx, stop := ctxinterrupt.WithSignalWaiter(context.Background())
 	defer stop()
 	ctx := ctxinterrupt.WithCancelOnInterrupt(hostCtx)
 	if cfg.ServerMode {
+		m := metrics.NoopMetrics
+		if cfg.MetricsConfig.Enabled {
+			metricsImpl := metrics.NewMetrics()
+			metricsSrv, err := metricsImpl.Start(cfg.MetricsConfig.ListenAddr, cfg.MetricsConfig.ListenPort)
+			if err != nil {
+				return fmt.Errorf("failed to start metrics server: %w", err)
+			}
+			defer func() {
+				if err := metricsSrv.Stop(context.Background()); err != nil {
+					logger.Error("Failed to stop metrics server", "err", err)
+				}
+			}()
+			logger.Info("Started metrics server", "addr", metricsSrv.Addr())
+			metricsImpl.RecordInfo(version.Version)
+			metricsImpl.RecordUp()
+			m = metricsImpl
+		}
+		prefetcherCreator := newPrefetcherCreator(m)
+		if cfg.ServerListenAddr != "" {
+			return ListenAndServePreimages(ctx, logger, cfg, cfg.ServerListenAddr, prefetcherCreator, m)
+		}
 		preimageChan := preimage.ClientPreimageChannel()
 		hinterChan := preimage.ClientHinterChannel()
-		return PreimageServer(ctx, logger, cfg, preimageChan, hinterChan, makeDefaultPrefetcher)
+		return PreimageServer(ctx, logger, cfg, preimageChan, hinterChan, prefetcherCreator, m)
 	}
 
 	if err := FaultProofProgram(ctx, logger, cfg); err != nil {
@@ -78,11 +103,40 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 	for _, opt := range opts {
 		opt(creators)
 	}
+	recorder := newReportRecorder(logger)
+	start := time.Now()
+	err := runFaultProofProgram(ctx, logger, cfg, creators.prefetcher, recorder)
+	if cfg.L2CrossCheckURL != "" {
+		if result := recorder.programResult(); result != nil {
+			err = errors.Join(err, crossCheck(ctx, logger, cfg, *result))
+		} else {
+			err = errors.Join(err, ErrCrossCheckNoResult)
+		}
+	}
+	if cfg.ReportPath != "" {
+		if writeErr := writeReport(cfg.ReportPath, recorder.report(cfg, time.Since(start), err)); writeErr != nil {
+			logger.Error("Failed to write run report", "path", cfg.ReportPath, "err", writeErr)
+		}
+	}
+	return err
+}
+
+// runFaultProofProgram runs the client program, natively or with the exec command, against a pre-image server.
+// The pre-image server and the client program have stopped when it returns.
+func runFaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Config, prefetcherCreator PrefetcherCreator, recorder *reportRecorder) error {
 	var (
-		serverErr chan error
-		pClientRW preimage.FileChannel
-		hClientRW preimage.FileChannel
+		serverErr   chan error
+		pClientRW   preimage.FileChannel
+		hClientRW   preimage.FileChannel
+		rangeClaims []cl.RangeClaim
 	)
+	if cfg.VerifyRange {
+		claims, err := fetchRangeClaims(ctx, logger, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to fetch range claims: %w", err)
+		}
+		rangeClaims = claims
+	}
 	defer func() {
 		if pClientRW != nil {
 			_ = pClientRW.Close()
@@ -114,7 +168,7 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 	serverErr = make(chan error)
 	go func() {
 		defer close(serverErr)
-		serverErr <- PreimageServer(ctx, logger, cfg, pHostRW, hHostRW, creators.prefetcher)
+		serverErr <- preimageServer(ctx, logger, cfg, pHostRW, hHostRW, prefetcherCreator, recorder, metrics.NoopMetrics)
 	}()
 
 	var cmd *exec.Cmd
@@ -125,6 +179,9 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 		cmd.ExtraFiles[cl.HClientWFd-3] = hClientRW.Writer()
 		cmd.ExtraFiles[cl.PClientRFd-3] = pClientRW.Reader()
 		cmd.ExtraFiles[cl.PClientWFd-3] = pClientRW.Writer()
+		if cfg.ClientMemoryLimit != 0 {
+			cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%d", cl.MemoryLimitEnvVar, cfg.ClientMemoryLimit>>20))
+		}
 		cmd.Stdout = os.Stdout // for debugging
 		cmd.Stderr = os.Stderr // for debugging
 
@@ -137,8 +194,10 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 		}
 		logger.Debug("Client program completed successfully")
 		return nil
+	} else if cfg.VerifyRange {
+		return cl.RunRangeProgram(logger, pClientRW, hClientRW, rangeClaims, cl.WithMemoryLimit(cfg.ClientMemoryLimit))
 	} else {
-		return cl.RunProgram(logger, pClientRW, hClientRW)
+		return cl.RunProgram(logger, pClientRW, hClientRW, cl.WithMemoryLimit(cfg.ClientMemoryLimit))
 	}
 }
 
@@ -146,7 +205,12 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 // This method will block until both the hinter and preimage handlers complete.
 // If either returns an error both handlers are stopped.
 // The supplied preimageChannel and hintChannel will be closed before this function returns.
-func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config, preimageChannel preimage.FileChannel, hintChannel preimage.FileChannel, prefetcherCreator PrefetcherCreator) error {
+func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config, preimageChannel preimage.FileChannel, hintChannel preimage.FileChannel, prefetcherCreator PrefetcherCreator, m metrics.Metricer) error {
+	return preimageServer(ctx, logger, cfg, preimageChannel, hintChannel, prefetcherCreator, nil, m)
+}
+
+// preimageServer is the PreimageServer, recording the served pre-images and hints with the recorder if not nil.
+func preimageServer(ctx context.Context, logger log.Logger, cfg *config.Config, preimageChannel preimage.FileChannel, hintChannel preimage.FileChannel, prefetcherCreator PrefetcherCreator, recorder *reportRecorder, m metrics.Metricer) error {
 	var serverDone chan error
 	var hinterDone chan error
 	logger.Info("Starting preimage server")
@@ -170,27 +234,58 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 		}
 	}()
 
+	kv, preimageGetter, hinter, err := openPreimageSource(ctx, logger, cfg, prefetcherCreator, m)
+	if err != nil {
+		return err
+	}
+	if recorder != nil {
+		preimageGetter = recorder.wrapGetter(preimageGetter)
+		hinter = recorder.wrapHinter(hinter)
+	}
+
+	serverDone = launchOracleServer(logger, preimageChannel, preimageGetter)
+	hinterDone = routeHints(logger, hintChannel, hinter)
+	select {
+	case err := <-serverDone:
+		return err
+	case err := <-hinterDone:
+		return err
+	case <-ctx.Done():
+		logger.Info("Shutting down")
+		return ctx.Err()
+	}
+}
+
+// openPreimageSource opens the KV store and prefetcher of the config, and returns the handlers of pre-image requests
+// and hints. The returned KV store must be closed once the handlers are no longer used.
+func openPreimageSource(ctx context.Context, logger log.Logger, cfg *config.Config, prefetcherCreator PrefetcherCreator, m metrics.Metricer) (kv kvstore.KV, getter preimage.PreimageGetter, hinter preimage.HintHandler, err error) {
 	if cfg.DataDir == "" {
 		logger.Info("Using in-memory storage")
 		kv = kvstore.NewMemKV()
 	} else {
 		if err := os.MkdirAll(cfg.DataDir, 0755); err != nil {
-			return fmt.Errorf("creating datadir: %w", err)
+			return nil, nil, nil, fmt.Errorf("creating datadir: %w", err)
 		}
 		store, err := kvstore.NewDiskKV(logger, cfg.DataDir, cfg.DataFormat)
 		if err != nil {
-			return fmt.Errorf("creating kvstore: %w", err)
+			return nil, nil, nil, fmt.Errorf("creating kvstore: %w", err)
 		}
 		kv = store
 	}
+	if cfg.CacheDir != "" {
+		cache, err := kvstore.NewSharedCache(logger, cfg.CacheDir, cfg.CacheMaxSize)
+		if err != nil {
+			_ = kv.Close()
+			return nil, nil, nil, fmt.Errorf("creating preimage cache: %w", err)
+		}
+		kv = kvstore.NewCachedKV(logger, kv, cache)
+	}
 
-	var (
-		getPreimage kvstore.PreimageSource
-		hinter      preimage.HintHandler
-	)
+	var getPreimage kvstore.PreimageSource
 	prefetch, err := prefetcherCreator(ctx, logger, kv, cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create prefetcher: %w", err)
+		_ = kv.Close()
+		return nil, nil, nil, fmt.Errorf("failed to create prefetcher: %w", err)
 	}
 	if prefetch != nil {
 		getPreimage = func(key common.Hash) ([]byte, error) { return prefetch.GetPreimage(ctx, key) }
@@ -206,51 +301,112 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 
 	localPreimageSource := kvstore.NewLocalPreimageSource(cfg)
 	splitter := kvstore.NewPreimageSourceSplitter(localPreimageSource.Get, getPreimage)
-	preimageGetter := preimage.WithVerification(splitter.Get)
+	return kv, recordServed(preimage.WithVerification(splitter.Get), m), hinter, nil
+}
 
-	serverDone = launchOracleServer(logger, preimageChannel, preimageGetter)
-	hinterDone = routeHints(logger, hintChannel, hinter)
-	select {
-	case err := <-serverDone:
-		return err
-	case err := <-hinterDone:
-		return err
-	case <-ctx.Done():
-		logger.Info("Shutting down")
-		return ctx.Err()
+// recordServed records the pre-images served by the getter with the metrics.
+func recordServed(getter preimage.PreimageGetter, m metrics.Metricer) preimage.PreimageGetter {
+	return func(key [32]byte) ([]byte, error) {
+		value, err := getter(key)
+		if err == nil {
+			name, ok := preimageKeyTypeNames[preimage.KeyType(key[0])]
+			if !ok {
+				name = "unknown"
+			}
+			m.RecordPreimageServed(name, len(value))
+		}
+		return value, err
+	}
+}
+
+// newPrefetcherCreator creates prefetchers recording their requests and hints with the metrics.
+func newPrefetcherCreator(m metrics.Metricer) PrefetcherCreator {
+	return func(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *config.Config) (Prefetcher, error) {
+		return makePrefetcher(ctx, logger, kv, cfg, m)
 	}
 }
 
 func makeDefaultPrefetcher(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *config.Config) (Prefetcher, error) {
+	return makePrefetcher(ctx, logger, kv, cfg, metrics.NoopMetrics)
+}
+
+func makePrefetcher(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *config.Config, m metrics.Metricer) (Prefetcher, error) {
 	if !cfg.FetchingEnabled() {
 		return nil, nil
 	}
-	logger.Info("Connecting to L1 node", "l1", cfg.L1URL)
-	l1RPC, err := client.NewRPC(ctx, logger, cfg.L1URL, client.WithDialBackoff(10))
+	l1RPC, err := dialRPCs(ctx, logger, "L1", cfg.L1URLs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to setup L1 RPC: %w", err)
 	}
-
-	logger.Info("Connecting to L2 node", "l2", cfg.L2URL)
-	l2RPC, err := client.NewRPC(ctx, logger, cfg.L2URL, client.WithDialBackoff(10))
+	l2RPC, err := dialRPCs(ctx, logger, "L2", cfg.L2URLs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to setup L2 RPC: %w", err)
 	}
 
+	l1RPC = metrics.NewInstrumentedRPC("l1", l1RPC, m)
+	l2RPC = metrics.NewInstrumentedRPC("l2", l2RPC, m)
+
 	l1ClCfg := sources.L1ClientDefaultConfig(cfg.Rollup, cfg.L1TrustRPC, cfg.L1RPCKind)
 	l2ClCfg := sources.L2ClientDefaultConfig(cfg.Rollup, true)
 	l1Cl, err := sources.NewL1Client(l1RPC, logger, nil, l1ClCfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create L1 client: %w", err)
 	}
-	l1Beacon := sources.NewBeaconHTTPClient(client.NewBasicHTTPClient(cfg.L1BeaconURL, logger))
-	l1BlobFetcher := sources.NewL1BeaconClient(l1Beacon, sources.L1BeaconClientConfig{FetchAllSidecars: false})
+	var l1BeaconHTTP client.HTTP
+	if len(cfg.L1BeaconURLs) == 1 {
+		l1BeaconHTTP = metrics.NewInstrumentedHTTP("l1-beacon", client.NewBasicHTTPClient(cfg.L1BeaconURLs[0], logger), m)
+	} else {
+		beaconClients := make([]client.HTTP, len(cfg.L1BeaconURLs))
+		for i, url := range cfg.L1BeaconURLs {
+			beaconClients[i] = metrics.NewInstrumentedHTTP("l1-beacon", client.NewBasicHTTPClient(url, logger), m)
+		}
+		l1BeaconHTTP = client.NewFailoverHTTP(logger.New("endpoints", "L1 beacon"), beaconClients)
+	}
+	l1Beacon := sources.NewBeaconHTTPClient(l1BeaconHTTP)
+	var blobFallbacks []sources.BlobSideCarsFetcher
+	for _, source := range cfg.L1BlobSources {
+		logger.Info("Using fallback blob source", "kind", source.Kind, "url", source.URL)
+		httpClient := metrics.NewInstrumentedHTTP("l1-blob-source", client.NewBasicHTTPClient(source.URL, logger), m)
+		switch source.Kind {
+		case types.BlobSourceBlobscan:
+			blobFallbacks = append(blobFallbacks, sources.NewBlobscanClient(httpClient))
+		default:
+			blobFallbacks = append(blobFallbacks, sources.NewBeaconHTTPClient(httpClient))
+		}
+	}
+	l1BlobFetcher := sources.NewL1BeaconClient(l1Beacon, sources.L1BeaconClientConfig{FetchAllSidecars: false}, blobFallbacks...)
 	l2Cl, err := NewL2Client(l2RPC, logger, nil, &L2ClientConfig{L2ClientConfig: l2ClCfg, L2Head: cfg.L2Head})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create L2 client: %w", err)
 	}
 	l2DebugCl := &L2Source{L2Client: l2Cl, DebugClient: sources.NewDebugClient(l2RPC.CallContext)}
-	return prefetcher.NewPrefetcher(logger, l1Cl, l1BlobFetcher, l2DebugCl, kv), nil
+	return prefetcher.NewPrefetcher(logger, l1Cl, l1BlobFetcher, l2DebugCl, kv,
+		prefetcher.WithParallelism(int(cfg.PrefetchParallelism)), prefetcher.WithMetrics(m)), nil
+}
+
+// dialRPCs connects to the RPC endpoints, failing over between them if there are multiple.
+// Endpoints that can't be dialed are skipped, as long as one of them can be.
+func dialRPCs(ctx context.Context, logger log.Logger, name string, urls []string) (client.RPC, error) {
+	if len(urls) == 1 {
+		logger.Info("Connecting to "+name+" node", "url", urls[0])
+		return client.NewRPC(ctx, logger, urls[0], client.WithDialBackoff(10))
+	}
+	var rpcs []client.RPC
+	var errs []error
+	for i, url := range urls {
+		logger.Info("Connecting to "+name+" node", "endpoint", i, "url", url)
+		rpc, err := client.NewRPC(ctx, logger, url, client.WithDialBackoff(10))
+		if err != nil {
+			logger.Warn("Failed to connect to "+name+" node, skipping endpoint", "endpoint", i, "err", err)
+			errs = append(errs, err)
+			continue
+		}
+		rpcs = append(rpcs, rpc)
+	}
+	if len(rpcs) == 0 {
+		return nil, errors.Join(errs...)
+	}
+	return client.NewFailoverRPC(logger.New("endpoints", name), rpcs), nil
 }
 
 func routeHints(logger log.Logger, hHostRW io.ReadWriter, hinter preimage.HintHandler) chan error {
@@ -3,6 +3,7 @@ package host
 import (
 	"context"
 	"errors"
+	"os/exec"
 	"testing"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-program/host/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -37,7 +39,7 @@ func TestServerMode(t *testing.T) {
 	logger := testlog.Logger(t, log.LevelTrace)
 	result := make(chan error)
 	go func() {
-		result <- PreimageServer(context.Background(), logger, cfg, preimageServer, hintServer, makeDefaultPrefetcher)
+		result <- PreimageServer(context.Background(), logger, cfg, preimageServer, hintServer, makeDefaultPrefetcher, metrics.NoopMetrics)
 	}()
 
 	pClient := preimage.NewOracleClient(preimageClient)
@@ -54,6 +56,20 @@ func TestServerMode(t *testing.T) {
 	require.ErrorIs(t, waitFor(result), kvstore.ErrNotFound)
 }
 
+func TestCrossCheckWithoutProgramResult(t *testing.T) {
+	execCmd, err := exec.LookPath("true")
+	if err != nil {
+		t.Skip("no true command to exec")
+	}
+	cfg := config.NewConfig(chaincfg.Sepolia, chainconfig.OPSepoliaChainConfig, common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+	cfg.DataDir = t.TempDir()
+	cfg.ExecCmd = execCmd
+	cfg.L2CrossCheckURL = "http://localhost:9551"
+	// The exec'd client program exits without reporting its result, so the cross-check can't run
+	err = FaultProofProgram(context.Background(), testlog.Logger(t, log.LevelInfo), cfg)
+	require.ErrorIs(t, err, ErrCrossCheckNoResult)
+}
+
 func waitFor(ch chan error) error {
 	timeout := time.After(30 * time.Second)
 	select {
@@ -0,0 +1,246 @@
+package kvstore
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/gofrs/flock"
+)
+
+const cacheLockFilename = "cache.lock"
+
+// SharedCache is a content-addressed pre-image store on disk, that may be shared by any number of concurrent
+// processes, e.g. the op-program runs of a challenger that re-execute the same block range at different steps.
+// Every pre-image is stored in a raw file named by its key, written with an atomic rename.
+// The modification time of a file is updated when the pre-image is read, and the least recently used
+// pre-images are evicted once the cache exceeds its maximum size.
+// Evictions are serialized between processes with a lock file, readers don't need the lock:
+// a pre-image that is evicted while it's read is a cache miss.
+type SharedCache struct {
+	logger  log.Logger
+	dir     string
+	maxSize uint64
+	lock    *flock.Flock
+
+	mu sync.Mutex
+	// size is the size of the cache when it was last scanned, plus the size of the pre-images written since.
+	// Pre-images written by other processes are only accounted for by the next scan.
+	size uint64
+}
+
+// NewSharedCache opens the cache in dir, creating it if it doesn't exist.
+// The cache is unlimited if maxSize is 0.
+func NewSharedCache(logger log.Logger, dir string, maxSize uint64) (*SharedCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache dir: %w", err)
+	}
+	c := &SharedCache{
+		logger:  logger,
+		dir:     dir,
+		maxSize: maxSize,
+		lock:    flock.New(filepath.Join(dir, cacheLockFilename)),
+	}
+	if maxSize != 0 {
+		entries, err := c.scan()
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			c.size += entry.size
+		}
+	}
+	logger.Info("Using shared pre-image cache", "dir", dir, "size", c.size, "maxSize", maxSize)
+	return c, nil
+}
+
+func (c *SharedCache) pathKey(k common.Hash) string {
+	key := k.Hex()
+	return filepath.Join(c.dir, key[2:4], key[4:])
+}
+
+func (c *SharedCache) Put(k common.Hash, v []byte) error {
+	path := c.pathKey(k)
+	if _, err := os.Stat(path); err == nil {
+		// pre-images are content-addressed, an existing file has the same content
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cache dir for pre-image %s: %w", k, err)
+	}
+	f, err := openTempFile(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to open temp file for pre-image %s: %w", k, err)
+	}
+	defer os.Remove(f.Name()) // Clean up the temp file if it doesn't actually get moved into place
+	if _, err := f.Write(v); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write pre-image %s to cache: %w", k, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close temp pre-image %s file: %w", k, err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("failed to move temp file %v to final destination %v: %w", f.Name(), path, err)
+	}
+
+	c.mu.Lock()
+	c.size += uint64(len(v))
+	evict := c.maxSize != 0 && c.size > c.maxSize
+	c.mu.Unlock()
+	if evict {
+		if err := c.evict(); err != nil {
+			c.logger.Warn("Failed to evict pre-images from cache", "err", err)
+		}
+	}
+	return nil
+}
+
+func (c *SharedCache) Get(k common.Hash) ([]byte, error) {
+	path := c.pathKey(k)
+	dat, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read pre-image %s from cache: %w", k, err)
+	}
+	now := time.Now()
+	// Failing to record the use only makes the pre-image more likely to be evicted
+	_ = os.Chtimes(path, now, now)
+	return dat, nil
+}
+
+func (c *SharedCache) Close() error {
+	return nil
+}
+
+type cacheEntry struct {
+	path    string
+	size    uint64
+	modTime time.Time
+}
+
+func (c *SharedCache) scan() ([]cacheEntry, error) {
+	var entries []cacheEntry
+	err := filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			// evicted by another process while walking
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() == cacheLockFilename || strings.HasSuffix(d.Name(), ".tmp") {
+			return nil
+		}
+		info, err := d.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		entries = append(entries, cacheEntry{path: path, size: uint64(info.Size()), modTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan cache: %w", err)
+	}
+	return entries, nil
+}
+
+// evict removes the least recently used pre-images until the cache is below 90% of its maximum size.
+// It's a no-op if another process is evicting pre-images already.
+func (c *SharedCache) evict() error {
+	locked, err := c.lock.TryLock()
+	if err != nil {
+		return fmt.Errorf("failed to lock cache: %w", err)
+	}
+	if !locked {
+		return nil
+	}
+	defer func() {
+		_ = c.lock.Unlock()
+	}()
+
+	entries, err := c.scan()
+	if err != nil {
+		return err
+	}
+	var size uint64
+	for _, entry := range entries {
+		size += entry.size
+	}
+	target := c.maxSize / 10 * 9
+	slices.SortFunc(entries, func(a, b cacheEntry) int {
+		return a.modTime.Compare(b.modTime)
+	})
+	var evicted int
+	for _, entry := range entries {
+		if size <= target {
+			break
+		}
+		if err := os.Remove(entry.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to evict pre-image: %w", err)
+		}
+		size -= entry.size
+		evicted++
+	}
+	c.logger.Debug("Evicted pre-images from cache", "evicted", evicted, "size", size)
+
+	c.mu.Lock()
+	c.size = size
+	c.mu.Unlock()
+	return nil
+}
+
+var _ KV = (*SharedCache)(nil)
+
+// cachedKV is a KV store backed by a shared cache: pre-images missing from the KV store are read from the cache,
+// and all pre-images written to the KV store are added to the cache.
+type cachedKV struct {
+	logger log.Logger
+	kv     KV
+	cache  KV
+}
+
+func NewCachedKV(logger log.Logger, kv KV, cache KV) KV {
+	return &cachedKV{logger: logger, kv: kv, cache: cache}
+}
+
+func (c *cachedKV) Put(k common.Hash, v []byte) error {
+	if err := c.kv.Put(k, v); err != nil {
+		return err
+	}
+	if err := c.cache.Put(k, v); err != nil {
+		c.logger.Warn("Failed to add pre-image to cache", "key", k, "err", err)
+	}
+	return nil
+}
+
+func (c *cachedKV) Get(k common.Hash) ([]byte, error) {
+	v, err := c.kv.Get(k)
+	if !errors.Is(err, ErrNotFound) {
+		return v, err
+	}
+	v, err = c.cache.Get(k)
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		c.logger.Warn("Failed to read pre-image from cache", "key", k, "err", err)
+		return nil, ErrNotFound
+	}
+	return v, nil
+}
+
+func (c *cachedKV) Close() error {
+	return errors.Join(c.kv.Close(), c.cache.Close())
+}
+
+var _ KV = (*cachedKV)(nil)
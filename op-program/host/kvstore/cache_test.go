@@ -0,0 +1,92 @@
+package kvstore
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharedCache(t *testing.T) {
+	cache, err := NewSharedCache(testlog.Logger(t, log.LevelInfo), t.TempDir(), 0)
+	require.NoError(t, err)
+	t.Cleanup(func() { // Can't use defer because kvTest runs tests in parallel.
+		require.NoError(t, cache.Close())
+	})
+	kvTest(t, cache)
+}
+
+func TestSharedCache_SharedBetweenInstances(t *testing.T) {
+	dir := t.TempDir()
+	logger := testlog.Logger(t, log.LevelInfo)
+	a, err := NewSharedCache(logger, dir, 0)
+	require.NoError(t, err)
+	b, err := NewSharedCache(logger, dir, 0)
+	require.NoError(t, err)
+	require.NoError(t, a.Put(common.Hash{0xaa}, []byte("hello")))
+	dat, err := b.Get(common.Hash{0xaa})
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), dat)
+}
+
+func TestSharedCache_Eviction(t *testing.T) {
+	dir := t.TempDir()
+	logger := testlog.Logger(t, log.LevelInfo)
+	cache, err := NewSharedCache(logger, dir, 100)
+	require.NoError(t, err)
+	value := make([]byte, 40)
+	start := time.Now().Add(-time.Hour)
+	for i, k := range []common.Hash{{0x01}, {0x02}} {
+		require.NoError(t, cache.Put(k, value))
+		used := start.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, os.Chtimes(cache.pathKey(k), used, used))
+	}
+	// reading the oldest pre-image makes it the most recently used
+	_, err = cache.Get(common.Hash{0x01})
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Put(common.Hash{0x03}, value))
+	_, err = cache.Get(common.Hash{0x02})
+	require.ErrorIs(t, err, ErrNotFound, "least recently used pre-image is evicted")
+	for _, k := range []common.Hash{{0x01}, {0x03}} {
+		_, err := cache.Get(k)
+		require.NoError(t, err)
+	}
+
+	// the size of existing pre-images is accounted for when reopening the cache
+	reopened, err := NewSharedCache(logger, dir, 100)
+	require.NoError(t, err)
+	require.Equal(t, uint64(80), reopened.size)
+}
+
+func TestCachedKV(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	cache, err := NewSharedCache(logger, t.TempDir(), 0)
+	require.NoError(t, err)
+	kv := NewCachedKV(logger, NewMemKV(), cache)
+	t.Cleanup(func() {
+		require.NoError(t, kv.Close())
+	})
+	kvTest(t, kv)
+
+	t.Run("reads from cache", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, cache.Put(common.Hash{0xee}, []byte("cached")))
+		dat, err := kv.Get(common.Hash{0xee})
+		require.NoError(t, err)
+		require.Equal(t, []byte("cached"), dat)
+	})
+
+	t.Run("writes to cache", func(t *testing.T) {
+		t.Parallel()
+		other := NewCachedKV(logger, NewMemKV(), cache)
+		require.NoError(t, kv.Put(common.Hash{0xff}, []byte("fetched")))
+		dat, err := other.Get(common.Hash{0xff})
+		require.NoError(t, err)
+		require.Equal(t, []byte("fetched"), dat)
+	})
+}
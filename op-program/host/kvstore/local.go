@@ -25,6 +25,10 @@ var (
 	l2ChainIDKey          = client.L2ChainIDLocalIndex.PreimageKey()
 	l2ChainConfigKey      = client.L2ChainConfigLocalIndex.PreimageKey()
 	rollupKey             = client.RollupConfigLocalIndex.PreimageKey()
+	chainConfigHashKey    = client.ChainConfigHashLocalIndex.PreimageKey()
+	interopChainsKey      = client.InteropChainsLocalIndex.PreimageKey()
+	dependencySetKey      = client.DependencySetLocalIndex.PreimageKey()
+	l2ClaimChainIDKey     = client.L2ClaimChainIDLocalIndex.PreimageKey()
 )
 
 func (s *LocalPreimageSource) Get(key common.Hash) ([]byte, error) {
@@ -40,8 +44,12 @@ func (s *LocalPreimageSource) Get(key common.Hash) ([]byte, error) {
 	case l2ChainIDKey:
 		// The CustomChainIDIndicator informs the client to rely on the L2ChainConfigKey to
 		// read the chain config. Otherwise, it'll attempt to read a non-existent hardcoded chain config
+		// The InteropChainIDIndicator informs the client to read the configs of all chains in the dependency set,
+		// and the chain ID of the claimed chain from the L2ClaimChainIDKey.
 		var chainID uint64
-		if s.config.IsCustomChainConfig {
+		if s.config.Interop != nil {
+			chainID = client.InteropChainIDIndicator
+		} else if s.config.IsCustomChainConfig {
 			chainID = client.CustomChainIDIndicator
 		} else {
 			chainID = s.config.L2ChainConfig.ChainID.Uint64()
@@ -51,6 +59,27 @@ func (s *LocalPreimageSource) Get(key common.Hash) ([]byte, error) {
 		return json.Marshal(s.config.L2ChainConfig)
 	case rollupKey:
 		return json.Marshal(s.config.Rollup)
+	case chainConfigHashKey:
+		hash, err := config.ChainConfigHash(s.config.Rollup, s.config.L2ChainConfig)
+		if err != nil {
+			return nil, err
+		}
+		return hash.Bytes(), nil
+	case interopChainsKey:
+		if s.config.Interop == nil {
+			return nil, ErrNotFound
+		}
+		return json.Marshal(s.config.Interop.Chains)
+	case dependencySetKey:
+		if s.config.Interop == nil {
+			return nil, ErrNotFound
+		}
+		return json.Marshal(s.config.Interop.DependencySet)
+	case l2ClaimChainIDKey:
+		if s.config.Interop == nil {
+			return nil, ErrNotFound
+		}
+		return binary.BigEndian.AppendUint64(nil, s.config.Rollup.L2ChainID.Uint64()), nil
 	default:
 		return nil, ErrNotFound
 	}
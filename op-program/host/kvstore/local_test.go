@@ -7,6 +7,8 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client"
+	"github.com/ethereum-optimism/optimism/op-program/client/interop"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params"
@@ -35,6 +37,7 @@ func TestLocalPreimageSource(t *testing.T) {
 		{"L2ChainID", l2ChainIDKey, binary.BigEndian.AppendUint64(nil, cfg.L2ChainConfig.ChainID.Uint64())},
 		{"Rollup", rollupKey, asJson(t, cfg.Rollup)},
 		{"ChainConfig", l2ChainConfigKey, asJson(t, cfg.L2ChainConfig)},
+		{"ChainConfigHash", chainConfigHashKey, client.CustomChainConfigHash(asJson(t, cfg.Rollup), asJson(t, cfg.L2ChainConfig)).Bytes()},
 		{"Unknown", preimage.LocalIndexKey(1000).PreimageKey(), nil},
 	}
 	for _, test := range tests {
@@ -50,6 +53,56 @@ func TestLocalPreimageSource(t *testing.T) {
 	}
 }
 
+func TestLocalPreimageSource_Interop(t *testing.T) {
+	cfg := &config.Config{
+		Rollup:             chaincfg.Sepolia,
+		L1Head:             common.HexToHash("0x1111"),
+		L2OutputRoot:       common.HexToHash("0x2222"),
+		L2Claim:            common.HexToHash("0x3333"),
+		L2ClaimBlockNumber: 1234,
+		L2ChainConfig:      params.GoerliChainConfig,
+		Interop: &config.InteropConfig{
+			Chains: []client.InteropChain{{
+				ChainID:       chaincfg.Sepolia.L2ChainID.Uint64(),
+				OutputRoot:    common.HexToHash("0x2222"),
+				RollupConfig:  chaincfg.Sepolia,
+				L2ChainConfig: params.GoerliChainConfig,
+			}},
+			DependencySet: &interop.DependencySet{
+				Chains:              []uint64{chaincfg.Sepolia.L2ChainID.Uint64()},
+				MessageExpiryWindow: interop.DefaultMessageExpiryWindow,
+			},
+		},
+	}
+	source := NewLocalPreimageSource(cfg)
+	tests := []struct {
+		name     string
+		key      common.Hash
+		expected []byte
+	}{
+		{"L2ChainID", l2ChainIDKey, binary.BigEndian.AppendUint64(nil, client.InteropChainIDIndicator)},
+		{"InteropChains", interopChainsKey, asJson(t, cfg.Interop.Chains)},
+		{"DependencySet", dependencySetKey, asJson(t, cfg.Interop.DependencySet)},
+		{"L2ClaimChainID", l2ClaimChainIDKey, binary.BigEndian.AppendUint64(nil, chaincfg.Sepolia.L2ChainID.Uint64())},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			result, err := source.Get(test.key)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, result)
+		})
+	}
+
+	t.Run("NotInterop", func(t *testing.T) {
+		source := NewLocalPreimageSource(&config.Config{Rollup: chaincfg.Sepolia})
+		for _, key := range []common.Hash{interopChainsKey, dependencySetKey, l2ClaimChainIDKey} {
+			_, err := source.Get(key)
+			require.ErrorIs(t, err, ErrNotFound)
+		}
+	})
+}
+
 func asJson(t *testing.T, v any) []byte {
 	d, err := json.Marshal(v)
 	require.NoError(t, err)
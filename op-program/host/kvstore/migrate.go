@@ -0,0 +1,132 @@
+package kvstore
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var ErrDestinationNotEmpty = errors.New("destination is not empty")
+
+// MigrateDiskKV copies all pre-images of the file or directory format store at srcDir into a new store of the
+// given format at destDir, and returns the number of copied pre-images.
+// The format of the source is read from srcDir, and defaults to the directory format if it wasn't recorded.
+// destDir must not exist or be empty. The source store is not modified.
+func MigrateDiskKV(logger log.Logger, srcDir string, destDir string, destFormat types.DataFormat) (uint64, error) {
+	srcFormat, err := readKVFormat(srcDir)
+	if errors.Is(err, ErrFormatUnavailable) {
+		srcFormat = types.DataFormatDirectory
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to read source format: %w", err)
+	}
+	var walk func(dir string, fn func(k common.Hash, path string) error) error
+	switch srcFormat {
+	case types.DataFormatFile:
+		walk = walkFileKV
+	case types.DataFormatDirectory:
+		walk = walkDirectoryKV
+	default:
+		return 0, fmt.Errorf("%w: can't migrate from %s", ErrUnsupportedFormat, srcFormat)
+	}
+
+	entries, err := os.ReadDir(destDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return 0, fmt.Errorf("failed to read destination: %w", err)
+	} else if len(entries) > 0 {
+		return 0, fmt.Errorf("%w: %s", ErrDestinationNotEmpty, destDir)
+	}
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		return 0, fmt.Errorf("failed to create destination: %w", err)
+	}
+	dest, err := NewDiskKV(logger, destDir, destFormat)
+	if err != nil {
+		return 0, err
+	}
+
+	var count uint64
+	err = walk(srcDir, func(k common.Hash, path string) error {
+		dat, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read pre-image %s: %w", k, err)
+		}
+		v, err := hex.DecodeString(string(dat))
+		if err != nil {
+			return fmt.Errorf("failed to decode pre-image %s: %w", k, err)
+		}
+		if err := dest.Put(k, v); err != nil {
+			return fmt.Errorf("failed to write pre-image %s: %w", k, err)
+		}
+		count++
+		if count%100_000 == 0 {
+			logger.Info("Migrating pre-images", "count", count)
+		}
+		return nil
+	})
+	if closeErr := dest.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to close destination: %w", closeErr)
+	}
+	return count, err
+}
+
+// walkFileKV calls fn for every pre-image file of a fileKV: the hex key with a .txt extension.
+func walkFileKV(dir string, fn func(k common.Hash, path string) error) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read source: %w", err)
+	}
+	for _, entry := range entries {
+		if k, ok := parseKeyFilename("", entry); ok {
+			if err := fn(k, filepath.Join(dir, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// walkDirectoryKV calls fn for every pre-image file of a directoryKV: the hex key split into a directory of
+// the first two bytes, and a file name of the rest of the key with a .txt extension.
+func walkDirectoryKV(dir string, fn func(k common.Hash, path string) error) error {
+	subdirs, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read source: %w", err)
+	}
+	for _, subdir := range subdirs {
+		if !subdir.IsDir() || len(subdir.Name()) != 4 {
+			continue
+		}
+		subdirPath := filepath.Join(dir, subdir.Name())
+		entries, err := os.ReadDir(subdirPath)
+		if err != nil {
+			return fmt.Errorf("failed to read source: %w", err)
+		}
+		for _, entry := range entries {
+			if k, ok := parseKeyFilename("0x"+subdir.Name(), entry); ok {
+				if err := fn(k, filepath.Join(subdirPath, entry.Name())); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// parseKeyFilename parses the key of a pre-image file, ignoring other files like temp files of interrupted writes.
+func parseKeyFilename(prefix string, entry os.DirEntry) (common.Hash, bool) {
+	name, ok := strings.CutSuffix(entry.Name(), ".txt")
+	if !ok || !entry.Type().IsRegular() {
+		return common.Hash{}, false
+	}
+	key, err := hex.DecodeString(strings.TrimPrefix(prefix+name, "0x"))
+	if err != nil || len(key) != common.HashLength {
+		return common.Hash{}, false
+	}
+	return common.Hash(key), true
+}
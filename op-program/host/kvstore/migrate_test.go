@@ -0,0 +1,67 @@
+package kvstore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateDiskKV(t *testing.T) {
+	for _, srcFormat := range []types.DataFormat{types.DataFormatFile, types.DataFormatDirectory} {
+		srcFormat := srcFormat
+		t.Run(string(srcFormat), func(t *testing.T) {
+			logger := testlog.Logger(t, log.LevelInfo)
+			srcDir := t.TempDir()
+			src, err := NewDiskKV(logger, srcDir, srcFormat)
+			require.NoError(t, err)
+			values := [][]byte{{}, {1}, []byte("pre-image")}
+			for _, v := range values {
+				require.NoError(t, src.Put(crypto.Keccak256Hash(v), v))
+			}
+			require.NoError(t, src.Close())
+			// leftover temp file of an interrupted write
+			require.NoError(t, os.WriteFile(filepath.Join(srcDir, crypto.Keccak256Hash([]byte{2}).String()+".txt.123"), []byte("02"), 0o644))
+
+			destDir := filepath.Join(t.TempDir(), "pebble")
+			count, err := MigrateDiskKV(logger, srcDir, destDir, types.DataFormatPebble)
+			require.NoError(t, err)
+			require.Equal(t, uint64(len(values)), count)
+
+			format, err := readKVFormat(destDir)
+			require.NoError(t, err)
+			require.Equal(t, types.DataFormatPebble, format)
+			dest, err := NewDiskKV(logger, destDir, types.DataFormatDirectory)
+			require.NoError(t, err)
+			defer dest.Close()
+			for _, v := range values {
+				actual, err := dest.Get(crypto.Keccak256Hash(v))
+				require.NoError(t, err)
+				require.Equal(t, v, actual)
+			}
+			_, err = dest.Get(crypto.Keccak256Hash([]byte{2}))
+			require.ErrorIs(t, err, ErrNotFound)
+		})
+	}
+
+	t.Run("NonEmptyDestination", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LevelInfo)
+		destDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(destDir, "data"), nil, 0o644))
+		_, err := MigrateDiskKV(logger, t.TempDir(), destDir, types.DataFormatPebble)
+		require.ErrorIs(t, err, ErrDestinationNotEmpty)
+	})
+
+	t.Run("UnsupportedSource", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LevelInfo)
+		srcDir := t.TempDir()
+		require.NoError(t, recordKVFormat(srcDir, types.DataFormatPebble))
+		_, err := MigrateDiskKV(logger, srcDir, t.TempDir(), types.DataFormatPebble)
+		require.ErrorIs(t, err, ErrUnsupportedFormat)
+	})
+}
@@ -0,0 +1,156 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+const Namespace = "op_program_host"
+
+type Metricer interface {
+	RecordInfo(version string)
+	RecordUp()
+
+	// RecordHint records the time to fetch the pre-images of a hint
+	RecordHint(hintType string, duration time.Duration)
+	// RecordPreimageRequest records a pre-image request to the prefetcher, and whether it was already in the kv store
+	RecordPreimageRequest(hit bool)
+	// RecordPreimageServed records a pre-image served to the client program
+	RecordPreimageServed(keyType string, size int)
+	// RecordRPCRequest records the start of a request to a source, and returns a function to record its completion
+	RecordRPCRequest(source string, method string) func(err error)
+}
+
+// Metrics implementation must implement RegistryMetricer to allow the metrics server to work.
+var _ opmetrics.RegistryMetricer = (*Metrics)(nil)
+
+type Metrics struct {
+	ns       string
+	registry *prometheus.Registry
+	factory  opmetrics.Factory
+
+	info prometheus.GaugeVec
+	up   prometheus.Gauge
+
+	hintDuration        *prometheus.HistogramVec
+	preimageRequests    *prometheus.CounterVec
+	preimagesServed     *prometheus.CounterVec
+	preimageBytesServed *prometheus.CounterVec
+	rpcRequests         *prometheus.CounterVec
+	rpcErrors           *prometheus.CounterVec
+	rpcDuration         *prometheus.HistogramVec
+}
+
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+var _ Metricer = (*Metrics)(nil)
+
+func NewMetrics() *Metrics {
+	registry := opmetrics.NewRegistry()
+	factory := opmetrics.With(registry)
+
+	return &Metrics{
+		ns:       Namespace,
+		registry: registry,
+		factory:  factory,
+
+		info: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "info",
+			Help:      "Pseudo-metric tracking version and config info",
+		}, []string{
+			"version",
+		}),
+		up: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "up",
+			Help:      "1 if the op-program host has finished starting up",
+		}),
+		hintDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "hint_duration_seconds",
+			Help:      "Time (in seconds) to fetch the pre-images of a hint, by hint type",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"type"}),
+		preimageRequests: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "preimage_requests_total",
+			Help:      "Number of pre-image requests to the prefetcher, by whether the pre-image was already stored (hit) or had to be fetched (miss)",
+		}, []string{"result"}),
+		preimagesServed: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "preimages_served_total",
+			Help:      "Number of pre-images served to the client program, by key type",
+		}, []string{"type"}),
+		preimageBytesServed: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "preimage_bytes_served_total",
+			Help:      "Number of pre-image bytes served to the client program, by key type",
+		}, []string{"type"}),
+		rpcRequests: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "rpc_requests_total",
+			Help:      "Number of requests to the L1, L2 and blob sources",
+		}, []string{"source", "method"}),
+		rpcErrors: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "rpc_errors_total",
+			Help:      "Number of failed requests to the L1, L2 and blob sources",
+		}, []string{"source", "method"}),
+		rpcDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "rpc_request_duration_seconds",
+			Help:      "Time (in seconds) of requests to the L1, L2 and blob sources",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		}, []string{"source", "method"}),
+	}
+}
+
+func (m *Metrics) Start(host string, port int) (*httputil.HTTPServer, error) {
+	return opmetrics.StartServer(m.registry, host, port)
+}
+
+// RecordInfo sets a pseudo-metric that contains versioning and
+// config info for the op-program host.
+func (m *Metrics) RecordInfo(version string) {
+	m.info.WithLabelValues(version).Set(1)
+}
+
+// RecordUp sets the up metric to 1.
+func (m *Metrics) RecordUp() {
+	m.up.Set(1)
+}
+
+func (m *Metrics) RecordHint(hintType string, duration time.Duration) {
+	m.hintDuration.WithLabelValues(hintType).Observe(duration.Seconds())
+}
+
+func (m *Metrics) RecordPreimageRequest(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.preimageRequests.WithLabelValues(result).Inc()
+}
+
+func (m *Metrics) RecordPreimageServed(keyType string, size int) {
+	m.preimagesServed.WithLabelValues(keyType).Inc()
+	m.preimageBytesServed.WithLabelValues(keyType).Add(float64(size))
+}
+
+func (m *Metrics) RecordRPCRequest(source string, method string) func(err error) {
+	m.rpcRequests.WithLabelValues(source, method).Inc()
+	timer := prometheus.NewTimer(m.rpcDuration.WithLabelValues(source, method))
+	return func(err error) {
+		timer.ObserveDuration()
+		if err != nil {
+			m.rpcErrors.WithLabelValues(source, method).Inc()
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package metrics
+
+import "time"
+
+type NoopMetricsImpl struct{}
+
+var NoopMetrics Metricer = new(NoopMetricsImpl)
+
+func (*NoopMetricsImpl) RecordInfo(version string)                          {}
+func (*NoopMetricsImpl) RecordUp()                                          {}
+func (*NoopMetricsImpl) RecordHint(hintType string, duration time.Duration) {}
+func (*NoopMetricsImpl) RecordPreimageRequest(hit bool)                     {}
+func (*NoopMetricsImpl) RecordPreimageServed(keyType string, size int)      {}
+func (*NoopMetricsImpl) RecordRPCRequest(source string, method string) func(err error) {
+	return func(err error) {}
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+// InstrumentedRPC is an RPC client that records the requests to a source.
+type InstrumentedRPC struct {
+	rpc    client.RPC
+	source string
+	m      Metricer
+}
+
+var _ client.RPC = (*InstrumentedRPC)(nil)
+
+func NewInstrumentedRPC(source string, c client.RPC, m Metricer) *InstrumentedRPC {
+	return &InstrumentedRPC{rpc: c, source: source, m: m}
+}
+
+func (r *InstrumentedRPC) Close() {
+	r.rpc.Close()
+}
+
+func (r *InstrumentedRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	done := r.m.RecordRPCRequest(r.source, method)
+	err := r.rpc.CallContext(ctx, result, method, args...)
+	done(err)
+	return err
+}
+
+// BatchCallContext records batch requests as a single request, since they are made with a single round trip.
+func (r *InstrumentedRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	done := r.m.RecordRPCRequest(r.source, opmetrics.BatchMethod)
+	err := r.rpc.BatchCallContext(ctx, b)
+	done(err)
+	return err
+}
+
+func (r *InstrumentedRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	return r.rpc.EthSubscribe(ctx, channel, args...)
+}
+
+// InstrumentedHTTP is an HTTP client that records the requests to a source.
+// Requests are recorded with the GET method rather than their path, which includes the requested block.
+type InstrumentedHTTP struct {
+	http   client.HTTP
+	source string
+	m      Metricer
+}
+
+var _ client.HTTP = (*InstrumentedHTTP)(nil)
+
+func NewInstrumentedHTTP(source string, c client.HTTP, m Metricer) *InstrumentedHTTP {
+	return &InstrumentedHTTP{http: c, source: source, m: m}
+}
+
+func (h *InstrumentedHTTP) Get(ctx context.Context, path string, query url.Values, headers http.Header) (*http.Response, error) {
+	done := h.m.RecordRPCRequest(h.source, http.MethodGet)
+	resp, err := h.http.Get(ctx, path, query, headers)
+	done(err)
+	return resp, err
+}
@@ -0,0 +1,22 @@
+package prefetcher
+
+import "time"
+
+// Metrics records the hints processed by the prefetcher and the pre-images it's requested.
+type Metrics interface {
+	RecordHint(hintType string, duration time.Duration)
+	RecordPreimageRequest(hit bool)
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) RecordHint(hintType string, duration time.Duration) {}
+func (noopMetrics) RecordPreimageRequest(hit bool)                     {}
+
+// WithMetrics records the time to fetch the pre-images of each hint, and whether requested pre-images are
+// already stored, with the metrics.
+func WithMetrics(m Metrics) PrefetcherOpt {
+	return func(p *Prefetcher) {
+		p.metrics = m
+	}
+}
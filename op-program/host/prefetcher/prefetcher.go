@@ -7,10 +7,12 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/client/l2"
+	"github.com/ethereum-optimism/optimism/op-program/client/l2/engineapi"
 	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -34,6 +36,18 @@ var acceleratedPrecompiles = []common.Address{
 	common.BytesToAddress([]byte{0x0a}), // KZG Point Evaluation
 }
 
+// acceleratedPrecompilesV2 are the precompiles that can only be accelerated with the V2 hint,
+// since the L1 PreimageOracle requires the gas to execute them with.
+var acceleratedPrecompilesV2 = append(slices.Clone(acceleratedPrecompiles),
+	common.BytesToAddress([]byte{0x0b}), // BLS12-381 G1 Add
+	common.BytesToAddress([]byte{0x0c}), // BLS12-381 G1 MSM
+	common.BytesToAddress([]byte{0x0d}), // BLS12-381 G2 Add
+	common.BytesToAddress([]byte{0x0e}), // BLS12-381 G2 MSM
+	common.BytesToAddress([]byte{0x0f}), // BLS12-381 Pairing
+	common.BytesToAddress([]byte{0x10}), // BLS12-381 Map Fp to G1
+	common.BytesToAddress([]byte{0x11}), // BLS12-381 Map Fp2 to G2
+)
+
 type L1Source interface {
 	InfoByHash(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, error)
 	InfoAndTxsByHash(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Transactions, error)
@@ -59,33 +73,44 @@ type Prefetcher struct {
 	l2Fetcher     L2Source
 	lastHint      string
 	kvStore       kvstore.KV
+	scheduler     *scheduler
+	metrics       Metrics
 }
 
-func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l1BlobFetcher L1BlobSource, l2Fetcher L2Source, kvStore kvstore.KV) *Prefetcher {
-	return &Prefetcher{
+func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l1BlobFetcher L1BlobSource, l2Fetcher L2Source, kvStore kvstore.KV, opts ...PrefetcherOpt) *Prefetcher {
+	p := &Prefetcher{
 		logger:        logger,
 		l1Fetcher:     NewRetryingL1Source(logger, l1Fetcher),
 		l1BlobFetcher: NewRetryingL1BlobSource(logger, l1BlobFetcher),
 		l2Fetcher:     NewRetryingL2Source(logger, l2Fetcher),
 		kvStore:       kvStore,
+		metrics:       noopMetrics{},
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 func (p *Prefetcher) Hint(hint string) error {
 	p.logger.Trace("Received hint", "hint", hint)
 	p.lastHint = hint
+	if p.scheduler != nil {
+		p.scheduler.prioritize(hint)
+	}
 	return nil
 }
 
 func (p *Prefetcher) GetPreimage(ctx context.Context, key common.Hash) ([]byte, error) {
 	p.logger.Trace("Pre-image requested", "key", key)
 	pre, err := p.kvStore.Get(key)
+	p.metrics.RecordPreimageRequest(!errors.Is(err, kvstore.ErrNotFound))
 	// Use a loop to keep retrying the prefetch as long as the key is not found
 	// This handles the case where the prefetch downloads a preimage, but it is then deleted unexpectedly
 	// before we get to read it.
 	for errors.Is(err, kvstore.ErrNotFound) && p.lastHint != "" {
 		hint := p.lastHint
-		if err := p.prefetch(ctx, hint); err != nil {
+		if err := p.fetch(ctx, hint); err != nil {
 			return nil, fmt.Errorf("prefetch failed: %w", err)
 		}
 		pre, err = p.kvStore.Get(key)
@@ -96,12 +121,29 @@ func (p *Prefetcher) GetPreimage(ctx context.Context, key common.Hash) ([]byte,
 	return pre, err
 }
 
+// fetch fetches the preimages of the hint, using the background task of the scheduler for it if there is one,
+// and lets the scheduler fetch the preimages expected to be requested next in the background.
+func (p *Prefetcher) fetch(ctx context.Context, hint string) error {
+	if p.scheduler == nil {
+		return p.prefetch(ctx, hint)
+	}
+	if err := p.scheduler.fetchNow(ctx, hint); err != nil {
+		return err
+	}
+	p.scheduler.scheduleNext(ctx, hint)
+	return nil
+}
+
 func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 	hintType, hintBytes, err := parseHint(hint)
 	if err != nil {
 		return err
 	}
 	p.logger.Debug("Prefetching", "type", hintType, "bytes", hexutil.Bytes(hintBytes))
+	start := time.Now()
+	defer func() {
+		p.metrics.RecordHint(hintType, time.Since(start))
+	}()
 	switch hintType {
 	case l1.HintL1BlockHeader:
 		if len(hintBytes) != 32 {
@@ -112,6 +154,9 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		if err != nil {
 			return fmt.Errorf("failed to fetch L1 block %s header: %w", hash, err)
 		}
+		if p.scheduler != nil {
+			p.scheduler.addL1Header(hash, header.ParentHash())
+		}
 		data, err := header.HeaderRLP()
 		if err != nil {
 			return fmt.Errorf("marshall header: %w", err)
@@ -187,7 +232,7 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		if !slices.Contains(acceleratedPrecompiles, precompileAddress) {
 			return fmt.Errorf("unsupported precompile address: %s", precompileAddress)
 		}
-		// NOTE: We use the precompiled contracts from Cancun because it's the only set that contains the addresses of all accelerated precompiles
+		// NOTE: We use the precompiled contracts from Cancun and the L1 BLS12-381 precompiles, as these contain all accelerated precompiles
 		// We assume the precompile Run function behavior does not change across EVM upgrades.
 		// As such, we must not rely on upgrade-specific behavior such as precompile.RequiredGas.
 		precompile := getPrecompiledContract(precompileAddress)
@@ -214,16 +259,22 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		// The requiredGas is only used by the L1 PreimageOracle to enforce complete precompile execution.
 
 		// For extra safety, avoid accelerating unexpected precompiles
-		if !slices.Contains(acceleratedPrecompiles, precompileAddress) {
+		if !slices.Contains(acceleratedPrecompilesV2, precompileAddress) {
 			return fmt.Errorf("unsupported precompile address: %s", precompileAddress)
 		}
-		// NOTE: We use the precompiled contracts from Cancun because it's the only set that contains the addresses of all accelerated precompiles
+		input := hintBytes[28:]
+		// The client handles BLS12-381 inputs of an invalid length itself, without the oracle.
+		// Reject them, rather than storing a result for an input the client is not expected to request.
+		if engineapi.IsBLS12381Precompile(precompileAddress) && !engineapi.ValidBLS12381InputLength(precompileAddress, input) {
+			return fmt.Errorf("invalid input length %d for BLS12-381 precompile %s", len(input), precompileAddress)
+		}
+		// NOTE: We use the precompiled contracts from Cancun and the L1 BLS12-381 precompiles, as these contain all accelerated precompiles
 		// We assume the precompile Run function behavior does not change across EVM upgrades.
 		// As such, we must not rely on upgrade-specific behavior such as precompile.RequiredGas.
 		precompile := getPrecompiledContract(precompileAddress)
 
-		// KZG Point Evaluation precompile also verifies its input
-		result, err := precompile.Run(hintBytes[28:])
+		// KZG Point Evaluation and BLS12-381 precompiles also verify their input
+		result, err := precompile.Run(input)
 		if err == nil {
 			result = append(precompileSuccess[:], result...)
 		} else {
@@ -329,5 +380,9 @@ func parseHint(hint string) (string, []byte, error) {
 }
 
 func getPrecompiledContract(address common.Address) vm.PrecompiledContract {
+	// The BLS12-381 precompiles must match the L1 precompiles, which are at different addresses than in the geth Prague set.
+	if engineapi.IsBLS12381Precompile(address) {
+		return engineapi.BLS12381PrecompiledContract(address)
+	}
 	return vm.PrecompiledContractsCancun[address]
 }
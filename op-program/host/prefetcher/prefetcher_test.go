@@ -1,12 +1,14 @@
 package prefetcher
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -162,6 +164,50 @@ func TestFetchL1Receipts(t *testing.T) {
 	})
 }
 
+func TestSchedulerL1BlockData(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	_, l1Cl, l1BlobSource, l2Cl, kv := createPrefetcher(t)
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LevelDebug), l1Cl, l1BlobSource, l2Cl, kv, WithParallelism(2))
+	defer l1Cl.AssertExpectations(t)
+
+	var blocks []*types.Block
+	var receipts []types.Receipts
+	for i := 0; i < 4; i++ {
+		block, rcpts := testutils.RandomBlock(rng, 2)
+		if i > 0 {
+			header := block.Header()
+			header.ParentHash = blocks[i-1].Hash()
+			block = block.WithSeal(header)
+			for _, rcpt := range rcpts {
+				rcpt.BlockHash = block.Hash()
+				for _, l := range rcpt.Logs {
+					l.BlockHash = block.Hash()
+				}
+			}
+		}
+		blocks = append(blocks, block)
+		receipts = append(receipts, rcpts)
+		// Each block is only fetched once, whether by the client or in the background
+		l1Cl.ExpectInfoByHash(block.Hash(), eth.BlockToInfo(block), nil)
+		l1Cl.ExpectInfoAndTxsByHash(block.Hash(), eth.BlockToInfo(block), block.Transactions(), nil)
+		l1Cl.ExpectFetchReceipts(block.Hash(), eth.BlockToInfo(block), rcpts, nil)
+	}
+
+	oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+	// Walk back from the last block, like the client looking for the L1 origin
+	for i := len(blocks) - 1; i >= 0; i-- {
+		require.Equal(t, blocks[i].Hash(), oracle.HeaderByBlockHash(blocks[i].Hash()).Hash())
+	}
+	// Fetching the receipts of the first block also fetches the transactions and receipts of the next two blocks.
+	// The last block is fetched after a miss.
+	for i, block := range blocks {
+		_, actualReceipts := oracle.ReceiptsByBlockHash(block.Hash())
+		assertReceiptsEqual(t, receipts[i], actualReceipts)
+		_, txs := oracle.TransactionsByBlockHash(block.Hash())
+		assertTransactionsEqual(t, block.Transactions(), txs)
+	}
+}
+
 func GetRandBlob(t *testing.T, seed int64) eth.Blob {
 	r := rand.New(rand.NewSource(seed))
 	bigData := eth.Data(make([]byte, eth.MaxBlobDataSize))
@@ -349,6 +395,41 @@ func TestFetchPrecompileResultV2(t *testing.T) {
 			requiredGas: 50_000,
 			result:      failure,
 		},
+		{
+			name:        "BLS12381G1Add-Valid",
+			addr:        common.BytesToAddress([]byte{0x0b}),
+			input:       make([]byte, 256), // sum of two points at infinity
+			requiredGas: 500,
+			result:      append(success, make([]byte, 128)...),
+		},
+		{
+			name:        "BLS12381G1MSM-Valid",
+			addr:        common.BytesToAddress([]byte{0x0c}),
+			input:       make([]byte, 320), // multi-scalar multiplication of two points at infinity
+			requiredGas: 22_000,
+			result:      append(success, make([]byte, 128)...),
+		},
+		{
+			name:        "BLS12381G2Add-Valid",
+			addr:        common.BytesToAddress([]byte{0x0d}),
+			input:       make([]byte, 512), // sum of two points at infinity
+			requiredGas: 800,
+			result:      append(success, make([]byte, 256)...),
+		},
+		{
+			name:        "BLS12381Pairing-Valid",
+			addr:        common.BytesToAddress([]byte{0x0f}),
+			input:       make([]byte, 384), // pairing of points at infinity
+			requiredGas: 108_000,
+			result:      append(success, common.FromHex("0000000000000000000000000000000000000000000000000000000000000001")...),
+		},
+		{
+			name:        "BLS12381MapG1-Invalid",
+			addr:        common.BytesToAddress([]byte{0x10}),
+			input:       bytes.Repeat([]byte{0xff}, 64), // field element larger than the modulus
+			requiredGas: 5500,
+			result:      failure,
+		},
 	}
 	for _, test := range tests {
 		test := test
@@ -402,8 +483,34 @@ func TestUnsupportedPrecompile(t *testing.T) {
 	oracle.Precompile(common.HexToAddress("0xdead"), nil)
 }
 
+func TestUnsupportedLegacyBLS12381Precompile(t *testing.T) {
+	prefetcher, _, _, _, _ := createPrefetcher(t)
+	oracleFn := func(t *testing.T, prefetcher *Prefetcher) preimage.OracleFn {
+		return func(key preimage.Key) []byte {
+			_, err := prefetcher.GetPreimage(context.Background(), key.PreimageKey())
+			require.ErrorContains(t, err, "unsupported precompile address")
+			return []byte{1}
+		}
+	}
+	oracle := newLegacyPrecompileOracle(oracleFn(t, prefetcher), asHinter(t, prefetcher))
+	oracle.Precompile(common.BytesToAddress([]byte{0x0b}), make([]byte, 256))
+}
+
+func TestInvalidBLS12381PrecompileInputLength(t *testing.T) {
+	prefetcher, _, _, _, _ := createPrefetcher(t)
+	oracleFn := func(t *testing.T, prefetcher *Prefetcher) preimage.OracleFn {
+		return func(key preimage.Key) []byte {
+			_, err := prefetcher.GetPreimage(context.Background(), key.PreimageKey())
+			require.ErrorContains(t, err, "invalid input length")
+			return []byte{1}
+		}
+	}
+	oracle := l1.NewPreimageOracle(oracleFn(t, prefetcher), asHinter(t, prefetcher))
+	oracle.Precompile(common.BytesToAddress([]byte{0x0b}), make([]byte, 255), 500)
+}
+
 func TestRestrictedPrecompileContracts(t *testing.T) {
-	for _, addr := range acceleratedPrecompiles {
+	for _, addr := range acceleratedPrecompilesV2 {
 		require.NotNil(t, getPrecompiledContract(addr))
 	}
 }
@@ -561,6 +668,47 @@ func TestBadHints(t *testing.T) {
 	})
 }
 
+type recordingMetrics struct {
+	hints  []string
+	hits   int
+	misses int
+}
+
+func (m *recordingMetrics) RecordHint(hintType string, duration time.Duration) {
+	m.hints = append(m.hints, hintType)
+}
+
+func (m *recordingMetrics) RecordPreimageRequest(hit bool) {
+	if hit {
+		m.hits++
+	} else {
+		m.misses++
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	node := testutils.RandomData(rng, 30)
+	hash := crypto.Keccak256Hash(node)
+
+	_, l1Source, l1BlobSource, l2Cl, kv := createPrefetcher(t)
+	m := &recordingMetrics{}
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LevelInfo), l1Source, l1BlobSource, l2Cl, kv, WithMetrics(m))
+	l2Cl.ExpectNodeByHash(hash, node, nil)
+	defer l2Cl.MockDebugClient.AssertExpectations(t)
+
+	oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+	require.EqualValues(t, node, oracle.NodeByHash(hash))
+	require.Equal(t, []string{l2.HintL2StateNode}, m.hints)
+	require.Equal(t, 0, m.hits)
+	require.Equal(t, 1, m.misses)
+
+	result, err := prefetcher.GetPreimage(context.Background(), preimage.Keccak256Key(hash).PreimageKey())
+	require.NoError(t, err)
+	require.EqualValues(t, node, result)
+	require.Equal(t, 1, m.hits)
+}
+
 func TestRetryWhenNotAvailableAfterPrefetching(t *testing.T) {
 	rng := rand.New(rand.NewSource(123))
 	node := testutils.RandomData(rng, 30)
@@ -0,0 +1,251 @@
+package prefetcher
+
+import (
+	"container/heap"
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/client/l2"
+)
+
+type PrefetcherOpt func(p *Prefetcher)
+
+// WithParallelism enables the prefetch scheduler: up to parallelism fetches of the preimages the client is expected
+// to request next run concurrently in the background, instead of being fetched one at a time on each cache miss.
+// The scheduler is disabled if parallelism is 0.
+func WithParallelism(parallelism int) PrefetcherOpt {
+	return func(p *Prefetcher) {
+		if parallelism > 0 {
+			p.scheduler = newScheduler(p.logger, parallelism, p.prefetch)
+		}
+	}
+}
+
+type fetchTask struct {
+	ctx  context.Context
+	hint string
+	key  string
+	// priority orders the queued tasks, lowest first
+	priority int64
+	// index is the position of the task in the queue, or -1 once it's started
+	index int
+	done  chan struct{}
+	err   error
+}
+
+// taskQueue is a heap of the tasks waiting for a worker, ordered by priority.
+type taskQueue []*fetchTask
+
+func (q taskQueue) Len() int           { return len(q) }
+func (q taskQueue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q taskQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *taskQueue) Push(x any) {
+	task := x.(*fetchTask)
+	task.index = len(*q)
+	*q = append(*q, task)
+}
+
+func (q *taskQueue) Pop() any {
+	old := *q
+	task := old[len(old)-1]
+	old[len(old)-1] = nil
+	task.index = -1
+	*q = old[:len(old)-1]
+	return task
+}
+
+// scheduler fetches the preimages of hints in the background with bounded parallelism.
+//
+// Derivation walks back the L1 chain from the L1 head, to find the L1 origin of the agreed L2 head, and then processes
+// the transactions and receipts of each L1 block in order. The headers fetched while walking back are the dependencies
+// of the scheduler: they provide the hashes of the next L1 blocks, so that the transactions and receipts of many blocks
+// can be fetched in parallel once the client requests the first of them.
+//
+// Hints that result in the same fetch are deduplicated across the run: the scheduler never fetches the same work twice
+// in the background, and a client request for work that is queued or in flight uses that task instead of fetching it again.
+// Queued tasks are fetched in the order the client is expected to read them, and a hinted task is fetched first,
+// since the client reads its preimages next.
+type scheduler struct {
+	logger      log.Logger
+	fetch       func(ctx context.Context, hint string) error
+	parallelism int
+	lookahead   int
+
+	mu      sync.Mutex
+	queue   taskQueue
+	running int
+	// tasks are the queued and in flight tasks, by work key
+	tasks map[string]*fetchTask
+	// fetched are the work keys fetched successfully by the scheduler in the background
+	fetched      map[string]struct{}
+	nextPriority int64
+	nextHint     int64
+	l1Children   map[common.Hash]common.Hash
+}
+
+func newScheduler(logger log.Logger, parallelism int, fetch func(ctx context.Context, hint string) error) *scheduler {
+	return &scheduler{
+		logger:      logger,
+		fetch:       fetch,
+		parallelism: parallelism,
+		lookahead:   parallelism,
+		tasks:       make(map[string]*fetchTask),
+		fetched:     make(map[string]struct{}),
+		l1Children:  make(map[common.Hash]common.Hash),
+	}
+}
+
+// workKey identifies the fetch of a hint. Hints that are fetched the same way have the same key.
+func workKey(hint string) string {
+	hintType, hintBytes, found := strings.Cut(hint, " ")
+	if found && hintType == l2.HintL2Transactions {
+		// The transactions of L2 blocks are fetched with their header
+		return l2.HintL2BlockHeader + " " + hintBytes
+	}
+	return hint
+}
+
+// addL1Header records the parent of a fetched L1 block, to find the blocks that follow it.
+func (s *scheduler) addL1Header(hash common.Hash, parent common.Hash) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.l1Children[parent] = hash
+}
+
+// outstanding returns the number of queued and in flight tasks.
+func (s *scheduler) outstanding() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.tasks)
+}
+
+// prioritize moves the task of a hint received from the client ahead of the other queued tasks, if it is queued.
+// Later hints are read sooner, as the client reads the preimages of a hint right after sending it.
+func (s *scheduler) prioritize(hint string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task, ok := s.tasks[workKey(hint)]
+	if !ok || task.index < 0 {
+		return
+	}
+	s.nextHint--
+	task.priority = s.nextHint
+	heap.Fix(&s.queue, task.index)
+}
+
+// fetchNow fetches the preimages of a hint requested by the client. A queued task for the hint is fetched immediately,
+// and an in flight task is waited for. The hint is fetched again after a failed background fetch.
+func (s *scheduler) fetchNow(ctx context.Context, hint string) error {
+	key := workKey(hint)
+	for {
+		s.mu.Lock()
+		task, ok := s.tasks[key]
+		if !ok {
+			task = &fetchTask{ctx: ctx, hint: hint, key: key, index: -1, done: make(chan struct{})}
+			s.tasks[key] = task
+		} else if task.index >= 0 {
+			heap.Remove(&s.queue, task.index)
+			task.ctx = ctx
+		} else {
+			s.mu.Unlock()
+			select {
+			case <-task.done:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			if task.err == nil {
+				return nil
+			}
+			s.logger.Debug("Retrying failed background prefetch", "hint", hint, "err", task.err)
+			continue
+		}
+		s.mu.Unlock()
+		s.run(task, false)
+		return task.err
+	}
+}
+
+// scheduleNext fetches the preimages the client is expected to request after the hint in the background.
+// For the transactions or receipts of an L1 block, these are the transactions and receipts of the block and of
+// the next known blocks, up to the lookahead.
+func (s *scheduler) scheduleNext(ctx context.Context, hint string) {
+	hintType, hintBytes, err := parseHint(hint)
+	if err != nil || len(hintBytes) != 32 || (hintType != l1.HintL1Transactions && hintType != l1.HintL1Receipts) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hash := common.Hash(hintBytes)
+	for i := 0; i <= s.lookahead; i++ {
+		s.scheduleLocked(ctx, l1.TransactionsHint(hash).Hint())
+		s.scheduleLocked(ctx, l1.ReceiptsHint(hash).Hint())
+		next, ok := s.l1Children[hash]
+		if !ok {
+			break
+		}
+		hash = next
+	}
+	s.logger.Trace("Scheduled prefetches", "hint", hint, "outstanding", len(s.tasks))
+}
+
+// scheduleLocked queues the fetch of the hint, unless it's already queued, in flight or fetched.
+func (s *scheduler) scheduleLocked(ctx context.Context, hint string) {
+	key := workKey(hint)
+	if _, ok := s.tasks[key]; ok {
+		return
+	}
+	if _, ok := s.fetched[key]; ok {
+		return
+	}
+	task := &fetchTask{ctx: ctx, hint: hint, key: key, priority: s.nextPriority, done: make(chan struct{})}
+	s.nextPriority++
+	s.tasks[key] = task
+	heap.Push(&s.queue, task)
+	if s.running < s.parallelism {
+		s.running++
+		go s.work()
+	}
+}
+
+// work runs queued tasks until the queue is empty.
+func (s *scheduler) work() {
+	for {
+		s.mu.Lock()
+		if s.queue.Len() == 0 {
+			s.running--
+			s.mu.Unlock()
+			return
+		}
+		task := heap.Pop(&s.queue).(*fetchTask)
+		s.mu.Unlock()
+		s.run(task, true)
+	}
+}
+
+func (s *scheduler) run(task *fetchTask, background bool) {
+	if background {
+		s.logger.Trace("Prefetching in background", "hint", task.hint)
+	}
+	err := s.fetch(task.ctx, task.hint)
+	if err != nil && background {
+		s.logger.Debug("Background prefetch failed", "hint", task.hint, "err", err)
+	}
+	s.mu.Lock()
+	delete(s.tasks, task.key)
+	if err == nil {
+		s.fetched[task.key] = struct{}{}
+	}
+	s.mu.Unlock()
+	task.err = err
+	close(task.done)
+}
@@ -0,0 +1,175 @@
+package prefetcher
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/client/l2"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// stubFetcher records the fetched hints, and blocks each fetch until it's released.
+type stubFetcher struct {
+	mu      sync.Mutex
+	fetched []string
+	started chan string
+	release chan struct{}
+	err     error
+}
+
+func newStubFetcher() *stubFetcher {
+	return &stubFetcher{started: make(chan string, 100), release: make(chan struct{})}
+}
+
+func (f *stubFetcher) fetch(_ context.Context, hint string) error {
+	f.started <- hint
+	<-f.release
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fetched = append(f.fetched, hint)
+	return f.err
+}
+
+func (f *stubFetcher) fetchedHints() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.fetched...)
+}
+
+// linkedBlocks adds n linked L1 blocks to the scheduler and returns their hashes, in chain order.
+func linkedBlocks(s *scheduler, n int) []common.Hash {
+	var hashes []common.Hash
+	for i := 0; i < n; i++ {
+		hash := common.Hash{byte(i + 1)}
+		if i > 0 {
+			s.addL1Header(hash, hashes[i-1])
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
+func TestSchedulerDeduplicatesHints(t *testing.T) {
+	f := newStubFetcher()
+	close(f.release)
+	s := newScheduler(testlog.Logger(t, log.LevelDebug), 2, f.fetch)
+	ctx := context.Background()
+
+	blockHash := common.Hash{0xaa}
+	require.NoError(t, s.fetchNow(ctx, l2.BlockHeaderHint(blockHash).Hint()))
+	s.mu.Lock()
+	s.scheduleLocked(ctx, l2.TransactionsHint(blockHash).Hint())
+	s.mu.Unlock()
+	require.Zero(t, s.outstanding(), "transactions are fetched with the header")
+
+	hashes := linkedBlocks(s, 2)
+	s.scheduleNext(ctx, l1.ReceiptsHint(hashes[0]).Hint())
+	s.scheduleNext(ctx, l1.ReceiptsHint(hashes[0]).Hint())
+	s.scheduleNext(ctx, l1.TransactionsHint(hashes[1]).Hint())
+	waitForOutstanding(t, s, 0)
+	require.ElementsMatch(t, []string{
+		l2.BlockHeaderHint(blockHash).Hint(),
+		l1.TransactionsHint(hashes[0]).Hint(),
+		l1.ReceiptsHint(hashes[0]).Hint(),
+		l1.TransactionsHint(hashes[1]).Hint(),
+		l1.ReceiptsHint(hashes[1]).Hint(),
+	}, f.fetchedHints())
+}
+
+func TestSchedulerPriority(t *testing.T) {
+	f := newStubFetcher()
+	s := newScheduler(testlog.Logger(t, log.LevelDebug), 1, f.fetch)
+	hashes := linkedBlocks(s, 2)
+	s.scheduleNext(context.Background(), l1.TransactionsHint(hashes[0]).Hint())
+	// The lookahead is the parallelism, so the next block is scheduled too
+	require.Equal(t, 4, s.outstanding())
+
+	// The single worker is busy with the first task, and the rest are queued in the order they're read
+	require.Equal(t, l1.TransactionsHint(hashes[0]).Hint(), <-f.started)
+	// A hint from the client moves its task ahead of the queue
+	s.prioritize(l1.ReceiptsHint(hashes[1]).Hint())
+	close(f.release)
+	waitForOutstanding(t, s, 0)
+	require.Equal(t, []string{
+		l1.TransactionsHint(hashes[0]).Hint(),
+		l1.ReceiptsHint(hashes[1]).Hint(),
+		l1.ReceiptsHint(hashes[0]).Hint(),
+		l1.TransactionsHint(hashes[1]).Hint(),
+	}, f.fetchedHints())
+}
+
+func TestSchedulerFetchNow(t *testing.T) {
+	t.Run("Queued", func(t *testing.T) {
+		f := newStubFetcher()
+		s := newScheduler(testlog.Logger(t, log.LevelDebug), 1, f.fetch)
+		hashes := linkedBlocks(s, 2)
+		s.scheduleNext(context.Background(), l1.TransactionsHint(hashes[0]).Hint())
+		<-f.started
+
+		// The queued task is fetched by the client immediately, while the worker is busy
+		hint := l1.ReceiptsHint(hashes[1]).Hint()
+		result := make(chan error)
+		go func() {
+			result <- s.fetchNow(context.Background(), hint)
+		}()
+		require.Equal(t, hint, <-f.started)
+		close(f.release)
+		require.NoError(t, <-result)
+		waitForOutstanding(t, s, 0)
+		require.Len(t, f.fetchedHints(), 4)
+	})
+
+	t.Run("InFlight", func(t *testing.T) {
+		f := newStubFetcher()
+		s := newScheduler(testlog.Logger(t, log.LevelDebug), 1, f.fetch)
+		hash := common.Hash{0xaa}
+		s.scheduleNext(context.Background(), l1.TransactionsHint(hash).Hint())
+		hint := <-f.started
+
+		// The client waits for the in flight task instead of fetching it again
+		result := make(chan error)
+		go func() {
+			result <- s.fetchNow(context.Background(), hint)
+		}()
+		// Give the client time to start waiting before the task completes
+		time.Sleep(50 * time.Millisecond)
+		close(f.release)
+		require.NoError(t, <-result)
+		waitForOutstanding(t, s, 0)
+		require.Equal(t, []string{
+			l1.TransactionsHint(hash).Hint(),
+			l1.ReceiptsHint(hash).Hint(),
+		}, f.fetchedHints())
+	})
+
+	t.Run("RetryFailed", func(t *testing.T) {
+		f := newStubFetcher()
+		f.err = errors.New("boom")
+		close(f.release)
+		s := newScheduler(testlog.Logger(t, log.LevelDebug), 1, f.fetch)
+		hint := l1.TransactionsHint(common.Hash{0xaa}).Hint()
+		require.ErrorIs(t, s.fetchNow(context.Background(), hint), f.err)
+		require.Zero(t, s.outstanding())
+
+		// Failed tasks are scheduled again
+		s.mu.Lock()
+		s.scheduleLocked(context.Background(), hint)
+		s.mu.Unlock()
+		waitForOutstanding(t, s, 0)
+		require.Equal(t, []string{hint, hint}, f.fetchedHints())
+	})
+}
+
+func waitForOutstanding(t *testing.T, s *scheduler, n int) {
+	require.Eventually(t, func() bool {
+		return s.outstanding() == n
+	}, 10*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,56 @@
+package host
+
+import (
+	"context"
+	"fmt"
+
+	cl "github.com/ethereum-optimism/optimism/op-program/client"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// l2OutputSource provides the output roots of an L2 node, to verify in range mode.
+type l2OutputSource interface {
+	InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error)
+	InfoByNumber(ctx context.Context, number uint64) (eth.BlockInfo, error)
+	OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error)
+}
+
+// fetchRangeClaims dials the L2 node and retrieves the output roots to verify in range mode.
+func fetchRangeClaims(ctx context.Context, logger log.Logger, cfg *config.Config) ([]cl.RangeClaim, error) {
+	l2RPC, err := dialRPCs(ctx, logger, "L2", cfg.L2URLs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup L2 RPC: %w", err)
+	}
+	defer l2RPC.Close()
+	l2Cl, err := sources.NewL2Client(l2RPC, logger, nil, sources.L2ClientDefaultConfig(cfg.Rollup, true))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create L2 client: %w", err)
+	}
+	return rangeClaims(ctx, logger, l2Cl, cfg.L2Head, cfg.L2ClaimBlockNumber, cfg.RangeStep)
+}
+
+// rangeClaims returns the output roots of every step blocks after the agreed L2 head, before the claimed block.
+func rangeClaims(ctx context.Context, logger log.Logger, src l2OutputSource, l2Head common.Hash, l2ClaimBlockNum uint64, step uint64) ([]cl.RangeClaim, error) {
+	agreed, err := src.InfoByHash(ctx, l2Head)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve agreed L2 block %s: %w", l2Head, err)
+	}
+	var claims []cl.RangeClaim
+	for n := agreed.NumberU64() + step; n < l2ClaimBlockNum; n += step {
+		info, err := src.InfoByNumber(ctx, n)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve L2 block %d: %w", n, err)
+		}
+		output, err := src.OutputV0AtBlock(ctx, info.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve output at L2 block %d: %w", n, err)
+		}
+		claims = append(claims, cl.RangeClaim{BlockNumber: n, OutputRoot: common.Hash(eth.OutputRoot(output))})
+	}
+	logger.Info("Retrieved output roots to verify", "from", agreed.NumberU64(), "to", l2ClaimBlockNum, "step", step, "count", len(claims))
+	return claims, nil
+}
@@ -0,0 +1,82 @@
+package host
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	cl "github.com/ethereum-optimism/optimism/op-program/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+type stubL2OutputSource struct {
+	headers []*types.Header
+}
+
+func (s *stubL2OutputSource) InfoByHash(_ context.Context, hash common.Hash) (eth.BlockInfo, error) {
+	for _, header := range s.headers {
+		if header.Hash() == hash {
+			return eth.HeaderBlockInfo(header), nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (s *stubL2OutputSource) InfoByNumber(_ context.Context, number uint64) (eth.BlockInfo, error) {
+	if number >= uint64(len(s.headers)) {
+		return nil, errors.New("not found")
+	}
+	return eth.HeaderBlockInfo(s.headers[number]), nil
+}
+
+func (s *stubL2OutputSource) OutputV0AtBlock(_ context.Context, blockHash common.Hash) (*eth.OutputV0, error) {
+	return &eth.OutputV0{BlockHash: blockHash}, nil
+}
+
+func (s *stubL2OutputSource) outputRoot(n uint64) common.Hash {
+	return common.Hash(eth.OutputRoot(&eth.OutputV0{BlockHash: s.headers[n].Hash()}))
+}
+
+func TestRangeClaims(t *testing.T) {
+	src := &stubL2OutputSource{}
+	for i := 0; i < 20; i++ {
+		src.headers = append(src.headers, &types.Header{Number: big.NewInt(int64(i))})
+	}
+	logger := testlog.Logger(t, log.LevelInfo)
+
+	t.Run("EveryBlock", func(t *testing.T) {
+		claims, err := rangeClaims(context.Background(), logger, src, src.headers[5].Hash(), 9, 1)
+		require.NoError(t, err)
+		require.Equal(t, []cl.RangeClaim{
+			{BlockNumber: 6, OutputRoot: src.outputRoot(6)},
+			{BlockNumber: 7, OutputRoot: src.outputRoot(7)},
+			{BlockNumber: 8, OutputRoot: src.outputRoot(8)},
+		}, claims)
+	})
+
+	t.Run("Step", func(t *testing.T) {
+		claims, err := rangeClaims(context.Background(), logger, src, src.headers[5].Hash(), 15, 4)
+		require.NoError(t, err)
+		require.Equal(t, []cl.RangeClaim{
+			{BlockNumber: 9, OutputRoot: src.outputRoot(9)},
+			{BlockNumber: 13, OutputRoot: src.outputRoot(13)},
+		}, claims)
+	})
+
+	t.Run("NoBlocksBeforeClaim", func(t *testing.T) {
+		claims, err := rangeClaims(context.Background(), logger, src, src.headers[5].Hash(), 6, 1)
+		require.NoError(t, err)
+		require.Empty(t, claims)
+	})
+
+	t.Run("UnknownBlock", func(t *testing.T) {
+		_, err := rangeClaims(context.Background(), logger, src, src.headers[18].Hash(), 25, 1)
+		require.ErrorContains(t, err, "failed to retrieve L2 block 20")
+	})
+}
@@ -0,0 +1,177 @@
+package host
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	cl "github.com/ethereum-optimism/optimism/op-program/client"
+	"github.com/ethereum-optimism/optimism/op-program/client/claim"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+// ExitReason is the reason the fault proof program run ended.
+type ExitReason string
+
+const (
+	ExitClaimValid         ExitReason = "claim-valid"
+	ExitClaimInvalid       ExitReason = "claim-invalid"
+	ExitCrossCheckMismatch ExitReason = "cross-check-mismatch"
+	ExitProgramError       ExitReason = "program-error"
+)
+
+// claimInvalidExitCode is the exit code of the client program when the claim is invalid.
+const claimInvalidExitCode = 1
+
+var preimageKeyTypeNames = map[preimage.KeyType]string{
+	preimage.LocalKeyType:         "local",
+	preimage.Keccak256KeyType:     "keccak256",
+	preimage.GlobalGenericKeyType: "global-generic",
+	preimage.Sha256KeyType:        "sha256",
+	preimage.BlobKeyType:          "blob",
+	preimage.PrecompileKeyType:    "precompile",
+}
+
+// Report is the machine-readable summary of a run of the fault proof program.
+type Report struct {
+	L1Head             common.Hash `json:"l1Head"`
+	L2OutputRoot       common.Hash `json:"l2OutputRoot"`
+	L2ClaimBlockNumber uint64      `json:"l2ClaimBlockNumber"`
+	ClaimedOutputRoot  common.Hash `json:"claimedOutputRoot"`
+	// ComputedOutputRoot is the output root computed by the client program.
+	// Omitted if the client program did not complete derivation.
+	ComputedOutputRoot *common.Hash `json:"computedOutputRoot,omitempty"`
+	// AgreedBlockNumber and SafeHeadNumber are the L2 blocks derivation started from and ended at.
+	// Zero if the client program did not complete derivation.
+	AgreedBlockNumber uint64 `json:"agreedBlockNumber"`
+	SafeHeadNumber    uint64 `json:"safeHeadNumber"`
+	BlocksDerived     uint64 `json:"blocksDerived"`
+	// Preimages is the number of pre-images served to the client program, by key type.
+	Preimages  map[string]uint64 `json:"preimages"`
+	WallTimeMs int64             `json:"wallTimeMs"`
+	ExitReason ExitReason        `json:"exitReason"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// reportRecorder collects the pre-images served to, and the result reported by, the client program.
+type reportRecorder struct {
+	logger log.Logger
+
+	mu        sync.Mutex
+	preimages map[preimage.KeyType]uint64
+	result    *cl.ProgramResult
+}
+
+func newReportRecorder(logger log.Logger) *reportRecorder {
+	return &reportRecorder{
+		logger:    logger,
+		preimages: make(map[preimage.KeyType]uint64),
+	}
+}
+
+func (r *reportRecorder) wrapGetter(getter preimage.PreimageGetter) preimage.PreimageGetter {
+	return func(key [32]byte) ([]byte, error) {
+		data, err := getter(key)
+		if err == nil {
+			r.mu.Lock()
+			r.preimages[preimage.KeyType(key[0])]++
+			r.mu.Unlock()
+		}
+		return data, err
+	}
+}
+
+// wrapHinter records the program result hint of the client program, and passes any other hints on to the hinter.
+func (r *reportRecorder) wrapHinter(hinter preimage.HintHandler) preimage.HintHandler {
+	return func(hint string) error {
+		hintType, hexData, _ := strings.Cut(hint, " ")
+		if hintType != cl.HintProgramResult {
+			return hinter(hint)
+		}
+		data, err := hexutil.Decode(hexData)
+		if err != nil {
+			r.logger.Warn("Ignoring invalid program result hint", "hint", hint, "err", err)
+			return nil
+		}
+		result, err := cl.ParseProgramResult(data)
+		if err != nil {
+			r.logger.Warn("Ignoring invalid program result hint", "hint", hint, "err", err)
+			return nil
+		}
+		r.mu.Lock()
+		r.result = &result
+		r.mu.Unlock()
+		return nil
+	}
+}
+
+// programResult returns the result reported by the client program, or nil if it didn't report one.
+func (r *reportRecorder) programResult() *cl.ProgramResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.result
+}
+
+func (r *reportRecorder) report(cfg *config.Config, wallTime time.Duration, runErr error) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	report := &Report{
+		L1Head:             cfg.L1Head,
+		L2OutputRoot:       cfg.L2OutputRoot,
+		L2ClaimBlockNumber: cfg.L2ClaimBlockNumber,
+		ClaimedOutputRoot:  cfg.L2Claim,
+		Preimages:          make(map[string]uint64, len(r.preimages)),
+		WallTimeMs:         wallTime.Milliseconds(),
+		ExitReason:         exitReason(runErr),
+	}
+	if r.result != nil {
+		outputRoot := common.Hash(r.result.OutputRoot)
+		report.ComputedOutputRoot = &outputRoot
+		report.AgreedBlockNumber = r.result.AgreedBlockNumber
+		report.SafeHeadNumber = r.result.SafeHeadNumber
+		if r.result.SafeHeadNumber > r.result.AgreedBlockNumber {
+			report.BlocksDerived = r.result.SafeHeadNumber - r.result.AgreedBlockNumber
+		}
+	}
+	for keyType, count := range r.preimages {
+		name, ok := preimageKeyTypeNames[keyType]
+		if !ok {
+			name = "unknown"
+		}
+		report.Preimages[name] += count
+	}
+	if runErr != nil {
+		report.Error = runErr.Error()
+	}
+	return report
+}
+
+func exitReason(err error) ExitReason {
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return ExitClaimValid
+	case errors.Is(err, ErrCrossCheckMismatch):
+		return ExitCrossCheckMismatch
+	case errors.Is(err, claim.ErrClaimNotValid):
+		return ExitClaimInvalid
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == claimInvalidExitCode:
+		return ExitClaimInvalid
+	default:
+		return ExitProgramError
+	}
+}
+
+// writeReport writes the report as JSON to the path, or to stdout if the path is "-".
+func writeReport(path string, report *Report) error {
+	return jsonutil.WriteJSON(report, ioutil.ToStdOutOrFileOrNoop(path, 0o644))
+}
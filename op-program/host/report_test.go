@@ -0,0 +1,108 @@
+package host
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/client"
+	"github.com/ethereum-optimism/optimism/op-program/client/claim"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportRecorder(t *testing.T) {
+	cfg := config.NewConfig(chaincfg.Sepolia, chainconfig.OPSepoliaChainConfig, common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+	recorder := newReportRecorder(testlog.Logger(t, log.LevelInfo))
+
+	getter := recorder.wrapGetter(func(key [32]byte) ([]byte, error) {
+		if key == preimage.Keccak256Key(common.Hash{0, 0xff}).PreimageKey() {
+			return nil, errors.New("not found")
+		}
+		return []byte{1}, nil
+	})
+	for _, key := range []preimage.Key{
+		preimage.LocalIndexKey(client.L1HeadLocalIndex),
+		preimage.Keccak256Key(common.Hash{0, 0x01}),
+		preimage.Keccak256Key(common.Hash{0, 0x02}),
+		preimage.Keccak256Key(common.Hash{0, 0xff}),
+		preimage.PrecompileKey(common.Hash{0x03}),
+	} {
+		_, _ = getter(key.PreimageKey())
+	}
+
+	var forwarded []string
+	hinter := recorder.wrapHinter(func(hint string) error {
+		forwarded = append(forwarded, hint)
+		return nil
+	})
+	otherHint := "l1-block-header " + common.Hash{0x01}.Hex()
+	require.NoError(t, hinter(otherHint))
+	require.NoError(t, hinter(client.ProgramResultHint{
+		OutputRoot:        eth.Bytes32{0x55},
+		BlockHash:         common.Hash{0x66},
+		AgreedBlockNumber: 990,
+		SafeHeadNumber:    1005,
+	}.Hint()))
+	require.Equal(t, []string{otherHint}, forwarded)
+
+	report := recorder.report(cfg, 2*time.Second, nil)
+	computed := common.Hash{0x55}
+	require.Equal(t, &Report{
+		L1Head:             cfg.L1Head,
+		L2OutputRoot:       cfg.L2OutputRoot,
+		L2ClaimBlockNumber: cfg.L2ClaimBlockNumber,
+		ClaimedOutputRoot:  cfg.L2Claim,
+		ComputedOutputRoot: &computed,
+		AgreedBlockNumber:  990,
+		SafeHeadNumber:     1005,
+		BlocksDerived:      15,
+		Preimages:          map[string]uint64{"local": 1, "keccak256": 2, "precompile": 1},
+		WallTimeMs:         2000,
+		ExitReason:         ExitClaimValid,
+	}, report)
+}
+
+func TestReportWithoutResult(t *testing.T) {
+	cfg := config.NewConfig(chaincfg.Sepolia, chainconfig.OPSepoliaChainConfig, common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+	recorder := newReportRecorder(testlog.Logger(t, log.LevelInfo))
+	hinter := recorder.wrapHinter(func(hint string) error { return nil })
+	require.NoError(t, hinter(client.HintProgramResult+" 0x1234"))
+
+	report := recorder.report(cfg, time.Second, errors.New("boom"))
+	require.Nil(t, report.ComputedOutputRoot)
+	require.Zero(t, report.BlocksDerived)
+	require.Equal(t, ExitProgramError, report.ExitReason)
+	require.Equal(t, "boom", report.Error)
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, writeReport(path, report))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var decoded Report
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, report, &decoded)
+}
+
+func TestExitReason(t *testing.T) {
+	require.Equal(t, ExitClaimValid, exitReason(nil))
+	require.Equal(t, ExitClaimInvalid, exitReason(fmt.Errorf("wrapped: %w", claim.ErrClaimNotValid)))
+	require.Equal(t, ExitProgramError, exitReason(errors.New("failed")))
+
+	err := exec.Command("sh", "-c", "exit 1").Run()
+	require.Equal(t, ExitClaimInvalid, exitReason(fmt.Errorf("failed to wait for child program: %w", err)))
+	err = exec.Command("sh", "-c", "exit 2").Run()
+	require.Equal(t, ExitProgramError, exitReason(fmt.Errorf("failed to wait for child program: %w", err)))
+}
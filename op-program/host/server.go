@@ -0,0 +1,176 @@
+package host
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/metrics"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Clients of a socket pre-image server use two connections, one for hints and one for pre-image requests,
+// each speaking the same protocol as the inherited file descriptors of the server mode.
+// The first byte written on a connection selects the channel.
+const (
+	HintChannelSelector     = byte('h')
+	PreimageChannelSelector = byte('p')
+)
+
+// ParseListenAddr splits a server address into the network and address to listen on.
+// Addresses without a scheme are TCP addresses.
+func ParseListenAddr(addr string) (network string, address string, err error) {
+	switch {
+	case strings.HasPrefix(addr, "unix://"):
+		network, address = "unix", strings.TrimPrefix(addr, "unix://")
+	case strings.HasPrefix(addr, "tcp://"):
+		network, address = "tcp", strings.TrimPrefix(addr, "tcp://")
+	case strings.Contains(addr, "://"):
+		return "", "", fmt.Errorf("unsupported listen address %q", addr)
+	default:
+		network, address = "tcp", addr
+	}
+	if address == "" {
+		return "", "", fmt.Errorf("missing address in %q", addr)
+	}
+	return network, address, nil
+}
+
+// ListenAndServePreimages serves hints and pre-image requests on the given address, until the context is done.
+func ListenAndServePreimages(ctx context.Context, logger log.Logger, cfg *config.Config, addr string, prefetcherCreator PrefetcherCreator, m metrics.Metricer) error {
+	network, address, err := ParseListenAddr(addr)
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %v: %w", addr, err)
+	}
+	return SocketPreimageServer(ctx, logger, cfg, listener, prefetcherCreator, m)
+}
+
+// SocketPreimageServer accepts connections from the listener, and serves their hints and pre-image requests
+// until the context is done. The listener is closed before this function returns.
+// Unlike the PreimageServer, a failed request only closes the connection it was received on,
+// so that later clients can still connect to the server.
+func SocketPreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config, listener net.Listener, prefetcherCreator PrefetcherCreator, m metrics.Metricer) error {
+	defer listener.Close()
+	logger.Info("Starting preimage server", "addr", listener.Addr())
+	kv, getter, hinter, err := openPreimageSource(ctx, logger, cfg, prefetcherCreator, m)
+	if err != nil {
+		return err
+	}
+	defer kv.Close()
+
+	srv := &socketServer{
+		logger: logger,
+		getter: getter,
+		hinter: hinter,
+		conns:  make(map[net.Conn]struct{}),
+	}
+	defer srv.closeConns()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = listener.Close()
+		case <-done:
+		}
+	}()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				logger.Info("Shutting down")
+				return ctx.Err()
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+		srv.serveConn(conn)
+	}
+}
+
+type socketServer struct {
+	logger log.Logger
+	getter preimage.PreimageGetter
+	hinter preimage.HintHandler
+
+	// handlerLock serializes the requests of all connections, the prefetcher is not safe for concurrent use
+	handlerLock sync.Mutex
+
+	connsLock sync.Mutex
+	conns     map[net.Conn]struct{}
+	wg        sync.WaitGroup
+}
+
+func (s *socketServer) getPreimage(key [32]byte) ([]byte, error) {
+	s.handlerLock.Lock()
+	defer s.handlerLock.Unlock()
+	return s.getter(key)
+}
+
+func (s *socketServer) hint(hint string) error {
+	s.handlerLock.Lock()
+	defer s.handlerLock.Unlock()
+	return s.hinter(hint)
+}
+
+func (s *socketServer) serveConn(conn net.Conn) {
+	s.connsLock.Lock()
+	s.conns[conn] = struct{}{}
+	s.connsLock.Unlock()
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			s.connsLock.Lock()
+			delete(s.conns, conn)
+			s.connsLock.Unlock()
+			_ = conn.Close()
+		}()
+		logger := s.logger.New("remote", conn.RemoteAddr())
+		var channel [1]byte
+		if _, err := io.ReadFull(conn, channel[:]); err != nil {
+			logger.Warn("Failed to read channel selector", "err", err)
+			return
+		}
+		var next func() error
+		switch channel[0] {
+		case HintChannelSelector:
+			r := preimage.NewHintReader(conn)
+			next = func() error { return r.NextHint(s.hint) }
+		case PreimageChannelSelector:
+			r := preimage.NewOracleServer(conn)
+			next = func() error { return r.NextPreimageRequest(s.getPreimage) }
+		default:
+			logger.Warn("Unknown channel selector", "channel", channel[0])
+			return
+		}
+		logger.Debug("Serving connection", "channel", string(channel[:]))
+		for {
+			if err := next(); err != nil {
+				if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+					logger.Error("Failed to serve connection", "channel", string(channel[:]), "err", err)
+				}
+				return
+			}
+		}
+	}()
+}
+
+// closeConns closes all open connections, and waits for their handlers to complete.
+func (s *socketServer) closeConns() {
+	s.connsLock.Lock()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.connsLock.Unlock()
+	s.wg.Wait()
+}
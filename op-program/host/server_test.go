@@ -0,0 +1,97 @@
+package host
+
+import (
+	"context"
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/client"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseListenAddr(t *testing.T) {
+	for addr, expected := range map[string][2]string{
+		"unix:///tmp/host.sock": {"unix", "/tmp/host.sock"},
+		"tcp://localhost:9000":  {"tcp", "localhost:9000"},
+		"localhost:9000":        {"tcp", "localhost:9000"},
+	} {
+		network, address, err := ParseListenAddr(addr)
+		require.NoError(t, err, addr)
+		require.Equal(t, expected, [2]string{network, address}, addr)
+	}
+	for _, addr := range []string{"http://localhost:9000", "unix://", ""} {
+		_, _, err := ParseListenAddr(addr)
+		require.Error(t, err, addr)
+	}
+}
+
+func TestSocketPreimageServer(t *testing.T) {
+	l1Head := common.Hash{0x11}
+	l2OutputRoot := common.Hash{0x33}
+	cfg := config.NewConfig(chaincfg.Sepolia, chainconfig.OPSepoliaChainConfig, l1Head, common.Hash{0x22}, l2OutputRoot, common.Hash{0x44}, 1000)
+	cfg.DataDir = t.TempDir()
+	cfg.ServerMode = true
+
+	listener, err := net.Listen("unix", filepath.Join(t.TempDir(), "host.sock"))
+	require.NoError(t, err)
+	addr := listener.Addr()
+	logger := testlog.Logger(t, log.LevelTrace)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	result := make(chan error)
+	go func() {
+		result <- SocketPreimageServer(ctx, logger, cfg, listener, makeDefaultPrefetcher, metrics.NoopMetrics)
+	}()
+
+	dial := func(channel byte) net.Conn {
+		conn, err := net.Dial(addr.Network(), addr.String())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+		_, err = conn.Write([]byte{channel})
+		require.NoError(t, err)
+		return conn
+	}
+
+	// multiple clients are served concurrently
+	for i := 0; i < 2; i++ {
+		pClient := preimage.NewOracleClient(dial(PreimageChannelSelector))
+		hClient := preimage.NewHintWriter(dial(HintChannelSelector))
+		hClient.Hint(l1HeadHint{})
+		require.Equal(t, l1Head.Bytes(), pClient.Get(client.L1HeadLocalIndex), "Should get l1 head preimages")
+		require.Equal(t, l2OutputRoot.Bytes(), pClient.Get(client.L2OutputRootLocalIndex), "Should get l2 output root preimages")
+	}
+
+	// an unavailable preimage only closes the connection of the request
+	conn := dial(PreimageChannelSelector)
+	key := preimage.Keccak256Key(common.Hash{0xaa}).PreimageKey()
+	_, err = conn.Write(key[:])
+	require.NoError(t, err)
+	var length [8]byte
+	_, err = io.ReadFull(conn, length[:])
+	require.ErrorIs(t, err, io.EOF)
+	pClient := preimage.NewOracleClient(dial(PreimageChannelSelector))
+	require.Equal(t, l1Head.Bytes(), pClient.Get(client.L1HeadLocalIndex))
+
+	// unknown channels are closed
+	_, err = io.ReadFull(dial('x'), length[:])
+	require.ErrorIs(t, err, io.EOF)
+
+	cancel()
+	require.ErrorIs(t, waitFor(result), context.Canceled)
+}
+
+type l1HeadHint struct{}
+
+func (l1HeadHint) Hint() string {
+	return "l1-block-header 0x11"
+}
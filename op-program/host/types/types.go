@@ -1,5 +1,11 @@
 package types
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
 type DataFormat string
 
 const (
@@ -9,3 +15,37 @@ const (
 )
 
 var SupportedDataFormats = []DataFormat{DataFormatFile, DataFormatDirectory, DataFormatPebble}
+
+type BlobSourceKind string
+
+const (
+	// BlobSourceBeacon is a beacon API compatible endpoint, e.g. a blob archiver
+	BlobSourceBeacon BlobSourceKind = "beacon"
+	// BlobSourceBlobscan is a blobscan-style API that serves blobs by their versioned hash
+	BlobSourceBlobscan BlobSourceKind = "blobscan"
+)
+
+var SupportedBlobSourceKinds = []BlobSourceKind{BlobSourceBeacon, BlobSourceBlobscan}
+
+// BlobSource is a fallback endpoint to fetch blobs from when they are not available from the L1 beacon node.
+type BlobSource struct {
+	Kind BlobSourceKind
+	URL  string
+}
+
+// ParseBlobSource parses a blob source of the form <kind>:<url>.
+// The kind is optional and defaults to a beacon API endpoint.
+func ParseBlobSource(s string) (BlobSource, error) {
+	for _, kind := range SupportedBlobSourceKinds {
+		if url, ok := strings.CutPrefix(s, string(kind)+":"); ok {
+			if url == "" {
+				return BlobSource{}, fmt.Errorf("missing url in %s blob source", kind)
+			}
+			return BlobSource{Kind: kind, URL: url}, nil
+		}
+	}
+	if s == "" {
+		return BlobSource{}, errors.New("empty blob source")
+	}
+	return BlobSource{Kind: BlobSourceBeacon, URL: s}, nil
+}
@@ -203,9 +203,9 @@ func (r *Runner) run(l1Head common.Hash, agreedBlockInfo eth.BlockInfo, agreedOu
 		r.rollupCfg, r.chainCfg, l1Head, agreedBlockInfo.Hash(), agreedOutputRoot, claimedOutputRoot, claimedBlockInfo.NumberU64())
 	offlineCfg.DataDir = r.dataDir
 	onlineCfg := *offlineCfg
-	onlineCfg.L1URL = r.l1RpcUrl
-	onlineCfg.L1BeaconURL = r.l1BeaconUrl
-	onlineCfg.L2URL = r.l2RpcUrl
+	onlineCfg.L1URLs = []string{r.l1RpcUrl}
+	onlineCfg.L1BeaconURLs = []string{r.l1BeaconUrl}
+	onlineCfg.L2URLs = []string{r.l2RpcUrl}
 	if r.l1RpcKind != "" {
 		onlineCfg.L1RPCKind = sources.RPCProviderKind(r.l1RpcKind)
 	}
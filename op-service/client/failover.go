@@ -0,0 +1,239 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+const (
+	failoverMinBackoff = time.Second
+	failoverMaxBackoff = time.Minute
+)
+
+// JSON-RPC error codes that providers use to signal rate limits
+var rateLimitErrorCodes = []int{-32005, 429}
+
+// endpoints tracks the health of a set of interchangeable endpoints.
+// An endpoint that fails is skipped with an exponential backoff, until a request to it succeeds again.
+type endpoints struct {
+	log   log.Logger
+	clock clock.Clock
+
+	mu      sync.Mutex
+	current int
+	health  []endpointHealth
+}
+
+type endpointHealth struct {
+	failures int
+	retryAt  time.Time
+}
+
+func newEndpoints(lgr log.Logger, clk clock.Clock, n int) *endpoints {
+	return &endpoints{log: lgr, clock: clk, health: make([]endpointHealth, n)}
+}
+
+// order returns the endpoints to try, starting at the current endpoint.
+// Endpoints in backoff are tried last, so that a request is attempted even if all endpoints are failing.
+func (e *endpoints) order() []int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := e.clock.Now()
+	n := len(e.health)
+	healthy := make([]int, 0, n)
+	var backoff []int
+	for i := 0; i < n; i++ {
+		idx := (e.current + i) % n
+		if e.health[idx].retryAt.After(now) {
+			backoff = append(backoff, idx)
+		} else {
+			healthy = append(healthy, idx)
+		}
+	}
+	return append(healthy, backoff...)
+}
+
+func (e *endpoints) success(idx int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.health[idx].failures > 0 {
+		e.log.Info("Endpoint recovered", "endpoint", idx)
+	}
+	e.health[idx] = endpointHealth{}
+	e.current = idx
+}
+
+func (e *endpoints) failure(idx int, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	h := &e.health[idx]
+	h.failures++
+	backoff := min(failoverMinBackoff<<min(h.failures-1, 6), failoverMaxBackoff)
+	h.retryAt = e.clock.Now().Add(backoff)
+	if e.current == idx {
+		e.current = (idx + 1) % len(e.health)
+	}
+	e.log.Warn("Endpoint failed, rotating to next endpoint", "endpoint", idx, "failures", h.failures, "backoff", backoff, "err", err)
+}
+
+// do runs the request on the endpoints until one succeeds, or fails with an error that's not specific to the endpoint.
+func (e *endpoints) do(ctx context.Context, req func(idx int) (rotate bool, err error)) error {
+	var errs []error
+	for _, idx := range e.order() {
+		rotate, err := req(idx)
+		if !rotate {
+			if err == nil {
+				e.success(idx)
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+		e.failure(idx, err)
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// FailoverRPC is an RPC backed by multiple interchangeable endpoints.
+// Requests are sent to the current endpoint, and retried on the next endpoint when they fail
+// with a transport, HTTP or rate limit error. Other JSON-RPC errors are returned as-is.
+type FailoverRPC struct {
+	rpcs      []RPC
+	endpoints *endpoints
+}
+
+var _ RPC = (*FailoverRPC)(nil)
+
+func NewFailoverRPC(lgr log.Logger, rpcs []RPC) *FailoverRPC {
+	return newFailoverRPC(lgr, clock.SystemClock, rpcs)
+}
+
+func newFailoverRPC(lgr log.Logger, clk clock.Clock, rpcs []RPC) *FailoverRPC {
+	return &FailoverRPC{rpcs: rpcs, endpoints: newEndpoints(lgr, clk, len(rpcs))}
+}
+
+// isEndpointError returns true if the error is likely specific to the endpoint, and may not occur on other endpoints.
+func isEndpointError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		for _, code := range rateLimitErrorCodes {
+			if rpcErr.ErrorCode() == code {
+				return true
+			}
+		}
+		return false
+	}
+	// transport errors, timeouts and HTTP errors
+	return true
+}
+
+func (f *FailoverRPC) Close() {
+	for _, r := range f.rpcs {
+		r.Close()
+	}
+}
+
+func (f *FailoverRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	return f.endpoints.do(ctx, func(idx int) (bool, error) {
+		err := f.rpcs[idx].CallContext(ctx, result, method, args...)
+		return isEndpointError(err), err
+	})
+}
+
+func (f *FailoverRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return f.endpoints.do(ctx, func(idx int) (bool, error) {
+		err := f.rpcs[idx].BatchCallContext(ctx, b)
+		if err == nil {
+			for _, elem := range b {
+				if isEndpointError(elem.Error) {
+					// rate limits are reported per element by some providers
+					err = elem.Error
+					break
+				}
+			}
+		}
+		if isEndpointError(err) {
+			for i := range b {
+				b[i].Error = nil
+			}
+			return true, err
+		}
+		return false, err
+	})
+}
+
+func (f *FailoverRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	var sub ethereum.Subscription
+	err := f.endpoints.do(ctx, func(idx int) (bool, error) {
+		var err error
+		sub, err = f.rpcs[idx].EthSubscribe(ctx, channel, args...)
+		return isEndpointError(err), err
+	})
+	return sub, err
+}
+
+// FailoverHTTP is an HTTP client backed by multiple interchangeable endpoints.
+// Requests are retried on the next endpoint when they fail, are rate limited, or return a server error.
+type FailoverHTTP struct {
+	clients   []HTTP
+	endpoints *endpoints
+}
+
+var _ HTTP = (*FailoverHTTP)(nil)
+
+func NewFailoverHTTP(lgr log.Logger, clients []HTTP) *FailoverHTTP {
+	return newFailoverHTTP(lgr, clock.SystemClock, clients)
+}
+
+func newFailoverHTTP(lgr log.Logger, clk clock.Clock, clients []HTTP) *FailoverHTTP {
+	return &FailoverHTTP{clients: clients, endpoints: newEndpoints(lgr, clk, len(clients))}
+}
+
+// errLastResponse stops the failover to return the response of the last endpoint
+var errLastResponse = errors.New("last endpoint response")
+
+func (f *FailoverHTTP) Get(ctx context.Context, path string, query url.Values, headers http.Header) (*http.Response, error) {
+	var resp *http.Response
+	attempts := 0
+	err := f.endpoints.do(ctx, func(idx int) (bool, error) {
+		attempts++
+		var err error
+		resp, err = f.clients[idx].Get(ctx, path, query, headers)
+		if err != nil {
+			return !errors.Is(err, context.Canceled), err
+		}
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+			statusErr := errors.New(resp.Status)
+			if attempts == len(f.clients) {
+				// return the response of the last endpoint, so the caller can handle the status
+				f.endpoints.failure(idx, statusErr)
+				return false, errLastResponse
+			}
+			_ = resp.Body.Close()
+			return true, statusErr
+		}
+		return false, nil
+	})
+	if err != nil && !errors.Is(err, errLastResponse) {
+		return nil, err
+	}
+	return resp, nil
+}
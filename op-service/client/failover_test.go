@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type jsonRPCError struct {
+	code int
+}
+
+func (e *jsonRPCError) Error() string  { return "json-rpc error" }
+func (e *jsonRPCError) ErrorCode() int { return e.code }
+
+var _ rpc.Error = (*jsonRPCError)(nil)
+
+type stubRPC struct {
+	err   error
+	calls int
+}
+
+func (s *stubRPC) Close() {}
+
+func (s *stubRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	s.calls++
+	return s.err
+}
+
+func (s *stubRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	s.calls++
+	return s.err
+}
+
+func (s *stubRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	s.calls++
+	return nil, s.err
+}
+
+func TestFailoverRPC(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	ctx := context.Background()
+
+	t.Run("RotateOnTransportError", func(t *testing.T) {
+		clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+		a, b := &stubRPC{err: errors.New("connection refused")}, &stubRPC{}
+		f := newFailoverRPC(logger, clk, []RPC{a, b})
+		require.NoError(t, f.CallContext(ctx, nil, "eth_chainId"))
+		require.Equal(t, 1, a.calls)
+		require.Equal(t, 1, b.calls)
+
+		// the failed endpoint is skipped while it's in backoff
+		require.NoError(t, f.CallContext(ctx, nil, "eth_chainId"))
+		require.Equal(t, 1, a.calls)
+		require.Equal(t, 2, b.calls)
+
+		// and is tried again once the current endpoint fails
+		a.err = nil
+		b.err = errors.New("timeout")
+		clk.AdvanceTime(failoverMinBackoff)
+		require.NoError(t, f.CallContext(ctx, nil, "eth_chainId"))
+		require.Equal(t, 2, a.calls)
+		require.Equal(t, 3, b.calls)
+	})
+
+	t.Run("RotateOnRateLimit", func(t *testing.T) {
+		a, b := &stubRPC{err: &jsonRPCError{code: -32005}}, &stubRPC{}
+		f := newFailoverRPC(logger, clock.NewDeterministicClock(time.Unix(1000, 0)), []RPC{a, b})
+		require.NoError(t, f.BatchCallContext(ctx, []rpc.BatchElem{{Method: "eth_chainId"}}))
+		require.Equal(t, 1, b.calls)
+	})
+
+	t.Run("RotateOnBatchElementRateLimit", func(t *testing.T) {
+		a, b := &stubRPC{}, &stubRPC{}
+		f := newFailoverRPC(logger, clock.NewDeterministicClock(time.Unix(1000, 0)), []RPC{a, b})
+		batch := []rpc.BatchElem{{Method: "eth_chainId", Error: &jsonRPCError{code: 429}}}
+		require.NoError(t, f.BatchCallContext(ctx, batch))
+		require.Equal(t, 1, a.calls)
+		require.Equal(t, 1, b.calls)
+		require.NoError(t, batch[0].Error)
+	})
+
+	t.Run("ReturnApplicationError", func(t *testing.T) {
+		appErr := &jsonRPCError{code: -32000}
+		a, b := &stubRPC{err: appErr}, &stubRPC{}
+		f := newFailoverRPC(logger, clock.NewDeterministicClock(time.Unix(1000, 0)), []RPC{a, b})
+		require.ErrorIs(t, f.CallContext(ctx, nil, "debug_dbGet"), appErr)
+		require.Equal(t, 0, b.calls)
+	})
+
+	t.Run("AllFailing", func(t *testing.T) {
+		errA, errB := errors.New("a failed"), errors.New("b failed")
+		a, b := &stubRPC{err: errA}, &stubRPC{err: errB}
+		f := newFailoverRPC(logger, clock.NewDeterministicClock(time.Unix(1000, 0)), []RPC{a, b})
+		err := f.CallContext(ctx, nil, "eth_chainId")
+		require.ErrorIs(t, err, errA)
+		require.ErrorIs(t, err, errB)
+		// endpoints in backoff are still tried when all endpoints are failing
+		err = f.CallContext(ctx, nil, "eth_chainId")
+		require.Error(t, err)
+		require.Equal(t, 2, a.calls)
+		require.Equal(t, 2, b.calls)
+	})
+}
+
+type stubHTTP struct {
+	status int
+	calls  int
+}
+
+func (s *stubHTTP) Get(ctx context.Context, path string, query url.Values, headers http.Header) (*http.Response, error) {
+	s.calls++
+	return &http.Response{StatusCode: s.status, Status: http.StatusText(s.status), Body: io.NopCloser(strings.NewReader(""))}, nil
+}
+
+func TestFailoverHTTP(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	ctx := context.Background()
+
+	t.Run("RotateOnServerError", func(t *testing.T) {
+		a, b := &stubHTTP{status: http.StatusTooManyRequests}, &stubHTTP{status: http.StatusOK}
+		f := newFailoverHTTP(logger, clock.NewDeterministicClock(time.Unix(1000, 0)), []HTTP{a, b})
+		resp, err := f.Get(ctx, "/eth/v1/beacon/genesis", nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, 1, a.calls)
+	})
+
+	t.Run("ReturnClientError", func(t *testing.T) {
+		a, b := &stubHTTP{status: http.StatusNotFound}, &stubHTTP{status: http.StatusOK}
+		f := newFailoverHTTP(logger, clock.NewDeterministicClock(time.Unix(1000, 0)), []HTTP{a, b})
+		resp, err := f.Get(ctx, "/eth/v1/beacon/blob_sidecars/1", nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+		require.Equal(t, 0, b.calls)
+	})
+
+	t.Run("ReturnLastResponse", func(t *testing.T) {
+		a, b := &stubHTTP{status: http.StatusBadGateway}, &stubHTTP{status: http.StatusServiceUnavailable}
+		f := newFailoverHTTP(logger, clock.NewDeterministicClock(time.Unix(1000, 0)), []HTTP{a, b})
+		resp, err := f.Get(ctx, "/eth/v1/beacon/genesis", nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		require.Equal(t, 1, a.calls)
+		require.Equal(t, 1, b.calls)
+	})
+}
@@ -1,5 +1,20 @@
 package eth
 
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// KZGCommitmentInclusionProofDepth is the depth of the merkle proof of a KZG commitment in the beacon block body:
+// 4 levels for the fields of the body, 1 for the length of the commitments list, and 12 for the list of up to 4096 commitments.
+const KZGCommitmentInclusionProofDepth = 17
+
+// blobKZGCommitmentsIndex is the index of the blob KZG commitments in the fields of the beacon block body.
+const blobKZGCommitmentsIndex = 11
+
+var ErrInvalidInclusionProof = errors.New("invalid kzg commitment inclusion proof")
+
 type BlobSidecar struct {
 	Blob          Blob         `json:"blob"`
 	Index         Uint64String `json:"index"`
@@ -13,8 +28,47 @@ type APIBlobSidecar struct {
 	KZGCommitment     Bytes48                 `json:"kzg_commitment"`
 	KZGProof          Bytes48                 `json:"kzg_proof"`
 	SignedBlockHeader SignedBeaconBlockHeader `json:"signed_block_header"`
-	// The inclusion-proof of the blob-sidecar into the beacon-block is ignored,
-	// since we verify blobs by their versioned hashes against the execution-layer block instead.
+	// InclusionProof is the merkle proof of the KZG commitment in the body of the beacon block.
+	// Blobs from the Beacon API are verified by their versioned hashes against the execution-layer block instead,
+	// but the proof binds blob sidecars from untrusted sources, like gossip, to the beacon block header.
+	InclusionProof []Bytes32 `json:"kzg_commitment_inclusion_proof"`
+}
+
+// VerifyInclusionProof verifies the inclusion proof of the KZG commitment in the body root of the beacon block header.
+func (sc *APIBlobSidecar) VerifyInclusionProof() error {
+	root, err := sc.InclusionProofRoot()
+	if err != nil {
+		return err
+	}
+	if root != sc.SignedBlockHeader.Message.BodyRoot {
+		return fmt.Errorf("%w: commitment is not included in body root %s", ErrInvalidInclusionProof, sc.SignedBlockHeader.Message.BodyRoot)
+	}
+	return nil
+}
+
+// InclusionProofRoot computes the beacon block body root that the inclusion proof of the KZG commitment proves the commitment against.
+func (sc *APIBlobSidecar) InclusionProofRoot() (Bytes32, error) {
+	if len(sc.InclusionProof) != KZGCommitmentInclusionProofDepth {
+		return Bytes32{}, fmt.Errorf("%w: expected %d branch nodes, got %d", ErrInvalidInclusionProof, KZGCommitmentInclusionProofDepth, len(sc.InclusionProof))
+	}
+	if sc.Index >= 1<<(KZGCommitmentInclusionProofDepth-5) {
+		return Bytes32{}, fmt.Errorf("%w: blob index %d out of range", ErrInvalidInclusionProof, sc.Index)
+	}
+	// The position of the commitment in the body subtree:
+	// the commitments list field, its data (the left child, next to the length), and the commitment in the list.
+	position := (uint64(blobKZGCommitmentsIndex)<<1)<<(KZGCommitmentInclusionProofDepth-5) | uint64(sc.Index)
+	// The commitment is 48 bytes, merkleized as two 32-byte chunks
+	var chunks [64]byte
+	copy(chunks[:], sc.KZGCommitment[:])
+	value := sha256.Sum256(chunks[:])
+	for i, branch := range sc.InclusionProof {
+		if (position>>i)&1 == 1 {
+			value = sha256.Sum256(append(branch[:], value[:]...))
+		} else {
+			value = sha256.Sum256(append(value[:], branch[:]...))
+		}
+	}
+	return value, nil
 }
 
 func (sc *APIBlobSidecar) BlobSidecar() *BlobSidecar {
@@ -82,7 +82,7 @@ func TestAPIGetBlobSidecarsResponse(t *testing.T) {
 	var resp eth.APIGetBlobSidecarsResponse
 	require.NoError(json.Unmarshal(jsonStr, &resp))
 	require.NotEmpty(resp.Data)
-	require.Equal(5, reflect.TypeOf(*resp.Data[0]).NumField(), "APIBlobSidecar changed, adjust test")
+	require.Equal(6, reflect.TypeOf(*resp.Data[0]).NumField(), "APIBlobSidecar changed, adjust test")
 	require.Equal(1, reflect.TypeOf(resp.Data[0].SignedBlockHeader).NumField(), "SignedBeaconBlockHeader changed, adjust test")
 	require.Equal(5, reflect.TypeOf(resp.Data[0].SignedBlockHeader.Message).NumField(), "BeaconBlockHeader changed, adjust test")
 
@@ -95,4 +95,37 @@ func TestAPIGetBlobSidecarsResponse(t *testing.T) {
 	require.NotZero(resp.Data[0].SignedBlockHeader.Message.BodyRoot)
 	require.NotZero(resp.Data[0].SignedBlockHeader.Message.ProposerIndex)
 	require.NotZero(resp.Data[0].SignedBlockHeader.Message.StateRoot)
+	require.Len(resp.Data[0].InclusionProof, eth.KZGCommitmentInclusionProofDepth)
+}
+
+func TestAPIBlobSidecarVerifyInclusionProof(t *testing.T) {
+	jsonStr, err := os.ReadFile(filepath.Join("testdata", "eth_v1_beacon_blob_sidecars_7422094_goerli.json"))
+	require.NoError(t, err)
+	var resp eth.APIGetBlobSidecarsResponse
+	require.NoError(t, json.Unmarshal(jsonStr, &resp))
+
+	for _, sidecar := range resp.Data {
+		require.NoError(t, sidecar.VerifyInclusionProof(), "blob %d", sidecar.Index)
+	}
+
+	t.Run("WrongIndex", func(t *testing.T) {
+		sidecar := *resp.Data[0]
+		sidecar.Index = 1
+		require.ErrorIs(t, sidecar.VerifyInclusionProof(), eth.ErrInvalidInclusionProof)
+	})
+	t.Run("WrongCommitment", func(t *testing.T) {
+		sidecar := *resp.Data[0]
+		sidecar.KZGCommitment = resp.Data[1].KZGCommitment
+		require.ErrorIs(t, sidecar.VerifyInclusionProof(), eth.ErrInvalidInclusionProof)
+	})
+	t.Run("WrongBodyRoot", func(t *testing.T) {
+		sidecar := *resp.Data[0]
+		sidecar.SignedBlockHeader.Message.BodyRoot[0] ^= 1
+		require.ErrorIs(t, sidecar.VerifyInclusionProof(), eth.ErrInvalidInclusionProof)
+	})
+	t.Run("ShortProof", func(t *testing.T) {
+		sidecar := *resp.Data[0]
+		sidecar.InclusionProof = sidecar.InclusionProof[1:]
+		require.ErrorIs(t, sidecar.VerifyInclusionProof(), eth.ErrInvalidInclusionProof)
+	})
 }
@@ -39,3 +39,16 @@ type SyncStatus struct {
 	// LocalSafeL2 is an L2 block derived from L1, not yet verified to have valid cross-L2 dependencies.
 	LocalSafeL2 L2BlockRef `json:"local_safe_l2"`
 }
+
+// SyncPhase describes the sync phase of the rollup node, to track the EL sync of its execution engine.
+type SyncPhase struct {
+	// Phase is the name of the current sync phase: consensus-layer sync,
+	// one of the EL sync phases, or the consolidation that follows the EL sync.
+	Phase string `json:"phase"`
+	// Checkpoint is the block that the execution engine is directed to EL sync to, if any.
+	Checkpoint *BlockID `json:"checkpoint,omitempty"`
+	// ELSyncStart is the unix timestamp of the start of the EL sync, 0 if the EL sync did not start.
+	ELSyncStart uint64 `json:"el_sync_start"`
+	// UnsafeL2 is the unsafe L2 head of the rollup node.
+	UnsafeL2 L2BlockRef `json:"unsafe_l2"`
+}
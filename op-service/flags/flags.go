@@ -19,6 +19,7 @@ const (
 	FjordOverrideFlagName    = "override.fjord"
 	GraniteOverrideFlagName  = "override.granite"
 	HoloceneOverrideFlagName = "override.holocene"
+	InteropOverrideFlagName  = "override.interop"
 )
 
 func CLIFlags(envPrefix string, category string) []cli.Flag {
@@ -65,6 +66,13 @@ func CLIFlags(envPrefix string, category string) []cli.Flag {
 			Hidden:   false,
 			Category: category,
 		},
+		&cli.Uint64Flag{
+			Name:     InteropOverrideFlagName,
+			Usage:    "Manually specify the Interop fork timestamp, overriding the bundled setting",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "OVERRIDE_INTEROP"),
+			Hidden:   false,
+			Category: category,
+		},
 		CLINetworkFlag(envPrefix, category),
 		CLIRollupConfigFlag(envPrefix, category),
 	}
@@ -7,15 +7,25 @@ import (
 	"io"
 	"os"
 	"strings"
+
+	"github.com/klauspost/compress/zstd"
 )
 
-// OpenDecompressed opens a reader for the specified file and automatically gzip decompresses the content
-// if the filename ends with .gz
+// OpenDecompressed opens a reader for the specified file and automatically decompresses the content
+// if the filename ends with .gz (gzip) or .zst (zstd)
 func OpenDecompressed(path string) (io.ReadCloser, error) {
 	r, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
+	if IsZstd(path) {
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			r.Close()
+			return nil, fmt.Errorf("failed to create zstd reader: %w", err)
+		}
+		return NewWrappedReadCloser(zr.IOReadCloser(), r), nil
+	}
 	if IsGzip(path) {
 		gr, err := gzip.NewReader(r)
 		if err != nil {
@@ -27,7 +37,7 @@ func OpenDecompressed(path string) (io.ReadCloser, error) {
 	return r, nil
 }
 
-// OpenCompressed opens a file for writing and automatically compresses the content if the filename ends with .gz
+// OpenCompressed opens a file for writing and automatically compresses the content if the filename ends with .gz or .zst
 func OpenCompressed(file string, flag int, perm os.FileMode) (io.WriteCloser, error) {
 	out, err := os.OpenFile(file, flag, perm)
 	if err != nil {
@@ -68,7 +78,21 @@ func IsGzip(path string) bool {
 	return strings.HasSuffix(path, ".gz")
 }
 
+// IsZstd determines if a path points to a zstd compressed file.
+// Returns true when the file has a .zst extension.
+func IsZstd(path string) bool {
+	return strings.HasSuffix(path, ".zst")
+}
+
 func CompressByFileType(file string, out io.WriteCloser) io.WriteCloser {
+	if IsZstd(file) {
+		zw, err := zstd.NewWriter(out)
+		if err != nil {
+			// only returned for invalid encoder options
+			panic(fmt.Errorf("failed to create zstd writer: %w", err))
+		}
+		return NewWrappedWriteCloser(zw, out)
+	}
 	if IsGzip(file) {
 		return NewWrappedWriteCloser(gzip.NewWriter(out), out)
 	}
@@ -18,6 +18,7 @@ func TestReadWriteWithOptionalCompression(t *testing.T) {
 	}{
 		{"Uncompressed", "test.notgz", false},
 		{"Gzipped", "test.gz", true},
+		{"Zstd", "test.zst", true},
 	}
 	for _, test := range tests {
 		test := test
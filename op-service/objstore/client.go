@@ -0,0 +1,35 @@
+// Package objstore creates clients of S3-compatible object stores, e.g. S3 or GCS buckets.
+package objstore
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// NewClient creates a client of the S3-compatible object store at the endpoint, with credentials from the AWS or
+// MinIO environment variables, or from the instance IAM role. The region of a bucket is looked up if region is empty.
+// The default transport is used if transport is nil.
+func NewClient(endpoint string, region string, transport http.RoundTripper) (*minio.Client, error) {
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds: credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvAWS{},
+			&credentials.EnvMinio{},
+			&credentials.IAM{},
+		}),
+		Secure:    true,
+		Region:    region,
+		Transport: transport,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create object store client for %v: %w", endpoint, err)
+	}
+	return client, nil
+}
+
+// IsNotFound returns whether the error is the error response of the object store to a missing object.
+func IsNotFound(err error) bool {
+	return minio.ToErrorResponse(err).Code == "NoSuchKey"
+}
@@ -0,0 +1,42 @@
+package objstore
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClient(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "access-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret-key")
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Authorization"), "access-key") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/bucket/found" {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code></Error>`))
+			return
+		}
+		w.Header().Set("Content-Length", "7")
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.Listener.Addr().String(), "us-east-1", server.Client().Transport)
+	require.NoError(t, err)
+
+	_, err = client.StatObject(context.Background(), "bucket", "found", minio.StatObjectOptions{})
+	require.NoError(t, err)
+	_, err = client.StatObject(context.Background(), "bucket", "missing", minio.StatObjectOptions{})
+	require.True(t, IsNotFound(err))
+	require.False(t, IsNotFound(errors.New("other")))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
@@ -87,7 +88,12 @@ func (s *SignerClient) pingVersion() (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
 	defer cancel()
 	if err := s.client.CallContext(ctx, &v, "health_status"); err != nil {
-		return "", err
+		// Signers like web3signer don't support health_status, so fall back to checking they can list their accounts.
+		var accounts []common.Address
+		if accountsErr := s.client.CallContext(ctx, &accounts, "eth_accounts"); accountsErr != nil {
+			return "", errors.Join(err, accountsErr)
+		}
+		return "unknown", nil
 	}
 	return v, nil
 }
@@ -0,0 +1,87 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignWithoutHealthStatus(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	chainID := big.NewInt(900)
+	endpoint := startWeb3Signer(t, key, chainID)
+
+	client, err := NewSignerClient(testlog.Logger(t, log.LevelInfo), endpoint, optls.CLIConfig{})
+	require.NoError(t, err)
+	require.Equal(t, "ok [version=unknown]", client.status)
+
+	to := common.Address{0xaa}
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     3,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10),
+		Gas:       21_000,
+		To:        &to,
+		Value:     big.NewInt(5),
+		Data:      []byte{0x01, 0x02},
+	})
+	signed, err := client.SignTransaction(context.Background(), chainID, from, tx)
+	require.NoError(t, err)
+	require.Equal(t, tx.Data(), signed.Data())
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	require.NoError(t, err)
+	require.Equal(t, from, sender)
+}
+
+// startWeb3Signer starts a signer that, like web3signer, doesn't support health_status or the input field.
+func startWeb3Signer(t *testing.T, key *ecdsa.PrivateKey, chainID *big.Int) string {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", &web3SignerAPI{key: key, chainID: chainID}))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+	return httpServer.URL
+}
+
+type web3SignerAPI struct {
+	key     *ecdsa.PrivateKey
+	chainID *big.Int
+}
+
+func (s *web3SignerAPI) Accounts() []common.Address {
+	return []common.Address{crypto.PubkeyToAddress(s.key.PublicKey)}
+}
+
+func (s *web3SignerAPI) SignTransaction(args TransactionArgs) (hexutil.Bytes, error) {
+	// Only the data field is used to create the transaction
+	args.Input = nil
+	if err := args.Check(); err != nil {
+		return nil, err
+	}
+	txData, err := args.ToTransactionData()
+	if err != nil {
+		return nil, err
+	}
+	signed, err := types.SignNewTx(s.key, types.LatestSignerForChainID(s.chainID), txData)
+	if err != nil {
+		return nil, err
+	}
+	return signed.MarshalBinary()
+}
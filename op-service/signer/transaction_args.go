@@ -49,6 +49,7 @@ func NewTransactionArgsFromTransaction(chainId *big.Int, from *common.Address, t
 	accesses := tx.AccessList()
 	args := &TransactionArgs{
 		From:                 from,
+		Data:                 &data, // Also set data for signers, like web3signer, that don't support input yet
 		Input:                &data,
 		Nonce:                &nonce,
 		Value:                (*hexutil.Big)(tx.Value()),
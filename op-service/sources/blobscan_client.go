@@ -0,0 +1,59 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const blobscanBlobsMethodPrefix = "blobs/"
+
+// blobscanBlob is the subset of a blob of the blobscan API that is needed to rebuild its sidecar.
+type blobscanBlob struct {
+	Commitment eth.Bytes48 `json:"commitment"`
+	Proof      eth.Bytes48 `json:"proof"`
+	Data       eth.Blob    `json:"data"`
+}
+
+// BlobscanClient implements BlobSideCarsFetcher over a blobscan-style API, which indexes blobs by their
+// versioned hash and keeps them after they expired on the beacon nodes.
+// Blobs are looked up by hash, so the slot is ignored, and only the requested blobs are fetched.
+// The API is not trusted: every blob is verified against its requested versioned hash.
+type BlobscanClient struct {
+	cl client.HTTP
+}
+
+var _ BlobSideCarsFetcher = (*BlobscanClient)(nil)
+
+func NewBlobscanClient(cl client.HTTP) *BlobscanClient {
+	return &BlobscanClient{cl}
+}
+
+func (cl *BlobscanClient) BeaconBlobSideCars(ctx context.Context, _ bool, _ uint64, hashes []eth.IndexedBlobHash) (eth.APIGetBlobSidecarsResponse, error) {
+	resp := eth.APIGetBlobSidecarsResponse{Data: make([]*eth.APIBlobSidecar, 0, len(hashes))}
+	for _, h := range hashes {
+		var blob blobscanBlob
+		if err := getJSON(ctx, cl.cl, &blob, path.Join(blobscanBlobsMethodPrefix, h.Hash.Hex()), nil); err != nil {
+			return eth.APIGetBlobSidecarsResponse{}, fmt.Errorf("failed to fetch blob %s: %w", h.Hash, err)
+		}
+		commitment := kzg4844.Commitment(blob.Commitment)
+		if hash := eth.KZGToVersionedHash(commitment); hash != h.Hash {
+			return eth.APIGetBlobSidecarsResponse{}, fmt.Errorf("expected hash %s for blob at index %d but got %s", h.Hash, h.Index, hash)
+		}
+		if err := eth.VerifyBlobProof(&blob.Data, commitment, kzg4844.Proof(blob.Proof)); err != nil {
+			return eth.APIGetBlobSidecarsResponse{}, fmt.Errorf("blob %s failed verification: %w", h.Hash, err)
+		}
+		resp.Data = append(resp.Data, &eth.APIBlobSidecar{
+			Index:         eth.Uint64String(h.Index),
+			Blob:          blob.Data,
+			KZGCommitment: blob.Commitment,
+			KZGProof:      blob.Proof,
+		})
+	}
+	return resp, nil
+}
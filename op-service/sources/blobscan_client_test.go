@@ -0,0 +1,100 @@
+package sources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/stretchr/testify/require"
+
+	client_mocks "github.com/ethereum-optimism/optimism/op-service/client/mocks"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func blobscanResponse(t *testing.T, sidecar *eth.BlobSidecar) *http.Response {
+	respBytes, err := json.Marshal(&blobscanBlob{
+		Commitment: sidecar.KZGCommitment,
+		Proof:      sidecar.KZGProof,
+		Data:       sidecar.Blob,
+	})
+	require.NoError(t, err)
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(respBytes))}
+}
+
+func TestBlobscanClient(t *testing.T) {
+	ctx := context.Background()
+	headers := http.Header{}
+	headers.Add("Accept", "application/json")
+	index0, sidecar0 := makeTestBlobSidecar(3)
+	index1, sidecar1 := makeTestBlobSidecar(9)
+
+	t.Run("Success", func(t *testing.T) {
+		c := client_mocks.NewHTTP(t)
+		b := NewBlobscanClient(c)
+		c.EXPECT().Get(ctx, path.Join(blobscanBlobsMethodPrefix, index0.Hash.Hex()), url.Values(nil), headers).Return(blobscanResponse(t, sidecar0), nil)
+		c.EXPECT().Get(ctx, path.Join(blobscanBlobsMethodPrefix, index1.Hash.Hex()), url.Values(nil), headers).Return(blobscanResponse(t, sidecar1), nil)
+
+		resp, err := b.BeaconBlobSideCars(ctx, false, 42, []eth.IndexedBlobHash{index0, index1})
+		require.NoError(t, err)
+		require.Len(t, resp.Data, 2)
+		require.Equal(t, sidecar0, resp.Data[0].BlobSidecar())
+		require.Equal(t, sidecar1, resp.Data[1].BlobSidecar())
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		c := client_mocks.NewHTTP(t)
+		b := NewBlobscanClient(c)
+		c.EXPECT().Get(ctx, path.Join(blobscanBlobsMethodPrefix, index0.Hash.Hex()), url.Values(nil), headers).
+			Return(&http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewReader(nil))}, nil)
+
+		_, err := b.BeaconBlobSideCars(ctx, false, 42, []eth.IndexedBlobHash{index0})
+		require.ErrorIs(t, err, ethereum.NotFound)
+	})
+
+	t.Run("WrongBlob", func(t *testing.T) {
+		c := client_mocks.NewHTTP(t)
+		b := NewBlobscanClient(c)
+		c.EXPECT().Get(ctx, path.Join(blobscanBlobsMethodPrefix, index0.Hash.Hex()), url.Values(nil), headers).Return(blobscanResponse(t, sidecar1), nil)
+
+		_, err := b.BeaconBlobSideCars(ctx, false, 42, []eth.IndexedBlobHash{index0})
+		require.ErrorContains(t, err, "expected hash")
+	})
+
+	t.Run("InvalidProof", func(t *testing.T) {
+		c := client_mocks.NewHTTP(t)
+		b := NewBlobscanClient(c)
+		invalid := *sidecar0
+		invalid.KZGProof = sidecar1.KZGProof
+		c.EXPECT().Get(ctx, path.Join(blobscanBlobsMethodPrefix, index0.Hash.Hex()), url.Values(nil), headers).Return(blobscanResponse(t, &invalid), nil)
+
+		_, err := b.BeaconBlobSideCars(ctx, false, 42, []eth.IndexedBlobHash{index0})
+		require.ErrorContains(t, err, "failed verification")
+	})
+}
+
+func TestBeaconClientBlobscanFallback(t *testing.T) {
+	ctx := context.Background()
+	index0, sidecar0 := makeTestBlobSidecar(3)
+
+	primary := client_mocks.NewHTTP(t)
+	blobscan := client_mocks.NewHTTP(t)
+	headers := http.Header{}
+	headers.Add("Accept", "application/json")
+	primary.EXPECT().Get(ctx, "eth/v1/beacon/blob_sidecars/42", url.Values{"indices": []string{"3"}}, headers).
+		Return(nil, errors.New("blob expired"))
+	blobscan.EXPECT().Get(ctx, path.Join(blobscanBlobsMethodPrefix, index0.Hash.Hex()), url.Values(nil), headers).Return(blobscanResponse(t, sidecar0), nil)
+
+	b := NewBeaconHTTPClient(primary)
+	cl := &L1BeaconClient{cl: b, pool: NewClientPool[BlobSideCarsFetcher](b, NewBlobscanClient(blobscan))}
+	resp, err := cl.fetchSidecars(ctx, 42, []eth.IndexedBlobHash{index0})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	require.Equal(t, sidecar0, resp.Data[0].BlobSidecar())
+}
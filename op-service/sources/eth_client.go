@@ -370,6 +370,17 @@ func (s *EthClient) ReadStorageAt(ctx context.Context, address common.Address, s
 	return common.BytesToHash(value.Bytes()), nil
 }
 
+// EvictBlock removes the cached data of the block with the given hash, e.g. because the block was reorged out.
+// Data cached by hash remains valid after a reorg, but evicting it leaves a bounded cache to the canonical blocks.
+func (s *EthClient) EvictBlock(hash common.Hash) {
+	s.headersCache.Remove(hash)
+	s.transactionsCache.Remove(hash)
+	s.payloadsCache.Remove(hash)
+	if rp, ok := s.recProvider.(*CachingReceiptsProvider); ok {
+		rp.Evict(hash)
+	}
+}
+
 func (s *EthClient) Close() {
 	s.client.Close()
 }
@@ -67,9 +67,15 @@ func NewBeaconHTTPClient(cl client.HTTP) *BeaconHTTPClient {
 }
 
 func (cl *BeaconHTTPClient) apiReq(ctx context.Context, dest any, reqPath string, reqQuery url.Values) error {
+	return getJSON(ctx, cl.cl, dest, reqPath, reqQuery)
+}
+
+// getJSON decodes the JSON response of a GET request into dest.
+// A 404 response is returned as an error wrapping ethereum.NotFound.
+func getJSON(ctx context.Context, cl client.HTTP, dest any, reqPath string, reqQuery url.Values) error {
 	headers := http.Header{}
 	headers.Add("Accept", "application/json")
-	resp, err := cl.cl.Get(ctx, reqPath, reqQuery, headers)
+	resp, err := cl.Get(ctx, reqPath, reqQuery, headers)
 	if err != nil {
 		return fmt.Errorf("http Get failed: %w", err)
 	}
@@ -238,8 +244,24 @@ func (cl *L1BeaconClient) fetchSidecars(ctx context.Context, slot uint64, hashes
 // Order of the returned sidecars is guaranteed to be that of the hashes.
 // Blob data is not checked for validity.
 func (cl *L1BeaconClient) GetBlobSidecars(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.BlobSidecar, error) {
+	apiscs, err := cl.GetAPIBlobSidecars(ctx, ref, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	bscs := make([]*eth.BlobSidecar, 0, len(hashes))
+	for _, apisc := range apiscs {
+		bscs = append(bscs, apisc.BlobSidecar())
+	}
+
+	return bscs, nil
+}
+
+// GetAPIBlobSidecars fetches the blob sidecars like [L1BeaconClient.GetBlobSidecars],
+// but retains the beacon block header and the inclusion proof of the sidecars, as served by the Beacon API.
+func (cl *L1BeaconClient) GetAPIBlobSidecars(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.APIBlobSidecar, error) {
 	if len(hashes) == 0 {
-		return []*eth.BlobSidecar{}, nil
+		return []*eth.APIBlobSidecar{}, nil
 	}
 	slotFn, err := cl.GetTimeToSlotFn(ctx)
 	if err != nil {
@@ -270,12 +292,7 @@ func (cl *L1BeaconClient) GetBlobSidecars(ctx context.Context, ref eth.L1BlockRe
 		return nil, fmt.Errorf("expected %v sidecars but got %v", len(hashes), len(apiscs))
 	}
 
-	bscs := make([]*eth.BlobSidecar, 0, len(hashes))
-	for _, apisc := range apiscs {
-		bscs = append(bscs, apisc.BlobSidecar())
-	}
-
-	return bscs, nil
+	return apiscs, nil
 }
 
 // GetBlobs fetches blobs that were confirmed in the specified L1 block with the given indexed
@@ -287,10 +304,12 @@ func (cl *L1BeaconClient) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hash
 	if err != nil {
 		return nil, fmt.Errorf("failed to get blob sidecars for L1BlockRef %s: %w", ref, err)
 	}
-	return blobsFromSidecars(blobSidecars, hashes)
+	return BlobsFromSidecars(blobSidecars, hashes)
 }
 
-func blobsFromSidecars(blobSidecars []*eth.BlobSidecar, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+// BlobsFromSidecars verifies the blob sidecars against the indexed hashes, and returns the blobs.
+// The sidecars must be ordered like the hashes.
+func BlobsFromSidecars(blobSidecars []*eth.BlobSidecar, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
 	if len(blobSidecars) != len(hashes) {
 		return nil, fmt.Errorf("number of hashes and blobSidecars mismatch, %d != %d", len(hashes), len(blobSidecars))
 	}
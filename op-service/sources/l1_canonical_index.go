@@ -0,0 +1,168 @@
+package sources
+
+import (
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
+)
+
+// canonicalIndex tracks the L1 block references by number, to serve block references by number without refetching them.
+//
+// Block references by hash are safe to cache, but by number they are not: a L1 reorg changes the block at a given number.
+// A stale chain is self-consistent, so the index only serves the blocks that are verified to be canonical:
+// the blocks that are linked by parent-hash to the latest head. Every new head is verified against the indexed blocks,
+// and the blocks that do not link to it, i.e. all blocks above the fork point of a reorg, are dropped.
+// Blocks that cannot be verified yet, e.g. because there is a gap between them and the head, are retained,
+// and served once the blocks in between are indexed.
+type canonicalIndex struct {
+	mu sync.Mutex
+
+	m     caching.Metrics
+	label string
+
+	maxSize int
+	refs    map[uint64]eth.L1BlockRef
+
+	// head is the number of the latest head. Only valid if hasHead is true.
+	head    uint64
+	hasHead bool
+	// verified is the number of the lowest block that is linked by parent-hash to the head.
+	verified uint64
+
+	// onDrop is called with the blocks that were dropped from the index because they are not canonical anymore.
+	onDrop func(ref eth.L1BlockRef)
+}
+
+func newCanonicalIndex(m caching.Metrics, label string, maxSize int, onDrop func(ref eth.L1BlockRef)) *canonicalIndex {
+	return &canonicalIndex{
+		m:       m,
+		label:   label,
+		maxSize: maxSize,
+		refs:    make(map[uint64]eth.L1BlockRef),
+		onDrop:  onDrop,
+	}
+}
+
+// Get returns the block reference at the given number, if it is verified to be canonical.
+func (c *canonicalIndex) Get(num uint64) (eth.L1BlockRef, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ref, ok := c.refs[num]
+	ok = ok && c.hasHead && num >= c.verified && num <= c.head
+	if c.m != nil {
+		c.m.CacheGet(c.label, ok)
+	}
+	return ref, ok
+}
+
+// AddHead indexes the given block reference as the latest head.
+// All indexed blocks above the head, and all blocks that do not link to the head, are dropped.
+func (c *canonicalIndex) AddHead(ref eth.L1BlockRef) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.hasHead && ref.Number == c.head && c.refs[c.head].Hash == ref.Hash {
+		return
+	}
+	extendsHead := c.hasHead && ref.Number == c.head+1 && c.refs[c.head].Hash == ref.ParentHash
+	for num, prev := range c.refs {
+		if num > ref.Number || (num == ref.Number && prev.Hash != ref.Hash) {
+			c.drop(prev)
+		}
+	}
+	c.refs[ref.Number] = ref
+	c.head = ref.Number
+	c.hasHead = true
+	if !extendsHead {
+		c.verified = ref.Number
+	}
+	c.extendVerified()
+	c.recordAdd(c.prune())
+}
+
+// Add indexes the given block reference, as retrieved from the canonical chain.
+// If it conflicts with the verified blocks, or it is the next block but does not link to the head,
+// the verified blocks are not served until the next head is indexed.
+func (c *canonicalIndex) Add(ref eth.L1BlockRef) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if prev, ok := c.refs[ref.Number]; ok {
+		if prev.Hash == ref.Hash {
+			return
+		}
+		c.drop(prev)
+		if c.hasHead && ref.Number >= c.verified && ref.Number <= c.head {
+			// The verified chain was reorged, but the new head is not known yet.
+			c.hasHead = false
+		}
+	}
+	c.refs[ref.Number] = ref
+	if c.hasHead && ref.Number == c.head+1 && ref.ParentHash != c.refs[c.head].Hash {
+		// The block after the head does not link to it: the head was reorged, but the new head is not known yet.
+		c.drop(c.refs[c.head])
+		c.hasHead = false
+	}
+	if c.hasHead && ref.Number+1 == c.verified {
+		if c.refs[c.verified].ParentHash == ref.Hash {
+			c.verified = ref.Number
+			c.extendVerified()
+		} else {
+			// The parent of the verified chain is not canonical anymore: the verified chain was reorged.
+			c.hasHead = false
+		}
+	}
+	c.recordAdd(c.prune())
+}
+
+// extendVerified extends the verified chain down to all indexed blocks that link to it.
+// The first block that does not link to it is not canonical, and is dropped.
+func (c *canonicalIndex) extendVerified() {
+	for c.verified > 0 {
+		parent, ok := c.refs[c.verified-1]
+		if !ok {
+			return
+		}
+		if parent.Hash != c.refs[c.verified].ParentHash {
+			c.drop(parent)
+			return
+		}
+		c.verified--
+	}
+}
+
+func (c *canonicalIndex) drop(ref eth.L1BlockRef) {
+	delete(c.refs, ref.Number)
+	if c.onDrop != nil {
+		c.onDrop(ref)
+	}
+}
+
+func (c *canonicalIndex) recordAdd(evicted bool) {
+	if c.m != nil {
+		c.m.CacheAdd(c.label, len(c.refs), evicted)
+	}
+}
+
+// prune removes the lowest blocks until the index fits the maximum size, and returns true if any were removed.
+func (c *canonicalIndex) prune() (evicted bool) {
+	for len(c.refs) > c.maxSize {
+		lowest := uint64(0)
+		first := true
+		for num := range c.refs {
+			if first || num < lowest {
+				lowest = num
+				first = false
+			}
+		}
+		delete(c.refs, lowest)
+		evicted = true
+		if c.hasHead && lowest >= c.verified {
+			if lowest >= c.head {
+				c.hasHead = false
+			} else {
+				c.verified = lowest + 1
+			}
+		}
+	}
+	return evicted
+}
@@ -0,0 +1,189 @@
+package sources
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func makeTestChain(rng *rand.Rand, parent eth.L1BlockRef, n int) []eth.L1BlockRef {
+	chain := make([]eth.L1BlockRef, 0, n)
+	for i := 0; i < n; i++ {
+		parent = testutils.NextRandomRef(rng, parent)
+		chain = append(chain, parent)
+	}
+	return chain
+}
+
+func requireServed(t *testing.T, idx *canonicalIndex, refs ...eth.L1BlockRef) {
+	t.Helper()
+	for _, ref := range refs {
+		got, ok := idx.Get(ref.Number)
+		require.True(t, ok, "block %d must be served", ref.Number)
+		require.Equal(t, ref, got)
+	}
+}
+
+func requireNotServed(t *testing.T, idx *canonicalIndex, refs ...eth.L1BlockRef) {
+	t.Helper()
+	for _, ref := range refs {
+		got, ok := idx.Get(ref.Number)
+		require.False(t, ok, "block %d must not be served, got %s", ref.Number, got)
+	}
+}
+
+func TestCanonicalIndex(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	genesis := eth.L1BlockRef{Hash: testutils.RandomHash(rng), Number: 0}
+
+	t.Run("ServeLinkedToHead", func(t *testing.T) {
+		idx := newCanonicalIndex(nil, "test", 100, nil)
+		chain := makeTestChain(rng, genesis, 10)
+		// Without a head, no blocks are verified
+		idx.Add(chain[8])
+		requireNotServed(t, idx, chain[8])
+
+		idx.AddHead(chain[9])
+		requireServed(t, idx, chain[8:]...)
+		// Blocks with a gap to the head can't be verified yet
+		idx.Add(chain[3])
+		requireNotServed(t, idx, chain[3])
+		for i := 7; i >= 4; i-- {
+			idx.Add(chain[i])
+		}
+		requireServed(t, idx, chain[3:]...)
+
+		// The head extends the verified chain
+		next := makeTestChain(rng, chain[9], 2)
+		idx.AddHead(next[0])
+		requireServed(t, idx, chain[3:]...)
+		requireServed(t, idx, next[0])
+		// Blocks above the head are not served
+		idx.Add(next[1])
+		requireNotServed(t, idx, next[1])
+	})
+
+	t.Run("ReorgWithSkippedHeads", func(t *testing.T) {
+		var dropped []eth.L1BlockRef
+		idx := newCanonicalIndex(nil, "test", 100, func(ref eth.L1BlockRef) {
+			dropped = append(dropped, ref)
+		})
+		chain := makeTestChain(rng, genesis, 10)
+		idx.AddHead(chain[9])
+		for i := 8; i >= 0; i-- {
+			idx.Add(chain[i])
+		}
+		requireServed(t, idx, chain...)
+
+		// Reorg of the blocks after chain[4], observed by a head that skips blocks.
+		// The reorged-out blocks are a self-consistent chain, but must not be served anymore.
+		alt := makeTestChain(rng, chain[4], 20)
+		idx.AddHead(alt[19])
+		requireNotServed(t, idx, chain...)
+		require.Empty(t, dropped, "blocks can't be verified as reorged out yet")
+
+		// Once the gap is filled, the blocks up to the fork point are served again
+		for i := 18; i >= 0; i-- {
+			idx.Add(alt[i])
+		}
+		requireServed(t, idx, alt...)
+		requireServed(t, idx, chain[:5]...)
+		require.ElementsMatch(t, chain[5:], dropped, "reorged-out blocks must be dropped")
+	})
+
+	t.Run("ReorgToShorterChain", func(t *testing.T) {
+		var dropped []eth.L1BlockRef
+		idx := newCanonicalIndex(nil, "test", 100, func(ref eth.L1BlockRef) {
+			dropped = append(dropped, ref)
+		})
+		chain := makeTestChain(rng, genesis, 10)
+		idx.AddHead(chain[9])
+		for i := 8; i >= 0; i-- {
+			idx.Add(chain[i])
+		}
+
+		alt := makeTestChain(rng, chain[5], 2)
+		idx.AddHead(alt[1])
+		// Blocks above the new head, and the blocks between the new head and the indexed fork point, are not served
+		requireNotServed(t, idx, chain[6], chain[8], chain[9])
+		idx.Add(alt[0])
+		requireServed(t, idx, chain[:6]...)
+		requireServed(t, idx, alt...)
+		require.ElementsMatch(t, chain[6:], dropped)
+	})
+
+	t.Run("ConflictBeforeHead", func(t *testing.T) {
+		idx := newCanonicalIndex(nil, "test", 100, nil)
+		chain := makeTestChain(rng, genesis, 5)
+		idx.AddHead(chain[4])
+		for i := 3; i >= 0; i-- {
+			idx.Add(chain[i])
+		}
+
+		// A block by number reveals a reorg before the head does
+		alt := makeTestChain(rng, chain[1], 1)
+		idx.Add(alt[0])
+		requireNotServed(t, idx, chain...)
+
+		// The next head verifies the chain again
+		idx.AddHead(alt[0])
+		requireServed(t, idx, chain[0], chain[1], alt[0])
+		requireNotServed(t, idx, chain[3], chain[4])
+	})
+
+	t.Run("ConflictWithParentOfVerified", func(t *testing.T) {
+		idx := newCanonicalIndex(nil, "test", 100, nil)
+		chain := makeTestChain(rng, genesis, 5)
+		idx.AddHead(chain[4])
+		idx.Add(chain[3])
+
+		alt := makeTestChain(rng, chain[1], 1)
+		idx.Add(alt[0])
+		requireNotServed(t, idx, chain[3], chain[4], alt[0])
+	})
+
+	t.Run("NextBlockNotLinkedToHead", func(t *testing.T) {
+		var dropped []eth.L1BlockRef
+		idx := newCanonicalIndex(nil, "test", 100, func(ref eth.L1BlockRef) {
+			dropped = append(dropped, ref)
+		})
+		chain := makeTestChain(rng, genesis, 5)
+		idx.AddHead(chain[4])
+		for i := 3; i >= 0; i-- {
+			idx.Add(chain[i])
+		}
+
+		// The block after the head reveals a reorg of the head, before the next head does
+		alt := makeTestChain(rng, chain[3], 2)
+		idx.Add(alt[1])
+		requireNotServed(t, idx, chain...)
+		require.Equal(t, []eth.L1BlockRef{chain[4]}, dropped)
+
+		idx.Add(alt[0])
+		idx.AddHead(alt[1])
+		requireServed(t, idx, chain[:4]...)
+		requireServed(t, idx, alt...)
+
+		// The block after the head is not served, if it links to the head
+		next := makeTestChain(rng, alt[1], 1)
+		idx.Add(next[0])
+		requireServed(t, idx, chain[:4]...)
+		requireNotServed(t, idx, next[0])
+	})
+
+	t.Run("Prune", func(t *testing.T) {
+		idx := newCanonicalIndex(nil, "test", 3, nil)
+		chain := makeTestChain(rng, genesis, 5)
+		idx.AddHead(chain[4])
+		for i := 3; i >= 0; i-- {
+			idx.Add(chain[i])
+		}
+		requireNotServed(t, idx, chain[:2]...)
+		requireServed(t, idx, chain[2:]...)
+		require.Len(t, idx.refs, 3)
+	})
+}
@@ -61,6 +61,9 @@ type L1Client struct {
 	// cache L1BlockRef by hash
 	// common.Hash -> eth.L1BlockRef
 	l1BlockRefsCache *caching.LRUCache[common.Hash, eth.L1BlockRef]
+
+	// index of the canonical L1BlockRefs by number, invalidated upon reorgs
+	canonical *canonicalIndex
 }
 
 // NewL1Client wraps a RPC with bindings to fetch L1 data, while logging errors, tracking metrics (optional), and caching.
@@ -70,10 +73,16 @@ func NewL1Client(client client.RPC, log log.Logger, metrics caching.Metrics, con
 		return nil, err
 	}
 
-	return &L1Client{
+	l1Client := &L1Client{
 		EthClient:        ethClient,
 		l1BlockRefsCache: caching.NewLRUCache[common.Hash, eth.L1BlockRef](metrics, "blockrefs", config.L1BlockRefsCacheSize),
-	}, nil
+	}
+	// Reorged-out blocks are evicted from the caches by hash, to retain the canonical blocks instead.
+	l1Client.canonical = newCanonicalIndex(metrics, "canonical_blockrefs", config.L1BlockRefsCacheSize, func(ref eth.L1BlockRef) {
+		l1Client.l1BlockRefsCache.Remove(ref.Hash)
+		l1Client.EvictBlock(ref.Hash)
+	})
+	return l1Client, nil
 }
 
 // L1BlockRefByLabel returns the [eth.L1BlockRef] for the given block label.
@@ -90,18 +99,38 @@ func (s *L1Client) L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel)
 	}
 	ref := eth.InfoToL1BlockRef(info)
 	s.l1BlockRefsCache.Add(ref.Hash, ref)
+	// Labeled blocks are canonical, and the head signals reorgs to the canonical index.
+	if label == eth.Unsafe {
+		s.canonical.AddHead(ref)
+	} else {
+		s.canonical.Add(ref)
+	}
 	return ref, nil
 }
 
+// OnNewL1Head indexes a new L1 head, e.g. from a subscription to the L1 heads,
+// to verify the block references by number against it.
+func (s *L1Client) OnNewL1Head(ref eth.L1BlockRef) {
+	s.l1BlockRefsCache.Add(ref.Hash, ref)
+	s.canonical.AddHead(ref)
+}
+
 // L1BlockRefByNumber returns an [eth.L1BlockRef] for the given block number.
-// Notice, we cannot cache a block reference by number because L1 re-orgs can invalidate the cached block reference.
+// Block references by number are served from the canonical index, if they link by parent-hash to the latest L1 head,
+// as retrieved with [L1Client.L1BlockRefByLabel] or signaled with [L1Client.OnNewL1Head].
+// A block reference of a reorged-out chain can only be returned until the reorg reaches the L1 head that is
+// retrieved next, or a block above the head is retrieved, like a block reference that is fetched just before a reorg.
 func (s *L1Client) L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error) {
+	if ref, ok := s.canonical.Get(num); ok {
+		return ref, nil
+	}
 	info, err := s.InfoByNumber(ctx, num)
 	if err != nil {
 		return eth.L1BlockRef{}, fmt.Errorf("failed to fetch header by num %d: %w", num, err)
 	}
 	ref := eth.InfoToL1BlockRef(info)
 	s.l1BlockRefsCache.Add(ref.Hash, ref)
+	s.canonical.Add(ref)
 	return ref, nil
 }
 
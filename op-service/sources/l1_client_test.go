@@ -0,0 +1,91 @@
+package sources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func nextRPCHeader(parent *RPCHeader) *RPCHeader {
+	_, rhdr := randHeader()
+	rhdr.ParentHash = parent.Hash
+	rhdr.Number = parent.Number + 1
+	rhdr.Time = parent.Time + 12
+	rhdr.Hash = rhdr.computeBlockHash()
+	return rhdr
+}
+
+func headerToL1BlockRef(t *testing.T, rhdr *RPCHeader) eth.L1BlockRef {
+	info, err := rhdr.Info(true, false)
+	require.NoError(t, err)
+	return eth.InfoToL1BlockRef(info)
+}
+
+func expectHeaderByNumber(m *mockRPC, rhdr *RPCHeader) {
+	m.On("CallContext", mock.Anything, new(*RPCHeader),
+		"eth_getBlockByNumber", []any{rhdr.Number.String(), false}).Run(func(args mock.Arguments) {
+		*args[1].(**RPCHeader) = rhdr
+	}).Return([]error{nil}).Once()
+}
+
+func TestL1Client_L1BlockRefByNumberReorg(t *testing.T) {
+	m := new(mockRPC)
+	s, err := NewL1Client(m, nil, nil, L1ClientDefaultConfig(&rollup.Config{SeqWindowSize: 10}, false, RPCKindStandard))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, genesis := randHeader()
+	genesis.Number = hexutil.Uint64(100)
+	genesis.Hash = genesis.computeBlockHash()
+	chain := []*RPCHeader{genesis}
+	for i := 0; i < 3; i++ {
+		chain = append(chain, nextRPCHeader(chain[len(chain)-1]))
+	}
+	head := chain[3]
+	s.OnNewL1Head(headerToL1BlockRef(t, head))
+
+	// Blocks are fetched once, and then served by number from the index
+	for _, rhdr := range chain[:3] {
+		expectHeaderByNumber(m, rhdr)
+	}
+	for i := 0; i < 2; i++ {
+		for _, rhdr := range chain {
+			ref, err := s.L1BlockRefByNumber(ctx, uint64(rhdr.Number))
+			require.NoError(t, err)
+			require.Equal(t, rhdr.Hash, ref.Hash)
+		}
+	}
+	m.Mock.AssertExpectations(t)
+
+	// A 1-block reorg of the head, revealed by the next block, before the next head is signaled
+	alt := nextRPCHeader(chain[2])
+	next := nextRPCHeader(alt)
+	expectHeaderByNumber(m, next)
+	expectHeaderByNumber(m, alt)
+	ref, err := s.L1BlockRefByNumber(ctx, uint64(next.Number))
+	require.NoError(t, err)
+	require.Equal(t, next.Hash, ref.Hash)
+	ref, err = s.L1BlockRefByNumber(ctx, uint64(alt.Number))
+	require.NoError(t, err)
+	require.Equal(t, alt.Hash, ref.Hash, "must not serve the reorged-out head")
+	require.Equal(t, chain[2].Hash, ref.ParentHash)
+	m.Mock.AssertExpectations(t)
+
+	// The next head verifies the new canonical chain
+	s.OnNewL1Head(headerToL1BlockRef(t, next))
+	for _, hash := range []common.Hash{chain[0].Hash, chain[1].Hash, chain[2].Hash, alt.Hash, next.Hash} {
+		ref, err := s.L1BlockRefByHash(ctx, hash)
+		require.NoError(t, err)
+		got, err := s.L1BlockRefByNumber(ctx, ref.Number)
+		require.NoError(t, err)
+		require.Equal(t, ref, got)
+	}
+	m.Mock.AssertExpectations(t)
+}
@@ -81,6 +81,11 @@ func (p *CachingReceiptsProvider) FetchReceipts(ctx context.Context, blockInfo e
 	return r, nil
 }
 
+// Evict removes the cached receipts of the given block, e.g. because the block was reorged out.
+func (p *CachingReceiptsProvider) Evict(blockHash common.Hash) {
+	p.cache.Remove(blockHash)
+}
+
 func (p *CachingReceiptsProvider) isInnerNil() bool {
 	return p.inner == nil
 }
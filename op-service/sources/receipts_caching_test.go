@@ -47,6 +47,28 @@ func TestCachingReceiptsProvider_Caching(t *testing.T) {
 	mrp.AssertExpectations(t)
 }
 
+func TestCachingReceiptsProvider_Evict(t *testing.T) {
+	block, receipts := randomRpcBlockAndReceipts(rand.New(rand.NewSource(69)), 4)
+	txHashes := receiptTxHashes(receipts)
+	blockid := block.BlockID()
+	mrp := new(mockReceiptsProvider)
+	rp := NewCachingReceiptsProvider(mrp, nil, 1)
+	ctx, done := context.WithTimeout(context.Background(), 10*time.Second)
+	defer done()
+
+	mrp.On("FetchReceipts", ctx, blockid, txHashes).
+		Return(types.Receipts(receipts), error(nil)).
+		Twice() // receipts should be fetched again after eviction
+
+	bInfo, _, _ := block.Info(true, true)
+	_, err := rp.FetchReceipts(ctx, bInfo, txHashes)
+	require.NoError(t, err)
+	rp.Evict(blockid.Hash)
+	_, err = rp.FetchReceipts(ctx, bInfo, txHashes)
+	require.NoError(t, err)
+	mrp.AssertExpectations(t)
+}
+
 func TestCachingReceiptsProvider_Concurrency(t *testing.T) {
 	block, receipts := randomRpcBlockAndReceipts(rand.New(rand.NewSource(69)), 4)
 	txHashes := receiptTxHashes(receipts)
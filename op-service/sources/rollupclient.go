@@ -2,10 +2,12 @@ package sources
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/client"
@@ -26,6 +28,40 @@ func (r *RollupClient) OutputAtBlock(ctx context.Context, blockNum uint64) (*eth
 	return output, err
 }
 
+// OutputsAtBlocks retrieves the outputs at each of the specified blocks in a single batch request.
+func (r *RollupClient) OutputsAtBlocks(ctx context.Context, blockNums []uint64) ([]*eth.OutputResponse, error) {
+	outputs := make([]*eth.OutputResponse, len(blockNums))
+	batch := make([]rpc.BatchElem, len(blockNums))
+	for i, blockNum := range blockNums {
+		batch[i] = rpc.BatchElem{
+			Method: "optimism_outputAtBlock",
+			Args:   []any{hexutil.Uint64(blockNum)},
+			Result: &outputs[i],
+		}
+	}
+	if err := r.rpc.BatchCallContext(ctx, batch); err != nil {
+		return nil, err
+	}
+	for i, elem := range batch {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("failed to fetch output at block %v: %w", blockNums[i], elem.Error)
+		}
+	}
+	return outputs, nil
+}
+
+func (r *RollupClient) OutputAtTimestamp(ctx context.Context, timestamp uint64) (*eth.OutputResponse, error) {
+	var output *eth.OutputResponse
+	err := r.rpc.CallContext(ctx, &output, "optimism_outputAtTimestamp", hexutil.Uint64(timestamp))
+	return output, err
+}
+
+func (r *RollupClient) OutputsAtBlockRange(ctx context.Context, start uint64, end uint64) ([]*eth.OutputResponse, error) {
+	var outputs []*eth.OutputResponse
+	err := r.rpc.CallContext(ctx, &outputs, "optimism_outputsAtBlockRange", hexutil.Uint64(start), hexutil.Uint64(end))
+	return outputs, err
+}
+
 func (r *RollupClient) SafeHeadAtL1Block(ctx context.Context, blockNum uint64) (*eth.SafeHeadResponse, error) {
 	var output *eth.SafeHeadResponse
 	err := r.rpc.CallContext(ctx, &output, "optimism_safeHeadAtL1Block", hexutil.Uint64(blockNum))
@@ -38,6 +74,12 @@ func (r *RollupClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
 	return output, err
 }
 
+func (r *RollupClient) SyncPhase(ctx context.Context) (*eth.SyncPhase, error) {
+	var output *eth.SyncPhase
+	err := r.rpc.CallContext(ctx, &output, "optimism_syncPhase")
+	return output, err
+}
+
 func (r *RollupClient) RollupConfig(ctx context.Context) (*rollup.Config, error) {
 	var output *rollup.Config
 	err := r.rpc.CallContext(ctx, &output, "optimism_rollupConfig")
@@ -60,6 +102,30 @@ func (r *RollupClient) StopSequencer(ctx context.Context) (common.Hash, error) {
 	return result, err
 }
 
+func (r *RollupClient) StartSequencerAtBlock(ctx context.Context, number uint64) (common.Hash, error) {
+	var result common.Hash
+	err := r.rpc.CallContext(ctx, &result, "admin_startSequencerAtBlock", hexutil.Uint64(number))
+	return result, err
+}
+
+func (r *RollupClient) StartSequencerAtTime(ctx context.Context, timestamp uint64) (common.Hash, error) {
+	var result common.Hash
+	err := r.rpc.CallContext(ctx, &result, "admin_startSequencerAtTime", hexutil.Uint64(timestamp))
+	return result, err
+}
+
+func (r *RollupClient) StopSequencerAtBlock(ctx context.Context, number uint64) (common.Hash, error) {
+	var result common.Hash
+	err := r.rpc.CallContext(ctx, &result, "admin_stopSequencerAtBlock", hexutil.Uint64(number))
+	return result, err
+}
+
+func (r *RollupClient) StopSequencerAtTime(ctx context.Context, timestamp uint64) (common.Hash, error) {
+	var result common.Hash
+	err := r.rpc.CallContext(ctx, &result, "admin_stopSequencerAtTime", hexutil.Uint64(timestamp))
+	return result, err
+}
+
 func (r *RollupClient) SequencerActive(ctx context.Context) (bool, error) {
 	var result bool
 	err := r.rpc.CallContext(ctx, &result, "admin_sequencerActive")
@@ -74,6 +140,22 @@ func (r *RollupClient) OverrideLeader(ctx context.Context) error {
 	return r.rpc.CallContext(ctx, nil, "admin_overrideLeader")
 }
 
+func (r *RollupClient) EventQueueDepth(ctx context.Context) (map[string]int, error) {
+	var result map[string]int
+	err := r.rpc.CallContext(ctx, &result, "admin_eventQueueDepth")
+	return result, err
+}
+
+func (r *RollupClient) ActiveEngine(ctx context.Context) (string, error) {
+	var result string
+	err := r.rpc.CallContext(ctx, &result, "admin_activeEngine")
+	return result, err
+}
+
+func (r *RollupClient) SetActiveEngine(ctx context.Context, name string) error {
+	return r.rpc.CallContext(ctx, nil, "admin_setActiveEngine", name)
+}
+
 func (r *RollupClient) SetLogLevel(ctx context.Context, lvl slog.Level) error {
 	return r.rpc.CallContext(ctx, nil, "admin_setLogLevel", lvl.String())
 }
@@ -0,0 +1,76 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+type outputsRPC struct {
+	outputs  map[uint64]*eth.OutputResponse
+	batches  int
+	batchErr error
+}
+
+func (o *outputsRPC) Close() {}
+
+func (o *outputsRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	return errors.New("unexpected call")
+}
+
+func (o *outputsRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	o.batches++
+	if o.batchErr != nil {
+		return o.batchErr
+	}
+	for i := range b {
+		if b[i].Method != "optimism_outputAtBlock" {
+			return errors.New("unexpected method")
+		}
+		output, ok := o.outputs[uint64(b[i].Args[0].(hexutil.Uint64))]
+		if !ok {
+			b[i].Error = errors.New("not found")
+			continue
+		}
+		*b[i].Result.(**eth.OutputResponse) = output
+	}
+	return nil
+}
+
+func (o *outputsRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	return nil, errors.New("unexpected subscribe")
+}
+
+func TestOutputsAtBlocks(t *testing.T) {
+	outputs := map[uint64]*eth.OutputResponse{
+		4: {OutputRoot: eth.Bytes32{0x04}},
+		5: {OutputRoot: eth.Bytes32{0x05}},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		stub := &outputsRPC{outputs: outputs}
+		actual, err := NewRollupClient(stub).OutputsAtBlocks(context.Background(), []uint64{5, 4})
+		require.NoError(t, err)
+		require.Equal(t, []*eth.OutputResponse{outputs[5], outputs[4]}, actual)
+		require.Equal(t, 1, stub.batches)
+	})
+
+	t.Run("ElementError", func(t *testing.T) {
+		stub := &outputsRPC{outputs: outputs}
+		_, err := NewRollupClient(stub).OutputsAtBlocks(context.Background(), []uint64{4, 6})
+		require.ErrorContains(t, err, "block 6")
+	})
+
+	t.Run("BatchError", func(t *testing.T) {
+		batchErr := errors.New("boom")
+		stub := &outputsRPC{outputs: outputs, batchErr: batchErr}
+		_, err := NewRollupClient(stub).OutputsAtBlocks(context.Background(), []uint64{4})
+		require.ErrorIs(t, err, batchErr)
+	})
+}
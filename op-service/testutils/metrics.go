@@ -83,3 +83,15 @@ func (t *TestDerivationMetrics) SetDerivationIdle(idle bool) {}
 
 func (t *TestDerivationMetrics) RecordPipelineReset() {
 }
+
+func (t *TestDerivationMetrics) RecordDerivationStageStep(stage string, duration time.Duration, output bool) {
+}
+
+func (t *TestDerivationMetrics) RecordDerivationStageQueueDepth(stage string, depth int) {
+}
+
+func (t *TestDerivationMetrics) RecordDerivationStageReset(stage string) {
+}
+
+func (t *TestDerivationMetrics) RecordELSyncPhase(phase string) {
+}
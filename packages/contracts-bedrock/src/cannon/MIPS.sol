@@ -44,8 +44,8 @@ contract MIPS is ISemver {
     }
 
     /// @notice The semantic version of the MIPS contract.
-    /// @custom:semver 1.1.1-beta.3
-    string public constant version = "1.1.1-beta.3";
+    /// @custom:semver 1.1.1-beta.7
+    string public constant version = "1.1.1-beta.7";
 
     /// @notice The preimage oracle contract.
     IPreimageOracle internal immutable ORACLE;
@@ -156,8 +156,7 @@ contract MIPS is ISemver {
             if (syscall_no == sys.SYS_MMAP) {
                 (v0, v1, state.heap) = sys.handleSysMmap(a0, a1, state.heap);
             } else if (syscall_no == sys.SYS_BRK) {
-                // brk: Returns a fixed address for the program break at 0x40000000
-                v0 = sys.PROGRAM_BREAK;
+                (v0, v1) = sys.handleSysBrk(a0);
             } else if (syscall_no == sys.SYS_CLONE) {
                 // clone (not supported) returns 1
                 v0 = 1;
@@ -191,6 +190,10 @@ contract MIPS is ISemver {
                 });
             } else if (syscall_no == sys.SYS_FCNTL) {
                 (v0, v1) = sys.handleSysFcntl(a0, a1);
+            } else if (syscall_no == sys.SYS_FSTAT64) {
+                (v0, v1, state.memRoot) = sys.handleSysFstat64(
+                    a0, a1, state.memRoot, MIPSMemory.memoryProofOffset(STEP_PROOF_OFFSET, 1)
+                );
             }
 
             st.CpuScalars memory cpu = getCpuScalars(state);
@@ -52,8 +52,8 @@ contract MIPS2 is ISemver {
     }
 
     /// @notice The semantic version of the MIPS2 contract.
-    /// @custom:semver 1.0.0-beta.8
-    string public constant version = "1.0.0-beta.8";
+    /// @custom:semver 1.0.0-beta.15
+    string public constant version = "1.0.0-beta.15";
 
     /// @notice The preimage oracle contract.
     IPreimageOracle internal immutable ORACLE;
@@ -264,8 +264,7 @@ contract MIPS2 is ISemver {
             if (syscall_no == sys.SYS_MMAP) {
                 (v0, v1, state.heap) = sys.handleSysMmap(a0, a1, state.heap);
             } else if (syscall_no == sys.SYS_BRK) {
-                // brk: Returns a fixed address for the program break at 0x40000000
-                v0 = sys.PROGRAM_BREAK;
+                (v0, v1) = sys.handleSysBrk(a0);
             } else if (syscall_no == sys.SYS_CLONE) {
                 if (sys.VALID_SYS_CLONE_FLAGS != a0) {
                     state.exited = true;
@@ -391,15 +390,10 @@ contract MIPS2 is ISemver {
                 v0 = sys.SYS_ERROR_SIGNAL;
                 v1 = sys.EBADF;
             } else if (syscall_no == sys.SYS_CLOCKGETTIME) {
-                if (a0 == sys.CLOCK_GETTIME_REALTIME_FLAG || a0 == sys.CLOCK_GETTIME_MONOTONIC_FLAG) {
+                (uint32 secs, uint32 nsecs, bool ok) = sys.clockGettimeValue(a0, state.step);
+                if (ok) {
                     v0 = 0;
                     v1 = 0;
-                    uint32 secs = 0;
-                    uint32 nsecs = 0;
-                    if (a0 == sys.CLOCK_GETTIME_MONOTONIC_FLAG) {
-                        secs = uint32(state.step / sys.HZ);
-                        nsecs = uint32((state.step % sys.HZ) * (1_000_000_000 / sys.HZ));
-                    }
                     uint32 effAddr = a1 & 0xFFffFFfc;
                     // First verify the effAddr path
                     if (
@@ -429,6 +423,20 @@ contract MIPS2 is ISemver {
             } else if (syscall_no == sys.SYS_GETPID) {
                 v0 = 0;
                 v1 = 0;
+            } else if (syscall_no == sys.SYS_GETRANDOM) {
+                (v0, v1, state.memRoot) = sys.handleSysGetRandom(
+                    a0, a1, state.step, state.memRoot, MIPSMemory.memoryProofOffset(MEM_PROOF_OFFSET, 1)
+                );
+            } else if (syscall_no == sys.SYS_EPOLLCREATE1) {
+                v0 = sys.FD_EPOLL;
+                v1 = 0;
+            } else if (syscall_no == sys.SYS_EPOLLCTL) {
+                (v0, v1) = sys.handleSysEpollCtl(a0);
+            } else if (syscall_no == sys.SYS_EPOLLPWAIT) {
+                (v0, v1) = sys.handleSysEpollPwait(a0);
+            } else if (syscall_no == sys.SYS_EVENTFD2) {
+                v0 = sys.FD_EVENTFD;
+                v1 = 0;
             } else if (syscall_no == sys.SYS_MUNMAP) {
                 // ignored
             } else if (syscall_no == sys.SYS_GETAFFINITY) {
@@ -448,7 +456,9 @@ contract MIPS2 is ISemver {
             } else if (syscall_no == sys.SYS_PREAD64) {
                 // ignored
             } else if (syscall_no == sys.SYS_FSTAT64) {
-                // ignored
+                (v0, v1, state.memRoot) = sys.handleSysFstat64(
+                    a0, a1, state.memRoot, MIPSMemory.memoryProofOffset(MEM_PROOF_OFFSET, 1)
+                );
             } else if (syscall_no == sys.SYS_OPENAT) {
                 // ignored
             } else if (syscall_no == sys.SYS_READLINK) {
@@ -457,16 +467,8 @@ contract MIPS2 is ISemver {
                 // ignored
             } else if (syscall_no == sys.SYS_IOCTL) {
                 // ignored
-            } else if (syscall_no == sys.SYS_EPOLLCREATE1) {
-                // ignored
             } else if (syscall_no == sys.SYS_PIPE2) {
                 // ignored
-            } else if (syscall_no == sys.SYS_EPOLLCTL) {
-                // ignored
-            } else if (syscall_no == sys.SYS_EPOLLPWAIT) {
-                // ignored
-            } else if (syscall_no == sys.SYS_GETRANDOM) {
-                // ignored
             } else if (syscall_no == sys.SYS_UNAME) {
                 // ignored
             } else if (syscall_no == sys.SYS_STAT64) {
@@ -482,13 +484,15 @@ contract MIPS2 is ISemver {
             } else if (syscall_no == sys.SYS_TGKILL) {
                 // ignored
             } else if (syscall_no == sys.SYS_SETITIMER) {
-                // ignored
+                (v0, v1) = sys.handleSysSetITimer(a0, a2);
             } else if (syscall_no == sys.SYS_TIMERCREATE) {
-                // ignored
+                (v0, v1, state.memRoot) = sys.handleSysTimerCreate(
+                    a0, a2, state.memRoot, MIPSMemory.memoryProofOffset(MEM_PROOF_OFFSET, 1)
+                );
             } else if (syscall_no == sys.SYS_TIMERSETTIME) {
-                // ignored
+                (v0, v1) = sys.handleSysTimerSetTime(a0, a3);
             } else if (syscall_no == sys.SYS_TIMERDELETE) {
-                // ignored
+                (v0, v1) = sys.handleSysTimerDelete(a0);
             } else {
                 revert("MIPS2: unimplemented syscall");
             }
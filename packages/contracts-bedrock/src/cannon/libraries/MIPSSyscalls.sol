@@ -43,6 +43,11 @@ library MIPSSyscalls {
     uint32 internal constant SYS_NANOSLEEP = 4166;
     uint32 internal constant SYS_CLOCKGETTIME = 4263;
     uint32 internal constant SYS_GETPID = 4020;
+    uint32 internal constant SYS_GETRANDOM = 4353;
+    uint32 internal constant SYS_EPOLLCREATE1 = 4326;
+    uint32 internal constant SYS_EPOLLCTL = 4249;
+    uint32 internal constant SYS_EPOLLPWAIT = 4313;
+    uint32 internal constant SYS_EVENTFD2 = 4325;
     // unused syscalls
     uint32 internal constant SYS_MUNMAP = 4091;
     uint32 internal constant SYS_GETAFFINITY = 4240;
@@ -58,11 +63,7 @@ library MIPSSyscalls {
     uint32 internal constant SYS_READLINK = 4085;
     uint32 internal constant SYS_READLINKAT = 4298;
     uint32 internal constant SYS_IOCTL = 4054;
-    uint32 internal constant SYS_EPOLLCREATE1 = 4326;
     uint32 internal constant SYS_PIPE2 = 4328;
-    uint32 internal constant SYS_EPOLLCTL = 4249;
-    uint32 internal constant SYS_EPOLLPWAIT = 4313;
-    uint32 internal constant SYS_GETRANDOM = 4353;
     uint32 internal constant SYS_UNAME = 4122;
     uint32 internal constant SYS_STAT64 = 4213;
     uint32 internal constant SYS_GETUID = 4024;
@@ -84,13 +85,36 @@ library MIPSSyscalls {
     uint32 internal constant FD_HINT_WRITE = 4;
     uint32 internal constant FD_PREIMAGE_READ = 5;
     uint32 internal constant FD_PREIMAGE_WRITE = 6;
+    uint32 internal constant FD_EVENTFD = 7;
+    uint32 internal constant FD_EPOLL = 8;
 
     uint32 internal constant SYS_ERROR_SIGNAL = 0xFF_FF_FF_FF;
     uint32 internal constant EBADF = 0x9;
+    uint32 internal constant ENOMEM = 0xc;
     uint32 internal constant EINVAL = 0x16;
     uint32 internal constant EAGAIN = 0xb;
+    uint32 internal constant EMFILE = 0x18;
     uint32 internal constant ETIMEDOUT = 0x91;
 
+    // SYS_FCNTL commands
+    uint32 internal constant F_DUPFD = 0;
+    uint32 internal constant F_GETFD = 1;
+    uint32 internal constant F_SETFD = 2;
+    uint32 internal constant F_GETFL = 3;
+    uint32 internal constant F_SETFL = 4;
+    uint32 internal constant F_DUPFD_CLOEXEC = 1030;
+
+    // SYS_FSTAT64 file types, and the offset of st_mode in the MIPS o32 struct stat64
+    uint32 internal constant S_IFIFO = 0x1000;
+    uint32 internal constant S_IFCHR = 0x2000;
+    uint32 internal constant STAT64_MODE_OFFSET = 24;
+
+    // Interval timers, and the id of the single timer handed out by timer_create
+    uint32 internal constant ITIMER_REAL = 0;
+    uint32 internal constant ITIMER_VIRTUAL = 1;
+    uint32 internal constant ITIMER_PROF = 2;
+    uint32 internal constant TIMER_ID = 0;
+
     uint32 internal constant FUTEX_WAIT_PRIVATE = 128;
     uint32 internal constant FUTEX_WAKE_PRIVATE = 129;
     uint32 internal constant FUTEX_TIMEOUT_STEPS = 10000;
@@ -101,9 +125,19 @@ library MIPSSyscalls {
     uint32 internal constant HZ = 10_000_000;
     uint32 internal constant CLOCK_GETTIME_REALTIME_FLAG = 0;
     uint32 internal constant CLOCK_GETTIME_MONOTONIC_FLAG = 1;
+    uint32 internal constant CLOCK_GETTIME_PROCESS_CPUTIME_FLAG = 2;
+    uint32 internal constant CLOCK_GETTIME_THREAD_CPUTIME_FLAG = 3;
+    uint32 internal constant CLOCK_GETTIME_MONOTONIC_RAW_FLAG = 4;
+    uint32 internal constant CLOCK_GETTIME_REALTIME_COARSE_FLAG = 5;
+    uint32 internal constant CLOCK_GETTIME_MONOTONIC_COARSE_FLAG = 6;
+    uint32 internal constant CLOCK_GETTIME_BOOTTIME_FLAG = 7;
+    uint32 internal constant CLOCK_GETTIME_REALTIME_ALARM_FLAG = 8;
+    uint32 internal constant CLOCK_GETTIME_BOOTTIME_ALARM_FLAG = 9;
     /// @notice Start of the data segment.
     uint32 internal constant PROGRAM_BREAK = 0x40000000;
     uint32 internal constant HEAP_END = 0x60000000;
+    /// @notice Start of the stack region. Mappings at fixed addresses must end below it.
+    uint32 internal constant STACK_LIMIT = 0x7f000000;
 
     // SYS_CLONE flags
     uint32 internal constant CLONE_VM = 0x100;
@@ -156,7 +190,7 @@ library MIPSSyscalls {
     /// @param _a1 The size of the new mapping
     /// @param _heap The current value of the heap pointer
     /// @return v0_ The address of the new mapping
-    /// @return v1_ Unused error code (0)
+    /// @return v1_ An error number, or 0 if there is no error
     /// @return newHeap_ The new value for the heap, may be unchanged
     function handleSysMmap(
         uint32 _a0,
@@ -187,12 +221,141 @@ library MIPSSyscalls {
                 }
             } else {
                 v0_ = _a0;
+                // Fail if the mapping wraps around to low memory, reaches into the stack region, or sz overflows
+                uint32 end = _a0 + sz;
+                if (end < _a0 || end > STACK_LIMIT || sz < _a1) {
+                    v0_ = SYS_ERROR_SIGNAL;
+                    v1_ = EINVAL;
+                    return (v0_, v1_, _heap);
+                }
             }
 
             return (v0_, v1_, newHeap_);
         }
     }
 
+    /// @notice Like a Linux brk syscall, but the program break is fixed at PROGRAM_BREAK.
+    ///         Requests to move the break fail.
+    /// @param _a0 The requested program break, or 0 to query it.
+    /// @return v0_ The program break, or -1 on error.
+    /// @return v1_ An error number, or 0 if there is no error.
+    function handleSysBrk(uint32 _a0) internal pure returns (uint32 v0_, uint32 v1_) {
+        unchecked {
+            if (_a0 == 0 || _a0 == PROGRAM_BREAK) {
+                return (PROGRAM_BREAK, 0);
+            }
+            return (SYS_ERROR_SIGNAL, ENOMEM);
+        }
+    }
+
+    /// @notice Computes the deterministic value of a clock_gettime clock at the given step.
+    ///         Realtime clocks are fixed at the Unix Epoch. All other clocks advance with the step count.
+    /// @param _clockId The clock_gettime clock id.
+    /// @param _step The current step.
+    /// @return secs_ The seconds of the clock value.
+    /// @return nsecs_ The nanoseconds of the clock value.
+    /// @return ok_ False if the clock id is not recognized.
+    function clockGettimeValue(
+        uint32 _clockId,
+        uint64 _step
+    )
+        internal
+        pure
+        returns (uint32 secs_, uint32 nsecs_, bool ok_)
+    {
+        unchecked {
+            if (
+                _clockId == CLOCK_GETTIME_REALTIME_FLAG || _clockId == CLOCK_GETTIME_REALTIME_COARSE_FLAG
+                    || _clockId == CLOCK_GETTIME_REALTIME_ALARM_FLAG
+            ) {
+                return (0, 0, true);
+            }
+            if (_clockId <= CLOCK_GETTIME_BOOTTIME_ALARM_FLAG) {
+                secs_ = uint32(_step / HZ);
+                nsecs_ = uint32((_step % HZ) * (1_000_000_000 / HZ));
+                return (secs_, nsecs_, true);
+            }
+            return (0, 0, false);
+        }
+    }
+
+    /// @notice Like a Linux getrandom syscall. Fills at most one aligned memory word with pseudo-random bytes,
+    ///         derived from the step count. May write fewer bytes than requested.
+    /// @param _a0 The address of the buffer to fill.
+    /// @param _a1 The number of bytes requested.
+    /// @param _step The current step.
+    /// @param _memRoot The current memory root.
+    /// @param _proofOffset The offset of the memory proof in calldata.
+    /// @return v0_ The number of bytes written.
+    /// @return v1_ Unused error code (0).
+    /// @return newMemRoot_ The new memory root, after writing the random bytes.
+    function handleSysGetRandom(
+        uint32 _a0,
+        uint32 _a1,
+        uint64 _step,
+        bytes32 _memRoot,
+        uint256 _proofOffset
+    )
+        internal
+        pure
+        returns (uint32 v0_, uint32 v1_, bytes32 newMemRoot_)
+    {
+        unchecked {
+            uint32 effAddr = _a0 & 0xFFffFFfc;
+            uint32 mem = MIPSMemory.readMem(_memRoot, effAddr, _proofOffset);
+
+            uint32 alignment = _a0 & 3;
+            uint32 n = 4 - alignment;
+            if (_a1 < n) {
+                n = _a1;
+            }
+            // Mask of the big-endian bytes [alignment, alignment+n) within the word
+            uint32 mask = uint32(((uint64(1) << (8 * n)) - 1) << (8 * (4 - alignment - n)));
+            uint32 random = uint32(splitmix64(_step));
+            newMemRoot_ = MIPSMemory.writeMem(effAddr, _proofOffset, (mem & ~mask) | (random & mask));
+
+            v0_ = n;
+            v1_ = 0;
+            return (v0_, v1_, newMemRoot_);
+        }
+    }
+
+    /// @notice Mixes the seed into a well-distributed 64-bit value. See https://prng.di.unimi.it/splitmix64.c
+    /// @param _seed The seed to mix.
+    /// @return z_ The mixed value.
+    function splitmix64(uint64 _seed) internal pure returns (uint64 z_) {
+        unchecked {
+            z_ = _seed + 0x9e3779b97f4a7c15;
+            z_ = (z_ ^ (z_ >> 30)) * 0xbf58476d1ce4e5b9;
+            z_ = (z_ ^ (z_ >> 27)) * 0x94d049bb133111eb;
+            z_ = z_ ^ (z_ >> 31);
+        }
+    }
+
+    /// @notice Like Linux epoll_ctl on the fixed epoll descriptor. Registrations are accepted, but never tracked,
+    ///         since no descriptor ever becomes ready.
+    /// @param _a0 The epoll file descriptor.
+    /// @return v0_ 0 on success, or -1 on error.
+    /// @return v1_ An error number, or 0 if there is no error.
+    function handleSysEpollCtl(uint32 _a0) internal pure returns (uint32 v0_, uint32 v1_) {
+        if (_a0 != FD_EPOLL) {
+            return (0xFFffFFff, EBADF);
+        }
+        return (0, 0);
+    }
+
+    /// @notice Like Linux epoll_pwait on the fixed epoll descriptor. The ready set is always empty,
+    ///         so it returns immediately with zero events, regardless of the timeout.
+    /// @param _a0 The epoll file descriptor.
+    /// @return v0_ The number of ready events, or -1 on error.
+    /// @return v1_ An error number, or 0 if there is no error.
+    function handleSysEpollPwait(uint32 _a0) internal pure returns (uint32 v0_, uint32 v1_) {
+        if (_a0 != FD_EPOLL) {
+            return (0xFFffFFff, EBADF);
+        }
+        return (0, 0);
+    }
+
     /// @notice Like a Linux read syscall. Splits unaligned reads into aligned reads.
     ///         Args are provided as a struct to reduce stack pressure.
     /// @return v0_ The number of bytes read, -1 on error.
@@ -255,6 +418,11 @@ library MIPSSyscalls {
                 // Don't read into memory, just say we read it all
                 // The result is ignored anyway
                 v0_ = _args.a2;
+            }
+            // eventfd counter is never readable
+            else if (_args.a0 == FD_EVENTFD) {
+                v0_ = 0xFFffFFff;
+                v1_ = EAGAIN;
             } else {
                 v0_ = 0xFFffFFff;
                 v1_ = EBADF;
@@ -322,6 +490,15 @@ library MIPSSyscalls {
                 newPreimageKey_ = key;
                 newPreimageOffset_ = 0; // reset offset, to read new pre-image data from the start
                 v0_ = _a2;
+            }
+            // eventfd writes add an 8-byte value to the counter, which is discarded
+            else if (_a0 == FD_EVENTFD) {
+                if (_a2 < 8) {
+                    v0_ = 0xFFffFFff;
+                    v1_ = EINVAL;
+                } else {
+                    v0_ = 8;
+                }
             } else {
                 v0_ = 0xFFffFFff;
                 v1_ = EBADF;
@@ -331,11 +508,12 @@ library MIPSSyscalls {
         }
     }
 
-    /// @notice Like Linux fcntl (file control) syscall, but only supports minimal file-descriptor control commands, to
-    /// retrieve the file-descriptor R/W flags.
+    /// @notice Like Linux fcntl (file control) syscall, but only supports minimal file-descriptor control commands.
+    ///         The file-descriptor table is fixed: descriptor flags are accepted but ignored, and descriptors
+    ///         can't be duplicated.
     /// @param _a0 The file descriptor.
     /// @param _a1 The control command.
-    /// @param v0_ The file status flag (only supported command is F_GETFL), or -1 on error.
+    /// @param v0_ The file status flag for F_GETFL, 0 for other supported commands, or -1 on error.
     /// @param v1_ An error number, or 0 if there is no error.
     function handleSysFcntl(uint32 _a0, uint32 _a1) internal pure returns (uint32 v0_, uint32 v1_) {
         unchecked {
@@ -343,25 +521,157 @@ library MIPSSyscalls {
             v1_ = uint32(0);
 
             // args: _a0 = fd, _a1 = cmd
-            if (_a1 == 3) {
-                // F_GETFL: get file descriptor flags
-                if (_a0 == FD_STDIN || _a0 == FD_PREIMAGE_READ || _a0 == FD_HINT_READ) {
-                    v0_ = 0; // O_RDONLY
-                } else if (_a0 == FD_STDOUT || _a0 == FD_STDERR || _a0 == FD_PREIMAGE_WRITE || _a0 == FD_HINT_WRITE) {
-                    v0_ = 1; // O_WRONLY
-                } else {
-                    v0_ = 0xFFffFFff;
-                    v1_ = EBADF;
-                }
-            } else {
+            if (
+                _a1 != F_GETFL && _a1 != F_GETFD && _a1 != F_SETFD && _a1 != F_SETFL && _a1 != F_DUPFD
+                    && _a1 != F_DUPFD_CLOEXEC
+            ) {
                 v0_ = 0xFFffFFff;
                 v1_ = EINVAL; // cmd not recognized by this kernel
+                return (v0_, v1_);
+            }
+
+            uint32 mode;
+            if (_a0 == FD_STDIN || _a0 == FD_PREIMAGE_READ || _a0 == FD_HINT_READ) {
+                mode = 0; // O_RDONLY
+            } else if (_a0 == FD_STDOUT || _a0 == FD_STDERR || _a0 == FD_PREIMAGE_WRITE || _a0 == FD_HINT_WRITE) {
+                mode = 1; // O_WRONLY
+            } else if (_a0 == FD_EVENTFD || _a0 == FD_EPOLL) {
+                mode = 2; // O_RDWR
+            } else {
+                v0_ = 0xFFffFFff;
+                v1_ = EBADF;
+                return (v0_, v1_);
+            }
+
+            if (_a1 == F_GETFL) {
+                // get file status flags
+                v0_ = mode;
+            } else if (_a1 == F_DUPFD || _a1 == F_DUPFD_CLOEXEC) {
+                // the fd table is fixed, no new descriptors can be allocated
+                v0_ = 0xFFffFFff;
+                v1_ = EMFILE;
             }
+            // F_GETFD never reports FD_CLOEXEC, F_SETFD and F_SETFL are ignored
 
             return (v0_, v1_);
         }
     }
 
+    /// @notice Like Linux fstat64, but only reports the file type of the fixed file descriptors.
+    ///         Only st_mode is written, the rest of the stat64 struct is left untouched.
+    /// @param _a0 The file descriptor.
+    /// @param _a1 The address of the stat64 struct.
+    /// @param _memRoot The current memory root.
+    /// @param _proofOffset The offset of the memory proof of st_mode in calldata.
+    /// @return v0_ 0, or -1 on error.
+    /// @return v1_ An error number, or 0 if there is no error.
+    /// @return newMemRoot_ The new memory root.
+    function handleSysFstat64(
+        uint32 _a0,
+        uint32 _a1,
+        bytes32 _memRoot,
+        uint256 _proofOffset
+    )
+        internal
+        pure
+        returns (uint32 v0_, uint32 v1_, bytes32 newMemRoot_)
+    {
+        unchecked {
+            v0_ = uint32(0);
+            v1_ = uint32(0);
+            newMemRoot_ = _memRoot;
+
+            uint32 mode;
+            if (_a0 == FD_STDIN || _a0 == FD_STDOUT || _a0 == FD_STDERR) {
+                mode = S_IFCHR | 438; // character device, like /dev/null (0o666)
+            } else if (
+                _a0 == FD_HINT_READ || _a0 == FD_HINT_WRITE || _a0 == FD_PREIMAGE_READ || _a0 == FD_PREIMAGE_WRITE
+            ) {
+                mode = S_IFIFO | 384; // pipe (0o600)
+            } else if (_a0 == FD_EVENTFD || _a0 == FD_EPOLL) {
+                mode = 384; // anonymous inode without a file type (0o600)
+            } else {
+                v0_ = 0xFFffFFff;
+                v1_ = EBADF;
+                return (v0_, v1_, newMemRoot_);
+            }
+
+            uint32 effAddr = (_a1 + STAT64_MODE_OFFSET) & 0xFFffFFfc;
+            // Verify the memory proof of st_mode before writing it
+            MIPSMemory.readMem(_memRoot, effAddr, _proofOffset);
+            newMemRoot_ = MIPSMemory.writeMem(effAddr, _proofOffset, mode);
+            return (v0_, v1_, newMemRoot_);
+        }
+    }
+
+    /// @notice Like Linux setitimer, but the timers never expire, since signals are never delivered.
+    ///         The previous value of the timer can't be requested.
+    /// @param _a0 The interval timer.
+    /// @param _a2 The address to write the previous value of the timer to.
+    /// @return v0_ 0, or -1 on error.
+    /// @return v1_ An error number, or 0 if there is no error.
+    function handleSysSetITimer(uint32 _a0, uint32 _a2) internal pure returns (uint32 v0_, uint32 v1_) {
+        if ((_a0 != ITIMER_REAL && _a0 != ITIMER_VIRTUAL && _a0 != ITIMER_PROF) || _a2 != 0) {
+            return (SYS_ERROR_SIGNAL, EINVAL);
+        }
+        return (0, 0);
+    }
+
+    /// @notice Like Linux timer_create, on one of the clocks of the virtual clock. The timer never expires.
+    /// @param _a0 The clock id.
+    /// @param _a2 The address to write the timer id to.
+    /// @param _memRoot The current memory root.
+    /// @param _proofOffset The offset of the memory proof of the timer id in calldata.
+    /// @return v0_ 0, or -1 on error.
+    /// @return v1_ An error number, or 0 if there is no error.
+    /// @return newMemRoot_ The new memory root.
+    function handleSysTimerCreate(
+        uint32 _a0,
+        uint32 _a2,
+        bytes32 _memRoot,
+        uint256 _proofOffset
+    )
+        internal
+        pure
+        returns (uint32 v0_, uint32 v1_, bytes32 newMemRoot_)
+    {
+        unchecked {
+            (,, bool ok) = clockGettimeValue(_a0, 0);
+            if (!ok) {
+                return (SYS_ERROR_SIGNAL, EINVAL, _memRoot);
+            }
+            uint32 effAddr = _a2 & 0xFFffFFfc;
+            // Verify the memory proof of the timer id before writing it
+            MIPSMemory.readMem(_memRoot, effAddr, _proofOffset);
+            newMemRoot_ = MIPSMemory.writeMem(effAddr, _proofOffset, TIMER_ID);
+            return (0, 0, newMemRoot_);
+        }
+    }
+
+    /// @notice Like Linux timer_settime, on the timer created by timer_create.
+    ///         The previous value of the timer can't be requested.
+    /// @param _a0 The timer id.
+    /// @param _a3 The address to write the previous value of the timer to.
+    /// @return v0_ 0, or -1 on error.
+    /// @return v1_ An error number, or 0 if there is no error.
+    function handleSysTimerSetTime(uint32 _a0, uint32 _a3) internal pure returns (uint32 v0_, uint32 v1_) {
+        if (_a0 != TIMER_ID || _a3 != 0) {
+            return (SYS_ERROR_SIGNAL, EINVAL);
+        }
+        return (0, 0);
+    }
+
+    /// @notice Like Linux timer_delete, on the timer created by timer_create.
+    /// @param _a0 The timer id.
+    /// @return v0_ 0, or -1 on error.
+    /// @return v1_ An error number, or 0 if there is no error.
+    function handleSysTimerDelete(uint32 _a0) internal pure returns (uint32 v0_, uint32 v1_) {
+        if (_a0 != TIMER_ID) {
+            return (SYS_ERROR_SIGNAL, EINVAL);
+        }
+        return (0, 0);
+    }
+
     function handleSyscallUpdates(
         st.CpuScalars memory _cpu,
         uint32[32] memory _registers,
@@ -1553,7 +1553,7 @@ contract MIPS_Test is CommonTest {
         uint32 insn = 0x0000000c; // syscall
         (MIPS.State memory state, bytes memory proof) = constructMIPSState(0, insn, 0x4, 0);
         state.registers[2] = 4045; // brk syscall
-        state.registers[4] = 0xdead;
+        state.registers[4] = 0; // query the program break
         bytes memory encodedState = encodeState(state);
 
         MIPS.State memory expect;
@@ -1568,6 +1568,26 @@ contract MIPS_Test is CommonTest {
         assertEq(postState, outputState(expect), "unexpected post state");
     }
 
+    function test_brk_move_fails() external {
+        uint32 insn = 0x0000000c; // syscall
+        (MIPS.State memory state, bytes memory proof) = constructMIPSState(0, insn, 0x4, 0);
+        state.registers[2] = 4045; // brk syscall
+        state.registers[4] = 0x40001000; // grow the program break
+        bytes memory encodedState = encodeState(state);
+
+        MIPS.State memory expect;
+        expect.memRoot = state.memRoot;
+        expect.step = state.step + 1;
+        expect.pc = state.nextPC;
+        expect.nextPC = state.nextPC + 4;
+        expect.registers[2] = sys.SYS_ERROR_SIGNAL;
+        expect.registers[4] = state.registers[4];
+        expect.registers[7] = sys.ENOMEM;
+
+        bytes32 postState = mips.step(encodedState, proof, 0);
+        assertEq(postState, outputState(expect), "unexpected post state");
+    }
+
     function test_clone_succeeds() external {
         uint32 insn = 0x0000000c; // syscall
         (MIPS.State memory state, bytes memory proof) = constructMIPSState(0, insn, 0x4, 0);
@@ -1631,6 +1651,78 @@ contract MIPS_Test is CommonTest {
         assertEq(postState, outputState(expect), "unexpected post state");
     }
 
+    function test_fcntl_dupfd_fails() external {
+        uint32 insn = 0x0000000c; // syscall
+        (MIPS.State memory state, bytes memory proof) = constructMIPSState(0, insn, 0x4, 0);
+        state.registers[2] = 4055; // fcntl syscall
+        state.registers[4] = sys.FD_STDOUT; // a0
+        state.registers[5] = sys.F_DUPFD; // a1
+
+        // The fd table is fixed, so descriptors can't be duplicated
+        MIPS.State memory expect;
+        expect.memRoot = state.memRoot;
+        expect.pc = state.nextPC;
+        expect.nextPC = state.nextPC + 4;
+        expect.step = state.step + 1;
+        expect.registers[2] = sys.SYS_ERROR_SIGNAL;
+        expect.registers[4] = state.registers[4];
+        expect.registers[5] = state.registers[5];
+        expect.registers[7] = sys.EMFILE;
+
+        bytes32 postState = mips.step(encodeState(state), proof, 0);
+        assertEq(postState, outputState(expect), "unexpected post state");
+
+        state.registers[5] = sys.F_DUPFD_CLOEXEC; // a1
+        expect.registers[5] = state.registers[5];
+        postState = mips.step(encodeState(state), proof, 0);
+        assertEq(postState, outputState(expect), "unexpected post state");
+    }
+
+    function test_fstat64_succeeds() external {
+        uint32 insn = 0x0000000c; // syscall
+        uint32 statAddr = 0x1000;
+        (MIPS.State memory state, bytes memory proof) =
+            constructMIPSState(0, insn, statAddr + sys.STAT64_MODE_OFFSET, 0xbad);
+        state.registers[2] = 4215; // fstat64 syscall
+        state.registers[4] = sys.FD_STDOUT; // a0
+        state.registers[5] = statAddr; // a1
+
+        MIPS.State memory expect;
+        (expect.memRoot,) = ffi.getCannonMemoryProof(0, insn, statAddr + sys.STAT64_MODE_OFFSET, 0x21b6);
+        expect.pc = state.nextPC;
+        expect.nextPC = state.nextPC + 4;
+        expect.step = state.step + 1;
+        expect.registers[2] = 0;
+        expect.registers[4] = state.registers[4];
+        expect.registers[5] = state.registers[5];
+
+        bytes32 postState = mips.step(encodeState(state), proof, 0);
+        assertEq(postState, outputState(expect), "unexpected post state");
+    }
+
+    function test_fstat64_badFd_fails() external {
+        uint32 insn = 0x0000000c; // syscall
+        uint32 statAddr = 0x1000;
+        (MIPS.State memory state, bytes memory proof) =
+            constructMIPSState(0, insn, statAddr + sys.STAT64_MODE_OFFSET, 0xbad);
+        state.registers[2] = 4215; // fstat64 syscall
+        state.registers[4] = 100; // a0
+        state.registers[5] = statAddr; // a1
+
+        MIPS.State memory expect;
+        expect.memRoot = state.memRoot;
+        expect.pc = state.nextPC;
+        expect.nextPC = state.nextPC + 4;
+        expect.step = state.step + 1;
+        expect.registers[2] = sys.SYS_ERROR_SIGNAL;
+        expect.registers[4] = state.registers[4];
+        expect.registers[5] = state.registers[5];
+        expect.registers[7] = sys.EBADF;
+
+        bytes32 postState = mips.step(encodeState(state), proof, 0);
+        assertEq(postState, outputState(expect), "unexpected post state");
+    }
+
     function test_prestate_exited_succeeds() external {
         uint32 insn = 0x0000000c; // syscall
         (MIPS.State memory state, bytes memory proof) = constructMIPSState(0, insn, 0x4, 0);
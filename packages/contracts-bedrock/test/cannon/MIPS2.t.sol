@@ -667,6 +667,92 @@ contract MIPS2_Test is CommonTest {
         assertEq(postState, outputState(expect), "unexpected post state");
     }
 
+    /// @dev static unit test asserting that fstat64 reports a pipe for the pre-image channel
+    function test_syscallFstat64_succeeds() public {
+        uint32 pc = 0;
+        uint32 insn = 0x0000000c; // syscall
+        uint32 statAddr = 0xb000;
+        (MIPS2.State memory state, MIPS2.ThreadState memory thread, bytes memory insnAndMemProof) =
+            constructMIPSState(pc, insn, statAddr + sys.STAT64_MODE_OFFSET, 0xbad);
+        thread.registers[2] = sys.SYS_FSTAT64;
+        thread.registers[A0_REG] = sys.FD_PREIMAGE_READ;
+        thread.registers[A1_REG] = statAddr;
+        thread.registers[7] = 0xdead;
+        bytes memory threadWitness = abi.encodePacked(encodeThread(thread), EMPTY_THREAD_ROOT);
+        updateThreadStacks(state, thread);
+
+        MIPS2.State memory expect = copyState(state);
+        (expect.memRoot,) = ffi.getCannonMemoryProof(pc, insn, statAddr + sys.STAT64_MODE_OFFSET, 0x1180);
+        expect.step = state.step + 1;
+        expect.stepsSinceLastContextSwitch = state.stepsSinceLastContextSwitch + 1;
+        MIPS2.ThreadState memory expectThread = copyThread(thread);
+        expectThread.pc = thread.nextPC;
+        expectThread.nextPC = thread.nextPC + 4;
+        expectThread.registers[2] = 0x0;
+        expectThread.registers[7] = 0x0;
+        expect.leftThreadStack = keccak256(abi.encodePacked(EMPTY_THREAD_ROOT, keccak256(encodeThread(expectThread))));
+
+        bytes32 postState = mips.step(encodeState(state), bytes.concat(threadWitness, insnAndMemProof), 0);
+        assertEq(postState, outputState(expect), "unexpected post state");
+    }
+
+    /// @dev static unit test asserting that timer_create writes the timer id
+    function test_syscallTimerCreate_succeeds() public {
+        uint32 pc = 0;
+        uint32 insn = 0x0000000c; // syscall
+        uint32 timerIdAddr = 0xb000;
+        (MIPS2.State memory state, MIPS2.ThreadState memory thread, bytes memory insnAndMemProof) =
+            constructMIPSState(pc, insn, timerIdAddr, 0xbad);
+        thread.registers[2] = sys.SYS_TIMERCREATE;
+        thread.registers[A0_REG] = sys.CLOCK_GETTIME_THREAD_CPUTIME_FLAG;
+        thread.registers[A1_REG] = 0xc000;
+        thread.registers[6] = timerIdAddr; // a2
+        thread.registers[7] = 0xdead;
+        bytes memory threadWitness = abi.encodePacked(encodeThread(thread), EMPTY_THREAD_ROOT);
+        updateThreadStacks(state, thread);
+
+        MIPS2.State memory expect = copyState(state);
+        (expect.memRoot,) = ffi.getCannonMemoryProof(pc, insn, timerIdAddr, sys.TIMER_ID);
+        expect.step = state.step + 1;
+        expect.stepsSinceLastContextSwitch = state.stepsSinceLastContextSwitch + 1;
+        MIPS2.ThreadState memory expectThread = copyThread(thread);
+        expectThread.pc = thread.nextPC;
+        expectThread.nextPC = thread.nextPC + 4;
+        expectThread.registers[2] = 0x0;
+        expectThread.registers[7] = 0x0;
+        expect.leftThreadStack = keccak256(abi.encodePacked(EMPTY_THREAD_ROOT, keccak256(encodeThread(expectThread))));
+
+        bytes32 postState = mips.step(encodeState(state), bytes.concat(threadWitness, insnAndMemProof), 0);
+        assertEq(postState, outputState(expect), "unexpected post state");
+    }
+
+    /// @dev static unit test asserting that timer_settime fails if the previous value of the timer is requested
+    function test_syscallTimerSetTimeOldValue_fails() public {
+        uint32 pc = 0;
+        uint32 insn = 0x0000000c; // syscall
+        (MIPS2.State memory state, MIPS2.ThreadState memory thread, bytes memory insnAndMemProof) =
+            constructMIPSState(pc, insn, 0x4, 0);
+        thread.registers[2] = sys.SYS_TIMERSETTIME;
+        thread.registers[A0_REG] = sys.TIMER_ID;
+        thread.registers[6] = 0xb000; // a2
+        thread.registers[7] = 0xc000; // a3
+        bytes memory threadWitness = abi.encodePacked(encodeThread(thread), EMPTY_THREAD_ROOT);
+        updateThreadStacks(state, thread);
+
+        MIPS2.State memory expect = copyState(state);
+        expect.step = state.step + 1;
+        expect.stepsSinceLastContextSwitch = state.stepsSinceLastContextSwitch + 1;
+        MIPS2.ThreadState memory expectThread = copyThread(thread);
+        expectThread.pc = thread.nextPC;
+        expectThread.nextPC = thread.nextPC + 4;
+        expectThread.registers[2] = sys.SYS_ERROR_SIGNAL;
+        expectThread.registers[7] = sys.EINVAL;
+        expect.leftThreadStack = keccak256(abi.encodePacked(EMPTY_THREAD_ROOT, keccak256(encodeThread(expectThread))));
+
+        bytes32 postState = mips.step(encodeState(state), bytes.concat(threadWitness, insnAndMemProof), 0);
+        assertEq(postState, outputState(expect), "unexpected post state");
+    }
+
     /// @dev static unit test asserting that clock_gettime syscall for monotonic time succeeds in writing to an
     /// unaligned address
     function test_syscallClockGettimeMonotonicUnaligned_succeeds() public {